@@ -0,0 +1,97 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+func blueprintsCmd(opts *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "blueprints",
+		Aliases: []string{"blueprint", "bp"},
+		Short:   "list, export and import blueprints",
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "list the blueprints",
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, args []string) error {
+			res, err := opts.client().send(http.MethodGet, "/blueprints", nil, nil)
+			if err != nil {
+				return err
+			}
+			return printJSON(c.OutOrStdout(), res)
+		},
+	})
+
+	var format, output string
+	export := &cobra.Command{
+		Use:   "export BLUEPRINT_ID",
+		Short: "export a blueprint with its scopes and scope configs, the connections are referenced by name",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			if format != "yaml" && format != "json" {
+				return fmt.Errorf("unknown format %s, yaml or json", format)
+			}
+			res, err := opts.client().send(http.MethodGet, "/blueprints/"+args[0]+"/export", url.Values{"format": {format}}, nil)
+			if err != nil {
+				return err
+			}
+			if output != "" {
+				return os.WriteFile(output, res, 0600)
+			}
+			if format == "json" {
+				return printJSON(c.OutOrStdout(), res)
+			}
+			_, err = c.OutOrStdout().Write(res)
+			return err
+		},
+	}
+	export.Flags().StringVar(&format, "format", "yaml", "yaml or json")
+	export.Flags().StringVarP(&output, "output", "o", "", "write to the file instead of stdout")
+	cmd.AddCommand(export)
+
+	var file string
+	importCmd := &cobra.Command{
+		Use:   "import",
+		Short: "import an exported blueprint, the blueprint of the same name is updated if any",
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, args []string) error {
+			payload, err := readPayload("", file, c.InOrStdin())
+			if err != nil {
+				return err
+			}
+			res, err := opts.client().do(http.MethodPost, "/blueprints/import", nil, "application/yaml", payload)
+			if err != nil {
+				return err
+			}
+			return printJSON(c.OutOrStdout(), res)
+		},
+	}
+	importCmd.Flags().StringVarP(&file, "file", "f", "", "yaml or json file of the blueprint, - for stdin")
+	_ = importCmd.MarkFlagRequired("file")
+	cmd.AddCommand(importCmd)
+	return cmd
+}
@@ -0,0 +1,113 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// client calls the REST API of a DevLake server, authenticated by an api key if any
+type client struct {
+	endpoint string
+	token    string
+	http     *http.Client
+}
+
+func newClient(endpoint string, token string, timeout time.Duration) *client {
+	return &client{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		token:    token,
+		http:     &http.Client{Timeout: timeout},
+	}
+}
+
+// apiError is the error body returned by the server
+type apiError struct {
+	Message string   `json:"message"`
+	Causes  []string `json:"causes"`
+}
+
+func (c *client) do(method string, path string, query url.Values, contentType string, body []byte) ([]byte, error) {
+	u := c.endpoint + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequest(method, u, reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	res, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode >= http.StatusBadRequest {
+		e := &apiError{}
+		if json.Unmarshal(resBody, e) == nil && e.Message != "" {
+			return nil, fmt.Errorf("%s %s: %d %s", method, path, res.StatusCode, e.Message)
+		}
+		return nil, fmt.Errorf("%s %s: %d %s", method, path, res.StatusCode, strings.TrimSpace(string(resBody)))
+	}
+	return resBody, nil
+}
+
+// send sends the payload as json and returns the raw response
+func (c *client) send(method string, path string, query url.Values, payload interface{}) ([]byte, error) {
+	var body []byte
+	switch p := payload.(type) {
+	case nil:
+	case []byte:
+		body = p
+	default:
+		var err error
+		if body, err = json.Marshal(p); err != nil {
+			return nil, err
+		}
+	}
+	return c.do(method, path, query, "application/json", body)
+}
+
+// sendJSON sends the payload and decodes the response into result
+func (c *client) sendJSON(method string, path string, query url.Values, payload interface{}, result interface{}) error {
+	resBody, err := c.send(method, path, query, payload)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(resBody, result)
+}
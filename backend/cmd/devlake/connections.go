@@ -0,0 +1,123 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+)
+
+func connectionsCmd(opts *globalOptions) *cobra.Command {
+	var pluginName, data, file string
+	cmd := &cobra.Command{
+		Use:     "connections",
+		Aliases: []string{"connection", "conn"},
+		Short:   "manage the connections of a data source plugin",
+	}
+	cmd.PersistentFlags().StringVarP(&pluginName, "plugin", "p", "", "name of the plugin, i.e. github")
+	_ = cmd.MarkPersistentFlagRequired("plugin")
+	payloadFlags := func(c *cobra.Command) *cobra.Command {
+		c.Flags().StringVarP(&data, "data", "d", "", "connection as inline json")
+		c.Flags().StringVarP(&file, "file", "f", "", "json file of the connection, - for stdin")
+		return c
+	}
+	path := func(suffix string) string {
+		return fmt.Sprintf("/plugins/%s/connections%s", pluginName, suffix)
+	}
+	run := func(c *cobra.Command, method string, path string, payload []byte) error {
+		res, err := opts.client().send(method, path, nil, payload)
+		if err != nil {
+			return err
+		}
+		return printJSON(c.OutOrStdout(), res)
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "list the connections",
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, args []string) error {
+			return run(c, http.MethodGet, path(""), nil)
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "get CONNECTION_ID",
+		Short: "show a connection",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			return run(c, http.MethodGet, path("/"+args[0]), nil)
+		},
+	})
+	cmd.AddCommand(payloadFlags(&cobra.Command{
+		Use:   "create",
+		Short: "create a connection",
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, args []string) error {
+			payload, err := readPayload(data, file, c.InOrStdin())
+			if err != nil {
+				return err
+			}
+			return run(c, http.MethodPost, path(""), payload)
+		},
+	}))
+	cmd.AddCommand(payloadFlags(&cobra.Command{
+		Use:   "update CONNECTION_ID",
+		Short: "update the given fields of a connection",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			payload, err := readPayload(data, file, c.InOrStdin())
+			if err != nil {
+				return err
+			}
+			return run(c, http.MethodPatch, path("/"+args[0]), payload)
+		},
+	}))
+	cmd.AddCommand(&cobra.Command{
+		Use:   "delete CONNECTION_ID",
+		Short: "delete a connection, fails if it is still used by blueprints",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			return run(c, http.MethodDelete, path("/"+args[0]), nil)
+		},
+	})
+	cmd.AddCommand(payloadFlags(&cobra.Command{
+		Use:   "test [CONNECTION_ID]",
+		Short: "test an existing connection, or the connection given by --data or --file",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			if len(args) == 1 {
+				var payload []byte
+				if data != "" || file != "" {
+					var err error
+					if payload, err = readPayload(data, file, c.InOrStdin()); err != nil {
+						return err
+					}
+				}
+				return run(c, http.MethodPost, path("/"+args[0]+"/test"), payload)
+			}
+			payload, err := readPayload(data, file, c.InOrStdin())
+			if err != nil {
+				return err
+			}
+			return run(c, http.MethodPost, fmt.Sprintf("/plugins/%s/test", pluginName), payload)
+		},
+	}))
+	return cmd
+}
@@ -0,0 +1,78 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// devlake administrates a DevLake server through its REST API, so connections, scopes, blueprints and pipelines
+// can be managed by scripts and CI jobs:
+//
+//	export DEVLAKE_ENDPOINT=http://localhost:8080 DEVLAKE_TOKEN=<api key>
+//	devlake connections create -p github --file github.json
+//	devlake scopes add -p github -c 1 --file repos.json
+//	devlake blueprints import --file blueprint.yaml
+//	devlake pipelines trigger 1 --follow
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+type globalOptions struct {
+	Endpoint string
+	Token    string
+	Timeout  time.Duration
+}
+
+func (o *globalOptions) client() *client {
+	return newClient(o.Endpoint, o.Token, o.Timeout)
+}
+
+func envOrDefault(key string, defaultValue string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return defaultValue
+}
+
+func newRootCmd() *cobra.Command {
+	opts := &globalOptions{}
+	cmd := &cobra.Command{
+		Use:           "devlake",
+		Short:         "manage the connections, scopes, blueprints and pipelines of a DevLake server",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+	cmd.PersistentFlags().StringVarP(&opts.Endpoint, "endpoint", "e", envOrDefault("DEVLAKE_ENDPOINT", "http://localhost:8080"), "endpoint of the DevLake api, env DEVLAKE_ENDPOINT")
+	cmd.PersistentFlags().StringVarP(&opts.Token, "token", "t", os.Getenv("DEVLAKE_TOKEN"), "api key sent as bearer token, env DEVLAKE_TOKEN")
+	cmd.PersistentFlags().DurationVar(&opts.Timeout, "timeout", 2*time.Minute, "timeout of each api request")
+	cmd.AddCommand(
+		connectionsCmd(opts),
+		scopesCmd(opts),
+		pipelinesCmd(opts),
+		blueprintsCmd(opts),
+	)
+	return cmd
+}
+
+func main() {
+	if err := newRootCmd().Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,143 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordedRequest struct {
+	Method      string
+	Path        string
+	Query       string
+	ContentType string
+	Auth        string
+	Body        string
+}
+
+// fakeServer replies the canned responses by method and path, and records the requests
+func fakeServer(t *testing.T, responses map[string][]string) (*httptest.Server, *[]recordedRequest) {
+	requests := &[]recordedRequest{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		*requests = append(*requests, recordedRequest{
+			Method:      r.Method,
+			Path:        r.URL.EscapedPath(),
+			Query:       r.URL.RawQuery,
+			ContentType: r.Header.Get("Content-Type"),
+			Auth:        r.Header.Get("Authorization"),
+			Body:        string(body),
+		})
+		key := r.Method + " " + r.URL.EscapedPath()
+		replies := responses[key]
+		if len(replies) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"success":false,"message":"not found"}`))
+			return
+		}
+		// the last reply is repeated
+		reply := replies[0]
+		if len(replies) > 1 {
+			responses[key] = replies[1:]
+		}
+		_, _ = w.Write([]byte(reply))
+	}))
+	t.Cleanup(server.Close)
+	return server, requests
+}
+
+func execute(server *httptest.Server, stdin string, args ...string) (string, error) {
+	cmd := newRootCmd()
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+	cmd.SetIn(strings.NewReader(stdin))
+	cmd.SetArgs(append([]string{"--endpoint", server.URL + "/", "--token", "secret"}, args...))
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestCreateConnection(t *testing.T) {
+	server, requests := fakeServer(t, map[string][]string{
+		"POST /plugins/github/connections": {`{"id":1,"name":"github"}`},
+	})
+	out, err := execute(server, `{"name":"github"}`, "connections", "create", "-p", "github", "-f", "-")
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"id":1,"name":"github"}`, out)
+	assert.Equal(t, "Bearer secret", (*requests)[0].Auth)
+	assert.Equal(t, `{"name":"github"}`, (*requests)[0].Body)
+}
+
+func TestApiError(t *testing.T) {
+	server, _ := fakeServer(t, nil)
+	_, err := execute(server, "", "connections", "get", "-p", "github", "1")
+	assert.EqualError(t, err, "GET /plugins/github/connections/1: 404 not found")
+}
+
+func TestAddScopes(t *testing.T) {
+	server, requests := fakeServer(t, map[string][]string{
+		"PUT /plugins/jenkins/connections/1/scopes":            {`[]`},
+		"DELETE /plugins/jenkins/connections/1/scopes/a%2Fjob": {`{}`},
+	})
+	_, err := execute(server, "", "scopes", "add", "-p", "jenkins", "-c", "1", "-d", `[{"fullName":"a/job"}]`)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"data":[{"fullName":"a/job"}]}`, (*requests)[0].Body)
+
+	_, err = execute(server, "", "scopes", "remove", "-p", "jenkins", "-c", "1", "a/job", "--data-only")
+	assert.Nil(t, err)
+	assert.Equal(t, "delete_data_only=true", (*requests)[1].Query)
+}
+
+func TestTriggerAndFollow(t *testing.T) {
+	server, requests := fakeServer(t, map[string][]string{
+		"POST /blueprints/3/trigger": {`{"id":7}`},
+		"GET /pipelines/7": {
+			`{"id":7,"status":"TASK_RUNNING","finishedTasks":0,"totalTasks":2}`,
+			`{"id":7,"status":"TASK_RUNNING","finishedTasks":0,"totalTasks":2}`,
+			`{"id":7,"status":"TASK_FAILED","finishedTasks":2,"totalTasks":2,"message":"boom"}`,
+		},
+		"GET /pipelines/7/tasks": {`{"tasks":[{"id":9,"plugin":"github","status":"TASK_FAILED","failedSubTask":"collectIssues","message":"boom"}]}`},
+	})
+	out, err := execute(server, "", "pipelines", "trigger", "3", "--full-sync", "--follow", "--interval", "1ms")
+	assert.EqualError(t, err, "pipeline 7 finished with TASK_FAILED boom")
+	policy := map[string]bool{}
+	assert.Nil(t, json.Unmarshal([]byte((*requests)[0].Body), &policy))
+	assert.Equal(t, map[string]bool{"skipCollectors": false, "fullSync": true}, policy)
+	assert.Contains(t, out, "pipeline 7 triggered\n")
+	assert.Equal(t, 1, strings.Count(out, "TASK_RUNNING 0/2 tasks finished"))
+	assert.Contains(t, out, "pipeline 7 TASK_FAILED 2/2 tasks finished")
+	assert.Contains(t, out, "task 9 (github) failed at collectIssues: boom")
+}
+
+func TestImportBlueprint(t *testing.T) {
+	server, requests := fakeServer(t, map[string][]string{
+		"POST /blueprints/import": {`{"id":3}`},
+	})
+	_, err := execute(server, "name: bp\n", "blueprints", "import", "-f", "-")
+	assert.Nil(t, err)
+	assert.Equal(t, "application/yaml", (*requests)[0].ContentType)
+	assert.Equal(t, "name: bp\n", (*requests)[0].Body)
+}
@@ -0,0 +1,52 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// printJSON prints the raw json response indented, anything else is printed as is
+func printJSON(w io.Writer, raw []byte) error {
+	var out bytes.Buffer
+	if err := json.Indent(&out, raw, "", "  "); err != nil {
+		_, err = w.Write(raw)
+		return err
+	}
+	_, err := fmt.Fprintln(w, out.String())
+	return err
+}
+
+// readPayload returns the inline json, or the content of the file, `-` reads stdin
+func readPayload(data string, file string, stdin io.Reader) ([]byte, error) {
+	switch {
+	case data != "" && file != "":
+		return nil, fmt.Errorf("--data and --file are mutually exclusive")
+	case data != "":
+		return []byte(data), nil
+	case file == "-":
+		return io.ReadAll(stdin)
+	case file != "":
+		return os.ReadFile(file)
+	}
+	return nil, fmt.Errorf("either --data or --file is required")
+}
@@ -0,0 +1,176 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/utils"
+	"github.com/spf13/cobra"
+)
+
+func pipelinesCmd(opts *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "pipelines",
+		Aliases: []string{"pipeline"},
+		Short:   "trigger, cancel and follow pipelines",
+	}
+	run := func(c *cobra.Command, method string, path string, query url.Values, payload interface{}) error {
+		res, err := opts.client().send(method, path, query, payload)
+		if err != nil {
+			return err
+		}
+		return printJSON(c.OutOrStdout(), res)
+	}
+
+	var status string
+	var blueprintId uint64
+	var page, pageSize int
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "list the pipelines, the latest first",
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, args []string) error {
+			query := url.Values{}
+			if status != "" {
+				query.Set("status", status)
+			}
+			if blueprintId > 0 {
+				query.Set("blueprint_id", strconv.FormatUint(blueprintId, 10))
+			}
+			if page > 0 {
+				query.Set("page", strconv.Itoa(page))
+			}
+			if pageSize > 0 {
+				query.Set("pageSize", strconv.Itoa(pageSize))
+			}
+			return run(c, http.MethodGet, "/pipelines", query, nil)
+		},
+	}
+	list.Flags().StringVar(&status, "status", "", "only list the pipelines of the status, i.e. TASK_FAILED")
+	list.Flags().Uint64Var(&blueprintId, "blueprint", 0, "only list the pipelines of the blueprint")
+	list.Flags().IntVar(&page, "page", 0, "page number, starting from 1")
+	list.Flags().IntVar(&pageSize, "page-size", 0, "page size")
+	cmd.AddCommand(list)
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "get PIPELINE_ID",
+		Short: "show a pipeline",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			return run(c, http.MethodGet, "/pipelines/"+args[0], nil, nil)
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "cancel PIPELINE_ID",
+		Short: "cancel a running pipeline",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			return run(c, http.MethodDelete, "/pipelines/"+args[0], nil, nil)
+		},
+	})
+
+	var interval time.Duration
+	follow := &cobra.Command{
+		Use:   "follow PIPELINE_ID",
+		Short: "print the progress of a pipeline until it finishes, fails unless the pipeline completed",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			pipelineId, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid pipeline id %s", args[0])
+			}
+			return followPipeline(opts.client(), c.OutOrStdout(), pipelineId, interval)
+		},
+	}
+	follow.Flags().DurationVar(&interval, "interval", 5*time.Second, "how often the progress is polled")
+	cmd.AddCommand(follow)
+
+	policy := &models.TriggerSyncPolicy{}
+	var followTriggered bool
+	trigger := &cobra.Command{
+		Use:   "trigger BLUEPRINT_ID",
+		Short: "trigger a pipeline of the blueprint",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			pipeline := &models.Pipeline{}
+			err := opts.client().sendJSON(http.MethodPost, "/blueprints/"+args[0]+"/trigger", nil, policy, pipeline)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(c.OutOrStdout(), "pipeline %d triggered\n", pipeline.ID)
+			if !followTriggered {
+				return nil
+			}
+			return followPipeline(opts.client(), c.OutOrStdout(), pipeline.ID, interval)
+		},
+	}
+	trigger.Flags().BoolVar(&policy.SkipCollectors, "skip-collectors", false, "only transform the data collected before")
+	trigger.Flags().BoolVar(&policy.FullSync, "full-sync", false, "collect all the data again instead of the changes only")
+	trigger.Flags().BoolVar(&followTriggered, "follow", false, "follow the progress of the pipeline, fails unless it completed")
+	trigger.Flags().DurationVar(&interval, "interval", 5*time.Second, "how often the progress is polled with --follow")
+	cmd.AddCommand(trigger)
+	return cmd
+}
+
+// followPipeline polls the pipeline and prints its progress whenever it changes, the failed tasks are printed
+// in the end if any
+func followPipeline(cli *client, w io.Writer, pipelineId uint64, interval time.Duration) error {
+	path := fmt.Sprintf("/pipelines/%d", pipelineId)
+	lastProgress := ""
+	for {
+		pipeline := &models.Pipeline{}
+		if err := cli.sendJSON(http.MethodGet, path, nil, nil, pipeline); err != nil {
+			return err
+		}
+		progress := fmt.Sprintf("%s %d/%d tasks finished", pipeline.Status, pipeline.FinishedTasks, pipeline.TotalTasks)
+		if progress != lastProgress {
+			fmt.Fprintf(w, "%s pipeline %d %s\n", time.Now().Format(time.TimeOnly), pipelineId, progress)
+			lastProgress = progress
+		}
+		if utils.StringsContains(models.FinishedTaskStatus, pipeline.Status) {
+			if pipeline.Status == models.TASK_COMPLETED {
+				return nil
+			}
+			printFailedTasks(cli, w, pipelineId)
+			return fmt.Errorf("pipeline %d finished with %s %s", pipelineId, pipeline.Status, pipeline.Message)
+		}
+		time.Sleep(interval)
+	}
+}
+
+func printFailedTasks(cli *client, w io.Writer, pipelineId uint64) {
+	res := &struct {
+		Tasks []*models.Task `json:"tasks"`
+	}{}
+	if err := cli.sendJSON(http.MethodGet, fmt.Sprintf("/pipelines/%d/tasks", pipelineId), nil, nil, res); err != nil {
+		return
+	}
+	for _, task := range res.Tasks {
+		if task.Status == models.TASK_FAILED {
+			fmt.Fprintf(w, "task %d (%s) failed at %s: %s\n", task.ID, task.Plugin, task.FailedSubTask, task.Message)
+		}
+	}
+}
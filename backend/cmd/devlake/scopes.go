@@ -0,0 +1,124 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+func scopesCmd(opts *globalOptions) *cobra.Command {
+	var pluginName, connectionId string
+	cmd := &cobra.Command{
+		Use:     "scopes",
+		Aliases: []string{"scope"},
+		Short:   "manage the scopes (repos, boards, projects...) of a connection",
+	}
+	cmd.PersistentFlags().StringVarP(&pluginName, "plugin", "p", "", "name of the plugin, i.e. github")
+	cmd.PersistentFlags().StringVarP(&connectionId, "connection", "c", "", "id of the connection")
+	_ = cmd.MarkPersistentFlagRequired("plugin")
+	_ = cmd.MarkPersistentFlagRequired("connection")
+	path := func(suffix string) string {
+		return fmt.Sprintf("/plugins/%s/connections/%s/scopes%s", pluginName, connectionId, suffix)
+	}
+
+	var search string
+	var page, pageSize int
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "list the scopes added to the connection",
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, args []string) error {
+			query := url.Values{}
+			if search != "" {
+				query.Set("searchTerm", search)
+			}
+			if page > 0 {
+				query.Set("page", strconv.Itoa(page))
+			}
+			if pageSize > 0 {
+				query.Set("pageSize", strconv.Itoa(pageSize))
+			}
+			res, err := opts.client().send(http.MethodGet, path(""), query, nil)
+			if err != nil {
+				return err
+			}
+			return printJSON(c.OutOrStdout(), res)
+		},
+	}
+	list.Flags().StringVarP(&search, "search", "s", "", "only list the scopes whose names contain the term")
+	list.Flags().IntVar(&page, "page", 0, "page number, starting from 1")
+	list.Flags().IntVar(&pageSize, "page-size", 0, "page size")
+	cmd.AddCommand(list)
+
+	var data, file string
+	add := &cobra.Command{
+		Use:   "add",
+		Short: "add or update scopes, the payload is a json array of the scopes",
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, args []string) error {
+			payload, err := readPayload(data, file, c.InOrStdin())
+			if err != nil {
+				return err
+			}
+			res, err := opts.client().send(http.MethodPut, path(""), nil, wrapScopes(payload))
+			if err != nil {
+				return err
+			}
+			return printJSON(c.OutOrStdout(), res)
+		},
+	}
+	add.Flags().StringVarP(&data, "data", "d", "", "scopes as inline json")
+	add.Flags().StringVarP(&file, "file", "f", "", "json file of the scopes, - for stdin")
+	cmd.AddCommand(add)
+
+	var dataOnly bool
+	remove := &cobra.Command{
+		Use:   "remove SCOPE_ID",
+		Short: "remove a scope and its data",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			query := url.Values{}
+			if dataOnly {
+				query.Set("delete_data_only", "true")
+			}
+			res, err := opts.client().send(http.MethodDelete, path("/"+url.PathEscape(args[0])), query, nil)
+			if err != nil {
+				return err
+			}
+			return printJSON(c.OutOrStdout(), res)
+		},
+	}
+	remove.Flags().BoolVar(&dataOnly, "data-only", false, "only delete the data collected for the scope, keep the scope")
+	cmd.AddCommand(remove)
+	return cmd
+}
+
+// wrapScopes accepts the bare array of scopes as well as the {"data": [...]} body expected by the api
+func wrapScopes(payload []byte) []byte {
+	trimmed := bytes.TrimSpace(payload)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		return append(append([]byte(`{"data":`), trimmed...), '}')
+	}
+	return payload
+}
@@ -107,6 +107,10 @@ func setDefaultValue(v *viper.Viper) {
 	v.SetDefault("RESUME_PIPELINES", true)
 	// v.SetDefault("CORS_ALLOW_ORIGIN", "*")
 	v.SetDefault("CONSUME_PIPELINES", true)
+	v.SetDefault("CONSUME_TASKS", true)
+	v.SetDefault("SMTP_PORT", 587)
+	v.SetDefault("TRACING_SERVICE_NAME", "devlake")
+	v.SetDefault("TRACING_SAMPLE_RATIO", 1.0)
 }
 
 func init() {
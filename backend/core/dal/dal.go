@@ -147,6 +147,8 @@ type Dal interface {
 	UpdateAllColumn(entity interface{}, clauses ...Clause) errors.Error
 	// CreateOrUpdate tries to create the record, or fallback to update all if failed
 	CreateOrUpdate(entity interface{}, clauses ...Clause) errors.Error
+	// BulkCreateOrUpdate works like CreateOrUpdate for a slice of records, with the fastest way the database offers
+	BulkCreateOrUpdate(entities interface{}, clauses ...Clause) errors.Error
 	// CreateIfNotExist tries to create the record if not exist
 	CreateIfNotExist(entity interface{}, clauses ...Clause) errors.Error
 	// Delete records from database
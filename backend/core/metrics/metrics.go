@@ -0,0 +1,91 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "devlake"
+
+var (
+	// PipelineDuration observes the finished pipelines by status
+	PipelineDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "pipeline_duration_seconds",
+		Help:      "Duration of the finished pipelines.",
+		// 30 seconds to about 4 hours
+		Buckets: prometheus.ExponentialBuckets(30, 2, 10),
+	}, []string{"status"})
+
+	// SubtaskDuration observes the finished subtasks
+	SubtaskDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "subtask_duration_seconds",
+		Help:      "Duration of the finished subtasks.",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 14),
+	}, []string{"plugin", "subtask", "status"})
+
+	// SubtaskRecords counts the records processed by subtasks
+	SubtaskRecords = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "subtask_records_total",
+		Help:      "Records processed by the subtasks.",
+	}, []string{"plugin", "subtask"})
+
+	// ApiRequests counts the requests sent to the data sources, status is the http status code or "error"
+	ApiRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "api_requests_total",
+		Help:      "Requests sent to the apis of the data sources.",
+	}, []string{"plugin", "connection", "status"})
+
+	// ApiRequestDuration observes the requests sent to the data sources
+	ApiRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "api_request_duration_seconds",
+		Help:      "Duration of the requests sent to the apis of the data sources.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"plugin", "connection"})
+
+	// ApiRateLimit is the rate limit the requests to the data sources are sent at
+	ApiRateLimit = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "api_rate_limit_requests_per_hour",
+		Help:      "Rate limit the requests to the apis of the data sources are sent at.",
+	}, []string{"plugin", "connection"})
+
+	// DbRowsWritten counts the rows saved in batches by table
+	DbRowsWritten = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "db_rows_written_total",
+		Help:      "Rows written to the database in batches.",
+	}, []string{"table"})
+)
+
+func init() {
+	prometheus.MustRegister(
+		PipelineDuration,
+		SubtaskDuration,
+		SubtaskRecords,
+		ApiRequests,
+		ApiRequestDuration,
+		ApiRateLimit,
+		DbRowsWritten,
+	)
+}
@@ -20,6 +20,7 @@ package migration
 import (
 	"fmt"
 	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	core "github.com/apache/incubator-devlake/core/log"
 	"github.com/apache/incubator-devlake/core/plugin"
@@ -37,8 +38,11 @@ type migratorImpl struct {
 	basicRes context.BasicRes
 	logger   core.Logger
 	executed map[string]bool
+	history  []MigrationHistory
 	scripts  []*scriptWithComment
 	pending  []*scriptWithComment
+	// pinned is the schema version set by Rollback, scripts newer than it are left pending
+	pinned uint64
 }
 
 func (m *migratorImpl) loadExecuted() errors.Error {
@@ -55,6 +59,7 @@ func (m *migratorImpl) loadExecuted() errors.Error {
 	if err != nil {
 		return errors.Default.Wrap(err, "error finding migration history records")
 	}
+	m.history = records
 	for _, record := range records {
 		scriptId := getScriptId(record.ScriptName, record.ScriptVersion)
 		m.executed[scriptId] = true
@@ -94,30 +99,115 @@ func (m *migratorImpl) Execute() errors.Error {
 	m.Info("Execute")
 	// execute them one by one
 	db := m.basicRes.GetDal()
-	for _, swc := range m.pending {
+	var skipped []*scriptWithComment
+	for i, swc := range m.pending {
 		scriptId := getScriptId(swc.script.Name(), swc.script.Version())
+		if m.isPinnedOut(swc.script) {
+			m.logger.Info("skipping migration script %s newer than pinned version %d", scriptId, m.pinned)
+			skipped = append(skipped, swc)
+			continue
+		}
 		m.logger.Info("applying migration script %s", scriptId)
 		err := swc.script.Up(m.basicRes)
 		if err != nil {
+			m.pending = append(skipped, m.pending[i:]...)
 			return err
 		}
-		err = db.Create(&MigrationHistory{
+		record := MigrationHistory{
 			ScriptVersion: swc.script.Version(),
 			ScriptName:    swc.script.Name(),
 			Comment:       swc.comment,
-		})
+		}
+		err = db.Create(&record)
 		if err != nil {
+			m.pending = append(skipped, m.pending[i:]...)
 			return errors.Default.Wrap(err, fmt.Sprintf("failed to execute migration script %s", scriptId))
 		}
 		m.executed[scriptId] = true
-		m.pending = m.pending[1:]
+		m.history = append(m.history, record)
+	}
+	m.pending = skipped
+	return nil
+}
+
+// Rollback reverts executed scripts newer than targetVersion in descending order and removes them
+// from the migration_history table. It refuses to do anything unless every one of them is reversible.
+func (m *migratorImpl) Rollback(targetVersion uint64) errors.Error {
+	m.Lock()
+	defer m.Unlock()
+	registered := make(map[string]*scriptWithComment)
+	for _, swc := range m.scripts {
+		registered[getScriptId(swc.script.Name(), swc.script.Version())] = swc
+	}
+	var records []MigrationHistory
+	var kept []MigrationHistory
+	for _, record := range m.history {
+		if record.ScriptVersion > targetVersion {
+			records = append(records, record)
+		} else {
+			kept = append(kept, record)
+		}
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].ScriptVersion != records[j].ScriptVersion {
+			return records[i].ScriptVersion > records[j].ScriptVersion
+		}
+		return records[i].ScriptName > records[j].ScriptName
+	})
+	// make sure all of them can be reverted before touching the database
+	scripts := make([]plugin.ReversibleMigrationScript, len(records))
+	for i, record := range records {
+		scriptId := getScriptId(record.ScriptName, record.ScriptVersion)
+		swc, ok := registered[scriptId]
+		if !ok {
+			return errors.BadInput.New(fmt.Sprintf("unable to rollback to %d: migration script %s is not registered", targetVersion, scriptId))
+		}
+		script, ok := swc.script.(plugin.ReversibleMigrationScript)
+		if !ok {
+			return errors.BadInput.New(fmt.Sprintf("unable to rollback to %d: migration script %s is not reversible", targetVersion, scriptId))
+		}
+		scripts[i] = script
+	}
+	m.logger.Info("rolling back %d migration scripts to version %d", len(records), targetVersion)
+	db := m.basicRes.GetDal()
+	for i, script := range scripts {
+		record := records[i]
+		scriptId := getScriptId(record.ScriptName, record.ScriptVersion)
+		m.logger.Info("reverting migration script %s", scriptId)
+		err := script.Down(m.basicRes)
+		if err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("failed to revert migration script %s", scriptId))
+		}
+		err = db.Delete(
+			&MigrationHistory{},
+			dal.Where("script_version = ? AND script_name = ?", record.ScriptVersion, record.ScriptName),
+		)
+		if err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("failed to delete migration history of %s", scriptId))
+		}
+		delete(m.executed, scriptId)
+		m.pending = append(m.pending, registered[scriptId])
+		m.history = append(kept[:len(kept):len(kept)], records[i+1:]...)
 	}
+	m.pinned = targetVersion
 	return nil
 }
 
 // HasPendingScripts returns if there is any pending migration scripts
 func (m *migratorImpl) HasPendingScripts() bool {
-	return len(m.executed) > 0 && len(m.pending) > 0
+	if len(m.executed) == 0 {
+		return false
+	}
+	for _, swc := range m.pending {
+		if !m.isPinnedOut(swc.script) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *migratorImpl) isPinnedOut(script plugin.MigrationScript) bool {
+	return m.pinned > 0 && script.Version() > m.pinned
 }
 
 // NewMigrator returns a new Migrator instance, which
@@ -85,3 +85,56 @@ func TestHasPendingScripts(t *testing.T) {
 	// make sure all method got called
 	mockDal.AssertExpectations(t)
 }
+
+func TestRollback(t *testing.T) {
+	// simulate db reaction
+	mockDal := new(mockdal.Dal)
+	mockDal.On("AutoMigrate", mock.Anything, mock.Anything).Return(nil).Once()
+	mockDal.On("All", mock.Anything, mock.Anything).Return(func(i interface{}, _ ...dal.Clause) errors.Error {
+		precords := i.(*[]MigrationHistory)
+		*precords = []MigrationHistory{
+			{ScriptName: "A", ScriptVersion: 1, Comment: "UniTest", CreatedAt: time.Now()},
+			{ScriptName: "B", ScriptVersion: 2, Comment: "UniTest", CreatedAt: time.Now()},
+			{ScriptName: "C", ScriptVersion: 3, Comment: "UniTest", CreatedAt: time.Now()},
+		}
+		return nil
+	}).Once()
+	mockDal.On("Delete", &MigrationHistory{}, mock.Anything).Return(nil).Twice()
+
+	// migrator initialization
+	basicRes := context.NewDefaultBasicRes(viper.New(), unithelper.DummyLogger(), mockDal)
+	migrator, err := NewMigrator(basicRes)
+	assert.Nil(t, err)
+
+	// A is not reversible, B and C are
+	var reverted []string
+	scriptA := new(mockplugin.MigrationScript)
+	scriptA.On("Version").Return(uint64(1))
+	scriptA.On("Name").Return("A")
+	scriptB := new(mockplugin.ReversibleMigrationScript)
+	scriptB.On("Down", mock.Anything).Run(func(_ mock.Arguments) { reverted = append(reverted, "B") }).Return(nil).Once()
+	scriptB.On("Version").Return(uint64(2))
+	scriptB.On("Name").Return("B")
+	scriptC := new(mockplugin.ReversibleMigrationScript)
+	scriptC.On("Down", mock.Anything).Run(func(_ mock.Arguments) { reverted = append(reverted, "C") }).Return(nil).Once()
+	scriptC.On("Version").Return(uint64(3))
+	scriptC.On("Name").Return("C")
+	migrator.Register([]plugin.MigrationScript{scriptA, scriptB, scriptC}, "UnitTest")
+
+	// A can't be reverted, nothing should be touched
+	assert.NotNil(t, migrator.Rollback(0))
+	assert.Empty(t, reverted)
+
+	// newer scripts get reverted first
+	assert.Nil(t, migrator.Rollback(1))
+	assert.Equal(t, []string{"C", "B"}, reverted)
+
+	// the schema is pinned, reverted scripts must not be applied again
+	assert.False(t, migrator.HasPendingScripts())
+	assert.Nil(t, migrator.Execute())
+
+	// make sure all method got called
+	mockDal.AssertExpectations(t)
+	scriptB.AssertExpectations(t)
+	scriptC.AssertExpectations(t)
+}
@@ -0,0 +1,41 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+)
+
+// ApiHttpCache holds the validators of the last response of an api url, so the next incremental collection can send
+// a conditional request and skip the unchanged resources
+type ApiHttpCache struct {
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+	CacheKey      string    `gorm:"primaryKey;type:varchar(64)" json:"cacheKey"`
+	RawDataTable  string    `gorm:"type:varchar(255)" json:"rawDataTable"`
+	RawDataParams string    `gorm:"type:varchar(255);index" json:"rawDataParams"`
+	Url           string    `gorm:"type:text" json:"url"`
+	ETag          string    `gorm:"column:etag;type:varchar(255)" json:"etag"`
+	LastModified  string    `gorm:"type:varchar(100)" json:"lastModified"`
+	// no page follows the response
+	LastPage bool `json:"lastPage"`
+}
+
+func (ApiHttpCache) TableName() string {
+	return "_devlake_api_http_cache"
+}
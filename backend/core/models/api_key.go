@@ -22,17 +22,29 @@ import (
 	"time"
 )
 
+// ApiKeyScopes restrict an api key further than its allowed path.
+type ApiKeyScopes struct {
+	// ReadOnly keys may only send GET and HEAD requests
+	ReadOnly bool `json:"readOnly"`
+	// Projects limits the key to the given projects, along with their blueprints and pipelines
+	Projects []string `json:"projects" gorm:"type:json;serializer:json"`
+}
+
 // ApiKey is the basic of api key management.
 type ApiKey struct {
 	common.Model
 	common.Creator
 	common.Updater
+	ApiKeyScopes
 	Name        string     `json:"name"`
 	ApiKey      string     `json:"apiKey,omitempty"`
 	ExpiredAt   *time.Time `json:"expiredAt"`
 	AllowedPath string     `json:"allowedPath"`
 	Type        string     `json:"type"`
 	Extra       string     `json:"extra"`
+	// PreviousApiKey is the hashed key replaced by the last rotation, still accepted until PreviousExpiredAt
+	PreviousApiKey    string     `json:"-"`
+	PreviousExpiredAt *time.Time `json:"previousExpiredAt"`
 }
 
 func (apiKey *ApiKey) TableName() string {
@@ -44,6 +56,7 @@ func (apiKey *ApiKey) RemoveHashedApiKey() {
 }
 
 type ApiInputApiKey struct {
+	ApiKeyScopes
 	Name        string     `json:"name" validate:"required,max=255"`
 	Type        string     `json:"type" validate:"required"`
 	AllowedPath string     `json:"allowedPath" validate:"required"`
@@ -51,3 +64,9 @@ type ApiInputApiKey struct {
 }
 
 type ApiOutputApiKey = ApiKey
+
+// ApiInputRotateApiKey is the input of the api key rotation
+type ApiInputRotateApiKey struct {
+	// GracePeriodSeconds keeps the replaced key valid for a while so the clients can be updated
+	GracePeriodSeconds int `json:"gracePeriodSeconds" validate:"min=0"`
+}
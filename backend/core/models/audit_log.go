@@ -0,0 +1,60 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+)
+
+const (
+	AUDIT_ACTION_CREATE  = "create"
+	AUDIT_ACTION_UPDATE  = "update"
+	AUDIT_ACTION_DELETE  = "delete"
+	AUDIT_ACTION_EXPORT  = "export"
+	AUDIT_ACTION_RESTORE = "restore"
+)
+
+const (
+	AUDIT_RESOURCE_CONNECTION   = "connection"
+	AUDIT_RESOURCE_SCOPE        = "scope"
+	AUDIT_RESOURCE_SCOPE_CONFIG = "scope-config"
+	AUDIT_RESOURCE_BLUEPRINT    = "blueprint"
+	AUDIT_RESOURCE_API_KEY      = "api-key"
+	AUDIT_RESOURCE_ROLE_BINDING = "role-binding"
+	AUDIT_RESOURCE_BACKUP       = "backup"
+)
+
+// AuditLog records a configuration change made through the api, the old and new values are stored as json with
+// secrets redacted
+type AuditLog struct {
+	ID           uint64    `json:"id" gorm:"primaryKey"`
+	CreatedAt    time.Time `json:"createdAt" gorm:"index"`
+	Actor        string    `json:"actor" gorm:"type:varchar(255);index"`
+	ActorEmail   string    `json:"actorEmail" gorm:"type:varchar(255)"`
+	Action       string    `json:"action" gorm:"type:varchar(20)"`
+	ResourceType string    `json:"resourceType" gorm:"type:varchar(50);index"`
+	Plugin       string    `json:"plugin" gorm:"type:varchar(100)"`
+	ResourceId   string    `json:"resourceId" gorm:"type:varchar(255);index"`
+	Path         string    `json:"path" gorm:"type:varchar(512)"`
+	OldValue     string    `json:"oldValue"`
+	NewValue     string    `json:"newValue"`
+}
+
+func (AuditLog) TableName() string {
+	return "_devlake_audit_logs"
+}
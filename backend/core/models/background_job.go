@@ -0,0 +1,58 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+const (
+	BACKGROUND_JOB_RUNNING = "RUNNING"
+	BACKGROUND_JOB_DONE    = "DONE"
+	// some items failed, the others were processed
+	BACKGROUND_JOB_PARTIAL = "PARTIAL"
+	BACKGROUND_JOB_FAILED  = "FAILED"
+)
+
+// BackgroundJob tracks a long-running api operation (i.e. adding hundreds of scopes at once) that is processed
+// in the background, clients poll it for the progress and the items that failed
+type BackgroundJob struct {
+	common.Model
+	Plugin       string                  `gorm:"type:varchar(100);index" json:"plugin"`
+	ConnectionId uint64                  `gorm:"index" json:"connectionId"`
+	Type         string                  `gorm:"type:varchar(100)" json:"type"`
+	Status       string                  `gorm:"type:varchar(20)" json:"status"`
+	Total        int                     `json:"total"`
+	Processed    int                     `json:"processed"`
+	Succeeded    int                     `json:"succeeded"`
+	Failures     []*BackgroundJobFailure `gorm:"type:json;serializer:json" json:"failures"`
+	Message      string                  `gorm:"type:text" json:"message"`
+	FinishedAt   *time.Time              `json:"finishedAt"`
+}
+
+// BackgroundJobFailure is an item the job failed to process
+type BackgroundJobFailure struct {
+	Key     string `json:"key"`
+	Message string `json:"message"`
+}
+
+func (BackgroundJob) TableName() string {
+	return "_devlake_background_jobs"
+}
@@ -0,0 +1,151 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+// BlackoutWindow is a period during which a blueprint doesn't collect, either a daily window like
+// the business hours, or a fixed period like a release freeze. Runs skipped in the window are caught up after it ends
+type BlackoutWindow struct {
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+	// daily window in the timezone of the blueprint, e.g. "09:00" to "18:00", it spans midnight if it ends before it starts
+	StartTime string `json:"startTime,omitempty" yaml:"startTime,omitempty" example:"09:00"`
+	EndTime   string `json:"endTime,omitempty" yaml:"endTime,omitempty" example:"18:00"`
+	// days the daily window starts on, "mon" to "sun", every day if empty
+	Weekdays []string `json:"weekdays,omitempty" yaml:"weekdays,omitempty"`
+	// fixed period
+	From *time.Time `json:"from,omitempty" yaml:"from,omitempty"`
+	To   *time.Time `json:"to,omitempty" yaml:"to,omitempty"`
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+func parseClock(clock string) (int, int, errors.Error) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, 0, errors.BadInput.Wrap(err, fmt.Sprintf("invalid time %s, expected HH:MM", clock))
+	}
+	return t.Hour(), t.Minute(), nil
+}
+
+// Validate checks the window is either a daily window or a fixed period
+func (w *BlackoutWindow) Validate() errors.Error {
+	if w.From != nil || w.To != nil {
+		if w.From == nil || w.To == nil || !w.From.Before(*w.To) {
+			return errors.BadInput.New(fmt.Sprintf("blackout window %s: from must be before to", w.Name))
+		}
+		if w.StartTime != "" || w.EndTime != "" || len(w.Weekdays) > 0 {
+			return errors.BadInput.New(fmt.Sprintf("blackout window %s: a fixed period can not be daily", w.Name))
+		}
+		return nil
+	}
+	if _, _, err := parseClock(w.StartTime); err != nil {
+		return errors.BadInput.Wrap(err, fmt.Sprintf("blackout window %s: invalid startTime", w.Name))
+	}
+	if _, _, err := parseClock(w.EndTime); err != nil {
+		return errors.BadInput.Wrap(err, fmt.Sprintf("blackout window %s: invalid endTime", w.Name))
+	}
+	if w.StartTime == w.EndTime {
+		return errors.BadInput.New(fmt.Sprintf("blackout window %s: startTime and endTime are the same", w.Name))
+	}
+	for _, day := range w.Weekdays {
+		if _, ok := weekdays[strings.ToLower(day)]; !ok {
+			return errors.BadInput.New(fmt.Sprintf("blackout window %s: invalid weekday %s", w.Name, day))
+		}
+	}
+	return nil
+}
+
+func (w *BlackoutWindow) startsOn(day time.Weekday) bool {
+	if len(w.Weekdays) == 0 {
+		return true
+	}
+	for _, d := range w.Weekdays {
+		if weekdays[strings.ToLower(d)] == day {
+			return true
+		}
+	}
+	return false
+}
+
+// EndOf returns the end of the window if t falls in it, the daily window is evaluated in loc
+func (w *BlackoutWindow) EndOf(t time.Time, loc *time.Location) (time.Time, bool) {
+	if w.From != nil && w.To != nil {
+		return *w.To, !t.Before(*w.From) && t.Before(*w.To)
+	}
+	startHour, startMinute, err := parseClock(w.StartTime)
+	if err != nil {
+		return time.Time{}, false
+	}
+	endHour, endMinute, err := parseClock(w.EndTime)
+	if err != nil {
+		return time.Time{}, false
+	}
+	local := t.In(loc)
+	// a window spanning midnight may have started the day before
+	for _, offset := range []int{0, -1} {
+		day := time.Date(local.Year(), local.Month(), local.Day()+offset, 0, 0, 0, 0, loc)
+		if !w.startsOn(day.Weekday()) {
+			continue
+		}
+		from := time.Date(day.Year(), day.Month(), day.Day(), startHour, startMinute, 0, 0, loc)
+		to := time.Date(day.Year(), day.Month(), day.Day(), endHour, endMinute, 0, 0, loc)
+		if !to.After(from) {
+			to = to.AddDate(0, 0, 1)
+		}
+		if !local.Before(from) && local.Before(to) {
+			return to, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// BlackoutUntil returns when the blackout covering t ends, following windows which overlap or touch each other
+func BlackoutUntil(windows []*BlackoutWindow, t time.Time, loc *time.Location) (time.Time, bool) {
+	until := t
+	inBlackout := false
+	// bounded in case the windows cover the whole week
+	for i := 0; i < 100; i++ {
+		extended := false
+		for _, w := range windows {
+			if end, ok := w.EndOf(until, loc); ok && end.After(until) {
+				until = end
+				extended = true
+			}
+		}
+		if !extended {
+			break
+		}
+		inBlackout = true
+	}
+	return until, inBlackout
+}
@@ -0,0 +1,83 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlackoutWindow_Validate(t *testing.T) {
+	from := time.Date(2023, 12, 20, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 14)
+	assert.Nil(t, (&BlackoutWindow{StartTime: "09:00", EndTime: "18:00", Weekdays: []string{"Mon", "fri"}}).Validate())
+	assert.Nil(t, (&BlackoutWindow{StartTime: "22:00", EndTime: "06:00"}).Validate())
+	assert.Nil(t, (&BlackoutWindow{From: &from, To: &to}).Validate())
+	assert.NotNil(t, (&BlackoutWindow{StartTime: "9am", EndTime: "18:00"}).Validate())
+	assert.NotNil(t, (&BlackoutWindow{StartTime: "09:00", EndTime: "09:00"}).Validate())
+	assert.NotNil(t, (&BlackoutWindow{StartTime: "09:00", EndTime: "18:00", Weekdays: []string{"monday"}}).Validate())
+	assert.NotNil(t, (&BlackoutWindow{From: &to, To: &from}).Validate())
+	assert.NotNil(t, (&BlackoutWindow{From: &from}).Validate())
+}
+
+func TestBlackoutWindow_EndOf(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Shanghai")
+	assert.Nil(t, err)
+	businessHours := &BlackoutWindow{StartTime: "09:00", EndTime: "18:00", Weekdays: []string{"mon", "tue", "wed", "thu", "fri"}}
+	// 2023-06-05 is a monday
+	end, ok := businessHours.EndOf(time.Date(2023, 6, 5, 2, 0, 0, 0, time.UTC), loc)
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2023, 6, 5, 18, 0, 0, 0, loc), end)
+	_, ok = businessHours.EndOf(time.Date(2023, 6, 5, 10, 0, 0, 0, time.UTC), loc)
+	assert.False(t, ok)
+	_, ok = businessHours.EndOf(time.Date(2023, 6, 10, 10, 0, 0, 0, loc), loc)
+	assert.False(t, ok)
+
+	// spans midnight, started on the day before
+	nightly := &BlackoutWindow{StartTime: "22:00", EndTime: "06:00", Weekdays: []string{"sun"}}
+	end, ok = nightly.EndOf(time.Date(2023, 6, 5, 1, 0, 0, 0, time.UTC), time.UTC)
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2023, 6, 5, 6, 0, 0, 0, time.UTC), end)
+	_, ok = nightly.EndOf(time.Date(2023, 6, 5, 23, 0, 0, 0, time.UTC), time.UTC)
+	assert.False(t, ok)
+
+	from := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+	freeze := &BlackoutWindow{From: &from, To: &to}
+	end, ok = freeze.EndOf(time.Date(2023, 6, 5, 1, 0, 0, 0, time.UTC), loc)
+	assert.True(t, ok)
+	assert.Equal(t, to, end)
+	_, ok = freeze.EndOf(to, loc)
+	assert.False(t, ok)
+}
+
+func TestBlackoutUntil(t *testing.T) {
+	windows := []*BlackoutWindow{
+		{StartTime: "09:00", EndTime: "12:00"},
+		{StartTime: "12:00", EndTime: "18:00"},
+	}
+	until, ok := BlackoutUntil(windows, time.Date(2023, 6, 5, 10, 0, 0, 0, time.UTC), time.UTC)
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2023, 6, 5, 18, 0, 0, 0, time.UTC), until)
+	_, ok = BlackoutUntil(windows, time.Date(2023, 6, 5, 20, 0, 0, 0, time.UTC), time.UTC)
+	assert.False(t, ok)
+	_, ok = BlackoutUntil(nil, time.Date(2023, 6, 5, 10, 0, 0, 0, time.UTC), time.UTC)
+	assert.False(t, ok)
+}
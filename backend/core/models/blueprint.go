@@ -30,20 +30,27 @@ const (
 
 // @Description CronConfig
 type Blueprint struct {
-	Name         string                 `json:"name" validate:"required"`
-	ProjectName  string                 `json:"projectName" gorm:"type:varchar(255)"`
-	Mode         string                 `json:"mode" gorm:"varchar(20)" validate:"required,oneof=NORMAL ADVANCED"`
-	Plan         PipelinePlan           `json:"plan" gorm:"serializer:encdec"`
-	Enable       bool                   `json:"enable"`
-	CronConfig   string                 `json:"cronConfig" format:"* * * * *" example:"0 0 * * 1"`
-	IsManual     bool                   `json:"isManual"`
-	BeforePlan   PipelinePlan           `json:"beforePlan" gorm:"serializer:encdec"`
-	AfterPlan    PipelinePlan           `json:"afterPlan" gorm:"serializer:encdec"`
-	Labels       []string               `json:"labels" gorm:"-"`
-	Connections  []*BlueprintConnection `json:"connections" gorm:"-"`
-	Priority     int                    `json:"priority"` // greater is higher
-	SyncPolicy   `gorm:"embedded"`
-	common.Model `swaggerignore:"true"`
+	Name        string                 `json:"name" validate:"required"`
+	ProjectName string                 `json:"projectName" gorm:"type:varchar(255)"`
+	Mode        string                 `json:"mode" gorm:"varchar(20)" validate:"required,oneof=NORMAL ADVANCED"`
+	Plan        PipelinePlan           `json:"plan" gorm:"serializer:encdec"`
+	Enable      bool                   `json:"enable"`
+	CronConfig  string                 `json:"cronConfig" format:"* * * * *" example:"0 0 * * 1"`
+	IsManual    bool                   `json:"isManual"`
+	BeforePlan  PipelinePlan           `json:"beforePlan" gorm:"serializer:encdec"`
+	AfterPlan   PipelinePlan           `json:"afterPlan" gorm:"serializer:encdec"`
+	Labels      []string               `json:"labels" gorm:"-"`
+	Connections []*BlueprintConnection `json:"connections" gorm:"-"`
+	Priority    int                    `json:"priority"` // greater is higher
+	// IANA timezone the cronConfig and the blackout windows are evaluated in, UTC if empty
+	Timezone        string            `json:"timezone" gorm:"type:varchar(100)" example:"Asia/Shanghai"`
+	BlackoutWindows []*BlackoutWindow `json:"blackoutWindows" gorm:"type:json;serializer:json"`
+	// ids of the blueprints whose latest pipelines must succeed before the blueprint runs,
+	// the blueprint is triggered once they all succeeded
+	DependsOn     []uint64                 `json:"dependsOn" gorm:"-"`
+	Notifications []*BlueprintNotification `json:"notifications" gorm:"serializer:encdec"`
+	SyncPolicy    `gorm:"embedded"`
+	common.Model  `swaggerignore:"true"`
 }
 
 func (Blueprint) TableName() string {
@@ -61,6 +68,16 @@ func (BlueprintLabel) TableName() string {
 	return "_devlake_blueprint_labels"
 }
 
+type BlueprintDependency struct {
+	CreatedAt   time.Time `json:"createdAt"`
+	BlueprintId uint64    `json:"blueprintId" gorm:"primaryKey"`
+	DependsOnId uint64    `json:"dependsOnId" gorm:"primaryKey;index"`
+}
+
+func (BlueprintDependency) TableName() string {
+	return "_devlake_blueprint_dependencies"
+}
+
 type BlueprintConnection struct {
 	BlueprintId  uint64            `json:"-" gorm:"primaryKey" validate:"required"`
 	PluginName   string            `json:"pluginName" gorm:"primaryKey;type:varchar(255)" validate:"required"`
@@ -77,6 +94,8 @@ type BlueprintScope struct {
 	PluginName   string `json:"-" gorm:"primaryKey;type:varchar(255)" validate:"required"`
 	ConnectionId uint64 `json:"-" gorm:"primaryKey" validate:"required"`
 	ScopeId      string `json:"scopeId" gorm:"primaryKey;type:varchar(255)" validate:"required"`
+	// overrides the sync policy of the blueprint for the scope
+	SyncPolicy *ScopeSyncPolicy `json:"syncPolicy,omitempty" gorm:"type:json;serializer:json"`
 }
 
 func (BlueprintScope) TableName() string {
@@ -91,5 +110,47 @@ type TriggerSyncPolicy struct {
 type SyncPolicy struct {
 	SkipOnFail bool       `json:"skipOnFail"`
 	TimeAfter  *time.Time `json:"timeAfter"`
+	// what to do when the collectors of a stage are expected to send more requests to a connection than its rate limit
+	RateLimitBudget string `json:"rateLimitBudget" gorm:"type:varchar(20)" validate:"omitempty,oneof=WARN STAGGER SPLIT"`
 	TriggerSyncPolicy
+	Timeouts
+}
+
+// Rate limit budgets of the SyncPolicy, the budget is ignored if empty
+const (
+	// RATE_LIMIT_BUDGET_WARN only logs the stages expected to exceed the rate limit
+	RATE_LIMIT_BUDGET_WARN = "WARN"
+	// RATE_LIMIT_BUDGET_STAGGER delays the tasks sharing a connection until the requests of the previous ones are replenished
+	RATE_LIMIT_BUDGET_STAGGER = "STAGGER"
+	// RATE_LIMIT_BUDGET_SPLIT spreads the tasks sharing a connection across the window until the next cron run
+	RATE_LIMIT_BUDGET_SPLIT = "SPLIT"
+)
+
+// ScopeSyncPolicy overrides the sync policy of the blueprint for some scopes, e.g. the full history of a new repo.
+// SkipCollectors and FullSync can only be turned on, so a manual trigger turning them on still applies to all scopes.
+type ScopeSyncPolicy struct {
+	// replaces the timeAfter of the blueprint, a zero time collects the full history
+	TimeAfter      *time.Time `json:"timeAfter,omitempty" yaml:"timeAfter,omitempty"`
+	SkipCollectors bool       `json:"skipCollectors,omitempty" yaml:"skipCollectors,omitempty"`
+	FullSync       bool       `json:"fullSync,omitempty" yaml:"fullSync,omitempty"`
+}
+
+// Apply returns the sync policy with the overrides applied
+func (p *ScopeSyncPolicy) Apply(syncPolicy *SyncPolicy) *SyncPolicy {
+	if p == nil {
+		return syncPolicy
+	}
+	applied := SyncPolicy{}
+	if syncPolicy != nil {
+		applied = *syncPolicy
+	}
+	if p.TimeAfter != nil {
+		applied.TimeAfter = p.TimeAfter
+		if p.TimeAfter.IsZero() {
+			applied.TimeAfter = nil
+		}
+	}
+	applied.SkipCollectors = applied.SkipCollectors || p.SkipCollectors
+	applied.FullSync = applied.FullSync || p.FullSync
+	return &applied
 }
@@ -0,0 +1,123 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+const (
+	NOTIFICATION_CHANNEL_SLACK   = "slack"
+	NOTIFICATION_CHANNEL_TEAMS   = "teams"
+	NOTIFICATION_CHANNEL_EMAIL   = "email"
+	NOTIFICATION_CHANNEL_WEBHOOK = "webhook"
+)
+
+// SanitizedNotificationSecret replaces the secret and the path of the url of the notifications in API responses
+const SanitizedNotificationSecret = "********"
+
+// BlueprintNotification sends a message to the channel when a pipeline of the blueprint finishes
+type BlueprintNotification struct {
+	Type string `json:"type" yaml:"type" example:"slack"`
+	// incoming webhook of slack or teams, or the endpoint of the generic webhook
+	Url string `json:"url,omitempty" yaml:"url,omitempty"`
+	// signs the payload of the generic webhook with HMAC-SHA256
+	Secret     string   `json:"secret,omitempty" yaml:"secret,omitempty"`
+	Recipients []string `json:"recipients,omitempty" yaml:"recipients,omitempty"`
+	// statuses of the finished pipelines to notify on, TASK_FAILED and TASK_PARTIAL if empty
+	On []string `json:"on,omitempty" yaml:"on,omitempty" example:"TASK_FAILED,TASK_PARTIAL,TASK_COMPLETED"`
+}
+
+// Validate checks the channel has what it needs to be sent to
+func (n *BlueprintNotification) Validate() errors.Error {
+	switch n.Type {
+	case NOTIFICATION_CHANNEL_SLACK, NOTIFICATION_CHANNEL_TEAMS, NOTIFICATION_CHANNEL_WEBHOOK:
+		u, err := url.Parse(n.Url)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.BadInput.New(fmt.Sprintf("%s notification: invalid url", n.Type))
+		}
+	case NOTIFICATION_CHANNEL_EMAIL:
+		if len(n.Recipients) == 0 {
+			return errors.BadInput.New("email notification: recipients are required")
+		}
+	default:
+		return errors.BadInput.New(fmt.Sprintf("unsupported notification type %s", n.Type))
+	}
+	for _, status := range n.On {
+		if status != TASK_COMPLETED && status != TASK_PARTIAL && status != TASK_FAILED && status != TASK_CANCELLED {
+			return errors.BadInput.New(fmt.Sprintf("%s notification: can not notify on %s", n.Type, status))
+		}
+	}
+	return nil
+}
+
+// NotifiesOn tells whether the channel is notified of a pipeline finishing with the status
+func (n *BlueprintNotification) NotifiesOn(status string) bool {
+	if len(n.On) == 0 {
+		return status == TASK_FAILED || status == TASK_PARTIAL
+	}
+	for _, s := range n.On {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// Sanitize masks the secret and the url of the channel, the incoming webhooks of slack and teams are secrets on their
+// own so only the scheme and the host of the url are kept
+func (n BlueprintNotification) Sanitize() *BlueprintNotification {
+	n.Url = sanitizeNotificationUrl(n.Url)
+	if n.Secret != "" {
+		n.Secret = SanitizedNotificationSecret
+	}
+	return &n
+}
+
+// IsSanitized tells whether the url or the secret of the channel is masked
+func (n *BlueprintNotification) IsSanitized() bool {
+	return n.Secret == SanitizedNotificationSecret || strings.HasSuffix(n.Url, "/"+SanitizedNotificationSecret)
+}
+
+// RestoreSanitized keeps the url and the secret of the stored channel when they are sent back masked
+func (n *BlueprintNotification) RestoreSanitized(existed []*BlueprintNotification) {
+	for _, e := range existed {
+		if e.Type != n.Type || sanitizeNotificationUrl(e.Url) != sanitizeNotificationUrl(n.Url) {
+			continue
+		}
+		if n.Url == sanitizeNotificationUrl(e.Url) {
+			n.Url = e.Url
+		}
+		if n.Secret == SanitizedNotificationSecret {
+			n.Secret = e.Secret
+		}
+		return
+	}
+}
+
+func sanitizeNotificationUrl(rawUrl string) string {
+	u, err := url.Parse(rawUrl)
+	if err != nil || u.Host == "" {
+		return rawUrl
+	}
+	return fmt.Sprintf("%s://%s/%s", u.Scheme, u.Host, SanitizedNotificationSecret)
+}
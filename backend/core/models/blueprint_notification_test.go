@@ -0,0 +1,66 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlueprintNotificationSanitize(t *testing.T) {
+	stored := &BlueprintNotification{
+		Type:   NOTIFICATION_CHANNEL_WEBHOOK,
+		Url:    "https://hooks.example.com/notify?token=abc",
+		Secret: "s3cret",
+	}
+	sanitized := stored.Sanitize()
+	assert.Equal(t, "https://hooks.example.com/********", sanitized.Url)
+	assert.Equal(t, SanitizedNotificationSecret, sanitized.Secret)
+	assert.True(t, sanitized.IsSanitized())
+	// the stored channel is left untouched
+	assert.Equal(t, "https://hooks.example.com/notify?token=abc", stored.Url)
+	assert.False(t, stored.IsSanitized())
+
+	sanitized.RestoreSanitized([]*BlueprintNotification{stored})
+	assert.Equal(t, stored, sanitized)
+
+	email := &BlueprintNotification{Type: NOTIFICATION_CHANNEL_EMAIL, Recipients: []string{"a@example.com"}}
+	assert.Equal(t, email, email.Sanitize())
+}
+
+func TestBlueprintNotificationRestoreSanitized(t *testing.T) {
+	existed := []*BlueprintNotification{
+		{Type: NOTIFICATION_CHANNEL_SLACK, Url: "https://hooks.slack.com/services/T/B/secret"},
+		{Type: NOTIFICATION_CHANNEL_TEAMS, Url: "https://outlook.office.com/webhook/secret"},
+	}
+
+	teams := &BlueprintNotification{Type: NOTIFICATION_CHANNEL_TEAMS, Url: "https://outlook.office.com/********"}
+	teams.RestoreSanitized(existed)
+	assert.Equal(t, "https://outlook.office.com/webhook/secret", teams.Url)
+
+	// a new url is kept
+	slack := &BlueprintNotification{Type: NOTIFICATION_CHANNEL_SLACK, Url: "https://hooks.slack.com/services/T/B/other"}
+	slack.RestoreSanitized(existed)
+	assert.Equal(t, "https://hooks.slack.com/services/T/B/other", slack.Url)
+
+	// nothing to restore from
+	webhook := &BlueprintNotification{Type: NOTIFICATION_CHANNEL_WEBHOOK, Url: "https://hooks.example.com/********", Secret: SanitizedNotificationSecret}
+	webhook.RestoreSanitized(existed)
+	assert.True(t, webhook.IsSanitized())
+}
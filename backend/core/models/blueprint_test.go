@@ -0,0 +1,62 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScopeSyncPolicy_Apply(t *testing.T) {
+	timeAfter := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	scopeTimeAfter := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	syncPolicy := &SyncPolicy{SkipOnFail: true, TimeAfter: &timeAfter}
+
+	var noOverride *ScopeSyncPolicy
+	assert.Same(t, syncPolicy, noOverride.Apply(syncPolicy))
+
+	applied := (&ScopeSyncPolicy{TimeAfter: &scopeTimeAfter, FullSync: true}).Apply(syncPolicy)
+	assert.Equal(t, &SyncPolicy{SkipOnFail: true, TimeAfter: &scopeTimeAfter, TriggerSyncPolicy: TriggerSyncPolicy{FullSync: true}}, applied)
+	assert.Equal(t, &timeAfter, syncPolicy.TimeAfter)
+
+	applied = (&ScopeSyncPolicy{TimeAfter: &time.Time{}}).Apply(syncPolicy)
+	assert.Nil(t, applied.TimeAfter)
+
+	syncPolicy.SkipCollectors = true
+	applied = (&ScopeSyncPolicy{}).Apply(syncPolicy)
+	assert.True(t, applied.SkipCollectors)
+}
+
+func TestBlueprintNotification(t *testing.T) {
+	assert.Nil(t, (&BlueprintNotification{Type: NOTIFICATION_CHANNEL_SLACK, Url: "https://hooks.slack.com/services/T/B/X"}).Validate())
+	assert.Nil(t, (&BlueprintNotification{Type: NOTIFICATION_CHANNEL_EMAIL, Recipients: []string{"dev@example.com"}}).Validate())
+	assert.NotNil(t, (&BlueprintNotification{Type: NOTIFICATION_CHANNEL_TEAMS, Url: "teams"}).Validate())
+	assert.NotNil(t, (&BlueprintNotification{Type: NOTIFICATION_CHANNEL_EMAIL}).Validate())
+	assert.NotNil(t, (&BlueprintNotification{Type: "pager"}).Validate())
+	assert.NotNil(t, (&BlueprintNotification{Type: NOTIFICATION_CHANNEL_WEBHOOK, Url: "http://example.com", On: []string{TASK_RUNNING}}).Validate())
+
+	byDefault := &BlueprintNotification{Type: NOTIFICATION_CHANNEL_SLACK}
+	assert.True(t, byDefault.NotifiesOn(TASK_FAILED))
+	assert.True(t, byDefault.NotifiesOn(TASK_PARTIAL))
+	assert.False(t, byDefault.NotifiesOn(TASK_COMPLETED))
+	onSuccess := &BlueprintNotification{Type: NOTIFICATION_CHANNEL_SLACK, On: []string{TASK_COMPLETED}}
+	assert.True(t, onSuccess.NotifiesOn(TASK_COMPLETED))
+	assert.False(t, onSuccess.NotifiesOn(TASK_FAILED))
+}
@@ -28,6 +28,8 @@ type CollectorLatestState struct {
 	RawDataTable       string    `gorm:"primaryKey;column:raw_data_table;type:varchar(255)" json:"raw_data_table"`
 	TimeAfter          *time.Time
 	LatestSuccessStart *time.Time
+	// LatestReconcileStart is when the deleted records were last reconciled against the source
+	LatestReconcileStart *time.Time
 }
 
 type LatestSyncState struct {
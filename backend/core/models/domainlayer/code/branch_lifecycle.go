@@ -0,0 +1,43 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package code
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// BranchLifecycle tracks when a branch was created and deleted by comparing successive snapshots of the repo
+type BranchLifecycle struct {
+	common.NoPKModel
+	RepoId         string     `json:"repoId" gorm:"primaryKey;type:varchar(255)"`
+	BranchName     string     `json:"branchName" gorm:"primaryKey;type:varchar(255)"`
+	IsDefault      bool       `json:"isDefault"`
+	HeadCommitSha  string     `json:"headCommitSha" gorm:"type:varchar(40)"`
+	HeadCommitDate *time.Time `json:"headCommitDate"`
+	ForkCommitSha  string     `json:"forkCommitSha" gorm:"type:varchar(40);comment:merge base with the default branch"`
+	ForkCommitDate *time.Time `json:"forkCommitDate"`
+	CreatedDate    *time.Time `json:"createdDate" gorm:"comment:when the branch was first seen, or the fork date for branches found by the first snapshot"`
+	LastSeenDate   *time.Time `json:"lastSeenDate"`
+	DeletedDate    *time.Time `json:"deletedDate" gorm:"comment:when the branch was found missing"`
+}
+
+func (BranchLifecycle) TableName() string {
+	return "branch_lifecycles"
+}
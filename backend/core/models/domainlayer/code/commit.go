@@ -39,6 +39,8 @@ type Commit struct {
 	CommitterEmail string `gorm:"type:varchar(255)"`
 	CommittedDate  time.Time
 	CommitterId    string `gorm:"index;type:varchar(255)"`
+	SignatureType  string `json:"signatureType" gorm:"type:varchar(20);comment:GPG or SSH, empty if the commit is not signed"`
+	Verified       bool   `json:"verified" gorm:"comment:the signature was verified against the configured signing keys"`
 }
 
 func (Commit) TableName() string {
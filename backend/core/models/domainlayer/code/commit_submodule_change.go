@@ -0,0 +1,42 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package code
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+const (
+	SUBMODULE_ADDED   = "ADDED"
+	SUBMODULE_UPDATED = "UPDATED"
+	SUBMODULE_REMOVED = "REMOVED"
+)
+
+// CommitSubmoduleChange records a submodule pointer (gitlink) change introduced by a commit
+type CommitSubmoduleChange struct {
+	common.NoPKModel
+	CommitSha    string `json:"commitSha" gorm:"primaryKey;type:varchar(40);comment:commit hash"`
+	Path         string `json:"path" gorm:"primaryKey;type:varchar(255);comment:submodule path in the parent repo"`
+	ChangeType   string `json:"changeType" gorm:"type:varchar(20)"`
+	OldCommitSha string `json:"oldCommitSha" gorm:"type:varchar(40);comment:submodule commit before the change"`
+	NewCommitSha string `json:"newCommitSha" gorm:"type:varchar(40);comment:submodule commit after the change"`
+}
+
+func (CommitSubmoduleChange) TableName() string {
+	return "commit_submodule_changes"
+}
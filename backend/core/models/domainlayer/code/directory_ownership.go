@@ -0,0 +1,43 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package code
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// DirectoryOwnership is a snapshot of the top contributors of a directory, ranked by the lines they own at the HEAD.
+// The root directory of the repo is recorded as `.`
+type DirectoryOwnership struct {
+	common.NoPKModel
+	RepoId        string     `json:"repoId" gorm:"primaryKey;type:varchar(255)"`
+	Directory     string     `json:"directory" gorm:"primaryKey;type:varchar(255)"`
+	AuthorEmail   string     `json:"authorEmail" gorm:"primaryKey;type:varchar(255)"`
+	AuthorName    string     `json:"authorName" gorm:"type:varchar(255)"`
+	Rank          int        `json:"rank"`
+	Lines         int        `json:"lines" gorm:"comment:surviving lines last modified by the author"`
+	TotalLines    int        `json:"totalLines" gorm:"comment:surviving lines of the directory"`
+	LastTouchedAt *time.Time `json:"lastTouchedAt" gorm:"comment:last time the author modified a surviving line of the directory"`
+	CommitSha     string     `json:"commitSha" gorm:"type:varchar(40);comment:the HEAD the snapshot was taken at"`
+}
+
+func (DirectoryOwnership) TableName() string {
+	return "directory_ownerships"
+}
@@ -31,6 +31,30 @@ const (
 	MERGED = "MERGED"
 )
 
+const (
+	PR_SIZE_XS = "XS"
+	PR_SIZE_S  = "S"
+	PR_SIZE_M  = "M"
+	PR_SIZE_L  = "L"
+	PR_SIZE_XL = "XL"
+)
+
+// ClassifyPullRequestSize buckets a pull request by the number of lines it changes
+func ClassifyPullRequestSize(linesChanged int) string {
+	switch {
+	case linesChanged <= 10:
+		return PR_SIZE_XS
+	case linesChanged <= 100:
+		return PR_SIZE_S
+	case linesChanged <= 500:
+		return PR_SIZE_M
+	case linesChanged <= 1000:
+		return PR_SIZE_L
+	default:
+		return PR_SIZE_XL
+	}
+}
+
 type PullRequest struct {
 	domainlayer.DomainEntity
 	BaseRepoId     string `gorm:"index"`
@@ -60,6 +84,11 @@ type PullRequest struct {
 	Additions      int
 	Deletions      int
 	IsDraft        bool
+	// size and risk classification, see ClassifyPullRequestSize
+	SizeBucket        string `gorm:"type:varchar(20)"`
+	ChangedFiles      int
+	IncludesMigration bool
+	IncludesInfra     bool
 }
 
 func (PullRequest) TableName() string {
@@ -0,0 +1,39 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package code
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// PullRequestReviewMetric is the companion of pull_requests holding review responsiveness figures,
+// computed from pull_request_comments and pull_request_reviewers after conversion
+type PullRequestReviewMetric struct {
+	PullRequestId    string `gorm:"primaryKey;type:varchar(255)"`
+	FirstReviewDate  *time.Time
+	ApprovedDate     *time.Time
+	ReviewRoundCount int
+	ReviewerCount    int
+	common.NoPKModel
+}
+
+func (PullRequestReviewMetric) TableName() string {
+	return "pull_request_review_metrics"
+}
@@ -31,6 +31,9 @@ type Ref struct {
 	IsDefault   bool
 	RefType     string `gorm:"type:varchar(255)"`
 	CreatedDate *time.Time
+	// SignatureType and Verified are only populated for signed annotated tags
+	SignatureType string `gorm:"type:varchar(20)"`
+	Verified      bool
 }
 
 func (Ref) TableName() string {
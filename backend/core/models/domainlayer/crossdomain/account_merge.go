@@ -0,0 +1,34 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crossdomain
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// AccountMerge is a manual decision to attach an account to a user. Merges take precedence over the automated
+// identity resolution and are re-applied to user_accounts every time it runs.
+type AccountMerge struct {
+	AccountId string `gorm:"primaryKey;type:varchar(255)" json:"accountId"`
+	UserId    string `gorm:"index;type:varchar(255)" json:"userId"`
+	common.NoPKModel
+}
+
+func (AccountMerge) TableName() string {
+	return "account_merges"
+}
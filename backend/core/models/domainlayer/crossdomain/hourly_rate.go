@@ -0,0 +1,40 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crossdomain
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+const (
+	HOURLY_RATE_USER = "USER"
+	HOURLY_RATE_TEAM = "TEAM"
+)
+
+// HourlyRate is the cost of an hour of work of a user or, as a fallback for its members, of a team.
+// All rates are expected to be expressed in the same currency.
+type HourlyRate struct {
+	SubjectType string  `gorm:"primaryKey;type:varchar(20)" json:"subjectType"`
+	SubjectId   string  `gorm:"primaryKey;type:varchar(255)" json:"subjectId"`
+	Rate        float64 `json:"rate"`
+	common.NoPKModel
+}
+
+func (HourlyRate) TableName() string {
+	return "hourly_rates"
+}
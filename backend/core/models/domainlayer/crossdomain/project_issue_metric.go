@@ -0,0 +1,49 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crossdomain
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+)
+
+// ProjectIssueMetric breaks the cycle time of an issue down into stages, based on the project_pr_metrics of the
+// pull requests linked to it, all spans are in minutes
+type ProjectIssueMetric struct {
+	domainlayer.DomainEntity
+	ProjectName string `gorm:"primaryKey;type:varchar(100)"`
+	PrCount     int
+	CodingTime  *int64 // first commit authored -> first pull request created
+	PickupTime  *int64 // first pull request created -> first review
+	ReviewTime  *int64 // first review -> last pull request merged
+	DeployTime  *int64 // last pull request merged -> last pull request deployed
+	CycleTime   *int64 // first commit authored -> last pull request deployed
+	LeadTime    *int64 // issue created -> last pull request deployed
+
+	IssueCreatedDate        *time.Time
+	FirstCommitAuthoredDate *time.Time
+	FirstPrCreatedDate      *time.Time
+	FirstReviewDate         *time.Time
+	LastPrMergedDate        *time.Time
+	DeployedDate            *time.Time
+}
+
+func (ProjectIssueMetric) TableName() string {
+	return "project_issue_metrics"
+}
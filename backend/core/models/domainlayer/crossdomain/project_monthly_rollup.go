@@ -0,0 +1,45 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crossdomain
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// ProjectMonthlyRollup is the monthly summary of a project refreshed after each successful run of its blueprint.
+// Month is the first day of the month in UTC.
+type ProjectMonthlyRollup struct {
+	ProjectName string    `gorm:"primaryKey;type:varchar(100)"`
+	Month       time.Time `gorm:"primaryKey"`
+	PrOpened    int
+	PrMerged    int
+	// AvgPrCycleTimeMinutes is the average cycle time of the pull requests merged during the month
+	AvgPrCycleTimeMinutes float64
+	// Deployments and DeploymentDays only count successful production deployments
+	Deployments    int
+	DeploymentDays int
+	BugsCreated    int
+	BugsResolved   int
+	common.NoPKModel
+}
+
+func (ProjectMonthlyRollup) TableName() string {
+	return "project_monthly_rollups"
+}
@@ -0,0 +1,76 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crossdomain
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+)
+
+// Release is a version of a product, either a code release (a tag of a repo, RepoId and CommitSha are set) or a
+// version planned in an issue tracker (BoardId is set)
+type Release struct {
+	domainlayer.DomainEntity
+	Name         string `gorm:"type:varchar(255)"`
+	TagName      string `gorm:"type:varchar(255)"`
+	Description  string
+	Url          string `gorm:"type:varchar(255)"`
+	ReleasedDate *time.Time
+	RepoId       string `gorm:"index;type:varchar(255)"`
+	CommitSha    string `gorm:"type:varchar(255)"`
+	BoardId      string `gorm:"index;type:varchar(255)"`
+}
+
+func (Release) TableName() string {
+	return "releases"
+}
+
+// ReleaseDeployment is a deployment shipping the commit of a release
+type ReleaseDeployment struct {
+	ReleaseId    string `gorm:"primaryKey;type:varchar(255)"`
+	DeploymentId string `gorm:"primaryKey;type:varchar(255)"`
+	common.NoPKModel
+}
+
+func (ReleaseDeployment) TableName() string {
+	return "release_deployments"
+}
+
+// ReleasePullRequest is a pull request merged between the previous release and this one
+type ReleasePullRequest struct {
+	ReleaseId     string `gorm:"primaryKey;type:varchar(255)"`
+	PullRequestId string `gorm:"primaryKey;type:varchar(255)"`
+	common.NoPKModel
+}
+
+func (ReleasePullRequest) TableName() string {
+	return "release_pull_requests"
+}
+
+// ReleaseIssue is an issue fixed by a release, either planned for the version or linked to a pull request of it
+type ReleaseIssue struct {
+	ReleaseId string `gorm:"primaryKey;type:varchar(255)"`
+	IssueId   string `gorm:"primaryKey;type:varchar(255)"`
+	common.NoPKModel
+}
+
+func (ReleaseIssue) TableName() string {
+	return "release_issues"
+}
@@ -0,0 +1,62 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crossdomain
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// Service is an entry of the service/component catalog, metrics can be rolled up per service through
+// the repos, cicd scopes, boards and deployment environments mapped onto it
+type Service struct {
+	Name        string `gorm:"primaryKey;type:varchar(255)" json:"name" validate:"required"`
+	Description string `json:"description"`
+	TeamId      string `gorm:"type:varchar(255)" json:"teamId"`
+	common.NoPKModel
+}
+
+func (Service) TableName() string {
+	return "services"
+}
+
+const (
+	SERVICE_MAPPING_REPOS        = "repos"
+	SERVICE_MAPPING_CICD_SCOPES  = "cicd_scopes"
+	SERVICE_MAPPING_BOARDS       = "boards"
+	SERVICE_MAPPING_ENVIRONMENTS = "environments"
+)
+
+// ServiceMappingTables lists the tables a service can be mapped onto, incident services are boards and
+// the row id of an environment is the environment name of cicd_deployment_commits
+var ServiceMappingTables = []string{
+	SERVICE_MAPPING_REPOS,
+	SERVICE_MAPPING_CICD_SCOPES,
+	SERVICE_MAPPING_BOARDS,
+	SERVICE_MAPPING_ENVIRONMENTS,
+}
+
+type ServiceMapping struct {
+	ServiceName string `gorm:"primaryKey;type:varchar(255)" json:"serviceName"`
+	Table       string `gorm:"primaryKey;type:varchar(255)" json:"table"`
+	RowId       string `gorm:"primaryKey;type:varchar(255)" json:"rowId"`
+	common.NoPKModel
+}
+
+func (ServiceMapping) TableName() string {
+	return "service_mapping"
+}
@@ -36,11 +36,15 @@ func GetDomainTablesInfo() []dal.Tabler {
 		&code.CommitParent{},
 		&code.Component{},
 		&code.CommitLineChange{},
+		&code.CommitSubmoduleChange{},
+		&code.DirectoryOwnership{},
+		&code.BranchLifecycle{},
 		&code.PullRequest{},
 		&code.PullRequestComment{},
 		&code.PullRequestCommit{},
 		&code.PullRequestLabel{},
 		&code.PullRequestReviewer{},
+		&code.PullRequestReviewMetric{},
 		&code.PullRequestAssignee{},
 		&code.Ref{},
 		&code.CommitsDiff{},
@@ -58,15 +62,26 @@ func GetDomainTablesInfo() []dal.Tabler {
 		&codequality.CqProject{},
 		// crossdomain
 		&crossdomain.Account{},
+		&crossdomain.AccountMerge{},
 		&crossdomain.BoardRepo{},
+		&crossdomain.HourlyRate{},
 		&crossdomain.IssueCommit{},
 		&crossdomain.IssueRepoCommit{},
 		&crossdomain.ProjectMapping{},
+		&crossdomain.ProjectMonthlyRollup{},
+		&crossdomain.Service{},
+		&crossdomain.ServiceMapping{},
 		&crossdomain.ProjectIncidentDeploymentRelationship{},
+		&crossdomain.ProjectIssueMetric{},
 		&crossdomain.ProjectPrMetric{},
 		&crossdomain.PullRequestIssue{},
 		&crossdomain.RefsIssuesDiffs{},
+		&crossdomain.Release{},
+		&crossdomain.ReleaseDeployment{},
+		&crossdomain.ReleaseIssue{},
+		&crossdomain.ReleasePullRequest{},
 		&crossdomain.Team{},
+		&crossdomain.TeamMapping{},
 		&crossdomain.TeamUser{},
 		&crossdomain.User{},
 		&crossdomain.UserAccount{},
@@ -83,18 +98,25 @@ func GetDomainTablesInfo() []dal.Tabler {
 		&ticket.Board{},
 		&ticket.BoardIssue{},
 		&ticket.BoardSprint{},
+		&ticket.BoardStatusSnapshot{},
+		&ticket.BoardFlowSnapshot{},
 		&ticket.Issue{},
 		&ticket.IssueChangelogs{},
+		&ticket.IssueStatusChange{},
 		&ticket.IssueComment{},
 		&ticket.IssueLabel{},
 		&ticket.IssueWorklog{},
+		&ticket.EffortCost{},
 		&ticket.Sprint{},
 		&ticket.SprintIssue{},
+		&ticket.SprintMetric{},
 		&ticket.IssueAssignee{},
 		&ticket.IssueRelationship{},
 		&ticket.IssueCustomArrayField{},
 		&ticket.Incident{},
 		&ticket.IncidentAssignee{},
+		&ticket.Postmortem{},
+		&ticket.PostmortemContributingFactor{},
 		// qa
 		&qa.QaProject{},
 		&qa.QaApi{},
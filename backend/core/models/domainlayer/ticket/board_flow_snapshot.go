@@ -0,0 +1,60 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ticket
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// BoardStatusSnapshot is the number of issues of a board sitting in a given column (original status) at the end of
+// a day, the building block of cumulative flow diagrams
+type BoardStatusSnapshot struct {
+	BoardId        string    `gorm:"primaryKey;type:varchar(255)"`
+	SnapshotDate   time.Time `gorm:"primaryKey"`
+	OriginalStatus string    `gorm:"primaryKey;type:varchar(255)"`
+	Status         string    `gorm:"type:varchar(100)"`
+	IssueCount     int
+	StoryPoints    float64
+	// AvgAgeDays and MaxAgeDays are how long, in days, the issues have been in the column
+	AvgAgeDays float64
+	MaxAgeDays float64
+	common.NoPKModel
+}
+
+func (BoardStatusSnapshot) TableName() string {
+	return "board_status_snapshots"
+}
+
+// BoardFlowSnapshot is the daily WIP and throughput of a board
+type BoardFlowSnapshot struct {
+	BoardId      string    `gorm:"primaryKey;type:varchar(255)"`
+	SnapshotDate time.Time `gorm:"primaryKey"`
+	WipIssues    int
+	WipPoints    float64
+	// AvgWipAgeDays is the average number of days the WIP issues have spent since they were first put IN_PROGRESS
+	AvgWipAgeDays    float64
+	ThroughputIssues int
+	ThroughputPoints float64
+	common.NoPKModel
+}
+
+func (BoardFlowSnapshot) TableName() string {
+	return "board_flow_snapshots"
+}
@@ -0,0 +1,44 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ticket
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+const (
+	EFFORT_COST_ISSUE   = "ISSUE"
+	EFFORT_COST_EPIC    = "EPIC"
+	EFFORT_COST_PROJECT = "PROJECT"
+)
+
+// EffortCost rolls up the worklogs of a project per issue, epic (ItemId is the epic key) and for the whole project
+// (ItemId is the project name). CostOfDelay is the part of the cost logged after the issue was due.
+type EffortCost struct {
+	ProjectName      string `gorm:"primaryKey;type:varchar(255)"`
+	Level            string `gorm:"primaryKey;type:varchar(20)"`
+	ItemId           string `gorm:"primaryKey;type:varchar(255)"`
+	TimeSpentMinutes int
+	Cost             float64
+	CostOfDelay      float64
+	common.NoPKModel
+}
+
+func (EffortCost) TableName() string {
+	return "effort_costs"
+}
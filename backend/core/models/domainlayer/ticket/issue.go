@@ -68,6 +68,7 @@ const (
 	INCIDENT    = "INCIDENT"
 	TASK        = "TASK"
 	SUBTASK     = "SUBTASK"
+	POSTMORTEM  = "POSTMORTEM"
 
 	// status
 	TODO        = "TODO"
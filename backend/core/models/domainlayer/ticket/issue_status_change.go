@@ -0,0 +1,58 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ticket
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+)
+
+// IssueStatusChange is a status transition of an issue, produced by the converters of each tracker from its
+// changelogs/events, so time-in-status can be analyzed the same way across trackers.
+// FromStatus/ToStatus are the standard statuses (TODO, IN_PROGRESS, DONE, OTHER), FromStatus is empty for the initial status
+type IssueStatusChange struct {
+	domainlayer.DomainEntity
+	IssueId            string `gorm:"index;type:varchar(255)"`
+	AuthorId           string `gorm:"type:varchar(255)"`
+	AuthorName         string `gorm:"type:varchar(255)"`
+	FromStatus         string `gorm:"type:varchar(100)"`
+	ToStatus           string `gorm:"type:varchar(100)"`
+	OriginalFromStatus string `gorm:"type:varchar(255)"`
+	OriginalToStatus   string `gorm:"type:varchar(255)"`
+	ChangedDate        time.Time
+}
+
+func (IssueStatusChange) TableName() string {
+	return "issue_status_changes"
+}
+
+// NewIssueStatusChange builds the status change recorded by a status changelog, the id is shared with the changelog
+func NewIssueStatusChange(changelog *IssueChangelogs) *IssueStatusChange {
+	return &IssueStatusChange{
+		DomainEntity:       changelog.DomainEntity,
+		IssueId:            changelog.IssueId,
+		AuthorId:           changelog.AuthorId,
+		AuthorName:         changelog.AuthorName,
+		FromStatus:         changelog.FromValue,
+		ToStatus:           changelog.ToValue,
+		OriginalFromStatus: changelog.OriginalFromValue,
+		OriginalToStatus:   changelog.OriginalToValue,
+		ChangedDate:        changelog.CreatedDate,
+	}
+}
@@ -0,0 +1,55 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ticket
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+)
+
+// Postmortem is the retrospective of an incident. CompletedDate is nil until the postmortem is done, and the
+// action items are the follow-up tasks it produced.
+type Postmortem struct {
+	domainlayer.DomainEntity
+	IncidentId               string `gorm:"index;type:varchar(255)"`
+	Title                    string
+	Url                      string `gorm:"type:varchar(255)"`
+	Status                   string `gorm:"type:varchar(100)"`
+	OriginalStatus           string `gorm:"type:varchar(100)"`
+	CreatedDate              *time.Time
+	CompletedDate            *time.Time
+	ActionItemCount          int
+	CompletedActionItemCount int
+}
+
+func (Postmortem) TableName() string {
+	return "postmortems"
+}
+
+// PostmortemContributingFactor tags a postmortem with a factor that contributed to the incident
+type PostmortemContributingFactor struct {
+	PostmortemId string `gorm:"primaryKey;type:varchar(255)"`
+	Factor       string `gorm:"primaryKey;type:varchar(255)"`
+	common.NoPKModel
+}
+
+func (PostmortemContributingFactor) TableName() string {
+	return "postmortem_contributing_factors"
+}
@@ -0,0 +1,50 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ticket
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// SprintMetric is the health of a sprint computed from the sprint membership history of its issues.
+// Committed issues were in the sprint when it started, added issues joined it after, removed issues left it
+// before it ended, and carryover issues stayed in it without being completed by its end.
+type SprintMetric struct {
+	SprintId        string `gorm:"primaryKey;type:varchar(255)"`
+	BoardId         string `gorm:"index;type:varchar(255)"`
+	SprintName      string `gorm:"type:varchar(255)"`
+	StartedDate     *time.Time
+	EndedDate       *time.Time
+	CommittedIssues int
+	CommittedPoints float64
+	AddedIssues     int
+	AddedPoints     float64
+	RemovedIssues   int
+	RemovedPoints   float64
+	CompletedIssues int
+	CompletedPoints float64
+	CarryoverIssues int
+	CarryoverPoints float64
+	common.NoPKModel
+}
+
+func (SprintMetric) TableName() string {
+	return "sprint_metrics"
+}
@@ -30,6 +30,8 @@ type IssueWorklog struct {
 	LoggedDate       *time.Time
 	StartedDate      *time.Time
 	IssueId          string `gorm:"index;type:varchar(255)"`
+	// Cost is the time spent priced at the hourly rate of the author (or the author's team), nil when no rate is known
+	Cost *float64
 }
 
 func (IssueWorklog) TableName() string {
@@ -0,0 +1,83 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addBoardFlowSnapshots)(nil)
+
+type boardStatusSnapshot20261014 struct {
+	BoardId        string    `gorm:"primaryKey;type:varchar(255)"`
+	SnapshotDate   time.Time `gorm:"primaryKey"`
+	OriginalStatus string    `gorm:"primaryKey;type:varchar(255)"`
+	Status         string    `gorm:"type:varchar(100)"`
+	IssueCount     int
+	StoryPoints    float64
+	AvgAgeDays     float64
+	MaxAgeDays     float64
+	archived.NoPKModel
+}
+
+func (boardStatusSnapshot20261014) TableName() string {
+	return "board_status_snapshots"
+}
+
+type boardFlowSnapshot20261014 struct {
+	BoardId          string    `gorm:"primaryKey;type:varchar(255)"`
+	SnapshotDate     time.Time `gorm:"primaryKey"`
+	WipIssues        int
+	WipPoints        float64
+	AvgWipAgeDays    float64
+	ThroughputIssues int
+	ThroughputPoints float64
+	archived.NoPKModel
+}
+
+func (boardFlowSnapshot20261014) TableName() string {
+	return "board_flow_snapshots"
+}
+
+type addBoardFlowSnapshots struct{}
+
+func (*addBoardFlowSnapshots) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		new(boardStatusSnapshot20261014),
+		new(boardFlowSnapshot20261014),
+	)
+}
+
+func (*addBoardFlowSnapshots) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropTables(new(boardStatusSnapshot20261014), new(boardFlowSnapshot20261014))
+}
+
+func (*addBoardFlowSnapshots) Version() uint64 {
+	return 20261014230000
+}
+
+func (*addBoardFlowSnapshots) Name() string {
+	return "add board_status_snapshots and board_flow_snapshots tables"
+}
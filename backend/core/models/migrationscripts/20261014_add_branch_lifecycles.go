@@ -0,0 +1,66 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addBranchLifecycles)(nil)
+
+type branchLifecycle20261014 struct {
+	archived.NoPKModel
+	RepoId         string `gorm:"primaryKey;type:varchar(255)"`
+	BranchName     string `gorm:"primaryKey;type:varchar(255)"`
+	IsDefault      bool
+	HeadCommitSha  string `gorm:"type:varchar(40)"`
+	HeadCommitDate *time.Time
+	ForkCommitSha  string `gorm:"type:varchar(40)"`
+	ForkCommitDate *time.Time
+	CreatedDate    *time.Time
+	LastSeenDate   *time.Time
+	DeletedDate    *time.Time
+}
+
+func (branchLifecycle20261014) TableName() string {
+	return "branch_lifecycles"
+}
+
+type addBranchLifecycles struct{}
+
+func (*addBranchLifecycles) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, new(branchLifecycle20261014))
+}
+
+func (*addBranchLifecycles) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropTables(new(branchLifecycle20261014))
+}
+
+func (*addBranchLifecycles) Version() uint64 {
+	return 20261014140000
+}
+
+func (*addBranchLifecycles) Name() string {
+	return "add branch_lifecycles table"
+}
@@ -0,0 +1,59 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addCommitSubmoduleChanges)(nil)
+
+type commitSubmoduleChange20261014 struct {
+	archived.NoPKModel
+	CommitSha    string `gorm:"primaryKey;type:varchar(40)"`
+	Path         string `gorm:"primaryKey;type:varchar(255)"`
+	ChangeType   string `gorm:"type:varchar(20)"`
+	OldCommitSha string `gorm:"type:varchar(40)"`
+	NewCommitSha string `gorm:"type:varchar(40)"`
+}
+
+func (commitSubmoduleChange20261014) TableName() string {
+	return "commit_submodule_changes"
+}
+
+type addCommitSubmoduleChanges struct{}
+
+func (*addCommitSubmoduleChanges) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, new(commitSubmoduleChange20261014))
+}
+
+func (*addCommitSubmoduleChanges) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropTables(new(commitSubmoduleChange20261014))
+}
+
+func (*addCommitSubmoduleChanges) Version() uint64 {
+	return 20261014100000
+}
+
+func (*addCommitSubmoduleChanges) Name() string {
+	return "add commit_submodule_changes table"
+}
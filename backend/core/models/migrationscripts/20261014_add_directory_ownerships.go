@@ -0,0 +1,65 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addDirectoryOwnerships)(nil)
+
+type directoryOwnership20261014 struct {
+	archived.NoPKModel
+	RepoId        string `gorm:"primaryKey;type:varchar(255)"`
+	Directory     string `gorm:"primaryKey;type:varchar(255)"`
+	AuthorEmail   string `gorm:"primaryKey;type:varchar(255)"`
+	AuthorName    string `gorm:"type:varchar(255)"`
+	Rank          int
+	Lines         int
+	TotalLines    int
+	LastTouchedAt *time.Time
+	CommitSha     string `gorm:"type:varchar(40)"`
+}
+
+func (directoryOwnership20261014) TableName() string {
+	return "directory_ownerships"
+}
+
+type addDirectoryOwnerships struct{}
+
+func (*addDirectoryOwnerships) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, new(directoryOwnership20261014))
+}
+
+func (*addDirectoryOwnerships) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropTables(new(directoryOwnership20261014))
+}
+
+func (*addDirectoryOwnerships) Version() uint64 {
+	return 20261014130000
+}
+
+func (*addDirectoryOwnerships) Name() string {
+	return "add directory_ownerships table"
+}
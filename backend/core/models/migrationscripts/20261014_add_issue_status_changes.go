@@ -0,0 +1,64 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addIssueStatusChanges)(nil)
+
+type issueStatusChange20261014 struct {
+	archived.DomainEntity
+	IssueId            string `gorm:"index;type:varchar(255)"`
+	AuthorId           string `gorm:"type:varchar(255)"`
+	AuthorName         string `gorm:"type:varchar(255)"`
+	FromStatus         string `gorm:"type:varchar(100)"`
+	ToStatus           string `gorm:"type:varchar(100)"`
+	OriginalFromStatus string `gorm:"type:varchar(255)"`
+	OriginalToStatus   string `gorm:"type:varchar(255)"`
+	ChangedDate        time.Time
+}
+
+func (issueStatusChange20261014) TableName() string {
+	return "issue_status_changes"
+}
+
+type addIssueStatusChanges struct{}
+
+func (*addIssueStatusChanges) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, new(issueStatusChange20261014))
+}
+
+func (*addIssueStatusChanges) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropTables(new(issueStatusChange20261014))
+}
+
+func (*addIssueStatusChanges) Version() uint64 {
+	return 20261014170000
+}
+
+func (*addIssueStatusChanges) Name() string {
+	return "add issue_status_changes table"
+}
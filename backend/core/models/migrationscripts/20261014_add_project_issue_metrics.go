@@ -0,0 +1,75 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addProjectIssueMetrics)(nil)
+
+type projectIssueMetric20261014 struct {
+	archived.DomainEntity
+	ProjectName             string `gorm:"primaryKey;type:varchar(100)"`
+	PrCount                 int
+	CodingTime              *int64
+	PickupTime              *int64
+	ReviewTime              *int64
+	DeployTime              *int64
+	CycleTime               *int64
+	LeadTime                *int64
+	IssueCreatedDate        *time.Time
+	FirstCommitAuthoredDate *time.Time
+	FirstPrCreatedDate      *time.Time
+	FirstReviewDate         *time.Time
+	LastPrMergedDate        *time.Time
+	DeployedDate            *time.Time
+}
+
+func (projectIssueMetric20261014) TableName() string {
+	return "project_issue_metrics"
+}
+
+type addProjectIssueMetrics struct{}
+
+func (*addProjectIssueMetrics) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, new(projectIssueMetric20261014))
+}
+
+func (*addProjectIssueMetrics) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropColumns(
+		projectIssueMetric20261014{}.TableName(),
+		"pr_count", "coding_time", "pickup_time", "review_time", "deploy_time", "cycle_time", "lead_time",
+		"issue_created_date", "first_commit_authored_date", "first_pr_created_date", "first_review_date",
+		"last_pr_merged_date", "deployed_date",
+	)
+}
+
+func (*addProjectIssueMetrics) Version() uint64 {
+	return 20261014160000
+}
+
+func (*addProjectIssueMetrics) Name() string {
+	return "add project_issue_metrics table"
+}
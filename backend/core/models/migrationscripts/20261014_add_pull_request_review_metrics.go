@@ -0,0 +1,61 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addPullRequestReviewMetrics)(nil)
+
+type pullRequestReviewMetric20261014 struct {
+	PullRequestId    string `gorm:"primaryKey;type:varchar(255)"`
+	FirstReviewDate  *time.Time
+	ApprovedDate     *time.Time
+	ReviewRoundCount int
+	ReviewerCount    int
+	archived.NoPKModel
+}
+
+func (pullRequestReviewMetric20261014) TableName() string {
+	return "pull_request_review_metrics"
+}
+
+type addPullRequestReviewMetrics struct{}
+
+func (*addPullRequestReviewMetrics) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, new(pullRequestReviewMetric20261014))
+}
+
+func (*addPullRequestReviewMetrics) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropTables(new(pullRequestReviewMetric20261014))
+}
+
+func (*addPullRequestReviewMetrics) Version() uint64 {
+	return 20261014180000
+}
+
+func (*addPullRequestReviewMetrics) Name() string {
+	return "add pull_request_review_metrics table"
+}
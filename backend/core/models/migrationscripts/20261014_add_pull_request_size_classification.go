@@ -0,0 +1,56 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addPullRequestSizeClassification)(nil)
+
+type pullRequest20261014 struct {
+	SizeBucket        string `gorm:"type:varchar(20)"`
+	ChangedFiles      int
+	IncludesMigration bool
+	IncludesInfra     bool
+}
+
+func (pullRequest20261014) TableName() string {
+	return "pull_requests"
+}
+
+type addPullRequestSizeClassification struct{}
+
+func (*addPullRequestSizeClassification) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, new(pullRequest20261014))
+}
+
+func (*addPullRequestSizeClassification) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropColumns(pullRequest20261014{}.TableName(), "size_bucket", "changed_files", "includes_migration", "includes_infra")
+}
+
+func (*addPullRequestSizeClassification) Version() uint64 {
+	return 20261014190000
+}
+
+func (*addPullRequestSizeClassification) Name() string {
+	return "add size_bucket, changed_files, includes_migration and includes_infra to pull_requests"
+}
@@ -0,0 +1,68 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addServices)(nil)
+
+type service20261014 struct {
+	Name        string `gorm:"primaryKey;type:varchar(255)"`
+	Description string
+	TeamId      string `gorm:"type:varchar(255)"`
+	archived.NoPKModel
+}
+
+func (service20261014) TableName() string {
+	return "services"
+}
+
+type serviceMapping20261014 struct {
+	ServiceName string `gorm:"primaryKey;type:varchar(255)"`
+	Table       string `gorm:"primaryKey;type:varchar(255)"`
+	RowId       string `gorm:"primaryKey;type:varchar(255)"`
+	archived.NoPKModel
+}
+
+func (serviceMapping20261014) TableName() string {
+	return "service_mapping"
+}
+
+type addServices struct{}
+
+func (*addServices) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, new(service20261014), new(serviceMapping20261014))
+}
+
+func (*addServices) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropTables(new(service20261014), new(serviceMapping20261014))
+}
+
+func (*addServices) Version() uint64 {
+	return 20261014200000
+}
+
+func (*addServices) Name() string {
+	return "add services and service_mapping tables"
+}
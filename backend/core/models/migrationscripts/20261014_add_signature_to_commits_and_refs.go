@@ -0,0 +1,68 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addSignatureToCommitsAndRefs)(nil)
+
+type commit20261014 struct {
+	SignatureType string `gorm:"type:varchar(20)"`
+	Verified      bool
+}
+
+func (commit20261014) TableName() string {
+	return "commits"
+}
+
+type ref20261014 struct {
+	SignatureType string `gorm:"type:varchar(20)"`
+	Verified      bool
+}
+
+func (ref20261014) TableName() string {
+	return "refs"
+}
+
+type addSignatureToCommitsAndRefs struct{}
+
+func (*addSignatureToCommitsAndRefs) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, new(commit20261014), new(ref20261014))
+}
+
+func (*addSignatureToCommitsAndRefs) Down(basicRes context.BasicRes) errors.Error {
+	db := basicRes.GetDal()
+	err := db.DropColumns(commit20261014{}.TableName(), "signature_type", "verified")
+	if err != nil {
+		return err
+	}
+	return db.DropColumns(ref20261014{}.TableName(), "signature_type", "verified")
+}
+
+func (*addSignatureToCommitsAndRefs) Version() uint64 {
+	return 20261014110000
+}
+
+func (*addSignatureToCommitsAndRefs) Name() string {
+	return "add signature_type and verified to commits and refs"
+}
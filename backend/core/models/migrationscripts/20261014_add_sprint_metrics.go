@@ -0,0 +1,71 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addSprintMetrics)(nil)
+
+type sprintMetric20261014 struct {
+	SprintId        string `gorm:"primaryKey;type:varchar(255)"`
+	BoardId         string `gorm:"index;type:varchar(255)"`
+	SprintName      string `gorm:"type:varchar(255)"`
+	StartedDate     *time.Time
+	EndedDate       *time.Time
+	CommittedIssues int
+	CommittedPoints float64
+	AddedIssues     int
+	AddedPoints     float64
+	RemovedIssues   int
+	RemovedPoints   float64
+	CompletedIssues int
+	CompletedPoints float64
+	CarryoverIssues int
+	CarryoverPoints float64
+	archived.NoPKModel
+}
+
+func (sprintMetric20261014) TableName() string {
+	return "sprint_metrics"
+}
+
+type addSprintMetrics struct{}
+
+func (*addSprintMetrics) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, new(sprintMetric20261014))
+}
+
+func (*addSprintMetrics) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropTables(new(sprintMetric20261014))
+}
+
+func (*addSprintMetrics) Version() uint64 {
+	return 20261014220000
+}
+
+func (*addSprintMetrics) Name() string {
+	return "add sprint_metrics table"
+}
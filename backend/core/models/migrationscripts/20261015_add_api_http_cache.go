@@ -0,0 +1,62 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addApiHttpCache)(nil)
+
+type apiHttpCache20261015 struct {
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	CacheKey      string `gorm:"primaryKey;type:varchar(64)"`
+	RawDataTable  string `gorm:"type:varchar(255)"`
+	RawDataParams string `gorm:"type:varchar(255);index"`
+	Url           string `gorm:"type:text"`
+	ETag          string `gorm:"column:etag;type:varchar(255)"`
+	LastModified  string `gorm:"type:varchar(100)"`
+}
+
+func (apiHttpCache20261015) TableName() string {
+	return "_devlake_api_http_cache"
+}
+
+type addApiHttpCache struct{}
+
+func (*addApiHttpCache) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, &apiHttpCache20261015{})
+}
+
+func (*addApiHttpCache) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropTables(&apiHttpCache20261015{})
+}
+
+func (*addApiHttpCache) Version() uint64 {
+	return 20261015170000
+}
+
+func (*addApiHttpCache) Name() string {
+	return "add api http cache"
+}
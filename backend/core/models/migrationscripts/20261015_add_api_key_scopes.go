@@ -0,0 +1,86 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addApiKeyScopes)(nil)
+
+type apiKey20261015 struct {
+	Creator           string `gorm:"type:varchar(255);uniqueIndex:idx__devlake_api_keys_creator_name"`
+	Name              string `gorm:"type:varchar(255);uniqueIndex:idx__devlake_api_keys_creator_name"`
+	ReadOnly          bool
+	Projects          string `gorm:"type:json"`
+	PreviousApiKey    string `gorm:"type:varchar(255);index"`
+	PreviousExpiredAt *time.Time
+}
+
+func (apiKey20261015) TableName() string {
+	return "_devlake_api_keys"
+}
+
+type apiKeyName20261015 struct {
+	Name string `gorm:"type:varchar(255);uniqueIndex"`
+}
+
+func (apiKeyName20261015) TableName() string {
+	return "_devlake_api_keys"
+}
+
+type addApiKeyScopes struct{}
+
+func (*addApiKeyScopes) Up(basicRes context.BasicRes) errors.Error {
+	// names are unique per user instead of globally
+	err := basicRes.GetDal().DropIndex("_devlake_api_keys", "name")
+	if err != nil {
+		return err
+	}
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&apiKey20261015{},
+	)
+}
+
+func (*addApiKeyScopes) Down(basicRes context.BasicRes) errors.Error {
+	db := basicRes.GetDal()
+	err := db.DropIndexes(apiKey20261015{}.TableName(), "idx__devlake_api_keys_creator_name")
+	if err != nil {
+		return err
+	}
+	err = db.DropColumns(apiKey20261015{}.TableName(), "read_only", "projects", "previous_api_key", "previous_expired_at")
+	if err != nil {
+		return err
+	}
+	// restore the global uniqueness of the names, it fails if two users picked the same name meanwhile
+	return migrationhelper.AutoMigrateTables(basicRes, &apiKeyName20261015{})
+}
+
+func (*addApiKeyScopes) Version() uint64 {
+	return 20261015140000
+}
+
+func (*addApiKeyScopes) Name() string {
+	return "add scopes and rotation to api keys"
+}
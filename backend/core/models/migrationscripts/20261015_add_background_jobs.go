@@ -0,0 +1,67 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addBackgroundJobs)(nil)
+
+type backgroundJob20261015 struct {
+	archived.Model
+	Plugin       string `gorm:"type:varchar(100);index"`
+	ConnectionId uint64 `gorm:"index"`
+	Type         string `gorm:"type:varchar(100)"`
+	Status       string `gorm:"type:varchar(20)"`
+	Total        int
+	Processed    int
+	Succeeded    int
+	Failures     json.RawMessage `gorm:"type:json"`
+	Message      string          `gorm:"type:text"`
+	FinishedAt   *time.Time
+}
+
+func (backgroundJob20261015) TableName() string {
+	return "_devlake_background_jobs"
+}
+
+type addBackgroundJobs struct{}
+
+func (*addBackgroundJobs) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, &backgroundJob20261015{})
+}
+
+func (*addBackgroundJobs) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropTables(&backgroundJob20261015{})
+}
+
+func (*addBackgroundJobs) Version() uint64 {
+	return 20261015200000
+}
+
+func (*addBackgroundJobs) Name() string {
+	return "add background jobs"
+}
@@ -0,0 +1,57 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addBlueprintCalendars)(nil)
+
+type blueprintCalendar20261015 struct {
+	Timezone        string `gorm:"type:varchar(100)"`
+	BlackoutWindows string `gorm:"type:json"`
+}
+
+func (blueprintCalendar20261015) TableName() string {
+	return "_devlake_blueprints"
+}
+
+type addBlueprintCalendars struct{}
+
+func (*addBlueprintCalendars) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&blueprintCalendar20261015{},
+	)
+}
+
+func (*addBlueprintCalendars) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropColumns(blueprintCalendar20261015{}.TableName(), "timezone", "blackout_windows")
+}
+
+func (*addBlueprintCalendars) Version() uint64 {
+	return 20261015090000
+}
+
+func (*addBlueprintCalendars) Name() string {
+	return "add timezone and blackout windows to blueprints"
+}
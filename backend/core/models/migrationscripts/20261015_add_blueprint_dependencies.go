@@ -0,0 +1,60 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addBlueprintDependencies)(nil)
+
+type blueprintDependency20261015 struct {
+	CreatedAt   time.Time
+	BlueprintId uint64 `gorm:"primaryKey"`
+	DependsOnId uint64 `gorm:"primaryKey;index"`
+}
+
+func (blueprintDependency20261015) TableName() string {
+	return "_devlake_blueprint_dependencies"
+}
+
+type addBlueprintDependencies struct{}
+
+func (*addBlueprintDependencies) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&blueprintDependency20261015{},
+	)
+}
+
+func (*addBlueprintDependencies) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropTables(&blueprintDependency20261015{})
+}
+
+func (*addBlueprintDependencies) Version() uint64 {
+	return 20261015100000
+}
+
+func (*addBlueprintDependencies) Name() string {
+	return "add dependencies between blueprints"
+}
@@ -0,0 +1,79 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addPostmortems)(nil)
+
+type postmortem20261015 struct {
+	archived.DomainEntity
+	IncidentId               string `gorm:"index;type:varchar(255)"`
+	Title                    string
+	Url                      string `gorm:"type:varchar(255)"`
+	Status                   string `gorm:"type:varchar(100)"`
+	OriginalStatus           string `gorm:"type:varchar(100)"`
+	CreatedDate              *time.Time
+	CompletedDate            *time.Time
+	ActionItemCount          int
+	CompletedActionItemCount int
+}
+
+func (postmortem20261015) TableName() string {
+	return "postmortems"
+}
+
+type postmortemContributingFactor20261015 struct {
+	PostmortemId string `gorm:"primaryKey;type:varchar(255)"`
+	Factor       string `gorm:"primaryKey;type:varchar(255)"`
+	archived.NoPKModel
+}
+
+func (postmortemContributingFactor20261015) TableName() string {
+	return "postmortem_contributing_factors"
+}
+
+type addPostmortems struct{}
+
+func (*addPostmortems) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		new(postmortem20261015),
+		new(postmortemContributingFactor20261015),
+	)
+}
+
+func (*addPostmortems) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropTables(new(postmortem20261015), new(postmortemContributingFactor20261015))
+}
+
+func (*addPostmortems) Version() uint64 {
+	return 20261015030000
+}
+
+func (*addPostmortems) Name() string {
+	return "add postmortems and postmortem_contributing_factors tables"
+}
@@ -0,0 +1,65 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addProjectMonthlyRollups)(nil)
+
+type projectMonthlyRollup20261015 struct {
+	ProjectName           string    `gorm:"primaryKey;type:varchar(100)"`
+	Month                 time.Time `gorm:"primaryKey"`
+	PrOpened              int
+	PrMerged              int
+	AvgPrCycleTimeMinutes float64
+	Deployments           int
+	DeploymentDays        int
+	BugsCreated           int
+	BugsResolved          int
+	archived.NoPKModel
+}
+
+func (projectMonthlyRollup20261015) TableName() string {
+	return "project_monthly_rollups"
+}
+
+type addProjectMonthlyRollups struct{}
+
+func (*addProjectMonthlyRollups) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, new(projectMonthlyRollup20261015))
+}
+
+func (*addProjectMonthlyRollups) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropTables(new(projectMonthlyRollup20261015))
+}
+
+func (*addProjectMonthlyRollups) Version() uint64 {
+	return 20261015020000
+}
+
+func (*addProjectMonthlyRollups) Name() string {
+	return "add project_monthly_rollups table"
+}
@@ -0,0 +1,86 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addRateLimitBudget)(nil)
+
+type blueprintRateLimitBudget20261015 struct {
+	RateLimitBudget string `gorm:"type:varchar(20)"`
+}
+
+func (blueprintRateLimitBudget20261015) TableName() string {
+	return "_devlake_blueprints"
+}
+
+type pipelineRateLimitBudget20261015 struct {
+	RateLimitBudget string `gorm:"type:varchar(20)"`
+}
+
+func (pipelineRateLimitBudget20261015) TableName() string {
+	return "_devlake_pipelines"
+}
+
+type taskStartDelay20261015 struct {
+	StartDelaySeconds int
+	StartAfter        *time.Time
+}
+
+func (taskStartDelay20261015) TableName() string {
+	return "_devlake_tasks"
+}
+
+type addRateLimitBudget struct{}
+
+func (*addRateLimitBudget) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&blueprintRateLimitBudget20261015{},
+		&pipelineRateLimitBudget20261015{},
+		&taskStartDelay20261015{},
+	)
+}
+
+func (*addRateLimitBudget) Down(basicRes context.BasicRes) errors.Error {
+	db := basicRes.GetDal()
+	err := db.DropColumns(blueprintRateLimitBudget20261015{}.TableName(), "rate_limit_budget")
+	if err != nil {
+		return err
+	}
+	err = db.DropColumns(pipelineRateLimitBudget20261015{}.TableName(), "rate_limit_budget")
+	if err != nil {
+		return err
+	}
+	return db.DropColumns(taskStartDelay20261015{}.TableName(), "start_delay_seconds", "start_after")
+}
+
+func (*addRateLimitBudget) Version() uint64 {
+	return 20261015160000
+}
+
+func (*addRateLimitBudget) Name() string {
+	return "add rate limit budget to blueprints and pipelines, and start delays to tasks"
+}
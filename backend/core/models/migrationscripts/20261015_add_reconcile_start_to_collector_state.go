@@ -0,0 +1,55 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addReconcileStartToCollectorState)(nil)
+
+type collectorLatestState20261015 struct {
+	LatestReconcileStart *time.Time
+}
+
+func (collectorLatestState20261015) TableName() string {
+	return "_devlake_collector_latest_state"
+}
+
+type addReconcileStartToCollectorState struct{}
+
+func (*addReconcileStartToCollectorState) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, &collectorLatestState20261015{})
+}
+
+func (*addReconcileStartToCollectorState) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropColumns(collectorLatestState20261015{}.TableName(), "latest_reconcile_start")
+}
+
+func (*addReconcileStartToCollectorState) Version() uint64 {
+	return 20261015180000
+}
+
+func (*addReconcileStartToCollectorState) Name() string {
+	return "add latest_reconcile_start to _devlake_collector_latest_state"
+}
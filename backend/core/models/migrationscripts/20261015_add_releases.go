@@ -0,0 +1,105 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addReleases)(nil)
+
+type release20261015 struct {
+	archived.DomainEntity
+	Name         string `gorm:"type:varchar(255)"`
+	TagName      string `gorm:"type:varchar(255)"`
+	Description  string
+	Url          string `gorm:"type:varchar(255)"`
+	ReleasedDate *time.Time
+	RepoId       string `gorm:"index;type:varchar(255)"`
+	CommitSha    string `gorm:"type:varchar(255)"`
+	BoardId      string `gorm:"index;type:varchar(255)"`
+}
+
+func (release20261015) TableName() string {
+	return "releases"
+}
+
+type releaseDeployment20261015 struct {
+	ReleaseId    string `gorm:"primaryKey;type:varchar(255)"`
+	DeploymentId string `gorm:"primaryKey;type:varchar(255)"`
+	archived.NoPKModel
+}
+
+func (releaseDeployment20261015) TableName() string {
+	return "release_deployments"
+}
+
+type releasePullRequest20261015 struct {
+	ReleaseId     string `gorm:"primaryKey;type:varchar(255)"`
+	PullRequestId string `gorm:"primaryKey;type:varchar(255)"`
+	archived.NoPKModel
+}
+
+func (releasePullRequest20261015) TableName() string {
+	return "release_pull_requests"
+}
+
+type releaseIssue20261015 struct {
+	ReleaseId string `gorm:"primaryKey;type:varchar(255)"`
+	IssueId   string `gorm:"primaryKey;type:varchar(255)"`
+	archived.NoPKModel
+}
+
+func (releaseIssue20261015) TableName() string {
+	return "release_issues"
+}
+
+type addReleases struct{}
+
+func (*addReleases) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		new(release20261015),
+		new(releaseDeployment20261015),
+		new(releasePullRequest20261015),
+		new(releaseIssue20261015),
+	)
+}
+
+func (*addReleases) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropTables(
+		new(release20261015),
+		new(releaseDeployment20261015),
+		new(releasePullRequest20261015),
+		new(releaseIssue20261015),
+	)
+}
+
+func (*addReleases) Version() uint64 {
+	return 20261015040000
+}
+
+func (*addReleases) Name() string {
+	return "add releases, release_deployments, release_pull_requests and release_issues tables"
+}
@@ -0,0 +1,64 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addRetentionPolicies)(nil)
+
+type retentionPolicy20261015 struct {
+	archived.Model
+	Plugin         string `gorm:"type:varchar(100);uniqueIndex:idx__devlake_retention_policies_plugin_class"`
+	TableClass     string `gorm:"type:varchar(20);uniqueIndex:idx__devlake_retention_policies_plugin_class"`
+	Days           int
+	LastPrunedAt   *time.Time
+	LastPrunedRows int64
+}
+
+func (retentionPolicy20261015) TableName() string {
+	return "_devlake_retention_policies"
+}
+
+type addRetentionPolicies struct{}
+
+func (*addRetentionPolicies) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&retentionPolicy20261015{},
+	)
+}
+
+func (*addRetentionPolicies) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropTables(&retentionPolicy20261015{})
+}
+
+func (*addRetentionPolicies) Version() uint64 {
+	return 20261015150000
+}
+
+func (*addRetentionPolicies) Name() string {
+	return "add retention policies"
+}
@@ -0,0 +1,65 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addRoleBindings)(nil)
+
+type roleBinding20261015 struct {
+	archived.Model
+	Creator      string
+	CreatorEmail string
+	SubjectType  string `gorm:"type:varchar(20);index"`
+	Subject      string `gorm:"type:varchar(255);index"`
+	Role         string `gorm:"type:varchar(20)"`
+	ResourceType string `gorm:"type:varchar(20)"`
+	Plugin       string `gorm:"type:varchar(100)"`
+	ResourceId   string `gorm:"type:varchar(255)"`
+}
+
+func (roleBinding20261015) TableName() string {
+	return "_devlake_role_bindings"
+}
+
+type addRoleBindings struct{}
+
+func (*addRoleBindings) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&roleBinding20261015{},
+	)
+}
+
+func (*addRoleBindings) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropTables(&roleBinding20261015{})
+}
+
+func (*addRoleBindings) Version() uint64 {
+	return 20261015130000
+}
+
+func (*addRoleBindings) Name() string {
+	return "add role bindings"
+}
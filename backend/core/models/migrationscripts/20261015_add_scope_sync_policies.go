@@ -0,0 +1,70 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addScopeSyncPolicies)(nil)
+
+type blueprintScopeSyncPolicy20261015 struct {
+	SyncPolicy string `gorm:"type:json"`
+}
+
+func (blueprintScopeSyncPolicy20261015) TableName() string {
+	return "_devlake_blueprint_scopes"
+}
+
+type taskSyncPolicy20261015 struct {
+	SyncPolicy string `gorm:"type:json"`
+}
+
+func (taskSyncPolicy20261015) TableName() string {
+	return "_devlake_tasks"
+}
+
+type addScopeSyncPolicies struct{}
+
+func (*addScopeSyncPolicies) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&blueprintScopeSyncPolicy20261015{},
+		&taskSyncPolicy20261015{},
+	)
+}
+
+func (*addScopeSyncPolicies) Down(basicRes context.BasicRes) errors.Error {
+	db := basicRes.GetDal()
+	err := db.DropColumns(blueprintScopeSyncPolicy20261015{}.TableName(), "sync_policy")
+	if err != nil {
+		return err
+	}
+	return db.DropColumns(taskSyncPolicy20261015{}.TableName(), "sync_policy")
+}
+
+func (*addScopeSyncPolicies) Version() uint64 {
+	return 20261015080000
+}
+
+func (*addScopeSyncPolicies) Name() string {
+	return "add per-scope sync policy overrides to blueprint scopes and tasks"
+}
@@ -0,0 +1,86 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addTimeouts)(nil)
+
+type timeouts20261015 struct {
+	TaskTimeoutSeconds    int
+	SubtaskTimeoutSeconds int
+}
+
+type blueprintTimeouts20261015 struct {
+	timeouts20261015 `gorm:"embedded"`
+}
+
+func (blueprintTimeouts20261015) TableName() string {
+	return "_devlake_blueprints"
+}
+
+type pipelineTimeouts20261015 struct {
+	timeouts20261015 `gorm:"embedded"`
+}
+
+func (pipelineTimeouts20261015) TableName() string {
+	return "_devlake_pipelines"
+}
+
+type taskTimeout20261015 struct {
+	Timeout string `gorm:"type:json"`
+}
+
+func (taskTimeout20261015) TableName() string {
+	return "_devlake_tasks"
+}
+
+type addTimeouts struct{}
+
+func (*addTimeouts) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&blueprintTimeouts20261015{},
+		&pipelineTimeouts20261015{},
+		&taskTimeout20261015{},
+	)
+}
+
+func (*addTimeouts) Down(basicRes context.BasicRes) errors.Error {
+	db := basicRes.GetDal()
+	for _, table := range []string{blueprintTimeouts20261015{}.TableName(), pipelineTimeouts20261015{}.TableName()} {
+		err := db.DropColumns(table, "task_timeout_seconds", "subtask_timeout_seconds")
+		if err != nil {
+			return err
+		}
+	}
+	return db.DropColumns(taskTimeout20261015{}.TableName(), "timeout")
+}
+
+func (*addTimeouts) Version() uint64 {
+	return 20261015070000
+}
+
+func (*addTimeouts) Name() string {
+	return "add task and subtask timeouts to blueprints, pipelines and tasks"
+}
@@ -0,0 +1,65 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addWebhookDeliveries)(nil)
+
+type webhookDelivery20261015 struct {
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	Plugin       string `gorm:"primaryKey;type:varchar(100)"`
+	Event        string `gorm:"primaryKey;type:varchar(100)"`
+	ConnectionId uint64 `gorm:"primaryKey"`
+	DeliveryId   string `gorm:"primaryKey;type:varchar(255)"`
+	RawDataTable string `gorm:"type:varchar(255)"`
+	RawDataId    uint64
+	Status       string `gorm:"type:varchar(20);index"`
+	Attempts     int
+	Message      string `gorm:"type:text"`
+}
+
+func (webhookDelivery20261015) TableName() string {
+	return "_devlake_webhook_deliveries"
+}
+
+type addWebhookDeliveries struct{}
+
+func (*addWebhookDeliveries) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, &webhookDelivery20261015{})
+}
+
+func (*addWebhookDeliveries) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropTables(&webhookDelivery20261015{})
+}
+
+func (*addWebhookDeliveries) Version() uint64 {
+	return 20261015190000
+}
+
+func (*addWebhookDeliveries) Name() string {
+	return "add webhook deliveries"
+}
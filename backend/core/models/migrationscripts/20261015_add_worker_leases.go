@@ -0,0 +1,70 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addWorkerLeases)(nil)
+
+type pipelineLease20261015 struct {
+	WorkerId       string `gorm:"type:varchar(255)"`
+	LeaseExpiresAt *time.Time
+}
+
+func (pipelineLease20261015) TableName() string {
+	return "_devlake_pipelines"
+}
+
+type taskLease20261015 struct {
+	WorkerId       string `gorm:"type:varchar(255)"`
+	LeaseExpiresAt *time.Time
+}
+
+func (taskLease20261015) TableName() string {
+	return "_devlake_tasks"
+}
+
+type addWorkerLeases struct{}
+
+func (*addWorkerLeases) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, &pipelineLease20261015{}, &taskLease20261015{})
+}
+
+func (*addWorkerLeases) Down(basicRes context.BasicRes) errors.Error {
+	db := basicRes.GetDal()
+	err := db.DropColumns(pipelineLease20261015{}.TableName(), "worker_id", "lease_expires_at")
+	if err != nil {
+		return err
+	}
+	return db.DropColumns(taskLease20261015{}.TableName(), "worker_id", "lease_expires_at")
+}
+
+func (*addWorkerLeases) Version() uint64 {
+	return 20261015060000
+}
+
+func (*addWorkerLeases) Name() string {
+	return "add worker_id and lease_expires_at to _devlake_pipelines and _devlake_tasks"
+}
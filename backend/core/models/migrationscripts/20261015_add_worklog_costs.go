@@ -0,0 +1,89 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addWorklogCosts)(nil)
+
+type issueWorklog20261015 struct {
+	Cost *float64
+}
+
+func (issueWorklog20261015) TableName() string {
+	return "issue_worklogs"
+}
+
+type hourlyRate20261015 struct {
+	SubjectType string `gorm:"primaryKey;type:varchar(20)"`
+	SubjectId   string `gorm:"primaryKey;type:varchar(255)"`
+	Rate        float64
+	archived.NoPKModel
+}
+
+func (hourlyRate20261015) TableName() string {
+	return "hourly_rates"
+}
+
+type effortCost20261015 struct {
+	ProjectName      string `gorm:"primaryKey;type:varchar(255)"`
+	Level            string `gorm:"primaryKey;type:varchar(20)"`
+	ItemId           string `gorm:"primaryKey;type:varchar(255)"`
+	TimeSpentMinutes int
+	Cost             float64
+	CostOfDelay      float64
+	archived.NoPKModel
+}
+
+func (effortCost20261015) TableName() string {
+	return "effort_costs"
+}
+
+type addWorklogCosts struct{}
+
+func (*addWorklogCosts) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		new(issueWorklog20261015),
+		new(hourlyRate20261015),
+		new(effortCost20261015),
+	)
+}
+
+func (*addWorklogCosts) Down(basicRes context.BasicRes) errors.Error {
+	db := basicRes.GetDal()
+	err := db.DropTables(new(hourlyRate20261015), new(effortCost20261015))
+	if err != nil {
+		return err
+	}
+	return db.DropColumns(issueWorklog20261015{}.TableName(), "cost")
+}
+
+func (*addWorklogCosts) Version() uint64 {
+	return 20261015000000
+}
+
+func (*addWorklogCosts) Name() string {
+	return "add cost to issue_worklogs, hourly_rates and effort_costs tables"
+}
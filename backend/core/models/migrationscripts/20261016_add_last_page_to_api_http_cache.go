@@ -0,0 +1,53 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addLastPageToApiHttpCache)(nil)
+
+type apiHttpCache20261016 struct {
+	LastPage bool
+}
+
+func (apiHttpCache20261016) TableName() string {
+	return "_devlake_api_http_cache"
+}
+
+type addLastPageToApiHttpCache struct{}
+
+func (*addLastPageToApiHttpCache) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, &apiHttpCache20261016{})
+}
+
+func (*addLastPageToApiHttpCache) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropColumns(apiHttpCache20261016{}.TableName(), "last_page")
+}
+
+func (*addLastPageToApiHttpCache) Version() uint64 {
+	return 20261016090000
+}
+
+func (*addLastPageToApiHttpCache) Name() string {
+	return "add last_page to _devlake_api_http_cache"
+}
@@ -140,5 +140,39 @@ func All() []plugin.MigrationScript {
 		new(extendFieldSizeForCq),
 		new(addIssueFixVerion),
 		new(addPipelinePriority),
+		new(addCommitSubmoduleChanges),
+		new(addSignatureToCommitsAndRefs),
+		new(addDirectoryOwnerships),
+		new(addBranchLifecycles),
+		new(addProjectIssueMetrics),
+		new(addIssueStatusChanges),
+		new(addPullRequestReviewMetrics),
+		new(addPullRequestSizeClassification),
+		new(addServices),
+		new(addTeamMapping),
+		new(addSprintMetrics),
+		new(addBoardFlowSnapshots),
+		new(addWorklogCosts),
+		new(addAccountMerges),
+		new(addProjectMonthlyRollups),
+		new(addPostmortems),
+		new(addReleases),
+		new(addTaskRetryPolicy),
+		new(addWorkerLeases),
+		new(addTimeouts),
+		new(addScopeSyncPolicies),
+		new(addBlueprintCalendars),
+		new(addBlueprintDependencies),
+		new(addBlueprintNotifications),
+		new(addAuditLogs),
+		new(addRoleBindings),
+		new(addApiKeyScopes),
+		new(addRetentionPolicies),
+		new(addRateLimitBudget),
+		new(addApiHttpCache),
+		new(addReconcileStartToCollectorState),
+		new(addWebhookDeliveries),
+		new(addBackgroundJobs),
+		new(addLastPageToApiHttpCache),
 	}
 }
@@ -24,9 +24,13 @@ import (
 )
 
 type GenericPipelineTask[T any] struct {
-	Plugin   string   `json:"plugin" binding:"required"`
-	Subtasks []string `json:"subtasks"`
-	Options  T        `json:"options"`
+	Plugin      string         `json:"plugin" binding:"required"`
+	Subtasks    []string       `json:"subtasks"`
+	Options     T              `json:"options"`
+	RetryPolicy *RetryPolicy   `json:"retryPolicy,omitempty"`
+	Timeout     *TimeoutPolicy `json:"timeout,omitempty"`
+	// overrides the sync policy of the pipeline for the scope of the task
+	SyncPolicy *ScopeSyncPolicy `json:"syncPolicy,omitempty"`
 }
 
 // PipelineTask represents a smallest unit of execution inside a PipelinePlan
@@ -51,6 +55,13 @@ func (plan PipelinePlan) IsEmpty() bool {
 	return true
 }
 
+// Priority levels of pipelines, any integer is accepted and greater is higher
+const (
+	PIPELINE_PRIORITY_LOW    = -10
+	PIPELINE_PRIORITY_NORMAL = 0
+	PIPELINE_PRIORITY_HIGH   = 10
+)
+
 type Pipeline struct {
 	common.Model
 	Name          string       `json:"name" gorm:"index"`
@@ -67,7 +78,10 @@ type Pipeline struct {
 	Stage         int          `json:"stage"`
 	Labels        []string     `json:"labels" gorm:"-"`
 	Priority      int          `json:"priority"` // greater is higher
-	SyncPolicy    `gorm:"embedded"`
+	// the worker coordinating the pipeline and until when it holds the pipeline, renewed by its heartbeats
+	WorkerId       string     `json:"workerId"`
+	LeaseExpiresAt *time.Time `json:"leaseExpiresAt"`
+	SyncPolicy     `gorm:"embedded"`
 }
 
 // We use a 2D array because the request body must be an array of a set of tasks
@@ -0,0 +1,43 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// RETENTION_TABLE_CLASS_RAW is the only class of tables pruned, the tool layer tables are derived from the raw ones and
+// would be emptied by the next full collection anyway
+const RETENTION_TABLE_CLASS_RAW = "raw"
+
+// RetentionPolicy tells how many days the rows of a table class are kept, either for a single plugin or for all the
+// plugins without a policy of their own when Plugin is empty
+type RetentionPolicy struct {
+	common.Model
+	Plugin         string     `json:"plugin" gorm:"type:varchar(100);uniqueIndex:idx__devlake_retention_policies_plugin_class"`
+	TableClass     string     `json:"tableClass" gorm:"type:varchar(20);uniqueIndex:idx__devlake_retention_policies_plugin_class" validate:"oneof=raw"`
+	Days           int        `json:"days" validate:"min=0"`
+	LastPrunedAt   *time.Time `json:"lastPrunedAt"`
+	LastPrunedRows int64      `json:"lastPrunedRows"`
+}
+
+func (RetentionPolicy) TableName() string {
+	return "_devlake_retention_policies"
+}
@@ -0,0 +1,58 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+const (
+	// RETRY_ON_SERVER retries on 5xx responses other than 500, e.g. 502 Bad Gateway
+	RETRY_ON_SERVER = "server"
+	// RETRY_ON_RATE_LIMIT retries on 429 Too Many Requests
+	RETRY_ON_RATE_LIMIT = "rate_limit"
+	// RETRY_ON_TIMEOUT retries on timeouts and deadlines exceeded
+	RETRY_ON_TIMEOUT = "timeout"
+	// RETRY_ON_NETWORK retries on network errors like connection resets and dns failures
+	RETRY_ON_NETWORK = "network"
+	// RETRY_ON_ANY retries on any error but cancellation, bad input and authorization errors
+	RETRY_ON_ANY = "any"
+)
+
+// RetryPolicy retries the failed subtasks of a task, the subtask is rerun from scratch on every attempt
+type RetryPolicy struct {
+	// including the first attempt, 0 or 1 means no retry
+	MaxAttempts int `json:"maxAttempts"`
+	// wait before the second attempt, defaults to 10 seconds
+	BackoffSeconds int `json:"backoffSeconds"`
+	// the wait grows by the multiplier on every attempt, defaults to 2
+	BackoffMultiplier float64 `json:"backoffMultiplier"`
+	// 0 means no limit
+	MaxBackoffSeconds int `json:"maxBackoffSeconds"`
+	// error classes to retry on, defaults to server, rate_limit, timeout and network
+	RetryOn []string `json:"retryOn"`
+	// per subtask policies replacing the task policy, keyed by subtask name
+	Subtasks map[string]*RetryPolicy `json:"subtasks,omitempty"`
+}
+
+// ForSubtask returns the policy applied to the subtask
+func (p *RetryPolicy) ForSubtask(name string) *RetryPolicy {
+	if p == nil {
+		return nil
+	}
+	if subtaskPolicy, ok := p.Subtasks[name]; ok {
+		return subtaskPolicy
+	}
+	return p
+}
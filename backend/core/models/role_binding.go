@@ -0,0 +1,54 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import "github.com/apache/incubator-devlake/core/models/common"
+
+const (
+	ROLE_ADMIN      = "admin"
+	ROLE_MAINTAINER = "maintainer"
+	ROLE_VIEWER     = "viewer"
+)
+
+const (
+	RBAC_SUBJECT_USER    = "user"
+	RBAC_SUBJECT_API_KEY = "api-key"
+)
+
+const (
+	RBAC_RESOURCE_GLOBAL     = "global"
+	RBAC_RESOURCE_PROJECT    = "project"
+	RBAC_RESOURCE_CONNECTION = "connection"
+)
+
+// RoleBinding grants a role to a user (matched by name or email) or an api key (matched by id), either globally or
+// on a single project or plugin connection
+type RoleBinding struct {
+	common.Model
+	common.Creator
+	SubjectType  string `json:"subjectType" gorm:"type:varchar(20);index" validate:"oneof=user api-key"`
+	Subject      string `json:"subject" gorm:"type:varchar(255);index" validate:"required"`
+	Role         string `json:"role" gorm:"type:varchar(20)" validate:"oneof=admin maintainer viewer"`
+	ResourceType string `json:"resourceType" gorm:"type:varchar(20)" validate:"oneof=global project connection"`
+	Plugin       string `json:"plugin" gorm:"type:varchar(100)"`
+	ResourceId   string `json:"resourceId" gorm:"type:varchar(255)"`
+}
+
+func (RoleBinding) TableName() string {
+	return "_devlake_role_bindings"
+}
@@ -32,10 +32,15 @@ const (
 	TASK_FAILED    = "TASK_FAILED"
 	TASK_CANCELLED = "TASK_CANCELLED"
 	TASK_PARTIAL   = "TASK_PARTIAL"
+	// TASK_PAUSING is requested on running tasks, they pause after the current subtask
+	TASK_PAUSING = "TASK_PAUSING"
+	TASK_PAUSED  = "TASK_PAUSED"
+	// TASK_QUEUED tasks wait to be claimed by a worker in the distributed mode
+	TASK_QUEUED = "TASK_QUEUED"
 )
 
 var (
-	PendingTaskStatus  = []string{TASK_CREATED, TASK_RERUN, TASK_RUNNING}
+	PendingTaskStatus  = []string{TASK_CREATED, TASK_RERUN, TASK_RUNNING, TASK_PAUSED}
 	FinishedTaskStatus = []string{TASK_PARTIAL, TASK_CANCELLED, TASK_FAILED, TASK_COMPLETED}
 )
 
@@ -57,6 +62,8 @@ type NewTask struct {
 	PipelineRow int    `json:"-"`
 	PipelineCol int    `json:"-"`
 	IsRerun     bool   `json:"-"`
+	// StartDelaySeconds is planned by the rate limit budget of the pipeline
+	StartDelaySeconds int `json:"-"`
 }
 
 type Task struct {
@@ -64,6 +71,9 @@ type Task struct {
 	Plugin         string                 `json:"plugin" gorm:"index"`
 	Subtasks       []string               `json:"subtasks" gorm:"type:json;serializer:json"`
 	Options        map[string]interface{} `json:"options" gorm:"serializer:encdec"`
+	RetryPolicy    *RetryPolicy           `json:"retryPolicy,omitempty" gorm:"type:json;serializer:json"`
+	Timeout        *TimeoutPolicy         `json:"timeout,omitempty" gorm:"type:json;serializer:json"`
+	SyncPolicy     *ScopeSyncPolicy       `json:"syncPolicy,omitempty" gorm:"type:json;serializer:json"`
 	Status         string                 `json:"status"`
 	Message        string                 `json:"message"`
 	ErrorName      string                 `json:"errorName"`
@@ -77,6 +87,12 @@ type Task struct {
 	BeganAt       *time.Time `json:"beganAt"`
 	FinishedAt    *time.Time `json:"finishedAt" gorm:"index"`
 	SpentSeconds  int        `json:"spentSeconds"`
+	// the worker running the task and until when it holds the task, renewed by its heartbeats
+	WorkerId       string     `json:"workerId"`
+	LeaseExpiresAt *time.Time `json:"leaseExpiresAt"`
+	// planned by the rate limit budget of the pipeline, the task starts that long after its stage
+	StartDelaySeconds int        `json:"startDelaySeconds"`
+	StartAfter        *time.Time `json:"startAfter"`
 }
 
 func (Task) TableName() string {
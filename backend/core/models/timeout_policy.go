@@ -0,0 +1,61 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+// TimeoutPolicy fails the task or its subtasks once they run longer than the limits, 0 means no limit
+type TimeoutPolicy struct {
+	// limit of the whole task, subtasks included
+	TaskSeconds int `json:"taskSeconds"`
+	// limit of every subtask, retries included
+	SubtaskSeconds int `json:"subtaskSeconds"`
+	// per subtask limits replacing SubtaskSeconds, keyed by subtask name
+	Subtasks map[string]int `json:"subtasks,omitempty"`
+}
+
+// Timeouts of the tasks in the pipelines of a blueprint, the timeout policy of a task takes precedence
+type Timeouts struct {
+	TaskTimeoutSeconds    int `json:"taskTimeoutSeconds" yaml:"taskTimeoutSeconds"`
+	SubtaskTimeoutSeconds int `json:"subtaskTimeoutSeconds" yaml:"subtaskTimeoutSeconds"`
+}
+
+// TaskLimit returns the limit of the task in seconds, falling back to the pipeline timeouts
+func (p *TimeoutPolicy) TaskLimit(fallback *Timeouts) int {
+	if p != nil && p.TaskSeconds > 0 {
+		return p.TaskSeconds
+	}
+	if fallback != nil {
+		return fallback.TaskTimeoutSeconds
+	}
+	return 0
+}
+
+// SubtaskLimit returns the limit of the subtask in seconds, falling back to the pipeline timeouts
+func (p *TimeoutPolicy) SubtaskLimit(name string, fallback *Timeouts) int {
+	if p != nil {
+		if seconds, ok := p.Subtasks[name]; ok {
+			return seconds
+		}
+		if p.SubtaskSeconds > 0 {
+			return p.SubtaskSeconds
+		}
+	}
+	if fallback != nil {
+		return fallback.SubtaskTimeoutSeconds
+	}
+	return 0
+}
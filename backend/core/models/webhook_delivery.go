@@ -0,0 +1,47 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+)
+
+const (
+	WEBHOOK_DELIVERY_DONE   = "DONE"
+	WEBHOOK_DELIVERY_FAILED = "FAILED"
+)
+
+// WebhookDelivery tracks a payload pushed to a webhook receiver, the retries of a handled delivery are acknowledged
+// without being handled again, and the failed ones can be replayed from the raw table
+type WebhookDelivery struct {
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+	Plugin       string    `gorm:"primaryKey;type:varchar(100)" json:"plugin"`
+	Event        string    `gorm:"primaryKey;type:varchar(100)" json:"event"`
+	ConnectionId uint64    `gorm:"primaryKey" json:"connectionId"`
+	DeliveryId   string    `gorm:"primaryKey;type:varchar(255)" json:"deliveryId"`
+	RawDataTable string    `gorm:"type:varchar(255)" json:"rawDataTable"`
+	RawDataId    uint64    `json:"rawDataId"`
+	Status       string    `gorm:"type:varchar(20);index" json:"status"`
+	Attempts     int       `json:"attempts"`
+	Message      string    `gorm:"type:text" json:"message"`
+}
+
+func (WebhookDelivery) TableName() string {
+	return "_devlake_webhook_deliveries"
+}
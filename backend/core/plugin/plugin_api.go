@@ -30,6 +30,7 @@ type ApiResourceInput struct {
 	Params  map[string]string      // path variables
 	Query   url.Values             // query string
 	Body    map[string]interface{} // json body
+	RawBody []byte                 // raw json body, i.e. to verify the signature of a webhook delivery
 	Request *http.Request
 
 	User *common.User
@@ -18,6 +18,7 @@ limitations under the License.
 package plugin
 
 import (
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 
@@ -48,6 +49,18 @@ type ApiAuthenticator interface {
 	SetupAuthentication(request *http.Request) errors.Error
 }
 
+// TlsConnection is to be implemented by the concrete Connection which requires a custom TLS config for the
+// ApiClient created by NewApiClientFromConnection, i.e. client certificates for mutual TLS
+type TlsConnection interface {
+	GetTlsConfig() (*tls.Config, errors.Error)
+}
+
+// NoProxyConnection is to be implemented by the concrete Connection which reaches some hosts without its proxy
+type NoProxyConnection interface {
+	// GetNoProxy returns the comma separated hosts, domains or CIDRs to be reached directly
+	GetNoProxy() string
+}
+
 // TODO: deprecated, remove
 // ConnectionValidator represents the API Connection would validate its fields with customized logic
 type ConnectionValidator interface {
@@ -65,12 +78,14 @@ const (
 	AUTH_METHOD_BASIC  = "BasicAuth"
 	AUTH_METHOD_TOKEN  = "AccessToken"
 	AUTH_METHOD_APPKEY = "AppKey"
+	AUTH_METHOD_OAUTH2 = "OAuth2"
 )
 
 var ALL_AUTH = map[string]bool{
 	AUTH_METHOD_BASIC:  true,
 	AUTH_METHOD_TOKEN:  true,
 	AUTH_METHOD_APPKEY: true,
+	AUTH_METHOD_OAUTH2: true,
 }
 
 // MultiAuthenticator represents the API Connection supports multiple authorization methods
@@ -93,6 +108,11 @@ type AppKeyAuthenticator interface {
 	GetAppKeyAuthenticator() ApiAuthenticator
 }
 
+// OAuth2Authenticator represents OAuth 2.0 Bearer Authentication with a refreshable access token
+type OAuth2Authenticator interface {
+	GetOAuth2Authenticator() ApiAuthenticator
+}
+
 // Scope represents the top level entity for a data source, i.e. github repo,
 // gitlab project, jira board. They turn into repo, board in Domain Layer. In
 // Apache Devlake, a Project is essentially a set of these top level entities,
@@ -29,11 +29,21 @@ type MigrationScript interface {
 	Name() string
 }
 
+// ReversibleMigrationScript is a MigrationScript that is able to revert its own changes, which allows
+// the database to be rolled back to a prior schema version
+type ReversibleMigrationScript interface {
+	MigrationScript
+	Down(basicRes context.BasicRes) errors.Error
+}
+
 // Migrator is responsible for making sure the registered scripts get applied to database and only once
 type Migrator interface {
 	Register(scripts []MigrationScript, comment string)
 	Execute() errors.Error
 	HasPendingScripts() bool
+	// Rollback reverts all executed scripts newer than targetVersion in reverse order, and pins the
+	// schema to targetVersion so that Execute won't apply them again
+	Rollback(targetVersion uint64) errors.Error
 }
 
 // PluginMigration is implemented by the plugin to declare all migration script that have to be applied to the database
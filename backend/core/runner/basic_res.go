@@ -23,6 +23,7 @@ import (
 	"github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/log"
 	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/core/tracing"
 	contextimpl "github.com/apache/incubator-devlake/impls/context"
 	"github.com/apache/incubator-devlake/impls/dalgorm"
 	"github.com/apache/incubator-devlake/impls/logruslog"
@@ -50,6 +51,9 @@ func CreateAppBasicRes() context.BasicRes {
 		panic(err)
 	}
 	dalgorm.Init(cfg.GetString(plugin.EncodeKeyEnvStr))
+	if err := tracing.Init(cfg); err != nil {
+		panic(err)
+	}
 	return CreateBasicRes(cfg, logger, db)
 }
 
@@ -28,9 +28,13 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/apache/incubator-devlake/core/log"
 	"github.com/apache/incubator-devlake/core/models"
 	"github.com/apache/incubator-devlake/core/models/migrationscripts"
 	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/core/tracing"
+	"github.com/apache/incubator-devlake/impls/logruslog"
+	"github.com/apache/incubator-devlake/server/services/grpcplugin"
 	"github.com/spf13/cobra"
 )
 
@@ -38,6 +42,11 @@ import (
 func RunCmd(cmd *cobra.Command) {
 	cmd.Flags().StringSliceP("subtasks", "t", nil, "specify what tasks to run, --subtasks=collectIssues,extractIssues")
 	cmd.Flags().BoolP("fullsync", "f", false, "run fullsync")
+	if grpcplugin.IsPluginProcess() {
+		// launched by the server, the command line flags are irrelevant
+		cmd.Run(cmd, nil)
+		return
+	}
 	err := cmd.Execute()
 	if err != nil {
 		panic(err)
@@ -50,7 +59,12 @@ func RunCmd(cmd *cobra.Command) {
 // pluginTask: specific built-in plugin, for example: feishu, jira...
 // options: plugin config
 func DirectRun(cmd *cobra.Command, args []string, pluginTask plugin.PluginTask, options map[string]interface{}, timeAfter string) {
+	if grpcplugin.IsPluginProcess() {
+		serveGrpcPlugin(cmd.Use, pluginTask)
+		return
+	}
 	basicRes := CreateAppBasicRes()
+	defer tracing.Shutdown()
 	tasks, err := cmd.Flags().GetStringSlice("subtasks")
 	if err != nil {
 		panic(err)
@@ -114,6 +128,25 @@ func DirectRun(cmd *cobra.Command, args []string, pluginTask plugin.PluginTask,
 	}
 }
 
+// serveGrpcPlugin exposes the plugin to the server over gRPC, migrations are triggered by the server
+func serveGrpcPlugin(name string, pluginTask plugin.PluginTask) {
+	// stdout is reserved for the go-plugin handshake
+	logruslog.Global.SetStream(&log.LoggerStreamConfig{Writer: os.Stderr})
+	basicRes := CreateAppBasicRes()
+	defer tracing.Shutdown()
+	if pluginInit, ok := pluginTask.(plugin.PluginInit); ok {
+		err := pluginInit.Init(basicRes)
+		if err != nil {
+			panic(err)
+		}
+	}
+	err := plugin.RegisterPlugin(name, pluginTask.(plugin.PluginMeta))
+	if err != nil {
+		panic(err)
+	}
+	grpcplugin.Serve(basicRes, name, pluginTask.(plugin.PluginMeta))
+}
+
 func createContext() context.Context {
 	ctx, cancel := context.WithCancel(context.Background())
 	sigc := make(chan os.Signal, 1)
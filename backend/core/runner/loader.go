@@ -28,6 +28,7 @@ import (
 	"github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/server/services/grpcplugin"
 	"github.com/apache/incubator-devlake/server/services/remote"
 	"github.com/apache/incubator-devlake/server/services/remote/bridge"
 	"github.com/apache/incubator-devlake/server/services/remote/models"
@@ -39,6 +40,10 @@ func LoadPlugins(basicRes context.BasicRes) errors.Error {
 	if err != nil {
 		return err
 	}
+	err = LoadGrpcPlugins(basicRes)
+	if err != nil {
+		return err
+	}
 	if !basicRes.GetConfigReader().GetBool("DISABLED_REMOTE_PLUGINS") {
 		err = LoadRemotePlugins(basicRes)
 		if err != nil {
@@ -88,6 +93,42 @@ func LoadGoPlugins(basicRes context.BasicRes) errors.Error {
 	return errors.Convert(walkErr)
 }
 
+// LoadGrpcPlugins launches every executable under GRPC_PLUGIN_DIR as an out-of-process plugin,
+// a plugin loaded this way replaces the `.so` plugin of the same name
+func LoadGrpcPlugins(basicRes context.BasicRes) errors.Error {
+	grpcPluginDir := basicRes.GetConfig("GRPC_PLUGIN_DIR")
+	if grpcPluginDir == "" {
+		return nil
+	}
+	basicRes.GetLogger().Info("Loading grpc plugins")
+	walkErr := filepath.WalkDir(grpcPluginDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || strings.HasSuffix(d.Name(), ".so") {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if info.Mode()&0111 == 0 {
+			return nil
+		}
+		pluginMeta, err := grpcplugin.Load(basicRes, path)
+		if err != nil {
+			return err
+		}
+		err = plugin.RegisterPlugin(pluginMeta.Name(), pluginMeta)
+		if err != nil {
+			return err
+		}
+		basicRes.GetLogger().Info(`grpc plugin loaded %s`, pluginMeta.Name())
+		return nil
+	})
+	return errors.Convert(walkErr)
+}
+
 func LoadRemotePlugins(basicRes context.BasicRes) errors.Error {
 	remotePluginDir := basicRes.GetConfig("REMOTE_PLUGIN_DIR")
 	if remotePluginDir != "" {
@@ -0,0 +1,115 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	gocontext "context"
+	goerror "errors"
+	"math"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/log"
+	"github.com/apache/incubator-devlake/core/models"
+)
+
+const (
+	defaultRetryBackoffSeconds    = 10
+	defaultRetryBackoffMultiplier = 2
+)
+
+var defaultRetryOn = []string{
+	models.RETRY_ON_SERVER,
+	models.RETRY_ON_RATE_LIMIT,
+	models.RETRY_ON_TIMEOUT,
+	models.RETRY_ON_NETWORK,
+}
+
+// runWithRetry runs the subtask until it succeeds, its error is not retryable by the policy or the attempts are exhausted
+func runWithRetry(ctx gocontext.Context, logger log.Logger, name string, policy *models.RetryPolicy, run func() errors.Error) errors.Error {
+	for attempt := 1; ; attempt++ {
+		err := run()
+		if err == nil || policy == nil || attempt >= policy.MaxAttempts || !isRetryable(err, policy.RetryOn) {
+			return err
+		}
+		backoff := retryBackoff(policy, attempt)
+		logger.Warn(err, "subtask %s failed on attempt %d/%d, retrying in %s", name, attempt, policy.MaxAttempts, backoff)
+		select {
+		case <-ctx.Done():
+			return errors.Convert(ctx.Err())
+		case <-time.After(backoff):
+		}
+	}
+}
+
+// retryBackoff returns the wait after the given failed attempt
+func retryBackoff(policy *models.RetryPolicy, attempt int) time.Duration {
+	seconds := float64(policy.BackoffSeconds)
+	if seconds <= 0 {
+		seconds = defaultRetryBackoffSeconds
+	}
+	multiplier := policy.BackoffMultiplier
+	if multiplier <= 0 {
+		multiplier = defaultRetryBackoffMultiplier
+	}
+	seconds *= math.Pow(multiplier, float64(attempt-1))
+	if policy.MaxBackoffSeconds > 0 && seconds > float64(policy.MaxBackoffSeconds) {
+		seconds = float64(policy.MaxBackoffSeconds)
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// isRetryable tells whether the error belongs to any of the classes, cancellations and pauses are never retried
+func isRetryable(err error, retryOn []string) bool {
+	if errors.Is(err, gocontext.Canceled) || errors.Is(err, ErrTaskPaused) {
+		return false
+	}
+	if len(retryOn) == 0 {
+		retryOn = defaultRetryOn
+	}
+	for _, class := range retryOn {
+		if errorClassMatches(err, class) {
+			return true
+		}
+	}
+	return false
+}
+
+func errorClassMatches(err error, class string) bool {
+	code := http.StatusInternalServerError
+	if lakeErr := errors.AsLakeErrorType(err); lakeErr != nil {
+		code = lakeErr.GetType().GetHttpCode()
+	}
+	var netErr net.Error
+	isNetErr := goerror.As(err, &netErr)
+	switch class {
+	case models.RETRY_ON_SERVER:
+		return code > http.StatusInternalServerError
+	case models.RETRY_ON_RATE_LIMIT:
+		return code == http.StatusTooManyRequests
+	case models.RETRY_ON_TIMEOUT:
+		return code == http.StatusGatewayTimeout || errors.Is(err, gocontext.DeadlineExceeded) || (isNetErr && netErr.Timeout())
+	case models.RETRY_ON_NETWORK:
+		return isNetErr
+	case models.RETRY_ON_ANY:
+		return code >= http.StatusInternalServerError || code == http.StatusTooManyRequests || code == http.StatusRequestTimeout
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	gocontext "context"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsRetryable(t *testing.T) {
+	badGateway := errors.Default.Wrap(errors.HttpStatus(http.StatusBadGateway).New("bad gateway"), "collect issues")
+	rateLimited := errors.HttpStatus(http.StatusTooManyRequests).New("too many requests")
+	dnsErr := errors.Convert(&net.DNSError{Err: "no such host", Name: "example.com"})
+
+	assert.True(t, isRetryable(badGateway, nil))
+	assert.True(t, isRetryable(rateLimited, nil))
+	assert.True(t, isRetryable(dnsErr, nil))
+	assert.False(t, isRetryable(errors.Default.New("unexpected"), nil))
+	assert.False(t, isRetryable(errors.BadInput.New("invalid options"), nil))
+	assert.False(t, isRetryable(errors.Convert(gocontext.Canceled), nil))
+
+	assert.False(t, isRetryable(badGateway, []string{models.RETRY_ON_RATE_LIMIT}))
+	assert.True(t, isRetryable(errors.Default.New("unexpected"), []string{models.RETRY_ON_ANY}))
+	assert.False(t, isRetryable(ErrTaskPaused, []string{models.RETRY_ON_ANY}))
+}
+
+func TestRetryBackoff(t *testing.T) {
+	policy := &models.RetryPolicy{BackoffSeconds: 5, MaxBackoffSeconds: 30}
+	assert.Equal(t, 5*time.Second, retryBackoff(policy, 1))
+	assert.Equal(t, 10*time.Second, retryBackoff(policy, 2))
+	assert.Equal(t, 20*time.Second, retryBackoff(policy, 3))
+	assert.Equal(t, 30*time.Second, retryBackoff(policy, 4))
+}
+
+func TestForSubtask(t *testing.T) {
+	convertPolicy := &models.RetryPolicy{MaxAttempts: 1}
+	policy := &models.RetryPolicy{MaxAttempts: 3, Subtasks: map[string]*models.RetryPolicy{"convertBugs": convertPolicy}}
+	assert.Equal(t, convertPolicy, policy.ForSubtask("convertBugs"))
+	assert.Equal(t, policy, policy.ForSubtask("collectBugs"))
+
+	var noPolicy *models.RetryPolicy
+	assert.Nil(t, noPolicy.ForSubtask("collectBugs"))
+}
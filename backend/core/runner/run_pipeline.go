@@ -36,9 +36,13 @@ func RunPipeline(
 	// load tasks for pipeline
 	db := basicRes.GetDal()
 	var tasks []models.Task
+	// the queued and running tasks are held by the workers in the distributed mode, a resumed pipeline waits for them
 	err := db.All(
 		&tasks,
-		dal.Where("pipeline_id = ? AND status in ?", pipelineId, []string{models.TASK_CREATED, models.TASK_RERUN, models.TASK_RESUME}),
+		dal.Where(
+			"pipeline_id = ? AND status in ?",
+			pipelineId, []string{models.TASK_CREATED, models.TASK_RERUN, models.TASK_RESUME, models.TASK_QUEUED, models.TASK_RUNNING},
+		),
 		dal.Orderby("pipeline_row, pipeline_col"),
 	)
 	if err != nil {
@@ -90,7 +94,7 @@ func runPipelineTasks(
 		err = runTasks(row)
 		if err != nil {
 			log.Error(err, "run tasks failed")
-			if errors.Is(err, gocontext.Canceled) || !dbPipeline.SkipOnFail {
+			if errors.Is(err, gocontext.Canceled) || errors.Is(err, ErrTaskPaused) || !dbPipeline.SkipOnFail {
 				log.Info("return error")
 				return err
 			}
@@ -29,14 +29,21 @@ import (
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/log"
+	"github.com/apache/incubator-devlake/core/metrics"
 	"github.com/apache/incubator-devlake/core/models"
 	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/core/tracing"
 	"github.com/apache/incubator-devlake/core/utils"
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	contextimpl "github.com/apache/incubator-devlake/impls/context"
 	"github.com/apache/incubator-devlake/impls/logruslog"
+	"go.opentelemetry.io/otel/attribute"
 )
 
+// ErrTaskPaused is returned by RunPluginSubTasks when the task was requested to pause, the finished subtasks
+// are skipped when the task resumes
+var ErrTaskPaused = errors.Default.New("task paused")
+
 // RunTask FIXME ...
 func RunTask(
 	ctx gocontext.Context,
@@ -77,6 +84,16 @@ func RunTask(
 		}
 		finishedAt := time.Now()
 		spentSeconds := finishedAt.Unix() - beganAt.Unix()
+		if errors.Is(err, ErrTaskPaused) {
+			dbe := db.UpdateColumns(task, []dal.DalSet{
+				{ColumnName: "status", Value: models.TASK_PAUSED},
+				{ColumnName: "message", Value: ""},
+			})
+			if dbe != nil {
+				logger.Error(dbe, "failed to finalize task status into db (task paused)")
+			}
+			return
+		}
 		if err != nil {
 			lakeErr := errors.AsLakeErrorType(err)
 			subTaskName := "unknown"
@@ -124,6 +141,10 @@ func RunTask(
 	if task.Status == models.TASK_COMPLETED {
 		return nil
 	}
+	// the pipeline was paused before the task started
+	if task.Status == models.TASK_PAUSING {
+		return ErrTaskPaused
+	}
 
 	// start execution
 	logger.Info("start executing task: %d", task.ID)
@@ -141,7 +162,7 @@ func RunTask(
 		basicRes.ReplaceLogger(logger),
 		task,
 		progress,
-		&dbPipeline.SyncPolicy,
+		task.SyncPolicy.Apply(&dbPipeline.SyncPolicy),
 	)
 	return err
 }
@@ -162,14 +183,25 @@ func RunPluginTask(
 	if !ok {
 		return errors.Default.New(fmt.Sprintf("plugin %s doesn't support PluginTask interface", task.Plugin))
 	}
-	return RunPluginSubTasks(
-		ctx,
-		basicRes,
-		task,
-		pluginTask,
-		progress,
-		syncPolicy,
+	ctx, cancel := gocontext.WithCancel(ctx)
+	defer cancel()
+	ctx, span := tracing.Start(ctx, fmt.Sprintf("task %s", task.Plugin),
+		attribute.String("devlake.plugin", task.Plugin),
+		attribute.Int64("devlake.task.id", int64(task.ID)),
+		attribute.Int64("devlake.pipeline.id", int64(task.PipelineId)),
 	)
+	err = runWithTimeout(cancel, fmt.Sprintf("task %s", task.Plugin), task.Timeout.TaskLimit(pipelineTimeouts(syncPolicy)), func() errors.Error {
+		return RunPluginSubTasks(
+			ctx,
+			basicRes,
+			task,
+			pluginTask,
+			progress,
+			syncPolicy,
+		)
+	})
+	tracing.End(span, err)
+	return err
 }
 
 // RunPluginSubTasks FIXME ...
@@ -183,6 +215,9 @@ func RunPluginSubTasks(
 ) errors.Error {
 	logger := basicRes.GetLogger()
 	logger.Info("start plugin")
+	// a timed out subtask cancels the whole task as the api clients are bound to the task context
+	ctx, cancel := gocontext.WithCancel(ctx)
+	defer cancel()
 	// find out all possible subtasks this plugin can offer
 	subtaskMetas := pluginTask.SubTaskMetas()
 	subtasksFlag := make(map[string]bool)
@@ -312,6 +347,15 @@ func RunPluginSubTasks(
 				SubTaskNumber: subtaskNumber,
 			}
 		}
+		if task.ID > 0 && subtaskNumber > 1 {
+			pausing := errors.Must1(basicRes.GetDal().Count(
+				dal.From(&models.Task{}), dal.Where("id = ? AND status = ?", task.ID, models.TASK_PAUSING),
+			))
+			if pausing > 0 {
+				logger.Info("task paused before subtask %s", subtaskMeta.Name)
+				return ErrTaskPaused
+			}
+		}
 		subtaskFinished := false
 		if !subtaskMeta.ForceRunOnResume {
 			if task.ID > 0 {
@@ -327,8 +371,26 @@ func RunPluginSubTasks(
 		} else {
 			logger.Info("executing subtask %s", subtaskMeta.Name)
 			start := time.Now()
-			err = runSubtask(basicRes, subtaskCtx, task.ID, subtaskNumber, subtaskMeta.EntryPoint)
+			spanCtx, span := tracing.Start(ctx, fmt.Sprintf("subtask %s", subtaskMeta.Name),
+				attribute.String("devlake.plugin", task.Plugin),
+				attribute.String("devlake.subtask", subtaskMeta.Name),
+			)
+			if c, ok := subtaskCtx.(*contextimpl.DefaultSubTaskContext); ok {
+				c.SetContext(spanCtx)
+			}
+			timeout := task.Timeout.SubtaskLimit(subtaskMeta.Name, pipelineTimeouts(syncPolicy))
+			err = runWithTimeout(cancel, fmt.Sprintf("subtask %s", subtaskMeta.Name), timeout, func() errors.Error {
+				return runWithRetry(ctx, logger, subtaskMeta.Name, task.RetryPolicy.ForSubtask(subtaskMeta.Name), func() errors.Error {
+					return runSubtask(basicRes, subtaskCtx, task.ID, subtaskNumber, subtaskMeta.EntryPoint)
+				})
+			})
+			tracing.End(span, err)
 			logger.Info("subtask %s finished in %d ms", subtaskMeta.Name, time.Since(start).Milliseconds())
+			subtaskStatus := models.TASK_COMPLETED
+			if err != nil {
+				subtaskStatus = models.TASK_FAILED
+			}
+			metrics.SubtaskDuration.WithLabelValues(task.Plugin, subtaskMeta.Name, subtaskStatus).Observe(time.Since(start).Seconds())
 			if err != nil {
 				err = errors.SubtaskErr.Wrap(err, fmt.Sprintf("subtask %s ended unexpectedly", subtaskMeta.Name), errors.WithData(&subtaskMeta))
 				logger.Error(err, "")
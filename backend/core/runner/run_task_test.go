@@ -0,0 +1,82 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	gocontext "context"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/metrics"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockcontext "github.com/apache/incubator-devlake/mocks/core/context"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type metricsTestPlugin struct{}
+
+func (metricsTestPlugin) SubTaskMetas() []plugin.SubTaskMeta {
+	return []plugin.SubTaskMeta{
+		{Name: "extractRecords", EnabledByDefault: true, EntryPoint: func(taskCtx plugin.SubTaskContext) errors.Error {
+			taskCtx.IncProgress(2)
+			taskCtx.IncProgress(3)
+			return nil
+		}},
+		{Name: "convertRecords", EnabledByDefault: true, EntryPoint: func(taskCtx plugin.SubTaskContext) errors.Error {
+			return errors.Default.New("boom")
+		}},
+	}
+}
+
+func (metricsTestPlugin) PrepareTaskData(plugin.TaskContext, map[string]interface{}) (interface{}, errors.Error) {
+	return nil, nil
+}
+
+func observed(t *testing.T, histogram *prometheus.HistogramVec, labels ...string) uint64 {
+	m := &dto.Metric{}
+	assert.Nil(t, histogram.WithLabelValues(labels...).(prometheus.Metric).Write(m))
+	return m.GetHistogram().GetSampleCount()
+}
+
+func TestRunPluginSubTasksMetrics(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	mockDal.On("CreateOrUpdate", mock.Anything, mock.Anything).Return(nil)
+	mockDal.On("UpdateColumns", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockRes := new(mockcontext.BasicRes)
+	mockRes.On("GetDal").Return(mockDal)
+	mockRes.On("GetLogger").Return(unithelper.DummyLogger())
+	mockRes.On("NestedLogger", mock.Anything).Return(mockRes)
+
+	extracted := observed(t, metrics.SubtaskDuration, "metricstest", "extractRecords", models.TASK_COMPLETED)
+	converted := observed(t, metrics.SubtaskDuration, "metricstest", "convertRecords", models.TASK_FAILED)
+	records := testutil.ToFloat64(metrics.SubtaskRecords.WithLabelValues("metricstest", "extractRecords"))
+
+	err := RunPluginSubTasks(gocontext.Background(), mockRes, &models.Task{Plugin: "metricstest"}, metricsTestPlugin{}, nil, nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, extracted+1, observed(t, metrics.SubtaskDuration, "metricstest", "extractRecords", models.TASK_COMPLETED))
+	assert.Equal(t, converted+1, observed(t, metrics.SubtaskDuration, "metricstest", "convertRecords", models.TASK_FAILED))
+	assert.Equal(t, records+5, testutil.ToFloat64(metrics.SubtaskRecords.WithLabelValues("metricstest", "extractRecords")))
+	assert.Equal(t, uint64(0), observed(t, metrics.SubtaskDuration, "metricstest", "convertRecords", models.TASK_COMPLETED))
+}
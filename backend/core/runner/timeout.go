@@ -0,0 +1,50 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	gocontext "context"
+	"fmt"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+)
+
+// pipelineTimeouts returns the timeouts of the pipeline the tasks fall back to
+func pipelineTimeouts(syncPolicy *models.SyncPolicy) *models.Timeouts {
+	if syncPolicy == nil {
+		return nil
+	}
+	return &syncPolicy.Timeouts
+}
+
+// runWithTimeout runs and calls cancel once run takes longer than seconds, the error is replaced by a timeout
+// error then so the task fails with a clear reason instead of looking cancelled
+func runWithTimeout(cancel gocontext.CancelFunc, what string, seconds int, run func() errors.Error) errors.Error {
+	if seconds <= 0 {
+		return run()
+	}
+	limit := time.Duration(seconds) * time.Second
+	timer := time.AfterFunc(limit, cancel)
+	err := run()
+	if !timer.Stop() {
+		return errors.Timeout.New(fmt.Sprintf("%s timed out after %s", what, limit))
+	}
+	return err
+}
@@ -0,0 +1,58 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	gocontext "context"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeoutLimits(t *testing.T) {
+	fallback := &models.Timeouts{TaskTimeoutSeconds: 3600, SubtaskTimeoutSeconds: 600}
+	policy := &models.TimeoutPolicy{SubtaskSeconds: 60, Subtasks: map[string]int{"collectIssues": 1800}}
+
+	assert.Equal(t, 3600, policy.TaskLimit(fallback))
+	assert.Equal(t, 1800, policy.SubtaskLimit("collectIssues", fallback))
+	assert.Equal(t, 60, policy.SubtaskLimit("convertIssues", fallback))
+
+	var noPolicy *models.TimeoutPolicy
+	assert.Equal(t, 600, noPolicy.SubtaskLimit("convertIssues", fallback))
+	assert.Equal(t, 0, noPolicy.TaskLimit(nil))
+}
+
+func TestRunWithTimeout(t *testing.T) {
+	ctx, cancel := gocontext.WithCancel(gocontext.Background())
+	defer cancel()
+	err := runWithTimeout(cancel, "subtask collectIssues", 1, func() errors.Error {
+		<-ctx.Done()
+		return errors.Convert(ctx.Err())
+	})
+	assert.Equal(t, errors.Timeout, err.GetType())
+	assert.Contains(t, err.Error(), "subtask collectIssues timed out after 1s")
+	assert.False(t, errors.Is(err, gocontext.Canceled))
+
+	failure := errors.Default.New("boom")
+	err = runWithTimeout(func() {}, "subtask convertIssues", 1, func() errors.Error {
+		return failure
+	})
+	assert.Equal(t, failure, err)
+}
@@ -0,0 +1,87 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	gocontext "context"
+
+	"github.com/apache/incubator-devlake/core/config"
+	"github.com/apache/incubator-devlake/core/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/apache/incubator-devlake"
+
+var provider *sdktrace.TracerProvider
+
+// Init exports the spans over OTLP/HTTP when TRACING_ENABLED is true. The exporter is configured
+// by the standard OTEL_EXPORTER_OTLP_* variables, spans are dropped otherwise
+func Init(cfg config.ConfigReader) errors.Error {
+	if !cfg.GetBool("TRACING_ENABLED") {
+		return nil
+	}
+	exporter, err := otlptracehttp.New(gocontext.Background())
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to create the OTLP trace exporter")
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		attribute.String("service.name", cfg.GetString("TRACING_SERVICE_NAME")),
+	))
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to create the trace resource")
+	}
+	provider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.GetFloat64("TRACING_SAMPLE_RATIO")))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return nil
+}
+
+// Shutdown flushes the pending spans
+func Shutdown() {
+	if provider != nil {
+		_ = provider.Shutdown(gocontext.Background())
+	}
+}
+
+// Start starts a span of devlake
+func Start(ctx gocontext.Context, name string, attrs ...attribute.KeyValue) (gocontext.Context, trace.Span) {
+	if ctx == nil {
+		ctx = gocontext.Background()
+	}
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records the error, if any, on the span and ends it
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
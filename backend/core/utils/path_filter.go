@@ -0,0 +1,121 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+// PathFilter decides which files should be counted in the commit stats based on glob patterns.
+// Patterns follow the gitignore flavor: `**` matches any number of directories, `*` and `?`
+// never cross a `/`, and patterns without a `/` are matched against files in any directory.
+type PathFilter struct {
+	includes []*regexp.Regexp
+	excludes []*regexp.Regexp
+}
+
+// NewPathFilter compiles the patterns, returns nil if no pattern was given
+func NewPathFilter(includes, excludes []string) (*PathFilter, errors.Error) {
+	f := &PathFilter{}
+	var err errors.Error
+	if f.includes, err = compileGlobs(includes); err != nil {
+		return nil, err
+	}
+	if f.excludes, err = compileGlobs(excludes); err != nil {
+		return nil, err
+	}
+	if len(f.includes) == 0 && len(f.excludes) == 0 {
+		return nil, nil
+	}
+	return f, nil
+}
+
+// Match returns true if the file should be counted
+func (f *PathFilter) Match(path string) bool {
+	if f == nil {
+		return true
+	}
+	if len(f.includes) > 0 && !matchAny(f.includes, path) {
+		return false
+	}
+	return !matchAny(f.excludes, path)
+}
+
+func matchAny(regexps []*regexp.Regexp, path string) bool {
+	for _, r := range regexps {
+		if r.MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
+
+func compileGlobs(globs []string) ([]*regexp.Regexp, errors.Error) {
+	var regexps []*regexp.Regexp
+	for _, glob := range globs {
+		glob = strings.TrimSpace(glob)
+		if glob == "" {
+			continue
+		}
+		r, err := regexp.Compile(globToRegexp(glob))
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, fmt.Sprintf("invalid path pattern: %s", glob))
+		}
+		regexps = append(regexps, r)
+	}
+	return regexps, nil
+}
+
+func globToRegexp(glob string) string {
+	var sb strings.Builder
+	sb.WriteString("^")
+	if strings.HasPrefix(glob, "/") {
+		glob = glob[1:]
+	} else if !strings.Contains(strings.TrimSuffix(glob, "/"), "/") {
+		sb.WriteString("(.*/)?")
+	}
+	// a trailing slash means everything under the directory
+	if strings.HasSuffix(glob, "/") {
+		glob += "**"
+	}
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch {
+		case c == '*' && i+1 < len(glob) && glob[i+1] == '*':
+			i++
+			if i+1 < len(glob) && glob[i+1] == '/' {
+				i++
+				sb.WriteString("(.*/)?")
+			} else {
+				sb.WriteString(".*")
+			}
+		case c == '*':
+			sb.WriteString("[^/]*")
+		case c == '?':
+			sb.WriteString("[^/]")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	sb.WriteString("$")
+	return sb.String()
+}
@@ -0,0 +1,53 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPathFilter_Match(t *testing.T) {
+	filter, err := NewPathFilter(nil, []string{"vendor/**", "**/*.min.js", "*.lock", "/docs/"})
+	assert.Nil(t, err)
+	assert.True(t, filter.Match("main.go"))
+	assert.True(t, filter.Match("pkg/vendor.go"))
+	assert.False(t, filter.Match("vendor/github.com/foo/bar.go"))
+	assert.False(t, filter.Match("web/dist/app.min.js"))
+	assert.False(t, filter.Match("app.min.js"))
+	assert.False(t, filter.Match("yarn.lock"))
+	assert.False(t, filter.Match("web/yarn.lock"))
+	assert.False(t, filter.Match("docs/index.md"))
+	assert.True(t, filter.Match("web/docs/index.md"))
+}
+
+func TestPathFilter_Includes(t *testing.T) {
+	filter, err := NewPathFilter([]string{"src/**"}, []string{"**/*_test.go"})
+	assert.Nil(t, err)
+	assert.True(t, filter.Match("src/a/b.go"))
+	assert.False(t, filter.Match("src/a/b_test.go"))
+	assert.False(t, filter.Match("README.md"))
+}
+
+func TestPathFilter_Nil(t *testing.T) {
+	filter, err := NewPathFilter(nil, []string{" "})
+	assert.Nil(t, err)
+	assert.Nil(t, filter)
+	assert.True(t, filter.Match("anything"))
+}
@@ -46,3 +46,11 @@ func StrToBoolOr(text string, defaultValue bool) (bool, errors.Error) {
 	}
 	return errors.Convert01(strconv.ParseBool(text))
 }
+
+// StrToFloatOr Return defaultValue if text is empty, or try to convert it to float64
+func StrToFloatOr(text string, defaultValue float64) (float64, errors.Error) {
+	if text == "" {
+		return defaultValue, nil
+	}
+	return errors.Convert01(strconv.ParseFloat(text, 64))
+}
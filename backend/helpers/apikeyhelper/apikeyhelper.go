@@ -62,6 +62,11 @@ func NewApiKeyHelper(basicRes context.BasicRes, logger log.Logger) *ApiKeyHelper
 }
 
 func (c *ApiKeyHelper) Create(tx dal.Transaction, user *common.User, name string, expiredAt *time.Time, allowedPath string, apiKeyType string, extra string) (*models.ApiKey, errors.Error) {
+	return c.CreateWithScopes(tx, user, name, expiredAt, allowedPath, apiKeyType, extra, models.ApiKeyScopes{})
+}
+
+// CreateWithScopes creates an api key which is further restricted by `scopes`
+func (c *ApiKeyHelper) CreateWithScopes(tx dal.Transaction, user *common.User, name string, expiredAt *time.Time, allowedPath string, apiKeyType string, extra string, scopes models.ApiKeyScopes) (*models.ApiKey, errors.Error) {
 	if _, err := regexp.Compile(allowedPath); err != nil {
 		c.logger.Error(err, "Compile allowed path")
 		return nil, errors.Default.Wrap(err, fmt.Sprintf("compile allowed path: %s", allowedPath))
@@ -77,12 +82,13 @@ func (c *ApiKeyHelper) Create(tx dal.Transaction, user *common.User, name string
 			CreatedAt: now,
 			UpdatedAt: now,
 		},
-		Name:        name,
-		ApiKey:      hashedApiKey,
-		ExpiredAt:   expiredAt,
-		AllowedPath: allowedPath,
-		Type:        apiKeyType,
-		Extra:       extra,
+		ApiKeyScopes: scopes,
+		Name:         name,
+		ApiKey:       hashedApiKey,
+		ExpiredAt:    expiredAt,
+		AllowedPath:  allowedPath,
+		Type:         apiKeyType,
+		Extra:        extra,
 	}
 	if user != nil {
 		apiKeyRecord.Creator = common.Creator{
@@ -110,6 +116,11 @@ func (c *ApiKeyHelper) CreateForPlugin(tx dal.Transaction, user *common.User, na
 }
 
 func (c *ApiKeyHelper) Put(user *common.User, id uint64) (*models.ApiKey, errors.Error) {
+	return c.Rotate(user, id, 0)
+}
+
+// Rotate replaces the api key with a new one, the replaced key is still accepted during `gracePeriod`
+func (c *ApiKeyHelper) Rotate(user *common.User, id uint64, gracePeriod time.Duration) (*models.ApiKey, errors.Error) {
 	db := c.basicRes.GetDal()
 	// verify exists
 	apiKey, err := c.getApiKeyById(db, id)
@@ -123,8 +134,19 @@ func (c *ApiKeyHelper) Put(user *common.User, id uint64) (*models.ApiKey, errors
 		c.logger.Error(err, "generateApiKey")
 		return nil, err
 	}
+	now := time.Now()
+	apiKey.PreviousApiKey = ""
+	apiKey.PreviousExpiredAt = nil
+	if gracePeriod > 0 {
+		previousExpiredAt := now.Add(gracePeriod)
+		if apiKey.ExpiredAt != nil && apiKey.ExpiredAt.Before(previousExpiredAt) {
+			previousExpiredAt = *apiKey.ExpiredAt
+		}
+		apiKey.PreviousApiKey = apiKey.ApiKey
+		apiKey.PreviousExpiredAt = &previousExpiredAt
+	}
 	apiKey.ApiKey = hashApiKey
-	apiKey.UpdatedAt = time.Now()
+	apiKey.UpdatedAt = now
 	if user != nil {
 		apiKey.Updater = common.Updater{
 			Updater:      user.Name,
@@ -133,7 +155,7 @@ func (c *ApiKeyHelper) Put(user *common.User, id uint64) (*models.ApiKey, errors
 	}
 	if err = db.Update(apiKey); err != nil {
 		c.logger.Error(err, "update api key, id: %d", id)
-		return nil, errors.Default.Wrap(err, "error deleting api key")
+		return nil, errors.Default.Wrap(err, "error rotating api key")
 	}
 	apiKey.ApiKey = apiKeyStr
 	return apiKey, nil
@@ -205,6 +227,19 @@ func (c *ApiKeyHelper) GetApiKey(tx dal.Dal, additionalClauses ...dal.Clause) (*
 	return apiKey, err
 }
 
+// GetApiKeyByToken finds the api key matching the plain `token`, either the current key or the one replaced by a
+// rotation which is still in its grace period
+func (c *ApiKeyHelper) GetApiKeyByToken(tx dal.Dal, token string) (*models.ApiKey, errors.Error) {
+	hashedApiKey, err := c.DigestToken(token)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetApiKey(tx, dal.Where(
+		"api_key = ? OR (previous_api_key = ? AND previous_expired_at > ?)",
+		hashedApiKey, hashedApiKey, time.Now(),
+	))
+}
+
 func (c *ApiKeyHelper) GenApiKeyNameForPlugin(pluginName string, connectionId uint64) string {
 	return fmt.Sprintf("%s-%d", pluginName, connectionId)
 }
@@ -0,0 +1,151 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apikeyhelper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// keyStore answers the queries of the helper the way the database would
+type keyStore []*models.ApiKey
+
+func (s keyStore) first(dst interface{}, clauses ...dal.Clause) errors.Error {
+	where := clauses[0].Data.(dal.DalClause)
+	for _, key := range s {
+		var found bool
+		if where.Expr == "id = ?" {
+			found = key.ID == where.Params[0].(uint64)
+		} else {
+			hashed, now := where.Params[0].(string), where.Params[2].(time.Time)
+			found = key.ApiKey == hashed ||
+				key.PreviousApiKey == hashed && key.PreviousExpiredAt != nil && key.PreviousExpiredAt.After(now)
+		}
+		if found {
+			*dst.(*models.ApiKey) = *key
+			return nil
+		}
+	}
+	return errors.NotFound.New("record not found")
+}
+
+func newTestHelper(store keyStore, updated *[]*models.ApiKey) *ApiKeyHelper {
+	logger := unithelper.DummyLogger()
+	basicRes := unithelper.DummyBasicRes(func(mockDal *mockdal.Dal) {
+		mockDal.On("First", mock.Anything, mock.Anything).Return(store.first)
+		mockDal.On("IsErrorNotFound", mock.Anything).Return(true).Maybe()
+		mockDal.On("Update", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			// the helper hands the plain key out in the same struct afterwards
+			saved := *args.Get(0).(*models.ApiKey)
+			*updated = append(*updated, &saved)
+		}).Return(nil).Maybe()
+	})
+	return &ApiKeyHelper{basicRes: basicRes, logger: logger, encryptionSecret: "secret"}
+}
+
+func TestRotate(t *testing.T) {
+	now := time.Now()
+	soon := now.Add(10 * time.Minute)
+	later := now.Add(time.Hour)
+	inAMinute := now.Add(time.Minute)
+	tests := []struct {
+		name              string
+		key               models.ApiKey
+		gracePeriod       time.Duration
+		previousApiKey    string
+		previousExpiredAt *time.Time
+	}{
+		{"without grace period", models.ApiKey{ApiKey: "old"}, 0, "", nil},
+		{"grace period", models.ApiKey{ApiKey: "old"}, time.Hour, "old", &later},
+		{"grace period capped at the expiry", models.ApiKey{ApiKey: "old", ExpiredAt: &soon}, time.Hour, "old", &soon},
+		{"grace period shorter than the expiry", models.ApiKey{ApiKey: "old", ExpiredAt: &later}, time.Minute, "old", &inAMinute},
+		{"previous rotation dropped", models.ApiKey{ApiKey: "old", PreviousApiKey: "older", PreviousExpiredAt: &later}, 0, "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.key.ID = 1
+			var updated []*models.ApiKey
+			helper := newTestHelper(keyStore{&tt.key}, &updated)
+
+			apiKey, err := helper.Rotate(nil, 1, tt.gracePeriod)
+			assert.Nil(t, err)
+			if !assert.Len(t, updated, 1) {
+				return
+			}
+			saved := updated[0]
+			// the plain key is returned once, only its digest is saved
+			hashed, err := helper.DigestToken(apiKey.ApiKey)
+			assert.Nil(t, err)
+			assert.Equal(t, hashed, saved.ApiKey)
+			assert.Equal(t, tt.previousApiKey, saved.PreviousApiKey)
+			if tt.previousExpiredAt == nil {
+				assert.Nil(t, saved.PreviousExpiredAt)
+			} else if assert.NotNil(t, saved.PreviousExpiredAt) {
+				assert.WithinDuration(t, *tt.previousExpiredAt, *saved.PreviousExpiredAt, time.Second)
+			}
+		})
+	}
+}
+
+func TestGetApiKeyByToken(t *testing.T) {
+	helper := newTestHelper(nil, nil)
+	digest := func(token string) string {
+		hashed, err := helper.DigestToken(token)
+		assert.Nil(t, err)
+		return hashed
+	}
+	past := time.Now().Add(-time.Second)
+	future := time.Now().Add(time.Hour)
+	store := keyStore{
+		{Name: "in grace period", ApiKey: digest("current1"), PreviousApiKey: digest("previous1"), PreviousExpiredAt: &future},
+		{Name: "grace period over", ApiKey: digest("current2"), PreviousApiKey: digest("previous2"), PreviousExpiredAt: &past},
+	}
+	helper = newTestHelper(store, nil)
+
+	tests := []struct {
+		token string
+		name  string
+	}{
+		{"current1", "in grace period"},
+		{"previous1", "in grace period"},
+		{"current2", "grace period over"},
+		{"previous2", ""},
+		{"unknown", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.token, func(t *testing.T) {
+			apiKey, err := helper.GetApiKeyByToken(nil, tt.token)
+			if tt.name == "" {
+				if assert.NotNil(t, err) {
+					assert.Equal(t, errors.NotFound, err.GetType())
+				}
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.name, apiKey.Name)
+		})
+	}
+}
@@ -429,10 +429,14 @@ func (t *DataFlowTester) ExportRawTable(rawTableName string, csvRelPath string)
 	defer csvWriter.Close()
 
 	for _, rawRow := range *rawRows {
+		data, err := api.DecompressRawData(rawRow.Data)
+		if err != nil {
+			panic(err)
+		}
 		csvWriter.Write([]string{
 			strconv.FormatUint(rawRow.ID, 10),
 			rawRow.Params,
-			string(rawRow.Data),
+			string(data),
 			rawRow.Url,
 			string(rawRow.Input),
 			rawRow.CreatedAt.In(location).Format("2006-01-02T15:04:05.000-07:00"),
@@ -0,0 +1,78 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchParams(t *testing.T) {
+	params := `{"ConnectionId":1,"BoardId":8}`
+	assert.True(t, matchParams(params, nil))
+	assert.True(t, matchParams(params, map[string]string{"ConnectionId": "1"}))
+	assert.True(t, matchParams(params, map[string]string{"ConnectionId": "1", "BoardId": "8"}))
+	assert.False(t, matchParams(params, map[string]string{"ConnectionId": "2"}))
+	assert.False(t, matchParams(params, map[string]string{"ProjectId": "1"}))
+	assert.False(t, matchParams(`not json`, map[string]string{"ConnectionId": "1"}))
+}
+
+func TestCamelCase(t *testing.T) {
+	assert.Equal(t, "Jira", camelCase("jira"))
+	assert.Equal(t, "IssueChangelog", camelCase("issue_changelog"))
+	assert.Equal(t, "IssueChangelog", camelCase("issue-changelog"))
+}
+
+func TestRenderScaffold(t *testing.T) {
+	source, err := renderScaffold(&scaffoldOptions{
+		Plugin:       "jira",
+		Name:         "issue",
+		RawTables:    []string{"_raw_jira_api_issues"},
+		Format:       FORMAT_JSON,
+		Extractors:   []string{"ExtractIssuesMeta"},
+		ToolModels:   []string{"JiraIssue"},
+		Converters:   []string{"ConvertIssuesMeta"},
+		DomainModels: []string{"ticket.Issue"},
+	})
+	assert.Nil(t, err)
+	code := string(source)
+	assert.True(t, strings.HasPrefix(code, "/*\nLicensed to the Apache Software Foundation"))
+	assert.Contains(t, code, "func TestIssueDataFlow(t *testing.T) {")
+	assert.Contains(t, code, `"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"`)
+	assert.Contains(t, code, `"github.com/apache/incubator-devlake/plugins/jira/tasks"`)
+	assert.Contains(t, code, "var plugin impl.Jira")
+	assert.Contains(t, code, `dataflowTester.ImportJsonIntoRawTable("./raw_tables/_raw_jira_api_issues.json", "_raw_jira_api_issues")`)
+	assert.Contains(t, code, "dataflowTester.Subtask(tasks.ExtractIssuesMeta, taskData)")
+	assert.Contains(t, code, "dataflowTester.VerifyTableWithOptions(ticket.Issue{}, e2ehelper.TableOptions{")
+}
+
+func TestRenderScaffoldInvalid(t *testing.T) {
+	_, err := renderScaffold(&scaffoldOptions{Plugin: "jira", Name: "issue", Format: FORMAT_CSV})
+	assert.NotNil(t, err)
+	_, err = renderScaffold(&scaffoldOptions{
+		Plugin:       "jira",
+		Name:         "issue",
+		RawTables:    []string{"_raw_jira_api_issues"},
+		Format:       FORMAT_CSV,
+		Converters:   []string{"ConvertIssuesMeta"},
+		DomainModels: []string{"Issue"},
+	})
+	assert.NotNil(t, err)
+}
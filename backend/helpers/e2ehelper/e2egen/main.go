@@ -0,0 +1,97 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// e2egen helps plugin developers to build e2e tests out of live data:
+//
+//  1. Run the collectors of the plugin against the live api, i.e. `go run plugins/jira/jira.go -c 1 -b 8 -t collectIssues`
+//  2. Record the collected responses as fixtures, i.e. `go run ./helpers/e2ehelper/e2egen record -p jira --param ConnectionId=1 --param BoardId=8`
+//  3. Scaffold the e2e test, i.e. `go run ./helpers/e2ehelper/e2egen scaffold -p jira -n issue -r _raw_jira_api_issues -e ExtractIssuesMeta -m JiraIssue`
+//  4. Run the test with E2E_DB_URL set, the missing snapshot tables are created on the first run for review
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/apache/incubator-devlake/core/config"
+	"github.com/apache/incubator-devlake/core/runner"
+	"github.com/apache/incubator-devlake/impls/dalgorm"
+	"github.com/apache/incubator-devlake/impls/logruslog"
+	"github.com/spf13/cobra"
+)
+
+func recordCmd() *cobra.Command {
+	opts := &recordOptions{}
+	var params map[string]string
+	cmd := &cobra.Command{
+		Use:   "record",
+		Short: "record the raw tables collected from the live api as fixtures",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Params = params
+			db, err := runner.NewGormDb(config.GetConfig(), logruslog.Global)
+			if err != nil {
+				return err
+			}
+			return recordRawTables(dalgorm.NewDalgorm(db), opts)
+		},
+	}
+	cmd.Flags().StringVarP(&opts.Plugin, "plugin", "p", "", "name of the plugin, all its raw tables would be recorded unless --tables is specified")
+	cmd.Flags().StringSliceVarP(&opts.Tables, "tables", "r", nil, "raw tables to record, i.e. --tables=_raw_jira_api_issues,_raw_jira_api_boards")
+	cmd.Flags().StringToStringVar(&params, "param", nil, "only record rows collected with the params, i.e. --param ConnectionId=1 --param BoardId=8")
+	cmd.Flags().IntVarP(&opts.Limit, "limit", "l", 0, "maximum number of rows to record per table, 0 for unlimited")
+	cmd.Flags().StringVarP(&opts.Format, "format", "f", FORMAT_CSV, "format of the fixtures, csv or json")
+	cmd.Flags().StringVarP(&opts.OutDir, "out", "o", "", "output directory, defaults to plugins/<plugin>/e2e/raw_tables")
+	return cmd
+}
+
+func scaffoldCmd() *cobra.Command {
+	opts := &scaffoldOptions{}
+	var force bool
+	cmd := &cobra.Command{
+		Use:   "scaffold",
+		Short: "generate an e2e test verifying the raw -> tool -> domain data flow",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := writeScaffold(opts, force)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("created e2e test: %s\n", path)
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&opts.Plugin, "plugin", "p", "", "name of the plugin")
+	cmd.Flags().StringVarP(&opts.Name, "name", "n", "", "name of the data flow, i.e. issue")
+	cmd.Flags().StringSliceVarP(&opts.RawTables, "raw-tables", "r", nil, "raw tables to import")
+	cmd.Flags().StringVarP(&opts.Format, "format", "f", FORMAT_CSV, "format of the raw table fixtures, csv or json")
+	cmd.Flags().StringSliceVarP(&opts.Extractors, "extractors", "e", nil, "extractor subtask metas in the tasks package, i.e. ExtractIssuesMeta")
+	cmd.Flags().StringSliceVarP(&opts.ToolModels, "tool-models", "m", nil, "tool layer models in the models package, i.e. JiraIssue")
+	cmd.Flags().StringSliceVarP(&opts.Converters, "converters", "c", nil, "converter subtask metas in the tasks package, i.e. ConvertIssuesMeta")
+	cmd.Flags().StringSliceVarP(&opts.DomainModels, "domain-models", "d", nil, "domain layer models, i.e. ticket.Issue")
+	cmd.Flags().StringVar(&opts.PluginType, "plugin-type", "", "type implementing the plugin, defaults to impl.<Plugin>")
+	cmd.Flags().StringVar(&opts.TaskData, "task-data", "", "expression of the task data, defaults to &tasks.<Plugin>TaskData{...}")
+	cmd.Flags().StringVarP(&opts.OutDir, "out", "o", "", "output directory, defaults to plugins/<plugin>/e2e")
+	cmd.Flags().BoolVar(&force, "force", false, "overwrite the existing test file")
+	return cmd
+}
+
+func main() {
+	cmd := &cobra.Command{Use: "e2egen", Short: "e2e test harness generator for plugins"}
+	cmd.AddCommand(recordCmd(), scaffoldCmd())
+	if err := cmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,192 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const (
+	FORMAT_CSV  = "csv"
+	FORMAT_JSON = "json"
+)
+
+type recordOptions struct {
+	Plugin string
+	Tables []string
+	Params map[string]string
+	Limit  int
+	Format string
+	OutDir string
+}
+
+func recordRawTables(db dal.Dal, opts *recordOptions) errors.Error {
+	if opts.Format != FORMAT_CSV && opts.Format != FORMAT_JSON {
+		return errors.BadInput.New(fmt.Sprintf("unsupported format %s", opts.Format))
+	}
+	tables := opts.Tables
+	if len(tables) == 0 {
+		if opts.Plugin == "" {
+			return errors.BadInput.New("either plugin or tables is required")
+		}
+		allTables, err := db.AllTables()
+		if err != nil {
+			return err
+		}
+		prefix := fmt.Sprintf("_raw_%s_", opts.Plugin)
+		for _, table := range allTables {
+			if strings.HasPrefix(table, prefix) {
+				tables = append(tables, table)
+			}
+		}
+		if len(tables) == 0 {
+			return errors.NotFound.New(fmt.Sprintf("no raw tables found for plugin %s, run its collectors first", opts.Plugin))
+		}
+	}
+	outDir := opts.OutDir
+	if outDir == "" {
+		if opts.Plugin == "" {
+			return errors.BadInput.New("out is required when plugin is not specified")
+		}
+		outDir = filepath.Join("plugins", opts.Plugin, "e2e", "raw_tables")
+	}
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return errors.Convert(err)
+	}
+	for _, table := range tables {
+		rows, err := loadRawRows(db, table, opts.Params, opts.Limit)
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(outDir, fmt.Sprintf("%s.%s", table, opts.Format))
+		if opts.Format == FORMAT_JSON {
+			err = writeJsonFixtures(path, rows)
+		} else {
+			err = writeCsvFixtures(path, rows)
+		}
+		if err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("failed to record %s", table))
+		}
+		fmt.Printf("recorded %d rows of %s into %s\n", len(rows), table, path)
+	}
+	return nil
+}
+
+// loadRawRows returns the decompressed rows of the raw table collected with the specified params
+func loadRawRows(db dal.Dal, table string, params map[string]string, limit int) ([]*api.RawData, errors.Error) {
+	cursor, err := db.Cursor(
+		dal.Select(`id, params, data, url, input, created_at`),
+		dal.From(table),
+		dal.Orderby(`id`),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+	var rows []*api.RawData
+	for cursor.Next() && (limit <= 0 || len(rows) < limit) {
+		row := &api.RawData{}
+		err = db.Fetch(cursor, row)
+		if err != nil {
+			return nil, err
+		}
+		if !matchParams(row.Params, params) {
+			continue
+		}
+		row.Data, err = api.DecompressRawData(row.Data)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// matchParams tells if the params of a raw row, which is a json object, has all the expected values
+func matchParams(rawParams string, expected map[string]string) bool {
+	if len(expected) == 0 {
+		return true
+	}
+	var params map[string]interface{}
+	if json.Unmarshal([]byte(rawParams), &params) != nil {
+		return false
+	}
+	for key, value := range expected {
+		actual, ok := params[key]
+		if !ok || fmt.Sprint(actual) != value {
+			return false
+		}
+	}
+	return true
+}
+
+func writeCsvFixtures(path string, rows []*api.RawData) errors.Error {
+	csvWriter, err := pluginhelper.NewCsvFileWriter(path, []string{`id`, `params`, `data`, `url`, `input`, `created_at`})
+	if err != nil {
+		return err
+	}
+	defer csvWriter.Close()
+	for _, row := range rows {
+		csvWriter.Write([]string{
+			strconv.FormatUint(row.ID, 10),
+			row.Params,
+			string(row.Data),
+			row.Url,
+			string(row.Input),
+			row.CreatedAt.UTC().Format("2006-01-02T15:04:05.000-07:00"),
+		})
+	}
+	return nil
+}
+
+func writeJsonFixtures(path string, rows []*api.RawData) errors.Error {
+	fixtures := make([]e2ehelper.RawFixture, 0, len(rows))
+	for _, row := range rows {
+		if !json.Valid(row.Data) {
+			return errors.BadInput.New(fmt.Sprintf("data of row %d is not json, please record in csv format", row.ID))
+		}
+		fixture := e2ehelper.RawFixture{
+			Id:        row.ID,
+			Params:    row.Params,
+			Data:      row.Data,
+			Url:       row.Url,
+			CreatedAt: row.CreatedAt.UTC().Truncate(time.Millisecond),
+		}
+		if json.Valid(row.Input) && string(row.Input) != "null" {
+			fixture.Input = row.Input
+		}
+		fixtures = append(fixtures, fixture)
+	}
+	content, err := json.MarshalIndent(fixtures, "", "  ")
+	if err != nil {
+		return errors.Convert(err)
+	}
+	return errors.Convert(os.WriteFile(path, append(content, '\n'), 0644))
+}
@@ -0,0 +1,232 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+type scaffoldOptions struct {
+	Plugin       string
+	Name         string
+	RawTables    []string
+	Format       string
+	Extractors   []string
+	ToolModels   []string
+	Converters   []string
+	DomainModels []string
+	PluginType   string
+	TaskData     string
+	OutDir       string
+}
+
+// domainPackages are the packages that domain models might be referred from, i.e. ticket.Issue
+var domainPackages = map[string]string{
+	"code":        "github.com/apache/incubator-devlake/core/models/domainlayer/code",
+	"codequality": "github.com/apache/incubator-devlake/core/models/domainlayer/codequality",
+	"crossdomain": "github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain",
+	"devops":      "github.com/apache/incubator-devlake/core/models/domainlayer/devops",
+	"qa":          "github.com/apache/incubator-devlake/core/models/domainlayer/qa",
+	"ticket":      "github.com/apache/incubator-devlake/core/models/domainlayer/ticket",
+}
+
+const licenseHeader = `/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+`
+
+var scaffoldTpl = template.Must(template.New("e2e").Parse(`package e2e
+
+import (
+	"testing"
+{{ range .Imports }}
+	"{{ . }}"
+{{- end }}
+)
+
+func Test{{ .Title }}DataFlow(t *testing.T) {
+	var plugin {{ .PluginType }}
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "{{ .Plugin }}", plugin)
+
+	// TODO: fill in the options the raw tables were collected with
+	taskData := {{ .TaskData }}
+
+	// import raw data table
+{{- range .RawTables }}
+	dataflowTester.Import{{ $.ImportFormat }}IntoRawTable("./raw_tables/{{ . }}.{{ $.Format }}", "{{ . }}")
+{{- end }}
+{{- if .ToolModels }}
+
+	// verify extraction
+{{- range .ToolModels }}
+	dataflowTester.FlushTabler(&models.{{ . }}{})
+{{- end }}
+{{- range .Extractors }}
+	dataflowTester.Subtask(tasks.{{ . }}, taskData)
+{{- end }}
+{{- range .ToolModels }}
+	dataflowTester.VerifyTableWithOptions(models.{{ . }}{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/" + models.{{ . }}{}.TableName() + ".csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+{{- end }}
+{{- end }}
+{{- if .DomainModels }}
+
+	// verify conversion
+{{- range .DomainModels }}
+	dataflowTester.FlushTabler(&{{ . }}{})
+{{- end }}
+{{- range .Converters }}
+	dataflowTester.Subtask(tasks.{{ . }}, taskData)
+{{- end }}
+{{- range .DomainModels }}
+	dataflowTester.VerifyTableWithOptions({{ . }}{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/" + {{ . }}{}.TableName() + ".csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+{{- end }}
+{{- end }}
+}
+`))
+
+// camelCase converts names like `issue_changelog` or `issue-changelog` into `IssueChangelog`
+func camelCase(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return r == '_' || r == '-' || r == ' '
+	})
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return strings.Join(words, "")
+}
+
+// renderScaffold generates the source code of the e2e test
+func renderScaffold(opts *scaffoldOptions) ([]byte, errors.Error) {
+	if opts.Plugin == "" || opts.Name == "" {
+		return nil, errors.BadInput.New("plugin and name are required")
+	}
+	if len(opts.RawTables) == 0 {
+		return nil, errors.BadInput.New("at least one raw table is required")
+	}
+	if len(opts.Extractors) == 0 && len(opts.Converters) == 0 {
+		return nil, errors.BadInput.New("at least one extractor or converter is required")
+	}
+	if opts.Format != FORMAT_CSV && opts.Format != FORMAT_JSON {
+		return nil, errors.BadInput.New(fmt.Sprintf("unsupported format %s", opts.Format))
+	}
+	pluginTitle := camelCase(opts.Plugin)
+	pluginPkg := "github.com/apache/incubator-devlake/plugins/" + opts.Plugin
+	imports := []string{"github.com/apache/incubator-devlake/helpers/e2ehelper"}
+	if len(opts.ToolModels) > 0 || len(opts.DomainModels) > 0 {
+		imports = append(imports, "github.com/apache/incubator-devlake/core/models/common")
+	}
+	for _, model := range opts.DomainModels {
+		pkg, _, ok := strings.Cut(model, ".")
+		if !ok || domainPackages[pkg] == "" {
+			return nil, errors.BadInput.New(fmt.Sprintf("domain model %s should be referred as <package>.<Model>, i.e. ticket.Issue", model))
+		}
+		imports = append(imports, domainPackages[pkg])
+	}
+	pluginType := opts.PluginType
+	if pluginType == "" {
+		pluginType = "impl." + pluginTitle
+	}
+	if strings.HasPrefix(pluginType, "impl.") {
+		imports = append(imports, pluginPkg+"/impl")
+	}
+	if len(opts.ToolModels) > 0 {
+		imports = append(imports, pluginPkg+"/models")
+	}
+	taskData := opts.TaskData
+	if taskData == "" {
+		taskData = fmt.Sprintf("&tasks.%sTaskData{\n\t\tOptions: &tasks.%sOptions{\n\t\t\tConnectionId: 1,\n\t\t},\n\t}", pluginTitle, pluginTitle)
+	}
+	if len(opts.Extractors) > 0 || len(opts.Converters) > 0 || strings.Contains(taskData, "tasks.") {
+		imports = append(imports, pluginPkg+"/tasks")
+	}
+	importFormat := "Csv"
+	if opts.Format == FORMAT_JSON {
+		importFormat = "Json"
+	}
+
+	buf := bytes.NewBufferString(licenseHeader)
+	err := scaffoldTpl.Execute(buf, map[string]interface{}{
+		"Imports":      imports,
+		"Title":        camelCase(opts.Name),
+		"Plugin":       opts.Plugin,
+		"PluginType":   pluginType,
+		"TaskData":     taskData,
+		"RawTables":    opts.RawTables,
+		"Format":       opts.Format,
+		"ImportFormat": importFormat,
+		"Extractors":   opts.Extractors,
+		"ToolModels":   opts.ToolModels,
+		"Converters":   opts.Converters,
+		"DomainModels": opts.DomainModels,
+	})
+	if err != nil {
+		return nil, errors.Convert(err)
+	}
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "generated code is invalid, please check the arguments")
+	}
+	return source, nil
+}
+
+func writeScaffold(opts *scaffoldOptions, force bool) (string, errors.Error) {
+	source, err := renderScaffold(opts)
+	if err != nil {
+		return "", err
+	}
+	outDir := opts.OutDir
+	if outDir == "" {
+		outDir = filepath.Join("plugins", opts.Plugin, "e2e")
+	}
+	path := filepath.Join(outDir, strings.ReplaceAll(opts.Name, "-", "_")+"_test.go")
+	if _, statErr := os.Stat(path); statErr == nil && !force {
+		return "", errors.BadInput.New(fmt.Sprintf("%s already exists, use --force to overwrite it", path))
+	}
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return "", errors.Convert(err)
+	}
+	return path, errors.Convert(os.WriteFile(path, source, 0644))
+}
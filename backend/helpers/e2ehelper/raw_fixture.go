@@ -0,0 +1,78 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2ehelper
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// RawFixture is a row of a raw table in the json fixture format, the `data` is embedded as is to keep the recorded
+// api responses readable and diffable
+type RawFixture struct {
+	Id        uint64          `json:"id"`
+	Params    string          `json:"params"`
+	Data      json.RawMessage `json:"data"`
+	Url       string          `json:"url"`
+	Input     json.RawMessage `json:"input,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// LoadRawFixtures reads the rows of a raw table from specified json file
+func LoadRawFixtures(jsonRelPath string) ([]RawFixture, errors.Error) {
+	content, err := os.ReadFile(jsonRelPath)
+	if err != nil {
+		return nil, errors.Convert(err)
+	}
+	var fixtures []RawFixture
+	err = json.Unmarshal(content, &fixtures)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "failed to decode raw fixtures from "+jsonRelPath)
+	}
+	return fixtures, nil
+}
+
+// ImportJsonIntoRawTable imports records from specified json file into target raw table, note that existing data would be deleted first.
+func (t *DataFlowTester) ImportJsonIntoRawTable(jsonRelPath string, rawTableName string) {
+	fixtures, err := LoadRawFixtures(jsonRelPath)
+	if err != nil {
+		panic(err)
+	}
+	t.FlushRawTable(rawTableName)
+	for _, fixture := range fixtures {
+		toInsertValues := map[string]interface{}{
+			`id`:         fixture.Id,
+			`params`:     fixture.Params,
+			`data`:       []byte(fixture.Data),
+			`url`:        fixture.Url,
+			`created_at`: fixture.CreatedAt,
+		}
+		if len(fixture.Input) > 0 {
+			toInsertValues[`input`] = string(fixture.Input)
+		}
+		result := t.Db.Table(rawTableName).Create(toInsertValues)
+		if result.Error != nil {
+			panic(result.Error)
+		}
+		assert.Equal(t.T, int64(1), result.RowsAffected)
+	}
+}
@@ -379,3 +379,44 @@ func hashScript(script plugin.MigrationScript) string {
 	}
 	return strings.ToUpper(hex.EncodeToString(hasher.Sum(nil)))
 }
+
+type connectionProxy struct {
+	ID    uint64 `gorm:"primaryKey"`
+	Proxy string `gorm:"type:text"`
+}
+
+// EncryptConnectionProxies encrypts the proxy urls stored in plain text in the connection table, their user info
+// may hold the password of the proxy
+func EncryptConnectionProxies(basicRes context.BasicRes, script plugin.MigrationScript, tableName string) errors.Error {
+	return transformConnectionProxies(basicRes, script, tableName, plugin.Encrypt)
+}
+
+// DecryptConnectionProxies reverts EncryptConnectionProxies
+func DecryptConnectionProxies(basicRes context.BasicRes, script plugin.MigrationScript, tableName string) errors.Error {
+	return transformConnectionProxies(basicRes, script, tableName, plugin.Decrypt)
+}
+
+func transformConnectionProxies(
+	basicRes context.BasicRes,
+	script plugin.MigrationScript,
+	tableName string,
+	transform func(encryptionSecret, proxy string) (string, errors.Error),
+) errors.Error {
+	encryptionSecret := basicRes.GetConfig(plugin.EncodeKeyEnvStr)
+	if encryptionSecret == "" {
+		return errors.BadInput.New("invalid encryptionSecret")
+	}
+	return TransformColumns(
+		basicRes,
+		script,
+		tableName,
+		[]string{"proxy"},
+		func(src *connectionProxy) (*connectionProxy, errors.Error) {
+			proxy, err := transform(encryptionSecret, src.Proxy)
+			if err != nil {
+				return nil, err
+			}
+			return &connectionProxy{ID: src.ID, Proxy: proxy}, nil
+		},
+	)
+}
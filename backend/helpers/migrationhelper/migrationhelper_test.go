@@ -143,6 +143,7 @@ func TestTransformTable(t *testing.T) {
 
 	mockRes.On("GetDal").Return(mockDal)
 	mockRes.On("GetLogger").Return(mockLog)
+	mockRes.On("GetConfig", mock.Anything).Return("").Maybe()
 
 	err := TransformTable(mockRes, &TestScript{}, TestTableNameSrc,
 		func(src *TestSrcTable) (*TestDstTable, errors.Error) {
@@ -214,6 +215,7 @@ func TestTransformTable_RollBack(t *testing.T) {
 
 	mockRes.On("GetDal").Return(mockDal)
 	mockRes.On("GetLogger").Return(mockLog)
+	mockRes.On("GetConfig", mock.Anything).Return("").Maybe()
 
 	err := TransformTable(mockRes, &TestScript{}, TestTableNameSrc,
 		func(src *TestSrcTable) (*TestDstTable, errors.Error) {
@@ -307,6 +309,7 @@ func TestCopyTableColumns(t *testing.T) {
 
 	mockRes.On("GetDal").Return(mockDal)
 	mockRes.On("GetLogger").Return(mockLog)
+	mockRes.On("GetConfig", mock.Anything).Return("").Maybe()
 
 	err := CopyTableColumns(mockRes, TestTableNameSrc, TestTableNameDst,
 		func(src *TestSrcTable) (*TestDstTable, errors.Error) {
@@ -378,6 +381,7 @@ func TestCopyTableColumns_RollBack(t *testing.T) {
 
 	mockRes.On("GetDal").Return(mockDal)
 	mockRes.On("GetLogger").Return(mockLog)
+	mockRes.On("GetConfig", mock.Anything).Return("").Maybe()
 
 	err := CopyTableColumns(mockRes, TestTableNameSrc, TestTableNameDst,
 		func(src *TestSrcTable) (*TestDstTable, errors.Error) {
@@ -470,6 +474,7 @@ func TestTransformColumns(t *testing.T) {
 
 	mockRes.On("GetDal").Return(mockDal)
 	mockRes.On("GetLogger").Return(mockLog)
+	mockRes.On("GetConfig", mock.Anything).Return("").Maybe()
 
 	err := TransformColumns(mockRes, &TestScript{}, TestTableNameSrc,
 		[]string{
@@ -547,6 +552,7 @@ func TestTransformColumns_RollBack(t *testing.T) {
 
 	mockRes.On("GetDal").Return(mockDal)
 	mockRes.On("GetLogger").Return(mockLog)
+	mockRes.On("GetConfig", mock.Anything).Return("").Maybe()
 
 	err := TransformColumns(mockRes, &TestScript{}, TestTableNameSrc,
 		[]string{
@@ -598,6 +604,7 @@ func TestChangeColumnsType(t *testing.T) {
 
 	mockRes.On("GetDal").Return(mockDal)
 	mockRes.On("GetLogger").Return(mockLog)
+	mockRes.On("GetConfig", mock.Anything).Return("").Maybe()
 
 	err := ChangeColumnsType[TestDstTable](mockRes, &TestScript{}, TestTableNameSrc,
 		[]string{
@@ -660,6 +667,7 @@ func TestChangeColumnsType_Rollback(t *testing.T) {
 
 	mockRes.On("GetDal").Return(mockDal)
 	mockRes.On("GetLogger").Return(mockLog)
+	mockRes.On("GetConfig", mock.Anything).Return("").Maybe()
 
 	err := ChangeColumnsType[TestDstTable](mockRes, &TestScript{}, TestTableNameSrc,
 		[]string{
@@ -0,0 +1,172 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/config"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/codequality"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+)
+
+const anonymizedEmailDomain = "anonymized.invalid"
+
+// the records may be added more than once, the pseudonyms are left as is
+var anonymizedNamePattern = regexp.MustCompile(`^user-[0-9a-f]{16}$`)
+
+// Anonymizer replaces the names and emails of the people in the domain layer records by pseudonyms. The same input
+// always gives the same pseudonym, so the metrics grouping or joining by person keep working
+type Anonymizer struct {
+	secret []byte
+}
+
+// NewAnonymizer returns the anonymizer when ANONYMIZE_PII is enabled, nil otherwise. The pseudonyms are keyed by
+// ANONYMIZATION_SECRET, or ENCRYPTION_SECRET if not set
+func NewAnonymizer() *Anonymizer {
+	cfg := config.GetConfig()
+	if !cfg.GetBool("ANONYMIZE_PII") {
+		return nil
+	}
+	secret := cfg.GetString("ANONYMIZATION_SECRET")
+	if secret == "" {
+		secret = cfg.GetString("ENCRYPTION_SECRET")
+	}
+	return &Anonymizer{secret: []byte(secret)}
+}
+
+// Anonymize replaces the personal data of the accounts, users, commits, pull requests, issues, incidents, directory
+// ownerships and code quality issues in place, other records are left untouched
+func (a *Anonymizer) Anonymize(record interface{}) {
+	switch r := record.(type) {
+	case *crossdomain.Account:
+		// the accounts of the commit authors are identified by their emails
+		r.Id = a.emailId(r.Id)
+		r.Email = a.Email(r.Email)
+		r.FullName = a.Name(r.FullName)
+		r.UserName = a.Name(r.UserName)
+		r.AvatarUrl = ""
+	case *crossdomain.User:
+		r.Email = a.Email(r.Email)
+		r.Name = a.Name(r.Name)
+	case *code.Commit:
+		r.AuthorName = a.Name(r.AuthorName)
+		r.AuthorEmail = a.Email(r.AuthorEmail)
+		r.AuthorId = a.emailId(r.AuthorId)
+		r.CommitterName = a.Name(r.CommitterName)
+		r.CommitterEmail = a.Email(r.CommitterEmail)
+		r.CommitterId = a.emailId(r.CommitterId)
+	case *code.PullRequest:
+		r.AuthorName = a.Name(r.AuthorName)
+		r.MergedByName = a.Name(r.MergedByName)
+	case *code.PullRequestCommit:
+		r.CommitAuthorName = a.Name(r.CommitAuthorName)
+		r.CommitAuthorEmail = a.Email(r.CommitAuthorEmail)
+	case *code.PullRequestReviewer:
+		r.Name = a.Name(r.Name)
+		r.UserName = a.Name(r.UserName)
+	case *code.PullRequestAssignee:
+		r.Name = a.Name(r.Name)
+		r.UserName = a.Name(r.UserName)
+	case *code.DirectoryOwnership:
+		r.AuthorName = a.Name(r.AuthorName)
+		r.AuthorEmail = a.Email(r.AuthorEmail)
+	case *ticket.Issue:
+		r.CreatorName = a.Name(r.CreatorName)
+		r.AssigneeName = a.Name(r.AssigneeName)
+	case *ticket.IssueAssignee:
+		r.AssigneeName = a.Name(r.AssigneeName)
+	case *ticket.IssueChangelogs:
+		r.AuthorName = a.Name(r.AuthorName)
+		r.AuthorId = a.emailId(r.AuthorId)
+	case *ticket.IssueStatusChange:
+		r.AuthorName = a.Name(r.AuthorName)
+	case *ticket.Incident:
+		r.CreatorName = a.Name(r.CreatorName)
+		r.AssigneeName = a.Name(r.AssigneeName)
+	case *ticket.IncidentAssignee:
+		r.AssigneeName = a.Name(r.AssigneeName)
+	case *codequality.CqIssue:
+		r.CommitAuthorEmail = a.Email(r.CommitAuthorEmail)
+		r.Assignee = a.Name(r.Assignee)
+	}
+}
+
+// anonymizedColumns are the columns holding names or emails, for the records saved as maps like the imported csv files
+var anonymizedColumns = map[string]bool{
+	"author_name":         false,
+	"author_email":        true,
+	"committer_name":      false,
+	"committer_email":     true,
+	"creator_name":        false,
+	"assignee_name":       false,
+	"merged_by_name":      false,
+	"commit_author_name":  false,
+	"commit_author_email": true,
+}
+
+// AnonymizeRecord replaces the names and emails of the record in place, the columns are told apart by their names
+func (a *Anonymizer) AnonymizeRecord(record map[string]interface{}) {
+	for column, isEmail := range anonymizedColumns {
+		value, ok := record[column].(string)
+		if !ok {
+			continue
+		}
+		if isEmail {
+			record[column] = a.Email(value)
+		} else {
+			record[column] = a.Name(value)
+		}
+	}
+}
+
+// Name returns the pseudonym of a name or a username
+func (a *Anonymizer) Name(name string) string {
+	if name == "" || anonymizedNamePattern.MatchString(name) {
+		return name
+	}
+	return "user-" + a.hash(name)
+}
+
+// Email returns the pseudonym of an email, case-insensitively since emails are
+func (a *Anonymizer) Email(email string) string {
+	if email == "" || strings.HasSuffix(email, "@"+anonymizedEmailDomain) {
+		return email
+	}
+	return a.hash(strings.ToLower(strings.TrimSpace(email))) + "@" + anonymizedEmailDomain
+}
+
+// emailId anonymizes the ids which are emails, like the author ids of the commits collected from git
+func (a *Anonymizer) emailId(id string) string {
+	if !strings.Contains(id, "@") {
+		return id
+	}
+	return a.Email(id)
+}
+
+func (a *Anonymizer) hash(value string) string {
+	h := hmac.New(sha256.New, a.secret)
+	h.Write([]byte(value))
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
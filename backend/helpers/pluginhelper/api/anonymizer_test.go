@@ -0,0 +1,99 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/codequality"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnonymizer(t *testing.T) {
+	anonymizer := &Anonymizer{secret: []byte("secret")}
+
+	account := &crossdomain.Account{Email: "Alice@Example.com", FullName: "Alice", UserName: "alice", AvatarUrl: "https://avatars/alice"}
+	commit := &code.Commit{AuthorName: "Alice", AuthorEmail: "alice@example.com", AuthorId: "alice@example.com", CommitterId: "github:GithubAccount:1:2"}
+	issue := &ticket.Issue{AssigneeName: "alice", CreatorName: "bob"}
+	anonymizer.Anonymize(account)
+	anonymizer.Anonymize(commit)
+	anonymizer.Anonymize(issue)
+
+	assert.Regexp(t, `^[0-9a-f]{16}@anonymized\.invalid$`, account.Email)
+	assert.Regexp(t, `^user-[0-9a-f]{16}$`, account.UserName)
+	assert.Empty(t, account.AvatarUrl)
+	// the same person gets the same pseudonyms everywhere
+	assert.Equal(t, account.Email, commit.AuthorEmail)
+	assert.Equal(t, account.Email, commit.AuthorId)
+	assert.Equal(t, account.FullName, commit.AuthorName)
+	assert.Equal(t, account.UserName, issue.AssigneeName)
+	assert.NotEqual(t, issue.AssigneeName, issue.CreatorName)
+	// ids which are not emails are kept
+	assert.Equal(t, "github:GithubAccount:1:2", commit.CommitterId)
+
+	// anonymizing twice changes nothing
+	email, userName := account.Email, account.UserName
+	anonymizer.Anonymize(account)
+	assert.Equal(t, email, account.Email)
+	assert.Equal(t, userName, account.UserName)
+}
+
+func TestAnonymizerRecords(t *testing.T) {
+	anonymizer := &Anonymizer{secret: []byte("secret")}
+	records := []interface{}{
+		&crossdomain.Account{DomainEntity: domainlayer.DomainEntity{Id: "alice@example.com"}, Email: "alice@example.com", FullName: "Alice Smith"},
+		&code.Commit{AuthorName: "Alice Smith", AuthorEmail: "alice@example.com", CommitterName: "Bob Jones", CommitterEmail: "bob@example.com", CommitterId: "bob@example.com"},
+		&code.PullRequest{AuthorName: "Alice Smith", MergedByName: "Bob Jones"},
+		&code.PullRequestCommit{CommitAuthorName: "Alice Smith", CommitAuthorEmail: "alice@example.com"},
+		&code.PullRequestReviewer{Name: "Bob Jones", UserName: "bob"},
+		&code.PullRequestAssignee{Name: "Bob Jones", UserName: "bob"},
+		&code.DirectoryOwnership{AuthorName: "Alice Smith", AuthorEmail: "alice@example.com"},
+		&ticket.Issue{CreatorName: "Alice Smith", AssigneeName: "Bob Jones"},
+		&ticket.IssueAssignee{AssigneeName: "Bob Jones"},
+		&ticket.IssueChangelogs{AuthorName: "Alice Smith", AuthorId: "alice@example.com"},
+		&ticket.IssueStatusChange{AuthorName: "Alice Smith"},
+		&ticket.Incident{CreatorName: "Alice Smith", AssigneeName: "Bob Jones"},
+		&ticket.IncidentAssignee{AssigneeName: "Bob Jones"},
+		&codequality.CqIssue{CommitAuthorEmail: "alice@example.com", Assignee: "bob"},
+	}
+	for _, record := range records {
+		anonymizer.Anonymize(record)
+		content, err := json.Marshal(record)
+		assert.Nil(t, err)
+		for _, identity := range []string{"Alice", "Bob", "alice", "bob", "example.com"} {
+			assert.NotContains(t, string(content), identity, "%T", record)
+		}
+	}
+	// the same person gets the same pseudonyms in every table
+	ownership := records[6].(*code.DirectoryOwnership)
+	assert.Equal(t, records[1].(*code.Commit).AuthorEmail, ownership.AuthorEmail)
+	assert.Equal(t, records[0].(*crossdomain.Account).Id, ownership.AuthorEmail)
+	assert.Equal(t, records[4].(*code.PullRequestReviewer).Name, records[2].(*code.PullRequest).MergedByName)
+
+	record := map[string]interface{}{"id": "issue-1", "creator_name": "Alice Smith", "author_email": "alice@example.com", "assignee_name": nil}
+	anonymizer.AnonymizeRecord(record)
+	assert.Equal(t, "issue-1", record["id"])
+	assert.Equal(t, anonymizer.Name("Alice Smith"), record["creator_name"])
+	assert.Equal(t, anonymizer.Email("alice@example.com"), record["author_email"])
+	assert.Nil(t, record["assignee_name"])
+}
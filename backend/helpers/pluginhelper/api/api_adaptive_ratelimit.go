@@ -0,0 +1,124 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/metrics"
+)
+
+const (
+	// adaptiveMinTickInterval caps the pace at 100 requests per second no matter how generous the server is
+	adaptiveMinTickInterval = 10 * time.Millisecond
+	adaptiveMaxWorkers      = 100
+	// adaptiveTolerance avoids resetting the ticker for every tiny change of the remaining quota
+	adaptiveTolerance = 0.1
+)
+
+var rateLimitRemainingHeaders = []string{"X-RateLimit-Remaining", "RateLimit-Remaining"}
+var rateLimitResetHeaders = []string{"X-RateLimit-Reset", "RateLimit-Reset"}
+
+// observeRateLimit reads the rate limit headers of a response, it returns the interval to spread the remaining quota
+// evenly until the window resets, and for how long the requests should be paused when the server asks so. The headers
+// describe the token of the response, the client rotating tokens is assumed to have as much left on each of them
+func observeRateLimit(header http.Header, now time.Time, tokens int) (interval time.Duration, pause time.Duration) {
+	if retryAfter := header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil {
+			pause = time.Duration(seconds) * time.Second
+		} else if at, err := http.ParseTime(retryAfter); err == nil {
+			pause = at.Sub(now)
+		}
+	}
+	remaining, ok := firstIntHeader(header, rateLimitRemainingHeaders)
+	if !ok {
+		return 0, pause
+	}
+	reset, ok := firstIntHeader(header, rateLimitResetHeaders)
+	if !ok {
+		return 0, pause
+	}
+	// the reset is either an epoch timestamp (GitHub, GitLab) or the number of seconds left in the window
+	window := time.Duration(reset) * time.Second
+	if reset > 1e9 {
+		window = time.Unix(reset, 0).Sub(now)
+	}
+	if window <= 0 {
+		return 0, pause
+	}
+	if remaining <= 0 {
+		// the other tokens may still have some quota left
+		if window > pause && tokens <= 1 {
+			pause = window
+		}
+		return 0, pause
+	}
+	if tokens > 1 {
+		remaining *= int64(tokens)
+	}
+	// leave some of the quota for other clients sharing the same token
+	requests := int64(math.Max(float64(remaining)*0.95, 1))
+	return window / time.Duration(requests), pause
+}
+
+func firstIntHeader(header http.Header, names []string) (int64, bool) {
+	for _, name := range names {
+		if value := header.Get(name); value != "" {
+			if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+				return n, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// adaptToRateLimit adjusts the pace and the number of workers to the rate limit reported by the server
+func (apiClient *ApiAsyncClient) adaptToRateLimit(res *http.Response) {
+	if !apiClient.adaptive || res == nil {
+		return
+	}
+	interval, pause := observeRateLimit(res.Header, time.Now(), apiClient.tokens)
+	if pause > 0 {
+		apiClient.logger.Info("rate limit reached, pausing requests for %s", pause)
+		apiClient.Pause(pause)
+	}
+	if interval <= 0 {
+		return
+	}
+	if interval < adaptiveMinTickInterval {
+		interval = adaptiveMinTickInterval
+	}
+	current := apiClient.GetTickInterval()
+	if math.Abs(float64(interval-current)) < float64(current)*adaptiveTolerance {
+		return
+	}
+	numOfWorkers := int(assumedResponseTime / interval)
+	if numOfWorkers < 1 {
+		numOfWorkers = 1
+	} else if numOfWorkers > adaptiveMaxWorkers {
+		numOfWorkers = adaptiveMaxWorkers
+	}
+	apiClient.logger.Debug("adjusting to rate limit, interval: %s -> %s, number of workers: %d", current, interval, numOfWorkers)
+	apiClient.Reset(interval)
+	apiClient.Tune(numOfWorkers)
+	apiClient.numOfWorkers.Store(int32(numOfWorkers))
+	metrics.ApiRateLimit.WithLabelValues(apiClient.metricsPlugin, apiClient.metricsConnection).Set(float64(time.Hour) / float64(interval))
+}
@@ -0,0 +1,99 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserveRateLimit(t *testing.T) {
+	now := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+
+	// epoch reset, 1000 requests left in 10 minutes
+	header := http.Header{}
+	header.Set("X-RateLimit-Remaining", "1000")
+	header.Set("X-RateLimit-Reset", strconv.FormatInt(now.Add(10*time.Minute).Unix(), 10))
+	interval, pause := observeRateLimit(header, now, 1)
+	assert.Equal(t, 10*time.Minute/950, interval)
+	assert.Equal(t, time.Duration(0), pause)
+
+	// seconds left in the window
+	header = http.Header{}
+	header.Set("RateLimit-Remaining", "100")
+	header.Set("RateLimit-Reset", "60")
+	interval, pause = observeRateLimit(header, now, 1)
+	assert.Equal(t, time.Minute/95, interval)
+	assert.Equal(t, time.Duration(0), pause)
+
+	// quota exhausted, wait until the window resets
+	header = http.Header{}
+	header.Set("X-RateLimit-Remaining", "0")
+	header.Set("X-RateLimit-Reset", strconv.FormatInt(now.Add(30*time.Second).Unix(), 10))
+	interval, pause = observeRateLimit(header, now, 1)
+	assert.Equal(t, time.Duration(0), interval)
+	assert.Equal(t, 30*time.Second, pause)
+
+	// the other tokens rotated by the client have as much left
+	header = http.Header{}
+	header.Set("X-RateLimit-Remaining", "1000")
+	header.Set("X-RateLimit-Reset", strconv.FormatInt(now.Add(10*time.Minute).Unix(), 10))
+	interval, pause = observeRateLimit(header, now, 4)
+	assert.Equal(t, 10*time.Minute/3800, interval)
+	assert.Equal(t, time.Duration(0), pause)
+	header.Set("X-RateLimit-Remaining", "0")
+	interval, pause = observeRateLimit(header, now, 4)
+	assert.Equal(t, time.Duration(0), interval)
+	assert.Equal(t, time.Duration(0), pause)
+
+	// retry after in seconds and http date
+	header = http.Header{}
+	header.Set("Retry-After", "5")
+	interval, pause = observeRateLimit(header, now, 1)
+	assert.Equal(t, time.Duration(0), interval)
+	assert.Equal(t, 5*time.Second, pause)
+	header.Set("Retry-After", now.Add(time.Minute).Format(http.TimeFormat))
+	_, pause = observeRateLimit(header, now, 1)
+	assert.Equal(t, time.Minute, pause)
+
+	// no rate limit headers at all
+	interval, pause = observeRateLimit(http.Header{}, now, 1)
+	assert.Equal(t, time.Duration(0), interval)
+	assert.Equal(t, time.Duration(0), pause)
+}
+
+func TestWorkerSchedulerPause(t *testing.T) {
+	s, _ := NewWorkerScheduler(context.Background(), 1, 10*time.Millisecond, unithelper.DummyLogger())
+	defer s.Release()
+	start := time.Now()
+	var executedAt time.Time
+	s.Pause(300 * time.Millisecond)
+	s.SubmitBlocking(func() errors.Error {
+		executedAt = time.Now()
+		return nil
+	})
+	assert.Nil(t, s.WaitAsync())
+	assert.GreaterOrEqual(t, executedAt.Sub(start), 300*time.Millisecond)
+}
@@ -24,10 +24,12 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"sync/atomic"
 	"time"
 
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/log"
+	"github.com/apache/incubator-devlake/core/metrics"
 	plugin "github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/core/utils"
 )
@@ -42,12 +44,21 @@ type ApiAsyncClient struct {
 	*ApiClient
 	*WorkerScheduler
 	maxRetry     int
-	numOfWorkers int
+	numOfWorkers atomic.Int32
 	logger       log.Logger
+	adaptive     bool
+	tokens       int
+	backoff      retryBackoff
+	breaker      *circuitBreaker
 }
 
 const defaultTimeout = 120 * time.Second
 
+// it is hard to tell how many workers would be sufficient, it depends on how slow the server responds.
+// we need more workers when server is responding slowly, because requests are sent in a fixed pace.
+// and because workers are relatively cheap, lets assume response takes 5 seconds
+const assumedResponseTime = 5 * time.Second
+
 // CreateAsyncApiClient creates a new ApiAsyncClient
 func CreateAsyncApiClient(
 	taskCtx plugin.TaskContext,
@@ -91,17 +102,15 @@ func CreateAsyncApiClient(
 		return nil, errors.Default.Wrap(err, "failed to calculate rateLimit for api")
 	}
 
-	// it is hard to tell how many workers would be sufficient, it depends on how slow the server responds.
-	// we need more workers when server is responding slowly, because requests are sent in a fixed pace.
-	// and because workers are relatively cheap, lets assume response takes 5 seconds
-	const RESPONSE_TIME = 5 * time.Second
 	// in order for scheduler to hold requests of 3 seconds, we need:
-	d := duration / RESPONSE_TIME
+	d := duration / assumedResponseTime
 	numOfWorkers := requests / int(d)
 	tickInterval, err := CalcTickInterval(requests, duration)
 	if err != nil {
 		return nil, err
 	}
+	apiClient.metricsPlugin = taskCtx.GetName()
+	metrics.ApiRateLimit.WithLabelValues(apiClient.metricsPlugin, apiClient.metricsConnection).Set(float64(requests) * float64(time.Hour) / float64(duration))
 
 	logger := taskCtx.GetLogger().Nested("api async client")
 	logger.Info(
@@ -122,14 +131,54 @@ func CreateAsyncApiClient(
 		return nil, errors.Default.Wrap(err, "failed to create scheduler")
 	}
 
+	// keep adjusting the pace to the rate limit headers unless the user has specified one explicitly
+	adaptive, err := utils.StrToBoolOr(taskCtx.GetConfig("API_ADAPTIVE_RATE_LIMIT"), false)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "failed to parse API_ADAPTIVE_RATE_LIMIT")
+	}
+
+	// retries are delayed exponentially, and requests are held off while the upstream keeps failing
+	backoff := retryBackoff{}
+	backoff.base, err = utils.StrToDurationOr(taskCtx.GetConfig("API_RETRY_BACKOFF"), time.Second)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "failed to parse API_RETRY_BACKOFF")
+	}
+	backoff.max, err = utils.StrToDurationOr(taskCtx.GetConfig("API_RETRY_MAX_BACKOFF"), time.Minute)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "failed to parse API_RETRY_MAX_BACKOFF")
+	}
+	backoff.jitter, err = utils.StrToFloatOr(taskCtx.GetConfig("API_RETRY_JITTER"), 0.5)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "failed to parse API_RETRY_JITTER")
+	}
+	breakerThreshold, err := utils.StrToIntOr(taskCtx.GetConfig("API_CIRCUIT_BREAKER_THRESHOLD"), 5)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "failed to parse API_CIRCUIT_BREAKER_THRESHOLD")
+	}
+	breakerCooldown, err := utils.StrToDurationOr(taskCtx.GetConfig("API_CIRCUIT_BREAKER_COOLDOWN"), 30*time.Second)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "failed to parse API_CIRCUIT_BREAKER_COOLDOWN")
+	}
+	breakerMaxTrips, err := utils.StrToIntOr(taskCtx.GetConfig("API_CIRCUIT_BREAKER_MAX_TRIPS"), 3)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "failed to parse API_CIRCUIT_BREAKER_MAX_TRIPS")
+	}
+
 	// finally, wrap around api client with async sematic
-	return &ApiAsyncClient{
-		apiClient,
-		scheduler,
-		retry,
-		numOfWorkers,
-		logger,
-	}, nil
+	asyncClient := &ApiAsyncClient{
+		ApiClient:       apiClient,
+		WorkerScheduler: scheduler,
+		maxRetry:        retry,
+		logger:          logger,
+		adaptive:        adaptive && rateLimiter.UserRateLimitPerHour <= 0,
+		tokens:          rateLimiter.Tokens,
+		backoff:         backoff,
+	}
+	if breakerThreshold > 0 {
+		asyncClient.breaker = newCircuitBreaker(breakerThreshold, breakerCooldown, breakerMaxTrips)
+	}
+	asyncClient.numOfWorkers.Store(int32(numOfWorkers))
+	return asyncClient, nil
 }
 
 // GetMaxRetry returns the maximum retry attempts for a request
@@ -160,16 +209,36 @@ func (apiClient *ApiAsyncClient) DoAsync(
 		var res *http.Response
 		var respBody []byte
 
+		// hold the request off while the upstream is considered unavailable
+		if apiClient.breaker != nil {
+			wait, breakerErr := apiClient.breaker.acquire()
+			if breakerErr != nil {
+				return breakerErr
+			}
+			if wait > 0 {
+				apiClient.NextTick(func() errors.Error {
+					if err := apiClient.sleep(wait); err != nil {
+						return errors.Convert(err)
+					}
+					apiClient.SubmitBlocking(request)
+					return nil
+				})
+				return nil
+			}
+		}
+
 		apiClient.logger.Debug("endpoint: %s  method: %s  header: %s  body: %s query: %s", path, method, header, body, query)
 		res, err = apiClient.Do(method, path, query, body, header)
 		if err == ErrIgnoreAndContinue {
 			// make sure defer func got be executed
 			err = nil //nolint
+			apiClient.reportToBreaker(true)
 			return nil
 		}
 
 		// make sure response body is read successfully, or we might have to retry
 		if err == nil {
+			apiClient.adaptToRateLimit(res)
 			// make sure response.Body stream will be closed to avoid running out of file handle
 			defer func(readCloser io.ReadCloser) { _ = readCloser.Close() }(res.Body)
 			// replace NetworkStream with MemoryBuffer
@@ -179,6 +248,11 @@ func (apiClient *ApiAsyncClient) DoAsync(
 			}
 		}
 
+		// only unreachable upstream and server errors count against the circuit breaker
+		if err != context.Canceled {
+			apiClient.reportToBreaker(err == nil && res.StatusCode < http.StatusInternalServerError)
+		}
+
 		// check
 		needRetry := false
 		errMessage := "unknown"
@@ -195,9 +269,13 @@ func (apiClient *ApiAsyncClient) DoAsync(
 		if needRetry {
 			// check whether we still have retry times and not error from handler and canceled error
 			if retry < apiClient.maxRetry && err != context.Canceled {
-				apiClient.logger.Warn(err, "retry #%d calling %s", retry, path)
+				delay := apiClient.backoff.delay(retry)
+				apiClient.logger.Warn(err, "retry #%d calling %s in %s", retry, path, delay)
 				retry++
 				apiClient.NextTick(func() errors.Error {
+					if err := apiClient.sleep(delay); err != nil {
+						return errors.Convert(err)
+					}
 					apiClient.SubmitBlocking(request)
 					return nil
 				})
@@ -218,6 +296,17 @@ func (apiClient *ApiAsyncClient) DoAsync(
 	apiClient.SubmitBlocking(request)
 }
 
+// reportToBreaker feeds the result of a request to the circuit breaker, and pauses all requests once it is opened
+func (apiClient *ApiAsyncClient) reportToBreaker(success bool) {
+	if apiClient.breaker == nil {
+		return
+	}
+	if apiClient.breaker.report(success) {
+		apiClient.logger.Warn(nil, "circuit breaker opened after consecutive failures, holding requests off for %s", apiClient.breaker.cooldown)
+		apiClient.Pause(apiClient.breaker.cooldown)
+	}
+}
+
 // DoGetAsync Enqueue an api get request, the request may be sent sometime in future in parallel with other api requests
 func (apiClient *ApiAsyncClient) DoGetAsync(
 	path string,
@@ -241,7 +330,7 @@ func (apiClient *ApiAsyncClient) DoPostAsync(
 
 // GetNumOfWorkers to return the Workers count if scheduler.
 func (apiClient *ApiAsyncClient) GetNumOfWorkers() int {
-	return apiClient.numOfWorkers
+	return int(apiClient.numOfWorkers.Load())
 }
 
 // RateLimitedApiClient FIXME ...
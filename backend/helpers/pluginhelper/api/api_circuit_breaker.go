@@ -0,0 +1,131 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+// retryBackoff calculates the delay before retrying a failed request, it grows exponentially with the number of
+// attempts, and is randomized by the jitter to avoid all workers retrying at the same moment
+type retryBackoff struct {
+	base   time.Duration
+	max    time.Duration
+	jitter float64
+}
+
+func (b retryBackoff) delay(attempt int) time.Duration {
+	if b.base <= 0 {
+		return 0
+	}
+	d := float64(b.base) * math.Pow(2, float64(attempt))
+	if b.max > 0 && d > float64(b.max) {
+		d = float64(b.max)
+	}
+	if b.jitter > 0 {
+		d -= d * math.Min(b.jitter, 1) * rand.Float64()
+	}
+	return time.Duration(d)
+}
+
+const (
+	circuitClosed = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// circuitBreaker stops sending requests to an upstream that keeps failing. It opens after `threshold` consecutive
+// failures, then lets a single probe through once the `cooldown` has passed, and gets closed again if the probe
+// succeeds. Collection fails fast after it has been opened for `maxTrips` times.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	maxTrips  int
+	now       func() time.Time
+
+	mu       sync.Mutex
+	state    int
+	failures int
+	trips    int
+	openedAt time.Time
+	probing  bool
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration, maxTrips int) *circuitBreaker {
+	return &circuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		maxTrips:  maxTrips,
+		now:       time.Now,
+	}
+}
+
+// acquire tells how long the request should wait before it may be sent
+func (b *circuitBreaker) acquire() (time.Duration, errors.Error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case circuitOpen:
+		if b.maxTrips > 0 && b.trips >= b.maxTrips {
+			return 0, errors.Default.New(fmt.Sprintf("circuit breaker opened %d times, the upstream seems to be unavailable", b.trips))
+		}
+		if elapsed := b.now().Sub(b.openedAt); elapsed < b.cooldown {
+			return b.cooldown - elapsed, nil
+		}
+		b.state = circuitHalfOpen
+		b.probing = true
+		return 0, nil
+	case circuitHalfOpen:
+		if b.probing {
+			// wait for the result of the probe
+			if b.cooldown < time.Second {
+				return b.cooldown, nil
+			}
+			return time.Second, nil
+		}
+		b.probing = true
+	}
+	return 0, nil
+}
+
+// report records the result of a request, it returns true if the breaker has just been opened
+func (b *circuitBreaker) report(success bool) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if success {
+		b.state = circuitClosed
+		b.failures = 0
+		b.probing = false
+		return false
+	}
+	b.failures++
+	if b.state == circuitHalfOpen || (b.state == circuitClosed && b.failures >= b.threshold) {
+		b.state = circuitOpen
+		b.openedAt = b.now()
+		b.probing = false
+		b.trips++
+		return true
+	}
+	return false
+}
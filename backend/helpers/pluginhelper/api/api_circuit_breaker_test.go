@@ -0,0 +1,93 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryBackoff(t *testing.T) {
+	b := retryBackoff{base: time.Second, max: 10 * time.Second}
+	assert.Equal(t, time.Second, b.delay(0))
+	assert.Equal(t, 2*time.Second, b.delay(1))
+	assert.Equal(t, 8*time.Second, b.delay(3))
+	assert.Equal(t, 10*time.Second, b.delay(4))
+
+	b.jitter = 0.5
+	for i := 0; i < 100; i++ {
+		d := b.delay(2)
+		assert.GreaterOrEqual(t, d, 2*time.Second)
+		assert.LessOrEqual(t, d, 4*time.Second)
+	}
+
+	assert.Equal(t, time.Duration(0), retryBackoff{}.delay(3))
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+	b := newCircuitBreaker(3, 30*time.Second, 3)
+	b.now = func() time.Time { return now }
+
+	// closed, failures below the threshold
+	assert.False(t, b.report(false))
+	assert.False(t, b.report(false))
+	assert.False(t, b.report(true))
+	assert.False(t, b.report(false))
+	assert.False(t, b.report(false))
+	wait, err := b.acquire()
+	assert.Nil(t, err)
+	assert.Equal(t, time.Duration(0), wait)
+
+	// opened by the third consecutive failure
+	assert.True(t, b.report(false))
+	now = now.Add(10 * time.Second)
+	wait, err = b.acquire()
+	assert.Nil(t, err)
+	assert.Equal(t, 20*time.Second, wait)
+
+	// half open after cooldown, only the probe goes through
+	now = now.Add(20 * time.Second)
+	wait, err = b.acquire()
+	assert.Nil(t, err)
+	assert.Equal(t, time.Duration(0), wait)
+	wait, err = b.acquire()
+	assert.Nil(t, err)
+	assert.Equal(t, time.Second, wait)
+
+	// probe succeeded
+	assert.False(t, b.report(true))
+	wait, err = b.acquire()
+	assert.Nil(t, err)
+	assert.Equal(t, time.Duration(0), wait)
+
+	// opened again, and the failed probe reopens it
+	b.report(false)
+	b.report(false)
+	assert.True(t, b.report(false))
+	now = now.Add(30 * time.Second)
+	_, err = b.acquire()
+	assert.Nil(t, err)
+	assert.True(t, b.report(false))
+
+	// tripped too many times
+	_, err = b.acquire()
+	assert.NotNil(t, err)
+}
@@ -30,6 +30,7 @@ import (
 	"net/url"
 	"reflect"
 	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -40,7 +41,10 @@ import (
 	"github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/log"
+	"github.com/apache/incubator-devlake/core/metrics"
+	"github.com/apache/incubator-devlake/core/tracing"
 	"github.com/apache/incubator-devlake/core/utils"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // ErrIgnoreAndContinue is a error which should be ignored
@@ -67,6 +71,10 @@ type ApiClient struct {
 	afterResponse plugin.ApiClientAfterResponse
 	ctx           gocontext.Context
 	logger        log.Logger
+
+	// labels of the request metrics
+	metricsPlugin     string
+	metricsConnection string
 }
 
 // NewApiClientFromConnection creates ApiClient based on given connection.
@@ -78,10 +86,25 @@ func NewApiClientFromConnection(
 	if reflect.ValueOf(connection).Kind() != reflect.Ptr {
 		panic(fmt.Errorf("connection is not a pointer"))
 	}
-	apiClient, err := NewApiClient(ctx, connection.GetEndpoint(), nil, 0, connection.GetProxy(), br)
+	var tlsConfig *tls.Config
+	if tlsConnection, ok := connection.(plugin.TlsConnection); ok {
+		var err errors.Error
+		tlsConfig, err = tlsConnection.GetTlsConfig()
+		if err != nil {
+			return nil, err
+		}
+	}
+	noProxy := ""
+	if noProxyConnection, ok := connection.(plugin.NoProxyConnection); ok {
+		noProxy = noProxyConnection.GetNoProxy()
+	}
+	apiClient, err := newApiClient(ctx, connection.GetEndpoint(), nil, 0, connection.GetProxy(), noProxy, tlsConfig, br)
 	if err != nil {
 		return nil, err
 	}
+	if c, ok := connection.(interface{ ConnectionId() uint64 }); ok {
+		apiClient.metricsConnection = strconv.FormatUint(c.ConnectionId(), 10)
+	}
 
 	// if connection needs to prepare the ApiClient, i.e. fetch token for future requests
 	if prepareApiClient, ok := connection.(plugin.PrepareApiClient); ok {
@@ -98,6 +121,16 @@ func NewApiClientFromConnection(
 		})
 	}
 
+	// if connection authenticates by OAuth2, refresh the token with the same http client and save the refreshed one
+	if oauth2Authenticator, ok := connection.(plugin.OAuth2Authenticator); ok {
+		if oauth2Auth, ok := oauth2Authenticator.GetOAuth2Authenticator().(*OAuth2); ok {
+			oauth2Auth.client = apiClient.client
+			if toolConnection, ok := connection.(plugin.ToolLayerConnection); ok && toolConnection.ConnectionId() > 0 {
+				oauth2Auth.store = newOAuth2TokenStore(br, toolConnection)
+			}
+		}
+	}
+
 	return apiClient, nil
 }
 
@@ -109,6 +142,21 @@ func NewApiClient(
 	timeout time.Duration,
 	proxy string,
 	br context.BasicRes,
+) (*ApiClient, errors.Error) {
+	return newApiClient(ctx, endpoint, headers, timeout, proxy, "", nil, br)
+}
+
+// newApiClient creates a new synchronize ApiClient, hosts in the noProxy list are reached without the proxy,
+// and the transport uses the tlsConfig if it is not nil
+func newApiClient(
+	ctx gocontext.Context,
+	endpoint string,
+	headers map[string]string,
+	timeout time.Duration,
+	proxy string,
+	noProxy string,
+	tlsConfig *tls.Config,
+	br context.BasicRes,
 ) (*ApiClient, errors.Error) {
 	cfg := br.GetConfigReader()
 	log := br.GetLogger()
@@ -133,15 +181,31 @@ func NewApiClient(
 
 	// set insecureSkipVerify
 	insecureSkipVerify := cfg.GetBool("IN_SECURE_SKIP_VERIFY")
-	if insecureSkipVerify {
+	if tlsConfig != nil {
+		tlsConfig.InsecureSkipVerify = insecureSkipVerify
+		apiClient.client.Transport.(*http.Transport).TLSClientConfig = tlsConfig
+	} else if insecureSkipVerify {
 		apiClient.client.Transport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
 	}
 
 	if proxy != "" {
-		err := apiClient.SetProxy(proxy)
+		err := apiClient.setProxy(proxy, noProxy)
 		if err != nil {
-			return nil, errors.Convert(err)
+			return nil, err
 		}
+	}
+
+	// record the responses to the disk, or replay them without accessing the network
+	transport, err := NewApiRecordingTransport(cfg, apiClient.client.Transport)
+	if err != nil {
+		return nil, err
+	}
+	apiClient.client.Transport = transport
+	recorder, _ := transport.(*apiRecorder)
+
+	if recorder != nil && recorder.replaying() {
+		log.Info("replaying the recorded responses of %s", endpoint)
+	} else if proxy != "" {
 		// check connectivity
 		res, err := apiClient.Get("/", nil, nil)
 		if err != nil {
@@ -282,15 +346,22 @@ func (apiClient *ApiClient) SetContext(ctx gocontext.Context) {
 	apiClient.ctx = ctx
 }
 
-// SetProxy FIXME ...
+// SetProxy sends all requests through the http, https or socks5 proxy, credentials can be given in the url
 func (apiClient *ApiClient) SetProxy(proxyUrl string) errors.Error {
-	pu, err := url.Parse(proxyUrl)
+	return apiClient.setProxy(proxyUrl, "")
+}
+
+// setProxy sends the requests through the proxy except the ones to the hosts in the noProxy list
+func (apiClient *ApiClient) setProxy(proxyUrl string, noProxy string) errors.Error {
+	proxyFunc, err := NewProxyFunc(proxyUrl, noProxy)
 	if err != nil {
-		return errors.Convert(err)
+		return err
 	}
-	if pu.Scheme == "http" || pu.Scheme == "socks5" {
-		apiClient.client.Transport.(*http.Transport).Proxy = http.ProxyURL(pu)
+	transport := apiClient.client.Transport
+	if recorder, ok := transport.(*apiRecorder); ok {
+		transport = recorder.next
 	}
+	transport.(*http.Transport).Proxy = proxyFunc
 	return nil
 }
 
@@ -373,7 +444,19 @@ func (apiClient *ApiClient) Do(
 		}
 	}
 	apiClient.logDebug("[api-client] %v %v", method, *uri)
-	res, err = errors.Convert01(apiClient.client.Do(req))
+	spanCtx, span := tracing.Start(req.Context(), fmt.Sprintf("HTTP %s", method),
+		attribute.String("http.method", method),
+		attribute.String("http.url", fmt.Sprintf("%s://%s%s", req.URL.Scheme, req.URL.Host, req.URL.Path)),
+		attribute.String("devlake.plugin", apiClient.metricsPlugin),
+		attribute.String("devlake.connection", apiClient.metricsConnection),
+	)
+	start := time.Now()
+	res, err = errors.Convert01(apiClient.client.Do(req.WithContext(spanCtx)))
+	apiClient.observeRequest(res, time.Since(start))
+	if res != nil {
+		span.SetAttributes(attribute.Int("http.status_code", res.StatusCode))
+	}
+	tracing.End(span, err)
 	if err != nil {
 		apiClient.logError(err, "[api-client] failed to request %s with error", req.URL.String())
 		return nil, err
@@ -394,6 +477,15 @@ func (apiClient *ApiClient) Do(
 	return res, nil
 }
 
+func (apiClient *ApiClient) observeRequest(res *http.Response, duration time.Duration) {
+	status := "error"
+	if res != nil {
+		status = strconv.Itoa(res.StatusCode)
+	}
+	metrics.ApiRequests.WithLabelValues(apiClient.metricsPlugin, apiClient.metricsConnection, status).Inc()
+	metrics.ApiRequestDuration.WithLabelValues(apiClient.metricsPlugin, apiClient.metricsConnection).Observe(duration.Seconds())
+}
+
 // Get FIXME ...
 func (apiClient *ApiClient) Get(
 	path string,
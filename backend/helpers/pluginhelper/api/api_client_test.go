@@ -18,10 +18,14 @@ limitations under the License.
 package api
 
 import (
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/metrics"
 	"github.com/apache/incubator-devlake/impls/logruslog"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -95,3 +99,12 @@ func TestApiClientBlackList(t *testing.T) {
 		})
 	}
 }
+
+func TestApiClientObserveRequest(t *testing.T) {
+	apiClient := &ApiClient{metricsPlugin: "TestApiClientObserveRequest", metricsConnection: "1"}
+	apiClient.observeRequest(&http.Response{StatusCode: http.StatusOK}, time.Second)
+	apiClient.observeRequest(&http.Response{StatusCode: http.StatusOK}, time.Second)
+	apiClient.observeRequest(nil, time.Second)
+	assert.Equal(t, float64(2), testutil.ToFloat64(metrics.ApiRequests.WithLabelValues("TestApiClientObserveRequest", "1", "200")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.ApiRequests.WithLabelValues("TestApiClientObserveRequest", "1", "error")))
+}
@@ -24,7 +24,6 @@ import (
 	"io"
 	"net/http"
 	"net/url"
-	"sync"
 	"text/template"
 	"time"
 
@@ -32,6 +31,7 @@ import (
 
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/utils"
 )
 
 var _ plugin.SubTask = (*ApiCollector)(nil)
@@ -76,6 +76,9 @@ type ApiCollectorArgs struct {
 	PageSize int
 	// GetNextPageCustomData indicate if this collection request each page in order and build query by the prev request
 	GetNextPageCustomData func(prevReqData *RequestData, prevPageResponse *http.Response) (interface{}, errors.Error)
+	// Pagination indicate if this collection request each page in order with a reusable strategy, i.e.
+	// CursorPagination, LinkHeaderPagination or KeysetPagination, it takes precedence over GetNextPageCustomData
+	Pagination Pagination
 	// Incremental indicate if this is an incremental collection, the existing data won't get deleted if it was true
 	Incremental bool `comment:"indicate if this collection is incremental update"`
 	// ApiClient is a asynchronize api request client with qps
@@ -91,6 +94,8 @@ type ApiCollectorArgs struct {
 	AfterResponse  plugin.ApiClientAfterResponse
 	RequestBody    func(reqData *RequestData) map[string]interface{}
 	Method         string
+	// CollectorLimits fails the collection once it fetched too many pages or records, or ran for too long
+	CollectorLimits
 }
 
 // ApiCollector FIXME ...
@@ -98,6 +103,9 @@ type ApiCollector struct {
 	*RawDataSubTask
 	args        *ApiCollectorArgs
 	urlTemplate *template.Template
+	httpCache   *apiHttpCache
+	conditional bool
+	guardrails  *collectorGuardrails
 }
 
 // NewApiCollector allocates a new ApiCollector with the given args.
@@ -141,15 +149,6 @@ func NewApiCollector(args ApiCollectorArgs) (*ApiCollector, errors.Error) {
 	return apiCollector, nil
 }
 
-var rawTableAutoMigrateLock sync.Mutex
-
-func (collector *ApiCollector) ensureRawTable() errors.Error {
-	db := collector.args.Ctx.GetDal()
-	rawTableAutoMigrateLock.Lock()
-	defer rawTableAutoMigrateLock.Unlock()
-	return db.AutoMigrate(&RawData{}, dal.From(collector.table))
-}
-
 // Execute will start collection
 func (collector *ApiCollector) Execute() errors.Error {
 	logger := collector.args.Ctx.GetLogger()
@@ -157,7 +156,7 @@ func (collector *ApiCollector) Execute() errors.Error {
 
 	// make sure table is created
 	db := collector.args.Ctx.GetDal()
-	err := collector.ensureRawTable()
+	err := ensureRawTable(db, logger, collector.table, collector.connectionId)
 	if err != nil {
 		return errors.Default.Wrap(err, "error auto-migrating collector")
 	}
@@ -175,6 +174,22 @@ func (collector *ApiCollector) Execute() errors.Error {
 		}
 	}
 
+	// remember the validators of responses, and send conditional requests for incremental collection only, since
+	// nothing would be saved for the unchanged resources
+	httpCacheEnabled, err := utils.StrToBoolOr(collector.args.Ctx.GetConfig("API_HTTP_CACHE"), false)
+	if err != nil {
+		return errors.BadInput.Wrap(err, "failed to parse API_HTTP_CACHE")
+	}
+	if httpCacheEnabled && collector.args.Method != http.MethodPost {
+		collector.httpCache = newApiHttpCache(db, logger, collector.table, collector.params)
+		collector.conditional = isIncremental
+	}
+
+	collector.guardrails, err = newCollectorGuardrails(collector.args.Ctx, collector.table, collector.args.CollectorLimits)
+	if err != nil {
+		return err
+	}
+
 	// if MinTickInterval was specified
 	if collector.args.MinTickInterval != nil {
 		minTickInterval := *collector.args.MinTickInterval
@@ -251,7 +266,9 @@ func (collector *ApiCollector) exec(input interface{}) {
 		Size: collector.args.PageSize,
 	}
 	// fetch the detail
-	if collector.args.PageSize <= 0 {
+	if collector.args.Pagination != nil {
+		collector.fetchPagesSequentially(reqData)
+	} else if collector.args.PageSize <= 0 {
 		collector.fetchAsync(reqData, nil)
 		// fetch pages sequentially
 	} else if collector.args.GetNextPageCustomData != nil {
@@ -270,10 +287,10 @@ func (collector *ApiCollector) fetchPagesSequentially(reqData *RequestData) {
 	var collect func() errors.Error
 	collect = func() errors.Error {
 		collector.fetchAsync(reqData, func(count int, body []byte, res *http.Response) errors.Error {
-			if count < collector.args.PageSize {
+			if collector.args.PageSize > 0 && count < collector.args.PageSize {
 				return nil
 			}
-			customData, err := collector.args.GetNextPageCustomData(reqData, res)
+			customData, err := collector.nextPageCustomData(reqData, body, res)
 			if err != nil {
 				if errors.Is(err, ErrFinishCollect) {
 					return nil
@@ -292,6 +309,22 @@ func (collector *ApiCollector) fetchPagesSequentially(reqData *RequestData) {
 	collector.args.ApiClient.NextTick(collect)
 }
 
+func (collector *ApiCollector) nextPageCustomData(reqData *RequestData, body []byte, res *http.Response) (interface{}, errors.Error) {
+	if collector.args.Pagination == nil {
+		return collector.args.GetNextPageCustomData(reqData, res)
+	}
+	items, err := collector.args.ResponseParser(res)
+	if err != nil && !errors.Is(err, ErrFinishCollect) {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewBuffer(body))
+	return collector.args.Pagination.NextPage(reqData, &PageResponse{
+		Response: res,
+		Body:     body,
+		Items:    items,
+	})
+}
+
 // fetchPagesDetermined fetches data of all pages for APIs that return paging information
 func (collector *ApiCollector) fetchPagesDetermined(reqData *RequestData) {
 	// fetch first page
@@ -408,6 +441,20 @@ func (collector *ApiCollector) SetAfterResponse(f plugin.ApiClientAfterResponse)
 	collector.args.ApiClient.SetAfterFunction(f)
 }
 
+// cacheResponse remembers the validators of the response once it has been saved, a response with fewer items than
+// a page is the last one
+func (collector *ApiCollector) cacheResponse(cacheUrl string, res *http.Response, count int, lastPage bool) errors.Error {
+	if collector.httpCache == nil {
+		return nil
+	}
+	lastPage = lastPage || collector.args.PageSize <= 0 || count < collector.args.PageSize
+	err := collector.httpCache.put(cacheUrl, res, lastPage)
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("error caching response of %s", cacheUrl))
+	}
+	return nil
+}
+
 func (collector *ApiCollector) fetchAsync(reqData *RequestData, handler func(int, []byte, *http.Response) errors.Error) {
 	if reqData.Pager == nil {
 		reqData.Pager = &Pager{
@@ -427,6 +474,12 @@ func (collector *ApiCollector) fetchAsync(reqData *RequestData, handler func(int
 			panic(err)
 		}
 	}
+	if collector.args.Pagination != nil {
+		if apiQuery == nil {
+			apiQuery = url.Values{}
+		}
+		collector.args.Pagination.Query(reqData, apiQuery)
+	}
 	var reqBody interface{}
 	if collector.args.RequestBody != nil {
 		reqBody = collector.args.RequestBody(reqData)
@@ -442,11 +495,24 @@ func (collector *ApiCollector) fetchAsync(reqData *RequestData, handler func(int
 			panic(err)
 		}
 	}
+	cacheUrl := ""
+	if collector.httpCache != nil {
+		cacheUrl = collector.httpCache.cacheUrl(apiUrl, apiQuery)
+		if collector.conditional {
+			apiHeader = collector.httpCache.conditionalHeader(cacheUrl, apiHeader)
+		}
+	}
 	logger := collector.args.Ctx.GetLogger()
 	logger.Debug("fetchAsync <<< enqueueing for %s %v", apiUrl, apiQuery)
 	responseHandler := func(res *http.Response) errors.Error {
 		defer logger.Debug("fetchAsync >>> done for %s %v", apiUrl, apiQuery)
 		logger := collector.args.Ctx.GetLogger()
+		// the last page hasn't changed since last collection
+		if res.StatusCode == http.StatusNotModified {
+			logger.Debug("fetchAsync === %s not modified", apiUrl)
+			collector.args.Ctx.IncProgress(1)
+			return nil
+		}
 		// read body to buffer
 		body, err := io.ReadAll(res.Body)
 		if err != nil {
@@ -466,19 +532,27 @@ func (collector *ApiCollector) fetchAsync(reqData *RequestData, handler func(int
 		}
 		// save to db
 		count := len(items)
+		if err := collector.guardrails.check(count); err != nil {
+			return err
+		}
 		if count == 0 {
 			collector.args.Ctx.IncProgress(1)
-			return nil
+			return collector.cacheResponse(cacheUrl, res, count, true)
 		}
 		db := collector.args.Ctx.GetDal()
 		urlString := res.Request.URL.String()
 		rows := make([]*RawData, count)
 		for i, msg := range items {
+			data, err := compressRawData(collector.compression, msg)
+			if err != nil {
+				return err
+			}
 			rows[i] = &RawData{
-				Params: collector.params,
-				Data:   msg,
-				Url:    urlString,
-				Input:  reqData.InputJSON,
+				Params:       collector.params,
+				ConnectionId: collector.connectionId,
+				Data:         data,
+				Url:          urlString,
+				Input:        reqData.InputJSON,
 			}
 		}
 		err = db.Create(rows, dal.From(collector.table))
@@ -486,6 +560,9 @@ func (collector *ApiCollector) fetchAsync(reqData *RequestData, handler func(int
 			return errors.Default.Wrap(err, fmt.Sprintf("error inserting raw rows into %s", collector.table))
 		}
 		logger.Debug("fetchAsync === total %d rows were saved into database", count)
+		if err := collector.cacheResponse(cacheUrl, res, count, handler == nil); err != nil {
+			return err
+		}
 		// increase progress only when it was not nested
 		collector.args.Ctx.IncProgress(1)
 		if handler != nil {
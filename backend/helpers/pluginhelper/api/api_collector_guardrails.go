@@ -0,0 +1,105 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/utils"
+)
+
+// CollectorLimits stops a runaway collection, e.g. a misconfigured scope or a broken pagination which never ends,
+// before it collects millions of rows and fills the disk. 0 means the limit falls back to the COLLECTOR_MAX_PAGES,
+// COLLECTOR_MAX_RECORDS and COLLECTOR_MAX_DURATION configured for all collectors, which are unlimited by default
+type CollectorLimits struct {
+	// MaxPages is the max number of responses a single execution of the collector may handle
+	MaxPages int
+	// MaxRecords is the max number of raw records a single execution of the collector may save
+	MaxRecords int
+	// MaxDuration is the max time a single execution of the collector may take
+	MaxDuration time.Duration
+}
+
+// collectorGuardrails counts the pages and records collected by a collector and fails it once a limit is exceeded
+type collectorGuardrails struct {
+	CollectorLimits
+	table     string
+	startedAt time.Time
+	pages     atomic.Int64
+	records   atomic.Int64
+}
+
+func newCollectorGuardrails(config interface{ GetConfig(string) string }, table string, limits CollectorLimits) (*collectorGuardrails, errors.Error) {
+	var err errors.Error
+	if limits.MaxPages <= 0 {
+		limits.MaxPages, err = utils.StrToIntOr(config.GetConfig("COLLECTOR_MAX_PAGES"), 0)
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, "failed to parse COLLECTOR_MAX_PAGES")
+		}
+	}
+	if limits.MaxRecords <= 0 {
+		limits.MaxRecords, err = utils.StrToIntOr(config.GetConfig("COLLECTOR_MAX_RECORDS"), 0)
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, "failed to parse COLLECTOR_MAX_RECORDS")
+		}
+	}
+	if limits.MaxDuration <= 0 {
+		limits.MaxDuration, err = utils.StrToDurationOr(config.GetConfig("COLLECTOR_MAX_DURATION"), 0)
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, "failed to parse COLLECTOR_MAX_DURATION")
+		}
+	}
+	return &collectorGuardrails{
+		CollectorLimits: limits,
+		table:           table,
+		startedAt:       time.Now(),
+	}, nil
+}
+
+// check counts a response with the number of records in it, it must be called before the records get saved so
+// nothing beyond the limits would ever reach the database
+func (g *collectorGuardrails) check(records int) errors.Error {
+	if g == nil {
+		return nil
+	}
+	const hint = "the scope might be misconfigured or the pagination of the api might be broken"
+	pages := g.pages.Add(1)
+	if g.MaxPages > 0 && pages > int64(g.MaxPages) {
+		return errors.Default.New(fmt.Sprintf(
+			"collection of %s stopped: more than %d pages were fetched, %s, raise MaxPages of the collector or COLLECTOR_MAX_PAGES if that many pages are expected",
+			g.table, g.MaxPages, hint,
+		))
+	}
+	total := g.records.Add(int64(records))
+	if g.MaxRecords > 0 && total > int64(g.MaxRecords) {
+		return errors.Default.New(fmt.Sprintf(
+			"collection of %s stopped: more than %d records were collected, %s, raise MaxRecords of the collector or COLLECTOR_MAX_RECORDS if that many records are expected",
+			g.table, g.MaxRecords, hint,
+		))
+	}
+	if elapsed := time.Since(g.startedAt); g.MaxDuration > 0 && elapsed > g.MaxDuration {
+		return errors.Default.New(fmt.Sprintf(
+			"collection of %s stopped: it has been running for %s which exceeds the limit of %s, %s, raise MaxDuration of the collector or COLLECTOR_MAX_DURATION if it is expected to take that long",
+			g.table, elapsed.Round(time.Second), g.MaxDuration, hint,
+		))
+	}
+	return nil
+}
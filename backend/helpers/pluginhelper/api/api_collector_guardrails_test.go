@@ -0,0 +1,76 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type guardrailsConfig map[string]string
+
+func (c guardrailsConfig) GetConfig(name string) string {
+	return c[name]
+}
+
+func TestCollectorGuardrailsMaxPages(t *testing.T) {
+	guardrails, err := newCollectorGuardrails(guardrailsConfig{}, "_raw_test", CollectorLimits{MaxPages: 2})
+	assert.Nil(t, err)
+	assert.Nil(t, guardrails.check(100))
+	assert.Nil(t, guardrails.check(100))
+	err = guardrails.check(100)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "more than 2 pages")
+	assert.Contains(t, err.Error(), "_raw_test")
+}
+
+func TestCollectorGuardrailsMaxRecords(t *testing.T) {
+	guardrails, err := newCollectorGuardrails(guardrailsConfig{"COLLECTOR_MAX_RECORDS": "150"}, "_raw_test", CollectorLimits{})
+	assert.Nil(t, err)
+	assert.Nil(t, guardrails.check(100))
+	err = guardrails.check(100)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "more than 150 records")
+}
+
+func TestCollectorGuardrailsMaxDuration(t *testing.T) {
+	guardrails, err := newCollectorGuardrails(guardrailsConfig{"COLLECTOR_MAX_DURATION": "1h"}, "_raw_test", CollectorLimits{MaxDuration: time.Millisecond})
+	assert.Nil(t, err)
+	assert.Equal(t, time.Millisecond, guardrails.MaxDuration)
+	time.Sleep(2 * time.Millisecond)
+	err = guardrails.check(1)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "exceeds the limit of 1ms")
+}
+
+func TestCollectorGuardrailsUnlimited(t *testing.T) {
+	guardrails, err := newCollectorGuardrails(guardrailsConfig{}, "_raw_test", CollectorLimits{})
+	assert.Nil(t, err)
+	for i := 0; i < 1000; i++ {
+		assert.Nil(t, guardrails.check(1000))
+	}
+	var nilGuardrails *collectorGuardrails
+	assert.Nil(t, nilGuardrails.check(1))
+}
+
+func TestCollectorGuardrailsInvalidConfig(t *testing.T) {
+	_, err := newCollectorGuardrails(guardrailsConfig{"COLLECTOR_MAX_PAGES": "many"}, "_raw_test", CollectorLimits{})
+	assert.NotNil(t, err)
+}
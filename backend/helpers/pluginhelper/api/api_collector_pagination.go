@@ -0,0 +1,194 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+// PageResponse is the response of the previous page handed to a Pagination to work out the next page
+type PageResponse struct {
+	Response *http.Response
+	Body     []byte
+	Items    []json.RawMessage
+}
+
+// Pagination is a reusable strategy to fetch pages in order, the next page is built from the previous one.
+// Use it as `ApiCollectorArgs.Pagination` instead of hand-rolling `GetNextPageCustomData` and `Query` for every api.
+type Pagination interface {
+	// Query sets the pagination parameters of the request, the CustomData of the reqData is what NextPage returned
+	Query(reqData *RequestData, query url.Values)
+	// NextPage returns the CustomData for the next page, or ErrFinishCollect if there is none
+	NextPage(prevReqData *RequestData, prevPage *PageResponse) (interface{}, errors.Error)
+}
+
+var _ Pagination = (*CursorPagination)(nil)
+var _ Pagination = (*LinkHeaderPagination)(nil)
+var _ Pagination = (*KeysetPagination)(nil)
+
+func setPageSizeParam(reqData *RequestData, query url.Values, pageSizeParam string) {
+	if pageSizeParam != "" && reqData.Pager != nil && reqData.Pager.Size > 0 {
+		query.Set(pageSizeParam, strconv.Itoa(reqData.Pager.Size))
+	}
+}
+
+// CursorPagination follows the opaque cursor returned in the response body, i.e. Slack, Feishu, Bitbucket Server
+type CursorPagination struct {
+	// CursorPath is the path to the cursor in the json response, i.e. ["response_metadata", "next_cursor"]
+	CursorPath []string
+	// CursorParam is the query parameter to send the cursor with, i.e. "cursor"
+	CursorParam string
+	// PageSizeParam is the optional query parameter to send the page size with, i.e. "limit"
+	PageSizeParam string
+}
+
+func (p *CursorPagination) Query(reqData *RequestData, query url.Values) {
+	setPageSizeParam(reqData, query, p.PageSizeParam)
+	if cursor, ok := reqData.CustomData.(string); ok && cursor != "" {
+		query.Set(p.CursorParam, cursor)
+	}
+}
+
+func (p *CursorPagination) NextPage(_ *RequestData, prevPage *PageResponse) (interface{}, errors.Error) {
+	var body interface{}
+	if err := json.Unmarshal(prevPage.Body, &body); err != nil {
+		return nil, errors.Default.Wrap(err, "failed to decode the response for the next cursor")
+	}
+	cursor := jsonPathString(body, p.CursorPath)
+	if cursor == "" {
+		return nil, ErrFinishCollect
+	}
+	return cursor, nil
+}
+
+// LinkHeaderPagination follows the `rel="next"` link in the Link header (RFC 8288), i.e. GitHub, GitLab
+type LinkHeaderPagination struct {
+	// PageSizeParam is the optional query parameter to send the page size with, i.e. "per_page"
+	PageSizeParam string
+}
+
+func (p *LinkHeaderPagination) Query(reqData *RequestData, query url.Values) {
+	setPageSizeParam(reqData, query, p.PageSizeParam)
+	if next, ok := reqData.CustomData.(url.Values); ok {
+		for key, values := range next {
+			query[key] = values
+		}
+	}
+}
+
+func (p *LinkHeaderPagination) NextPage(_ *RequestData, prevPage *PageResponse) (interface{}, errors.Error) {
+	next := nextLink(prevPage.Response.Header.Values("Link"))
+	if next == "" {
+		return nil, ErrFinishCollect
+	}
+	nextUrl, err := url.Parse(next)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("invalid next link %s", next))
+	}
+	return nextUrl.Query(), nil
+}
+
+// nextLink returns the target of the `rel="next"` link
+func nextLink(headers []string) string {
+	for _, header := range headers {
+		for _, link := range strings.Split(header, ",") {
+			segments := strings.Split(link, ";")
+			target := strings.TrimSpace(segments[0])
+			if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+			for _, param := range segments[1:] {
+				key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+				if !ok || strings.TrimSpace(key) != "rel" {
+					continue
+				}
+				for _, rel := range strings.Fields(strings.Trim(strings.TrimSpace(value), `"`)) {
+					if rel == "next" {
+						return target[1 : len(target)-1]
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// KeysetPagination pages through records sorted by a key in ascending order, i.e. `updated_at`, by asking for the
+// records after the key of the last record of the previous page. The records sharing the key with the last one are
+// collected again, which is fine since they are deduplicated by extractors.
+type KeysetPagination struct {
+	// KeyPath is the path to the key in a single record, i.e. ["updated_at"]
+	KeyPath []string
+	// KeyParam is the query parameter to send the key with, i.e. "updated_after"
+	KeyParam string
+	// PageSizeParam is the optional query parameter to send the page size with, i.e. "per_page"
+	PageSizeParam string
+}
+
+func (p *KeysetPagination) Query(reqData *RequestData, query url.Values) {
+	setPageSizeParam(reqData, query, p.PageSizeParam)
+	if key, ok := reqData.CustomData.(string); ok && key != "" {
+		query.Set(p.KeyParam, key)
+	}
+}
+
+func (p *KeysetPagination) NextPage(prevReqData *RequestData, prevPage *PageResponse) (interface{}, errors.Error) {
+	if len(prevPage.Items) == 0 {
+		return nil, ErrFinishCollect
+	}
+	var last interface{}
+	if err := json.Unmarshal(prevPage.Items[len(prevPage.Items)-1], &last); err != nil {
+		return nil, errors.Default.Wrap(err, "failed to decode the last record for the next key")
+	}
+	key := jsonPathString(last, p.KeyPath)
+	if key == "" {
+		return nil, errors.Default.New(fmt.Sprintf("key %s not found in the last record", strings.Join(p.KeyPath, ".")))
+	}
+	// the whole page shares the same key, we would be fetching it over and over again
+	if prev, ok := prevReqData.CustomData.(string); ok && prev == key {
+		return nil, errors.Default.New(fmt.Sprintf("all records of the page share the key %s, please increase the page size", key))
+	}
+	return key, nil
+}
+
+// jsonPathString walks down the decoded json by the path and returns the value as string, or empty if not found
+func jsonPathString(value interface{}, path []string) string {
+	for _, key := range path {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		value = object[key]
+	}
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return ""
+}
@@ -0,0 +1,92 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursorPagination(t *testing.T) {
+	p := &CursorPagination{
+		CursorPath:    []string{"response_metadata", "next_cursor"},
+		CursorParam:   "cursor",
+		PageSizeParam: "limit",
+	}
+	reqData := &RequestData{Pager: &Pager{Page: 1, Size: 50}}
+	query := url.Values{}
+	p.Query(reqData, query)
+	assert.Equal(t, url.Values{"limit": {"50"}}, query)
+
+	next, err := p.NextPage(reqData, &PageResponse{Body: []byte(`{"response_metadata":{"next_cursor":"dXNlcjpVMEc5V0ZYTlo="}}`)})
+	assert.Nil(t, err)
+	assert.Equal(t, "dXNlcjpVMEc5V0ZYTlo=", next)
+	reqData.CustomData = next
+	query = url.Values{}
+	p.Query(reqData, query)
+	assert.Equal(t, "dXNlcjpVMEc5V0ZYTlo=", query.Get("cursor"))
+
+	_, err = p.NextPage(reqData, &PageResponse{Body: []byte(`{"response_metadata":{"next_cursor":""}}`)})
+	assert.True(t, errors.Is(err, ErrFinishCollect))
+}
+
+func TestLinkHeaderPagination(t *testing.T) {
+	p := &LinkHeaderPagination{PageSizeParam: "per_page"}
+	header := http.Header{}
+	header.Set("Link", `<https://api.github.com/repositories/1/issues?page=2&per_page=100>; rel="next", <https://api.github.com/repositories/1/issues?page=5&per_page=100>; rel="last"`)
+	reqData := &RequestData{Pager: &Pager{Page: 1, Size: 100}}
+	next, err := p.NextPage(reqData, &PageResponse{Response: &http.Response{Header: header}})
+	assert.Nil(t, err)
+	reqData.CustomData = next
+	query := url.Values{"state": {"all"}}
+	p.Query(reqData, query)
+	assert.Equal(t, url.Values{"state": {"all"}, "page": {"2"}, "per_page": {"100"}}, query)
+
+	header.Set("Link", `<https://api.github.com/repositories/1/issues?page=1&per_page=100>; rel="first prev"`)
+	_, err = p.NextPage(reqData, &PageResponse{Response: &http.Response{Header: header}})
+	assert.True(t, errors.Is(err, ErrFinishCollect))
+}
+
+func TestKeysetPagination(t *testing.T) {
+	p := &KeysetPagination{KeyPath: []string{"updated_at"}, KeyParam: "updated_after"}
+	reqData := &RequestData{Pager: &Pager{Page: 1, Size: 2}}
+	items := []json.RawMessage{
+		json.RawMessage(`{"id":1,"updated_at":"2026-10-01T00:00:00Z"}`),
+		json.RawMessage(`{"id":2,"updated_at":"2026-10-02T00:00:00Z"}`),
+	}
+	next, err := p.NextPage(reqData, &PageResponse{Items: items})
+	assert.Nil(t, err)
+	assert.Equal(t, "2026-10-02T00:00:00Z", next)
+	reqData.CustomData = next
+	query := url.Values{}
+	p.Query(reqData, query)
+	assert.Equal(t, "2026-10-02T00:00:00Z", query.Get("updated_after"))
+
+	// the page is full of the same key
+	_, err = p.NextPage(reqData, &PageResponse{Items: items[1:]})
+	assert.NotNil(t, err)
+	assert.False(t, errors.Is(err, ErrFinishCollect))
+
+	_, err = p.NextPage(reqData, &PageResponse{})
+	assert.True(t, errors.Is(err, ErrFinishCollect))
+}
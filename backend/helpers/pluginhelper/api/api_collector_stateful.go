@@ -91,6 +91,24 @@ func (m *StatefulApiCollector) InitGraphQLCollector(args GraphqlCollectorArgs) e
 	return nil
 }
 
+// InitReconciler appends a reconciler removing the records deleted from the source, if they are due to be reconciled
+func (m *StatefulApiCollector) InitReconciler(args DeletedRecordReconcilerArgs) errors.Error {
+	interval, err := GetReconcileInterval(m.Ctx, &args)
+	if err != nil {
+		return err
+	}
+	if !m.CollectorStateManager.ShouldReconcile(interval) {
+		return nil
+	}
+	reconciler, err := NewDeletedRecordReconciler(m.RawDataSubTaskArgs, args)
+	if err != nil {
+		return err
+	}
+	m.nestedCollectors = append(m.nestedCollectors, reconciler)
+	m.CollectorStateManager.reconciled = true
+	return nil
+}
+
 // Execute all nested collectors and save the state if all collectors succeed
 func (m *StatefulApiCollector) Execute() errors.Error {
 	for _, subtask := range m.nestedCollectors {
@@ -123,7 +141,8 @@ func (m *StatefulApiCollector) Execute() errors.Error {
 //   - Undetermind Strategy: if the API supports sorting by the Created Date in Descending order and
 //     fetching by Page Number, use the `Concurrent` hook
 //   - Sequential Strategy: if the API supports sorting by the Created Date in Descending order but
-//     the next page can only be fetched by the Cursor/Token from the previous page, use the `GetNextPageCustomData` hook
+//     the next page can only be fetched by the Cursor/Token from the previous page, use the `Pagination` or
+//     `GetNextPageCustomData` hook
 func NewStatefulApiCollectorForFinalizableEntity(args FinalizableApiCollectorArgs) (plugin.SubTask, errors.Error) {
 	// create a manager which could execute multiple collector but acts as a single subtask to callers
 	manager, err := NewStatefulApiCollector(RawDataSubTaskArgs{
@@ -224,7 +243,9 @@ func NewStatefulApiCollectorForFinalizableEntity(args FinalizableApiCollectorArg
 		PageSize:              args.CollectNewRecordsByList.PageSize,
 		Concurrency:           args.CollectNewRecordsByList.Concurrency,
 		GetNextPageCustomData: args.CollectNewRecordsByList.GetNextPageCustomData,
+		Pagination:            args.CollectNewRecordsByList.Pagination,
 		GetTotalPages:         args.CollectNewRecordsByList.GetTotalPages,
+		CollectorLimits:       args.CollectorLimits,
 	})
 
 	if err != nil {
@@ -264,6 +285,7 @@ func NewStatefulApiCollectorForFinalizableEntity(args FinalizableApiCollectorArg
 		AfterResponse:   args.CollectUnfinishedDetails.AfterResponse,
 		RequestBody:     args.CollectUnfinishedDetails.RequestBody,
 		Method:          args.CollectUnfinishedDetails.Method,
+		CollectorLimits: args.CollectorLimits,
 	})
 	return manager, err
 }
@@ -273,6 +295,8 @@ type FinalizableApiCollectorArgs struct {
 	ApiClient                RateLimitedApiClient
 	CollectNewRecordsByList  FinalizableApiCollectorListArgs
 	CollectUnfinishedDetails *FinalizableApiCollectorDetailArgs
+	// CollectorLimits applies to the list and detail collectors respectively
+	CollectorLimits
 }
 
 // FinalizableApiCollectorCommonArgs is the common arguments for both list and detail collectors
@@ -296,6 +320,7 @@ type FinalizableApiCollectorListArgs struct {
 	Concurrency           int                                                                                         // required for Undetermined Strategy, number of concurrent requests
 	GetNextPageCustomData func(prevReqData *RequestData, prevPageResponse *http.Response) (interface{}, errors.Error) // required for Sequential Strategy, to extract the next page cursor from the given response
 	GetTotalPages         func(res *http.Response, args *ApiCollectorArgs) (int, errors.Error)                        // required for Determined Strategy, to extract the total number of pages from the given response
+	Pagination            Pagination                                                                                  // optional for Sequential Strategy, a reusable strategy i.e. CursorPagination instead of GetNextPageCustomData
 	BuildInputIterator    func(isIncremental bool, createdAfter *time.Time) (Iterator, errors.Error)
 }
 
@@ -77,6 +77,13 @@ func (extractor *ApiExtractor) Execute() errors.Error {
 	if !db.HasTable(extractor.table) {
 		return nil
 	}
+	pageSize, err := getExtractorStreamPageSize(extractor.args.Ctx)
+	if err != nil {
+		return err
+	}
+	if pageSize > 0 {
+		return extractor.executeStreaming(pageSize)
+	}
 	clauses := []dal.Clause{
 		dal.From(extractor.table),
 		dal.Where("params = ?", extractor.params),
@@ -111,32 +118,59 @@ func (extractor *ApiExtractor) Execute() errors.Error {
 		if err != nil {
 			return errors.Default.Wrap(err, "error fetching row")
 		}
+		err = decompressRawRow(row)
+		if err != nil {
+			return err
+		}
 
 		results, err := extractor.args.Extract(row)
 		if err != nil {
 			return errors.Default.Wrap(err, "error calling plugin Extract implementation")
 		}
-		for _, result := range results {
-			// get the batch operator for the specific type
-			batch, err := divider.ForType(reflect.TypeOf(result))
+		err = saveExtractedResults(divider, extractor.table, row.Params, row, results)
+		if err != nil {
+			return err
+		}
+		extractor.args.Ctx.IncProgress(1)
+	}
+
+	// save the last batches
+	return divider.Close()
+}
+
+// executeStreaming reads, extracts and saves the raw rows concurrently with bounded memory
+func (extractor *ApiExtractor) executeStreaming(pageSize int) errors.Error {
+	clauses := []dal.Clause{
+		dal.From(extractor.table),
+		dal.Where("params = ?", extractor.params),
+	}
+	extractor.args.Ctx.GetLogger().Info("stream data from %s where params=%s by pages of %d", extractor.table, extractor.params, pageSize)
+	divider := NewBatchSaveDivider(extractor.args.Ctx, extractor.args.BatchSize, extractor.table, extractor.params)
+	extractor.args.Ctx.SetProgress(0, -1)
+	err := streamExtract(
+		extractor.args.Ctx.GetContext(),
+		extractor.args.Ctx.GetDal(),
+		clauses,
+		pageSize,
+		func(row *RawData) ([]interface{}, errors.Error) {
+			results, err := extractor.args.Extract(row)
 			if err != nil {
-				return errors.Default.Wrap(err, "error getting batch from result")
+				return nil, errors.Default.Wrap(err, "error calling plugin Extract implementation")
 			}
-			// set raw data origin field
-			setRawDataOrigin(result, common.RawDataOrigin{
-				RawDataTable:  extractor.table,
-				RawDataId:     row.ID,
-				RawDataParams: row.Params,
-			})
-			// records get saved into db when slots were max outed
-			err = batch.Add(result)
+			return results, nil
+		},
+		func(row *RawData, results []interface{}) errors.Error {
+			err := saveExtractedResults(divider, extractor.table, row.Params, row, results)
 			if err != nil {
-				return errors.Default.Wrap(err, "error adding result to batch")
+				return err
 			}
-		}
-		extractor.args.Ctx.IncProgress(1)
+			extractor.args.Ctx.IncProgress(1)
+			return nil
+		},
+	)
+	if err != nil {
+		return err
 	}
-
 	// save the last batches
 	return divider.Close()
 }
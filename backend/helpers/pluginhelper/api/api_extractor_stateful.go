@@ -19,11 +19,9 @@ package api
 
 import (
 	"encoding/json"
-	"reflect"
 
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
-	"github.com/apache/incubator-devlake/core/models/common"
 	plugin "github.com/apache/incubator-devlake/core/plugin"
 )
 
@@ -104,10 +102,8 @@ func (extractor *StatefulApiExtractor[InputType]) Execute() errors.Error {
 	}
 
 	clauses := []dal.Clause{
-		dal.Select("id"),
 		dal.From(table),
 		dal.Where("params = ?", params),
-		dal.Orderby("id ASC"),
 	}
 
 	if extractor.IsIncremental() {
@@ -118,6 +114,15 @@ func (extractor *StatefulApiExtractor[InputType]) Execute() errors.Error {
 	}
 	clauses = append(clauses, dal.Where("created_at < ? ", extractor.GetUntil()))
 
+	pageSize, err := getExtractorStreamPageSize(extractor.SubTaskContext)
+	if err != nil {
+		return err
+	}
+	if pageSize > 0 {
+		return extractor.executeStreaming(clauses, pageSize)
+	}
+	clauses = append(clauses, dal.Select("id"), dal.Orderby("id ASC"))
+
 	// first get total count for progress tracking
 	count, err := db.Count(clauses...)
 	if err != nil {
@@ -154,46 +159,72 @@ func (extractor *StatefulApiExtractor[InputType]) Execute() errors.Error {
 		if err != nil {
 			return errors.Default.Wrap(err, "error loading full row by ID")
 		}
-
-		body := new(InputType)
-		err = errors.Convert(json.Unmarshal(row.Data, body))
+		err = decompressRawRow(row)
 		if err != nil {
 			return err
 		}
 
-		if extractor.BeforeExtract != nil {
-			err = extractor.BeforeExtract(body, extractor.SubtaskStateManager)
-			if err != nil {
-				return err
-			}
+		results, err := extractor.extract(row)
+		if err != nil {
+			return err
 		}
+		err = saveExtractedResults(divider, table, params, row, results)
+		if err != nil {
+			return err
+		}
+		extractor.IncProgress(1)
+	}
 
-		results, err := extractor.Extract(body, row)
+	// save the last batches
+	err = divider.Close()
+	if err != nil {
+		return err
+	}
+	// save the incremental state
+	return extractor.SubtaskStateManager.Close()
+}
+
+// extract decodes the body of the row and passes it to BeforeExtract and Extract
+func (extractor *StatefulApiExtractor[InputType]) extract(row *RawData) ([]interface{}, errors.Error) {
+	body := new(InputType)
+	err := errors.Convert(json.Unmarshal(row.Data, body))
+	if err != nil {
+		return nil, err
+	}
+	if extractor.BeforeExtract != nil {
+		err = extractor.BeforeExtract(body, extractor.SubtaskStateManager)
 		if err != nil {
-			return errors.Default.Wrap(err, "error calling plugin Extract implementation")
+			return nil, err
 		}
+	}
+	results, err := extractor.Extract(body, row)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error calling plugin Extract implementation")
+	}
+	return results, nil
+}
 
-		for _, result := range results {
-			// get the batch operator for the specific type
-			batch, err := divider.ForType(reflect.TypeOf(result))
-			if err != nil {
-				return errors.Default.Wrap(err, "error getting batch from result")
-			}
-			// set raw data origin field
-			setRawDataOrigin(result, common.RawDataOrigin{
-				RawDataTable:  table,
-				RawDataParams: params,
-				RawDataId:     row.ID,
-			})
-			// records get saved into db when slots were max outed
-			err = batch.Add(result)
+// executeStreaming reads, extracts and saves the raw rows matched by clauses concurrently with bounded memory
+func (extractor *StatefulApiExtractor[InputType]) executeStreaming(clauses []dal.Clause, pageSize int) errors.Error {
+	table := extractor.GetRawDataTable()
+	params := extractor.GetRawDataParams()
+	extractor.GetLogger().Info("stream data from %s where params=%s by pages of %d", table, params, pageSize)
+	divider := NewBatchSaveDivider(extractor.SubTaskContext, extractor.GetBatchSize(), table, params)
+	divider.SetIncrementalMode(extractor.IsIncremental())
+	extractor.SetProgress(0, -1)
+	err := streamExtract(extractor.GetContext(), extractor.GetDal(), clauses, pageSize, extractor.extract,
+		func(row *RawData, results []interface{}) errors.Error {
+			err := saveExtractedResults(divider, table, params, row, results)
 			if err != nil {
-				return errors.Default.Wrap(err, "error adding result to batch")
+				return err
 			}
-		}
-		extractor.IncProgress(1)
+			extractor.IncProgress(1)
+			return nil
+		},
+	)
+	if err != nil {
+		return err
 	}
-
 	// save the last batches
 	err = divider.Close()
 	if err != nil {
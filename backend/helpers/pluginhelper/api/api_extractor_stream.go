@@ -0,0 +1,155 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	gocontext "context"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/core/utils"
+	"golang.org/x/sync/errgroup"
+)
+
+// extractedRow carries the results extracted from a raw row to the writer
+type extractedRow struct {
+	row     *RawData
+	results []interface{}
+}
+
+// getExtractorStreamPageSize returns how many raw rows the extractors read at a time in streaming mode,
+// 0 means the streaming mode was not enabled by EXTRACTOR_STREAMING
+func getExtractorStreamPageSize(taskCtx plugin.SubTaskContext) (int, errors.Error) {
+	streaming, err := utils.StrToBoolOr(taskCtx.GetConfig("EXTRACTOR_STREAMING"), false)
+	if err != nil {
+		return 0, errors.BadInput.Wrap(err, "failed to parse EXTRACTOR_STREAMING")
+	}
+	if !streaming {
+		return 0, nil
+	}
+	pageSize, err := utils.StrToIntOr(taskCtx.GetConfig("EXTRACTOR_STREAMING_PAGE_SIZE"), 500)
+	if err != nil {
+		return 0, errors.BadInput.Wrap(err, "failed to parse EXTRACTOR_STREAMING_PAGE_SIZE")
+	}
+	if pageSize <= 0 {
+		return 0, errors.BadInput.New("EXTRACTOR_STREAMING_PAGE_SIZE must be positive")
+	}
+	return pageSize, nil
+}
+
+// streamExtract pipelines the extraction of the raw rows matched by clauses in three stages: the rows are read page
+// by page in the order of id, extracted, and handed over to save. The stages run concurrently and are connected by
+// channels holding at most a page, so the memory stays bounded no matter how large the raw table is
+func streamExtract(
+	ctx gocontext.Context,
+	db dal.Dal,
+	clauses []dal.Clause,
+	pageSize int,
+	extract func(row *RawData) ([]interface{}, errors.Error),
+	save func(row *RawData, results []interface{}) errors.Error,
+) errors.Error {
+	g, gctx := errgroup.WithContext(ctx)
+	rows := make(chan *RawData, pageSize)
+	extracted := make(chan extractedRow, pageSize)
+
+	g.Go(func() error {
+		defer close(rows)
+		var lastId uint64
+		for {
+			var page []*RawData
+			pageClauses := append(append([]dal.Clause{}, clauses...),
+				dal.Where("id > ?", lastId),
+				dal.Orderby("id ASC"),
+				dal.Limit(pageSize),
+			)
+			err := db.All(&page, pageClauses...)
+			if err != nil {
+				return errors.Default.Wrap(err, "error reading raw rows")
+			}
+			for _, row := range page {
+				select {
+				case rows <- row:
+				case <-gctx.Done():
+					return gctx.Err()
+				}
+			}
+			if len(page) < pageSize {
+				return nil
+			}
+			lastId = page[len(page)-1].ID
+		}
+	})
+
+	g.Go(func() error {
+		defer close(extracted)
+		for row := range rows {
+			err := decompressRawRow(row)
+			if err != nil {
+				return err
+			}
+			results, err := extract(row)
+			if err != nil {
+				return err
+			}
+			select {
+			case extracted <- extractedRow{row: row, results: results}:
+			case <-gctx.Done():
+				return gctx.Err()
+			}
+		}
+		return nil
+	})
+
+	g.Go(func() error {
+		for item := range extracted {
+			err := save(item.row, item.results)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	return errors.Convert(g.Wait())
+}
+
+// saveExtractedResults hands the results extracted from the row over to the batch saves of their types,
+// records get saved into db when the batches were max outed
+func saveExtractedResults(divider *BatchSaveDivider, table string, params string, row *RawData, results []interface{}) errors.Error {
+	for _, result := range results {
+		// get the batch operator for the specific type
+		batch, err := divider.ForType(reflect.TypeOf(result))
+		if err != nil {
+			return errors.Default.Wrap(err, "error getting batch from result")
+		}
+		// set raw data origin field
+		setRawDataOrigin(result, common.RawDataOrigin{
+			RawDataTable:  table,
+			RawDataId:     row.ID,
+			RawDataParams: params,
+		})
+		err = batch.Add(result)
+		if err != nil {
+			return errors.Default.Wrap(err, "error adding result to batch")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	gocontext "context"
+	"fmt"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// mockRawTable serves the raw rows 1..total page by page like `WHERE id > ? ORDER BY id LIMIT ?`
+func mockRawTable(total uint64) *mockdal.Dal {
+	mockDal := new(mockdal.Dal)
+	mockDal.On("All", mock.Anything, mock.Anything).Return(func(dst interface{}, clauses ...dal.Clause) errors.Error {
+		var lastId uint64
+		limit := 0
+		for _, c := range clauses {
+			switch c.Type {
+			case dal.WhereClause:
+				if where := c.Data.(dal.DalClause); where.Expr == "id > ?" {
+					lastId = where.Params[0].(uint64)
+				}
+			case dal.LimitClause:
+				limit = c.Data.(int)
+			}
+		}
+		page := dst.(*[]*RawData)
+		for id := lastId + 1; id <= total && len(*page) < limit; id++ {
+			*page = append(*page, &RawData{ID: id, Data: []byte(fmt.Sprintf(`{"id":%d}`, id))})
+		}
+		return nil
+	})
+	return mockDal
+}
+
+func TestStreamExtract(t *testing.T) {
+	mockDal := mockRawTable(7)
+	var saved []uint64
+	err := streamExtract(gocontext.Background(), mockDal, nil, 3,
+		func(row *RawData) ([]interface{}, errors.Error) {
+			return []interface{}{row.ID * 10}, nil
+		},
+		func(row *RawData, results []interface{}) errors.Error {
+			assert.Equal(t, row.ID*10, results[0])
+			saved = append(saved, row.ID)
+			return nil
+		},
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{1, 2, 3, 4, 5, 6, 7}, saved)
+	// the third page is short, so the reader stops without querying an empty page
+	mockDal.AssertNumberOfCalls(t, "All", 3)
+}
+
+func TestStreamExtractStopsOnError(t *testing.T) {
+	mockDal := mockRawTable(1000)
+	saved := 0
+	err := streamExtract(gocontext.Background(), mockDal, nil, 10,
+		func(row *RawData) ([]interface{}, errors.Error) {
+			if row.ID == 25 {
+				return nil, errors.Default.New("broken row")
+			}
+			return nil, nil
+		},
+		func(row *RawData, results []interface{}) errors.Error {
+			saved++
+			return nil
+		},
+	)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "broken row")
+	assert.Less(t, saved, 25)
+	// the reader gave up instead of reading the whole table
+	assert.Less(t, len(mockDal.Calls), 100)
+}
@@ -0,0 +1,125 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/log"
+	"github.com/apache/incubator-devlake/core/models"
+)
+
+// apiHttpCache remembers the ETag/Last-Modified of the responses collected into a raw table, so that the following
+// incremental collections may send conditional requests, and the server would respond with 304 Not Modified for
+// the unchanged resources, which normally doesn't count against the rate limit
+type apiHttpCache struct {
+	db     dal.Dal
+	logger log.Logger
+	table  string
+	params string
+}
+
+func newApiHttpCache(db dal.Dal, logger log.Logger, table string, params string) *apiHttpCache {
+	return &apiHttpCache{
+		db:     db,
+		logger: logger,
+		table:  table,
+		params: params,
+	}
+}
+
+// httpCacheVolatileParams are the query parameters the incremental collections move forward on every run, they are
+// left out of the cache url, or no run would ever find the validators saved by the previous one
+var httpCacheVolatileParams = []string{
+	"since", "until", "modified", "minTime", "searchCriteria.minTime",
+	"updated_after", "updated_before", "created_after", "createdAfter",
+}
+
+// cacheUrl returns the url the validators are stored for
+func (c *apiHttpCache) cacheUrl(apiUrl string, query url.Values) string {
+	if len(query) == 0 {
+		return apiUrl
+	}
+	stable := url.Values{}
+	for name, values := range query {
+		stable[name] = values
+	}
+	for _, name := range httpCacheVolatileParams {
+		stable.Del(name)
+	}
+	if len(stable) == 0 {
+		return apiUrl
+	}
+	return apiUrl + "?" + stable.Encode()
+}
+
+func (c *apiHttpCache) cacheKey(cacheUrl string) string {
+	sum := sha256.Sum256([]byte(c.table + "\n" + c.params + "\n" + cacheUrl))
+	return hex.EncodeToString(sum[:])
+}
+
+// conditionalHeader returns a copy of the header with the validators of the cached response of the url. Only the
+// responses of the last page are revalidated, a 304 carries no items so the pages following it couldn't be requested
+func (c *apiHttpCache) conditionalHeader(cacheUrl string, header http.Header) http.Header {
+	entry := &models.ApiHttpCache{}
+	err := c.db.First(entry, dal.Where("cache_key = ?", c.cacheKey(cacheUrl)))
+	if err != nil {
+		if !c.db.IsErrorNotFound(err) {
+			c.logger.Warn(err, "failed to load http cache of %s", cacheUrl)
+		}
+		return header
+	}
+	if !entry.LastPage {
+		return header
+	}
+	if header == nil {
+		header = http.Header{}
+	} else {
+		header = header.Clone()
+	}
+	if entry.ETag != "" && header.Get("If-None-Match") == "" {
+		header.Set("If-None-Match", entry.ETag)
+	}
+	if entry.LastModified != "" && header.Get("If-Modified-Since") == "" {
+		header.Set("If-Modified-Since", entry.LastModified)
+	}
+	return header
+}
+
+// put stores the validators of the response, it is a no-op if the server didn't return any
+func (c *apiHttpCache) put(cacheUrl string, res *http.Response, lastPage bool) errors.Error {
+	etag := res.Header.Get("ETag")
+	lastModified := res.Header.Get("Last-Modified")
+	if etag == "" && lastModified == "" {
+		return nil
+	}
+	return c.db.CreateOrUpdate(&models.ApiHttpCache{
+		CacheKey:      c.cacheKey(cacheUrl),
+		RawDataTable:  c.table,
+		RawDataParams: c.params,
+		Url:           cacheUrl,
+		ETag:          etag,
+		LastModified:  lastModified,
+		LastPage:      lastPage,
+	})
+}
@@ -0,0 +1,97 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestApiHttpCacheConditionalHeader(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	cache := newApiHttpCache(mockDal, unithelper.DummyLogger(), "_raw_github_api_issues", `{"ConnectionId":1}`)
+	cacheUrl := cache.cacheUrl("repos/apache/incubator-devlake/issues", url.Values{"page": {"1"}})
+	assert.Equal(t, "repos/apache/incubator-devlake/issues?page=1", cacheUrl)
+	// the incremental runs move since forward, they share the validators
+	assert.Equal(t, cacheUrl, cache.cacheUrl("repos/apache/incubator-devlake/issues", url.Values{
+		"page":  {"1"},
+		"since": {"2026-10-14T00:00:00Z"},
+	}))
+	assert.Equal(t, "projects/1/issues", cache.cacheUrl("projects/1/issues", url.Values{"updated_after": {"2026-10-14"}}))
+
+	mockDal.On("First", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		entry := args.Get(0).(*models.ApiHttpCache)
+		entry.ETag = `W/"abc"`
+		entry.LastModified = "Wed, 14 Oct 2026 00:00:00 GMT"
+		entry.LastPage = true
+	}).Return(nil).Once()
+	original := http.Header{"Accept": {"application/json"}}
+	header := cache.conditionalHeader(cacheUrl, original)
+	assert.Equal(t, `W/"abc"`, header.Get("If-None-Match"))
+	assert.Equal(t, "Wed, 14 Oct 2026 00:00:00 GMT", header.Get("If-Modified-Since"))
+	assert.Equal(t, "application/json", header.Get("Accept"))
+	assert.Empty(t, original.Get("If-None-Match"))
+
+	// more pages followed the cached response, a 304 couldn't tell them
+	mockDal.On("First", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		entry := args.Get(0).(*models.ApiHttpCache)
+		entry.ETag = `W/"abc"`
+	}).Return(nil).Once()
+	assert.Equal(t, original, cache.conditionalHeader(cacheUrl, original))
+
+	// nothing cached yet
+	notFound := errors.NotFound.New("record not found")
+	mockDal.On("First", mock.Anything, mock.Anything).Return(notFound).Once()
+	mockDal.On("IsErrorNotFound", notFound).Return(true).Once()
+	assert.Nil(t, cache.conditionalHeader(cacheUrl, nil))
+
+	mockDal.AssertExpectations(t)
+}
+
+func TestApiHttpCachePut(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	cache := newApiHttpCache(mockDal, unithelper.DummyLogger(), "_raw_github_api_issues", `{"ConnectionId":1}`)
+	cacheUrl := "repos/apache/incubator-devlake/issues?page=1"
+
+	// no validators, nothing to cache
+	assert.Nil(t, cache.put(cacheUrl, &http.Response{Header: http.Header{}}, true))
+
+	mockDal.On("CreateOrUpdate", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		entry := args.Get(0).(*models.ApiHttpCache)
+		assert.Equal(t, cache.cacheKey(cacheUrl), entry.CacheKey)
+		assert.Len(t, entry.CacheKey, 64)
+		assert.Equal(t, `"abc"`, entry.ETag)
+		assert.Equal(t, cacheUrl, entry.Url)
+		assert.True(t, entry.LastPage)
+	}).Return(nil).Once()
+	assert.Nil(t, cache.put(cacheUrl, &http.Response{Header: http.Header{"Etag": {`"abc"`}}}, true))
+
+	// the key differs across connections
+	other := newApiHttpCache(mockDal, unithelper.DummyLogger(), "_raw_github_api_issues", `{"ConnectionId":2}`)
+	assert.NotEqual(t, cache.cacheKey(cacheUrl), other.cacheKey(cacheUrl))
+
+	mockDal.AssertExpectations(t)
+}
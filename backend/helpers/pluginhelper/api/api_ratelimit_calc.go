@@ -31,6 +31,8 @@ type ApiRateLimitCalculator struct {
 	Method                 string
 	ApiPath                string
 	DynamicRateLimit       func(res *http.Response) (int, time.Duration, errors.Error)
+	// Tokens is the number of tokens the api client rotates, the rate limit headers describe one of them
+	Tokens int
 }
 
 // Calculate FIXME ...
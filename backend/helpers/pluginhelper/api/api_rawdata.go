@@ -35,6 +35,8 @@ type RawData struct {
 	Url       string
 	Input     json.RawMessage `gorm:"type:json"`
 	CreatedAt time.Time       `gorm:"index"`
+	// ConnectionId is extracted from Params, it is the partition key when the raw tables are partitioned by connection
+	ConnectionId uint64
 }
 
 type TaskOptions interface {
@@ -58,9 +60,11 @@ type RawDataSubTaskArgs struct {
 
 // RawDataSubTask is Common features for raw data sub-tasks
 type RawDataSubTask struct {
-	args   *RawDataSubTaskArgs
-	table  string
-	params string
+	args         *RawDataSubTaskArgs
+	table        string
+	params       string
+	connectionId uint64
+	compression  string
 }
 
 // NewRawDataSubTask constructor for RawDataSubTask
@@ -84,9 +88,11 @@ func NewRawDataSubTask(args RawDataSubTaskArgs) (*RawDataSubTask, errors.Error)
 		paramsString = plugin.MarshalScopeParams(params)
 	}
 	return &RawDataSubTask{
-		args:   &args,
-		table:  fmt.Sprintf("_raw_%s", args.Table),
-		params: paramsString,
+		args:         &args,
+		table:        fmt.Sprintf("_raw_%s", args.Table),
+		params:       paramsString,
+		connectionId: rawParamsConnectionId(paramsString),
+		compression:  rawCompression(args.Ctx),
 	}, nil
 }
 
@@ -0,0 +1,228 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/config"
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+const (
+	API_RECORDING_RECORD = "record"
+	API_RECORDING_REPLAY = "replay"
+)
+
+// apiRecording is a response of the data source api saved to the disk
+type apiRecording struct {
+	Method     string      `json:"method"`
+	Url        string      `json:"url"`
+	Status     int         `json:"status"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+	RecordedAt time.Time   `json:"recordedAt"`
+}
+
+// apiRecorder is the transport saving every response of the data source api into the dir in the record mode, and
+// serving the saved responses without touching the network in the replay mode, so a pipeline may be reproduced
+// offline. The identical requests sent more than once are recorded in sequence, and replayed in the same order
+type apiRecorder struct {
+	mode   string
+	dir    string
+	next   http.RoundTripper
+	lock   sync.Mutex
+	counts map[string]int
+}
+
+func newApiRecorder(mode string, dir string, next http.RoundTripper) (*apiRecorder, errors.Error) {
+	if mode != API_RECORDING_RECORD && mode != API_RECORDING_REPLAY {
+		return nil, errors.BadInput.New(fmt.Sprintf("invalid API_RECORDING_MODE %s, it must be %s or %s", mode, API_RECORDING_RECORD, API_RECORDING_REPLAY))
+	}
+	if dir == "" {
+		return nil, errors.BadInput.New("API_RECORDING_DIR is required when API_RECORDING_MODE is set")
+	}
+	if mode == API_RECORDING_RECORD {
+		// the recordings hold the data of the data sources, they are kept private to the user running devlake
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to create the recording dir %s", dir))
+		}
+	}
+	return &apiRecorder{
+		mode:   mode,
+		dir:    dir,
+		next:   next,
+		counts: map[string]int{},
+	}, nil
+}
+
+// NewApiRecordingTransport wraps the transport with the recorder when API_RECORDING_MODE is set, for the clients
+// not created by NewApiClient, i.e. the graphql ones
+func NewApiRecordingTransport(cfg config.ConfigReader, next http.RoundTripper) (http.RoundTripper, errors.Error) {
+	recordingMode := cfg.GetString("API_RECORDING_MODE")
+	if recordingMode == "" {
+		return next, nil
+	}
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return newApiRecorder(recordingMode, cfg.GetString("API_RECORDING_DIR"), next)
+}
+
+func (r *apiRecorder) replaying() bool {
+	return r.mode == API_RECORDING_REPLAY
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *apiRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	key := recordingKey(req, body)
+	r.lock.Lock()
+	r.counts[key]++
+	seq := r.counts[key]
+	r.lock.Unlock()
+	if r.replaying() {
+		return r.replay(req, key, seq)
+	}
+	res, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	return r.record(req, res, key, seq)
+}
+
+func (r *apiRecorder) record(req *http.Request, res *http.Response, key string, seq int) (*http.Response, error) {
+	resBody, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(resBody))
+	recording, err := json.MarshalIndent(&apiRecording{
+		Method:     req.Method,
+		Url:        redactRecordingUrl(req.URL),
+		Status:     res.StatusCode,
+		Header:     redactRecordingHeader(res.Header),
+		Body:       resBody,
+		RecordedAt: time.Now(),
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(r.recordingPath(key, seq), recording, 0600); err != nil {
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to record the response of %s %s", req.Method, req.URL))
+	}
+	return res, nil
+}
+
+// replay serves the seq-th recorded response of the request, or the last one if it was sent fewer times while recording
+func (r *apiRecorder) replay(req *http.Request, key string, seq int) (*http.Response, error) {
+	for ; seq > 0; seq-- {
+		content, err := os.ReadFile(r.recordingPath(key, seq))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		recording := &apiRecording{}
+		if err := json.Unmarshal(content, recording); err != nil {
+			return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to decode the recorded response of %s %s", req.Method, req.URL))
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", recording.Status, http.StatusText(recording.Status)),
+			StatusCode:    recording.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        recording.Header,
+			Body:          io.NopCloser(bytes.NewReader(recording.Body)),
+			ContentLength: int64(len(recording.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, errors.NotFound.New(fmt.Sprintf("no recorded response of %s %s in %s", req.Method, req.URL, r.dir))
+}
+
+// recordingSecretHeaders are the headers carrying credentials, they are never written to the disk
+var recordingSecretHeaders = []string{"Authorization", "Cookie", "Set-Cookie", "Private-Token", "Proxy-Authorization"}
+
+func redactRecordingHeader(header http.Header) http.Header {
+	redacted := header.Clone()
+	for _, name := range recordingSecretHeaders {
+		if redacted.Get(name) != "" {
+			redacted.Set(name, SanitizedProxyPassword)
+		}
+	}
+	return redacted
+}
+
+// redactRecordingUrl masks the credentials in the url, the password of the user info and the query parameters named
+// like tokens, secrets, passwords or api keys
+func redactRecordingUrl(u *url.URL) string {
+	redacted := *u
+	if _, ok := redacted.User.Password(); ok {
+		redacted.User = url.UserPassword(redacted.User.Username(), SanitizedProxyPassword)
+	}
+	query := redacted.Query()
+	for name := range query {
+		lower := strings.ToLower(name)
+		if strings.Contains(lower, "token") || strings.Contains(lower, "secret") || strings.Contains(lower, "password") ||
+			lower == "key" || lower == "apikey" || lower == "api_key" {
+			query.Set(name, SanitizedProxyPassword)
+		}
+	}
+	redacted.RawQuery = query.Encode()
+	return redacted.String()
+}
+
+func (r *apiRecorder) recordingPath(key string, seq int) string {
+	return filepath.Join(r.dir, fmt.Sprintf("%s-%d.json", key, seq))
+}
+
+// recordingKey identifies the request by the method, url and body, the headers are left out since they carry the
+// credentials, which may differ between the recording and the replaying
+func recordingKey(req *http.Request, body []byte) string {
+	u := *req.URL
+	u.RawQuery = u.Query().Encode()
+	sum := sha256.Sum256([]byte(req.Method + "\n" + u.String() + "\n" + string(body)))
+	host := strings.NewReplacer(":", "_", "/", "_").Replace(u.Host)
+	return host + "-" + hex.EncodeToString(sum[:])[:32]
+}
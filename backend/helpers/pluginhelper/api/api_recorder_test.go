@@ -0,0 +1,141 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApiRecorder(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("X-Calls", strings.Repeat("+", calls))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(r.URL.Query().Get("page") + ":" + strings.Repeat("+", calls)))
+	}))
+	dir := t.TempDir()
+
+	get := func(rt http.RoundTripper, query string) (*http.Response, string, error) {
+		client := &http.Client{Transport: rt}
+		res, err := client.Get(server.URL + "/api/issues?" + query)
+		if err != nil {
+			return nil, "", err
+		}
+		defer res.Body.Close()
+		body, _ := io.ReadAll(res.Body)
+		return res, string(body), nil
+	}
+
+	recorder, err := newApiRecorder(API_RECORDING_RECORD, dir, http.DefaultTransport)
+	assert.Nil(t, err)
+	_, body, e := get(recorder, "page=1&size=10")
+	assert.Nil(t, e)
+	assert.Equal(t, "1:+", body)
+	_, body, e = get(recorder, "page=1&size=10")
+	assert.Nil(t, e)
+	assert.Equal(t, "1:++", body)
+	_, body, e = get(recorder, "page=2&size=10")
+	assert.Nil(t, e)
+	assert.Equal(t, "2:+++", body)
+	server.Close()
+
+	replayer, err := newApiRecorder(API_RECORDING_REPLAY, dir, http.DefaultTransport)
+	assert.Nil(t, err)
+	// the query is matched regardless of the order of the parameters
+	res, body, e := get(replayer, "size=10&page=1")
+	assert.Nil(t, e)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "+", res.Header.Get("X-Calls"))
+	assert.Equal(t, "1:+", body)
+	_, body, e = get(replayer, "page=1&size=10")
+	assert.Nil(t, e)
+	assert.Equal(t, "1:++", body)
+	// requested more times than recorded, the last one is served
+	_, body, e = get(replayer, "page=1&size=10")
+	assert.Nil(t, e)
+	assert.Equal(t, "1:++", body)
+	_, body, e = get(replayer, "page=2&size=10")
+	assert.Nil(t, e)
+	assert.Equal(t, "2:+++", body)
+	_, _, e = get(replayer, "page=3&size=10")
+	assert.NotNil(t, e)
+	assert.Equal(t, 3, calls)
+}
+
+func TestNewApiRecorder(t *testing.T) {
+	_, err := newApiRecorder("rewind", t.TempDir(), http.DefaultTransport)
+	assert.NotNil(t, err)
+	_, err = newApiRecorder(API_RECORDING_REPLAY, "", http.DefaultTransport)
+	assert.NotNil(t, err)
+}
+
+func TestApiRecorderRedactsCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Set-Cookie", "session=s3cret")
+		w.Header().Set("X-Request-Id", "42")
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+	dir := filepath.Join(t.TempDir(), "recordings")
+	recorder, err := newApiRecorder(API_RECORDING_RECORD, dir, http.DefaultTransport)
+	assert.Nil(t, err)
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/api/issues?private_token=s3cret&page=2", nil)
+	req.Header.Set("Authorization", "Bearer s3cret")
+	req.Header.Set("PRIVATE-TOKEN", "s3cret")
+	res, e := (&http.Client{Transport: recorder}).Do(req)
+	assert.Nil(t, e)
+	res.Body.Close()
+
+	info, e := os.Stat(dir)
+	assert.Nil(t, e)
+	assert.Equal(t, os.FileMode(0700), info.Mode().Perm())
+	files, e := os.ReadDir(dir)
+	assert.Nil(t, e)
+	assert.Len(t, files, 1)
+	info, e = files[0].Info()
+	assert.Nil(t, e)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	content, e := os.ReadFile(filepath.Join(dir, files[0].Name()))
+	assert.Nil(t, e)
+	assert.NotContains(t, string(content), "s3cret")
+	assert.Contains(t, string(content), "page=2")
+	assert.Contains(t, string(content), "X-Request-Id")
+}
+
+func TestNewApiRecordingTransport(t *testing.T) {
+	cfg := viper.New()
+	transport, err := NewApiRecordingTransport(cfg, http.DefaultTransport)
+	assert.Nil(t, err)
+	assert.Equal(t, http.DefaultTransport, transport)
+
+	cfg.Set("API_RECORDING_MODE", API_RECORDING_REPLAY)
+	cfg.Set("API_RECORDING_DIR", t.TempDir())
+	transport, err = NewApiRecordingTransport(cfg, nil)
+	assert.Nil(t, err)
+	assert.IsType(t, &apiRecorder{}, transport)
+}
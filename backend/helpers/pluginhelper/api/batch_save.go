@@ -18,15 +18,21 @@ limitations under the License.
 package api
 
 import (
+	gocontext "context"
 	"fmt"
 	"reflect"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/log"
+	"github.com/apache/incubator-devlake/core/metrics"
+	"github.com/apache/incubator-devlake/core/tracing"
+	"github.com/apache/incubator-devlake/core/utils"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // BatchSave performs multiple records persistence of a specific type in one sql query to improve the performance
@@ -43,15 +49,37 @@ type BatchSave struct {
 	valueIndex map[string]int
 	primaryKey []reflect.StructField
 	tableName  string
+	ctx        gocontext.Context
+	anonymizer *Anonymizer
 	mutex      sync.Mutex
 	lastErr    errors.Error
+	// bulk saves the records by dal.BulkCreateOrUpdate
+	bulk bool
+	// flushInterval flushes the records on adding if the last flush was earlier than it, 0 means never
+	flushInterval time.Duration
+	lastFlush     time.Time
 }
 
-// NewBatchSave creates a new BatchSave instance
+// NewBatchSave creates a new BatchSave instance, the size can be overridden by BATCH_SAVE_SIZE
 func NewBatchSave(basicRes context.BasicRes, slotType reflect.Type, size int, tableName ...string) (*BatchSave, errors.Error) {
 	if slotType.Kind() != reflect.Ptr {
 		panic(errors.Default.New("slotType must be a pointer"))
 	}
+	configuredSize, err := utils.StrToIntOr(basicRes.GetConfig("BATCH_SAVE_SIZE"), 0)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "failed to parse BATCH_SAVE_SIZE")
+	}
+	if configuredSize > 0 {
+		size = configuredSize
+	}
+	flushInterval, err := utils.StrToDurationOr(basicRes.GetConfig("BATCH_SAVE_FLUSH_INTERVAL"), 0)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "failed to parse BATCH_SAVE_FLUSH_INTERVAL")
+	}
+	bulk, err := utils.StrToBoolOr(basicRes.GetConfig("BATCH_SAVE_BULK_INSERT"), false)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "failed to parse BATCH_SAVE_BULK_INSERT")
+	}
 	db := basicRes.GetDal()
 	primaryKey := db.GetPrimaryKeyFields(slotType)
 	// check if it has primaryKey
@@ -64,16 +92,26 @@ func NewBatchSave(basicRes context.BasicRes, slotType reflect.Type, size int, ta
 	}
 
 	logger := basicRes.GetLogger().Nested(slotType.String())
+	// batches saved by subtasks are traced in the spans of the subtasks
+	ctx := gocontext.Background()
+	if execCtx, ok := basicRes.(interface{ GetContext() gocontext.Context }); ok && execCtx.GetContext() != nil {
+		ctx = execCtx.GetContext()
+	}
 	return &BatchSave{
-		basicRes:   basicRes,
-		log:        logger,
-		db:         db,
-		slotType:   slotType,
-		slots:      reflect.MakeSlice(reflect.SliceOf(slotType), size, size),
-		size:       size,
-		valueIndex: make(map[string]int),
-		primaryKey: primaryKey,
-		tableName:  tn,
+		ctx:           ctx,
+		basicRes:      basicRes,
+		log:           logger,
+		db:            db,
+		slotType:      slotType,
+		slots:         reflect.MakeSlice(reflect.SliceOf(slotType), size, size),
+		size:          size,
+		valueIndex:    make(map[string]int),
+		primaryKey:    primaryKey,
+		tableName:     tn,
+		anonymizer:    NewAnonymizer(),
+		bulk:          bulk,
+		flushInterval: flushInterval,
+		lastFlush:     time.Now(),
 	}, nil
 }
 
@@ -90,6 +128,9 @@ func (c *BatchSave) Add(slot interface{}) errors.Error {
 		return errors.Default.Wrap(c.lastErr, "add slot failed due to previous err")
 	}
 	stripZeroByte(slot)
+	if c.anonymizer != nil {
+		c.anonymizer.Anonymize(slot)
+	}
 	// deduplication
 	key := getKeyValue(slot, c.primaryKey)
 	c.mutex.Lock()
@@ -104,8 +145,8 @@ func (c *BatchSave) Add(slot interface{}) errors.Error {
 	}
 	c.slots.Index(c.current).Set(reflect.ValueOf(slot))
 	c.current++
-	// flush out into database if maxed out
-	if c.current == c.size {
+	// flush out into database if maxed out or not flushed for a while
+	if c.current == c.size || (c.flushInterval > 0 && time.Since(c.lastFlush) >= c.flushInterval) {
 		return c.flushWithoutLocking()
 	} else if c.current%100 == 0 {
 		c.log.Debug("batch save current: %d", c.current)
@@ -128,17 +169,39 @@ func (c *BatchSave) flushWithoutLocking() errors.Error {
 	if c.tableName != "" {
 		clauses = append(clauses, dal.From(c.tableName))
 	}
-	err := c.db.CreateOrUpdate(c.slots.Slice(0, c.current).Interface(), clauses...)
+	_, span := tracing.Start(c.ctx, fmt.Sprintf("batch save %s", c.metricsTable()),
+		attribute.String("db.table", c.metricsTable()),
+		attribute.Int("db.rows", c.current),
+	)
+	var err errors.Error
+	if c.bulk {
+		err = c.db.BulkCreateOrUpdate(c.slots.Slice(0, c.current).Interface(), clauses...)
+	} else {
+		err = c.db.CreateOrUpdate(c.slots.Slice(0, c.current).Interface(), clauses...)
+	}
+	tracing.End(span, err)
 	if err != nil {
 		c.lastErr = err
 		return err
 	}
 	c.log.Debug("batch save flush total %d records to database", c.current)
+	metrics.DbRowsWritten.WithLabelValues(c.metricsTable()).Add(float64(c.current))
 	c.current = 0
 	c.valueIndex = make(map[string]int)
+	c.lastFlush = time.Now()
 	return nil
 }
 
+func (c *BatchSave) metricsTable() string {
+	if c.tableName != "" {
+		return c.tableName
+	}
+	if tabler, ok := reflect.New(c.slotType.Elem()).Interface().(dal.Tabler); ok {
+		return tabler.TableName()
+	}
+	return c.slotType.Elem().Name()
+}
+
 // Close would flush the cache and release resources
 func (c *BatchSave) Close() errors.Error {
 	c.mutex.Lock()
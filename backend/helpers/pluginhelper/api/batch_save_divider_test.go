@@ -58,6 +58,7 @@ func TestBatchSaveDivider(t *testing.T) {
 
 	mockRes.On("GetDal").Return(mockDal)
 	mockRes.On("GetLogger").Return(mockLogger)
+	mockRes.On("GetConfig", mock.Anything).Return("").Maybe()
 
 	// we expect total 2 deletion calls after all code got carried out
 	mockDal.On("Delete", mock.Anything, mock.Anything).Return(nil).Twice()
@@ -18,7 +18,18 @@ limitations under the License.
 package api
 
 import (
+	"reflect"
 	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/metrics"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockcontext "github.com/apache/incubator-devlake/mocks/core/context"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func Test_stripZeroByte(t *testing.T) {
@@ -66,3 +77,116 @@ func Test_stripZeroByte(t *testing.T) {
 		})
 	}
 }
+
+func TestBatchSaveConfig(t *testing.T) {
+	type saved struct {
+		method string
+		ids    []string
+	}
+	tests := []struct {
+		name   string
+		config map[string]string
+		want   []saved
+	}{
+		{
+			name:   "default size",
+			config: map[string]string{},
+			want:   []saved{{"CreateOrUpdate", []string{"1", "2", "3"}}},
+		},
+		{
+			name:   "configured size",
+			config: map[string]string{"BATCH_SAVE_SIZE": "2"},
+			want:   []saved{{"CreateOrUpdate", []string{"1", "2"}}, {"CreateOrUpdate", []string{"3"}}},
+		},
+		{
+			name:   "bulk insert",
+			config: map[string]string{"BATCH_SAVE_SIZE": "2", "BATCH_SAVE_BULK_INSERT": "true"},
+			want:   []saved{{"BulkCreateOrUpdate", []string{"1", "2"}}, {"BulkCreateOrUpdate", []string{"3"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var batches []saved
+			record := func(method string) func(args mock.Arguments) {
+				return func(args mock.Arguments) {
+					var ids []string
+					for _, slot := range args.Get(0).([]*MockJiraChangelogBsd) {
+						ids = append(ids, slot.ID)
+					}
+					batches = append(batches, saved{method, ids})
+				}
+			}
+			mockDal := new(mockdal.Dal)
+			mockDal.On("GetPrimaryKeyFields", mock.Anything).Return([]reflect.StructField{{Name: "ID", Type: reflect.TypeOf("")}})
+			mockDal.On("CreateOrUpdate", mock.Anything, mock.Anything).Run(record("CreateOrUpdate")).Return(nil)
+			mockDal.On("BulkCreateOrUpdate", mock.Anything, mock.Anything).Run(record("BulkCreateOrUpdate")).Return(nil)
+			mockRes := new(mockcontext.BasicRes)
+			mockRes.On("GetDal").Return(mockDal)
+			mockRes.On("GetLogger").Return(unithelper.DummyLogger())
+			mockRes.On("GetConfig", mock.Anything).Return(func(name string) string { return tt.config[name] })
+
+			batch, err := NewBatchSave(mockRes, reflect.TypeOf(&MockJiraChangelogBsd{}), 100)
+			assert.Nil(t, err)
+			for _, id := range []string{"1", "2", "3"} {
+				assert.Nil(t, batch.Add(&MockJiraChangelogBsd{ID: id}))
+			}
+			assert.Nil(t, batch.Close())
+			assert.Equal(t, tt.want, batches)
+		})
+	}
+}
+
+func TestBatchSaveFlushInterval(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	mockDal.On("GetPrimaryKeyFields", mock.Anything).Return([]reflect.StructField{{Name: "ID", Type: reflect.TypeOf("")}})
+	mockDal.On("CreateOrUpdate", mock.Anything, mock.Anything).Return(nil)
+	mockRes := new(mockcontext.BasicRes)
+	mockRes.On("GetDal").Return(mockDal)
+	mockRes.On("GetLogger").Return(unithelper.DummyLogger())
+	mockRes.On("GetConfig", "BATCH_SAVE_FLUSH_INTERVAL").Return("50ms")
+	mockRes.On("GetConfig", mock.Anything).Return("")
+
+	batch, err := NewBatchSave(mockRes, reflect.TypeOf(&MockJiraChangelogBsd{}), 100)
+	assert.Nil(t, err)
+	assert.Nil(t, batch.Add(&MockJiraChangelogBsd{ID: "1"}))
+	mockDal.AssertNotCalled(t, "CreateOrUpdate", mock.Anything, mock.Anything)
+	// the batch isn't full, but it wasn't flushed for longer than the interval
+	time.Sleep(60 * time.Millisecond)
+	assert.Nil(t, batch.Add(&MockJiraChangelogBsd{ID: "2"}))
+	mockDal.AssertNumberOfCalls(t, "CreateOrUpdate", 1)
+}
+
+func TestBatchSaveInvalidConfig(t *testing.T) {
+	for _, config := range []string{"BATCH_SAVE_SIZE", "BATCH_SAVE_FLUSH_INTERVAL", "BATCH_SAVE_BULK_INSERT"} {
+		mockRes := new(mockcontext.BasicRes)
+		mockRes.On("GetConfig", config).Return("soon")
+		mockRes.On("GetConfig", mock.Anything).Return("")
+		_, err := NewBatchSave(mockRes, reflect.TypeOf(&MockJiraChangelogBsd{}), 100)
+		if assert.NotNil(t, err, config) {
+			assert.Equal(t, errors.BadInput, err.GetType())
+		}
+	}
+}
+
+func TestBatchSaveRowsWritten(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	mockDal.On("GetPrimaryKeyFields", mock.Anything).Return([]reflect.StructField{{Name: "ID", Type: reflect.TypeOf("")}})
+	mockDal.On("CreateOrUpdate", mock.Anything, mock.Anything).Return(nil).Once()
+	mockDal.On("CreateOrUpdate", mock.Anything, mock.Anything).Return(errors.Default.New("deadlock")).Once()
+	mockRes := new(mockcontext.BasicRes)
+	mockRes.On("GetDal").Return(mockDal)
+	mockRes.On("GetLogger").Return(unithelper.DummyLogger())
+	mockRes.On("GetConfig", "BATCH_SAVE_SIZE").Return("2")
+	mockRes.On("GetConfig", mock.Anything).Return("")
+	rowsWritten := metrics.DbRowsWritten.WithLabelValues("MockJiraChangelogBsd")
+	before := testutil.ToFloat64(rowsWritten)
+
+	batch, err := NewBatchSave(mockRes, reflect.TypeOf(&MockJiraChangelogBsd{}), 100)
+	assert.Nil(t, err)
+	for _, id := range []string{"1", "2", "3"} {
+		assert.Nil(t, batch.Add(&MockJiraChangelogBsd{ID: id}))
+	}
+	assert.NotNil(t, batch.Close())
+	// the rows of the failed batch are not counted
+	assert.Equal(t, float64(2), testutil.ToFloat64(rowsWritten)-before)
+}
@@ -0,0 +1,75 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+// SanitizedClientKey replaces the client private key in API responses
+const SanitizedClientKey = "********"
+
+// ClientTls holds the PEM encoded client certificate, its private key and an optional CA bundle for endpoints
+// fronted with mutual TLS, connections embed it to let the api client present the certificate,
+// the private key is stored encrypted
+type ClientTls struct {
+	ClientCert string `mapstructure:"clientCert" json:"clientCert" gorm:"type:text"`
+	ClientKey  string `mapstructure:"clientKey" json:"clientKey" gorm:"type:text;serializer:encdec"`
+	CaCert     string `mapstructure:"caCert" json:"caCert" gorm:"type:text"`
+}
+
+// GetTlsConfig builds the TLS config of the api client transport, nil is returned if nothing was configured
+func (c ClientTls) GetTlsConfig() (*tls.Config, errors.Error) {
+	if c.ClientCert == "" && c.ClientKey == "" && c.CaCert == "" {
+		return nil, nil
+	}
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if c.ClientCert != "" || c.ClientKey != "" {
+		cert, err := tls.X509KeyPair([]byte(c.ClientCert), []byte(c.ClientKey))
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, "invalid client certificate or key")
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	if c.CaCert != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(c.CaCert)) {
+			return nil, errors.BadInput.New("no certificate found in the CA bundle")
+		}
+		config.RootCAs = pool
+	}
+	return config, nil
+}
+
+// Sanitize masks the client private key
+func (c ClientTls) Sanitize() ClientTls {
+	if c.ClientKey != "" {
+		c.ClientKey = SanitizedClientKey
+	}
+	return c
+}
+
+// RestoreSanitized keeps the stored private key when the request sends the sanitized value back
+func (c *ClientTls) RestoreSanitized(existed ClientTls) {
+	if c.ClientKey == SanitizedClientKey {
+		c.ClientKey = existed.ClientKey
+	}
+}
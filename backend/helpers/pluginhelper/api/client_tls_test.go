@@ -0,0 +1,120 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testCertificate issues a certificate signed by the parent, or a self-signed CA if the parent is nil
+func testCertificate(t *testing.T, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey, string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: "devlake"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	assert.Nil(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.Nil(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	assert.Nil(t, err)
+	certPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPem := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+	return cert, key, string(certPem), string(keyPem)
+}
+
+func TestClientTlsGetTlsConfig(t *testing.T) {
+	ca, caKey, caPem, _ := testCertificate(t, nil, nil)
+	_, _, serverCertPem, serverKeyPem := testCertificate(t, ca, caKey)
+	_, _, clientCertPem, clientKeyPem := testCertificate(t, ca, caKey)
+
+	serverCert, err := tls.X509KeyPair([]byte(serverCertPem), []byte(serverKeyPem))
+	assert.Nil(t, err)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	// nothing configured
+	config, lakeErr := ClientTls{}.GetTlsConfig()
+	assert.Nil(t, lakeErr)
+	assert.Nil(t, config)
+
+	// the server refuses the client without certificate
+	config, lakeErr = ClientTls{CaCert: caPem}.GetTlsConfig()
+	assert.Nil(t, lakeErr)
+	_, err = (&http.Client{Transport: &http.Transport{TLSClientConfig: config}}).Get(server.URL)
+	assert.NotNil(t, err)
+
+	config, lakeErr = ClientTls{ClientCert: clientCertPem, ClientKey: clientKeyPem, CaCert: caPem}.GetTlsConfig()
+	assert.Nil(t, lakeErr)
+	res, err := (&http.Client{Transport: &http.Transport{TLSClientConfig: config}}).Get(server.URL)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusNoContent, res.StatusCode)
+	assert.Nil(t, res.Body.Close())
+
+	_, lakeErr = ClientTls{ClientCert: clientCertPem}.GetTlsConfig()
+	assert.NotNil(t, lakeErr)
+	_, lakeErr = ClientTls{CaCert: "not a certificate"}.GetTlsConfig()
+	assert.NotNil(t, lakeErr)
+}
+
+func TestClientTlsSanitize(t *testing.T) {
+	stored := ClientTls{ClientCert: "cert", ClientKey: "key"}
+	sanitized := stored.Sanitize()
+	assert.Equal(t, "cert", sanitized.ClientCert)
+	assert.Equal(t, SanitizedClientKey, sanitized.ClientKey)
+	assert.Equal(t, "key", stored.ClientKey)
+
+	sanitized.RestoreSanitized(stored)
+	assert.Equal(t, "key", sanitized.ClientKey)
+}
@@ -38,6 +38,8 @@ type CollectorStateManager struct {
 	since *time.Time
 	// Until is the end time of the time range to collect
 	until *time.Time
+	// reconciled indicates whether the deleted records get reconciled in this run
+	reconciled bool
 }
 
 // NewCollectorStateManager create a new CollectorStateManager
@@ -105,6 +107,15 @@ func (c *CollectorStateManager) GetUntil() *time.Time {
 	return c.until
 }
 
+// ShouldReconcile tells whether the deleted records are due to be reconciled against the source. It is only needed
+// in the incremental mode, since a full sync recreates all records
+func (c *CollectorStateManager) ShouldReconcile(interval time.Duration) bool {
+	if !c.isIncremental || interval <= 0 {
+		return false
+	}
+	return c.state.LatestReconcileStart == nil || c.until.Sub(*c.state.LatestReconcileStart) >= interval
+}
+
 func (c *CollectorStateManager) Close() errors.Error {
 	// update timeAfter in the database only for fullsync mode
 	if !c.isIncremental {
@@ -115,5 +126,9 @@ func (c *CollectorStateManager) Close() errors.Error {
 	}
 	// always update the latest success start time
 	c.state.LatestSuccessStart = c.until
+	// a full sync leaves no deleted records behind just like a reconciliation does
+	if c.reconciled || !c.isIncremental {
+		c.state.LatestReconcileStart = c.until
+	}
 	return c.db.Update(c.state)
 }
@@ -41,7 +41,7 @@ func (c BaseConnection) GetHash() string {
 // RestConnection implements the ApiConnection interface
 type RestConnection struct {
 	Endpoint         string `mapstructure:"endpoint" validate:"required" json:"endpoint"`
-	Proxy            string `mapstructure:"proxy" json:"proxy"`
+	Proxy            string `mapstructure:"proxy" json:"proxy" gorm:"serializer:encdec"`
 	RateLimitPerHour int    `comment:"api request rate limit per hour" json:"rateLimitPerHour"`
 }
 
@@ -59,6 +59,16 @@ func (rc RestConnection) GetProxy() string {
 	return rc.Proxy
 }
 
+// SanitizeProxy masks the password of the proxy url
+func (rc *RestConnection) SanitizeProxy() {
+	rc.Proxy = SanitizeProxy(rc.Proxy)
+}
+
+// RestoreSanitizedProxy keeps the stored proxy password when the sanitized proxy url is sent back
+func (rc *RestConnection) RestoreSanitizedProxy(existed string) {
+	rc.Proxy = RestoreSanitizedProxy(rc.Proxy, existed)
+}
+
 // GetProxy returns the Rate Limit for the connection
 func (rc RestConnection) GetRateLimitPerHour() int {
 	return rc.RateLimitPerHour
@@ -18,15 +18,21 @@ limitations under the License.
 package api
 
 import (
+	gocontext "context"
 	"encoding/base64"
 	"fmt"
 	"net/http"
 	"reflect"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/go-playground/validator/v10"
+	"golang.org/x/oauth2"
 )
 
 // BasicAuth implements HTTP Basic Authentication
@@ -91,9 +97,147 @@ func (ak *AppKey) GetAppKeyAuthenticator() plugin.ApiAuthenticator {
 	return ak
 }
 
+// oauth2ExpiryLeeway makes the access token refreshed a bit before it expires, so it won't expire on the way
+const oauth2ExpiryLeeway = time.Minute
+
+// oauth2RefreshLock prevents concurrent requests from refreshing the same token more than once, a refresh token
+// is usually invalidated once used. The instances sharing the database are coordinated by oauth2TokenStore
+var oauth2RefreshLock sync.Mutex
+
+// OAuth2 implements OAuth 2.0 Bearer Authentication, the access token gets refreshed by the refresh token
+// automatically when it expires
+type OAuth2 struct {
+	TokenUrl       string     `mapstructure:"tokenUrl" validate:"required" json:"tokenUrl"`
+	ClientId       string     `mapstructure:"clientId" validate:"required" json:"clientId"`
+	ClientSecret   string     `mapstructure:"clientSecret" json:"clientSecret" gorm:"serializer:encdec"`
+	AccessToken    string     `mapstructure:"accessToken" json:"accessToken" gorm:"serializer:encdec"`
+	RefreshToken   string     `mapstructure:"refreshToken" validate:"required" json:"refreshToken" gorm:"serializer:encdec"`
+	TokenExpiresAt *time.Time `mapstructure:"tokenExpiresAt" json:"tokenExpiresAt"`
+
+	// client sends the refresh requests, and store keeps the refreshed token of the saved connection, both are set by
+	// NewApiClientFromConnection
+	client *http.Client
+	store  *oauth2TokenStore
+}
+
+// Expired returns true if the access token is missing or about to expire
+func (o *OAuth2) Expired(now time.Time) bool {
+	return o.AccessToken == "" || (o.TokenExpiresAt != nil && now.Add(oauth2ExpiryLeeway).After(*o.TokenExpiresAt))
+}
+
+// Refresh exchanges the refresh token for a new access token, the refresh token gets replaced as well if the
+// server rotates it. The caller is responsible for saving the rotated refresh token
+func (o *OAuth2) Refresh(ctx gocontext.Context) errors.Error {
+	if o.client != nil {
+		ctx = gocontext.WithValue(ctx, oauth2.HTTPClient, o.client)
+	}
+	config := &oauth2.Config{
+		ClientID:     o.ClientId,
+		ClientSecret: o.ClientSecret,
+		Endpoint:     oauth2.Endpoint{TokenURL: o.TokenUrl},
+	}
+	token, err := config.TokenSource(ctx, &oauth2.Token{RefreshToken: o.RefreshToken}).Token()
+	if err != nil {
+		return errors.Unauthorized.Wrap(err, "failed to refresh the oauth2 access token")
+	}
+	o.AccessToken = token.AccessToken
+	if token.RefreshToken != "" {
+		o.RefreshToken = token.RefreshToken
+	}
+	o.TokenExpiresAt = nil
+	if !token.Expiry.IsZero() {
+		o.TokenExpiresAt = &token.Expiry
+	}
+	return nil
+}
+
+// SetupAuthentication sets up the request headers for authentication, it refreshes the access token if needed. Only
+// the token of a saved connection is refreshed, the rotated refresh token would be lost otherwise
+func (o *OAuth2) SetupAuthentication(request *http.Request) errors.Error {
+	oauth2RefreshLock.Lock()
+	defer oauth2RefreshLock.Unlock()
+	if o.Expired(time.Now()) {
+		if o.store == nil {
+			return errors.Unauthorized.New("the oauth2 access token is expired, it can only be refreshed for a saved connection")
+		}
+		if err := o.store.refresh(request.Context(), o); err != nil {
+			return err
+		}
+	}
+	request.Header.Set("Authorization", fmt.Sprintf("Bearer %v", o.AccessToken))
+	return nil
+}
+
+// oauth2TokenStore keeps the token of a saved connection in its table. The row is locked while refreshing, so the
+// instances sharing the database don't use the same refresh token twice, and only the token columns are updated
+type oauth2TokenStore struct {
+	db               dal.Dal
+	table            string
+	connectionId     uint64
+	encryptionSecret string
+}
+
+func newOAuth2TokenStore(basicRes context.BasicRes, connection plugin.ToolLayerConnection) *oauth2TokenStore {
+	return &oauth2TokenStore{
+		db:               basicRes.GetDal(),
+		table:            connection.TableName(),
+		connectionId:     connection.ConnectionId(),
+		encryptionSecret: basicRes.GetConfig(plugin.EncodeKeyEnvStr),
+	}
+}
+
+// refresh adopts the token refreshed by another instance, or refreshes it and saves the new one
+func (s *oauth2TokenStore) refresh(ctx gocontext.Context, o *OAuth2) (err errors.Error) {
+	tx := s.db.Begin()
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+	saved := &OAuth2{}
+	err = tx.First(saved,
+		dal.Select("access_token, refresh_token, token_expires_at"),
+		dal.From(s.table),
+		dal.Where("id = ?", s.connectionId),
+		dal.Lock(true, false),
+	)
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to load the oauth2 token of the connection")
+	}
+	o.AccessToken, o.RefreshToken, o.TokenExpiresAt = saved.AccessToken, saved.RefreshToken, saved.TokenExpiresAt
+	if !o.Expired(time.Now()) {
+		return nil
+	}
+	if err = o.Refresh(ctx); err != nil {
+		return err
+	}
+	encrypted := make([]string, 3)
+	for i, token := range []string{o.AccessToken, o.RefreshToken, saved.RefreshToken} {
+		if encrypted[i], err = plugin.Encrypt(s.encryptionSecret, token); err != nil {
+			return err
+		}
+	}
+	err = tx.UpdateColumns(s.table, []dal.DalSet{
+		{ColumnName: "access_token", Value: encrypted[0]},
+		{ColumnName: "refresh_token", Value: encrypted[1]},
+		{ColumnName: "token_expires_at", Value: o.TokenExpiresAt},
+	}, dal.Where("id = ? AND refresh_token = ?", s.connectionId, encrypted[2]))
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to save the refreshed oauth2 token")
+	}
+	return nil
+}
+
+// GetOAuth2Authenticator returns SetupAuthentication
+func (o *OAuth2) GetOAuth2Authenticator() plugin.ApiAuthenticator {
+	return o
+}
+
 // MultiAuth implements the MultiAuthenticator interface
 type MultiAuth struct {
-	AuthMethod       string `mapstructure:"authMethod" json:"authMethod" validate:"required,oneof=BasicAuth AccessToken AppKey"`
+	AuthMethod       string `mapstructure:"authMethod" json:"authMethod" validate:"required,oneof=BasicAuth AccessToken AppKey OAuth2"`
 	apiAuthenticator plugin.ApiAuthenticator
 }
 
@@ -126,6 +270,12 @@ func (ma *MultiAuth) GetApiAuthenticator(connection plugin.ApiConnection) (plugi
 		}
 		// check ae/models/connection.go:AeAppKey if you needed an example
 		ma.apiAuthenticator = appKey.GetAppKeyAuthenticator()
+	case plugin.AUTH_METHOD_OAUTH2:
+		oauth2Auth, ok := connection.(plugin.OAuth2Authenticator)
+		if !ok {
+			return nil, errors.Default.New("connection doesn't support OAuth2 Authentication")
+		}
+		ma.apiAuthenticator = oauth2Auth.GetOAuth2Authenticator()
 	default:
 		return nil, errors.Default.New("no Authentication Method was specified")
 	}
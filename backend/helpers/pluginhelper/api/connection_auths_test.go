@@ -0,0 +1,172 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newOAuth2TokenServer(t *testing.T, refreshed *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Nil(t, r.ParseForm())
+		assert.Equal(t, "refresh_token", r.PostForm.Get("grant_type"))
+		assert.Equal(t, fmt.Sprintf("refresh%d", *refreshed), r.PostForm.Get("refresh_token"))
+		*refreshed++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token":"access%d","refresh_token":"refresh%d","token_type":"bearer","expires_in":3600}`,
+			*refreshed, *refreshed)
+	}))
+}
+
+// newOAuth2MockStore returns the store of connection 1 holding the given token, and the transaction locking it
+func newOAuth2MockStore(saved *OAuth2) (*oauth2TokenStore, *mockdal.Transaction) {
+	mockTx := new(mockdal.Transaction)
+	mockTx.On("First", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(0).(*OAuth2) = *saved
+	}).Return(nil).Once()
+	mockDal := new(mockdal.Dal)
+	mockDal.On("Begin").Return(mockTx).Once()
+	return &oauth2TokenStore{db: mockDal, table: "_tool_jira_connections", connectionId: 1, encryptionSecret: "secret"}, mockTx
+}
+
+func TestOAuth2SetupAuthentication(t *testing.T) {
+	refreshed := 0
+	server := newOAuth2TokenServer(t, &refreshed)
+	defer server.Close()
+
+	store, mockTx := newOAuth2MockStore(&OAuth2{RefreshToken: "refresh0"})
+	var saved []dal.DalSet
+	var condition dal.Clause
+	mockTx.On("UpdateColumns", "_tool_jira_connections", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(1).([]dal.DalSet)
+		condition = args.Get(2).([]dal.Clause)[0]
+	}).Return(nil).Once()
+	mockTx.On("Commit").Return(nil).Once()
+	auth := &OAuth2{
+		TokenUrl:     server.URL,
+		ClientId:     "client",
+		ClientSecret: "secret",
+		RefreshToken: "refresh0",
+		client:       server.Client(),
+		store:        store,
+	}
+	assert.True(t, auth.Expired(time.Now()))
+
+	// the missing access token gets refreshed, only the token columns are saved, and only if the refresh token
+	// wasn't rotated by someone else
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	assert.Nil(t, auth.SetupAuthentication(req))
+	assert.Equal(t, "Bearer access1", req.Header.Get("Authorization"))
+	assert.Equal(t, "refresh1", auth.RefreshToken)
+	assert.NotNil(t, auth.TokenExpiresAt)
+	mockTx.AssertExpectations(t)
+	if assert.Len(t, saved, 3) {
+		assert.Equal(t, []string{"access_token", "refresh_token", "token_expires_at"}, []string{saved[0].ColumnName, saved[1].ColumnName, saved[2].ColumnName})
+		refreshToken, err := plugin.Decrypt("secret", saved[1].Value.(string))
+		assert.Nil(t, err)
+		assert.Equal(t, "refresh1", refreshToken)
+	}
+	where := condition.Data.(dal.DalClause)
+	assert.Equal(t, "id = ? AND refresh_token = ?", where.Expr)
+	previous, err := plugin.Decrypt("secret", where.Params[1].(string))
+	assert.Nil(t, err)
+	assert.Equal(t, "refresh0", previous)
+
+	// the valid access token is reused
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	assert.Nil(t, auth.SetupAuthentication(req))
+	assert.Equal(t, "Bearer access1", req.Header.Get("Authorization"))
+	assert.Equal(t, 1, refreshed)
+}
+
+func TestOAuth2RefreshedByAnotherInstance(t *testing.T) {
+	refreshed := 0
+	server := newOAuth2TokenServer(t, &refreshed)
+	defer server.Close()
+
+	// the token saved in the database was refreshed by another instance while this one held the old one
+	expiresAt := time.Now().Add(time.Hour)
+	store, mockTx := newOAuth2MockStore(&OAuth2{AccessToken: "access5", RefreshToken: "refresh5", TokenExpiresAt: &expiresAt})
+	mockTx.On("Commit").Return(nil).Once()
+	expired := time.Now().Add(-time.Hour)
+	auth := &OAuth2{
+		TokenUrl:       server.URL,
+		ClientId:       "client",
+		AccessToken:    "access4",
+		RefreshToken:   "refresh4",
+		TokenExpiresAt: &expired,
+		client:         server.Client(),
+		store:          store,
+	}
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	assert.Nil(t, auth.SetupAuthentication(req))
+	assert.Equal(t, "Bearer access5", req.Header.Get("Authorization"))
+	assert.Equal(t, "refresh5", auth.RefreshToken)
+	assert.Equal(t, 0, refreshed)
+	mockTx.AssertNotCalled(t, "UpdateColumns", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOAuth2UnsavedConnection(t *testing.T) {
+	refreshed := 0
+	server := newOAuth2TokenServer(t, &refreshed)
+	defer server.Close()
+
+	// a valid access token can be used to test a connection before saving it
+	expiresAt := time.Now().Add(time.Hour)
+	auth := &OAuth2{TokenUrl: server.URL, ClientId: "client", AccessToken: "access0", RefreshToken: "refresh0", TokenExpiresAt: &expiresAt, client: server.Client()}
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	assert.Nil(t, auth.SetupAuthentication(req))
+	assert.Equal(t, "Bearer access0", req.Header.Get("Authorization"))
+
+	// but it isn't refreshed, there would be nowhere to save the rotated refresh token
+	expired := time.Now().Add(-time.Hour)
+	auth.TokenExpiresAt = &expired
+	err := auth.SetupAuthentication(httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.NotNil(t, err)
+	assert.Equal(t, errors.Unauthorized, err.GetType())
+	assert.Equal(t, 0, refreshed)
+	assert.Equal(t, "refresh0", auth.RefreshToken)
+}
+
+func TestOAuth2RefreshFailed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+	}))
+	defer server.Close()
+
+	store, mockTx := newOAuth2MockStore(&OAuth2{RefreshToken: "revoked"})
+	mockTx.On("Rollback").Return(nil).Once()
+	auth := &OAuth2{TokenUrl: server.URL, ClientId: "client", RefreshToken: "revoked", client: server.Client(), store: store}
+	err := auth.SetupAuthentication(httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.NotNil(t, err)
+	assert.Equal(t, errors.Unauthorized, err.GetType())
+	mockTx.AssertExpectations(t)
+}
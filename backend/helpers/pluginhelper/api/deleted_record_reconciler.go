@@ -0,0 +1,254 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/core/utils"
+)
+
+// deleteBatchSize is the number of ids handed to DeleteRecords at a time
+const deleteBatchSize = 500
+
+// DeletedRecordReconcilerArgs tells the reconciler how to list the ids of all records existing in the source, and how
+// to remove the records deleted from the source. The incremental collection would never see a deleted record, so
+// the records of the scope are reconciled against the source every once in a while
+type DeletedRecordReconcilerArgs struct {
+	ApiClient RateLimitedApiClient
+	// UrlTemplate lists all records of the scope, i.e. `projects/{{ .Params.ProjectId }}/issues`, the api should
+	// return as few fields as possible since only the ids are needed
+	UrlTemplate string
+	Query       func(reqData *RequestData) (url.Values, errors.Error)
+	Header      func(reqData *RequestData) (http.Header, errors.Error)
+	PageSize    int
+	// Pagination fetches the pages in order, i.e. &LinkHeaderPagination{PageSizeParam: "per_page"}
+	Pagination     Pagination
+	ResponseParser func(res *http.Response) ([]json.RawMessage, errors.Error)
+	// GetId returns the id of a record in the response, which must match the ToolIdColumn
+	GetId func(item json.RawMessage) (string, errors.Error)
+	// ToolIdColumn and ToolClauses select the ids of the tool layer records of the scope, i.e. "gitlab_id" and
+	// dal.From(&models.GitlabIssue{}), dal.Where("connection_id = ? AND project_id = ?", connectionId, projectId)
+	ToolIdColumn string
+	ToolClauses  []dal.Clause
+	// DeleteRecords removes the tool layer and domain layer records of the ids deleted from the source, plugins
+	// consuming deletion events (i.e. webhooks) could call the same function to remove the records right away
+	DeleteRecords func(ids []string) errors.Error
+	// Interval is how often the records get reconciled, 0 falls back to DELETED_RECORDS_RECONCILE_INTERVAL, and
+	// the reconciliation is disabled if both are 0
+	Interval time.Duration
+}
+
+// DeletedRecordReconciler removes the records which exist in the tool layer but no longer in the source
+type DeletedRecordReconciler struct {
+	args        *DeletedRecordReconcilerArgs
+	rawArgs     RawDataSubTaskArgs
+	urlTemplate *template.Template
+}
+
+// NewDeletedRecordReconciler creates a new DeletedRecordReconciler
+func NewDeletedRecordReconciler(rawArgs RawDataSubTaskArgs, args DeletedRecordReconcilerArgs) (*DeletedRecordReconciler, errors.Error) {
+	if args.ApiClient == nil {
+		return nil, errors.Default.New("ApiClient is required")
+	}
+	if args.Pagination == nil {
+		return nil, errors.Default.New("Pagination is required")
+	}
+	if args.ResponseParser == nil || args.GetId == nil {
+		return nil, errors.Default.New("ResponseParser and GetId are required")
+	}
+	if args.ToolIdColumn == "" || args.DeleteRecords == nil {
+		return nil, errors.Default.New("ToolIdColumn and DeleteRecords are required")
+	}
+	tpl, err := errors.Convert01(template.New(rawArgs.Table).Parse(args.UrlTemplate))
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "Failed to compile UrlTemplate")
+	}
+	return &DeletedRecordReconciler{
+		args:        &args,
+		rawArgs:     rawArgs,
+		urlTemplate: tpl,
+	}, nil
+}
+
+// GetReconcileInterval returns the Interval of the args, or the DELETED_RECORDS_RECONCILE_INTERVAL
+func GetReconcileInterval(config interface{ GetConfig(string) string }, args *DeletedRecordReconcilerArgs) (time.Duration, errors.Error) {
+	if args.Interval > 0 {
+		return args.Interval, nil
+	}
+	interval, err := utils.StrToDurationOr(config.GetConfig("DELETED_RECORDS_RECONCILE_INTERVAL"), 0)
+	if err != nil {
+		return 0, errors.BadInput.Wrap(err, "failed to parse DELETED_RECORDS_RECONCILE_INTERVAL")
+	}
+	return interval, nil
+}
+
+// Execute lists the ids in the source and removes the tool layer records missing from them
+func (r *DeletedRecordReconciler) Execute() errors.Error {
+	logger := r.rawArgs.Ctx.GetLogger()
+	logger.Info("start reconciling deleted records")
+	sourceIds, err := r.listSourceIds()
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to list the ids in the source")
+	}
+	var toolIds []string
+	err = r.rawArgs.Ctx.GetDal().Pluck(r.args.ToolIdColumn, &toolIds, r.args.ToolClauses...)
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to list the ids in the tool layer")
+	}
+	// an empty list most likely means the api misbehaved rather than everything got deleted
+	if len(sourceIds) == 0 && len(toolIds) > 0 {
+		logger.Warn(nil, "no records were listed in the source while %d exist in the tool layer, skip reconciling", len(toolIds))
+		return nil
+	}
+	var deletedIds []string
+	for _, id := range toolIds {
+		if _, ok := sourceIds[id]; !ok {
+			deletedIds = append(deletedIds, id)
+		}
+	}
+	for start := 0; start < len(deletedIds); start += deleteBatchSize {
+		end := start + deleteBatchSize
+		if end > len(deletedIds) {
+			end = len(deletedIds)
+		}
+		err = r.args.DeleteRecords(deletedIds[start:end])
+		if err != nil {
+			return errors.Default.Wrap(err, "failed to delete the records deleted from the source")
+		}
+	}
+	logger.Info("end reconciling deleted records, %d of %d records were deleted from the source", len(deletedIds), len(toolIds))
+	return nil
+}
+
+// listSourceIds fetches all pages in order and collects the ids in them
+func (r *DeletedRecordReconciler) listSourceIds() (map[string]struct{}, errors.Error) {
+	ids := make(map[string]struct{})
+	var mu sync.Mutex
+	apiClient := r.args.ApiClient
+	reqData := &RequestData{
+		Pager: &Pager{
+			Page: 1,
+			Size: r.args.PageSize,
+		},
+		Params: r.params(),
+	}
+	var fetch func() errors.Error
+	fetch = func() errors.Error {
+		apiUrl, apiQuery, apiHeader, err := r.buildRequest(reqData)
+		if err != nil {
+			return err
+		}
+		apiClient.DoGetAsync(apiUrl, apiQuery, apiHeader, func(res *http.Response) errors.Error {
+			body, readErr := io.ReadAll(res.Body)
+			if readErr != nil {
+				return errors.Default.Wrap(readErr, fmt.Sprintf("error reading response from %s", apiUrl))
+			}
+			res.Body.Close()
+			res.Body = io.NopCloser(bytes.NewBuffer(body))
+			items, err := r.args.ResponseParser(res)
+			if err != nil {
+				return errors.Default.Wrap(err, fmt.Sprintf("error parsing response from %s", apiUrl))
+			}
+			mu.Lock()
+			for _, item := range items {
+				id, err := r.args.GetId(item)
+				if err != nil {
+					mu.Unlock()
+					return err
+				}
+				ids[id] = struct{}{}
+			}
+			mu.Unlock()
+			if len(items) == 0 {
+				return nil
+			}
+			res.Body = io.NopCloser(bytes.NewBuffer(body))
+			next, err := r.args.Pagination.NextPage(reqData, &PageResponse{
+				Response: res,
+				Body:     body,
+				Items:    items,
+			})
+			if err != nil {
+				if errors.Is(err, ErrFinishCollect) {
+					return nil
+				}
+				return err
+			}
+			reqData.CustomData = next
+			reqData.Pager.Skip += reqData.Pager.Size
+			reqData.Pager.Page += 1
+			apiClient.NextTick(fetch)
+			return nil
+		})
+		return nil
+	}
+	apiClient.NextTick(fetch)
+	err := apiClient.WaitAsync()
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (r *DeletedRecordReconciler) buildRequest(reqData *RequestData) (string, url.Values, http.Header, errors.Error) {
+	var buf bytes.Buffer
+	err := errors.Convert(r.urlTemplate.Execute(&buf, reqData))
+	if err != nil {
+		return "", nil, nil, err
+	}
+	apiQuery := url.Values{}
+	if r.args.Query != nil {
+		apiQuery, err = r.args.Query(reqData)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		if apiQuery == nil {
+			apiQuery = url.Values{}
+		}
+	}
+	r.args.Pagination.Query(reqData, apiQuery)
+	var apiHeader http.Header
+	if r.args.Header != nil {
+		apiHeader, err = r.args.Header(reqData)
+		if err != nil {
+			return "", nil, nil, err
+		}
+	}
+	return buf.String(), apiQuery, apiHeader, nil
+}
+
+func (r *DeletedRecordReconciler) params() interface{} {
+	if r.rawArgs.Options != nil {
+		return r.rawArgs.Options.GetParams()
+	}
+	return r.rawArgs.Params
+}
+
+var _ plugin.SubTask = (*DeletedRecordReconciler)(nil)
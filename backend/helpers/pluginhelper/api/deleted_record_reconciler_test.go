@@ -0,0 +1,160 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	mockapi "github.com/apache/incubator-devlake/mocks/helpers/pluginhelper/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newReconcilerTestApiClient(t *testing.T, pages map[string]string) *mockapi.RateLimitedApiClient {
+	mockApi := new(mockapi.RateLimitedApiClient)
+	mockApi.On("DoGetAsync", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		query := args.Get(1).(url.Values)
+		page := query.Get("page")
+		header := http.Header{}
+		if _, ok := pages[next(page)]; ok {
+			header.Set("Link", `<https://example.com/items?page=`+next(page)+`&per_page=2>; rel="next"`)
+		}
+		res := &http.Response{
+			Header:  header,
+			Request: &http.Request{URL: &url.URL{}},
+			Body:    io.NopCloser(bytes.NewBufferString(pages[page])),
+		}
+		handler := args.Get(3).(plugin.ApiAsyncCallback)
+		assert.Nil(t, handler(res))
+	})
+	mockApi.On("NextTick", mock.Anything).Run(func(args mock.Arguments) {
+		handler := args.Get(0).(func() errors.Error)
+		assert.Nil(t, handler())
+	})
+	mockApi.On("WaitAsync").Return(nil)
+	return mockApi
+}
+
+func next(page string) string {
+	if page == "" {
+		return "2"
+	}
+	return string(rune(page[0] + 1))
+}
+
+func newReconcilerTestArgs(mockApi RateLimitedApiClient, deleted *[]string) DeletedRecordReconcilerArgs {
+	return DeletedRecordReconcilerArgs{
+		ApiClient:      mockApi,
+		UrlTemplate:    "items",
+		PageSize:       2,
+		Pagination:     &LinkHeaderPagination{PageSizeParam: "per_page"},
+		ResponseParser: GetRawMessageArrayFromResponse,
+		GetId: func(item json.RawMessage) (string, errors.Error) {
+			var record struct {
+				Id json.Number `json:"id"`
+			}
+			err := errors.Convert(json.Unmarshal(item, &record))
+			return record.Id.String(), err
+		},
+		ToolIdColumn: "id",
+		DeleteRecords: func(ids []string) errors.Error {
+			*deleted = append(*deleted, ids...)
+			return nil
+		},
+	}
+}
+
+func TestDeletedRecordReconciler(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	mockDal.On("Pluck", "id", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(1).(*[]string) = []string{"1", "2", "3", "4", "5"}
+	}).Return(nil).Once()
+	mockCtx := unithelper.DummySubTaskContext(mockDal)
+	mockApi := newReconcilerTestApiClient(t, map[string]string{
+		"":  `[{"id":1},{"id":3}]`,
+		"2": `[{"id":5},{"id":6}]`,
+	})
+
+	var deleted []string
+	reconciler, err := NewDeletedRecordReconciler(
+		RawDataSubTaskArgs{Ctx: mockCtx, Table: "items"},
+		newReconcilerTestArgs(mockApi, &deleted),
+	)
+	assert.Nil(t, err)
+	assert.Nil(t, reconciler.Execute())
+	assert.Equal(t, []string{"2", "4"}, deleted)
+	mockApi.AssertNumberOfCalls(t, "DoGetAsync", 2)
+	mockDal.AssertExpectations(t)
+}
+
+func TestDeletedRecordReconcilerSkipsEmptySource(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	mockDal.On("Pluck", "id", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(1).(*[]string) = []string{"1", "2"}
+	}).Return(nil).Once()
+	mockCtx := unithelper.DummySubTaskContext(mockDal)
+	mockApi := newReconcilerTestApiClient(t, map[string]string{"": `[]`})
+
+	var deleted []string
+	reconciler, err := NewDeletedRecordReconciler(
+		RawDataSubTaskArgs{Ctx: mockCtx, Table: "items"},
+		newReconcilerTestArgs(mockApi, &deleted),
+	)
+	assert.Nil(t, err)
+	assert.Nil(t, reconciler.Execute())
+	assert.Empty(t, deleted)
+}
+
+func TestCollectorStateManagerShouldReconcile(t *testing.T) {
+	now := time.Now()
+	recent := now.Add(-time.Hour)
+	stale := now.Add(-48 * time.Hour)
+	for _, tc := range []struct {
+		name            string
+		incremental     bool
+		reconciled      *time.Time
+		interval        time.Duration
+		shouldReconcile bool
+	}{
+		{name: "disabled", incremental: true, interval: 0, shouldReconcile: false},
+		{name: "full sync", incremental: false, interval: 24 * time.Hour, shouldReconcile: false},
+		{name: "never reconciled", incremental: true, interval: 24 * time.Hour, shouldReconcile: true},
+		{name: "reconciled recently", incremental: true, reconciled: &recent, interval: 24 * time.Hour, shouldReconcile: false},
+		{name: "reconciled long ago", incremental: true, reconciled: &stale, interval: 24 * time.Hour, shouldReconcile: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			stateManager := &CollectorStateManager{
+				state:         &models.CollectorLatestState{LatestReconcileStart: tc.reconciled},
+				isIncremental: tc.incremental,
+				until:         &now,
+			}
+			assert.Equal(t, tc.shouldReconcile, stateManager.ShouldReconcile(tc.interval))
+		})
+	}
+}
@@ -54,6 +54,9 @@ func (connApi *DsConnectionApiHelper[C, S, SC]) GetMergedConnection(input *plugi
 		return nil, errors.BadInput.Wrap(err, "find connection from db")
 	}
 	if input.Body != nil {
+		if v, ok := interface{}(connection).(proxySanitizer); ok {
+			defer v.RestoreSanitizedProxy(v.GetProxy())
+		}
 		if err := DecodeMapStruct(input.Body, connection, false); err != nil {
 			return nil, err
 		}
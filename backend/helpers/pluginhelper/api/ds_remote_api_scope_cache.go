@@ -0,0 +1,99 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultRemoteScopesCacheTtl       = 10 * time.Minute
+	defaultRemoteScopesPrefetchPages  = 20
+	remoteScopesCachePurgeGracePeriod = time.Minute
+)
+
+// remoteScopesCache keeps the pages of remote scopes in memory for a while, listing the repos of a large GitHub
+// org or the boards of a Jira instance takes many slow requests and the config-ui asks for them over and over
+type remoteScopesCache struct {
+	mu       sync.Mutex
+	ttl      time.Duration
+	entries  map[string]*remoteScopesCacheEntry
+	purgedAt time.Time
+}
+
+type remoteScopesCacheEntry struct {
+	body      map[string]interface{}
+	expiresAt time.Time
+}
+
+func newRemoteScopesCache(ttl time.Duration) *remoteScopesCache {
+	return &remoteScopesCache{
+		ttl:      ttl,
+		entries:  make(map[string]*remoteScopesCacheEntry),
+		purgedAt: time.Now(),
+	}
+}
+
+func (c *remoteScopesCache) enabled() bool {
+	return c.ttl > 0
+}
+
+func (c *remoteScopesCache) get(key string) map[string]interface{} {
+	if !c.enabled() {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil
+	}
+	return entry.body
+}
+
+func (c *remoteScopesCache) set(key string, body map[string]interface{}) {
+	if !c.enabled() {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	// drop the expired pages every now and then so the connections nobody looks at don't pile up
+	if now.Sub(c.purgedAt) > remoteScopesCachePurgeGracePeriod {
+		for k, entry := range c.entries {
+			if now.After(entry.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+		c.purgedAt = now
+	}
+	c.entries[key] = &remoteScopesCacheEntry{body: body, expiresAt: now.Add(c.ttl)}
+}
+
+// purge removes all the pages with the given key prefix, i.e. of a connection
+func (c *remoteScopesCache) purge(prefix string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k := range c.entries {
+		if strings.HasPrefix(k, prefix) {
+			delete(c.entries, k)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api/models"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	"github.com/stretchr/testify/assert"
+)
+
+type testRemoteConnection struct {
+	BaseConnection
+	RestConnection
+}
+
+func (testRemoteConnection) TableName() string {
+	return "_tool_test_connections"
+}
+
+type testRemotePage struct {
+	Page int `json:"page"`
+}
+
+func newTestRemoteScopeListHelper(calls *[]string, prefetchPages int) *DsRemoteApiScopeListHelper[testRemoteConnection, TestFakeGithubRepo, testRemotePage] {
+	return &DsRemoteApiScopeListHelper[testRemoteConnection, TestFakeGithubRepo, testRemotePage]{
+		DsRemoteApiProxyHelper: &DsRemoteApiProxyHelper[testRemoteConnection]{logger: unithelper.DummyLogger()},
+		cache:                  newRemoteScopesCache(time.Minute),
+		prefetchPages:          prefetchPages,
+		listRemoteScopes: func(connection *testRemoteConnection, apiClient plugin.ApiClient, groupId string, page testRemotePage) (
+			[]models.DsRemoteApiScopeListEntry[TestFakeGithubRepo], *testRemotePage, errors.Error,
+		) {
+			*calls = append(*calls, groupId)
+			if groupId == "" && page.Page == 0 {
+				// two orgs on two pages
+				return []models.DsRemoteApiScopeListEntry[TestFakeGithubRepo]{
+					{Type: RAS_ENTRY_TYPE_GROUP, Id: "org1"},
+				}, &testRemotePage{Page: 1}, nil
+			}
+			if groupId == "" {
+				return []models.DsRemoteApiScopeListEntry[TestFakeGithubRepo]{
+					{Type: RAS_ENTRY_TYPE_GROUP, Id: "org2"},
+				}, nil, nil
+			}
+			return []models.DsRemoteApiScopeListEntry[TestFakeGithubRepo]{
+				{Type: RAS_ENTRY_TYPE_SCOPE, Id: groupId + "/repo"},
+			}, nil, nil
+		},
+	}
+}
+
+func TestRemoteScopesCache(t *testing.T) {
+	var calls []string
+	rsl := newTestRemoteScopeListHelper(&calls, 0)
+	connection := &testRemoteConnection{BaseConnection: BaseConnection{Model: common.Model{ID: 1}}}
+
+	body, err := rsl.list(connection, nil, "org1", "")
+	assert.Nil(t, err)
+	assert.Equal(t, []models.DsRemoteApiScopeListEntry[TestFakeGithubRepo]{{Type: RAS_ENTRY_TYPE_SCOPE, Id: "org1/repo"}}, body["children"])
+	cached, err := rsl.list(connection, nil, "org1", "")
+	assert.Nil(t, err)
+	assert.Equal(t, body, cached)
+	assert.Equal(t, []string{"org1"}, calls)
+
+	// the pages of the other connections are kept
+	rsl.cache.purge(rsl.connectionCachePrefix(&testRemoteConnection{BaseConnection: BaseConnection{Model: common.Model{ID: 11}}}))
+	_, _ = rsl.list(connection, nil, "org1", "")
+	assert.Equal(t, []string{"org1"}, calls)
+	rsl.cache.purge(rsl.connectionCachePrefix(connection))
+	_, _ = rsl.list(connection, nil, "org1", "")
+	assert.Equal(t, []string{"org1", "org1"}, calls)
+
+	// expired
+	rsl.cache.ttl = time.Nanosecond
+	rsl.cache.set("1:org1:", body)
+	time.Sleep(time.Millisecond)
+	assert.Nil(t, rsl.cache.get("1:org1:"))
+}
+
+func TestRemoteScopesPrefetch(t *testing.T) {
+	var calls []string
+	rsl := newTestRemoteScopeListHelper(&calls, 3)
+	connection := &testRemoteConnection{BaseConnection: BaseConnection{Model: common.Model{ID: 1}}}
+
+	assert.Nil(t, rsl.walk(connection, nil))
+	// breadth first: the 2 pages of orgs, then the repos of the first org
+	assert.Equal(t, []string{"", "", "org1"}, calls)
+
+	_, _ = rsl.list(connection, nil, "org1", "")
+	_, _ = rsl.list(connection, nil, "org2", "")
+	assert.Equal(t, []string{"", "", "org1", "org2"}, calls)
+}
@@ -20,9 +20,11 @@ package api
 import (
 	"encoding/base64"
 	"encoding/json"
+	"fmt"
 
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/core/utils"
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/api/models"
 )
 
@@ -37,9 +39,15 @@ type DsListRemoteScopes[C plugin.ToolLayerApiConnection, S plugin.ToolLayerScope
 
 // DsRemoteApiScopeListHelper is a helper to list scopes page by page on remote servers
 // P is the page info type
+// The pages are cached for REMOTE_SCOPES_CACHE_TTL (10m by default, 0 disables the cache), `refresh=true` or updating
+// the connection drops the cached pages of the connection. The first REMOTE_SCOPES_PREFETCH_PAGES pages (20 by default,
+// 0 disables the prefetch) are fetched in the background once a connection is created or updated, so the scope picker
+// shows up quickly
 type DsRemoteApiScopeListHelper[C plugin.ToolLayerApiConnection, S plugin.ToolLayerScope, P any] struct {
 	*DsRemoteApiProxyHelper[C]
 	listRemoteScopes DsListRemoteScopes[C, S, P]
+	cache            *remoteScopesCache
+	prefetchPages    int
 }
 
 // NewDsRemoteApiScopeListHelper creates a new DsRemoteApiScopeListHelper
@@ -51,10 +59,35 @@ func NewDsRemoteApiScopeListHelper[
 	rap *DsRemoteApiProxyHelper[C],
 	listRemoteScopes DsListRemoteScopes[C, S, P],
 ) *DsRemoteApiScopeListHelper[C, S, P] {
-	return &DsRemoteApiScopeListHelper[C, S, P]{
+	basicRes := rap.basicRes
+	ttl, err := utils.StrToDurationOr(basicRes.GetConfig("REMOTE_SCOPES_CACHE_TTL"), defaultRemoteScopesCacheTtl)
+	if err != nil {
+		rap.logger.Warn(err, "invalid REMOTE_SCOPES_CACHE_TTL, fallback to %s", defaultRemoteScopesCacheTtl)
+		ttl = defaultRemoteScopesCacheTtl
+	}
+	prefetchPages, err := utils.StrToIntOr(basicRes.GetConfig("REMOTE_SCOPES_PREFETCH_PAGES"), defaultRemoteScopesPrefetchPages)
+	if err != nil {
+		rap.logger.Warn(err, "invalid REMOTE_SCOPES_PREFETCH_PAGES, fallback to %d", defaultRemoteScopesPrefetchPages)
+		prefetchPages = defaultRemoteScopesPrefetchPages
+	}
+	rsl := &DsRemoteApiScopeListHelper[C, S, P]{
 		DsRemoteApiProxyHelper: rap,
 		listRemoteScopes:       listRemoteScopes,
+		cache:                  newRemoteScopesCache(ttl),
+		prefetchPages:          prefetchPages,
 	}
+	if rsl.cache.enabled() {
+		onChanged := func(connection *C) {
+			rsl.cache.purge(rsl.connectionCachePrefix(connection))
+			if rsl.prefetchPages > 0 {
+				c := *connection
+				go rsl.prefetch(&c)
+			}
+		}
+		rap.OnCreated(onChanged)
+		rap.OnUpdated(onChanged)
+	}
+	return rsl
 }
 
 // Get returns scopes on the data source
@@ -63,9 +96,25 @@ func (rsl *DsRemoteApiScopeListHelper[C, S, P]) Get(input *plugin.ApiResourceInp
 	if err != nil {
 		return nil, err
 	}
-	groupId := input.Query.Get("groupId")
+	if input.Query.Get("refresh") == "true" {
+		rsl.cache.purge(rsl.connectionCachePrefix(connection))
+	}
+	body, err := rsl.list(connection, apiClient, input.Query.Get("groupId"), input.Query.Get("pageToken"))
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{
+		Body: body,
+	}, nil
+}
+
+// list returns a page of the children of the group, from the cache if possible
+func (rsl *DsRemoteApiScopeListHelper[C, S, P]) list(connection *C, apiClient plugin.ApiClient, groupId, pageToken string) (map[string]interface{}, errors.Error) {
+	cacheKey := fmt.Sprintf("%s%s:%s", rsl.connectionCachePrefix(connection), groupId, pageToken)
+	if body := rsl.cache.get(cacheKey); body != nil {
+		return body, nil
+	}
 	pageInfo := new(P)
-	pageToken := input.Query.Get("pageToken")
 	// decode page token, we use pageToken because the pagination strategy varies from plugin to plugin
 	// some may use `page` and `size` while some may adopt `offset` and `limit`, even some may use `cursor`
 	if pageToken != "" {
@@ -88,10 +137,56 @@ func (rsl *DsRemoteApiScopeListHelper[C, S, P]) Get(input *plugin.ApiResourceInp
 	if children == nil {
 		children = []models.DsRemoteApiScopeListEntry[S]{}
 	}
-	return &plugin.ApiResourceOutput{
-		Body: map[string]interface{}{
-			"children":      children,
-			"nextPageToken": nextPageToken,
-		},
-	}, nil
+	body := map[string]interface{}{
+		"children":      children,
+		"nextPageToken": nextPageToken,
+	}
+	rsl.cache.set(cacheKey, body)
+	return body, nil
+}
+
+// prefetch caches the first pages of the remote scopes of the connection in the background
+func (rsl *DsRemoteApiScopeListHelper[C, S, P]) prefetch(connection *C) {
+	defer func() {
+		if r := recover(); r != nil {
+			rsl.logger.Warn(nil, "prefetching remote scopes of connection %d panicked: %v", (*connection).ConnectionId(), r)
+		}
+	}()
+	apiClient, err := rsl.getApiClient(connection)
+	if err == nil {
+		err = rsl.walk(connection, apiClient)
+	}
+	if err != nil {
+		rsl.logger.Warn(err, "failed to prefetch remote scopes of connection %d", (*connection).ConnectionId())
+		return
+	}
+	rsl.logger.Info("prefetched remote scopes of connection %d", (*connection).ConnectionId())
+}
+
+// walk lists the remote scopes tree breadth first up to prefetchPages pages
+func (rsl *DsRemoteApiScopeListHelper[C, S, P]) walk(connection *C, apiClient plugin.ApiClient) errors.Error {
+	type page struct{ groupId, pageToken string }
+	queue := []page{{}}
+	for fetched := 0; len(queue) > 0 && fetched < rsl.prefetchPages; fetched++ {
+		next := queue[0]
+		queue = queue[1:]
+		body, err := rsl.list(connection, apiClient, next.groupId, next.pageToken)
+		if err != nil {
+			return err
+		}
+		if nextPageToken := body["nextPageToken"].(string); nextPageToken != "" {
+			queue = append(queue, page{groupId: next.groupId, pageToken: nextPageToken})
+		}
+		for _, child := range body["children"].([]models.DsRemoteApiScopeListEntry[S]) {
+			if child.Type == RAS_ENTRY_TYPE_GROUP {
+				queue = append(queue, page{groupId: child.Id})
+			}
+		}
+	}
+	return nil
+}
+
+// connectionCachePrefix is the prefix of the cache keys of all the pages of the connection
+func (rsl *DsRemoteApiScopeListHelper[C, S, P]) connectionCachePrefix(connection *C) string {
+	return fmt.Sprintf("%d:", (*connection).ConnectionId())
 }
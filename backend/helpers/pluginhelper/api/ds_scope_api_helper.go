@@ -19,6 +19,8 @@ package api
 
 import (
 	"fmt"
+	"net/http"
+	"strconv"
 
 	"github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/errors"
@@ -35,11 +37,24 @@ type PutScopesReqBody[T any] struct {
 	Data []*T `json:"data"`
 }
 
+// PatchScopesReqBody attaches the scope config to many scopes at once
+type PatchScopesReqBody struct {
+	ScopeIds      []string `json:"scopeIds" mapstructure:"scopeIds"`
+	ScopeConfigId uint64   `json:"scopeConfigId" mapstructure:"scopeConfigId"`
+}
+
+// DeleteScopesReqBody deletes many scopes, or their data only, at once
+type DeleteScopesReqBody struct {
+	ScopeIds       []string `json:"scopeIds" mapstructure:"scopeIds"`
+	DeleteDataOnly bool     `json:"deleteDataOnly" mapstructure:"deleteDataOnly"`
+}
+
 type ScopeDetail[S plugin.ToolLayerScope, SC plugin.ToolLayerScopeConfig] srvhelper.ScopeDetail[S, SC]
 
 type DsScopeApiHelper[C plugin.ToolLayerConnection, S plugin.ToolLayerScope, SC plugin.ToolLayerScopeConfig] struct {
 	*ModelApiHelper[S]
 	*srvhelper.ScopeSrvHelper[C, S, SC]
+	jobs *srvhelper.BackgroundJobSrvHelper
 }
 
 func NewDsScopeApiHelper[
@@ -53,6 +68,7 @@ func NewDsScopeApiHelper[
 	return &DsScopeApiHelper[C, S, SC]{
 		ModelApiHelper: NewModelApiHelper[S](basicRes, srvHelper.ModelSrvHelper, []string{"connectionId", "scopeId"}, sterilizer),
 		ScopeSrvHelper: srvHelper,
+		jobs:           srvhelper.NewBackgroundJobSrvHelper(basicRes),
 	}
 }
 
@@ -118,16 +134,141 @@ func (scopeApi *DsScopeApiHelper[C, S, SC]) PutMultiple(input *plugin.ApiResourc
 		}
 		dict["connectionId"] = connectionId
 	}
-	return scopeApi.ModelApiHelper.PutMultipleCb(input, func(m *S) errors.Error {
-		ok := setRawDataOrigin(m, common.RawDataOrigin{
-			RawDataTable:  fmt.Sprintf("_raw_%s_scopes", scopeApi.GetPluginName()),
-			RawDataParams: plugin.MarshalScopeParams((*m).ScopeParams()),
-		})
-		if !ok {
-			panic("set raw data origin failed")
+	if input.Query.Get("async") == "true" {
+		return scopeApi.putMultipleAsync(connectionId, input)
+	}
+	return scopeApi.ModelApiHelper.PutMultipleCb(input, scopeApi.setRawDataOrigin)
+}
+
+// putMultipleAsync saves the scopes in a background job, so adding hundreds of scopes doesn't time out the request
+func (scopeApi *DsScopeApiHelper[C, S, SC]) putMultipleAsync(connectionId uint64, input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	var req PutScopesReqBody[S]
+	err := DecodeMapStruct(input.Body, &req, false)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, len(req.Data))
+	for i, scope := range req.Data {
+		keys[i] = (*scope).ScopeId()
+	}
+	job, err := scopeApi.jobs.Start(scopeApi.GetPluginName(), connectionId, "put-scopes", keys, func(i int) errors.Error {
+		scope := req.Data[i]
+		if err := scopeApi.setRawDataOrigin(scope); err != nil {
+			return err
+		}
+		return scopeApi.ScopeSrvHelper.CreateOrUpdate(scope)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{
+		Status: http.StatusAccepted,
+		Body:   job,
+	}, nil
+}
+
+// PatchMultiple attaches the scope config to the given scopes of the connection
+func (scopeApi *DsScopeApiHelper[C, S, SC]) PatchMultiple(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	connectionId, err := extractConnectionId(input)
+	if err != nil {
+		return nil, err
+	}
+	var req PatchScopesReqBody
+	err = DecodeMapStruct(input.Body, &req, false)
+	if err != nil {
+		return nil, err
+	}
+	err = scopeApi.ScopeSrvHelper.SetScopeConfig(connectionId, req.ScopeIds, req.ScopeConfigId)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{
+		Body: &req,
+	}, nil
+}
+
+// GetJob returns the progress of a background job of the connection
+func (scopeApi *DsScopeApiHelper[C, S, SC]) GetJob(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	connectionId, err := extractConnectionId(input)
+	if err != nil {
+		return nil, err
+	}
+	jobId, e := strconv.ParseUint(input.Params["jobId"], 10, 64)
+	if e != nil {
+		return nil, errors.BadInput.Wrap(e, "jobId must be a number")
+	}
+	job, err := scopeApi.jobs.Get(scopeApi.GetPluginName(), connectionId, jobId)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{
+		Body: job,
+	}, nil
+}
+
+// DeleteMultiple deletes the given scopes of the connection, or their data only, in a background job
+func (scopeApi *DsScopeApiHelper[C, S, SC]) DeleteMultiple(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	connectionId, err := extractConnectionId(input)
+	if err != nil {
+		return nil, err
+	}
+	var req DeleteScopesReqBody
+	err = DecodeMapStruct(input.Body, &req, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.ScopeIds) == 0 {
+		return nil, errors.BadInput.New("scopeIds is required")
+	}
+	return scopeApi.startDeletion(connectionId, req.ScopeIds, req.DeleteDataOnly)
+}
+
+// GetJobs returns the latest background jobs of the connection
+func (scopeApi *DsScopeApiHelper[C, S, SC]) GetJobs(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	connectionId, err := extractConnectionId(input)
+	if err != nil {
+		return nil, err
+	}
+	jobs, err := scopeApi.jobs.List(scopeApi.GetPluginName(), connectionId, 50)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{
+		Body: jobs,
+	}, nil
+}
+
+// startDeletion deletes the scopes in a background job, which waits for the running pipelines to finish
+func (scopeApi *DsScopeApiHelper[C, S, SC]) startDeletion(connectionId uint64, scopeIds []string, dataOnly bool) (*plugin.ApiResourceOutput, errors.Error) {
+	jobType := "delete-scopes"
+	if dataOnly {
+		jobType = "delete-scopes-data"
+	}
+	job, err := scopeApi.jobs.Start(scopeApi.GetPluginName(), connectionId, jobType, scopeIds, func(i int) errors.Error {
+		scope, err := scopeApi.ScopeSrvHelper.FindByPk(connectionId, scopeIds[i])
+		if err != nil {
+			return err
 		}
-		return nil
+		return scopeApi.ScopeSrvHelper.DeleteScopeWhenIdle(scope, dataOnly)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{
+		Status: http.StatusAccepted,
+		Body:   job,
+	}, nil
+}
+
+func (scopeApi *DsScopeApiHelper[C, S, SC]) setRawDataOrigin(m *S) errors.Error {
+	ok := setRawDataOrigin(m, common.RawDataOrigin{
+		RawDataTable:  fmt.Sprintf("_raw_%s_scopes", scopeApi.GetPluginName()),
+		RawDataParams: plugin.MarshalScopeParams((*m).ScopeParams()),
 	})
+	if !ok {
+		panic("set raw data origin failed")
+	}
+	return nil
 }
 
 func (scopeApi *DsScopeApiHelper[C, S, SC]) Delete(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
@@ -136,6 +277,11 @@ func (scopeApi *DsScopeApiHelper[C, S, SC]) Delete(input *plugin.ApiResourceInpu
 	if err != nil {
 		return nil, err
 	}
+	// deleting the data might take longer than the request is allowed to
+	if input.Query.Get("async") == "true" {
+		s := *scope
+		return scopeApi.startDeletion(s.ScopeConnectionId(), []string{s.ScopeId()}, input.Query.Get("delete_data_only") == "true")
+	}
 	// time.Sleep(1 * time.Minute) # uncomment this line if you were to verify pipelines get blocked while deleting data
 	// check referencing blueprints
 	refs, err := scopeApi.ScopeSrvHelper.DeleteScope(scope, input.Query.Get("delete_data_only") == "true")
@@ -18,6 +18,9 @@ limitations under the License.
 package api
 
 import (
+	"net/http"
+	"strings"
+
 	"github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/plugin"
@@ -76,6 +79,9 @@ func (connApi *DsScopeConfigApiHelper[C, S, SC]) Post(input *plugin.ApiResourceI
 		return nil, err
 	}
 	input.Body["connectionId"] = connectionId
+	if out, err = connApi.validateBody(input, false); err != nil {
+		return out, err
+	}
 	return connApi.ModelApiHelper.Post(input)
 }
 
@@ -86,9 +92,37 @@ func (connApi *DsScopeConfigApiHelper[C, S, SC]) Patch(input *plugin.ApiResource
 		return nil, err
 	}
 	input.Body["connectionId"] = connectionId
+	if out, err = connApi.validateBody(input, true); err != nil {
+		return out, err
+	}
 	return connApi.ModelApiHelper.Patch(input)
 }
 
+// GetSchema returns the json schema of the scope config
+func (connApi *DsScopeConfigApiHelper[C, S, SC]) GetSchema(input *plugin.ApiResourceInput) (out *plugin.ApiResourceOutput, err errors.Error) {
+	return &plugin.ApiResourceOutput{
+		Body: connApi.ScopeConfigSrvHelper.GetSchema(),
+	}, nil
+}
+
+// validateBody rejects the request with the list of violated fields if the body doesn't match the schema
+func (connApi *DsScopeConfigApiHelper[C, S, SC]) validateBody(input *plugin.ApiResourceInput, partial bool) (*plugin.ApiResourceOutput, errors.Error) {
+	violations := connApi.ScopeConfigSrvHelper.ValidateBody(input.Body, partial)
+	if len(violations) == 0 {
+		return nil, nil
+	}
+	messages := make([]string, len(violations))
+	for i, violation := range violations {
+		messages[i] = violation.Field + " " + violation.Message
+	}
+	err := errors.BadInput.New("invalid scope config: " + strings.Join(messages, "; "))
+	return &plugin.ApiResourceOutput{Body: &shared.ApiBody{
+		Success: false,
+		Message: err.Error(),
+		Data:    violations,
+	}, Status: http.StatusBadRequest}, err
+}
+
 func (connApi *DsScopeConfigApiHelper[C, S, SC]) Delete(input *plugin.ApiResourceInput) (out *plugin.ApiResourceOutput, err errors.Error) {
 	var scopeConfig *SC
 	scopeConfig, err = connApi.FindByPk(input)
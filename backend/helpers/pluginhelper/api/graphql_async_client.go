@@ -43,8 +43,10 @@ type GraphqlAsyncClient struct {
 	waitBeforeRetry  time.Duration
 	rateExhaustCond  *sync.Cond
 	rateRemaining    int
+	maxRateRemaining int
 	getRateRemaining func(context.Context, *graphql.Client, log.Logger) (rateRemaining int, resetAt *time.Time, err errors.Error)
 	getRateCost      func(q interface{}) int
+	estimateRateCost func(q interface{}, variables map[string]interface{}) int
 }
 
 // CreateAsyncGraphqlClient creates a new GraphqlAsyncClient
@@ -63,6 +65,7 @@ func CreateAsyncGraphqlClient(
 		rateExhaustCond:  sync.NewCond(&sync.Mutex{}),
 		rateRemaining:    0,
 		getRateRemaining: getRateRemaining,
+		estimateRateCost: EstimateGraphqlQueryCost,
 	}
 
 	if getRateRemaining != nil {
@@ -111,6 +114,9 @@ func (apiClient *GraphqlAsyncClient) SetMaxRetry(
 // updateRateRemaining call getRateRemaining to update rateRemaining periodically
 func (apiClient *GraphqlAsyncClient) updateRateRemaining(rateRemaining int, resetAt *time.Time) {
 	apiClient.rateRemaining = rateRemaining
+	if rateRemaining > apiClient.maxRateRemaining {
+		apiClient.maxRateRemaining = rateRemaining
+	}
 	if rateRemaining > 0 {
 		apiClient.rateExhaustCond.Signal()
 	}
@@ -134,11 +140,48 @@ func (apiClient *GraphqlAsyncClient) updateRateRemaining(rateRemaining int, rese
 }
 
 // SetGetRateCost to calculate how many rate cost
-// if not set, all query just cost 1
+// if not set, the cost is read from the `RateLimit { Cost }` field of the query, or 1 if the field doesn't exist
 func (apiClient *GraphqlAsyncClient) SetGetRateCost(getRateCost func(q interface{}) int) {
 	apiClient.getRateCost = getRateCost
 }
 
+// SetEstimateRateCost to estimate how many rate cost before sending the query, the query waits until the remaining
+// rate covers the estimation. if not set, EstimateGraphqlQueryCost is used
+func (apiClient *GraphqlAsyncClient) SetEstimateRateCost(estimateRateCost func(q interface{}, variables map[string]interface{}) int) {
+	apiClient.estimateRateCost = estimateRateCost
+}
+
+// estimateCost returns the estimated cost of the query, it never exceeds the largest remaining rate ever seen,
+// otherwise the query would wait forever
+func (apiClient *GraphqlAsyncClient) estimateCost(q interface{}, variables map[string]interface{}) int {
+	if apiClient.estimateRateCost == nil {
+		return 1
+	}
+	cost := apiClient.estimateRateCost(q, variables)
+	if apiClient.maxRateRemaining > 0 && cost > apiClient.maxRateRemaining {
+		cost = apiClient.maxRateRemaining
+	}
+	return cost
+}
+
+// updateRateCost deducts the cost of the finished query from the remaining rate, or takes the remaining rate
+// reported along with the response
+func (apiClient *GraphqlAsyncClient) updateRateCost(q interface{}, variables map[string]interface{}) {
+	rateLimit := parseGraphqlRateLimit(q)
+	cost := 1
+	if apiClient.getRateCost != nil {
+		cost = apiClient.getRateCost(q)
+	} else if rateLimit.hasCost {
+		cost = rateLimit.cost
+	}
+	if rateLimit.hasRemaining {
+		apiClient.rateRemaining = rateLimit.remaining
+	} else {
+		apiClient.rateRemaining -= cost
+	}
+	apiClient.logger.Debug(`query cost %d in %v`, cost, variables)
+}
+
 // Query send a graphql request when get lock
 // []graphql.DataError are the errors returned in response body
 // errors.Error is other error
@@ -150,8 +193,10 @@ func (apiClient *GraphqlAsyncClient) Query(q interface{}, variables map[string]i
 
 	apiClient.rateExhaustCond.L.Lock()
 	defer apiClient.rateExhaustCond.L.Unlock()
-	for apiClient.rateRemaining <= 0 {
-		apiClient.logger.Info(`rate limit remaining exhausted, waiting for next period.`)
+	estimatedCost := apiClient.estimateCost(q, variables)
+	for apiClient.rateRemaining <= 0 || apiClient.rateRemaining < estimatedCost {
+		apiClient.logger.Info(`rate limit remaining %d is not enough for the estimated cost %d, waiting for next period.`,
+			apiClient.rateRemaining, estimatedCost)
 		apiClient.rateExhaustCond.Wait()
 	}
 
@@ -168,21 +213,20 @@ func (apiClient *GraphqlAsyncClient) Query(q interface{}, variables map[string]i
 			if err == context.Canceled {
 				return nil, err
 			}
+			if isGraphqlComplexityError(err) {
+				// retrying won't help, the caller has to ask for less
+				return nil, errors.Default.Wrap(err, "graphql query is too complex")
+			}
 			if err != nil {
 				apiClient.logger.Warn(err, "retry #%d graphql calling after %ds", retryTime, apiClient.waitBeforeRetry/time.Second)
 				retryTime++
 				<-time.After(apiClient.waitBeforeRetry)
 				continue
 			}
+			apiClient.updateRateCost(q, variables)
 			if dataErrors != nil {
 				return dataErrors, nil
 			}
-			cost := 1
-			if apiClient.getRateCost != nil {
-				cost = apiClient.getRateCost(q)
-			}
-			apiClient.rateRemaining -= cost
-			apiClient.logger.Debug(`query cost %d in %v`, cost, variables)
 			return nil, nil
 		}
 	}
@@ -134,7 +134,7 @@ func (collector *GraphqlCollector) Execute() errors.Error {
 
 	// make sure table is created
 	db := collector.args.Ctx.GetDal()
-	err := db.AutoMigrate(&RawData{}, dal.From(collector.table))
+	err := ensureRawTable(db, logger, collector.table, collector.connectionId)
 	if err != nil {
 		return errors.Default.Wrap(err, "error running auto-migrate")
 	}
@@ -219,36 +219,92 @@ func (collector *GraphqlCollector) exec(input interface{}) {
 
 // fetchOneByOne fetches data of all pages for APIs that return paging information
 func (collector *GraphqlCollector) fetchOneByOne(reqData *GraphqlRequestData) {
-	// fetch first page
-	var fetchNextPage func(query interface{}) errors.Error
-	fetchNextPage = func(query interface{}) errors.Error {
-		pageInfo, err := collector.args.GetPageInfo(query, collector.args)
+	collector.fetchAsync(reqData, collector.fetchNextPage)
+}
+
+// fetchNextPage enqueues the page after the one requested by reqData, a page size shrunk by fetchAsync is kept
+func (collector *GraphqlCollector) fetchNextPage(reqData *GraphqlRequestData, query interface{}) errors.Error {
+	pageInfo, err := collector.args.GetPageInfo(query, collector.args)
+	if err != nil {
+		return errors.Default.Wrap(err, "fetchPagesDetermined get totalPages failed")
+	}
+	if pageInfo == nil {
+		return errors.Default.New("fetchPagesDetermined got pageInfo is nil")
+	}
+	if pageInfo.HasNextPage {
+		collector.args.GraphqlClient.NextTick(func() errors.Error {
+			reqDataTemp := &GraphqlRequestData{
+				Pager: &CursorPager{
+					SkipCursor: &pageInfo.EndCursor,
+					Size:       reqData.Pager.Size,
+				},
+				Input:     reqData.Input,
+				InputJSON: reqData.InputJSON,
+			}
+			collector.fetchAsync(reqDataTemp, collector.fetchNextPage)
+			return nil
+		}, collector.checkError)
+	}
+	return nil
+}
+
+// shrinkGraphqlRequest splits a request which is too complex for the server into smaller ones, it halves the page
+// size first and then the batch of inputs, nil is returned when the request can't be any smaller
+func shrinkGraphqlRequest(reqData *GraphqlRequestData) []*GraphqlRequestData {
+	if reqData.Pager != nil && reqData.Pager.Size > 1 {
+		return []*GraphqlRequestData{{
+			Pager: &CursorPager{
+				SkipCursor: reqData.Pager.SkipCursor,
+				Size:       reqData.Pager.Size / 2,
+			},
+			Params:    reqData.Params,
+			Input:     reqData.Input,
+			InputJSON: reqData.InputJSON,
+		}}
+	}
+	inputs, ok := reqData.Input.([]interface{})
+	if !ok || len(inputs) < 2 {
+		return nil
+	}
+	half := len(inputs) / 2
+	var shrunk []*GraphqlRequestData
+	for _, part := range [][]interface{}{inputs[:half], inputs[half:]} {
+		inputJson, err := json.Marshal(part)
 		if err != nil {
-			return errors.Default.Wrap(err, "fetchPagesDetermined get totalPages failed")
-		}
-		if pageInfo == nil {
-			return errors.Default.New("fetchPagesDetermined got pageInfo is nil")
+			return nil
 		}
-		if pageInfo.HasNextPage {
-			collector.args.GraphqlClient.NextTick(func() errors.Error {
-				reqDataTemp := &GraphqlRequestData{
-					Pager: &CursorPager{
-						SkipCursor: &pageInfo.EndCursor,
-						Size:       collector.args.PageSize,
-					},
-					Input:     reqData.Input,
-					InputJSON: reqData.InputJSON,
-				}
-				collector.fetchAsync(reqDataTemp, fetchNextPage)
-				return nil
-			}, collector.checkError)
+		var pager *CursorPager
+		if reqData.Pager != nil {
+			pager = &CursorPager{SkipCursor: reqData.Pager.SkipCursor, Size: reqData.Pager.Size}
 		}
-		return nil
+		shrunk = append(shrunk, &GraphqlRequestData{
+			Pager:     pager,
+			Params:    reqData.Params,
+			Input:     part,
+			InputJSON: inputJson,
+		})
 	}
-	collector.fetchAsync(reqData, fetchNextPage)
+	return shrunk
 }
 
-func (collector *GraphqlCollector) fetchAsync(reqData *GraphqlRequestData, handler func(query interface{}) errors.Error) {
+// retrySmaller refetches the data of reqData by smaller requests if the server complained about the complexity
+func (collector *GraphqlCollector) retrySmaller(
+	reqData *GraphqlRequestData,
+	handler func(reqData *GraphqlRequestData, query interface{}) errors.Error,
+	cause error,
+) bool {
+	shrunk := shrinkGraphqlRequest(reqData)
+	if shrunk == nil {
+		return false
+	}
+	collector.args.Ctx.GetLogger().Warn(cause, "graphql query is too complex, retry with %d smaller queries", len(shrunk))
+	for _, smaller := range shrunk {
+		collector.fetchAsync(smaller, handler)
+	}
+	return true
+}
+
+func (collector *GraphqlCollector) fetchAsync(reqData *GraphqlRequestData, handler func(reqData *GraphqlRequestData, query interface{}) errors.Error) {
 	if reqData.Pager == nil {
 		reqData.Pager = &CursorPager{
 			SkipCursor: nil,
@@ -264,6 +320,14 @@ func (collector *GraphqlCollector) fetchAsync(reqData *GraphqlRequestData, handl
 	logger := collector.args.Ctx.GetLogger()
 	db := collector.args.Ctx.GetDal()
 	dataErrors, err := collector.args.GraphqlClient.Query(query, variables)
+	if isGraphqlComplexityError(err) && collector.retrySmaller(reqData, handler, err) {
+		return
+	}
+	for _, dataError := range dataErrors {
+		if isGraphqlComplexityError(dataError) && collector.retrySmaller(reqData, handler, dataError) {
+			return
+		}
+	}
 	if err != nil {
 		if err == context.Canceled {
 			// direct error message for error combine
@@ -293,11 +357,17 @@ func (collector *GraphqlCollector) fetchAsync(reqData *GraphqlRequestData, handl
 
 	results, err := collector.args.ResponseParser(query)
 	for _, result := range results {
+		data, compressErr := compressRawData(collector.compression, result)
+		if compressErr != nil {
+			collector.checkError(compressErr)
+			return
+		}
 		row := &RawData{
-			Params: collector.params,
-			Data:   result,
-			Url:    queryStr,
-			Input:  variablesJson,
+			Params:       collector.params,
+			ConnectionId: collector.connectionId,
+			Data:         data,
+			Url:          queryStr,
+			Input:        variablesJson,
 		}
 		// collector.batchSave.Add(row)
 		err = db.Create(row, dal.From(collector.table))
@@ -319,7 +389,7 @@ func (collector *GraphqlCollector) fetchAsync(reqData *GraphqlRequestData, handl
 	collector.args.Ctx.IncProgress(1)
 	if handler != nil {
 		// trigger next fetch, but return if ErrFinishCollect got from ResponseParser
-		err = handler(query)
+		err = handler(reqData, query)
 		if err != nil {
 			collector.checkError(errors.Default.Wrap(err, `handle failed in graphql collector`))
 			return
@@ -0,0 +1,170 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// graphqlComplexityMessages are the fragments of error messages returned by graphql services when a query
+// asks for too many nodes or takes too long to resolve, both can be fixed by asking for less in one query
+var graphqlComplexityMessages = []string{
+	"MAX_NODE_LIMIT_EXCEEDED",
+	"RESOURCE_LIMITS_EXCEEDED",
+	"exceeds the maximum limit",
+	"resource limits for this query exceeded",
+	"query has complexity",
+	"may be the result of a timeout",
+}
+
+var graphqlConnectionSizePattern = regexp.MustCompile(`\b(?:first|last)\s*:\s*(\$?\w+)`)
+
+var graphqlJsonUnmarshaler = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// graphqlRateLimit is the rate limit information a query wrapper received along with its data
+type graphqlRateLimit struct {
+	cost         int
+	hasCost      bool
+	remaining    int
+	hasRemaining bool
+	resetAt      *time.Time
+}
+
+// isGraphqlComplexityError tells whether the err was caused by the query being too expensive to resolve
+func isGraphqlComplexityError(err error) bool {
+	if err == nil {
+		return false
+	}
+	message := strings.ToLower(err.Error())
+	for _, fragment := range graphqlComplexityMessages {
+		if strings.Contains(message, strings.ToLower(fragment)) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseGraphqlRateLimit reads the `RateLimit { Cost Remaining ResetAt }` field of the query wrapper if it was requested
+func parseGraphqlRateLimit(q interface{}) graphqlRateLimit {
+	result := graphqlRateLimit{}
+	v := reflect.Indirect(reflect.ValueOf(q))
+	if v.Kind() != reflect.Struct {
+		return result
+	}
+	rateLimit := reflect.Indirect(v.FieldByName(`RateLimit`))
+	if rateLimit.Kind() != reflect.Struct {
+		return result
+	}
+	result.cost, result.hasCost = graphqlIntValue(rateLimit.FieldByName(`Cost`))
+	result.remaining, result.hasRemaining = graphqlIntValue(rateLimit.FieldByName(`Remaining`))
+	resetAt := reflect.Indirect(rateLimit.FieldByName(`ResetAt`))
+	if resetAt.IsValid() {
+		if t, ok := resetAt.Interface().(time.Time); ok && !t.IsZero() {
+			result.resetAt = &t
+		}
+	}
+	return result
+}
+
+// EstimateGraphqlQueryCost estimates the rate limit points the query would cost before sending it, following the
+// way GitHub calculates it: every connection costs as many requests as its parents could return nodes, the sum
+// of all connections divided by 100 is the cost, and any query costs at least 1 point.
+// Connections are recognized by the `first` or `last` argument in the graphql tag of the query struct.
+func EstimateGraphqlQueryCost(q interface{}, variables map[string]interface{}) int {
+	requests := 0
+	estimateGraphqlRequests(reflect.TypeOf(q), variables, 1, &requests)
+	return int(math.Max(1, math.Round(float64(requests)/100)))
+}
+
+func estimateGraphqlRequests(t reflect.Type, variables map[string]interface{}, multiplier int, requests *int) {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || reflect.PtrTo(t).Implements(graphqlJsonUnmarshaler) {
+		return
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("graphql")
+		if f.Tag.Get("graphql-extend") == "true" {
+			// the field is repeated once for every item of the variable named after it
+			name := tag
+			if index := strings.IndexAny(name, `(:[$!@`); index != -1 {
+				name = name[:index]
+			}
+			items, _ := variables[name].([]map[string]interface{})
+			for _, item := range items {
+				merged := make(map[string]interface{}, len(variables)+len(item))
+				for k, v := range variables {
+					merged[k] = v
+				}
+				for k, v := range item {
+					merged[k] = v
+				}
+				estimateGraphqlField(f, tag, merged, multiplier, requests)
+			}
+			continue
+		}
+		estimateGraphqlField(f, tag, variables, multiplier, requests)
+	}
+}
+
+func estimateGraphqlField(f reflect.StructField, tag string, variables map[string]interface{}, multiplier int, requests *int) {
+	if match := graphqlConnectionSizePattern.FindStringSubmatch(tag); match != nil {
+		if size := graphqlArgumentSize(match[1], variables); size > 0 {
+			*requests += multiplier
+			multiplier *= size
+		}
+	}
+	estimateGraphqlRequests(f.Type, variables, multiplier, requests)
+}
+
+// graphqlArgumentSize resolves a literal or a $variable argument to an int, it returns 0 when it is unknown
+func graphqlArgumentSize(arg string, variables map[string]interface{}) int {
+	if !strings.HasPrefix(arg, "$") {
+		var size int
+		if err := json.Unmarshal([]byte(arg), &size); err != nil {
+			return 0
+		}
+		return size
+	}
+	value, ok := variables[strings.TrimPrefix(arg, "$")]
+	if !ok || value == nil {
+		return 0
+	}
+	size, _ := graphqlIntValue(reflect.ValueOf(value))
+	return size
+}
+
+func graphqlIntValue(v reflect.Value) (int, bool) {
+	v = reflect.Indirect(v)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return int(v.Float()), true
+	}
+	return 0, false
+}
@@ -0,0 +1,134 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/merico-dev/graphql"
+	"github.com/stretchr/testify/assert"
+)
+
+type testGraphqlIssueQuery struct {
+	RateLimit struct {
+		Cost      int
+		Remaining int
+		ResetAt   time.Time
+	}
+	Repository struct {
+		Issues struct {
+			Nodes []struct {
+				Title  string
+				Labels struct {
+					Nodes []struct{ Name string }
+				} `graphql:"labels(first: 100)"`
+				Comments struct {
+					Nodes []struct{ Body string }
+				} `graphql:"comments(last: $commentSize)"`
+			}
+		} `graphql:"issues(first: $pageSize, after: $skipCursor)"`
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
+type testGraphqlUserQuery struct {
+	Users []struct {
+		Login         string
+		Organizations struct {
+			Nodes []struct{ Login string }
+		} `graphql:"organizations(first: 10)"`
+	} `graphql:"user(login: $login)" graphql-extend:"true"`
+}
+
+func TestEstimateGraphqlQueryCost(t *testing.T) {
+	// 1 request for issues, 50 requests for labels and comments of every issue
+	cost := EstimateGraphqlQueryCost(&testGraphqlIssueQuery{}, map[string]interface{}{
+		"pageSize":    graphql.Int(50),
+		"commentSize": 10,
+		"skipCursor":  (*graphql.String)(nil),
+	})
+	assert.Equal(t, 1, cost)
+
+	cost = EstimateGraphqlQueryCost(&testGraphqlIssueQuery{}, map[string]interface{}{
+		"pageSize":    graphql.Int(100),
+		"commentSize": 100,
+	})
+	assert.Equal(t, 2, cost)
+
+	// unknown connection sizes are not counted
+	assert.Equal(t, 1, EstimateGraphqlQueryCost(&testGraphqlIssueQuery{}, nil))
+
+	users := []map[string]interface{}{}
+	for i := 0; i < 300; i++ {
+		users = append(users, map[string]interface{}{"login": graphql.String(fmt.Sprintf("user%d", i))})
+	}
+	assert.Equal(t, 3, EstimateGraphqlQueryCost(&testGraphqlUserQuery{}, map[string]interface{}{"user": users}))
+}
+
+func TestParseGraphqlRateLimit(t *testing.T) {
+	query := &testGraphqlIssueQuery{}
+	query.RateLimit.Cost = 3
+	query.RateLimit.Remaining = 4000
+	query.RateLimit.ResetAt = time.Date(2026, 10, 15, 18, 0, 0, 0, time.UTC)
+	rateLimit := parseGraphqlRateLimit(query)
+	assert.True(t, rateLimit.hasCost)
+	assert.Equal(t, 3, rateLimit.cost)
+	assert.True(t, rateLimit.hasRemaining)
+	assert.Equal(t, 4000, rateLimit.remaining)
+	assert.Equal(t, query.RateLimit.ResetAt, *rateLimit.resetAt)
+
+	rateLimit = parseGraphqlRateLimit(&testGraphqlUserQuery{})
+	assert.False(t, rateLimit.hasCost)
+	assert.False(t, rateLimit.hasRemaining)
+}
+
+func TestIsGraphqlComplexityError(t *testing.T) {
+	assert.True(t, isGraphqlComplexityError(graphql.DataError{
+		Message: "By the time this query traverses to the comments connection, it is requesting up to 1,000,000 possible nodes which exceeds the maximum limit of 500,000.",
+	}))
+	assert.True(t, isGraphqlComplexityError(fmt.Errorf("non-200 OK status code: 502 Bad Gateway body: %q",
+		"Something went wrong while executing your query. This may be the result of a timeout, or it could be a GitHub bug.")))
+	assert.False(t, isGraphqlComplexityError(graphql.DataError{Message: "Could not resolve to a Repository with the name 'foo'."}))
+	assert.False(t, isGraphqlComplexityError(nil))
+}
+
+func TestShrinkGraphqlRequest(t *testing.T) {
+	cursor := "abc"
+	shrunk := shrinkGraphqlRequest(&GraphqlRequestData{
+		Pager: &CursorPager{SkipCursor: &cursor, Size: 10},
+		Input: "input",
+	})
+	assert.Len(t, shrunk, 1)
+	assert.Equal(t, 5, shrunk[0].Pager.Size)
+	assert.Equal(t, &cursor, shrunk[0].Pager.SkipCursor)
+	assert.Equal(t, "input", shrunk[0].Input)
+
+	shrunk = shrinkGraphqlRequest(&GraphqlRequestData{
+		Pager: &CursorPager{Size: 1},
+		Input: []interface{}{1, 2, 3},
+	})
+	assert.Len(t, shrunk, 2)
+	assert.Equal(t, []interface{}{1}, shrunk[0].Input)
+	assert.Equal(t, []byte("[1]"), shrunk[0].InputJSON)
+	assert.Equal(t, []interface{}{2, 3}, shrunk[1].Input)
+	assert.Equal(t, []byte("[2,3]"), shrunk[1].InputJSON)
+
+	assert.Nil(t, shrinkGraphqlRequest(&GraphqlRequestData{Pager: &CursorPager{Size: 1}, Input: []interface{}{1}}))
+	assert.Nil(t, shrinkGraphqlRequest(&GraphqlRequestData{Pager: &CursorPager{}}))
+}
@@ -40,6 +40,8 @@ type ModelApiHelper[M dal.Tabler] struct {
 	modelName      string
 	pkPathVarNames []string
 	sterilizers    []func(m M) M
+	createdHooks   []func(m *M)
+	updatedHooks   []func(m *M)
 }
 
 func NewModelApiHelper[M dal.Tabler](
@@ -73,6 +75,9 @@ func (self *ModelApiHelper[M]) Post(input *plugin.ApiResourceInput) (*plugin.Api
 	if err != nil {
 		return nil, err
 	}
+	for _, hook := range self.createdHooks {
+		hook(model)
+	}
 	model = self.Sanitize(model)
 	return &plugin.ApiResourceOutput{
 		Status: http.StatusCreated,
@@ -80,6 +85,16 @@ func (self *ModelApiHelper[M]) Post(input *plugin.ApiResourceInput) (*plugin.Api
 	}, nil
 }
 
+// OnCreated registers a hook to be called after a model is created by Post
+func (self *ModelApiHelper[M]) OnCreated(hook func(m *M)) {
+	self.createdHooks = append(self.createdHooks, hook)
+}
+
+// OnUpdated registers a hook to be called after a model is updated by Patch
+func (self *ModelApiHelper[M]) OnUpdated(hook func(m *M)) {
+	self.updatedHooks = append(self.updatedHooks, hook)
+}
+
 func (self *ModelApiHelper[M]) ExtractPkValues(input *plugin.ApiResourceInput) ([]interface{}, errors.Error) {
 	pkv := make([]interface{}, len(self.pkPathVarNames))
 	for i, pkn := range self.pkPathVarNames {
@@ -111,6 +126,14 @@ func (self *ModelApiHelper[M]) GetDetail(input *plugin.ApiResourceInput) (*plugi
 	}, nil
 }
 
+// proxySanitizer is implemented by the connections embedding RestConnection, the password of their proxy is masked in
+// the responses and kept when it is sent back masked
+type proxySanitizer interface {
+	GetProxy() string
+	SanitizeProxy()
+	RestoreSanitizedProxy(existed string)
+}
+
 func (self *ModelApiHelper[M]) Sanitize(model *M) *M {
 	if self.sterilizers != nil {
 		for _, sterilizer := range self.sterilizers {
@@ -118,6 +141,11 @@ func (self *ModelApiHelper[M]) Sanitize(model *M) *M {
 			model = &sanitizedModel
 		}
 	}
+	if _, ok := interface{}(model).(proxySanitizer); ok {
+		sanitizedModel := *model
+		model = &sanitizedModel
+		interface{}(model).(proxySanitizer).SanitizeProxy()
+	}
 	return model
 }
 
@@ -140,6 +168,9 @@ func (self *ModelApiHelper[M]) PatchModel(input *plugin.ApiResourceInput, zeroFi
 	if err != nil {
 		return nil, err
 	}
+	if v, ok := interface{}(model).(proxySanitizer); ok {
+		defer v.RestoreSanitizedProxy(v.GetProxy())
+	}
 	if v, ok := (interface{}(model)).(CustomMerge[M]); ok {
 		if err := v.MergeFromRequest(model, input.Body); err != nil {
 			return nil, err
@@ -161,6 +192,9 @@ func (self *ModelApiHelper[M]) Patch(input *plugin.ApiResourceInput) (*plugin.Ap
 	if err := self.dalHelper.Update(model); err != nil {
 		return nil, err
 	}
+	for _, hook := range self.updatedHooks {
+		hook(model)
+	}
 	model = self.Sanitize(model)
 	return &plugin.ApiResourceOutput{
 		Body: model,
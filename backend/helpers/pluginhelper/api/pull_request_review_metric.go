@@ -0,0 +1,117 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+const reviewApproved = "APPROVED"
+
+// ComputePullRequestReviewMetric derives the review figures of a pull request from its comments, which must be
+// sorted by created date, and its requested reviewers. Comments made by the pull request author are not reviews,
+// but they end the current review round: a round is a run of reviewer activity answered by the author.
+func ComputePullRequestReviewMetric(
+	pr *code.PullRequest,
+	comments []*code.PullRequestComment,
+	reviewers []*code.PullRequestReviewer,
+) *code.PullRequestReviewMetric {
+	metric := &code.PullRequestReviewMetric{PullRequestId: pr.Id}
+	reviewerIds := make(map[string]bool)
+	for _, reviewer := range reviewers {
+		if reviewer.ReviewerId != "" && reviewer.ReviewerId != pr.AuthorId {
+			reviewerIds[reviewer.ReviewerId] = true
+		}
+	}
+	inRound := false
+	for _, comment := range comments {
+		if comment.AccountId == pr.AuthorId {
+			inRound = false
+			continue
+		}
+		if metric.FirstReviewDate == nil {
+			createdDate := comment.CreatedDate
+			metric.FirstReviewDate = &createdDate
+		}
+		if metric.ApprovedDate == nil && comment.Type == code.REVIEW && comment.Status == reviewApproved {
+			createdDate := comment.CreatedDate
+			metric.ApprovedDate = &createdDate
+		}
+		if !inRound {
+			metric.ReviewRoundCount++
+			inRound = true
+		}
+		if comment.AccountId != "" {
+			reviewerIds[comment.AccountId] = true
+		}
+	}
+	metric.ReviewerCount = len(reviewerIds)
+	return metric
+}
+
+// CalculatePullRequestReviewMetrics refreshes pull_request_review_metrics for the pull requests of the given
+// domain repo, it is meant to be called by a subtask running after comments and reviewers are converted
+func CalculatePullRequestReviewMetrics(taskCtx plugin.SubTaskContext, repoId string) errors.Error {
+	db := taskCtx.GetDal()
+	err := db.Delete(
+		&code.PullRequestReviewMetric{},
+		dal.Where("pull_request_id IN (SELECT id FROM pull_requests WHERE base_repo_id = ?)", repoId),
+	)
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to delete previous pull_request_review_metrics")
+	}
+
+	cursor, err := db.Cursor(
+		dal.From(&code.PullRequest{}),
+		dal.Where("base_repo_id = ?", repoId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	batchSave, err := NewBatchSave(taskCtx, reflect.TypeOf(&code.PullRequestReviewMetric{}), 500)
+	if err != nil {
+		return err
+	}
+	for cursor.Next() {
+		pr := &code.PullRequest{}
+		if err = db.Fetch(cursor, pr); err != nil {
+			return err
+		}
+		var comments []*code.PullRequestComment
+		err = db.All(&comments, dal.Where("pull_request_id = ?", pr.Id), dal.Orderby("created_date ASC"))
+		if err != nil {
+			return err
+		}
+		var reviewers []*code.PullRequestReviewer
+		err = db.All(&reviewers, dal.Where("pull_request_id = ?", pr.Id))
+		if err != nil {
+			return err
+		}
+		if err = batchSave.Add(ComputePullRequestReviewMetric(pr, comments, reviewers)); err != nil {
+			return err
+		}
+	}
+	return batchSave.Close()
+}
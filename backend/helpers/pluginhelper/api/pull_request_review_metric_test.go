@@ -0,0 +1,86 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputePullRequestReviewMetric(t *testing.T) {
+	base := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	at := func(hours int) *time.Time {
+		date := base.Add(time.Duration(hours) * time.Hour)
+		return &date
+	}
+	comment := func(accountId string, commentType string, status string, hours int) *code.PullRequestComment {
+		return &code.PullRequestComment{AccountId: accountId, Type: commentType, Status: status, CreatedDate: *at(hours)}
+	}
+	pr := &code.PullRequest{DomainEntity: domainlayer.DomainEntity{Id: "pr:1"}, AuthorId: "author"}
+	tests := []struct {
+		name      string
+		comments  []*code.PullRequestComment
+		reviewers []*code.PullRequestReviewer
+		want      *code.PullRequestReviewMetric
+	}{
+		{
+			name:     "no review",
+			comments: []*code.PullRequestComment{comment("author", code.NORMAL_COMMENT, "", 0)},
+			want:     &code.PullRequestReviewMetric{PullRequestId: "pr:1"},
+		},
+		{
+			name: "rounds are ended by the author",
+			comments: []*code.PullRequestComment{
+				comment("author", code.NORMAL_COMMENT, "", 0),
+				comment("alice", code.REVIEW, "CHANGES_REQUESTED", 1),
+				comment("bob", code.NORMAL_COMMENT, "", 2),
+				comment("author", code.NORMAL_COMMENT, "", 3),
+				comment("alice", code.REVIEW, "APPROVED", 4),
+			},
+			want: &code.PullRequestReviewMetric{PullRequestId: "pr:1", FirstReviewDate: at(1), ApprovedDate: at(4), ReviewRoundCount: 2, ReviewerCount: 2},
+		},
+		{
+			name: "approved by the first approval only",
+			comments: []*code.PullRequestComment{
+				comment("alice", code.NORMAL_COMMENT, "APPROVED", 1),
+				comment("alice", code.REVIEW, "APPROVED", 2),
+				comment("bob", code.REVIEW, "APPROVED", 3),
+			},
+			want: &code.PullRequestReviewMetric{PullRequestId: "pr:1", FirstReviewDate: at(1), ApprovedDate: at(2), ReviewRoundCount: 1, ReviewerCount: 2},
+		},
+		{
+			name:     "requested reviewers count without commenting, the author doesn't",
+			comments: []*code.PullRequestComment{comment("alice", code.REVIEW, "COMMENTED", 1)},
+			reviewers: []*code.PullRequestReviewer{
+				{PullRequestId: "pr:1", ReviewerId: "alice"},
+				{PullRequestId: "pr:1", ReviewerId: "carol"},
+				{PullRequestId: "pr:1", ReviewerId: "author"},
+			},
+			want: &code.PullRequestReviewMetric{PullRequestId: "pr:1", FirstReviewDate: at(1), ReviewRoundCount: 1, ReviewerCount: 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ComputePullRequestReviewMetric(pr, tt.comments, tt.reviewers))
+		})
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/core/utils"
+)
+
+// PullRequestPathRules holds the glob patterns flagging the files a pull request touches as risky
+type PullRequestPathRules struct {
+	MigrationPaths []string
+	InfraPaths     []string
+}
+
+// ClassifyPullRequest fills the size bucket, the number of files touched and the migration/infra flags of a
+// pull request, filePaths are the distinct files changed by its commits
+func ClassifyPullRequest(pr *code.PullRequest, filePaths []string, migration, infra *utils.PathFilter) {
+	pr.SizeBucket = code.ClassifyPullRequestSize(pr.Additions + pr.Deletions)
+	pr.ChangedFiles = len(filePaths)
+	pr.IncludesMigration = false
+	pr.IncludesInfra = false
+	for _, filePath := range filePaths {
+		if migration != nil && migration.Match(filePath) {
+			pr.IncludesMigration = true
+		}
+		if infra != nil && infra.Match(filePath) {
+			pr.IncludesInfra = true
+		}
+	}
+}
+
+// ClassifyPullRequests classifies the pull requests of the given domain repo by size and risk. Files are read from
+// the commit_files of the pull request commits, so the file based figures are only available once the repo has
+// been cloned by gitextractor.
+func ClassifyPullRequests(taskCtx plugin.SubTaskContext, repoId string, rules PullRequestPathRules) errors.Error {
+	db := taskCtx.GetDal()
+	migration, err := utils.NewPathFilter(rules.MigrationPaths, nil)
+	if err != nil {
+		return err
+	}
+	infra, err := utils.NewPathFilter(rules.InfraPaths, nil)
+	if err != nil {
+		return err
+	}
+
+	cursor, err := db.Cursor(
+		dal.From(&code.PullRequest{}),
+		dal.Where("base_repo_id = ?", repoId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	// the files are looked up for a batch of pull requests at once
+	var batch []*code.PullRequest
+	for cursor.Next() {
+		pr := &code.PullRequest{}
+		if err = db.Fetch(cursor, pr); err != nil {
+			return err
+		}
+		batch = append(batch, pr)
+		if len(batch) == classifyPullRequestsBatchSize {
+			if err = classifyPullRequestBatch(db, batch, migration, infra); err != nil {
+				return err
+			}
+			batch = nil
+		}
+	}
+	return classifyPullRequestBatch(db, batch, migration, infra)
+}
+
+const classifyPullRequestsBatchSize = 500
+
+func classifyPullRequestBatch(db dal.Dal, batch []*code.PullRequest, migration, infra *utils.PathFilter) errors.Error {
+	if len(batch) == 0 {
+		return nil
+	}
+	prIds := make([]string, len(batch))
+	for i, pr := range batch {
+		prIds[i] = pr.Id
+	}
+	var prFiles []struct {
+		PullRequestId string
+		FilePath      string
+	}
+	err := db.All(&prFiles,
+		dal.Select("DISTINCT prc.pull_request_id, cf.file_path"),
+		dal.From("commit_files cf"),
+		dal.Join("JOIN pull_request_commits prc ON prc.commit_sha = cf.commit_sha"),
+		dal.Where("prc.pull_request_id IN ?", prIds),
+	)
+	if err != nil {
+		return err
+	}
+	filePaths := make(map[string][]string, len(batch))
+	for _, prFile := range prFiles {
+		filePaths[prFile.PullRequestId] = append(filePaths[prFile.PullRequestId], prFile.FilePath)
+	}
+	for _, pr := range batch {
+		ClassifyPullRequest(pr, filePaths[pr.Id], migration, infra)
+		err = db.UpdateColumns(&code.PullRequest{}, []dal.DalSet{
+			{ColumnName: "size_bucket", Value: pr.SizeBucket},
+			{ColumnName: "changed_files", Value: pr.ChangedFiles},
+			{ColumnName: "includes_migration", Value: pr.IncludesMigration},
+			{ColumnName: "includes_infra", Value: pr.IncludesInfra},
+		}, dal.Where("id = ?", pr.Id))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
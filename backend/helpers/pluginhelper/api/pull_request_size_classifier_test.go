@@ -0,0 +1,92 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/utils"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestClassifyPullRequest(t *testing.T) {
+	migration, err := utils.NewPathFilter([]string{"migrations/", "**/migrationscripts/*.go"}, nil)
+	assert.Nil(t, err)
+	infra, err := utils.NewPathFilter([]string{"Dockerfile", "deploy/**", "*.tf"}, nil)
+	assert.Nil(t, err)
+
+	pr := &code.PullRequest{Additions: 80, Deletions: 30}
+	ClassifyPullRequest(pr, []string{"backend/core/models/migrationscripts/register.go", "README.md"}, migration, infra)
+	assert.Equal(t, code.PR_SIZE_M, pr.SizeBucket)
+	assert.Equal(t, 2, pr.ChangedFiles)
+	assert.True(t, pr.IncludesMigration)
+	assert.False(t, pr.IncludesInfra)
+
+	pr = &code.PullRequest{Additions: 5}
+	ClassifyPullRequest(pr, []string{"infra/main.tf"}, migration, infra)
+	assert.Equal(t, code.PR_SIZE_XS, pr.SizeBucket)
+	assert.False(t, pr.IncludesMigration)
+	assert.True(t, pr.IncludesInfra)
+
+	pr = &code.PullRequest{Additions: 2000, IncludesInfra: true}
+	ClassifyPullRequest(pr, []string{"deploy/k8s.yaml"}, nil, nil)
+	assert.Equal(t, code.PR_SIZE_XL, pr.SizeBucket)
+	assert.False(t, pr.IncludesInfra)
+}
+
+func TestClassifyPullRequestBatch(t *testing.T) {
+	migration, err := utils.NewPathFilter([]string{"migrations/"}, nil)
+	assert.Nil(t, err)
+	mockDal := new(mockdal.Dal)
+	// the files of the whole batch are read by a single query
+	mockDal.On("All", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		rows := reflect.ValueOf(args.Get(0)).Elem()
+		for _, file := range [][2]string{{"pr1", "migrations/001.sql"}, {"pr1", "README.md"}, {"pr2", "main.go"}} {
+			row := reflect.New(rows.Type().Elem()).Elem()
+			row.Field(0).SetString(file[0])
+			row.Field(1).SetString(file[1])
+			rows.Set(reflect.Append(rows, row))
+		}
+	}).Return(nil).Once()
+	updated := map[string][]dal.DalSet{}
+	mockDal.On("UpdateColumns", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		where := args.Get(2).([]dal.Clause)[0].Data.(dal.DalClause)
+		updated[where.Params[0].(string)] = args.Get(1).([]dal.DalSet)
+	}).Return(nil)
+
+	pr1 := &code.PullRequest{Additions: 5}
+	pr1.Id = "pr1"
+	pr2 := &code.PullRequest{Additions: 5}
+	pr2.Id = "pr2"
+	pr3 := &code.PullRequest{Additions: 5}
+	pr3.Id = "pr3"
+	assert.Nil(t, classifyPullRequestBatch(mockDal, []*code.PullRequest{pr1, pr2, pr3}, migration, nil))
+
+	mockDal.AssertNumberOfCalls(t, "All", 1)
+	assert.Equal(t, 2, pr1.ChangedFiles)
+	assert.True(t, pr1.IncludesMigration)
+	assert.Equal(t, 1, pr2.ChangedFiles)
+	assert.False(t, pr2.IncludesMigration)
+	assert.Equal(t, 0, pr3.ChangedFiles)
+	assert.Len(t, updated, 3)
+}
@@ -0,0 +1,138 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/apache/incubator-devlake/core/config"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	RAW_COMPRESSION_GZIP = "gzip"
+	RAW_COMPRESSION_ZSTD = "zstd"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// the encoder and the decoder are safe for concurrent use through EncodeAll and DecodeAll
+var zstdEncoder, _ = zstd.NewWriter(nil)
+var zstdDecoder, _ = zstd.NewReader(nil)
+
+var gzipWriters = sync.Pool{New: func() any { return gzip.NewWriter(nil) }}
+
+// rawCompression returns the algorithm used to compress the raw data of the plugin according to RAW_COMPRESSION,
+// which is a comma separated list of `<algorithm>` applied to all plugins and `<plugin>:<algorithm>` overriding it,
+// i.e. `zstd,jira:gzip,webhook:none`
+func rawCompression(ctx plugin.SubTaskContext) string {
+	setting := config.GetConfig().GetString("RAW_COMPRESSION")
+	if setting == "" {
+		return ""
+	}
+	return parseRawCompression(setting, ctx.TaskContext().GetName())
+}
+
+func parseRawCompression(setting string, pluginName string) string {
+	var algorithm string
+	for _, entry := range strings.Split(setting, ",") {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if name, value, ok := strings.Cut(entry, ":"); ok {
+			if strings.TrimSpace(name) == pluginName {
+				return normalizeRawCompression(value)
+			}
+			continue
+		}
+		algorithm = normalizeRawCompression(entry)
+	}
+	return algorithm
+}
+
+func normalizeRawCompression(algorithm string) string {
+	algorithm = strings.TrimSpace(algorithm)
+	if algorithm == RAW_COMPRESSION_GZIP || algorithm == RAW_COMPRESSION_ZSTD {
+		return algorithm
+	}
+	return ""
+}
+
+// compressRawData compresses the payload with the algorithm, the payload is returned as is if the algorithm is empty
+func compressRawData(algorithm string, data []byte) ([]byte, errors.Error) {
+	switch algorithm {
+	case RAW_COMPRESSION_ZSTD:
+		return zstdEncoder.EncodeAll(data, make([]byte, 0, len(data)/4)), nil
+	case RAW_COMPRESSION_GZIP:
+		var buf bytes.Buffer
+		writer := gzipWriters.Get().(*gzip.Writer)
+		defer gzipWriters.Put(writer)
+		writer.Reset(&buf)
+		if _, err := writer.Write(data); err != nil {
+			return nil, errors.Convert(err)
+		}
+		if err := writer.Close(); err != nil {
+			return nil, errors.Convert(err)
+		}
+		return buf.Bytes(), nil
+	}
+	return data, nil
+}
+
+// DecompressRawData returns the JSON payload of a raw row. Compressed payloads are recognized by their magic number,
+// which never starts a JSON document, so rows stored before and after enabling the compression can be mixed
+func DecompressRawData(data []byte) ([]byte, errors.Error) {
+	switch {
+	case bytes.HasPrefix(data, zstdMagic):
+		decompressed, err := zstdDecoder.DecodeAll(data, nil)
+		if err != nil {
+			return nil, errors.Default.Wrap(err, "failed to decompress zstd raw data")
+		}
+		return decompressed, nil
+	case bytes.HasPrefix(data, gzipMagic):
+		reader, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, errors.Default.Wrap(err, "failed to decompress gzip raw data")
+		}
+		defer reader.Close()
+		decompressed, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, errors.Default.Wrap(err, "failed to decompress gzip raw data")
+		}
+		return decompressed, nil
+	}
+	return data, nil
+}
+
+// decompressRawRow replaces the payload of the row with its decompressed JSON
+func decompressRawRow(row *RawData) errors.Error {
+	data, err := DecompressRawData(row.Data)
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("raw row %d", row.ID))
+	}
+	row.Data = data
+	return nil
+}
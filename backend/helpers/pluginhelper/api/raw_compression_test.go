@@ -0,0 +1,47 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRawCompression(t *testing.T) {
+	assert.Equal(t, "zstd", parseRawCompression("zstd,jira:gzip,webhook:none", "github"))
+	assert.Equal(t, "gzip", parseRawCompression("zstd,jira:gzip,webhook:none", "jira"))
+	assert.Equal(t, "", parseRawCompression("zstd,jira:gzip,webhook:none", "webhook"))
+	assert.Equal(t, "", parseRawCompression("github:zstd", "jira"))
+	assert.Equal(t, "", parseRawCompression("lz4", "jira"))
+}
+
+func TestCompressRawData(t *testing.T) {
+	payload := bytes.Repeat([]byte(`{"id":1,"title":"a chatty api"}`), 100)
+	for _, algorithm := range []string{"", RAW_COMPRESSION_GZIP, RAW_COMPRESSION_ZSTD} {
+		compressed, err := compressRawData(algorithm, payload)
+		assert.Nil(t, err)
+		if algorithm != "" {
+			assert.Less(t, len(compressed), len(payload)/10)
+		}
+		decompressed, err := DecompressRawData(compressed)
+		assert.Nil(t, err)
+		assert.Equal(t, payload, decompressed)
+	}
+}
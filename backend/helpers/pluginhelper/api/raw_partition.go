@@ -0,0 +1,373 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/config"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/log"
+)
+
+const (
+	// RAW_PARTITION_BY_CREATED_AT partitions the raw tables by the month of created_at
+	RAW_PARTITION_BY_CREATED_AT = "created_at"
+	// RAW_PARTITION_BY_CONNECTION_ID partitions the raw tables by the connection which collected the rows
+	RAW_PARTITION_BY_CONNECTION_ID = "connection_id"
+)
+
+var rawTableAutoMigrateLock sync.Mutex
+
+// ensureRawTable creates the raw table if it doesn't exist. When RAW_TABLE_PARTITION_BY is set and the database is
+// PostgreSQL, the table is created as a natively partitioned table and the partitions the collector is about to write
+// into are created on demand. The existing tables are left as they are until PartitionRawTables converts them
+func ensureRawTable(db dal.Dal, logger log.Logger, table string, connectionId uint64) errors.Error {
+	rawTableAutoMigrateLock.Lock()
+	defer rawTableAutoMigrateLock.Unlock()
+	by := rawTablePartitionBy(db)
+	if by == "" {
+		return db.AutoMigrate(&RawData{}, dal.From(table))
+	}
+	partitionKey, err := rawTablePartitionKey(db, table)
+	if err != nil {
+		return err
+	}
+	switch {
+	case partitionKey != "":
+		// an existing partitioned table keeps its key even if the configuration changed
+		by = partitionKey
+	case db.HasTable(table):
+		return db.AutoMigrate(&RawData{}, dal.From(table))
+	default:
+		err = createPartitionedRawTable(db, table, by)
+	}
+	if err != nil {
+		return err
+	}
+	return ensureRawPartitions(db, logger, table, by, connectionId, time.Now())
+}
+
+// rawTablePartitionBy returns the configured partition column of the raw tables, or empty if they are not partitioned
+func rawTablePartitionBy(db dal.Dal) string {
+	by := strings.ToLower(strings.TrimSpace(config.GetConfig().GetString("RAW_TABLE_PARTITION_BY")))
+	if by != RAW_PARTITION_BY_CREATED_AT && by != RAW_PARTITION_BY_CONNECTION_ID {
+		return ""
+	}
+	if db.Dialect() != "postgres" {
+		return ""
+	}
+	return by
+}
+
+// rawTablePartitionKey returns the partition column of the table, or empty if the table is not partitioned
+func rawTablePartitionKey(db dal.Dal, table string) (string, errors.Error) {
+	rows, err := db.RawCursor(
+		"SELECT pg_get_partkeydef(c.oid) FROM pg_class c WHERE c.relname = ? AND c.relkind = 'p' AND pg_table_is_visible(c.oid)",
+		table,
+	)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return "", nil
+	}
+	var keyDef string
+	if err := rows.Scan(&keyDef); err != nil {
+		return "", errors.Convert(err)
+	}
+	// keyDef looks like `RANGE (created_at)` or `LIST (connection_id)`
+	if strings.Contains(keyDef, RAW_PARTITION_BY_CONNECTION_ID) {
+		return RAW_PARTITION_BY_CONNECTION_ID, nil
+	}
+	return RAW_PARTITION_BY_CREATED_AT, nil
+}
+
+// createPartitionedRawTable creates the columns of RawData by hand since gorm can't create partitioned tables, the
+// partition column has to be part of the primary key
+func createPartitionedRawTable(db dal.Dal, table string, by string) errors.Error {
+	strategy := "RANGE"
+	if by == RAW_PARTITION_BY_CONNECTION_ID {
+		strategy = "LIST"
+	}
+	statements := []string{
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS "%s" (
+	id bigserial,
+	params varchar(255),
+	data bytea,
+	url text,
+	input json,
+	created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	connection_id bigint NOT NULL DEFAULT 0,
+	PRIMARY KEY (id, %s)
+) PARTITION BY %s (%s)`, table, by, strategy, by),
+		// rows without a matching partition go into the default one rather than failing the collection
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS "%s_default" PARTITION OF "%s" DEFAULT`, table, table),
+	}
+	for _, statement := range statements {
+		if err := db.Exec(statement); err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("failed to create the partitioned table %s", table))
+		}
+	}
+	return nil
+}
+
+// createRawTableIndexes creates the same indexes as AutoMigrate would, they are propagated to every partition
+func createRawTableIndexes(db dal.Dal, table string) errors.Error {
+	for _, column := range []string{"params", "created_at"} {
+		err := db.Exec(fmt.Sprintf(`CREATE INDEX IF NOT EXISTS "idx_%s_%s" ON "%s" (%s)`, table, column, table, column))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PartitionRawTables converts the existing raw tables which are not partitioned yet into partitioned ones according
+// to RAW_TABLE_PARTITION_BY, it returns the converted tables. The collections must not write into the tables meanwhile
+func PartitionRawTables(db dal.Dal, logger log.Logger) ([]string, errors.Error) {
+	by := rawTablePartitionBy(db)
+	if by == "" {
+		return nil, errors.BadInput.New("RAW_TABLE_PARTITION_BY is not set or the database is not PostgreSQL")
+	}
+	var tables []string
+	err := scanRawColumn(db, &tables, `SELECT c.relname FROM pg_class c
+WHERE c.relkind = 'r' AND NOT c.relispartition AND c.relname LIKE '\_raw\_%' AND pg_table_is_visible(c.oid)
+ORDER BY c.relname`)
+	if err != nil {
+		return nil, err
+	}
+	converted := make([]string, 0, len(tables))
+	for _, table := range tables {
+		logger.Info("converting %s into a table partitioned by %s", table, by)
+		rawTableAutoMigrateLock.Lock()
+		err = convertRawTable(db, table, by)
+		rawTableAutoMigrateLock.Unlock()
+		if err != nil {
+			return converted, errors.Default.Wrap(err, fmt.Sprintf("failed to convert %s", table))
+		}
+		converted = append(converted, table)
+	}
+	return converted, nil
+}
+
+// convertRawTable moves the rows of an existing table into a new partitioned table within a single transaction,
+// this is done once and may take a while for large tables
+func convertRawTable(db dal.Dal, table string, by string) (err errors.Error) {
+	oldTable := table + "_unpartitioned"
+	tx := db.Begin()
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+	// the serial sequence and the indexes keep their names after renaming the table, move them out of the way
+	statements := []string{
+		fmt.Sprintf(`ALTER TABLE "%s" RENAME TO "%s"`, table, oldTable),
+		fmt.Sprintf(`ALTER SEQUENCE IF EXISTS "%s_id_seq" RENAME TO "%s_id_seq"`, table, oldTable),
+	}
+	for _, column := range []string{"params", "created_at"} {
+		statements = append(statements, fmt.Sprintf(
+			`ALTER INDEX IF EXISTS "idx_%s_%s" RENAME TO "idx_%s_%s"`, table, column, oldTable, column,
+		))
+	}
+	for _, statement := range statements {
+		if err = tx.Exec(statement); err != nil {
+			return err
+		}
+	}
+	if err = createPartitionedRawTable(tx, table, by); err != nil {
+		return err
+	}
+	// create the partitions upfront so that the copied rows don't end up in the default partition
+	connectionIdExpr := `COALESCE(NULLIF(substring(params from '"ConnectionId":([0-9]+)'), '')::bigint, 0)`
+	if by == RAW_PARTITION_BY_CONNECTION_ID {
+		var connectionIds []uint64
+		err = scanRawColumn(tx, &connectionIds, fmt.Sprintf(`SELECT DISTINCT %s FROM "%s"`, connectionIdExpr, oldTable))
+		if err != nil {
+			return err
+		}
+		for _, connectionId := range connectionIds {
+			if err = createRawPartition(tx, table, by, connectionId, time.Time{}); err != nil {
+				return err
+			}
+		}
+	} else {
+		var months []time.Time
+		err = scanRawColumn(tx, &months, fmt.Sprintf(
+			`SELECT DISTINCT date_trunc('month', created_at AT TIME ZONE 'UTC') FROM "%s" WHERE created_at IS NOT NULL`,
+			oldTable,
+		))
+		if err != nil {
+			return err
+		}
+		for _, month := range months {
+			if err = createRawPartition(tx, table, by, 0, month); err != nil {
+				return err
+			}
+		}
+	}
+	statements = []string{
+		fmt.Sprintf(`INSERT INTO "%s" (id, params, data, url, input, created_at, connection_id)
+SELECT id, params, data, url, input, COALESCE(created_at, CURRENT_TIMESTAMP), %s FROM "%s"`,
+			table, connectionIdExpr, oldTable),
+		fmt.Sprintf(`SELECT setval(pg_get_serial_sequence('"%s"', 'id'), COALESCE((SELECT MAX(id) FROM "%s"), 0) + 1, false)`,
+			table, table),
+		fmt.Sprintf(`DROP TABLE "%s"`, oldTable),
+	}
+	for _, statement := range statements {
+		if err = tx.Exec(statement); err != nil {
+			return err
+		}
+	}
+	return createRawTableIndexes(tx, table)
+}
+
+func scanRawColumn[T any](db dal.Dal, dst *[]T, query string) errors.Error {
+	rows, err := db.RawCursor(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var value T
+		if err := rows.Scan(&value); err != nil {
+			return errors.Convert(err)
+		}
+		*dst = append(*dst, value)
+	}
+	return errors.Convert(rows.Err())
+}
+
+// ensureRawPartitions creates the partition of the connection, or the partitions of the current and the next month
+// so that a collection running over the turn of the month doesn't spill into the default partition
+func ensureRawPartitions(db dal.Dal, logger log.Logger, table string, by string, connectionId uint64, now time.Time) errors.Error {
+	var err errors.Error
+	if by == RAW_PARTITION_BY_CONNECTION_ID {
+		if connectionId == 0 {
+			return nil
+		}
+		err = createRawPartition(db, table, by, connectionId, time.Time{})
+	} else {
+		month := rawPartitionMonth(now)
+		err = createRawPartition(db, table, by, 0, month)
+		if err == nil {
+			err = createRawPartition(db, table, by, 0, month.AddDate(0, 1, 0))
+		}
+	}
+	if err != nil {
+		// most likely the default partition already holds rows of the new partition, they keep going there
+		logger.Warn(err, "failed to create a partition of %s", table)
+	}
+	return createRawTableIndexes(db, table)
+}
+
+func createRawPartition(db dal.Dal, table string, by string, connectionId uint64, month time.Time) errors.Error {
+	name := rawPartitionName(table, by, connectionId, month)
+	var bound string
+	if by == RAW_PARTITION_BY_CONNECTION_ID {
+		bound = fmt.Sprintf("IN (%d)", connectionId)
+	} else {
+		bound = fmt.Sprintf(
+			"FROM ('%s') TO ('%s')",
+			month.Format("2006-01-02 15:04:05Z07:00"),
+			month.AddDate(0, 1, 0).Format("2006-01-02 15:04:05Z07:00"),
+		)
+	}
+	return db.Exec(fmt.Sprintf(`CREATE TABLE IF NOT EXISTS "%s" PARTITION OF "%s" FOR VALUES %s`, name, table, bound))
+}
+
+// rawPartitionName returns `<table>_c<connectionId>` for connection partitions and `<table>_p<yyyymm>` for monthly ones
+func rawPartitionName(table string, by string, connectionId uint64, month time.Time) string {
+	if by == RAW_PARTITION_BY_CONNECTION_ID {
+		return fmt.Sprintf("%s_c%d", table, connectionId)
+	}
+	return fmt.Sprintf("%s_p%s", table, month.Format("200601"))
+}
+
+func rawPartitionMonth(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// rawParamsConnectionId extracts the connection id from the params of the raw rows, 0 if there is none
+func rawParamsConnectionId(params string) uint64 {
+	var fields map[string]json.RawMessage
+	if json.Unmarshal([]byte(params), &fields) != nil {
+		return 0
+	}
+	var connectionId uint64
+	if json.Unmarshal(fields["ConnectionId"], &connectionId) != nil {
+		return 0
+	}
+	return connectionId
+}
+
+// DropExpiredRawPartitions drops the monthly partitions of the raw table which only hold rows created before the
+// cutoff, which is a lot cheaper than deleting the rows. It returns the number of dropped rows, the remaining rows are
+// left to be deleted by the caller
+func DropExpiredRawPartitions(db dal.Dal, table string, cutoff time.Time) (int64, errors.Error) {
+	if db.Dialect() != "postgres" {
+		return 0, nil
+	}
+	partitionKey, err := rawTablePartitionKey(db, table)
+	if err != nil || partitionKey != RAW_PARTITION_BY_CREATED_AT {
+		return 0, err
+	}
+	var partitions []string
+	err = scanRawColumn(db, &partitions, fmt.Sprintf(
+		`SELECT c.relname FROM pg_inherits i JOIN pg_class c ON c.oid = i.inhrelid WHERE i.inhparent = '"%s"'::regclass`,
+		table,
+	))
+	if err != nil {
+		return 0, err
+	}
+	var dropped int64
+	for _, partition := range partitions {
+		month, ok := parseRawPartitionMonth(table, partition)
+		if !ok || month.AddDate(0, 1, 0).After(cutoff) {
+			continue
+		}
+		rows, err := db.Count(dal.From(partition))
+		if err != nil {
+			return dropped, err
+		}
+		if err = db.Exec(fmt.Sprintf(`DROP TABLE "%s"`, partition)); err != nil {
+			return dropped, err
+		}
+		dropped += rows
+	}
+	return dropped, nil
+}
+
+func parseRawPartitionMonth(table string, partition string) (time.Time, bool) {
+	suffix, ok := strings.CutPrefix(partition, table+"_p")
+	if !ok {
+		return time.Time{}, false
+	}
+	month, err := time.Parse("200601", suffix)
+	return month, err == nil
+}
@@ -0,0 +1,49 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRawPartitionName(t *testing.T) {
+	month := rawPartitionMonth(time.Date(2026, 10, 31, 23, 0, 0, 0, time.FixedZone("UTC-5", -5*3600)))
+	assert.Equal(t, time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), month.AddDate(0, -1, 0))
+
+	name := rawPartitionName("_raw_jira_api_issues", RAW_PARTITION_BY_CREATED_AT, 0, month)
+	assert.Equal(t, "_raw_jira_api_issues_p202611", name)
+	parsed, ok := parseRawPartitionMonth("_raw_jira_api_issues", name)
+	assert.True(t, ok)
+	assert.Equal(t, month, parsed)
+
+	name = rawPartitionName("_raw_jira_api_issues", RAW_PARTITION_BY_CONNECTION_ID, 3, time.Time{})
+	assert.Equal(t, "_raw_jira_api_issues_c3", name)
+	_, ok = parseRawPartitionMonth("_raw_jira_api_issues", name)
+	assert.False(t, ok)
+	_, ok = parseRawPartitionMonth("_raw_jira_api_issues", "_raw_jira_api_issues_default")
+	assert.False(t, ok)
+}
+
+func TestRawParamsConnectionId(t *testing.T) {
+	assert.Equal(t, uint64(2), rawParamsConnectionId(`{"ConnectionId":2,"BoardId":8}`))
+	assert.Equal(t, uint64(0), rawParamsConnectionId(`{"Name":"apache/incubator-devlake"}`))
+	assert.Equal(t, uint64(0), rawParamsConnectionId(""))
+}
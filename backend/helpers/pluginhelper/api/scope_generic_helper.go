@@ -551,8 +551,8 @@ func (gs *GenericScopeApiHelper[Conn, Scope, ScopeConfig]) transactionalDelete(t
 			params = []interface{}{rawDataParams}
 		} else {
 			// framework tables: should check plugin, connection and scope
-			if table == (models.CollectorLatestState{}.TableName()) {
-				// diff sync state
+			if table == (models.CollectorLatestState{}.TableName()) || table == (models.ApiHttpCache{}.TableName()) {
+				// diff sync state and http cache
 				where = "raw_data_table LIKE ? AND raw_data_params = ?"
 			} else {
 				// domain layer table
@@ -657,7 +657,7 @@ func (gs *GenericScopeApiHelper[Conn, Scope, ScopeConfig]) getAffectedTables(plu
 			}
 		}
 		// additional tables
-		tables = append(tables, models.CollectorLatestState{}.TableName())
+		tables = append(tables, models.CollectorLatestState{}.TableName(), models.ApiHttpCache{}.TableName())
 	}
 	gs.log.Debug("Discovered %d tables used by plugin \"%s\": %v", len(tables), pluginName, tables)
 	return tables, nil
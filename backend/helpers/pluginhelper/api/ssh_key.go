@@ -0,0 +1,79 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+// SanitizedSshSecret replaces the SSH private key and passphrase in API responses, nothing of them should be exposed
+const SanitizedSshSecret = "********"
+
+// SshKey holds the SSH private key to clone the repos of a connection over SSH,
+// the key and its optional passphrase are stored encrypted
+type SshKey struct {
+	SshPrivateKey string `mapstructure:"sshPrivateKey" json:"sshPrivateKey" gorm:"type:text;serializer:encdec"`
+	SshPassphrase string `mapstructure:"sshPassphrase" json:"sshPassphrase" gorm:"type:text;serializer:encdec"`
+}
+
+// HasSshKey returns true if the repos should be cloned over SSH
+func (k SshKey) HasSshKey() bool {
+	return k.SshPrivateKey != ""
+}
+
+// Sanitize masks the private key and passphrase
+func (k SshKey) Sanitize() SshKey {
+	if k.SshPrivateKey != "" {
+		k.SshPrivateKey = SanitizedSshSecret
+	}
+	if k.SshPassphrase != "" {
+		k.SshPassphrase = SanitizedSshSecret
+	}
+	return k
+}
+
+// RestoreSanitized keeps the stored private key and passphrase when the request sends the sanitized values back
+func (k *SshKey) RestoreSanitized(existed SshKey) {
+	if k.SshPrivateKey == SanitizedSshSecret {
+		k.SshPrivateKey = existed.SshPrivateKey
+	}
+	if k.SshPassphrase == SanitizedSshSecret {
+		k.SshPassphrase = existed.SshPassphrase
+	}
+}
+
+// MakeSshCloneUrl converts a http(s) clone url like `https://github.com/apache/incubator-devlake.git`
+// to its SSH counterpart `ssh://git@github.com/apache/incubator-devlake.git`
+func MakeSshCloneUrl(httpUrl string) (string, errors.Error) {
+	u, err := url.Parse(httpUrl)
+	if err != nil {
+		return "", errors.BadInput.Wrap(err, fmt.Sprintf("failed to parse the clone url %s", httpUrl))
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", errors.BadInput.New(fmt.Sprintf("unexpected scheme of the clone url %s", httpUrl))
+	}
+	path := u.Path
+	if !strings.HasSuffix(path, ".git") {
+		path += ".git"
+	}
+	return fmt.Sprintf("ssh://git@%s%s", u.Hostname(), path), nil
+}
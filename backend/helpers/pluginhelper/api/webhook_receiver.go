@@ -0,0 +1,285 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/dbhelper"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// WebhookReceiverArgs describes the payloads pushed to a webhook endpoint and how to save them
+type WebhookReceiverArgs struct {
+	// Plugin and Event name the raw table `_raw_<plugin>_webhook_<event>` keeping the payloads for replay
+	Plugin string
+	Event  string
+	// NewPayload returns a pointer to the struct the body gets decoded into by its `mapstructure` tags and
+	// validated by its `validate` tags
+	NewPayload func() interface{}
+	// Signature verifies the delivery with the secret of the connection, nil means no verification
+	Signature WebhookSignature
+	// DeliveryIdHeader carries the unique id of a delivery, i.e. X-Gitlab-Event-UUID, the retries of a handled
+	// delivery are acknowledged without being handled again. The deliveries without it are always handled, since
+	// identical payloads may be legitimately posted more than once
+	DeliveryIdHeader string
+	// Handle saves the payload within the transaction, it must be idempotent (i.e. CreateOrUpdate) since a delivery
+	// can be replayed
+	Handle func(tx dal.Transaction, connectionId uint64, payload interface{}) errors.Error
+}
+
+// WebhookReceiver is the hardened implementation shared by the webhook endpoints of the plugins, it verifies the
+// signature, validates the payload, keeps it in the raw table, and handles every delivery only once
+type WebhookReceiver struct {
+	basicRes  context.BasicRes
+	args      *WebhookReceiverArgs
+	validator *validator.Validate
+	rawTable  string
+	// rawTableOnce makes sure the raw table is created once per connection
+	rawTableOnce sync.Map
+}
+
+// WebhookDeliveryOutput is the response of a delivery
+type WebhookDeliveryOutput struct {
+	DeliveryId string `json:"deliveryId"`
+	Duplicated bool   `json:"duplicated"`
+}
+
+// WebhookReplayInput selects the deliveries to replay, all failed deliveries are replayed if DeliveryIds is empty
+type WebhookReplayInput struct {
+	DeliveryIds []string `json:"deliveryIds" mapstructure:"deliveryIds"`
+}
+
+// WebhookReplayOutput is the result of a replay
+type WebhookReplayOutput struct {
+	Replayed int                       `json:"replayed"`
+	Failed   []*models.WebhookDelivery `json:"failed"`
+}
+
+// NewWebhookReceiver creates a new WebhookReceiver
+func NewWebhookReceiver(basicRes context.BasicRes, args WebhookReceiverArgs) *WebhookReceiver {
+	if args.Plugin == "" || args.Event == "" {
+		panic(errors.Default.New("Plugin and Event are required"))
+	}
+	if args.NewPayload == nil || args.Handle == nil {
+		panic(errors.Default.New("NewPayload and Handle are required"))
+	}
+	return &WebhookReceiver{
+		basicRes:  basicRes,
+		args:      &args,
+		validator: validator.New(),
+		rawTable:  fmt.Sprintf("_raw_%s_webhook_%s", args.Plugin, args.Event),
+	}
+}
+
+// Receive handles a delivery to the connection, the signature is verified only if the connection has a secret
+func (r *WebhookReceiver) Receive(input *plugin.ApiResourceInput, connectionId uint64, secret string) (*plugin.ApiResourceOutput, errors.Error) {
+	body := input.RawBody
+	if body == nil {
+		var err error
+		body, err = json.Marshal(input.Body)
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, "failed to encode the body")
+		}
+	}
+	header := http.Header{}
+	url := ""
+	if input.Request != nil {
+		header = input.Request.Header
+		url = input.Request.URL.String()
+	}
+	if r.args.Signature != nil && secret != "" {
+		if err := r.args.Signature.Verify(header, body, secret); err != nil {
+			return nil, err
+		}
+	}
+	delivery := &models.WebhookDelivery{}
+	deliveryId := r.deliveryId(header)
+	if deliveryId != "" {
+		db := r.basicRes.GetDal()
+		err := db.First(delivery, dal.Where(
+			"plugin = ? AND event = ? AND connection_id = ? AND delivery_id = ?",
+			r.args.Plugin, r.args.Event, connectionId, deliveryId,
+		))
+		if err != nil && !db.IsErrorNotFound(err) {
+			return nil, err
+		}
+		if err == nil && delivery.Status == models.WEBHOOK_DELIVERY_DONE {
+			return &plugin.ApiResourceOutput{Body: &WebhookDeliveryOutput{DeliveryId: deliveryId, Duplicated: true}}, nil
+		}
+	} else {
+		// the delivery is still recorded, to be replayed if it fails
+		deliveryId = uuid.NewString()
+	}
+	payload, err := r.decode(body)
+	if err != nil {
+		return nil, err
+	}
+	rawDataId, err := r.saveRawData(connectionId, url, body)
+	if err != nil {
+		return nil, err
+	}
+	delivery = &models.WebhookDelivery{
+		Plugin:       r.args.Plugin,
+		Event:        r.args.Event,
+		ConnectionId: connectionId,
+		DeliveryId:   deliveryId,
+		RawDataTable: r.rawTable,
+		RawDataId:    rawDataId,
+		Attempts:     delivery.Attempts,
+	}
+	err = r.handle(delivery, payload)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: &WebhookDeliveryOutput{DeliveryId: deliveryId}}, nil
+}
+
+// Replay handles the stored payloads of the deliveries again, i.e. after fixing a bug of the handler
+func (r *WebhookReceiver) Replay(connectionId uint64, replay *WebhookReplayInput) (*WebhookReplayOutput, errors.Error) {
+	db := r.basicRes.GetDal()
+	clauses := []dal.Clause{
+		dal.Where("plugin = ? AND event = ? AND connection_id = ?", r.args.Plugin, r.args.Event, connectionId),
+	}
+	if len(replay.DeliveryIds) > 0 {
+		clauses = append(clauses, dal.Where("delivery_id IN ?", replay.DeliveryIds))
+	} else {
+		clauses = append(clauses, dal.Where("status = ?", models.WEBHOOK_DELIVERY_FAILED))
+	}
+	var deliveries []*models.WebhookDelivery
+	err := db.All(&deliveries, clauses...)
+	if err != nil {
+		return nil, err
+	}
+	output := &WebhookReplayOutput{Failed: []*models.WebhookDelivery{}}
+	for _, delivery := range deliveries {
+		rawData := &RawData{}
+		err = db.First(rawData, dal.From(delivery.RawDataTable), dal.Where("id = ?", delivery.RawDataId))
+		if err != nil {
+			return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to load the payload of delivery %s", delivery.DeliveryId))
+		}
+		payload, err := r.decode(rawData.Data)
+		if err == nil {
+			err = r.handle(delivery, payload)
+		}
+		if err != nil {
+			delivery.Message = err.Error()
+			output.Failed = append(output.Failed, delivery)
+			continue
+		}
+		output.Replayed++
+	}
+	return output, nil
+}
+
+// PostReplay is the api resource replaying the deliveries of the connection
+func (r *WebhookReceiver) PostReplay(input *plugin.ApiResourceInput, connectionId uint64) (*plugin.ApiResourceOutput, errors.Error) {
+	replay := &WebhookReplayInput{}
+	err := DecodeMapStruct(input.Body, replay, true)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid replay request")
+	}
+	output, err := r.Replay(connectionId, replay)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: output}, nil
+}
+
+// deliveryId returns the id of the delivery sent by the source, empty if there is none
+func (r *WebhookReceiver) deliveryId(header http.Header) string {
+	if r.args.DeliveryIdHeader == "" {
+		return ""
+	}
+	return header.Get(r.args.DeliveryIdHeader)
+}
+
+// decode decodes and validates the payload
+func (r *WebhookReceiver) decode(body []byte) (interface{}, errors.Error) {
+	var fields map[string]interface{}
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid json payload")
+	}
+	payload := r.args.NewPayload()
+	if err := DecodeMapStruct(fields, payload, true); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid payload")
+	}
+	if err := r.validator.Struct(payload); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid payload")
+	}
+	return payload, nil
+}
+
+func (r *WebhookReceiver) saveRawData(connectionId uint64, url string, body []byte) (uint64, errors.Error) {
+	db := r.basicRes.GetDal()
+	if _, ok := r.rawTableOnce.Load(connectionId); !ok {
+		err := ensureRawTable(db, r.basicRes.GetLogger(), r.rawTable, connectionId)
+		if err != nil {
+			return 0, errors.Default.Wrap(err, fmt.Sprintf("failed to create %s", r.rawTable))
+		}
+		r.rawTableOnce.Store(connectionId, true)
+	}
+	params, err := json.Marshal(map[string]uint64{"ConnectionId": connectionId})
+	if err != nil {
+		return 0, errors.Convert(err)
+	}
+	rawData := &RawData{
+		Params:       string(params),
+		ConnectionId: connectionId,
+		Data:         body,
+		Url:          url,
+	}
+	if err := db.Create(rawData, dal.From(r.rawTable)); err != nil {
+		return 0, errors.Default.Wrap(err, fmt.Sprintf("failed to save the payload into %s", r.rawTable))
+	}
+	return rawData.ID, nil
+}
+
+// handle saves the payload and the delivery in a transaction, the failure is recorded for replay
+func (r *WebhookReceiver) handle(delivery *models.WebhookDelivery, payload interface{}) (err errors.Error) {
+	delivery.Attempts++
+	err = func() (err errors.Error) {
+		txHelper := dbhelper.NewTxHelper(r.basicRes, &err)
+		defer txHelper.End()
+		tx := txHelper.Begin()
+		err = r.args.Handle(tx, delivery.ConnectionId, payload)
+		if err != nil {
+			return err
+		}
+		delivery.Status = models.WEBHOOK_DELIVERY_DONE
+		delivery.Message = ""
+		return tx.CreateOrUpdate(delivery)
+	}()
+	if err != nil {
+		delivery.Status = models.WEBHOOK_DELIVERY_FAILED
+		delivery.Message = err.Error()
+		if saveErr := r.basicRes.GetDal().CreateOrUpdate(delivery); saveErr != nil {
+			r.basicRes.GetLogger().Error(saveErr, "failed to record the failed webhook delivery %s", delivery.DeliveryId)
+		}
+	}
+	return err
+}
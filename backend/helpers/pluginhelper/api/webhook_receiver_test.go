@@ -0,0 +1,211 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockcontext "github.com/apache/incubator-devlake/mocks/core/context"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type webhookTestPayload struct {
+	Key    string `mapstructure:"key" validate:"required"`
+	Status string `mapstructure:"status" validate:"omitempty,oneof=OPEN CLOSED"`
+}
+
+func newWebhookTestReceiver(mockDal *mockdal.Dal, handled *[]*webhookTestPayload) *WebhookReceiver {
+	mockRes := new(mockcontext.BasicRes)
+	mockRes.On("GetDal").Return(mockDal)
+	mockRes.On("GetLogger").Return(unithelper.DummyLogger())
+	return NewWebhookReceiver(mockRes, WebhookReceiverArgs{
+		Plugin:           "test",
+		Event:            "issues",
+		NewPayload:       func() interface{} { return &webhookTestPayload{} },
+		Signature:        &HmacSignature{Header: "X-Signature", Prefix: "sha256="},
+		DeliveryIdHeader: "X-Delivery",
+		Handle: func(tx dal.Transaction, connectionId uint64, payload interface{}) errors.Error {
+			*handled = append(*handled, payload.(*webhookTestPayload))
+			return nil
+		},
+	})
+}
+
+func newWebhookTestInput(body string, header http.Header) *plugin.ApiResourceInput {
+	return &plugin.ApiResourceInput{
+		RawBody: []byte(body),
+		Request: &http.Request{Header: header, URL: &url.URL{Path: "/plugins/test/connections/1/issues"}},
+	}
+}
+
+func TestWebhookReceiverReceive(t *testing.T) {
+	mockTx := new(mockdal.Transaction)
+	mockTx.On("CreateOrUpdate", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		delivery := args.Get(0).(*models.WebhookDelivery)
+		assert.Equal(t, "d1", delivery.DeliveryId)
+		assert.Equal(t, models.WEBHOOK_DELIVERY_DONE, delivery.Status)
+		assert.Equal(t, uint64(42), delivery.RawDataId)
+		assert.Equal(t, "_raw_test_webhook_issues", delivery.RawDataTable)
+	}).Return(nil).Once()
+	mockTx.On("UnlockTables").Return(nil)
+	mockTx.On("Commit").Return(nil).Once()
+	mockDal := new(mockdal.Dal)
+	mockDal.On("First", mock.Anything, mock.Anything).Return(errors.NotFound.New("not found")).Once()
+	mockDal.On("IsErrorNotFound", mock.Anything).Return(true)
+	mockDal.On("AutoMigrate", mock.Anything, mock.Anything).Return(nil).Once()
+	mockDal.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(0).(*RawData).ID = 42
+	}).Return(nil).Once()
+	mockDal.On("Begin").Return(mockTx)
+
+	var handled []*webhookTestPayload
+	receiver := newWebhookTestReceiver(mockDal, &handled)
+	body := `{"key":"ISSUE-1","status":"OPEN"}`
+	output, err := receiver.Receive(newWebhookTestInput(body, http.Header{
+		"X-Signature": {SignWebhookBody([]byte(body), "secret")},
+		"X-Delivery":  {"d1"},
+	}), 1, "secret")
+	assert.Nil(t, err)
+	assert.Equal(t, &WebhookDeliveryOutput{DeliveryId: "d1"}, output.Body)
+	assert.Equal(t, []*webhookTestPayload{{Key: "ISSUE-1", Status: "OPEN"}}, handled)
+	mockDal.AssertExpectations(t)
+	mockTx.AssertExpectations(t)
+}
+
+func TestWebhookReceiverDuplicated(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	mockDal.On("First", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(0).(*models.WebhookDelivery).Status = models.WEBHOOK_DELIVERY_DONE
+	}).Return(nil).Once()
+
+	var handled []*webhookTestPayload
+	receiver := newWebhookTestReceiver(mockDal, &handled)
+	output, err := receiver.Receive(newWebhookTestInput(`{"key":"ISSUE-1"}`, http.Header{"X-Delivery": {"d1"}}), 1, "")
+	assert.Nil(t, err)
+	assert.Equal(t, &WebhookDeliveryOutput{DeliveryId: "d1", Duplicated: true}, output.Body)
+	assert.Empty(t, handled)
+}
+
+func TestWebhookReceiverWithoutDeliveryId(t *testing.T) {
+	mockTx := new(mockdal.Transaction)
+	var deliveryIds []string
+	mockTx.On("CreateOrUpdate", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		deliveryIds = append(deliveryIds, args.Get(0).(*models.WebhookDelivery).DeliveryId)
+	}).Return(nil).Twice()
+	mockTx.On("UnlockTables").Return(nil)
+	mockTx.On("Commit").Return(nil).Twice()
+	mockDal := new(mockdal.Dal)
+	mockDal.On("AutoMigrate", mock.Anything, mock.Anything).Return(nil).Once()
+	mockDal.On("Create", mock.Anything, mock.Anything).Return(nil).Twice()
+	mockDal.On("Begin").Return(mockTx)
+
+	// the same payload posted twice without a delivery id, i.e. the same status reported again, is handled twice
+	var handled []*webhookTestPayload
+	receiver := newWebhookTestReceiver(mockDal, &handled)
+	for i := 0; i < 2; i++ {
+		output, err := receiver.Receive(newWebhookTestInput(`{"key":"ISSUE-1","status":"OPEN"}`, http.Header{}), 1, "")
+		assert.Nil(t, err)
+		assert.False(t, output.Body.(*WebhookDeliveryOutput).Duplicated)
+	}
+	assert.Len(t, handled, 2)
+	assert.Len(t, deliveryIds, 2)
+	assert.NotEqual(t, deliveryIds[0], deliveryIds[1])
+	mockDal.AssertNotCalled(t, "First", mock.Anything, mock.Anything)
+}
+
+func TestWebhookReceiverRejects(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	mockDal.On("First", mock.Anything, mock.Anything).Return(errors.NotFound.New("not found"))
+	mockDal.On("IsErrorNotFound", mock.Anything).Return(true)
+
+	var handled []*webhookTestPayload
+	receiver := newWebhookTestReceiver(mockDal, &handled)
+	body := `{"key":"ISSUE-1"}`
+
+	_, err := receiver.Receive(newWebhookTestInput(body, http.Header{
+		"X-Signature": {SignWebhookBody([]byte(body), "another secret")},
+	}), 1, "secret")
+	assert.Equal(t, errors.Unauthorized, err.GetType())
+
+	_, err = receiver.Receive(newWebhookTestInput(`{"status":"DONE"}`, http.Header{}), 1, "")
+	assert.Equal(t, errors.BadInput, err.GetType())
+
+	_, err = receiver.Receive(newWebhookTestInput(`not json`, http.Header{}), 1, "")
+	assert.Equal(t, errors.BadInput, err.GetType())
+	assert.Empty(t, handled)
+}
+
+func TestTokenSignature(t *testing.T) {
+	signature := &TokenSignature{Header: "X-Gitlab-Token"}
+	assert.Nil(t, signature.Verify(http.Header{"X-Gitlab-Token": {"secret"}}, nil, "secret"))
+	assert.NotNil(t, signature.Verify(http.Header{"X-Gitlab-Token": {"guess"}}, nil, "secret"))
+	assert.NotNil(t, signature.Verify(http.Header{}, nil, "secret"))
+}
+
+func TestHmacSignature(t *testing.T) {
+	signature := &HmacSignature{Header: "X-Hub-Signature-256", Prefix: "sha256="}
+	body := []byte(`{"action":"opened"}`)
+	assert.Nil(t, signature.Verify(http.Header{"X-Hub-Signature-256": {SignWebhookBody(body, "secret")}}, body, "secret"))
+	assert.NotNil(t, signature.Verify(http.Header{"X-Hub-Signature-256": {SignWebhookBody(body, "secret")}}, []byte(`{}`), "secret"))
+	assert.NotNil(t, signature.Verify(http.Header{"X-Hub-Signature-256": {"sha256=zz"}}, body, "secret"))
+	assert.NotNil(t, signature.Verify(http.Header{"X-Hub-Signature-256": {"md5=00"}}, body, "secret"))
+}
+
+func TestTimestampedHmacSignature(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	signature := &TimestampedHmacSignature{
+		HmacSignature:   HmacSignature{Header: "X-Slack-Signature", Prefix: "v0="},
+		TimestampHeader: "X-Slack-Request-Timestamp",
+		Format: func(timestamp string, body []byte) []byte {
+			return []byte("v0:" + timestamp + ":" + string(body))
+		},
+		now: func() time.Time { return now },
+	}
+	body := []byte(`{"type":"event_callback"}`)
+	sign := func(timestamp string, content []byte) http.Header {
+		return http.Header{
+			"X-Slack-Request-Timestamp": {timestamp},
+			"X-Slack-Signature":         {"v0=" + strings.TrimPrefix(SignWebhookBody([]byte("v0:"+timestamp+":"+string(content)), "secret"), "sha256=")},
+		}
+	}
+	assert.Nil(t, signature.Verify(sign("1700000000", body), body, "secret"))
+	assert.Nil(t, signature.Verify(sign("1699999800", body), body, "secret"))
+	// signed for another body or with another secret
+	assert.NotNil(t, signature.Verify(sign("1700000000", []byte(`{}`)), body, "secret"))
+	assert.NotNil(t, signature.Verify(sign("1700000000", body), body, "other"))
+	// stale or future timestamps, even correctly signed
+	assert.NotNil(t, signature.Verify(sign("1699999000", body), body, "secret"))
+	assert.NotNil(t, signature.Verify(sign("1700001000", body), body, "secret"))
+	// missing or malformed timestamp
+	header := sign("1700000000", body)
+	header.Del("X-Slack-Request-Timestamp")
+	err := signature.Verify(header, body, "secret")
+	assert.Equal(t, errors.Unauthorized, err.GetType())
+	assert.NotNil(t, signature.Verify(sign("yesterday", body), body, "secret"))
+}
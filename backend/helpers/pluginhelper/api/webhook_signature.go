@@ -0,0 +1,141 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+// WebhookSignature verifies a delivery was sent by someone holding the secret of the connection
+type WebhookSignature interface {
+	Verify(header http.Header, body []byte, secret string) errors.Error
+}
+
+var _ WebhookSignature = (*HmacSignature)(nil)
+var _ WebhookSignature = (*TokenSignature)(nil)
+
+// HmacSignature verifies the hex encoded HMAC of the body sent in a header, i.e.
+// `X-Hub-Signature-256: sha256=<hmac>` sent by GitHub and Jira
+type HmacSignature struct {
+	// Header carries the signature, i.e. X-Hub-Signature-256
+	Header string
+	// Prefix precedes the hex encoded signature, i.e. "sha256="
+	Prefix string
+	// Hash is sha256 if nil
+	Hash func() hash.Hash
+}
+
+func (s *HmacSignature) Verify(header http.Header, body []byte, secret string) errors.Error {
+	signature := header.Get(s.Header)
+	if signature == "" {
+		return errors.Unauthorized.New(fmt.Sprintf("missing signature header %s", s.Header))
+	}
+	if !strings.HasPrefix(signature, s.Prefix) {
+		return errors.Unauthorized.New(fmt.Sprintf("malformed signature header %s", s.Header))
+	}
+	actual, err := hex.DecodeString(strings.TrimPrefix(signature, s.Prefix))
+	if err != nil {
+		return errors.Unauthorized.New(fmt.Sprintf("malformed signature header %s", s.Header))
+	}
+	hashFunc := s.Hash
+	if hashFunc == nil {
+		hashFunc = sha256.New
+	}
+	mac := hmac.New(hashFunc, []byte(secret))
+	mac.Write(body)
+	if !hmac.Equal(actual, mac.Sum(nil)) {
+		return errors.Unauthorized.New("signature mismatched")
+	}
+	return nil
+}
+
+// SignWebhookBody returns the HMAC-SHA256 signature of the body in the format HmacSignature{Prefix: "sha256="} expects
+func SignWebhookBody(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// TokenSignature verifies the secret sent as it is in a header, i.e. `X-Gitlab-Token` sent by GitLab
+type TokenSignature struct {
+	Header string
+}
+
+func (s *TokenSignature) Verify(header http.Header, _ []byte, secret string) errors.Error {
+	token := header.Get(s.Header)
+	if token == "" {
+		return errors.Unauthorized.New(fmt.Sprintf("missing token header %s", s.Header))
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(secret)) != 1 {
+		return errors.Unauthorized.New("token mismatched")
+	}
+	return nil
+}
+
+// TimestampedHmacSignature verifies the HMAC of the content signed along with the timestamp header, i.e.
+// `X-Slack-Signature: v0=<hmac>` of `v0:<timestamp>:<body>` sent by Slack. The requests whose timestamp is farther
+// than the tolerance from now are refused so that a captured request can't be replayed later
+type TimestampedHmacSignature struct {
+	HmacSignature
+	// TimestampHeader carries the unix timestamp of the request in seconds, i.e. X-Slack-Request-Timestamp
+	TimestampHeader string
+	// Format returns the signed content, "<timestamp>:<body>" if nil
+	Format func(timestamp string, body []byte) []byte
+	// Tolerance is 5 minutes if zero
+	Tolerance time.Duration
+	now       func() time.Time
+}
+
+var _ WebhookSignature = (*TimestampedHmacSignature)(nil)
+
+func (s *TimestampedHmacSignature) Verify(header http.Header, body []byte, secret string) errors.Error {
+	timestamp := header.Get(s.TimestampHeader)
+	if timestamp == "" {
+		return errors.Unauthorized.New(fmt.Sprintf("missing timestamp header %s", s.TimestampHeader))
+	}
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errors.Unauthorized.New(fmt.Sprintf("malformed timestamp header %s", s.TimestampHeader))
+	}
+	now := time.Now
+	if s.now != nil {
+		now = s.now
+	}
+	tolerance := s.Tolerance
+	if tolerance == 0 {
+		tolerance = 5 * time.Minute
+	}
+	if age := now().Sub(time.Unix(seconds, 0)); age > tolerance || age < -tolerance {
+		return errors.Unauthorized.New("the timestamp of the request is too far from now")
+	}
+	if s.Format != nil {
+		return s.HmacSignature.Verify(header, s.Format(timestamp, body), secret)
+	}
+	return s.HmacSignature.Verify(header, []byte(timestamp+":"+string(body)), secret)
+}
@@ -53,6 +53,7 @@ type WorkerScheduler struct {
 	counter      int32
 	logger       log.Logger
 	tickInterval time.Duration
+	pausedUntil  time.Time
 }
 
 //var callframeEnabled = os.Getenv("ASYNC_CF") == "true"
@@ -108,6 +109,9 @@ func (s *WorkerScheduler) SubmitBlocking(task func() errors.Error) {
 		case <-s.ctx.Done():
 			panic(s.ctx.Err())
 		case <-s.ticker.C:
+			if err := s.waitUntilResumed(); err != nil {
+				panic(err)
+			}
 			err := task()
 			if err != nil {
 				panic(err)
@@ -177,15 +181,55 @@ func (s *WorkerScheduler) WaitAsync() errors.Error {
 
 // Reset stops a WorkScheduler and resets its period to the specified duration.
 func (s *WorkerScheduler) Reset(interval time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.tickInterval = interval
 	s.ticker.Reset(interval)
 }
 
 // GetTickInterval returns current tick interval of the WorkScheduler
 func (s *WorkerScheduler) GetTickInterval() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	return s.tickInterval
 }
 
+// Tune changes the number of workers running tasks in parallel
+func (s *WorkerScheduler) Tune(numOfWorkers int) {
+	s.pool.Tune(numOfWorkers)
+}
+
+// Pause holds all tasks that are due within the specified duration, i.e. when the server asks us to retry later
+func (s *WorkerScheduler) Pause(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if until := time.Now().Add(d); until.After(s.pausedUntil) {
+		s.pausedUntil = until
+	}
+}
+
+func (s *WorkerScheduler) waitUntilResumed() error {
+	s.mu.Lock()
+	d := time.Until(s.pausedUntil)
+	s.mu.Unlock()
+	return s.sleep(d)
+}
+
+// sleep blocks for the specified duration unless the scheduler is canceled
+func (s *WorkerScheduler) sleep(d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
 // Release resources
 func (s *WorkerScheduler) Release() {
 	s.waitGroup.Wait()
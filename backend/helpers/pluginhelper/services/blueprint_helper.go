@@ -39,6 +39,8 @@ type GetBlueprintQuery struct {
 	PageSize    int
 	Mode        string
 	Type        string
+	// ProjectNames restricts the result to the blueprints of the given projects unless nil
+	ProjectNames []string
 }
 
 type BlueprintProjectPairs struct {
@@ -89,6 +91,23 @@ func (b *BlueprintManager) SaveDbBlueprint(blueprint *models.Blueprint) errors.E
 			return errors.Default.Wrap(err, "error creating DB blueprint's labelModels")
 		}
 	}
+	err = b.db.Delete(&models.BlueprintDependency{}, dal.Where(`blueprint_id = ?`, blueprint.ID))
+	if err != nil {
+		return errors.Default.Wrap(err, "error delete DB blueprint's old dependencies")
+	}
+	if len(blueprint.DependsOn) > 0 {
+		dependencies := make([]*models.BlueprintDependency, 0, len(blueprint.DependsOn))
+		for _, dependsOnId := range blueprint.DependsOn {
+			dependencies = append(dependencies, &models.BlueprintDependency{
+				BlueprintId: blueprint.ID,
+				DependsOnId: dependsOnId,
+			})
+		}
+		err = b.db.Create(&dependencies)
+		if err != nil {
+			return errors.Default.Wrap(err, "error creating DB blueprint's dependencies")
+		}
+	}
 	errors.Must(b.db.Delete(&models.BlueprintConnection{}, dal.Where("blueprint_id = ?", blueprint.ID)))
 	errors.Must(b.db.Delete(&models.BlueprintScope{}, dal.Where("blueprint_id = ?", blueprint.ID)))
 	for _, conn := range blueprint.Connections {
@@ -155,6 +174,9 @@ func (b *BlueprintManager) GetDbBlueprints(query *GetBlueprintQuery) ([]*models.
 	if query.Mode != "" {
 		clauses = append(clauses, dal.Where("mode = ?", query.Mode))
 	}
+	if query.ProjectNames != nil {
+		clauses = append(clauses, dal.Where("project_name IN ?", query.ProjectNames))
+	}
 
 	// count total records
 	// var count int64
@@ -250,6 +272,19 @@ func (b *BlueprintManager) GetDbBlueprintByProjectName(projectName string) (*mod
 	return dbBlueprint, nil
 }
 
+// GetDependentBlueprintIds returns the ids of the blueprints depending on the blueprint
+func (b *BlueprintManager) GetDependentBlueprintIds(blueprintId uint64) ([]uint64, errors.Error) {
+	var ids []uint64
+	err := b.db.Pluck(
+		"blueprint_id",
+		&ids, dal.From(&models.BlueprintDependency{}), dal.Where("depends_on_id = ?", blueprintId),
+	)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error getting the dependent blueprints")
+	}
+	return ids, nil
+}
+
 // DeleteBlueprint deletes a blueprint by its id
 func (b *BlueprintManager) DeleteBlueprint(id uint64) errors.Error {
 	var err errors.Error
@@ -266,6 +301,10 @@ func (b *BlueprintManager) DeleteBlueprint(id uint64) errors.Error {
 	if err != nil {
 		return err
 	}
+	err = tx.Delete(&models.BlueprintDependency{}, dal.Where("blueprint_id = ?", id))
+	if err != nil {
+		return err
+	}
 	err = tx.Delete(&models.Blueprint{
 		Model: common.Model{
 			ID: id,
@@ -286,6 +325,12 @@ func (b *BlueprintManager) fillBlueprintDetail(blueprint *models.Blueprint) erro
 			&blueprint.Labels, dal.From(&models.BlueprintLabel{}), dal.Where("blueprint_id = ?", blueprint.ID),
 		),
 	)
+	errors.Must(
+		b.db.Pluck(
+			"depends_on_id",
+			&blueprint.DependsOn, dal.From(&models.BlueprintDependency{}), dal.Where("blueprint_id = ?", blueprint.ID),
+		),
+	)
 	errors.Must(
 		b.db.All(
 			&blueprint.Connections,
@@ -0,0 +1,148 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package srvhelper
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/log"
+	"github.com/apache/incubator-devlake/core/models"
+)
+
+// how often the progress of a running job is saved
+var backgroundJobSaveInterval = time.Second
+
+// BackgroundJobSrvHelper processes long-running api operations item by item in the background and keeps track
+// of their progress in the database so that clients don't have to wait for (and time out on) the request
+type BackgroundJobSrvHelper struct {
+	db  dal.Dal
+	log log.Logger
+}
+
+func NewBackgroundJobSrvHelper(basicRes context.BasicRes) *BackgroundJobSrvHelper {
+	return &BackgroundJobSrvHelper{
+		db:  basicRes.GetDal(),
+		log: basicRes.GetLogger().Nested("background_job"),
+	}
+}
+
+// Start creates the job and processes the items identified by keys in a goroutine, an item failing is recorded
+// in the job and doesn't stop the others
+func (srv *BackgroundJobSrvHelper) Start(
+	pluginName string,
+	connectionId uint64,
+	jobType string,
+	keys []string,
+	process func(i int) errors.Error,
+) (*models.BackgroundJob, errors.Error) {
+	job := &models.BackgroundJob{
+		Plugin:       pluginName,
+		ConnectionId: connectionId,
+		Type:         jobType,
+		Status:       models.BACKGROUND_JOB_RUNNING,
+		Total:        len(keys),
+		Failures:     []*models.BackgroundJobFailure{},
+	}
+	if err := srv.db.Create(job); err != nil {
+		return nil, err
+	}
+	created := *job
+	go srv.run(job, keys, process)
+	return &created, nil
+}
+
+// List returns the latest jobs of the plugin and connection
+func (srv *BackgroundJobSrvHelper) List(pluginName string, connectionId uint64, limit int) ([]*models.BackgroundJob, errors.Error) {
+	jobs := make([]*models.BackgroundJob, 0)
+	err := srv.db.All(&jobs,
+		dal.Where("plugin = ? AND connection_id = ?", pluginName, connectionId),
+		dal.Orderby("id DESC"),
+		dal.Limit(limit),
+	)
+	return jobs, err
+}
+
+// Get returns the job of the plugin and connection
+func (srv *BackgroundJobSrvHelper) Get(pluginName string, connectionId uint64, jobId uint64) (*models.BackgroundJob, errors.Error) {
+	job := &models.BackgroundJob{}
+	err := srv.db.First(job, dal.Where("id = ? AND plugin = ? AND connection_id = ?", jobId, pluginName, connectionId))
+	if err != nil {
+		if srv.db.IsErrorNotFound(err) {
+			return nil, errors.NotFound.Wrap(err, fmt.Sprintf("background job %d not found", jobId))
+		}
+		return nil, err
+	}
+	return job, nil
+}
+
+func (srv *BackgroundJobSrvHelper) run(job *models.BackgroundJob, keys []string, process func(i int) errors.Error) {
+	defer func() {
+		if r := recover(); r != nil {
+			job.Status = models.BACKGROUND_JOB_FAILED
+			job.Message = fmt.Sprintf("%v", r)
+		}
+		finishedAt := time.Now()
+		job.FinishedAt = &finishedAt
+		srv.save(job)
+	}()
+	savedAt := time.Now()
+	for i, key := range keys {
+		if err := srv.processItem(i, process); err != nil {
+			job.Failures = append(job.Failures, &models.BackgroundJobFailure{Key: key, Message: err.Messages().Format()})
+		} else {
+			job.Succeeded++
+		}
+		job.Processed++
+		if time.Since(savedAt) >= backgroundJobSaveInterval {
+			srv.save(job)
+			savedAt = time.Now()
+		}
+	}
+	switch {
+	case len(job.Failures) == 0:
+		job.Status = models.BACKGROUND_JOB_DONE
+	case job.Succeeded == 0:
+		job.Status = models.BACKGROUND_JOB_FAILED
+	default:
+		job.Status = models.BACKGROUND_JOB_PARTIAL
+	}
+}
+
+// processItem turns the panic of a single item into its failure
+func (srv *BackgroundJobSrvHelper) processItem(i int, process func(i int) errors.Error) (err errors.Error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if e, ok := r.(error); ok {
+				err = errors.Convert(e)
+			} else {
+				err = errors.Default.New(fmt.Sprintf("%v", r))
+			}
+		}
+	}()
+	return process(i)
+}
+
+func (srv *BackgroundJobSrvHelper) save(job *models.BackgroundJob) {
+	if err := srv.db.Update(job); err != nil {
+		srv.log.Error(err, "failed to save the progress of background job %d", job.ID)
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package srvhelper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockcontext "github.com/apache/incubator-devlake/mocks/core/context"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newBackgroundJobTestHelper(mockDal *mockdal.Dal) *BackgroundJobSrvHelper {
+	mockRes := new(mockcontext.BasicRes)
+	mockRes.On("GetDal").Return(mockDal)
+	mockRes.On("GetLogger").Return(unithelper.DummyLogger())
+	return NewBackgroundJobSrvHelper(mockRes)
+}
+
+func TestBackgroundJobRun(t *testing.T) {
+	var saved models.BackgroundJob
+	mockDal := new(mockdal.Dal)
+	mockDal.On("Update", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		saved = *args.Get(0).(*models.BackgroundJob)
+	}).Return(nil)
+	srv := newBackgroundJobTestHelper(mockDal)
+
+	job := &models.BackgroundJob{Status: models.BACKGROUND_JOB_RUNNING, Total: 3}
+	srv.run(job, []string{"a", "b", "c"}, func(i int) errors.Error {
+		switch i {
+		case 1:
+			return errors.BadInput.New("invalid scope")
+		case 2:
+			panic("boom")
+		}
+		return nil
+	})
+	assert.Equal(t, models.BACKGROUND_JOB_PARTIAL, saved.Status)
+	assert.Equal(t, 3, saved.Processed)
+	assert.Equal(t, 1, saved.Succeeded)
+	assert.Equal(t, []*models.BackgroundJobFailure{
+		{Key: "b", Message: "invalid scope (400)"},
+		{Key: "c", Message: "boom"},
+	}, saved.Failures)
+	assert.NotNil(t, saved.FinishedAt)
+
+	job = &models.BackgroundJob{Status: models.BACKGROUND_JOB_RUNNING, Total: 1}
+	srv.run(job, []string{"a"}, func(i int) errors.Error { return nil })
+	assert.Equal(t, models.BACKGROUND_JOB_DONE, saved.Status)
+
+	job = &models.BackgroundJob{Status: models.BACKGROUND_JOB_RUNNING, Total: 1}
+	srv.run(job, []string{"a"}, func(i int) errors.Error { return errors.Default.New("failed") })
+	assert.Equal(t, models.BACKGROUND_JOB_FAILED, saved.Status)
+}
+
+func TestBackgroundJobGet(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	mockDal.On("First", mock.Anything, mock.Anything).Return(errors.NotFound.New("record not found"))
+	mockDal.On("IsErrorNotFound", mock.Anything).Return(true)
+	srv := newBackgroundJobTestHelper(mockDal)
+
+	_, err := srv.Get("github", 1, 2)
+	assert.Equal(t, errors.NotFound, err.GetType())
+}
+
+func TestBackgroundJobStart(t *testing.T) {
+	saved := make(chan models.BackgroundJob, 1)
+	mockDal := new(mockdal.Dal)
+	mockDal.On("Create", mock.Anything, mock.Anything).Return(nil)
+	mockDal.On("Update", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		saved <- *args.Get(0).(*models.BackgroundJob)
+	}).Return(nil)
+	srv := newBackgroundJobTestHelper(mockDal)
+
+	job, err := srv.Start("github", 1, "delete-scopes", []string{"a", "b"}, func(i int) errors.Error { return nil })
+	assert.Nil(t, err)
+	assert.Equal(t, models.BACKGROUND_JOB_RUNNING, job.Status)
+	assert.Equal(t, 2, job.Total)
+	finished := <-saved
+	assert.Equal(t, models.BACKGROUND_JOB_DONE, finished.Status)
+	assert.Equal(t, 2, finished.Succeeded)
+}
+
+func TestWaitForIdlePipelines(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	mockDal.On("Count", mock.Anything).Return(int64(1), nil).Twice()
+	mockDal.On("Count", mock.Anything).Return(int64(0), nil).Once()
+	srv := &ModelSrvHelper[models.BackgroundJob]{db: mockDal, log: unithelper.DummyLogger()}
+
+	assert.Nil(t, srv.waitForIdlePipelines(time.Now().Add(time.Minute), time.Millisecond))
+	mockDal.AssertNumberOfCalls(t, "Count", 3)
+
+	mockDal = new(mockdal.Dal)
+	mockDal.On("Count", mock.Anything).Return(int64(1), nil)
+	srv.db = mockDal
+	err := srv.waitForIdlePipelines(time.Now().Add(-time.Second), time.Millisecond)
+	assert.Equal(t, errors.Conflict, err.GetType())
+}
@@ -0,0 +1,261 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package srvhelper
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	SCHEMA_TYPE_OBJECT  = "object"
+	SCHEMA_TYPE_ARRAY   = "array"
+	SCHEMA_TYPE_STRING  = "string"
+	SCHEMA_TYPE_INTEGER = "integer"
+	SCHEMA_TYPE_NUMBER  = "number"
+	SCHEMA_TYPE_BOOLEAN = "boolean"
+
+	SCHEMA_FORMAT_REGEX     = "regex"
+	SCHEMA_FORMAT_DATE_TIME = "date-time"
+)
+
+// JsonSchema is the subset of the JSON schema (draft 7) needed to describe and validate plugin models
+type JsonSchema struct {
+	Type       string                 `json:"type,omitempty"`
+	Format     string                 `json:"format,omitempty"`
+	Enum       []string               `json:"enum,omitempty"`
+	Properties map[string]*JsonSchema `json:"properties,omitempty"`
+	Required   []string               `json:"required,omitempty"`
+	Items      *JsonSchema            `json:"items,omitempty"`
+}
+
+// SchemaViolation describes why a field of the request body doesn't satisfy the schema
+type SchemaViolation struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// GenerateJsonSchema builds the schema of the given model from its json tags, types and validation tags.
+// String fields named `xxxPattern` or tagged with `format:"regex"` must be valid regular expressions,
+// and the values listed in `enums:"a,b"` or `validate:"oneof=a b"` become enums.
+func GenerateJsonSchema(model interface{}) *JsonSchema {
+	return generateJsonSchema(reflect.TypeOf(model))
+}
+
+func generateJsonSchema(t reflect.Type) *JsonSchema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return &JsonSchema{Type: SCHEMA_TYPE_STRING}
+	case reflect.Bool:
+		return &JsonSchema{Type: SCHEMA_TYPE_BOOLEAN}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &JsonSchema{Type: SCHEMA_TYPE_INTEGER}
+	case reflect.Float32, reflect.Float64:
+		return &JsonSchema{Type: SCHEMA_TYPE_NUMBER}
+	case reflect.Slice, reflect.Array:
+		// []byte is typically a json document (i.e. datatypes.JSON), accept anything
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &JsonSchema{}
+		}
+		return &JsonSchema{Type: SCHEMA_TYPE_ARRAY, Items: generateJsonSchema(t.Elem())}
+	case reflect.Map:
+		return &JsonSchema{Type: SCHEMA_TYPE_OBJECT}
+	case reflect.Struct:
+		if t == timeType {
+			return &JsonSchema{Type: SCHEMA_TYPE_STRING, Format: SCHEMA_FORMAT_DATE_TIME}
+		}
+		schema := &JsonSchema{Type: SCHEMA_TYPE_OBJECT, Properties: make(map[string]*JsonSchema)}
+		addStructProperties(schema, t)
+		sort.Strings(schema.Required)
+		return schema
+	}
+	// interfaces and anything else can't be described
+	return &JsonSchema{}
+}
+
+func addStructProperties(schema *JsonSchema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		jsonTag := field.Tag.Get("json")
+		if jsonTag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(jsonTag, ",")
+		// embedded structs share the properties of their parent
+		if field.Anonymous && (name == "" || strings.Contains(options, "inline")) {
+			ft := field.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				addStructProperties(schema, ft)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		property := generateJsonSchema(field.Type)
+		if property.Type == SCHEMA_TYPE_STRING && property.Format == "" {
+			if format := field.Tag.Get("format"); format != "" {
+				property.Format = format
+			} else if strings.HasSuffix(field.Name, "Pattern") {
+				property.Format = SCHEMA_FORMAT_REGEX
+			}
+		}
+		for _, rule := range strings.Split(field.Tag.Get("validate"), ",") {
+			if rule == "required" {
+				schema.Required = append(schema.Required, name)
+			} else if strings.HasPrefix(rule, "oneof=") {
+				property.Enum = strings.Fields(strings.TrimPrefix(rule, "oneof="))
+			}
+		}
+		if enums := field.Tag.Get("enums"); enums != "" {
+			property.Enum = strings.Split(enums, ",")
+		}
+		schema.Properties[name] = property
+	}
+}
+
+// Validate checks the given json value against the schema, required properties are skipped when partial is true
+// (i.e. PATCH requests)
+func (schema *JsonSchema) Validate(value interface{}, partial bool) []*SchemaViolation {
+	var violations []*SchemaViolation
+	schema.validate("", value, partial, &violations)
+	return violations
+}
+
+func (schema *JsonSchema) validate(path string, value interface{}, partial bool, violations *[]*SchemaViolation) {
+	if value == nil || schema.Type == "" {
+		return
+	}
+	violate := func(message string, args ...interface{}) {
+		*violations = append(*violations, &SchemaViolation{Field: path, Message: fmt.Sprintf(message, args...)})
+	}
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	switch schema.Type {
+	case SCHEMA_TYPE_STRING:
+		if v.Kind() != reflect.String {
+			violate("must be a string")
+			return
+		}
+		s := v.String()
+		if len(schema.Enum) > 0 && s != "" && !contains(schema.Enum, s) {
+			violate("must be one of [%s]", strings.Join(schema.Enum, ", "))
+		}
+		switch schema.Format {
+		case SCHEMA_FORMAT_REGEX:
+			if _, err := regexp.Compile(s); err != nil {
+				violate("must be a valid regular expression: %s", err.Error())
+			}
+		case SCHEMA_FORMAT_DATE_TIME:
+			if _, err := time.Parse(time.RFC3339, s); s != "" && err != nil {
+				violate("must be a RFC3339 date-time")
+			}
+		}
+	case SCHEMA_TYPE_BOOLEAN:
+		if v.Kind() != reflect.Bool {
+			violate("must be a boolean")
+		}
+	case SCHEMA_TYPE_INTEGER:
+		if v.CanInt() || v.CanUint() {
+			return
+		}
+		if !v.CanFloat() || v.Float() != math.Trunc(v.Float()) {
+			violate("must be an integer")
+		}
+	case SCHEMA_TYPE_NUMBER:
+		if !v.CanInt() && !v.CanUint() && !v.CanFloat() {
+			violate("must be a number")
+		}
+	case SCHEMA_TYPE_ARRAY:
+		if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+			violate("must be an array")
+			return
+		}
+		if schema.Items == nil {
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			schema.Items.validate(fmt.Sprintf("%s[%d]", path, i), v.Index(i).Interface(), partial, violations)
+		}
+	case SCHEMA_TYPE_OBJECT:
+		if v.Kind() != reflect.Map || v.Type().Key().Kind() != reflect.String {
+			violate("must be an object")
+			return
+		}
+		if !partial {
+			for _, name := range schema.Required {
+				if mv := v.MapIndex(reflect.ValueOf(name)); !mv.IsValid() || isEmptyJsonValue(mv.Interface()) {
+					*violations = append(*violations, &SchemaViolation{Field: joinSchemaPath(path, name), Message: "is required"})
+				}
+			}
+		}
+		// unknown properties are ignored so clients can post back what they received
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+		for _, key := range keys {
+			if property, ok := schema.Properties[key.String()]; ok {
+				property.validate(joinSchemaPath(path, key.String()), v.MapIndex(key).Interface(), partial, violations)
+			}
+		}
+	}
+}
+
+func isEmptyJsonValue(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	return reflect.ValueOf(value).IsZero()
+}
+
+func joinSchemaPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package srvhelper
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/stretchr/testify/assert"
+)
+
+type testScopeConfig struct {
+	common.ScopeConfig `mapstructure:",squash" json:",inline" gorm:"embedded"`
+	DeploymentPattern  string            `json:"deploymentPattern"`
+	IssueStatus        string            `json:"issueStatus" validate:"omitempty,oneof=TODO DONE"`
+	Severity           string            `json:"severity" enums:"low,high"`
+	Timeout            int               `json:"timeout"`
+	Enabled            bool              `json:"enabled"`
+	Paths              []string          `json:"paths"`
+	Refdiff            map[string]string `json:"refdiff"`
+	Internal           string            `json:"-"`
+}
+
+func TestGenerateJsonSchema(t *testing.T) {
+	schema := GenerateJsonSchema(&testScopeConfig{})
+	assert.Equal(t, SCHEMA_TYPE_OBJECT, schema.Type)
+	assert.Equal(t, []string{"connectionId", "name"}, schema.Required)
+	assert.Equal(t, &JsonSchema{Type: SCHEMA_TYPE_STRING, Format: SCHEMA_FORMAT_REGEX}, schema.Properties["deploymentPattern"])
+	assert.Equal(t, []string{"TODO", "DONE"}, schema.Properties["issueStatus"].Enum)
+	assert.Equal(t, []string{"low", "high"}, schema.Properties["severity"].Enum)
+	assert.Equal(t, SCHEMA_TYPE_INTEGER, schema.Properties["timeout"].Type)
+	assert.Equal(t, SCHEMA_TYPE_BOOLEAN, schema.Properties["enabled"].Type)
+	assert.Equal(t, &JsonSchema{Type: SCHEMA_TYPE_ARRAY, Items: &JsonSchema{Type: SCHEMA_TYPE_STRING}}, schema.Properties["paths"])
+	assert.Equal(t, SCHEMA_TYPE_OBJECT, schema.Properties["refdiff"].Type)
+	assert.Equal(t, &JsonSchema{Type: SCHEMA_TYPE_STRING, Format: SCHEMA_FORMAT_DATE_TIME}, schema.Properties["createdAt"])
+	assert.Contains(t, schema.Properties, "entities")
+	assert.NotContains(t, schema.Properties, "Internal")
+}
+
+func TestJsonSchemaValidate(t *testing.T) {
+	schema := GenerateJsonSchema(&testScopeConfig{})
+
+	assert.Empty(t, schema.Validate(map[string]interface{}{
+		"connectionId":      uint64(1),
+		"name":              "default",
+		"deploymentPattern": "(?i)deploy",
+		"issueStatus":       "DONE",
+		"timeout":           float64(30),
+		"paths":             []interface{}{"src/**"},
+		"refdiff":           map[string]interface{}{"tagsLimit": float64(10)},
+		"unknown":           "ignored",
+	}, false))
+
+	assert.Equal(t, []*SchemaViolation{
+		{Field: "name", Message: "is required"},
+		{Field: "deploymentPattern", Message: "must be a valid regular expression: error parsing regexp: missing closing ): `(deploy`"},
+		{Field: "enabled", Message: "must be a boolean"},
+		{Field: "issueStatus", Message: "must be one of [TODO, DONE]"},
+		{Field: "paths[1]", Message: "must be a string"},
+		{Field: "timeout", Message: "must be an integer"},
+	}, schema.Validate(map[string]interface{}{
+		"connectionId":      float64(1),
+		"deploymentPattern": "(deploy",
+		"enabled":           "yes",
+		"issueStatus":       "CLOSED",
+		"paths":             []interface{}{"src/**", float64(1)},
+		"timeout":           1.5,
+	}, false))
+
+	// required properties are not checked for partial updates
+	assert.Empty(t, schema.Validate(map[string]interface{}{"severity": "high"}, true))
+}
@@ -214,3 +214,18 @@ func (srv *ModelSrvHelper[M]) NoRunningPipeline(fn func(tx dal.Transaction) erro
 	err = fn(nestedTX)
 	return
 }
+
+// waitForIdlePipelines polls the pipelines without locking them until none is running
+func (srv *ModelSrvHelper[M]) waitForIdlePipelines(deadline time.Time, interval time.Duration) errors.Error {
+	for {
+		count, err := srv.db.Count(dal.From(&models.Pipeline{}), dal.Where("status = ?", models.TASK_RUNNING))
+		if err != nil || count == 0 {
+			return err
+		}
+		if time.Now().After(deadline) {
+			return errors.Conflict.New(fmt.Sprintf("%d pipelines are still running", count))
+		}
+		srv.log.Info("waiting for %d running pipelines to finish", count)
+		time.Sleep(interval)
+	}
+}
@@ -38,6 +38,7 @@ func (NoScopeConfig) ScopeConfigConnectionId() uint64 { return 0 }
 // ScopeConfigSrvHelper
 type ScopeConfigSrvHelper[C plugin.ToolLayerConnection, S plugin.ToolLayerScope, SC plugin.ToolLayerScopeConfig] struct {
 	*ModelSrvHelper[SC]
+	schema *JsonSchema
 }
 
 func NewScopeConfigSrvHelper[
@@ -47,9 +48,21 @@ func NewScopeConfigSrvHelper[
 ](basicRes context.BasicRes, searchColumns []string) *ScopeConfigSrvHelper[C, S, SC] {
 	return &ScopeConfigSrvHelper[C, S, SC]{
 		ModelSrvHelper: NewModelSrvHelper[SC](basicRes, searchColumns),
+		schema:         GenerateJsonSchema(new(SC)),
 	}
 }
 
+// GetSchema returns the json schema of the scope config
+func (scopeConfigSrv *ScopeConfigSrvHelper[C, S, SC]) GetSchema() *JsonSchema {
+	return scopeConfigSrv.schema
+}
+
+// ValidateBody checks the request body against the json schema of the scope config so that invalid
+// settings (i.e. broken regular expressions) are rejected by the api instead of failing the pipeline later
+func (scopeConfigSrv *ScopeConfigSrvHelper[C, S, SC]) ValidateBody(body map[string]interface{}, partial bool) []*SchemaViolation {
+	return scopeConfigSrv.schema.Validate(body, partial)
+}
+
 func (scopeConfigSrv *ScopeConfigSrvHelper[C, S, SC]) GetAllByConnectionId(connectionId uint64) ([]*SC, errors.Error) {
 	var scopeConfigs []*SC
 	err := scopeConfigSrv.db.All(&scopeConfigs,
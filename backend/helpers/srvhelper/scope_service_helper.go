@@ -22,6 +22,7 @@ import (
 	"reflect"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/dal"
@@ -43,6 +44,12 @@ type ScopeDetail[S plugin.ToolLayerScope, SC plugin.ToolLayerScopeConfig] struct
 	Blueprints  []*models.Blueprint `json:"blueprints,omitempty"`
 }
 
+var (
+	// how often a deletion waiting for the running pipelines checks them, and how long it may wait at most
+	scopeDeletionRetryInterval = 10 * time.Second
+	scopeDeletionMaxWait       = 6 * time.Hour
+)
+
 type ScopeSrvHelper[C plugin.ToolLayerConnection, S plugin.ToolLayerScope, SC plugin.ToolLayerScopeConfig] struct {
 	*ModelSrvHelper[S]
 	pluginName string
@@ -188,6 +195,79 @@ func (scopeSrv *ScopeSrvHelper[C, S, SC]) DeleteScope(scope *S, dataOnly bool) (
 	return
 }
 
+// SetScopeConfig attaches the scope config to the given scopes of the connection in one go, scopeConfigId 0 detaches
+// whatever scope config they have
+func (scopeSrv *ScopeSrvHelper[C, S, SC]) SetScopeConfig(connectionId uint64, scopeIds []string, scopeConfigId uint64) errors.Error {
+	if len(scopeIds) == 0 {
+		return errors.BadInput.New("scopeIds is required")
+	}
+	if scopeConfigId > 0 {
+		count, err := scopeSrv.db.Count(dal.From(new(SC)), dal.Where("id = ? AND connection_id = ?", scopeConfigId, connectionId))
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return errors.BadInput.New(fmt.Sprintf("scope config %d not found in connection %d", scopeConfigId, connectionId))
+		}
+	}
+	scopeIdColumn, err := scopeSrv.getScopeIdColumn()
+	if err != nil {
+		return err
+	}
+	var existingIds []string
+	err = scopeSrv.db.Pluck(scopeIdColumn, &existingIds,
+		dal.From(new(S)),
+		dal.Where(fmt.Sprintf("connection_id = ? AND %s IN ?", scopeIdColumn), connectionId, scopeIds),
+	)
+	if err != nil {
+		return err
+	}
+	if len(existingIds) < len(scopeIds) {
+		existing := make(map[string]bool, len(existingIds))
+		for _, id := range existingIds {
+			existing[id] = true
+		}
+		var missing []string
+		for _, id := range scopeIds {
+			if !existing[id] {
+				missing = append(missing, id)
+			}
+		}
+		return errors.BadInput.New(fmt.Sprintf("scopes not found: %s", strings.Join(missing, ", ")))
+	}
+	return scopeSrv.db.UpdateColumn(
+		new(S), "scope_config_id", scopeConfigId,
+		dal.Where(fmt.Sprintf("connection_id = ? AND %s IN ?", scopeIdColumn), connectionId, scopeIds),
+	)
+}
+
+// getScopeIdColumn returns the primary key column of the scope model other than the connection_id
+func (scopeSrv *ScopeSrvHelper[C, S, SC]) getScopeIdColumn() (string, errors.Error) {
+	for _, pk := range scopeSrv.pk {
+		if pk.Name() != "connection_id" {
+			return pk.Name(), nil
+		}
+	}
+	return "", errors.Internal.New("Scope model should have 2 primary key fields")
+}
+
+// DeleteScopeWhenIdle is like DeleteScope, but waits for the running pipelines to finish instead of failing,
+// it is meant to be called by background jobs. The pipelines table is only locked by the deletion itself, the
+// waiting doesn't block anything
+func (scopeSrv *ScopeSrvHelper[C, S, SC]) DeleteScopeWhenIdle(scope *S, dataOnly bool) errors.Error {
+	deadline := time.Now().Add(scopeDeletionMaxWait)
+	for {
+		if err := scopeSrv.waitForIdlePipelines(deadline, scopeDeletionRetryInterval); err != nil {
+			return err
+		}
+		refs, err := scopeSrv.DeleteScope(scope, dataOnly)
+		// referenced by blueprints, waiting won't help
+		if err == nil || refs != nil || err.GetType() != errors.Conflict || time.Now().After(deadline) {
+			return err
+		}
+	}
+}
+
 func (scopeSrv *ScopeSrvHelper[C, S, SC]) getScopeConfig(scopeConfigId uint64) *SC {
 	if scopeConfigId < 1 {
 		return nil
@@ -241,8 +321,8 @@ func (scopeSrv *ScopeSrvHelper[C, S, SC]) deleteScopeData(scope plugin.ToolLayer
 			params = []interface{}{rawDataParams}
 		} else {
 			// framework tables: should check plugin, connection and scope
-			if table == (models.CollectorLatestState{}.TableName()) {
-				// diff sync state
+			if table == (models.CollectorLatestState{}.TableName()) || table == (models.ApiHttpCache{}.TableName()) {
+				// diff sync state and http cache
 				where = "raw_data_table LIKE ? AND raw_data_params = ?"
 			} else {
 				// domain layer table
@@ -299,7 +379,7 @@ func (scopeSrv *ScopeSrvHelper[C, S, SC]) getAffectedTables() ([]string, errors.
 			}
 		}
 		// additional tables
-		tables = append(tables, models.CollectorLatestState{}.TableName())
+		tables = append(tables, models.CollectorLatestState{}.TableName(), models.ApiHttpCache{}.TableName())
 	}
 	scopeSrv.log.Debug("Discovered %d tables used by plugin \"%s\": %v", len(tables), scopeSrv.pluginName, tables)
 	return tables, nil
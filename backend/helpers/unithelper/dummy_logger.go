@@ -30,7 +30,7 @@ func DummyLogger() *mocklog.Logger {
 	logger.On("Log", mock.Anything, mock.Anything, mock.Anything).Maybe()
 	logger.On("Debug", mock.Anything, mock.Anything).Maybe()
 	logger.On("Info", mock.Anything, mock.Anything).Maybe()
-	logger.On("Warn", mock.Anything, mock.Anything).Maybe()
+	logger.On("Warn", mock.Anything, mock.Anything, mock.Anything).Maybe()
 	logger.On("Error", mock.Anything, mock.Anything, mock.Anything).Maybe()
 	logger.On("Nested", mock.Anything).Return(logger).Maybe()
 	return logger
@@ -31,6 +31,7 @@ func DummySubTaskContext(db dal.Dal) *mockplugin.SubTaskContext {
 	mockCtx.On("SetProgress", mock.Anything, mock.Anything)
 	mockCtx.On("IncProgress", mock.Anything, mock.Anything)
 	mockCtx.On("GetName").Return("test")
+	mockCtx.On("GetConfig", mock.Anything).Return("").Maybe()
 	mockTaskContext := new(mockplugin.TaskContext)
 	mockTaskContext.On("SyncPolicy").Return(nil)
 	mockCtx.On("TaskContext").Return(mockTaskContext)
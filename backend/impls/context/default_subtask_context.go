@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/metrics"
 	"github.com/apache/incubator-devlake/core/models"
 	"github.com/apache/incubator-devlake/core/plugin"
 )
@@ -44,6 +45,9 @@ func (c *DefaultSubTaskContext) SetProgress(current int, total int) {
 // IncProgress FIXME ...
 func (c *DefaultSubTaskContext) IncProgress(quantity int) {
 	c.defaultExecContext.IncProgress(plugin.SubTaskIncProgress, quantity)
+	if c.taskCtx != nil && quantity > 0 {
+		metrics.SubtaskRecords.WithLabelValues(c.taskCtx.GetName(), c.GetName()).Add(float64(quantity))
+	}
 	if c.LastProgressTime.IsZero() || c.LastProgressTime.Add(3*time.Second).Before(time.Now()) || c.current%1000 == 0 {
 		c.LastProgressTime = time.Now()
 		c.BasicRes.GetLogger().Info("finished records: %d(not exactly)", c.current)
@@ -52,6 +56,11 @@ func (c *DefaultSubTaskContext) IncProgress(quantity int) {
 	}
 }
 
+// SetContext replaces the context of the subtask, e.g. with one carrying the span of the subtask
+func (c *DefaultSubTaskContext) SetContext(ctx gocontext.Context) {
+	c.defaultExecContext.ctx = ctx
+}
+
 // TaskContext FIXME ...
 func (c *DefaultSubTaskContext) TaskContext() plugin.TaskContext {
 	if c.taskCtx == nil {
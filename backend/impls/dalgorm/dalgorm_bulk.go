@@ -0,0 +1,227 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dalgorm
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// maxPlaceholders is the max number of placeholders MySQL accepts in a prepared statement
+const maxPlaceholders = 65535
+
+// BulkCreateOrUpdate creates or updates the slice of records with the fastest way the database offers:
+// Postgres COPYs the records into a temporary table and upserts them into the target table with one
+// INSERT ... ON CONFLICT, others upsert with multi-row INSERT ... ON DUPLICATE KEY chunked under the placeholder limit.
+// The records must not share the same primary key
+func (d *Dalgorm) BulkCreateOrUpdate(entities interface{}, clauses ...dal.Clause) errors.Error {
+	d.unwrapDynamic(&entities, &clauses)
+	rows := reflect.Indirect(reflect.ValueOf(entities))
+	if rows.Kind() != reflect.Slice {
+		return d.CreateOrUpdate(entities, clauses...)
+	}
+	if rows.Len() == 0 {
+		return nil
+	}
+	stmt := &gorm.Statement{DB: d.db}
+	if err := stmt.Parse(rows.Index(0).Interface()); err != nil {
+		return errors.Default.Wrap(err, "failed to parse the schema of the records")
+	}
+	// COPY needs its own connection, the records saved in a transaction are upserted by the transaction instead
+	if sqlDB, ok := d.db.Statement.ConnPool.(*sql.DB); ok && d.Dialect() == "postgres" && copyable(stmt.Schema) {
+		copied, err := d.copyAndUpsert(sqlDB, stmt.Schema, bulkTableName(stmt.Schema, clauses), rows)
+		if err != nil || copied {
+			return err
+		}
+	}
+	chunkSize := bulkChunkSize(len(stmt.Schema.DBNames))
+	for start := 0; start < rows.Len(); start += chunkSize {
+		end := start + chunkSize
+		if end > rows.Len() {
+			end = rows.Len()
+		}
+		err := d.CreateOrUpdate(rows.Slice(start, end).Interface(), clauses...)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyable tells whether the records can be COPYed, the auto increment primary keys are left to the database by
+// INSERT but COPY would write them as they are
+func copyable(s *schema.Schema) bool {
+	if len(s.PrimaryFields) == 0 {
+		return false
+	}
+	for _, field := range s.PrimaryFields {
+		if field.AutoIncrement {
+			return false
+		}
+	}
+	return true
+}
+
+// bulkChunkSize returns how many records with the number of columns can be inserted by a statement
+func bulkChunkSize(columns int) int {
+	if columns == 0 {
+		return maxPlaceholders
+	}
+	size := maxPlaceholders / columns
+	if size < 1 {
+		return 1
+	}
+	return size
+}
+
+// bulkTableName returns the table specified by the dal.From clause, or the table of the schema
+func bulkTableName(s *schema.Schema, clauses []dal.Clause) string {
+	for _, c := range clauses {
+		if c.Type == dal.FromClause {
+			if table, ok := c.Data.(string); ok {
+				return table
+			}
+		}
+	}
+	return s.Table
+}
+
+// copyAndUpsert COPYs the records into a temporary table and upserts them into the table in a transaction,
+// false is returned if the connection isn't made by pgx which supports the COPY protocol
+func (d *Dalgorm) copyAndUpsert(sqlDB *sql.DB, s *schema.Schema, table string, rows reflect.Value) (bool, errors.Error) {
+	ctx := d.db.Statement.Context
+	var fields []*schema.Field
+	var columns []string
+	for _, dbName := range s.DBNames {
+		field := s.FieldsByDBName[dbName]
+		if field.Creatable {
+			fields = append(fields, field)
+			columns = append(columns, dbName)
+		}
+	}
+	values, err := bulkValues(d.db, fields, rows, time.Now())
+	if err != nil {
+		return false, err
+	}
+	conn, e := sqlDB.Conn(ctx)
+	if e != nil {
+		return false, errors.Default.Wrap(e, "failed to get a connection for COPY")
+	}
+	defer conn.Close()
+	copied := false
+	e = conn.Raw(func(driverConn interface{}) error {
+		stdConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return nil
+		}
+		copied = true
+		tx, err := stdConn.Conn().Begin(ctx)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = tx.Rollback(ctx) }()
+		tmp := fmt.Sprintf("_devlake_bulk_%s", table)
+		_, err = tx.Exec(ctx, fmt.Sprintf("CREATE TEMPORARY TABLE %s (LIKE %s INCLUDING DEFAULTS) ON COMMIT DROP",
+			pgx.Identifier{tmp}.Sanitize(), pgx.Identifier{table}.Sanitize()))
+		if err != nil {
+			return err
+		}
+		_, err = tx.CopyFrom(ctx, pgx.Identifier{tmp}, columns, pgx.CopyFromRows(values))
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(ctx, upsertFromSql(table, tmp, fields))
+		if err != nil {
+			return err
+		}
+		return tx.Commit(ctx)
+	})
+	if e != nil {
+		return copied, errors.Default.Wrap(e, fmt.Sprintf("failed to COPY records into %s", table))
+	}
+	return copied, nil
+}
+
+// bulkValues converts the records into the column values of the fields, the auto create/update time
+// fields are filled like gorm does on creation
+func bulkValues(db *gorm.DB, fields []*schema.Field, rows reflect.Value, now time.Time) ([][]interface{}, errors.Error) {
+	ctx := db.Statement.Context
+	values := make([][]interface{}, rows.Len())
+	for i := 0; i < rows.Len(); i++ {
+		rv := reflect.Indirect(rows.Index(i))
+		values[i] = make([]interface{}, len(fields))
+		for j, field := range fields {
+			value, zero := field.ValueOf(ctx, rv)
+			if zero && (field.AutoCreateTime > 0 || field.AutoUpdateTime > 0) {
+				if err := field.Set(ctx, rv, now); err != nil {
+					return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to set %s", field.Name))
+				}
+				value, _ = field.ValueOf(ctx, rv)
+			}
+			if pv := reflect.ValueOf(value); pv.Kind() == reflect.Ptr && pv.IsNil() {
+				value = nil
+			} else if valuer, ok := value.(driver.Valuer); ok {
+				v, err := valuer.Value()
+				if err != nil {
+					return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to get the value of %s", field.Name))
+				}
+				value = v
+			}
+			values[i][j] = value
+		}
+	}
+	return values, nil
+}
+
+// upsertFromSql builds the statement moving the records from the temporary table into the table, the columns other
+// than the primary keys and the auto create time get updated on conflict, just like gorm's `OnConflict{UpdateAll: true}`
+func upsertFromSql(table string, tmp string, fields []*schema.Field) string {
+	var columns, primaryKeys, updates []string
+	for _, field := range fields {
+		column := pgx.Identifier{field.DBName}.Sanitize()
+		columns = append(columns, column)
+		if field.PrimaryKey {
+			primaryKeys = append(primaryKeys, column)
+		} else if field.AutoCreateTime == 0 {
+			updates = append(updates, fmt.Sprintf("%s = EXCLUDED.%s", column, column))
+		}
+	}
+	onConflict := "DO NOTHING"
+	if len(updates) > 0 {
+		onConflict = "DO UPDATE SET " + strings.Join(updates, ", ")
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM %s ON CONFLICT (%s) %s",
+		pgx.Identifier{table}.Sanitize(),
+		strings.Join(columns, ", "),
+		strings.Join(columns, ", "),
+		pgx.Identifier{tmp}.Sanitize(),
+		strings.Join(primaryKeys, ", "),
+		onConflict,
+	)
+}
@@ -0,0 +1,143 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dalgorm
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+type bulkRecord struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	Id           string `gorm:"primaryKey"`
+	Name         string
+	ClosedAt     *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type bulkAutoIncrementRecord struct {
+	Id   uint64 `gorm:"primaryKey;autoIncrement"`
+	Name string
+}
+
+func TestBulkCreateOrUpdate(t *testing.T) {
+	// the statements are built but not executed
+	db, err := gorm.Open(
+		mysql.New(mysql.Config{DSN: "devlake:devlake@tcp(127.0.0.1:1)/devlake", SkipInitializeWithVersion: true}),
+		&gorm.Config{DryRun: true, SkipDefaultTransaction: true, DisableAutomaticPing: true},
+	)
+	assert.Nil(t, err)
+	type statement struct {
+		table string
+		rows  int
+	}
+	var statements []statement
+	assert.Nil(t, db.Callback().Create().After("gorm:create").Register("test:bulk", func(tx *gorm.DB) {
+		statements = append(statements, statement{tx.Statement.Table, tx.Statement.ReflectValue.Len()})
+	}))
+	records := make([]*bulkRecord, 25000)
+	for i := range records {
+		records[i] = &bulkRecord{ConnectionId: 1, Id: fmt.Sprintf("%d", i)}
+	}
+
+	// the records are chunked under the placeholder limit of MySQL, 6 columns each
+	assert.Nil(t, NewDalgorm(db).BulkCreateOrUpdate(records))
+	assert.Equal(t, []statement{{"bulk_records", 10922}, {"bulk_records", 10922}, {"bulk_records", 3156}}, statements)
+
+	statements = nil
+	assert.Nil(t, NewDalgorm(db).BulkCreateOrUpdate(records[:2], dal.From("other_records")))
+	assert.Equal(t, []statement{{"other_records", 2}}, statements)
+
+	statements = nil
+	assert.Nil(t, NewDalgorm(db).BulkCreateOrUpdate([]*bulkRecord{}))
+	assert.Empty(t, statements)
+}
+
+func parseBulkSchema(t *testing.T, model interface{}) *schema.Schema {
+	s, err := schema.Parse(model, &sync.Map{}, schema.NamingStrategy{})
+	assert.Nil(t, err)
+	return s
+}
+
+func TestCopyable(t *testing.T) {
+	// COPY would write the auto increment keys as they are instead of leaving them to the database
+	assert.True(t, copyable(parseBulkSchema(t, &bulkRecord{})))
+	assert.False(t, copyable(parseBulkSchema(t, &bulkAutoIncrementRecord{})))
+}
+
+func TestUpsertFromSql(t *testing.T) {
+	s := parseBulkSchema(t, &bulkRecord{})
+	var fields []*schema.Field
+	for _, dbName := range s.DBNames {
+		fields = append(fields, s.FieldsByDBName[dbName])
+	}
+	tests := []struct {
+		name   string
+		fields []*schema.Field
+		want   string
+	}{
+		{
+			"the created_at is kept on conflict",
+			fields,
+			`INSERT INTO "bulk_records" ("connection_id", "id", "name", "closed_at", "created_at", "updated_at") ` +
+				`SELECT "connection_id", "id", "name", "closed_at", "created_at", "updated_at" FROM "_tmp" ` +
+				`ON CONFLICT ("connection_id", "id") DO UPDATE SET "name" = EXCLUDED."name", "closed_at" = EXCLUDED."closed_at", "updated_at" = EXCLUDED."updated_at"`,
+		},
+		{
+			"nothing to update",
+			s.PrimaryFields,
+			`INSERT INTO "bulk_records" ("connection_id", "id") SELECT "connection_id", "id" FROM "_tmp" ON CONFLICT ("connection_id", "id") DO NOTHING`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, upsertFromSql("bulk_records", "_tmp", tt.fields))
+		})
+	}
+}
+
+func TestBulkValues(t *testing.T) {
+	s := parseBulkSchema(t, &bulkRecord{})
+	var fields []*schema.Field
+	for _, dbName := range s.DBNames {
+		fields = append(fields, s.FieldsByDBName[dbName])
+	}
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	createdAt := now.Add(-time.Hour)
+	records := []*bulkRecord{
+		{ConnectionId: 1, Id: "a", Name: "foo", CreatedAt: createdAt},
+		{ConnectionId: 1, Id: "b", Name: "bar", ClosedAt: &now},
+	}
+	db := &gorm.DB{Statement: &gorm.Statement{Context: context.Background()}}
+	values, err := bulkValues(db, fields, reflect.ValueOf(records), now)
+	assert.Nil(t, err)
+	// the auto create and update times are filled like gorm does, nil pointers are written as NULL
+	assert.Equal(t, []interface{}{uint64(1), "a", "foo", nil, createdAt, now}, values[0])
+	assert.Equal(t, []interface{}{uint64(1), "b", "bar", &now, now, now}, values[1])
+}
@@ -67,14 +67,17 @@ func (AeConnection) TableName() string {
 
 func (connection AeConnection) Sanitize() AeConnection {
 	connection.AeAppKey.SecretKey = utils.SanitizeString(connection.AeAppKey.SecretKey)
+	connection.SanitizeProxy()
 	return connection
 }
 
 func (connection *AeConnection) MergeFromRequest(target *AeConnection, body map[string]interface{}) error {
 	secretKey := target.SecretKey
+	proxy := target.Proxy
 	if err := helper.DecodeMapStruct(body, target, true); err != nil {
 		return err
 	}
+	target.RestoreSanitizedProxy(proxy)
 	modifiedSecretKey := target.SecretKey
 	if modifiedSecretKey == "" || modifiedSecretKey == utils.SanitizeString(secretKey) {
 		target.SecretKey = secretKey
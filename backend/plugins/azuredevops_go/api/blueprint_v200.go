@@ -18,7 +18,6 @@ limitations under the License.
 package api
 
 import (
-	"net/url"
 
 	"golang.org/x/exp/slices"
 
@@ -168,23 +167,21 @@ func makePipelinePlanV200(
 
 		// collect git data by gitextractor if CODE was requested
 		if utils.StringsContains(scopeConfig.Entities, plugin.DOMAIN_TYPE_CODE) && !scope.Scope.IsPrivate || len(scopeConfig.Entities) == 0 {
-			cloneUrl, err := errors.Convert01(url.Parse(azuredevopsRepo.RemoteUrl))
-			if err != nil {
-				return nil, err
+			gitextOpts := map[string]interface{}{
+				"url":            azuredevopsRepo.RemoteUrl,
+				"name":           azuredevopsRepo.Name,
+				"repoId":         didgen.NewDomainIdGenerator(&models.AzuredevopsRepo{}).Generate(connection.ID, azuredevopsRepo.Id),
+				"proxy":          connection.Proxy,
+				"noShallowClone": true,
 			}
-
+			// the token is injected by gitextractor at clone time, and only for the repos hosted by azure devops
 			if scope.Scope.Type == models.RepositoryTypeADO {
-				cloneUrl.User = url.UserPassword("git", connection.Token)
+				gitextOpts["connectionId"] = connection.ID
+				gitextOpts["pluginName"] = "azuredevops_go"
 			}
 			stage = append(stage, &coreModels.PipelineTask{
-				Plugin: "gitextractor",
-				Options: map[string]interface{}{
-					"url":            cloneUrl.String(),
-					"name":           azuredevopsRepo.Name,
-					"repoId":         didgen.NewDomainIdGenerator(&models.AzuredevopsRepo{}).Generate(connection.ID, azuredevopsRepo.Id),
-					"proxy":          connection.Proxy,
-					"noShallowClone": true,
-				},
+				Plugin:  "gitextractor",
+				Options: gitextOpts,
 			})
 		}
 
@@ -109,3 +109,14 @@ func GetProjectsByScopeConfig(input *plugin.ApiResourceInput) (*plugin.ApiResour
 func DeleteScopeConfig(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
 	return dsHelper.ScopeConfigApi.Delete(input)
 }
+
+// GetScopeConfigSchema return the json schema of the scope config
+// @Summary return the json schema of the scope config
+// @Description return the json schema of the scope config for Azure DevOps, which is enforced when creating or updating scope configs
+// @Tags plugins/azuredevops
+// @Success 200  {object} srvhelper.JsonSchema
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/azuredevops/scope-config-schema [GET]
+func GetScopeConfigSchema(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return dsHelper.ScopeConfigApi.GetSchema(input)
+}
@@ -223,8 +223,16 @@ func (p Azuredevops) ApiResources() map[string]map[string]plugin.ApiResourceHand
 			"DELETE": api.DeleteScope,
 		},
 		"connections/:connectionId/scopes": {
-			"GET": api.GetScopes,
-			"PUT": api.PutScopes,
+			"GET":    api.GetScopes,
+			"PUT":    api.PutScopes,
+			"PATCH":  api.PatchScopes,
+			"DELETE": api.DeleteScopes,
+		},
+		"connections/:connectionId/scope-jobs": {
+			"GET": api.GetScopeJobs,
+		},
+		"connections/:connectionId/scope-jobs/:jobId": {
+			"GET": api.GetScopeJob,
 		},
 		"connections/:connectionId/scope-configs": {
 			"POST": api.PostScopeConfig,
@@ -247,6 +255,9 @@ func (p Azuredevops) ApiResources() map[string]map[string]plugin.ApiResourceHand
 		"scope-config/:scopeConfigId/projects": {
 			"GET": api.GetProjectsByScopeConfig,
 		},
+		"scope-config-schema": {
+			"GET": api.GetScopeConfigSchema,
+		},
 	}
 }
 
@@ -265,3 +276,12 @@ func (p Azuredevops) Close(taskCtx plugin.TaskContext) errors.Error {
 	data.ApiClient.Release()
 	return nil
 }
+
+func (p Azuredevops) GetDynamicGitCredential(taskCtx plugin.TaskContext, connectionId uint64) (string, string, errors.Error) {
+	connection := &models.AzuredevopsConnection{}
+	err := helper.NewConnectionHelper(taskCtx, nil, p.Name()).FirstById(connection, connectionId)
+	if err != nil {
+		return "", "", errors.Default.Wrap(err, "unable to get azuredevops connection by the given connection ID")
+	}
+	return "git", connection.Token, nil
+}
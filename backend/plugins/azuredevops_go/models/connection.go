@@ -54,7 +54,7 @@ type AzuredevopsConn struct {
 	AzuredevopsAccessToken `mapstructure:",squash"`
 	Organization           string `json:"organization"`
 	//Endpoint         string `mapstructure:"endpoint" json:"endpoint"`
-	Proxy string `mapstructure:"proxy" json:"proxy"`
+	Proxy string `mapstructure:"proxy" json:"proxy" gorm:"serializer:encdec"`
 	//RateLimitPerHour int    `comment:"api request rate limit per hour" json:"rateLimitPerHour"`
 }
 
@@ -66,6 +66,16 @@ func (conn *AzuredevopsConn) GetProxy() string {
 	return conn.Proxy
 }
 
+// SanitizeProxy masks the password of the proxy url
+func (conn *AzuredevopsConn) SanitizeProxy() {
+	conn.Proxy = api.SanitizeProxy(conn.Proxy)
+}
+
+// RestoreSanitizedProxy keeps the stored proxy password when the sanitized proxy url is sent back
+func (conn *AzuredevopsConn) RestoreSanitizedProxy(existed string) {
+	conn.Proxy = api.RestoreSanitizedProxy(conn.Proxy, existed)
+}
+
 func (conn *AzuredevopsConn) GetRateLimitPerHour() int {
 	return 0
 }
@@ -34,6 +34,7 @@ type ScopeDetail api.ScopeDetail[models.BambooPlan, models.BambooScopeConfig]
 // @Accept application/json
 // @Param connectionId path int true "connection ID"
 // @Param scope body PutScopesReqBody true "json"
+// @Param async query bool false "process the scopes in a background job and return the job"
 // @Success 200  {object} []models.BambooPlan
 // @Failure 400  {object} shared.ApiBody "Bad Request"
 // @Failure 500  {object} shared.ApiBody "Internal Error"
@@ -96,6 +97,7 @@ func GetScope(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors
 // @Param connectionId path int true "connection ID"
 // @Param scopeId path string true "scope ID"
 // @Param delete_data_only query bool false "Only delete the scope data, not the scope itself"
+// @Param async query bool false "Delete in a background job and respond with the job right away"
 // @Success 200
 // @Failure 400  {object} shared.ApiBody "Bad Request"
 // @Failure 409  {object} api.ScopeRefDoc "References exist to this scope"
@@ -104,3 +106,60 @@ func GetScope(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors
 func DeleteScope(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
 	return dsHelper.ScopeApi.Delete(input)
 }
+
+// PatchScopes attach a scope config to many scopes
+// @Summary attach a scope config to many scopes
+// @Description attach the scope config to all the given scopes in one call, scopeConfigId 0 detaches their scope configs
+// @Tags plugins/bamboo
+// @Accept application/json
+// @Param connectionId path int true "connection ID"
+// @Param scope body api.PatchScopesReqBody true "json"
+// @Success 200  {object} api.PatchScopesReqBody
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/bamboo/connections/{connectionId}/scopes [PATCH]
+func PatchScopes(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return dsHelper.ScopeApi.PatchMultiple(input)
+}
+
+// GetScopeJob get the progress of a background scope job
+// @Summary get the progress of a background scope job
+// @Description get the progress and the failed scopes of a job started by creating scopes asynchronously
+// @Tags plugins/bamboo
+// @Param connectionId path int true "connection ID"
+// @Param jobId path int true "job ID"
+// @Success 200  {object} models.BackgroundJob
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/bamboo/connections/{connectionId}/scope-jobs/{jobId} [GET]
+func GetScopeJob(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return dsHelper.ScopeApi.GetJob(input)
+}
+
+// DeleteScopes delete many scopes, or their data only, at once
+// @Summary delete many scopes, or their data only, at once
+// @Description delete the scopes in a background job which waits for the running pipelines, poll the job for the progress
+// @Tags plugins/bamboo
+// @Accept application/json
+// @Param connectionId path int true "connection ID"
+// @Param scope body api.DeleteScopesReqBody true "json"
+// @Success 202  {object} models.BackgroundJob
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/bamboo/connections/{connectionId}/scopes [DELETE]
+func DeleteScopes(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return dsHelper.ScopeApi.DeleteMultiple(input)
+}
+
+// GetScopeJobs get the latest background scope jobs of the connection
+// @Summary get the latest background scope jobs of the connection
+// @Description get the latest 50 jobs creating or deleting the scopes of the connection, the newest first
+// @Tags plugins/bamboo
+// @Param connectionId path int true "connection ID"
+// @Success 200  {object} []models.BackgroundJob
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/bamboo/connections/{connectionId}/scope-jobs [GET]
+func GetScopeJobs(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return dsHelper.ScopeApi.GetJobs(input)
+}
@@ -228,8 +228,16 @@ func (p Bamboo) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
 			"DELETE": api.DeleteScopeConfig,
 		},
 		"connections/:connectionId/scopes": {
-			"GET": api.GetScopeList,
-			"PUT": api.PutScopes,
+			"GET":    api.GetScopeList,
+			"PUT":    api.PutScopes,
+			"PATCH":  api.PatchScopes,
+			"DELETE": api.DeleteScopes,
+		},
+		"connections/:connectionId/scope-jobs": {
+			"GET": api.GetScopeJobs,
+		},
+		"connections/:connectionId/scope-jobs/:jobId": {
+			"GET": api.GetScopeJob,
 		},
 		"connections/:connectionId/scopes/:scopeId/latest-sync-state": {
 			"GET": api.GetScopeLatestSyncState,
@@ -251,6 +259,9 @@ func (p Bamboo) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
 		"scope-config/:scopeConfigId/projects": {
 			"GET": api.GetProjectsByScopeConfig,
 		},
+		"scope-config-schema": {
+			"GET": api.GetScopeConfigSchema,
+		},
 	}
 }
 
@@ -190,6 +190,7 @@ func searchBitbucketRepos(
 // @Param connectionId path int false "connection ID"
 // @Param groupId query string false "group ID"
 // @Param pageToken query string false "page Token"
+// @Param refresh query bool false "drop the cached remote scopes of the connection and fetch them again"
 // @Failure 400  {object} shared.ApiBody "Bad Request"
 // @Failure 500  {object} shared.ApiBody "Internal Error"
 // @Success 200  {object} dsmodels.DsRemoteApiScopeList[models.BitbucketRepo]
@@ -226,8 +226,16 @@ func (p Bitbucket) ApiResources() map[string]map[string]plugin.ApiResourceHandle
 			"GET": api.SearchRemoteScopes,
 		},
 		"connections/:connectionId/scopes": {
-			"GET": api.GetScopes,
-			"PUT": api.PutScopes,
+			"GET":    api.GetScopes,
+			"PUT":    api.PutScopes,
+			"PATCH":  api.PatchScopes,
+			"DELETE": api.DeleteScopes,
+		},
+		"connections/:connectionId/scope-jobs": {
+			"GET": api.GetScopeJobs,
+		},
+		"connections/:connectionId/scope-jobs/:jobId": {
+			"GET": api.GetScopeJob,
 		},
 		"connections/:connectionId/scope-configs": {
 			"POST": api.PostScopeConfig,
@@ -241,6 +249,9 @@ func (p Bitbucket) ApiResources() map[string]map[string]plugin.ApiResourceHandle
 		"scope-config/:scopeConfigId/projects": {
 			"GET": api.GetProjectsByScopeConfig,
 		},
+		"scope-config-schema": {
+			"GET": api.GetScopeConfigSchema,
+		},
 	}
 }
 
@@ -253,6 +264,15 @@ func (p Bitbucket) Close(taskCtx plugin.TaskContext) errors.Error {
 	return nil
 }
 
+func (p Bitbucket) GetDynamicGitCredential(taskCtx plugin.TaskContext, connectionId uint64) (string, string, errors.Error) {
+	connection := &models.BitbucketConnection{}
+	err := helper.NewConnectionHelper(taskCtx, nil, p.Name()).FirstById(connection, connectionId)
+	if err != nil {
+		return "", "", errors.Default.Wrap(err, "unable to get bitbucket connection by the given connection ID")
+	}
+	return connection.Username, connection.Password, nil
+}
+
 func EnrichOptions(taskCtx plugin.TaskContext,
 	op *tasks.BitbucketOptions,
 	apiClient *helper.ApiClient) errors.Error {
@@ -19,7 +19,6 @@ package api
 
 import (
 	"context"
-	"net/url"
 
 	"github.com/apache/incubator-devlake/core/errors"
 	coreModels "github.com/apache/incubator-devlake/core/models"
@@ -116,19 +115,17 @@ func makeDataSourcePipelinePlanV200(
 		}
 		// add gitex stage
 		if utils.StringsContains(scopeConfig.Entities, plugin.DOMAIN_TYPE_CODE) {
-			cloneUrl, err := errors.Convert01(url.Parse(bitbucketRepo.CloneUrl))
-			if err != nil {
-				return nil, err
-			}
-			cloneUrl.User = url.UserPassword(connection.Username, connection.Password)
+			// the credential is injected by gitextractor at clone time, it must not be kept in the plan
 			stage = append(stage, &coreModels.PipelineTask{
 				Plugin: "gitextractor",
 				Options: map[string]interface{}{
-					"url":      cloneUrl.String(),
-					"name":     bitbucketRepo.BitbucketId,
-					"fullName": bitbucketRepo.BitbucketId,
-					"repoId":   didgen.NewDomainIdGenerator(&models.BitbucketServerRepo{}).Generate(connection.ID, bitbucketRepo.BitbucketId),
-					"proxy":    connection.Proxy,
+					"url":          bitbucketRepo.CloneUrl,
+					"name":         bitbucketRepo.BitbucketId,
+					"fullName":     bitbucketRepo.BitbucketId,
+					"repoId":       didgen.NewDomainIdGenerator(&models.BitbucketServerRepo{}).Generate(connection.ID, bitbucketRepo.BitbucketId),
+					"proxy":        connection.Proxy,
+					"connectionId": connection.ID,
+					"pluginName":   "bitbucket_server",
 				},
 			})
 
@@ -114,3 +114,14 @@ func DeleteScopeConfig(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutpu
 	input.Params["scopeConfigId"] = strings.TrimLeft(input.Params["scopeConfigId"], "/")
 	return dsHelper.ScopeConfigApi.Delete(input)
 }
+
+// GetScopeConfigSchema return the json schema of the scope config
+// @Summary return the json schema of the scope config
+// @Description return the json schema of the scope config for Bitbucket, which is enforced when creating or updating scope configs
+// @Tags plugins/bitbucket_server
+// @Success 200  {object} srvhelper.JsonSchema
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/bitbucket_server/scope-config-schema [GET]
+func GetScopeConfigSchema(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return dsHelper.ScopeConfigApi.GetSchema(input)
+}
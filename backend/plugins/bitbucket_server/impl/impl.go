@@ -189,8 +189,16 @@ func (p BitbucketServer) ApiResources() map[string]map[string]plugin.ApiResource
 			"GET": api.SearchRemoteScopes,
 		},
 		"connections/:connectionId/scopes": {
-			"GET": api.GetScopeList,
-			"PUT": api.PutScope,
+			"GET":    api.GetScopeList,
+			"PUT":    api.PutScope,
+			"PATCH":  api.PatchScopes,
+			"DELETE": api.DeleteScopes,
+		},
+		"connections/:connectionId/scope-jobs": {
+			"GET": api.GetScopeJobs,
+		},
+		"connections/:connectionId/scope-jobs/:jobId": {
+			"GET": api.GetScopeJob,
 		},
 		"connections/:connectionId/scope-configs": {
 			"POST": api.CreateScopeConfig,
@@ -204,6 +212,9 @@ func (p BitbucketServer) ApiResources() map[string]map[string]plugin.ApiResource
 		"scope-config/:scopeConfigId/projects": {
 			"GET": api.GetProjectsByScopeConfig,
 		},
+		"scope-config-schema": {
+			"GET": api.GetScopeConfigSchema,
+		},
 	}
 }
 
@@ -216,6 +227,15 @@ func (p BitbucketServer) Close(taskCtx plugin.TaskContext) errors.Error {
 	return nil
 }
 
+func (p BitbucketServer) GetDynamicGitCredential(taskCtx plugin.TaskContext, connectionId uint64) (string, string, errors.Error) {
+	connection := &models.BitbucketServerConnection{}
+	err := helper.NewConnectionHelper(taskCtx, nil, p.Name()).FirstById(connection, connectionId)
+	if err != nil {
+		return "", "", errors.Default.Wrap(err, "unable to get bitbucket server connection by the given connection ID")
+	}
+	return connection.Username, connection.Password, nil
+}
+
 func EnrichOptions(taskCtx plugin.TaskContext,
 	op *tasks.BitbucketServerOptions,
 	apiClient *helper.ApiClient) errors.Error {
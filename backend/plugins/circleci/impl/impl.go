@@ -195,8 +195,16 @@ func (p Circleci) ApiResources() map[string]map[string]plugin.ApiResourceHandler
 			"GET": api.GetScopeLatestSyncState,
 		},
 		"connections/:connectionId/scopes": {
-			"GET": api.GetScopes,
-			"PUT": api.PutScopes,
+			"GET":    api.GetScopes,
+			"PUT":    api.PutScopes,
+			"PATCH":  api.PatchScopes,
+			"DELETE": api.DeleteScopes,
+		},
+		"connections/:connectionId/scope-jobs": {
+			"GET": api.GetScopeJobs,
+		},
+		"connections/:connectionId/scope-jobs/:jobId": {
+			"GET": api.GetScopeJob,
 		},
 		"connections/:connectionId/scope-configs": {
 			"POST": api.PostScopeConfig,
@@ -210,6 +218,9 @@ func (p Circleci) ApiResources() map[string]map[string]plugin.ApiResourceHandler
 		"scope-config/:scopeConfigId/projects": {
 			"GET": api.GetProjectsByScopeConfig,
 		},
+		"scope-config-schema": {
+			"GET": api.GetScopeConfigSchema,
+		},
 	}
 }
 
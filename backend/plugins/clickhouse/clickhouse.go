@@ -0,0 +1,59 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"github.com/apache/incubator-devlake/core/runner"
+	"github.com/apache/incubator-devlake/plugins/clickhouse/impl"
+	"github.com/spf13/cobra"
+)
+
+var PluginEntry impl.ClickHouse
+
+func main() {
+	cmd := &cobra.Command{Use: "clickhouse"}
+	sourceType := cmd.Flags().StringP("source_type", "s", "", "Source type")
+	sourceDsn := cmd.Flags().StringP("source_dsn", "S", "", "Source dsn")
+	updateColumn := cmd.Flags().StringP("update_column", "c", "", "Update column")
+	_ = cmd.MarkFlagRequired("host")
+	host := cmd.Flags().StringP("host", "h", "", "ClickHouse host")
+	port := cmd.Flags().IntP("port", "p", 8123, "ClickHouse http port")
+	user := cmd.Flags().StringP("user", "u", "default", "ClickHouse user")
+	password := cmd.Flags().StringP("password", "P", "", "ClickHouse password")
+	database := cmd.Flags().StringP("database", "d", "default", "ClickHouse database")
+	tables := cmd.Flags().StringArrayP("table", "t", []string{}, "Source tables, support regexp")
+	batchSize := cmd.Flags().IntP("batch_size", "b", 10000, "ClickHouse insert batch size")
+	domainLayer := cmd.Flags().StringP("domain_layer", "l", "", "Export the tables of the domain layer, priority over tables")
+	timeAfter := cmd.Flags().StringP("time_after", "a", "", "collect data that are created after specified time, ie 2006-01-02T15:04:05Z")
+	cmd.Run = func(cmd *cobra.Command, args []string) {
+		runner.DirectRun(cmd, args, PluginEntry, map[string]interface{}{
+			"source_type":   *sourceType,
+			"source_dsn":    *sourceDsn,
+			"update_column": *updateColumn,
+			"host":          *host,
+			"port":          *port,
+			"user":          *user,
+			"password":      *password,
+			"database":      *database,
+			"tables":        *tables,
+			"batch_size":    *batchSize,
+			"domain_layer":  *domainLayer,
+		}, *timeAfter)
+	}
+	runner.RunCmd(cmd)
+}
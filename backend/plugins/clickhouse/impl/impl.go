@@ -0,0 +1,78 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package impl
+
+import (
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/clickhouse/tasks"
+)
+
+type ClickHouse struct{}
+
+// make sure interface is implemented
+var _ interface {
+	plugin.PluginMeta
+	plugin.PluginTask
+	plugin.PluginModel
+} = (*ClickHouse)(nil)
+
+func (s ClickHouse) SubTaskMetas() []plugin.SubTaskMeta {
+	return []plugin.SubTaskMeta{
+		tasks.ExportDataTaskMeta,
+	}
+}
+
+func (s ClickHouse) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]interface{}) (interface{}, errors.Error) {
+	var op tasks.ClickHouseConfig
+	err := helper.Decode(options, &op, nil)
+	if err != nil {
+		return nil, err
+	}
+	if op.Host == "" {
+		return nil, errors.BadInput.New("host is required")
+	}
+	if op.Port == 0 {
+		op.Port = 8123
+	}
+	if op.Database == "" {
+		op.Database = "default"
+	}
+	if op.BatchSize <= 0 {
+		op.BatchSize = 10000
+	}
+	return &op, nil
+}
+
+func (s ClickHouse) GetTablesInfo() []dal.Tabler {
+	return []dal.Tabler{}
+}
+
+func (s ClickHouse) Description() string {
+	return "Sync data from database to ClickHouse"
+}
+
+func (s ClickHouse) Name() string {
+	return "clickhouse"
+}
+
+func (s ClickHouse) RootPkgPath() string {
+	return "github.com/apache/incubator-devlake/plugins/clickhouse"
+}
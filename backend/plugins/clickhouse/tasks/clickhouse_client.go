@@ -0,0 +1,79 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+// clickhouseClient talks to the HTTP interface of ClickHouse, which saves us from depending on a native driver
+type clickhouseClient struct {
+	ctx      context.Context
+	endpoint string
+	user     string
+	password string
+	database string
+	client   *http.Client
+}
+
+func newClickhouseClient(ctx context.Context, config *ClickHouseConfig) *clickhouseClient {
+	return &clickhouseClient{
+		ctx:      ctx,
+		endpoint: fmt.Sprintf("http://%s:%d/", config.Host, config.Port),
+		user:     config.User,
+		password: config.Password,
+		database: config.Database,
+		client:   &http.Client{},
+	}
+}
+
+// exec runs the query, the body holds the data of INSERT queries
+func (c *clickhouseClient) exec(query string, body []byte) (string, errors.Error) {
+	params := url.Values{}
+	params.Set("database", c.database)
+	params.Set("query", query)
+	// accept the RFC3339 times produced by encoding/json
+	params.Set("date_time_input_format", "best_effort")
+	req, err := http.NewRequestWithContext(c.ctx, http.MethodPost, c.endpoint+"?"+params.Encode(), bytes.NewReader(body))
+	if err != nil {
+		return "", errors.Convert(err)
+	}
+	req.Header.Set("X-ClickHouse-User", c.user)
+	req.Header.Set("X-ClickHouse-Key", c.password)
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", errors.Convert(err)
+	}
+	defer resp.Body.Close()
+	result, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", errors.Convert(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.HttpStatus(resp.StatusCode).New(fmt.Sprintf("clickhouse: %s", strings.TrimSpace(string(result))))
+	}
+	return strings.TrimSpace(string(result)), nil
+}
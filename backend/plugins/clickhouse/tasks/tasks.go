@@ -0,0 +1,385 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/impls/dalgorm"
+	"github.com/apache/incubator-devlake/plugins/clickhouse/utils"
+	starrocksUtils "github.com/apache/incubator-devlake/plugins/starrocks/utils"
+
+	"github.com/lib/pq"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type Table struct {
+	name string
+}
+
+func (t *Table) TableName() string {
+	return t.name
+}
+
+type exportingColumn struct {
+	name     string
+	dataType string
+}
+
+func ExportData(c plugin.SubTaskContext) errors.Error {
+	logger := c.GetLogger()
+	config := c.GetData().(*ClickHouseConfig)
+
+	// 1. Get db instance
+	var db dal.Dal
+	if config.SourceDsn != "" && config.SourceType != "" {
+		o, err := getDbInstance(config)
+		if err != nil {
+			return errors.Convert(err)
+		}
+		db = dalgorm.NewDalgorm(o)
+		sqlDB, err := o.DB()
+		if err != nil {
+			return errors.Convert(err)
+		}
+		defer sqlDB.Close()
+	} else {
+		db = c.GetDal()
+	}
+
+	// 2. Filter out the tables to export
+	tables, err := getExportingTables(config, db)
+	if err != nil {
+		return err
+	}
+
+	// 3. copy devlake data to clickhouse, every table is loaded into a temporary table and swapped in at the end,
+	// so that grafana never reads a half loaded table
+	ch := newClickhouseClient(c.GetContext(), config)
+	for _, table := range tables {
+		select {
+		case <-c.GetContext().Done():
+			return errors.Convert(c.GetContext().Err())
+		default:
+		}
+		upToDate, err := isUpToDate(config, db, ch, table)
+		if err != nil {
+			return err
+		}
+		if upToDate {
+			logger.Info("table %s is up to date, so skip it", table)
+			continue
+		}
+		columns, orderBy, err := createTmpTableInClickhouse(c, db, ch, table)
+		if err != nil {
+			logger.Error(err, "create table %s in clickhouse error", table)
+			return err
+		}
+		err = copyDataToClickhouse(c, db, ch, table, columns, orderBy)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isUpToDate tells whether the latest update and the number of rows of the table are the same on both sides
+func isUpToDate(config *ClickHouseConfig, db dal.Dal, ch *clickhouseClient, table string) (bool, errors.Error) {
+	updateColumn := config.UpdateColumn
+	if updateColumn == "" || !db.HasColumn(table, updateColumn) {
+		return false, nil
+	}
+	var updatedFrom time.Time
+	err := db.All(&updatedFrom, dal.Select(updateColumn), dal.From(table), dal.Limit(1), dal.Orderby(fmt.Sprintf("%s desc", updateColumn)))
+	if err != nil {
+		return false, err
+	}
+	sourceCount, err := db.Count(dal.From(table))
+	if err != nil {
+		return false, err
+	}
+	result, err := ch.exec(fmt.Sprintf(
+		"SELECT count(), ifNull(toUnixTimestamp64Milli(max(`%s`)), 0) FROM `%s` FORMAT TabSeparated", updateColumn, table,
+	), nil)
+	if err != nil {
+		// the table doesn't exist yet or its columns changed
+		return false, nil
+	}
+	fields := strings.Split(result, "\t")
+	if len(fields) != 2 {
+		return false, nil
+	}
+	destCount, _ := strconv.ParseInt(fields[0], 10, 64)
+	updatedTo, _ := strconv.ParseInt(fields[1], 10, 64)
+	return destCount == sourceCount && updatedTo == updatedFrom.UnixMilli(), nil
+}
+
+// create temp table with the columns of the source table
+func createTmpTableInClickhouse(c plugin.SubTaskContext, db dal.Dal, ch *clickhouseClient, table string) ([]exportingColumn, string, errors.Error) {
+	logger := c.GetLogger()
+	config := c.GetData().(*ClickHouseConfig)
+	columnMetas, err := db.GetColumns(&Table{name: table}, nil)
+	if err != nil {
+		if !strings.Contains(err.Error(), "cached plan must not change result type") {
+			return nil, "", err
+		}
+		logger.Warn(err, "skip err: cached plan must not change result type")
+		columnMetas, err = db.GetColumns(&Table{name: table}, nil)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+	separator, err := identifierSeparator(db)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var columns []exportingColumn
+	var definitions, pks, orders []string
+	tableConfig, ok := config.TableConfigs[table]
+	for _, cm := range columnMetas {
+		name := cm.Name()
+		if ok {
+			if len(tableConfig.ExcludedColumns) > 0 && slices.Contains(tableConfig.ExcludedColumns, name) {
+				continue
+			}
+			if len(tableConfig.IncludedColumns) > 0 && !slices.Contains(tableConfig.IncludedColumns, name) {
+				continue
+			}
+		}
+		columnDatatype, ok := cm.ColumnType()
+		if !ok {
+			return nil, "", errors.Default.New(fmt.Sprintf("Get [%s] ColumeType Failed", name))
+		}
+		dataType := utils.GetClickHouseDataType(columnDatatype)
+		columns = append(columns, exportingColumn{name: name, dataType: dataType})
+		// the sorting key of MergeTree tables can't be nullable, neither can arrays
+		isPrimaryKey, ok := cm.PrimaryKey()
+		if isPrimaryKey && ok {
+			pks = append(pks, fmt.Sprintf("`%s`", name))
+			orders = append(orders, fmt.Sprintf("%s%s%s", separator, name, separator))
+		} else if !strings.HasPrefix(dataType, "Array") {
+			dataType = fmt.Sprintf("Nullable(%s)", dataType)
+		}
+		definitions = append(definitions, fmt.Sprintf("`%s` %s", name, dataType))
+	}
+	if len(columns) == 0 {
+		return nil, "", errors.BadInput.New(fmt.Sprintf("no column of %s to export", table))
+	}
+
+	orderBy := strings.Join(orders, ", ")
+	if v, ok := config.OrderBy[table]; ok {
+		orderBy = v
+	}
+	if orderBy == "" {
+		orderBy = fmt.Sprintf("%s%s%s", separator, columns[0].name, separator)
+	}
+	sortingKey := "tuple()"
+	if len(pks) > 0 {
+		sortingKey = fmt.Sprintf("(%s)", strings.Join(pks, ", "))
+	}
+	extra := fmt.Sprintf("ENGINE = MergeTree ORDER BY %s", sortingKey)
+	if v, ok := config.Extra[table]; ok {
+		extra = v
+	}
+	tmpTable := fmt.Sprintf("%s_tmp", table)
+	_, err = ch.exec(fmt.Sprintf("DROP TABLE IF EXISTS `%s`", tmpTable), nil)
+	if err != nil {
+		return nil, "", err
+	}
+	tableSql := fmt.Sprintf("CREATE TABLE `%s` ( %s ) %s", tmpTable, strings.Join(definitions, ", "), extra)
+	logger.Debug(tableSql)
+	_, err = ch.exec(tableSql, nil)
+	return columns, orderBy, err
+}
+
+func identifierSeparator(db dal.Dal) (string, errors.Error) {
+	switch db.Dialect() {
+	case "postgres":
+		return "\"", nil
+	case "mysql":
+		return "`", nil
+	}
+	return "", errors.NotFound.New(fmt.Sprintf("unsupported dialect %s", db.Dialect()))
+}
+
+// copy the rows into the temp table in batches and swap it with the final table
+func copyDataToClickhouse(c plugin.SubTaskContext, db dal.Dal, ch *clickhouseClient, table string, columns []exportingColumn, orderBy string) errors.Error {
+	logger := c.GetLogger()
+	config := c.GetData().(*ClickHouseConfig)
+	tmpTable := fmt.Sprintf("%s_tmp", table)
+	where := config.TableConfigs[table].Where
+	separator, err := identifierSeparator(db)
+	if err != nil {
+		return err
+	}
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = fmt.Sprintf("%s%s%s", separator, column.name, separator)
+	}
+	rows, err := db.Cursor(
+		dal.Select(strings.Join(names, ", ")),
+		dal.From(table),
+		dal.Orderby(orderBy),
+		dal.Where(where),
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	insertSql := fmt.Sprintf("INSERT INTO `%s` FORMAT JSONEachRow", tmpTable)
+	var batch bytes.Buffer
+	var batchCount, total int
+	for rows.Next() {
+		select {
+		case <-c.GetContext().Done():
+			return errors.Convert(c.GetContext().Err())
+		default:
+		}
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i, column := range columns {
+			if strings.HasPrefix(column.dataType, "Array") {
+				var arr []string
+				values[i] = &arr
+				pointers[i] = pq.Array(&arr)
+			} else {
+				pointers[i] = &values[i]
+			}
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return errors.Convert(err)
+		}
+		row := make(map[string]interface{}, len(columns))
+		for i, column := range columns {
+			// the mysql driver returns text as bytes, which would be base64 encoded otherwise
+			if b, ok := values[i].([]byte); ok {
+				values[i] = string(b)
+			}
+			row[column.name] = values[i]
+		}
+		line, jsonErr := json.Marshal(row)
+		if jsonErr != nil {
+			return errors.Convert(jsonErr)
+		}
+		batch.Write(line)
+		batch.WriteByte('\n')
+		batchCount++
+		if batchCount == config.BatchSize {
+			if _, err = ch.exec(insertSql, batch.Bytes()); err != nil {
+				return err
+			}
+			total += batchCount
+			logger.Debug("load %s: %d rows", table, total)
+			batch.Reset()
+			batchCount = 0
+		}
+	}
+	if batchCount != 0 {
+		if _, err = ch.exec(insertSql, batch.Bytes()); err != nil {
+			return err
+		}
+		total += batchCount
+	}
+
+	// swap the temp table with the final one, creating it first if it doesn't exist
+	for _, statement := range []string{
+		fmt.Sprintf("CREATE TABLE IF NOT EXISTS `%s` AS `%s`", table, tmpTable),
+		fmt.Sprintf("EXCHANGE TABLES `%s` AND `%s`", tmpTable, table),
+		fmt.Sprintf("DROP TABLE IF EXISTS `%s`", tmpTable),
+	} {
+		if _, err = ch.exec(statement, nil); err != nil {
+			return err
+		}
+	}
+
+	// check data count
+	result, err := ch.exec(fmt.Sprintf("SELECT count() FROM `%s`", table), nil)
+	if err != nil {
+		return err
+	}
+	if strconv.Itoa(total) != result {
+		logger.Warn(nil, "source count %d not equal to clickhouse count %s", total, result)
+	}
+	logger.Info("load %s to clickhouse success", table)
+	return nil
+}
+
+// get db instance
+func getDbInstance(config *ClickHouseConfig) (o *gorm.DB, err error) {
+	switch config.SourceType {
+	case "mysql":
+		return gorm.Open(mysql.Open(config.SourceDsn))
+	case "postgres":
+		return gorm.Open(postgres.Open(config.SourceDsn))
+	}
+	return nil, errors.NotFound.New(fmt.Sprintf("unsupported source type %s", config.SourceType))
+}
+
+// get exported tables
+func getExportingTables(config *ClickHouseConfig, db dal.Dal) ([]string, errors.Error) {
+	if config.DomainLayer != "" {
+		tables := starrocksUtils.GetTablesByDomainLayer(config.DomainLayer)
+		if tables == nil {
+			return nil, errors.NotFound.New(fmt.Sprintf("no table found by domain layer: %s", config.DomainLayer))
+		}
+		return tables, nil
+	}
+	allTables, err := db.AllTables()
+	if err != nil {
+		return nil, err
+	}
+	if len(config.Tables) == 0 {
+		return allTables, nil
+	}
+	var tables []string
+	for _, table := range allTables {
+		for _, r := range config.Tables {
+			ok, err := regexp.MatchString(r, table)
+			if err != nil {
+				return nil, errors.BadInput.Wrap(err, fmt.Sprintf("invalid table pattern %s", r))
+			}
+			if ok {
+				tables = append(tables, table)
+				break
+			}
+		}
+	}
+	return tables, nil
+}
+
+var ExportDataTaskMeta = plugin.SubTaskMeta{
+	Name:             "ExportData",
+	EntryPoint:       ExportData,
+	EnabledByDefault: true,
+	Description:      "Load data to ClickHouse",
+}
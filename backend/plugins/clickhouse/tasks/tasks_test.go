@@ -0,0 +1,153 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	mockplugin "github.com/apache/incubator-devlake/mocks/core/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// fakeClickhouse answers the queries sent to the HTTP interface with respond and records them
+func fakeClickhouse(t *testing.T, respond func(query string) (int, string)) (*clickhouseClient, *[]string) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query().Get("query")
+		queries = append(queries, query)
+		status, body := respond(query)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	u, err := url.Parse(server.URL)
+	assert.Nil(t, err)
+	port, err := strconv.Atoi(u.Port())
+	assert.Nil(t, err)
+	return newClickhouseClient(context.Background(), &ClickHouseConfig{Host: u.Hostname(), Port: port, Database: "lake"}), &queries
+}
+
+func mockColumn(name string, columnType string, isPrimaryKey bool) dal.ColumnMeta {
+	column := new(mockdal.ColumnMeta)
+	column.On("Name").Return(name)
+	column.On("ColumnType").Return(columnType, true)
+	column.On("PrimaryKey").Return(isPrimaryKey, true)
+	return column
+}
+
+func TestCreateTmpTableInClickhouse(t *testing.T) {
+	ch, queries := fakeClickhouse(t, func(string) (int, string) { return http.StatusOK, "" })
+	mockDal := new(mockdal.Dal)
+	mockDal.On("GetColumns", &Table{name: "issues"}, mock.Anything).Return([]dal.ColumnMeta{
+		mockColumn("id", "varchar(255)", true),
+		mockColumn("title", "varchar(255)", false),
+		mockColumn("description", "longtext", false),
+		mockColumn("labels", "text[]", false),
+		mockColumn("created_date", "datetime(3)", false),
+	}, nil)
+	mockDal.On("Dialect").Return("mysql")
+	mockCtx := new(mockplugin.SubTaskContext)
+	mockCtx.On("GetLogger").Return(unithelper.DummyLogger())
+	mockCtx.On("GetData").Return(&ClickHouseConfig{
+		TableConfigs: map[string]TableConfig{"issues": {ExcludedColumns: []string{"description"}}},
+	})
+
+	columns, orderBy, err := createTmpTableInClickhouse(mockCtx, mockDal, ch, "issues")
+	assert.Nil(t, err)
+	assert.Equal(t, []exportingColumn{
+		{"id", "String"},
+		{"title", "String"},
+		{"labels", "Array(String)"},
+		{"created_date", "DateTime64(3)"},
+	}, columns)
+	assert.Equal(t, "`id`", orderBy)
+	// the primary keys sort the table and are not nullable, neither are the arrays
+	assert.Equal(t, []string{
+		"DROP TABLE IF EXISTS `issues_tmp`",
+		"CREATE TABLE `issues_tmp` ( `id` String, `title` Nullable(String), `labels` Array(String), `created_date` Nullable(DateTime64(3)) ) ENGINE = MergeTree ORDER BY (`id`)",
+	}, *queries)
+}
+
+func TestIsUpToDate(t *testing.T) {
+	updatedAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		status int
+		result string
+		want   bool
+	}{
+		{"same count and latest update", http.StatusOK, fmt.Sprintf("3\t%d", updatedAt.UnixMilli()), true},
+		{"rows missing", http.StatusOK, fmt.Sprintf("2\t%d", updatedAt.UnixMilli()), false},
+		{"rows updated since", http.StatusOK, fmt.Sprintf("3\t%d", updatedAt.Add(-time.Minute).UnixMilli()), false},
+		{"table not created yet", http.StatusNotFound, "Table lake.issues doesn't exist", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch, _ := fakeClickhouse(t, func(string) (int, string) { return tt.status, tt.result })
+			mockDal := new(mockdal.Dal)
+			mockDal.On("HasColumn", "issues", "updated_at").Return(true)
+			mockDal.On("All", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				*args.Get(0).(*time.Time) = updatedAt
+			}).Return(nil)
+			mockDal.On("Count", mock.Anything).Return(int64(3), nil)
+
+			upToDate, err := isUpToDate(&ClickHouseConfig{UpdateColumn: "updated_at"}, mockDal, ch, "issues")
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, upToDate)
+		})
+	}
+}
+
+func TestIsUpToDateWithoutUpdateColumn(t *testing.T) {
+	ch, queries := fakeClickhouse(t, func(string) (int, string) { return http.StatusOK, "" })
+	mockDal := new(mockdal.Dal)
+	mockDal.On("HasColumn", "commits", "updated_at").Return(false)
+
+	upToDate, err := isUpToDate(&ClickHouseConfig{UpdateColumn: "updated_at"}, mockDal, ch, "commits")
+	assert.Nil(t, err)
+	assert.False(t, upToDate)
+	assert.Empty(t, *queries)
+}
+
+func TestGetExportingTables(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	mockDal.On("AllTables").Return([]string{"issues", "issue_comments", "_raw_jira_api_issues", "commits"}, nil)
+
+	tables, err := getExportingTables(&ClickHouseConfig{}, mockDal)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"issues", "issue_comments", "_raw_jira_api_issues", "commits"}, tables)
+
+	// a table matched by several patterns is exported once
+	tables, err = getExportingTables(&ClickHouseConfig{Tables: []string{"^issue", "comments$"}}, mockDal)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"issues", "issue_comments"}, tables)
+
+	_, err = getExportingTables(&ClickHouseConfig{Tables: []string{"("}}, mockDal)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,61 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GetClickHouseDataType analysis and return the data type of ClickHouse
+func GetClickHouseDataType(dataType string) string {
+	dataType = strings.ToLower(dataType)
+	unsigned := strings.Contains(dataType, "unsigned")
+	dataType = strings.TrimSpace(strings.Replace(dataType, "unsigned", "", 1))
+	intType := func(bits int) string {
+		if unsigned {
+			return fmt.Sprintf("UInt%d", bits)
+		}
+		return fmt.Sprintf("Int%d", bits)
+	}
+	switch {
+	case strings.HasSuffix(dataType, "[]"):
+		return fmt.Sprintf("Array(%s)", GetClickHouseDataType(strings.TrimSuffix(dataType, "[]")))
+	case strings.HasPrefix(dataType, "datetime"), strings.HasPrefix(dataType, "timestamp"):
+		return "DateTime64(3)"
+	case dataType == "date":
+		return "Date32"
+	case dataType == "tinyint(1)", dataType == "boolean":
+		return "UInt8"
+	case strings.HasPrefix(dataType, "bigint"), dataType == "bigserial":
+		return intType(64)
+	case strings.HasPrefix(dataType, "smallint"), dataType == "smallserial":
+		return intType(16)
+	case strings.HasPrefix(dataType, "tinyint"):
+		return intType(8)
+	case dataType == "int", dataType == "integer", dataType == "serial", strings.HasPrefix(dataType, "int("),
+		strings.HasPrefix(dataType, "mediumint"):
+		return intType(32)
+	case dataType == "real", strings.HasPrefix(dataType, "float"):
+		return "Float32"
+	case strings.HasPrefix(dataType, "double"), strings.HasPrefix(dataType, "numeric"), strings.HasPrefix(dataType, "decimal"):
+		return "Float64"
+	}
+	// text, json and everything else is kept as is
+	return "String"
+}
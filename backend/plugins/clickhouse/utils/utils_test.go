@@ -0,0 +1,54 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetClickHouseDataType(t *testing.T) {
+	tests := []struct {
+		dataType string
+		want     string
+	}{
+		{"varchar(255)", "String"},
+		{"longtext", "String"},
+		{"json", "String"},
+		{"datetime(3)", "DateTime64(3)"},
+		{"timestamp with time zone", "DateTime64(3)"},
+		{"date", "Date32"},
+		{"tinyint(1)", "UInt8"},
+		{"boolean", "UInt8"},
+		{"tinyint(4)", "Int8"},
+		{"smallint", "Int16"},
+		{"int(11)", "Int32"},
+		{"integer", "Int32"},
+		{"bigint(20) unsigned", "UInt64"},
+		{"bigserial", "Int64"},
+		{"real", "Float32"},
+		{"double precision", "Float64"},
+		{"decimal(10,2)", "Float64"},
+		{"text[]", "Array(String)"},
+		{"bigint[]", "Array(Int64)"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, GetClickHouseDataType(tt.dataType), tt.dataType)
+	}
+}
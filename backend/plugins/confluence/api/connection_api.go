@@ -0,0 +1,157 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/confluence/models"
+)
+
+func testConfluenceConn(ctx context.Context, connection models.ConfluenceConn) (*plugin.ApiResourceOutput, errors.Error) {
+	if err := vld.Struct(connection); err != nil {
+		return nil, errors.BadInput.Wrap(err, "error validating connection")
+	}
+	apiClient, err := api.NewApiClientFromConnection(ctx, basicRes, &connection)
+	if err != nil {
+		return nil, err
+	}
+
+	// listing the spaces requires the same permissions as collecting them
+	response, err := apiClient.Get("rest/api/space", map[string][]string{"limit": {"1"}}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		return &plugin.ApiResourceOutput{Body: nil, Status: http.StatusOK}, nil
+	case http.StatusUnauthorized:
+		return nil, errors.HttpStatus(http.StatusUnauthorized).New("StatusUnauthorized error, please check your username and api token")
+	case http.StatusForbidden:
+		return nil, errors.HttpStatus(http.StatusForbidden).New("the user is not allowed to browse the spaces")
+	case http.StatusNotFound:
+		return nil, errors.HttpStatus(http.StatusNotFound).New("the endpoint is not a Confluence site, it looks like https://your-domain.atlassian.net/wiki/")
+	}
+	return &plugin.ApiResourceOutput{Body: nil, Status: response.StatusCode}, errors.HttpStatus(response.StatusCode).New("could not validate connection")
+}
+
+// TestConnection test confluence connection
+// @Summary test confluence connection
+// @Description Test Confluence Connection
+// @Tags plugins/confluence
+// @Param body body models.ConfluenceConn true "json body"
+// @Success 200  {object} shared.ApiBody "Success"
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/confluence/test [POST]
+func TestConnection(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	var connection models.ConfluenceConn
+	err := api.Decode(input.Body, &connection, vld)
+	if err != nil {
+		return nil, err
+	}
+	testConnectionResult, testConnectionErr := testConfluenceConn(context.TODO(), connection)
+	if testConnectionErr != nil {
+		return nil, plugin.WrapTestConnectionErrResp(basicRes, testConnectionErr)
+	}
+	return testConnectionResult, nil
+}
+
+// TestExistingConnection test an existing confluence connection
+// @Summary test confluence connection
+// @Description Test Confluence Connection
+// @Tags plugins/confluence
+// @Param connectionId path int true "connection ID"
+// @Success 200  {object} shared.ApiBody "Success"
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/confluence/connections/{connectionId}/test [POST]
+func TestExistingConnection(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	connection, err := dsHelper.ConnApi.GetMergedConnection(input)
+	if err != nil {
+		return nil, err
+	}
+	testConnectionResult, testConnectionErr := testConfluenceConn(context.TODO(), connection.ConfluenceConn)
+	if testConnectionErr != nil {
+		return nil, plugin.WrapTestConnectionErrResp(basicRes, testConnectionErr)
+	}
+	return testConnectionResult, nil
+}
+
+// @Summary create confluence connection
+// @Description Create Confluence connection
+// @Tags plugins/confluence
+// @Param body body models.ConfluenceConnection true "json body"
+// @Success 200  {object} models.ConfluenceConnection
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/confluence/connections [POST]
+func PostConnections(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return dsHelper.ConnApi.Post(input)
+}
+
+// @Summary patch confluence connection
+// @Description Patch Confluence connection
+// @Tags plugins/confluence
+// @Param body body models.ConfluenceConnection true "json body"
+// @Success 200  {object} models.ConfluenceConnection
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/confluence/connections/{connectionId} [PATCH]
+func PatchConnection(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return dsHelper.ConnApi.Patch(input)
+}
+
+// @Summary delete confluence connection
+// @Description Delete Confluence connection
+// @Tags plugins/confluence
+// @Success 200  {object} models.ConfluenceConnection
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 409  {object} services.BlueprintProjectPairs "References exist to this connection"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/confluence/connections/{connectionId} [DELETE]
+func DeleteConnection(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return dsHelper.ConnApi.Delete(input)
+}
+
+// @Summary list confluence connections
+// @Description List Confluence connections
+// @Tags plugins/confluence
+// @Success 200  {object} models.ConfluenceConnection
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/confluence/connections [GET]
+func ListConnections(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return dsHelper.ConnApi.GetAll(input)
+}
+
+// @Summary get confluence connection
+// @Description Get Confluence connection
+// @Tags plugins/confluence
+// @Success 200  {object} models.ConfluenceConnection
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/confluence/connections/{connectionId} [GET]
+func GetConnection(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return dsHelper.ConnApi.GetDetail(input)
+}
@@ -0,0 +1,181 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	dsmodels "github.com/apache/incubator-devlake/helpers/pluginhelper/api/models"
+	"github.com/apache/incubator-devlake/plugins/confluence/models"
+)
+
+type ConfluenceRemotePagination struct {
+	Start int `json:"start"`
+	Limit int `json:"limit"`
+}
+
+type confluenceLinks struct {
+	Base string `json:"base"`
+	Next string `json:"next"`
+}
+
+func toRemoteScopeEntry(space *models.ConfluenceSpace) dsmodels.DsRemoteApiScopeListEntry[models.ConfluenceSpace] {
+	return dsmodels.DsRemoteApiScopeListEntry[models.ConfluenceSpace]{
+		Type:     api.RAS_ENTRY_TYPE_SCOPE,
+		Id:       space.SpaceKey,
+		ParentId: nil,
+		Name:     space.Name,
+		FullName: space.Name,
+		Data:     space,
+	}
+}
+
+func listConfluenceRemoteScopes(
+	connection *models.ConfluenceConnection,
+	apiClient plugin.ApiClient,
+	groupId string,
+	page ConfluenceRemotePagination,
+) (
+	children []dsmodels.DsRemoteApiScopeListEntry[models.ConfluenceSpace],
+	nextPage *ConfluenceRemotePagination,
+	err errors.Error,
+) {
+	if page.Limit == 0 {
+		page.Limit = 100
+	}
+	res, err := apiClient.Get("rest/api/space", url.Values{
+		"start": {fmt.Sprintf("%v", page.Start)},
+		"limit": {fmt.Sprintf("%v", page.Limit)},
+	}, nil)
+	if err != nil {
+		return
+	}
+	resBody := struct {
+		Results []models.ConfluenceApiSpace `json:"results"`
+		Size    int                         `json:"size"`
+		Links   confluenceLinks             `json:"_links"`
+	}{}
+	err = api.UnmarshalResponse(res, &resBody)
+	if err != nil {
+		return
+	}
+
+	for _, apiSpace := range resBody.Results {
+		space := apiSpace.ConvertApiScope(resBody.Links.Base)
+		space.ConnectionId = connection.ID
+		children = append(children, toRemoteScopeEntry(space))
+	}
+
+	if resBody.Links.Next != "" {
+		nextPage = &ConfluenceRemotePagination{
+			Start: page.Start + resBody.Size,
+			Limit: page.Limit,
+		}
+	}
+	return
+}
+
+// RemoteScopes list all available scopes (spaces) for this connection
+// @Summary list all available scopes (spaces) for this connection
+// @Description list all available scopes (spaces) for this connection
+// @Tags plugins/confluence
+// @Accept application/json
+// @Param connectionId path int false "connection ID"
+// @Param groupId query string false "group ID"
+// @Param pageToken query string false "page Token"
+// @Param refresh query bool false "drop the cached remote scopes of the connection and fetch them again"
+// @Success 200  {object} dsmodels.DsRemoteApiScopeList[models.ConfluenceSpace]
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/confluence/connections/{connectionId}/remote-scopes [GET]
+func RemoteScopes(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return raScopeList.Get(input)
+}
+
+func searchConfluenceRemoteSpaces(
+	apiClient plugin.ApiClient,
+	params *dsmodels.DsRemoteApiScopeSearchParams,
+) (
+	children []dsmodels.DsRemoteApiScopeListEntry[models.ConfluenceSpace],
+	err errors.Error,
+) {
+	if params.Page == 0 {
+		params.Page = 1
+	}
+	if params.PageSize == 0 {
+		params.PageSize = 50
+	}
+	keyword := strings.ReplaceAll(params.Search, `"`, `\"`)
+	res, err := apiClient.Get("rest/api/search", url.Values{
+		"cql":   {fmt.Sprintf(`type = space AND title ~ "%s"`, keyword)},
+		"start": {fmt.Sprintf("%v", (params.Page-1)*params.PageSize)},
+		"limit": {fmt.Sprintf("%v", params.PageSize)},
+	}, nil)
+	if err != nil {
+		return
+	}
+	resBody := struct {
+		Results []struct {
+			Space *models.ConfluenceApiSpace `json:"space"`
+		} `json:"results"`
+		Links confluenceLinks `json:"_links"`
+	}{}
+	err = api.UnmarshalResponse(res, &resBody)
+	if err != nil {
+		return
+	}
+	for _, result := range resBody.Results {
+		if result.Space == nil {
+			continue
+		}
+		children = append(children, toRemoteScopeEntry(result.Space.ConvertApiScope(resBody.Links.Base)))
+	}
+	return
+}
+
+// SearchRemoteScopes searches spaces on the remote server
+// @Summary searches spaces on the remote server
+// @Description searches spaces on the remote server by their names
+// @Tags plugins/confluence
+// @Accept application/json
+// @Param connectionId path int false "connection ID"
+// @Param search query string false "search"
+// @Param page query int false "page number"
+// @Param pageSize query int false "page size per page"
+// @Success 200  {object} dsmodels.DsRemoteApiScopeList[models.ConfluenceSpace] "the parentIds are always null"
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/confluence/connections/{connectionId}/search-remote-scopes [GET]
+func SearchRemoteScopes(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return raScopeSearch.Get(input)
+}
+
+// @Summary Remote server API proxy
+// @Description Forward API requests to the specified remote server
+// @Param connectionId path int true "connection ID"
+// @Param path path string true "path to a API endpoint"
+// @Tags plugins/confluence
+// @Router /plugins/confluence/connections/{connectionId}/proxy/{path} [GET]
+func Proxy(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return raProxy.Proxy(input)
+}
@@ -0,0 +1,194 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package impl
+
+import (
+	"fmt"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	coreModels "github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/confluence/api"
+	"github.com/apache/incubator-devlake/plugins/confluence/models"
+	"github.com/apache/incubator-devlake/plugins/confluence/models/migrationscripts"
+	"github.com/apache/incubator-devlake/plugins/confluence/tasks"
+)
+
+// make sure interface is implemented
+var _ interface {
+	plugin.PluginMeta
+	plugin.PluginInit
+	plugin.PluginModel
+	plugin.PluginTask
+	plugin.PluginMigration
+	plugin.PluginApi
+	plugin.DataSourcePluginBlueprintV200
+	plugin.CloseablePluginTask
+	plugin.PluginSource
+} = (*Confluence)(nil)
+
+type Confluence struct{}
+
+func (p Confluence) Description() string {
+	return "collect Confluence spaces, pages, page versions and contributors"
+}
+
+func (p Confluence) Name() string {
+	return "confluence"
+}
+
+func (p Confluence) Init(br context.BasicRes) errors.Error {
+	api.Init(br, p)
+
+	return nil
+}
+
+func (p Confluence) Connection() dal.Tabler {
+	return &models.ConfluenceConnection{}
+}
+
+func (p Confluence) Scope() plugin.ToolLayerScope {
+	return &models.ConfluenceSpace{}
+}
+
+func (p Confluence) ScopeConfig() dal.Tabler {
+	return nil
+}
+
+func (p Confluence) GetTablesInfo() []dal.Tabler {
+	return []dal.Tabler{
+		&models.ConfluenceConnection{},
+		&models.ConfluenceSpace{},
+		&models.ConfluencePage{},
+		&models.ConfluencePageVersion{},
+		&models.ConfluenceAccount{},
+	}
+}
+
+func (p Confluence) SubTaskMetas() []plugin.SubTaskMeta {
+	return []plugin.SubTaskMeta{
+		tasks.CollectPagesMeta,
+		tasks.ExtractPagesMeta,
+		tasks.CollectPageVersionsMeta,
+		tasks.ExtractPageVersionsMeta,
+		tasks.ConvertAccountsMeta,
+	}
+}
+
+func (p Confluence) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]interface{}) (interface{}, errors.Error) {
+	op, err := tasks.DecodeAndValidateTaskOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	connectionHelper := helper.NewConnectionHelper(
+		taskCtx,
+		nil,
+		p.Name(),
+	)
+	connection := &models.ConfluenceConnection{}
+	err = connectionHelper.FirstById(connection, op.ConnectionId)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "unable to get Confluence connection by the given connection ID")
+	}
+
+	apiClient, err := tasks.CreateApiClient(taskCtx, connection)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "unable to get Confluence API client instance")
+	}
+	return &tasks.ConfluenceTaskData{
+		Options:   op,
+		ApiClient: apiClient,
+	}, nil
+}
+
+// RootPkgPath information lost when compiled as plugin(.so)
+func (p Confluence) RootPkgPath() string {
+	return "github.com/apache/incubator-devlake/plugins/confluence"
+}
+
+func (p Confluence) MigrationScripts() []plugin.MigrationScript {
+	return migrationscripts.All()
+}
+
+func (p Confluence) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
+	return map[string]map[string]plugin.ApiResourceHandler{
+		"test": {
+			"POST": api.TestConnection,
+		},
+		"connections": {
+			"POST": api.PostConnections,
+			"GET":  api.ListConnections,
+		},
+		"connections/:connectionId": {
+			"GET":    api.GetConnection,
+			"PATCH":  api.PatchConnection,
+			"DELETE": api.DeleteConnection,
+		},
+		"connections/:connectionId/test": {
+			"POST": api.TestExistingConnection,
+		},
+		"connections/:connectionId/remote-scopes": {
+			"GET": api.RemoteScopes,
+		},
+		"connections/:connectionId/search-remote-scopes": {
+			"GET": api.SearchRemoteScopes,
+		},
+		"connections/:connectionId/scopes/:scopeId": {
+			"GET":    api.GetScope,
+			"PATCH":  api.UpdateScope,
+			"DELETE": api.DeleteScope,
+		},
+		"connections/:connectionId/scopes": {
+			"GET":    api.GetScopeList,
+			"PUT":    api.PutScope,
+			"PATCH":  api.PatchScopes,
+			"DELETE": api.DeleteScopes,
+		},
+		"connections/:connectionId/scope-jobs": {
+			"GET": api.GetScopeJobs,
+		},
+		"connections/:connectionId/scope-jobs/:jobId": {
+			"GET": api.GetScopeJob,
+		},
+		"connections/:connectionId/scopes/:scopeId/latest-sync-state": {
+			"GET": api.GetScopeLatestSyncState,
+		},
+		"connections/:connectionId/proxy/rest/*path": {
+			"GET": api.Proxy,
+		},
+	}
+}
+
+func (p Confluence) MakeDataSourcePipelinePlanV200(
+	connectionId uint64,
+	scopes []*coreModels.BlueprintScope,
+) (coreModels.PipelinePlan, []plugin.Scope, errors.Error) {
+	return api.MakeDataSourcePipelinePlanV200(p.SubTaskMetas(), connectionId, scopes)
+}
+
+func (p Confluence) Close(taskCtx plugin.TaskContext) errors.Error {
+	data, ok := taskCtx.GetData().(*tasks.ConfluenceTaskData)
+	if !ok {
+		return errors.Default.New(fmt.Sprintf("GetData failed when try to close %+v", taskCtx))
+	}
+	data.ApiClient.Release()
+	return nil
+}
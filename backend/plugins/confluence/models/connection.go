@@ -0,0 +1,66 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/core/utils"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var _ plugin.ApiConnection = (*ConfluenceConnection)(nil)
+
+// ConfluenceConn holds the essential information to connect to the Confluence API,
+// the endpoint looks like https://your-domain.atlassian.net/wiki/ and Confluence Cloud
+// takes the email of the user as username and an api token as password
+type ConfluenceConn struct {
+	api.RestConnection `mapstructure:",squash"`
+	api.BasicAuth      `mapstructure:",squash"`
+}
+
+func (conn ConfluenceConn) Sanitize() ConfluenceConn {
+	conn.Password = ""
+	return conn
+}
+
+// ConfluenceConnection holds ConfluenceConn plus ID/Name for database storage
+type ConfluenceConnection struct {
+	api.BaseConnection `mapstructure:",squash"`
+	ConfluenceConn     `mapstructure:",squash"`
+}
+
+func (connection *ConfluenceConnection) MergeFromRequest(target *ConfluenceConnection, body map[string]interface{}) error {
+	password := target.Password
+	if err := api.DecodeMapStruct(body, target, true); err != nil {
+		return err
+	}
+	modifiedPassword := target.Password
+	if modifiedPassword == "" || modifiedPassword == utils.SanitizeString(password) {
+		target.Password = password
+	}
+	return nil
+}
+
+func (connection ConfluenceConnection) Sanitize() ConfluenceConnection {
+	connection.ConfluenceConn = connection.ConfluenceConn.Sanitize()
+	return connection
+}
+
+func (ConfluenceConnection) TableName() string {
+	return "_tool_confluence_connections"
+}
@@ -0,0 +1,45 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// ConfluencePage is the latest state of a page, LastUpdatedDate tells how fresh the page is
+type ConfluencePage struct {
+	ConnectionId         uint64 `gorm:"primaryKey"`
+	PageId               string `gorm:"primaryKey;type:varchar(100)"`
+	SpaceKey             string `gorm:"type:varchar(255);index"`
+	ParentId             string `gorm:"type:varchar(100)"`
+	Title                string `gorm:"type:varchar(500)"`
+	Status               string `gorm:"type:varchar(100)"`
+	Url                  string `gorm:"type:varchar(500)"`
+	Version              int
+	CreatorAccountId     string `gorm:"type:varchar(255)"`
+	CreatedDate          *time.Time
+	LastUpdaterAccountId string `gorm:"type:varchar(255)"`
+	LastUpdatedDate      *time.Time
+	common.NoPKModel
+}
+
+func (ConfluencePage) TableName() string {
+	return "_tool_confluence_pages"
+}
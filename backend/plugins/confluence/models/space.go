@@ -0,0 +1,93 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var _ plugin.ToolLayerScope = (*ConfluenceSpace)(nil)
+
+type ConfluenceApiParams struct {
+	ConnectionId uint64
+	SpaceKey     string
+}
+
+// ConfluenceSpace is the scope of the plugin, the pages are collected space by space
+type ConfluenceSpace struct {
+	common.Scope `mapstructure:",squash"`
+	SpaceKey     string `json:"spaceKey" validate:"required" gorm:"type:varchar(255);primaryKey" mapstructure:"spaceKey"`
+	SpaceId      uint64 `json:"spaceId" mapstructure:"spaceId"`
+	Name         string `json:"name" gorm:"type:varchar(255)" mapstructure:"name"`
+	Type         string `json:"type" gorm:"type:varchar(100)" mapstructure:"type"`
+	Status       string `json:"status" gorm:"type:varchar(100)" mapstructure:"status"`
+	Url          string `json:"url" gorm:"type:varchar(255)" mapstructure:"url"`
+}
+
+func (ConfluenceSpace) TableName() string {
+	return "_tool_confluence_spaces"
+}
+
+func (s ConfluenceSpace) ScopeId() string {
+	return s.SpaceKey
+}
+
+func (s ConfluenceSpace) ScopeName() string {
+	return s.Name
+}
+
+func (s ConfluenceSpace) ScopeFullName() string {
+	return s.Name
+}
+
+func (s ConfluenceSpace) ScopeParams() interface{} {
+	return ConfluenceApiParams{
+		ConnectionId: s.ConnectionId,
+		SpaceKey:     s.SpaceKey,
+	}
+}
+
+// ConfluenceApiSpace is a space returned by the rest/api/space endpoint
+type ConfluenceApiSpace struct {
+	Id     uint64 `json:"id"`
+	Key    string `json:"key"`
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Status string `json:"status"`
+	Links  struct {
+		Webui string `json:"webui"`
+	} `json:"_links"`
+}
+
+// ConvertApiScope takes the base url returned in the `_links` of the response, the web links of the space are relative to it
+func (s ConfluenceApiSpace) ConvertApiScope(baseUrl string) *ConfluenceSpace {
+	space := &ConfluenceSpace{
+		SpaceKey: s.Key,
+		SpaceId:  s.Id,
+		Name:     s.Name,
+		Type:     s.Type,
+		Status:   s.Status,
+	}
+	if s.Links.Webui != "" {
+		space.Url = strings.TrimSuffix(baseUrl, "/") + s.Links.Webui
+	}
+	return space
+}
@@ -0,0 +1,85 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/confluence/models"
+)
+
+var _ plugin.SubTaskEntryPoint = ConvertAccounts
+
+var ConvertAccountsMeta = plugin.SubTaskMeta{
+	Name:             "convertAccounts",
+	EntryPoint:       ConvertAccounts,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table _tool_confluence_accounts into domain layer table accounts",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CROSS},
+}
+
+// ConvertAccounts converts the contributors of the space, so their pages and versions can be attributed to team members
+func ConvertAccounts(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*ConfluenceTaskData)
+	db := taskCtx.GetDal()
+	connectionId := data.Options.ConnectionId
+	cursor, err := db.Cursor(
+		dal.Select("DISTINCT a.*"),
+		dal.From("_tool_confluence_accounts a"),
+		dal.Join(`JOIN _tool_confluence_page_versions v
+			ON v.connection_id = a.connection_id AND v.author_account_id = a.account_id`),
+		dal.Where("a.connection_id = ? AND v.space_key = ?", connectionId, data.Options.SpaceKey),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	accountIdGen := didgen.NewDomainIdGenerator(&models.ConfluenceAccount{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx:     taskCtx,
+			Options: data.Options,
+			Table:   RAW_PAGE_VERSIONS_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.ConfluenceAccount{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			account := inputRow.(*models.ConfluenceAccount)
+			return []interface{}{
+				&crossdomain.Account{
+					DomainEntity: domainlayer.DomainEntity{Id: accountIdGen.Generate(connectionId, account.AccountId)},
+					UserName:     account.DisplayName,
+					FullName:     account.DisplayName,
+					Email:        account.Email,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
@@ -0,0 +1,91 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/confluence/models"
+)
+
+var _ plugin.SubTaskEntryPoint = ExtractPages
+
+var ExtractPagesMeta = plugin.SubTaskMeta{
+	Name:             "extractPages",
+	EntryPoint:       ExtractPages,
+	EnabledByDefault: true,
+	Description:      "Extract raw data into tool layer table _tool_confluence_pages and _tool_confluence_accounts",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CROSS},
+}
+
+func ExtractPages(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*ConfluenceTaskData)
+	endpoint := data.ApiClient.GetEndpoint()
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx:     taskCtx,
+			Options: data.Options,
+			Table:   RAW_PAGES_TABLE,
+		},
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiPage := &confluenceApiPage{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiPage))
+			if err != nil {
+				return nil, err
+			}
+			return extractPage(data.Options, endpoint, apiPage), nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
+
+func extractPage(op *ConfluenceOptions, endpoint string, apiPage *confluenceApiPage) []interface{} {
+	page := &models.ConfluencePage{
+		ConnectionId:     op.ConnectionId,
+		PageId:           apiPage.Id,
+		SpaceKey:         op.SpaceKey,
+		Title:            apiPage.Title,
+		Status:           apiPage.Status,
+		Url:              webUrl(endpoint, apiPage.Links.Webui),
+		CreatorAccountId: apiPage.History.CreatedBy.id(),
+		CreatedDate:      apiPage.History.CreatedDate.ToNullableTime(),
+	}
+	// the last ancestor is the direct parent of the page
+	if len(apiPage.Ancestors) > 0 {
+		page.ParentId = apiPage.Ancestors[len(apiPage.Ancestors)-1].Id
+	}
+	results := []interface{}{page}
+	if account := toAccount(op.ConnectionId, apiPage.History.CreatedBy); account != nil {
+		results = append(results, account)
+	}
+	if apiPage.Version != nil {
+		page.Version = apiPage.Version.Number
+		page.LastUpdaterAccountId = apiPage.Version.By.id()
+		page.LastUpdatedDate = apiPage.Version.When.ToNullableTime()
+		if account := toAccount(op.ConnectionId, apiPage.Version.By); account != nil {
+			results = append(results, account)
+		}
+	}
+	return results
+}
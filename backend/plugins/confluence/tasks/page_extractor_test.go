@@ -0,0 +1,75 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/plugins/confluence/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractPage(t *testing.T) {
+	apiPage := &confluenceApiPage{}
+	err := json.Unmarshal([]byte(`{
+		"id": "123",
+		"status": "current",
+		"title": "Runbook",
+		"history": {
+			"createdBy": {"accountId": "a1", "displayName": "Alice", "email": "alice@example.com"},
+			"createdDate": "2024-01-02T03:04:05.000Z"
+		},
+		"version": {
+			"number": 3,
+			"when": "2024-02-03T04:05:06.000Z",
+			"by": {"username": "bob", "userKey": "k2", "displayName": "Bob"}
+		},
+		"ancestors": [{"id": "1"}, {"id": "12"}],
+		"_links": {"webui": "/spaces/OPS/pages/123/Runbook"}
+	}`), apiPage)
+	assert.Nil(t, err)
+
+	results := extractPage(&ConfluenceOptions{ConnectionId: 1, SpaceKey: "OPS"}, "https://example.atlassian.net/wiki/", apiPage)
+	assert.Len(t, results, 3)
+	page := results[0].(*models.ConfluencePage)
+	assert.Equal(t, "12", page.ParentId)
+	assert.Equal(t, "https://example.atlassian.net/wiki/spaces/OPS/pages/123/Runbook", page.Url)
+	assert.Equal(t, "a1", page.CreatorAccountId)
+	assert.Equal(t, "k2", page.LastUpdaterAccountId)
+	assert.Equal(t, 3, page.Version)
+	assert.Equal(t, time.Date(2024, 2, 3, 4, 5, 6, 0, time.UTC), page.LastUpdatedDate.UTC())
+	assert.Equal(t, "Alice", results[1].(*models.ConfluenceAccount).DisplayName)
+	assert.Equal(t, "k2", results[2].(*models.ConfluenceAccount).AccountId)
+}
+
+func TestExtractPageWithoutVersion(t *testing.T) {
+	results := extractPage(&ConfluenceOptions{ConnectionId: 1, SpaceKey: "OPS"}, "", &confluenceApiPage{Id: "123"})
+	assert.Len(t, results, 1)
+	assert.Equal(t, "", results[0].(*models.ConfluencePage).Url)
+}
+
+func TestBuildPagesCQL(t *testing.T) {
+	assert.Equal(t, `space = "OPS" AND type = page ORDER BY created ASC`, buildPagesCQL("OPS", nil))
+	since := time.Date(2024, 3, 1, 0, 30, 0, 0, time.UTC)
+	assert.Equal(t,
+		`space = "OPS" AND type = page AND lastmodified >= "2024-02-29" ORDER BY created ASC`,
+		buildPagesCQL("OPS", &since),
+	)
+}
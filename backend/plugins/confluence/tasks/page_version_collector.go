@@ -0,0 +1,98 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/confluence/models"
+)
+
+const RAW_PAGE_VERSIONS_TABLE = "confluence_api_page_versions"
+
+var _ plugin.SubTaskEntryPoint = CollectPageVersions
+
+var CollectPageVersionsMeta = plugin.SubTaskMeta{
+	Name:             "collectPageVersions",
+	EntryPoint:       CollectPageVersions,
+	EnabledByDefault: true,
+	Description:      "collect the versions of Confluence pages, only the pages updated since the last collection in diffSync mode",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CROSS},
+	DependencyTables: []string{models.ConfluencePage{}.TableName()},
+	ProductTables:    []string{RAW_PAGE_VERSIONS_TABLE},
+}
+
+type pageInput struct {
+	PageId string
+}
+
+func CollectPageVersions(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*ConfluenceTaskData)
+	db := taskCtx.GetDal()
+	apiCollector, err := api.NewStatefulApiCollector(api.RawDataSubTaskArgs{
+		Ctx:     taskCtx,
+		Options: data.Options,
+		Table:   RAW_PAGE_VERSIONS_TABLE,
+	})
+	if err != nil {
+		return err
+	}
+
+	clauses := []dal.Clause{
+		dal.Select("page_id"),
+		dal.From(&models.ConfluencePage{}),
+		dal.Where("connection_id = ? AND space_key = ?", data.Options.ConnectionId, data.Options.SpaceKey),
+	}
+	if apiCollector.IsIncremental() && apiCollector.GetSince() != nil {
+		clauses = append(clauses, dal.Where("last_updated_date > ?", apiCollector.GetSince()))
+	}
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return err
+	}
+	iterator, err := api.NewDalCursorIterator(db, cursor, reflect.TypeOf(pageInput{}))
+	if err != nil {
+		return err
+	}
+
+	err = apiCollector.InitCollector(api.ApiCollectorArgs{
+		ApiClient:   data.ApiClient,
+		PageSize:    100,
+		Concurrency: 5,
+		Input:       iterator,
+		UrlTemplate: "rest/api/content/{{ .Input.PageId }}/version",
+		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+			query := url.Values{}
+			query.Set("start", fmt.Sprintf("%v", reqData.Pager.Skip))
+			query.Set("limit", fmt.Sprintf("%v", reqData.Pager.Size))
+			return query, nil
+		},
+		ResponseParser: parseResults,
+		AfterResponse:  ignoreHTTPStatus404,
+	})
+	if err != nil {
+		return err
+	}
+	return apiCollector.Execute()
+}
@@ -0,0 +1,79 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/confluence/models"
+)
+
+var _ plugin.SubTaskEntryPoint = ExtractPageVersions
+
+var ExtractPageVersionsMeta = plugin.SubTaskMeta{
+	Name:             "extractPageVersions",
+	EntryPoint:       ExtractPageVersions,
+	EnabledByDefault: true,
+	Description:      "Extract raw data into tool layer table _tool_confluence_page_versions and _tool_confluence_accounts",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CROSS},
+}
+
+func ExtractPageVersions(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*ConfluenceTaskData)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx:     taskCtx,
+			Options: data.Options,
+			Table:   RAW_PAGE_VERSIONS_TABLE,
+		},
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			input := &pageInput{}
+			err := errors.Convert(json.Unmarshal(row.Input, input))
+			if err != nil {
+				return nil, err
+			}
+			apiVersion := &confluenceApiVersion{}
+			err = errors.Convert(json.Unmarshal(row.Data, apiVersion))
+			if err != nil {
+				return nil, err
+			}
+			version := &models.ConfluencePageVersion{
+				ConnectionId:    data.Options.ConnectionId,
+				PageId:          input.PageId,
+				Number:          apiVersion.Number,
+				SpaceKey:        data.Options.SpaceKey,
+				AuthorAccountId: apiVersion.By.id(),
+				Message:         apiVersion.Message,
+				MinorEdit:       apiVersion.MinorEdit,
+				CreatedDate:     apiVersion.When.ToNullableTime(),
+			}
+			results := []interface{}{version}
+			if account := toAccount(data.Options.ConnectionId, apiVersion.By); account != nil {
+				results = append(results, account)
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
@@ -0,0 +1,120 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/confluence/models"
+)
+
+// confluenceApiUser is the user embedded in pages and versions, Confluence Cloud identifies users by accountId
+// while Confluence Server and Data Center use userKey and username
+type confluenceApiUser struct {
+	AccountId   string `json:"accountId"`
+	AccountType string `json:"accountType"`
+	UserKey     string `json:"userKey"`
+	Username    string `json:"username"`
+	DisplayName string `json:"displayName"`
+	Email       string `json:"email"`
+}
+
+func (u *confluenceApiUser) id() string {
+	if u == nil {
+		return ""
+	}
+	if u.AccountId != "" {
+		return u.AccountId
+	}
+	if u.UserKey != "" {
+		return u.UserKey
+	}
+	return u.Username
+}
+
+type confluenceApiVersion struct {
+	Number    int                 `json:"number"`
+	When      *common.Iso8601Time `json:"when"`
+	Message   string              `json:"message"`
+	MinorEdit bool                `json:"minorEdit"`
+	By        *confluenceApiUser  `json:"by"`
+}
+
+type confluenceApiPage struct {
+	Id      string `json:"id"`
+	Status  string `json:"status"`
+	Title   string `json:"title"`
+	History struct {
+		CreatedBy   *confluenceApiUser  `json:"createdBy"`
+		CreatedDate *common.Iso8601Time `json:"createdDate"`
+	} `json:"history"`
+	Version   *confluenceApiVersion `json:"version"`
+	Ancestors []struct {
+		Id string `json:"id"`
+	} `json:"ancestors"`
+	Links struct {
+		Webui string `json:"webui"`
+	} `json:"_links"`
+}
+
+// parseResults returns the results of a paginated Confluence response
+func parseResults(res *http.Response) ([]json.RawMessage, errors.Error) {
+	var body struct {
+		Results []json.RawMessage `json:"results"`
+	}
+	err := api.UnmarshalResponse(res, &body)
+	return body.Results, err
+}
+
+// toAccount returns nil for anonymous or missing users
+func toAccount(connectionId uint64, user *confluenceApiUser) *models.ConfluenceAccount {
+	accountId := user.id()
+	if accountId == "" {
+		return nil
+	}
+	return &models.ConfluenceAccount{
+		ConnectionId: connectionId,
+		AccountId:    accountId,
+		DisplayName:  user.DisplayName,
+		Email:        user.Email,
+		AccountType:  user.AccountType,
+	}
+}
+
+func webUrl(endpoint string, webui string) string {
+	if webui == "" {
+		return ""
+	}
+	return strings.TrimSuffix(endpoint, "/") + webui
+}
+
+// ignoreHTTPStatus404 skips the pages deleted after they were collected
+func ignoreHTTPStatus404(res *http.Response) errors.Error {
+	if res.StatusCode == http.StatusUnauthorized {
+		return errors.Unauthorized.New("authentication failed, please check your username and api token")
+	}
+	if res.StatusCode == http.StatusNotFound {
+		return api.ErrIgnoreAndContinue
+	}
+	return nil
+}
@@ -40,6 +40,8 @@ type Field struct {
 	DisplayName string `json:"displayName" example:"department"`
 	DataType    string `json:"dataType" example:"varchar(255)"`
 	Description string `json:"description" example:"more details about the column"`
+	// Expression makes the field computed from the other columns of the row, i.e. a bucket of the lead time
+	Expression string `json:"expression" example:"CASE WHEN lead_time_minutes < 1440 THEN 'within a day' ELSE 'longer' END"`
 }
 
 func (f *Field) toDBModel(table string) (*models.CustomizedField, errors.Error) {
@@ -59,6 +61,7 @@ func (f *Field) toDBModel(table string) (*models.CustomizedField, errors.Error)
 		DisplayName: f.DisplayName,
 		DataType:    t,
 		Description: f.Description,
+		Expression:  strings.TrimSpace(f.Expression),
 	}, nil
 }
 
@@ -69,6 +72,7 @@ func fromCustomizedField(cf models.CustomizedField) fieldResponse {
 			DisplayName: cf.DisplayName,
 			DataType:    cf.DataType.String(),
 			Description: cf.Description,
+			Expression:  cf.Expression,
 		},
 		IsCustomizedField: strings.HasPrefix(cf.ColumnName, "x_"),
 	}
@@ -105,7 +109,7 @@ func (h *Handlers) ListFields(input *plugin.ApiResourceInput) (*plugin.ApiResour
 
 // CreateFields create a customized field
 // @Summary create a customized field
-// @Description create a customized field
+// @Description create a customized field, the field is computed by the subtask computeCustomizedFields if the expression is given
 // @Tags plugins/customize
 // @Param table path string true "the table name"
 // @Param request body Field true "request body"
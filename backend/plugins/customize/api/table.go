@@ -0,0 +1,209 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/customize/models"
+)
+
+type TableRequest struct {
+	Name        string  `json:"name" example:"x_service_tiers"`
+	DisplayName string  `json:"displayName" example:"service tiers"`
+	Description string  `json:"description" example:"the tiers of the services"`
+	Fields      []Field `json:"fields"`
+}
+
+type tableResponse struct {
+	*models.CustomizedTable
+	Fields []fieldResponse `json:"fields"`
+}
+
+type TableRowsRequest struct {
+	Rows        []map[string]interface{} `json:"rows"`
+	Incremental bool                     `json:"incremental"`
+}
+
+type tableRowsResponse struct {
+	Rows  []map[string]interface{} `json:"rows"`
+	Count int64                    `json:"count"`
+}
+
+// ListTables return all customized tables
+// @Summary return all customized tables
+// @Description return all tables defined by the users
+// @Tags plugins/customize
+// @Success 200  {object} []models.CustomizedTable "Success"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/customize/tables [GET]
+func (h *Handlers) ListTables(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	tables, err := h.svc.ListTables()
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: tables, Status: http.StatusOK}, nil
+}
+
+// CreateTable create a customized table
+// @Summary create a customized table
+// @Description create a table to bring in the data no plugin provides, it has the columns id, created_at, updated_at and the given fields
+// @Tags plugins/customize
+// @Param request body TableRequest true "request body"
+// @Success 200  {object} tableResponse "Success"
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/customize/tables [POST]
+func (h *Handlers) CreateTable(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	request := &TableRequest{}
+	err := helper.Decode(input.Body, request, nil)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid body")
+	}
+	table := &models.CustomizedTable{
+		Name:        request.Name,
+		DisplayName: request.DisplayName,
+		Description: request.Description,
+	}
+	fields := make([]*models.CustomizedField, 0, len(request.Fields))
+	for _, fld := range request.Fields {
+		customizedField, err := fld.toDBModel(table.Name)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, customizedField)
+	}
+	if err = h.svc.CreateTable(table, fields); err != nil {
+		return nil, err
+	}
+	return h.getTable(table.Name)
+}
+
+// GetTable return a customized table
+// @Summary return a customized table
+// @Description return a customized table along with its fields
+// @Tags plugins/customize
+// @Param table path string true "the table name"
+// @Success 200  {object} tableResponse "Success"
+// @Failure 404  {object} shared.ApiBody "Not Found"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/customize/tables/{table} [GET]
+func (h *Handlers) GetTable(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return h.getTable(input.Params["table"])
+}
+
+// DeleteTable delete a customized table
+// @Summary delete a customized table
+// @Description drop a customized table along with its rows
+// @Tags plugins/customize
+// @Param table path string true "the table name"
+// @Success 200  {object} shared.ApiBody "Success"
+// @Failure 404  {object} shared.ApiBody "Not Found"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/customize/tables/{table} [DELETE]
+func (h *Handlers) DeleteTable(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	err := h.svc.DeleteTable(input.Params["table"])
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Status: http.StatusOK}, nil
+}
+
+// GetTableRows return the rows of a customized table
+// @Summary return the rows of a customized table
+// @Description return a page of the rows of a customized table ordered by id
+// @Tags plugins/customize
+// @Param table path string true "the table name"
+// @Param page query int false "page number, default 1"
+// @Param pageSize query int false "page size, default 50"
+// @Success 200  {object} tableRowsResponse "Success"
+// @Failure 404  {object} shared.ApiBody "Not Found"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/customize/tables/{table}/rows [GET]
+func (h *Handlers) GetTableRows(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	limit, offset := helper.GetLimitOffset(input.Query, "pageSize", "page")
+	rows, count, err := h.svc.GetTableRows(input.Params["table"], limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: tableRowsResponse{Rows: rows, Count: count}, Status: http.StatusOK}, nil
+}
+
+// PostTableRows save json rows into a customized table
+// @Summary save json rows into a customized table
+// @Description save the rows into a customized table, the rows are replaced unless incremental is true, a row with an existing id updates it
+// @Tags plugins/customize
+// @Param table path string true "the table name"
+// @Param request body TableRowsRequest true "request body"
+// @Success 200
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 404  {object} shared.ApiBody "Not Found"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/customize/tables/{table}/rows [POST]
+func (h *Handlers) PostTableRows(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	request := &TableRowsRequest{}
+	err := helper.Decode(input.Body, request, nil)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid body")
+	}
+	return nil, h.svc.SaveTableRows(input.Params["table"], request.Rows, request.Incremental)
+}
+
+// ImportTableRows accepts a CSV file, parses and saves it to a customized table
+// @Summary      Upload the rows of a customized table
+// @Description  Upload a csv file with the column id and the fields of the table, the rows are replaced unless incremental is true
+// @Tags 		 plugins/customize
+// @Accept       multipart/form-data
+// @Param        table path string true "the table name"
+// @Param        incremental formData bool false "whether to import incrementally"
+// @Param        file formData file true "select file to upload"
+// @Produce      json
+// @Success      200
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 404  {object} shared.ApiBody "Not Found"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/customize/tables/{table}/rows.csv [post]
+func (h *Handlers) ImportTableRows(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	file, err := h.extractFile(input)
+	if err != nil {
+		return nil, err
+	}
+	// nolint
+	defer file.Close()
+	incremental := input.Request.FormValue("incremental") == "true"
+	return nil, h.svc.ImportTableRows(input.Params["table"], file, incremental)
+}
+
+func (h *Handlers) getTable(name string) (*plugin.ApiResourceOutput, errors.Error) {
+	table, err := h.svc.GetTable(name)
+	if err != nil {
+		return nil, err
+	}
+	customizedFields, err := h.svc.GetFields(name)
+	if err != nil {
+		return nil, err
+	}
+	fields := make([]fieldResponse, 0, len(customizedFields))
+	for _, cf := range customizedFields {
+		fields = append(fields, fromCustomizedField(cf))
+	}
+	return &plugin.ApiResourceOutput{Body: tableResponse{CustomizedTable: table, Fields: fields}, Status: http.StatusOK}, nil
+}
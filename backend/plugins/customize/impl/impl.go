@@ -18,9 +18,12 @@ limitations under the License.
 package impl
 
 import (
+	"encoding/json"
+
 	"github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
+	coreModels "github.com/apache/incubator-devlake/core/models"
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/plugins/customize/api"
 	"github.com/apache/incubator-devlake/plugins/customize/models"
@@ -35,6 +38,7 @@ var _ interface {
 	plugin.PluginApi
 	plugin.PluginModel
 	plugin.PluginMigration
+	plugin.MetricPluginBlueprintV200
 } = (*Customize)(nil)
 
 var handlers *api.Handlers
@@ -50,12 +54,14 @@ func (p Customize) Init(basicRes context.BasicRes) errors.Error {
 func (p Customize) GetTablesInfo() []dal.Tabler {
 	return []dal.Tabler{
 		&models.CustomizedField{},
+		&models.CustomizedTable{},
 	}
 }
 
 func (p Customize) SubTaskMetas() []plugin.SubTaskMeta {
 	return []plugin.SubTaskMeta{
 		tasks.ExtractCustomizedFieldsMeta,
+		tasks.ComputeCustomizedFieldsMeta,
 	}
 }
 
@@ -74,6 +80,30 @@ func (p Customize) PrepareTaskData(taskCtx plugin.TaskContext, options map[strin
 	return taskData, nil
 }
 
+// MakeMetricPluginPipelinePlanV200 computes the customized fields once the data sources of the project are synced
+func (p Customize) MakeMetricPluginPipelinePlanV200(projectName string, options json.RawMessage) (coreModels.PipelinePlan, errors.Error) {
+	op := &tasks.Options{}
+	if options != nil && string(options) != "\"\"" {
+		err := json.Unmarshal(options, op)
+		if err != nil {
+			return nil, errors.Default.WrapRaw(err)
+		}
+	}
+	taskOptions := map[string]interface{}{"projectName": projectName}
+	if len(op.ComputedFieldTables) > 0 {
+		taskOptions["computedFieldTables"] = op.ComputedFieldTables
+	}
+	return coreModels.PipelinePlan{
+		{
+			{
+				Plugin:   "customize",
+				Subtasks: []string{tasks.ComputeCustomizedFieldsMeta.Name},
+				Options:  taskOptions,
+			},
+		},
+	}, nil
+}
+
 func (p Customize) Description() string {
 	return "To customize table fields"
 }
@@ -99,6 +129,21 @@ func (p Customize) ApiResources() map[string]map[string]plugin.ApiResourceHandle
 		":table/fields/:field": {
 			"DELETE": handlers.DeleteField,
 		},
+		"tables": {
+			"GET":  handlers.ListTables,
+			"POST": handlers.CreateTable,
+		},
+		"tables/:table": {
+			"GET":    handlers.GetTable,
+			"DELETE": handlers.DeleteTable,
+		},
+		"tables/:table/rows": {
+			"GET":  handlers.GetTableRows,
+			"POST": handlers.PostTableRows,
+		},
+		"tables/:table/rows.csv": {
+			"POST": handlers.ImportTableRows,
+		},
 		"csvfiles/issues.csv": {
 			"POST": handlers.ImportIssue,
 		},
@@ -31,6 +31,9 @@ type CustomizedField struct {
 	DisplayName string         `gorm:"type:varchar(255)"`
 	DataType    dal.ColumnType `gorm:"type:varchar(255)"`
 	Description string
+	// Expression is the SQL expression computing the value from the other columns of the same row, the values
+	// are materialized by the subtask computeCustomizedFields
+	Expression string
 }
 
 func (t *CustomizedField) TableName() string {
@@ -0,0 +1,44 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+)
+
+// CustomizedTable is a table defined by the users to bring in the data no plugin provides, i.e. the tiers of the
+// services or the cost centers, its columns are recorded as the CustomizedField of the table
+type CustomizedTable struct {
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+	Name        string    `json:"name" gorm:"primaryKey;type:varchar(255)"`
+	DisplayName string    `json:"displayName" gorm:"type:varchar(255)"`
+	Description string    `json:"description"`
+}
+
+func (t *CustomizedTable) TableName() string {
+	return "_tool_customized_tables"
+}
+
+// CustomizedTableRow holds the columns every customized table starts with, the rows are identified by the id
+// so that importing them again updates them
+type CustomizedTableRow struct {
+	Id        string    `gorm:"primaryKey;type:varchar(255)"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
@@ -0,0 +1,42 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/plugins/customize/models/migrationscripts/archived"
+)
+
+type addCustomizedTables struct{}
+
+func (script *addCustomizedTables) Up(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().AutoMigrate(&archived.CustomizedTable{})
+}
+
+func (*addCustomizedTables) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropTables(&archived.CustomizedTable{})
+}
+
+func (*addCustomizedTables) Version() uint64 {
+	return 20261015210000
+}
+
+func (*addCustomizedTables) Name() string {
+	return "add _tool_customized_tables"
+}
@@ -32,17 +32,21 @@ import (
 	"github.com/apache/incubator-devlake/core/models/domainlayer/qa"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
 	"github.com/apache/incubator-devlake/helpers/pluginhelper"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	customizeModels "github.com/apache/incubator-devlake/plugins/customize/models"
+	"github.com/apache/incubator-devlake/plugins/customize/tasks"
 )
 
 // Service wraps database operations
 type Service struct {
 	dal         dal.Dal
 	nameChecker *regexp.Regexp
+	// anonymizer is nil unless ANONYMIZE_PII is enabled, the imported records don't go through the batch savers
+	anonymizer *api.Anonymizer
 }
 
 func NewService(dal dal.Dal) *Service {
-	return &Service{dal: dal, nameChecker: regexp.MustCompile(`^x_[a-zA-Z0-9_]{0,50}$`)}
+	return &Service{dal: dal, nameChecker: regexp.MustCompile(`^x_[a-zA-Z0-9_]{0,50}$`), anonymizer: api.NewAnonymizer()}
 }
 
 // GetFields returns all the fields of the table
@@ -119,6 +123,11 @@ func (s *Service) CreateField(cf *customizeModels.CustomizedField) errors.Error
 	if exists {
 		return errors.BadInput.New(fmt.Sprintf("the column %s already exists", cf.ColumnName))
 	}
+	if cf.Expression != "" {
+		if err = s.checkExpression(cf.TbName, cf.Expression); err != nil {
+			return err
+		}
+	}
 	err = s.dal.Create(cf)
 	if err != nil {
 		return errors.Default.Wrap(err, "create customizedField")
@@ -130,6 +139,27 @@ func (s *Service) CreateField(cf *customizeModels.CustomizedField) errors.Error
 	return nil
 }
 
+// checkExpression makes sure the expression of a computed field only refers to the columns of the table and is valid,
+// the database is asked to evaluate it on no rows at all
+func (s *Service) checkExpression(table, expression string) errors.Error {
+	columnMetas, err := s.dal.GetColumns(&dal.DefaultTabler{Name: table}, nil)
+	if err != nil {
+		return err
+	}
+	columns := make([]string, 0, len(columnMetas))
+	for _, columnMeta := range columnMetas {
+		columns = append(columns, columnMeta.Name())
+	}
+	if err = tasks.CheckExpression(expression, columns); err != nil {
+		return err
+	}
+	err = s.dal.Exec(fmt.Sprintf("SELECT (%s) FROM %s WHERE 1 = 0", expression, table))
+	if err != nil {
+		return errors.BadInput.Wrap(err, "invalid expression")
+	}
+	return nil
+}
+
 // DeleteField deletes the `field` form the `table`
 func (s *Service) DeleteField(table, field string) errors.Error {
 	exists, err := s.checkField(table, field)
@@ -256,6 +286,9 @@ func (s *Service) importCSV(file io.ReadCloser, rawDataParams string, recordHand
 			}
 			record["created_at"] = now
 			record["updated_at"] = now
+			if s.anonymizer != nil {
+				s.anonymizer.AnonymizeRecord(record)
+			}
 			err = recordHandler(record)
 			if err != nil {
 				return errors.BadInput.Wrap(err, fmt.Sprintf("error on processing the line:%d", line))
@@ -270,6 +303,9 @@ func (s *Service) createOrUpdateAccount(accountName string, rawDataParams string
 	if accountName == "" {
 		return "", nil // Return empty ID if name is empty, no error needed here.
 	}
+	if s.anonymizer != nil {
+		accountName = s.anonymizer.Name(accountName)
+	}
 	now := time.Now()
 	accountId := fmt.Sprintf("csv:CsvAccount:0:%s", accountName)
 	account := &crossdomain.Account{
@@ -18,11 +18,17 @@ limitations under the License.
 package service
 
 import (
+	"io"
 	"regexp"
 	"strings"
 	"testing"
 
+	"github.com/apache/incubator-devlake/core/config"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestService_checkFieldName(t *testing.T) {
@@ -190,3 +196,49 @@ func TestGetStringField(t *testing.T) {
 		})
 	}
 }
+
+func TestImportAnonymized(t *testing.T) {
+	cfg := config.GetConfig()
+	cfg.Set("ANONYMIZE_PII", true)
+	cfg.Set("ANONYMIZATION_SECRET", "secret")
+	defer func() {
+		cfg.Set("ANONYMIZE_PII", false)
+		cfg.Set("ANONYMIZATION_SECRET", "")
+	}()
+
+	var saved []string
+	save := func(args mock.Arguments) {
+		switch r := args.Get(0).(type) {
+		case *crossdomain.Account:
+			saved = append(saved, r.Id, r.FullName, r.UserName)
+		case map[string]interface{}:
+			for _, v := range r {
+				if v, ok := v.(string); ok {
+					saved = append(saved, v)
+				}
+			}
+		}
+	}
+	mockDal := new(mockdal.Dal)
+	mockDal.On("CreateOrUpdate", mock.Anything, mock.Anything).Run(save).Return(nil)
+	mockDal.On("CreateWithMap", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		save(mock.Arguments{args.Get(1)})
+	}).Return(nil)
+	s := NewService(mockDal)
+
+	issues := "id,title,creator_name,assignee_name\nissue-1,crash,Alice Smith,Bob Jones\n"
+	assert.Nil(t, s.ImportIssue("board-1", io.NopCloser(strings.NewReader(issues)), true))
+	changelogs := "id,issue_id,author_name,field_name,original_from_value,original_to_value,created_date\n" +
+		"cl-1,issue-1,Alice Smith,assignee,Bob Jones,Carol White,2026-01-01T00:00:00Z\n"
+	assert.Nil(t, s.ImportIssueChangelog("board-1", io.NopCloser(strings.NewReader(changelogs)), true))
+
+	assert.NotEmpty(t, saved)
+	for _, value := range saved {
+		for _, name := range []string{"Alice", "Bob", "Carol"} {
+			assert.NotContains(t, value, name)
+		}
+	}
+	// the accounts of the issues and the changelogs are the same
+	assert.Contains(t, saved, "csv:CsvAccount:0:"+s.anonymizer.Name("Alice Smith"))
+	mockDal.AssertCalled(t, "CreateWithMap", &ticket.IssueChangelogs{}, mock.Anything)
+}
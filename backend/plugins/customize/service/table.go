@@ -0,0 +1,284 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	customizeModels "github.com/apache/incubator-devlake/plugins/customize/models"
+)
+
+// the customized tables are prefixed like the customized fields so they never collide with the tables of devlake
+var tableNameChecker = regexp.MustCompile(`^x_[a-z0-9_]{1,50}$`)
+
+// ListTables returns all the customized tables
+func (s *Service) ListTables() ([]*customizeModels.CustomizedTable, errors.Error) {
+	tables := make([]*customizeModels.CustomizedTable, 0)
+	err := s.dal.All(&tables, dal.Orderby("name"))
+	return tables, err
+}
+
+// GetTable returns the customized table
+func (s *Service) GetTable(name string) (*customizeModels.CustomizedTable, errors.Error) {
+	table := &customizeModels.CustomizedTable{}
+	err := s.dal.First(table, dal.Where("name = ?", name))
+	if err != nil {
+		if s.dal.IsErrorNotFound(err) {
+			return nil, errors.NotFound.New(fmt.Sprintf("customized table %s not found", name))
+		}
+		return nil, err
+	}
+	return table, nil
+}
+
+// CreateTable creates the table with the columns `id`, `created_at`, `updated_at` and the given fields
+func (s *Service) CreateTable(table *customizeModels.CustomizedTable, fields []*customizeModels.CustomizedField) errors.Error {
+	if !tableNameChecker.MatchString(table.Name) {
+		return errors.BadInput.New("the table name should start with x_ and contain lowercase letters, digits and underscores only")
+	}
+	if s.dal.HasTable(table.Name) {
+		return errors.BadInput.New(fmt.Sprintf("the table %s already exists", table.Name))
+	}
+	// check the fields before touching the database so a bad field doesn't leave a half-created table
+	seen := make(map[string]bool)
+	for _, field := range fields {
+		if !s.nameChecker.MatchString(field.ColumnName) {
+			return errors.BadInput.New(fmt.Sprintf("invalid column name %s", field.ColumnName))
+		}
+		if seen[field.ColumnName] {
+			return errors.BadInput.New(fmt.Sprintf("the column %s is duplicated", field.ColumnName))
+		}
+		seen[field.ColumnName] = true
+		field.TbName = table.Name
+	}
+	err := s.dal.Create(table)
+	if err != nil {
+		return errors.Default.Wrap(err, "create customizedTable")
+	}
+	if err = s.createTableColumns(table, fields); err != nil {
+		// the ddl can't be rolled back by a transaction on mysql, the table and its records are removed by hand
+		// so the name may be used again
+		if dropErr := s.dropTable(table.Name); dropErr != nil {
+			return errors.Default.Combine([]error{err, dropErr})
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *Service) createTableColumns(table *customizeModels.CustomizedTable, fields []*customizeModels.CustomizedField) errors.Error {
+	err := s.dal.AutoMigrate(&customizeModels.CustomizedTableRow{}, dal.From(table.Name))
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("failed to create the table %s", table.Name))
+	}
+	for _, field := range fields {
+		if err = s.CreateField(field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteTable drops the customized table along with its fields
+func (s *Service) DeleteTable(name string) errors.Error {
+	if _, err := s.GetTable(name); err != nil {
+		return err
+	}
+	return s.dropTable(name)
+}
+
+func (s *Service) dropTable(name string) errors.Error {
+	if err := s.dal.DropTables(name); err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("failed to drop the table %s", name))
+	}
+	if err := s.dal.Delete(&customizeModels.CustomizedField{}, dal.Where("tb_name = ?", name)); err != nil {
+		return err
+	}
+	return s.dal.Delete(&customizeModels.CustomizedTable{}, dal.Where("name = ?", name))
+}
+
+// GetTableRows returns a page of the rows of the customized table
+func (s *Service) GetTableRows(name string, limit, offset int) ([]map[string]interface{}, int64, errors.Error) {
+	if _, err := s.GetTable(name); err != nil {
+		return nil, 0, err
+	}
+	count, err := s.dal.Count(dal.From(name))
+	if err != nil {
+		return nil, 0, err
+	}
+	rows := make([]map[string]interface{}, 0)
+	err = s.dal.All(&rows, dal.From(name), dal.Orderby("id"), dal.Offset(offset), dal.Limit(limit))
+	return rows, count, err
+}
+
+// ImportTableRows imports the csv file into the customized table, the rows of the table are replaced unless
+// incremental is true, a row with an existing id updates it
+func (s *Service) ImportTableRows(name string, file io.ReadCloser, incremental bool) errors.Error {
+	return s.importTableRows(name, incremental, func(txSrv *Service, handler func(map[string]interface{}) errors.Error) errors.Error {
+		return txSrv.importCSV(file, name, handler)
+	})
+}
+
+// SaveTableRows is like ImportTableRows but takes the rows as json objects
+func (s *Service) SaveTableRows(name string, rows []map[string]interface{}, incremental bool) errors.Error {
+	return s.importTableRows(name, incremental, func(_ *Service, handler func(map[string]interface{}) errors.Error) errors.Error {
+		now := time.Now()
+		for i, row := range rows {
+			row["created_at"] = now
+			row["updated_at"] = now
+			if err := handler(row); err != nil {
+				return errors.BadInput.Wrap(err, fmt.Sprintf("error on processing the row:%d", i+1))
+			}
+		}
+		return nil
+	})
+}
+
+// importTableRows feeds the rows to the customized table in a single transaction, a bad row leaves the table as it
+// was instead of emptied or half imported
+func (s *Service) importTableRows(
+	name string,
+	incremental bool,
+	feed func(txSrv *Service, handler func(map[string]interface{}) errors.Error) errors.Error,
+) (err errors.Error) {
+	if _, err = s.GetTable(name); err != nil {
+		return err
+	}
+	fields, err := s.getCustomizedFields(name)
+	if err != nil {
+		return err
+	}
+	columns := make(map[string]dal.ColumnType, len(fields))
+	for _, field := range fields {
+		columns[field.ColumnName] = field.DataType
+	}
+	tx := s.dal.Begin()
+	defer func() {
+		r := recover()
+		if r != nil || err != nil {
+			_ = tx.Rollback()
+		}
+		if r != nil {
+			panic(r)
+		}
+	}()
+	txSrv := *s
+	txSrv.dal = tx
+	if !incremental {
+		if err = tx.Delete(&customizeModels.Table{Name: name}, dal.Where("1 = 1")); err != nil {
+			return err
+		}
+	}
+	err = feed(&txSrv, func(record map[string]interface{}) errors.Error {
+		row, err := toTableRow(record, columns)
+		if err != nil {
+			return err
+		}
+		return tx.CreateWithMap(&customizeModels.Table{Name: name}, row)
+	})
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// toTableRow keeps the id, the timestamps and the customized fields of the record, converting the values
+// to the types of the columns
+func toTableRow(record map[string]interface{}, columns map[string]dal.ColumnType) (map[string]interface{}, errors.Error) {
+	var id string
+	switch v := record["id"].(type) {
+	case string:
+		id = strings.TrimSpace(v)
+	case float64:
+		// json numbers are fine as ids too
+		id = strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	if id == "" {
+		return nil, errors.BadInput.New("record without required field id")
+	}
+	row := map[string]interface{}{
+		"id":         id,
+		"created_at": record["created_at"],
+		"updated_at": record["updated_at"],
+	}
+	for key, value := range record {
+		switch key {
+		case "id", "created_at", "updated_at", "_raw_data_params":
+			continue
+		}
+		dataType, ok := columns[key]
+		if !ok {
+			return nil, errors.BadInput.New(fmt.Sprintf("unknown column %s", key))
+		}
+		converted, err := convertColumnValue(value, dataType)
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, fmt.Sprintf("invalid value of the column %s", key))
+		}
+		row[key] = converted
+	}
+	return row, nil
+}
+
+// convertColumnValue converts the values of csv (strings) and json (strings, numbers and booleans) to the type
+// of the column, empty values are null
+func convertColumnValue(value interface{}, dataType dal.ColumnType) (interface{}, errors.Error) {
+	if value == nil {
+		return nil, nil
+	}
+	str, isString := value.(string)
+	if isString && strings.TrimSpace(str) == "" && dataType != dal.Varchar && dataType != dal.Text {
+		return nil, nil
+	}
+	switch dataType {
+	case dal.Int:
+		if number, ok := value.(float64); ok {
+			return int64(number), nil
+		}
+		if isString {
+			number, err := strconv.ParseInt(strings.TrimSpace(str), 10, 64)
+			return number, errors.Convert(err)
+		}
+	case dal.Float:
+		if number, ok := value.(float64); ok {
+			return number, nil
+		}
+		if isString {
+			number, err := strconv.ParseFloat(strings.TrimSpace(str), 64)
+			return number, errors.Convert(err)
+		}
+	case dal.Time:
+		if isString {
+			t, err := common.ConvertStringToTime(strings.TrimSpace(str))
+			return t, errors.Convert(err)
+		}
+	default:
+		if isString {
+			return str, nil
+		}
+		return fmt.Sprint(value), nil
+	}
+	return nil, errors.BadInput.New(fmt.Sprintf("%v is not a valid %s", value, dataType))
+}
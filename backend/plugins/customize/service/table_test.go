@@ -0,0 +1,140 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	customizeModels "github.com/apache/incubator-devlake/plugins/customize/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestToTableRow(t *testing.T) {
+	columns := map[string]dal.ColumnType{
+		"x_tier":      dal.Varchar,
+		"x_cost":      dal.Float,
+		"x_headcount": dal.Int,
+		"x_since":     dal.Time,
+	}
+	now := time.Now()
+	row, err := toTableRow(map[string]interface{}{
+		"id":               "payments",
+		"x_tier":           "1",
+		"x_cost":           "12.5",
+		"x_headcount":      float64(7),
+		"x_since":          "2023-01-02T03:04:05Z",
+		"created_at":       now,
+		"updated_at":       now,
+		"_raw_data_params": "x_service_tiers",
+	}, columns)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"id":          "payments",
+		"x_tier":      "1",
+		"x_cost":      12.5,
+		"x_headcount": int64(7),
+		"x_since":     time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC),
+		"created_at":  now,
+		"updated_at":  now,
+	}, row)
+
+	row, err = toTableRow(map[string]interface{}{"id": float64(42), "x_cost": ""}, columns)
+	assert.Nil(t, err)
+	assert.Equal(t, "42", row["id"])
+	assert.Nil(t, row["x_cost"])
+
+	_, err = toTableRow(map[string]interface{}{"x_tier": "1"}, columns)
+	assert.NotNil(t, err)
+	_, err = toTableRow(map[string]interface{}{"id": "payments", "x_unknown": "1"}, columns)
+	assert.NotNil(t, err)
+	_, err = toTableRow(map[string]interface{}{"id": "payments", "x_headcount": "seven"}, columns)
+	assert.NotNil(t, err)
+}
+
+func TestTableNameChecker(t *testing.T) {
+	assert.True(t, tableNameChecker.MatchString("x_service_tiers"))
+	assert.False(t, tableNameChecker.MatchString("service_tiers"))
+	assert.False(t, tableNameChecker.MatchString("x_Service"))
+	assert.False(t, tableNameChecker.MatchString("x_tiers; DROP TABLE issues"))
+}
+
+func TestCheckExpression(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	columns := make([]dal.ColumnMeta, 0)
+	for _, name := range []string{"id", "resolution_date"} {
+		column := mockdal.NewColumnMeta(t)
+		column.On("Name").Return(name)
+		columns = append(columns, column)
+	}
+	mockDal.On("GetColumns", mock.Anything, mock.Anything).Return(columns, nil)
+	mockDal.On("Exec", "SELECT (YEAR(resolution_date)) FROM issues WHERE 1 = 0", mock.Anything).Return(nil)
+	mockDal.On("Exec", "SELECT (YEAR(resolution_date) +) FROM issues WHERE 1 = 0", mock.Anything).Return(errors.Default.New("syntax error"))
+	svc := NewService(mockDal)
+
+	assert.Nil(t, svc.checkExpression("issues", "YEAR(resolution_date)"))
+	assert.Equal(t, errors.BadInput, svc.checkExpression("issues", "YEAR(resolution_date) +").GetType())
+	assert.Equal(t, errors.BadInput, svc.checkExpression("issues", "YEAR(no_such_column)").GetType())
+	assert.Equal(t, errors.BadInput, svc.checkExpression("issues", "1; DROP TABLE issues").GetType())
+	assert.Equal(t, errors.BadInput, svc.checkExpression("issues", "(SELECT password FROM users LIMIT 1)").GetType())
+}
+
+func TestSaveTableRowsRollsBackOnBadRow(t *testing.T) {
+	mockTx := new(mockdal.Transaction)
+	mockTx.On("Delete", &customizeModels.Table{Name: "x_service_tiers"}, mock.Anything).Return(nil).Once()
+	mockTx.On("CreateWithMap", &customizeModels.Table{Name: "x_service_tiers"}, mock.Anything).Return(nil).Once()
+	mockTx.On("Rollback").Return(nil).Once()
+	mockDal := new(mockdal.Dal)
+	mockDal.On("First", mock.Anything, mock.Anything).Return(nil)
+	mockDal.On("All", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(0).(*[]customizeModels.CustomizedField) = []customizeModels.CustomizedField{
+			{TbName: "x_service_tiers", ColumnName: "x_headcount", DataType: dal.Int},
+		}
+	}).Return(nil)
+	mockDal.On("Begin").Return(mockTx)
+	svc := NewService(mockDal)
+
+	err := svc.SaveTableRows("x_service_tiers", []map[string]interface{}{
+		{"id": "payments", "x_headcount": "7"},
+		{"id": "search", "x_headcount": "seven"},
+	}, false)
+	assert.Equal(t, errors.BadInput, err.GetType())
+	mockTx.AssertExpectations(t)
+	mockTx.AssertNotCalled(t, "Commit")
+}
+
+func TestCreateTableCleansUpOnFailure(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	mockDal.On("HasTable", "x_service_tiers").Return(false)
+	mockDal.On("Create", mock.Anything, mock.Anything).Return(nil)
+	mockDal.On("AutoMigrate", mock.Anything, mock.Anything).Return(errors.Default.New("disk full"))
+	mockDal.On("DropTables", []interface{}{"x_service_tiers"}).Return(nil).Once()
+	mockDal.On("Delete", &customizeModels.CustomizedField{}, mock.Anything).Return(nil).Once()
+	mockDal.On("Delete", &customizeModels.CustomizedTable{}, mock.Anything).Return(nil).Once()
+	svc := NewService(mockDal)
+
+	err := svc.CreateTable(&customizeModels.CustomizedTable{Name: "x_service_tiers"}, []*customizeModels.CustomizedField{
+		{ColumnName: "x_tier", DataType: dal.Varchar},
+	})
+	assert.NotNil(t, err)
+	mockDal.AssertExpectations(t)
+}
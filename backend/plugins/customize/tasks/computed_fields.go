@@ -0,0 +1,107 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/customize/models"
+)
+
+var _ plugin.SubTaskEntryPoint = ComputeCustomizedFields
+
+var ComputeCustomizedFieldsMeta = plugin.SubTaskMeta{Name: "computeCustomizedFields",
+	EntryPoint:       ComputeCustomizedFields,
+	EnabledByDefault: true,
+	Description:      "materialize the customized fields computed by SQL expressions",
+}
+
+// projectRowFilters restrict the computed fields of the tables to the rows of a project, through the scopes of the
+// project in project_mapping
+var projectRowFilters = map[string]string{
+	"issues":                  "id IN (SELECT bi.issue_id FROM board_issues bi JOIN project_mapping pm ON pm.row_id = bi.board_id AND pm.table = 'boards' WHERE pm.project_name = ?)",
+	"sprints":                 "id IN (SELECT bs.sprint_id FROM board_sprints bs JOIN project_mapping pm ON pm.row_id = bs.board_id AND pm.table = 'boards' WHERE pm.project_name = ?)",
+	"pull_requests":           "base_repo_id IN (SELECT row_id FROM project_mapping WHERE project_name = ? AND `table` = 'repos')",
+	"commits":                 "sha IN (SELECT rc.commit_sha FROM repo_commits rc JOIN project_mapping pm ON pm.row_id = rc.repo_id AND pm.table = 'repos' WHERE pm.project_name = ?)",
+	"cicd_pipelines":          "cicd_scope_id IN (SELECT row_id FROM project_mapping WHERE project_name = ? AND `table` = 'cicd_scopes')",
+	"cicd_tasks":              "cicd_scope_id IN (SELECT row_id FROM project_mapping WHERE project_name = ? AND `table` = 'cicd_scopes')",
+	"cicd_deployments":        "cicd_scope_id IN (SELECT row_id FROM project_mapping WHERE project_name = ? AND `table` = 'cicd_scopes')",
+	"cicd_deployment_commits": "cicd_scope_id IN (SELECT row_id FROM project_mapping WHERE project_name = ? AND `table` = 'cicd_scopes')",
+	"qa_test_cases":           "qa_project_id IN (SELECT row_id FROM project_mapping WHERE project_name = ? AND `table` = 'qa_projects')",
+}
+
+// ComputeCustomizedFields evaluates the expressions of the computed fields against the rows of their tables, only the
+// rows of the project when run for one. It is meant to run after the data sources so the fields see the latest data
+func ComputeCustomizedFields(taskCtx plugin.SubTaskContext) errors.Error {
+	d := taskCtx.GetDal()
+	logger := taskCtx.GetLogger()
+	var fields []*models.CustomizedField
+	clauses := []dal.Clause{dal.Where("expression IS NOT NULL AND expression != ''"), dal.Orderby("tb_name, column_name")}
+	projectName := ""
+	if data, ok := taskCtx.GetData().(*TaskData); ok && data != nil && data.Options != nil {
+		if len(data.Options.ComputedFieldTables) > 0 {
+			clauses = append(clauses, dal.Where("tb_name IN ?", data.Options.ComputedFieldTables))
+		}
+		projectName = data.Options.ProjectName
+	}
+	if err := d.All(&fields, clauses...); err != nil {
+		return err
+	}
+	taskCtx.SetProgress(0, len(fields))
+	for _, field := range fields {
+		if _, ok := projectRowFilters[field.TbName]; projectName != "" && !ok {
+			logger.Warn(nil, "the rows of %s can't be attributed to the project %s, %s is not computed", field.TbName, projectName, field.ColumnName)
+			taskCtx.IncProgress(1)
+			continue
+		}
+		logger.Info("computing %s.%s", field.TbName, field.ColumnName)
+		if err := computeCustomizedField(d, field, projectName); err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("failed to compute the field %s.%s", field.TbName, field.ColumnName))
+		}
+		taskCtx.IncProgress(1)
+	}
+	return nil
+}
+
+func computeCustomizedField(d dal.Dal, field *models.CustomizedField, projectName string) errors.Error {
+	columnMetas, err := d.GetColumns(&dal.DefaultTabler{Name: field.TbName}, nil)
+	if err != nil {
+		return err
+	}
+	columns := make([]string, 0, len(columnMetas))
+	hasColumn := false
+	for _, columnMeta := range columnMetas {
+		columns = append(columns, columnMeta.Name())
+		hasColumn = hasColumn || columnMeta.Name() == field.ColumnName
+	}
+	if !hasColumn {
+		return errors.BadInput.New(fmt.Sprintf("unknown column %s", field.ColumnName))
+	}
+	// the expressions were checked when the fields were created, they are checked again since they end up in the sql
+	if err := CheckExpression(field.Expression, columns); err != nil {
+		return err
+	}
+	sql := fmt.Sprintf("UPDATE %s SET %s = (%s)", field.TbName, field.ColumnName, field.Expression)
+	if projectName == "" {
+		return d.Exec(sql)
+	}
+	return d.Exec(sql+" WHERE "+projectRowFilters[field.TbName], projectName)
+}
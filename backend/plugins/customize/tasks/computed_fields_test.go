@@ -0,0 +1,101 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	mockplugin "github.com/apache/incubator-devlake/mocks/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/customize/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func mockComputedFieldsContext(t *testing.T, options *Options, fields []*models.CustomizedField) (*mockplugin.SubTaskContext, *mockdal.Dal) {
+	mockDal := new(mockdal.Dal)
+	mockDal.On("All", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(0).(*[]*models.CustomizedField) = fields
+	}).Return(nil).Maybe()
+	mockDal.On("All", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(0).(*[]*models.CustomizedField) = fields
+	}).Return(nil).Maybe()
+	columns := make([]dal.ColumnMeta, 0)
+	for _, name := range []string{"id", "story_point", "x_points"} {
+		column := mockdal.NewColumnMeta(t)
+		column.On("Name").Return(name).Maybe()
+		columns = append(columns, column)
+	}
+	mockDal.On("GetColumns", mock.Anything, mock.Anything).Return(columns, nil).Maybe()
+
+	mockCtx := new(mockplugin.SubTaskContext)
+	mockCtx.On("GetDal").Return(mockDal)
+	mockCtx.On("GetLogger").Return(unithelper.DummyLogger())
+	mockCtx.On("GetData").Return(&TaskData{Options: options})
+	mockCtx.On("SetProgress", mock.Anything, mock.Anything)
+	mockCtx.On("IncProgress", mock.Anything)
+	return mockCtx, mockDal
+}
+
+func TestComputeCustomizedFields(t *testing.T) {
+	fields := []*models.CustomizedField{
+		{TbName: "issues", ColumnName: "x_points", Expression: "story_point * 2"},
+	}
+	mockCtx, mockDal := mockComputedFieldsContext(t, &Options{}, fields)
+	mockDal.On("Exec", "UPDATE issues SET x_points = (story_point * 2)", []interface{}(nil)).Return(nil).Once()
+	assert.Nil(t, ComputeCustomizedFields(mockCtx))
+	mockDal.AssertExpectations(t)
+}
+
+func TestComputeCustomizedFieldsOfProject(t *testing.T) {
+	fields := []*models.CustomizedField{
+		{TbName: "issues", ColumnName: "x_points", Expression: "story_point * 2"},
+		{TbName: "accounts", ColumnName: "x_points", Expression: "id"},
+	}
+	mockCtx, mockDal := mockComputedFieldsContext(t, &Options{ProjectName: "project1"}, fields)
+	mockDal.On("Exec", "UPDATE issues SET x_points = (story_point * 2) WHERE "+projectRowFilters["issues"], []interface{}{"project1"}).Return(nil).Once()
+	assert.Nil(t, ComputeCustomizedFields(mockCtx))
+	// the accounts can't be attributed to the project, they are left alone
+	mockDal.AssertNumberOfCalls(t, "Exec", 1)
+	mockDal.AssertExpectations(t)
+}
+
+func TestComputeCustomizedFieldsRefusesInjectedExpression(t *testing.T) {
+	fields := []*models.CustomizedField{
+		{TbName: "issues", ColumnName: "x_points", Expression: "(SELECT password FROM users)"},
+	}
+	mockCtx, mockDal := mockComputedFieldsContext(t, &Options{}, fields)
+	assert.NotNil(t, ComputeCustomizedFields(mockCtx))
+	mockDal.AssertNotCalled(t, "Exec", mock.Anything, mock.Anything)
+}
+
+func TestComputeCustomizedFieldsRefusesPlaceholder(t *testing.T) {
+	// the question mark would take the name of the project instead of the one in the filter
+	fields := []*models.CustomizedField{
+		{TbName: "issues", ColumnName: "x_points", Expression: "IF(story_point = '?', 1, 0)"},
+	}
+	mockCtx, mockDal := mockComputedFieldsContext(t, &Options{ProjectName: "project1"}, fields)
+	err := ComputeCustomizedFields(mockCtx)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, errors.BadInput, err.GetType())
+	}
+	mockDal.AssertNotCalled(t, "Exec", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,134 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+// expressionFunctions are the SQL functions allowed in the expressions of the computed fields
+var expressionFunctions = map[string]bool{
+	"ABS": true, "CAST": true, "CEIL": true, "COALESCE": true, "CONCAT": true, "DATE": true, "DATEDIFF": true,
+	"DAY": true, "FLOOR": true, "GREATEST": true, "HOUR": true, "IF": true, "IFNULL": true, "LEAST": true,
+	"LENGTH": true, "LOWER": true, "MONTH": true, "NULLIF": true, "ROUND": true, "SUBSTRING": true,
+	"TIMESTAMPDIFF": true, "TRIM": true, "UPPER": true, "WEEK": true, "YEAR": true,
+}
+
+// expressionKeywords are the SQL keywords allowed in the expressions of the computed fields, including the units of
+// TIMESTAMPDIFF and the types of CAST
+var expressionKeywords = map[string]bool{
+	"AND": true, "AS": true, "BETWEEN": true, "CASE": true, "ELSE": true, "END": true, "FALSE": true, "IN": true,
+	"IS": true, "LIKE": true, "NOT": true, "NULL": true, "OR": true, "THEN": true, "TRUE": true, "WHEN": true,
+	"SECOND": true, "MINUTE": true, "HOUR": true, "DAY": true, "WEEK": true, "MONTH": true, "YEAR": true,
+	"CHAR": true, "DATETIME": true, "DECIMAL": true, "SIGNED": true, "UNSIGNED": true,
+}
+
+var expressionOperators = []string{"<=", ">=", "<>", "!=", "+", "-", "*", "/", "%", "=", "<", ">", "(", ")", ","}
+
+// CheckExpression makes sure the expression of a computed field only refers to the columns of its own table, the
+// literals and the allowed functions and operators, so that it can't read other tables or run another statement
+func CheckExpression(expression string, columns []string) errors.Error {
+	knownColumns := make(map[string]bool, len(columns))
+	for _, column := range columns {
+		knownColumns[strings.ToLower(column)] = true
+	}
+	runes := []rune(expression)
+	depth := 0
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '\'':
+			// string literals, the quotes are escaped by doubling them, backslashes are refused since their meaning
+			// depends on the sql mode, and question marks since they would be bound to the parameters of the statement
+			i++
+			for {
+				if i >= len(runes) {
+					return errors.BadInput.New("unterminated string literal in the expression")
+				}
+				if runes[i] == '\\' {
+					return errors.BadInput.New("backslashes are not allowed in the string literals of the expression")
+				}
+				if runes[i] == '?' {
+					return errors.BadInput.New("question marks are not allowed in the string literals of the expression")
+				}
+				if runes[i] == '\'' {
+					if i+1 < len(runes) && runes[i+1] == '\'' {
+						i += 2
+						continue
+					}
+					i++
+					break
+				}
+				i++
+			}
+		case unicode.IsDigit(r):
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+		case r == '_' || unicode.IsLetter(r):
+			start := i
+			for i < len(runes) && (runes[i] == '_' || unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i])) {
+				i++
+			}
+			word := string(runes[start:i])
+			upper := strings.ToUpper(word)
+			next := i
+			for next < len(runes) && unicode.IsSpace(runes[next]) {
+				next++
+			}
+			if next < len(runes) && runes[next] == '(' {
+				if !expressionFunctions[upper] {
+					return errors.BadInput.New(fmt.Sprintf("the function %s is not allowed in the expression", word))
+				}
+			} else if !expressionKeywords[upper] && !knownColumns[strings.ToLower(word)] {
+				return errors.BadInput.New(fmt.Sprintf("unknown column %s in the expression", word))
+			}
+		default:
+			operator := ""
+			for _, op := range expressionOperators {
+				if strings.HasPrefix(string(runes[i:]), op) {
+					operator = op
+					break
+				}
+			}
+			if operator == "" || strings.HasPrefix(string(runes[i:]), "--") || strings.HasPrefix(string(runes[i:]), "/*") {
+				return errors.BadInput.New(fmt.Sprintf("unexpected %q in the expression", r))
+			}
+			switch operator {
+			case "(":
+				depth++
+			case ")":
+				depth--
+				if depth < 0 {
+					return errors.BadInput.New("unbalanced parentheses in the expression")
+				}
+			}
+			i += len([]rune(operator))
+		}
+	}
+	if depth != 0 {
+		return errors.BadInput.New("unbalanced parentheses in the expression")
+	}
+	return nil
+}
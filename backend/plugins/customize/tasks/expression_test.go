@@ -0,0 +1,66 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckExpression(t *testing.T) {
+	columns := []string{"id", "created_date", "resolution_date", "story_point", "priority", "x_lead_time"}
+	tests := []struct {
+		name       string
+		expression string
+		valid      bool
+	}{
+		{"arithmetic", "story_point * 2 + 1", true},
+		{"function", "TIMESTAMPDIFF(MINUTE, created_date, resolution_date)", true},
+		{"case", "CASE WHEN priority = 'High' THEN 1 ELSE 0 END", true},
+		{"escaped quote", "CONCAT(priority, ' isn''t set')", true},
+		{"cast", "CAST(story_point AS SIGNED)", true},
+		{"null check", "IFNULL(x_lead_time, 0) IS NOT NULL", true},
+		{"unknown column", "password", false},
+		{"other table", "(SELECT MAX(id) FROM users)", false},
+		{"qualified column", "users.id", false},
+		{"function not allowed", "SLEEP(10)", false},
+		{"statement", "1; DROP TABLE issues", false},
+		{"line comment", "story_point -- ", false},
+		{"block comment", "story_point /* */", false},
+		{"backslash", "CONCAT(priority, '\\'')", false},
+		{"placeholder in string", "IF(priority = '?', 1, 0)", false},
+		{"placeholder outside string", "story_point = ?", false},
+		{"double quotes", `"id"`, false},
+		{"backquotes", "`id`", false},
+		{"variable", "@@version", false},
+		{"unterminated string", "'abc", false},
+		{"unbalanced parentheses", "(story_point", false},
+		{"closing parenthesis first", ") OR (1", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckExpression(tt.expression, columns)
+			if tt.valid {
+				assert.Nil(t, err)
+			} else {
+				assert.NotNil(t, err)
+			}
+		})
+	}
+}
@@ -26,6 +26,10 @@ type MappingRules struct {
 
 type Options struct {
 	TransformationRules []MappingRules `json:"transformationRules"`
+	// ComputedFieldTables limits the computed fields to those of the tables, all of them are computed if empty
+	ComputedFieldTables []string `json:"computedFieldTables" mapstructure:"computedFieldTables"`
+	// ProjectName limits the computed fields to the rows of the project, all rows are computed if empty
+	ProjectName string `json:"projectName" mapstructure:"projectName"`
 }
 
 type TaskData struct {
@@ -26,6 +26,9 @@ package api
 func _() {}
 
 type Options struct {
+	ConnectionId   uint64   `json:"connectionId"`
+	JobId          uint64   `json:"jobId"`
+	TriggerJob     bool     `json:"triggerJob"`
 	ProjectPath    string   `json:"projectPath"`
 	ProjectGitURL  string   `json:"projectGitURL"`
 	ProjectName    string   `json:"projectName"`
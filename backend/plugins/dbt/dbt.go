@@ -31,6 +31,9 @@ func main() {
 	_ = dbtCmd.MarkFlagRequired("projectPath")
 	projectPath := dbtCmd.Flags().StringP("projectPath", "p", "/Users/abeizn/demoapp", "user dbt project directory.")
 	projectGitURL := dbtCmd.Flags().StringP("projectGitURL", "g", "", "user dbt project git url.")
+	connectionId := dbtCmd.Flags().Uint64P("connectionId", "c", 0, "dbt Cloud connection id, collect the dbt Cloud job instead of running dbt locally if set.")
+	jobId := dbtCmd.Flags().Uint64P("jobId", "j", 0, "dbt Cloud job id.")
+	triggerJob := dbtCmd.Flags().BoolP("triggerJob", "", false, "trigger a run of the dbt Cloud job before collecting.")
 	projectName := dbtCmd.Flags().StringP("projectName", "n", "demoapp", "user dbt project name.")
 	projectTarget := dbtCmd.Flags().StringP("projectTarget", "o", "dev", "this is the default target your dbt project will use.")
 	modelsSlice := []string{"my_first_dbt_model", "my_second_dbt_model"}
@@ -59,6 +62,9 @@ func main() {
 			projectVarsConvert[k] = v
 		}
 		runner.DirectRun(cmd, args, PluginEntry, map[string]interface{}{
+			"connectionId":   *connectionId,
+			"jobId":          *jobId,
+			"triggerJob":     *triggerJob,
 			"projectPath":    *projectPath,
 			"projectName":    *projectName,
 			"projectTarget":  *projectTarget,
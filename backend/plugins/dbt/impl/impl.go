@@ -18,21 +18,37 @@ limitations under the License.
 package impl
 
 import (
+	"fmt"
+
+	"github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/plugin"
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/dbt/api"
+	"github.com/apache/incubator-devlake/plugins/dbt/models"
+	"github.com/apache/incubator-devlake/plugins/dbt/models/migrationscripts"
 	"github.com/apache/incubator-devlake/plugins/dbt/tasks"
 )
 
 var _ interface {
 	plugin.PluginMeta
+	plugin.PluginInit
 	plugin.PluginTask
+	plugin.PluginApi
 	plugin.PluginModel
+	plugin.PluginMigration
+	plugin.CloseablePluginTask
 } = (*Dbt)(nil)
 
 type Dbt struct{}
 
+func (p Dbt) Init(basicRes context.BasicRes) errors.Error {
+	api.Init(basicRes, p)
+
+	return nil
+}
+
 func (p Dbt) Description() string {
 	return "Convert data by dbt"
 }
@@ -41,11 +57,23 @@ func (p Dbt) SubTaskMetas() []plugin.SubTaskMeta {
 	return []plugin.SubTaskMeta{
 		tasks.GitMeta,
 		tasks.DbtConverterMeta,
+		tasks.TriggerJobMeta,
+		tasks.CollectRunsMeta,
+		tasks.ExtractRunsMeta,
+		tasks.CollectRunResultsMeta,
+		tasks.ExtractRunResultsMeta,
+		tasks.CollectManifestMeta,
+		tasks.ExtractManifestMeta,
 	}
 }
 
 func (p Dbt) GetTablesInfo() []dal.Tabler {
-	return []dal.Tabler{}
+	return []dal.Tabler{
+		&models.DbtConnection{},
+		&models.DbtRun{},
+		&models.DbtRunResult{},
+		&models.DbtNode{},
+	}
 }
 
 func (p Dbt) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]interface{}) (interface{}, errors.Error) {
@@ -54,6 +82,9 @@ func (p Dbt) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]inte
 	if err != nil {
 		return nil, err
 	}
+	if op.IsCloud() {
+		return p.prepareCloudTaskData(taskCtx, &op)
+	}
 	if op.ProjectPath == "" {
 		return nil, errors.Default.New("projectPath is required for dbt plugin")
 	}
@@ -67,6 +98,31 @@ func (p Dbt) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]inte
 	}, nil
 }
 
+func (p Dbt) prepareCloudTaskData(taskCtx plugin.TaskContext, op *tasks.DbtOptions) (*tasks.DbtTaskData, errors.Error) {
+	if op.JobId == 0 {
+		return nil, errors.BadInput.New("jobId is required for dbt Cloud")
+	}
+	connection := &models.DbtConnection{}
+	connectionHelper := helper.NewConnectionHelper(
+		taskCtx,
+		nil,
+		p.Name(),
+	)
+	err := connectionHelper.FirstById(connection, op.ConnectionId)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error getting connection for dbt plugin")
+	}
+	apiClient, err := tasks.CreateApiClient(taskCtx, connection)
+	if err != nil {
+		return nil, err
+	}
+	return &tasks.DbtTaskData{
+		Options:   op,
+		AccountId: connection.AccountId,
+		ApiClient: apiClient,
+	}, nil
+}
+
 func (p Dbt) RootPkgPath() string {
 	return "github.com/apache/incubator-devlake/plugins/dbt"
 }
@@ -74,3 +130,38 @@ func (p Dbt) RootPkgPath() string {
 func (p Dbt) Name() string {
 	return "dbt"
 }
+
+func (p Dbt) MigrationScripts() []plugin.MigrationScript {
+	return migrationscripts.All()
+}
+
+func (p Dbt) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
+	return map[string]map[string]plugin.ApiResourceHandler{
+		"test": {
+			"POST": api.TestConnection,
+		},
+		"connections": {
+			"GET":  api.ListConnections,
+			"POST": api.PostConnections,
+		},
+		"connections/:connectionId": {
+			"GET":    api.GetConnection,
+			"PATCH":  api.PatchConnection,
+			"DELETE": api.DeleteConnection,
+		},
+		"connections/:connectionId/test": {
+			"POST": api.TestExistingConnection,
+		},
+	}
+}
+
+func (p Dbt) Close(taskCtx plugin.TaskContext) errors.Error {
+	data, ok := taskCtx.GetData().(*tasks.DbtTaskData)
+	if !ok {
+		return errors.Default.New(fmt.Sprintf("GetData failed when try to close %+v", taskCtx))
+	}
+	if data.ApiClient != nil {
+		data.ApiClient.Release()
+	}
+	return nil
+}
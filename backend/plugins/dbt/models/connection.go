@@ -0,0 +1,69 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/core/utils"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var _ plugin.ApiConnection = (*DbtConnection)(nil)
+
+// DbtConn holds the essential information to connect to the dbt Cloud API, the endpoint looks like
+// https://cloud.getdbt.com/api/v2/ and the token is a service account token or a personal access token
+type DbtConn struct {
+	api.RestConnection `mapstructure:",squash"`
+	api.AccessToken    `mapstructure:",squash"`
+	AccountId          uint64 `mapstructure:"accountId" validate:"required" json:"accountId"`
+}
+
+func (conn DbtConn) Sanitize() DbtConn {
+	conn.Token = utils.SanitizeString(conn.Token)
+	conn.SanitizeProxy()
+	return conn
+}
+
+// DbtConnection holds DbtConn plus ID/Name for database storage
+type DbtConnection struct {
+	api.BaseConnection `mapstructure:",squash"`
+	DbtConn            `mapstructure:",squash"`
+}
+
+func (connection *DbtConnection) MergeFromRequest(target *DbtConnection, body map[string]interface{}) error {
+	token := target.Token
+	proxy := target.Proxy
+	if err := api.DecodeMapStruct(body, target, true); err != nil {
+		return err
+	}
+	target.RestoreSanitizedProxy(proxy)
+	modifiedToken := target.Token
+	if modifiedToken == "" || modifiedToken == utils.SanitizeString(token) {
+		target.Token = token
+	}
+	return nil
+}
+
+func (connection DbtConnection) Sanitize() DbtConnection {
+	connection.DbtConn = connection.DbtConn.Sanitize()
+	return connection
+}
+
+func (DbtConnection) TableName() string {
+	return "_tool_dbt_connections"
+}
@@ -0,0 +1,46 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// DbtNode is a model, test, seed, snapshot or source of the project, parsed from its manifest.json.
+// The nodes of a local project have 0 as ConnectionId and JobId.
+type DbtNode struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	JobId        uint64 `gorm:"primaryKey;autoIncrement:false"`
+	UniqueId     string `gorm:"primaryKey;type:varchar(255)"`
+	ResourceType string `gorm:"type:varchar(100)"`
+	Name         string `gorm:"type:varchar(255)"`
+	PackageName  string `gorm:"type:varchar(255)"`
+	Path         string `gorm:"type:varchar(500)"`
+	Materialized string `gorm:"type:varchar(100)"`
+	Database     string `gorm:"type:varchar(255)"`
+	Schema       string `gorm:"type:varchar(255)"`
+	Tags         string `gorm:"type:varchar(500)"`
+	// the unique ids of the nodes it depends on, comma separated
+	DependsOn   string
+	Description string
+	common.NoPKModel
+}
+
+func (DbtNode) TableName() string {
+	return "_tool_dbt_nodes"
+}
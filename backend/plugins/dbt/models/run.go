@@ -0,0 +1,57 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+const (
+	RUN_STATUS_QUEUED    = "queued"
+	RUN_STATUS_RUNNING   = "running"
+	RUN_STATUS_SUCCESS   = "success"
+	RUN_STATUS_ERROR     = "error"
+	RUN_STATUS_CANCELLED = "cancelled"
+)
+
+// DbtRun is a run of a dbt Cloud job, or a local `dbt run` whose ConnectionId is 0
+type DbtRun struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	// the run id of dbt Cloud, or the invocation id of a local run
+	Id           string `gorm:"primaryKey;type:varchar(100)"`
+	JobId        uint64 `gorm:"index"`
+	ProjectName  string `gorm:"type:varchar(255)"`
+	InvocationId string `gorm:"type:varchar(100)"`
+	Status       string `gorm:"type:varchar(100)"`
+	Cause        string `gorm:"type:varchar(255)"`
+	DbtVersion   string `gorm:"type:varchar(100)"`
+	GitBranch    string `gorm:"type:varchar(255)"`
+	GitSha       string `gorm:"type:varchar(100)"`
+	Url          string `gorm:"type:varchar(255)"`
+	CreatedDate  *time.Time
+	StartedDate  *time.Time
+	FinishedDate *time.Time
+	DurationSec  float64
+	common.NoPKModel
+}
+
+func (DbtRun) TableName() string {
+	return "_tool_dbt_runs"
+}
@@ -0,0 +1,45 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// DbtRunResult is the result of a model, test, seed or snapshot of a run, parsed from its run_results.json
+type DbtRunResult struct {
+	ConnectionId  uint64 `gorm:"primaryKey"`
+	RunId         string `gorm:"primaryKey;type:varchar(100)"`
+	UniqueId      string `gorm:"primaryKey;type:varchar(255)"`
+	ResourceType  string `gorm:"type:varchar(100)"`
+	Status        string `gorm:"type:varchar(100)"`
+	Message       string
+	Failures      *int
+	RowsAffected  *int64
+	ExecutionTime float64
+	ThreadId      string `gorm:"type:varchar(100)"`
+	StartedDate   *time.Time
+	FinishedDate  *time.Time
+	common.NoPKModel
+}
+
+func (DbtRunResult) TableName() string {
+	return "_tool_dbt_run_results"
+}
@@ -0,0 +1,197 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/plugins/dbt/models"
+)
+
+// dbtRunResults is the run_results.json artifact written by dbt run, build and test
+type dbtRunResults struct {
+	Metadata struct {
+		DbtVersion   string     `json:"dbt_version"`
+		GeneratedAt  *time.Time `json:"generated_at"`
+		InvocationId string     `json:"invocation_id"`
+	} `json:"metadata"`
+	Results     []dbtRunResult `json:"results"`
+	ElapsedTime float64        `json:"elapsed_time"`
+}
+
+type dbtRunResult struct {
+	UniqueId        string  `json:"unique_id"`
+	Status          string  `json:"status"`
+	Message         *string `json:"message"`
+	Failures        *int    `json:"failures"`
+	ThreadId        string  `json:"thread_id"`
+	ExecutionTime   float64 `json:"execution_time"`
+	AdapterResponse struct {
+		RowsAffected *int64 `json:"rows_affected"`
+	} `json:"adapter_response"`
+	Timing []struct {
+		Name        string     `json:"name"`
+		StartedAt   *time.Time `json:"started_at"`
+		CompletedAt *time.Time `json:"completed_at"`
+	} `json:"timing"`
+}
+
+// dbtManifest is the manifest.json artifact describing the nodes of the project
+type dbtManifest struct {
+	Nodes   map[string]*dbtManifestNode `json:"nodes"`
+	Sources map[string]*dbtManifestNode `json:"sources"`
+}
+
+type dbtManifestNode struct {
+	UniqueId         string   `json:"unique_id"`
+	ResourceType     string   `json:"resource_type"`
+	Name             string   `json:"name"`
+	PackageName      string   `json:"package_name"`
+	OriginalFilePath string   `json:"original_file_path"`
+	Database         *string  `json:"database"`
+	Schema           string   `json:"schema"`
+	Description      string   `json:"description"`
+	Tags             []string `json:"tags"`
+	Config           struct {
+		Materialized string `json:"materialized"`
+	} `json:"config"`
+	DependsOn struct {
+		Nodes []string `json:"nodes"`
+	} `json:"depends_on"`
+}
+
+// the statuses of the results failing the run, the others are success, pass, warn and skipped
+var failedResultStatuses = []string{"error", "fail", "runtime error"}
+
+func parseRunResults(blob []byte) (*dbtRunResults, errors.Error) {
+	runResults := &dbtRunResults{}
+	if err := json.Unmarshal(blob, runResults); err != nil {
+		return nil, errors.Default.Wrap(err, "failed to parse run_results.json")
+	}
+	return runResults, nil
+}
+
+func parseManifest(blob []byte) (*dbtManifest, errors.Error) {
+	manifest := &dbtManifest{}
+	if err := json.Unmarshal(blob, manifest); err != nil {
+		return nil, errors.Default.Wrap(err, "failed to parse manifest.json")
+	}
+	return manifest, nil
+}
+
+func toRunResults(connectionId uint64, runId string, runResults *dbtRunResults) []*models.DbtRunResult {
+	results := make([]*models.DbtRunResult, 0, len(runResults.Results))
+	for _, r := range runResults.Results {
+		result := &models.DbtRunResult{
+			ConnectionId:  connectionId,
+			RunId:         runId,
+			UniqueId:      r.UniqueId,
+			ResourceType:  resourceTypeOf(r.UniqueId),
+			Status:        r.Status,
+			Failures:      r.Failures,
+			RowsAffected:  r.AdapterResponse.RowsAffected,
+			ExecutionTime: r.ExecutionTime,
+			ThreadId:      r.ThreadId,
+		}
+		if r.Message != nil {
+			result.Message = *r.Message
+		}
+		for _, timing := range r.Timing {
+			if timing.Name == "execute" {
+				result.StartedDate = timing.StartedAt
+				result.FinishedDate = timing.CompletedAt
+			}
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// toLocalRun turns the run_results.json of a local `dbt run` into a run
+func toLocalRun(projectName string, runResults *dbtRunResults) *models.DbtRun {
+	run := &models.DbtRun{
+		Id:           runResults.Metadata.InvocationId,
+		ProjectName:  projectName,
+		InvocationId: runResults.Metadata.InvocationId,
+		Status:       models.RUN_STATUS_SUCCESS,
+		Cause:        "local",
+		DbtVersion:   runResults.Metadata.DbtVersion,
+		FinishedDate: runResults.Metadata.GeneratedAt,
+		DurationSec:  runResults.ElapsedTime,
+	}
+	if generatedAt := runResults.Metadata.GeneratedAt; generatedAt != nil {
+		startedAt := generatedAt.Add(-time.Duration(runResults.ElapsedTime * float64(time.Second)))
+		run.CreatedDate = &startedAt
+		run.StartedDate = &startedAt
+	}
+	for _, r := range runResults.Results {
+		for _, status := range failedResultStatuses {
+			if r.Status == status {
+				run.Status = models.RUN_STATUS_ERROR
+			}
+		}
+	}
+	return run
+}
+
+func toNodes(connectionId, jobId uint64, manifest *dbtManifest) []*models.DbtNode {
+	nodes := make([]*models.DbtNode, 0, len(manifest.Nodes)+len(manifest.Sources))
+	for _, group := range []map[string]*dbtManifestNode{manifest.Nodes, manifest.Sources} {
+		for uniqueId, n := range group {
+			if n.UniqueId == "" {
+				n.UniqueId = uniqueId
+			}
+			node := &models.DbtNode{
+				ConnectionId: connectionId,
+				JobId:        jobId,
+				UniqueId:     n.UniqueId,
+				ResourceType: n.ResourceType,
+				Name:         n.Name,
+				PackageName:  n.PackageName,
+				Path:         n.OriginalFilePath,
+				Materialized: n.Config.Materialized,
+				Schema:       n.Schema,
+				Tags:         strings.Join(n.Tags, ","),
+				DependsOn:    strings.Join(n.DependsOn.Nodes, ","),
+				Description:  n.Description,
+			}
+			if n.Database != nil {
+				node.Database = *n.Database
+			}
+			if node.ResourceType == "" {
+				node.ResourceType = resourceTypeOf(n.UniqueId)
+			}
+			nodes = append(nodes, node)
+		}
+	}
+	// keep the order stable so the batches are the same from run to run
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].UniqueId < nodes[j].UniqueId
+	})
+	return nodes
+}
+
+// resourceTypeOf returns the resource type prefixing the unique id, i.e. model for model.jaffle_shop.customers
+func resourceTypeOf(uniqueId string) string {
+	resourceType, _, _ := strings.Cut(uniqueId, ".")
+	return resourceType
+}
@@ -0,0 +1,197 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/dbt/models"
+	"github.com/stretchr/testify/assert"
+)
+
+const testRunResults = `{
+	"metadata": {"dbt_version": "1.7.4", "generated_at": "2024-03-05T10:22:09.5Z", "invocation_id": "0e3b0c6a-7a3d"},
+	"elapsed_time": 60.5,
+	"results": [
+		{
+			"unique_id": "model.jaffle_shop.customers",
+			"status": "success",
+			"message": "SELECT 100",
+			"failures": null,
+			"thread_id": "Thread-1",
+			"execution_time": 1.5,
+			"adapter_response": {"rows_affected": 100},
+			"timing": [
+				{"name": "compile", "started_at": "2024-03-05T10:21:10Z", "completed_at": "2024-03-05T10:21:11Z"},
+				{"name": "execute", "started_at": "2024-03-05T10:21:11Z", "completed_at": "2024-03-05T10:21:12.5Z"}
+			]
+		},
+		{
+			"unique_id": "test.jaffle_shop.unique_customers_customer_id.c5af1ff4b1",
+			"status": "fail",
+			"message": "Got 2 results, configured to fail if != 0",
+			"failures": 2,
+			"thread_id": "Thread-2",
+			"execution_time": 0.2,
+			"adapter_response": {},
+			"timing": []
+		}
+	]
+}`
+
+const testManifest = `{
+	"nodes": {
+		"model.jaffle_shop.customers": {
+			"unique_id": "model.jaffle_shop.customers",
+			"resource_type": "model",
+			"name": "customers",
+			"package_name": "jaffle_shop",
+			"original_file_path": "models/customers.sql",
+			"database": "analytics",
+			"schema": "dbt_prod",
+			"description": "One record per customer",
+			"tags": ["daily", "core"],
+			"config": {"materialized": "table"},
+			"depends_on": {"nodes": ["model.jaffle_shop.stg_customers", "model.jaffle_shop.stg_orders"]}
+		}
+	},
+	"sources": {
+		"source.jaffle_shop.raw.customers": {
+			"unique_id": "source.jaffle_shop.raw.customers",
+			"resource_type": "source",
+			"name": "customers",
+			"package_name": "jaffle_shop",
+			"original_file_path": "models/sources.yml",
+			"database": null,
+			"schema": "raw",
+			"config": {}
+		}
+	}
+}`
+
+func TestToRunResults(t *testing.T) {
+	runResults, err := parseRunResults([]byte(testRunResults))
+	assert.Nil(t, err)
+	results := toRunResults(1, "70403103", runResults)
+	assert.Len(t, results, 2)
+
+	model := results[0]
+	assert.Equal(t, "model", model.ResourceType)
+	assert.Equal(t, "success", model.Status)
+	assert.Equal(t, "SELECT 100", model.Message)
+	assert.Nil(t, model.Failures)
+	assert.Equal(t, int64(100), *model.RowsAffected)
+	assert.Equal(t, time.Date(2024, 3, 5, 10, 21, 11, 0, time.UTC), *model.StartedDate)
+	assert.Equal(t, time.Date(2024, 3, 5, 10, 21, 12, 5e8, time.UTC), *model.FinishedDate)
+
+	test := results[1]
+	assert.Equal(t, "test", test.ResourceType)
+	assert.Equal(t, 2, *test.Failures)
+	assert.Nil(t, test.RowsAffected)
+	assert.Nil(t, test.StartedDate)
+}
+
+func TestToLocalRun(t *testing.T) {
+	runResults, err := parseRunResults([]byte(testRunResults))
+	assert.Nil(t, err)
+	run := toLocalRun("jaffle_shop", runResults)
+	assert.Equal(t, "0e3b0c6a-7a3d", run.Id)
+	assert.Equal(t, uint64(0), run.ConnectionId)
+	// a failing test fails the run
+	assert.Equal(t, models.RUN_STATUS_ERROR, run.Status)
+	assert.Equal(t, "1.7.4", run.DbtVersion)
+	assert.Equal(t, time.Date(2024, 3, 5, 10, 21, 9, 0, time.UTC), *run.StartedDate)
+	assert.Equal(t, 60.5, run.DurationSec)
+}
+
+func TestToNodes(t *testing.T) {
+	manifest, err := parseManifest([]byte(testManifest))
+	assert.Nil(t, err)
+	nodes := toNodes(1, 42, manifest)
+	assert.Equal(t, []*models.DbtNode{
+		{
+			ConnectionId: 1,
+			JobId:        42,
+			UniqueId:     "model.jaffle_shop.customers",
+			ResourceType: "model",
+			Name:         "customers",
+			PackageName:  "jaffle_shop",
+			Path:         "models/customers.sql",
+			Materialized: "table",
+			Database:     "analytics",
+			Schema:       "dbt_prod",
+			Tags:         "daily,core",
+			DependsOn:    "model.jaffle_shop.stg_customers,model.jaffle_shop.stg_orders",
+			Description:  "One record per customer",
+		},
+		{
+			ConnectionId: 1,
+			JobId:        42,
+			UniqueId:     "source.jaffle_shop.raw.customers",
+			ResourceType: "source",
+			Name:         "customers",
+			PackageName:  "jaffle_shop",
+			Path:         "models/sources.yml",
+			Schema:       "raw",
+		},
+	}, nodes)
+}
+
+func TestDbtCloudRun(t *testing.T) {
+	apiRun := &dbtCloudRun{}
+	assert.Nil(t, json.Unmarshal([]byte(`{
+		"id": 70403103,
+		"job_definition_id": 42,
+		"status": 20,
+		"dbt_version": "1.7.0-latest",
+		"git_branch": "main",
+		"git_sha": null,
+		"href": "https://cloud.getdbt.com/deploy/1/projects/2/runs/70403103/",
+		"created_at": "2024-03-05 10:20:00.123456+00:00",
+		"started_at": "2024-03-05 10:21:09.658391+00:00",
+		"finished_at": "2024-03-05 10:22:09.658391+00:00",
+		"trigger": {"cause": "Kicked off from UI"}
+	}`), apiRun))
+	run := apiRun.toRun(1)
+	assert.Equal(t, "70403103", run.Id)
+	assert.Equal(t, uint64(42), run.JobId)
+	assert.Equal(t, models.RUN_STATUS_ERROR, run.Status)
+	assert.Equal(t, "main", run.GitBranch)
+	assert.Equal(t, "", run.GitSha)
+	assert.Equal(t, "Kicked off from UI", run.Cause)
+	assert.Equal(t, time.Date(2024, 3, 5, 10, 21, 9, 658391000, time.UTC), run.StartedDate.UTC())
+	assert.Equal(t, float64(60), run.DurationSec)
+}
+
+func TestRunsCreatedAfter(t *testing.T) {
+	runs := []json.RawMessage{
+		json.RawMessage(`{"id": 3, "created_at": "2024-03-05 10:00:00.000000+00:00"}`),
+		json.RawMessage(`{"id": 2, "created_at": "2024-03-04 10:00:00.000000+00:00"}`),
+		json.RawMessage(`{"id": 1, "created_at": "2024-03-03 10:00:00.000000+00:00"}`),
+	}
+	kept, err := runsCreatedAfter(runs, time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, api.ErrFinishCollect, err)
+	assert.Equal(t, runs[:2], kept)
+
+	kept, err = runsCreatedAfter(runs, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	assert.Nil(t, err)
+	assert.Equal(t, runs, kept)
+}
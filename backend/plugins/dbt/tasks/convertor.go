@@ -35,8 +35,11 @@ import (
 
 func DbtConverter(taskCtx plugin.SubTaskContext) (err errors.Error) {
 	logger := taskCtx.GetLogger()
-	taskCtx.SetProgress(0, -1)
 	data := taskCtx.GetData().(*DbtTaskData)
+	if data.Options.IsCloud() {
+		return nil
+	}
+	taskCtx.SetProgress(0, -1)
 	models := data.Options.SelectedModels
 	projectPath := data.Options.ProjectPath
 	projectName := data.Options.ProjectName
@@ -196,6 +199,10 @@ func DbtConverter(taskCtx plugin.SubTaskContext) (err errors.Error) {
 	var errStr string
 	defer func() {
 		err = errors.Convert(cmd.Wait())
+		// dbt writes the artifacts even if some models failed, which are worth keeping the most
+		if ingestErr := ingestLocalArtifacts(taskCtx, data); ingestErr != nil {
+			logger.Error(ingestErr, "failed to ingest the artifacts of the dbt project")
+		}
 		if err != nil {
 			logger.Error(err, "The DBT project run failed!")
 			err = errors.SubtaskErr.New(errStr)
@@ -28,7 +28,7 @@ import (
 func Git(taskCtx plugin.SubTaskContext) errors.Error {
 	logger := taskCtx.GetLogger()
 	data := taskCtx.GetData().(*DbtTaskData)
-	if data.Options.ProjectGitURL == "" {
+	if data.Options.IsCloud() || data.Options.ProjectGitURL == "" {
 		return nil
 	}
 
@@ -0,0 +1,98 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/dbt/models"
+)
+
+// how often the triggered run is checked for completion
+var runPollInterval = 10 * time.Second
+
+var _ plugin.SubTaskEntryPoint = TriggerJob
+
+var TriggerJobMeta = plugin.SubTaskMeta{
+	Name:             "triggerJob",
+	EntryPoint:       TriggerJob,
+	EnabledByDefault: true,
+	Description:      "trigger a run of the dbt Cloud job and wait for it to finish if triggerJob is on",
+}
+
+func TriggerJob(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*DbtTaskData)
+	if !data.Options.IsCloud() || !data.Options.TriggerJob {
+		return nil
+	}
+	logger := taskCtx.GetLogger()
+	run := &dbtCloudRun{}
+	res, err := data.ApiClient.Post(
+		fmt.Sprintf("accounts/%d/jobs/%d/run/", data.AccountId, data.Options.JobId),
+		nil,
+		map[string]interface{}{"cause": "Triggered by DevLake"},
+		nil,
+	)
+	if err == nil {
+		err = decodeData(res, run)
+	}
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("failed to trigger dbt Cloud job %d", data.Options.JobId))
+	}
+	logger.Info("triggered run %d of dbt Cloud job %d", run.Id, data.Options.JobId)
+	taskCtx.SetProgress(0, -1)
+	for !run.IsComplete {
+		select {
+		case <-taskCtx.GetContext().Done():
+			return errors.Convert(taskCtx.GetContext().Err())
+		case <-time.After(runPollInterval):
+		}
+		res, err = data.ApiClient.Get(fmt.Sprintf("accounts/%d/runs/%d/", data.AccountId, run.Id), nil, nil)
+		if err == nil {
+			err = decodeData(res, run)
+		}
+		if err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("failed to check run %d of dbt Cloud job %d", run.Id, data.Options.JobId))
+		}
+		taskCtx.IncProgress(1)
+	}
+	// the failed run is collected like any other run, which is how the health of the transformation is observed
+	status := dbtCloudRunStatuses[run.Status]
+	if status != models.RUN_STATUS_SUCCESS {
+		logger.Warn(nil, "run %d of dbt Cloud job %d finished as %s", run.Id, data.Options.JobId, status)
+	} else {
+		logger.Info("run %d of dbt Cloud job %d succeeded", run.Id, data.Options.JobId)
+	}
+	return nil
+}
+
+// decodeData decodes the `data` of the dbt Cloud response into the result
+func decodeData(res *http.Response, result interface{}) errors.Error {
+	if res.StatusCode >= 300 {
+		res.Body.Close()
+		return errors.HttpStatus(res.StatusCode).New(fmt.Sprintf("dbt Cloud responded %d", res.StatusCode))
+	}
+	return api.UnmarshalResponse(res, &struct {
+		Data interface{} `json:"data"`
+	}{Data: result})
+}
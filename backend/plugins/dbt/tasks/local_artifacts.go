@@ -0,0 +1,105 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+// the artifacts of local runs are read from the target directory of the project instead of a raw table
+const LOCAL_ARTIFACTS_TABLE = "dbt_local_artifacts"
+
+type DbtLocalParams struct {
+	ProjectName string
+}
+
+// ingestLocalArtifacts saves the run_results.json and manifest.json written by the local `dbt run`, the history of
+// the runs is kept while the nodes are replaced by the latest manifest
+func ingestLocalArtifacts(taskCtx plugin.SubTaskContext, data *DbtTaskData) errors.Error {
+	targetPath := filepath.Join(data.Options.ProjectPath, "target")
+	blob, err := os.ReadFile(filepath.Join(targetPath, "run_results.json"))
+	if os.IsNotExist(err) {
+		taskCtx.GetLogger().Warn(nil, "run_results.json not found in %s", targetPath)
+		return nil
+	}
+	if err != nil {
+		return errors.Convert(err)
+	}
+	runResults, e := parseRunResults(blob)
+	if e != nil {
+		return e
+	}
+	params, err := json.Marshal(DbtLocalParams{ProjectName: data.Options.ProjectName})
+	if err != nil {
+		return errors.Convert(err)
+	}
+	origin := common.RawDataOrigin{RawDataTable: LOCAL_ARTIFACTS_TABLE, RawDataParams: string(params)}
+
+	history := api.NewBatchSaveDivider(taskCtx, 500, LOCAL_ARTIFACTS_TABLE, string(params))
+	history.SetIncrementalMode(true)
+	run := toLocalRun(data.Options.ProjectName, runResults)
+	run.RawDataOrigin = origin
+	if e = saveToDivider(history, run); e != nil {
+		return e
+	}
+	for _, result := range toRunResults(0, run.Id, runResults) {
+		result.RawDataOrigin = origin
+		if e = saveToDivider(history, result); e != nil {
+			return e
+		}
+	}
+	if e = history.Close(); e != nil {
+		return e
+	}
+
+	blob, err = os.ReadFile(filepath.Join(targetPath, "manifest.json"))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Convert(err)
+	}
+	manifest, e := parseManifest(blob)
+	if e != nil {
+		return e
+	}
+	latest := api.NewBatchSaveDivider(taskCtx, 500, LOCAL_ARTIFACTS_TABLE, string(params))
+	for _, node := range toNodes(0, 0, manifest) {
+		node.RawDataOrigin = origin
+		if e = saveToDivider(latest, node); e != nil {
+			return e
+		}
+	}
+	return latest.Close()
+}
+
+func saveToDivider(divider *api.BatchSaveDivider, row interface{}) errors.Error {
+	batch, err := divider.ForType(reflect.TypeOf(row))
+	if err != nil {
+		return err
+	}
+	return batch.Add(row)
+}
@@ -0,0 +1,81 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/dbt/models"
+)
+
+const RAW_MANIFESTS_TABLE = "dbt_cloud_api_manifests"
+
+var _ plugin.SubTaskEntryPoint = CollectManifest
+
+var CollectManifestMeta = plugin.SubTaskMeta{
+	Name:             "collectManifest",
+	EntryPoint:       CollectManifest,
+	EnabledByDefault: true,
+	Description:      "collect the manifest.json artifact of the latest successful dbt Cloud run",
+	DependencyTables: []string{models.DbtRun{}.TableName()},
+	ProductTables:    []string{RAW_MANIFESTS_TABLE},
+}
+
+func CollectManifest(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*DbtTaskData)
+	if !data.Options.IsCloud() {
+		return nil
+	}
+	db := taskCtx.GetDal()
+	cursor, err := db.Cursor(
+		dal.Select("id"),
+		dal.From(&models.DbtRun{}),
+		dal.Where("connection_id = ? AND job_id = ? AND status = ?", data.Options.ConnectionId, data.Options.JobId, models.RUN_STATUS_SUCCESS),
+		dal.Orderby("finished_date DESC"),
+		dal.Limit(1),
+	)
+	if err != nil {
+		return err
+	}
+	iterator, err := api.NewDalCursorIterator(db, cursor, reflect.TypeOf(runInput{}))
+	if err != nil {
+		return err
+	}
+	// the nodes describe the project as it is, the manifests of the older runs are not relevant
+	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx:     taskCtx,
+			Options: data.Options,
+			Table:   RAW_MANIFESTS_TABLE,
+		},
+		ApiClient:      data.ApiClient,
+		Input:          iterator,
+		UrlTemplate:    fmt.Sprintf("accounts/%d/runs/{{ .Input.Id }}/artifacts/manifest.json", data.AccountId),
+		ResponseParser: parseArtifact,
+		AfterResponse:  ignoreHTTPStatus404,
+	})
+	if err != nil {
+		return err
+	}
+	return collector.Execute()
+}
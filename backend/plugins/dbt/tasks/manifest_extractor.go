@@ -0,0 +1,63 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var _ plugin.SubTaskEntryPoint = ExtractManifest
+
+var ExtractManifestMeta = plugin.SubTaskMeta{
+	Name:             "extractManifest",
+	EntryPoint:       ExtractManifest,
+	EnabledByDefault: true,
+	Description:      "Extract raw data into tool layer table _tool_dbt_nodes",
+}
+
+func ExtractManifest(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*DbtTaskData)
+	if !data.Options.IsCloud() {
+		return nil
+	}
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx:     taskCtx,
+			Options: data.Options,
+			Table:   RAW_MANIFESTS_TABLE,
+		},
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			manifest, err := parseManifest(row.Data)
+			if err != nil {
+				return nil, err
+			}
+			nodes := toNodes(data.Options.ConnectionId, data.Options.JobId, manifest)
+			results := make([]interface{}, 0, len(nodes))
+			for _, node := range nodes {
+				results = append(results, node)
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
@@ -0,0 +1,102 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_RUNS_TABLE = "dbt_cloud_api_runs"
+
+var _ plugin.SubTaskEntryPoint = CollectRuns
+
+var CollectRunsMeta = plugin.SubTaskMeta{
+	Name:             "collectRuns",
+	EntryPoint:       CollectRuns,
+	EnabledByDefault: true,
+	Description:      "collect the runs of the dbt Cloud job, supports timeFilter and diffSync",
+	ProductTables:    []string{RAW_RUNS_TABLE},
+}
+
+func CollectRuns(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*DbtTaskData)
+	if !data.Options.IsCloud() {
+		return nil
+	}
+	apiCollector, err := api.NewStatefulApiCollector(api.RawDataSubTaskArgs{
+		Ctx:     taskCtx,
+		Options: data.Options,
+		Table:   RAW_RUNS_TABLE,
+	})
+	if err != nil {
+		return err
+	}
+
+	// the runs are listed from the newest, runs still in progress last time are collected again by going back a day
+	var until *time.Time
+	if since := apiCollector.GetSince(); since != nil {
+		t := since.Add(-24 * time.Hour)
+		until = &t
+	}
+	err = apiCollector.InitCollector(api.ApiCollectorArgs{
+		ApiClient:   data.ApiClient,
+		PageSize:    100,
+		UrlTemplate: fmt.Sprintf("accounts/%d/runs/", data.AccountId),
+		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+			query := url.Values{}
+			query.Set("job_definition_id", fmt.Sprintf("%d", data.Options.JobId))
+			query.Set("order_by", "-id")
+			query.Set("offset", fmt.Sprintf("%v", reqData.Pager.Skip))
+			query.Set("limit", fmt.Sprintf("%v", reqData.Pager.Size))
+			return query, nil
+		},
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			runs, err := parseData(res)
+			if err != nil || until == nil {
+				return runs, err
+			}
+			return runsCreatedAfter(runs, *until)
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return apiCollector.Execute()
+}
+
+// runsCreatedAfter keeps the runs created after the time, and finishes the collection once an older run is met
+func runsCreatedAfter(runs []json.RawMessage, after time.Time) ([]json.RawMessage, errors.Error) {
+	for i, raw := range runs {
+		run := &dbtCloudRun{}
+		if err := json.Unmarshal(raw, run); err != nil {
+			return nil, errors.Convert(err)
+		}
+		if createdAt := parseDbtCloudTime(run.CreatedAt); createdAt != nil && createdAt.Before(after) {
+			return runs[:i], api.ErrFinishCollect
+		}
+	}
+	return runs, nil
+}
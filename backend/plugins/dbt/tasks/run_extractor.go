@@ -0,0 +1,63 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var _ plugin.SubTaskEntryPoint = ExtractRuns
+
+var ExtractRunsMeta = plugin.SubTaskMeta{
+	Name:             "extractRuns",
+	EntryPoint:       ExtractRuns,
+	EnabledByDefault: true,
+	Description:      "Extract raw data into tool layer table _tool_dbt_runs",
+}
+
+func ExtractRuns(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*DbtTaskData)
+	if !data.Options.IsCloud() {
+		return nil
+	}
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx:     taskCtx,
+			Options: data.Options,
+			Table:   RAW_RUNS_TABLE,
+		},
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiRun := &dbtCloudRun{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiRun))
+			if err != nil {
+				return nil, err
+			}
+			run := apiRun.toRun(data.Options.ConnectionId)
+			run.ProjectName = data.Options.ProjectName
+			return []interface{}{run}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
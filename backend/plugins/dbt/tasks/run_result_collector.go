@@ -0,0 +1,94 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/dbt/models"
+)
+
+const RAW_RUN_RESULTS_TABLE = "dbt_cloud_api_run_results"
+
+var _ plugin.SubTaskEntryPoint = CollectRunResults
+
+var CollectRunResultsMeta = plugin.SubTaskMeta{
+	Name:             "collectRunResults",
+	EntryPoint:       CollectRunResults,
+	EnabledByDefault: true,
+	Description:      "collect the run_results.json artifact of the finished dbt Cloud runs, only the runs finished since the last collection in diffSync mode",
+	DependencyTables: []string{models.DbtRun{}.TableName()},
+	ProductTables:    []string{RAW_RUN_RESULTS_TABLE},
+}
+
+type runInput struct {
+	Id string
+}
+
+func CollectRunResults(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*DbtTaskData)
+	if !data.Options.IsCloud() {
+		return nil
+	}
+	db := taskCtx.GetDal()
+	apiCollector, err := api.NewStatefulApiCollector(api.RawDataSubTaskArgs{
+		Ctx:     taskCtx,
+		Options: data.Options,
+		Table:   RAW_RUN_RESULTS_TABLE,
+	})
+	if err != nil {
+		return err
+	}
+
+	clauses := []dal.Clause{
+		dal.Select("id"),
+		dal.From(&models.DbtRun{}),
+		dal.Where(
+			"connection_id = ? AND job_id = ? AND status IN ?",
+			data.Options.ConnectionId, data.Options.JobId, []string{models.RUN_STATUS_SUCCESS, models.RUN_STATUS_ERROR},
+		),
+	}
+	if apiCollector.IsIncremental() && apiCollector.GetSince() != nil {
+		clauses = append(clauses, dal.Where("finished_date > ?", apiCollector.GetSince()))
+	}
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return err
+	}
+	iterator, err := api.NewDalCursorIterator(db, cursor, reflect.TypeOf(runInput{}))
+	if err != nil {
+		return err
+	}
+
+	err = apiCollector.InitCollector(api.ApiCollectorArgs{
+		ApiClient:      data.ApiClient,
+		Input:          iterator,
+		UrlTemplate:    fmt.Sprintf("accounts/%d/runs/{{ .Input.Id }}/artifacts/run_results.json", data.AccountId),
+		ResponseParser: parseArtifact,
+		AfterResponse:  ignoreHTTPStatus404,
+	})
+	if err != nil {
+		return err
+	}
+	return apiCollector.Execute()
+}
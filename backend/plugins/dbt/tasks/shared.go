@@ -0,0 +1,133 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/dbt/models"
+)
+
+// dbtCloudRun is a run of the dbt Cloud api v2
+type dbtCloudRun struct {
+	Id              uint64  `json:"id"`
+	JobDefinitionId uint64  `json:"job_definition_id"`
+	Status          int     `json:"status"`
+	DbtVersion      string  `json:"dbt_version"`
+	GitBranch       *string `json:"git_branch"`
+	GitSha          *string `json:"git_sha"`
+	Href            string  `json:"href"`
+	CreatedAt       *string `json:"created_at"`
+	StartedAt       *string `json:"started_at"`
+	FinishedAt      *string `json:"finished_at"`
+	IsComplete      bool    `json:"is_complete"`
+	Trigger         struct {
+		Cause string `json:"cause"`
+	} `json:"trigger"`
+}
+
+// the status codes of the runs of dbt Cloud
+var dbtCloudRunStatuses = map[int]string{
+	1:  models.RUN_STATUS_QUEUED,
+	2:  models.RUN_STATUS_RUNNING, // starting
+	3:  models.RUN_STATUS_RUNNING,
+	10: models.RUN_STATUS_SUCCESS,
+	20: models.RUN_STATUS_ERROR,
+	30: models.RUN_STATUS_CANCELLED,
+}
+
+func (r *dbtCloudRun) toRun(connectionId uint64) *models.DbtRun {
+	run := &models.DbtRun{
+		ConnectionId: connectionId,
+		Id:           fmt.Sprintf("%d", r.Id),
+		JobId:        r.JobDefinitionId,
+		Status:       dbtCloudRunStatuses[r.Status],
+		Cause:        r.Trigger.Cause,
+		DbtVersion:   r.DbtVersion,
+		Url:          r.Href,
+		CreatedDate:  parseDbtCloudTime(r.CreatedAt),
+		StartedDate:  parseDbtCloudTime(r.StartedAt),
+		FinishedDate: parseDbtCloudTime(r.FinishedAt),
+	}
+	if r.GitBranch != nil {
+		run.GitBranch = *r.GitBranch
+	}
+	if r.GitSha != nil {
+		run.GitSha = *r.GitSha
+	}
+	if run.StartedDate != nil && run.FinishedDate != nil {
+		run.DurationSec = run.FinishedDate.Sub(*run.StartedDate).Seconds()
+	}
+	return run
+}
+
+// dbt Cloud responds the times like 2024-03-05 10:21:09.658391+00:00
+var dbtCloudTimeLayouts = []string{
+	"2006-01-02 15:04:05.999999999-07:00",
+	time.RFC3339Nano,
+}
+
+func parseDbtCloudTime(s *string) *time.Time {
+	if s == nil || *s == "" {
+		return nil
+	}
+	for _, layout := range dbtCloudTimeLayouts {
+		if t, err := time.Parse(layout, *s); err == nil {
+			return &t
+		}
+	}
+	return nil
+}
+
+// parseData returns the records of the `data` of a dbt Cloud response
+func parseData(res *http.Response) ([]json.RawMessage, errors.Error) {
+	var body struct {
+		Data []json.RawMessage `json:"data"`
+	}
+	err := api.UnmarshalResponse(res, &body)
+	if err != nil {
+		return nil, err
+	}
+	return body.Data, nil
+}
+
+// parseArtifact keeps the whole artifact as a single record
+func parseArtifact(res *http.Response) ([]json.RawMessage, errors.Error) {
+	var artifact json.RawMessage
+	err := api.UnmarshalResponse(res, &artifact)
+	if err != nil {
+		return nil, err
+	}
+	return []json.RawMessage{artifact}, nil
+}
+
+// ignoreHTTPStatus404 skips the runs without the artifact, i.e. a run failed before dbt started
+func ignoreHTTPStatus404(res *http.Response) errors.Error {
+	if res.StatusCode == http.StatusUnauthorized {
+		return errors.Unauthorized.New("authentication failed, please check your AccessToken")
+	}
+	if res.StatusCode == http.StatusNotFound {
+		return api.ErrIgnoreAndContinue
+	}
+	return nil
+}
@@ -17,7 +17,17 @@ limitations under the License.
 
 package tasks
 
+import (
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
 type DbtOptions struct {
+	// the dbt Cloud connection, the job of dbt Cloud is collected instead of running dbt locally if it is not 0
+	ConnectionId uint64 `json:"connectionId"`
+	JobId        uint64 `json:"jobId"`
+	// trigger a run of the dbt Cloud job and wait for it to finish before collecting the runs
+	TriggerJob bool `json:"triggerJob"`
+
 	ProjectPath   string `json:"projectPath"`
 	ProjectName   string `json:"projectName"`
 	ProjectTarget string `json:"projectTarget"`
@@ -42,5 +52,23 @@ type DbtOptions struct {
 }
 
 type DbtTaskData struct {
-	Options *DbtOptions
+	Options   *DbtOptions
+	AccountId uint64
+	ApiClient *helper.ApiAsyncClient
+}
+
+type DbtApiParams struct {
+	ConnectionId uint64
+	JobId        uint64
+}
+
+func (options *DbtOptions) IsCloud() bool {
+	return options.ConnectionId != 0
+}
+
+func (options *DbtOptions) GetParams() any {
+	return DbtApiParams{
+		ConnectionId: options.ConnectionId,
+		JobId:        options.JobId,
+	}
 }
@@ -0,0 +1,110 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/dora/models"
+)
+
+type DeploymentFlagRequest struct {
+	// Type is either ROLLBACK or HOTFIX
+	Type string `json:"type" mapstructure:"type"`
+}
+
+// ListDeploymentFlags returns the explicit rollback and hotfix flags of the deployments of a project
+// @Summary list deployment flags
+// @Tags plugins/dora
+// @Param projectName path string true "project name"
+// @Success 200  {object} []models.DeploymentFlag
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/dora/projects/{projectName}/deployment-flags [GET]
+func ListDeploymentFlags(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	flags := make([]*models.DeploymentFlag, 0)
+	err := basicRes.GetDal().All(&flags, dal.Where("project_name = ?", input.Params["projectName"]))
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: flags, Status: http.StatusOK}, nil
+}
+
+// PutDeploymentFlag marks a deployment as a rollback or a hotfix
+// @Summary flag a deployment as a rollback or a hotfix
+// @Description the flag overrides the patterns of the deployment classification rule from the next dora run
+// @Tags plugins/dora
+// @Accept application/json
+// @Param projectName path string true "project name"
+// @Param deploymentId path string true "cicd deployment id"
+// @Param body body DeploymentFlagRequest true "json"
+// @Success 200  {object} models.DeploymentFlag
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 404  {object} shared.ApiBody "Not Found"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/dora/projects/{projectName}/deployment-flags/{deploymentId} [PUT]
+func PutDeploymentFlag(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	var request DeploymentFlagRequest
+	if err := helper.Decode(input.Body, &request, nil); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid body")
+	}
+	if request.Type != models.DEPLOYMENT_ROLLBACK && request.Type != models.DEPLOYMENT_HOTFIX {
+		return nil, errors.BadInput.New("type must be either ROLLBACK or HOTFIX")
+	}
+	db := basicRes.GetDal()
+	deploymentId := input.Params["deploymentId"]
+	count, err := db.Count(dal.From(&devops.CicdDeploymentCommit{}), dal.Where("cicd_deployment_id = ?", deploymentId))
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, errors.NotFound.New("deployment not found")
+	}
+	flag := &models.DeploymentFlag{
+		ProjectName:  input.Params["projectName"],
+		DeploymentId: deploymentId,
+		Type:         request.Type,
+	}
+	if err = db.CreateOrUpdate(flag); err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: flag, Status: http.StatusOK}, nil
+}
+
+// DeleteDeploymentFlag removes the flag of a deployment, the patterns apply again from the next dora run
+// @Summary delete a deployment flag
+// @Tags plugins/dora
+// @Param projectName path string true "project name"
+// @Param deploymentId path string true "cicd deployment id"
+// @Success 200
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/dora/projects/{projectName}/deployment-flags/{deploymentId} [DELETE]
+func DeleteDeploymentFlag(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	err := basicRes.GetDal().Delete(
+		&models.DeploymentFlag{},
+		dal.Where("project_name = ? AND deployment_id = ?", input.Params["projectName"], input.Params["deploymentId"]),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Status: http.StatusOK}, nil
+}
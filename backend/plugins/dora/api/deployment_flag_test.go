@@ -0,0 +1,78 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	"github.com/apache/incubator-devlake/plugins/dora/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPutDeploymentFlag(t *testing.T) {
+	tests := []struct {
+		name         string
+		deploymentId string
+		flagType     string
+		flag         *models.DeploymentFlag
+		errType      *errors.Type
+	}{
+		{
+			name:         "rollback",
+			deploymentId: "d2",
+			flagType:     models.DEPLOYMENT_ROLLBACK,
+			flag:         &models.DeploymentFlag{ProjectName: "project1", DeploymentId: "d2", Type: models.DEPLOYMENT_ROLLBACK},
+		},
+		{
+			name:         "hotfix replaces the rollback flag",
+			deploymentId: "d1",
+			flagType:     models.DEPLOYMENT_HOTFIX,
+			flag:         &models.DeploymentFlag{ProjectName: "project1", DeploymentId: "d1", Type: models.DEPLOYMENT_HOTFIX},
+		},
+		{name: "unknown type", deploymentId: "d1", flagType: "REVERT", errType: errors.BadInput},
+		{name: "unknown deployment", deploymentId: "d3", flagType: models.DEPLOYMENT_HOTFIX, errType: errors.NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &doraStore{
+				deployments: map[string]bool{"d1": true, "d2": true},
+				flags:       map[string]string{"d1": models.DEPLOYMENT_ROLLBACK},
+			}
+			basicRes = unithelper.DummyBasicRes(store.mock)
+			output, err := PutDeploymentFlag(&plugin.ApiResourceInput{
+				Params: map[string]string{"projectName": "project1", "deploymentId": tt.deploymentId},
+				Body:   map[string]interface{}{"type": tt.flagType},
+			})
+			if tt.errType != nil {
+				if assert.NotNil(t, err) {
+					assert.Equal(t, tt.errType, err.GetType())
+				}
+				assert.Equal(t, map[string]string{"d1": models.DEPLOYMENT_ROLLBACK}, store.flags)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, http.StatusOK, output.Status)
+			assert.Equal(t, tt.flag, output.Body)
+			assert.Equal(t, tt.flagType, store.flags[tt.deploymentId])
+		})
+	}
+}
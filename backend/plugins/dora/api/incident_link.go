@@ -0,0 +1,144 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/dora/models"
+)
+
+type IncidentLinkRequest struct {
+	// DeploymentId is the cicd_deployment_id of the deployment causing the incident
+	DeploymentId string `json:"deploymentId" mapstructure:"deploymentId"`
+	// Unlinked marks the incident as not caused by any deployment
+	Unlinked bool `json:"unlinked" mapstructure:"unlinked"`
+}
+
+// ListIncidentLinks returns the manual incident-deployment links of a project
+// @Summary list manual incident-deployment links
+// @Tags plugins/dora
+// @Param projectName path string true "project name"
+// @Success 200  {object} []models.IncidentDeploymentLink
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/dora/projects/{projectName}/incident-links [GET]
+func ListIncidentLinks(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	links := make([]*models.IncidentDeploymentLink, 0)
+	err := basicRes.GetDal().All(&links, dal.Where("project_name = ?", input.Params["projectName"]))
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: links, Status: http.StatusOK}, nil
+}
+
+// PutIncidentLink links an incident to the deployment that caused it, or unlinks it from any deployment
+// @Summary link or unlink an incident to a deployment
+// @Description the link overrides the automatic rules and takes effect immediately, it is kept by the following dora runs
+// @Tags plugins/dora
+// @Accept application/json
+// @Param projectName path string true "project name"
+// @Param incidentId path string true "incident id"
+// @Param body body IncidentLinkRequest true "json"
+// @Success 200  {object} models.IncidentDeploymentLink
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 404  {object} shared.ApiBody "Not Found"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/dora/projects/{projectName}/incident-links/{incidentId} [PUT]
+func PutIncidentLink(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	var request IncidentLinkRequest
+	if err := helper.Decode(input.Body, &request, nil); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid body")
+	}
+	if request.Unlinked == (request.DeploymentId != "") {
+		return nil, errors.BadInput.New("either deploymentId or unlinked must be specified")
+	}
+	db := basicRes.GetDal()
+	projectName, incidentId := input.Params["projectName"], input.Params["incidentId"]
+	if err := db.First(&ticket.Incident{}, dal.Where("id = ?", incidentId)); err != nil {
+		if db.IsErrorNotFound(err) {
+			return nil, errors.NotFound.New("incident not found")
+		}
+		return nil, err
+	}
+	if request.DeploymentId != "" {
+		count, err := db.Count(dal.From(&devops.CicdDeploymentCommit{}), dal.Where("cicd_deployment_id = ?", request.DeploymentId))
+		if err != nil {
+			return nil, err
+		}
+		if count == 0 {
+			return nil, errors.NotFound.New("deployment not found")
+		}
+	}
+
+	link := &models.IncidentDeploymentLink{
+		ProjectName:  projectName,
+		IncidentId:   incidentId,
+		DeploymentId: request.DeploymentId,
+		Unlinked:     request.Unlinked,
+	}
+	if err := db.CreateOrUpdate(link); err != nil {
+		return nil, err
+	}
+	// apply the link to the current relationships right away instead of waiting for the next run
+	if request.Unlinked {
+		err := db.Delete(
+			&crossdomain.ProjectIncidentDeploymentRelationship{},
+			dal.Where("project_name = ? AND id = ?", projectName, incidentId),
+		)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		err := db.CreateOrUpdate(&crossdomain.ProjectIncidentDeploymentRelationship{
+			DomainEntity: domainlayer.DomainEntity{Id: incidentId},
+			ProjectName:  projectName,
+			DeploymentId: request.DeploymentId,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &plugin.ApiResourceOutput{Body: link, Status: http.StatusOK}, nil
+}
+
+// DeleteIncidentLink removes the manual link of an incident, the automatic rules apply again from the next dora run
+// @Summary delete a manual incident-deployment link
+// @Tags plugins/dora
+// @Param projectName path string true "project name"
+// @Param incidentId path string true "incident id"
+// @Success 200
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/dora/projects/{projectName}/incident-links/{incidentId} [DELETE]
+func DeleteIncidentLink(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	err := basicRes.GetDal().Delete(
+		&models.IncidentDeploymentLink{},
+		dal.Where("project_name = ? AND incident_id = ?", input.Params["projectName"], input.Params["incidentId"]),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Status: http.StatusOK}, nil
+}
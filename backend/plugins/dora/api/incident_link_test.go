@@ -0,0 +1,137 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/apache/incubator-devlake/plugins/dora/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// whereParam returns the first parameter of the first where clause
+func whereParam(clauses []dal.Clause) interface{} {
+	for _, clause := range clauses {
+		if clause.Type == dal.WhereClause {
+			return clause.Data.(dal.DalClause).Params[0]
+		}
+	}
+	return nil
+}
+
+// doraStore stands for the tables of a project: the incidents, the deployments, the relationships between them and
+// the flags of the deployments
+type doraStore struct {
+	incidents     map[string]bool
+	deployments   map[string]bool
+	relationships map[string]string
+	flags         map[string]string
+}
+
+func (s *doraStore) mock(mockDal *mockdal.Dal) {
+	errNotFound := errors.NotFound.New("record not found")
+	mockDal.On("First", mock.Anything, mock.Anything).Return(func(_ interface{}, clauses ...dal.Clause) errors.Error {
+		if s.incidents[whereParam(clauses).(string)] {
+			return nil
+		}
+		return errNotFound
+	}).Maybe()
+	mockDal.On("IsErrorNotFound", mock.Anything).Return(func(err error) bool { return err == errNotFound }).Maybe()
+	mockDal.On("Count", mock.Anything).Return(func(clauses ...dal.Clause) (int64, errors.Error) {
+		if s.deployments[whereParam(clauses).(string)] {
+			return 1, nil
+		}
+		return 0, nil
+	}).Maybe()
+	mockDal.On("CreateOrUpdate", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		switch record := args.Get(0).(type) {
+		case *crossdomain.ProjectIncidentDeploymentRelationship:
+			s.relationships[record.Id] = record.DeploymentId
+		case *models.DeploymentFlag:
+			s.flags[record.DeploymentId] = record.Type
+		}
+	}).Return(nil).Maybe()
+	mockDal.On("Delete", &crossdomain.ProjectIncidentDeploymentRelationship{}, mock.Anything).Run(func(args mock.Arguments) {
+		delete(s.relationships, args.Get(1).([]dal.Clause)[0].Data.(dal.DalClause).Params[1].(string))
+	}).Return(nil).Maybe()
+}
+
+func TestPutIncidentLink(t *testing.T) {
+	tests := []struct {
+		name          string
+		incidentId    string
+		body          map[string]interface{}
+		link          *models.IncidentDeploymentLink
+		relationships map[string]string
+		errType       *errors.Type
+	}{
+		{
+			name:          "link to another deployment",
+			incidentId:    "i1",
+			body:          map[string]interface{}{"deploymentId": "d2"},
+			link:          &models.IncidentDeploymentLink{ProjectName: "project1", IncidentId: "i1", DeploymentId: "d2"},
+			relationships: map[string]string{"i1": "d2", "i2": "d1"},
+		},
+		{
+			name:          "unlink",
+			incidentId:    "i1",
+			body:          map[string]interface{}{"unlinked": true},
+			link:          &models.IncidentDeploymentLink{ProjectName: "project1", IncidentId: "i1", Unlinked: true},
+			relationships: map[string]string{"i2": "d1"},
+		},
+		{name: "neither deployment nor unlinked", incidentId: "i1", body: map[string]interface{}{}, errType: errors.BadInput},
+		{name: "both deployment and unlinked", incidentId: "i1", body: map[string]interface{}{"deploymentId": "d2", "unlinked": true}, errType: errors.BadInput},
+		{name: "unknown incident", incidentId: "i3", body: map[string]interface{}{"deploymentId": "d2"}, errType: errors.NotFound},
+		{name: "unknown deployment", incidentId: "i1", body: map[string]interface{}{"deploymentId": "d3"}, errType: errors.NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &doraStore{
+				incidents:     map[string]bool{"i1": true, "i2": true},
+				deployments:   map[string]bool{"d1": true, "d2": true},
+				relationships: map[string]string{"i1": "d1", "i2": "d1"},
+			}
+			basicRes = unithelper.DummyBasicRes(store.mock)
+			output, err := PutIncidentLink(&plugin.ApiResourceInput{
+				Params: map[string]string{"projectName": "project1", "incidentId": tt.incidentId},
+				Body:   tt.body,
+			})
+			if tt.errType != nil {
+				if assert.NotNil(t, err) {
+					assert.Equal(t, tt.errType, err.GetType())
+				}
+				// the relationships are left as they were
+				assert.Equal(t, map[string]string{"i1": "d1", "i2": "d1"}, store.relationships)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, http.StatusOK, output.Status)
+			assert.Equal(t, tt.link, output.Body)
+			// the link takes effect right away, the other incidents are untouched
+			assert.Equal(t, tt.relationships, store.relationships)
+		})
+	}
+}
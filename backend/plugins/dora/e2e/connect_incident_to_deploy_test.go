@@ -26,6 +26,7 @@ import (
 	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
 	"github.com/apache/incubator-devlake/helpers/e2ehelper"
 	"github.com/apache/incubator-devlake/plugins/dora/impl"
+	"github.com/apache/incubator-devlake/plugins/dora/models"
 	"github.com/apache/incubator-devlake/plugins/dora/tasks"
 )
 
@@ -44,6 +45,8 @@ func TestConnectIncidentToDeploymentDataFlow(t *testing.T) {
 	dataflowTester.ImportCsvIntoTabler("./connect_incident_to_deployment/raw_tables/incidents.csv", &ticket.Incident{})
 
 	// verify converter
+	dataflowTester.FlushTabler(&models.IncidentDeploymentLink{})
+	dataflowTester.FlushTabler(&models.DuplicateIncident{})
 	dataflowTester.FlushTabler(&crossdomain.ProjectIncidentDeploymentRelationship{})
 	dataflowTester.Subtask(tasks.ConnectIncidentToDeploymentMeta, taskData)
 	dataflowTester.VerifyTableWithOptions(&crossdomain.ProjectIncidentDeploymentRelationship{}, e2ehelper.TableOptions{
@@ -51,3 +54,29 @@ func TestConnectIncidentToDeploymentDataFlow(t *testing.T) {
 		IgnoreTypes: []interface{}{common.NoPKModel{}},
 	})
 }
+
+func TestConnectIncidentToDeploymentWithLinksDataFlow(t *testing.T) {
+	var plugin impl.Dora
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "dora", plugin)
+
+	taskData := &tasks.DoraTaskData{
+		Options: &tasks.DoraOptions{
+			ProjectName: "project1",
+			// incidents more than 60 days after the latest deployment are not attributed to it
+			IncidentDeploymentRule: &tasks.IncidentDeploymentRule{TimeWindowHours: 60 * 24},
+		},
+	}
+	dataflowTester.ImportCsvIntoTabler("./connect_incident_to_deployment/prev_success_deployment_commit/cicd_deployment_commits_after.csv", &devops.CicdDeploymentCommit{})
+	dataflowTester.ImportCsvIntoTabler("./connect_incident_to_deployment/raw_tables/project_mapping.csv", &crossdomain.ProjectMapping{})
+	dataflowTester.ImportCsvIntoTabler("./connect_incident_to_deployment/raw_tables/incidents.csv", &ticket.Incident{})
+	// the manual links of project1 override the rule, the one of project2 is ignored
+	dataflowTester.ImportCsvIntoTabler("./connect_incident_to_deployment/raw_tables/incident_deployment_links.csv", &models.IncidentDeploymentLink{})
+
+	dataflowTester.FlushTabler(&models.DuplicateIncident{})
+	dataflowTester.FlushTabler(&crossdomain.ProjectIncidentDeploymentRelationship{})
+	dataflowTester.Subtask(tasks.ConnectIncidentToDeploymentMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&crossdomain.ProjectIncidentDeploymentRelationship{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./connect_incident_to_deployment/snapshot_tables/project_incident_deployment_relationships_linked.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+}
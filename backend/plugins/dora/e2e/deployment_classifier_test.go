@@ -0,0 +1,56 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/dora/impl"
+	"github.com/apache/incubator-devlake/plugins/dora/models"
+	"github.com/apache/incubator-devlake/plugins/dora/tasks"
+)
+
+func TestClassifyDeploymentsDataFlow(t *testing.T) {
+	var plugin impl.Dora
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "dora", plugin)
+
+	taskData := &tasks.DoraTaskData{
+		Options: &tasks.DoraOptions{
+			ProjectName: "project1",
+			DeploymentClassificationRule: &tasks.DeploymentClassificationRule{
+				RollbackPattern: `(?i)^revert`,
+				HotfixPattern:   `(?i)hotfix`,
+			},
+		},
+	}
+	dataflowTester.ImportCsvIntoTabler("./deployment_classification/project_mapping.csv", &crossdomain.ProjectMapping{})
+	dataflowTester.ImportCsvIntoTabler("./deployment_classification/cicd_deployment_commits.csv", &devops.CicdDeploymentCommit{})
+	dataflowTester.ImportCsvIntoTabler("./deployment_classification/_tool_dora_deployment_flags.csv", &models.DeploymentFlag{})
+
+	// only the successful production deployments of the project are classified, the flag wins over the patterns
+	dataflowTester.FlushTabler(&models.DeploymentClassification{})
+	dataflowTester.Subtask(tasks.ClassifyDeploymentsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&models.DeploymentClassification{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./deployment_classification/_tool_dora_deployment_classifications.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+}
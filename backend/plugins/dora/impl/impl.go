@@ -20,10 +20,13 @@ package impl
 import (
 	"encoding/json"
 
+	"github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	coreModels "github.com/apache/incubator-devlake/core/models"
 	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/dora/api"
+	"github.com/apache/incubator-devlake/plugins/dora/models"
 	"github.com/apache/incubator-devlake/plugins/dora/models/migrationscripts"
 	"github.com/apache/incubator-devlake/plugins/dora/tasks"
 )
@@ -36,10 +39,36 @@ var _ interface {
 	plugin.PluginMetric
 	plugin.PluginMigration
 	plugin.MetricPluginBlueprintV200
+	plugin.PluginInit
+	plugin.PluginApi
 } = (*Dora)(nil)
 
 type Dora struct{}
 
+func (p Dora) Init(basicRes context.BasicRes) errors.Error {
+	api.Init(basicRes)
+	return nil
+}
+
+func (p Dora) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
+	return map[string]map[string]plugin.ApiResourceHandler{
+		"projects/:projectName/incident-links": {
+			"GET": api.ListIncidentLinks,
+		},
+		"projects/:projectName/incident-links/:incidentId": {
+			"PUT":    api.PutIncidentLink,
+			"DELETE": api.DeleteIncidentLink,
+		},
+		"projects/:projectName/deployment-flags": {
+			"GET": api.ListDeploymentFlags,
+		},
+		"projects/:projectName/deployment-flags/:deploymentId": {
+			"PUT":    api.PutDeploymentFlag,
+			"DELETE": api.DeleteDeploymentFlag,
+		},
+	}
+}
+
 func (p Dora) Description() string {
 	return "collect some Dora data"
 }
@@ -68,7 +97,16 @@ func (p Dora) RequiredDataEntities() (data []map[string]interface{}, err errors.
 }
 
 func (p Dora) GetTablesInfo() []dal.Tabler {
-	return []dal.Tabler{}
+	return []dal.Tabler{
+		&models.DeploymentFrequency{},
+		&models.IncidentDeploymentLink{},
+		&models.EnvironmentServiceMetric{},
+		&models.TeamMetric{},
+		&models.DuplicateIncident{},
+		&models.DeploymentClassification{},
+		&models.DeploymentFlag{},
+		&models.DeploymentService{},
+	}
 }
 
 func (p Dora) Name() string {
@@ -94,8 +132,15 @@ func (p Dora) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.EnrichPrevSuccessDeploymentCommitMeta,
 		tasks.EnrichTaskEnvMeta,
 		tasks.CalculateChangeLeadTimeMeta,
+		tasks.CalculateIssueCycleTimeMeta,
 		tasks.IssuesToIncidentsMeta,
+		tasks.DeduplicateIncidentsMeta,
 		tasks.ConnectIncidentToDeploymentMeta,
+		tasks.AttributeDeploymentServicesMeta,
+		tasks.ClassifyDeploymentsMeta,
+		tasks.CalculateDeploymentFrequencyMeta,
+		tasks.CalculateEnvironmentServiceMetricsMeta,
+		tasks.CalculateTeamMetricsMeta,
 	}
 }
 
@@ -127,6 +172,25 @@ func (p Dora) MakeMetricPluginPipelinePlanV200(projectName string, options json.
 		}
 	}
 
+	metricOptions := map[string]interface{}{
+		"projectName": projectName,
+	}
+	if len(op.DeploymentFrequencyWindows) > 0 {
+		metricOptions["deploymentFrequencyWindows"] = op.DeploymentFrequencyWindows
+	}
+	if op.IncidentDeploymentRule != nil {
+		metricOptions["incidentDeploymentRule"] = op.IncidentDeploymentRule
+	}
+	if op.IncidentDedupRule != nil {
+		metricOptions["incidentDedupRule"] = op.IncidentDedupRule
+	}
+	if op.DeploymentClassificationRule != nil {
+		metricOptions["deploymentClassificationRule"] = op.DeploymentClassificationRule
+	}
+	if len(op.ServiceRules) > 0 {
+		metricOptions["serviceRules"] = op.ServiceRules
+	}
+
 	plan := coreModels.PipelinePlan{
 		{
 			{
@@ -154,14 +218,19 @@ func (p Dora) MakeMetricPluginPipelinePlanV200(projectName string, options json.
 		},
 		{
 			{
-				Plugin: "dora",
-				Options: map[string]interface{}{
-					"projectName": projectName,
-				},
+				Plugin:  "dora",
+				Options: metricOptions,
 				Subtasks: []string{
 					"calculateChangeLeadTime",
+					tasks.CalculateIssueCycleTimeMeta.Name,
 					tasks.IssuesToIncidentsMeta.Name,
+					tasks.DeduplicateIncidentsMeta.Name,
 					"ConnectIncidentToDeployment",
+					tasks.AttributeDeploymentServicesMeta.Name,
+					tasks.ClassifyDeploymentsMeta.Name,
+					tasks.CalculateDeploymentFrequencyMeta.Name,
+					tasks.CalculateEnvironmentServiceMetricsMeta.Name,
+					tasks.CalculateTeamMetricsMeta.Name,
 				},
 			},
 		},
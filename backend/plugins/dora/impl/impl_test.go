@@ -61,8 +61,15 @@ func TestMakeMetricPluginPipelinePlanV200(t *testing.T) {
 				Plugin: "dora",
 				Subtasks: []string{
 					"calculateChangeLeadTime",
+					tasks.CalculateIssueCycleTimeMeta.Name,
 					tasks.IssuesToIncidentsMeta.Name,
+					tasks.DeduplicateIncidentsMeta.Name,
 					"ConnectIncidentToDeployment",
+					tasks.AttributeDeploymentServicesMeta.Name,
+					tasks.ClassifyDeploymentsMeta.Name,
+					tasks.CalculateDeploymentFrequencyMeta.Name,
+					tasks.CalculateEnvironmentServiceMetricsMeta.Name,
+					tasks.CalculateTeamMetricsMeta.Name,
 				},
 				Options: map[string]interface{}{"projectName": projectName},
 			},
@@ -70,3 +77,38 @@ func TestMakeMetricPluginPipelinePlanV200(t *testing.T) {
 	}
 	assert.Equal(t, doraOutputPlan, plan)
 }
+
+func TestMakeMetricPluginPipelinePlanV200WithRules(t *testing.T) {
+	var dora Dora
+	const projectName = "TestMakePlanV200-project"
+	optionJson := []byte(`{
+		"deploymentFrequencyWindows": ["7d"],
+		"incidentDedupRule": {"sourcePrecedence": ["pagerduty", "jira"], "windowMinutes": 30},
+		"serviceRules": [
+			{"service": "api", "pathPattern": "^services/api/"},
+			{"service": "web", "deploymentPattern": "web", "environmentPattern": "^prod"}
+		]
+	}`)
+	plan, err := dora.MakeMetricPluginPipelinePlanV200(projectName, optionJson)
+	assert.Nil(t, err)
+	metricOptions := plan[2][0].Options
+	assert.Equal(t, []tasks.ServiceRule{
+		{Service: "api", PathPattern: "^services/api/"},
+		{Service: "web", DeploymentPattern: "web", EnvironmentPattern: "^prod"},
+	}, metricOptions["serviceRules"])
+
+	// the options are saved along with the pipeline and decoded by the subtasks
+	saved, marshalErr := json.Marshal(metricOptions)
+	assert.Nil(t, marshalErr)
+	var options map[string]interface{}
+	assert.Nil(t, json.Unmarshal(saved, &options))
+	op, err := tasks.DecodeAndValidateTaskOptions(options)
+	assert.Nil(t, err)
+	assert.Equal(t, projectName, op.ProjectName)
+	assert.Equal(t, []string{"7d"}, op.DeploymentFrequencyWindows)
+	assert.Equal(t, 30, op.IncidentDedupRule.WindowMinutes)
+	assert.Len(t, op.ServiceRules, 2)
+	assert.Equal(t, "api", op.ServiceRules[0].Service)
+	assert.Equal(t, "^services/api/", op.ServiceRules[0].PathPattern)
+	assert.Equal(t, "^prod", op.ServiceRules[1].EnvironmentPattern)
+}
@@ -0,0 +1,59 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+const (
+	DEPLOYMENT_ROLLBACK = "ROLLBACK"
+	DEPLOYMENT_HOTFIX   = "HOTFIX"
+)
+
+// DeploymentClassification marks a deployment of a project as a rollback or a hotfix. A rollback recovers from
+// the failure of the previous deployment of the same cicd scope, RecoveryMinutes is the time between both.
+type DeploymentClassification struct {
+	common.NoPKModel
+	ProjectName            string `gorm:"primaryKey;type:varchar(100)"`
+	DeploymentId           string `gorm:"primaryKey;type:varchar(255)"`
+	CicdScopeId            string `gorm:"type:varchar(255)"`
+	Type                   string `gorm:"type:varchar(20)"`
+	Manual                 bool
+	FinishedDate           *time.Time
+	RolledBackDeploymentId string `gorm:"type:varchar(255)"`
+	RecoveryMinutes        *int64
+}
+
+func (DeploymentClassification) TableName() string {
+	return "_tool_dora_deployment_classifications"
+}
+
+// DeploymentFlag explicitly classifies a deployment, it takes precedence over the patterns of the rule
+type DeploymentFlag struct {
+	common.NoPKModel
+	ProjectName  string `json:"projectName" gorm:"primaryKey;type:varchar(100)"`
+	DeploymentId string `json:"deploymentId" gorm:"primaryKey;type:varchar(255)"`
+	Type         string `json:"type" gorm:"type:varchar(20)"`
+}
+
+func (DeploymentFlag) TableName() string {
+	return "_tool_dora_deployment_flags"
+}
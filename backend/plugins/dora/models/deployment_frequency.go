@@ -0,0 +1,42 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// DeploymentFrequency is the number of successful production deployments of a project within a window,
+// windows are either rolling (one row per day, covering the given number of days up to that day) or calendar based.
+// Service is empty for all deployments of the project, or one of the services attributed by the service rules
+type DeploymentFrequency struct {
+	common.NoPKModel
+	ProjectName     string    `gorm:"primaryKey;type:varchar(100)"`
+	Service         string    `gorm:"primaryKey;type:varchar(255)"`
+	Window          string    `gorm:"primaryKey;type:varchar(20)"` // 7d, 14d, 30d, month or quarter
+	WindowStart     time.Time `gorm:"primaryKey"`
+	WindowEnd       time.Time // exclusive
+	DeploymentCount int
+	DeploymentDays  int // number of days with at least one deployment
+}
+
+func (DeploymentFrequency) TableName() string {
+	return "_tool_dora_deployment_frequencies"
+}
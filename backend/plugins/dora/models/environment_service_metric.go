@@ -0,0 +1,44 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// EnvironmentServiceMetric holds the change failure rate and failed deployment recovery time of the deployments
+// finished in a month, per environment and per service (the cicd scope), Service is empty for all services combined
+type EnvironmentServiceMetric struct {
+	common.NoPKModel
+	ProjectName           string    `gorm:"primaryKey;type:varchar(100)"`
+	Environment           string    `gorm:"primaryKey;type:varchar(255)"`
+	Service               string    `gorm:"primaryKey;type:varchar(255)"`
+	Month                 time.Time `gorm:"primaryKey"`
+	DeploymentCount       int
+	FailedDeploymentCount int // deployments either failed or caused incidents
+	ChangeFailureRate     float64
+	RecoveredCount        int
+	// median minutes from a failed deployment to the next successful one, or to the resolution of the incident it caused
+	MedianRecoveryMinutes *int64
+}
+
+func (EnvironmentServiceMetric) TableName() string {
+	return "_tool_dora_environment_service_metrics"
+}
@@ -0,0 +1,36 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// IncidentDeploymentLink overrides the deployment an incident is attributed to, it takes precedence over
+// the automatic rules. An Unlinked incident is not attributed to any deployment at all
+type IncidentDeploymentLink struct {
+	common.NoPKModel
+	ProjectName  string `json:"projectName" gorm:"primaryKey;type:varchar(100)"`
+	IncidentId   string `json:"incidentId" gorm:"primaryKey;type:varchar(255)"`
+	DeploymentId string `json:"deploymentId" gorm:"type:varchar(255)"`
+	Unlinked     bool   `json:"unlinked"`
+}
+
+func (IncidentDeploymentLink) TableName() string {
+	return "_tool_dora_incident_deployment_links"
+}
@@ -0,0 +1,76 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+type addDeploymentClassifications struct{}
+
+type deploymentClassification20261014 struct {
+	archived.NoPKModel
+	ProjectName            string `gorm:"primaryKey;type:varchar(100)"`
+	DeploymentId           string `gorm:"primaryKey;type:varchar(255)"`
+	CicdScopeId            string `gorm:"type:varchar(255)"`
+	Type                   string `gorm:"type:varchar(20)"`
+	Manual                 bool
+	FinishedDate           *time.Time
+	RolledBackDeploymentId string `gorm:"type:varchar(255)"`
+	RecoveryMinutes        *int64
+}
+
+func (deploymentClassification20261014) TableName() string {
+	return "_tool_dora_deployment_classifications"
+}
+
+type deploymentFlag20261014 struct {
+	archived.NoPKModel
+	ProjectName  string `gorm:"primaryKey;type:varchar(100)"`
+	DeploymentId string `gorm:"primaryKey;type:varchar(255)"`
+	Type         string `gorm:"type:varchar(20)"`
+}
+
+func (deploymentFlag20261014) TableName() string {
+	return "_tool_dora_deployment_flags"
+}
+
+func (*addDeploymentClassifications) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&deploymentClassification20261014{},
+		&deploymentFlag20261014{},
+	)
+}
+
+func (*addDeploymentClassifications) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropTables(&deploymentClassification20261014{}, &deploymentFlag20261014{})
+}
+
+func (*addDeploymentClassifications) Version() uint64 {
+	return 20261014000006
+}
+
+func (*addDeploymentClassifications) Name() string {
+	return "add _tool_dora_deployment_classifications and _tool_dora_deployment_flags tables"
+}
@@ -0,0 +1,50 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+type addDuplicateIncidents struct{}
+
+type duplicateIncident20261014 struct {
+	archived.NoPKModel
+	ProjectName         string `gorm:"primaryKey;type:varchar(100)"`
+	IncidentId          string `gorm:"primaryKey;type:varchar(255)"`
+	CanonicalIncidentId string `gorm:"type:varchar(255)"`
+}
+
+func (duplicateIncident20261014) TableName() string {
+	return "_tool_dora_duplicate_incidents"
+}
+
+func (*addDuplicateIncidents) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, &duplicateIncident20261014{})
+}
+
+func (*addDuplicateIncidents) Version() uint64 {
+	return 20261014000005
+}
+
+func (*addDuplicateIncidents) Name() string {
+	return "add _tool_dora_duplicate_incidents table"
+}
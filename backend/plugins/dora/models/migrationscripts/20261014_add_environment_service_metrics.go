@@ -0,0 +1,58 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+type addEnvironmentServiceMetrics struct{}
+
+type environmentServiceMetric20261014 struct {
+	archived.NoPKModel
+	ProjectName           string    `gorm:"primaryKey;type:varchar(100)"`
+	Environment           string    `gorm:"primaryKey;type:varchar(255)"`
+	Service               string    `gorm:"primaryKey;type:varchar(255)"`
+	Month                 time.Time `gorm:"primaryKey"`
+	DeploymentCount       int
+	FailedDeploymentCount int
+	ChangeFailureRate     float64
+	RecoveredCount        int
+	MedianRecoveryMinutes *int64
+}
+
+func (environmentServiceMetric20261014) TableName() string {
+	return "_tool_dora_environment_service_metrics"
+}
+
+func (*addEnvironmentServiceMetrics) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, &environmentServiceMetric20261014{})
+}
+
+func (*addEnvironmentServiceMetrics) Version() uint64 {
+	return 20261014000003
+}
+
+func (*addEnvironmentServiceMetrics) Name() string {
+	return "add _tool_dora_environment_service_metrics table"
+}
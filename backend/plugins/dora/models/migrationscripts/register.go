@@ -27,5 +27,12 @@ func All() []plugin.MigrationScript {
 		new(addDoraBenchmark),
 		new(fixDoraBenchmarkMetric),
 		new(adddoraBenchmark2023),
+		new(addDeploymentFrequencies),
+		new(addIncidentDeploymentLinks),
+		new(addEnvironmentServiceMetrics),
+		new(addTeamMetrics),
+		new(addDuplicateIncidents),
+		new(addDeploymentClassifications),
+		new(addDeploymentServices),
 	}
 }
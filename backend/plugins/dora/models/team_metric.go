@@ -0,0 +1,47 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// TeamMetric holds the DORA metrics of the deployments shipping pull requests authored by members of a team within
+// a month, along with the DORA 2023 performance level (elite, high, medium or low) of each metric
+type TeamMetric struct {
+	common.NoPKModel
+	ProjectName              string    `gorm:"primaryKey;type:varchar(100)"`
+	TeamId                   string    `gorm:"primaryKey;type:varchar(255)"`
+	Month                    time.Time `gorm:"primaryKey"`
+	TeamName                 string    `gorm:"type:varchar(255)"`
+	DeploymentCount          int
+	DeploymentDays           int
+	MedianLeadTimeMinutes    *int64
+	ChangeFailureRate        float64
+	MedianRecoveryMinutes    *int64
+	DeploymentFrequencyLevel string `gorm:"type:varchar(20)"`
+	LeadTimeLevel            string `gorm:"type:varchar(20)"`
+	ChangeFailureRateLevel   string `gorm:"type:varchar(20)"`
+	RecoveryTimeLevel        string `gorm:"type:varchar(20)"`
+}
+
+func (TeamMetric) TableName() string {
+	return "_tool_dora_team_metrics"
+}
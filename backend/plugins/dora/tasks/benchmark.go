@@ -0,0 +1,97 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+const (
+	LEVEL_ELITE  = "elite"
+	LEVEL_HIGH   = "high"
+	LEVEL_MEDIUM = "medium"
+	LEVEL_LOW    = "low"
+)
+
+// the levels below follow the DORA 2023 report, which is the latest one in dora_benchmarks
+
+// deploymentFrequencyLevel classifies by the average number of days with deployments per week
+func deploymentFrequencyLevel(deploymentDays int, daysInPeriod int) string {
+	if daysInPeriod <= 0 {
+		return ""
+	}
+	perWeek := float64(deploymentDays) * 7 / float64(daysInPeriod)
+	switch {
+	case perWeek >= 5:
+		// deploying (almost) every working day, on-demand
+		return LEVEL_ELITE
+	case perWeek >= 1:
+		return LEVEL_HIGH
+	case deploymentDays >= 1:
+		return LEVEL_MEDIUM
+	default:
+		return LEVEL_LOW
+	}
+}
+
+// leadTimeLevel classifies the median lead time for changes
+func leadTimeLevel(minutes *int64) string {
+	if minutes == nil {
+		return ""
+	}
+	switch {
+	case *minutes < 24*60:
+		return LEVEL_ELITE
+	case *minutes < 7*24*60:
+		return LEVEL_HIGH
+	case *minutes < 30*24*60:
+		return LEVEL_MEDIUM
+	default:
+		return LEVEL_LOW
+	}
+}
+
+// changeFailureRateLevel classifies the change failure rate, an empty level is returned if nothing was deployed
+func changeFailureRateLevel(rate float64, deploymentCount int) string {
+	if deploymentCount == 0 {
+		return ""
+	}
+	switch {
+	case rate <= 0.05:
+		return LEVEL_ELITE
+	case rate <= 0.10:
+		return LEVEL_HIGH
+	case rate <= 0.15:
+		return LEVEL_MEDIUM
+	default:
+		return LEVEL_LOW
+	}
+}
+
+// recoveryTimeLevel classifies the median failed deployment recovery time
+func recoveryTimeLevel(minutes *int64) string {
+	if minutes == nil {
+		return ""
+	}
+	switch {
+	case *minutes < 60:
+		return LEVEL_ELITE
+	case *minutes < 24*60:
+		return LEVEL_HIGH
+	case *minutes < 7*24*60:
+		return LEVEL_MEDIUM
+	default:
+		return LEVEL_LOW
+	}
+}
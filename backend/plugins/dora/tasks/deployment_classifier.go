@@ -0,0 +1,190 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/dora/models"
+)
+
+// ClassifyDeploymentsMeta contains metadata for the ClassifyDeployments subtask.
+var ClassifyDeploymentsMeta = plugin.SubTaskMeta{
+	Name:             "classifyDeployments",
+	EntryPoint:       ClassifyDeployments,
+	EnabledByDefault: true,
+	Description:      "Mark deployments as rollbacks or hotfixes by patterns or explicit flags",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+type deploymentCommitText struct {
+	CicdDeploymentId string
+	CicdScopeId      string
+	Name             string
+	DisplayTitle     string
+	CommitMsg        string
+	FinishedDate     *time.Time
+}
+
+// deploymentCandidate is a successful production deployment along with the texts the patterns are matched against
+type deploymentCandidate struct {
+	Id           string
+	CicdScopeId  string
+	FinishedDate time.Time
+	Texts        []string
+}
+
+// ClassifyDeployments materializes the rollbacks and hotfixes of a project into _tool_dora_deployment_classifications
+func ClassifyDeployments(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*DoraTaskData)
+	projectName := data.Options.ProjectName
+	err := db.Delete(&models.DeploymentClassification{}, dal.Where("project_name = ?", projectName))
+	if err != nil {
+		return errors.Default.Wrap(err, "error deleting previous deployment classifications")
+	}
+
+	var flags []*models.DeploymentFlag
+	if err = db.All(&flags, dal.Where("project_name = ?", projectName)); err != nil {
+		return errors.Default.Wrap(err, "error loading deployment flags")
+	}
+	rule := data.Options.DeploymentClassificationRule
+	if len(flags) == 0 && (rule == nil || (rule.RollbackPattern == "" && rule.HotfixPattern == "")) {
+		return nil
+	}
+	var rollbackRegex, hotfixRegex *regexp.Regexp
+	if rule != nil && rule.RollbackPattern != "" {
+		rollbackRegex = regexp.MustCompile(rule.RollbackPattern)
+	}
+	if rule != nil && rule.HotfixPattern != "" {
+		hotfixRegex = regexp.MustCompile(rule.HotfixPattern)
+	}
+
+	var rows []*deploymentCommitText
+	err = db.All(
+		&rows,
+		dal.Select("dc.cicd_deployment_id, dc.cicd_scope_id, dc.name, dc.display_title, dc.commit_msg, dc.finished_date"),
+		dal.From("cicd_deployment_commits dc"),
+		dal.Join("LEFT JOIN project_mapping pm ON (pm.row_id = dc.cicd_scope_id AND pm.table = 'cicd_scopes')"),
+		dal.Where(
+			"pm.project_name = ? AND dc.result = ? AND dc.environment = ? AND dc.finished_date IS NOT NULL",
+			projectName, devops.RESULT_SUCCESS, devops.PRODUCTION,
+		),
+	)
+	if err != nil {
+		return err
+	}
+	candidates := make([]*deploymentCandidate, 0)
+	byId := make(map[string]*deploymentCandidate)
+	for _, row := range rows {
+		candidate, ok := byId[row.CicdDeploymentId]
+		if !ok {
+			candidate = &deploymentCandidate{
+				Id:           row.CicdDeploymentId,
+				CicdScopeId:  row.CicdScopeId,
+				FinishedDate: *row.FinishedDate,
+				Texts:        []string{row.Name, row.DisplayTitle},
+			}
+			byId[row.CicdDeploymentId] = candidate
+			candidates = append(candidates, candidate)
+		}
+		if row.FinishedDate.After(candidate.FinishedDate) {
+			candidate.FinishedDate = *row.FinishedDate
+		}
+		candidate.Texts = append(candidate.Texts, row.CommitMsg)
+	}
+
+	flagByDeployment := make(map[string]string, len(flags))
+	for _, flag := range flags {
+		flagByDeployment[flag.DeploymentId] = flag.Type
+	}
+	classifications := classifyDeployments(projectName, candidates, flagByDeployment, rollbackRegex, hotfixRegex)
+	batchSave, err := api.NewBatchSave(taskCtx, reflect.TypeOf(&models.DeploymentClassification{}), 500)
+	if err != nil {
+		return err
+	}
+	for _, classification := range classifications {
+		if err = batchSave.Add(classification); err != nil {
+			return err
+		}
+	}
+	return batchSave.Close()
+}
+
+// classifyDeployments flags take precedence over the patterns, the rollback pattern over the hotfix one.
+// The deployment rolled back is the previous deployment of the same cicd scope.
+func classifyDeployments(
+	projectName string,
+	candidates []*deploymentCandidate,
+	flags map[string]string,
+	rollbackRegex, hotfixRegex *regexp.Regexp,
+) []*models.DeploymentClassification {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].FinishedDate.Before(candidates[j].FinishedDate)
+	})
+	matches := func(regex *regexp.Regexp, texts []string) bool {
+		if regex == nil {
+			return false
+		}
+		for _, text := range texts {
+			if text != "" && regex.MatchString(text) {
+				return true
+			}
+		}
+		return false
+	}
+	previousOfScope := make(map[string]*deploymentCandidate)
+	var classifications []*models.DeploymentClassification
+	for _, candidate := range candidates {
+		previous := previousOfScope[candidate.CicdScopeId]
+		previousOfScope[candidate.CicdScopeId] = candidate
+
+		classification := &models.DeploymentClassification{
+			ProjectName:  projectName,
+			DeploymentId: candidate.Id,
+			CicdScopeId:  candidate.CicdScopeId,
+		}
+		finishedDate := candidate.FinishedDate
+		classification.FinishedDate = &finishedDate
+		if flag, ok := flags[candidate.Id]; ok {
+			classification.Type = flag
+			classification.Manual = true
+		} else if matches(rollbackRegex, candidate.Texts) {
+			classification.Type = models.DEPLOYMENT_ROLLBACK
+		} else if matches(hotfixRegex, candidate.Texts) {
+			classification.Type = models.DEPLOYMENT_HOTFIX
+		} else {
+			continue
+		}
+		if classification.Type == models.DEPLOYMENT_ROLLBACK && previous != nil {
+			classification.RolledBackDeploymentId = previous.Id
+			recoveryMinutes := int64(candidate.FinishedDate.Sub(previous.FinishedDate).Minutes())
+			classification.RecoveryMinutes = &recoveryMinutes
+		}
+		classifications = append(classifications, classification)
+	}
+	return classifications
+}
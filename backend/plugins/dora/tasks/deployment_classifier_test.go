@@ -0,0 +1,111 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/plugins/dora/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyDeployments(t *testing.T) {
+	at := func(h int) time.Time {
+		return time.Date(2023, 5, 1, h, 0, 0, 0, time.UTC)
+	}
+	candidates := []*deploymentCandidate{
+		{Id: "d3", CicdScopeId: "s1", FinishedDate: at(3), Texts: []string{"deploy", "", "Revert \"add feature\""}},
+		{Id: "d1", CicdScopeId: "s1", FinishedDate: at(1), Texts: []string{"deploy", "", "add feature"}},
+		{Id: "d2", CicdScopeId: "s2", FinishedDate: at(2), Texts: []string{"hotfix-deploy", "", "fix typo"}},
+		{Id: "d4", CicdScopeId: "s2", FinishedDate: at(5), Texts: []string{"deploy", "", "bump version"}},
+		{Id: "d5", CicdScopeId: "s1", FinishedDate: at(6), Texts: []string{"deploy", "", "hotfix: revert config"}},
+	}
+	flags := map[string]string{"d4": models.DEPLOYMENT_ROLLBACK}
+	got := classifyDeployments("p", candidates, flags, regexp.MustCompile(`(?i)^revert`), regexp.MustCompile(`(?i)hotfix`))
+
+	assert.Len(t, got, 4)
+	assert.Equal(t, "d2", got[0].DeploymentId)
+	assert.Equal(t, models.DEPLOYMENT_HOTFIX, got[0].Type)
+	assert.Nil(t, got[0].RecoveryMinutes)
+
+	assert.Equal(t, "d3", got[1].DeploymentId)
+	assert.Equal(t, models.DEPLOYMENT_ROLLBACK, got[1].Type)
+	assert.Equal(t, "d1", got[1].RolledBackDeploymentId)
+	assert.Equal(t, int64(120), *got[1].RecoveryMinutes)
+	assert.False(t, got[1].Manual)
+
+	assert.Equal(t, "d4", got[2].DeploymentId)
+	assert.True(t, got[2].Manual)
+	assert.Equal(t, "d2", got[2].RolledBackDeploymentId)
+	assert.Equal(t, int64(180), *got[2].RecoveryMinutes)
+
+	// the rollback pattern is anchored, the hotfix one matches
+	assert.Equal(t, "d5", got[3].DeploymentId)
+	assert.Equal(t, models.DEPLOYMENT_HOTFIX, got[3].Type)
+}
+
+func TestClassifyDeploymentsWithFlags(t *testing.T) {
+	at := func(h int) time.Time {
+		return time.Date(2023, 5, 1, h, 0, 0, 0, time.UTC)
+	}
+	rollbackRegex, hotfixRegex := regexp.MustCompile(`(?i)^revert`), regexp.MustCompile(`(?i)hotfix`)
+	tests := []struct {
+		name            string
+		flag            string
+		texts           []string
+		classification  string
+		rolledBack      string
+		recoveryMinutes int64
+	}{
+		{name: "by the rollback pattern", texts: []string{"Revert \"add feature\""}, classification: models.DEPLOYMENT_ROLLBACK, rolledBack: "d1", recoveryMinutes: 60},
+		{name: "by the hotfix pattern", texts: []string{"hotfix-deploy"}, classification: models.DEPLOYMENT_HOTFIX},
+		{name: "not matched", texts: []string{"bump version"}},
+		{name: "rollback flag without a pattern", flag: models.DEPLOYMENT_ROLLBACK, texts: []string{"bump version"}, classification: models.DEPLOYMENT_ROLLBACK, rolledBack: "d1", recoveryMinutes: 60},
+		{name: "rollback flag over the hotfix pattern", flag: models.DEPLOYMENT_ROLLBACK, texts: []string{"hotfix-deploy"}, classification: models.DEPLOYMENT_ROLLBACK, rolledBack: "d1", recoveryMinutes: 60},
+		{name: "hotfix flag over the rollback pattern", flag: models.DEPLOYMENT_HOTFIX, texts: []string{"Revert \"add feature\""}, classification: models.DEPLOYMENT_HOTFIX},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := map[string]string{}
+			if tt.flag != "" {
+				flags["d2"] = tt.flag
+			}
+			got := classifyDeployments("p", []*deploymentCandidate{
+				{Id: "d1", CicdScopeId: "s1", FinishedDate: at(1), Texts: []string{"add feature"}},
+				{Id: "d2", CicdScopeId: "s1", FinishedDate: at(2), Texts: tt.texts},
+			}, flags, rollbackRegex, hotfixRegex)
+			if tt.classification == "" {
+				assert.Empty(t, got)
+				return
+			}
+			if assert.Len(t, got, 1) {
+				assert.Equal(t, "d2", got[0].DeploymentId)
+				assert.Equal(t, tt.classification, got[0].Type)
+				assert.Equal(t, tt.flag != "", got[0].Manual)
+				assert.Equal(t, tt.rolledBack, got[0].RolledBackDeploymentId)
+				if tt.recoveryMinutes > 0 {
+					assert.Equal(t, tt.recoveryMinutes, *got[0].RecoveryMinutes)
+				} else {
+					assert.Nil(t, got[0].RecoveryMinutes)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/dora/models"
+)
+
+const (
+	WINDOW_MONTH   = "month"
+	WINDOW_QUARTER = "quarter"
+)
+
+// rollingWindows maps the rolling windows to their length in days
+var rollingWindows = map[string]int{
+	"7d":  7,
+	"14d": 14,
+	"30d": 30,
+}
+
+// DefaultDeploymentFrequencyWindows are used when no window was specified in the task options
+var DefaultDeploymentFrequencyWindows = []string{"7d", "14d", "30d", WINDOW_MONTH, WINDOW_QUARTER}
+
+// CalculateDeploymentFrequencyMeta contains metadata for the CalculateDeploymentFrequency subtask.
+var CalculateDeploymentFrequencyMeta = plugin.SubTaskMeta{
+	Name:             "calculateDeploymentFrequency",
+	EntryPoint:       CalculateDeploymentFrequency,
+	EnabledByDefault: true,
+	Description:      "Calculate deployment frequency over rolling and calendar windows",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+// CalculateDeploymentFrequency materializes the deployment frequency of a project into _tool_dora_deployment_frequencies
+func CalculateDeploymentFrequency(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*DoraTaskData)
+	// Clear previous results from the project
+	err := db.Delete(&models.DeploymentFrequency{}, dal.Where("project_name = ?", data.Options.ProjectName))
+	if err != nil {
+		return errors.Default.Wrap(err, "error deleting previous deployment frequencies")
+	}
+
+	var deployments []*simpleCicdDeploymentCommit
+	err = db.All(
+		&deployments,
+		dal.Select("dc.cicd_deployment_id as id, MAX(dc.finished_date) as finished_date"),
+		dal.From("cicd_deployment_commits dc"),
+		dal.Join("LEFT JOIN project_mapping pm ON (pm.row_id = dc.cicd_scope_id AND pm.table = 'cicd_scopes')"),
+		dal.Where(
+			"pm.project_name = ? AND dc.result = ? AND dc.environment = ? AND dc.finished_date IS NOT NULL",
+			data.Options.ProjectName, devops.RESULT_SUCCESS, devops.PRODUCTION,
+		),
+		dal.Groupby("dc.cicd_deployment_id"),
+	)
+	if err != nil {
+		return err
+	}
+	excluded := make(map[string]bool)
+	if rule := data.Options.DeploymentClassificationRule; rule != nil && rule.ExcludeRollbacksFromDeploymentFrequency {
+		var rollbackIds []string
+		err = db.Pluck(
+			"deployment_id",
+			&rollbackIds,
+			dal.From(&models.DeploymentClassification{}),
+			dal.Where("project_name = ? AND type = ?", data.Options.ProjectName, models.DEPLOYMENT_ROLLBACK),
+		)
+		if err != nil {
+			return err
+		}
+		for _, id := range rollbackIds {
+			excluded[id] = true
+		}
+	}
+	services, err := loadDeploymentServices(db, data.Options.ProjectName)
+	if err != nil {
+		return err
+	}
+	deployedAt := make([]time.Time, 0, len(deployments))
+	// services are only attributed with service rules, the series of all deployments comes first
+	serviceDeployedAt := make(map[string][]time.Time)
+	var serviceNames []string
+	for _, deployment := range deployments {
+		if excluded[deployment.Id] {
+			continue
+		}
+		deployedAt = append(deployedAt, *deployment.FinishedDate)
+		for _, service := range services[deployment.Id] {
+			if _, ok := serviceDeployedAt[service]; !ok {
+				serviceNames = append(serviceNames, service)
+			}
+			serviceDeployedAt[service] = append(serviceDeployedAt[service], *deployment.FinishedDate)
+		}
+	}
+
+	now := time.Now()
+	frequencies := computeDeploymentFrequencies(data.Options.ProjectName, data.Options.DeploymentFrequencyWindows, deployedAt, now)
+	for _, service := range serviceNames {
+		for _, frequency := range computeDeploymentFrequencies(data.Options.ProjectName, data.Options.DeploymentFrequencyWindows, serviceDeployedAt[service], now) {
+			frequency.Service = service
+			frequencies = append(frequencies, frequency)
+		}
+	}
+	batchSave, err := api.NewBatchSave(taskCtx, reflect.TypeOf(&models.DeploymentFrequency{}), 500)
+	if err != nil {
+		return err
+	}
+	for _, frequency := range frequencies {
+		if err = batchSave.Add(frequency); err != nil {
+			return err
+		}
+	}
+	return batchSave.Close()
+}
+
+// computeDeploymentFrequencies counts the deployments within each window from the day of the first deployment up to now, all in UTC
+func computeDeploymentFrequencies(projectName string, windows []string, deployedAt []time.Time, now time.Time) []*models.DeploymentFrequency {
+	if len(deployedAt) == 0 {
+		return nil
+	}
+	day := func(t time.Time) time.Time {
+		return t.UTC().Truncate(24 * time.Hour)
+	}
+	first := day(deployedAt[0])
+	for _, t := range deployedAt {
+		if day(t).Before(first) {
+			first = day(t)
+		}
+	}
+	today := day(now)
+	if today.Before(first) {
+		today = first
+	}
+	days := int(today.Sub(first).Hours()/24) + 1
+	// prefix sums of deployments and days with deployments, indexed by days since the first deployment
+	perDay := make([]int, days)
+	for _, t := range deployedAt {
+		if i := int(day(t).Sub(first).Hours() / 24); i < days {
+			perDay[i]++
+		}
+	}
+	deployments := make([]int, days+1)
+	deploymentDays := make([]int, days+1)
+	for i, count := range perDay {
+		deployments[i+1] = deployments[i] + count
+		deploymentDays[i+1] = deploymentDays[i]
+		if count > 0 {
+			deploymentDays[i+1]++
+		}
+	}
+	index := func(t time.Time) int {
+		i := int(t.Sub(first).Hours() / 24)
+		if i < 0 {
+			return 0
+		}
+		if i > days {
+			return days
+		}
+		return i
+	}
+	frequency := func(window string, start, end time.Time) *models.DeploymentFrequency {
+		from, to := index(start), index(end)
+		return &models.DeploymentFrequency{
+			ProjectName:     projectName,
+			Window:          window,
+			WindowStart:     start,
+			WindowEnd:       end,
+			DeploymentCount: deployments[to] - deployments[from],
+			DeploymentDays:  deploymentDays[to] - deploymentDays[from],
+		}
+	}
+
+	if len(windows) == 0 {
+		windows = DefaultDeploymentFrequencyWindows
+	}
+	var frequencies []*models.DeploymentFrequency
+	for _, window := range windows {
+		if length, ok := rollingWindows[window]; ok {
+			for d := first; !d.After(today); d = d.AddDate(0, 0, 1) {
+				end := d.AddDate(0, 0, 1)
+				frequencies = append(frequencies, frequency(window, end.AddDate(0, 0, -length), end))
+			}
+			continue
+		}
+		months := 1
+		if window == WINDOW_QUARTER {
+			months = 3
+		}
+		month := time.Month((int(first.Month())-1)/months*months + 1)
+		for start := time.Date(first.Year(), month, 1, 0, 0, 0, 0, time.UTC); !start.After(today); start = start.AddDate(0, months, 0) {
+			frequencies = append(frequencies, frequency(window, start, start.AddDate(0, months, 0)))
+		}
+	}
+	return frequencies
+}
@@ -0,0 +1,61 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeDeploymentFrequencies(t *testing.T) {
+	date := func(s string) time.Time {
+		d, err := time.Parse(time.RFC3339, s)
+		assert.Nil(t, err)
+		return d
+	}
+	deployedAt := []time.Time{
+		date("2024-03-30T10:00:00Z"),
+		date("2024-03-30T12:00:00Z"),
+		date("2024-04-02T08:00:00Z"),
+	}
+	frequencies := computeDeploymentFrequencies("p1", []string{"7d", WINDOW_MONTH, WINDOW_QUARTER}, deployedAt, date("2024-04-03T00:00:00Z"))
+
+	// 5 rolling windows from 03-30 to 04-03, 2 months and 2 quarters
+	assert.Len(t, frequencies, 9)
+	last7d := frequencies[4]
+	assert.Equal(t, date("2024-03-28T00:00:00Z"), last7d.WindowStart)
+	assert.Equal(t, date("2024-04-04T00:00:00Z"), last7d.WindowEnd)
+	assert.Equal(t, 3, last7d.DeploymentCount)
+	assert.Equal(t, 2, last7d.DeploymentDays)
+
+	march, april := frequencies[5], frequencies[6]
+	assert.Equal(t, date("2024-03-01T00:00:00Z"), march.WindowStart)
+	assert.Equal(t, 2, march.DeploymentCount)
+	assert.Equal(t, 1, march.DeploymentDays)
+	assert.Equal(t, 1, april.DeploymentCount)
+
+	q1, q2 := frequencies[7], frequencies[8]
+	assert.Equal(t, date("2024-01-01T00:00:00Z"), q1.WindowStart)
+	assert.Equal(t, date("2024-04-01T00:00:00Z"), q1.WindowEnd)
+	assert.Equal(t, 2, q1.DeploymentCount)
+	assert.Equal(t, 1, q2.DeploymentCount)
+
+	assert.Empty(t, computeDeploymentFrequencies("p1", nil, nil, time.Now()))
+}
@@ -0,0 +1,211 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"regexp"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/dora/models"
+)
+
+// AttributeDeploymentServicesMeta contains metadata for the AttributeDeploymentServices subtask.
+var AttributeDeploymentServicesMeta = plugin.SubTaskMeta{
+	Name:             "attributeDeploymentServices",
+	EntryPoint:       AttributeDeploymentServices,
+	EnabledByDefault: true,
+	Description:      "Attribute the deployments of monorepos to services by the service rules",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+type serviceDeployment struct {
+	Id           string
+	Name         string
+	DisplayTitle string
+	Environment  string
+	ScopeName    string
+}
+
+type deploymentFile struct {
+	DeploymentId string
+	FilePath     string
+}
+
+type compiledServiceRule struct {
+	service     string
+	deployment  *regexp.Regexp
+	environment *regexp.Regexp
+	path        *regexp.Regexp
+}
+
+// AttributeDeploymentServices materializes the services of the deployments into _tool_dora_deployment_services,
+// nothing is attributed without service rules and the metrics fall back to the cicd scopes as services
+func AttributeDeploymentServices(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*DoraTaskData)
+	projectName := data.Options.ProjectName
+	err := db.Delete(&models.DeploymentService{}, dal.Where("project_name = ?", projectName))
+	if err != nil {
+		return errors.Default.Wrap(err, "error deleting previous deployment services")
+	}
+	if len(data.Options.ServiceRules) == 0 {
+		return nil
+	}
+	rules := make([]*compiledServiceRule, 0, len(data.Options.ServiceRules))
+	matchPaths := false
+	for _, rule := range data.Options.ServiceRules {
+		compiled := &compiledServiceRule{service: rule.Service}
+		if rule.DeploymentPattern != "" {
+			compiled.deployment = regexp.MustCompile(rule.DeploymentPattern)
+		}
+		if rule.EnvironmentPattern != "" {
+			compiled.environment = regexp.MustCompile(rule.EnvironmentPattern)
+		}
+		if rule.PathPattern != "" {
+			compiled.path = regexp.MustCompile(rule.PathPattern)
+			matchPaths = true
+		}
+		rules = append(rules, compiled)
+	}
+
+	var deployments []*serviceDeployment
+	err = db.All(
+		&deployments,
+		dal.Select("d.id, d.name, d.display_title, d.environment, cs.name as scope_name"),
+		dal.From("cicd_deployments d"),
+		dal.Join("LEFT JOIN project_mapping pm ON (pm.row_id = d.cicd_scope_id AND pm.table = 'cicd_scopes')"),
+		dal.Join("LEFT JOIN cicd_scopes cs ON (cs.id = d.cicd_scope_id)"),
+		dal.Where("pm.project_name = ?", projectName),
+	)
+	if err != nil {
+		return err
+	}
+	paths := make(map[string][]string)
+	if matchPaths {
+		if paths, err = loadDeploymentFiles(db, projectName); err != nil {
+			return err
+		}
+	}
+
+	batchSave, err := api.NewBatchSave(taskCtx, reflect.TypeOf(&models.DeploymentService{}), 500)
+	if err != nil {
+		return err
+	}
+	for _, deployment := range deployments {
+		for _, service := range attributeServices(rules, deployment, paths[deployment.Id]) {
+			err = batchSave.Add(&models.DeploymentService{
+				ProjectName:  projectName,
+				DeploymentId: deployment.Id,
+				Service:      service,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return batchSave.Close()
+}
+
+// loadDeploymentFiles returns the files changed by the deployed commits, which are the commit of the deployment
+// and the commits since the previous successful deployment calculated by refdiff
+func loadDeploymentFiles(db dal.Dal, projectName string) (map[string][]string, errors.Error) {
+	var headFiles, diffFiles []*deploymentFile
+	err := db.All(
+		&headFiles,
+		dal.Select("DISTINCT dc.cicd_deployment_id as deployment_id, cf.file_path"),
+		dal.From("cicd_deployment_commits dc"),
+		dal.Join("LEFT JOIN project_mapping pm ON (pm.row_id = dc.cicd_scope_id AND pm.table = 'cicd_scopes')"),
+		dal.Join("INNER JOIN commit_files cf ON (cf.commit_sha = dc.commit_sha)"),
+		dal.Where("pm.project_name = ?", projectName),
+	)
+	if err != nil {
+		return nil, err
+	}
+	err = db.All(
+		&diffFiles,
+		dal.Select("DISTINCT dc.cicd_deployment_id as deployment_id, cf.file_path"),
+		dal.From("cicd_deployment_commits dc"),
+		dal.Join("LEFT JOIN project_mapping pm ON (pm.row_id = dc.cicd_scope_id AND pm.table = 'cicd_scopes')"),
+		dal.Join("INNER JOIN cicd_deployment_commits prev ON (prev.id = dc.prev_success_deployment_commit_id)"),
+		dal.Join("INNER JOIN commits_diffs cd ON (cd.new_commit_sha = dc.commit_sha AND cd.old_commit_sha = prev.commit_sha)"),
+		dal.Join("INNER JOIN commit_files cf ON (cf.commit_sha = cd.commit_sha)"),
+		dal.Where("pm.project_name = ?", projectName),
+	)
+	if err != nil {
+		return nil, err
+	}
+	paths := make(map[string][]string)
+	for _, file := range append(headFiles, diffFiles...) {
+		paths[file.DeploymentId] = append(paths[file.DeploymentId], file.FilePath)
+	}
+	return paths, nil
+}
+
+// attributeServices returns the services of all matching rules in the order of the rules,
+// or the name of the cicd scope if no rule matches
+func attributeServices(rules []*compiledServiceRule, deployment *serviceDeployment, paths []string) []string {
+	var services []string
+	seen := make(map[string]bool)
+	for _, rule := range rules {
+		if seen[rule.service] {
+			continue
+		}
+		if rule.deployment != nil && !rule.deployment.MatchString(deployment.Name) && !rule.deployment.MatchString(deployment.DisplayTitle) {
+			continue
+		}
+		if rule.environment != nil && !rule.environment.MatchString(deployment.Environment) {
+			continue
+		}
+		if rule.path != nil && !anyMatch(rule.path, paths) {
+			continue
+		}
+		seen[rule.service] = true
+		services = append(services, rule.service)
+	}
+	if len(services) == 0 && deployment.ScopeName != "" {
+		services = append(services, deployment.ScopeName)
+	}
+	return services
+}
+
+func anyMatch(re *regexp.Regexp, values []string) bool {
+	for _, value := range values {
+		if re.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// loadDeploymentServices returns the services attributed to the deployments of the project, keyed by deployment id
+func loadDeploymentServices(db dal.Dal, projectName string) (map[string][]string, errors.Error) {
+	var rows []*models.DeploymentService
+	err := db.All(&rows, dal.Where("project_name = ?", projectName), dal.Orderby("deployment_id, service"))
+	if err != nil {
+		return nil, err
+	}
+	services := make(map[string][]string)
+	for _, row := range rows {
+		services[row.DeploymentId] = append(services[row.DeploymentId], row.Service)
+	}
+	return services, nil
+}
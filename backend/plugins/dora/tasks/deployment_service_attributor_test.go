@@ -0,0 +1,71 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttributeServices(t *testing.T) {
+	rules := []*compiledServiceRule{
+		{service: "api", path: regexp.MustCompile(`^services/api/`)},
+		{service: "web", deployment: regexp.MustCompile(`(?i)deploy-web`)},
+		{service: "web-prod", deployment: regexp.MustCompile(`(?i)deploy-web`), environment: regexp.MustCompile(devops.PRODUCTION)},
+	}
+	deployment := &serviceDeployment{Id: "d1", Name: "Deploy-Web", Environment: devops.STAGING, ScopeName: "mono"}
+
+	assert.Equal(t, []string{"web"}, attributeServices(rules, deployment, nil))
+	assert.Equal(t, []string{"api", "web"}, attributeServices(rules, deployment, []string{"README.md", "services/api/main.go"}))
+
+	deployment.Environment = devops.PRODUCTION
+	assert.Equal(t, []string{"web", "web-prod"}, attributeServices(rules, deployment, nil))
+
+	// falls back to the cicd scope
+	other := &serviceDeployment{Id: "d2", Name: "release", Environment: devops.PRODUCTION, ScopeName: "mono"}
+	assert.Equal(t, []string{"mono"}, attributeServices(rules, other, []string{"docs/index.md"}))
+}
+
+func TestEnvironmentServiceMetricsOfMonorepo(t *testing.T) {
+	finished := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	deployments := expandDeploymentServices(
+		[]*deploymentOutcome{
+			{Id: "d1", Environment: devops.PRODUCTION, Service: "mono", Result: devops.RESULT_FAILURE, FinishedDate: &finished},
+			{Id: "d2", Environment: devops.PRODUCTION, Service: "mono", Result: devops.RESULT_SUCCESS, FinishedDate: &finished},
+		},
+		map[string][]string{"d1": {"api", "web"}, "d2": {"api"}},
+	)
+	assert.Len(t, deployments, 3)
+
+	metrics := computeEnvironmentServiceMetrics("p1", deployments, nil)
+	// production/api, production/all and production/web
+	assert.Len(t, metrics, 3)
+	api, all, web := metrics[0], metrics[1], metrics[2]
+	assert.Equal(t, "api", api.Service)
+	assert.Equal(t, 2, api.DeploymentCount)
+	assert.Equal(t, 1, api.RecoveredCount)
+	assert.Equal(t, "", all.Service)
+	assert.Equal(t, 2, all.DeploymentCount)
+	assert.Equal(t, 0.5, all.ChangeFailureRate)
+	assert.Equal(t, "web", web.Service)
+	assert.Equal(t, 1.0, web.ChangeFailureRate)
+}
@@ -0,0 +1,247 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/dora/models"
+)
+
+// CalculateEnvironmentServiceMetricsMeta contains metadata for the CalculateEnvironmentServiceMetrics subtask.
+var CalculateEnvironmentServiceMetricsMeta = plugin.SubTaskMeta{
+	Name:             "calculateEnvironmentServiceMetrics",
+	EntryPoint:       CalculateEnvironmentServiceMetrics,
+	EnabledByDefault: true,
+	Description:      "Calculate change failure rate and failed deployment recovery time per environment and service",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD, plugin.DOMAIN_TYPE_TICKET},
+}
+
+type deploymentOutcome struct {
+	Id           string
+	Environment  string
+	Service      string
+	Result       string
+	FinishedDate *time.Time
+}
+
+type attributedIncident struct {
+	DeploymentId   string
+	ResolutionDate *time.Time
+}
+
+// CalculateEnvironmentServiceMetrics materializes the metrics into _tool_dora_environment_service_metrics,
+// it runs after ConnectIncidentToDeployment so the incidents are attributed to deployments already
+func CalculateEnvironmentServiceMetrics(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*DoraTaskData)
+	// Clear previous results from the project
+	err := db.Delete(&models.EnvironmentServiceMetric{}, dal.Where("project_name = ?", data.Options.ProjectName))
+	if err != nil {
+		return errors.Default.Wrap(err, "error deleting previous environment service metrics")
+	}
+
+	var deployments []*deploymentOutcome
+	err = db.All(
+		&deployments,
+		dal.Select("d.id, d.environment, cs.name as service, d.result, d.finished_date"),
+		dal.From("cicd_deployments d"),
+		dal.Join("LEFT JOIN project_mapping pm ON (pm.row_id = d.cicd_scope_id AND pm.table = 'cicd_scopes')"),
+		dal.Join("LEFT JOIN cicd_scopes cs ON (cs.id = d.cicd_scope_id)"),
+		dal.Where(
+			"pm.project_name = ? AND d.result IN ? AND d.finished_date IS NOT NULL",
+			data.Options.ProjectName, []string{devops.RESULT_SUCCESS, devops.RESULT_FAILURE},
+		),
+		dal.Orderby("d.finished_date"),
+	)
+	if err != nil {
+		return err
+	}
+	if len(data.Options.ServiceRules) > 0 {
+		services, err := loadDeploymentServices(db, data.Options.ProjectName)
+		if err != nil {
+			return err
+		}
+		deployments = expandDeploymentServices(deployments, services)
+	}
+	incidents, err := loadAttributedIncidents(db, data.Options.ProjectName)
+	if err != nil {
+		return err
+	}
+
+	batchSave, err := api.NewBatchSave(taskCtx, reflect.TypeOf(&models.EnvironmentServiceMetric{}), 500)
+	if err != nil {
+		return err
+	}
+	for _, metric := range computeEnvironmentServiceMetrics(data.Options.ProjectName, deployments, incidents) {
+		if err = batchSave.Add(metric); err != nil {
+			return err
+		}
+	}
+	return batchSave.Close()
+}
+
+// expandDeploymentServices replaces the cicd scope of each deployment with the services attributed to it,
+// a deployment of several services is repeated once per service
+func expandDeploymentServices(deployments []*deploymentOutcome, services map[string][]string) []*deploymentOutcome {
+	expanded := make([]*deploymentOutcome, 0, len(deployments))
+	for _, deployment := range deployments {
+		attributed, ok := services[deployment.Id]
+		if !ok {
+			expanded = append(expanded, deployment)
+			continue
+		}
+		for _, service := range attributed {
+			copied := *deployment
+			copied.Service = service
+			expanded = append(expanded, &copied)
+		}
+	}
+	return expanded
+}
+
+// computeEnvironmentServiceMetrics aggregates the deployments (ordered by finished date) by environment, service and month.
+// A deployment is failed if its result is FAILURE or any incident is attributed to it, it recovers when the next deployment
+// of the same environment and service succeeds, or when all incidents it caused are resolved.
+// A deployment repeated for several services is counted once for all services combined
+func computeEnvironmentServiceMetrics(projectName string, deployments []*deploymentOutcome, incidents []*attributedIncident) []*models.EnvironmentServiceMetric {
+	incidentsByDeployment := make(map[string][]*attributedIncident)
+	for _, incident := range incidents {
+		incidentsByDeployment[incident.DeploymentId] = append(incidentsByDeployment[incident.DeploymentId], incident)
+	}
+	type bucketKey struct {
+		environment string
+		service     string
+		month       time.Time
+	}
+	metrics := make(map[bucketKey]*models.EnvironmentServiceMetric)
+	recoveries := make(map[bucketKey][]int64)
+	var keys []bucketKey
+	add := func(key bucketKey, failed bool, recovery *int64) {
+		metric, ok := metrics[key]
+		if !ok {
+			metric = &models.EnvironmentServiceMetric{
+				ProjectName: projectName,
+				Environment: key.environment,
+				Service:     key.service,
+				Month:       key.month,
+			}
+			metrics[key] = metric
+			keys = append(keys, key)
+		}
+		metric.DeploymentCount++
+		if failed {
+			metric.FailedDeploymentCount++
+		}
+		if recovery != nil {
+			metric.RecoveredCount++
+			recoveries[key] = append(recoveries[key], *recovery)
+		}
+	}
+
+	combined := make(map[string]bool)
+	for i, deployment := range deployments {
+		finished := deployment.FinishedDate.UTC()
+		related := incidentsByDeployment[deployment.Id]
+		failed := deployment.Result == devops.RESULT_FAILURE || len(related) > 0
+		var recovery *int64
+		if len(related) > 0 {
+			recovery = incidentRecovery(finished, related)
+		} else if failed {
+			for _, next := range deployments[i+1:] {
+				if next.Environment == deployment.Environment && next.Service == deployment.Service && next.Result == devops.RESULT_SUCCESS {
+					recovery = minutesBetween(finished, *next.FinishedDate)
+					break
+				}
+			}
+		}
+		month := time.Date(finished.Year(), finished.Month(), 1, 0, 0, 0, 0, time.UTC)
+		add(bucketKey{deployment.Environment, deployment.Service, month}, failed, recovery)
+		if deployment.Service != "" && !combined[deployment.Id] {
+			combined[deployment.Id] = true
+			add(bucketKey{deployment.Environment, "", month}, failed, recovery)
+		}
+	}
+
+	result := make([]*models.EnvironmentServiceMetric, 0, len(keys))
+	for _, key := range keys {
+		metric := metrics[key]
+		metric.ChangeFailureRate = float64(metric.FailedDeploymentCount) / float64(metric.DeploymentCount)
+		metric.MedianRecoveryMinutes = median(recoveries[key])
+		result = append(result, metric)
+	}
+	return result
+}
+
+// loadAttributedIncidents loads the incidents attributed to deployments by ConnectIncidentToDeployment
+func loadAttributedIncidents(db dal.Dal, projectName string) ([]*attributedIncident, errors.Error) {
+	var incidents []*attributedIncident
+	err := db.All(
+		&incidents,
+		dal.Select("r.deployment_id, i.resolution_date"),
+		dal.From("project_incident_deployment_relationships r"),
+		dal.Join("LEFT JOIN incidents i ON (i.id = r.id)"),
+		dal.Where("r.project_name = ?", projectName),
+	)
+	return incidents, err
+}
+
+// incidentRecovery returns the minutes from the deployment to the resolution of the last incident it caused,
+// nil if any of them is not resolved yet
+func incidentRecovery(deployedAt time.Time, incidents []*attributedIncident) *int64 {
+	var resolved *time.Time
+	for _, incident := range incidents {
+		if incident.ResolutionDate == nil {
+			return nil
+		}
+		if resolved == nil || incident.ResolutionDate.After(*resolved) {
+			resolved = incident.ResolutionDate
+		}
+	}
+	if resolved == nil {
+		return nil
+	}
+	return minutesBetween(deployedAt, *resolved)
+}
+
+func minutesBetween(from, to time.Time) *int64 {
+	minutes := int64(to.Sub(from).Minutes())
+	if minutes < 0 {
+		minutes = 0
+	}
+	return &minutes
+}
+
+func median(values []int64) *int64 {
+	if len(values) == 0 {
+		return nil
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	m := values[len(values)/2]
+	if len(values)%2 == 0 {
+		m = (values[len(values)/2-1] + m) / 2
+	}
+	return &m
+}
@@ -0,0 +1,69 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeEnvironmentServiceMetrics(t *testing.T) {
+	at := func(s string) *time.Time {
+		d, err := time.Parse(time.RFC3339, s)
+		assert.Nil(t, err)
+		return &d
+	}
+	deployments := []*deploymentOutcome{
+		{Id: "d1", Environment: devops.PRODUCTION, Service: "api", Result: devops.RESULT_SUCCESS, FinishedDate: at("2024-05-01T00:00:00Z")},
+		{Id: "d2", Environment: devops.PRODUCTION, Service: "api", Result: devops.RESULT_FAILURE, FinishedDate: at("2024-05-02T00:00:00Z")},
+		{Id: "d3", Environment: devops.STAGING, Service: "api", Result: devops.RESULT_SUCCESS, FinishedDate: at("2024-05-02T00:30:00Z")},
+		{Id: "d4", Environment: devops.PRODUCTION, Service: "api", Result: devops.RESULT_SUCCESS, FinishedDate: at("2024-05-02T01:00:00Z")},
+		{Id: "d5", Environment: devops.PRODUCTION, Service: "web", Result: devops.RESULT_SUCCESS, FinishedDate: at("2024-05-03T00:00:00Z")},
+	}
+	incidents := []*attributedIncident{
+		{DeploymentId: "d1", ResolutionDate: at("2024-05-01T03:00:00Z")},
+		{DeploymentId: "d5", ResolutionDate: nil},
+	}
+	metrics := computeEnvironmentServiceMetrics("p1", deployments, incidents)
+	// production/api, production/all, staging/api, staging/all and production/web
+	assert.Len(t, metrics, 5)
+
+	prodApi, prodAll, staging, prodWeb := metrics[0], metrics[1], metrics[2], metrics[4]
+	assert.Equal(t, "api", prodApi.Service)
+	assert.Equal(t, 3, prodApi.DeploymentCount)
+	assert.Equal(t, 2, prodApi.FailedDeploymentCount)
+	assert.Equal(t, 2, prodApi.RecoveredCount)
+	// recovered in 180 and 60 minutes
+	assert.Equal(t, int64(120), *prodApi.MedianRecoveryMinutes)
+
+	assert.Equal(t, "", prodAll.Service)
+	assert.Equal(t, 4, prodAll.DeploymentCount)
+	assert.Equal(t, 3, prodAll.FailedDeploymentCount)
+	assert.Equal(t, 0.75, prodAll.ChangeFailureRate)
+
+	assert.Equal(t, devops.STAGING, staging.Environment)
+	assert.Equal(t, 0.0, staging.ChangeFailureRate)
+	assert.Nil(t, staging.MedianRecoveryMinutes)
+
+	assert.Equal(t, "web", prodWeb.Service)
+	assert.Equal(t, 1, prodWeb.FailedDeploymentCount)
+	assert.Equal(t, 0, prodWeb.RecoveredCount)
+}
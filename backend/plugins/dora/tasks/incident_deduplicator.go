@@ -0,0 +1,161 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/dora/models"
+)
+
+// DeduplicateIncidentsMeta contains metadata for the DeduplicateIncidents subtask.
+var DeduplicateIncidentsMeta = plugin.SubTaskMeta{
+	Name:             "deduplicateIncidents",
+	EntryPoint:       DeduplicateIncidents,
+	EnabledByDefault: true,
+	Description:      "Find incidents reported by multiple sources for the same outage",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+type simpleIncident struct {
+	Id          string
+	CreatedDate *time.Time
+}
+
+// DeduplicateIncidents records the duplicated incidents of the project, they are skipped by ConnectIncidentToDeployment
+func DeduplicateIncidents(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*DoraTaskData)
+	// Clear previous results from the project
+	err := db.Delete(&models.DuplicateIncident{}, dal.Where("project_name = ?", data.Options.ProjectName))
+	if err != nil {
+		return errors.Default.Wrap(err, "error deleting previous duplicate incidents")
+	}
+	rule := data.Options.IncidentDedupRule
+	if rule == nil {
+		return nil
+	}
+
+	var incidents []*simpleIncident
+	err = db.All(
+		&incidents,
+		dal.Select("i.id, i.created_date"),
+		dal.From("incidents i"),
+		dal.Join("LEFT JOIN project_mapping pm ON (pm.row_id = i.scope_id AND pm.table = i.table)"),
+		dal.Where("pm.project_name = ? AND i.created_date IS NOT NULL", data.Options.ProjectName),
+	)
+	if err != nil {
+		return err
+	}
+
+	batchSave, err := api.NewBatchSave(taskCtx, reflect.TypeOf(&models.DuplicateIncident{}), 500)
+	if err != nil {
+		return err
+	}
+	for _, duplicate := range findDuplicateIncidents(data.Options.ProjectName, rule, incidents) {
+		if err = batchSave.Add(duplicate); err != nil {
+			return err
+		}
+	}
+	return batchSave.Close()
+}
+
+// incidentSource returns the plugin which the incident comes from, e.g. `pagerduty` for `pagerduty:Incident:1:42`
+func incidentSource(incidentId string) string {
+	return strings.SplitN(incidentId, ":", 2)[0]
+}
+
+// findDuplicateIncidents groups incidents of different sources created within the window of the first incident of
+// the group, two incidents of the same source are never considered duplicates
+func findDuplicateIncidents(projectName string, rule *IncidentDedupRule, incidents []*simpleIncident) []*models.DuplicateIncident {
+	window := time.Duration(rule.WindowMinutes) * time.Minute
+	if window == 0 {
+		window = time.Hour
+	}
+	rank := func(source string) int {
+		for i, s := range rule.SourcePrecedence {
+			if s == source {
+				return i
+			}
+		}
+		return len(rule.SourcePrecedence)
+	}
+	sort.SliceStable(incidents, func(i, j int) bool {
+		return incidents[i].CreatedDate.Before(*incidents[j].CreatedDate)
+	})
+
+	type group struct {
+		start     time.Time
+		sources   map[string]bool
+		incidents []*simpleIncident
+	}
+	var groups, open []*group
+	for _, incident := range incidents {
+		source := incidentSource(incident.Id)
+		var target *group
+		stillOpen := open[:0]
+		for _, g := range open {
+			if incident.CreatedDate.Sub(g.start) > window {
+				continue
+			}
+			stillOpen = append(stillOpen, g)
+			if target == nil && !g.sources[source] {
+				target = g
+			}
+		}
+		open = stillOpen
+		if target == nil {
+			target = &group{start: *incident.CreatedDate, sources: make(map[string]bool)}
+			groups = append(groups, target)
+			open = append(open, target)
+		}
+		target.sources[source] = true
+		target.incidents = append(target.incidents, incident)
+	}
+
+	var duplicates []*models.DuplicateIncident
+	for _, g := range groups {
+		if len(g.incidents) < 2 {
+			continue
+		}
+		// incidents are ordered by created date, so the earliest one wins among sources of the same rank
+		canonical := g.incidents[0]
+		for _, incident := range g.incidents[1:] {
+			if rank(incidentSource(incident.Id)) < rank(incidentSource(canonical.Id)) {
+				canonical = incident
+			}
+		}
+		for _, incident := range g.incidents {
+			if incident != canonical {
+				duplicates = append(duplicates, &models.DuplicateIncident{
+					ProjectName:         projectName,
+					IncidentId:          incident.Id,
+					CanonicalIncidentId: canonical.Id,
+				})
+			}
+		}
+	}
+	return duplicates
+}
@@ -0,0 +1,53 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindDuplicateIncidents(t *testing.T) {
+	at := func(s string) *time.Time {
+		d, err := time.Parse(time.RFC3339, s)
+		assert.Nil(t, err)
+		return &d
+	}
+	incidents := []*simpleIncident{
+		{Id: "jira:JiraIssues:1:10", CreatedDate: at("2024-05-01T10:05:00Z")},
+		{Id: "pagerduty:Incident:1:1", CreatedDate: at("2024-05-01T10:00:00Z")},
+		// same source as the first pagerduty incident, a separated outage
+		{Id: "pagerduty:Incident:1:2", CreatedDate: at("2024-05-01T10:20:00Z")},
+		// out of the window
+		{Id: "jira:JiraIssues:1:11", CreatedDate: at("2024-05-01T12:00:00Z")},
+	}
+	rule := &IncidentDedupRule{SourcePrecedence: []string{"pagerduty", "jira"}, WindowMinutes: 30}
+	duplicates := findDuplicateIncidents("p1", rule, incidents)
+	assert.Len(t, duplicates, 1)
+	assert.Equal(t, "jira:JiraIssues:1:10", duplicates[0].IncidentId)
+	assert.Equal(t, "pagerduty:Incident:1:1", duplicates[0].CanonicalIncidentId)
+
+	// jira wins when it comes first
+	rule.SourcePrecedence = []string{"jira"}
+	duplicates = findDuplicateIncidents("p1", rule, incidents)
+	assert.Len(t, duplicates, 1)
+	assert.Equal(t, "pagerduty:Incident:1:1", duplicates[0].IncidentId)
+	assert.Equal(t, "jira:JiraIssues:1:10", duplicates[0].CanonicalIncidentId)
+}
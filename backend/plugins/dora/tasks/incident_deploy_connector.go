@@ -19,6 +19,7 @@ package tasks
 
 import (
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/apache/incubator-devlake/core/dal"
@@ -29,6 +30,7 @@ import (
 	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/dora/models"
 )
 
 var ConnectIncidentToDeploymentMeta = plugin.SubTaskMeta{
@@ -55,6 +57,30 @@ func ConnectIncidentToDeployment(taskCtx plugin.SubTaskContext) errors.Error {
 		return errors.Default.Wrap(err, "error deleting previous project_incident_deployment_relationships")
 	}
 	logger.Info("delete previous project_incident_deployment_relationships")
+	// manual links take precedence over the automatic rule
+	var links []*models.IncidentDeploymentLink
+	err = db.All(&links, dal.Where("project_name = ?", data.Options.ProjectName))
+	if err != nil {
+		return errors.Default.Wrap(err, "error loading incident deployment links")
+	}
+	linkByIncident := make(map[string]*models.IncidentDeploymentLink, len(links))
+	for _, link := range links {
+		linkByIncident[link.IncidentId] = link
+	}
+	// duplicated incidents of an outage are not attributed to deployments, the canonical one stands for them
+	var duplicates []*models.DuplicateIncident
+	err = db.All(&duplicates, dal.Where("project_name = ?", data.Options.ProjectName))
+	if err != nil {
+		return errors.Default.Wrap(err, "error loading duplicate incidents")
+	}
+	duplicated := make(map[string]bool, len(duplicates))
+	for _, duplicate := range duplicates {
+		duplicated[duplicate.IncidentId] = true
+	}
+	rule := data.Options.IncidentDeploymentRule
+	if rule == nil {
+		rule = &IncidentDeploymentRule{}
+	}
 	// select all issues belongs to the board
 	clauses := []dal.Clause{
 		dal.From(`incidents i`),
@@ -99,40 +125,19 @@ func ConnectIncidentToDeployment(taskCtx plugin.SubTaskContext) errors.Error {
 				ProjectName: data.Options.ProjectName,
 			}
 			logger.Debug("get incident: %+v", incident.Id)
-			cicdDeploymentCommit := &devops.CicdDeploymentCommit{}
-			cicdDeploymentCommitClauses := []dal.Clause{
-				dal.Select("cicd_deployment_commits.cicd_deployment_id as id, cicd_deployment_commits.finished_date as finished_date"),
-				dal.From(cicdDeploymentCommit),
-				dal.Join("left join project_mapping pm on cicd_deployment_commits.cicd_scope_id = pm.row_id"),
-				dal.Where(
-					`cicd_deployment_commits.finished_date < ?
-					    and cicd_deployment_commits.result = ?
-						and cicd_deployment_commits.environment = ?
-						and pm.table = ?
-						and pm.project_name = ?`,
-					incident.CreatedDate, devops.RESULT_SUCCESS, devops.PRODUCTION, "cicd_scopes", data.Options.ProjectName,
-				),
-				dal.Orderby("finished_date DESC"),
-				dal.Limit(1),
-			}
-
-			scdc := &simpleCicdDeploymentCommit{}
-			err = db.All(scdc, cicdDeploymentCommitClauses...)
+			deploymentId, err := incidentDeployment(linkByIncident[incident.Id], duplicated[incident.Id], func() (string, errors.Error) {
+				return lastDeploymentByRule(db, incident, rule, data.Options.ProjectName)
+			})
 			if err != nil {
-				if db.IsErrorNotFound(err) {
-					logger.Warn(err, "deployment commit not found")
-					return nil, nil
-				} else {
-					logger.Error(err, "get all deployment commits")
-					return nil, err
-				}
+				logger.Error(err, "get all deployment commits")
+				return nil, err
 			}
-			if scdc.Id != "" {
-				projectIssueMetric.DeploymentId = scdc.Id
-				return []interface{}{projectIssueMetric}, nil
+			if deploymentId == "" {
+				logger.Debug("no deployment found, incident will be ignored: %+v", incident.Id)
+				return nil, nil
 			}
-			logger.Debug("scdc.id is empty, incident will be ignored: %+v", incident.Id)
-			return nil, nil
+			projectIssueMetric.DeploymentId = deploymentId
+			return []interface{}{projectIssueMetric}, nil
 		},
 	})
 	if err != nil {
@@ -141,3 +146,63 @@ func ConnectIncidentToDeployment(taskCtx plugin.SubTaskContext) errors.Error {
 
 	return enricher.Execute()
 }
+
+// incidentDeployment returns the id of the deployment the incident is attributed to, or an empty string. The manual
+// link takes precedence over the automatic rule, duplicated incidents are left to their canonical one
+func incidentDeployment(link *models.IncidentDeploymentLink, duplicated bool, lastDeployment func() (string, errors.Error)) (string, errors.Error) {
+	if link != nil {
+		if link.Unlinked {
+			return "", nil
+		}
+		return link.DeploymentId, nil
+	}
+	if duplicated {
+		return "", nil
+	}
+	return lastDeployment()
+}
+
+// lastDeploymentByRule returns the last successful production deployment of the project finished before the incident
+// was created, within the time window and of the service of the incident when the rule says so
+func lastDeploymentByRule(db dal.Dal, incident *ticket.Incident, rule *IncidentDeploymentRule, projectName string) (string, errors.Error) {
+	cicdDeploymentCommit := &devops.CicdDeploymentCommit{}
+	cicdDeploymentCommitClauses := []dal.Clause{
+		dal.Select("cicd_deployment_commits.cicd_deployment_id as id, cicd_deployment_commits.finished_date as finished_date"),
+		dal.From(cicdDeploymentCommit),
+		dal.Join("left join project_mapping pm on cicd_deployment_commits.cicd_scope_id = pm.row_id"),
+		dal.Where(
+			`cicd_deployment_commits.finished_date < ?
+			    and cicd_deployment_commits.result = ?
+				and cicd_deployment_commits.environment = ?
+				and pm.table = ?
+				and pm.project_name = ?`,
+			incident.CreatedDate, devops.RESULT_SUCCESS, devops.PRODUCTION, "cicd_scopes", projectName,
+		),
+	}
+	if rule.TimeWindowHours > 0 && incident.CreatedDate != nil {
+		cicdDeploymentCommitClauses = append(cicdDeploymentCommitClauses, dal.Where(
+			"cicd_deployment_commits.finished_date >= ?",
+			incident.CreatedDate.Add(-time.Duration(rule.TimeWindowHours)*time.Hour),
+		))
+	}
+	if rule.MatchService && incident.Component != "" {
+		cicdDeploymentCommitClauses = append(cicdDeploymentCommitClauses,
+			dal.Join("left join cicd_scopes cs on cs.id = cicd_deployment_commits.cicd_scope_id"),
+			dal.Where("LOWER(cs.name) = ?", strings.ToLower(incident.Component)),
+		)
+	}
+	cicdDeploymentCommitClauses = append(cicdDeploymentCommitClauses,
+		dal.Orderby("finished_date DESC"),
+		dal.Limit(1),
+	)
+
+	scdc := &simpleCicdDeploymentCommit{}
+	err := db.All(scdc, cicdDeploymentCommitClauses...)
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return scdc.Id, nil
+}
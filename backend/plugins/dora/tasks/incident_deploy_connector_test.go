@@ -0,0 +1,63 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/plugins/dora/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIncidentDeployment(t *testing.T) {
+	tests := []struct {
+		name       string
+		link       *models.IncidentDeploymentLink
+		duplicated bool
+		// the deployment found by the time window rule, empty when none finished within the window
+		byRule     string
+		deployment string
+		ruleUsed   bool
+	}{
+		{name: "found by the rule", byRule: "d2", deployment: "d2", ruleUsed: true},
+		{name: "outside the time window", byRule: "", deployment: "", ruleUsed: true},
+		{name: "linked outside the time window", link: &models.IncidentDeploymentLink{DeploymentId: "d1"}, byRule: "", deployment: "d1"},
+		{name: "linked to another deployment", link: &models.IncidentDeploymentLink{DeploymentId: "d1"}, byRule: "d2", deployment: "d1"},
+		{name: "unlinked within the time window", link: &models.IncidentDeploymentLink{Unlinked: true}, byRule: "d2", deployment: ""},
+		{name: "duplicated", duplicated: true, byRule: "d2", deployment: ""},
+		{name: "linked duplicate", link: &models.IncidentDeploymentLink{DeploymentId: "d1"}, duplicated: true, byRule: "d2", deployment: "d1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ruleUsed := false
+			deployment, err := incidentDeployment(tt.link, tt.duplicated, func() (string, errors.Error) {
+				ruleUsed = true
+				return tt.byRule, nil
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, tt.deployment, deployment)
+			assert.Equal(t, tt.ruleUsed, ruleUsed)
+		})
+	}
+
+	_, err := incidentDeployment(nil, false, func() (string, errors.Error) {
+		return "", errors.Default.New("connection lost")
+	})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,140 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+// CalculateIssueCycleTimeMeta contains metadata for the CalculateIssueCycleTime subtask.
+var CalculateIssueCycleTimeMeta = plugin.SubTaskMeta{
+	Name:             "calculateIssueCycleTime",
+	EntryPoint:       CalculateIssueCycleTime,
+	EnabledByDefault: true,
+	Description:      "Calculate cycle time breakdown of issues from their pull requests",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET, plugin.DOMAIN_TYPE_CODE},
+}
+
+type issuePrMetric struct {
+	IssueId          string
+	IssueCreatedDate *time.Time
+	crossdomain.ProjectPrMetric
+}
+
+// CalculateIssueCycleTime materializes project_issue_metrics, it runs after calculateChangeLeadTime
+func CalculateIssueCycleTime(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*DoraTaskData)
+	// Clear previous results from the project
+	err := db.Delete(&crossdomain.ProjectIssueMetric{}, dal.Where("project_name = ?", data.Options.ProjectName))
+	if err != nil {
+		return errors.Default.Wrap(err, "error deleting previous project_issue_metrics")
+	}
+
+	cursor, err := db.Cursor(
+		dal.Select("pri.issue_id, i.created_date as issue_created_date, ppm.*"),
+		dal.From("project_pr_metrics ppm"),
+		dal.Join("JOIN pull_request_issues pri ON (pri.pull_request_id = ppm.id)"),
+		dal.Join("JOIN issues i ON (i.id = pri.issue_id)"),
+		dal.Where("ppm.project_name = ?", data.Options.ProjectName),
+		dal.Orderby("pri.issue_id"),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	batchSave, err := api.NewBatchSave(taskCtx, reflect.TypeOf(&crossdomain.ProjectIssueMetric{}), 500)
+	if err != nil {
+		return err
+	}
+	var current *crossdomain.ProjectIssueMetric
+	var undeployed bool
+	flush := func() errors.Error {
+		if current == nil {
+			return nil
+		}
+		if undeployed {
+			current.DeployedDate = nil
+		}
+		fillIssueCycleTime(current)
+		return batchSave.Add(current)
+	}
+	for cursor.Next() {
+		row := &issuePrMetric{}
+		if err = db.Fetch(cursor, row); err != nil {
+			return err
+		}
+		if current == nil || current.Id != row.IssueId {
+			if err = flush(); err != nil {
+				return err
+			}
+			current = &crossdomain.ProjectIssueMetric{
+				DomainEntity:     domainlayer.DomainEntity{Id: row.IssueId},
+				ProjectName:      data.Options.ProjectName,
+				IssueCreatedDate: row.IssueCreatedDate,
+			}
+			undeployed = false
+		}
+		current.PrCount++
+		current.FirstCommitAuthoredDate = earliest(current.FirstCommitAuthoredDate, row.FirstCommitAuthoredDate)
+		current.FirstPrCreatedDate = earliest(current.FirstPrCreatedDate, row.PrCreatedDate)
+		current.FirstReviewDate = earliest(current.FirstReviewDate, row.FirstCommentDate)
+		current.LastPrMergedDate = latest(current.LastPrMergedDate, row.PrMergedDate)
+		// an issue is deployed once all of its merged pull requests are deployed
+		if row.PrMergedDate != nil && row.PrDeployedDate == nil {
+			undeployed = true
+		}
+		current.DeployedDate = latest(current.DeployedDate, row.PrDeployedDate)
+	}
+	if err = flush(); err != nil {
+		return err
+	}
+	return batchSave.Close()
+}
+
+func fillIssueCycleTime(metric *crossdomain.ProjectIssueMetric) {
+	metric.CodingTime = computeTimeSpan(metric.FirstCommitAuthoredDate, metric.FirstPrCreatedDate)
+	metric.PickupTime = computeTimeSpan(metric.FirstPrCreatedDate, metric.FirstReviewDate)
+	metric.ReviewTime = computeTimeSpan(metric.FirstReviewDate, metric.LastPrMergedDate)
+	metric.DeployTime = computeTimeSpan(metric.LastPrMergedDate, metric.DeployedDate)
+	metric.CycleTime = computeTimeSpan(metric.FirstCommitAuthoredDate, metric.DeployedDate)
+	metric.LeadTime = computeTimeSpan(metric.IssueCreatedDate, metric.DeployedDate)
+}
+
+func earliest(a, b *time.Time) *time.Time {
+	if a == nil || (b != nil && b.Before(*a)) {
+		return b
+	}
+	return a
+}
+
+func latest(a, b *time.Time) *time.Time {
+	if a == nil || (b != nil && b.After(*a)) {
+		return b
+	}
+	return a
+}
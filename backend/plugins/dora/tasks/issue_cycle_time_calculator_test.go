@@ -0,0 +1,53 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFillIssueCycleTime(t *testing.T) {
+	at := func(s string) *time.Time {
+		d, err := time.Parse(time.RFC3339, s)
+		assert.Nil(t, err)
+		return &d
+	}
+	metric := &crossdomain.ProjectIssueMetric{
+		IssueCreatedDate:        at("2024-05-01T00:00:00Z"),
+		FirstCommitAuthoredDate: earliest(at("2024-05-01T10:00:00Z"), at("2024-05-01T09:00:00Z")),
+		FirstPrCreatedDate:      at("2024-05-01T12:00:00Z"),
+		FirstReviewDate:         at("2024-05-01T13:00:00Z"),
+		LastPrMergedDate:        latest(at("2024-05-01T14:00:00Z"), at("2024-05-01T15:00:00Z")),
+	}
+	fillIssueCycleTime(metric)
+	assert.Equal(t, int64(180), *metric.CodingTime)
+	assert.Equal(t, int64(60), *metric.PickupTime)
+	assert.Equal(t, int64(120), *metric.ReviewTime)
+	assert.Nil(t, metric.DeployTime)
+	assert.Nil(t, metric.LeadTime)
+
+	metric.DeployedDate = at("2024-05-02T00:00:00Z")
+	fillIssueCycleTime(metric)
+	assert.Equal(t, int64(540), *metric.DeployTime)
+	assert.Equal(t, int64(900), *metric.CycleTime)
+	assert.Equal(t, int64(1440), *metric.LeadTime)
+}
@@ -18,6 +18,9 @@ limitations under the License.
 package tasks
 
 import (
+	"fmt"
+	"regexp"
+
 	"github.com/apache/incubator-devlake/core/errors"
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 )
@@ -31,6 +34,52 @@ type DoraOptions struct {
 	Since       string
 	ProjectName string  `json:"projectName"`
 	ScopeId     *string `json:"scopeId,omitempty"`
+	// windows of deployment frequency: 7d, 14d, 30d (rolling), month or quarter (calendar), defaults to all of them
+	DeploymentFrequencyWindows []string `json:"deploymentFrequencyWindows,omitempty" mapstructure:"deploymentFrequencyWindows"`
+	// rule to attribute incidents to deployments automatically, manual links take precedence over it
+	IncidentDeploymentRule *IncidentDeploymentRule `json:"incidentDeploymentRule,omitempty" mapstructure:"incidentDeploymentRule"`
+	// rule to count an outage reported by multiple sources (e.g. pagerduty and jira) once, no deduplication if omitted
+	IncidentDedupRule *IncidentDedupRule `json:"incidentDedupRule,omitempty" mapstructure:"incidentDedupRule"`
+	// rule to mark deployments as rollbacks or hotfixes, deployment flags set through the api take precedence over it
+	DeploymentClassificationRule *DeploymentClassificationRule `json:"deploymentClassificationRule,omitempty" mapstructure:"deploymentClassificationRule"`
+	// rules to attribute the deployments of a monorepo to services, deployments default to the name of their cicd scope
+	ServiceRules []ServiceRule `json:"serviceRules,omitempty" mapstructure:"serviceRules"`
+}
+
+// IncidentDeploymentRule narrows down the deployments an incident could be caused by, the latest one is picked
+type IncidentDeploymentRule struct {
+	// only deployments finished within the given hours before the incident are considered, 0 means no limit
+	TimeWindowHours int `json:"timeWindowHours" mapstructure:"timeWindowHours"`
+	// only deployments of the cicd scope named after the component of the incident are considered,
+	// incidents without a component are not affected
+	MatchService bool `json:"matchService" mapstructure:"matchService"`
+}
+
+// IncidentDedupRule considers incidents from different sources created within the window as the same outage,
+// the one from the source listed first in SourcePrecedence is kept, sources are the plugin names like `pagerduty`
+type IncidentDedupRule struct {
+	SourcePrecedence []string `json:"sourcePrecedence" mapstructure:"sourcePrecedence"`
+	// defaults to 60 minutes
+	WindowMinutes int `json:"windowMinutes" mapstructure:"windowMinutes"`
+}
+
+// DeploymentClassificationRule matches the patterns against the name and title of the deployments and the messages
+// of their commits, a deployment matching both patterns is considered a rollback
+type DeploymentClassificationRule struct {
+	RollbackPattern string `json:"rollbackPattern" mapstructure:"rollbackPattern"`
+	HotfixPattern   string `json:"hotfixPattern" mapstructure:"hotfixPattern"`
+	// rollbacks are still counted toward failure recovery
+	ExcludeRollbacksFromDeploymentFrequency bool `json:"excludeRollbacksFromDeploymentFrequency" mapstructure:"excludeRollbacksFromDeploymentFrequency"`
+}
+
+// ServiceRule attributes a deployment to the service when all the given patterns match: DeploymentPattern against the
+// name or title of the deployment, EnvironmentPattern against its environment and PathPattern against any file changed
+// by the deployed commits. A deployment matching several rules is attributed to all their services.
+type ServiceRule struct {
+	Service            string `json:"service" mapstructure:"service"`
+	DeploymentPattern  string `json:"deploymentPattern" mapstructure:"deploymentPattern"`
+	EnvironmentPattern string `json:"environmentPattern" mapstructure:"environmentPattern"`
+	PathPattern        string `json:"pathPattern" mapstructure:"pathPattern"`
 }
 
 type DoraTaskData struct {
@@ -44,6 +93,37 @@ func DecodeAndValidateTaskOptions(options map[string]interface{}) (*DoraOptions,
 	if err != nil {
 		return nil, errors.Default.Wrap(err, "error decoding DORA task options")
 	}
+	if op.IncidentDeploymentRule != nil && op.IncidentDeploymentRule.TimeWindowHours < 0 {
+		return nil, errors.BadInput.New("timeWindowHours must not be negative")
+	}
+	if op.IncidentDedupRule != nil && op.IncidentDedupRule.WindowMinutes < 0 {
+		return nil, errors.BadInput.New("windowMinutes must not be negative")
+	}
+	if rule := op.DeploymentClassificationRule; rule != nil {
+		for _, pattern := range []string{rule.RollbackPattern, rule.HotfixPattern} {
+			if _, err := regexp.Compile(pattern); err != nil {
+				return nil, errors.BadInput.Wrap(err, fmt.Sprintf("invalid deployment classification pattern: %s", pattern))
+			}
+		}
+	}
+	for _, rule := range op.ServiceRules {
+		if rule.Service == "" {
+			return nil, errors.BadInput.New("service is required for service rules")
+		}
+		if rule.DeploymentPattern == "" && rule.EnvironmentPattern == "" && rule.PathPattern == "" {
+			return nil, errors.BadInput.New(fmt.Sprintf("service rule of %s has no pattern", rule.Service))
+		}
+		for _, pattern := range []string{rule.DeploymentPattern, rule.EnvironmentPattern, rule.PathPattern} {
+			if _, err := regexp.Compile(pattern); err != nil {
+				return nil, errors.BadInput.Wrap(err, fmt.Sprintf("invalid service rule pattern: %s", pattern))
+			}
+		}
+	}
+	for _, window := range op.DeploymentFrequencyWindows {
+		if _, ok := rollingWindows[window]; !ok && window != WINDOW_MONTH && window != WINDOW_QUARTER {
+			return nil, errors.BadInput.New(fmt.Sprintf("invalid deployment frequency window: %s", window))
+		}
+	}
 
 	return &op, nil
 }
@@ -0,0 +1,201 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/dora/models"
+)
+
+// CalculateTeamMetricsMeta contains metadata for the CalculateTeamMetrics subtask.
+var CalculateTeamMetricsMeta = plugin.SubTaskMeta{
+	Name:             "calculateTeamMetrics",
+	EntryPoint:       CalculateTeamMetrics,
+	EnabledByDefault: true,
+	Description:      "Calculate DORA metrics and benchmark levels per team",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CROSS, plugin.DOMAIN_TYPE_CICD, plugin.DOMAIN_TYPE_CODE},
+}
+
+type teamPrDeployment struct {
+	TeamId       string
+	PrId         string
+	TeamName     string
+	PrCycleTime  *int64
+	DeploymentId string
+	FinishedDate *time.Time
+}
+
+// CalculateTeamMetrics materializes _tool_dora_team_metrics, pull requests are attributed to the teams of their authors
+// by the team_users and user_accounts mappings of the org plugin and to the teams owning their repo by team_mapping,
+// and deployments to the teams of the pull requests they ship
+func CalculateTeamMetrics(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*DoraTaskData)
+	// Clear previous results from the project
+	err := db.Delete(&models.TeamMetric{}, dal.Where("project_name = ?", data.Options.ProjectName))
+	if err != nil {
+		return errors.Default.Wrap(err, "error deleting previous team metrics")
+	}
+
+	// pull requests are attributed to the teams of their authors, and to the teams owning their repo
+	var authorPrDeployments, repoPrDeployments []*teamPrDeployment
+	err = db.All(
+		&authorPrDeployments,
+		dal.Select("t.id as team_id, t.name as team_name, pr.id as pr_id, ppm.pr_cycle_time, dc.cicd_deployment_id as deployment_id, dc.finished_date"),
+		dal.From("project_pr_metrics ppm"),
+		dal.Join("LEFT JOIN pull_requests pr ON (pr.id = ppm.id)"),
+		dal.Join("JOIN user_accounts ua ON (ua.account_id = pr.author_id)"),
+		dal.Join("JOIN team_users tu ON (tu.user_id = ua.user_id)"),
+		dal.Join("JOIN teams t ON (t.id = tu.team_id)"),
+		dal.Join("JOIN cicd_deployment_commits dc ON (dc.id = ppm.deployment_commit_id)"),
+		dal.Where("ppm.project_name = ? AND dc.finished_date IS NOT NULL", data.Options.ProjectName),
+	)
+	if err != nil {
+		return err
+	}
+	err = db.All(
+		&repoPrDeployments,
+		dal.Select("t.id as team_id, t.name as team_name, pr.id as pr_id, ppm.pr_cycle_time, dc.cicd_deployment_id as deployment_id, dc.finished_date"),
+		dal.From("project_pr_metrics ppm"),
+		dal.Join("LEFT JOIN pull_requests pr ON (pr.id = ppm.id)"),
+		dal.Join("JOIN team_mapping tm ON (tm.table = 'repos' AND tm.row_id = pr.base_repo_id)"),
+		dal.Join("JOIN teams t ON (t.id = tm.team_id)"),
+		dal.Join("JOIN cicd_deployment_commits dc ON (dc.id = ppm.deployment_commit_id)"),
+		dal.Where("ppm.project_name = ? AND dc.finished_date IS NOT NULL", data.Options.ProjectName),
+	)
+	if err != nil {
+		return err
+	}
+	prDeployments := uniqueTeamPrDeployments(append(authorPrDeployments, repoPrDeployments...))
+	incidents, err := loadAttributedIncidents(db, data.Options.ProjectName)
+	if err != nil {
+		return err
+	}
+
+	batchSave, err := api.NewBatchSave(taskCtx, reflect.TypeOf(&models.TeamMetric{}), 500)
+	if err != nil {
+		return err
+	}
+	for _, metric := range computeTeamMetrics(data.Options.ProjectName, prDeployments, incidents, time.Now()) {
+		if err = batchSave.Add(metric); err != nil {
+			return err
+		}
+	}
+	return batchSave.Close()
+}
+
+// uniqueTeamPrDeployments drops the pull requests attributed twice to the same team, once by their author and
+// once by their repo
+func uniqueTeamPrDeployments(prDeployments []*teamPrDeployment) []*teamPrDeployment {
+	type teamPr struct {
+		teamId string
+		prId   string
+	}
+	seen := make(map[teamPr]bool)
+	result := make([]*teamPrDeployment, 0, len(prDeployments))
+	for _, prDeployment := range prDeployments {
+		key := teamPr{prDeployment.TeamId, prDeployment.PrId}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, prDeployment)
+	}
+	return result
+}
+
+// computeTeamMetrics aggregates the deployed pull requests by team and the month they were deployed in
+func computeTeamMetrics(projectName string, prDeployments []*teamPrDeployment, incidents []*attributedIncident, now time.Time) []*models.TeamMetric {
+	incidentsByDeployment := make(map[string][]*attributedIncident)
+	for _, incident := range incidents {
+		incidentsByDeployment[incident.DeploymentId] = append(incidentsByDeployment[incident.DeploymentId], incident)
+	}
+	type bucketKey struct {
+		teamId string
+		month  time.Time
+	}
+	type bucket struct {
+		metric      *models.TeamMetric
+		deployments map[string]time.Time
+		leadTimes   []int64
+	}
+	buckets := make(map[bucketKey]*bucket)
+	var keys []bucketKey
+	for _, prDeployment := range prDeployments {
+		finished := prDeployment.FinishedDate.UTC()
+		key := bucketKey{prDeployment.TeamId, time.Date(finished.Year(), finished.Month(), 1, 0, 0, 0, 0, time.UTC)}
+		b, ok := buckets[key]
+		if !ok {
+			b = &bucket{
+				metric: &models.TeamMetric{
+					ProjectName: projectName,
+					TeamId:      prDeployment.TeamId,
+					TeamName:    prDeployment.TeamName,
+					Month:       key.month,
+				},
+				deployments: make(map[string]time.Time),
+			}
+			buckets[key] = b
+			keys = append(keys, key)
+		}
+		b.deployments[prDeployment.DeploymentId] = finished
+		if prDeployment.PrCycleTime != nil {
+			b.leadTimes = append(b.leadTimes, *prDeployment.PrCycleTime)
+		}
+	}
+
+	result := make([]*models.TeamMetric, 0, len(keys))
+	for _, key := range keys {
+		b := buckets[key]
+		metric := b.metric
+		days := make(map[time.Time]bool)
+		failed := 0
+		var recoveries []int64
+		for deploymentId, finished := range b.deployments {
+			days[finished.Truncate(24*time.Hour)] = true
+			if related := incidentsByDeployment[deploymentId]; len(related) > 0 {
+				failed++
+				if recovery := incidentRecovery(finished, related); recovery != nil {
+					recoveries = append(recoveries, *recovery)
+				}
+			}
+		}
+		metric.DeploymentCount = len(b.deployments)
+		metric.DeploymentDays = len(days)
+		metric.ChangeFailureRate = float64(failed) / float64(metric.DeploymentCount)
+		metric.MedianLeadTimeMinutes = median(b.leadTimes)
+		metric.MedianRecoveryMinutes = median(recoveries)
+
+		daysInPeriod := key.month.AddDate(0, 1, -1).Day()
+		if elapsed := now.UTC(); elapsed.Year() == key.month.Year() && elapsed.Month() == key.month.Month() {
+			daysInPeriod = elapsed.Day()
+		}
+		metric.DeploymentFrequencyLevel = deploymentFrequencyLevel(metric.DeploymentDays, daysInPeriod)
+		metric.LeadTimeLevel = leadTimeLevel(metric.MedianLeadTimeMinutes)
+		metric.ChangeFailureRateLevel = changeFailureRateLevel(metric.ChangeFailureRate, metric.DeploymentCount)
+		metric.RecoveryTimeLevel = recoveryTimeLevel(metric.MedianRecoveryMinutes)
+		result = append(result, metric)
+	}
+	return result
+}
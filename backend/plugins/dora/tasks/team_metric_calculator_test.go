@@ -0,0 +1,73 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeTeamMetrics(t *testing.T) {
+	at := func(s string) *time.Time {
+		d, err := time.Parse(time.RFC3339, s)
+		assert.Nil(t, err)
+		return &d
+	}
+	minutes := func(m int64) *int64 {
+		return &m
+	}
+	prDeployments := []*teamPrDeployment{
+		{TeamId: "t1", TeamName: "core", PrCycleTime: minutes(600), DeploymentId: "d1", FinishedDate: at("2024-05-01T10:00:00Z")},
+		{TeamId: "t1", TeamName: "core", PrCycleTime: minutes(1000), DeploymentId: "d1", FinishedDate: at("2024-05-01T10:00:00Z")},
+		{TeamId: "t1", TeamName: "core", PrCycleTime: minutes(2000), DeploymentId: "d2", FinishedDate: at("2024-05-08T10:00:00Z")},
+		{TeamId: "t2", TeamName: "web", PrCycleTime: minutes(90000), DeploymentId: "d2", FinishedDate: at("2024-05-08T10:00:00Z")},
+	}
+	incidents := []*attributedIncident{
+		{DeploymentId: "d2", ResolutionDate: at("2024-05-08T10:30:00Z")},
+	}
+	metrics := computeTeamMetrics("p1", prDeployments, incidents, *at("2024-06-10T00:00:00Z"))
+	assert.Len(t, metrics, 2)
+
+	core, web := metrics[0], metrics[1]
+	assert.Equal(t, 2, core.DeploymentCount)
+	assert.Equal(t, 2, core.DeploymentDays)
+	assert.Equal(t, int64(1000), *core.MedianLeadTimeMinutes)
+	assert.Equal(t, 0.5, core.ChangeFailureRate)
+	assert.Equal(t, int64(30), *core.MedianRecoveryMinutes)
+	assert.Equal(t, LEVEL_MEDIUM, core.DeploymentFrequencyLevel)
+	assert.Equal(t, LEVEL_ELITE, core.LeadTimeLevel)
+	assert.Equal(t, LEVEL_LOW, core.ChangeFailureRateLevel)
+	assert.Equal(t, LEVEL_ELITE, core.RecoveryTimeLevel)
+
+	assert.Equal(t, "web", web.TeamName)
+	assert.Equal(t, LEVEL_LOW, web.LeadTimeLevel)
+	assert.Equal(t, 1.0, web.ChangeFailureRate)
+}
+
+func TestUniqueTeamPrDeployments(t *testing.T) {
+	byAuthor := &teamPrDeployment{TeamId: "t1", PrId: "pr1", DeploymentId: "d1"}
+	otherTeam := &teamPrDeployment{TeamId: "t2", PrId: "pr1", DeploymentId: "d1"}
+	byRepo := &teamPrDeployment{TeamId: "t1", PrId: "pr1", DeploymentId: "d1"}
+	otherPr := &teamPrDeployment{TeamId: "t1", PrId: "pr2", DeploymentId: "d1"}
+	// a pull request of a team member to a repo owned by the same team counts once for it
+	prDeployments := uniqueTeamPrDeployments([]*teamPrDeployment{byAuthor, otherTeam, byRepo, otherPr})
+	assert.Equal(t, []*teamPrDeployment{byAuthor, otherTeam, otherPr}, prDeployments)
+	assert.Same(t, byAuthor, prDeployments[0])
+}
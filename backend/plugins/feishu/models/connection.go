@@ -36,6 +36,7 @@ type FeishuConn struct {
 
 func (conn *FeishuConn) Sanitize() FeishuConn {
 	conn.SecretKey = utils.SanitizeString(conn.SecretKey)
+	conn.SanitizeProxy()
 	return *conn
 }
 
@@ -85,9 +86,11 @@ func (connection FeishuConnection) Sanitize() FeishuConnection {
 
 func (connection *FeishuConnection) MergeFromRequest(target *FeishuConnection, body map[string]interface{}) error {
 	secretKey := target.SecretKey
+	proxy := target.Proxy
 	if err := helper.DecodeMapStruct(body, target, true); err != nil {
 		return err
 	}
+	target.RestoreSanitizedProxy(proxy)
 	modifiedSecretKey := target.SecretKey
 	if modifiedSecretKey == "" || modifiedSecretKey == utils.SanitizeString(secretKey) {
 		target.SecretKey = secretKey
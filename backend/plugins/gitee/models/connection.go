@@ -43,6 +43,7 @@ type GiteeConn struct {
 
 func (connection GiteeConn) Sanitize() GiteeConn {
 	connection.Token = utils.SanitizeString(connection.Token)
+	connection.SanitizeProxy()
 	return connection
 }
 
@@ -54,9 +55,11 @@ type GiteeConnection struct {
 
 func (connection *GiteeConnection) MergeFromRequest(target *GiteeConnection, body map[string]interface{}) error {
 	token := target.Token
+	proxy := target.Proxy
 	if err := helper.DecodeMapStruct(body, target, true); err != nil {
 		return err
 	}
+	target.RestoreSanitizedProxy(proxy)
 	modifiedToken := target.Token
 	if modifiedToken == "" || modifiedToken == utils.SanitizeString(token) {
 		target.Token = token
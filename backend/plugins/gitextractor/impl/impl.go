@@ -24,7 +24,10 @@ import (
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/core/utils"
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitextractor/models"
+	"github.com/apache/incubator-devlake/plugins/gitextractor/models/migrationscripts"
 	"github.com/apache/incubator-devlake/plugins/gitextractor/parser"
 	"github.com/apache/incubator-devlake/plugins/gitextractor/tasks"
 	giturls "github.com/chainguard-dev/git-urls"
@@ -34,16 +37,27 @@ var _ interface {
 	plugin.PluginMeta
 	plugin.PluginTask
 	plugin.PluginModel
+	plugin.PluginMigration
 } = (*GitExtractor)(nil)
 
 type GitExtractor struct{}
 
-type DynamicGitUrl interface {
-	GetDynamicGitUrl(taskCtx plugin.TaskContext, connectionId uint64, repoUrl string) (string, errors.Error)
+// DynamicGitCredential is implemented by plugins to provide the credential of the given connection at clone time,
+// so tokens don't have to be embedded in the clone urls kept in the pipeline plan
+type DynamicGitCredential interface {
+	GetDynamicGitCredential(taskCtx plugin.TaskContext, connectionId uint64) (username string, password string, err errors.Error)
+}
+
+// DynamicSshKey is implemented by plugins whose connections manage an SSH key, so the key
+// is loaded at runtime instead of being kept in the pipeline plan
+type DynamicSshKey interface {
+	GetDynamicSshKey(taskCtx plugin.TaskContext, connectionId uint64) (privateKey string, passphrase string, err errors.Error)
 }
 
 func (p GitExtractor) GetTablesInfo() []dal.Tabler {
-	return []dal.Tabler{}
+	return []dal.Tabler{
+		&models.GitRepoState{},
+	}
 }
 
 func (p GitExtractor) Description() string {
@@ -60,8 +74,10 @@ func (p GitExtractor) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.CloneGitRepoMeta,
 		tasks.CollectGitCommitMeta,
 		tasks.CollectGitBranchMeta,
+		tasks.CollectGitBranchLifecycleMeta,
 		tasks.CollectGitTagMeta,
 		tasks.CollectGitDiffLineMeta,
+		tasks.CollectGitOwnershipMeta,
 	}
 }
 
@@ -73,21 +89,39 @@ func (p GitExtractor) PrepareTaskData(taskCtx plugin.TaskContext, options map[st
 		return nil, err
 	}
 
+	// the credential is injected into git commands at clone time instead of being embedded in the url
+	cleanUrl, credential, e := parser.ExtractGitCredential(op.Url)
+	if e != nil {
+		return nil, errors.BadInput.Wrap(e, "failed to parse git url")
+	}
+	op.Url = cleanUrl
+	if op.Password != "" {
+		credential = &parser.GitCredential{Username: op.User, Password: op.Password}
+	}
+
 	if op.PluginName != "" {
 		pluginInstance, err := plugin.GetPlugin(op.PluginName)
 		if err != nil {
 			return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to get plugin instance for plugin: %s", op.PluginName))
 		}
 
-		if pluginGit, ok := pluginInstance.(DynamicGitUrl); ok {
-			gitUrl, err := pluginGit.GetDynamicGitUrl(taskCtx, op.ConnectionId, op.Url)
+		if provider, ok := pluginInstance.(DynamicGitCredential); ok {
+			username, password, err := provider.GetDynamicGitCredential(taskCtx, op.ConnectionId)
 			if err != nil {
-				return nil, errors.Default.Wrap(err, "failed to get Git URL")
+				return nil, errors.Default.Wrap(err, "failed to get Git credential")
 			}
-
-			op.Url = gitUrl
+			credential = &parser.GitCredential{Username: username, Password: password}
 		} else {
-			log.Printf("Plugin does not implement DynamicGitUrl interface for plugin: %s", op.PluginName)
+			log.Info("plugin %s does not implement DynamicGitCredential, the credential of the options is used", op.PluginName)
+		}
+
+		if pluginSsh, ok := pluginInstance.(DynamicSshKey); ok && op.PrivateKey == "" {
+			privateKey, passphrase, err := pluginSsh.GetDynamicSshKey(taskCtx, op.ConnectionId)
+			if err != nil {
+				return nil, errors.Default.Wrap(err, "failed to get SSH key")
+			}
+			op.PrivateKey = privateKey
+			op.Passphrase = passphrase
 		}
 	}
 
@@ -96,9 +130,9 @@ func (p GitExtractor) PrepareTaskData(taskCtx plugin.TaskContext, options map[st
 		return nil, errors.BadInput.Wrap(err, "failed to parse git url")
 	}
 
-	// append username to the git url
-	if op.User != "" {
-		parsedURL.User = url.UserPassword(op.User, op.Password)
+	// append username to the git url, only the user part is kept for non-http urls, e.g. ssh://user@host/repo
+	if op.User != "" && parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
+		parsedURL.User = url.User(op.User)
 		op.Url = parsedURL.String()
 	}
 
@@ -118,13 +152,39 @@ func (p GitExtractor) PrepareTaskData(taskCtx plugin.TaskContext, options map[st
 	loadBool(&op.UseGoGit, "UseGoGit", false)
 	loadBool(&op.SkipCommitStat, "SKIP_COMMIT_STAT", false)
 	loadBool(&op.SkipCommitFiles, "SKIP_COMMIT_FILES", true)
+	loadBool(&op.CollectSubmodules, "COLLECT_SUBMODULES", false)
+	loadBool(&op.VerifySignatures, "VERIFY_SIGNATURES", false)
+	loadBool(&op.DetectRenames, "DETECT_RENAMES", false)
+	loadBool(&op.DetectCopies, "DETECT_COPIES", false)
 	log.Info("UseGoGit: %v", *op.UseGoGit)
 	log.Info("SkipCommitStat: %v", *op.SkipCommitStat)
 	log.Info("SkipCommitFiles: %v", *op.SkipCommitFiles)
+	log.Info("CollectSubmodules: %v", *op.CollectSubmodules)
+	log.Info("VerifySignatures: %v", *op.VerifySignatures)
+	log.Info("DetectRenames: %v", *op.DetectRenames)
+	log.Info("DetectCopies: %v", *op.DetectCopies)
 
 	taskData := &parser.GitExtractorTaskData{
-		Options:   &op,
-		ParsedURL: parsedURL,
+		Options:    &op,
+		ParsedURL:  parsedURL,
+		Credential: credential,
+	}
+	if *op.VerifySignatures {
+		verifier, err := parser.NewSignatureVerifier(op.SigningKeys)
+		if err != nil {
+			return nil, err
+		}
+		taskData.Verifier = verifier
+	}
+	for name, value := range map[string]int{"renameThreshold": op.RenameThreshold, "copyThreshold": op.CopyThreshold} {
+		if value < 0 || value > 100 {
+			return nil, errors.BadInput.New(fmt.Sprintf("%s must be between 0 and 100", name))
+		}
+	}
+	var filterErr errors.Error
+	taskData.PathFilter, filterErr = utils.NewPathFilter(op.IncludePaths, op.ExcludePaths)
+	if filterErr != nil {
+		return nil, filterErr
 	}
 	return taskData, nil
 }
@@ -146,6 +206,10 @@ func (p GitExtractor) RootPkgPath() string {
 	return "github.com/apache/incubator-devlake/plugins/gitextractor"
 }
 
+func (p GitExtractor) MigrationScripts() []plugin.MigrationScript {
+	return migrationscripts.All()
+}
+
 func (p GitExtractor) TestConnection(id uint64) errors.Error {
 	return nil
 }
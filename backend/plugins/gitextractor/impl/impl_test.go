@@ -0,0 +1,61 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package impl
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockplugin "github.com/apache/incubator-devlake/mocks/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/gitextractor/parser"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrepareTaskDataThresholds(t *testing.T) {
+	tests := []struct {
+		name    string
+		options map[string]interface{}
+		valid   bool
+	}{
+		{"default", map[string]interface{}{}, true},
+		{"bounds", map[string]interface{}{"renameThreshold": 0, "copyThreshold": 100}, true},
+		{"rename below 0", map[string]interface{}{"renameThreshold": -1}, false},
+		{"rename above 100", map[string]interface{}{"renameThreshold": 101}, false},
+		{"copy below 0", map[string]interface{}{"copyThreshold": -10}, false},
+		{"copy above 100", map[string]interface{}{"copyThreshold": 150}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtx := new(mockplugin.TaskContext)
+			mockCtx.On("GetLogger").Return(unithelper.DummyLogger())
+			mockCtx.On("GetConfigReader").Return(viper.New())
+			tt.options["url"] = "https://github.com/apache/incubator-devlake.git"
+			tt.options["repoId"] = "github:GithubRepo:1:384111310"
+
+			taskData, err := GitExtractor{}.PrepareTaskData(mockCtx, tt.options)
+			if tt.valid {
+				assert.Nil(t, err)
+				assert.IsType(t, &parser.GitExtractorTaskData{}, taskData)
+			} else if assert.NotNil(t, err) {
+				assert.Equal(t, errors.BadInput, err.GetType())
+			}
+		})
+	}
+}
@@ -31,6 +31,8 @@ type Store interface {
 	CommitParents(pp []*code.CommitParent) errors.Error
 	CommitFileComponents(commitFileComponent *code.CommitFileComponent) errors.Error
 	CommitLineChange(commitLineChange *code.CommitLineChange) errors.Error
+	CommitSubmoduleChanges(changes []*code.CommitSubmoduleChange) errors.Error
 	RepoSnapshot(snapshot *code.RepoSnapshot) errors.Error
+	DirectoryOwnerships(ownerships []*code.DirectoryOwnership) errors.Error
 	Close() errors.Error
 }
@@ -0,0 +1,46 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+	"github.com/apache/incubator-devlake/plugins/gitextractor/models/migrationscripts/archived"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addRepoStates)(nil)
+
+type addRepoStates struct{}
+
+func (*addRepoStates) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, &archived.GitRepoState{})
+}
+
+func (*addRepoStates) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropTables(&archived.GitRepoState{})
+}
+
+func (*addRepoStates) Version() uint64 {
+	return 20261014120000
+}
+
+func (*addRepoStates) Name() string {
+	return "add _tool_gitextractor_repo_states table"
+}
@@ -39,10 +39,18 @@ var ErrNoData = errors.NotModified.New("No data to be collected")
 // CloneRepoConfig is the configuration for the CloneRepo method
 // the subtask should run in Full Sync mode whenever the configuration is changed
 type CloneRepoConfig struct {
-	UseGoGit        *bool
-	SkipCommitStat  *bool
-	SkipCommitFiles *bool
-	NoShallowClone  bool
+	UseGoGit          *bool
+	SkipCommitStat    *bool
+	SkipCommitFiles   *bool
+	NoShallowClone    bool
+	CollectSubmodules *bool
+	IncludePaths      []string
+	ExcludePaths      []string
+	DetectRenames     *bool
+	DetectCopies      *bool
+	RenameThreshold   int
+	CopyThreshold     int
+	RenameLimit       int
 }
 
 type GitcliCloner struct {
@@ -64,10 +72,18 @@ func NewGitcliCloner(ctx plugin.SubTaskContext, localDir string) (*GitcliCloner,
 		SubTaskContext: ctx,
 		Params:         taskData.Options.GitExtractorApiParams,
 		SubtaskConfig: CloneRepoConfig{
-			UseGoGit:        taskData.Options.UseGoGit,
-			SkipCommitStat:  taskData.Options.SkipCommitStat,
-			SkipCommitFiles: taskData.Options.SkipCommitFiles,
-			NoShallowClone:  taskData.Options.NoShallowClone,
+			UseGoGit:          taskData.Options.UseGoGit,
+			SkipCommitStat:    taskData.Options.SkipCommitStat,
+			SkipCommitFiles:   taskData.Options.SkipCommitFiles,
+			NoShallowClone:    taskData.Options.NoShallowClone,
+			CollectSubmodules: taskData.Options.CollectSubmodules,
+			IncludePaths:      taskData.Options.IncludePaths,
+			ExcludePaths:      taskData.Options.ExcludePaths,
+			DetectRenames:     taskData.Options.DetectRenames,
+			DetectCopies:      taskData.Options.DetectCopies,
+			RenameThreshold:   taskData.Options.RenameThreshold,
+			CopyThreshold:     taskData.Options.CopyThreshold,
+			RenameLimit:       taskData.Options.RenameLimit,
 		},
 	}))
 
@@ -101,6 +117,7 @@ func (g *GitcliCloner) prepareSync() errors.Error {
 		if remoteUrl.Scheme == "https" && g.ctx.GetConfigReader().GetBool("IN_SECURE_SKIP_VERIFY") {
 			g.syncEnvs = append(g.syncEnvs, "GIT_SSL_NO_VERIFY=true")
 		}
+		g.syncEnvs = append(g.syncEnvs, taskData.Credential.Envs()...)
 	} else if remoteUrl.Scheme == "ssh" {
 		var sshCmdArgs []string
 		if taskData.Options.Proxy != "" {
@@ -303,7 +320,7 @@ func (g *GitcliCloner) gitCmd(gitcmd string, args ...string) errors.Error {
 }
 
 func (g *GitcliCloner) git(env []string, dir string, gitcmd string, args ...string) errors.Error {
-	g.logger.Debug("git %s %v", gitcmd, args)
+	g.logger.Debug("git %s %v", gitcmd, g.scrub(strings.Join(sanitizeArgs(args), " ")))
 	args = append([]string{gitcmd}, args...)
 	cmd := exec.CommandContext(g.ctx.GetContext(), "git", args...)
 	cmd.Env = env
@@ -314,16 +331,21 @@ func (g *GitcliCloner) git(env []string, dir string, gitcmd string, args ...stri
 func (g *GitcliCloner) execCommand(cmd *exec.Cmd) errors.Error {
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		g.logger.Debug("err: %v, output: %s", err, string(output))
-		outputString := string(output)
+		outputString := g.scrub(string(output))
+		g.logger.Debug("err: %v, output: %s", err, outputString)
 		if strings.Contains(outputString, "fatal: error processing shallow info: 4") ||
 			strings.Contains(outputString, "fatal: the remote end hung up unexpectedly") {
 			return ErrNoData
 		}
-		return errors.Default.New(fmt.Sprintf("git cmd %v in %s failed: %s", sanitizeArgs(cmd.Args), cmd.Dir, generateErrMsg(output, err)))
+		return errors.Default.New(g.scrub(fmt.Sprintf("git cmd %v in %s failed: %s", sanitizeArgs(cmd.Args), cmd.Dir, generateErrMsg(output, err))))
 	}
 	return nil
 }
+
+// scrub masks the injected credential in the text to be logged or returned
+func (g *GitcliCloner) scrub(text string) string {
+	return g.taskData.Credential.Scrub(text)
+}
 func generateErrMsg(output []byte, err error) string {
 	errMsg := strings.TrimSpace(string(output))
 	if errMsg == "" {
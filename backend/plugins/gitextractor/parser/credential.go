@@ -0,0 +1,82 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+
+	giturls "github.com/chainguard-dev/git-urls"
+)
+
+// GitCredential is injected into the git commands as a http header at clone time, so
+// the secret never shows up in the clone url, the command arguments or the local repo config
+type GitCredential struct {
+	Username string
+	Password string
+}
+
+// ExtractGitCredential strips the userinfo from the given git url, the credential found in the url is returned
+// alongside the clean url. Urls other than http(s) are returned untouched since their userinfo is not a secret
+func ExtractGitCredential(gitUrl string) (string, *GitCredential, error) {
+	u, err := giturls.Parse(gitUrl)
+	if err != nil {
+		return "", nil, err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.User == nil {
+		return gitUrl, nil, nil
+	}
+	password, ok := u.User.Password()
+	if !ok {
+		return gitUrl, nil, nil
+	}
+	credential := &GitCredential{Username: u.User.Username(), Password: password}
+	u.User = nil
+	return u.String(), credential, nil
+}
+
+// Envs returns the environment variables to make git send the credential along with every http request
+func (c *GitCredential) Envs() []string {
+	if c == nil || c.Password == "" {
+		return nil
+	}
+	username := c.Username
+	if username == "" {
+		username = "git"
+	}
+	auth := base64.StdEncoding.EncodeToString([]byte(username + ":" + c.Password))
+	return []string{
+		"GIT_CONFIG_COUNT=1",
+		"GIT_CONFIG_KEY_0=http.extraHeader",
+		fmt.Sprintf("GIT_CONFIG_VALUE_0=Authorization: Basic %s", auth),
+		// never fall back to prompting for a username/password
+		"GIT_TERMINAL_PROMPT=0",
+	}
+}
+
+// Scrub masks the secret in the given text, including the url-escaped form of it
+func (c *GitCredential) Scrub(text string) string {
+	if c == nil || c.Password == "" {
+		return text
+	}
+	mask := strings.Repeat("*", len(c.Password))
+	text = strings.ReplaceAll(text, c.Password, mask)
+	return strings.ReplaceAll(text, url.QueryEscape(c.Password), mask)
+}
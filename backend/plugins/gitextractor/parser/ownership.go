@@ -0,0 +1,107 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"path"
+	"sort"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+)
+
+// ownershipTopN is the number of contributors kept for each directory
+const ownershipTopN = 5
+
+type ownershipCounter struct {
+	authorName    string
+	lines         int
+	lastTouchedAt time.Time
+}
+
+// ownershipAccumulator sums up the surviving lines of each author into the directory of a file as well as all its ancestors
+type ownershipAccumulator struct {
+	dirs map[string]map[string]*ownershipCounter
+}
+
+func newOwnershipAccumulator() *ownershipAccumulator {
+	return &ownershipAccumulator{dirs: make(map[string]map[string]*ownershipCounter)}
+}
+
+func (a *ownershipAccumulator) add(filePath, authorEmail, authorName string, lines int, touchedAt time.Time) {
+	dir := path.Dir(filePath)
+	for {
+		authors, ok := a.dirs[dir]
+		if !ok {
+			authors = make(map[string]*ownershipCounter)
+			a.dirs[dir] = authors
+		}
+		counter, ok := authors[authorEmail]
+		if !ok {
+			counter = &ownershipCounter{authorName: authorName}
+			authors[authorEmail] = counter
+		}
+		counter.lines += lines
+		if touchedAt.After(counter.lastTouchedAt) {
+			counter.lastTouchedAt = touchedAt
+		}
+		if dir == "." {
+			return
+		}
+		dir = path.Dir(dir)
+	}
+}
+
+// ownerships ranks the authors of each directory by lines and keeps the top ones
+func (a *ownershipAccumulator) ownerships(repoId, commitSha string) []*code.DirectoryOwnership {
+	var result []*code.DirectoryOwnership
+	for dir, authors := range a.dirs {
+		totalLines := 0
+		emails := make([]string, 0, len(authors))
+		for email, counter := range authors {
+			totalLines += counter.lines
+			emails = append(emails, email)
+		}
+		sort.Slice(emails, func(i, j int) bool {
+			li, lj := authors[emails[i]].lines, authors[emails[j]].lines
+			if li != lj {
+				return li > lj
+			}
+			return emails[i] < emails[j]
+		})
+		if len(emails) > ownershipTopN {
+			emails = emails[:ownershipTopN]
+		}
+		for i, email := range emails {
+			counter := authors[email]
+			lastTouchedAt := counter.lastTouchedAt
+			result = append(result, &code.DirectoryOwnership{
+				RepoId:        repoId,
+				Directory:     dir,
+				AuthorEmail:   email,
+				AuthorName:    counter.authorName,
+				Rank:          i + 1,
+				Lines:         counter.lines,
+				TotalLines:    totalLines,
+				LastTouchedAt: &lastTouchedAt,
+				CommitSha:     commitSha,
+			})
+		}
+	}
+	return result
+}
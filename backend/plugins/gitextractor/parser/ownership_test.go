@@ -0,0 +1,106 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/stretchr/testify/assert"
+)
+
+type blamedLines struct {
+	filePath    string
+	authorEmail string
+	lines       int
+	touchedAt   time.Time
+}
+
+func Test_ownershipAccumulator(t *testing.T) {
+	day1 := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	day2 := time.Date(2025, 2, 2, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		blamed []blamedLines
+		// directory/rank -> email, lines, total lines, last touched
+		want map[string]code.DirectoryOwnership
+	}{
+		{
+			name: "lines roll up into every ancestor directory",
+			blamed: []blamedLines{
+				{"backend/core/a.go", "alice@example.com", 10, day1},
+				{"backend/b.go", "bob@example.com", 4, day2},
+			},
+			want: map[string]code.DirectoryOwnership{
+				"backend/core/1": {AuthorEmail: "alice@example.com", Lines: 10, TotalLines: 10, LastTouchedAt: &day1},
+				"backend/1":      {AuthorEmail: "alice@example.com", Lines: 10, TotalLines: 14, LastTouchedAt: &day1},
+				"backend/2":      {AuthorEmail: "bob@example.com", Lines: 4, TotalLines: 14, LastTouchedAt: &day2},
+				"./1":            {AuthorEmail: "alice@example.com", Lines: 10, TotalLines: 14, LastTouchedAt: &day1},
+				"./2":            {AuthorEmail: "bob@example.com", Lines: 4, TotalLines: 14, LastTouchedAt: &day2},
+			},
+		},
+		{
+			name: "the latest touch of an author wins and ties rank by email",
+			blamed: []blamedLines{
+				{"a.go", "bob@example.com", 3, day2},
+				{"b.go", "bob@example.com", 2, day1},
+				{"c.go", "alice@example.com", 5, day1},
+			},
+			want: map[string]code.DirectoryOwnership{
+				"./1": {AuthorEmail: "alice@example.com", Lines: 5, TotalLines: 10, LastTouchedAt: &day1},
+				"./2": {AuthorEmail: "bob@example.com", Lines: 5, TotalLines: 10, LastTouchedAt: &day2},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accumulator := newOwnershipAccumulator()
+			for _, b := range tt.blamed {
+				accumulator.add(b.filePath, b.authorEmail, b.authorEmail, b.lines, b.touchedAt)
+			}
+			got := make(map[string]code.DirectoryOwnership)
+			for _, o := range accumulator.ownerships("repo1", "sha1") {
+				assert.Equal(t, "repo1", o.RepoId)
+				assert.Equal(t, "sha1", o.CommitSha)
+				got[fmt.Sprintf("%s/%d", o.Directory, o.Rank)] = code.DirectoryOwnership{
+					AuthorEmail:   o.AuthorEmail,
+					Lines:         o.Lines,
+					TotalLines:    o.TotalLines,
+					LastTouchedAt: o.LastTouchedAt,
+				}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_ownershipAccumulatorKeepsTopContributors(t *testing.T) {
+	accumulator := newOwnershipAccumulator()
+	for i := 1; i <= ownershipTopN+2; i++ {
+		accumulator.add("a.go", fmt.Sprintf("author%d@example.com", i), "", i, time.Time{})
+	}
+	ownerships := accumulator.ownerships("repo1", "sha1")
+	assert.Len(t, ownerships, ownershipTopN)
+	sort.Slice(ownerships, func(i, j int) bool { return ownerships[i].Rank < ownerships[j].Rank })
+	assert.Equal(t, "author7@example.com", ownerships[0].AuthorEmail)
+	assert.Equal(t, 7, ownerships[0].Lines)
+	assert.Equal(t, 28, ownerships[0].TotalLines)
+}
@@ -19,8 +19,12 @@ package parser
 
 import (
 	"context"
+	"strings"
+	"time"
 
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
 	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/core/utils"
 )
 
 const (
@@ -42,4 +46,78 @@ type RepoCollector interface {
 	CollectBranches(subtaskCtx plugin.SubTaskContext) error
 	CollectCommits(subtaskCtx plugin.SubTaskContext) error
 	CollectDiffLine(subtaskCtx plugin.SubTaskContext) error
+	CollectOwnership(subtaskCtx plugin.SubTaskContext) error
+	ListHeads(ctx context.Context) ([]string, error)
+	ListBranches(ctx context.Context) ([]*BranchHead, error)
+}
+
+// BranchHead is the current state of a branch, ForkCommitSha is the merge base with the default branch
+type BranchHead struct {
+	Name           string
+	CommitSha      string
+	CommitDate     time.Time
+	IsDefault      bool
+	ForkCommitSha  string
+	ForkCommitDate *time.Time
+}
+
+// newSubmoduleChange builds the submodule pointer change out of both sides of a diff entry,
+// it returns nil when neither side of the entry is a submodule
+func newSubmoduleChange(commitSha, oldPath, newPath, oldSha, newSha string, oldIsSubmodule, newIsSubmodule bool) *code.CommitSubmoduleChange {
+	change := &code.CommitSubmoduleChange{
+		CommitSha: commitSha,
+	}
+	switch {
+	case oldIsSubmodule && newIsSubmodule:
+		if oldSha == newSha {
+			return nil
+		}
+		change.Path = newPath
+		change.ChangeType = code.SUBMODULE_UPDATED
+		change.OldCommitSha = oldSha
+		change.NewCommitSha = newSha
+	case newIsSubmodule:
+		change.Path = newPath
+		change.ChangeType = code.SUBMODULE_ADDED
+		change.NewCommitSha = newSha
+	case oldIsSubmodule:
+		change.Path = oldPath
+		change.ChangeType = code.SUBMODULE_REMOVED
+		change.OldCommitSha = oldSha
+	default:
+		return nil
+	}
+	return change
+}
+
+// fileExcluder tells whether a file should be left out of the commit stats,
+// either by its extension or by the include/exclude path patterns
+type fileExcluder struct {
+	extensions []string
+	pathFilter *utils.PathFilter
+}
+
+func newFileExcluder(taskData *GitExtractorTaskData) *fileExcluder {
+	e := &fileExcluder{pathFilter: taskData.PathFilter}
+	for _, ext := range taskData.Options.ExcludeFileExtensions {
+		ext = strings.ToLower(strings.TrimSpace(ext))
+		if ext != "" {
+			e.extensions = append(e.extensions, ext)
+		}
+	}
+	return e
+}
+
+func (e *fileExcluder) isEmpty() bool {
+	return len(e.extensions) == 0 && e.pathFilter == nil
+}
+
+func (e *fileExcluder) excluded(path string) bool {
+	lower := strings.ToLower(path)
+	for _, ext := range e.extensions {
+		if strings.HasSuffix(lower, ext) {
+			return true
+		}
+	}
+	return !e.pathFilter.Match(path)
 }
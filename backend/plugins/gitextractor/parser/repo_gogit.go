@@ -22,8 +22,8 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"io"
 	"regexp"
-	"strings"
 
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
@@ -34,6 +34,7 @@ import (
 	"github.com/apache/incubator-devlake/plugins/gitextractor/models"
 	gogit "github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-git/go-git/v5/plumbing/storer"
 )
@@ -198,6 +199,20 @@ func (r *GogitRepoCollector) CollectTags(subtaskCtx plugin.SubTaskContext) error
 				CommitSha:            tagCommit,
 				RefType:              TAG,
 			}
+			if taskData := subtaskCtx.GetData().(*GitExtractorTaskData); *taskData.Options.VerifySignatures {
+				// lightweight tags are not objects and thus can not be signed
+				if tag, e := r.repo.TagObject(ref.Hash()); e == nil && tag.PGPSignature != "" {
+					encoded := &plumbing.MemoryObject{}
+					if err := tag.EncodeWithoutSignature(encoded); err != nil {
+						return err
+					}
+					payload, err := readEncodedObject(encoded)
+					if err != nil {
+						return err
+					}
+					codeRef.SignatureType, codeRef.Verified = taskData.Verifier.Verify(tag.PGPSignature, payload)
+				}
+			}
 			err = r.store.Refs(codeRef)
 			if err != nil {
 				return err
@@ -261,6 +276,66 @@ func (r *GogitRepoCollector) CollectBranches(subtaskCtx plugin.SubTaskContext) e
 	return nil
 }
 
+// ListBranches returns the head of each branch along with the commit it forked from the default branch
+func (r *GogitRepoCollector) ListBranches(ctx context.Context) ([]*BranchHead, error) {
+	refIter, err := r.repo.Storer.IterReferences()
+	if err != nil {
+		return nil, err
+	}
+	branchIter := storer.NewReferenceFilteredIter(
+		func(r *plumbing.Reference) bool {
+			return r.Name().IsBranch() || r.Name().IsRemote()
+		}, refIter)
+	headRef, err := r.repo.Head()
+	if err != nil {
+		return nil, err
+	}
+	var branches []*BranchHead
+	commits := make(map[string]*object.Commit)
+	var defaultCommit *object.Commit
+	err = branchIter.ForEach(func(ref *plumbing.Reference) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		commit, err := r.repo.CommitObject(ref.Hash())
+		if err != nil {
+			// the branch does not point to a commit
+			return nil
+		}
+		head := &BranchHead{
+			Name:       ref.Name().Short(),
+			CommitSha:  commit.Hash.String(),
+			CommitDate: commit.Committer.When,
+			IsDefault:  ref.Name() == headRef.Name(),
+		}
+		if head.IsDefault {
+			defaultCommit = commit
+		}
+		commits[head.Name] = commit
+		branches = append(branches, head)
+		return nil
+	})
+	if err != nil || defaultCommit == nil {
+		return branches, err
+	}
+	for _, branch := range branches {
+		if branch.IsDefault {
+			continue
+		}
+		bases, err := commits[branch.Name].MergeBase(defaultCommit)
+		// the history of the branch can't be walked, or is unrelated to the default branch
+		if err != nil || len(bases) == 0 {
+			continue
+		}
+		forkCommitDate := bases[0].Committer.When
+		branch.ForkCommitSha = bases[0].Hash.String()
+		branch.ForkCommitDate = &forkCommitDate
+	}
+	return branches, nil
+}
+
 func (r *GogitRepoCollector) getComponentMap(subtaskCtx plugin.SubTaskContext) (map[string]*regexp.Regexp, error) {
 	db := subtaskCtx.GetDal()
 	components := make([]code.Component, 0)
@@ -278,26 +353,19 @@ func (r *GogitRepoCollector) getComponentMap(subtaskCtx plugin.SubTaskContext) (
 // CollectCommits Collect data from each commit, we can also get the diff line
 func (r *GogitRepoCollector) CollectCommits(subtaskCtx plugin.SubTaskContext) (err error) {
 	taskOpts := subtaskCtx.GetData().(*GitExtractorTaskData).Options
+	verifier := subtaskCtx.GetData().(*GitExtractorTaskData).Verifier
+	excluder := newFileExcluder(subtaskCtx.GetData().(*GitExtractorTaskData))
+	diffTreeOpts := newDiffTreeOptions(taskOpts)
 	// check it first
 	componentMap, err := r.getComponentMap(subtaskCtx)
 	if err != nil {
 		return err
 	}
 
-	repo := r.repo
 	store := r.store
 
-	commitsObjectsIter, err := repo.CommitObjects()
-	if err != nil {
-		return err
-	}
-
-	if err := commitsObjectsIter.ForEach(func(commit *object.Commit) error {
-		select {
-		case <-subtaskCtx.GetContext().Done():
-			return subtaskCtx.GetContext().Err()
-		default:
-		}
+	knownHeads := subtaskCtx.GetData().(*GitExtractorTaskData).KnownHeads
+	if err := r.forEachCommit(subtaskCtx.GetContext(), knownHeads, func(commit *object.Commit) error {
 		commitSha := commit.Hash.String()
 
 		if commit.NumParents() != 0 {
@@ -325,33 +393,33 @@ func (r *GogitRepoCollector) CollectCommits(subtaskCtx plugin.SubTaskContext) (e
 			CommitterId:    commit.Committer.Email,
 			CommittedDate:  commit.Committer.When,
 		}
+		if *taskOpts.VerifySignatures && commit.PGPSignature != "" {
+			encoded := &plumbing.MemoryObject{}
+			if err = commit.EncodeWithoutSignature(encoded); err != nil {
+				return err
+			}
+			payload, err := readEncodedObject(encoded)
+			if err != nil {
+				return err
+			}
+			codeCommit.SignatureType, codeCommit.Verified = verifier.Verify(commit.PGPSignature, payload)
+		}
 		if err = r.storeParentCommits(commitSha, commit); err != nil {
 			return err
 		}
+		if *taskOpts.CollectSubmodules {
+			if err = r.storeSubmoduleChanges(subtaskCtx.GetContext(), commitSha, commit); err != nil {
+				return err
+			}
+		}
 
 		if !*taskOpts.SkipCommitStat {
-			stats, err := commit.StatsContext(subtaskCtx.GetContext())
+			stats, err := commitStats(subtaskCtx.GetContext(), commit, diffTreeOpts)
 			if err != nil {
 				return err
 			} else {
-				excluded := map[string]struct{}{}
-				for _, ext := range taskOpts.ExcludeFileExtensions {
-					e := strings.ToLower(strings.TrimSpace(ext))
-					if e == "" {
-						continue
-					}
-					excluded[e] = struct{}{}
-				}
 				for _, stat := range stats {
-					nameLower := strings.ToLower(stat.Name)
-					skip := false
-					for ext := range excluded {
-						if strings.HasSuffix(nameLower, ext) {
-							skip = true
-							break
-						}
-					}
-					if skip {
+					if excluder.excluded(stat.Name) {
 						continue
 					}
 					codeCommit.Additions += stat.Addition
@@ -380,7 +448,7 @@ func (r *GogitRepoCollector) CollectCommits(subtaskCtx plugin.SubTaskContext) (e
 			return err
 		}
 		if !*taskOpts.SkipCommitFiles {
-			if err := r.storeDiffCommitFilesComparedToParent(subtaskCtx, componentMap, commit, taskOpts.ExcludeFileExtensions); err != nil {
+			if err := r.storeDiffCommitFilesComparedToParent(subtaskCtx, componentMap, commit, excluder); err != nil {
 				return err
 			}
 		}
@@ -392,6 +460,96 @@ func (r *GogitRepoCollector) CollectCommits(subtaskCtx plugin.SubTaskContext) (e
 	return
 }
 
+// forEachCommit iterates all commits in the repo, or only the ones which are not reachable from the
+// knownHeads (heads extracted by the previous run) when they are provided
+func (r *GogitRepoCollector) forEachCommit(ctx context.Context, knownHeads []string, cb func(commit *object.Commit) error) error {
+	checkCtx := func() error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			return nil
+		}
+	}
+	ignore := make([]plumbing.Hash, 0, len(knownHeads))
+	for _, sha := range knownHeads {
+		hash := plumbing.NewHash(sha)
+		// the head might have been force-pushed away, or be older than the shallow boundary of the local clone
+		if _, err := r.repo.CommitObject(hash); err != nil {
+			continue
+		}
+		ignore = append(ignore, hash)
+	}
+	if len(ignore) == 0 {
+		if len(knownHeads) > 0 {
+			r.logger.Info("none of the %d known heads is in the repo, walking all commits", len(knownHeads))
+		}
+		commitsObjectsIter, err := r.repo.CommitObjects()
+		if err != nil {
+			return err
+		}
+		return commitsObjectsIter.ForEach(func(commit *object.Commit) error {
+			if err := checkCtx(); err != nil {
+				return err
+			}
+			return cb(commit)
+		})
+	}
+	heads, err := r.ListHeads(ctx)
+	if err != nil {
+		return err
+	}
+	// shared across heads so commits reachable from multiple branches are processed only once
+	seen := make(map[plumbing.Hash]bool)
+	for _, head := range heads {
+		headCommit, err := r.repo.CommitObject(plumbing.NewHash(head))
+		if err != nil {
+			return err
+		}
+		err = object.NewCommitPreorderIter(headCommit, seen, ignore).ForEach(func(commit *object.Commit) error {
+			if err := checkCtx(); err != nil {
+				return err
+			}
+			seen[commit.Hash] = true
+			return cb(commit)
+		})
+		// the parents beyond the shallow boundary are missing
+		if err != nil && !errors.Is(err, plumbing.ErrObjectNotFound) {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListHeads returns the commit shas that all branches and tags point to
+func (r *GogitRepoCollector) ListHeads(ctx context.Context) ([]string, error) {
+	refs, err := r.repo.References()
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	var heads []string
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() != plumbing.HashReference {
+			return nil
+		}
+		hash, err := r.repo.ResolveRevision(plumbing.Revision(ref.Name()))
+		if err != nil {
+			// the reference does not point to a committish
+			return nil
+		}
+		if _, err := r.repo.CommitObject(*hash); err != nil {
+			return nil
+		}
+		if sha := hash.String(); !seen[sha] {
+			seen[sha] = true
+			heads = append(heads, sha)
+		}
+		return nil
+	})
+	return heads, err
+}
+
 func (r *GogitRepoCollector) storeParentCommits(commitSha string, commit *object.Commit) error {
 	if commit == nil {
 		return nil
@@ -418,6 +576,86 @@ func (r *GogitRepoCollector) storeParentCommits(commitSha string, commit *object
 	return r.store.CommitParents(commitParents)
 }
 
+// storeSubmoduleChanges records the submodule pointers moved by the commit compared to its first parent
+func (r *GogitRepoCollector) storeSubmoduleChanges(ctx context.Context, commitSha string, commit *object.Commit) error {
+	commitTree, err := commit.Tree()
+	if err != nil {
+		return err
+	}
+	var firstParentTree *object.Tree
+	if commit.NumParents() > 0 {
+		firstParent, err := commit.Parents().Next()
+		if err != nil {
+			return err
+		}
+		firstParentTree, err = firstParent.Tree()
+		if err != nil {
+			return err
+		}
+	}
+	diffs, err := object.DiffTreeContext(ctx, firstParentTree, commitTree)
+	if err != nil {
+		return err
+	}
+	var changes []*code.CommitSubmoduleChange
+	for _, diff := range diffs {
+		oldIsSubmodule := diff.From.TreeEntry.Mode == filemode.Submodule
+		newIsSubmodule := diff.To.TreeEntry.Mode == filemode.Submodule
+		var oldSha, newSha string
+		if oldIsSubmodule {
+			oldSha = diff.From.TreeEntry.Hash.String()
+		}
+		if newIsSubmodule {
+			newSha = diff.To.TreeEntry.Hash.String()
+		}
+		change := newSubmoduleChange(commitSha, diff.From.Name, diff.To.Name, oldSha, newSha, oldIsSubmodule, newIsSubmodule)
+		if change != nil {
+			changes = append(changes, change)
+		}
+	}
+	return r.store.CommitSubmoduleChanges(changes)
+}
+
+// newDiffTreeOptions applies the rename thresholds onto the go-git defaults, which always detect renames
+func newDiffTreeOptions(taskOpts *GitExtractorOptions) *object.DiffTreeOptions {
+	opts := *object.DefaultDiffTreeOptions
+	if taskOpts.RenameThreshold > 0 {
+		opts.RenameScore = uint(taskOpts.RenameThreshold)
+	}
+	if taskOpts.RenameLimit > 0 {
+		opts.RenameLimit = uint(taskOpts.RenameLimit)
+	}
+	return &opts
+}
+
+// commitStats works like commit.StatsContext with customized diff tree options
+func commitStats(ctx context.Context, commit *object.Commit, opts *object.DiffTreeOptions) (object.FileStats, error) {
+	commitTree, err := commit.Tree()
+	if err != nil {
+		return nil, err
+	}
+	parentTree := &object.Tree{}
+	if commit.NumParents() != 0 {
+		firstParent, err := commit.Parents().Next()
+		if err != nil {
+			return nil, err
+		}
+		parentTree, err = firstParent.Tree()
+		if err != nil {
+			return nil, err
+		}
+	}
+	changes, err := object.DiffTreeWithOptions(ctx, parentTree, commitTree, opts)
+	if err != nil {
+		return nil, err
+	}
+	patch, err := changes.PatchContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return patch.Stats(), nil
+}
+
 func (r *GogitRepoCollector) getCurrentAndParentTree(ctx context.Context, commit *object.Commit) (*object.Tree, *object.Tree, error) {
 	if _, err := commit.Stats(); err != nil {
 		return nil, nil, err
@@ -440,24 +678,20 @@ func (r *GogitRepoCollector) getCurrentAndParentTree(ctx context.Context, commit
 	return commitTree, firstParentTree, nil
 }
 
-func (r *GogitRepoCollector) storeDiffCommitFilesComparedToParent(subtaskCtx plugin.SubTaskContext, componentMap map[string]*regexp.Regexp, commit *object.Commit, excludeExts []string) (err error) {
+func (r *GogitRepoCollector) storeDiffCommitFilesComparedToParent(subtaskCtx plugin.SubTaskContext, componentMap map[string]*regexp.Regexp, commit *object.Commit, excluder *fileExcluder) (err error) {
 	commitTree, firstParentTree, err := r.getCurrentAndParentTree(subtaskCtx.GetContext(), commit)
 	if err != nil {
 		return err
 	}
 	// no parent, doesn't need to patch
-	patch, err := firstParentTree.PatchContext(subtaskCtx.GetContext(), commitTree)
+	diffTreeOpts := newDiffTreeOptions(subtaskCtx.GetData().(*GitExtractorTaskData).Options)
+	changes, err := object.DiffTreeWithOptions(subtaskCtx.GetContext(), firstParentTree, commitTree, diffTreeOpts)
 	if err != nil {
 		return err
 	}
-	// normalize exclusions
-	excluded := map[string]struct{}{}
-	for _, ext := range excludeExts {
-		e := strings.ToLower(strings.TrimSpace(ext))
-		if e == "" {
-			continue
-		}
-		excluded[e] = struct{}{}
+	patch, err := changes.PatchContext(subtaskCtx.GetContext())
+	if err != nil {
+		return err
 	}
 	for _, p := range patch.Stats() {
 		commitFile := &code.CommitFile{
@@ -465,18 +699,8 @@ func (r *GogitRepoCollector) storeDiffCommitFilesComparedToParent(subtaskCtx plu
 		}
 		fileName := p.Name
 		commitFile.FilePath = fileName
-		if len(excluded) > 0 {
-			lower := strings.ToLower(fileName)
-			skip := false
-			for ext := range excluded {
-				if strings.HasSuffix(lower, ext) {
-					skip = true
-					break
-				}
-			}
-			if skip {
-				continue
-			}
+		if excluder.excluded(fileName) {
+			continue
 		}
 		commitFile.Id = genCommitFileId(commitFile.CommitSha, fileName)
 		commitFile.Deletions = p.Deletion
@@ -493,6 +717,19 @@ func (r *GogitRepoCollector) storeDiffCommitFilesComparedToParent(subtaskCtx plu
 	return nil
 }
 
+func readEncodedObject(obj plumbing.EncodedObject) (string, error) {
+	reader, err := obj.Reader()
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
 // With some long path,the varchar(255) was not enough both ID and file_path
 // So we use the hash to compress the path in ID and add length of file_path.
 // Use commitSha and the sha256 of FilePath to create id
@@ -601,6 +838,54 @@ func (r *GogitRepoCollector) GetCommitList(subtaskCtx plugin.SubTaskContext) ([]
 	return commitList, nil
 }
 
+// CollectOwnership blames every file of the HEAD to find out who owns the surviving lines of each directory
+func (r *GogitRepoCollector) CollectOwnership(subtaskCtx plugin.SubTaskContext) error {
+	head, err := r.repo.Head()
+	if err != nil {
+		return err
+	}
+	commit, err := r.repo.CommitObject(head.Hash())
+	if err != nil {
+		return err
+	}
+	files, err := commit.Files()
+	if err != nil {
+		return err
+	}
+	excluder := newFileExcluder(subtaskCtx.GetData().(*GitExtractorTaskData))
+	accumulator := newOwnershipAccumulator()
+	err = files.ForEach(func(file *object.File) error {
+		select {
+		case <-subtaskCtx.GetContext().Done():
+			return subtaskCtx.GetContext().Err()
+		default:
+		}
+		if excluder.excluded(file.Name) {
+			return nil
+		}
+		isBinary, err := file.IsBinary()
+		if err != nil {
+			return err
+		}
+		if isBinary {
+			return nil
+		}
+		blameResult, err := gogit.Blame(commit, file.Name)
+		if err != nil {
+			return err
+		}
+		for _, line := range blameResult.Lines {
+			accumulator.add(file.Name, line.Author, line.AuthorName, 1, line.Date)
+		}
+		subtaskCtx.IncProgress(1)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return r.store.DirectoryOwnerships(accumulator.ownerships(r.id, commit.Hash.String()))
+}
+
 func (r *GogitRepoCollector) CollectDiffLine(subtaskCtx plugin.SubTaskContext) error {
 	commitList, err := r.GetCommitList(subtaskCtx)
 	if err != nil {
@@ -0,0 +1,226 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	"github.com/apache/incubator-devlake/plugins/gitextractor/models"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func storeCommit(t *testing.T, repo *gogit.Repository, when time.Time, parents ...plumbing.Hash) plumbing.Hash {
+	return storeCommitWithTree(t, repo, &object.Tree{}, when, parents...)
+}
+
+func storeCommitWithTree(t *testing.T, repo *gogit.Repository, tree *object.Tree, when time.Time, parents ...plumbing.Hash) plumbing.Hash {
+	obj := repo.Storer.NewEncodedObject()
+	assert.Nil(t, tree.Encode(obj))
+	treeHash, err := repo.Storer.SetEncodedObject(obj)
+	assert.Nil(t, err)
+	signature := object.Signature{Name: "devlake", Email: "devlake@example.com", When: when}
+	commit := &object.Commit{
+		Author:       signature,
+		Committer:    signature,
+		Message:      when.String(),
+		TreeHash:     treeHash,
+		ParentHashes: parents,
+	}
+	obj = repo.Storer.NewEncodedObject()
+	assert.Nil(t, commit.Encode(obj))
+	hash, err := repo.Storer.SetEncodedObject(obj)
+	assert.Nil(t, err)
+	return hash
+}
+
+func TestGogitListBranches(t *testing.T) {
+	repo, err := gogit.Init(memory.NewStorage(), nil)
+	assert.Nil(t, err)
+	start := time.Date(2025, 2, 20, 10, 0, 0, 0, time.UTC)
+	root := storeCommit(t, repo, start)
+	branches := map[string]plumbing.Hash{
+		"master":  storeCommit(t, repo, start.Add(time.Hour), root),
+		"feature": storeCommit(t, repo, start.Add(2*time.Hour), root),
+		"orphan":  storeCommit(t, repo, start.Add(3*time.Hour)),
+		// the parent of the commit is missing, its history can't be walked
+		"broken": storeCommit(t, repo, start.Add(4*time.Hour), plumbing.NewHash("1111111111111111111111111111111111111111")),
+	}
+	for name, hash := range branches {
+		assert.Nil(t, repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName(name), hash)))
+	}
+
+	collector := &GogitRepoCollector{repo: repo}
+	heads, err := collector.ListBranches(context.Background())
+	assert.Nil(t, err)
+	assert.Len(t, heads, 4)
+	for _, head := range heads {
+		assert.Equal(t, branches[head.Name].String(), head.CommitSha)
+		assert.Equal(t, head.Name == "master", head.IsDefault)
+		if head.Name == "feature" {
+			assert.Equal(t, root.String(), head.ForkCommitSha)
+			assert.Equal(t, start, head.ForkCommitDate.UTC())
+		} else {
+			assert.Empty(t, head.ForkCommitSha)
+			assert.Nil(t, head.ForkCommitDate)
+		}
+	}
+}
+
+type submoduleChangeStore struct {
+	models.Store
+	changes []*code.CommitSubmoduleChange
+}
+
+func (s *submoduleChangeStore) CommitSubmoduleChanges(changes []*code.CommitSubmoduleChange) errors.Error {
+	s.changes = append(s.changes, changes...)
+	return nil
+}
+
+func TestGogitStoreSubmoduleChanges(t *testing.T) {
+	repo, err := gogit.Init(memory.NewStorage(), nil)
+	assert.Nil(t, err)
+	start := time.Date(2025, 2, 20, 10, 0, 0, 0, time.UTC)
+	pointer := func(sha string) *object.Tree {
+		return &object.Tree{Entries: []object.TreeEntry{{Name: "libs", Mode: filemode.Submodule, Hash: plumbing.NewHash(sha)}}}
+	}
+	added := storeCommitWithTree(t, repo, pointer("1111111111111111111111111111111111111111"), start)
+	updated := storeCommitWithTree(t, repo, pointer("2222222222222222222222222222222222222222"), start.Add(time.Hour), added)
+	removed := storeCommit(t, repo, start.Add(2*time.Hour), updated)
+
+	store := &submoduleChangeStore{}
+	collector := &GogitRepoCollector{repo: repo, store: store}
+	for _, hash := range []plumbing.Hash{added, updated, removed} {
+		commit, err := repo.CommitObject(hash)
+		assert.Nil(t, err)
+		assert.Nil(t, collector.storeSubmoduleChanges(context.Background(), hash.String(), commit))
+	}
+	assert.Equal(t, []*code.CommitSubmoduleChange{
+		{CommitSha: added.String(), Path: "libs", ChangeType: code.SUBMODULE_ADDED, NewCommitSha: "1111111111111111111111111111111111111111"},
+		{
+			CommitSha: updated.String(), Path: "libs", ChangeType: code.SUBMODULE_UPDATED,
+			OldCommitSha: "1111111111111111111111111111111111111111", NewCommitSha: "2222222222222222222222222222222222222222",
+		},
+		{CommitSha: removed.String(), Path: "libs", ChangeType: code.SUBMODULE_REMOVED, OldCommitSha: "2222222222222222222222222222222222222222"},
+	}, store.changes)
+}
+
+func TestGogitForEachCommit(t *testing.T) {
+	repo, err := gogit.Init(memory.NewStorage(), nil)
+	assert.Nil(t, err)
+	start := time.Date(2025, 2, 20, 10, 0, 0, 0, time.UTC)
+	root := storeCommit(t, repo, start)
+	extracted := storeCommit(t, repo, start.Add(time.Hour), root)
+	master := storeCommit(t, repo, start.Add(2*time.Hour), extracted)
+	feature := storeCommit(t, repo, start.Add(3*time.Hour), extracted)
+	// the parent is beyond the shallow boundary of the clone
+	boundary := plumbing.NewHash("1111111111111111111111111111111111111111")
+	shallow := storeCommit(t, repo, start.Add(4*time.Hour), boundary)
+	// not reachable from any branch, only a full walk of the repo finds it
+	dangling := storeCommit(t, repo, start.Add(5*time.Hour), root)
+	for name, hash := range map[string]plumbing.Hash{"master": master, "feature": feature, "shallow": shallow} {
+		assert.Nil(t, repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName(name), hash)))
+	}
+	forcePushed := "2222222222222222222222222222222222222222"
+
+	tests := []struct {
+		name       string
+		knownHeads []string
+		walked     []plumbing.Hash
+	}{
+		{"first run", nil, []plumbing.Hash{root, extracted, master, feature, shallow, dangling}},
+		{"new commits only", []string{extracted.String()}, []plumbing.Hash{master, feature, shallow}},
+		{"all extracted", []string{master.String(), feature.String(), shallow.String()}, nil},
+		{"one of the heads force-pushed away", []string{extracted.String(), forcePushed}, []plumbing.Hash{master, feature, shallow}},
+		{"head force-pushed away", []string{forcePushed}, []plumbing.Hash{root, extracted, master, feature, shallow, dangling}},
+		{"head beyond the shallow boundary", []string{boundary.String()}, []plumbing.Hash{root, extracted, master, feature, shallow, dangling}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := &GogitRepoCollector{repo: repo, logger: unithelper.DummyLogger()}
+			var walked []plumbing.Hash
+			err := collector.forEachCommit(context.Background(), tt.knownHeads, func(commit *object.Commit) error {
+				walked = append(walked, commit.Hash)
+				return nil
+			})
+			assert.Nil(t, err)
+			assert.ElementsMatch(t, tt.walked, walked)
+		})
+	}
+}
+
+func storeFile(t *testing.T, repo *gogit.Repository, name string, content string) *object.Tree {
+	obj := repo.Storer.NewEncodedObject()
+	obj.SetType(plumbing.BlobObject)
+	w, err := obj.Writer()
+	assert.Nil(t, err)
+	_, err = w.Write([]byte(content))
+	assert.Nil(t, err)
+	assert.Nil(t, w.Close())
+	hash, err := repo.Storer.SetEncodedObject(obj)
+	assert.Nil(t, err)
+	return &object.Tree{Entries: []object.TreeEntry{{Name: name, Mode: filemode.Regular, Hash: hash}}}
+}
+
+func TestGogitCommitStatsRenameThreshold(t *testing.T) {
+	repo, err := gogit.Init(memory.NewStorage(), nil)
+	assert.Nil(t, err)
+	lines := make([]string, 10)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("line %d", i)
+	}
+	start := time.Date(2025, 2, 20, 10, 0, 0, 0, time.UTC)
+	parent := storeCommitWithTree(t, repo, storeFile(t, repo, "old.go", strings.Join(lines, "\n")+"\n"), start)
+	// the file is moved and 2 of its 10 lines are changed
+	lines[3], lines[7] = "changed 3", "changed 7"
+	renamed := storeCommitWithTree(t, repo, storeFile(t, repo, "new.go", strings.Join(lines, "\n")+"\n"), start.Add(time.Hour), parent)
+	commit, err := repo.CommitObject(renamed)
+	assert.Nil(t, err)
+
+	tests := []struct {
+		name      string
+		threshold int
+		files     []string
+	}{
+		{"default threshold", 0, []string{"old.go => new.go"}},
+		{"low threshold", 50, []string{"old.go => new.go"}},
+		{"high threshold", 90, []string{"old.go", "new.go"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats, err := commitStats(context.Background(), commit, newDiffTreeOptions(&GitExtractorOptions{RenameThreshold: tt.threshold}))
+			assert.Nil(t, err)
+			var files []string
+			for _, stat := range stats {
+				files = append(files, stat.Name)
+			}
+			assert.ElementsMatch(t, tt.files, files)
+		})
+	}
+}
@@ -25,7 +25,6 @@ import (
 	"regexp"
 	"sort"
 	"strconv"
-	"strings"
 
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
@@ -177,8 +176,16 @@ func (r *Libgit2RepoCollector) CollectTags(subtaskCtx plugin.SubTaskContext) err
 		if err1 != nil && err1.Error() != TypeNotMatchError {
 			return errors.Convert(err1)
 		}
+		var signatureType string
+		var verified bool
 		if tag != nil {
 			tagCommit = tag.TargetId().String()
+			if taskData := subtaskCtx.GetData().(*GitExtractorTaskData); *taskData.Options.VerifySignatures {
+				signatureType, verified, err1 = r.verifyTagSignature(taskData.Verifier, id)
+				if err1 != nil {
+					return err1
+				}
+			}
 		} else {
 			tagCommit = id.String()
 		}
@@ -190,6 +197,8 @@ func (r *Libgit2RepoCollector) CollectTags(subtaskCtx plugin.SubTaskContext) err
 				Name:                 name,
 				CommitSha:            tagCommit,
 				RefType:              TAG,
+				SignatureType:        signatureType,
+				Verified:             verified,
 			}
 			err1 = r.store.Refs(ref)
 			if err1 != nil {
@@ -201,6 +210,24 @@ func (r *Libgit2RepoCollector) CollectTags(subtaskCtx plugin.SubTaskContext) err
 	}))
 }
 
+// verifyTagSignature reads the raw annotated tag object since the signature is appended to its message
+func (r *Libgit2RepoCollector) verifyTagSignature(verifier *SignatureVerifier, id *git.Oid) (string, bool, error) {
+	odb, err := r.repo.Odb()
+	if err != nil {
+		return "", false, err
+	}
+	obj, err := odb.Read(id)
+	if err != nil {
+		return "", false, err
+	}
+	payload, signature := splitSignedObject(string(obj.Data()))
+	if signature == "" {
+		return "", false, nil
+	}
+	signatureType, verified := verifier.Verify(signature, payload)
+	return signatureType, verified, nil
+}
+
 // CollectBranches Collect branch data
 func (r *Libgit2RepoCollector) CollectBranches(subtaskCtx plugin.SubTaskContext) error {
 	var repoInter *git.BranchIterator
@@ -249,6 +276,8 @@ func (r *Libgit2RepoCollector) CollectBranches(subtaskCtx plugin.SubTaskContext)
 // CollectCommits Collect data from each commit, we can also get the diff line
 func (r *Libgit2RepoCollector) CollectCommits(subtaskCtx plugin.SubTaskContext) error {
 	taskOpts := subtaskCtx.GetData().(*GitExtractorTaskData).Options
+	verifier := subtaskCtx.GetData().(*GitExtractorTaskData).Verifier
+	excluder := newFileExcluder(subtaskCtx.GetData().(*GitExtractorTaskData))
 	opts, err := getDiffOpts()
 	if err != nil {
 		return err
@@ -263,23 +292,8 @@ func (r *Libgit2RepoCollector) CollectCommits(subtaskCtx plugin.SubTaskContext)
 	for _, component := range components {
 		componentMap[component.Name] = regexp.MustCompile(component.PathRegex)
 	}
-	odb, err := errors.Convert01(r.repo.Odb())
-	if err != nil {
-		return err
-	}
-	return errors.Convert(odb.ForEach(func(id *git.Oid) error {
-		select {
-		case <-subtaskCtx.GetContext().Done():
-			return subtaskCtx.GetContext().Err()
-		default:
-		}
-		commit, err1 := r.repo.LookupCommit(id)
-		if err1 != nil && err1.Error() != TypeNotMatchError {
-			return errors.Convert(err1)
-		}
-		if commit == nil {
-			return nil
-		}
+	knownHeads := subtaskCtx.GetData().(*GitExtractorTaskData).KnownHeads
+	return errors.Convert(r.forEachCommit(subtaskCtx.GetContext(), knownHeads, func(commit *git.Commit) error {
 		var parent *git.Commit
 		if commit.ParentCount() > 0 {
 			parent = commit.Parent(0)
@@ -311,15 +325,27 @@ func (r *Libgit2RepoCollector) CollectCommits(subtaskCtx plugin.SubTaskContext)
 			c.CommitterId = committer.Email
 			c.CommittedDate = committer.When
 		}
+		if *taskOpts.VerifySignatures {
+			// ExtractSignature fails with ErrorCodeNotFound if the commit is not signed
+			if signature, signed, e := commit.ExtractSignature(); e == nil {
+				c.SignatureType, c.Verified = verifier.Verify(signature, signed)
+			}
+		}
 		err = r.storeParentCommits(commitSha, commit)
 		if err != nil {
 			return err
 		}
+		if *taskOpts.CollectSubmodules {
+			err = r.storeSubmoduleChanges(commitSha, commit, parent, opts)
+			if err != nil {
+				return err
+			}
+		}
 
 		if !*taskOpts.SkipCommitStat {
 			var stats *git.DiffStats
 			var addIncluded, delIncluded int
-			if stats, addIncluded, delIncluded, err = r.getDiffComparedToParent(taskOpts, c.Sha, commit, parent, opts, componentMap); err != nil {
+			if stats, addIncluded, delIncluded, err = r.getDiffComparedToParent(taskOpts, excluder, c.Sha, commit, parent, opts, componentMap); err != nil {
 				return err
 			}
 			r.logger.Debug("state: %#+v\n", stats.Deletions())
@@ -344,6 +370,178 @@ func (r *Libgit2RepoCollector) CollectCommits(subtaskCtx plugin.SubTaskContext)
 	}))
 }
 
+// forEachCommit iterates all commits in the ODB, or only the ones which are not reachable from the
+// knownHeads (heads extracted by the previous run) when they are provided
+func (r *Libgit2RepoCollector) forEachCommit(ctx context.Context, knownHeads []string, cb func(commit *git.Commit) error) error {
+	var hidden []*git.Oid
+	for _, sha := range knownHeads {
+		oid, err := git.NewOid(sha)
+		if err != nil {
+			continue
+		}
+		// the head might have been force-pushed away, or be older than the shallow boundary of the local clone
+		if _, err := r.repo.LookupCommit(oid); err != nil {
+			continue
+		}
+		hidden = append(hidden, oid)
+	}
+	if len(hidden) == 0 {
+		if len(knownHeads) > 0 {
+			r.logger.Info("none of the %d known heads is in the repo, walking all commits", len(knownHeads))
+		}
+		odb, err := r.repo.Odb()
+		if err != nil {
+			return err
+		}
+		return odb.ForEach(func(id *git.Oid) error {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+			}
+			commit, err := r.repo.LookupCommit(id)
+			if err != nil && err.Error() != TypeNotMatchError {
+				return errors.Convert(err)
+			}
+			if commit == nil {
+				return nil
+			}
+			return cb(commit)
+		})
+	}
+	walk, err := r.repo.Walk()
+	if err != nil {
+		return err
+	}
+	defer walk.Free()
+	// references which do not point to a committish are ignored by libgit2
+	if err = walk.PushGlob("*"); err != nil {
+		return err
+	}
+	for _, oid := range hidden {
+		if err = walk.Hide(oid); err != nil {
+			return err
+		}
+	}
+	r.logger.Info("walking commits which are not reachable from %d of %d known heads", len(hidden), len(knownHeads))
+	var cbErr error
+	err = walk.Iterate(func(commit *git.Commit) bool {
+		select {
+		case <-ctx.Done():
+			cbErr = ctx.Err()
+			return false
+		default:
+		}
+		cbErr = cb(commit)
+		return cbErr == nil
+	})
+	if cbErr != nil {
+		return cbErr
+	}
+	return err
+}
+
+// ListHeads returns the commit shas that all branches and tags point to
+func (r *Libgit2RepoCollector) ListHeads(ctx context.Context) ([]string, error) {
+	iter, err := r.repo.NewReferenceIterator()
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Free()
+	seen := make(map[string]bool)
+	var heads []string
+	for {
+		ref, err := iter.Next()
+		if err != nil {
+			if git.IsErrorCode(err, git.ErrorCodeIterOver) {
+				break
+			}
+			return nil, err
+		}
+		obj, err := ref.Peel(git.ObjectCommit)
+		if err != nil {
+			// the reference does not point to a committish
+			continue
+		}
+		sha := obj.Id().String()
+		if !seen[sha] {
+			seen[sha] = true
+			heads = append(heads, sha)
+		}
+	}
+	return heads, nil
+}
+
+// ListBranches returns the head of each branch along with the commit it forked from the default branch
+func (r *Libgit2RepoCollector) ListBranches(ctx context.Context) ([]*BranchHead, error) {
+	iter, err := r.repo.NewBranchIterator(git.BranchAll)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Free()
+	var branches []*BranchHead
+	var defaultOid *git.Oid
+	err = iter.ForEach(func(branch *git.Branch, branchType git.BranchType) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		name, err := branch.Name()
+		if err != nil {
+			return err
+		}
+		obj, err := branch.Peel(git.ObjectCommit)
+		if err != nil {
+			// the branch does not point to a committish
+			return nil
+		}
+		commit, err := obj.AsCommit()
+		if err != nil {
+			return err
+		}
+		head := &BranchHead{
+			Name:       name,
+			CommitSha:  commit.Id().String(),
+			CommitDate: commit.Committer().When,
+		}
+		head.IsDefault, err = branch.IsHead()
+		if err != nil && err.Error() != TypeNotMatchError {
+			return err
+		}
+		if head.IsDefault {
+			defaultOid = commit.Id()
+		}
+		branches = append(branches, head)
+		return nil
+	})
+	if err != nil || defaultOid == nil {
+		return branches, err
+	}
+	for _, branch := range branches {
+		if branch.IsDefault {
+			continue
+		}
+		oid, err := git.NewOid(branch.CommitSha)
+		if err != nil {
+			return nil, err
+		}
+		base, err := r.repo.MergeBase(oid, defaultOid)
+		if err != nil {
+			// unrelated histories
+			continue
+		}
+		baseCommit, err := r.repo.LookupCommit(base)
+		if err != nil {
+			return nil, err
+		}
+		forkCommitDate := baseCommit.Committer().When
+		branch.ForkCommitSha = base.String()
+		branch.ForkCommitDate = &forkCommitDate
+	}
+	return branches, nil
+}
+
 func (r *Libgit2RepoCollector) storeParentCommits(commitSha string, commit *git.Commit) errors.Error {
 	var commitParents []*code.CommitParent
 	for i := uint(0); i < commit.ParentCount(); i++ {
@@ -360,7 +558,53 @@ func (r *Libgit2RepoCollector) storeParentCommits(commitSha string, commit *git.
 	return r.store.CommitParents(commitParents)
 }
 
-func (r *Libgit2RepoCollector) getDiffComparedToParent(taskOpts *GitExtractorOptions, commitSha string, commit *git.Commit, parent *git.Commit, opts *git.DiffOptions, componentMap map[string]*regexp.Regexp) (*git.DiffStats, int, int, errors.Error) {
+// storeSubmoduleChanges records the submodule pointers moved by the commit compared to its first parent
+func (r *Libgit2RepoCollector) storeSubmoduleChanges(commitSha string, commit *git.Commit, parent *git.Commit, opts *git.DiffOptions) errors.Error {
+	var err error
+	var parentTree, tree *git.Tree
+	if parent != nil {
+		parentTree, err = parent.Tree()
+		if err != nil {
+			return errors.Convert(err)
+		}
+	}
+	tree, err = commit.Tree()
+	if err != nil {
+		return errors.Convert(err)
+	}
+	diff, err := r.repo.DiffTreeToTree(parentTree, tree, opts)
+	if err != nil {
+		return errors.Convert(err)
+	}
+	defer diff.Free()
+	numDeltas, err := diff.NumDeltas()
+	if err != nil {
+		return errors.Convert(err)
+	}
+	var changes []*code.CommitSubmoduleChange
+	for i := 0; i < numDeltas; i++ {
+		delta, err := diff.Delta(i)
+		if err != nil {
+			return errors.Convert(err)
+		}
+		oldIsSubmodule := delta.OldFile.Mode == uint16(git.FilemodeCommit)
+		newIsSubmodule := delta.NewFile.Mode == uint16(git.FilemodeCommit)
+		var oldSha, newSha string
+		if oldIsSubmodule && delta.OldFile.Oid != nil {
+			oldSha = delta.OldFile.Oid.String()
+		}
+		if newIsSubmodule && delta.NewFile.Oid != nil {
+			newSha = delta.NewFile.Oid.String()
+		}
+		change := newSubmoduleChange(commitSha, delta.OldFile.Path, delta.NewFile.Path, oldSha, newSha, oldIsSubmodule, newIsSubmodule)
+		if change != nil {
+			changes = append(changes, change)
+		}
+	}
+	return r.store.CommitSubmoduleChanges(changes)
+}
+
+func (r *Libgit2RepoCollector) getDiffComparedToParent(taskOpts *GitExtractorOptions, excluder *fileExcluder, commitSha string, commit *git.Commit, parent *git.Commit, opts *git.DiffOptions, componentMap map[string]*regexp.Regexp) (*git.DiffStats, int, int, errors.Error) {
 	var err error
 	var parentTree, tree *git.Tree
 	if parent != nil {
@@ -378,17 +622,13 @@ func (r *Libgit2RepoCollector) getDiffComparedToParent(taskOpts *GitExtractorOpt
 	if err != nil {
 		return nil, 0, 0, errors.Convert(err)
 	}
-	// build excluded extension set
-	excluded := map[string]struct{}{}
-	for _, ext := range taskOpts.ExcludeFileExtensions {
-		e := strings.ToLower(strings.TrimSpace(ext))
-		if e == "" {
-			continue
-		}
-		excluded[e] = struct{}{}
+	defer diff.Free()
+	err = findSimilar(taskOpts, diff)
+	if err != nil {
+		return nil, 0, 0, errors.Convert(err)
 	}
 	if !*taskOpts.SkipCommitFiles {
-		err = r.storeCommitFilesFromDiff(commitSha, diff, componentMap, excluded)
+		err = r.storeCommitFilesFromDiff(commitSha, diff, componentMap, excluder)
 		if err != nil {
 			return nil, 0, 0, errors.Convert(err)
 		}
@@ -401,7 +641,7 @@ func (r *Libgit2RepoCollector) getDiffComparedToParent(taskOpts *GitExtractorOpt
 	// calculate included totals with exclusions
 	addIncluded := 0
 	delIncluded := 0
-	if len(excluded) == 0 {
+	if excluder.isEmpty() {
 		addIncluded = stats.Insertions()
 		delIncluded = stats.Deletions()
 		return stats, addIncluded, delIncluded, nil
@@ -412,14 +652,11 @@ func (r *Libgit2RepoCollector) getDiffComparedToParent(taskOpts *GitExtractorOpt
 		if file.Status == git.DeltaDeleted || pathForCheck == "" {
 			pathForCheck = file.OldFile.Path
 		}
-		lower := strings.ToLower(pathForCheck)
-		for ext := range excluded {
-			if strings.HasSuffix(lower, ext) {
-				// skip all lines for excluded files
-				return func(hunk git.DiffHunk) (git.DiffForEachLineCallback, error) {
-					return func(line git.DiffLine) error { return nil }, nil
-				}, nil
-			}
+		if excluder.excluded(pathForCheck) {
+			// skip all lines for excluded files
+			return func(hunk git.DiffHunk) (git.DiffForEachLineCallback, error) {
+				return func(line git.DiffLine) error { return nil }, nil
+			}, nil
 		}
 		return func(hunk git.DiffHunk) (git.DiffForEachLineCallback, error) {
 			return func(line git.DiffLine) error {
@@ -436,7 +673,7 @@ func (r *Libgit2RepoCollector) getDiffComparedToParent(taskOpts *GitExtractorOpt
 	return stats, addIncluded, delIncluded, nil
 }
 
-func (r *Libgit2RepoCollector) storeCommitFilesFromDiff(commitSha string, diff *git.Diff, componentMap map[string]*regexp.Regexp, excluded map[string]struct{}) errors.Error {
+func (r *Libgit2RepoCollector) storeCommitFilesFromDiff(commitSha string, diff *git.Diff, componentMap map[string]*regexp.Regexp, excluder *fileExcluder) errors.Error {
 	var commitFile *code.CommitFile
 	var commitFileComponent *code.CommitFileComponent
 	var err error
@@ -450,20 +687,17 @@ func (r *Libgit2RepoCollector) storeCommitFilesFromDiff(commitSha string, diff *
 			}
 		}
 
-		// skip files by extension if configured
-		if len(excluded) > 0 {
+		// skip files by extension or path pattern if configured
+		if !excluder.isEmpty() {
 			pathForCheck := file.NewFile.Path
 			if file.Status == git.DeltaDeleted || pathForCheck == "" {
 				pathForCheck = file.OldFile.Path
 			}
-			lower := strings.ToLower(pathForCheck)
-			for ext := range excluded {
-				if strings.HasSuffix(lower, ext) {
-					// skip this file entirely
-					return func(hunk git.DiffHunk) (git.DiffForEachLineCallback, error) {
-						return func(line git.DiffLine) error { return nil }, nil
-					}, nil
-				}
+			if excluder.excluded(pathForCheck) {
+				// skip this file entirely
+				return func(hunk git.DiffHunk) (git.DiffForEachLineCallback, error) {
+					return func(line git.DiffLine) error { return nil }, nil
+				}, nil
 			}
 		}
 
@@ -521,6 +755,74 @@ func (r *Libgit2RepoCollector) storeCommitFilesFromDiff(commitSha string, diff *
 }
 
 // CollectDiffLine get line diff data from a specific branch
+// CollectOwnership blames every file of the HEAD to find out who owns the surviving lines of each directory
+func (r *Libgit2RepoCollector) CollectOwnership(subtaskCtx plugin.SubTaskContext) error {
+	head, err := r.repo.Head()
+	if err != nil {
+		return err
+	}
+	commit, err := r.repo.LookupCommit(head.Target())
+	if err != nil {
+		return err
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return err
+	}
+	opts, err := git.DefaultBlameOptions()
+	if err != nil {
+		return err
+	}
+	opts.NewestCommit = commit.Id()
+	excluder := newFileExcluder(subtaskCtx.GetData().(*GitExtractorTaskData))
+	accumulator := newOwnershipAccumulator()
+	err = tree.Walk(func(dir string, entry *git.TreeEntry) error {
+		select {
+		case <-subtaskCtx.GetContext().Done():
+			return subtaskCtx.GetContext().Err()
+		default:
+		}
+		// submodules and directories are skipped
+		if entry.Type != git.ObjectBlob {
+			return nil
+		}
+		filePath := dir + entry.Name
+		if excluder.excluded(filePath) {
+			return nil
+		}
+		blob, err := r.repo.LookupBlob(entry.Id)
+		if err != nil {
+			return err
+		}
+		isBinary := blob.IsBinary()
+		blob.Free()
+		if isBinary {
+			return nil
+		}
+		blame, err := r.repo.BlameFile(filePath, &opts)
+		if err != nil {
+			return err
+		}
+		defer blame.Free()
+		for i := 0; i < blame.HunkCount(); i++ {
+			hunk, err := blame.HunkByIndex(i)
+			if err != nil {
+				return err
+			}
+			if hunk.FinalSignature == nil {
+				continue
+			}
+			accumulator.add(filePath, hunk.FinalSignature.Email, hunk.FinalSignature.Name, int(hunk.LinesInHunk), hunk.FinalSignature.When)
+		}
+		subtaskCtx.IncProgress(1)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return r.store.DirectoryOwnerships(accumulator.ownerships(r.id, commit.Id().String()))
+}
+
 func (r *Libgit2RepoCollector) CollectDiffLine(subtaskCtx plugin.SubTaskContext) error {
 	//Using this subtask,we can get every line change in every commit.
 	//We maintain a snapshot structure to get which commit each deleted line belongs to
@@ -686,6 +988,34 @@ func updateSnapshotFileBlame(currentCommit *git.Commit, deleted models.DiffLines
 	}
 }
 
+// findSimilar detects renamed and copied files in the diff if enabled, otherwise they are counted as deleted and added
+func findSimilar(taskOpts *GitExtractorOptions, diff *git.Diff) error {
+	if !*taskOpts.DetectRenames && !*taskOpts.DetectCopies {
+		return nil
+	}
+	opts, err := git.DefaultDiffFindOptions()
+	if err != nil {
+		return err
+	}
+	opts.Flags = 0
+	if *taskOpts.DetectRenames {
+		opts.Flags |= git.DiffFindRenames
+	}
+	if *taskOpts.DetectCopies {
+		opts.Flags |= git.DiffFindCopies
+	}
+	if taskOpts.RenameThreshold > 0 {
+		opts.RenameThreshold = uint16(taskOpts.RenameThreshold)
+	}
+	if taskOpts.CopyThreshold > 0 {
+		opts.CopyThreshold = uint16(taskOpts.CopyThreshold)
+	}
+	if taskOpts.RenameLimit > 0 {
+		opts.RenameLimit = uint(taskOpts.RenameLimit)
+	}
+	return diff.FindSimilar(&opts)
+}
+
 func getDiffOpts() (*git.DiffOptions, errors.Error) {
 	opts, err := git.DefaultDiffOptions()
 	if err != nil {
@@ -0,0 +1,68 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_newSubmoduleChange(t *testing.T) {
+	type args struct {
+		oldPath, newPath               string
+		oldSha, newSha                 string
+		oldIsSubmodule, newIsSubmodule bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want *code.CommitSubmoduleChange
+	}{
+		{
+			name: "added",
+			args: args{newPath: "libs/a", newSha: "n1", newIsSubmodule: true},
+			want: &code.CommitSubmoduleChange{CommitSha: "c", Path: "libs/a", ChangeType: code.SUBMODULE_ADDED, NewCommitSha: "n1"},
+		},
+		{
+			name: "updated",
+			args: args{oldPath: "libs/a", newPath: "libs/a", oldSha: "o1", newSha: "n1", oldIsSubmodule: true, newIsSubmodule: true},
+			want: &code.CommitSubmoduleChange{CommitSha: "c", Path: "libs/a", ChangeType: code.SUBMODULE_UPDATED, OldCommitSha: "o1", NewCommitSha: "n1"},
+		},
+		{
+			name: "removed",
+			args: args{oldPath: "libs/a", oldSha: "o1", oldIsSubmodule: true},
+			want: &code.CommitSubmoduleChange{CommitSha: "c", Path: "libs/a", ChangeType: code.SUBMODULE_REMOVED, OldCommitSha: "o1"},
+		},
+		{
+			name: "pointer unchanged",
+			args: args{oldPath: "libs/a", newPath: "libs/a", oldSha: "o1", newSha: "o1", oldIsSubmodule: true, newIsSubmodule: true},
+		},
+		{
+			name: "plain file",
+			args: args{oldPath: "main.go", newPath: "main.go", oldSha: "o1", newSha: "n1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newSubmoduleChange("c", tt.args.oldPath, tt.args.newPath, tt.args.oldSha, tt.args.newSha, tt.args.oldIsSubmodule, tt.args.newIsSubmodule)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
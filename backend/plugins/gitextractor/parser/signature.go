@@ -0,0 +1,176 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/apache/incubator-devlake/core/errors"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	SIGNATURE_GPG = "GPG"
+	SIGNATURE_SSH = "SSH"
+
+	pgpSignatureHeader = "-----BEGIN PGP SIGNATURE-----"
+	sshSignatureHeader = "-----BEGIN SSH SIGNATURE-----"
+	sshSigMagic        = "SSHSIG"
+	sshSigNamespace    = "git"
+)
+
+// SignatureVerifier checks commit/tag signatures against the keys configured on the task options,
+// armored PGP public keys and `authorized_keys` formatted SSH public keys are both accepted
+type SignatureVerifier struct {
+	pgpKeys openpgp.EntityList
+	sshKeys []ssh.PublicKey
+}
+
+func NewSignatureVerifier(keys []string) (*SignatureVerifier, errors.Error) {
+	v := &SignatureVerifier{}
+	for i, key := range keys {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+		if strings.HasPrefix(key, "-----BEGIN PGP PUBLIC KEY BLOCK-----") {
+			entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(key))
+			if err != nil {
+				return nil, errors.BadInput.Wrap(err, fmt.Sprintf("failed to parse the PGP public key of signingKeys #%d", i))
+			}
+			v.pgpKeys = append(v.pgpKeys, entities...)
+			continue
+		}
+		pk, _, _, _, err := ssh.ParseAuthorizedKey([]byte(key))
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, fmt.Sprintf("failed to parse the SSH public key of signingKeys #%d", i))
+		}
+		v.sshKeys = append(v.sshKeys, pk)
+	}
+	return v, nil
+}
+
+// Verify detects the signature type and reports whether it was produced by one of the configured keys
+func (v *SignatureVerifier) Verify(signature, payload string) (signatureType string, verified bool) {
+	switch {
+	case strings.HasPrefix(strings.TrimSpace(signature), pgpSignatureHeader):
+		signatureType = SIGNATURE_GPG
+		if v != nil && len(v.pgpKeys) > 0 {
+			_, err := openpgp.CheckArmoredDetachedSignature(v.pgpKeys, strings.NewReader(payload), strings.NewReader(signature), nil)
+			verified = err == nil
+		}
+	case strings.HasPrefix(strings.TrimSpace(signature), sshSignatureHeader):
+		signatureType = SIGNATURE_SSH
+		if v != nil && len(v.sshKeys) > 0 {
+			verified = v.verifySsh(signature, payload)
+		}
+	}
+	return
+}
+
+// verifySsh implements the verification of the `sshsig` format described in
+// https://github.com/openssh/openssh-portable/blob/master/PROTOCOL.sshsig
+func (v *SignatureVerifier) verifySsh(signature, payload string) bool {
+	block, _ := pem.Decode([]byte(signature))
+	if block == nil || !bytes.HasPrefix(block.Bytes, []byte(sshSigMagic)) {
+		return false
+	}
+	blob := block.Bytes[len(sshSigMagic):]
+	if len(blob) < 4 || binary.BigEndian.Uint32(blob) != 1 {
+		return false
+	}
+	blob = blob[4:]
+	var fields [5][]byte
+	for i := range fields {
+		var ok bool
+		fields[i], blob, ok = readSshString(blob)
+		if !ok {
+			return false
+		}
+	}
+	publicKey, namespace, reserved, hashAlgorithm, rawSig := fields[0], fields[1], fields[2], fields[3], fields[4]
+	if string(namespace) != sshSigNamespace {
+		return false
+	}
+	signer, err := ssh.ParsePublicKey(publicKey)
+	if err != nil || !v.isTrustedSshKey(signer) {
+		return false
+	}
+	var digest []byte
+	switch string(hashAlgorithm) {
+	case "sha256":
+		sum := sha256.Sum256([]byte(payload))
+		digest = sum[:]
+	case "sha512":
+		sum := sha512.Sum512([]byte(payload))
+		digest = sum[:]
+	default:
+		return false
+	}
+	sig := &ssh.Signature{}
+	if err := ssh.Unmarshal(rawSig, sig); err != nil {
+		return false
+	}
+	signed := []byte(sshSigMagic)
+	for _, field := range [][]byte{namespace, reserved, hashAlgorithm, digest} {
+		signed = appendSshString(signed, field)
+	}
+	return signer.Verify(signed, sig) == nil
+}
+
+func (v *SignatureVerifier) isTrustedSshKey(key ssh.PublicKey) bool {
+	for _, trusted := range v.sshKeys {
+		if bytes.Equal(trusted.Marshal(), key.Marshal()) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitSignedObject separates the raw content of a signed tag object into the signed payload and the signature
+func splitSignedObject(raw string) (payload string, signature string) {
+	for _, header := range []string{pgpSignatureHeader, sshSignatureHeader} {
+		if i := strings.Index(raw, header); i >= 0 {
+			return raw[:i], raw[i:]
+		}
+	}
+	return raw, ""
+}
+
+func readSshString(in []byte) ([]byte, []byte, bool) {
+	if len(in) < 4 {
+		return nil, nil, false
+	}
+	length := binary.BigEndian.Uint32(in)
+	if uint32(len(in)-4) < length {
+		return nil, nil, false
+	}
+	return in[4 : 4+length], in[4+length:], true
+}
+
+func appendSshString(out []byte, s []byte) []byte {
+	out = binary.BigEndian.AppendUint32(out, uint32(len(s)))
+	return append(out, s...)
+}
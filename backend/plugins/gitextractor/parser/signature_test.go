@@ -0,0 +1,153 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+)
+
+const signedPayload = "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\nauthor devlake <devlake@example.com> 1740045600 +0000\n\nsigned commit\n"
+
+func newPgpKey(t *testing.T) (*openpgp.Entity, string) {
+	entity, err := openpgp.NewEntity("devlake", "", "devlake@example.com", nil)
+	assert.Nil(t, err)
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, openpgp.PublicKeyType, nil)
+	assert.Nil(t, err)
+	assert.Nil(t, entity.Serialize(w))
+	assert.Nil(t, w.Close())
+	return entity, buf.String()
+}
+
+func signPgp(t *testing.T, entity *openpgp.Entity, payload string) string {
+	var buf bytes.Buffer
+	assert.Nil(t, openpgp.ArmoredDetachSign(&buf, entity, strings.NewReader(payload), nil))
+	return buf.String()
+}
+
+func newSshKey(t *testing.T) (ssh.Signer, string) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+	signer, err := ssh.NewSignerFromKey(privateKey)
+	assert.Nil(t, err)
+	return signer, string(ssh.MarshalAuthorizedKey(signer.PublicKey()))
+}
+
+// signSsh produces the armored signature `ssh-keygen -Y sign -n git` would
+func signSsh(t *testing.T, signer ssh.Signer, payload string) string {
+	digest := sha512.Sum512([]byte(payload))
+	signed := []byte(sshSigMagic)
+	for _, field := range [][]byte{[]byte(sshSigNamespace), nil, []byte("sha512"), digest[:]} {
+		signed = appendSshString(signed, field)
+	}
+	sig, err := signer.Sign(rand.Reader, signed)
+	assert.Nil(t, err)
+	blob := append([]byte(sshSigMagic), 0, 0, 0, 1)
+	for _, field := range [][]byte{signer.PublicKey().Marshal(), []byte(sshSigNamespace), nil, []byte("sha512"), ssh.Marshal(sig)} {
+		blob = appendSshString(blob, field)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "SSH SIGNATURE", Bytes: blob}))
+}
+
+func TestSignatureVerifier_Verify(t *testing.T) {
+	pgpEntity, pgpPublicKey := newPgpKey(t)
+	otherPgpEntity, _ := newPgpKey(t)
+	sshSigner, sshPublicKey := newSshKey(t)
+	otherSshSigner, _ := newSshKey(t)
+	verifier, err := NewSignatureVerifier([]string{pgpPublicKey, sshPublicKey})
+	assert.Nil(t, err)
+
+	tests := []struct {
+		name          string
+		signature     string
+		payload       string
+		wantType      string
+		wantVerified  bool
+		emptyVerifier bool
+	}{
+		{name: "unsigned", payload: signedPayload},
+		{name: "gpg by a configured key", signature: signPgp(t, pgpEntity, signedPayload), payload: signedPayload, wantType: SIGNATURE_GPG, wantVerified: true},
+		{name: "gpg by an unknown key", signature: signPgp(t, otherPgpEntity, signedPayload), payload: signedPayload, wantType: SIGNATURE_GPG},
+		{name: "gpg of another payload", signature: signPgp(t, pgpEntity, signedPayload), payload: signedPayload + "tampered", wantType: SIGNATURE_GPG},
+		{name: "ssh by a configured key", signature: signSsh(t, sshSigner, signedPayload), payload: signedPayload, wantType: SIGNATURE_SSH, wantVerified: true},
+		{name: "ssh by an unknown key", signature: signSsh(t, otherSshSigner, signedPayload), payload: signedPayload, wantType: SIGNATURE_SSH},
+		{name: "ssh of another payload", signature: signSsh(t, sshSigner, signedPayload), payload: signedPayload + "tampered", wantType: SIGNATURE_SSH},
+		{name: "no key configured", signature: signSsh(t, sshSigner, signedPayload), payload: signedPayload, wantType: SIGNATURE_SSH, emptyVerifier: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := verifier
+			if tt.emptyVerifier {
+				v = nil
+			}
+			signatureType, verified := v.Verify(tt.signature, tt.payload)
+			assert.Equal(t, tt.wantType, signatureType)
+			assert.Equal(t, tt.wantVerified, verified)
+		})
+	}
+}
+
+func TestNewSignatureVerifierRejectsBadKey(t *testing.T) {
+	_, err := NewSignatureVerifier([]string{"ssh-ed25519 not-a-key"})
+	assert.NotNil(t, err)
+}
+
+func Test_splitSignedObject(t *testing.T) {
+	tests := []struct {
+		name          string
+		raw           string
+		wantPayload   string
+		wantSignature string
+	}{
+		{
+			name:        "unsigned tag",
+			raw:         "object 1111\ntype commit\ntag v1.0.0\n\nrelease\n",
+			wantPayload: "object 1111\ntype commit\ntag v1.0.0\n\nrelease\n",
+		},
+		{
+			name:          "gpg signed tag",
+			raw:           "object 1111\ntag v1.0.0\n\nrelease\n" + pgpSignatureHeader + "\nsig\n",
+			wantPayload:   "object 1111\ntag v1.0.0\n\nrelease\n",
+			wantSignature: pgpSignatureHeader + "\nsig\n",
+		},
+		{
+			name:          "ssh signed tag",
+			raw:           "object 1111\ntag v1.0.0\n\nrelease\n" + sshSignatureHeader + "\nsig\n",
+			wantPayload:   "object 1111\ntag v1.0.0\n\nrelease\n",
+			wantSignature: sshSignatureHeader + "\nsig\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload, signature := splitSignedObject(tt.raw)
+			assert.Equal(t, tt.wantPayload, payload)
+			assert.Equal(t, tt.wantSignature, signature)
+		})
+	}
+}
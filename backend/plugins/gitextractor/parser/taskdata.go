@@ -19,13 +19,19 @@ package parser
 
 import (
 	"net/url"
+
+	"github.com/apache/incubator-devlake/core/utils"
 )
 
 type GitExtractorTaskData struct {
 	Options         *GitExtractorOptions
 	ParsedURL       *url.URL
+	Credential      *GitCredential // injected into git commands at clone time, nil for anonymous or ssh access
 	GitRepo         RepoCollector
-	SkipAllSubtasks bool // silently skip all tasks without raising errors
+	Verifier        *SignatureVerifier
+	PathFilter      *utils.PathFilter
+	KnownHeads      []string // heads extracted by the previous run, commits reachable from them are skipped
+	SkipAllSubtasks bool     // silently skip all tasks without raising errors
 }
 
 type GitExtractorApiParams struct {
@@ -45,8 +51,22 @@ type GitExtractorOptions struct {
 	SkipCommitStat        *bool  `json:"skipCommitStat" mapstructure:"skipCommitStat" comment:"skip all commit stat including added/deleted lines and commit files as well"`
 	SkipCommitFiles       *bool  `json:"skipCommitFiles" mapstructure:"skipCommitFiles"`
 	NoShallowClone        bool   `json:"noShallowClone" mapstructure:"noShallowClone"`
-	ConnectionId          uint64 `json:"connectionId" mapstructure:"connectionId,omitempty"`
-	PluginName            string `json:"pluginName" mapstructure:"pluginName,omitempty"`
+	CollectSubmodules     *bool  `json:"collectSubmodules" mapstructure:"collectSubmodules" comment:"record submodule pointer changes of each commit"`
+	VerifySignatures      *bool  `json:"verifySignatures" mapstructure:"verifySignatures" comment:"record GPG/SSH signatures of commits and tags"`
+	DetectRenames         *bool  `json:"detectRenames" mapstructure:"detectRenames" comment:"libgit2 only, go-git always detects renames"`
+	DetectCopies          *bool  `json:"detectCopies" mapstructure:"detectCopies" comment:"libgit2 only"`
+	// similarity (0-100) for a pair of deleted/added files to be considered as a rename or copy, 0 means the default of the git library
+	RenameThreshold int `json:"renameThreshold" mapstructure:"renameThreshold"`
+	CopyThreshold   int `json:"copyThreshold" mapstructure:"copyThreshold"`
+	// maximum number of files to compare when detecting renames, 0 means the default of the git library
+	RenameLimit int `json:"renameLimit" mapstructure:"renameLimit"`
+	// armored PGP public keys or SSH public keys in the authorized_keys format, used to verify signatures
+	SigningKeys  []string `json:"signingKeys" mapstructure:"signingKeys"`
+	ConnectionId uint64   `json:"connectionId" mapstructure:"connectionId,omitempty"`
+	PluginName   string   `json:"pluginName" mapstructure:"pluginName,omitempty"`
 	// Configured by upstream plugin (e.g., GitLab) to exclude file extensions from commit stats
 	ExcludeFileExtensions []string `json:"excludeFileExtensions" mapstructure:"excludeFileExtensions"`
+	// glob patterns of files to be counted in / left out of commit stats, e.g. `src/**`, `**/*_test.go`, `vendor/`
+	IncludePaths []string `json:"includePaths" mapstructure:"includePaths"`
+	ExcludePaths []string `json:"excludePaths" mapstructure:"excludePaths"`
 }
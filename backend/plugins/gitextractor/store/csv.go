@@ -86,6 +86,8 @@ type CsvStore struct {
 	commitFileComponentWriter *csvWriter
 	commitLineChangeWriter    *csvWriter
 	snapshotWriter            *csvWriter
+	submoduleChangeWriter     *csvWriter
+	ownershipWriter           *csvWriter
 }
 
 func NewCsvStore(dir string) (*CsvStore, errors.Error) {
@@ -129,6 +131,14 @@ func NewCsvStore(dir string) (*CsvStore, errors.Error) {
 	if err != nil {
 		return nil, errors.Convert(err)
 	}
+	s.submoduleChangeWriter, err = newCsvWriter(filepath.Join(dir, "commit_submodule_changes.csv"), code.CommitSubmoduleChange{})
+	if err != nil {
+		return nil, errors.Convert(err)
+	}
+	s.ownershipWriter, err = newCsvWriter(filepath.Join(dir, "directory_ownerships.csv"), code.DirectoryOwnership{})
+	if err != nil {
+		return nil, errors.Convert(err)
+	}
 	return s, nil
 }
 
@@ -174,6 +184,26 @@ func (c *CsvStore) CommitParents(pp []*code.CommitParent) errors.Error {
 	return nil
 }
 
+func (c *CsvStore) CommitSubmoduleChanges(changes []*code.CommitSubmoduleChange) errors.Error {
+	for _, change := range changes {
+		err := c.submoduleChangeWriter.Write(change)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *CsvStore) DirectoryOwnerships(ownerships []*code.DirectoryOwnership) errors.Error {
+	for _, ownership := range ownerships {
+		err := c.ownershipWriter.Write(ownership)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (c *CsvStore) Close() errors.Error {
 	if c.repoCommitWriter != nil {
 		c.repoCommitWriter.Close()
@@ -199,5 +229,11 @@ func (c *CsvStore) Close() errors.Error {
 	if c.commitLineChangeWriter != nil {
 		c.commitLineChangeWriter.Close()
 	}
+	if c.submoduleChangeWriter != nil {
+		c.submoduleChangeWriter.Close()
+	}
+	if c.ownershipWriter != nil {
+		c.ownershipWriter.Close()
+	}
 	return nil
 }
@@ -160,6 +160,42 @@ func (d *Database) CommitParents(pp []*code.CommitParent) errors.Error {
 	return nil
 }
 
+func (d *Database) CommitSubmoduleChanges(changes []*code.CommitSubmoduleChange) errors.Error {
+	if len(changes) == 0 {
+		return nil
+	}
+	batch, err := d.driver.ForType(reflect.TypeOf(changes[0]))
+	if err != nil {
+		return err
+	}
+	for _, change := range changes {
+		d.updateRawDataFields(&change.RawDataOrigin)
+		err = batch.Add(change)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *Database) DirectoryOwnerships(ownerships []*code.DirectoryOwnership) errors.Error {
+	if len(ownerships) == 0 {
+		return nil
+	}
+	batch, err := d.driver.ForType(reflect.TypeOf(ownerships[0]))
+	if err != nil {
+		return err
+	}
+	for _, ownership := range ownerships {
+		d.updateRawDataFields(&ownership.RawDataOrigin)
+		err = batch.Add(ownership)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (d *Database) Close() errors.Error {
 	return d.driver.Close()
 }
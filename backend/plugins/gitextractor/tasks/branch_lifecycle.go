@@ -0,0 +1,112 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/gitextractor/parser"
+)
+
+var CollectGitBranchLifecycleMeta = plugin.SubTaskMeta{
+	Name:             "Collect Branch Lifecycle",
+	EntryPoint:       CollectGitBranchLifecycle,
+	EnabledByDefault: true,
+	Description:      "compare the branches with the previous snapshot to track their creation and deletion",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+	Dependencies:     []*plugin.SubTaskMeta{&CloneGitRepoMeta},
+}
+
+func CollectGitBranchLifecycle(subTaskCtx plugin.SubTaskContext) errors.Error {
+	taskData := subTaskCtx.TaskContext().GetData().(*parser.GitExtractorTaskData)
+	if taskData.SkipAllSubtasks {
+		return nil
+	}
+	repo := getGitRepo(subTaskCtx)
+	heads, e := repo.ListBranches(subTaskCtx.GetContext())
+	if e != nil {
+		return errors.Default.Wrap(errors.Convert(e), "failed to list the branches of the repo")
+	}
+	db := subTaskCtx.GetDal()
+	var existing []*code.BranchLifecycle
+	err := db.All(&existing, dal.Where("repo_id = ?", taskData.Options.RepoId))
+	if err != nil {
+		return err
+	}
+	lifecycles := mergeBranchLifecycles(taskData.Options.RepoId, existing, heads, time.Now())
+	subTaskCtx.SetProgress(0, len(lifecycles))
+	for _, lifecycle := range lifecycles {
+		err = db.CreateOrUpdate(lifecycle)
+		if err != nil {
+			return err
+		}
+		subTaskCtx.IncProgress(1)
+	}
+	return nil
+}
+
+// mergeBranchLifecycles applies the current branches onto the previous snapshot:
+// new branches are marked as created, missing ones as deleted, and the rest get their heads refreshed
+func mergeBranchLifecycles(repoId string, existing []*code.BranchLifecycle, heads []*parser.BranchHead, now time.Time) []*code.BranchLifecycle {
+	firstSnapshot := len(existing) == 0
+	lifecycles := make(map[string]*code.BranchLifecycle, len(existing))
+	for _, lifecycle := range existing {
+		lifecycles[lifecycle.BranchName] = lifecycle
+	}
+	seen := make(map[string]bool, len(heads))
+	for _, head := range heads {
+		seen[head.Name] = true
+		lifecycle, ok := lifecycles[head.Name]
+		if !ok {
+			lifecycle = &code.BranchLifecycle{
+				RepoId:     repoId,
+				BranchName: head.Name,
+			}
+			lifecycles[head.Name] = lifecycle
+		}
+		if !ok || lifecycle.DeletedDate != nil {
+			// the exact creation time of branches found by the first snapshot is unknown, the fork date is the best guess
+			createdDate := now
+			if firstSnapshot && head.ForkCommitDate != nil {
+				createdDate = *head.ForkCommitDate
+			}
+			lifecycle.CreatedDate = &createdDate
+			lifecycle.DeletedDate = nil
+		}
+		headCommitDate := head.CommitDate
+		lifecycle.IsDefault = head.IsDefault
+		lifecycle.HeadCommitSha = head.CommitSha
+		lifecycle.HeadCommitDate = &headCommitDate
+		lifecycle.ForkCommitSha = head.ForkCommitSha
+		lifecycle.ForkCommitDate = head.ForkCommitDate
+		lifecycle.LastSeenDate = &now
+	}
+	result := make([]*code.BranchLifecycle, 0, len(lifecycles))
+	for name, lifecycle := range lifecycles {
+		if !seen[name] && lifecycle.DeletedDate == nil {
+			lifecycle.DeletedDate = &now
+			lifecycle.IsDefault = false
+		}
+		result = append(result, lifecycle)
+	}
+	return result
+}
@@ -20,8 +20,10 @@ package tasks
 import (
 	"os"
 
+	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/gitextractor/models"
 	"github.com/apache/incubator-devlake/plugins/gitextractor/parser"
 	"github.com/apache/incubator-devlake/plugins/gitextractor/store"
 )
@@ -68,6 +70,13 @@ func CloneGitRepo(subTaskCtx plugin.SubTaskContext) errors.Error {
 	}
 	if repoCloner.IsIncremental() {
 		storage.SetIncrementalMode(repoCloner.IsIncremental())
+		// skip the commits extracted by the previous run
+		repoState := &models.GitRepoState{}
+		err = subTaskCtx.GetDal().First(repoState, dal.Where("repo_id = ?", op.RepoId))
+		if err != nil && !subTaskCtx.GetDal().IsErrorNotFound(err) {
+			return errors.Default.Wrap(err, "failed to load the previous repo state")
+		}
+		taskData.KnownHeads = repoState.Heads
 	}
 	// We have done comparison experiments for git2go and go-git, and the results show that git2go has better performance.
 	var repoCollector parser.RepoCollector
@@ -18,8 +18,11 @@ limitations under the License.
 package tasks
 
 import (
+	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
 	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/gitextractor/models"
 	"github.com/apache/incubator-devlake/plugins/gitextractor/parser"
 )
 
@@ -35,7 +38,23 @@ func CollectGitCommits(subTaskCtx plugin.SubTaskContext) errors.Error {
 	} else {
 		subTaskCtx.SetProgress(0, count)
 	}
-	return errors.Convert(repo.CollectCommits(subTaskCtx))
+	if err := repo.CollectCommits(subTaskCtx); err != nil {
+		return errors.Convert(err)
+	}
+	return saveRepoState(subTaskCtx, repo)
+}
+
+// saveRepoState persists the heads that have been extracted, the next incremental run would start from them
+func saveRepoState(subTaskCtx plugin.SubTaskContext, repo parser.RepoCollector) errors.Error {
+	heads, err := repo.ListHeads(subTaskCtx.GetContext())
+	if err != nil {
+		return errors.Default.Wrap(errors.Convert(err), "failed to list the heads of the repo")
+	}
+	taskData := subTaskCtx.GetData().(*parser.GitExtractorTaskData)
+	return subTaskCtx.GetDal().CreateOrUpdate(&models.GitRepoState{
+		RepoId: taskData.Options.RepoId,
+		Heads:  heads,
+	})
 }
 
 func CollectGitBranches(subTaskCtx plugin.SubTaskContext) errors.Error {
@@ -81,6 +100,21 @@ func CollectGitDiffLines(subTaskCtx plugin.SubTaskContext) errors.Error {
 	return nil
 }
 
+func CollectGitOwnership(subTaskCtx plugin.SubTaskContext) errors.Error {
+	if subTaskCtx.TaskContext().GetData().(*parser.GitExtractorTaskData).SkipAllSubtasks {
+		return nil
+	}
+	repo := getGitRepo(subTaskCtx)
+	// ownership is a snapshot of the HEAD, the previous one is replaced as a whole
+	repoId := subTaskCtx.GetData().(*parser.GitExtractorTaskData).Options.RepoId
+	err := subTaskCtx.GetDal().Delete(&code.DirectoryOwnership{}, dal.Where("repo_id = ?", repoId))
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to delete the previous ownership snapshot")
+	}
+	subTaskCtx.SetProgress(0, -1)
+	return errors.Convert(repo.CollectOwnership(subTaskCtx))
+}
+
 func getGitRepo(subTaskCtx plugin.SubTaskContext) parser.RepoCollector {
 	taskData, ok := subTaskCtx.GetData().(*parser.GitExtractorTaskData)
 	if !ok {
@@ -129,3 +163,12 @@ var CollectGitDiffLineMeta = plugin.SubTaskMeta{
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
 	Dependencies:     []*plugin.SubTaskMeta{&CloneGitRepoMeta},
 }
+
+var CollectGitOwnershipMeta = plugin.SubTaskMeta{
+	Name:             "Collect Ownership",
+	EntryPoint:       CollectGitOwnership,
+	EnabledByDefault: false,
+	Description:      "blame the HEAD to snapshot the top contributors of each directory into Domain Layer Tables",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+	Dependencies:     []*plugin.SubTaskMeta{&CloneGitRepoMeta},
+}
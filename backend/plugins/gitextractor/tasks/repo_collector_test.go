@@ -0,0 +1,88 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	mockplugin "github.com/apache/incubator-devlake/mocks/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/gitextractor/models"
+	"github.com/apache/incubator-devlake/plugins/gitextractor/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type fakeRepoCollector struct {
+	parser.RepoCollector
+	collectErr error
+}
+
+func (r *fakeRepoCollector) CountCommits(context.Context) (int, error) {
+	return 2, nil
+}
+
+func (r *fakeRepoCollector) CollectCommits(plugin.SubTaskContext) error {
+	return r.collectErr
+}
+
+func (r *fakeRepoCollector) ListHeads(context.Context) ([]string, error) {
+	return []string{"a5f1d2c", "9b3e7f0"}, nil
+}
+
+func TestCollectGitCommitsSavesRepoState(t *testing.T) {
+	tests := []struct {
+		name       string
+		collectErr error
+		state      *models.GitRepoState
+	}{
+		{name: "collected", state: &models.GitRepoState{RepoId: "github:GithubRepo:1:1", Heads: []string{"a5f1d2c", "9b3e7f0"}}},
+		// the next run has to extract the commits again
+		{name: "failed", collectErr: errors.Default.New("object not found")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taskData := &parser.GitExtractorTaskData{
+				Options: &parser.GitExtractorOptions{GitExtractorApiParams: parser.GitExtractorApiParams{RepoId: "github:GithubRepo:1:1"}},
+				GitRepo: &fakeRepoCollector{collectErr: tt.collectErr},
+			}
+			var state *models.GitRepoState
+			mockDal := new(mockdal.Dal)
+			mockDal.On("CreateOrUpdate", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				state = args.Get(0).(*models.GitRepoState)
+			}).Return(nil).Maybe()
+			mockTaskCtx := new(mockplugin.TaskContext)
+			mockTaskCtx.On("GetData").Return(taskData)
+			mockCtx := new(mockplugin.SubTaskContext)
+			mockCtx.On("TaskContext").Return(mockTaskCtx)
+			mockCtx.On("GetData").Return(taskData)
+			mockCtx.On("GetContext").Return(context.Background())
+			mockCtx.On("GetLogger").Return(unithelper.DummyLogger())
+			mockCtx.On("GetDal").Return(mockDal)
+			mockCtx.On("SetProgress", mock.Anything, mock.Anything)
+
+			err := CollectGitCommits(mockCtx)
+			assert.Equal(t, tt.collectErr == nil, err == nil)
+			assert.Equal(t, tt.state, state)
+		})
+	}
+}
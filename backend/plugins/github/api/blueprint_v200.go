@@ -23,8 +23,6 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"net/url"
-	"strings"
 
 	"github.com/apache/incubator-devlake/core/errors"
 	coreModels "github.com/apache/incubator-devlake/core/models"
@@ -119,23 +117,33 @@ func makeDataSourcePipelinePlanV200(
 
 		// add gitex stage
 		if utils.StringsContains(scopeConfig.Entities, plugin.DOMAIN_TYPE_CODE) || len(scopeConfig.Entities) == 0 {
-			cloneUrl, err := errors.Convert01(url.Parse(githubRepo.CloneUrl))
-			if err != nil {
-				return nil, err
+			// the token is injected by gitextractor at clone time, it must not be kept in the plan
+			repoUrl := githubRepo.CloneUrl
+			if connection.HasSshKey() {
+				// the private key is loaded by gitextractor from the connection as well
+				repoUrl, err = helper.MakeSshCloneUrl(githubRepo.CloneUrl)
+				if err != nil {
+					return nil, err
+				}
+			}
+			gitextOpts := map[string]interface{}{
+				"url":          repoUrl,
+				"name":         githubRepo.FullName,
+				"fullName":     githubRepo.FullName,
+				"repoId":       didgen.NewDomainIdGenerator(&models.GithubRepo{}).Generate(connection.ID, githubRepo.GithubId),
+				"proxy":        connection.Proxy,
+				"connectionId": githubRepo.ConnectionId,
+				"pluginName":   "github",
+			}
+			if len(scopeConfig.DiffIncludePaths) > 0 {
+				gitextOpts["includePaths"] = scopeConfig.DiffIncludePaths
+			}
+			if len(scopeConfig.DiffExcludePaths) > 0 {
+				gitextOpts["excludePaths"] = scopeConfig.DiffExcludePaths
 			}
-			token := strings.Split(connection.Token, ",")[0]
-			cloneUrl.User = url.UserPassword("git", token)
 			stage = append(stage, &coreModels.PipelineTask{
-				Plugin: "gitextractor",
-				Options: map[string]interface{}{
-					"url":          cloneUrl.String(),
-					"name":         githubRepo.FullName,
-					"fullName":     githubRepo.FullName,
-					"repoId":       didgen.NewDomainIdGenerator(&models.GithubRepo{}).Generate(connection.ID, githubRepo.GithubId),
-					"proxy":        connection.Proxy,
-					"connectionId": githubRepo.ConnectionId,
-					"pluginName":   "github",
-				},
+				Plugin:  "gitextractor",
+				Options: gitextOpts,
 			})
 
 		}
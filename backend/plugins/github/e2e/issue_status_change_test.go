@@ -0,0 +1,63 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/github/impl"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+	"github.com/apache/incubator-devlake/plugins/github/tasks"
+)
+
+func TestIssueStatusChangeDataFlow(t *testing.T) {
+	var plugin impl.Github
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "github", plugin)
+
+	taskData := &tasks.GithubTaskData{
+		Options: &tasks.GithubOptions{
+			ConnectionId: 1,
+			Name:         "panjf2000/ants",
+			GithubId:     134018330,
+		},
+	}
+
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_github_issues_for_status_changes.csv", &models.GithubIssue{})
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_github_issue_events_for_status_changes.csv", &models.GithubIssueEvent{})
+
+	// only the closed and reopened events of the issues in the repo are status changes
+	dataflowTester.FlushTabler(&ticket.IssueStatusChange{})
+	dataflowTester.Subtask(tasks.ConvertIssueStatusChangesMeta, taskData)
+	dataflowTester.VerifyTable(
+		ticket.IssueStatusChange{},
+		"./snapshot_tables/issue_status_changes.csv",
+		e2ehelper.ColumnWithRawData(
+			"id",
+			"issue_id",
+			"author_id",
+			"author_name",
+			"from_status",
+			"to_status",
+			"original_from_status",
+			"original_to_status",
+			"changed_date",
+		),
+	)
+}
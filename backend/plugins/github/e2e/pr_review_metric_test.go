@@ -0,0 +1,59 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/github/impl"
+	"github.com/apache/incubator-devlake/plugins/github/tasks"
+)
+
+func TestPrReviewMetricDataFlow(t *testing.T) {
+	var plugin impl.Github
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "github", plugin)
+
+	taskData := &tasks.GithubTaskData{
+		Options: &tasks.GithubOptions{
+			ConnectionId: 1,
+			Name:         "panjf2000/ants",
+			GithubId:     134018330,
+		},
+	}
+
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/pull_requests_for_review_metrics.csv", code.PullRequest{})
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/pull_request_comments_for_review_metrics.csv", code.PullRequestComment{})
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/pull_request_reviewers_for_review_metrics.csv", code.PullRequestReviewer{})
+
+	// only the pull requests of the repo are computed
+	dataflowTester.FlushTabler(&code.PullRequestReviewMetric{})
+	dataflowTester.Subtask(tasks.CalculatePullRequestReviewMetricsMeta, taskData)
+	dataflowTester.VerifyTable(
+		code.PullRequestReviewMetric{},
+		"./snapshot_tables/pull_request_review_metrics.csv",
+		[]string{
+			"pull_request_id",
+			"first_review_date",
+			"approved_date",
+			"review_round_count",
+			"reviewer_count",
+		},
+	)
+}
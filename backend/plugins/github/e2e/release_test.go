@@ -0,0 +1,61 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/github/impl"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+	"github.com/apache/incubator-devlake/plugins/github/tasks"
+)
+
+func TestReleaseDataFlow(t *testing.T) {
+	var plugin impl.Github
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "github", plugin)
+
+	taskData := &tasks.GithubTaskData{
+		Options: &tasks.GithubOptions{
+			ConnectionId: 1,
+			Name:         "panjf2000/ants",
+			GithubId:     134018330,
+		},
+	}
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_github_releases.csv", &models.GithubRelease{})
+
+	// verify the releases of the repo are converted along with the cicd releases
+	dataflowTester.FlushTabler(&crossdomain.Release{})
+	dataflowTester.Subtask(tasks.ConvertReleasesMeta, taskData)
+	dataflowTester.VerifyTable(
+		crossdomain.Release{},
+		"./snapshot_tables/releases.csv",
+		[]string{
+			"id",
+			"name",
+			"tag_name",
+			"description",
+			"url",
+			"released_date",
+			"repo_id",
+			"commit_sha",
+			"board_id",
+		},
+	)
+}
@@ -201,8 +201,16 @@ func (p Github) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
 			"GET": api.GetScopeLatestSyncState,
 		},
 		"connections/:connectionId/scopes": {
-			"GET": api.GetScopes,
-			"PUT": api.PutScopes,
+			"GET":    api.GetScopes,
+			"PUT":    api.PutScopes,
+			"PATCH":  api.PatchScopes,
+			"DELETE": api.DeleteScopes,
+		},
+		"connections/:connectionId/scope-jobs": {
+			"GET": api.GetScopeJobs,
+		},
+		"connections/:connectionId/scope-jobs/:jobId": {
+			"GET": api.GetScopeJob,
 		},
 		"connections/:connectionId/scope-configs": {
 			"POST": api.PostScopeConfig,
@@ -225,6 +233,9 @@ func (p Github) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
 		"scope-config/:scopeConfigId/projects": {
 			"GET": api.GetProjectsByScopeConfig,
 		},
+		"scope-config-schema": {
+			"GET": api.GetScopeConfigSchema,
+		},
 	}
 }
 
@@ -244,7 +255,7 @@ func (p Github) Close(taskCtx plugin.TaskContext) errors.Error {
 	return nil
 }
 
-func (p Github) GetDynamicGitUrl(taskCtx plugin.TaskContext, connectionId uint64, repoUrl string) (string, errors.Error) {
+func (p Github) GetDynamicGitCredential(taskCtx plugin.TaskContext, connectionId uint64) (string, string, errors.Error) {
 	connectionHelper := helper.NewConnectionHelper(
 		taskCtx,
 		nil,
@@ -254,25 +265,21 @@ func (p Github) GetDynamicGitUrl(taskCtx plugin.TaskContext, connectionId uint64
 	connection := &models.GithubConnection{}
 	err := connectionHelper.FirstById(connection, connectionId)
 	if err != nil {
-		return "", errors.Default.Wrap(err, "unable to get github connection by the given connection ID")
+		return "", "", errors.Default.Wrap(err, "unable to get github connection by the given connection ID")
 	}
 
 	apiClient, err := helper.NewApiClient(taskCtx.GetContext(), connection.GetEndpoint(), nil, 0, connection.GetProxy(), taskCtx)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 
+	// refreshes the installation token for github app connections
 	err = connection.PrepareApiClient(apiClient)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 
-	newUrl, err := replaceAcessTokenInUrl(repoUrl, strings.Split(connection.Token, ",")[0])
-	if err != nil {
-		return "", err
-	}
-
-	return newUrl, nil
+	return "git", strings.Split(connection.Token, ",")[0], nil
 }
 
 func EnrichOptions(taskCtx plugin.TaskContext,
@@ -344,23 +351,11 @@ func convertApiRepoToScope(repo *tasks.GithubApiRepo, connectionId uint64) *mode
 	return &scope
 }
 
-func replaceAcessTokenInUrl(gitURL, newCredential string) (string, errors.Error) {
-	atIndex := strings.Index(gitURL, "@")
-	if atIndex == -1 {
-		return "", errors.Default.New("Invalid Git URL")
-	}
-
-	protocolIndex := strings.Index(gitURL, "://")
-	if protocolIndex == -1 {
-		return "", errors.Default.New("Invalid Git URL")
+func (p Github) GetDynamicSshKey(taskCtx plugin.TaskContext, connectionId uint64) (string, string, errors.Error) {
+	connection := &models.GithubConnection{}
+	err := helper.NewConnectionHelper(taskCtx, nil, p.Name()).FirstById(connection, connectionId)
+	if err != nil {
+		return "", "", errors.Default.Wrap(err, "unable to get github connection by the given connection ID")
 	}
-
-	// Extract the base URL (e.g., "https://git:")
-	baseURL := gitURL[:protocolIndex+7]
-
-	repoURL := gitURL[atIndex+1:]
-
-	modifiedURL := fmt.Sprintf("%s%s@%s", baseURL, newCredential, repoURL)
-
-	return modifiedURL, nil
+	return connection.SshPrivateKey, connection.SshPassphrase, nil
 }
@@ -56,6 +56,7 @@ type GithubConn struct {
 	helper.MultiAuth      `mapstructure:",squash"`
 	GithubAccessToken     `mapstructure:",squash" authMethod:"AccessToken"`
 	GithubAppKey          `mapstructure:",squash" authMethod:"AppKey"`
+	helper.SshKey         `mapstructure:",squash"`
 }
 
 // PrepareApiClient splits Token to tokens for SetupAuthentication to utilize
@@ -129,6 +130,7 @@ func (connection *GithubConnection) Merge(existed, modified *GithubConnection, b
 	// There are many kinds of update, we just update all fields simply.
 	existedTokenStr := existed.Token
 	existSecretKey := existed.SecretKey
+	existedSshKey := existed.SshKey
 
 	existed.Name = modified.Name
 	if _, ok := body["enableGraphql"]; ok {
@@ -142,6 +144,15 @@ func (connection *GithubConnection) Merge(existed, modified *GithubConnection, b
 	existed.Endpoint = modified.Endpoint
 	existed.RateLimitPerHour = modified.RateLimitPerHour
 
+	// handle ssh key, the stored one is kept if the request doesn't mention it or sends it back sanitized
+	if _, ok := body["sshPrivateKey"]; ok {
+		existed.SshPrivateKey = modified.SshPrivateKey
+	}
+	if _, ok := body["sshPassphrase"]; ok {
+		existed.SshPassphrase = modified.SshPassphrase
+	}
+	existed.SshKey.RestoreSanitized(existedSshKey)
+
 	// handle secret
 	if existSecretKey == "" {
 		if modified.SecretKey != "" {
@@ -272,6 +283,7 @@ func (connection GithubConnection) Sanitize() GithubConnection {
 func (conn *GithubConn) Sanitize() GithubConn {
 	conn.SanitizeTokens()
 	conn.SanitizeSecret()
+	conn.SshKey = conn.SshKey.Sanitize()
 	return *conn
 }
 
@@ -0,0 +1,55 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addDiffPathFilters)(nil)
+
+type githubScopeConfig20261014 struct {
+	DiffIncludePaths []string `gorm:"type:json" json:"diffIncludePaths" mapstructure:"diffIncludePaths"`
+	DiffExcludePaths []string `gorm:"type:json" json:"diffExcludePaths" mapstructure:"diffExcludePaths"`
+}
+
+func (githubScopeConfig20261014) TableName() string {
+	return "_tool_github_scope_configs"
+}
+
+type addDiffPathFilters struct{}
+
+func (script *addDiffPathFilters) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&githubScopeConfig20261014{},
+	)
+}
+
+func (*addDiffPathFilters) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropColumns(githubScopeConfig20261014{}.TableName(), "diff_include_paths", "diff_exclude_paths")
+}
+
+func (*addDiffPathFilters) Version() uint64 { return 20261014100000 }
+
+func (*addDiffPathFilters) Name() string {
+	return "add diff_include_paths and diff_exclude_paths to _tool_github_scope_configs"
+}
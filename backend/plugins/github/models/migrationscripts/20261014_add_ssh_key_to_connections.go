@@ -0,0 +1,55 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addSshKeyToConnections)(nil)
+
+type githubConnection20261014 struct {
+	SshPrivateKey string `gorm:"type:text;serializer:encdec"`
+	SshPassphrase string `gorm:"type:text;serializer:encdec"`
+}
+
+func (githubConnection20261014) TableName() string {
+	return "_tool_github_connections"
+}
+
+type addSshKeyToConnections struct{}
+
+func (script *addSshKeyToConnections) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&githubConnection20261014{},
+	)
+}
+
+func (*addSshKeyToConnections) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropColumns(githubConnection20261014{}.TableName(), "ssh_private_key", "ssh_passphrase")
+}
+
+func (*addSshKeyToConnections) Version() uint64 { return 20261014110000 }
+
+func (*addSshKeyToConnections) Name() string {
+	return "add ssh_private_key and ssh_passphrase to _tool_github_connections"
+}
@@ -55,5 +55,9 @@ func All() []plugin.MigrationScript {
 		new(addIsDraftToPr),
 		new(changeIssueComponentType),
 		new(addIndexToGithubJobs),
+		new(addDiffPathFilters),
+		new(addSshKeyToConnections),
+		new(addPrRiskPaths),
+		new(encryptConnectionProxy),
 	}
 }
@@ -27,19 +27,25 @@ var _ plugin.ToolLayerScopeConfig = (*GithubScopeConfig)(nil)
 
 type GithubScopeConfig struct {
 	common.ScopeConfig   `mapstructure:",squash" json:",inline" gorm:"embedded"`
-	PrType               string            `mapstructure:"prType,omitempty" json:"prType" gorm:"type:varchar(255)"`
-	PrComponent          string            `mapstructure:"prComponent,omitempty" json:"prComponent" gorm:"type:varchar(255)"`
+	PrType               string            `mapstructure:"prType,omitempty" json:"prType" gorm:"type:varchar(255)" format:"regex"`
+	PrComponent          string            `mapstructure:"prComponent,omitempty" json:"prComponent" gorm:"type:varchar(255)" format:"regex"`
 	PrBodyClosePattern   string            `mapstructure:"prBodyClosePattern,omitempty" json:"prBodyClosePattern" gorm:"type:varchar(255)"`
-	IssueSeverity        string            `mapstructure:"issueSeverity,omitempty" json:"issueSeverity" gorm:"type:varchar(255)"`
-	IssuePriority        string            `mapstructure:"issuePriority,omitempty" json:"issuePriority" gorm:"type:varchar(255)"`
-	IssueComponent       string            `mapstructure:"issueComponent,omitempty" json:"issueComponent" gorm:"type:varchar(255)"`
-	IssueTypeBug         string            `mapstructure:"issueTypeBug,omitempty" json:"issueTypeBug" gorm:"type:varchar(255)"`
-	IssueTypeIncident    string            `mapstructure:"issueTypeIncident,omitempty" json:"issueTypeIncident" gorm:"type:varchar(255)"`
-	IssueTypeRequirement string            `mapstructure:"issueTypeRequirement,omitempty" json:"issueTypeRequirement" gorm:"type:varchar(255)"`
+	IssueSeverity        string            `mapstructure:"issueSeverity,omitempty" json:"issueSeverity" gorm:"type:varchar(255)" format:"regex"`
+	IssuePriority        string            `mapstructure:"issuePriority,omitempty" json:"issuePriority" gorm:"type:varchar(255)" format:"regex"`
+	IssueComponent       string            `mapstructure:"issueComponent,omitempty" json:"issueComponent" gorm:"type:varchar(255)" format:"regex"`
+	IssueTypeBug         string            `mapstructure:"issueTypeBug,omitempty" json:"issueTypeBug" gorm:"type:varchar(255)" format:"regex"`
+	IssueTypeIncident    string            `mapstructure:"issueTypeIncident,omitempty" json:"issueTypeIncident" gorm:"type:varchar(255)" format:"regex"`
+	IssueTypeRequirement string            `mapstructure:"issueTypeRequirement,omitempty" json:"issueTypeRequirement" gorm:"type:varchar(255)" format:"regex"`
 	DeploymentPattern    string            `mapstructure:"deploymentPattern,omitempty" json:"deploymentPattern" gorm:"type:varchar(255)"`
 	ProductionPattern    string            `mapstructure:"productionPattern,omitempty" json:"productionPattern" gorm:"type:varchar(255)"`
 	EnvNamePattern       string            `mapstructure:"envNamePattern,omitempty" json:"envNamePattern" gorm:"type:varchar(255)"`
 	Refdiff              datatypes.JSONMap `mapstructure:"refdiff,omitempty" json:"refdiff" swaggertype:"object" format:"json"`
+	// Glob patterns (e.g. `src/**`, `vendor/**`, `**/*.min.js`) of files to include in / exclude from the commit additions/deletions
+	DiffIncludePaths []string `mapstructure:"diffIncludePaths" json:"diffIncludePaths" gorm:"type:json;serializer:json"`
+	DiffExcludePaths []string `mapstructure:"diffExcludePaths" json:"diffExcludePaths" gorm:"type:json;serializer:json"`
+	// Glob patterns of database migration / infrastructure files, a pull request touching them is flagged as risky
+	PrMigrationPaths []string `mapstructure:"prMigrationPaths" json:"prMigrationPaths" gorm:"type:json;serializer:json"`
+	PrInfraPaths     []string `mapstructure:"prInfraPaths" json:"prInfraPaths" gorm:"type:json;serializer:json"`
 }
 
 // GetConnectionId implements plugin.ToolLayerScopeConfig.
@@ -0,0 +1,45 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/helpers/srvhelper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGithubScopeConfigPatterns(t *testing.T) {
+	schema := srvhelper.GenerateJsonSchema(&GithubScopeConfig{})
+	for _, field := range []string{
+		"prType", "prComponent", "issueSeverity", "issueComponent", "issuePriority",
+		"issueTypeBug", "issueTypeIncident", "issueTypeRequirement",
+	} {
+		t.Run(field, func(t *testing.T) {
+			valid := map[string]interface{}{"connectionId": float64(1), "name": "default", field: "type/(.*)$"}
+			assert.Empty(t, schema.Validate(valid, false))
+
+			invalid := map[string]interface{}{"connectionId": float64(1), "name": "default", field: "type/(.*$"}
+			violations := schema.Validate(invalid, false)
+			if assert.Len(t, violations, 1) {
+				assert.Equal(t, field, violations[0].Field)
+				assert.Contains(t, violations[0].Message, "must be a valid regular expression")
+			}
+		})
+	}
+}
@@ -37,6 +37,7 @@ func CreateApiClient(taskCtx plugin.TaskContext, connection *models.GithubConnec
 	// create rate limit calculator
 	rateLimiter := &api.ApiRateLimitCalculator{
 		UserRateLimitPerHour: connection.RateLimitPerHour,
+		Tokens:               connection.GetTokensCount(),
 		Method:               http.MethodGet,
 		DynamicRateLimit: func(res *http.Response) (int, time.Duration, errors.Error) {
 			/* calculate by number of remaining requests
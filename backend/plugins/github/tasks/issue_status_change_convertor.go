@@ -0,0 +1,119 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ConvertIssueStatusChangesMeta)
+}
+
+var ConvertIssueStatusChangesMeta = plugin.SubTaskMeta{
+	Name:             "Convert Issue Status Changes",
+	EntryPoint:       ConvertIssueStatusChanges,
+	EnabledByDefault: true,
+	Description:      "Convert closed/reopened events in tool layer table github_issue_events into domain layer table issue_status_changes",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+	DependencyTables: []string{
+		models.GithubIssueEvent{}.TableName(), // cursor
+		models.GithubIssue{}.TableName(),      // cursor
+		RAW_EVENTS_TABLE},
+	ProductTables: []string{ticket.IssueStatusChange{}.TableName()},
+}
+
+// github issues only have open and closed states, so a closed event moves an issue from TODO to DONE and
+// a reopened event moves it back
+var githubIssueEventStatusChanges = map[string][2]string{
+	"closed":   {"open", "closed"},
+	"reopened": {"closed", "open"},
+}
+
+func ConvertIssueStatusChanges(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*GithubTaskData)
+
+	cursor, err := db.Cursor(
+		dal.Select("_tool_github_issue_events.*"),
+		dal.From(&models.GithubIssueEvent{}),
+		dal.Join(`left join _tool_github_issues on _tool_github_issues.github_id = _tool_github_issue_events.issue_id
+			and _tool_github_issues.connection_id = _tool_github_issue_events.connection_id`),
+		dal.Where(
+			"_tool_github_issues.repo_id = ? and _tool_github_issue_events.connection_id = ? and _tool_github_issue_events.type in ?",
+			data.Options.GithubId, data.Options.ConnectionId, []string{"closed", "reopened"},
+		),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	eventIdGen := didgen.NewDomainIdGenerator(&models.GithubIssueEvent{})
+	issueIdGen := didgen.NewDomainIdGenerator(&models.GithubIssue{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: GithubApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Name:         data.Options.Name,
+			},
+			Table: RAW_EVENTS_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.GithubIssueEvent{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			event := inputRow.(*models.GithubIssueEvent)
+			states := githubIssueEventStatusChanges[event.Type]
+			statusChange := &ticket.IssueStatusChange{
+				DomainEntity:       domainlayer.DomainEntity{Id: eventIdGen.Generate(data.Options.ConnectionId, event.GithubId)},
+				IssueId:            issueIdGen.Generate(data.Options.ConnectionId, event.IssueId),
+				AuthorName:         event.AuthorUsername,
+				FromStatus:         getGithubIssueStdStatus(states[0]),
+				ToStatus:           getGithubIssueStdStatus(states[1]),
+				OriginalFromStatus: states[0],
+				OriginalToStatus:   states[1],
+				ChangedDate:        event.GithubCreatedAt,
+			}
+			return []interface{}{
+				statusChange,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+func getGithubIssueStdStatus(state string) string {
+	if state == "closed" {
+		return ticket.DONE
+	}
+	return ticket.TODO
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
 	"github.com/apache/incubator-devlake/core/plugin"
@@ -45,7 +46,7 @@ var ConvertReleasesMeta = plugin.SubTaskMeta{
 	Description:      "Convert tool layer table github_releases into domain layer table releases",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
 	DependencyTables: []string{models.GithubRelease{}.TableName()},
-	ProductTables:    []string{devops.CicdRelease{}.TableName()},
+	ProductTables:    []string{devops.CicdRelease{}.TableName(), crossdomain.Release{}.TableName()},
 }
 
 func ConvertRelease(taskCtx plugin.SubTaskContext) errors.Error {
@@ -90,8 +91,20 @@ func ConvertRelease(taskCtx plugin.SubTaskContext) errors.Error {
 				RepoId: releaseScopeIdGen.Generate(githubRelease.ConnectionId, githubRelease.GithubId),
 			}
 
+			domainRelease := &crossdomain.Release{
+				DomainEntity: release.DomainEntity,
+				Name:         release.Name,
+				TagName:      release.TagName,
+				Description:  release.Description,
+				Url:          release.URL,
+				ReleasedDate: githubRelease.PublishedAt,
+				RepoId:       release.RepoId,
+				CommitSha:    release.CommitSha,
+			}
+
 			return []interface{}{
 				release,
+				domainRelease,
 			}, nil
 		},
 	})
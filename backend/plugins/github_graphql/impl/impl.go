@@ -22,7 +22,6 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
-	"reflect"
 	"strings"
 	"time"
 
@@ -126,8 +125,11 @@ func (p GithubGraphql) SubTaskMetas() []plugin.SubTaskMeta {
 		githubTasks.ConvertPullRequestIssuesMeta,
 		githubTasks.ConvertIssueAssigneeMeta,
 		githubTasks.ConvertIssueCommentsMeta,
+		githubTasks.ConvertIssueStatusChangesMeta,
 		githubTasks.ConvertPullRequestCommentsMeta,
 		githubTasks.ConvertReviewsMeta,
+		githubTasks.CalculatePullRequestReviewMetricsMeta,
+		githubTasks.ClassifyPullRequestsMeta,
 		githubTasks.ConvertMilestonesMeta,
 		githubTasks.ConvertAccountsMeta,
 
@@ -187,26 +189,27 @@ func (p GithubGraphql) PrepareTaskData(taskCtx plugin.TaskContext, options map[s
 	oauthContext := taskCtx.GetContext()
 	proxy := connection.GetProxy()
 	if proxy != "" {
-		pu, err := url.Parse(proxy)
+		proxyFunc, err := helper.NewProxyFunc(proxy, "")
 		if err != nil {
-			return nil, errors.Convert(err)
+			return nil, err
 		}
-		if pu.Scheme == "http" || pu.Scheme == "socks5" {
-			proxyClient := &http.Client{
-				Transport: &http.Transport{Proxy: http.ProxyURL(pu)},
-			}
-			oauthContext = context.WithValue(
-				taskCtx.GetContext(),
-				oauth2.HTTPClient,
-				proxyClient,
-			)
-			logger.Debug("Proxy set in oauthContext to %s", proxy)
-		} else {
-			return nil, errors.BadInput.New("Unsupported scheme set in proxy")
+		proxyClient := &http.Client{
+			Transport: &http.Transport{Proxy: proxyFunc},
 		}
+		oauthContext = context.WithValue(
+			taskCtx.GetContext(),
+			oauth2.HTTPClient,
+			proxyClient,
+		)
+		logger.Debug("Proxy set in oauthContext to %s", helper.SanitizeProxy(proxy))
 	}
 
 	httpClient := oauth2.NewClient(oauthContext, src)
+	// the graphql client doesn't go through NewApiClient, it is recorded or replayed the same way
+	httpClient.Transport, err = helper.NewApiRecordingTransport(taskCtx.GetConfigReader(), httpClient.Transport)
+	if err != nil {
+		return nil, err
+	}
 	endpoint, err := errors.Convert01(url.Parse(connection.Endpoint))
 	if err != nil {
 		return nil, errors.BadInput.Wrap(err, fmt.Sprintf("malformed connection endpoint supplied: %s", connection.Endpoint))
@@ -241,11 +244,6 @@ func (p GithubGraphql) PrepareTaskData(taskCtx plugin.TaskContext, options map[s
 		return nil, err
 	}
 
-	graphqlClient.SetGetRateCost(func(q interface{}) int {
-		v := reflect.ValueOf(q)
-		return int(v.Elem().FieldByName(`RateLimit`).FieldByName(`Cost`).Int())
-	})
-
 	regexEnricher := helper.NewRegexEnricher()
 	if err = regexEnricher.TryAdd(devops.DEPLOYMENT, op.ScopeConfig.DeploymentPattern); err != nil {
 		return nil, errors.BadInput.Wrap(err, "invalid value for `deploymentPattern`")
@@ -34,7 +34,8 @@ const RAW_ACCOUNTS_TABLE = "github_graphql_accounts"
 
 type GraphqlQueryAccountWrapper struct {
 	RateLimit struct {
-		Cost int
+		Cost      int
+		Remaining int
 	}
 	Users []GraphqlQueryAccount `graphql:"user(login: $login)" graphql-extend:"true"`
 }
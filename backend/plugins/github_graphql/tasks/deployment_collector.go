@@ -45,7 +45,8 @@ var CollectDeploymentsMeta = plugin.SubTaskMeta{
 
 type GraphqlQueryDeploymentWrapper struct {
 	RateLimit struct {
-		Cost int `graphql:"cost"`
+		Cost      int `graphql:"cost"`
+		Remaining int `graphql:"remaining"`
 	} `graphql:"rateLimit"`
 	Repository struct {
 		Deployments struct {
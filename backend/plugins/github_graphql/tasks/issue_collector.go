@@ -37,7 +37,8 @@ const RAW_ISSUES_TABLE = "github_graphql_issues"
 
 type GraphqlQueryIssueWrapper struct {
 	RateLimit struct {
-		Cost int
+		Cost      int
+		Remaining int
 	}
 	Repository struct {
 		IssueList struct {
@@ -50,7 +51,8 @@ type GraphqlQueryIssueWrapper struct {
 
 type GraphqlQueryIssueDetailWrapper struct {
 	RateLimit struct {
-		Cost int
+		Cost      int
+		Remaining int
 	}
 	Repository struct {
 		Issues []GraphqlQueryIssue `graphql:"issue(number: $number)" graphql-extend:"true"`
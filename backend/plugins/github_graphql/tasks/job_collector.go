@@ -36,7 +36,8 @@ const RAW_GRAPHQL_JOBS_TABLE = "github_graphql_jobs"
 
 type GraphqlQueryCheckRunWrapper struct {
 	RateLimit struct {
-		Cost int
+		Cost      int
+		Remaining int
 	}
 	Node []GraphqlQueryCheckSuite `graphql:"node(id: $id)" graphql-extend:"true"`
 }
@@ -37,7 +37,8 @@ const RAW_PRS_TABLE = "github_graphql_prs"
 // GraphqlQueryPrWrapper is a wrapper for collecting new PRs since the previous collection
 type GraphqlQueryPrWrapper struct {
 	RateLimit struct {
-		Cost int
+		Cost      int
+		Remaining int
 	}
 	Repository struct {
 		PullRequests struct {
@@ -51,7 +52,8 @@ type GraphqlQueryPrWrapper struct {
 // GraphqlQueryPrDetailWrapper is a wrapper for refetching OPEN PRs from the database to update the details
 type GraphqlQueryPrDetailWrapper struct {
 	RateLimit struct {
-		Cost int
+		Cost      int
+		Remaining int
 	}
 	Repository struct {
 		PullRequests []GraphqlQueryPr `graphql:"pullRequest(number: $number)" graphql-extend:"true"`
@@ -34,7 +34,8 @@ const RAW_RELEASE_TABLE = "github_graphql_release"
 
 type GraphqlQueryReleaseWrapper struct {
 	RateLimit struct {
-		Cost int
+		Cost      int
+		Remaining int
 	}
 	Repository struct {
 		Releases struct {
@@ -22,7 +22,6 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"net/url"
 
 	"github.com/apache/incubator-devlake/plugins/gitlab/tasks"
 
@@ -127,13 +126,17 @@ func makePipelinePlanV200(
 
 		// collect git data by gitextractor if CODE was requested
 		if utils.StringsContains(scopeConfig.Entities, plugin.DOMAIN_TYPE_CODE) || len(scopeConfig.Entities) == 0 {
-			cloneUrl, err := errors.Convert01(url.Parse(gitlabProject.HttpUrlToRepo))
-			if err != nil {
-				return nil, err
+			// the token is injected by gitextractor at clone time, it must not be kept in the plan
+			repoUrl := gitlabProject.HttpUrlToRepo
+			if connection.HasSshKey() {
+				// the private key is loaded by gitextractor from the connection as well
+				repoUrl, err = helper.MakeSshCloneUrl(gitlabProject.HttpUrlToRepo)
+				if err != nil {
+					return nil, err
+				}
 			}
-			cloneUrl.User = url.UserPassword("git", connection.Token)
 			gitextOpts := map[string]interface{}{
-				"url":          cloneUrl.String(),
+				"url":          repoUrl,
 				"name":         gitlabProject.Name,
 				"fullName":     gitlabProject.PathWithNamespace,
 				"repoId":       didgen.NewDomainIdGenerator(&models.GitlabProject{}).Generate(connection.ID, gitlabProject.GitlabId),
@@ -145,6 +148,12 @@ func makePipelinePlanV200(
 				// pass excluded file extensions to gitextractor to support PR Size exclusion
 				gitextOpts["excludeFileExtensions"] = scopeConfig.PrSizeExcludedFileExtensions
 			}
+			if len(scopeConfig.DiffIncludePaths) > 0 {
+				gitextOpts["includePaths"] = scopeConfig.DiffIncludePaths
+			}
+			if len(scopeConfig.DiffExcludePaths) > 0 {
+				gitextOpts["excludePaths"] = scopeConfig.DiffExcludePaths
+			}
 			stage = append(stage, &coreModels.PipelineTask{
 				Plugin:  "gitextractor",
 				Options: gitextOpts,
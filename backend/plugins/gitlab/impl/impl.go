@@ -87,6 +87,7 @@ func (p Gitlab) GetTablesInfo() []dal.Tabler {
 		&models.GitlabCommit{},
 		&models.GitlabIssue{},
 		&models.GitlabIssueLabel{},
+		&models.GitlabIssueStateEvent{},
 		&models.GitlabJob{},
 		&models.GitlabMergeRequest{},
 		&models.GitlabMrComment{},
@@ -261,8 +262,16 @@ func (p Gitlab) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
 			"GET": api.SearchRemoteScopes,
 		},
 		"connections/:connectionId/scopes": {
-			"GET": api.GetScopeList,
-			"PUT": api.PutScopes,
+			"GET":    api.GetScopeList,
+			"PUT":    api.PutScopes,
+			"PATCH":  api.PatchScopes,
+			"DELETE": api.DeleteScopes,
+		},
+		"connections/:connectionId/scope-jobs": {
+			"GET": api.GetScopeJobs,
+		},
+		"connections/:connectionId/scope-jobs/:jobId": {
+			"GET": api.GetScopeJob,
 		},
 		"connections/:connectionId/scope-configs": {
 			"POST": api.CreateScopeConfig,
@@ -279,6 +288,9 @@ func (p Gitlab) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
 		"scope-config/:scopeConfigId/projects": {
 			"GET": api.GetProjectsByScopeConfig,
 		},
+		"scope-config-schema": {
+			"GET": api.GetScopeConfigSchema,
+		},
 	}
 }
 
@@ -290,3 +302,21 @@ func (p Gitlab) Close(taskCtx plugin.TaskContext) errors.Error {
 	data.ApiClient.Release()
 	return nil
 }
+
+func (p Gitlab) GetDynamicSshKey(taskCtx plugin.TaskContext, connectionId uint64) (string, string, errors.Error) {
+	connection := &models.GitlabConnection{}
+	err := helper.NewConnectionHelper(taskCtx, nil, p.Name()).FirstById(connection, connectionId)
+	if err != nil {
+		return "", "", errors.Default.Wrap(err, "unable to get gitlab connection by the given connection ID")
+	}
+	return connection.SshPrivateKey, connection.SshPassphrase, nil
+}
+
+func (p Gitlab) GetDynamicGitCredential(taskCtx plugin.TaskContext, connectionId uint64) (string, string, errors.Error) {
+	connection := &models.GitlabConnection{}
+	err := helper.NewConnectionHelper(taskCtx, nil, p.Name()).FirstById(connection, connectionId)
+	if err != nil {
+		return "", "", errors.Default.Wrap(err, "unable to get gitlab connection by the given connection ID")
+	}
+	return "git", connection.Token, nil
+}
@@ -31,6 +31,9 @@ import (
 type GitlabConn struct {
 	api.RestConnection `mapstructure:",squash"`
 	api.AccessToken    `mapstructure:",squash"`
+	api.SshKey         `mapstructure:",squash"`
+	api.ClientTls      `mapstructure:",squash"`
+	api.ProxyBypass    `mapstructure:",squash"`
 }
 
 const GitlabCloudEndPoint string = "https://gitlab.com/api/v4/"
@@ -45,6 +48,9 @@ func (conn *GitlabConn) SetupAuthentication(request *http.Request) errors.Error
 
 func (conn *GitlabConn) Sanitize() GitlabConn {
 	conn.Token = utils.SanitizeString(conn.Token)
+	conn.SshKey = conn.SshKey.Sanitize()
+	conn.ClientTls = conn.ClientTls.Sanitize()
+	conn.Proxy = api.SanitizeProxy(conn.Proxy)
 	return *conn
 }
 
@@ -154,9 +160,15 @@ func (connection GitlabConnection) Sanitize() GitlabConnection {
 
 func (connection *GitlabConnection) MergeFromRequest(target *GitlabConnection, body map[string]interface{}) error {
 	token := target.Token
+	sshKey := target.SshKey
+	clientTls := target.ClientTls
+	proxy := target.Proxy
 	if err := api.DecodeMapStruct(body, target, true); err != nil {
 		return err
 	}
+	target.SshKey.RestoreSanitized(sshKey)
+	target.ClientTls.RestoreSanitized(clientTls)
+	target.Proxy = api.RestoreSanitizedProxy(target.Proxy, proxy)
 	modifiedToken := target.Token
 	if modifiedToken == "" || modifiedToken == utils.SanitizeString(token) {
 		target.Token = token
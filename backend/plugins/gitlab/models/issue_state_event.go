@@ -0,0 +1,40 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// GitlabIssueStateEvent is a resource state event of an issue, ex. closed, reopened
+type GitlabIssueStateEvent struct {
+	ConnectionId    uint64 `gorm:"primaryKey"`
+	GitlabId        int    `gorm:"primaryKey"`
+	IssueId         int    `gorm:"index"`
+	State           string `gorm:"type:varchar(100)"`
+	AuthorUserId    int
+	AuthorUsername  string    `gorm:"type:varchar(255)"`
+	GitlabCreatedAt time.Time `gorm:"index"`
+	common.NoPKModel
+}
+
+func (GitlabIssueStateEvent) TableName() string {
+	return "_tool_gitlab_issue_state_events"
+}
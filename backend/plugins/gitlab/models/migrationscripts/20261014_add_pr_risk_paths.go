@@ -0,0 +1,55 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addPrRiskPaths)(nil)
+
+type gitlabScopeConfigPrRiskPaths20261014 struct {
+	PrMigrationPaths []string `gorm:"type:json" json:"prMigrationPaths" mapstructure:"prMigrationPaths"`
+	PrInfraPaths     []string `gorm:"type:json" json:"prInfraPaths" mapstructure:"prInfraPaths"`
+}
+
+func (gitlabScopeConfigPrRiskPaths20261014) TableName() string {
+	return "_tool_gitlab_scope_configs"
+}
+
+type addPrRiskPaths struct{}
+
+func (script *addPrRiskPaths) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&gitlabScopeConfigPrRiskPaths20261014{},
+	)
+}
+
+func (*addPrRiskPaths) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropColumns(gitlabScopeConfigPrRiskPaths20261014{}.TableName(), "pr_migration_paths", "pr_infra_paths")
+}
+
+func (*addPrRiskPaths) Version() uint64 { return 20261014130000 }
+
+func (*addPrRiskPaths) Name() string {
+	return "add pr_migration_paths and pr_infra_paths to _tool_gitlab_scope_configs"
+}
@@ -0,0 +1,56 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addClientTlsToConnections)(nil)
+
+type gitlabConnection20261015 struct {
+	ClientCert string `gorm:"type:text"`
+	ClientKey  string `gorm:"type:text;serializer:encdec"`
+	CaCert     string `gorm:"type:text"`
+}
+
+func (gitlabConnection20261015) TableName() string {
+	return "_tool_gitlab_connections"
+}
+
+type addClientTlsToConnections struct{}
+
+func (script *addClientTlsToConnections) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&gitlabConnection20261015{},
+	)
+}
+
+func (*addClientTlsToConnections) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropColumns(gitlabConnection20261015{}.TableName(), "client_cert", "client_key", "ca_cert")
+}
+
+func (*addClientTlsToConnections) Version() uint64 { return 20261015110000 }
+
+func (*addClientTlsToConnections) Name() string {
+	return "add client_cert, client_key and ca_cert to _tool_gitlab_connections"
+}
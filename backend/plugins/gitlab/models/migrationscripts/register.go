@@ -53,5 +53,12 @@ func All() []plugin.MigrationScript {
 		new(changeIssueComponentType),
 		new(addIsChildToPipelines240906),
 		new(addPrSizeExcludedFileExtensions),
+		new(addDiffPathFilters),
+		new(addSshKeyToConnections),
+		new(addIssueStateEvents),
+		new(addPrRiskPaths),
+		new(addClientTlsToConnections),
+		new(addNoProxyToConnections),
+		new(encryptConnectionProxy),
 	}
 }
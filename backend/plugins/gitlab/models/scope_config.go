@@ -39,6 +39,12 @@ type GitlabScopeConfig struct {
 	Refdiff              datatypes.JSONMap `mapstructure:"refdiff,omitempty" json:"refdiff" swaggertype:"object" format:"json"`
 	// A list of file extensions to exclude when calculating PR Size (affects commit additions/deletions used by dashboards)
 	PrSizeExcludedFileExtensions []string `mapstructure:"prSizeExcludedFileExtensions" json:"prSizeExcludedFileExtensions" gorm:"type:json;serializer:json"`
+	// Glob patterns (e.g. `src/**`, `vendor/**`, `**/*.min.js`) of files to include in / exclude from the commit additions/deletions
+	DiffIncludePaths []string `mapstructure:"diffIncludePaths" json:"diffIncludePaths" gorm:"type:json;serializer:json"`
+	DiffExcludePaths []string `mapstructure:"diffExcludePaths" json:"diffExcludePaths" gorm:"type:json;serializer:json"`
+	// Glob patterns of database migration / infrastructure files, a pull request touching them is flagged as risky
+	PrMigrationPaths []string `mapstructure:"prMigrationPaths" json:"prMigrationPaths" gorm:"type:json;serializer:json"`
+	PrInfraPaths     []string `mapstructure:"prInfraPaths" json:"prInfraPaths" gorm:"type:json;serializer:json"`
 }
 
 func (t GitlabScopeConfig) TableName() string {
@@ -0,0 +1,154 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+)
+
+// getGitlabId returns the global id of an issue or merge request in the response
+func getGitlabId(item json.RawMessage) (string, errors.Error) {
+	var record struct {
+		Id int `json:"id"`
+	}
+	if err := json.Unmarshal(item, &record); err != nil {
+		return "", errors.Default.Wrap(err, "failed to decode the id")
+	}
+	return strconv.Itoa(record.Id), nil
+}
+
+func toGitlabIds(ids []string) ([]int, errors.Error) {
+	gitlabIds := make([]int, len(ids))
+	for i, id := range ids {
+		gitlabId, err := strconv.Atoi(id)
+		if err != nil {
+			return nil, errors.Default.Wrap(err, fmt.Sprintf("invalid gitlab id %s", id))
+		}
+		gitlabIds[i] = gitlabId
+	}
+	return gitlabIds, nil
+}
+
+// deleteRecords deletes the rows of the models whose column is in the values
+func deleteRecords(db dal.Dal, values interface{}, columns map[string][]interface{}, extra ...dal.Clause) errors.Error {
+	for column, entities := range columns {
+		for _, entity := range entities {
+			clauses := append([]dal.Clause{dal.Where(column+" IN ?", values)}, extra...)
+			if err := db.Delete(entity, clauses...); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// deleteIssues removes the issues deleted from gitlab along with their tool layer and domain layer records
+func deleteIssues(taskCtx plugin.SubTaskContext, connectionId uint64, ids []string) errors.Error {
+	gitlabIds, err := toGitlabIds(ids)
+	if err != nil {
+		return err
+	}
+	issueIdGen := didgen.NewDomainIdGenerator(&models.GitlabIssue{})
+	issueIds := make([]string, len(gitlabIds))
+	for i, gitlabId := range gitlabIds {
+		issueIds[i] = issueIdGen.Generate(connectionId, gitlabId)
+	}
+	db := taskCtx.GetDal()
+	err = deleteRecords(db, issueIds, map[string][]interface{}{
+		"id":       {&ticket.Issue{}},
+		"issue_id": {&ticket.BoardIssue{}, &ticket.IssueLabel{}, &ticket.IssueAssignee{}, &ticket.IssueStatusChange{}},
+	})
+	if err != nil {
+		return err
+	}
+	err = deleteRecords(db, gitlabIds, map[string][]interface{}{
+		"gitlab_id": {&models.GitlabIssue{}, &models.GitlabIssueAssignee{}},
+		"issue_id":  {&models.GitlabIssueLabel{}, &models.GitlabIssueStateEvent{}},
+	}, dal.Where("connection_id = ?", connectionId))
+	if err != nil {
+		return err
+	}
+	taskCtx.GetLogger().Info("deleted %d issues which no longer exist in gitlab", len(ids))
+	return nil
+}
+
+// deleteMergeRequests removes the merge requests deleted from gitlab along with their tool layer and domain layer records
+func deleteMergeRequests(taskCtx plugin.SubTaskContext, connectionId uint64, ids []string) errors.Error {
+	gitlabIds, err := toGitlabIds(ids)
+	if err != nil {
+		return err
+	}
+	mrIdGen := didgen.NewDomainIdGenerator(&models.GitlabMergeRequest{})
+	mrIds := make([]string, len(gitlabIds))
+	for i, gitlabId := range gitlabIds {
+		mrIds[i] = mrIdGen.Generate(connectionId, gitlabId)
+	}
+	db := taskCtx.GetDal()
+	err = deleteRecords(db, mrIds, map[string][]interface{}{
+		"id": {&code.PullRequest{}},
+		"pull_request_id": {
+			&code.PullRequestLabel{}, &code.PullRequestCommit{}, &code.PullRequestComment{},
+			&code.PullRequestAssignee{}, &code.PullRequestReviewer{}, &code.PullRequestReviewMetric{},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	err = deleteRecords(db, gitlabIds, map[string][]interface{}{
+		"gitlab_id": {&models.GitlabMergeRequest{}},
+		"mr_id":     {&models.GitlabMrLabel{}},
+		"merge_request_id": {
+			&models.GitlabMrNote{}, &models.GitlabMrComment{}, &models.GitlabMrCommit{},
+			&models.GitlabReviewer{}, &models.GitlabAssignee{},
+		},
+	}, dal.Where("connection_id = ?", connectionId))
+	if err != nil {
+		return err
+	}
+	taskCtx.GetLogger().Info("deleted %d merge requests which no longer exist in gitlab", len(ids))
+	return nil
+}
+
+// newDeletedRecordReconcilerArgs lists the ids of all issues or merge requests of the project
+func newDeletedRecordReconcilerArgs(data *GitlabTaskData, urlTemplate string, toolModel dal.Tabler, deleteRecords func(ids []string) errors.Error) helper.DeletedRecordReconcilerArgs {
+	return helper.DeletedRecordReconcilerArgs{
+		ApiClient:      data.ApiClient,
+		UrlTemplate:    urlTemplate,
+		PageSize:       100,
+		Pagination:     &helper.LinkHeaderPagination{PageSizeParam: "per_page"},
+		ResponseParser: GetRawMessageFromResponse,
+		GetId:          getGitlabId,
+		ToolIdColumn:   "gitlab_id",
+		ToolClauses: []dal.Clause{
+			dal.From(toolModel),
+			dal.Where("connection_id = ? AND project_id = ?", data.Options.ConnectionId, data.Options.ProjectId),
+		},
+		DeleteRecords: deleteRecords,
+	}
+}
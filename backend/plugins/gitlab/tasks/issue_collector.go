@@ -27,6 +27,7 @@ import (
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/plugin"
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
 )
 
 func init() {
@@ -86,5 +87,15 @@ func CollectApiIssues(taskCtx plugin.SubTaskContext) errors.Error {
 		return err
 	}
 
+	err = collectorWithState.InitReconciler(newDeletedRecordReconcilerArgs(
+		data, "projects/{{ .Params.ProjectId }}/issues", &models.GitlabIssue{},
+		func(ids []string) errors.Error {
+			return deleteIssues(taskCtx, data.Options.ConnectionId, ids)
+		},
+	))
+	if err != nil {
+		return err
+	}
+
 	return collectorWithState.Execute()
 }
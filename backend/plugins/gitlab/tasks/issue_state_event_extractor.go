@@ -0,0 +1,81 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ExtractApiIssueStateEventsMeta)
+}
+
+type IssueStateEvent struct {
+	GitlabId     int    `json:"id"`
+	ResourceType string `json:"resource_type"`
+	ResourceId   int    `json:"resource_id"`
+	State        string `json:"state"`
+	User         *struct {
+		Id       int    `json:"id"`
+		Username string `json:"username"`
+	} `json:"user"`
+	GitlabCreatedAt common.Iso8601Time `json:"created_at"`
+}
+
+var ExtractApiIssueStateEventsMeta = plugin.SubTaskMeta{
+	Name:             "Extract Issue State Events",
+	EntryPoint:       ExtractApiIssueStateEvents,
+	EnabledByDefault: true,
+	Description:      "Extract raw issue state events data into tool layer table _tool_gitlab_issue_state_events",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+	Dependencies:     []*plugin.SubTaskMeta{&CollectApiIssueStateEventsMeta},
+}
+
+func ExtractApiIssueStateEvents(subtaskCtx plugin.SubTaskContext) errors.Error {
+	subtaskCommonArgs, data := CreateSubtaskCommonArgs(subtaskCtx, RAW_ISSUE_STATE_EVENTS_TABLE)
+
+	extractor, err := api.NewStatefulApiExtractor(&api.StatefulApiExtractorArgs[IssueStateEvent]{
+		SubtaskCommonArgs: subtaskCommonArgs,
+		Extract: func(event *IssueStateEvent, row *api.RawData) ([]interface{}, errors.Error) {
+			if event.GitlabId == 0 || event.ResourceType != "Issue" {
+				return nil, nil
+			}
+			toolEvent := &models.GitlabIssueStateEvent{
+				ConnectionId:    data.Options.ConnectionId,
+				GitlabId:        event.GitlabId,
+				IssueId:         event.ResourceId,
+				State:           event.State,
+				GitlabCreatedAt: event.GitlabCreatedAt.ToTime(),
+			}
+			if event.User != nil {
+				toolEvent.AuthorUserId = event.User.Id
+				toolEvent.AuthorUsername = event.User.Username
+			}
+			return []interface{}{toolEvent}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
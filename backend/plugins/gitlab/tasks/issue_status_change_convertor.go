@@ -0,0 +1,110 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ConvertIssueStatusChangesMeta)
+}
+
+var ConvertIssueStatusChangesMeta = plugin.SubTaskMeta{
+	Name:             "Convert Issue Status Changes",
+	EntryPoint:       ConvertIssueStatusChanges,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table _tool_gitlab_issue_state_events into domain layer table issue_status_changes",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+	Dependencies:     []*plugin.SubTaskMeta{&ConvertIssuesMeta, &ExtractApiIssueStateEventsMeta},
+}
+
+// gitlab issues only have opened and closed states, a state event records the state the issue moved to
+var gitlabIssueStateEventTransitions = map[string][2]string{
+	"closed":   {"opened", "closed"},
+	"reopened": {"closed", "opened"},
+}
+
+func ConvertIssueStatusChanges(subtaskCtx plugin.SubTaskContext) errors.Error {
+	subtaskCommonArgs, data := CreateSubtaskCommonArgs(subtaskCtx, RAW_ISSUE_STATE_EVENTS_TABLE)
+
+	db := subtaskCtx.GetDal()
+	eventIdGen := didgen.NewDomainIdGenerator(&models.GitlabIssueStateEvent{})
+	issueIdGen := didgen.NewDomainIdGenerator(&models.GitlabIssue{})
+	accountIdGen := didgen.NewDomainIdGenerator(&models.GitlabAccount{})
+
+	converter, err := api.NewStatefulDataConverter(&api.StatefulDataConverterArgs[models.GitlabIssueStateEvent]{
+		SubtaskCommonArgs: subtaskCommonArgs,
+		Input: func(stateManager *api.SubtaskStateManager) (dal.Rows, errors.Error) {
+			clauses := []dal.Clause{
+				dal.Select("e.*"),
+				dal.From("_tool_gitlab_issue_state_events e"),
+				dal.Join("LEFT JOIN _tool_gitlab_issues s ON s.gitlab_id = e.issue_id AND e.connection_id = s.connection_id"),
+				dal.Where("s.project_id = ? AND s.connection_id = ?", data.Options.ProjectId, data.Options.ConnectionId),
+			}
+			if stateManager.IsIncremental() {
+				since := stateManager.GetSince()
+				if since != nil {
+					clauses = append(clauses, dal.Where("e.updated_at >= ? ", since))
+				}
+			}
+			return db.Cursor(clauses...)
+		},
+		Convert: func(event *models.GitlabIssueStateEvent) ([]interface{}, errors.Error) {
+			transition, ok := gitlabIssueStateEventTransitions[event.State]
+			if !ok {
+				return nil, nil
+			}
+			statusChange := &ticket.IssueStatusChange{
+				DomainEntity:       domainlayer.DomainEntity{Id: eventIdGen.Generate(data.Options.ConnectionId, event.GitlabId)},
+				IssueId:            issueIdGen.Generate(data.Options.ConnectionId, event.IssueId),
+				AuthorName:         event.AuthorUsername,
+				FromStatus:         getGitlabIssueStdStatus(transition[0]),
+				ToStatus:           getGitlabIssueStdStatus(transition[1]),
+				OriginalFromStatus: transition[0],
+				OriginalToStatus:   transition[1],
+				ChangedDate:        event.GitlabCreatedAt,
+			}
+			if event.AuthorUserId != 0 {
+				statusChange.AuthorId = accountIdGen.Generate(data.Options.ConnectionId, event.AuthorUserId)
+			}
+			return []interface{}{
+				statusChange,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+func getGitlabIssueStdStatus(state string) string {
+	if state == "opened" {
+		return ticket.TODO
+	}
+	return ticket.DONE
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/plugin"
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
 )
 
 const RAW_MERGE_REQUEST_TABLE = "gitlab_api_merge_requests"
@@ -70,5 +71,15 @@ func CollectApiMergeRequests(taskCtx plugin.SubTaskContext) errors.Error {
 		return err
 	}
 
+	err = apiCollector.InitReconciler(newDeletedRecordReconcilerArgs(
+		data, "projects/{{ .Params.ProjectId }}/merge_requests", &models.GitlabMergeRequest{},
+		func(ids []string) errors.Error {
+			return deleteMergeRequests(taskCtx, data.Options.ConnectionId, ids)
+		},
+	))
+	if err != nil {
+		return err
+	}
+
 	return apiCollector.Execute()
 }
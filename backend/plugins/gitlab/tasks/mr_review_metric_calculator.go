@@ -0,0 +1,45 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&CalculateMrReviewMetricsMeta)
+}
+
+var CalculateMrReviewMetricsMeta = plugin.SubTaskMeta{
+	Name:             "Calculate MR Review Metrics",
+	EntryPoint:       CalculateMrReviewMetrics,
+	EnabledByDefault: true,
+	Description:      "Calculate first review, approval, review rounds and reviewers of merge requests into domain layer table pull_request_review_metrics",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+	Dependencies:     []*plugin.SubTaskMeta{&ConvertApiMergeRequestsMeta, &ConvertMrCommentMeta, &ConvertMrReviewersMeta},
+}
+
+func CalculateMrReviewMetrics(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*GitlabTaskData)
+	repoId := didgen.NewDomainIdGenerator(&models.GitlabProject{}).Generate(data.Options.ConnectionId, data.Options.ProjectId)
+	return api.CalculatePullRequestReviewMetrics(taskCtx, repoId)
+}
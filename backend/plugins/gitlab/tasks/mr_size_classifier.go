@@ -0,0 +1,50 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ClassifyMergeRequestsMeta)
+}
+
+var ClassifyMergeRequestsMeta = plugin.SubTaskMeta{
+	Name:             "Classify MR Sizes",
+	EntryPoint:       ClassifyMergeRequests,
+	EnabledByDefault: true,
+	Description:      "Classify merge requests by size bucket, files touched and migration/infra path rules of the scope config",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+	Dependencies:     []*plugin.SubTaskMeta{&ConvertApiMergeRequestsMeta, &ConvertApiMrCommitsMeta},
+}
+
+func ClassifyMergeRequests(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*GitlabTaskData)
+	repoId := didgen.NewDomainIdGenerator(&models.GitlabProject{}).Generate(data.Options.ConnectionId, data.Options.ProjectId)
+	rules := api.PullRequestPathRules{}
+	if data.Options.ScopeConfig != nil {
+		rules.MigrationPaths = data.Options.ScopeConfig.PrMigrationPaths
+		rules.InfraPaths = data.Options.ScopeConfig.PrInfraPaths
+	}
+	return api.ClassifyPullRequests(taskCtx, repoId, rules)
+}
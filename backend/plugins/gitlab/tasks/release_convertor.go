@@ -0,0 +1,94 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+)
+
+var _ plugin.SubTaskEntryPoint = ConvertReleases
+
+func init() {
+	RegisterSubtaskMeta(&ConvertReleasesMeta)
+}
+
+var ConvertReleasesMeta = plugin.SubTaskMeta{
+	Name:             "Convert Releases",
+	EntryPoint:       ConvertReleases,
+	EnabledByDefault: false,
+	Description:      "Convert tool layer table gitlab_tags with a release into domain layer table releases",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+	Dependencies:     []*plugin.SubTaskMeta{&ExtractTagMeta},
+}
+
+func ConvertReleases(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_TAG_TABLE)
+	db := taskCtx.GetDal()
+
+	// tags are not keyed by project, the raw data params are the only way to tell which project a tag belongs to
+	cursor, err := db.Cursor(
+		dal.From(&models.GitlabTag{}),
+		dal.Where(
+			"connection_id = ? AND _raw_data_table = ? AND _raw_data_params = ? AND release_description != ''",
+			data.Options.ConnectionId, fmt.Sprintf("_raw_%s", RAW_TAG_TABLE), plugin.MarshalScopeParams(rawDataSubTaskArgs.Params),
+		),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	releaseIdGen := didgen.NewDomainIdGenerator(&models.GitlabTag{})
+	projectIdGen := didgen.NewDomainIdGenerator(&models.GitlabProject{})
+	repoId := projectIdGen.Generate(data.Options.ConnectionId, data.Options.ProjectId)
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.GitlabTag{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			gitlabTag := inputRow.(*models.GitlabTag)
+			release := &crossdomain.Release{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: releaseIdGen.Generate(gitlabTag.ConnectionId, data.Options.ProjectId, gitlabTag.Name),
+				},
+				Name:        gitlabTag.Name,
+				TagName:     gitlabTag.Name,
+				Description: gitlabTag.ReleaseDescription,
+				RepoId:      repoId,
+				CommitSha:   gitlabTag.Target,
+			}
+			return []interface{}{release}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
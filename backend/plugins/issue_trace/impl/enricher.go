@@ -90,6 +90,12 @@ func (p IssueTrace) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.ConvertIssueStatusHistoryMeta,
 		// issue_assignee_history
 		tasks.ConvertIssueAssigneeHistoryMeta,
+		// sprint_metrics
+		tasks.CalculateSprintMetricsMeta,
+		// board_status_snapshots, board_flow_snapshots
+		tasks.SnapshotBoardFlowMeta,
+		// issue_worklogs.cost, effort_costs
+		tasks.CalculateWorklogCostsMeta,
 	}
 }
 
@@ -165,12 +171,16 @@ func (p IssueTrace) MakeMetricPluginPipelinePlanV200(projectName string, options
 			{
 				Plugin: "issue_trace",
 				Options: map[string]interface{}{
-					"projectName": projectName,
-					"scopeIds":    op.ScopeIds,
+					"projectName":  projectName,
+					"scopeIds":     op.ScopeIds,
+					"snapshotDays": op.SnapshotDays,
 				},
 				Subtasks: []string{
 					"ConvertIssueStatusHistory",
 					"ConvertIssueAssigneeHistory",
+					"CalculateSprintMetrics",
+					"SnapshotBoardFlow",
+					"CalculateWorklogCosts",
 				},
 			},
 		},
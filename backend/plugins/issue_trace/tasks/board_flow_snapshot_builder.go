@@ -0,0 +1,217 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/issue_trace/utils"
+)
+
+const defaultSnapshotDays = 90
+
+var SnapshotBoardFlowMeta = plugin.SubTaskMeta{
+	Name:             "SnapshotBoardFlow",
+	EntryPoint:       SnapshotBoardFlow,
+	EnabledByDefault: true,
+	Description:      "Take daily snapshots of WIP, throughput and age of issues per board from issue status history",
+}
+
+// IssueStatusSpan is a period of time an issue of the board spent in a status
+type IssueStatusSpan struct {
+	IssueId        string
+	Status         string
+	OriginalStatus string
+	StartDate      time.Time
+	StoryPoint     *float64
+}
+
+func SnapshotBoardFlow(taskCtx plugin.SubTaskContext) errors.Error {
+	logger := taskCtx.GetLogger()
+	data := taskCtx.GetData().(*TaskData)
+	db := taskCtx.GetDal()
+
+	days := data.Options.SnapshotDays
+	if days <= 0 {
+		days = defaultSnapshotDays
+	}
+	to := time.Now().UTC().Truncate(24 * time.Hour)
+	from := to.AddDate(0, 0, 1-days)
+
+	inserter := helper.NewBatchSaveDivider(taskCtx, utils.BATCH_SIZE, "", "")
+	defer inserter.Close()
+	statusInserter, err := inserter.ForType(reflect.TypeOf(&ticket.BoardStatusSnapshot{}))
+	if err != nil {
+		return err
+	}
+	flowInserter, err := inserter.ForType(reflect.TypeOf(&ticket.BoardFlowSnapshot{}))
+	if err != nil {
+		return err
+	}
+	for _, boardId := range data.ScopeIds {
+		if ctxErr := utils.CheckCancel(taskCtx); ctxErr != nil {
+			return ctxErr
+		}
+		logger.Info("taking flow snapshots of board %s from %s", boardId, from.Format(time.DateOnly))
+		for _, table := range []dal.Tabler{&ticket.BoardStatusSnapshot{}, &ticket.BoardFlowSnapshot{}} {
+			err = db.Delete(table, dal.Where("board_id = ? AND snapshot_date >= ?", boardId, from))
+			if err != nil {
+				return errors.Default.Wrap(err, "failed to delete previous board snapshots")
+			}
+		}
+		var spans []*IssueStatusSpan
+		err = db.All(&spans,
+			dal.Select("issue_status_history.issue_id, issue_status_history.status, issue_status_history.original_status, "+
+				"issue_status_history.start_date, issues.story_point"),
+			dal.From("issue_status_history"),
+			dal.Join("INNER JOIN board_issues ON board_issues.issue_id = issue_status_history.issue_id"),
+			dal.Join("INNER JOIN issues ON issues.id = issue_status_history.issue_id"),
+			dal.Where("board_issues.board_id = ?", boardId),
+		)
+		if err != nil {
+			return errors.Default.Wrap(err, "failed to load issue status history")
+		}
+		statusSnapshots, flowSnapshots := buildBoardSnapshots(boardId, spans, from, to)
+		for _, s := range statusSnapshots {
+			if err = statusInserter.Add(s); err != nil {
+				return err
+			}
+		}
+		for _, s := range flowSnapshots {
+			if err = flowInserter.Add(s); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type issueTimeline struct {
+	spans           []*IssueStatusSpan
+	firstInProgress *time.Time
+	completions     []time.Time
+}
+
+// buildBoardSnapshots computes the state of the board at the end of each day between from and to (both inclusive).
+// Each status of an issue lasts until the next one starts, and the last one is still ongoing.
+func buildBoardSnapshots(
+	boardId string,
+	spans []*IssueStatusSpan,
+	from, to time.Time,
+) ([]*ticket.BoardStatusSnapshot, []*ticket.BoardFlowSnapshot) {
+	timelines := make(map[string]*issueTimeline)
+	issueIds := make([]string, 0)
+	for _, span := range spans {
+		timeline, ok := timelines[span.IssueId]
+		if !ok {
+			timeline = &issueTimeline{}
+			timelines[span.IssueId] = timeline
+			issueIds = append(issueIds, span.IssueId)
+		}
+		timeline.spans = append(timeline.spans, span)
+	}
+	sort.Strings(issueIds)
+	for _, timeline := range timelines {
+		sort.SliceStable(timeline.spans, func(i, j int) bool {
+			return timeline.spans[i].StartDate.Before(timeline.spans[j].StartDate)
+		})
+		for i, span := range timeline.spans {
+			if span.Status == ticket.IN_PROGRESS && timeline.firstInProgress == nil {
+				startDate := span.StartDate
+				timeline.firstInProgress = &startDate
+			}
+			if span.Status == ticket.DONE && (i == 0 || timeline.spans[i-1].Status != ticket.DONE) {
+				timeline.completions = append(timeline.completions, span.StartDate)
+			}
+		}
+	}
+
+	statusSnapshots := make([]*ticket.BoardStatusSnapshot, 0)
+	flowSnapshots := make([]*ticket.BoardFlowSnapshot, 0)
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		endOfDay := day.AddDate(0, 0, 1)
+		flow := &ticket.BoardFlowSnapshot{BoardId: boardId, SnapshotDate: day}
+		byStatus := make(map[string]*ticket.BoardStatusSnapshot)
+		columns := make([]string, 0)
+		var wipAgeDays float64
+		for _, issueId := range issueIds {
+			timeline := timelines[issueId]
+			var points float64
+			if timeline.spans[0].StoryPoint != nil {
+				points = *timeline.spans[0].StoryPoint
+			}
+			for _, completedAt := range timeline.completions {
+				if !completedAt.Before(day) && completedAt.Before(endOfDay) {
+					flow.ThroughputIssues++
+					flow.ThroughputPoints += points
+				}
+			}
+			var current *IssueStatusSpan
+			for _, span := range timeline.spans {
+				if span.StartDate.Before(endOfDay) {
+					current = span
+				}
+			}
+			if current == nil {
+				// the issue didn't exist yet
+				continue
+			}
+			snapshot, ok := byStatus[current.OriginalStatus]
+			if !ok {
+				snapshot = &ticket.BoardStatusSnapshot{
+					BoardId:        boardId,
+					SnapshotDate:   day,
+					OriginalStatus: current.OriginalStatus,
+					Status:         current.Status,
+				}
+				byStatus[current.OriginalStatus] = snapshot
+				columns = append(columns, current.OriginalStatus)
+			}
+			ageDays := endOfDay.Sub(current.StartDate).Hours() / 24
+			snapshot.IssueCount++
+			snapshot.StoryPoints += points
+			snapshot.AvgAgeDays += ageDays
+			if ageDays > snapshot.MaxAgeDays {
+				snapshot.MaxAgeDays = ageDays
+			}
+			if current.Status == ticket.IN_PROGRESS {
+				flow.WipIssues++
+				flow.WipPoints += points
+				wipAgeDays += endOfDay.Sub(*timeline.firstInProgress).Hours() / 24
+			}
+		}
+		if flow.WipIssues > 0 {
+			flow.AvgWipAgeDays = wipAgeDays / float64(flow.WipIssues)
+		}
+		sort.Strings(columns)
+		for _, column := range columns {
+			snapshot := byStatus[column]
+			snapshot.AvgAgeDays = snapshot.AvgAgeDays / float64(snapshot.IssueCount)
+			statusSnapshots = append(statusSnapshots, snapshot)
+		}
+		flowSnapshots = append(flowSnapshots, flow)
+	}
+	return statusSnapshots, flowSnapshots
+}
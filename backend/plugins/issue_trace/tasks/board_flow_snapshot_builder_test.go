@@ -0,0 +1,63 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_buildBoardSnapshots(t *testing.T) {
+	day := func(d, h int) time.Time {
+		return time.Date(2023, 5, d, h, 0, 0, 0, time.UTC)
+	}
+	point := func(p float64) *float64 {
+		return &p
+	}
+	spans := []*IssueStatusSpan{
+		{IssueId: "1", Status: ticket.TODO, OriginalStatus: "Open", StartDate: day(1, 10), StoryPoint: point(3)},
+		{IssueId: "1", Status: ticket.IN_PROGRESS, OriginalStatus: "Doing", StartDate: day(2, 10), StoryPoint: point(3)},
+		{IssueId: "1", Status: ticket.DONE, OriginalStatus: "Closed", StartDate: day(3, 10), StoryPoint: point(3)},
+		{IssueId: "2", Status: ticket.IN_PROGRESS, OriginalStatus: "Doing", StartDate: day(2, 12), StoryPoint: point(5)},
+	}
+	statusSnapshots, flowSnapshots := buildBoardSnapshots("jira:JiraBoard:1:2", spans, day(1, 0), day(3, 0))
+
+	assert.Len(t, flowSnapshots, 3)
+	assert.Equal(t, 0, flowSnapshots[0].WipIssues)
+	assert.Equal(t, 2, flowSnapshots[1].WipIssues)
+	assert.Equal(t, float64(8), flowSnapshots[1].WipPoints)
+	assert.Equal(t, 0, flowSnapshots[1].ThroughputIssues)
+	assert.Equal(t, 1, flowSnapshots[2].WipIssues)
+	assert.InDelta(t, 1.5, flowSnapshots[2].AvgWipAgeDays, 0.001)
+	assert.Equal(t, 1, flowSnapshots[2].ThroughputIssues)
+	assert.Equal(t, float64(3), flowSnapshots[2].ThroughputPoints)
+
+	// day 1: Open(1), day 2: Doing(2), day 3: Closed(1) Doing(1)
+	assert.Len(t, statusSnapshots, 4)
+	assert.Equal(t, "Open", statusSnapshots[0].OriginalStatus)
+	assert.Equal(t, day(1, 0), statusSnapshots[0].SnapshotDate)
+	assert.Equal(t, "Doing", statusSnapshots[1].OriginalStatus)
+	assert.Equal(t, 2, statusSnapshots[1].IssueCount)
+	assert.Equal(t, "Closed", statusSnapshots[2].OriginalStatus)
+	assert.Equal(t, ticket.DONE, statusSnapshots[2].Status)
+	assert.Equal(t, "Doing", statusSnapshots[3].OriginalStatus)
+	assert.InDelta(t, 1.5, statusSnapshots[3].MaxAgeDays, 0.001)
+}
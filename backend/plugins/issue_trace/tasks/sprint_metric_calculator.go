@@ -0,0 +1,250 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/issue_trace/utils"
+)
+
+var CalculateSprintMetricsMeta = plugin.SubTaskMeta{
+	Name:             "CalculateSprintMetrics",
+	EntryPoint:       CalculateSprintMetrics,
+	EnabledByDefault: true,
+	Description:      "Calculate sprint health metrics from sprint membership history",
+}
+
+type boardSprint struct {
+	ticket.Sprint
+	BoardId string
+}
+
+// SprintIssueState is an issue that belonged to a sprint at some point
+type SprintIssueState struct {
+	IssueId        string
+	StoryPoint     *float64
+	ResolutionDate *time.Time
+	// InSprint tells whether the issue is currently attached to the sprint according to sprint_issues
+	InSprint bool
+}
+
+// SprintMembershipChange is a changelog entry adding an issue to or removing it from a sprint
+type SprintMembershipChange struct {
+	IssueId     string
+	CreatedDate time.Time
+	Added       bool
+}
+
+type sprintChangelog struct {
+	IssueId           string
+	CreatedDate       time.Time
+	OriginalFromValue string
+	OriginalToValue   string
+}
+
+func CalculateSprintMetrics(taskCtx plugin.SubTaskContext) errors.Error {
+	logger := taskCtx.GetLogger()
+	data := taskCtx.GetData().(*TaskData)
+	db := taskCtx.GetDal()
+
+	var sprints []*boardSprint
+	err := db.All(&sprints,
+		dal.Select("sprints.*, board_sprints.board_id"),
+		dal.From("sprints"),
+		dal.Join("INNER JOIN board_sprints ON board_sprints.sprint_id = sprints.id"),
+		dal.Where("board_sprints.board_id IN ?", data.ScopeIds),
+	)
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to load sprints")
+	}
+	logger.Info("calculating metrics of %d sprints, boards %s", len(sprints), data.ScopeIds)
+	if len(sprints) == 0 {
+		return nil
+	}
+	sprintIds := make([]string, 0, len(sprints))
+	for _, s := range sprints {
+		sprintIds = append(sprintIds, s.Id)
+	}
+	err = db.Delete(&ticket.SprintMetric{}, dal.Where("sprint_id IN ?", sprintIds))
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to delete previous sprint metrics")
+	}
+
+	inserter := helper.NewBatchSaveDivider(taskCtx, utils.BATCH_SIZE, "", "")
+	defer inserter.Close()
+	batchInserter, err := inserter.ForType(reflect.TypeOf(&ticket.SprintMetric{}))
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, sprint := range sprints {
+		if ctxErr := utils.CheckCancel(taskCtx); ctxErr != nil {
+			return ctxErr
+		}
+		if sprint.StartedDate == nil {
+			// the sprint hasn't started yet, nothing was committed
+			continue
+		}
+		issues, changes, err := loadSprintMembership(db, sprint.Id)
+		if err != nil {
+			return err
+		}
+		err = batchInserter.Add(computeSprintMetric(&sprint.Sprint, sprint.BoardId, issues, changes, now))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadSprintMembership returns every issue that was part of the sprint at some point along with the changelogs
+// moving issues in and out of it
+func loadSprintMembership(db dal.Dal, sprintId string) ([]*SprintIssueState, []*SprintMembershipChange, errors.Error) {
+	var changelogs []*sprintChangelog
+	err := db.All(&changelogs,
+		dal.Select("issue_id, created_date, original_from_value, original_to_value"),
+		dal.From("issue_changelogs"),
+		dal.Where("field_name = 'Sprint' AND (original_from_value LIKE ? OR original_to_value LIKE ?)",
+			"%"+sprintId+"%", "%"+sprintId+"%"),
+		dal.Orderby("created_date ASC"),
+	)
+	if err != nil {
+		return nil, nil, errors.Default.Wrap(err, "failed to load sprint changelogs")
+	}
+	changes := make([]*SprintMembershipChange, 0, len(changelogs))
+	issueIds := make([]string, 0)
+	for _, cl := range changelogs {
+		removed, added := utils.ResolveMultiChangelogs(cl.OriginalFromValue, cl.OriginalToValue)
+		var change *SprintMembershipChange
+		if utils.StringContains(added, sprintId) {
+			change = &SprintMembershipChange{IssueId: cl.IssueId, CreatedDate: cl.CreatedDate, Added: true}
+		} else if utils.StringContains(removed, sprintId) {
+			change = &SprintMembershipChange{IssueId: cl.IssueId, CreatedDate: cl.CreatedDate, Added: false}
+		} else {
+			continue
+		}
+		changes = append(changes, change)
+		if !utils.StringContains(issueIds, cl.IssueId) {
+			issueIds = append(issueIds, cl.IssueId)
+		}
+	}
+
+	var issues []*SprintIssueState
+	err = db.All(&issues,
+		dal.Select("issues.id AS issue_id, issues.story_point, issues.resolution_date, sprint_issues.sprint_id IS NOT NULL AS in_sprint"),
+		dal.From("issues"),
+		dal.Join("LEFT JOIN sprint_issues ON sprint_issues.issue_id = issues.id AND sprint_issues.sprint_id = ?", sprintId),
+		dal.Where("sprint_issues.sprint_id IS NOT NULL OR issues.id IN ?", append(issueIds, "")),
+	)
+	if err != nil {
+		return nil, nil, errors.Default.Wrap(err, "failed to load sprint issues")
+	}
+	return issues, changes, nil
+}
+
+// computeSprintMetric replays the membership changes of every issue to find out whether it was in the sprint when
+// the sprint started and when it ended. Issues without any change are considered to have been in the sprint
+// for its whole duration when they are attached to it.
+func computeSprintMetric(
+	sprint *ticket.Sprint,
+	boardId string,
+	issues []*SprintIssueState,
+	changes []*SprintMembershipChange,
+	now time.Time,
+) *ticket.SprintMetric {
+	metric := &ticket.SprintMetric{
+		SprintId:    sprint.Id,
+		BoardId:     boardId,
+		SprintName:  sprint.Name,
+		StartedDate: sprint.StartedDate,
+		EndedDate:   sprint.EndedDate,
+	}
+	endDate := now
+	if sprint.CompletedDate != nil {
+		endDate = *sprint.CompletedDate
+	} else if sprint.EndedDate != nil && sprint.EndedDate.Before(now) {
+		endDate = *sprint.EndedDate
+	}
+
+	changesByIssue := make(map[string][]*SprintMembershipChange)
+	for _, c := range changes {
+		changesByIssue[c.IssueId] = append(changesByIssue[c.IssueId], c)
+	}
+	for _, issue := range issues {
+		issueChanges := changesByIssue[issue.IssueId]
+		sort.SliceStable(issueChanges, func(i, j int) bool {
+			return issueChanges[i].CreatedDate.Before(issueChanges[j].CreatedDate)
+		})
+		memberAt := func(t time.Time) bool {
+			if len(issueChanges) == 0 {
+				return issue.InSprint
+			}
+			member := !issueChanges[0].Added
+			for _, c := range issueChanges {
+				if c.CreatedDate.After(t) {
+					break
+				}
+				member = c.Added
+			}
+			return member
+		}
+		joinedLater := false
+		for _, c := range issueChanges {
+			if c.Added && c.CreatedDate.After(*sprint.StartedDate) && !c.CreatedDate.After(endDate) {
+				joinedLater = true
+				break
+			}
+		}
+		var points float64
+		if issue.StoryPoint != nil {
+			points = *issue.StoryPoint
+		}
+		atStart := memberAt(*sprint.StartedDate)
+		atEnd := memberAt(endDate)
+		if atStart {
+			metric.CommittedIssues++
+			metric.CommittedPoints += points
+			if !atEnd {
+				metric.RemovedIssues++
+				metric.RemovedPoints += points
+			}
+		} else if joinedLater {
+			metric.AddedIssues++
+			metric.AddedPoints += points
+		}
+		if !atEnd {
+			continue
+		}
+		if issue.ResolutionDate != nil && !issue.ResolutionDate.After(endDate) {
+			metric.CompletedIssues++
+			metric.CompletedPoints += points
+		} else {
+			metric.CarryoverIssues++
+			metric.CarryoverPoints += points
+		}
+	}
+	return metric
+}
@@ -0,0 +1,74 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_computeSprintMetric(t *testing.T) {
+	day := func(d int) *time.Time {
+		v := time.Date(2023, 5, d, 0, 0, 0, 0, time.UTC)
+		return &v
+	}
+	point := func(p float64) *float64 {
+		return &p
+	}
+	sprint := &ticket.Sprint{
+		DomainEntity:  domainlayer.DomainEntity{Id: "jira:JiraSprint:1:10"},
+		Name:          "Sprint 10",
+		StartedDate:   day(1),
+		EndedDate:     day(14),
+		CompletedDate: day(15),
+	}
+	issues := []*SprintIssueState{
+		// committed and completed
+		{IssueId: "1", StoryPoint: point(3), ResolutionDate: day(10), InSprint: true},
+		// committed and carried over
+		{IssueId: "2", StoryPoint: point(5), InSprint: true},
+		// added mid-sprint and completed
+		{IssueId: "3", StoryPoint: point(2), ResolutionDate: day(12), InSprint: true},
+		// committed then moved out of the sprint
+		{IssueId: "4", StoryPoint: point(8), InSprint: false},
+		// committed, resolved after the sprint was completed
+		{IssueId: "5", StoryPoint: nil, ResolutionDate: day(20), InSprint: true},
+	}
+	changes := []*SprintMembershipChange{
+		{IssueId: "3", CreatedDate: *day(5), Added: true},
+		{IssueId: "4", CreatedDate: *day(7), Added: false},
+	}
+	metric := computeSprintMetric(sprint, "jira:JiraBoard:1:2", issues, changes, *day(30))
+
+	assert.Equal(t, "jira:JiraSprint:1:10", metric.SprintId)
+	assert.Equal(t, "jira:JiraBoard:1:2", metric.BoardId)
+	assert.Equal(t, 4, metric.CommittedIssues)
+	assert.Equal(t, float64(16), metric.CommittedPoints)
+	assert.Equal(t, 1, metric.AddedIssues)
+	assert.Equal(t, float64(2), metric.AddedPoints)
+	assert.Equal(t, 1, metric.RemovedIssues)
+	assert.Equal(t, float64(8), metric.RemovedPoints)
+	assert.Equal(t, 2, metric.CompletedIssues)
+	assert.Equal(t, float64(5), metric.CompletedPoints)
+	assert.Equal(t, 2, metric.CarryoverIssues)
+	assert.Equal(t, float64(5), metric.CarryoverPoints)
+}
@@ -22,6 +22,8 @@ type Options struct {
 	Plugin      string   `json:"plugin"`   // jira
 	ScopeIds    []string `json:"scopeIds"` // 68
 	ProjectName string   `json:"projectName"`
+	// SnapshotDays is how many days of board flow snapshots are rebuilt, defaults to 90
+	SnapshotDays int `json:"snapshotDays"`
 }
 
 // TaskData converted parameter
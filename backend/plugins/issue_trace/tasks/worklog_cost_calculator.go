@@ -0,0 +1,223 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/issue_trace/utils"
+)
+
+var CalculateWorklogCostsMeta = plugin.SubTaskMeta{
+	Name:             "CalculateWorklogCosts",
+	EntryPoint:       CalculateWorklogCosts,
+	EnabledByDefault: true,
+	Description:      "Price worklogs with the hourly rates configured in the org plugin and roll up effort costs per issue, epic and project",
+}
+
+// CostedWorklog is a worklog along with the issue fields needed to roll it up
+type CostedWorklog struct {
+	ticket.IssueWorklog
+	EpicKey string
+	DueDate *time.Time
+}
+
+// hourlyRateResolver finds the rate of the author of a worklog, falling back to the first team of the user
+// having a rate
+type hourlyRateResolver struct {
+	userRates    map[string]float64
+	teamRates    map[string]float64
+	accountUsers map[string]string
+	userTeams    map[string][]string
+}
+
+func (r *hourlyRateResolver) rateOf(accountId string) (float64, bool) {
+	userId, ok := r.accountUsers[accountId]
+	if !ok {
+		return 0, false
+	}
+	if rate, ok := r.userRates[userId]; ok {
+		return rate, true
+	}
+	for _, teamId := range r.userTeams[userId] {
+		if rate, ok := r.teamRates[teamId]; ok {
+			return rate, true
+		}
+	}
+	return 0, false
+}
+
+func loadHourlyRateResolver(db dal.Dal) (*hourlyRateResolver, errors.Error) {
+	resolver := &hourlyRateResolver{
+		userRates:    make(map[string]float64),
+		teamRates:    make(map[string]float64),
+		accountUsers: make(map[string]string),
+		userTeams:    make(map[string][]string),
+	}
+	var rates []*crossdomain.HourlyRate
+	if err := db.All(&rates); err != nil {
+		return nil, errors.Default.Wrap(err, "failed to load hourly rates")
+	}
+	for _, rate := range rates {
+		if rate.SubjectType == crossdomain.HOURLY_RATE_USER {
+			resolver.userRates[rate.SubjectId] = rate.Rate
+		} else {
+			resolver.teamRates[rate.SubjectId] = rate.Rate
+		}
+	}
+	var userAccounts []*crossdomain.UserAccount
+	if err := db.All(&userAccounts); err != nil {
+		return nil, errors.Default.Wrap(err, "failed to load user accounts")
+	}
+	for _, ua := range userAccounts {
+		resolver.accountUsers[ua.AccountId] = ua.UserId
+	}
+	var teamUsers []*crossdomain.TeamUser
+	if err := db.All(&teamUsers, dal.Orderby("team_id")); err != nil {
+		return nil, errors.Default.Wrap(err, "failed to load team users")
+	}
+	for _, tu := range teamUsers {
+		resolver.userTeams[tu.UserId] = append(resolver.userTeams[tu.UserId], tu.TeamId)
+	}
+	return resolver, nil
+}
+
+func CalculateWorklogCosts(taskCtx plugin.SubTaskContext) errors.Error {
+	logger := taskCtx.GetLogger()
+	data := taskCtx.GetData().(*TaskData)
+	db := taskCtx.GetDal()
+
+	resolver, err := loadHourlyRateResolver(db)
+	if err != nil {
+		return err
+	}
+	if len(resolver.userRates) == 0 && len(resolver.teamRates) == 0 {
+		logger.Info("no hourly rate configured, skip worklog costing")
+		return nil
+	}
+
+	cursor, err := db.Cursor(
+		dal.Select("DISTINCT issue_worklogs.*, issues.epic_key, issues.due_date"),
+		dal.From("issue_worklogs"),
+		dal.Join("INNER JOIN issues ON issues.id = issue_worklogs.issue_id"),
+		dal.Join("INNER JOIN board_issues ON board_issues.issue_id = issue_worklogs.issue_id"),
+		dal.Where("board_issues.board_id IN ?", data.ScopeIds),
+	)
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to query worklogs")
+	}
+	defer cursor.Close()
+
+	inserter := helper.NewBatchSaveDivider(taskCtx, utils.BATCH_SIZE, "", "")
+	defer inserter.Close()
+	worklogInserter, err := inserter.ForType(reflect.TypeOf(&ticket.IssueWorklog{}))
+	if err != nil {
+		return err
+	}
+	worklogs := make([]*CostedWorklog, 0)
+	for cursor.Next() {
+		if ctxErr := utils.CheckCancel(taskCtx); ctxErr != nil {
+			return ctxErr
+		}
+		worklog := &CostedWorklog{}
+		if err = db.Fetch(cursor, worklog); err != nil {
+			return errors.Default.Wrap(err, "failed to fetch worklog")
+		}
+		worklog.Cost = nil
+		if rate, ok := resolver.rateOf(worklog.AuthorId); ok {
+			cost := float64(worklog.TimeSpentMinutes) / 60 * rate
+			worklog.Cost = &cost
+		}
+		if err = worklogInserter.Add(&worklog.IssueWorklog); err != nil {
+			return err
+		}
+		worklogs = append(worklogs, worklog)
+	}
+
+	err = db.Delete(&ticket.EffortCost{}, dal.Where("project_name = ?", data.ProjectName))
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to delete previous effort costs")
+	}
+	effortCostInserter, err := inserter.ForType(reflect.TypeOf(&ticket.EffortCost{}))
+	if err != nil {
+		return err
+	}
+	for _, effortCost := range rollupEffortCosts(data.ProjectName, worklogs) {
+		if err = effortCostInserter.Add(effortCost); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rollupEffortCosts sums the time spent and cost of the worklogs per issue, per epic and, when the task runs for a
+// project, for the whole project. Worklogs without a cost still count toward the time spent.
+func rollupEffortCosts(projectName string, worklogs []*CostedWorklog) []*ticket.EffortCost {
+	rollups := make(map[string]map[string]*ticket.EffortCost)
+	add := func(level, itemId string, worklog *CostedWorklog) {
+		if rollups[level] == nil {
+			rollups[level] = make(map[string]*ticket.EffortCost)
+		}
+		effortCost, ok := rollups[level][itemId]
+		if !ok {
+			effortCost = &ticket.EffortCost{ProjectName: projectName, Level: level, ItemId: itemId}
+			rollups[level][itemId] = effortCost
+		}
+		effortCost.TimeSpentMinutes += worklog.TimeSpentMinutes
+		if worklog.Cost == nil {
+			return
+		}
+		effortCost.Cost += *worklog.Cost
+		workedDate := worklog.StartedDate
+		if workedDate == nil {
+			workedDate = worklog.LoggedDate
+		}
+		if worklog.DueDate != nil && workedDate != nil && workedDate.After(*worklog.DueDate) {
+			effortCost.CostOfDelay += *worklog.Cost
+		}
+	}
+	for _, worklog := range worklogs {
+		add(ticket.EFFORT_COST_ISSUE, worklog.IssueId, worklog)
+		if worklog.EpicKey != "" {
+			add(ticket.EFFORT_COST_EPIC, worklog.EpicKey, worklog)
+		}
+		if projectName != "" {
+			add(ticket.EFFORT_COST_PROJECT, projectName, worklog)
+		}
+	}
+	result := make([]*ticket.EffortCost, 0)
+	for _, level := range []string{ticket.EFFORT_COST_ISSUE, ticket.EFFORT_COST_EPIC, ticket.EFFORT_COST_PROJECT} {
+		itemIds := make([]string, 0, len(rollups[level]))
+		for itemId := range rollups[level] {
+			itemIds = append(itemIds, itemId)
+		}
+		sort.Strings(itemIds)
+		for _, itemId := range itemIds {
+			result = append(result, rollups[level][itemId])
+		}
+	}
+	return result
+}
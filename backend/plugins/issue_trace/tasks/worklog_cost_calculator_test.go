@@ -0,0 +1,79 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_hourlyRateResolver(t *testing.T) {
+	resolver := &hourlyRateResolver{
+		userRates:    map[string]float64{"u1": 100},
+		teamRates:    map[string]float64{"t2": 50},
+		accountUsers: map[string]string{"a1": "u1", "a2": "u2", "a3": "u3"},
+		userTeams:    map[string][]string{"u1": {"t2"}, "u2": {"t1", "t2"}},
+	}
+	rate, ok := resolver.rateOf("a1")
+	assert.True(t, ok)
+	assert.Equal(t, float64(100), rate)
+	rate, ok = resolver.rateOf("a2")
+	assert.True(t, ok)
+	assert.Equal(t, float64(50), rate)
+	_, ok = resolver.rateOf("a3")
+	assert.False(t, ok)
+	_, ok = resolver.rateOf("unknown")
+	assert.False(t, ok)
+}
+
+func Test_rollupEffortCosts(t *testing.T) {
+	day := func(d int) *time.Time {
+		v := time.Date(2023, 5, d, 0, 0, 0, 0, time.UTC)
+		return &v
+	}
+	cost := func(c float64) *float64 {
+		return &c
+	}
+	worklog := func(issueId, epicKey string, minutes int, c *float64, started, due *time.Time) *CostedWorklog {
+		w := &CostedWorklog{EpicKey: epicKey, DueDate: due}
+		w.IssueId = issueId
+		w.TimeSpentMinutes = minutes
+		w.Cost = c
+		w.StartedDate = started
+		return w
+	}
+	worklogs := []*CostedWorklog{
+		worklog("i1", "E-1", 60, cost(100), day(1), day(5)),
+		worklog("i1", "E-1", 120, cost(200), day(6), day(5)),
+		worklog("i2", "E-1", 30, nil, day(6), day(5)),
+		worklog("i3", "", 90, cost(75), day(2), nil),
+	}
+	got := rollupEffortCosts("proj", worklogs)
+	assert.Equal(t, []*ticket.EffortCost{
+		{ProjectName: "proj", Level: ticket.EFFORT_COST_ISSUE, ItemId: "i1", TimeSpentMinutes: 180, Cost: 300, CostOfDelay: 200},
+		{ProjectName: "proj", Level: ticket.EFFORT_COST_ISSUE, ItemId: "i2", TimeSpentMinutes: 30},
+		{ProjectName: "proj", Level: ticket.EFFORT_COST_ISSUE, ItemId: "i3", TimeSpentMinutes: 90, Cost: 75},
+		{ProjectName: "proj", Level: ticket.EFFORT_COST_EPIC, ItemId: "E-1", TimeSpentMinutes: 210, Cost: 300, CostOfDelay: 200},
+		{ProjectName: "proj", Level: ticket.EFFORT_COST_PROJECT, ItemId: "proj", TimeSpentMinutes: 300, Cost: 375, CostOfDelay: 200},
+	}, got)
+
+	assert.Len(t, rollupEffortCosts("", worklogs), 4)
+}
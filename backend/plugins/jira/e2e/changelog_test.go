@@ -116,6 +116,7 @@ func TestIssueChangelogDataFlow(t *testing.T) {
 	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_jira_statuses_for_changelog.csv", &models.JiraStatus{})
 	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_jira_board_issues_for_changelog.csv", &models.JiraBoardIssue{})
 	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_jira_issue_fields.csv", &models.JiraIssueField{})
+	dataflowTester.FlushTabler(&ticket.IssueStatusChange{})
 	dataflowTester.FlushTabler(&ticket.IssueChangelogs{})
 	dataflowTester.Subtask(tasks.ConvertIssueChangelogsMeta, taskData)
 	dataflowTester.VerifyTable(
@@ -135,4 +136,19 @@ func TestIssueChangelogDataFlow(t *testing.T) {
 			"created_date",
 		),
 	)
+	dataflowTester.VerifyTable(
+		ticket.IssueStatusChange{},
+		"./snapshot_tables/issue_status_changes.csv",
+		e2ehelper.ColumnWithRawData(
+			"id",
+			"issue_id",
+			"author_id",
+			"author_name",
+			"from_status",
+			"to_status",
+			"original_from_status",
+			"original_to_status",
+			"changed_date",
+		),
+	)
 }
@@ -0,0 +1,70 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/jira/impl"
+	"github.com/apache/incubator-devlake/plugins/jira/models"
+	"github.com/apache/incubator-devlake/plugins/jira/tasks"
+)
+
+func TestPostmortemDataFlow(t *testing.T) {
+	var plugin impl.Jira
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "jira", plugin)
+
+	taskData := &tasks.JiraTaskData{
+		Options: &tasks.JiraOptions{
+			ConnectionId: 2,
+			BoardId:      8,
+		},
+	}
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_jira_issues_for_postmortems.csv", &models.JiraIssue{})
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_jira_board_issues_for_postmortems.csv", &models.JiraBoardIssue{})
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_jira_issue_relationships_for_postmortems.csv", &models.JiraIssueRelationship{})
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_jira_issue_labels_for_postmortems.csv", &models.JiraIssueLabel{})
+
+	// verify conversion
+	dataflowTester.FlushTabler(&ticket.Postmortem{})
+	dataflowTester.FlushTabler(&ticket.PostmortemContributingFactor{})
+	dataflowTester.Subtask(tasks.ConvertPostmortemsMeta, taskData)
+	dataflowTester.VerifyTable(
+		ticket.Postmortem{},
+		"./snapshot_tables/postmortems.csv",
+		[]string{
+			"id",
+			"incident_id",
+			"title",
+			"url",
+			"status",
+			"original_status",
+			"created_date",
+			"completed_date",
+			"action_item_count",
+			"completed_action_item_count",
+		},
+	)
+	dataflowTester.VerifyTable(
+		ticket.PostmortemContributingFactor{},
+		"./snapshot_tables/postmortem_contributing_factors.csv",
+		[]string{"postmortem_id", "factor"},
+	)
+}
@@ -43,11 +43,16 @@ type JiraConn struct {
 	helper.MultiAuth      `mapstructure:",squash"`
 	helper.BasicAuth      `mapstructure:",squash"`
 	helper.AccessToken    `mapstructure:",squash"`
+	// OAuth2 authenticates against Jira Cloud through an OAuth 2.0 app, the rotated tokens are saved to the connection
+	helper.OAuth2 `mapstructure:",squash"`
 }
 
 func (jc *JiraConn) Sanitize() JiraConn {
 	jc.Password = ""
 	jc.AccessToken.Token = utils.SanitizeString(jc.AccessToken.Token)
+	jc.OAuth2.ClientSecret = utils.SanitizeString(jc.OAuth2.ClientSecret)
+	jc.OAuth2.AccessToken = ""
+	jc.OAuth2.RefreshToken = utils.SanitizeString(jc.OAuth2.RefreshToken)
 	return *jc
 }
 
@@ -71,6 +76,9 @@ func (connection *JiraConnection) MergeFromRequest(target *JiraConnection, body
 	token := target.Token
 	password := target.Password
 	authMethod := target.AuthMethod
+	clientSecret := target.ClientSecret
+	oauth2AccessToken := target.OAuth2.AccessToken
+	refreshToken := target.RefreshToken
 
 	if err := helper.DecodeMapStruct(body, target, true); err != nil {
 		return err
@@ -88,6 +96,16 @@ func (connection *JiraConnection) MergeFromRequest(target *JiraConnection, body
 		if modifiedPassword == "" || modifiedPassword == utils.SanitizeString(password) {
 			target.Password = password
 		}
+		if target.ClientSecret == "" || target.ClientSecret == utils.SanitizeString(clientSecret) {
+			target.ClientSecret = clientSecret
+		}
+		// the access token is never sent back, it is kept unless the refresh token is replaced
+		if target.RefreshToken == "" || target.RefreshToken == utils.SanitizeString(refreshToken) {
+			target.RefreshToken = refreshToken
+			if target.OAuth2.AccessToken == "" {
+				target.OAuth2.AccessToken = oauth2AccessToken
+			}
+		}
 	}
 
 	return nil
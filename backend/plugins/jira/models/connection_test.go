@@ -0,0 +1,86 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+)
+
+func newOAuth2Connection() *JiraConnection {
+	expiresAt := time.Now().Add(time.Hour)
+	connection := &JiraConnection{}
+	connection.ID = 1
+	connection.Name = "jira"
+	connection.Endpoint = "https://api.atlassian.com/ex/jira/cloud-id/rest/"
+	connection.AuthMethod = plugin.AUTH_METHOD_OAUTH2
+	connection.TokenUrl = "https://auth.atlassian.com/oauth/token"
+	connection.ClientId = "client"
+	connection.ClientSecret = "client-secret"
+	connection.OAuth2.AccessToken = "access-token"
+	connection.RefreshToken = "refresh-token"
+	connection.TokenExpiresAt = &expiresAt
+	return connection
+}
+
+func TestJiraConnectionOAuth2(t *testing.T) {
+	connection := newOAuth2Connection()
+	var _ plugin.ToolLayerConnection = connection
+
+	// the basic auth and access token fields aren't required
+	assert.Nil(t, connection.ValidateConnection(connection, validator.New()))
+
+	req := httptest.NewRequest("GET", "/", nil)
+	assert.Nil(t, connection.SetupAuthentication(req))
+	assert.Equal(t, "Bearer access-token", req.Header.Get("Authorization"))
+	_, ok := connection.GetOAuth2Authenticator().(*helper.OAuth2)
+	assert.True(t, ok)
+}
+
+func TestJiraConnectionOAuth2Sanitize(t *testing.T) {
+	sanitized := newOAuth2Connection().Sanitize()
+	assert.NotEqual(t, "client-secret", sanitized.ClientSecret)
+	assert.NotEqual(t, "refresh-token", sanitized.RefreshToken)
+	assert.Empty(t, sanitized.OAuth2.AccessToken)
+
+	// the masked secrets sent back are kept
+	target := newOAuth2Connection()
+	err := target.MergeFromRequest(target, map[string]interface{}{
+		"name":         "jira",
+		"clientSecret": sanitized.ClientSecret,
+		"refreshToken": sanitized.RefreshToken,
+		"accessToken":  "",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "client-secret", target.ClientSecret)
+	assert.Equal(t, "refresh-token", target.RefreshToken)
+	assert.Equal(t, "access-token", target.OAuth2.AccessToken)
+
+	// a new refresh token drops the access token issued for the old one
+	target = newOAuth2Connection()
+	err = target.MergeFromRequest(target, map[string]interface{}{"refreshToken": "new-refresh-token", "accessToken": ""})
+	assert.Nil(t, err)
+	assert.Equal(t, "new-refresh-token", target.RefreshToken)
+	assert.Empty(t, target.OAuth2.AccessToken)
+}
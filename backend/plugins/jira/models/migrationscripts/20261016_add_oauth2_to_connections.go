@@ -0,0 +1,58 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addOAuth2ToConnections)(nil)
+
+type jiraConnection20261016 struct {
+	TokenUrl       string `gorm:"type:varchar(255)"`
+	ClientId       string `gorm:"type:varchar(255)"`
+	ClientSecret   string `gorm:"type:text"`
+	AccessToken    string `gorm:"type:text"`
+	RefreshToken   string `gorm:"type:text"`
+	TokenExpiresAt *time.Time
+}
+
+func (jiraConnection20261016) TableName() string {
+	return "_tool_jira_connections"
+}
+
+type addOAuth2ToConnections struct{}
+
+func (*addOAuth2ToConnections) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, &jiraConnection20261016{})
+}
+
+func (*addOAuth2ToConnections) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropColumns(jiraConnection20261016{}.TableName(), "token_url", "client_id", "client_secret", "access_token", "refresh_token", "token_expires_at")
+}
+
+func (*addOAuth2ToConnections) Version() uint64 { return 20261016110000 }
+
+func (*addOAuth2ToConnections) Name() string {
+	return "add the oauth2 columns to _tool_jira_connections"
+}
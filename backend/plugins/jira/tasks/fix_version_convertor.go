@@ -0,0 +1,115 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/jira/models"
+)
+
+var ConvertFixVersionsMeta = plugin.SubTaskMeta{
+	Name:             "convertFixVersions",
+	EntryPoint:       ConvertFixVersions,
+	EnabledByDefault: true,
+	Description:      "Convert the fix versions of jira issues into domain layer tables releases and release_issues",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+// ConvertFixVersions turns every fix version of the board issues into a release of the board, with the issues
+// planned for the version as the release issues
+func ConvertFixVersions(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*JiraTaskData)
+	connectionId := data.Options.ConnectionId
+	boardId := data.Options.BoardId
+
+	cursor, err := db.Cursor(
+		dal.Select("_tool_jira_issues.*"),
+		dal.From("_tool_jira_issues"),
+		dal.Join(`left join _tool_jira_board_issues
+			on _tool_jira_board_issues.issue_id = _tool_jira_issues.issue_id
+			and _tool_jira_board_issues.connection_id = _tool_jira_issues.connection_id`),
+		dal.Where(
+			"_tool_jira_board_issues.connection_id = ? AND _tool_jira_board_issues.board_id = ? AND _tool_jira_issues.fix_versions != ''",
+			connectionId, boardId,
+		),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	issueIdGen := didgen.NewDomainIdGenerator(&models.JiraIssue{})
+	boardIdGen := didgen.NewDomainIdGenerator(&models.JiraBoard{})
+	domainBoardId := boardIdGen.Generate(connectionId, boardId)
+	converter, err := helper.NewDataConverter(helper.DataConverterArgs{
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: JiraApiParams{
+				ConnectionId: connectionId,
+				BoardId:      boardId,
+			},
+			Table: RAW_ISSUE_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.JiraIssue{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			jiraIssue := inputRow.(*models.JiraIssue)
+			var result []interface{}
+			for _, name := range strings.Split(jiraIssue.FixVersions, ",") {
+				name = strings.TrimSpace(name)
+				if name == "" {
+					continue
+				}
+				releaseId := fixVersionReleaseId(connectionId, boardId, name)
+				result = append(result,
+					&crossdomain.Release{
+						DomainEntity: domainlayer.DomainEntity{Id: releaseId},
+						Name:         name,
+						BoardId:      domainBoardId,
+					},
+					&crossdomain.ReleaseIssue{
+						ReleaseId: releaseId,
+						IssueId:   issueIdGen.Generate(connectionId, jiraIssue.IssueId),
+					},
+				)
+			}
+			return result, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+// fixVersionReleaseId follows the didgen format, jira only stores the names of the fix versions of an issue
+func fixVersionReleaseId(connectionId uint64, boardId uint64, name string) string {
+	return fmt.Sprintf("jira:JiraFixVersion:%d:%d:%s", connectionId, boardId, name)
+}
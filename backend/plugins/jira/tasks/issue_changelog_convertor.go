@@ -151,6 +151,7 @@ func ConvertIssueChangelogs(subtaskCtx plugin.SubTaskContext) errors.Error {
 					changelog.OriginalToValue = toStatus.Name
 					changelog.ToValue = getStdStatus(toStatus.StatusCategory)
 				}
+				return []interface{}{changelog, ticket.NewIssueStatusChange(changelog)}, nil
 			default:
 				if v, ok := issueFieldMap[row.Field]; ok && v.SchemaType == "user" {
 					if row.FromValue != "" {
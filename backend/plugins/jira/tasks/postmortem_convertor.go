@@ -0,0 +1,153 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/jira/models"
+)
+
+var ConvertPostmortemsMeta = plugin.SubTaskMeta{
+	Name:             "convertPostmortems",
+	EntryPoint:       ConvertPostmortems,
+	EnabledByDefault: true,
+	Description:      "Convert jira issues mapped to the POSTMORTEM type into domain layer table postmortems",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+// ConvertPostmortems converts the issues mapped to ticket.POSTMORTEM in the type mappings. The incident is the
+// linked issue of the INCIDENT type, the action items are the subtasks, and the labels are the contributing factors.
+func ConvertPostmortems(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*JiraTaskData)
+	connectionId := data.Options.ConnectionId
+
+	cursor, err := db.Cursor(
+		dal.Select("_tool_jira_issues.*"),
+		dal.From("_tool_jira_issues"),
+		dal.Join(`left join _tool_jira_board_issues
+			on _tool_jira_board_issues.issue_id = _tool_jira_issues.issue_id
+			and _tool_jira_board_issues.connection_id = _tool_jira_issues.connection_id`),
+		dal.Where(
+			"_tool_jira_board_issues.connection_id = ? AND _tool_jira_board_issues.board_id = ? AND _tool_jira_issues.std_type = ?",
+			connectionId, data.Options.BoardId, ticket.POSTMORTEM,
+		),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	issueIdGen := didgen.NewDomainIdGenerator(&models.JiraIssue{})
+	converter, err := helper.NewDataConverter(helper.DataConverterArgs{
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: JiraApiParams{
+				ConnectionId: connectionId,
+				BoardId:      data.Options.BoardId,
+			},
+			Table: RAW_ISSUE_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.JiraIssue{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			jiraIssue := inputRow.(*models.JiraIssue)
+			postmortem := &ticket.Postmortem{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: issueIdGen.Generate(connectionId, jiraIssue.IssueId),
+				},
+				Title:          jiraIssue.Summary,
+				Url:            convertURL(jiraIssue.Self, jiraIssue.IssueKey),
+				Status:         jiraIssue.StdStatus,
+				OriginalStatus: jiraIssue.StatusName,
+				CreatedDate:    &jiraIssue.Created,
+			}
+			if jiraIssue.StdStatus == ticket.DONE {
+				postmortem.CompletedDate = jiraIssue.ResolutionDate
+				if postmortem.CompletedDate == nil {
+					postmortem.CompletedDate = &jiraIssue.Updated
+				}
+			}
+
+			var incidentIds []uint64
+			err := db.Pluck(
+				"i.issue_id",
+				&incidentIds,
+				dal.From("_tool_jira_issue_relationships r"),
+				dal.Join(`INNER JOIN _tool_jira_issues i ON i.connection_id = r.connection_id
+					AND (i.issue_id = r.inward_issue_id OR i.issue_id = r.outward_issue_id)`),
+				dal.Where("r.connection_id = ? AND r.issue_id = ? AND i.std_type = ?", connectionId, jiraIssue.IssueId, ticket.INCIDENT),
+				dal.Orderby("i.issue_id"),
+			)
+			if err != nil {
+				return nil, err
+			}
+			if len(incidentIds) > 0 {
+				postmortem.IncidentId = issueIdGen.Generate(connectionId, incidentIds[0])
+			}
+
+			var actionItemStatuses []string
+			err = db.Pluck(
+				"std_status",
+				&actionItemStatuses,
+				dal.From(&models.JiraIssue{}),
+				dal.Where("connection_id = ? AND parent_id = ?", connectionId, jiraIssue.IssueId),
+			)
+			if err != nil {
+				return nil, err
+			}
+			postmortem.ActionItemCount = len(actionItemStatuses)
+			for _, status := range actionItemStatuses {
+				if status == ticket.DONE {
+					postmortem.CompletedActionItemCount++
+				}
+			}
+
+			var labels []string
+			err = db.Pluck(
+				"label_name",
+				&labels,
+				dal.From(&models.JiraIssueLabel{}),
+				dal.Where("connection_id = ? AND issue_id = ?", connectionId, jiraIssue.IssueId),
+			)
+			if err != nil {
+				return nil, err
+			}
+			results := []interface{}{postmortem}
+			for _, label := range labels {
+				results = append(results, &ticket.PostmortemContributingFactor{
+					PostmortemId: postmortem.Id,
+					Factor:       label,
+				})
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
@@ -0,0 +1,133 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+type AccountMergeRequest struct {
+	UserId     string   `json:"userId" mapstructure:"userId"`
+	AccountIds []string `json:"accountIds" mapstructure:"accountIds"`
+}
+
+// GetAccountMerges returns the manual account merges, optionally those of a single user
+// @Summary      get account merges
+// @Tags 		 plugins/org
+// @Param        userId    query     string  false  "only return the merges into the user"
+// @Success      200  {object} []crossdomain.AccountMerge
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/account_merges [get]
+func (h *Handlers) GetAccountMerges(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	clauses := []dal.Clause{dal.Orderby("user_id, account_id")}
+	if userId := input.Query.Get("userId"); userId != "" {
+		clauses = append(clauses, dal.Where("user_id = ?", userId))
+	}
+	merges := make([]*crossdomain.AccountMerge, 0)
+	if err := h.db.All(&merges, clauses...); err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: merges, Status: http.StatusOK}, nil
+}
+
+// PostAccountMerges attaches accounts to a user, overriding the automated identity resolution. The merges are
+// applied to user_accounts right away and kept across pipeline runs.
+// @Summary      merge accounts into a user
+// @Tags 		 plugins/org
+// @Accept       application/json
+// @Param        body body AccountMergeRequest true "json"
+// @Success      200  {object} []crossdomain.AccountMerge
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/account_merges [post]
+func (h *Handlers) PostAccountMerges(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	var request AccountMergeRequest
+	if err := helper.Decode(input.Body, &request, nil); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid body")
+	}
+	if request.UserId == "" || len(request.AccountIds) == 0 {
+		return nil, errors.BadInput.New("userId and accountIds are required")
+	}
+	count, err := h.db.Count(dal.From(&crossdomain.User{}), dal.Where("id = ?", request.UserId))
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, errors.BadInput.New(fmt.Sprintf("user [%s] does not exist", request.UserId))
+	}
+	count, err = h.db.Count(dal.From(&crossdomain.Account{}), dal.Where("id IN ?", request.AccountIds))
+	if err != nil {
+		return nil, err
+	}
+	if int(count) != len(request.AccountIds) {
+		return nil, errors.BadInput.New("some of the accounts do not exist")
+	}
+	merges := make([]*crossdomain.AccountMerge, 0, len(request.AccountIds))
+	userAccounts := make([]*crossdomain.UserAccount, 0, len(request.AccountIds))
+	for _, accountId := range request.AccountIds {
+		merges = append(merges, &crossdomain.AccountMerge{AccountId: accountId, UserId: request.UserId})
+		userAccounts = append(userAccounts, &crossdomain.UserAccount{
+			UserId:    request.UserId,
+			AccountId: accountId,
+			NoPKModel: common.NoPKModel{RawDataOrigin: common.RawDataOrigin{RawDataTable: "account_merges"}},
+		})
+	}
+	if err = h.db.CreateOrUpdate(merges); err != nil {
+		return nil, err
+	}
+	if err = h.db.CreateOrUpdate(userAccounts); err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: merges, Status: http.StatusOK}, nil
+}
+
+// DeleteAccountMerge detaches an account from the user it was merged into, the identity resolution decides
+// which user it belongs to on the next run
+// @Summary      unmerge an account
+// @Tags 		 plugins/org
+// @Param        accountId    path     string  true  "account id"
+// @Success      200
+// @Failure 404  {object} shared.ApiBody "Not Found"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/account_merges/{accountId} [delete]
+func (h *Handlers) DeleteAccountMerge(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	accountId := input.Params["accountId"]
+	count, err := h.db.Count(dal.From(&crossdomain.AccountMerge{}), dal.Where("account_id = ?", accountId))
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, errors.NotFound.New(fmt.Sprintf("account [%s] has not been merged", accountId))
+	}
+	if err = h.db.Delete(&crossdomain.AccountMerge{}, dal.Where("account_id = ?", accountId)); err != nil {
+		return nil, err
+	}
+	err = h.db.Delete(&crossdomain.UserAccount{}, dal.Where("account_id = ? AND _raw_data_table = ?", accountId, "account_merges"))
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Status: http.StatusOK}, nil
+}
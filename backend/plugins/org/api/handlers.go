@@ -20,6 +20,7 @@ package api
 import (
 	"encoding/csv"
 	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/gocarina/gocsv"
 	"net/http"
@@ -29,10 +30,11 @@ const maxMemory = 32 << 20 // 32 MB
 
 type Handlers struct {
 	store store
+	db    dal.Dal
 }
 
 func NewHandlers(basicRes context.BasicRes) *Handlers {
-	return &Handlers{store: NewDbStore(basicRes.GetDal(), basicRes)}
+	return &Handlers{store: NewDbStore(basicRes.GetDal(), basicRes), db: basicRes.GetDal()}
 }
 
 func (h *Handlers) unmarshal(r *http.Request, items interface{}) errors.Error {
@@ -0,0 +1,91 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+type HourlyRateItem struct {
+	SubjectType string  `json:"subjectType" mapstructure:"subjectType"`
+	SubjectId   string  `json:"subjectId" mapstructure:"subjectId"`
+	Rate        float64 `json:"rate" mapstructure:"rate"`
+}
+
+type HourlyRatesRequest struct {
+	Rates []HourlyRateItem `json:"rates" mapstructure:"rates"`
+}
+
+// GetHourlyRates returns the hourly rates of users and teams used to price worklogs
+// @Summary      get hourly rates
+// @Tags 		 plugins/org
+// @Success      200  {object} []crossdomain.HourlyRate
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/hourly_rates [get]
+func (h *Handlers) GetHourlyRates(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	rates := make([]*crossdomain.HourlyRate, 0)
+	if err := h.db.All(&rates, dal.Orderby("subject_type, subject_id")); err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: rates, Status: http.StatusOK}, nil
+}
+
+// PutHourlyRates replaces the hourly rates, a user without a rate is priced at the rate of one of its teams
+// @Summary      set hourly rates
+// @Tags 		 plugins/org
+// @Accept       application/json
+// @Param        body body HourlyRatesRequest true "json, subjectType is either USER or TEAM"
+// @Success      200  {object} []crossdomain.HourlyRate
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/hourly_rates [put]
+func (h *Handlers) PutHourlyRates(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	var request HourlyRatesRequest
+	if err := helper.Decode(input.Body, &request, nil); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid body")
+	}
+	rates := make([]*crossdomain.HourlyRate, 0, len(request.Rates))
+	for _, item := range request.Rates {
+		if item.SubjectType != crossdomain.HOURLY_RATE_USER && item.SubjectType != crossdomain.HOURLY_RATE_TEAM {
+			return nil, errors.BadInput.New(fmt.Sprintf("subjectType must be %s or %s, got [%s]", crossdomain.HOURLY_RATE_USER, crossdomain.HOURLY_RATE_TEAM, item.SubjectType))
+		}
+		if item.SubjectId == "" {
+			return nil, errors.BadInput.New("subjectId is required")
+		}
+		if item.Rate < 0 {
+			return nil, errors.BadInput.New(fmt.Sprintf("the rate of %s [%s] must not be negative", item.SubjectType, item.SubjectId))
+		}
+		rates = append(rates, &crossdomain.HourlyRate{SubjectType: item.SubjectType, SubjectId: item.SubjectId, Rate: item.Rate})
+	}
+	if err := h.db.Delete(&crossdomain.HourlyRate{}, dal.Where("1 = 1")); err != nil {
+		return nil, err
+	}
+	if len(rates) > 0 {
+		if err := h.db.CreateOrUpdate(rates); err != nil {
+			return nil, err
+		}
+	}
+	return &plugin.ApiResourceOutput{Body: rates, Status: http.StatusOK}, nil
+}
@@ -0,0 +1,227 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+// TeamNode is a team in the team tree, along with its users rolled up from the child teams
+type TeamNode struct {
+	*crossdomain.Team
+	// Depth is 0 for the root teams
+	Depth int `json:"depth"`
+	// Path is the names of the ancestors and the team joined by ` / `
+	Path string `json:"path"`
+	// DirectUserCount is the number of users assigned to the team itself
+	DirectUserCount int `json:"directUserCount"`
+	// EffectiveUserCount is the number of distinct users of the team and all its descendants
+	EffectiveUserCount int         `json:"effectiveUserCount"`
+	Children           []*TeamNode `json:"children,omitempty"`
+	effectiveUserIds   map[string]bool
+}
+
+// GetTeamTree returns the teams as a tree, or as a flat list ordered depth-first with flat=true which suits
+// the variables of the Grafana dashboards
+// @Summary      get the team tree
+// @Tags 		 plugins/org
+// @Param        rootId    query     string  false  "only return the subtree of the team"
+// @Param        flat      query     bool    false  "return the teams of the tree as a flat list, depth-first"
+// @Success      200  {object} []TeamNode
+// @Failure 404  {object} shared.ApiBody "Not Found"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/teams/tree [get]
+func (h *Handlers) GetTeamTree(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	roots, nodes, err := h.loadTeamTree()
+	if err != nil {
+		return nil, err
+	}
+	if rootId := input.Query.Get("rootId"); rootId != "" {
+		root, ok := nodes[rootId]
+		if !ok {
+			return nil, errors.NotFound.New("team [" + rootId + "] not found")
+		}
+		roots = []*TeamNode{root}
+	}
+	if input.Query.Get("flat") == "true" {
+		flat := make([]*TeamNode, 0, len(nodes))
+		walkTeamTree(roots, func(node *TeamNode) {
+			flat = append(flat, &TeamNode{
+				Team:               node.Team,
+				Depth:              node.Depth,
+				Path:               node.Path,
+				DirectUserCount:    node.DirectUserCount,
+				EffectiveUserCount: node.EffectiveUserCount,
+			})
+		})
+		return &plugin.ApiResourceOutput{Body: flat, Status: http.StatusOK}, nil
+	}
+	return &plugin.ApiResourceOutput{Body: roots, Status: http.StatusOK}, nil
+}
+
+// GetTeamEffectiveUsers returns the users of a team and of all its descendants
+// @Summary      get the effective users of a team
+// @Tags 		 plugins/org
+// @Param        teamId    path     string  true  "team id"
+// @Success      200  {object} []crossdomain.User
+// @Failure 404  {object} shared.ApiBody "Not Found"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/teams/{teamId}/effective_users [get]
+func (h *Handlers) GetTeamEffectiveUsers(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	_, nodes, err := h.loadTeamTree()
+	if err != nil {
+		return nil, err
+	}
+	teamId := input.Params["teamId"]
+	node, ok := nodes[teamId]
+	if !ok {
+		return nil, errors.NotFound.New("team [" + teamId + "] not found")
+	}
+	users := make([]*crossdomain.User, 0)
+	if len(node.effectiveUserIds) > 0 {
+		userIds := make([]string, 0, len(node.effectiveUserIds))
+		for userId := range node.effectiveUserIds {
+			userIds = append(userIds, userId)
+		}
+		if err = h.db.All(&users, dal.Where("id IN ?", userIds), dal.Orderby("name")); err != nil {
+			return nil, err
+		}
+	}
+	return &plugin.ApiResourceOutput{Body: users, Status: http.StatusOK}, nil
+}
+
+// GetUserTeams returns the teams a user belongs to, directly or through a child team
+// @Summary      get the effective teams of a user
+// @Tags 		 plugins/org
+// @Param        userId    path     string  true  "user id"
+// @Success      200  {object} []TeamNode
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/users/{userId}/teams [get]
+func (h *Handlers) GetUserTeams(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	roots, _, err := h.loadTeamTree()
+	if err != nil {
+		return nil, err
+	}
+	userId := input.Params["userId"]
+	teams := make([]*TeamNode, 0)
+	walkTeamTree(roots, func(node *TeamNode) {
+		if node.effectiveUserIds[userId] {
+			teams = append(teams, &TeamNode{
+				Team:               node.Team,
+				Depth:              node.Depth,
+				Path:               node.Path,
+				DirectUserCount:    node.DirectUserCount,
+				EffectiveUserCount: node.EffectiveUserCount,
+			})
+		}
+	})
+	return &plugin.ApiResourceOutput{Body: teams, Status: http.StatusOK}, nil
+}
+
+// loadTeamTree returns the root teams and all the teams by id
+func (h *Handlers) loadTeamTree() ([]*TeamNode, map[string]*TeamNode, errors.Error) {
+	var teams []*crossdomain.Team
+	if err := h.db.All(&teams, dal.Orderby("sorting_index, name")); err != nil {
+		return nil, nil, err
+	}
+	var teamUsers []*crossdomain.TeamUser
+	if err := h.db.All(&teamUsers); err != nil {
+		return nil, nil, err
+	}
+	roots, nodes := buildTeamTree(teams, teamUsers)
+	return roots, nodes, nil
+}
+
+// buildTeamTree nests the teams under their parents and rolls the users up, teams whose parent doesn't exist
+// are treated as roots, and so are the teams of a cycle which the csv import doesn't prevent
+func buildTeamTree(teams []*crossdomain.Team, teamUsers []*crossdomain.TeamUser) ([]*TeamNode, map[string]*TeamNode) {
+	nodes := make(map[string]*TeamNode, len(teams))
+	for _, team := range teams {
+		nodes[team.Id] = &TeamNode{Team: team, effectiveUserIds: make(map[string]bool)}
+	}
+	for _, teamUser := range teamUsers {
+		if node, ok := nodes[teamUser.TeamId]; ok && !node.effectiveUserIds[teamUser.UserId] {
+			node.DirectUserCount++
+			node.effectiveUserIds[teamUser.UserId] = true
+		}
+	}
+	roots := make([]*TeamNode, 0)
+	attached := make(map[string]bool, len(teams))
+	for _, team := range teams {
+		if parent, ok := nodes[team.ParentId]; ok && team.ParentId != team.Id && !isTeamAncestor(team.Id, team.ParentId, nodes) {
+			parent.Children = append(parent.Children, nodes[team.Id])
+			attached[team.Id] = true
+		}
+	}
+	for _, team := range teams {
+		if !attached[team.Id] {
+			roots = append(roots, nodes[team.Id])
+		}
+	}
+	var rollup func(children []*TeamNode, depth int, path string)
+	rollup = func(children []*TeamNode, depth int, path string) {
+		for _, node := range children {
+			node.Depth = depth
+			node.Path = node.Name
+			if path != "" {
+				node.Path = strings.Join([]string{path, node.Name}, " / ")
+			}
+			rollup(node.Children, depth+1, node.Path)
+			for _, child := range node.Children {
+				for userId := range child.effectiveUserIds {
+					node.effectiveUserIds[userId] = true
+				}
+			}
+			node.EffectiveUserCount = len(node.effectiveUserIds)
+		}
+	}
+	rollup(roots, 0, "")
+	return roots, nodes
+}
+
+// isTeamAncestor tells if teamId is among the ancestors of parentId, following the parents until a team
+// is visited twice
+func isTeamAncestor(teamId, parentId string, nodes map[string]*TeamNode) bool {
+	visited := make(map[string]bool)
+	for id := parentId; id != "" && !visited[id]; {
+		if id == teamId {
+			return true
+		}
+		visited[id] = true
+		node, ok := nodes[id]
+		if !ok {
+			return false
+		}
+		id = node.ParentId
+	}
+	return false
+}
+
+// walkTeamTree visits the teams depth-first
+func walkTeamTree(nodes []*TeamNode, visit func(node *TeamNode)) {
+	for _, node := range nodes {
+		visit(node)
+		walkTeamTree(node.Children, visit)
+	}
+}
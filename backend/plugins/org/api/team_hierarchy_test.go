@@ -0,0 +1,80 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestTeam(id, name, parentId string) *crossdomain.Team {
+	return &crossdomain.Team{DomainEntity: domainlayer.DomainEntity{Id: id}, Name: name, ParentId: parentId}
+}
+
+func effectiveUserIds(node *TeamNode) []string {
+	userIds := make([]string, 0, len(node.effectiveUserIds))
+	for userId := range node.effectiveUserIds {
+		userIds = append(userIds, userId)
+	}
+	sort.Strings(userIds)
+	return userIds
+}
+
+func TestBuildTeamTree(t *testing.T) {
+	teams := []*crossdomain.Team{
+		newTestTeam("eng", "Engineering", ""),
+		newTestTeam("platform", "Platform", "eng"),
+		newTestTeam("infra", "Infra", "platform"),
+		newTestTeam("sales", "Sales", ""),
+		newTestTeam("orphan", "Orphan", "missing"),
+		newTestTeam("a", "A", "b"),
+		newTestTeam("b", "B", "a"),
+	}
+	teamUsers := []*crossdomain.TeamUser{
+		{TeamId: "eng", UserId: "u1"},
+		{TeamId: "platform", UserId: "u2"},
+		{TeamId: "infra", UserId: "u2"},
+		{TeamId: "infra", UserId: "u3"},
+		{TeamId: "sales", UserId: "u4"},
+	}
+	roots, nodes := buildTeamTree(teams, teamUsers)
+
+	rootIds := make([]string, 0)
+	for _, root := range roots {
+		rootIds = append(rootIds, root.Id)
+	}
+	assert.Equal(t, []string{"eng", "sales", "orphan", "a", "b"}, rootIds)
+
+	assert.Equal(t, []string{"u1", "u2", "u3"}, effectiveUserIds(nodes["eng"]))
+	assert.Equal(t, 1, nodes["eng"].DirectUserCount)
+	assert.Equal(t, 3, nodes["eng"].EffectiveUserCount)
+	assert.Equal(t, 2, nodes["platform"].EffectiveUserCount)
+	assert.Equal(t, 2, nodes["infra"].Depth)
+	assert.Equal(t, "Engineering / Platform / Infra", nodes["infra"].Path)
+	assert.Equal(t, 0, nodes["orphan"].Depth)
+
+	visited := make([]string, 0)
+	walkTeamTree(roots[:1], func(node *TeamNode) {
+		visited = append(visited, node.Id)
+	})
+	assert.Equal(t, []string{"eng", "platform", "infra"}, visited)
+}
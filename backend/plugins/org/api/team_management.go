@@ -0,0 +1,384 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/google/uuid"
+)
+
+type TeamRequest struct {
+	Id           string  `json:"id" mapstructure:"id"`
+	Name         *string `json:"name" mapstructure:"name"`
+	Alias        *string `json:"alias" mapstructure:"alias"`
+	ParentId     *string `json:"parentId" mapstructure:"parentId"`
+	SortingIndex *int    `json:"sortingIndex" mapstructure:"sortingIndex"`
+}
+
+type TeamUsersRequest struct {
+	UserIds []string `json:"userIds" mapstructure:"userIds"`
+}
+
+type TeamMappingItem struct {
+	Table string `json:"table" mapstructure:"table"`
+	RowId string `json:"rowId" mapstructure:"rowId"`
+}
+
+type TeamMappingsRequest struct {
+	Mappings []TeamMappingItem `json:"mappings" mapstructure:"mappings"`
+}
+
+// ListTeams returns the teams, or the child teams of a team when parentId is given
+// @Summary      list teams
+// @Tags 		 plugins/org
+// @Param        parentId    query     string  false  "only return the direct children of the team, empty for root teams"
+// @Success      200  {object} []crossdomain.Team
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/teams [get]
+func (h *Handlers) ListTeams(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	clauses := []dal.Clause{dal.Orderby("sorting_index, name")}
+	if input.Query.Has("parentId") {
+		clauses = append(clauses, dal.Where("parent_id = ?", input.Query.Get("parentId")))
+	}
+	teams := make([]*crossdomain.Team, 0)
+	if err := h.db.All(&teams, clauses...); err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: teams, Status: http.StatusOK}, nil
+}
+
+// GetTeamById returns a team
+// @Summary      get a team
+// @Tags 		 plugins/org
+// @Param        teamId    path     string  true  "team id"
+// @Success      200  {object} crossdomain.Team
+// @Failure 404  {object} shared.ApiBody "Not Found"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/teams/{teamId} [get]
+func (h *Handlers) GetTeamById(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	team, err := h.findTeam(input.Params["teamId"])
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: team, Status: http.StatusOK}, nil
+}
+
+// PostTeam creates a team, nested under parentId if given
+// @Summary      create a team
+// @Tags 		 plugins/org
+// @Accept       application/json
+// @Param        body body TeamRequest true "json, the id is generated if omitted"
+// @Success      201  {object} crossdomain.Team
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/teams [post]
+func (h *Handlers) PostTeam(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	var request TeamRequest
+	if err := helper.Decode(input.Body, &request, nil); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid body")
+	}
+	if request.Name == nil || *request.Name == "" {
+		return nil, errors.BadInput.New("name is required")
+	}
+	team := &crossdomain.Team{}
+	team.Id = request.Id
+	if team.Id == "" {
+		team.Id = uuid.New().String()
+	}
+	if err := h.applyTeamRequest(team, &request); err != nil {
+		return nil, err
+	}
+	if err := h.db.Create(team); err != nil {
+		if h.db.IsDuplicationError(err) {
+			return nil, errors.BadInput.New(fmt.Sprintf("team [%s] already exists", team.Id))
+		}
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: team, Status: http.StatusCreated}, nil
+}
+
+// PatchTeam updates a team, set parentId to move it under another team or to the root level
+// @Summary      update a team
+// @Tags 		 plugins/org
+// @Accept       application/json
+// @Param        teamId    path     string  true  "team id"
+// @Param        body body TeamRequest true "json"
+// @Success      200  {object} crossdomain.Team
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 404  {object} shared.ApiBody "Not Found"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/teams/{teamId} [patch]
+func (h *Handlers) PatchTeam(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	var request TeamRequest
+	if err := helper.Decode(input.Body, &request, nil); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid body")
+	}
+	team, err := h.findTeam(input.Params["teamId"])
+	if err != nil {
+		return nil, err
+	}
+	if err = h.applyTeamRequest(team, &request); err != nil {
+		return nil, err
+	}
+	if err = h.db.Update(team); err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: team, Status: http.StatusOK}, nil
+}
+
+// DeleteTeam deletes a team without child teams, along with its users and mappings
+// @Summary      delete a team
+// @Tags 		 plugins/org
+// @Param        teamId    path     string  true  "team id"
+// @Success      200
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 404  {object} shared.ApiBody "Not Found"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/teams/{teamId} [delete]
+func (h *Handlers) DeleteTeam(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	team, err := h.findTeam(input.Params["teamId"])
+	if err != nil {
+		return nil, err
+	}
+	children, err := h.db.Count(dal.From(&crossdomain.Team{}), dal.Where("parent_id = ?", team.Id))
+	if err != nil {
+		return nil, err
+	}
+	if children > 0 {
+		return nil, errors.BadInput.New(fmt.Sprintf("team [%s] has %d child teams, move or delete them first", team.Id, children))
+	}
+	if err = h.db.Delete(&crossdomain.TeamUser{}, dal.Where("team_id = ?", team.Id)); err != nil {
+		return nil, err
+	}
+	if err = h.db.Delete(&crossdomain.TeamMapping{}, dal.Where("team_id = ?", team.Id)); err != nil {
+		return nil, err
+	}
+	if err = h.db.Delete(&crossdomain.Team{}, dal.Where("id = ?", team.Id)); err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Status: http.StatusOK}, nil
+}
+
+// GetTeamUsers returns the users assigned to a team
+// @Summary      get users of a team
+// @Tags 		 plugins/org
+// @Param        teamId    path     string  true  "team id"
+// @Success      200  {object} []crossdomain.User
+// @Failure 404  {object} shared.ApiBody "Not Found"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/teams/{teamId}/users [get]
+func (h *Handlers) GetTeamUsers(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	team, err := h.findTeam(input.Params["teamId"])
+	if err != nil {
+		return nil, err
+	}
+	users := make([]*crossdomain.User, 0)
+	err = h.db.All(
+		&users,
+		dal.Select("u.*"),
+		dal.From("users u"),
+		dal.Join("JOIN team_users tu ON (tu.user_id = u.id)"),
+		dal.Where("tu.team_id = ?", team.Id),
+		dal.Orderby("u.name"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: users, Status: http.StatusOK}, nil
+}
+
+// PutTeamUsers replaces the users assigned to a team
+// @Summary      assign users to a team
+// @Tags 		 plugins/org
+// @Accept       application/json
+// @Param        teamId    path     string  true  "team id"
+// @Param        body body TeamUsersRequest true "json"
+// @Success      200  {object} []crossdomain.TeamUser
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 404  {object} shared.ApiBody "Not Found"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/teams/{teamId}/users [put]
+func (h *Handlers) PutTeamUsers(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	var request TeamUsersRequest
+	if err := helper.Decode(input.Body, &request, nil); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid body")
+	}
+	team, err := h.findTeam(input.Params["teamId"])
+	if err != nil {
+		return nil, err
+	}
+	teamUsers := make([]*crossdomain.TeamUser, 0, len(request.UserIds))
+	seen := make(map[string]bool)
+	for _, userId := range request.UserIds {
+		if userId == "" || seen[userId] {
+			continue
+		}
+		seen[userId] = true
+		teamUsers = append(teamUsers, &crossdomain.TeamUser{TeamId: team.Id, UserId: userId})
+	}
+	if len(seen) > 0 {
+		userIds := make([]string, 0, len(seen))
+		for userId := range seen {
+			userIds = append(userIds, userId)
+		}
+		count, err := h.db.Count(dal.From(&crossdomain.User{}), dal.Where("id IN ?", userIds))
+		if err != nil {
+			return nil, err
+		}
+		if int(count) != len(userIds) {
+			return nil, errors.BadInput.New("some of the users do not exist")
+		}
+	}
+	if err = h.db.Delete(&crossdomain.TeamUser{}, dal.Where("team_id = ?", team.Id)); err != nil {
+		return nil, err
+	}
+	if len(teamUsers) > 0 {
+		if err = h.db.CreateOrUpdate(teamUsers); err != nil {
+			return nil, err
+		}
+	}
+	return &plugin.ApiResourceOutput{Body: teamUsers, Status: http.StatusOK}, nil
+}
+
+// GetTeamMappings returns the projects and repos owned by a team
+// @Summary      get projects and repos of a team
+// @Tags 		 plugins/org
+// @Param        teamId    path     string  true  "team id"
+// @Success      200  {object} []crossdomain.TeamMapping
+// @Failure 404  {object} shared.ApiBody "Not Found"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/teams/{teamId}/mappings [get]
+func (h *Handlers) GetTeamMappings(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	team, err := h.findTeam(input.Params["teamId"])
+	if err != nil {
+		return nil, err
+	}
+	mappings := make([]*crossdomain.TeamMapping, 0)
+	if err = h.db.All(&mappings, dal.Where("team_id = ?", team.Id)); err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: mappings, Status: http.StatusOK}, nil
+}
+
+// PutTeamMappings replaces the projects and repos owned by a team, the row id of a project is its name
+// @Summary      set projects and repos of a team
+// @Tags 		 plugins/org
+// @Accept       application/json
+// @Param        teamId    path     string  true  "team id"
+// @Param        body body TeamMappingsRequest true "json"
+// @Success      200  {object} []crossdomain.TeamMapping
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 404  {object} shared.ApiBody "Not Found"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/teams/{teamId}/mappings [put]
+func (h *Handlers) PutTeamMappings(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	var request TeamMappingsRequest
+	if err := helper.Decode(input.Body, &request, nil); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid body")
+	}
+	team, err := h.findTeam(input.Params["teamId"])
+	if err != nil {
+		return nil, err
+	}
+	mappings := make([]*crossdomain.TeamMapping, 0, len(request.Mappings))
+	for _, item := range request.Mappings {
+		if item.Table != crossdomain.TEAM_MAPPING_PROJECTS && item.Table != crossdomain.TEAM_MAPPING_REPOS {
+			return nil, errors.BadInput.New(fmt.Sprintf("teams can only be mapped onto %s and %s, got [%s]", crossdomain.TEAM_MAPPING_PROJECTS, crossdomain.TEAM_MAPPING_REPOS, item.Table))
+		}
+		if item.RowId == "" {
+			return nil, errors.BadInput.New("rowId is required")
+		}
+		mappings = append(mappings, &crossdomain.TeamMapping{TeamId: team.Id, Table: item.Table, RowId: item.RowId})
+	}
+	if err = h.db.Delete(&crossdomain.TeamMapping{}, dal.Where("team_id = ?", team.Id)); err != nil {
+		return nil, err
+	}
+	if len(mappings) > 0 {
+		if err = h.db.CreateOrUpdate(mappings); err != nil {
+			return nil, err
+		}
+	}
+	return &plugin.ApiResourceOutput{Body: mappings, Status: http.StatusOK}, nil
+}
+
+func (h *Handlers) findTeam(teamId string) (*crossdomain.Team, errors.Error) {
+	team := &crossdomain.Team{}
+	err := h.db.First(team, dal.Where("id = ?", teamId))
+	if err != nil {
+		if h.db.IsErrorNotFound(err) {
+			return nil, errors.NotFound.New(fmt.Sprintf("team [%s] not found", teamId))
+		}
+		return nil, err
+	}
+	return team, nil
+}
+
+func (h *Handlers) applyTeamRequest(team *crossdomain.Team, request *TeamRequest) errors.Error {
+	if request.Name != nil {
+		team.Name = *request.Name
+	}
+	if request.Alias != nil {
+		team.Alias = *request.Alias
+	}
+	if request.SortingIndex != nil {
+		team.SortingIndex = *request.SortingIndex
+	}
+	if request.ParentId != nil && *request.ParentId != team.ParentId {
+		if *request.ParentId != "" {
+			var teams []*crossdomain.Team
+			if err := h.db.All(&teams); err != nil {
+				return err
+			}
+			parents := make(map[string]string, len(teams))
+			for _, t := range teams {
+				parents[t.Id] = t.ParentId
+			}
+			if err := checkTeamParent(team.Id, *request.ParentId, parents); err != nil {
+				return err
+			}
+		}
+		team.ParentId = *request.ParentId
+	}
+	return nil
+}
+
+// checkTeamParent makes sure parentId exists and moving the team under it keeps the hierarchy a tree,
+// parents maps each team id to its parent id
+func checkTeamParent(teamId, parentId string, parents map[string]string) errors.Error {
+	if _, ok := parents[parentId]; !ok {
+		return errors.BadInput.New(fmt.Sprintf("parent team [%s] not found", parentId))
+	}
+	visited := make(map[string]bool)
+	for id := parentId; id != ""; id = parents[id] {
+		if id == teamId {
+			return errors.BadInput.New(fmt.Sprintf("team [%s] can not be nested under its own descendant [%s]", teamId, parentId))
+		}
+		if visited[id] {
+			break
+		}
+		visited[id] = true
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/plugin"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCheckTeamParent(t *testing.T) {
+	parents := map[string]string{
+		"eng":      "",
+		"platform": "eng",
+		"infra":    "platform",
+		"sales":    "",
+	}
+	tests := []struct {
+		name     string
+		teamId   string
+		parentId string
+		wantErr  bool
+	}{
+		{"under another root", "sales", "eng", false},
+		{"under a nested team", "sales", "infra", false},
+		{"new team", "", "platform", false},
+		{"parent not found", "sales", "missing", true},
+		{"under itself", "eng", "eng", true},
+		{"under its descendant", "eng", "infra", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkTeamParent(tt.teamId, tt.parentId, parents)
+			if tt.wantErr {
+				if assert.NotNil(t, err) {
+					assert.Equal(t, errors.BadInput, err.GetType())
+				}
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func mockTeamDal(team *crossdomain.Team) *mockdal.Dal {
+	mockDal := new(mockdal.Dal)
+	mockDal.On("First", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(0).(*crossdomain.Team) = *team
+	}).Return(nil)
+	return mockDal
+}
+
+func TestPatchTeamMovesTeam(t *testing.T) {
+	mockDal := mockTeamDal(newTestTeam("sales", "Sales", ""))
+	mockDal.On("All", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(0).(*[]*crossdomain.Team) = []*crossdomain.Team{
+			newTestTeam("eng", "Engineering", ""),
+			newTestTeam("sales", "Sales", ""),
+		}
+	}).Return(nil)
+	mockDal.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+	h := &Handlers{db: mockDal}
+
+	output, err := h.PatchTeam(&plugin.ApiResourceInput{
+		Params: map[string]string{"teamId": "sales"},
+		Body:   map[string]interface{}{"parentId": "eng", "alias": "S"},
+	})
+	assert.Nil(t, err)
+	team := output.Body.(*crossdomain.Team)
+	assert.Equal(t, "eng", team.ParentId)
+	assert.Equal(t, "S", team.Alias)
+	assert.Equal(t, "Sales", team.Name)
+	mockDal.AssertExpectations(t)
+}
+
+func TestDeleteTeamWithChildTeams(t *testing.T) {
+	mockDal := mockTeamDal(newTestTeam("eng", "Engineering", ""))
+	mockDal.On("Count", mock.Anything).Return(int64(2), nil).Once()
+	h := &Handlers{db: mockDal}
+
+	_, err := h.DeleteTeam(&plugin.ApiResourceInput{Params: map[string]string{"teamId": "eng"}})
+	if assert.NotNil(t, err) {
+		assert.Equal(t, errors.BadInput, err.GetType())
+	}
+	mockDal.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestPutTeamUsers(t *testing.T) {
+	mockDal := mockTeamDal(newTestTeam("eng", "Engineering", ""))
+	mockDal.On("Count", mock.Anything).Return(int64(2), nil).Once()
+	mockDal.On("Delete", &crossdomain.TeamUser{}, []dal.Clause{dal.Where("team_id = ?", "eng")}).Return(nil).Once()
+	var saved []*crossdomain.TeamUser
+	mockDal.On("CreateOrUpdate", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(0).([]*crossdomain.TeamUser)
+	}).Return(nil).Once()
+	h := &Handlers{db: mockDal}
+
+	_, err := h.PutTeamUsers(&plugin.ApiResourceInput{
+		Params: map[string]string{"teamId": "eng"},
+		Body:   map[string]interface{}{"userIds": []string{"u1", "u2", "u1", ""}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []*crossdomain.TeamUser{{TeamId: "eng", UserId: "u1"}, {TeamId: "eng", UserId: "u2"}}, saved)
+	mockDal.AssertExpectations(t)
+
+	// unknown users are rejected before the current users are removed
+	mockDal = mockTeamDal(newTestTeam("eng", "Engineering", ""))
+	mockDal.On("Count", mock.Anything).Return(int64(1), nil).Once()
+	h = &Handlers{db: mockDal}
+	_, err = h.PutTeamUsers(&plugin.ApiResourceInput{
+		Params: map[string]string{"teamId": "eng"},
+		Body:   map[string]interface{}{"userIds": []string{"u1", "missing"}},
+	})
+	if assert.NotNil(t, err) {
+		assert.Equal(t, errors.BadInput, err.GetType())
+	}
+	mockDal.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestPutTeamMappings(t *testing.T) {
+	mockDal := mockTeamDal(newTestTeam("eng", "Engineering", ""))
+	mockDal.On("Delete", &crossdomain.TeamMapping{}, []dal.Clause{dal.Where("team_id = ?", "eng")}).Return(nil).Once()
+	var saved []*crossdomain.TeamMapping
+	mockDal.On("CreateOrUpdate", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(0).([]*crossdomain.TeamMapping)
+	}).Return(nil).Once()
+	h := &Handlers{db: mockDal}
+
+	_, err := h.PutTeamMappings(&plugin.ApiResourceInput{
+		Params: map[string]string{"teamId": "eng"},
+		Body: map[string]interface{}{"mappings": []map[string]interface{}{
+			{"table": crossdomain.TEAM_MAPPING_PROJECTS, "rowId": "devlake"},
+			{"table": crossdomain.TEAM_MAPPING_REPOS, "rowId": "github:GithubRepo:1:1"},
+		}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []*crossdomain.TeamMapping{
+		{TeamId: "eng", Table: crossdomain.TEAM_MAPPING_PROJECTS, RowId: "devlake"},
+		{TeamId: "eng", Table: crossdomain.TEAM_MAPPING_REPOS, RowId: "github:GithubRepo:1:1"},
+	}, saved)
+	mockDal.AssertExpectations(t)
+
+	for _, item := range []map[string]interface{}{
+		{"table": "boards", "rowId": "jira:JiraBoard:1:1"},
+		{"table": crossdomain.TEAM_MAPPING_REPOS, "rowId": ""},
+	} {
+		mockDal = mockTeamDal(newTestTeam("eng", "Engineering", ""))
+		h = &Handlers{db: mockDal}
+		_, err = h.PutTeamMappings(&plugin.ApiResourceInput{
+			Params: map[string]string{"teamId": "eng"},
+			Body:   map[string]interface{}{"mappings": []map[string]interface{}{item}},
+		})
+		if assert.NotNil(t, err) {
+			assert.Equal(t, errors.BadInput, err.GetType())
+		}
+		mockDal.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	}
+}
@@ -53,3 +53,33 @@ func TestUserAccountDataFlow(t *testing.T) {
 		),
 	)
 }
+
+func TestIdentityResolutionDataFlow(t *testing.T) {
+	var plugin impl.Org
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "org", plugin)
+
+	taskData := &tasks.TaskData{
+		Options: &tasks.Options{
+			ConnectionId: 2,
+		},
+	}
+
+	// a1 and a2 were resolved into an auto user last time, a1 has got an exact identity since then
+	dataflowTester.FlushTabler(&crossdomain.AccountMerge{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/identity_users.csv", &crossdomain.User{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/identity_accounts.csv", &crossdomain.Account{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/identity_user_accounts.csv", &crossdomain.UserAccount{})
+
+	dataflowTester.Subtask(tasks.ConnectUserAccountsExactMeta, taskData)
+	dataflowTester.Subtask(tasks.ResolveAccountIdentitiesMeta, taskData)
+	dataflowTester.VerifyTable(
+		crossdomain.UserAccount{},
+		"./snapshot_tables/identity_user_accounts.csv",
+		[]string{"user_id", "account_id", "_raw_data_table"},
+	)
+	dataflowTester.VerifyTable(
+		crossdomain.User{},
+		"./snapshot_tables/identity_users.csv",
+		[]string{"id", "email", "name", "_raw_data_table"},
+	)
+}
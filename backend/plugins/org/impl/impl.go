@@ -60,6 +60,7 @@ func (p Org) Name() string {
 func (p Org) SubTaskMetas() []plugin.SubTaskMeta {
 	return []plugin.SubTaskMeta{
 		tasks.ConnectUserAccountsExactMeta,
+		tasks.ResolveAccountIdentitiesMeta,
 		tasks.SetProjectMappingMeta,
 		tasks.SleepMeta,
 	}
@@ -108,6 +109,43 @@ func (p Org) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
 			"GET": p.handlers.GetTeam,
 			"PUT": p.handlers.CreateTeam,
 		},
+		"teams": {
+			"GET":  p.handlers.ListTeams,
+			"POST": p.handlers.PostTeam,
+		},
+		"teams/tree": {
+			"GET": p.handlers.GetTeamTree,
+		},
+		"teams/:teamId": {
+			"GET":    p.handlers.GetTeamById,
+			"PATCH":  p.handlers.PatchTeam,
+			"DELETE": p.handlers.DeleteTeam,
+		},
+		"teams/:teamId/users": {
+			"GET": p.handlers.GetTeamUsers,
+			"PUT": p.handlers.PutTeamUsers,
+		},
+		"teams/:teamId/effective_users": {
+			"GET": p.handlers.GetTeamEffectiveUsers,
+		},
+		"teams/:teamId/mappings": {
+			"GET": p.handlers.GetTeamMappings,
+			"PUT": p.handlers.PutTeamMappings,
+		},
+		"account_merges": {
+			"GET":  p.handlers.GetAccountMerges,
+			"POST": p.handlers.PostAccountMerges,
+		},
+		"account_merges/:accountId": {
+			"DELETE": p.handlers.DeleteAccountMerge,
+		},
+		"hourly_rates": {
+			"GET": p.handlers.GetHourlyRates,
+			"PUT": p.handlers.PutHourlyRates,
+		},
+		"users/:userId/teams": {
+			"GET": p.handlers.GetUserTeams,
+		},
 		"users.csv": {
 			"GET": p.handlers.GetUser,
 			"PUT": p.handlers.CreateUser,
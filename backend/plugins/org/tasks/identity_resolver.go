@@ -0,0 +1,205 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+const (
+	// user_accounts created from account_merges
+	rawTableAccountMerges = "account_merges"
+	// users and user_accounts created by the identity resolution
+	rawTableIdentityResolution = "accounts"
+	autoUserIdPrefix           = "auto:"
+)
+
+var ResolveAccountIdentitiesMeta = plugin.SubTaskMeta{
+	Name:             "resolveAccountIdentities",
+	EntryPoint:       ResolveAccountIdentities,
+	EnabledByDefault: true,
+	Description:      "apply manual account merges and group the remaining accounts of the same person into users",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CROSS},
+}
+
+// ResolveAccountIdentities re-applies the manual merges, then clusters the accounts not attached to any user by
+// email and full name, creating one user per cluster. The user ids are derived from the accounts so they are kept
+// across runs, along with whatever refers to them (teams for instance).
+func ResolveAccountIdentities(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	logger := taskCtx.GetLogger()
+
+	err := db.Delete(&crossdomain.UserAccount{}, dal.Where("_raw_data_table = ?", rawTableAccountMerges))
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to delete previously merged user accounts")
+	}
+	if err = deleteResolvedUserAccounts(db); err != nil {
+		return err
+	}
+
+	var merges []*crossdomain.AccountMerge
+	if err = db.All(&merges); err != nil {
+		return errors.Default.Wrap(err, "failed to load account merges")
+	}
+	if len(merges) > 0 {
+		mergedAccounts := make([]*crossdomain.UserAccount, 0, len(merges))
+		for _, merge := range merges {
+			mergedAccounts = append(mergedAccounts, &crossdomain.UserAccount{
+				UserId:    merge.UserId,
+				AccountId: merge.AccountId,
+				NoPKModel: common.NoPKModel{RawDataOrigin: common.RawDataOrigin{RawDataTable: rawTableAccountMerges}},
+			})
+		}
+		if err = db.CreateOrUpdate(mergedAccounts); err != nil {
+			return errors.Default.Wrap(err, "failed to apply account merges")
+		}
+	}
+
+	var accounts []*crossdomain.Account
+	err = db.All(&accounts, dal.Where("id NOT IN (SELECT account_id FROM user_accounts)"), dal.Orderby("id"))
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to load unresolved accounts")
+	}
+	clusters := clusterAccounts(accounts)
+	users := make([]*crossdomain.User, 0, len(clusters))
+	userAccounts := make([]*crossdomain.UserAccount, 0, len(accounts))
+	for _, cluster := range clusters {
+		user := newIdentityUser(cluster)
+		users = append(users, user)
+		for _, account := range cluster {
+			userAccounts = append(userAccounts, &crossdomain.UserAccount{
+				UserId:    user.Id,
+				AccountId: account.Id,
+				NoPKModel: common.NoPKModel{RawDataOrigin: common.RawDataOrigin{RawDataTable: rawTableIdentityResolution}},
+			})
+		}
+	}
+	logger.Info("applied %d account merges, resolved %d accounts into %d users", len(merges), len(userAccounts), len(users))
+	if len(users) > 0 {
+		if err = db.CreateOrUpdate(users); err != nil {
+			return errors.Default.Wrap(err, "failed to save resolved users")
+		}
+		if err = db.CreateOrUpdate(userAccounts); err != nil {
+			return errors.Default.Wrap(err, "failed to save resolved user accounts")
+		}
+	}
+	// drop the users of clusters which no longer exist
+	return db.Delete(
+		&crossdomain.User{},
+		dal.Where("_raw_data_table = ? AND id NOT IN (SELECT user_id FROM user_accounts)", rawTableIdentityResolution),
+	)
+}
+
+// deleteResolvedUserAccounts deletes the user accounts created by the previous identity resolution
+func deleteResolvedUserAccounts(db dal.Dal) errors.Error {
+	err := db.Delete(&crossdomain.UserAccount{}, dal.Where("_raw_data_table = ?", rawTableIdentityResolution))
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to delete previously resolved user accounts")
+	}
+	return nil
+}
+
+// clusterAccounts groups accounts sharing an email or a full name, case-insensitively. Single-word full names
+// are ignored as they are too likely to be shared by different people (or bots). An account sharing nothing with
+// the others is left alone, no user is created for it.
+func clusterAccounts(accounts []*crossdomain.Account) [][]*crossdomain.Account {
+	parents := make([]int, len(accounts))
+	for i := range parents {
+		parents[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parents[i] != i {
+			parents[i] = find(parents[i])
+		}
+		return parents[i]
+	}
+	owners := make(map[string]int)
+	for i, account := range accounts {
+		for _, key := range identityKeys(account) {
+			if j, ok := owners[key]; ok {
+				parents[find(i)] = find(j)
+			} else {
+				owners[key] = i
+			}
+		}
+	}
+	groups := make(map[int][]*crossdomain.Account)
+	roots := make([]int, 0)
+	for i, account := range accounts {
+		root := find(i)
+		if _, ok := groups[root]; !ok {
+			roots = append(roots, root)
+		}
+		groups[root] = append(groups[root], account)
+	}
+	clusters := make([][]*crossdomain.Account, 0, len(roots))
+	for _, root := range roots {
+		cluster := groups[root]
+		if len(cluster) < 2 {
+			continue
+		}
+		sort.Slice(cluster, func(i, j int) bool { return cluster[i].Id < cluster[j].Id })
+		clusters = append(clusters, cluster)
+	}
+	return clusters
+}
+
+func identityKeys(account *crossdomain.Account) []string {
+	keys := make([]string, 0, 2)
+	if email := strings.ToLower(strings.TrimSpace(account.Email)); email != "" {
+		keys = append(keys, "email:"+email)
+	}
+	if words := strings.Fields(strings.ToLower(account.FullName)); len(words) > 1 {
+		keys = append(keys, "name:"+strings.Join(words, " "))
+	}
+	return keys
+}
+
+// newIdentityUser creates the user of a cluster, its id is derived from the smallest account id
+func newIdentityUser(cluster []*crossdomain.Account) *crossdomain.User {
+	hash := sha256.Sum256([]byte(cluster[0].Id))
+	user := &crossdomain.User{
+		DomainEntity: domainlayer.DomainEntity{
+			Id:        autoUserIdPrefix + hex.EncodeToString(hash[:8]),
+			NoPKModel: common.NoPKModel{RawDataOrigin: common.RawDataOrigin{RawDataTable: rawTableIdentityResolution}},
+		},
+	}
+	for _, account := range cluster {
+		if user.Email == "" {
+			user.Email = account.Email
+		}
+		if user.Name == "" {
+			user.Name = account.FullName
+		}
+	}
+	if user.Name == "" {
+		user.Name = cluster[0].UserName
+	}
+	return user
+}
@@ -0,0 +1,68 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClusterAccounts(t *testing.T) {
+	account := func(id, email, fullName, userName string) *crossdomain.Account {
+		return &crossdomain.Account{
+			DomainEntity: domainlayer.DomainEntity{Id: id},
+			Email:        email,
+			FullName:     fullName,
+			UserName:     userName,
+		}
+	}
+	accounts := []*crossdomain.Account{
+		account("github:1", "Jane.Doe@example.com", "", "jdoe"),
+		account("jira:1", "jane.doe@example.com ", "Jane  Doe", "jane"),
+		account("gitlab:1", "", "jane doe", "jane.doe"),
+		account("zentao:1", "", "admin", "admin"),
+		account("gitlab:2", "", "admin", "admin"),
+		account("jira:2", "john@example.com", "John Smith", "john"),
+		account("bitbucket:1", "", "Ann Lee", "ann"),
+		account("jira:3", "ann@example.com", "ann  lee", "ann"),
+	}
+	clusters := clusterAccounts(accounts)
+	ids := make([][]string, 0)
+	for _, cluster := range clusters {
+		clusterIds := make([]string, 0)
+		for _, a := range cluster {
+			clusterIds = append(clusterIds, a.Id)
+		}
+		ids = append(ids, clusterIds)
+	}
+	// the single-word names don't merge, neither do the accounts sharing nothing
+	assert.Equal(t, [][]string{
+		{"github:1", "gitlab:1", "jira:1"},
+		{"bitbucket:1", "jira:3"},
+	}, ids)
+
+	user := newIdentityUser(clusters[0])
+	assert.Equal(t, "Jane.Doe@example.com", user.Email)
+	assert.Equal(t, "jane doe", user.Name)
+	assert.Equal(t, user.Id, newIdentityUser(clusters[0]).Id)
+	assert.Equal(t, "Ann Lee", newIdentityUser(clusters[1]).Name)
+	assert.Equal(t, "ann@example.com", newIdentityUser(clusters[1]).Email)
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"reflect"
+	"strings"
 )
 
 var ConnectUserAccountsExactMeta = plugin.SubTaskMeta{
@@ -37,14 +38,22 @@ var ConnectUserAccountsExactMeta = plugin.SubTaskMeta{
 func ConnectUserAccountsExact(taskCtx plugin.SubTaskContext) errors.Error {
 	db := taskCtx.GetDal()
 	data := taskCtx.GetData().(*TaskData)
+	// the accounts resolved automatically last time are matched again, an exact identity takes precedence
+	err := deleteResolvedUserAccounts(db)
+	if err != nil {
+		return err
+	}
 	var users []crossdomain.User
-	err := db.All(&users)
+	err = db.All(&users)
 	if err != nil {
 		return err
 	}
 	emails := make(map[string]string)
 	names := make(map[string]string)
 	for _, user := range users {
+		if strings.HasPrefix(user.Id, autoUserIdPrefix) {
+			continue
+		}
 		if user.Email != "" {
 			emails[user.Email] = user.Id
 		}
@@ -0,0 +1,173 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/refdiff/tasks"
+)
+
+var shaRegexp = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+type CompareRequest struct {
+	RepoId string `json:"repoId"`
+	// NewRef and OldRef accept ref names like `refs/tags/v1.2.0` as well as full commit shas
+	NewRef string `json:"newRef"`
+	OldRef string `json:"oldRef"`
+}
+
+type CompareCommit struct {
+	Sha          string    `json:"sha"`
+	Message      string    `json:"message"`
+	AuthorName   string    `json:"authorName"`
+	AuthorEmail  string    `json:"authorEmail"`
+	AuthoredDate time.Time `json:"authoredDate"`
+}
+
+type CompareIssue struct {
+	Id       string `json:"id"`
+	IssueKey string `json:"issueKey"`
+	Title    string `json:"title"`
+	Type     string `json:"type"`
+	Status   string `json:"status"`
+	Url      string `json:"url"`
+}
+
+type CompareResult struct {
+	NewCommitSha string          `json:"newCommitSha"`
+	OldCommitSha string          `json:"oldCommitSha"`
+	Commits      []CompareCommit `json:"commits"`
+	Issues       []CompareIssue  `json:"issues"`
+}
+
+// Compare calculates the commits and issues between two refs on the fly
+// @Summary compare two refs of a repo
+// @Description returns the commits reachable from newRef but not from oldRef, along with the issues linked to them via pull requests, nothing is persisted
+// @Tags plugins/refdiff
+// @Accept application/json
+// @Param body body CompareRequest true "json"
+// @Success 200  {object} CompareResult
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 404  {object} shared.ApiBody "Not Found"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/refdiff/compare [POST]
+func Compare(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	req := &CompareRequest{}
+	err := helper.Decode(input.Body, req, nil)
+	if err != nil {
+		return nil, err
+	}
+	if req.RepoId == "" || req.NewRef == "" || req.OldRef == "" {
+		return nil, errors.BadInput.New("repoId, newRef and oldRef are required")
+	}
+	db := basicRes.GetDal()
+	result := &CompareResult{
+		Commits: []CompareCommit{},
+		Issues:  []CompareIssue{},
+	}
+	if result.NewCommitSha, err = resolveRef(db, req.RepoId, req.NewRef); err != nil {
+		return nil, err
+	}
+	if result.OldCommitSha, err = resolveRef(db, req.RepoId, req.OldRef); err != nil {
+		return nil, err
+	}
+	if result.NewCommitSha == result.OldCommitSha {
+		return &plugin.ApiResourceOutput{Body: result, Status: http.StatusOK}, nil
+	}
+
+	commitNodeGraph, err := tasks.LoadCommitNodeGraph(context.Background(), db, req.RepoId)
+	if err != nil {
+		return nil, err
+	}
+	shas, _, _ := commitNodeGraph.CalculateLostSha(result.OldCommitSha, result.NewCommitSha)
+	if len(shas) == 0 {
+		return &plugin.ApiResourceOutput{Body: result, Status: http.StatusOK}, nil
+	}
+
+	var commits []code.Commit
+	err = db.All(&commits, dal.Where("sha IN ?", shas), dal.Orderby("authored_date DESC"))
+	if err != nil {
+		return nil, err
+	}
+	for _, commit := range commits {
+		result.Commits = append(result.Commits, CompareCommit{
+			Sha:          commit.Sha,
+			Message:      commit.Message,
+			AuthorName:   commit.AuthorName,
+			AuthorEmail:  commit.AuthorEmail,
+			AuthoredDate: commit.AuthoredDate,
+		})
+	}
+
+	// issues are linked to the commits through the pull requests that contain or merged them
+	var issues []ticket.Issue
+	err = db.All(
+		&issues,
+		dal.Where(`id IN (
+			SELECT pri.issue_id FROM pull_request_issues pri WHERE pri.pull_request_id IN (
+				SELECT prc.pull_request_id FROM pull_request_commits prc
+				LEFT JOIN pull_requests pr ON pr.id = prc.pull_request_id
+				WHERE pr.base_repo_id = ? AND prc.commit_sha IN ?
+				UNION
+				SELECT pr.id FROM pull_requests pr WHERE pr.base_repo_id = ? AND pr.merge_commit_sha IN ?
+			)
+		)`, req.RepoId, shas, req.RepoId, shas),
+		dal.Orderby("issue_key"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	for _, issue := range issues {
+		result.Issues = append(result.Issues, CompareIssue{
+			Id:       issue.Id,
+			IssueKey: issue.IssueKey,
+			Title:    issue.Title,
+			Type:     issue.Type,
+			Status:   issue.Status,
+			Url:      issue.Url,
+		})
+	}
+	return &plugin.ApiResourceOutput{Body: result, Status: http.StatusOK}, nil
+}
+
+// resolveRef looks up the commit sha of the ref, a full commit sha is returned as is
+func resolveRef(db dal.Dal, repoId, ref string) (string, errors.Error) {
+	r := &code.Ref{}
+	err := db.First(r, dal.Where("id = ?", fmt.Sprintf("%s:%s", repoId, ref)))
+	if err == nil {
+		return r.CommitSha, nil
+	}
+	if !db.IsErrorNotFound(err) {
+		return "", errors.Default.Wrap(err, fmt.Sprintf("failed to load ref %s", ref))
+	}
+	if shaRegexp.MatchString(ref) {
+		return ref, nil
+	}
+	return "", errors.NotFound.New(fmt.Sprintf("ref %s not found in repo %s", ref, repoId))
+}
@@ -0,0 +1,133 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	shaA = "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	shaB = "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+	shaC = "cccccccccccccccccccccccccccccccccccccccc"
+)
+
+func TestResolveRef(t *testing.T) {
+	notFound := errors.NotFound.New("record not found")
+	tests := []struct {
+		name     string
+		ref      string
+		found    bool
+		wantSha  string
+		wantType *errors.Type
+	}{
+		{name: "ref name", ref: "refs/tags/v1.0.0", found: true, wantSha: shaA},
+		{name: "full commit sha", ref: shaB, wantSha: shaB},
+		{name: "unknown ref name", ref: "refs/tags/v9.9.9", wantType: errors.NotFound},
+		{name: "short commit sha", ref: "bbbbbbb", wantType: errors.NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDal := new(mockdal.Dal)
+			if tt.found {
+				mockDal.On("First", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					args.Get(0).(*code.Ref).CommitSha = shaA
+				}).Return(nil).Once()
+			} else {
+				mockDal.On("First", mock.Anything, mock.Anything).Return(notFound).Once()
+				mockDal.On("IsErrorNotFound", notFound).Return(true).Once()
+			}
+			sha, err := resolveRef(mockDal, "github:GithubRepo:1:1", tt.ref)
+			assert.Equal(t, tt.wantSha, sha)
+			if tt.wantType == nil {
+				assert.Nil(t, err)
+			} else if assert.NotNil(t, err) {
+				assert.Equal(t, tt.wantType, err.GetType())
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	notFound := errors.NotFound.New("record not found")
+	// shaC -> shaB -> shaA
+	parents := []code.CommitParent{
+		{CommitSha: shaC, ParentCommitSha: shaB},
+		{CommitSha: shaB, ParentCommitSha: shaA},
+	}
+	var lostShas []string
+	basicRes = unithelper.DummyBasicRes(func(mockDal *mockdal.Dal) {
+		mockDal.On("First", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			args.Get(0).(*code.Ref).CommitSha = shaC
+		}).Return(nil).Once()
+		mockDal.On("First", mock.Anything, mock.Anything).Return(notFound).Once()
+		mockDal.On("IsErrorNotFound", notFound).Return(true).Once()
+
+		rows := new(mockdal.Rows)
+		rows.On("Next").Return(true).Times(len(parents))
+		rows.On("Next").Return(false).Once()
+		rows.On("Close").Return(nil)
+		mockDal.On("Cursor", mock.Anything).Return(rows, nil).Once()
+		fetched := 0
+		mockDal.On("Fetch", rows, mock.Anything).Run(func(args mock.Arguments) {
+			*args.Get(1).(*code.CommitParent) = parents[fetched]
+			fetched++
+		}).Return(nil)
+
+		mockDal.On("All", mock.AnythingOfType("*[]code.Commit"), mock.Anything).Run(func(args mock.Arguments) {
+			lostShas = args.Get(1).([]dal.Clause)[0].Data.(dal.DalClause).Params[0].([]string)
+			*args.Get(0).(*[]code.Commit) = []code.Commit{{Sha: shaC, Message: "second"}, {Sha: shaB, Message: "first"}}
+		}).Return(nil).Once()
+		mockDal.On("All", mock.AnythingOfType("*[]ticket.Issue"), mock.Anything).Run(func(args mock.Arguments) {
+			*args.Get(0).(*[]ticket.Issue) = []ticket.Issue{{IssueKey: "DL-1", Title: "fix"}}
+		}).Return(nil).Once()
+	})
+
+	output, err := Compare(&plugin.ApiResourceInput{Body: map[string]interface{}{
+		"repoId": "github:GithubRepo:1:1",
+		"newRef": "refs/tags/v2.0.0",
+		"oldRef": shaA,
+	}})
+	assert.Nil(t, err)
+	result := output.Body.(*CompareResult)
+	assert.Equal(t, shaC, result.NewCommitSha)
+	assert.Equal(t, shaA, result.OldCommitSha)
+	assert.ElementsMatch(t, []string{shaB, shaC}, lostShas)
+	assert.Equal(t, []string{shaC, shaB}, []string{result.Commits[0].Sha, result.Commits[1].Sha})
+	assert.Equal(t, []CompareIssue{{IssueKey: "DL-1", Title: "fix"}}, result.Issues)
+}
+
+func TestCompareRequiresBothRefs(t *testing.T) {
+	_, err := Compare(&plugin.ApiResourceInput{Body: map[string]interface{}{
+		"repoId": "github:GithubRepo:1:1",
+		"newRef": "refs/tags/v2.0.0",
+	}})
+	if assert.NotNil(t, err) {
+		assert.Equal(t, errors.BadInput, err.GetType())
+	}
+}
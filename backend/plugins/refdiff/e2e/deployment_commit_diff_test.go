@@ -49,6 +49,7 @@ func TestDeploymentCommitDiffDataFlow(t *testing.T) {
 	// verify extraction
 	dataflowTester.FlushTabler(&code.CommitsDiff{})
 	dataflowTester.FlushTabler(&models.FinishedCommitsDiff{})
+	dataflowTester.FlushTabler(&models.DeploymentDiffState{})
 
 	dataflowTester.Subtask(tasks.CalculateDeploymentCommitsDiffMeta, taskData)
 	dataflowTester.VerifyTableWithOptions(&code.CommitsDiff{}, e2ehelper.TableOptions{
@@ -0,0 +1,71 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/refdiff/impl"
+	"github.com/apache/incubator-devlake/plugins/refdiff/models"
+	"github.com/apache/incubator-devlake/plugins/refdiff/tasks"
+)
+
+func TestLinkReleasesDataFlow(t *testing.T) {
+
+	var plugin impl.RefDiff
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "refdiff", plugin)
+
+	taskData := &tasks.RefdiffTaskData{
+		Options: &models.RefdiffOptions{
+			RepoId: "repo1",
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoTabler("./release_linker/releases.csv", &crossdomain.Release{})
+	dataflowTester.ImportCsvIntoTabler("./release_linker/commits_diffs.csv", &code.CommitsDiff{})
+	dataflowTester.ImportCsvIntoTabler("./release_linker/pull_requests.csv", &code.PullRequest{})
+	dataflowTester.ImportCsvIntoTabler("./release_linker/pull_request_commits.csv", &code.PullRequestCommit{})
+	dataflowTester.ImportCsvIntoTabler("./release_linker/pull_request_issues.csv", &crossdomain.PullRequestIssue{})
+	dataflowTester.ImportCsvIntoTabler("./release_linker/cicd_deployment_commits.csv", &devops.CicdDeploymentCommit{})
+
+	// verify the links, the pull requests are merged or contain commits between the tags of the release
+	dataflowTester.FlushTabler(&crossdomain.ReleasePullRequest{})
+	dataflowTester.FlushTabler(&crossdomain.ReleaseIssue{})
+	dataflowTester.FlushTabler(&crossdomain.ReleaseDeployment{})
+	dataflowTester.Subtask(tasks.LinkReleasesMeta, taskData)
+	dataflowTester.VerifyTable(
+		crossdomain.ReleasePullRequest{},
+		"./release_linker/release_pull_requests.csv",
+		[]string{"release_id", "pull_request_id"},
+	)
+	dataflowTester.VerifyTable(
+		crossdomain.ReleaseIssue{},
+		"./release_linker/release_issues.csv",
+		[]string{"release_id", "issue_id"},
+	)
+	dataflowTester.VerifyTable(
+		crossdomain.ReleaseDeployment{},
+		"./release_linker/release_deployments.csv",
+		[]string{"release_id", "deployment_id"},
+	)
+}
@@ -18,25 +18,35 @@ limitations under the License.
 package impl
 
 import (
+	"github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/plugin"
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/refdiff/api"
 	"github.com/apache/incubator-devlake/plugins/refdiff/models"
+	"github.com/apache/incubator-devlake/plugins/refdiff/models/migrationscripts"
 	"github.com/apache/incubator-devlake/plugins/refdiff/tasks"
 )
 
 // make sure interface is implemented
 var _ interface {
 	plugin.PluginMeta
+	plugin.PluginInit
 	plugin.PluginTask
 	plugin.PluginApi
 	plugin.PluginModel
+	plugin.PluginMigration
 	plugin.PluginMetric
 } = (*RefDiff)(nil)
 
 type RefDiff struct{}
 
+func (p RefDiff) Init(basicRes context.BasicRes) errors.Error {
+	api.Init(basicRes)
+	return nil
+}
+
 func (p RefDiff) Description() string {
 	return "Calculate commits diff for specified ref pairs based on `commits` and `commit_parents` tables"
 }
@@ -52,6 +62,7 @@ func (p RefDiff) RequiredDataEntities() (data []map[string]interface{}, err erro
 func (p RefDiff) GetTablesInfo() []dal.Tabler {
 	return []dal.Tabler{
 		&models.FinishedCommitsDiff{},
+		&models.DeploymentDiffState{},
 	}
 }
 
@@ -73,6 +84,7 @@ func (p RefDiff) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.CalculateIssuesDiffMeta,
 		tasks.CalculatePrCherryPickMeta,
 		tasks.CalculateDeploymentCommitsDiffMeta,
+		tasks.LinkReleasesMeta,
 	}
 }
 
@@ -88,11 +100,11 @@ func (p RefDiff) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]
 	tagsLimit := op.TagsLimit
 	tagsOrder := op.TagsOrder
 
-	rs, err := tasks.CalculateTagPattern(db, tagsPattern, tagsLimit, tagsOrder)
+	rs, err := tasks.CalculateTagPattern(db, tagsPattern, tagsLimit, tagsOrder, op.TagsSkipPrerelease)
 	if err != nil {
 		return nil, err
 	}
-	op.AllPairs, err = tasks.CalculateCommitPairs(db, op.RepoId, op.Pairs, rs)
+	op.AllPairs, err = tasks.CalculateCommitPairs(db, op.RepoId, op.Pairs, rs, op.TagsCompareFinalReleases)
 	if err != nil {
 		return nil, err
 	}
@@ -107,6 +119,14 @@ func (p RefDiff) RootPkgPath() string {
 	return "github.com/apache/incubator-devlake/plugins/refdiff"
 }
 
+func (p RefDiff) MigrationScripts() []plugin.MigrationScript {
+	return migrationscripts.All()
+}
+
 func (p RefDiff) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
-	return nil
+	return map[string]map[string]plugin.ApiResourceHandler{
+		"compare": {
+			"POST": api.Compare,
+		},
+	}
 }
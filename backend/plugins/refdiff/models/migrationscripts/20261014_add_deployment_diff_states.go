@@ -0,0 +1,46 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+	"github.com/apache/incubator-devlake/plugins/refdiff/models/migrationscripts/archived"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addDeploymentDiffStates)(nil)
+
+type addDeploymentDiffStates struct{}
+
+func (*addDeploymentDiffStates) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, &archived.DeploymentDiffState{})
+}
+
+func (*addDeploymentDiffStates) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropTables(&archived.DeploymentDiffState{})
+}
+
+func (*addDeploymentDiffStates) Version() uint64 {
+	return 20261014150000
+}
+
+func (*addDeploymentDiffStates) Name() string {
+	return "add _tool_refdiff_deployment_diff_states table"
+}
@@ -33,6 +33,10 @@ type RefdiffOptions struct {
 	TagsPattern string // The Pattern to match from all tags
 	TagsLimit   int    // How many tags be matched should be used.
 	TagsOrder   string // The Rule to Order the tag list
+	// Leave pre-releases like v1.2.0-rc.1 out of the tag list
+	TagsSkipPrerelease bool
+	// Compare a final release with the previous final release instead of the pre-releases in between
+	TagsCompareFinalReleases bool
 
 	AllPairs    RefCommitPairs // Pairs and TagsPattern Pairs
 	ProjectName string
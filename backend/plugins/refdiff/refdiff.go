@@ -36,6 +36,8 @@ func main() {
 	tagsPattern := refdiffCmd.Flags().StringP("tags-pattern", "p", "", "tags pattern")
 	tagsLimit := refdiffCmd.Flags().IntP("tags-limit", "l", 2, "tags limit")
 	tagsOrder := refdiffCmd.Flags().StringP("tags-order", "d", "", "tags order")
+	tagsSkipPrerelease := refdiffCmd.Flags().Bool("tags-skip-prerelease", false, "skip pre-release tags like v1.2.0-rc.1")
+	tagsCompareFinalReleases := refdiffCmd.Flags().Bool("tags-compare-final-releases", false, "compare a final release with the previous final release")
 
 	projectName := refdiffCmd.Flags().StringP("project-name", "P", "", "project name")
 	timeAfter := refdiffCmd.Flags().StringP("time-after", "a", "", "collect data that are created after specified time, ie 2006-01-02T15:04:05Z")
@@ -54,12 +56,14 @@ func main() {
 		}
 
 		runner.DirectRun(cmd, args, PluginEntry, map[string]interface{}{
-			"repoId":      repoId,
-			"pairs":       pairs,
-			"tagsPattern": *tagsPattern,
-			"tagsLimit":   *tagsLimit,
-			"tagsOrder":   *tagsOrder,
-			"projectName": *projectName,
+			"repoId":                   repoId,
+			"pairs":                    pairs,
+			"tagsPattern":              *tagsPattern,
+			"tagsLimit":                *tagsLimit,
+			"tagsOrder":                *tagsOrder,
+			"tagsSkipPrerelease":       *tagsSkipPrerelease,
+			"tagsCompareFinalReleases": *tagsCompareFinalReleases,
+			"projectName":              *projectName,
 		}, *timeAfter)
 	}
 	runner.RunCmd(refdiffCmd)
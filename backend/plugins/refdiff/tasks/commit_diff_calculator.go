@@ -18,6 +18,7 @@ limitations under the License.
 package tasks
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 
@@ -71,35 +72,13 @@ func CalculateCommitsDiff(taskCtx plugin.SubTaskContext) errors.Error {
 		return nil
 	}
 
-	commitNodeGraph := utils.NewCommitNodeGraph()
 	// mysql limit
 	insertCountLimitOfCommitsDiff := int(65535 / reflect.ValueOf(code.CommitsDiff{}).NumField())
 
-	// load commits from db
-	cursor, err := db.Cursor(
-		dal.Select("cp.*"),
-		dal.Join("LEFT JOIN repo_commits rc ON (rc.commit_sha = cp.commit_sha)"),
-		dal.From("commit_parents cp"),
-		dal.Where("rc.repo_id = ?", repoId),
-	)
+	commitNodeGraph, err := LoadCommitNodeGraph(ctx, db, repoId)
 	if err != nil {
 		return err
 	}
-	defer cursor.Close()
-
-	for cursor.Next() {
-		select {
-		case <-ctx.Done():
-			return errors.Convert(ctx.Err())
-		default:
-		}
-		commitParent := &code.CommitParent{}
-		err = db.Fetch(cursor, commitParent)
-		if err != nil {
-			return errors.Default.Wrap(err, "failed to read commit from database")
-		}
-		commitNodeGraph.AddParent(commitParent.CommitSha, commitParent.ParentCommitSha)
-	}
 
 	logger.Info("Create a commit node graph with node count[%d]", commitNodeGraph.Size())
 
@@ -191,6 +170,36 @@ func CalculateCommitsDiff(taskCtx plugin.SubTaskContext) errors.Error {
 	return nil
 }
 
+// LoadCommitNodeGraph builds the commit graph of the repo out of commit_parents
+func LoadCommitNodeGraph(ctx context.Context, db dal.Dal, repoId string) (*utils.CommitNodeGraph, errors.Error) {
+	commitNodeGraph := utils.NewCommitNodeGraph()
+	cursor, err := db.Cursor(
+		dal.Select("cp.*"),
+		dal.Join("LEFT JOIN repo_commits rc ON (rc.commit_sha = cp.commit_sha)"),
+		dal.From("commit_parents cp"),
+		dal.Where("rc.repo_id = ?", repoId),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	for cursor.Next() {
+		select {
+		case <-ctx.Done():
+			return nil, errors.Convert(ctx.Err())
+		default:
+		}
+		commitParent := &code.CommitParent{}
+		err = db.Fetch(cursor, commitParent)
+		if err != nil {
+			return nil, errors.Default.Wrap(err, "failed to read commit from database")
+		}
+		commitNodeGraph.AddParent(commitParent.CommitSha, commitParent.ParentCommitSha)
+	}
+	return commitNodeGraph, nil
+}
+
 var CalculateCommitsDiffMeta = plugin.SubTaskMeta{
 	Name:             "calculateCommitsDiff",
 	EntryPoint:       CalculateCommitsDiff,
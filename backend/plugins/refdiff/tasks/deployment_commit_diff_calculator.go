@@ -20,6 +20,7 @@ package tasks
 import (
 	"context"
 	"reflect"
+	"time"
 
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
@@ -48,11 +49,10 @@ func CalculateDeploymentCommitsDiff(taskCtx plugin.SubTaskContext) errors.Error
 		return nil
 	}
 
-	// step 1. select all deployment commits that need to be calculated
-	pairs := make([]*deploymentCommitPair, 0)
-	err := db.All(
-		&pairs,
-		dal.Select("dc.id, dc.commit_sha, p.commit_sha as prev_commit_sha"),
+	// step 1. select deployment commits that need to be calculated, deployments before the last calculated one
+	// of the same chain are skipped unless a full sync was requested
+	clauses := []dal.Clause{
+		dal.Select("dc.id, dc.cicd_scope_id, dc.repo_url, dc.environment, dc.repo_id, dc.started_date, dc.commit_sha, p.commit_sha as prev_commit_sha"),
 		dal.From("cicd_deployment_commits dc"),
 		dal.Join("LEFT JOIN project_mapping pm ON (pm.table = 'cicd_scopes' AND pm.row_id = dc.cicd_scope_id)"),
 		dal.Join("LEFT JOIN cicd_deployment_commits p ON (dc.prev_success_deployment_commit_id = p.id)"),
@@ -67,8 +67,19 @@ func CalculateDeploymentCommitsDiff(taskCtx plugin.SubTaskContext) errors.Error
 			`,
 			data.Options.ProjectName,
 		),
-		dal.Orderby(`dc.cicd_scope_id, dc.repo_url, dc.environment, dc.started_date`),
-	)
+	}
+	syncPolicy := taskCtx.TaskContext().SyncPolicy()
+	if syncPolicy == nil || !syncPolicy.FullSync {
+		clauses = append(clauses,
+			dal.Join(`LEFT JOIN _tool_refdiff_deployment_diff_states s ON (
+				s.cicd_scope_id = dc.cicd_scope_id AND s.repo_url = dc.repo_url AND s.environment = dc.environment
+			)`),
+			dal.Where("s.last_started_date IS NULL OR dc.started_date > s.last_started_date"),
+		)
+	}
+	clauses = append(clauses, dal.Orderby(`dc.cicd_scope_id, dc.repo_url, dc.environment, dc.started_date`))
+	pairs := make([]*deploymentCommitPair, 0)
+	err := db.All(&pairs, clauses...)
 	if err != nil {
 		return err
 	}
@@ -78,8 +89,8 @@ func CalculateDeploymentCommitsDiff(taskCtx plugin.SubTaskContext) errors.Error
 		return nil
 	}
 
-	// step 2. construct a commit node graph and batch save
-	graph, err := loadCommitGraph(ctx, db, data)
+	// step 2. construct a commit node graph of the deployed repos and batch save
+	graph, err := loadCommitGraph(ctx, db, data, deployedRepoIds(pairs))
 	if err != nil {
 		return err
 	}
@@ -88,7 +99,10 @@ func CalculateDeploymentCommitsDiff(taskCtx plugin.SubTaskContext) errors.Error
 		return err
 	}
 
-	// step 3. iterate all pairs and calculate diff
+	// step 3. iterate all pairs and calculate diff, the commits reachable from the last calculated deployment
+	// are kept along the chain, so the history is walked once per chain instead of once per pair
+	var chain *models.DeploymentDiffState
+	var reachable map[string]struct{}
 	taskCtx.SetProgress(0, pairsCount)
 	for _, pair := range pairs {
 		select {
@@ -96,7 +110,19 @@ func CalculateDeploymentCommitsDiff(taskCtx plugin.SubTaskContext) errors.Error
 			return errors.Convert(ctx.Err())
 		default:
 		}
-		lostSha, oldCount, newCount := graph.CalculateLostSha(pair.PrevCommitSha, pair.CommitSha)
+		if chain == nil || chain.CicdScopeId != pair.CicdScopeId || chain.RepoUrl != pair.RepoUrl || chain.Environment != pair.Environment {
+			chain = &models.DeploymentDiffState{
+				CicdScopeId: pair.CicdScopeId,
+				RepoUrl:     pair.RepoUrl,
+				Environment: pair.Environment,
+			}
+			reachable = nil
+		}
+		if reachable == nil || chain.LastCommitSha != pair.PrevCommitSha {
+			reachable = graph.Ancestors(pair.PrevCommitSha)
+		}
+		oldCount := len(reachable)
+		lostSha := graph.CollectLostSha(pair.CommitSha, reachable)
 		for i, sha := range lostSha {
 			commitsDiff := &code.CommitsDiff{
 				NewCommitSha: pair.CommitSha,
@@ -122,10 +148,18 @@ func CalculateDeploymentCommitsDiff(taskCtx plugin.SubTaskContext) errors.Error
 		if err != nil {
 			return err
 		}
+		// move the watermark of the chain forward
+		chain.LastDeploymentCommitId = pair.Id
+		chain.LastCommitSha = pair.CommitSha
+		chain.LastStartedDate = pair.StartedDate
+		err = db.CreateOrUpdate(chain)
+		if err != nil {
+			return err
+		}
 
 		logger.Info(
 			"total %d commits of difference found between [new][%s] and [old][%s(total:%d)]",
-			newCount,
+			len(lostSha),
 			pair.CommitSha,
 			pair.PrevCommitSha,
 			oldCount,
@@ -137,20 +171,49 @@ func CalculateDeploymentCommitsDiff(taskCtx plugin.SubTaskContext) errors.Error
 
 type deploymentCommitPair struct {
 	Id            string
+	CicdScopeId   string
+	RepoUrl       string
+	Environment   string
+	RepoId        string
+	StartedDate   *time.Time
 	CommitSha     string
 	PrevCommitSha string
 }
 
-func loadCommitGraph(ctx context.Context, db dal.Dal, data *RefdiffTaskData) (*utils.CommitNodeGraph, errors.Error) {
+// deployedRepoIds returns the repos of the given deployments, or nil if any of them is unknown
+func deployedRepoIds(pairs []*deploymentCommitPair) []string {
+	repoIds := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, pair := range pairs {
+		if pair.RepoId == "" {
+			return nil
+		}
+		if !seen[pair.RepoId] {
+			seen[pair.RepoId] = true
+			repoIds = append(repoIds, pair.RepoId)
+		}
+	}
+	return repoIds
+}
+
+// loadCommitGraph loads the commits of the given repos, or all repos of the project if repoIds is empty
+func loadCommitGraph(ctx context.Context, db dal.Dal, data *RefdiffTaskData, repoIds []string) (*utils.CommitNodeGraph, errors.Error) {
 	graph := utils.NewCommitNodeGraph()
 
-	cursor, err := db.Cursor(
+	clauses := []dal.Clause{
 		dal.Select("cp.commit_sha, cp.parent_commit_sha"),
 		dal.From("commit_parents cp"),
 		dal.Join("LEFT JOIN repo_commits rc ON (rc.commit_sha = cp.commit_sha)"),
-		dal.Join("LEFT JOIN project_mapping pm ON (pm.table = 'repos' AND pm.row_id = rc.repo_id)"),
-		dal.Where("pm.project_name = ?", data.Options.ProjectName),
-	)
+	}
+	if len(repoIds) > 0 {
+		clauses = append(clauses, dal.Where("rc.repo_id IN ?", repoIds))
+	} else {
+		clauses = append(clauses,
+			dal.Join("LEFT JOIN project_mapping pm ON (pm.table = 'repos' AND pm.row_id = rc.repo_id)"),
+			dal.Where("pm.project_name = ?", data.Options.ProjectName),
+		)
+	}
+	cursor, err := db.Cursor(clauses...)
 	if err != nil {
 		return nil, err
 	}
@@ -28,6 +28,7 @@ import (
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
 	"github.com/apache/incubator-devlake/plugins/refdiff/models"
+	"github.com/apache/incubator-devlake/plugins/refdiff/utils"
 )
 
 type RefdiffTaskData struct {
@@ -76,6 +77,11 @@ func (rs RefsSemver) Len() int {
 }
 
 func (rs RefsSemver) Less(i, j int) bool {
+	vi, iok := utils.ParseSemver(rs[i].Name)
+	vj, jok := utils.ParseSemver(rs[j].Name)
+	if iok && jok {
+		return vi.Compare(vj) < 0
+	}
 	parti := strings.Split(rs[i].Name, ".")
 	partj := strings.Split(rs[j].Name, ".")
 
@@ -117,8 +123,8 @@ func (rs RefsReverseSemver) Swap(i, j int) {
 	rs[i], rs[j] = rs[j], rs[i]
 }
 
-// CalculateTagPattern Calculate the TagPattern order by tagsOrder and return the Refs
-func CalculateTagPattern(db dal.Dal, tagsPattern string, tagsLimit int, tagsOrder string) (Refs, errors.Error) {
+// CalculateTagPattern Calculate the TagPattern order by tagsOrder and return the Refs, pre-releases are left out if skipPrerelease is set
+func CalculateTagPattern(db dal.Dal, tagsPattern string, tagsLimit int, tagsOrder string, skipPrerelease bool) (Refs, errors.Error) {
 	rs := Refs{}
 
 	// caculate Pattern part
@@ -146,9 +152,13 @@ func CalculateTagPattern(db dal.Dal, tagsPattern string, tagsLimit int, tagsOrde
 			return rs, err
 		}
 
-		if ok := r.Match([]byte(ref.Name)); ok {
-			rs = append(rs, ref)
+		if ok := r.Match([]byte(ref.Name)); !ok {
+			continue
+		}
+		if v, ok := utils.ParseSemver(ref.Name); ok && skipPrerelease && v.IsPrerelease() {
+			continue
 		}
+		rs = append(rs, ref)
 	}
 	switch tagsOrder {
 	case "alphabetically":
@@ -169,11 +179,36 @@ func CalculateTagPattern(db dal.Dal, tagsPattern string, tagsLimit int, tagsOrde
 	return rs, nil
 }
 
-// CalculateCommitPairs Calculate the commits pairs both from Options.Pairs and TagPattern
-func CalculateCommitPairs(db dal.Dal, repoId string, pairs []models.RefPair, rs Refs) (models.RefCommitPairs, errors.Error) {
+// previousFinalReleaseIndex returns the index of the ref that rs[i] should be compared with. When the refs are in
+// descending semver order, a final release skips the pre-releases in between (e.g. v2.0.0-rc.1) and gets compared
+// with the previous final release, so the diff covers everything shipped since then. Otherwise it's the next one.
+func previousFinalReleaseIndex(rs Refs, i int) int {
+	v, ok := utils.ParseSemver(rs[i].Name)
+	if !ok || v.IsPrerelease() {
+		return i + 1
+	}
+	for j := i + 1; j < len(rs); j++ {
+		prev, ok := utils.ParseSemver(rs[j].Name)
+		if !ok || prev.Compare(v) >= 0 {
+			break
+		}
+		if !prev.IsPrerelease() {
+			return j
+		}
+	}
+	return i + 1
+}
+
+// CalculateCommitPairs Calculate the commits pairs both from Options.Pairs and TagPattern, each tag is paired with the
+// next one unless compareFinalReleases is set
+func CalculateCommitPairs(db dal.Dal, repoId string, pairs []models.RefPair, rs Refs, compareFinalReleases bool) (models.RefCommitPairs, errors.Error) {
 	commitPairs := make(models.RefCommitPairs, 0, len(rs)+len(pairs))
-	for i := 1; i < len(rs); i++ {
-		commitPairs = append(commitPairs, [4]string{rs[i-1].CommitSha, rs[i].CommitSha, rs[i-1].Name, rs[i].Name})
+	for i := 0; i < len(rs)-1; i++ {
+		j := i + 1
+		if compareFinalReleases {
+			j = previousFinalReleaseIndex(rs, i)
+		}
+		commitPairs = append(commitPairs, [4]string{rs[i].CommitSha, rs[j].CommitSha, rs[i].Name, rs[j].Name})
 	}
 
 	// caculate pairs part
@@ -0,0 +1,64 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/plugins/refdiff/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculateCommitPairs(t *testing.T) {
+	rs := Refs{
+		{Name: "v2.0.0", CommitSha: "c4"},
+		{Name: "v2.0.0-rc.2", CommitSha: "c3"},
+		{Name: "v2.0.0-rc.1", CommitSha: "c2"},
+		{Name: "v1.9.0", CommitSha: "c1"},
+	}
+	tests := []struct {
+		name                 string
+		compareFinalReleases bool
+		want                 models.RefCommitPairs
+	}{
+		{
+			name: "each tag is paired with the next one",
+			want: models.RefCommitPairs{
+				{"c4", "c3", "v2.0.0", "v2.0.0-rc.2"},
+				{"c3", "c2", "v2.0.0-rc.2", "v2.0.0-rc.1"},
+				{"c2", "c1", "v2.0.0-rc.1", "v1.9.0"},
+			},
+		},
+		{
+			name:                 "a final release is paired with the previous final release",
+			compareFinalReleases: true,
+			want: models.RefCommitPairs{
+				{"c4", "c1", "v2.0.0", "v1.9.0"},
+				{"c3", "c2", "v2.0.0-rc.2", "v2.0.0-rc.1"},
+				{"c2", "c1", "v2.0.0-rc.1", "v1.9.0"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CalculateCommitPairs(nil, "repo", nil, rs, tt.compareFinalReleases)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
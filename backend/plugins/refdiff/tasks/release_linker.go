@@ -0,0 +1,119 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+type releaseLinkParams struct {
+	RepoId string
+}
+
+// LinkReleases links the releases of the repo to the pull requests merged between the release tag and the
+// previous tag (from commits_diffs), to the issues of those pull requests, and to the deployments of the release commit
+func LinkReleases(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*RefdiffTaskData)
+	repoId := data.Options.RepoId
+
+	if data.Options.ProjectName != "" {
+		return nil
+	}
+
+	combinePr := dal.Join(
+		`left join (
+		select pull_request_id as id, commit_sha from pull_request_commits
+			left join pull_requests p on pull_request_commits.pull_request_id = p.id
+			where p.base_repo_id = ?
+		union
+		select id, merge_commit_sha as commit_sha from pull_requests where base_repo_id = ?) _combine_pr
+		on _combine_pr.commit_sha = commits_diffs.commit_sha`, repoId, repoId)
+
+	err := linkReleases(taskCtx, reflect.TypeOf(crossdomain.ReleasePullRequest{}),
+		dal.From("releases"),
+		dal.Join("inner join commits_diffs on commits_diffs.new_commit_sha = releases.commit_sha"),
+		combinePr,
+		dal.Where("releases.repo_id = ? AND releases.commit_sha != '' AND _combine_pr.id IS NOT NULL", repoId),
+		dal.Select("distinct releases.id as release_id, _combine_pr.id as pull_request_id"),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = linkReleases(taskCtx, reflect.TypeOf(crossdomain.ReleaseIssue{}),
+		dal.From("releases"),
+		dal.Join("inner join commits_diffs on commits_diffs.new_commit_sha = releases.commit_sha"),
+		combinePr,
+		dal.Join("inner join pull_request_issues on pull_request_issues.pull_request_id = _combine_pr.id"),
+		dal.Where("releases.repo_id = ? AND releases.commit_sha != ''", repoId),
+		dal.Select("distinct releases.id as release_id, pull_request_issues.issue_id as issue_id"),
+	)
+	if err != nil {
+		return err
+	}
+
+	return linkReleases(taskCtx, reflect.TypeOf(crossdomain.ReleaseDeployment{}),
+		dal.From("releases"),
+		dal.Join(`inner join cicd_deployment_commits
+			on cicd_deployment_commits.commit_sha = releases.commit_sha
+			and cicd_deployment_commits.repo_id = releases.repo_id`),
+		dal.Where("releases.repo_id = ? AND releases.commit_sha != '' AND cicd_deployment_commits.cicd_deployment_id != ''", repoId),
+		dal.Select("distinct releases.id as release_id, cicd_deployment_commits.cicd_deployment_id as deployment_id"),
+	)
+}
+
+func linkReleases(taskCtx plugin.SubTaskContext, rowType reflect.Type, clauses ...dal.Clause) errors.Error {
+	data := taskCtx.GetData().(*RefdiffTaskData)
+	cursor, err := taskCtx.GetDal().Cursor(clauses...)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType: rowType,
+		Input:        cursor,
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx:    taskCtx,
+			Table:  "releases",
+			Params: releaseLinkParams{RepoId: data.Options.RepoId},
+		},
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			return []interface{}{inputRow}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+var LinkReleasesMeta = plugin.SubTaskMeta{
+	Name:             "linkReleases",
+	EntryPoint:       LinkReleases,
+	EnabledByDefault: true,
+	Description:      "Link releases to the pull requests, issues and deployments of their tag",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+}
@@ -105,6 +105,39 @@ func (cng *CommitNodeGraph) CalculateLostSha(source_sha string, target_sha strin
 	return lostSha, len(oldGroup), len(newGroup)
 }
 
+// Ancestors returns all commit sha reachable from the given commit, the commit itself included
+func (cng *CommitNodeGraph) Ancestors(sha string) map[string]struct{} {
+	reachable := make(map[string]struct{})
+	cng.CollectLostSha(sha, reachable)
+	return reachable
+}
+
+// CollectLostSha returns commit sha reachable from the given commit but not in the reachable set, in the same order as
+// CalculateLostSha does. The returned sha are added to the set, so it becomes the ancestors of the given commit, which
+// allows calculating a chain of diff (e.g. successive deployments) without walking the whole history for every pair
+func (cng *CommitNodeGraph) CollectLostSha(sha string, reachable map[string]struct{}) []string {
+	var lostSha []string
+	commitNode, ok := cng.node[sha]
+	if !ok {
+		commitNode = &CommitNode{
+			Sha: sha,
+		}
+	}
+	var dfs func(*CommitNode)
+	dfs = func(now *CommitNode) {
+		if _, ok := reachable[now.Sha]; ok {
+			return
+		}
+		reachable[now.Sha] = struct{}{}
+		lostSha = append(lostSha, now.Sha)
+		for _, node := range now.Parent {
+			dfs(node)
+		}
+	}
+	dfs(commitNode)
+	return lostSha
+}
+
 func (cng *CommitNodeGraph) Size() int {
 	return len(cng.node)
 }
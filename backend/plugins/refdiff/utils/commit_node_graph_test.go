@@ -0,0 +1,46 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectLostShaAlongChain(t *testing.T) {
+	// a -- b -- c -- e -- f
+	//       \       /
+	//        --- d -
+	graph := NewCommitNodeGraph()
+	graph.AddParent("b", "a")
+	graph.AddParent("c", "b")
+	graph.AddParent("d", "b")
+	graph.AddParent("e", "c")
+	graph.AddParent("e", "d")
+	graph.AddParent("f", "e")
+
+	reachable := graph.Ancestors("b")
+	assert.Len(t, reachable, 2)
+	for _, pair := range [][2]string{{"b", "c"}, {"c", "e"}, {"e", "f"}} {
+		expected, _, _ := graph.CalculateLostSha(pair[0], pair[1])
+		assert.Equal(t, expected, graph.CollectLostSha(pair[1], reachable))
+	}
+	assert.Len(t, reachable, 6)
+	assert.Empty(t, graph.CollectLostSha("d", reachable))
+}
@@ -0,0 +1,65 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSemver(t *testing.T) {
+	v, ok := ParseSemver("v1.2.3-rc.1+build.5")
+	assert.True(t, ok)
+	assert.Equal(t, &Semver{Major: 1, Minor: 2, Patch: 3, Prerelease: []string{"rc", "1"}}, v)
+
+	v, ok = ParseSemver("release-2.10")
+	assert.True(t, ok)
+	assert.Equal(t, &Semver{Major: 2, Minor: 10}, v)
+	assert.False(t, v.IsPrerelease())
+
+	_, ok = ParseSemver("nightly")
+	assert.False(t, ok)
+}
+
+func TestSemverCompare(t *testing.T) {
+	// ordered by precedence as listed in the semver spec
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"v1.0.1",
+		"1.2.0",
+		"1.10.0",
+		"2.0.0",
+	}
+	for i := 1; i < len(ordered); i++ {
+		lower, _ := ParseSemver(ordered[i-1])
+		higher, _ := ParseSemver(ordered[i])
+		assert.Equal(t, -1, lower.Compare(higher), "%s < %s", ordered[i-1], ordered[i])
+		assert.Equal(t, 1, higher.Compare(lower), "%s > %s", ordered[i], ordered[i-1])
+	}
+	a, _ := ParseSemver("v1.0.0+build.1")
+	b, _ := ParseSemver("1.0.0")
+	assert.Equal(t, 0, a.Compare(b))
+}
@@ -20,8 +20,12 @@ package api
 import (
 	"github.com/apache/incubator-devlake/core/errors"
 	coreModels "github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
 	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/core/utils"
 	helperapi "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/slack/models"
 	"github.com/apache/incubator-devlake/plugins/slack/tasks"
 )
 
@@ -37,14 +41,16 @@ func MakeDataSourcePipelinePlanV200(
 	}
 	// Build one stage per selected channel
 	plan := make(coreModels.PipelinePlan, len(scopeDetails))
+	scopes := make([]plugin.Scope, 0, len(scopeDetails))
+	idgen := didgen.NewDomainIdGenerator(&models.SlackChannel{})
 	for i, scopeDetail := range scopeDetails {
 		stage := plan[i]
 		if stage == nil {
 			stage = coreModels.PipelineStage{}
 		}
-		// Only include CROSS domain subtasks; Slack subtasks define DomainTypes accordingly.
-		entities := []string{plugin.DOMAIN_TYPE_CROSS}
-		scope := scopeDetail.Scope // *models.SlackChannel
+		// The messages are always collected, the incidents are found in them only for the TICKET entity
+		scope, scopeConfig := scopeDetail.Scope, scopeDetail.ScopeConfig
+		entities := append([]string{plugin.DOMAIN_TYPE_CROSS}, scopeConfig.Entities...)
 		task, err := helperapi.MakePipelinePlanTask(
 			"slack",
 			subtaskMetas,
@@ -56,7 +62,10 @@ func MakeDataSourcePipelinePlanV200(
 		}
 		stage = append(stage, task)
 		plan[i] = stage
+		// the incident channels are boards
+		if utils.StringsContains(entities, plugin.DOMAIN_TYPE_TICKET) {
+			scopes = append(scopes, ticket.NewBoard(idgen.Generate(connectionId, scope.Id), scope.Name))
+		}
 	}
-	// No domain scopes emitted by Slack for now
-	return plan, nil, nil
+	return plan, scopes, nil
 }
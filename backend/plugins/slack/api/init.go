@@ -21,7 +21,6 @@ import (
 	"github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
-	"github.com/apache/incubator-devlake/helpers/srvhelper"
 	"github.com/apache/incubator-devlake/plugins/slack/models"
 	"github.com/go-playground/validator/v10"
 )
@@ -29,10 +28,21 @@ import (
 var vld *validator.Validate
 var connectionHelper *api.ConnectionApiHelper
 var basicRes context.BasicRes
-var dsHelper *api.DsHelper[models.SlackConnection, models.SlackChannel, srvhelper.NoScopeConfig]
+var dsHelper *api.DsHelper[models.SlackConnection, models.SlackChannel, models.SlackScopeConfig]
 var raProxy *api.DsRemoteApiProxyHelper[models.SlackConnection]
 var raScopeList *api.DsRemoteApiScopeListHelper[models.SlackConnection, models.SlackChannel, SlackRemotePagination]
 var raScopeSearch *api.DsRemoteApiScopeSearchHelper[models.SlackConnection, models.SlackChannel]
+var workflowIncidentReceiver *api.WebhookReceiver
+
+// workflowIncidentSignature verifies the incidents pushed to the connections having a signing secret the way Slack
+// signs its requests, i.e. the HMAC of "v0:1700000000:<body>"
+var workflowIncidentSignature = &api.TimestampedHmacSignature{
+	HmacSignature:   api.HmacSignature{Header: "X-Slack-Signature", Prefix: "v0="},
+	TimestampHeader: "X-Slack-Request-Timestamp",
+	Format: func(timestamp string, body []byte) []byte {
+		return []byte("v0:" + timestamp + ":" + string(body))
+	},
+}
 
 func Init(br context.BasicRes, p plugin.PluginMeta) {
 
@@ -45,7 +55,7 @@ func Init(br context.BasicRes, p plugin.PluginMeta) {
 	)
 
 	dsHelper = api.NewDataSourceHelper[
-		models.SlackConnection, models.SlackChannel, srvhelper.NoScopeConfig,
+		models.SlackConnection, models.SlackChannel, models.SlackScopeConfig,
 	](
 		br,
 		p.Name(),
@@ -57,4 +67,11 @@ func Init(br context.BasicRes, p plugin.PluginMeta) {
 	raProxy = api.NewDsRemoteApiProxyHelper[models.SlackConnection](dsHelper.ConnApi.ModelApiHelper)
 	raScopeList = api.NewDsRemoteApiScopeListHelper[models.SlackConnection, models.SlackChannel, SlackRemotePagination](raProxy, listSlackRemoteScopes)
 	raScopeSearch = api.NewDsRemoteApiScopeSearchHelper[models.SlackConnection, models.SlackChannel](raProxy, searchSlackRemoteScopes)
+	workflowIncidentReceiver = api.NewWebhookReceiver(basicRes, api.WebhookReceiverArgs{
+		Plugin:     p.Name(),
+		Event:      "workflow_incidents",
+		NewPayload: func() interface{} { return &WorkflowIncidentReq{} },
+		Signature:  workflowIncidentSignature,
+		Handle:     saveWorkflowIncident,
+	})
 }
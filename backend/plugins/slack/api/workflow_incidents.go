@@ -0,0 +1,168 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/slack/models"
+	"github.com/apache/incubator-devlake/plugins/slack/tasks"
+)
+
+const (
+	WORKFLOW_INCIDENT_DECLARED = "declared"
+	WORKFLOW_INCIDENT_JOINED   = "joined"
+	WORKFLOW_INCIDENT_RESOLVED = "resolved"
+)
+
+// WorkflowIncidentReq is sent by a Slack Workflow when an incident is declared, a responder joins, or it is resolved
+type WorkflowIncidentReq struct {
+	Event      string `mapstructure:"event" validate:"required,oneof=declared joined resolved"`
+	IncidentId string `mapstructure:"incidentId" validate:"required"`
+	ChannelId  string `mapstructure:"channelId" validate:"required"`
+	Title      string `mapstructure:"title"`
+	Severity   string `mapstructure:"severity"`
+	Url        string `mapstructure:"url"`
+	// UserId is the user declaring, joining or resolving the incident
+	UserId    string     `mapstructure:"userId"`
+	Timestamp *time.Time `mapstructure:"timestamp"`
+}
+
+// PostWorkflowIncidents
+// @Summary receive an incident event sent by a Slack Workflow
+// @Description Record the declaration, responders and resolution of an incident, example: {"event":"declared","incidentId":"INC-42","channelId":"C0123456","title":"checkout is down","severity":"SEV1","userId":"U0123456"}<br/>
+// @Description The channel must be added as a scope, the incidents become domain incidents on the next run of the blueprint<br/>
+// @Description The request must be signed like Slack does if the connection has a signing secret, see the headers X-Slack-Signature and X-Slack-Request-Timestamp
+// @Tags plugins/slack
+// @Param connectionId path int true "connection ID"
+// @Param body body WorkflowIncidentReq true "json body"
+// @Success 200  {object} api.WebhookDeliveryOutput
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 401  {object} shared.ApiBody "Unauthorized"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/slack/connections/{connectionId}/workflow-incidents [POST]
+func PostWorkflowIncidents(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	connection := &models.SlackConnection{}
+	if err := connectionHelper.First(connection, input.Params); err != nil {
+		return nil, err
+	}
+	return workflowIncidentReceiver.Receive(input, connection.ID, connection.SigningSecret)
+}
+
+// ReplayWorkflowIncidents
+// @Summary replay the incident events sent by the Slack Workflows
+// @Description Handle the stored payloads again, all failed deliveries are replayed if no deliveryIds were given
+// @Tags plugins/slack
+// @Param connectionId path int true "connection ID"
+// @Param body body api.WebhookReplayInput false "json body"
+// @Success 200  {object} api.WebhookReplayOutput
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/slack/connections/{connectionId}/workflow-incidents/replay [POST]
+func ReplayWorkflowIncidents(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	connection := &models.SlackConnection{}
+	if err := connectionHelper.First(connection, input.Params); err != nil {
+		return nil, err
+	}
+	return workflowIncidentReceiver.PostReplay(input, connection.ID)
+}
+
+// saveWorkflowIncident applies the event to the incident, it is idempotent so the delivery can be replayed
+func saveWorkflowIncident(tx dal.Transaction, connectionId uint64, payload interface{}) errors.Error {
+	event := payload.(*WorkflowIncidentReq)
+	timestamp := time.Now()
+	if event.Timestamp != nil {
+		timestamp = *event.Timestamp
+	}
+	// the converter treats the incidents of the channel as if they were found in its messages
+	origin := common.RawDataOrigin{
+		RawDataTable:  "_raw_" + tasks.RAW_CHANNEL_MESSAGE_TABLE,
+		RawDataParams: plugin.MarshalScopeParams(&tasks.SlackParams{ConnectionId: connectionId, ScopeId: event.ChannelId}),
+	}
+	incident := &models.SlackIncident{}
+	err := tx.First(incident, dal.Where(
+		"connection_id = ? AND channel_id = ? AND id = ?", connectionId, event.ChannelId, event.IncidentId,
+	))
+	if err != nil && !tx.IsErrorNotFound(err) {
+		return err
+	}
+	if err != nil {
+		incident = &models.SlackIncident{
+			NoPKModel:    common.NoPKModel{RawDataOrigin: origin},
+			ConnectionId: connectionId,
+			ChannelId:    event.ChannelId,
+			Id:           event.IncidentId,
+			Source:       models.INCIDENT_SOURCE_WORKFLOW,
+			Title:        event.IncidentId,
+			DeclaredAt:   timestamp,
+		}
+	}
+	if event.Title != "" {
+		incident.Title = event.Title
+	}
+	if event.Severity != "" {
+		incident.Severity = event.Severity
+	}
+	if event.Url != "" {
+		incident.Url = event.Url
+	}
+	switch event.Event {
+	case WORKFLOW_INCIDENT_DECLARED:
+		incident.DeclaredAt = timestamp
+		incident.DeclaredBy = event.UserId
+	case WORKFLOW_INCIDENT_RESOLVED:
+		incident.ResolvedAt = &timestamp
+		incident.ResolvedBy = event.UserId
+	}
+	if err := tx.CreateOrUpdate(incident); err != nil {
+		return err
+	}
+	if event.UserId == "" {
+		return nil
+	}
+	responder := &models.SlackIncidentResponder{}
+	err = tx.First(responder, dal.Where(
+		"connection_id = ? AND channel_id = ? AND incident_id = ? AND user_id = ?",
+		connectionId, event.ChannelId, event.IncidentId, event.UserId,
+	))
+	if err != nil && !tx.IsErrorNotFound(err) {
+		return err
+	}
+	if err != nil {
+		responder = &models.SlackIncidentResponder{
+			NoPKModel:        common.NoPKModel{RawDataOrigin: origin},
+			ConnectionId:     connectionId,
+			ChannelId:        event.ChannelId,
+			IncidentId:       event.IncidentId,
+			UserId:           event.UserId,
+			FirstRespondedAt: timestamp,
+			LastRespondedAt:  timestamp,
+		}
+	}
+	if timestamp.Before(responder.FirstRespondedAt) {
+		responder.FirstRespondedAt = timestamp
+	}
+	if timestamp.After(responder.LastRespondedAt) {
+		responder.LastRespondedAt = timestamp
+	}
+	return tx.CreateOrUpdate(responder)
+}
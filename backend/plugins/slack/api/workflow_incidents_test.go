@@ -0,0 +1,49 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkflowIncidentSignature(t *testing.T) {
+	body := []byte(`{"event":"declared","incidentId":"INC-42","channelId":"C0123456"}`)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	// signed the way Slack documents it
+	mac := hmac.New(sha256.New, []byte("signing-secret"))
+	mac.Write([]byte("v0:" + timestamp + ":" + string(body)))
+	header := http.Header{}
+	header.Set("X-Slack-Request-Timestamp", timestamp)
+	header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+
+	assert.Nil(t, workflowIncidentSignature.Verify(header, body, "signing-secret"))
+	assert.Equal(t, errors.Unauthorized, workflowIncidentSignature.Verify(header, body, "another-secret").GetType())
+	assert.Equal(t, errors.Unauthorized, workflowIncidentSignature.Verify(header, []byte(`{}`), "signing-secret").GetType())
+
+	header.Del("X-Slack-Request-Timestamp")
+	assert.Equal(t, errors.Unauthorized, workflowIncidentSignature.Verify(header, body, "signing-secret").GetType())
+}
@@ -19,6 +19,7 @@ package impl
 
 import (
 	"fmt"
+	"regexp"
 
 	"github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/dal"
@@ -58,6 +59,9 @@ func (p Slack) GetTablesInfo() []dal.Tabler {
 		&models.SlackConnection{},
 		&models.SlackChannelMessage{},
 		&models.SlackChannel{},
+		&models.SlackScopeConfig{},
+		&models.SlackIncident{},
+		&models.SlackIncidentResponder{},
 	}
 }
 
@@ -78,7 +82,7 @@ func (p Slack) Scope() plugin.ToolLayerScope {
 }
 
 func (p Slack) ScopeConfig() dal.Tabler {
-	return nil
+	return &models.SlackScopeConfig{}
 }
 
 func (p Slack) SubTaskMetas() []plugin.SubTaskMeta {
@@ -91,6 +95,10 @@ func (p Slack) SubTaskMetas() []plugin.SubTaskMeta {
 
 		tasks.CollectThreadMeta,
 		tasks.ExtractThreadMeta,
+
+		tasks.EnrichIncidentsMeta,
+		tasks.ConvertChannelMeta,
+		tasks.ConvertIncidentsMeta,
 	}
 }
 
@@ -115,10 +123,53 @@ func (p Slack) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]in
 	if err != nil {
 		return nil, err
 	}
-	return &tasks.SlackTaskData{
+	taskData := &tasks.SlackTaskData{
 		Options:   &op,
 		ApiClient: apiClient,
-	}, nil
+	}
+	if op.ChannelId == "" {
+		return taskData, nil
+	}
+	// fallback to channel scope config
+	db := taskCtx.GetDal()
+	if op.ScopeConfig == nil && op.ScopeConfigId == 0 {
+		channel := &models.SlackChannel{}
+		err = db.First(channel, dal.Where("connection_id = ? AND id = ?", op.ConnectionId, op.ChannelId))
+		if err != nil && !db.IsErrorNotFound(err) {
+			return nil, err
+		}
+		op.ScopeConfigId = channel.ScopeConfigId
+	}
+	if op.ScopeConfig == nil && op.ScopeConfigId != 0 {
+		var scopeConfig models.SlackScopeConfig
+		err = db.First(&scopeConfig, dal.Where("id = ?", op.ScopeConfigId))
+		if err != nil && !db.IsErrorNotFound(err) {
+			return nil, errors.BadInput.Wrap(err, "fail to load scopeConfig")
+		}
+		op.ScopeConfig = &scopeConfig
+	}
+	if op.ScopeConfig == nil {
+		op.ScopeConfig = new(models.SlackScopeConfig)
+	}
+	if taskData.IncidentDeclarationRegex, err = compilePattern(op.ScopeConfig.IncidentDeclarationPattern); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid value for `incidentDeclarationPattern`")
+	}
+	if taskData.IncidentResolutionRegex, err = compilePattern(op.ScopeConfig.IncidentResolutionPattern); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid value for `incidentResolutionPattern`")
+	}
+	if taskData.IncidentSeverityRegex, err = compilePattern(op.ScopeConfig.IncidentSeverityPattern); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid value for `incidentSeverityPattern`")
+	}
+	return taskData, nil
+}
+
+// compilePattern returns nil if the pattern is empty
+func compilePattern(pattern string) (*regexp.Regexp, errors.Error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	re, err := regexp.Compile(pattern)
+	return re, errors.Convert(err)
 }
 
 func (p Slack) RootPkgPath() string {
@@ -160,8 +211,16 @@ func (p Slack) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
 			"GET": api.SearchRemoteScopes,
 		},
 		"connections/:connectionId/scopes": {
-			"GET": api.GetScopeList,
-			"PUT": api.PutScopes,
+			"GET":    api.GetScopeList,
+			"PUT":    api.PutScopes,
+			"PATCH":  api.PatchScopes,
+			"DELETE": api.DeleteScopes,
+		},
+		"connections/:connectionId/scope-jobs": {
+			"GET": api.GetScopeJobs,
+		},
+		"connections/:connectionId/scope-jobs/:jobId": {
+			"GET": api.GetScopeJob,
 		},
 		"connections/:connectionId/scopes/:scopeId": {
 			"GET":    api.GetScope,
@@ -171,6 +230,27 @@ func (p Slack) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
 		"connections/:connectionId/scopes/:scopeId/latest-sync-state": {
 			"GET": api.GetScopeLatestSyncState,
 		},
+		"connections/:connectionId/scope-configs": {
+			"POST": api.PostScopeConfig,
+			"GET":  api.GetScopeConfigList,
+		},
+		"connections/:connectionId/scope-configs/:scopeConfigId": {
+			"PATCH":  api.PatchScopeConfig,
+			"GET":    api.GetScopeConfig,
+			"DELETE": api.DeleteScopeConfig,
+		},
+		"scope-config/:scopeConfigId/projects": {
+			"GET": api.GetProjectsByScopeConfig,
+		},
+		"scope-config-schema": {
+			"GET": api.GetScopeConfigSchema,
+		},
+		"connections/:connectionId/workflow-incidents": {
+			"POST": api.PostWorkflowIncidents,
+		},
+		"connections/:connectionId/workflow-incidents/replay": {
+			"POST": api.ReplayWorkflowIncidents,
+		},
 	}
 }
 
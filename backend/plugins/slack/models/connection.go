@@ -26,10 +26,14 @@ import (
 type SlackConn struct {
 	helper.RestConnection `mapstructure:",squash"`
 	helper.AccessToken    `mapstructure:",squash"`
+	// SigningSecret of the Slack app verifies the incidents pushed by the Workflows, the `X-Slack-Signature` and
+	// `X-Slack-Request-Timestamp` headers are required if it is set
+	SigningSecret string `mapstructure:"signingSecret" json:"signingSecret" gorm:"serializer:encdec"`
 }
 
 func (connection SlackConn) Sanitize() SlackConn {
 	connection.Token = utils.SanitizeString(connection.Token)
+	connection.SigningSecret = utils.SanitizeString(connection.SigningSecret)
 	return connection
 }
 
@@ -50,6 +54,7 @@ func (connection SlackConnection) Sanitize() SlackConnection {
 
 func (connection *SlackConnection) MergeFromRequest(target *SlackConnection, body map[string]interface{}) error {
 	token := target.Token
+	signingSecret := target.SigningSecret
 	if err := helper.DecodeMapStruct(body, target, true); err != nil {
 		return err
 	}
@@ -57,5 +62,9 @@ func (connection *SlackConnection) MergeFromRequest(target *SlackConnection, bod
 	if modifiedToken == "" || modifiedToken == utils.SanitizeString(token) {
 		target.Token = token
 	}
+	// the signing secret may be removed by sending an empty one, unlike the token
+	if target.SigningSecret != "" && target.SigningSecret == utils.SanitizeString(signingSecret) {
+		target.SigningSecret = signingSecret
+	}
 	return nil
 }
@@ -0,0 +1,67 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+const (
+	// the incident is a thread of an incident channel
+	INCIDENT_SOURCE_MESSAGE = "message"
+	// the incident is pushed by a Slack Workflow
+	INCIDENT_SOURCE_WORKFLOW = "workflow"
+)
+
+type SlackIncident struct {
+	common.NoPKModel
+	ConnectionId uint64 `gorm:"primaryKey"`
+	ChannelId    string `gorm:"primaryKey;type:varchar(255)"`
+	// Id is the ts of the declaration message, or the incident id sent by the workflow
+	Id         string `gorm:"primaryKey;type:varchar(255)"`
+	Source     string `gorm:"type:varchar(20)"`
+	Title      string
+	Severity   string `gorm:"type:varchar(255)"`
+	Url        string
+	DeclaredBy string `gorm:"type:varchar(255)"`
+	DeclaredAt time.Time
+	ResolvedBy string `gorm:"type:varchar(255)"`
+	ResolvedAt *time.Time
+}
+
+func (SlackIncident) TableName() string {
+	return "_tool_slack_incidents"
+}
+
+// SlackIncidentResponder is a user taking part in the incident
+type SlackIncidentResponder struct {
+	common.NoPKModel
+	ConnectionId     uint64 `gorm:"primaryKey"`
+	ChannelId        string `gorm:"primaryKey;type:varchar(255)"`
+	IncidentId       string `gorm:"primaryKey;type:varchar(255)"`
+	UserId           string `gorm:"primaryKey;type:varchar(255)"`
+	MessageCount     int
+	FirstRespondedAt time.Time
+	LastRespondedAt  time.Time
+}
+
+func (SlackIncidentResponder) TableName() string {
+	return "_tool_slack_incident_responders"
+}
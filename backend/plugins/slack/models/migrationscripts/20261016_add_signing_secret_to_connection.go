@@ -0,0 +1,50 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+type addSigningSecretToConnection struct{}
+
+type slackConnection20261016 struct {
+	SigningSecret string
+}
+
+func (slackConnection20261016) TableName() string {
+	return "_tool_slack_connections"
+}
+
+func (*addSigningSecretToConnection) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, &slackConnection20261016{})
+}
+
+func (*addSigningSecretToConnection) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropColumns(slackConnection20261016{}.TableName(), "signing_secret")
+}
+
+func (*addSigningSecretToConnection) Version() uint64 {
+	return 20261016110000
+}
+
+func (*addSigningSecretToConnection) Name() string {
+	return "add signing_secret to _tool_slack_connections"
+}
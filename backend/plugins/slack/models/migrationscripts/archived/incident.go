@@ -0,0 +1,58 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+)
+
+type SlackIncident struct {
+	archived.NoPKModel
+	ConnectionId uint64 `gorm:"primaryKey"`
+	ChannelId    string `gorm:"primaryKey;type:varchar(255)"`
+	Id           string `gorm:"primaryKey;type:varchar(255)"`
+	Source       string `gorm:"type:varchar(20)"`
+	Title        string
+	Severity     string `gorm:"type:varchar(255)"`
+	Url          string
+	DeclaredBy   string `gorm:"type:varchar(255)"`
+	DeclaredAt   time.Time
+	ResolvedBy   string `gorm:"type:varchar(255)"`
+	ResolvedAt   *time.Time
+}
+
+func (SlackIncident) TableName() string {
+	return "_tool_slack_incidents"
+}
+
+type SlackIncidentResponder struct {
+	archived.NoPKModel
+	ConnectionId     uint64 `gorm:"primaryKey"`
+	ChannelId        string `gorm:"primaryKey;type:varchar(255)"`
+	IncidentId       string `gorm:"primaryKey;type:varchar(255)"`
+	UserId           string `gorm:"primaryKey;type:varchar(255)"`
+	MessageCount     int
+	FirstRespondedAt time.Time
+	LastRespondedAt  time.Time
+}
+
+func (SlackIncidentResponder) TableName() string {
+	return "_tool_slack_incident_responders"
+}
@@ -26,5 +26,8 @@ func All() []plugin.MigrationScript {
 	return []plugin.MigrationScript{
 		new(addInitTables),
 		new(addScopeConfigIdToSlackChannel),
+		new(addIncidents),
+		new(encryptConnectionProxy),
+		new(addSigningSecretToConnection),
 	}
 }
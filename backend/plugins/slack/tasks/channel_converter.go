@@ -0,0 +1,82 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/slack/models"
+)
+
+var _ plugin.SubTaskEntryPoint = ConvertChannel
+
+var ConvertChannelMeta = plugin.SubTaskMeta{
+	Name:             "convertChannel",
+	EntryPoint:       ConvertChannel,
+	EnabledByDefault: true,
+	Description:      "Convert the incident channel into domain layer table boards",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func ConvertChannel(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*SlackTaskData)
+	db := taskCtx.GetDal()
+	cursor, err := db.Cursor(
+		dal.From(&models.SlackChannel{}),
+		dal.Where("connection_id = ? AND id = ?", data.Options.ConnectionId, data.Options.ChannelId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	boardIdGen := didgen.NewDomainIdGenerator(&models.SlackChannel{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx:     taskCtx,
+			Options: data.Options,
+			Table:   RAW_CHANNEL_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.SlackChannel{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			channel := inputRow.(*models.SlackChannel)
+			createdDate := time.Unix(int64(channel.Created), 0)
+			board := &ticket.Board{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: boardIdGen.Generate(channel.ConnectionId, channel.Id),
+				},
+				Name:        channel.Name,
+				CreatedDate: &createdDate,
+				Type:        "slack-channel",
+			}
+			return []interface{}{board}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
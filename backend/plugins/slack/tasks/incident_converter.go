@@ -0,0 +1,147 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/slack/models"
+)
+
+var _ plugin.SubTaskEntryPoint = ConvertIncidents
+
+var ConvertIncidentsMeta = plugin.SubTaskMeta{
+	Name:             "convertIncidents",
+	EntryPoint:       ConvertIncidents,
+	EnabledByDefault: true,
+	Description:      "Convert the incidents and their responders into domain layer tables issues and incidents",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func ConvertIncidents(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*SlackTaskData)
+	db := taskCtx.GetDal()
+	connectionId, channelId := data.Options.ConnectionId, data.Options.ChannelId
+	// the responders of the whole channel are loaded at once rather than queried incident by incident
+	var allResponders []*models.SlackIncidentResponder
+	err := db.All(&allResponders,
+		dal.Where("connection_id = ? AND channel_id = ?", connectionId, channelId),
+		dal.Orderby("incident_id, first_responded_at"),
+	)
+	if err != nil {
+		return err
+	}
+	respondersByIncident := make(map[string][]*models.SlackIncidentResponder)
+	for _, responder := range allResponders {
+		respondersByIncident[responder.IncidentId] = append(respondersByIncident[responder.IncidentId], responder)
+	}
+	cursor, err := db.Cursor(
+		dal.From(&models.SlackIncident{}),
+		dal.Where("connection_id = ? AND channel_id = ?", connectionId, channelId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	boardId := didgen.NewDomainIdGenerator(&models.SlackChannel{}).Generate(connectionId, channelId)
+	issueIdGen := didgen.NewDomainIdGenerator(&models.SlackIncident{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx:     taskCtx,
+			Options: data.Options,
+			Table:   RAW_CHANNEL_MESSAGE_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.SlackIncident{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			incident := inputRow.(*models.SlackIncident)
+			responders := respondersByIncident[incident.Id]
+			issue := toIncidentIssue(incident, responders, issueIdGen.Generate(connectionId, channelId, incident.Id))
+			domainIncident, e := issue.ToIncident(boardId)
+			if e != nil {
+				return nil, errors.Convert(e)
+			}
+			results := []interface{}{
+				issue,
+				domainIncident,
+				&ticket.BoardIssue{BoardId: boardId, IssueId: issue.Id},
+			}
+			for _, responder := range responders {
+				assigneeId := accountId(connectionId, responder.UserId)
+				results = append(results,
+					&ticket.IssueAssignee{IssueId: issue.Id, AssigneeId: assigneeId, AssigneeName: responder.UserId},
+					&ticket.IncidentAssignee{IncidentId: issue.Id, AssigneeId: assigneeId, AssigneeName: responder.UserId},
+				)
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
+
+// toIncidentIssue converts the incident into an issue of type INCIDENT, the first responder is the assignee
+func toIncidentIssue(incident *models.SlackIncident, responders []*models.SlackIncidentResponder, id string) *ticket.Issue {
+	declaredAt := incident.DeclaredAt
+	issue := &ticket.Issue{
+		DomainEntity: domainlayer.DomainEntity{
+			Id: id,
+		},
+		Url:            incident.Url,
+		IssueKey:       incident.Id,
+		Title:          incident.Title,
+		Type:           ticket.INCIDENT,
+		Status:         ticket.TODO,
+		OriginalStatus: "declared",
+		Severity:       incident.Severity,
+		CreatedDate:    &declaredAt,
+		UpdatedDate:    &declaredAt,
+	}
+	if incident.DeclaredBy != "" {
+		issue.CreatorId = accountId(incident.ConnectionId, incident.DeclaredBy)
+		issue.CreatorName = incident.DeclaredBy
+	}
+	if len(responders) > 0 {
+		issue.AssigneeId = accountId(incident.ConnectionId, responders[0].UserId)
+		issue.AssigneeName = responders[0].UserId
+	}
+	if incident.ResolvedAt != nil {
+		issue.Status = ticket.DONE
+		issue.OriginalStatus = "resolved"
+		issue.ResolutionDate = incident.ResolvedAt
+		issue.UpdatedDate = incident.ResolvedAt
+		leadTimeMinutes := uint(incident.ResolvedAt.Sub(declaredAt).Minutes())
+		issue.LeadTimeMinutes = &leadTimeMinutes
+	}
+	return issue
+}
+
+// accountId is the domain id of the slack user, slack users aren't collected so far
+func accountId(connectionId uint64, userId string) string {
+	return fmt.Sprintf("slack:SlackUser:%d:%s", connectionId, userId)
+}
@@ -0,0 +1,217 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/slack/models"
+)
+
+var _ plugin.SubTaskEntryPoint = EnrichIncidents
+
+var EnrichIncidentsMeta = plugin.SubTaskMeta{
+	Name:             "enrichIncidents",
+	EntryPoint:       EnrichIncidents,
+	EnabledByDefault: true,
+	Description:      "Find the incidents declared in the threads of the incident channel and their responders",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+// EnrichIncidents finds the incidents in the messages of the channel from scratch, the incidents pushed by the
+// workflows are left as they are
+func EnrichIncidents(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*SlackTaskData)
+	if data.IncidentDeclarationRegex == nil {
+		return nil
+	}
+	db := taskCtx.GetDal()
+	connectionId, channelId := data.Options.ConnectionId, data.Options.ChannelId
+	cursor, err := db.Cursor(
+		dal.From(&models.SlackChannelMessage{}),
+		dal.Where("connection_id = ? AND channel_id = ?", connectionId, channelId),
+		dal.Orderby("ts"),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	finder := newIncidentFinder(data, common.RawDataOrigin{
+		RawDataTable:  "_raw_" + RAW_CHANNEL_MESSAGE_TABLE,
+		RawDataParams: plugin.MarshalScopeParams(data.Options.GetParams()),
+	})
+	for cursor.Next() {
+		message := &models.SlackChannelMessage{}
+		if err := db.Fetch(cursor, message); err != nil {
+			return err
+		}
+		finder.add(message)
+	}
+	return saveFoundIncidents(db, connectionId, channelId, finder)
+}
+
+// saveFoundIncidents replaces the incidents found in the messages previously in a transaction, so a failure leaves
+// the ones of the last run in place instead of none
+func saveFoundIncidents(db dal.Dal, connectionId uint64, channelId string, finder *incidentFinder) (err errors.Error) {
+	tx := db.Begin()
+	defer func() {
+		if r := recover(); r != nil || err != nil {
+			_ = tx.Rollback()
+			if r != nil {
+				panic(r)
+			}
+		}
+	}()
+	err = tx.Delete(
+		&models.SlackIncidentResponder{},
+		dal.Where(
+			`connection_id = ? AND channel_id = ? AND incident_id IN (
+				SELECT id FROM _tool_slack_incidents WHERE connection_id = ? AND channel_id = ? AND source = ?
+			)`,
+			connectionId, channelId, connectionId, channelId, models.INCIDENT_SOURCE_MESSAGE,
+		),
+	)
+	if err != nil {
+		return err
+	}
+	err = tx.Delete(
+		&models.SlackIncident{},
+		dal.Where("connection_id = ? AND channel_id = ? AND source = ?", connectionId, channelId, models.INCIDENT_SOURCE_MESSAGE),
+	)
+	if err != nil {
+		return err
+	}
+	for _, incident := range finder.incidents {
+		if err = tx.Create(incident); err != nil {
+			return err
+		}
+	}
+	for _, responder := range finder.responders {
+		if err = tx.Create(responder); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// incidentFinder goes through the messages of a channel ordered by ts, so a thread is always seen before its replies
+type incidentFinder struct {
+	data       *SlackTaskData
+	origin     common.RawDataOrigin
+	incidents  []*models.SlackIncident
+	responders []*models.SlackIncidentResponder
+	// ts of the declaration => incident
+	byThread map[string]*models.SlackIncident
+	// ts of the declaration + user => responder
+	byResponder map[string]*models.SlackIncidentResponder
+}
+
+func newIncidentFinder(data *SlackTaskData, origin common.RawDataOrigin) *incidentFinder {
+	return &incidentFinder{
+		data:        data,
+		origin:      origin,
+		byThread:    make(map[string]*models.SlackIncident),
+		byResponder: make(map[string]*models.SlackIncidentResponder),
+	}
+}
+
+func (f *incidentFinder) add(message *models.SlackChannelMessage) {
+	if message.ThreadTs == "" || message.ThreadTs == message.Ts {
+		if f.data.IncidentDeclarationRegex.MatchString(message.Text) {
+			f.declare(message)
+		}
+		return
+	}
+	incident, ok := f.byThread[message.ThreadTs]
+	if !ok {
+		return
+	}
+	postedAt := parseTs(message.Ts)
+	if incident.ResolvedAt == nil && f.data.IncidentResolutionRegex != nil && f.data.IncidentResolutionRegex.MatchString(message.Text) {
+		incident.ResolvedAt = &postedAt
+		incident.ResolvedBy = message.User
+	}
+	// the messages of the bots don't count as participation
+	if message.User == "" || message.Subtype == "bot_message" {
+		return
+	}
+	key := message.ThreadTs + ":" + message.User
+	responder, ok := f.byResponder[key]
+	if !ok {
+		responder = &models.SlackIncidentResponder{
+			NoPKModel:        common.NoPKModel{RawDataOrigin: f.origin},
+			ConnectionId:     message.ConnectionId,
+			ChannelId:        message.ChannelId,
+			IncidentId:       message.ThreadTs,
+			UserId:           message.User,
+			FirstRespondedAt: postedAt,
+		}
+		f.byResponder[key] = responder
+		f.responders = append(f.responders, responder)
+	}
+	responder.MessageCount++
+	responder.LastRespondedAt = postedAt
+}
+
+func (f *incidentFinder) declare(message *models.SlackChannelMessage) {
+	incident := &models.SlackIncident{
+		NoPKModel:    common.NoPKModel{RawDataOrigin: f.origin},
+		ConnectionId: message.ConnectionId,
+		ChannelId:    message.ChannelId,
+		Id:           message.Ts,
+		Source:       models.INCIDENT_SOURCE_MESSAGE,
+		Title:        incidentTitle(message.Text),
+		DeclaredBy:   message.User,
+		DeclaredAt:   parseTs(message.Ts),
+	}
+	if f.data.IncidentSeverityRegex != nil {
+		if groups := f.data.IncidentSeverityRegex.FindStringSubmatch(message.Text); len(groups) > 1 {
+			incident.Severity = groups[1]
+		} else if len(groups) == 1 {
+			incident.Severity = groups[0]
+		}
+	}
+	f.byThread[message.Ts] = incident
+	f.incidents = append(f.incidents, incident)
+}
+
+// incidentTitle is the first line of the declaration
+func incidentTitle(text string) string {
+	title := strings.TrimSpace(strings.SplitN(strings.TrimSpace(text), "\n", 2)[0])
+	if runes := []rune(title); len(runes) > 255 {
+		title = string(runes[:255])
+	}
+	return title
+}
+
+// parseTs converts the ts of a message, i.e. 1700000000.123456, into time
+func parseTs(ts string) time.Time {
+	parts := strings.SplitN(ts, ".", 2)
+	seconds, _ := strconv.ParseInt(parts[0], 10, 64)
+	var micros int64
+	if len(parts) == 2 {
+		micros, _ = strconv.ParseInt(parts[1], 10, 64)
+	}
+	return time.Unix(seconds, micros*int64(time.Microsecond)).UTC()
+}
@@ -0,0 +1,104 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/apache/incubator-devlake/plugins/slack/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestIncidentFinder(t *testing.T) {
+	finder := newIncidentFinder(&SlackTaskData{
+		IncidentDeclarationRegex: regexp.MustCompile(`(?i)incident declared`),
+		IncidentResolutionRegex:  regexp.MustCompile(`(?i)\bresolved\b`),
+		IncidentSeverityRegex:    regexp.MustCompile(`(?i)\b(sev[0-9])\b`),
+	}, common.RawDataOrigin{})
+	messages := []*models.SlackChannelMessage{
+		{Ts: "1700000000.000100", User: "U1", Text: "Incident declared: checkout is down SEV1\nsee the dashboard"},
+		{Ts: "1700000060.000000", ThreadTs: "1700000000.000100", User: "U2", Text: "looking"},
+		{Ts: "1700000120.000000", ThreadTs: "1700000000.000100", Subtype: "bot_message", Text: "paged the on-call"},
+		{Ts: "1700000180.000000", User: "U3", Text: "unrelated chat"},
+		{Ts: "1700000240.000000", ThreadTs: "1700000180.000000", User: "U2", Text: "resolved"},
+		{Ts: "1700000600.000000", ThreadTs: "1700000000.000100", User: "U2", Text: "rolled back, resolved"},
+		{Ts: "1700000660.000000", ThreadTs: "1700000000.000100", User: "U1", Text: "resolved, thanks"},
+	}
+	for _, message := range messages {
+		finder.add(message)
+	}
+
+	assert.Len(t, finder.incidents, 1)
+	incident := finder.incidents[0]
+	assert.Equal(t, "1700000000.000100", incident.Id)
+	assert.Equal(t, models.INCIDENT_SOURCE_MESSAGE, incident.Source)
+	assert.Equal(t, "Incident declared: checkout is down SEV1", incident.Title)
+	assert.Equal(t, "SEV1", incident.Severity)
+	assert.Equal(t, "U1", incident.DeclaredBy)
+	assert.Equal(t, time.Unix(1700000000, 100000).UTC(), incident.DeclaredAt)
+	assert.Equal(t, time.Unix(1700000600, 0).UTC(), *incident.ResolvedAt)
+	assert.Equal(t, "U2", incident.ResolvedBy)
+
+	assert.Len(t, finder.responders, 2)
+	assert.Equal(t, "U2", finder.responders[0].UserId)
+	assert.Equal(t, 2, finder.responders[0].MessageCount)
+	assert.Equal(t, time.Unix(1700000060, 0).UTC(), finder.responders[0].FirstRespondedAt)
+	assert.Equal(t, time.Unix(1700000600, 0).UTC(), finder.responders[0].LastRespondedAt)
+	assert.Equal(t, "U1", finder.responders[1].UserId)
+}
+
+func TestToIncidentIssue(t *testing.T) {
+	declaredAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	resolvedAt := declaredAt.Add(90 * time.Minute)
+	issue := toIncidentIssue(&models.SlackIncident{
+		ConnectionId: 1,
+		Id:           "INC-42",
+		Title:        "checkout is down",
+		DeclaredBy:   "U1",
+		DeclaredAt:   declaredAt,
+		ResolvedAt:   &resolvedAt,
+	}, []*models.SlackIncidentResponder{{UserId: "U2"}}, "slack:SlackIncident:1:C1:INC-42")
+	assert.Equal(t, "INCIDENT", issue.Type)
+	assert.Equal(t, "DONE", issue.Status)
+	assert.Equal(t, uint(90), *issue.LeadTimeMinutes)
+	assert.Equal(t, "slack:SlackUser:1:U1", issue.CreatorId)
+	assert.Equal(t, "slack:SlackUser:1:U2", issue.AssigneeId)
+}
+
+func TestSaveFoundIncidentsRollsBack(t *testing.T) {
+	finder := newIncidentFinder(&SlackTaskData{}, common.RawDataOrigin{})
+	finder.incidents = []*models.SlackIncident{{Id: "1700000000.000100"}}
+	finder.responders = []*models.SlackIncidentResponder{{IncidentId: "1700000000.000100", UserId: "U2"}}
+	mockTx := new(mockdal.Transaction)
+	mockTx.On("Delete", mock.Anything, mock.Anything).Return(nil).Twice()
+	mockTx.On("Create", finder.incidents[0], mock.Anything).Return(nil).Once()
+	mockTx.On("Create", finder.responders[0], mock.Anything).Return(errors.Default.New("duplicate key")).Once()
+	mockTx.On("Rollback").Return(nil).Once()
+	mockDal := new(mockdal.Dal)
+	mockDal.On("Begin").Return(mockTx)
+
+	assert.NotNil(t, saveFoundIncidents(mockDal, 1, "C1", finder))
+	mockTx.AssertExpectations(t)
+	mockTx.AssertNotCalled(t, "Commit")
+}
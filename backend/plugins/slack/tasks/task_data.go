@@ -18,7 +18,10 @@ limitations under the License.
 package tasks
 
 import (
+	"regexp"
+
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/slack/models"
 )
 
 type SlackApiParams struct {
@@ -26,13 +29,19 @@ type SlackApiParams struct {
 }
 
 type SlackOptions struct {
-	ConnectionId uint64 `json:"connectionId"`
-	ChannelId    string `json:"channelId,omitempty" mapstructure:"channelId,omitempty"`
+	ConnectionId  uint64                   `json:"connectionId"`
+	ChannelId     string                   `json:"channelId,omitempty" mapstructure:"channelId,omitempty"`
+	ScopeConfigId uint64                   `json:"scopeConfigId,omitempty" mapstructure:"scopeConfigId,omitempty"`
+	ScopeConfig   *models.SlackScopeConfig `json:"scopeConfig,omitempty" mapstructure:"scopeConfig,omitempty"`
 }
 
 type SlackTaskData struct {
 	Options   *SlackOptions
 	ApiClient *helper.ApiAsyncClient
+	// nil if the channel isn't an incident channel
+	IncidentDeclarationRegex *regexp.Regexp
+	IncidentResolutionRegex  *regexp.Regexp
+	IncidentSeverityRegex    *regexp.Regexp
 }
 
 // SlackParams defines the raw params shape used to tag raw tables for scoping and latest-sync-state
@@ -39,5 +39,6 @@ func All() []plugin.MigrationScript {
 		new(addOrgToConn),
 		new(addIssueImpacts),
 		new(extendSonarqubeFieldSize),
+		new(encryptConnectionProxy),
 	}
 }
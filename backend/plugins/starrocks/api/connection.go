@@ -37,20 +37,27 @@ func PostStarRocksPipeline(input *plugin.ApiResourceInput) (*plugin.ApiResourceO
 type StarRocksPipelinePlan [][]struct {
 	Plugin  string `json:"plugin"`
 	Options struct {
-		SourceType   string            `json:"source_type"`
-		SourceDsn    string            `json:"source_dsn"`
-		UpdateColumn string            `json:"update_column"`
-		Host         string            `json:"host"`
-		Port         int               `json:"port"`
-		User         string            `json:"user"`
-		Password     string            `json:"password"` // notice:
-		Database     string            `json:"database"`
-		BeHost       string            `json:"be_host"`
-		BePort       int               `json:"be_port"`
-		Tables       []string          `json:"tables"`
-		BatchSize    int               `json:"batch_size"`
-		OrderBy      map[string]string `json:"order_by"`
-		Extra        string            `json:"extra"`
-		DomainLayer  string            `json:"domain_layer"`
+		SourceType     string   `json:"source_type"`
+		SourceDsn      string   `json:"source_dsn"`
+		UpdateColumn   string   `json:"update_column"`
+		Incremental    bool     `json:"incremental"`
+		Host           string   `json:"host"`
+		Port           int      `json:"port"`
+		User           string   `json:"user"`
+		Password       string   `json:"password"` // notice:
+		Database       string   `json:"database"`
+		BeHost         string   `json:"be_host"`
+		BePort         int      `json:"be_port"`
+		Tables         []string `json:"tables"`
+		ExcludedTables []string `json:"excluded_tables"`
+		TableConfigs   map[string]struct {
+			IncludedColumns []string `json:"included_columns"`
+			ExcludedColumns []string `json:"excluded_columns"`
+			Where           string   `json:"where"`
+		} `json:"table_configs"`
+		BatchSize   int               `json:"batch_size"`
+		OrderBy     map[string]string `json:"order_by"`
+		Extra       string            `json:"extra"`
+		DomainLayer string            `json:"domain_layer"`
 	} `json:"options"`
 }
@@ -49,6 +49,9 @@ func (s StarRocks) PrepareTaskData(taskCtx plugin.TaskContext, options map[strin
 	if op.BeHost == "" {
 		op.BeHost = op.Host
 	}
+	if op.Incremental && op.UpdateColumn == "" {
+		op.UpdateColumn = "updated_at"
+	}
 	return &op, nil
 }
 
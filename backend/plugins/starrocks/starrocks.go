@@ -30,6 +30,7 @@ func main() {
 	sourceType := cmd.Flags().StringP("source_type", "st", "", "Source type")
 	sourceDsn := cmd.Flags().StringP("source_dsn", "sd", "", "Source dsn")
 	updateColumn := cmd.Flags().StringP("update_column", "uc", "", "Update column")
+	incremental := cmd.Flags().BoolP("incremental", "i", false, "Only load the rows updated since the last sync")
 	_ = cmd.MarkFlagRequired("host")
 	host := cmd.Flags().StringP("host", "h", "", "StarRocks host")
 	_ = cmd.MarkFlagRequired("port")
@@ -45,6 +46,7 @@ func main() {
 	database := cmd.Flags().StringP("database", "d", "", "StarRocks database")
 	_ = cmd.MarkFlagRequired("table")
 	tables := cmd.Flags().StringArrayP("table", "t", []string{}, "StarRocks table")
+	excludedTables := cmd.Flags().StringArrayP("excluded_table", "x", []string{}, "Tables not to export, support regexp")
 	_ = cmd.MarkFlagRequired("batch_size")
 	batchSize := cmd.Flags().StringP("batch_size", "b", "", "StarRocks insert batch size")
 	_ = cmd.MarkFlagRequired("batch_size")
@@ -53,20 +55,22 @@ func main() {
 	timeAfter := cmd.Flags().StringP("time_after", "a", "", "collect data that are created after specified time, ie 2006-01-02T15:04:05Z")
 	cmd.Run = func(cmd *cobra.Command, args []string) {
 		runner.DirectRun(cmd, args, PluginEntry, map[string]interface{}{
-			"source_type":   sourceType,
-			"source_dsn":    sourceDsn,
-			"update_column": updateColumn,
-			"host":          host,
-			"port":          port,
-			"user":          user,
-			"password":      password,
-			"database":      database,
-			"be_host":       beHost,
-			"be_port":       bePort,
-			"tables":        tables,
-			"batch_size":    batchSize,
-			"extra":         extra,
-			"order_by":      orderBy,
+			"source_type":     sourceType,
+			"source_dsn":      sourceDsn,
+			"update_column":   updateColumn,
+			"incremental":     incremental,
+			"host":            host,
+			"port":            port,
+			"user":            user,
+			"password":        password,
+			"database":        database,
+			"be_host":         beHost,
+			"be_port":         bePort,
+			"tables":          tables,
+			"excluded_tables": excludedTables,
+			"batch_size":      batchSize,
+			"extra":           extra,
+			"order_by":        orderBy,
 		}, *timeAfter)
 	}
 	runner.RunCmd(cmd)
@@ -0,0 +1,198 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/plugins/starrocks/utils"
+)
+
+type starrocksColumn struct {
+	comment string
+	isKey   bool
+}
+
+// isColumnExported applies the included and excluded columns of the table config
+func isColumnExported(config *StarRocksConfig, table string, name string) bool {
+	tableConfig, ok := config.TableConfigs[table]
+	if !ok {
+		return true
+	}
+	if len(tableConfig.ExcludedColumns) > 0 && slices.Contains(tableConfig.ExcludedColumns, name) {
+		return false
+	}
+	if len(tableConfig.IncludedColumns) > 0 && !slices.Contains(tableConfig.IncludedColumns, name) {
+		return false
+	}
+	return true
+}
+
+// columnSignature is the source column type as stored in the comment of the starrocks column
+func columnSignature(columnType string) string {
+	return strings.ReplaceAll(strings.ToLower(columnType), "'", "")
+}
+
+// syncIncrementally loads the rows updated since the latest update of the starrocks table into it and adds or drops
+// the columns which were added or removed in the source table. It returns false when the table has to be reloaded
+// fully, that is when it doesn't exist yet, isn't a primary key table, a column changed its type or the number of rows
+// differs after the sync (rows were deleted from the source table)
+func syncIncrementally(dc *DataConfigParams) (bool, error) {
+	logger := dc.Ctx.GetLogger()
+	config := dc.Config
+	db := dc.SrcDb
+	starrocksDb := dc.DestDb
+	table := dc.SrcTableName
+	starrocksTable := dc.DestTableName
+	updateColumn := config.UpdateColumn
+	if !db.HasColumn(table, updateColumn) || !isColumnExported(config, table, updateColumn) {
+		return false, nil
+	}
+	model, err := queryStarrocksTableModel(dc)
+	if err != nil || model != "PRIMARY_KEYS" {
+		return false, err
+	}
+	starrocksColumns, err := queryStarrocksColumns(dc)
+	if err != nil {
+		return false, err
+	}
+
+	// evolve the schema
+	columnMetas, err := db.GetColumns(&Table{name: table}, nil)
+	if err != nil {
+		return false, err
+	}
+	columnMap := make(map[string]string)
+	for _, cm := range columnMetas {
+		name := cm.Name()
+		if !isColumnExported(config, table, name) {
+			continue
+		}
+		columnDatatype, ok := cm.ColumnType()
+		if !ok {
+			return false, errors.Default.New(fmt.Sprintf("Get [%s] ColumeType Failed", name))
+		}
+		dataType := utils.GetStarRocksDataType(columnDatatype)
+		columnMap[name] = dataType
+		signature := columnSignature(columnDatatype)
+		starrocksColumn, ok := starrocksColumns[name]
+		if !ok {
+			logger.Info("add column %s to %s", name, starrocksTable)
+			err = starrocksDb.Exec(fmt.Sprintf("ALTER TABLE `%s` ADD COLUMN `%s` %s COMMENT '%s'", starrocksTable, name, dataType, signature))
+			if err != nil {
+				return false, err
+			}
+			continue
+		}
+		delete(starrocksColumns, name)
+		if starrocksColumn.comment != signature {
+			logger.Info("column %s of %s changed from %s to %s", name, starrocksTable, starrocksColumn.comment, signature)
+			return false, nil
+		}
+	}
+	for name, starrocksColumn := range starrocksColumns {
+		if starrocksColumn.isKey {
+			return false, nil
+		}
+		logger.Info("drop column %s from %s", name, starrocksTable)
+		err = starrocksDb.Exec(fmt.Sprintf("ALTER TABLE `%s` DROP COLUMN `%s`", starrocksTable, name))
+		if err != nil {
+			return false, err
+		}
+	}
+
+	// load the rows updated since the watermark, rows updated at the watermark are loaded again in case the previous
+	// sync only got part of them
+	var updatedTo time.Time
+	err = starrocksDb.All(&updatedTo, dal.Select(updateColumn), dal.From(starrocksTable), dal.Limit(1), dal.Orderby(fmt.Sprintf("%s desc", updateColumn)))
+	if err != nil {
+		return false, err
+	}
+	if updatedTo.IsZero() {
+		return false, nil
+	}
+	where := fmt.Sprintf("%s >= ?", updateColumn)
+	if tableConfig, ok := config.TableConfigs[table]; ok && tableConfig.Where != "" {
+		where = fmt.Sprintf("(%s) AND %s", tableConfig.Where, where)
+	}
+	err = loadRows(dc, columnMap, starrocksTable, dal.Where(where, updatedTo))
+	if err != nil {
+		return false, err
+	}
+
+	// check data count
+	sourceCount, err := db.Count(dal.From(table))
+	if err != nil {
+		return false, err
+	}
+	starrocksCount, err := starrocksDb.Count(dal.From(starrocksTable))
+	if err != nil {
+		return false, err
+	}
+	if sourceCount != starrocksCount {
+		logger.Info("source count %d not equal to starrocks count %d", sourceCount, starrocksCount)
+		return false, nil
+	}
+	logger.Info("load %s to starrocks incrementally since %s success", table, updatedTo.Format(time.RFC3339))
+	return true, nil
+}
+
+// queryStarrocksTableModel returns the data model of the starrocks table, or empty if the table doesn't exist
+func queryStarrocksTableModel(dc *DataConfigParams) (string, error) {
+	rows, err := dc.DestDb.RawCursor(fmt.Sprintf(
+		"SELECT TABLE_MODEL FROM information_schema.tables_config WHERE TABLE_SCHEMA = '%s' AND TABLE_NAME = '%s'",
+		dc.Config.Database, dc.DestTableName,
+	))
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+	var model string
+	if rows.Next() {
+		if err := rows.Scan(&model); err != nil {
+			return "", err
+		}
+	}
+	return model, rows.Err()
+}
+
+func queryStarrocksColumns(dc *DataConfigParams) (map[string]starrocksColumn, error) {
+	rows, err := dc.DestDb.RawCursor(fmt.Sprintf(
+		"SELECT COLUMN_NAME, COLUMN_COMMENT, COLUMN_KEY FROM information_schema.columns WHERE TABLE_SCHEMA = '%s' AND TABLE_NAME = '%s'",
+		dc.Config.Database, dc.DestTableName,
+	))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	columns := make(map[string]starrocksColumn)
+	for rows.Next() {
+		var name, comment, key string
+		if err := rows.Scan(&name, &comment, &key); err != nil {
+			return nil, err
+		}
+		columns[name] = starrocksColumn{comment: comment, isKey: key != ""}
+	}
+	return columns, rows.Err()
+}
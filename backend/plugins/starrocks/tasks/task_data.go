@@ -27,17 +27,22 @@ type StarRocksConfig struct {
 	SourceType   string `mapstructure:"source_type"`
 	SourceDsn    string `mapstructure:"source_dsn"`
 	UpdateColumn string `mapstructure:"update_column"`
-	Host         string
-	Port         int
-	User         string
-	Password     string
-	Database     string
-	BeHost       string `mapstructure:"be_host"`
-	BePort       int    `mapstructure:"be_port"`
-	Tables       []string
-	TableConfigs map[string]TableConfig `mapstructure:"table_configs"`
-	BatchSize    int                    `mapstructure:"batch_size"`
-	OrderBy      map[string]string      `mapstructure:"order_by"`
-	DomainLayer  string                 `mapstructure:"domain_layer"`
-	Extra        map[string]string
+	// Incremental only loads the rows changed since the latest UpdateColumn of the StarRocks table and evolves its
+	// columns, instead of reloading the whole table
+	Incremental bool
+	Host        string
+	Port        int
+	User        string
+	Password    string
+	Database    string
+	BeHost      string `mapstructure:"be_host"`
+	BePort      int    `mapstructure:"be_port"`
+	Tables      []string
+	// ExcludedTables are regexps of the tables not to export, applied after Tables and DomainLayer
+	ExcludedTables []string               `mapstructure:"excluded_tables"`
+	TableConfigs   map[string]TableConfig `mapstructure:"table_configs"`
+	BatchSize      int                    `mapstructure:"batch_size"`
+	OrderBy        map[string]string      `mapstructure:"order_by"`
+	DomainLayer    string                 `mapstructure:"domain_layer"`
+	Extra          map[string]string
 }
@@ -29,8 +29,6 @@ import (
 	"strings"
 	"time"
 
-	"golang.org/x/exp/slices"
-
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/plugin"
@@ -114,6 +112,17 @@ func ExportData(c plugin.SubTaskContext) errors.Error {
 			SrcTableName:  table,
 			DestTableName: table,
 		}
+		if config.Incremental {
+			synced, err := syncIncrementally(&dc)
+			if err != nil {
+				logger.Error(err, "sync table %s incrementally error", table)
+				return errors.Convert(err)
+			}
+			if synced {
+				continue
+			}
+			logger.Info("table %s can't be synced incrementally, reload it fully", table)
+		}
 		columnMap, orderBy, skip, err := createTmpTableInStarrocks(&dc)
 		if skip {
 			logger.Info(fmt.Sprintf("table %s is up to date, so skip it", table))
@@ -164,20 +173,11 @@ func createTmpTableInStarrocks(dc *DataConfigParams) (map[string]string, string,
 	} else {
 		return nil, "", false, errors.NotFound.New(fmt.Sprintf("unsupported dialect %s", db.Dialect()))
 	}
-	tableConfig, ok := config.TableConfigs[table]
+	var pkColumns, otherColumns []string
 	for _, cm := range columnMetas {
 		name := cm.Name()
-		if ok {
-			if len(tableConfig.ExcludedColumns) > 0 {
-				if slices.Contains(tableConfig.ExcludedColumns, name) {
-					continue
-				}
-			}
-			if len(tableConfig.IncludedColumns) > 0 {
-				if !slices.Contains(tableConfig.IncludedColumns, name) {
-					continue
-				}
-			}
+		if !isColumnExported(config, table, name) {
+			continue
 		}
 		if name == updateColumn {
 			// check update column to detect skip or not
@@ -218,19 +218,29 @@ func createTmpTableInStarrocks(dc *DataConfigParams) (map[string]string, string,
 		}
 		dataType := utils.GetStarRocksDataType(columnDatatype)
 		columnMap[name] = dataType
-		column := fmt.Sprintf("`%s` %s", name, dataType)
-		columns = append(columns, column)
+		// the source type is kept in the comment to detect changed columns on incremental syncs
+		column := fmt.Sprintf("`%s` %s COMMENT '%s'", name, dataType, columnSignature(columnDatatype))
 		isPrimaryKey, ok := cm.PrimaryKey()
 		if isPrimaryKey && ok {
 			pks = append(pks, fmt.Sprintf("`%s`", name))
 			orders = append(orders, fmt.Sprintf("%s%s%s", separator, name, separator))
+			pkColumns = append(pkColumns, fmt.Sprintf("`%s` %s NOT NULL COMMENT '%s'", name, dataType, columnSignature(columnDatatype)))
+		} else {
+			otherColumns = append(otherColumns, column)
 		}
+		columns = append(columns, column)
 		if firstcm == "" {
 			firstcm = fmt.Sprintf("`%s`", name)
 			firstcmName = fmt.Sprintf("%s%s%s", separator, name, separator)
 		}
 	}
 
+	// incremental syncs upsert the changed rows, which requires a primary key table whose key columns come first
+	keysType := ""
+	if config.Incremental && len(pks) > 0 {
+		keysType = fmt.Sprintf("primary key(%s) ", strings.Join(pks, ", "))
+		columns = append(pkColumns, otherColumns...)
+	}
 	if len(pks) == 0 {
 		pks = append(pks, firstcm)
 	}
@@ -247,7 +257,7 @@ func createTmpTableInStarrocks(dc *DataConfigParams) (map[string]string, string,
 	if replicationNum == "" {
 		replicationNum = "1"
 	}
-	extra := fmt.Sprintf(`engine=olap distributed by hash(%s) properties("replication_num" = "%s")`, strings.Join(pks, ", "), replicationNum)
+	extra := fmt.Sprintf(`engine=olap %sdistributed by hash(%s) properties("replication_num" = "%s")`, keysType, strings.Join(pks, ", "), replicationNum)
 	if config.Extra != nil {
 		if v, ok := config.Extra[table]; ok {
 			extra = v
@@ -261,7 +271,6 @@ func createTmpTableInStarrocks(dc *DataConfigParams) (map[string]string, string,
 
 // put data to final dst database
 func copyDataToDst(dc *DataConfigParams, columnMap map[string]string, orderBy string) error {
-	c := dc.Ctx
 	logger := dc.Ctx.GetLogger()
 	config := dc.Config
 	db := dc.SrcDb
@@ -269,27 +278,58 @@ func copyDataToDst(dc *DataConfigParams, columnMap map[string]string, orderBy st
 	table := dc.SrcTableName
 	starrocksTable := dc.DestTableName
 	starrocksTmpTable := fmt.Sprintf("%s_tmp", starrocksTable)
-	tableConfig, ok := config.TableConfigs[table]
 	where := ""
-	if ok {
+	if tableConfig, ok := config.TableConfigs[table]; ok {
 		where = tableConfig.Where
 	}
+	err := loadRows(dc, columnMap, starrocksTmpTable, dal.Orderby(orderBy), dal.Where(where))
+	if err != nil {
+		return err
+	}
+
+	// drop old table
+	err = starrocksDb.Exec("DROP TABLE IF EXISTS ?", clause.Table{Name: starrocksTable})
+	if err != nil {
+		return err
+	}
+	// rename tmp table to old table
+	err = starrocksDb.Exec("ALTER TABLE ? RENAME ?", clause.Table{Name: starrocksTmpTable}, clause.Table{Name: starrocksTable})
+	if err != nil {
+		return err
+	}
+
+	// check data count
+	sourceCount, err := db.Count(dal.From(table))
+	if err != nil {
+		return err
+	}
+	starrocksCount, err := starrocksDb.Count(dal.From(starrocksTable))
+	if err != nil {
+		return err
+	}
+	if sourceCount != starrocksCount {
+		logger.Warn(nil, "source count %d not equal to starrocks count %d", sourceCount, starrocksCount)
+	}
+	logger.Info("load %s to starrocks success", table)
+	return nil
+}
+
+// loadRows streams the matched rows of the source table into the starrocks table in batches
+func loadRows(dc *DataConfigParams, columnMap map[string]string, starrocksTable string, clauses ...dal.Clause) error {
+	c := dc.Ctx
+	logger := dc.Ctx.GetLogger()
+	config := dc.Config
+	db := dc.SrcDb
+	table := dc.SrcTableName
+	clauses = append([]dal.Clause{dal.From(table)}, clauses...)
 	var offset int
 	var err error
 	var rows dal.Rows
-	rows, err = db.Cursor(
-		dal.From(table),
-		dal.Orderby(orderBy),
-		dal.Where(where),
-	)
+	rows, err = db.Cursor(clauses...)
 	if err != nil {
 		if strings.Contains(err.Error(), "cached plan must not change result type") {
 			logger.Warn(err, "skip err: cached plan must not change result type")
-			rows, err = db.Cursor(
-				dal.From(table),
-				dal.Orderby(orderBy),
-				dal.Where(where),
-			)
+			rows, err = db.Cursor(clauses...)
 			if err != nil {
 				return err
 			}
@@ -335,7 +375,7 @@ func copyDataToDst(dc *DataConfigParams, columnMap map[string]string, orderBy st
 		data = append(data, row)
 		batchCount += 1
 		if batchCount == config.BatchSize {
-			err = putBatchData(c, starrocksTmpTable, table, data, config, offset)
+			err = putBatchData(c, starrocksTable, table, data, config, offset)
 			if err != nil {
 				return err
 			}
@@ -344,36 +384,11 @@ func copyDataToDst(dc *DataConfigParams, columnMap map[string]string, orderBy st
 		}
 	}
 	if batchCount != 0 {
-		err = putBatchData(c, starrocksTmpTable, table, data, config, offset)
+		err = putBatchData(c, starrocksTable, table, data, config, offset)
 		if err != nil {
 			return err
 		}
 	}
-
-	// drop old table
-	err = starrocksDb.Exec("DROP TABLE IF EXISTS ?", clause.Table{Name: starrocksTable})
-	if err != nil {
-		return err
-	}
-	// rename tmp table to old table
-	err = starrocksDb.Exec("ALTER TABLE ? RENAME ?", clause.Table{Name: starrocksTmpTable}, clause.Table{Name: starrocksTable})
-	if err != nil {
-		return err
-	}
-
-	// check data count
-	sourceCount, err := db.Count(dal.From(table))
-	if err != nil {
-		return err
-	}
-	starrocksCount, err := starrocksDb.Count(dal.From(starrocksTable))
-	if err != nil {
-		return err
-	}
-	if sourceCount != starrocksCount {
-		logger.Warn(nil, "source count %d not equal to starrocks count %d", sourceCount, starrocksCount)
-	}
-	logger.Info("load %s to starrocks success", table)
 	return nil
 }
 
@@ -511,7 +526,26 @@ func getExportingTables(c plugin.SubTaskContext, db dal.Dal) (starrocksTables []
 			}
 		}
 	}
-	return starrocksTables, nil
+	if len(config.ExcludedTables) == 0 {
+		return starrocksTables, nil
+	}
+	var includedTables []string
+	for _, table := range starrocksTables {
+		excluded := false
+		for _, r := range config.ExcludedTables {
+			excluded, err = regexp.MatchString(r, table)
+			if err != nil {
+				return nil, err
+			}
+			if excluded {
+				break
+			}
+		}
+		if !excluded {
+			includedTables = append(includedTables, table)
+		}
+	}
+	return includedTables, nil
 }
 
 var ExportDataTaskMeta = plugin.SubTaskMeta{
@@ -0,0 +1,116 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	mockplugin "github.com/apache/incubator-devlake/mocks/core/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsColumnExported(t *testing.T) {
+	config := &StarRocksConfig{
+		TableConfigs: map[string]TableConfig{
+			"issues":        {ExcludedColumns: []string{"description"}},
+			"pull_requests": {IncludedColumns: []string{"id", "title", "updated_at"}, ExcludedColumns: []string{"title"}},
+		},
+	}
+	tests := []struct {
+		table  string
+		column string
+		want   bool
+	}{
+		{"commits", "message", true},
+		{"issues", "title", true},
+		{"issues", "description", false},
+		{"pull_requests", "id", true},
+		{"pull_requests", "body", false},
+		{"pull_requests", "title", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, isColumnExported(config, tt.table, tt.column), "%s.%s", tt.table, tt.column)
+	}
+}
+
+func TestColumnSignature(t *testing.T) {
+	assert.Equal(t, "varchar(255)", columnSignature("VARCHAR(255)"))
+	assert.Equal(t, "enum(open,closed)", columnSignature("enum('open','closed')"))
+	// the signature changes with the type, which makes the incremental sync reload the table
+	assert.NotEqual(t, columnSignature("varchar(255)"), columnSignature("text"))
+}
+
+func TestGetExportingTables(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	mockDal.On("AllTables").Return([]string{"issues", "issue_comments", "_raw_jira_api_issues", "_tool_jira_issues", "commits"}, nil)
+	tests := []struct {
+		name   string
+		config *StarRocksConfig
+		want   []string
+	}{
+		{
+			name:   "all tables",
+			config: &StarRocksConfig{},
+			want:   []string{"issues", "issue_comments", "_raw_jira_api_issues", "_tool_jira_issues", "commits"},
+		},
+		{
+			name:   "excluded tables",
+			config: &StarRocksConfig{ExcludedTables: []string{"^_raw_", "^_tool_"}},
+			want:   []string{"issues", "issue_comments", "commits"},
+		},
+		{
+			name:   "excluded from the matched tables",
+			config: &StarRocksConfig{Tables: []string{"^issue"}, ExcludedTables: []string{"comments$"}},
+			want:   []string{"issues"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtx := new(mockplugin.SubTaskContext)
+			mockCtx.On("GetData").Return(tt.config)
+			tables, err := getExportingTables(mockCtx, mockDal)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, tables)
+		})
+	}
+}
+
+func TestSyncIncrementallyFallsBackToFullReload(t *testing.T) {
+	mockCtx := new(mockplugin.SubTaskContext)
+	mockCtx.On("GetLogger").Return(unithelper.DummyLogger())
+	srcDal := new(mockdal.Dal)
+	srcDal.On("HasColumn", "issues", "updated_at").Return(true)
+	srcDal.On("HasColumn", "commits", "updated_at").Return(false)
+	destDal := new(mockdal.Dal)
+	config := &StarRocksConfig{
+		UpdateColumn: "updated_at",
+		Incremental:  true,
+		TableConfigs: map[string]TableConfig{"issues": {ExcludedColumns: []string{"updated_at"}}},
+	}
+
+	// no update column to track the changed rows
+	synced, err := syncIncrementally(&DataConfigParams{Ctx: mockCtx, Config: config, SrcDb: srcDal, DestDb: destDal, SrcTableName: "commits", DestTableName: "commits"})
+	assert.Nil(t, err)
+	assert.False(t, synced)
+	// the update column isn't exported
+	synced, err = syncIncrementally(&DataConfigParams{Ctx: mockCtx, Config: config, SrcDb: srcDal, DestDb: destDal, SrcTableName: "issues", DestTableName: "issues"})
+	assert.Nil(t, err)
+	assert.False(t, synced)
+}
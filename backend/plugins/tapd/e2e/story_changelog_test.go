@@ -126,6 +126,7 @@ func TestTapdStoryChangelogDataFlow(t *testing.T) {
 			"ConnectionId": uint64(2),
 		},
 	)
+	dataflowTester.FlushTabler(&ticket.IssueStatusChange{})
 	dataflowTester.FlushTabler(&ticket.IssueChangelogs{})
 	dataflowTester.Subtask(tasks.ConvertStoryChangelogMeta, taskData)
 	dataflowTester.VerifyTable(
@@ -145,4 +146,19 @@ func TestTapdStoryChangelogDataFlow(t *testing.T) {
 			"original_to_value",
 		),
 	)
+	dataflowTester.VerifyTable(
+		ticket.IssueStatusChange{},
+		"./snapshot_tables/issue_status_changes_story.csv",
+		e2ehelper.ColumnWithRawData(
+			"id",
+			"issue_id",
+			"author_id",
+			"author_name",
+			"from_status",
+			"to_status",
+			"original_from_status",
+			"original_to_status",
+			"changed_date",
+		),
+	)
 }
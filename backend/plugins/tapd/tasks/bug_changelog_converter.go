@@ -131,6 +131,10 @@ func ConvertBugChangelog(taskCtx plugin.SubTaskContext) errors.Error {
 					domainCl.FromValue = getStdStatus(domainCl.OriginalFromValue)
 					domainCl.ToValue = getStdStatus(domainCl.OriginalToValue)
 				}
+				return []interface{}{
+					domainCl,
+					ticket.NewIssueStatusChange(domainCl),
+				}, nil
 			}
 			return []interface{}{
 				domainCl,
@@ -142,6 +142,12 @@ func ConvertStoryChangelog(taskCtx plugin.SubTaskContext) errors.Error {
 				domainCl.OriginalToValue = generateDomainAccountIdForUsers(cl.ValueAfterParsed, cl.ConnectionId)
 
 			}
+			if domainCl.FieldName == "status" {
+				return []interface{}{
+					domainCl,
+					ticket.NewIssueStatusChange(domainCl),
+				}, nil
+			}
 			return []interface{}{
 				domainCl,
 			}, nil
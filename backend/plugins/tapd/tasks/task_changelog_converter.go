@@ -135,6 +135,12 @@ func ConvertTaskChangelog(taskCtx plugin.SubTaskContext) errors.Error {
 				domainCl.OriginalFromValue = generateDomainAccountIdForUsers(cl.ValueBeforeParsed, cl.ConnectionId)
 				domainCl.OriginalToValue = generateDomainAccountIdForUsers(cl.ValueAfterParsed, cl.ConnectionId)
 			}
+			if domainCl.FieldName == "status" {
+				return []interface{}{
+					domainCl,
+					ticket.NewIssueStatusChange(domainCl),
+				}, nil
+			}
 			return []interface{}{
 				domainCl,
 			}, nil
@@ -32,7 +32,7 @@ import (
 
 // PostConnections
 // @Summary create webhook connection
-// @Description Create webhook connection, example: {"name":"Webhook data connection name"}
+// @Description Create webhook connection, example: {"name":"Webhook data connection name","secret":"optional secret to sign the payloads"}
 // @Tags plugins/webhook
 // @Param body body WebhookConnectionResponse true "json body"
 // @Success 200  {object} WebhookConnectionResponse
@@ -90,11 +90,16 @@ func PostConnections(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput,
 // @Router /plugins/webhook/connections/{connectionId} [PATCH]
 func PatchConnection(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
 	connection := &models.WebhookConnection{}
-	err := connectionHelper.Patch(connection, input)
+	err := connectionHelper.First(connection, input.Params)
 	if err != nil {
 		return nil, err
 	}
-	return &plugin.ApiResourceOutput{Body: connection}, nil
+	keepSanitizedSecret(connection, input.Body)
+	err = connectionHelper.Patch(connection, input)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: connection.Sanitize()}, nil
 }
 
 // PatchConnectionByName
@@ -108,11 +113,23 @@ func PatchConnection(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput,
 // @Router /plugins/webhook/connections/by-name/{connectionName} [PATCH]
 func PatchConnectionByName(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
 	connection := &models.WebhookConnection{}
-	err := connectionHelper.PatchByName(connection, input)
+	err := connectionHelper.FirstByName(connection, input.Params)
 	if err != nil {
 		return nil, err
 	}
-	return &plugin.ApiResourceOutput{Body: connection}, nil
+	keepSanitizedSecret(connection, input.Body)
+	err = connectionHelper.PatchByName(connection, input)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: connection.Sanitize()}, nil
+}
+
+// keepSanitizedSecret drops the secret from the body if it is the sanitized one sent back by the UI
+func keepSanitizedSecret(connection *models.WebhookConnection, body map[string]interface{}) {
+	if secret, ok := body["secret"].(string); ok && secret != "" && secret == connection.Sanitize().Secret {
+		delete(body, "secret")
+	}
 }
 
 // DeleteConnection
@@ -164,6 +181,14 @@ func deleteConnection(e error, connectionId uint64) (*plugin.ApiResourceOutput,
 		logger.Error(err, "delete connection: %d", connectionId)
 		return nil, err
 	}
+	err = tx.Delete(&models.WebhookDeployment{}, dal.Where("connection_id = ?", connectionId))
+	if err != nil {
+		if err := tx.Rollback(); err != nil {
+			logger.Error(err, "transaction Rollback")
+		}
+		logger.Error(err, "delete deployments of connection: %d", connectionId)
+		return nil, err
+	}
 	extra := fmt.Sprintf("connectionId:%d", connectionId)
 	err = apiKeyHelper.DeleteForPlugin(tx, pluginName, extra)
 	if err != nil {
@@ -184,6 +209,7 @@ type WebhookConnectionResponse struct {
 	models.WebhookConnection
 	PostIssuesEndpoint             string             `json:"postIssuesEndpoint"`
 	CloseIssuesEndpoint            string             `json:"closeIssuesEndpoint"`
+	PostIssueEventsEndpoint        string             `json:"postIssueEventsEndpoint"`
 	PostPullRequestsEndpoint       string             `json:"postPullRequestsEndpoint"`
 	PostPipelineTaskEndpoint       string             `json:"postPipelineTaskEndpoint"`
 	PostPipelineDeployTaskEndpoint string             `json:"postPipelineDeployTaskEndpoint"`
@@ -254,9 +280,10 @@ func getConnection(err errors.Error, connection *models.WebhookConnection) (*plu
 }
 
 func formatConnection(connection *models.WebhookConnection, withApiKeyInfo bool) (*WebhookConnectionResponse, errors.Error) {
-	response := &WebhookConnectionResponse{WebhookConnection: *connection}
+	response := &WebhookConnectionResponse{WebhookConnection: connection.Sanitize()}
 	response.PostIssuesEndpoint = fmt.Sprintf(`/rest/plugins/webhook/connections/%d/issues`, connection.ID)
 	response.CloseIssuesEndpoint = fmt.Sprintf(`/rest/plugins/webhook/connections/%d/issue/:issueKey/close`, connection.ID)
+	response.PostIssueEventsEndpoint = fmt.Sprintf(`/rest/plugins/webhook/connections/%d/issue_events`, connection.ID)
 	response.PostPullRequestsEndpoint = fmt.Sprintf(`/rest/plugins/webhook/connections/%d/pull_requests`, connection.ID)
 	response.PostPipelineTaskEndpoint = fmt.Sprintf(`/rest/plugins/webhook/connections/%d/cicd_tasks`, connection.ID)
 	response.PostPipelineDeployTaskEndpoint = fmt.Sprintf(`/rest/plugins/webhook/connections/%d/deployments`, connection.ID)
@@ -20,21 +20,16 @@ package api
 import (
 	"crypto/md5"
 	"fmt"
-	"net/http"
 	"strings"
 	"time"
 
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/log"
 
-	"github.com/apache/incubator-devlake/helpers/dbhelper"
-	"github.com/go-playground/validator/v10"
-
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models/domainlayer"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
 	"github.com/apache/incubator-devlake/core/plugin"
-	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"github.com/apache/incubator-devlake/plugins/webhook/models"
 )
 
@@ -44,6 +39,10 @@ type WebhookDeploymentReq struct {
 	Result       string `mapstructure:"result"`
 	Environment  string `validate:"omitempty,oneof=PRODUCTION STAGING TESTING DEVELOPMENT"`
 	Name         string `mapstructure:"name"`
+	// Url links to the pipeline triggering the deployment
+	Url      string                        `mapstructure:"url"`
+	Approver string                        `mapstructure:"approver"`
+	Artifact *WebhookDeploymentArtifactReq `mapstructure:"artifact"`
 	// DeploymentCommits is used for multiple commits in one deployment
 	DeploymentCommits []WebhookDeploymentCommitReq `mapstructure:"deploymentCommits" validate:"omitempty,dive"`
 	CreatedDate       *time.Time                   `mapstructure:"createdDate"`
@@ -62,16 +61,27 @@ type WebhookDeploymentCommitReq struct {
 	CommitMsg    string     `mapstructure:"commitMsg"`
 	Result       string     `mapstructure:"result"`
 	Status       string     `mapstructure:"status"`
+	Url          string     `mapstructure:"url"`
 	CreatedDate  *time.Time `mapstructure:"createdDate"`
 	// QueuedDate   *time.Time `mapstructure:"queue_time"`
 	StartedDate  *time.Time `mapstructure:"startedDate" validate:"required"`
 	FinishedDate *time.Time `mapstructure:"finishedDate" validate:"required"`
 }
 
+// WebhookDeploymentArtifactReq is the artifact (i.e. a docker image) being deployed
+type WebhookDeploymentArtifactReq struct {
+	Name    string `mapstructure:"name" validate:"required"`
+	Version string `mapstructure:"version"`
+	Url     string `mapstructure:"url"`
+	Digest  string `mapstructure:"digest"`
+}
+
 // PostDeployments
 // @Summary create deployment by webhook
 // @Description Create deployment pipeline by webhook.<br/>
-// @Description example1: {"repo_url":"devlake","commit_sha":"015e3d3b480e417aede5a1293bd61de9b0fd051d","start_time":"2020-01-01T12:00:00+00:00","end_time":"2020-01-01T12:59:59+00:00","environment":"PRODUCTION"}<br/>
+// @Description example1: {"id":"deploy-1","url":"https://ci.example.com/pipelines/1","approver":"alice","artifact":{"name":"devlake","version":"v1.0.0","digest":"sha256:4f2a..."},"startedDate":"2020-01-01T12:00:00+00:00","finishedDate":"2020-01-01T12:59:59+00:00","environment":"PRODUCTION","deploymentCommits":[{"repoUrl":"https://github.com/apache/incubator-devlake","commitSha":"015e3d3b480e417aede5a1293bd61de9b0fd051d"}]}<br/>
+// @Description The payload must be signed by the `X-Hub-Signature-256: sha256=<hmac>` header if the connection has a secret.<br/>
+// @Description The retries of a request carrying the same `X-Webhook-Delivery-Id` header are handled only once.<br/>
 // @Description So we suggest request before task after deployment pipeline finish.
 // @Description Both cicd_pipeline and cicd_task will be created
 // @Tags plugins/webhook
@@ -91,7 +101,9 @@ func PostDeployments(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput,
 // PostDeploymentsByName
 // @Summary create deployment by webhook name
 // @Description Create deployment pipeline by webhook name.<br/>
-// @Description example1: {"repo_url":"devlake","commit_sha":"015e3d3b480e417aede5a1293bd61de9b0fd051d","start_time":"2020-01-01T12:00:00+00:00","end_time":"2020-01-01T12:59:59+00:00","environment":"PRODUCTION"}<br/>
+// @Description example1: {"id":"deploy-1","url":"https://ci.example.com/pipelines/1","approver":"alice","artifact":{"name":"devlake","version":"v1.0.0","digest":"sha256:4f2a..."},"startedDate":"2020-01-01T12:00:00+00:00","finishedDate":"2020-01-01T12:59:59+00:00","environment":"PRODUCTION","deploymentCommits":[{"repoUrl":"https://github.com/apache/incubator-devlake","commitSha":"015e3d3b480e417aede5a1293bd61de9b0fd051d"}]}<br/>
+// @Description The payload must be signed by the `X-Hub-Signature-256: sha256=<hmac>` header if the connection has a secret.<br/>
+// @Description The retries of a request carrying the same `X-Webhook-Delivery-Id` header are handled only once.<br/>
 // @Description So we suggest request before task after deployment pipeline finish.
 // @Description Both cicd_pipeline and cicd_task will be created
 // @Tags plugins/webhook
@@ -112,27 +124,18 @@ func postDeployments(input *plugin.ApiResourceInput, connection *models.WebhookC
 	if err != nil {
 		return nil, err
 	}
-	// get request
-	request := &WebhookDeploymentReq{}
-	err = api.DecodeMapStruct(input.Body, request, true)
-	if err != nil {
-		return &plugin.ApiResourceOutput{Body: err.Error(), Status: http.StatusBadRequest}, nil
-	}
-	// validate
-	vld = validator.New()
-	err = errors.Convert(vld.Struct(request))
-	if err != nil {
-		return nil, errors.BadInput.Wrap(vld.Struct(request), `input json error`)
-	}
-	txHelper := dbhelper.NewTxHelper(basicRes, &err)
-	defer txHelper.End()
-	tx := txHelper.Begin()
-	if err := CreateDeploymentAndDeploymentCommits(connection, request, tx, logger); err != nil {
+	return deploymentReceiver.Receive(input, connection.ID, connection.Secret)
+}
+
+// saveDeployment saves the deployment pushed to the connection, it is idempotent so the delivery can be replayed
+func saveDeployment(tx dal.Transaction, connectionId uint64, payload interface{}) errors.Error {
+	connection := &models.WebhookConnection{}
+	connection.ID = connectionId
+	if err := CreateDeploymentAndDeploymentCommits(connection, payload.(*WebhookDeploymentReq), tx, logger); err != nil {
 		logger.Error(err, "create deployments")
-		return nil, err
+		return err
 	}
-
-	return &plugin.ApiResourceOutput{Body: nil, Status: http.StatusOK}, nil
+	return nil
 }
 
 func CreateDeploymentAndDeploymentCommits(connection *models.WebhookConnection, request *WebhookDeploymentReq, tx dal.Transaction, logger log.Logger) errors.Error {
@@ -223,8 +226,12 @@ func CreateDeploymentAndDeploymentCommits(connection *models.WebhookConnection,
 			RefName:             commit.RefName,
 			CommitSha:           commit.CommitSha,
 			CommitMsg:           commit.CommitMsg,
+			Url:                 commit.Url,
 			//QueuedDurationSec: queuedDuration,
 		}
+		if deploymentCommits[i].Url == "" {
+			deploymentCommits[i].Url = request.Url
+		}
 	}
 
 	if err := tx.CreateOrUpdate(deploymentCommits); err != nil {
@@ -239,10 +246,29 @@ func CreateDeploymentAndDeploymentCommits(connection *models.WebhookConnection,
 	deployment.StartedDate = request.StartedDate
 	deployment.FinishedDate = request.FinishedDate
 	deployment.Result = request.Result
+	deployment.Url = request.Url
 	if err := tx.CreateOrUpdate(deployment); err != nil {
 		logger.Error(err, "failed to save deployment")
 		return err
 	}
+
+	// keep the details cicd_deployments has no room for
+	webhookDeployment := &models.WebhookDeployment{
+		ConnectionId: connection.ID,
+		DeploymentId: deploymentId,
+		PipelineUrl:  request.Url,
+		Approver:     request.Approver,
+	}
+	if request.Artifact != nil {
+		webhookDeployment.ArtifactName = request.Artifact.Name
+		webhookDeployment.ArtifactVersion = request.Artifact.Version
+		webhookDeployment.ArtifactUrl = request.Artifact.Url
+		webhookDeployment.ArtifactDigest = request.Artifact.Digest
+	}
+	if err := tx.CreateOrUpdate(webhookDeployment); err != nil {
+		logger.Error(err, "failed to save webhook deployment")
+		return err
+	}
 	return nil
 }
 
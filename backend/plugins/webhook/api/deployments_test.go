@@ -0,0 +1,82 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/apache/incubator-devlake/plugins/webhook/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateDeploymentAndDeploymentCommits(t *testing.T) {
+	var saved []interface{}
+	tx := new(mockdal.Transaction)
+	tx.On("CreateOrUpdate", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		saved = append(saved, args.Get(0))
+	}).Return(nil)
+
+	connection := &models.WebhookConnection{}
+	connection.ID = 1
+	started := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	finished := started.Add(time.Hour)
+	err := CreateDeploymentAndDeploymentCommits(connection, &WebhookDeploymentReq{
+		Id:       "deploy-1",
+		Url:      "https://ci.example.com/pipelines/1",
+		Approver: "alice",
+		Artifact: &WebhookDeploymentArtifactReq{Name: "devlake", Version: "v1.0.0"},
+		DeploymentCommits: []WebhookDeploymentCommitReq{
+			{RepoUrl: "https://github.com/apache/incubator-devlake", CommitSha: "015e3d3b"},
+			{RepoUrl: "https://github.com/apache/incubator-devlake-helm-chart", CommitSha: "a1b2c3d4", Url: "https://ci.example.com/pipelines/1/jobs/2"},
+		},
+		StartedDate:  &started,
+		FinishedDate: &finished,
+	}, tx, nil)
+	assert.Nil(t, err)
+	assert.Len(t, saved, 3)
+
+	commits := saved[0].([]*devops.CicdDeploymentCommit)
+	assert.Len(t, commits, 2)
+	assert.Equal(t, "https://ci.example.com/pipelines/1", commits[0].Url)
+	assert.Equal(t, "https://ci.example.com/pipelines/1/jobs/2", commits[1].Url)
+
+	deployment := saved[1].(*devops.CICDDeployment)
+	assert.Equal(t, "deploy-1", deployment.Id)
+	assert.Equal(t, "https://ci.example.com/pipelines/1", deployment.Url)
+
+	webhookDeployment := saved[2].(*models.WebhookDeployment)
+	assert.Equal(t, "deploy-1", webhookDeployment.DeploymentId)
+	assert.Equal(t, "alice", webhookDeployment.Approver)
+	assert.Equal(t, "devlake", webhookDeployment.ArtifactName)
+	assert.Equal(t, "v1.0.0", webhookDeployment.ArtifactVersion)
+}
+
+func TestKeepSanitizedSecret(t *testing.T) {
+	connection := &models.WebhookConnection{Secret: "my-webhook-secret"}
+	body := map[string]interface{}{"secret": connection.Sanitize().Secret}
+	keepSanitizedSecret(connection, body)
+	assert.NotContains(t, body, "secret")
+
+	body = map[string]interface{}{"secret": "another-secret"}
+	keepSanitizedSecret(connection, body)
+	assert.Equal(t, "another-secret", body["secret"])
+}
@@ -33,6 +33,26 @@ var connectionHelper *api.ConnectionApiHelper
 var apiKeyHelper *apikeyhelper.ApiKeyHelper
 var basicRes context.BasicRes
 var logger log.Logger
+var issueReceiver *api.WebhookReceiver
+var issueEventReceiver *api.WebhookReceiver
+var deploymentReceiver *api.WebhookReceiver
+var pullRequestReceiver *api.WebhookReceiver
+
+// webhookSignature verifies the payloads pushed to the connections having a secret
+var webhookSignature = &api.HmacSignature{Header: "X-Hub-Signature-256", Prefix: "sha256="}
+
+// webhookDeliveryIdHeader may be sent by the clients retrying their requests, the retries are handled only once
+const webhookDeliveryIdHeader = "X-Webhook-Delivery-Id"
+
+// closeIssueSignature verifies the requests closing issues, they have no body so the method and the path are signed
+// along with the timestamp, i.e. the HMAC of "1700000000\nPOST /plugins/webhook/1/issue/KEY-1/close"
+var closeIssueSignature = &api.TimestampedHmacSignature{
+	HmacSignature:   *webhookSignature,
+	TimestampHeader: "X-Webhook-Timestamp",
+	Format: func(timestamp string, body []byte) []byte {
+		return []byte(timestamp + "\n" + string(body))
+	},
+}
 
 func Init(br context.BasicRes, p plugin.PluginMeta) {
 	basicRes = br
@@ -40,4 +60,36 @@ func Init(br context.BasicRes, p plugin.PluginMeta) {
 	vld = validator.New()
 	connectionHelper = api.NewConnectionHelper(basicRes, vld, p.Name())
 	apiKeyHelper = apikeyhelper.NewApiKeyHelper(basicRes, logger)
+	issueReceiver = api.NewWebhookReceiver(basicRes, api.WebhookReceiverArgs{
+		Plugin:           pluginName,
+		Event:            "issues",
+		NewPayload:       func() interface{} { return &WebhookIssueRequest{} },
+		Signature:        webhookSignature,
+		DeliveryIdHeader: webhookDeliveryIdHeader,
+		Handle:           saveIssue,
+	})
+	issueEventReceiver = api.NewWebhookReceiver(basicRes, api.WebhookReceiverArgs{
+		Plugin:           pluginName,
+		Event:            "issue_events",
+		NewPayload:       func() interface{} { return &WebhookIssueEventReq{} },
+		Signature:        webhookSignature,
+		DeliveryIdHeader: webhookDeliveryIdHeader,
+		Handle:           saveIssueEvent,
+	})
+	deploymentReceiver = api.NewWebhookReceiver(basicRes, api.WebhookReceiverArgs{
+		Plugin:           pluginName,
+		Event:            "deployments",
+		NewPayload:       func() interface{} { return &WebhookDeploymentReq{} },
+		Signature:        webhookSignature,
+		DeliveryIdHeader: webhookDeliveryIdHeader,
+		Handle:           saveDeployment,
+	})
+	pullRequestReceiver = api.NewWebhookReceiver(basicRes, api.WebhookReceiverArgs{
+		Plugin:           pluginName,
+		Event:            "pull_requests",
+		NewPayload:       func() interface{} { return &WebhookPullRequestReq{} },
+		Signature:        webhookSignature,
+		DeliveryIdHeader: webhookDeliveryIdHeader,
+		Handle:           savePullRequest,
+	})
 }
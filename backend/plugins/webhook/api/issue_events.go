@@ -0,0 +1,235 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/webhook/models"
+)
+
+const (
+	ISSUE_CREATED = "created"
+	ISSUE_UPDATED = "updated"
+	ISSUE_CLOSED  = "closed"
+)
+
+// WebhookIssueEventReq is an event of the lifecycle of an issue, unlike WebhookIssueRequest only the fields being
+// changed have to be sent, the others are kept as they are
+type WebhookIssueEventReq struct {
+	Event          string `mapstructure:"event" validate:"required,oneof=created updated closed"`
+	IssueKey       string `mapstructure:"issueKey" validate:"required"`
+	Url            string `mapstructure:"url"`
+	Title          string `mapstructure:"title"`
+	Description    string `mapstructure:"description"`
+	EpicKey        string `mapstructure:"epicKey"`
+	ParentIssueKey string `mapstructure:"parentIssueKey"`
+	Type           string `mapstructure:"type"`
+	Status         string `mapstructure:"status" validate:"omitempty,oneof=TODO DONE IN_PROGRESS"`
+	OriginalStatus string `mapstructure:"originalStatus"`
+	Severity       string `mapstructure:"severity"`
+	Priority       string `mapstructure:"priority"`
+	Component      string `mapstructure:"component"`
+	CreatorId      string `mapstructure:"creatorId"`
+	CreatorName    string `mapstructure:"creatorName"`
+	AssigneeId     string `mapstructure:"assigneeId"`
+	AssigneeName   string `mapstructure:"assigneeName"`
+	// Timestamp is when the event happened, it is the default of createdDate, updatedDate and resolutionDate
+	Timestamp      *time.Time `mapstructure:"timestamp"`
+	CreatedDate    *time.Time `mapstructure:"createdDate"`
+	UpdatedDate    *time.Time `mapstructure:"updatedDate"`
+	ResolutionDate *time.Time `mapstructure:"resolutionDate"`
+}
+
+// PostIssueEvents
+// @Summary receive an event of the lifecycle of an issue
+// @Description Create, update or close an issue by the event, only the changed fields have to be sent, example: {"event":"closed","issueKey":"DLK-1234","originalStatus":"resolved","timestamp":"2020-01-02T12:00:00+00:00"}<br/>
+// @Description The status changes are recorded as issue changelogs, the lead time is calculated when the issue is closed. The events happened before the last update of the issue are ignored
+// @Tags plugins/webhook
+// @Param body body WebhookIssueEventReq true "json body"
+// @Success 200  {object} api.WebhookDeliveryOutput
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 401  {string} errcode.Error "Unauthorized"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/webhook/connections/:connectionId/issue_events [POST]
+func PostIssueEvents(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	connection := &models.WebhookConnection{}
+	err := connectionHelper.First(connection, input.Params)
+	return postIssueEvents(input, err, connection)
+}
+
+// PostIssueEventsByName
+// @Summary receive an event of the lifecycle of an issue by webhook name
+// @Description Create, update or close an issue by the event, only the changed fields have to be sent, example: {"event":"closed","issueKey":"DLK-1234","originalStatus":"resolved","timestamp":"2020-01-02T12:00:00+00:00"}<br/>
+// @Description The status changes are recorded as issue changelogs, the lead time is calculated when the issue is closed. The events happened before the last update of the issue are ignored
+// @Tags plugins/webhook
+// @Param body body WebhookIssueEventReq true "json body"
+// @Success 200  {object} api.WebhookDeliveryOutput
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 401  {string} errcode.Error "Unauthorized"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/webhook/connections/by-name/:connectionName/issue_events [POST]
+func PostIssueEventsByName(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	connection := &models.WebhookConnection{}
+	err := connectionHelper.FirstByName(connection, input.Params)
+	return postIssueEvents(input, err, connection)
+}
+
+func postIssueEvents(input *plugin.ApiResourceInput, err errors.Error, connection *models.WebhookConnection) (*plugin.ApiResourceOutput, errors.Error) {
+	if err != nil {
+		return nil, err
+	}
+	return issueEventReceiver.Receive(input, connection.ID, connection.Secret)
+}
+
+// saveIssueEvent applies the event to the issue, it is idempotent so the delivery can be replayed
+func saveIssueEvent(tx dal.Transaction, connectionId uint64, payload interface{}) errors.Error {
+	event := payload.(*WebhookIssueEventReq)
+	issueId := fmt.Sprintf("%s:%d:%s", "webhook", connectionId, event.IssueKey)
+	domainIssue := &ticket.Issue{}
+	err := tx.First(domainIssue, dal.Where("id = ?", issueId))
+	if err != nil && !tx.IsErrorNotFound(err) {
+		return err
+	}
+	exists := err == nil
+	if !exists && event.Title == "" {
+		return errors.BadInput.New(fmt.Sprintf("issue %s not found, the title is required to create it", event.IssueKey))
+	}
+	timestamp := time.Now()
+	if event.Timestamp != nil {
+		timestamp = *event.Timestamp
+	}
+	// the deliveries may arrive out of order, an event older than the issue would revert the newer changes
+	if exists && isStaleIssueEvent(domainIssue, event, timestamp) {
+		return nil
+	}
+	changelog := applyIssueEvent(domainIssue, connectionId, event, timestamp)
+	if !exists {
+		domainIssue.Id = issueId
+	}
+	if err := saveDomainIssue(tx, connectionId, domainIssue); err != nil {
+		return err
+	}
+	if changelog != nil {
+		return tx.CreateOrUpdate(changelog)
+	}
+	return nil
+}
+
+// isStaleIssueEvent tells whether the event happened before the last update of the issue
+func isStaleIssueEvent(issue *ticket.Issue, event *WebhookIssueEventReq, timestamp time.Time) bool {
+	if issue.UpdatedDate == nil {
+		return false
+	}
+	happenedAt := timestamp
+	if event.UpdatedDate != nil {
+		happenedAt = *event.UpdatedDate
+	}
+	return happenedAt.Before(*issue.UpdatedDate)
+}
+
+// applyIssueEvent copies the fields sent by the event to the issue, and returns the changelog of the status if it
+// was changed
+func applyIssueEvent(issue *ticket.Issue, connectionId uint64, event *WebhookIssueEventReq, timestamp time.Time) *ticket.IssueChangelogs {
+	setIfPresent := func(field *string, value string) {
+		if value != "" {
+			*field = value
+		}
+	}
+	toId := func(key string) string {
+		if key == "" {
+			return ""
+		}
+		return fmt.Sprintf("%s:%d:%s", "webhook", connectionId, key)
+	}
+	issue.IssueKey = event.IssueKey
+	setIfPresent(&issue.Url, event.Url)
+	setIfPresent(&issue.Title, event.Title)
+	setIfPresent(&issue.Description, event.Description)
+	setIfPresent(&issue.EpicKey, event.EpicKey)
+	setIfPresent(&issue.ParentIssueId, toId(event.ParentIssueKey))
+	setIfPresent(&issue.Type, event.Type)
+	setIfPresent(&issue.Severity, event.Severity)
+	setIfPresent(&issue.Priority, event.Priority)
+	setIfPresent(&issue.Component, event.Component)
+	setIfPresent(&issue.CreatorId, toId(event.CreatorId))
+	setIfPresent(&issue.CreatorName, event.CreatorName)
+	setIfPresent(&issue.AssigneeId, toId(event.AssigneeId))
+	setIfPresent(&issue.AssigneeName, event.AssigneeName)
+
+	fromStatus, fromOriginalStatus := issue.Status, issue.OriginalStatus
+	setIfPresent(&issue.Status, event.Status)
+	setIfPresent(&issue.OriginalStatus, event.OriginalStatus)
+	if event.Event == ISSUE_CLOSED {
+		issue.Status = ticket.DONE
+	}
+	if issue.Status == "" {
+		issue.Status = ticket.TODO
+	}
+	if issue.OriginalStatus == "" {
+		issue.OriginalStatus = issue.Status
+	}
+
+	if event.CreatedDate != nil {
+		issue.CreatedDate = event.CreatedDate
+	} else if issue.CreatedDate == nil {
+		issue.CreatedDate = &timestamp
+	}
+	if event.UpdatedDate != nil {
+		issue.UpdatedDate = event.UpdatedDate
+	} else {
+		issue.UpdatedDate = &timestamp
+	}
+	if event.ResolutionDate != nil {
+		issue.ResolutionDate = event.ResolutionDate
+	} else if issue.Status == ticket.DONE && issue.ResolutionDate == nil {
+		issue.ResolutionDate = &timestamp
+	} else if issue.Status != ticket.DONE {
+		// reopened
+		issue.ResolutionDate = nil
+	}
+	if issue.ResolutionDate != nil && issue.CreatedDate != nil {
+		leadTimeMinutes := uint(issue.ResolutionDate.Sub(*issue.CreatedDate).Minutes())
+		issue.LeadTimeMinutes = &leadTimeMinutes
+	} else {
+		issue.LeadTimeMinutes = nil
+	}
+
+	if fromStatus == issue.Status && fromOriginalStatus == issue.OriginalStatus {
+		return nil
+	}
+	return &ticket.IssueChangelogs{
+		DomainEntity: domainlayer.DomainEntity{
+			Id: fmt.Sprintf("%s:%d:%s:%d", "webhook", connectionId, event.IssueKey, timestamp.UnixMilli()),
+		},
+		IssueId:           toId(event.IssueKey),
+		FieldId:           "status",
+		FieldName:         "status",
+		OriginalFromValue: fromOriginalStatus,
+		OriginalToValue:   issue.OriginalStatus,
+		FromValue:         fromStatus,
+		ToValue:           issue.Status,
+		CreatedDate:       timestamp,
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestApplyIssueEvent(t *testing.T) {
+	created := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	issue := &ticket.Issue{}
+	changelog := applyIssueEvent(issue, 1, &WebhookIssueEventReq{
+		Event:          ISSUE_CREATED,
+		IssueKey:       "BUG-1",
+		Title:          "crash on startup",
+		Type:           ticket.BUG,
+		Severity:       "critical",
+		OriginalStatus: "open",
+		AssigneeId:     "u1",
+	}, created)
+	assert.Equal(t, ticket.TODO, issue.Status)
+	assert.Equal(t, "webhook:1:u1", issue.AssigneeId)
+	assert.Equal(t, created, *issue.CreatedDate)
+	assert.Nil(t, issue.ResolutionDate)
+	assert.Equal(t, "open", changelog.OriginalToValue)
+
+	updated := created.Add(time.Hour)
+	changelog = applyIssueEvent(issue, 1, &WebhookIssueEventReq{
+		Event:    ISSUE_UPDATED,
+		IssueKey: "BUG-1",
+		Severity: "major",
+	}, updated)
+	assert.Nil(t, changelog)
+	assert.Equal(t, "major", issue.Severity)
+	assert.Equal(t, "crash on startup", issue.Title)
+	assert.Equal(t, updated, *issue.UpdatedDate)
+
+	closed := created.Add(2 * time.Hour)
+	changelog = applyIssueEvent(issue, 1, &WebhookIssueEventReq{
+		Event:          ISSUE_CLOSED,
+		IssueKey:       "BUG-1",
+		OriginalStatus: "fixed",
+	}, closed)
+	assert.Equal(t, ticket.DONE, issue.Status)
+	assert.Equal(t, closed, *issue.ResolutionDate)
+	assert.Equal(t, uint(120), *issue.LeadTimeMinutes)
+	assert.Equal(t, ticket.TODO, changelog.FromValue)
+	assert.Equal(t, ticket.DONE, changelog.ToValue)
+	assert.Equal(t, "webhook:1:BUG-1", changelog.IssueId)
+
+	applyIssueEvent(issue, 1, &WebhookIssueEventReq{
+		Event:    ISSUE_UPDATED,
+		IssueKey: "BUG-1",
+		Status:   ticket.IN_PROGRESS,
+	}, closed.Add(time.Hour))
+	assert.Nil(t, issue.ResolutionDate)
+	assert.Nil(t, issue.LeadTimeMinutes)
+}
+
+func TestSaveIssueEventOutOfOrder(t *testing.T) {
+	closed := time.Date(2026, 1, 1, 14, 0, 0, 0, time.UTC)
+	mockTx := new(mockdal.Transaction)
+	mockTx.On("First", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		issue := args.Get(0).(*ticket.Issue)
+		issue.Id = "webhook:1:BUG-1"
+		issue.Status = ticket.DONE
+		issue.UpdatedDate = &closed
+	}).Return(nil)
+
+	// the update sent before the issue was closed is delivered after the close
+	updated := closed.Add(-time.Hour)
+	err := saveIssueEvent(mockTx, 1, &WebhookIssueEventReq{
+		Event:     ISSUE_UPDATED,
+		IssueKey:  "BUG-1",
+		Status:    ticket.IN_PROGRESS,
+		Timestamp: &updated,
+	})
+	assert.Nil(t, err)
+	mockTx.AssertNotCalled(t, "CreateOrUpdate", mock.Anything, mock.Anything)
+
+	// the explicit updatedDate wins over the timestamp
+	err = saveIssueEvent(mockTx, 1, &WebhookIssueEventReq{
+		Event:       ISSUE_UPDATED,
+		IssueKey:    "BUG-1",
+		Status:      ticket.IN_PROGRESS,
+		Timestamp:   &closed,
+		UpdatedDate: &updated,
+	})
+	assert.Nil(t, err)
+	mockTx.AssertNotCalled(t, "CreateOrUpdate", mock.Anything, mock.Anything)
+}
+
+func TestIsStaleIssueEvent(t *testing.T) {
+	updated := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	event := &WebhookIssueEventReq{Event: ISSUE_UPDATED, IssueKey: "BUG-1"}
+	assert.False(t, isStaleIssueEvent(&ticket.Issue{}, event, updated.Add(-time.Hour)))
+	issue := &ticket.Issue{UpdatedDate: &updated}
+	assert.True(t, isStaleIssueEvent(issue, event, updated.Add(-time.Hour)))
+	assert.False(t, isStaleIssueEvent(issue, event, updated))
+	assert.False(t, isStaleIssueEvent(issue, event, updated.Add(time.Hour)))
+}
@@ -29,10 +29,7 @@ import (
 	"github.com/apache/incubator-devlake/core/models/domainlayer"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
 	"github.com/apache/incubator-devlake/core/plugin"
-	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"github.com/apache/incubator-devlake/plugins/webhook/models"
-
-	"github.com/go-playground/validator/v10"
 )
 
 type WebhookIssueRequest struct {
@@ -116,24 +113,15 @@ func postIssue(input *plugin.ApiResourceInput, err errors.Error, connection *mod
 	if err != nil {
 		return nil, err
 	}
-	// get request
-	request := &WebhookIssueRequest{}
-	err = helper.DecodeMapStruct(input.Body, request, true)
-	if err != nil {
-		return &plugin.ApiResourceOutput{Body: err.Error(), Status: http.StatusBadRequest}, nil
-	}
-	// validate
-	vld = validator.New()
-	err = errors.Convert(vld.Struct(request))
-	if err != nil {
-		return &plugin.ApiResourceOutput{Body: err.Error(), Status: http.StatusBadRequest}, nil
-	}
-	txHelper := dbhelper.NewTxHelper(basicRes, &err)
-	defer txHelper.End()
-	tx := txHelper.Begin()
+	return issueReceiver.Receive(input, connection.ID, connection.Secret)
+}
+
+// saveIssue saves the issue pushed to the connection, it is idempotent so the delivery can be replayed
+func saveIssue(tx dal.Transaction, connectionId uint64, payload interface{}) errors.Error {
+	request := payload.(*WebhookIssueRequest)
 	domainIssue := &ticket.Issue{
 		DomainEntity: domainlayer.DomainEntity{
-			Id: fmt.Sprintf("%s:%d:%s", "webhook", connection.ID, request.IssueKey),
+			Id: fmt.Sprintf("%s:%d:%s", "webhook", connectionId, request.IssueKey),
 		},
 		Url:                     request.Url,
 		IssueKey:                request.IssueKey,
@@ -165,16 +153,20 @@ func postIssue(input *plugin.ApiResourceInput, err errors.Error, connection *mod
 	}
 	// FIXME we have no idea about how to calculate domainIssue.TimeRemainingMinutes and domainIssue.TimeSpentMinutes.
 	if request.CreatorId != "" {
-		domainIssue.CreatorId = fmt.Sprintf("%s:%d:%s", "webhook", connection.ID, request.CreatorId)
+		domainIssue.CreatorId = fmt.Sprintf("%s:%d:%s", "webhook", connectionId, request.CreatorId)
 	}
 	if request.AssigneeId != "" {
-		domainIssue.AssigneeId = fmt.Sprintf("%s:%d:%s", "webhook", connection.ID, request.AssigneeId)
+		domainIssue.AssigneeId = fmt.Sprintf("%s:%d:%s", "webhook", connectionId, request.AssigneeId)
 	}
 	if request.ParentIssueKey != "" {
-		domainIssue.ParentIssueId = fmt.Sprintf("%s:%d:%s", "webhook", connection.ID, request.ParentIssueKey)
+		domainIssue.ParentIssueId = fmt.Sprintf("%s:%d:%s", "webhook", connectionId, request.ParentIssueKey)
 	}
+	return saveDomainIssue(tx, connectionId, domainIssue)
+}
 
-	domainBoardId := fmt.Sprintf("%s:%d", "webhook", connection.ID)
+// saveDomainIssue saves the issue along with the board of the connection and the incident if it is one
+func saveDomainIssue(tx dal.Transaction, connectionId uint64, domainIssue *ticket.Issue) errors.Error {
+	domainBoardId := fmt.Sprintf("%s:%d", "webhook", connectionId)
 
 	boardIssue := &ticket.BoardIssue{
 		BoardId: domainBoardId,
@@ -184,7 +176,7 @@ func postIssue(input *plugin.ApiResourceInput, err errors.Error, connection *mod
 	// check if board exists
 	count, err := tx.Count(dal.From(&ticket.Board{}), dal.Where("id = ?", domainBoardId))
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// only create board with domainBoard non-existent
@@ -196,36 +188,39 @@ func postIssue(input *plugin.ApiResourceInput, err errors.Error, connection *mod
 		}
 		err = tx.Create(domainBoard)
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
 	// save
 	err = tx.CreateOrUpdate(domainIssue)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	err = tx.CreateOrUpdate(boardIssue)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	if domainIssue.IsIncident() {
 		if err := saveIncidentRelatedRecordsFromIssue(tx, logger, domainBoardId, domainIssue); err != nil {
 			logger.Error(err, "failed to save incident related records")
-			return nil, errors.Convert(err)
+			return errors.Convert(err)
 		}
 	}
 
-	return &plugin.ApiResourceOutput{Body: nil, Status: http.StatusOK}, nil
+	return nil
 }
 
 // CloseIssue
 // @Summary set issue's status to DONE
 // @Description set issue's status to DONE
+// @Description If the connection has a secret, the request must carry its unix timestamp in the `X-Webhook-Timestamp` header,
+// @Description and be signed by the `X-Hub-Signature-256: sha256=<hmac>` header of `<timestamp>\n<method> <path>`. Stale requests are refused.
 // @Tags plugins/webhook
 // @Success 200  {string} noResponse ""
 // @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 401  {string} errcode.Error "Unauthorized"
 // @Failure 500  {string} errcode.Error "Internal Error"
 // @Router /plugins/webhook/:connectionId/issue/:issueKey/close [POST]
 func CloseIssue(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
@@ -237,9 +232,12 @@ func CloseIssue(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, erro
 // CloseIssueByName
 // @Summary set issue's status to DONE
 // @Description set issue's status to DONE
+// @Description If the connection has a secret, the request must carry its unix timestamp in the `X-Webhook-Timestamp` header,
+// @Description and be signed by the `X-Hub-Signature-256: sha256=<hmac>` header of `<timestamp>\n<method> <path>`. Stale requests are refused.
 // @Tags plugins/webhook
 // @Success 200  {string} noResponse ""
 // @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 401  {string} errcode.Error "Unauthorized"
 // @Failure 500  {string} errcode.Error "Internal Error"
 // @Router /plugins/webhook/by-name/:connectionName/issue/:issueKey/close [POST]
 func CloseIssueByName(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
@@ -252,6 +250,16 @@ func closeIssue(input *plugin.ApiResourceInput, err errors.Error, connection *mo
 	if err != nil {
 		return nil, err
 	}
+	// closing doesn't go through the receiver and has no body, the method and the path are signed instead
+	if connection.Secret != "" {
+		if input.Request == nil {
+			return nil, errors.Unauthorized.New("the request closing the issue must be signed")
+		}
+		request := input.Request.Method + " " + input.Request.URL.Path
+		if err := closeIssueSignature.Verify(input.Request.Header, []byte(request), connection.Secret); err != nil {
+			return nil, err
+		}
+	}
 
 	txHelper := dbhelper.NewTxHelper(basicRes, &err)
 	defer txHelper.End()
@@ -0,0 +1,64 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/webhook/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCloseIssueInput(path string, timestamp time.Time, signed string) *plugin.ApiResourceInput {
+	request := httptest.NewRequest(http.MethodPost, path, nil)
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	request.Header.Set("X-Webhook-Timestamp", ts)
+	request.Header.Set("X-Hub-Signature-256", api.SignWebhookBody([]byte(ts+"\n"+signed), "secret"))
+	return &plugin.ApiResourceInput{
+		Params:  map[string]string{"connectionId": "1", "issueKey": "BUG-1"},
+		Request: request,
+	}
+}
+
+func TestCloseIssueSignature(t *testing.T) {
+	path := "/plugins/webhook/1/issue/BUG-1/close"
+	header := newCloseIssueInput(path, time.Now(), "POST "+path).Request.Header
+	assert.Nil(t, closeIssueSignature.Verify(header, []byte("POST "+path), "secret"))
+
+	connection := &models.WebhookConnection{}
+	connection.ID = 1
+	connection.Secret = "secret"
+	for name, input := range map[string]*plugin.ApiResourceInput{
+		"unsigned":           {Params: map[string]string{"issueKey": "BUG-1"}},
+		"signed empty body":  newCloseIssueInput(path, time.Now(), ""),
+		"signed other issue": newCloseIssueInput(path, time.Now(), "POST /plugins/webhook/1/issue/BUG-2/close"),
+		"stale":              newCloseIssueInput(path, time.Now().Add(-time.Hour), "POST "+path),
+	} {
+		_, err := closeIssue(input, nil, connection)
+		if assert.NotNil(t, err, name) {
+			assert.Equal(t, errors.Unauthorized, err.GetType(), name)
+		}
+	}
+}
@@ -19,20 +19,15 @@ package api
 
 import (
 	"fmt"
-	"net/http"
 	"time"
 
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/log"
 
-	"github.com/apache/incubator-devlake/helpers/dbhelper"
-	"github.com/go-playground/validator/v10"
-
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models/domainlayer"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
 	"github.com/apache/incubator-devlake/core/plugin"
-	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"github.com/apache/incubator-devlake/plugins/webhook/models"
 )
 
@@ -108,27 +103,18 @@ func postPullRequests(input *plugin.ApiResourceInput, connection *models.Webhook
 	if err != nil {
 		return nil, err
 	}
-	// get request
-	request := &WebhookPullRequestReq{}
-	err = api.DecodeMapStruct(input.Body, request, true)
-	if err != nil {
-		return &plugin.ApiResourceOutput{Body: err.Error(), Status: http.StatusBadRequest}, nil
-	}
-	// validate
-	vld = validator.New()
-	err = errors.Convert(vld.Struct(request))
-	if err != nil {
-		return nil, errors.BadInput.Wrap(vld.Struct(request), `input json error`)
-	}
-	txHelper := dbhelper.NewTxHelper(basicRes, &err)
-	defer txHelper.End()
-	tx := txHelper.Begin()
-	if err := CreatePullRequest(connection, request, tx, logger); err != nil {
+	return pullRequestReceiver.Receive(input, connection.ID, connection.Secret)
+}
+
+// savePullRequest saves the pull request pushed to the connection, it is idempotent so the delivery can be replayed
+func savePullRequest(tx dal.Transaction, connectionId uint64, payload interface{}) errors.Error {
+	connection := &models.WebhookConnection{}
+	connection.ID = connectionId
+	if err := CreatePullRequest(connection, payload.(*WebhookPullRequestReq), tx, logger); err != nil {
 		logger.Error(err, "create pull requests")
-		return nil, err
+		return err
 	}
-
-	return &plugin.ApiResourceOutput{Body: nil, Status: http.StatusOK}, nil
+	return nil
 }
 
 func CreatePullRequest(connection *models.WebhookConnection, request *WebhookPullRequestReq, tx dal.Transaction, logger log.Logger) errors.Error {
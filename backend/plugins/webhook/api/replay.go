@@ -0,0 +1,86 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/webhook/models"
+)
+
+// ReplayIssues
+// @Summary replay the issues pushed to the webhook
+// @Description Handle the stored payloads again, all failed deliveries are replayed if no deliveryIds were given
+// @Tags plugins/webhook
+// @Param body body api.WebhookReplayInput false "json body"
+// @Success 200  {object} api.WebhookReplayOutput
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/webhook/connections/:connectionId/issues/replay [POST]
+func ReplayIssues(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return replay(input, issueReceiver)
+}
+
+// ReplayIssueEvents
+// @Summary replay the issue events pushed to the webhook
+// @Description Handle the stored payloads again, all failed deliveries are replayed if no deliveryIds were given
+// @Tags plugins/webhook
+// @Param body body api.WebhookReplayInput false "json body"
+// @Success 200  {object} api.WebhookReplayOutput
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/webhook/connections/:connectionId/issue_events/replay [POST]
+func ReplayIssueEvents(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return replay(input, issueEventReceiver)
+}
+
+// ReplayDeployments
+// @Summary replay the deployments pushed to the webhook
+// @Description Handle the stored payloads again, all failed deliveries are replayed if no deliveryIds were given
+// @Tags plugins/webhook
+// @Param body body api.WebhookReplayInput false "json body"
+// @Success 200  {object} api.WebhookReplayOutput
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/webhook/connections/:connectionId/deployments/replay [POST]
+func ReplayDeployments(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return replay(input, deploymentReceiver)
+}
+
+// ReplayPullRequests
+// @Summary replay the pull requests pushed to the webhook
+// @Description Handle the stored payloads again, all failed deliveries are replayed if no deliveryIds were given
+// @Tags plugins/webhook
+// @Param body body api.WebhookReplayInput false "json body"
+// @Success 200  {object} api.WebhookReplayOutput
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/webhook/connections/:connectionId/pull_requests/replay [POST]
+func ReplayPullRequests(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return replay(input, pullRequestReceiver)
+}
+
+func replay(input *plugin.ApiResourceInput, receiver *api.WebhookReceiver) (*plugin.ApiResourceOutput, errors.Error) {
+	connection := &models.WebhookConnection{}
+	err := connectionHelper.First(connection, input.Params)
+	if err != nil {
+		return nil, err
+	}
+	return receiver.PostReplay(input, connection.ID)
+}
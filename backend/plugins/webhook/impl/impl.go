@@ -57,6 +57,7 @@ func (p Webhook) Init(basicRes context.BasicRes) errors.Error {
 func (p Webhook) GetTablesInfo() []dal.Tabler {
 	return []dal.Tabler{
 		&models.WebhookConnection{},
+		&models.WebhookDeployment{},
 	}
 }
 
@@ -99,6 +100,21 @@ func (p Webhook) ApiResources() map[string]map[string]plugin.ApiResourceHandler
 		"connections/:connectionId/issue/:issueKey/close": {
 			"POST": api.CloseIssue,
 		},
+		"connections/:connectionId/issue_events": {
+			"POST": api.PostIssueEvents,
+		},
+		"connections/:connectionId/deployments/replay": {
+			"POST": api.ReplayDeployments,
+		},
+		"connections/:connectionId/pull_requests/replay": {
+			"POST": api.ReplayPullRequests,
+		},
+		"connections/:connectionId/issues/replay": {
+			"POST": api.ReplayIssues,
+		},
+		"connections/:connectionId/issue_events/replay": {
+			"POST": api.ReplayIssueEvents,
+		},
 		":connectionId/deployments": {
 			"POST": api.PostDeployments,
 		},
@@ -128,5 +144,8 @@ func (p Webhook) ApiResources() map[string]map[string]plugin.ApiResourceHandler
 		"connections/by-name/:connectionName/issue/:issueKey/close": {
 			"POST": api.CloseIssueByName,
 		},
+		"connections/by-name/:connectionName/issue_events": {
+			"POST": api.PostIssueEventsByName,
+		},
 	}
 }
@@ -0,0 +1,40 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// WebhookDeployment keeps the details of a pushed deployment that don't fit in cicd_deployments,
+// it shares the id of the cicd_deployments record
+type WebhookDeployment struct {
+	ConnectionId    uint64 `gorm:"primaryKey"`
+	DeploymentId    string `gorm:"primaryKey;type:varchar(255)"`
+	PipelineUrl     string
+	Approver        string `gorm:"type:varchar(255)"`
+	ArtifactName    string `gorm:"type:varchar(255)"`
+	ArtifactVersion string `gorm:"type:varchar(255)"`
+	ArtifactUrl     string
+	ArtifactDigest  string `gorm:"type:varchar(255)"`
+	common.NoPKModel
+}
+
+func (WebhookDeployment) TableName() string {
+	return "_tool_webhook_deployments"
+}
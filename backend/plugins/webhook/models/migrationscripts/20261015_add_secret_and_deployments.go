@@ -0,0 +1,60 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+	"github.com/apache/incubator-devlake/plugins/webhook/models/migrationscripts/archived"
+)
+
+type addSecretAndDeployments struct{}
+
+type webhookConnection20261015 struct {
+	Secret string
+}
+
+func (webhookConnection20261015) TableName() string {
+	return "_tool_webhook_connections"
+}
+
+func (*addSecretAndDeployments) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&webhookConnection20261015{},
+		&archived.WebhookDeployment{},
+	)
+}
+
+func (*addSecretAndDeployments) Down(basicRes context.BasicRes) errors.Error {
+	db := basicRes.GetDal()
+	err := db.DropTables(&archived.WebhookDeployment{})
+	if err != nil {
+		return err
+	}
+	return db.DropColumns(webhookConnection20261015{}.TableName(), "secret")
+}
+
+func (*addSecretAndDeployments) Version() uint64 {
+	return 20261015230000
+}
+
+func (*addSecretAndDeployments) Name() string {
+	return "add secret to _tool_webhook_connections and add _tool_webhook_deployments"
+}
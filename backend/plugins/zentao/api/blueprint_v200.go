@@ -18,8 +18,11 @@ limitations under the License.
 package api
 
 import (
+	"strings"
+
 	"github.com/apache/incubator-devlake/core/errors"
 	coreModels "github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
 	"github.com/apache/incubator-devlake/core/plugin"
@@ -39,7 +42,22 @@ func MakeDataSourcePipelinePlanV200(
 	if err != nil {
 		return nil, nil, err
 	}
-	scopeDetails, err := dsHelper.ScopeSrv.MapScopeDetails(connectionId, bpScopes)
+	// the products are referred to as products/{id} in the blueprints, the projects by their ids
+	var projectBpScopes, productBpScopes []*coreModels.BlueprintScope
+	for _, bpScope := range bpScopes {
+		if productId, ok := strings.CutPrefix(bpScope.ScopeId, models.PRODUCT_SCOPE_ID_PREFIX); ok {
+			productBpScope := *bpScope
+			productBpScope.ScopeId = productId
+			productBpScopes = append(productBpScopes, &productBpScope)
+		} else {
+			projectBpScopes = append(projectBpScopes, bpScope)
+		}
+	}
+	scopeDetails, err := dsHelper.ScopeSrv.MapScopeDetails(connectionId, projectBpScopes)
+	if err != nil {
+		return nil, nil, err
+	}
+	productScopeDetails, err := productDsHelper.ScopeSrv.MapScopeDetails(connectionId, productBpScopes)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -47,8 +65,17 @@ func MakeDataSourcePipelinePlanV200(
 	if err != nil {
 		return nil, nil, err
 	}
+	productPlan, err := makeProductPipelinePlanV200(subtaskMetas, productScopeDetails, connection)
+	if err != nil {
+		return nil, nil, err
+	}
+	plan = append(plan, productPlan...)
 	scopes, err := makeScopesV200(scopeDetails, connection)
-	return plan, scopes, err
+	if err != nil {
+		return nil, nil, err
+	}
+	productScopes := makeProductScopesV200(productScopeDetails, connection)
+	return plan, append(scopes, productScopes...), nil
 }
 
 func makePipelinePlanV200(
@@ -98,7 +125,60 @@ func makeScopesV200(
 		if utils.StringsContains(scopeConfig.Entities, plugin.DOMAIN_TYPE_TICKET) {
 			scopes = append(scopes, ticket.NewBoard(id, scope.Name))
 		}
+		if utils.StringsContains(scopeConfig.Entities, plugin.DOMAIN_TYPE_CICD) {
+			scopes = append(scopes, devops.NewCicdScope(id, scope.Name))
+		}
 	}
 
 	return scopes, nil
 }
+
+func makeProductPipelinePlanV200(
+	subtaskMetas []plugin.SubTaskMeta,
+	scopeDetails []*srvhelper.ScopeDetail[models.ZentaoProduct, models.ZentaoScopeConfig],
+	connection *models.ZentaoConnection,
+) (coreModels.PipelinePlan, errors.Error) {
+	// only the subtasks working on the products are run for them
+	var productSubtaskMetas []plugin.SubTaskMeta
+	for _, subtaskMeta := range subtaskMetas {
+		for _, productSubtaskMeta := range tasks.ProductSubTaskMetas {
+			if subtaskMeta.Name == productSubtaskMeta.Name {
+				productSubtaskMetas = append(productSubtaskMetas, subtaskMeta)
+				break
+			}
+		}
+	}
+	plan := make(coreModels.PipelinePlan, len(scopeDetails))
+	for i, scopeDetail := range scopeDetails {
+		scope, scopeConfig := scopeDetail.Scope, scopeDetail.ScopeConfig
+		task, err := helper.MakePipelinePlanTask(
+			"zentao",
+			productSubtaskMetas,
+			scopeConfig.Entities,
+			tasks.ZentaoOptions{
+				ConnectionId: connection.ID,
+				ProductId:    scope.Id,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+		plan[i] = coreModels.PipelineStage{task}
+	}
+	return plan, nil
+}
+
+func makeProductScopesV200(
+	scopeDetails []*srvhelper.ScopeDetail[models.ZentaoProduct, models.ZentaoScopeConfig],
+	connection *models.ZentaoConnection,
+) []plugin.Scope {
+	scopes := make([]plugin.Scope, 0, len(scopeDetails))
+	idgen := didgen.NewDomainIdGenerator(&models.ZentaoProduct{})
+	for _, scopeDetail := range scopeDetails {
+		scope, scopeConfig := scopeDetail.Scope, scopeDetail.ScopeConfig
+		if utils.StringsContains(scopeConfig.Entities, plugin.DOMAIN_TYPE_TICKET) {
+			scopes = append(scopes, ticket.NewBoard(idgen.Generate(connection.ID, scope.Id), scope.Name))
+		}
+	}
+	return scopes
+}
@@ -40,7 +40,7 @@ type ZentaoTestConnResponse struct {
 func testConnection(ctx context.Context, connection models.ZentaoConn) (*ZentaoTestConnResponse, errors.Error) {
 	// process input
 	if vld != nil {
-		if err := vld.Struct(connection); err != nil {
+		if err := connection.CustomValidate(&connection, vld); err != nil {
 			return nil, errors.Default.Wrap(err, "error validating target")
 		}
 	}
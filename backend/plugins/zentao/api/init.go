@@ -32,6 +32,10 @@ var dsHelper *api.DsHelper[models.ZentaoConnection, models.ZentaoProject, models
 var raProxy *api.DsRemoteApiProxyHelper[models.ZentaoConnection]
 var raScopeList *api.DsRemoteApiScopeListHelper[models.ZentaoConnection, models.ZentaoProject, ZentaoRemotePagination]
 
+// the products are scopes as well, they share the connections and scope configs with the projects
+var productDsHelper *api.DsHelper[models.ZentaoConnection, models.ZentaoProduct, models.ZentaoScopeConfig]
+var raProductScopeList *api.DsRemoteApiScopeListHelper[models.ZentaoConnection, models.ZentaoProduct, ZentaoRemotePagination]
+
 func Init(br context.BasicRes, p plugin.PluginMeta) {
 	vld = validator.New()
 	basicRes = br
@@ -48,6 +52,19 @@ func Init(br context.BasicRes, p plugin.PluginMeta) {
 		nil,
 		nil,
 	)
+	productDsHelper = api.NewDataSourceHelper[
+		models.ZentaoConnection, models.ZentaoProduct, models.ZentaoScopeConfig,
+	](
+		br,
+		p.Name(),
+		[]string{"name"},
+		func(c models.ZentaoConnection) models.ZentaoConnection {
+			return c.Sanitize()
+		},
+		nil,
+		nil,
+	)
 	raProxy = api.NewDsRemoteApiProxyHelper(dsHelper.ConnApi.ModelApiHelper)
 	raScopeList = api.NewDsRemoteApiScopeListHelper(raProxy, listZentaoRemoteScopes)
+	raProductScopeList = api.NewDsRemoteApiScopeListHelper(raProxy, listZentaoRemoteProducts)
 }
@@ -39,6 +39,12 @@ type ZentaoRemoteProjects struct {
 	Values []models.ZentaoProject `json:"projects"`
 }
 
+type ZentaoRemoteProducts struct {
+	ZentaoRemotePagination
+	Total  int                    `json:"total"`
+	Values []models.ZentaoProduct `json:"products"`
+}
+
 func listZentaoRemoteScopes(
 	connection *models.ZentaoConnection,
 	apiClient plugin.ApiClient,
@@ -90,6 +96,57 @@ func listZentaoRemoteScopes(
 	return
 }
 
+func listZentaoRemoteProducts(
+	connection *models.ZentaoConnection,
+	apiClient plugin.ApiClient,
+	groupId string,
+	page ZentaoRemotePagination,
+) (
+	children []dsmodels.DsRemoteApiScopeListEntry[models.ZentaoProduct],
+	nextPage *ZentaoRemotePagination,
+	err errors.Error,
+) {
+	if page.Page == 0 {
+		page.Page = 1
+	}
+	if page.Limit == 0 {
+		page.Limit = 20
+	}
+	// list products part
+	res, err := apiClient.Get("/products", url.Values{
+		"page":  {fmt.Sprintf("%d", page.Page)},
+		"limit": {fmt.Sprintf("%d", page.Limit)},
+	}, nil)
+	if err != nil {
+		return
+	}
+	// parse response body
+	resBody := &ZentaoRemoteProducts{}
+	err = api.UnmarshalResponse(res, resBody)
+	if err != nil {
+		return
+	}
+	// convert to dsmodels.DsRemoteApiScopeListEntry
+	for _, p := range resBody.Values {
+		tmpProduct := p
+		children = append(children, dsmodels.DsRemoteApiScopeListEntry[models.ZentaoProduct]{
+			Type:     api.RAS_ENTRY_TYPE_SCOPE,
+			Id:       fmt.Sprintf("%v", tmpProduct.Id),
+			Name:     tmpProduct.Name,
+			FullName: tmpProduct.Name,
+			Data:     &tmpProduct,
+		})
+	}
+	// next page
+	if (resBody.Page-1)*resBody.Limit+len(resBody.Values) < resBody.Total {
+		nextPage = &ZentaoRemotePagination{
+			Page:  page.Page + 1,
+			Limit: page.Limit,
+		}
+	}
+	return
+}
+
 // RemoteScopes list all available scope for users
 // @Summary list all available scope for users
 // @Description list all available scope for users
@@ -98,6 +155,7 @@ func listZentaoRemoteScopes(
 // @Param connectionId path int false "connection ID"
 // @Param groupId query string false "group ID"
 // @Param pageToken query string false "page Token"
+// @Param refresh query bool false "drop the cached remote scopes of the connection and fetch them again"
 // @Success 200  {object} api.RemoteScopesOutput
 // @Failure 400  {object} shared.ApiBody "Bad Request"
 // @Failure 500  {object} shared.ApiBody "Internal Error"
@@ -106,6 +164,23 @@ func RemoteScopes(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, er
 	return raScopeList.Get(input)
 }
 
+// RemoteProductScopes list all available products for users
+// @Summary list all available products for users
+// @Description list all available products for users
+// @Tags plugins/zentao
+// @Accept application/json
+// @Param connectionId path int false "connection ID"
+// @Param groupId query string false "group ID"
+// @Param pageToken query string false "page Token"
+// @Param refresh query bool false "drop the cached remote scopes of the connection and fetch them again"
+// @Success 200  {object} api.RemoteScopesOutput
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/zentao/connections/{connectionId}/remote-product-scopes [GET]
+func RemoteProductScopes(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return raProductScopeList.Get(input)
+}
+
 // @Summary Remote server API proxy
 // @Description Forward API requests to the specified remote server
 // @Param connectionId path int true "connection ID"
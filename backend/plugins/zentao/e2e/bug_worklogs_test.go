@@ -0,0 +1,65 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/zentao/impl"
+	"github.com/apache/incubator-devlake/plugins/zentao/models"
+	"github.com/apache/incubator-devlake/plugins/zentao/tasks"
+)
+
+func TestZentaoBugWorklogDataFlow(t *testing.T) {
+
+	var zentao impl.Zentao
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "zentao", zentao)
+
+	taskData := &tasks.ZentaoTaskData{
+		Options: &tasks.ZentaoOptions{
+			ConnectionId: 1,
+			ProjectId:    1,
+		},
+		ApiClient: getFakeAPIClient(),
+	}
+
+	// import _raw_zentao_api_bug_worklogs raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_zentao_api_bug_worklogs.csv",
+		"_raw_zentao_api_bug_worklogs")
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_zentao_accounts.csv", &models.ZentaoAccount{})
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_zentao_bugs.csv", &models.ZentaoBug{})
+
+	// verify worklogs extraction
+	dataflowTester.FlushTabler(&models.ZentaoWorklog{})
+	dataflowTester.Subtask(tasks.ExtractBugWorklogsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&models.ZentaoWorklog{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_zentao_bug_worklogs.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	// verify worklogs conversion, the efforts of the bugs of other projects are left out
+	dataflowTester.FlushTabler(&ticket.IssueWorklog{})
+	dataflowTester.Subtask(tasks.ConvertBugWorklogsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&ticket.IssueWorklog{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/issue_worklogs_bug.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+}
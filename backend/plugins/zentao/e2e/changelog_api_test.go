@@ -0,0 +1,82 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/zentao/impl"
+	"github.com/apache/incubator-devlake/plugins/zentao/models"
+	"github.com/apache/incubator-devlake/plugins/zentao/tasks"
+)
+
+func TestZentaoApiChangelogDataFlow(t *testing.T) {
+
+	var zentao impl.Zentao
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "zentao", zentao)
+
+	// no RemoteDb, the changelogs come from the actions collected from the api
+	taskData := &tasks.ZentaoTaskData{
+		Options: &tasks.ZentaoOptions{
+			ConnectionId: 1,
+			ProjectId:    48,
+		},
+		AccountCache: tasks.NewAccountCache(dataflowTester.Dal, 1),
+		ApiClient:    getFakeAPIClient(),
+	}
+
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_zentao_api_actions.csv",
+		"_raw_zentao_api_actions")
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_zentao_accounts.csv", &models.ZentaoAccount{})
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.ZentaoChangelog{})
+	dataflowTester.FlushTabler(&models.ZentaoChangelogDetail{})
+	dataflowTester.Subtask(tasks.ExtractApiChangelogMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&models.ZentaoChangelog{}, e2ehelper.TableOptions{
+		CSVRelPath: "./snapshot_tables/_tool_zentao_changelog_api.csv",
+		TargetFields: []string{
+			"connection_id", "id", "object_id", "object_type", "project", "execution", "actor", "action", "comment", "vision", "date",
+		},
+	})
+	dataflowTester.VerifyTableWithOptions(&models.ZentaoChangelogDetail{}, e2ehelper.TableOptions{
+		CSVRelPath:   "./snapshot_tables/_tool_zentao_changelog_detail_api.csv",
+		TargetFields: []string{"connection_id", "id", "changelog_id", "field", "old", "new", "diff"},
+	})
+
+	// verify conversion, the action without any history has no changelog
+	dataflowTester.FlushTabler(&ticket.IssueChangelogs{})
+	dataflowTester.FlushTabler(&ticket.IssueStatusChange{})
+	dataflowTester.Subtask(tasks.ConvertChangelogMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&ticket.IssueChangelogs{}, e2ehelper.TableOptions{
+		CSVRelPath: "./snapshot_tables/issue_changelogs_api.csv",
+		TargetFields: []string{
+			"id", "issue_id", "author_id", "author_name", "field_id", "field_name",
+			"original_from_value", "original_to_value", "from_value", "to_value", "created_date",
+		},
+	})
+	dataflowTester.VerifyTableWithOptions(&ticket.IssueStatusChange{}, e2ehelper.TableOptions{
+		CSVRelPath: "./snapshot_tables/issue_status_changes_api.csv",
+		TargetFields: []string{
+			"id", "issue_id", "author_id", "author_name", "from_status", "to_status",
+			"original_from_status", "original_to_status", "changed_date",
+		},
+	})
+}
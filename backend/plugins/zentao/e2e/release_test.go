@@ -0,0 +1,80 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/zentao/impl"
+	"github.com/apache/incubator-devlake/plugins/zentao/models"
+	"github.com/apache/incubator-devlake/plugins/zentao/tasks"
+)
+
+func TestZentaoReleaseDataFlow(t *testing.T) {
+
+	var zentao impl.Zentao
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "zentao", zentao)
+
+	taskData := &tasks.ZentaoTaskData{
+		Options: &tasks.ZentaoOptions{
+			ConnectionId: 1,
+			ProjectId:    48,
+		},
+		AccountCache: tasks.NewAccountCache(dataflowTester.Dal, 1),
+		ApiClient:    getFakeAPIClient(),
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_zentao_api_releases.csv",
+		"_raw_zentao_api_releases")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.ZentaoRelease{})
+	dataflowTester.Subtask(tasks.ExtractReleaseMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&models.ZentaoRelease{}, e2ehelper.TableOptions{
+		CSVRelPath: "./snapshot_tables/_tool_zentao_releases.csv",
+		TargetFields: []string{
+			"connection_id", "id", "project", "product", "name", "status", "created_by_id", "created_date", "date",
+			"released_date", "deleted",
+		},
+	})
+
+	// verify conversion, the release 2 without the created date falls back to the planned date and the release 3
+	// without any date is skipped
+	dataflowTester.FlushTabler(&models.ZentaoProject{})
+	dataflowTester.FlushTabler(&devops.CicdScope{})
+	dataflowTester.FlushTabler(&devops.CicdDeploymentCommit{})
+	dataflowTester.FlushTabler(&devops.CICDDeployment{})
+	dataflowTester.Subtask(tasks.ConvertReleaseMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&devops.CicdDeploymentCommit{}, e2ehelper.TableOptions{
+		CSVRelPath: "./snapshot_tables/cicd_deployment_commits_release.csv",
+		TargetFields: []string{
+			"id", "cicd_scope_id", "cicd_deployment_id", "name", "result", "status", "original_status", "environment",
+			"original_environment", "created_date", "finished_date", "display_title", "url",
+		},
+	})
+	dataflowTester.VerifyTableWithOptions(&devops.CICDDeployment{}, e2ehelper.TableOptions{
+		CSVRelPath: "./snapshot_tables/cicd_deployments_release.csv",
+		TargetFields: []string{
+			"id", "cicd_scope_id", "name", "result", "status", "original_status", "environment", "original_environment",
+			"created_date", "finished_date", "display_title", "url",
+		},
+	})
+}
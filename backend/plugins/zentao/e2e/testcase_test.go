@@ -0,0 +1,67 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/qa"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/zentao/impl"
+	"github.com/apache/incubator-devlake/plugins/zentao/models"
+	"github.com/apache/incubator-devlake/plugins/zentao/tasks"
+)
+
+func TestZentaoTestCaseDataFlow(t *testing.T) {
+
+	var zentao impl.Zentao
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "zentao", zentao)
+
+	// the testcase 11 is linked to both the projects 48 and 49
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_zentao_api_testcases.csv",
+		"_raw_zentao_api_testcases")
+	dataflowTester.FlushTabler(&models.ZentaoProject{})
+	dataflowTester.FlushTabler(&models.ZentaoTestCase{})
+	dataflowTester.FlushTabler(&qa.QaProject{})
+	dataflowTester.FlushTabler(&qa.QaTestCase{})
+	for _, projectId := range []int64{48, 49} {
+		taskData := &tasks.ZentaoTaskData{
+			Options: &tasks.ZentaoOptions{
+				ConnectionId: 1,
+				ProjectId:    projectId,
+			},
+			AccountCache: tasks.NewAccountCache(dataflowTester.Dal, 1),
+			ApiClient:    getFakeAPIClient(),
+		}
+		dataflowTester.Subtask(tasks.ExtractTestCaseMeta, taskData)
+		dataflowTester.Subtask(tasks.ConvertTestCaseMeta, taskData)
+	}
+
+	// each project keeps its own copy of the testcase
+	dataflowTester.VerifyTableWithOptions(&models.ZentaoTestCase{}, e2ehelper.TableOptions{
+		CSVRelPath: "./snapshot_tables/_tool_zentao_testcases.csv",
+		TargetFields: []string{
+			"connection_id", "project", "id", "product", "title", "type", "status", "opened_by_id", "opened_date", "deleted",
+		},
+	})
+	// the deleted testcase is left out
+	dataflowTester.VerifyTableWithOptions(&qa.QaTestCase{}, e2ehelper.TableOptions{
+		CSVRelPath:   "./snapshot_tables/qa_test_cases.csv",
+		TargetFields: []string{"id", "name", "create_time", "creator_id", "type", "qa_project_id"},
+	})
+}
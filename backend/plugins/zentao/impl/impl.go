@@ -92,6 +92,10 @@ func (p Zentao) GetTablesInfo() []dal.Tabler {
 		&models.ZentaoProductSummary{},
 		&models.ZentaoProjectStory{},
 		&models.ZentaoWorklog{},
+		&models.ZentaoTestCase{},
+		&models.ZentaoTestTask{},
+		&models.ZentaoTestRun{},
+		&models.ZentaoRelease{},
 	}
 }
 
@@ -110,6 +114,7 @@ func (p Zentao) ScopeConfig() dal.Tabler {
 func (p Zentao) SubTaskMetas() []plugin.SubTaskMeta {
 	return []plugin.SubTaskMeta{
 		tasks.ConvertProjectMeta,
+		tasks.ConvertProductMeta,
 
 		// both
 		tasks.CollectAccountMeta,
@@ -160,11 +165,32 @@ func (p Zentao) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.ConvertBugRepoCommitsMeta,
 
 		tasks.DBGetChangelogMeta,
+		tasks.CollectApiChangelogMeta,
+		tasks.ExtractApiChangelogMeta,
 		tasks.ConvertChangelogMeta,
 
 		tasks.CollectTaskWorklogsMeta,
 		tasks.ExtractTaskWorklogsMeta,
 		tasks.ConvertTaskWorklogsMeta,
+		tasks.CollectBugWorklogsMeta,
+		tasks.ExtractBugWorklogsMeta,
+		tasks.ConvertBugWorklogsMeta,
+		tasks.CollectStoryWorklogsMeta,
+		tasks.ExtractStoryWorklogsMeta,
+		tasks.ConvertStoryWorklogsMeta,
+		// test
+		tasks.CollectTestCaseMeta,
+		tasks.ExtractTestCaseMeta,
+		tasks.ConvertTestCaseMeta,
+		tasks.CollectTestTaskMeta,
+		tasks.ExtractTestTaskMeta,
+		tasks.CollectTestRunMeta,
+		tasks.ExtractTestRunMeta,
+		tasks.ConvertTestRunMeta,
+		// release
+		tasks.CollectReleaseMeta,
+		tasks.ExtractReleaseMeta,
+		tasks.ConvertReleaseMeta,
 	}
 }
 
@@ -262,14 +288,31 @@ func (p Zentao) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
 			"POST": api.TestExistingConnection,
 		},
 		"connections/:connectionId/scopes": {
-			"PUT": api.PutScopes,
-			"GET": api.GetScopes,
+			"PUT":    api.PutScopes,
+			"GET":    api.GetScopes,
+			"PATCH":  api.PatchScopes,
+			"DELETE": api.DeleteScopes,
+		},
+		"connections/:connectionId/scope-jobs": {
+			"GET": api.GetScopeJobs,
+		},
+		"connections/:connectionId/scope-jobs/:jobId": {
+			"GET": api.GetScopeJob,
 		},
 		"connections/:connectionId/scopes/:scopeId": {
 			"GET":    api.GetScope,
 			"PATCH":  api.PatchScope,
 			"DELETE": api.DeleteProjectScope,
 		},
+		"connections/:connectionId/product-scopes": {
+			"PUT": api.PutProductScopes,
+			"GET": api.GetProductScopes,
+		},
+		"connections/:connectionId/product-scopes/:scopeId": {
+			"GET":    api.GetProductScope,
+			"PATCH":  api.PatchProductScope,
+			"DELETE": api.DeleteProductScope,
+		},
 		"connections/:connectionId/scope-configs": {
 			"POST": api.PostScopeConfig,
 			"GET":  api.GetScopeConfigList,
@@ -285,12 +328,18 @@ func (p Zentao) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
 		"connections/:connectionId/remote-scopes": {
 			"GET": api.RemoteScopes,
 		},
+		"connections/:connectionId/remote-product-scopes": {
+			"GET": api.RemoteProductScopes,
+		},
 		"connections/:connectionId/proxy/*path": {
 			"GET": api.Proxy,
 		},
 		"scope-config/:scopeConfigId/projects": {
 			"GET": api.GetProjectsByScopeConfig,
 		},
+		"scope-config-schema": {
+			"GET": api.GetScopeConfigSchema,
+		},
 	}
 }
 
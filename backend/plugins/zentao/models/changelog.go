@@ -65,3 +65,28 @@ type ZentaoChangelogCom struct {
 	Changelog       *ZentaoChangelog
 	ChangelogDetail *ZentaoChangelogDetail
 }
+
+// ZentaoActionRes is an action listed in the details of the stories, tasks and bugs by the api, along with the
+// histories of the fields changed by the action
+type ZentaoActionRes struct {
+	Id         int64               `json:"id"`
+	ObjectType string              `json:"objectType"`
+	ObjectId   int64               `json:"objectID"`
+	Project    int64               `json:"project"`
+	Execution  int64               `json:"execution"`
+	Actor      string              `json:"actor"`
+	Action     string              `json:"action"`
+	Date       *common.Iso8601Time `json:"date"`
+	Comment    string              `json:"comment"`
+	Extra      interface{}         `json:"extra"`
+	Read       interface{}         `json:"read"`
+	Vision     string              `json:"vision"`
+	Efforted   interface{}         `json:"efforted"`
+	History    []struct {
+		Id    int64  `json:"id"`
+		Field string `json:"field"`
+		Old   string `json:"old"`
+		New   string `json:"new"`
+		Diff  string `json:"diff"`
+	} `json:"history"`
+}
@@ -25,11 +25,17 @@ import (
 
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/core/utils"
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/go-playground/validator/v10"
 )
 
 // PrepareApiClient fetches token from Zentao API for future requests
 func (connection ZentaoConn) PrepareApiClient(apiClient plugin.ApiClient) errors.Error {
+	// the personal token is sent along with every request by SetupAuthentication
+	if connection.AuthMethod == plugin.AUTH_METHOD_TOKEN {
+		return nil
+	}
 	// request for access token
 	tokenReqBody := &ApiAccessTokenRequest{
 		Account:  connection.Username,
@@ -65,7 +71,9 @@ func (connection ZentaoConn) PrepareApiClient(apiClient plugin.ApiClient) errors
 // ZentaoConn holds the essential information to connect to the Gitlab API
 type ZentaoConn struct {
 	helper.RestConnection `mapstructure:",squash"`
+	helper.MultiAuth      `mapstructure:",squash"`
 	helper.BasicAuth      `mapstructure:",squash"`
+	helper.AccessToken    `mapstructure:",squash"`
 
 	DbUrl          string `mapstructure:"dbUrl"  json:"dbUrl" gorm:"serializer:encdec"`
 	DbIdleConns    int    `json:"dbIdleConns" mapstructure:"dbIdleConns"`
@@ -73,6 +81,25 @@ type ZentaoConn struct {
 	DbMaxConns     int    `json:"dbMaxConns" mapstructure:"dbMaxConns"`
 }
 
+// SetupAuthentication sends the personal token in the Token header as zentao expects, the password is exchanged
+// for the token by PrepareApiClient
+func (connection *ZentaoConn) SetupAuthentication(req *http.Request) errors.Error {
+	if connection.AuthMethod == plugin.AUTH_METHOD_TOKEN {
+		req.Header.Set("Token", connection.Token)
+		return nil
+	}
+	return connection.BasicAuth.SetupAuthentication(req)
+}
+
+// CustomValidate validates the fields of the chosen auth method only, the connections without an auth method
+// authenticate with the username and password as they used to
+func (connection *ZentaoConn) CustomValidate(entity interface{}, v *validator.Validate) errors.Error {
+	if connection.AuthMethod == "" {
+		connection.AuthMethod = plugin.AUTH_METHOD_BASIC
+	}
+	return connection.MultiAuth.CustomValidate(entity, v)
+}
+
 func (connection ZentaoConn) GetHash() string {
 	// zentao's token will expire after about 24min, so api client cannot be cached.
 	return ""
@@ -80,6 +107,7 @@ func (connection ZentaoConn) GetHash() string {
 
 func (connection ZentaoConn) Sanitize() ZentaoConn {
 	connection.Password = ""
+	connection.AccessToken.Token = utils.SanitizeString(connection.AccessToken.Token)
 	if connection.DbUrl != "" {
 		connection.DbUrl = connection.SanitizeDbUrl()
 	}
@@ -122,6 +150,8 @@ func (connection ZentaoConnection) Sanitize() ZentaoConnection {
 
 func (connection *ZentaoConnection) MergeFromRequest(target *ZentaoConnection, body map[string]interface{}) error {
 	password := target.Password
+	token := target.Token
+	authMethod := target.AuthMethod
 	existedDBUrl := target.DbUrl
 	existedSanitizedConnectionDBUrl := target.Sanitize().DbUrl
 	if err := helper.DecodeMapStruct(body, target, true); err != nil {
@@ -132,6 +162,9 @@ func (connection *ZentaoConnection) MergeFromRequest(target *ZentaoConnection, b
 	if modifiedPassword == "" {
 		target.Password = password
 	}
+	if authMethod == target.AuthMethod && (target.Token == "" || target.Token == utils.SanitizeString(token)) {
+		target.Token = token
+	}
 
 	if existedDBUrl != "" && target.DbUrl != "" && existedSanitizedConnectionDBUrl == target.DbUrl {
 		target.DbUrl = existedDBUrl
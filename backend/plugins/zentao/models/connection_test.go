@@ -0,0 +1,48 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZentaoConn_SetupAuthentication(t *testing.T) {
+	connection := &ZentaoConn{
+		MultiAuth:   helper.MultiAuth{AuthMethod: plugin.AUTH_METHOD_TOKEN},
+		AccessToken: helper.AccessToken{Token: "personal-token"},
+	}
+	req, _ := http.NewRequest(http.MethodGet, "http://zentao/api.php/v1/user", nil)
+	assert.Nil(t, connection.SetupAuthentication(req))
+	assert.Equal(t, "personal-token", req.Header.Get("Token"))
+	assert.Empty(t, req.Header.Get("Authorization"))
+	// the token is used as is without asking the token endpoint
+	assert.Nil(t, connection.PrepareApiClient(nil))
+
+	connection = &ZentaoConn{
+		MultiAuth: helper.MultiAuth{AuthMethod: plugin.AUTH_METHOD_BASIC},
+		BasicAuth: helper.BasicAuth{Username: "admin", Password: "secret"},
+	}
+	req, _ = http.NewRequest(http.MethodGet, "http://zentao/api.php/v1/user", nil)
+	assert.Nil(t, connection.SetupAuthentication(req))
+	assert.Empty(t, req.Header.Get("Token"))
+}
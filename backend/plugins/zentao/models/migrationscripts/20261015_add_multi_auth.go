@@ -0,0 +1,61 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+type zentaoMultiAuth20261015 struct {
+	AuthMethod string `gorm:"type:varchar(20)"`
+	Token      string `gorm:"type:varchar(255)"`
+}
+
+func (zentaoMultiAuth20261015) TableName() string {
+	return "_tool_zentao_connections"
+}
+
+type addMultiAuth struct{}
+
+func (*addMultiAuth) Up(basicRes context.BasicRes) errors.Error {
+	err := migrationhelper.AutoMigrateTables(basicRes, &zentaoMultiAuth20261015{})
+	if err != nil {
+		return err
+	}
+	return basicRes.GetDal().UpdateColumn(
+		&zentaoMultiAuth20261015{},
+		"auth_method", plugin.AUTH_METHOD_BASIC,
+		dal.Where("auth_method IS NULL"),
+	)
+}
+
+func (*addMultiAuth) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropColumns(zentaoMultiAuth20261015{}.TableName(), "auth_method", "token")
+}
+
+func (*addMultiAuth) Version() uint64 {
+	return 20261015150000
+}
+
+func (*addMultiAuth) Name() string {
+	return "add multiauth to _tool_zentao_connections"
+}
@@ -0,0 +1,90 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+	zentaoArchived "github.com/apache/incubator-devlake/plugins/zentao/models/migrationscripts/archived"
+)
+
+type addProjectToTestCasePrimaryKey struct{}
+
+type zentaoTestCase20261016 struct {
+	archived.NoPKModel
+	ConnectionId   uint64 `gorm:"primaryKey;type:BIGINT  NOT NULL"`
+	Project        int64  `gorm:"primaryKey;type:BIGINT  NOT NULL;autoIncrement:false"`
+	ID             int64  `gorm:"primaryKey;type:BIGINT  NOT NULL;autoIncrement:false"`
+	Product        int64
+	Branch         int
+	Lib            int
+	Module         int
+	Story          int64
+	StoryVersion   int
+	Title          string
+	Precondition   string
+	Keywords       string
+	Pri            int
+	Type           string `gorm:"type:varchar(100)"`
+	Auto           string `gorm:"type:varchar(100)"`
+	Stage          string
+	Status         string `gorm:"type:varchar(100)"`
+	OpenedById     int64
+	OpenedDate     *time.Time
+	LastEditedById int64
+	LastEditedDate *time.Time
+	Version        int
+	FromBug        int64
+	LastRunnerId   int64
+	LastRunDate    *time.Time
+	LastRunResult  string `gorm:"type:varchar(100)"`
+	Deleted        bool
+}
+
+func (zentaoTestCase20261016) TableName() string {
+	return "_tool_zentao_testcases"
+}
+
+// Up recreates the table, the testcases are extracted again from the raw data on the next run
+func (*addProjectToTestCasePrimaryKey) Up(basicRes context.BasicRes) errors.Error {
+	err := basicRes.GetDal().DropTables(&zentaoArchived.ZentaoTestCase{})
+	if err != nil {
+		return err
+	}
+	return migrationhelper.AutoMigrateTables(basicRes, &zentaoTestCase20261016{})
+}
+
+func (*addProjectToTestCasePrimaryKey) Down(basicRes context.BasicRes) errors.Error {
+	err := basicRes.GetDal().DropTables(&zentaoTestCase20261016{})
+	if err != nil {
+		return err
+	}
+	return migrationhelper.AutoMigrateTables(basicRes, &zentaoArchived.ZentaoTestCase{})
+}
+
+func (*addProjectToTestCasePrimaryKey) Version() uint64 {
+	return 20261016120000
+}
+
+func (*addProjectToTestCasePrimaryKey) Name() string {
+	return "add project to the primary key of _tool_zentao_testcases"
+}
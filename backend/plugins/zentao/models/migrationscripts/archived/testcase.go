@@ -0,0 +1,104 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+)
+
+type ZentaoTestCase struct {
+	archived.NoPKModel
+	ConnectionId   uint64 `gorm:"primaryKey;type:BIGINT  NOT NULL"`
+	ID             int64  `json:"id" gorm:"primaryKey;type:BIGINT  NOT NULL;autoIncrement:false"`
+	Project        int64  `json:"project" gorm:"index"`
+	Product        int64  `json:"product"`
+	Branch         int    `json:"branch"`
+	Lib            int    `json:"lib"`
+	Module         int    `json:"module"`
+	Story          int64  `json:"story"`
+	StoryVersion   int    `json:"storyVersion"`
+	Title          string `json:"title"`
+	Precondition   string `json:"precondition"`
+	Keywords       string `json:"keywords"`
+	Pri            int    `json:"pri"`
+	Type           string `json:"type" gorm:"type:varchar(100)"`
+	Auto           string `json:"auto" gorm:"type:varchar(100)"`
+	Stage          string `json:"stage"`
+	Status         string `json:"status" gorm:"type:varchar(100)"`
+	OpenedById     int64
+	OpenedDate     *time.Time `json:"openedDate"`
+	LastEditedById int64
+	LastEditedDate *time.Time `json:"lastEditedDate"`
+	Version        int        `json:"version"`
+	FromBug        int64      `json:"fromBug"`
+	LastRunnerId   int64
+	LastRunDate    *time.Time `json:"lastRunDate"`
+	LastRunResult  string     `json:"lastRunResult" gorm:"type:varchar(100)"`
+	Deleted        bool       `json:"deleted"`
+}
+
+func (ZentaoTestCase) TableName() string {
+	return "_tool_zentao_testcases"
+}
+
+type ZentaoTestTask struct {
+	archived.NoPKModel
+	ConnectionId     uint64 `gorm:"primaryKey;type:BIGINT  NOT NULL"`
+	ID               int64  `json:"id" gorm:"primaryKey;type:BIGINT  NOT NULL;autoIncrement:false"`
+	Project          int64  `json:"project" gorm:"index"`
+	Product          int64  `json:"product"`
+	Execution        int64  `json:"execution"`
+	Build            string `json:"build"`
+	Name             string `json:"name"`
+	Type             string `json:"type"`
+	OwnerId          int64
+	Pri              int        `json:"pri"`
+	Begin            *time.Time `json:"begin"`
+	End              *time.Time `json:"end"`
+	RealFinishedDate *time.Time `json:"realFinishedDate"`
+	Status           string     `json:"status" gorm:"type:varchar(100)"`
+	CreatedById      int64
+	CreatedDate      *time.Time `json:"createdDate"`
+	Deleted          bool       `json:"deleted"`
+}
+
+func (ZentaoTestTask) TableName() string {
+	return "_tool_zentao_testtasks"
+}
+
+type ZentaoTestRun struct {
+	archived.NoPKModel
+	ConnectionId  uint64 `gorm:"primaryKey;type:BIGINT  NOT NULL"`
+	ID            int64  `json:"id" gorm:"primaryKey;type:BIGINT  NOT NULL;autoIncrement:false"`
+	Project       int64  `json:"project" gorm:"index"`
+	Task          int64  `json:"task" gorm:"index"`
+	Case          int64  `json:"case"`
+	Version       int    `json:"version"`
+	Title         string `json:"title"`
+	AssignedToId  int64
+	LastRunnerId  int64
+	LastRunDate   *time.Time `json:"lastRunDate"`
+	LastRunResult string     `json:"lastRunResult" gorm:"type:varchar(100)"`
+	Status        string     `json:"status" gorm:"type:varchar(100)"`
+}
+
+func (ZentaoTestRun) TableName() string {
+	return "_tool_zentao_testruns"
+}
@@ -34,5 +34,10 @@ func All() []plugin.MigrationScript {
 		new(dropTotalReal),
 		new(addWorklogs),
 		new(updateScopeConfig),
+		new(addTestTables),
+		new(addReleases),
+		new(addMultiAuth),
+		new(encryptConnectionProxy),
+		new(addProjectToTestCasePrimaryKey),
 	}
 }
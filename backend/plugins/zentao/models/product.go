@@ -18,6 +18,8 @@ limitations under the License.
 package models
 
 import (
+	"strconv"
+
 	"github.com/apache/incubator-devlake/core/models/common"
 )
 
@@ -65,43 +67,63 @@ type ZentaoProductRes struct {
 	CaseReview bool    `json:"caseReview" mapstructure:"caseReview"`
 }
 
+// PRODUCT_SCOPE_ID_PREFIX tells the products apart from the projects in the scopes of the blueprints
+const PRODUCT_SCOPE_ID_PREFIX = "products/"
+
 type ZentaoProduct struct {
-	common.NoPKModel `json:"-"`
-	ConnectionId     uint64 `json:"connectionId" mapstructure:"connectionId" gorm:"primaryKey;type:BIGINT  NOT NULL"`
-	Id               int64  `json:"id" mapstructure:"id" gorm:"primaryKey;type:BIGINT  NOT NULL;autoIncrement:false"`
-	Program          int    `json:"program" mapstructure:"program"`
-	Name             string `json:"name" mapstructure:"name"`
-	Code             string `json:"code" mapstructure:"code"`
-	Bind             string `json:"bind" mapstructure:"bind"`
-	Line             int    `json:"line" mapstructure:"line"`
-	Type             string `json:"type" mapstructure:"type"`
-	ProductType      string `json:"productType" mapstructure:"productType"`
-	Status           string `json:"status" mapstructure:"status"`
-	SubStatus        string `json:"subStatus" mapstructure:"subStatus"`
-	Description      string `json:"desc" mapstructure:"desc"`
-	POId             int64
-	QDId             int64
-	RDId             int64
-	Acl              string `json:"acl" mapstructure:"acl"`
-	Reviewer         string `json:"reviewer" mapstructure:"reviewer"`
-	CreatedById      int64
-	CreatedDate      *common.Iso8601Time `json:"createdDate" mapstructure:"createdDate"`
-	CreatedVersion   string              `json:"createdVersion" mapstructure:"createdVersion"`
-	OrderIn          int                 `json:"order" mapstructure:"order"`
-	Deleted          string              `json:"deleted" mapstructure:"deleted"`
-	Plans            int                 `json:"plans" mapstructure:"plans"`
-	Releases         int                 `json:"releases" mapstructure:"releases"`
-	Builds           int                 `json:"builds" mapstructure:"builds"`
-	Cases            int                 `json:"cases" mapstructure:"cases"`
-	Projects         int                 `json:"projects" mapstructure:"projects"`
-	Executions       int                 `json:"executions" mapstructure:"executions"`
-	Bugs             int                 `json:"bugs" mapstructure:"bugs"`
-	Docs             int                 `json:"docs" mapstructure:"docs"`
-	Progress         float64             `json:"progress" mapstructure:"progress"`
-	CaseReview       bool                `json:"caseReview" mapstructure:"caseReview"`
-	ScopeConfigId    uint64              `json:"scopeConfigId,omitempty" mapstructure:"scopeConfigId"`
+	common.Scope   `mapstructure:",squash"`
+	Id             int64  `json:"id" mapstructure:"id" gorm:"primaryKey;type:BIGINT  NOT NULL;autoIncrement:false"`
+	Program        int    `json:"program" mapstructure:"program"`
+	Name           string `json:"name" mapstructure:"name"`
+	Code           string `json:"code" mapstructure:"code"`
+	Bind           string `json:"bind" mapstructure:"bind"`
+	Line           int    `json:"line" mapstructure:"line"`
+	Type           string `json:"type" mapstructure:"type"`
+	ProductType    string `json:"productType" mapstructure:"productType"`
+	Status         string `json:"status" mapstructure:"status"`
+	SubStatus      string `json:"subStatus" mapstructure:"subStatus"`
+	Description    string `json:"desc" mapstructure:"desc"`
+	POId           int64
+	QDId           int64
+	RDId           int64
+	Acl            string `json:"acl" mapstructure:"acl"`
+	Reviewer       string `json:"reviewer" mapstructure:"reviewer"`
+	CreatedById    int64
+	CreatedDate    *common.Iso8601Time `json:"createdDate" mapstructure:"createdDate"`
+	CreatedVersion string              `json:"createdVersion" mapstructure:"createdVersion"`
+	OrderIn        int                 `json:"order" mapstructure:"order"`
+	Deleted        string              `json:"deleted" mapstructure:"deleted"`
+	Plans          int                 `json:"plans" mapstructure:"plans"`
+	Releases       int                 `json:"releases" mapstructure:"releases"`
+	Builds         int                 `json:"builds" mapstructure:"builds"`
+	Cases          int                 `json:"cases" mapstructure:"cases"`
+	Projects       int                 `json:"projects" mapstructure:"projects"`
+	Executions     int                 `json:"executions" mapstructure:"executions"`
+	Bugs           int                 `json:"bugs" mapstructure:"bugs"`
+	Docs           int                 `json:"docs" mapstructure:"docs"`
+	Progress       float64             `json:"progress" mapstructure:"progress"`
+	CaseReview     bool                `json:"caseReview" mapstructure:"caseReview"`
 }
 
 func (ZentaoProduct) TableName() string {
 	return "_tool_zentao_products"
 }
+
+func (p ZentaoProduct) ScopeId() string {
+	return PRODUCT_SCOPE_ID_PREFIX + strconv.FormatInt(p.Id, 10)
+}
+
+func (p ZentaoProduct) ScopeName() string {
+	return p.Name
+}
+
+func (p ZentaoProduct) ScopeFullName() string {
+	return p.Name
+}
+
+func (p ZentaoProduct) ScopeParams() interface{} {
+	return &ZentaoApiParams{
+		ConnectionId: p.ConnectionId,
+		ProductId:    p.Id,
+	}
+}
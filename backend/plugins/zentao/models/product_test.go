@@ -0,0 +1,37 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZentaoProduct_ScopeParams(t *testing.T) {
+	product := ZentaoProduct{Scope: common.Scope{ConnectionId: 1}, Id: 5}
+	assert.Equal(t, "products/5", product.ScopeId())
+	assert.Equal(t, `{"ConnectionId":1,"ProductId":5}`, plugin.MarshalScopeParams(product.ScopeParams()))
+
+	// the params of the projects stay the same as the ones of the collected raw data
+	project := ZentaoProject{Scope: common.Scope{ConnectionId: 1}, Id: 5}
+	assert.Equal(t, "5", project.ScopeId())
+	assert.Equal(t, `{"ConnectionId":1,"ProjectId":5}`, plugin.MarshalScopeParams(project.ScopeParams()))
+}
@@ -230,5 +230,6 @@ func (p ZentaoProject) ConvertApiScope() plugin.ToolLayerScope {
 
 type ZentaoApiParams struct {
 	ConnectionId uint64
-	ProjectId    int64
+	ProjectId    int64 `json:",omitempty"`
+	ProductId    int64 `json:",omitempty"`
 }
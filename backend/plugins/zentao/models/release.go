@@ -0,0 +1,69 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+const (
+	RELEASE_STATUS_WAIT      = "wait"
+	RELEASE_STATUS_NORMAL    = "normal"
+	RELEASE_STATUS_FAIL      = "fail"
+	RELEASE_STATUS_TERMINATE = "terminate"
+)
+
+type ZentaoReleaseRes struct {
+	ID           int64               `json:"id"`
+	Project      interface{}         `json:"project"`
+	Product      int64               `json:"product"`
+	Branch       interface{}         `json:"branch"`
+	Build        interface{}         `json:"build"`
+	Name         string              `json:"name"`
+	Marker       interface{}         `json:"marker"`
+	Date         *common.Iso8601Time `json:"date"`
+	ReleasedDate *common.Iso8601Time `json:"releasedDate"`
+	Desc         string              `json:"desc"`
+	Status       string              `json:"status"`
+	CreatedBy    *ApiAccount         `json:"createdBy"`
+	CreatedDate  *common.Iso8601Time `json:"createdDate"`
+	Deleted      bool                `json:"deleted"`
+}
+
+type ZentaoRelease struct {
+	common.NoPKModel
+	ConnectionId uint64              `gorm:"primaryKey;type:BIGINT  NOT NULL"`
+	ID           int64               `json:"id" gorm:"primaryKey;type:BIGINT  NOT NULL;autoIncrement:false"`
+	Project      int64               `json:"project" gorm:"index"`
+	Product      int64               `json:"product"`
+	Branch       string              `json:"branch"`
+	Build        string              `json:"build"`
+	Name         string              `json:"name"`
+	Marker       bool                `json:"marker"`
+	Date         *common.Iso8601Time `json:"date"`
+	ReleasedDate *common.Iso8601Time `json:"releasedDate"`
+	Desc         string              `json:"desc"`
+	Status       string              `json:"status" gorm:"type:varchar(100)"`
+	CreatedById  int64
+	CreatedDate  *common.Iso8601Time `json:"createdDate"`
+	Deleted      bool                `json:"deleted"`
+}
+
+func (ZentaoRelease) TableName() string {
+	return "_tool_zentao_releases"
+}
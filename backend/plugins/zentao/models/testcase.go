@@ -0,0 +1,88 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+type ZentaoTestCaseRes struct {
+	ID             int64               `json:"id"`
+	Project        int64               `json:"project"`
+	Product        int64               `json:"product"`
+	Branch         int                 `json:"branch"`
+	Lib            int                 `json:"lib"`
+	Module         int                 `json:"module"`
+	Story          int64               `json:"story"`
+	StoryVersion   int                 `json:"storyVersion"`
+	Title          string              `json:"title"`
+	Precondition   string              `json:"precondition"`
+	Keywords       string              `json:"keywords"`
+	Pri            int                 `json:"pri"`
+	Type           string              `json:"type"`
+	Auto           string              `json:"auto"`
+	Stage          string              `json:"stage"`
+	Status         string              `json:"status"`
+	OpenedBy       *ApiAccount         `json:"openedBy"`
+	OpenedDate     *common.Iso8601Time `json:"openedDate"`
+	LastEditedBy   *ApiAccount         `json:"lastEditedBy"`
+	LastEditedDate *common.Iso8601Time `json:"lastEditedDate"`
+	Version        int                 `json:"version"`
+	FromBug        int64               `json:"fromBug"`
+	LastRunner     *ApiAccount         `json:"lastRunner"`
+	LastRunDate    *common.Iso8601Time `json:"lastRunDate"`
+	LastRunResult  string              `json:"lastRunResult"`
+	Deleted        bool                `json:"deleted"`
+}
+
+// ZentaoTestCase is keyed by the project as well, a testcase of the product may be linked to several projects and
+// each of them keeps its own copy
+type ZentaoTestCase struct {
+	common.NoPKModel
+	ConnectionId   uint64 `gorm:"primaryKey;type:BIGINT  NOT NULL"`
+	Project        int64  `json:"project" gorm:"primaryKey;type:BIGINT  NOT NULL;autoIncrement:false"`
+	ID             int64  `json:"id" gorm:"primaryKey;type:BIGINT  NOT NULL;autoIncrement:false"`
+	Product        int64  `json:"product"`
+	Branch         int    `json:"branch"`
+	Lib            int    `json:"lib"`
+	Module         int    `json:"module"`
+	Story          int64  `json:"story"`
+	StoryVersion   int    `json:"storyVersion"`
+	Title          string `json:"title"`
+	Precondition   string `json:"precondition"`
+	Keywords       string `json:"keywords"`
+	Pri            int    `json:"pri"`
+	Type           string `json:"type" gorm:"type:varchar(100)"`
+	Auto           string `json:"auto" gorm:"type:varchar(100)"`
+	Stage          string `json:"stage"`
+	Status         string `json:"status" gorm:"type:varchar(100)"`
+	OpenedById     int64
+	OpenedDate     *common.Iso8601Time `json:"openedDate"`
+	LastEditedById int64
+	LastEditedDate *common.Iso8601Time `json:"lastEditedDate"`
+	Version        int                 `json:"version"`
+	FromBug        int64               `json:"fromBug"`
+	LastRunnerId   int64
+	LastRunDate    *common.Iso8601Time `json:"lastRunDate"`
+	LastRunResult  string              `json:"lastRunResult" gorm:"type:varchar(100)"`
+	Deleted        bool                `json:"deleted"`
+}
+
+func (ZentaoTestCase) TableName() string {
+	return "_tool_zentao_testcases"
+}
@@ -0,0 +1,101 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+type ZentaoTestTaskRes struct {
+	ID               int64               `json:"id"`
+	Project          int64               `json:"project"`
+	Product          int64               `json:"product"`
+	Execution        int64               `json:"execution"`
+	Build            interface{}         `json:"build"`
+	Name             string              `json:"name"`
+	Type             string              `json:"type"`
+	Owner            *ApiAccount         `json:"owner"`
+	Pri              int                 `json:"pri"`
+	Begin            *common.Iso8601Time `json:"begin"`
+	End              *common.Iso8601Time `json:"end"`
+	RealFinishedDate *common.Iso8601Time `json:"realFinishedDate"`
+	Status           string              `json:"status"`
+	CreatedBy        *ApiAccount         `json:"createdBy"`
+	CreatedDate      *common.Iso8601Time `json:"createdDate"`
+	Deleted          bool                `json:"deleted"`
+}
+
+// ZentaoTestTask is a round of testing (the testtask of zentao) running a set of test cases against a build
+type ZentaoTestTask struct {
+	common.NoPKModel
+	ConnectionId     uint64 `gorm:"primaryKey;type:BIGINT  NOT NULL"`
+	ID               int64  `json:"id" gorm:"primaryKey;type:BIGINT  NOT NULL;autoIncrement:false"`
+	Project          int64  `json:"project" gorm:"index"`
+	Product          int64  `json:"product"`
+	Execution        int64  `json:"execution"`
+	Build            string `json:"build"`
+	Name             string `json:"name"`
+	Type             string `json:"type"`
+	OwnerId          int64
+	Pri              int                 `json:"pri"`
+	Begin            *common.Iso8601Time `json:"begin"`
+	End              *common.Iso8601Time `json:"end"`
+	RealFinishedDate *common.Iso8601Time `json:"realFinishedDate"`
+	Status           string              `json:"status" gorm:"type:varchar(100)"`
+	CreatedById      int64
+	CreatedDate      *common.Iso8601Time `json:"createdDate"`
+	Deleted          bool                `json:"deleted"`
+}
+
+func (ZentaoTestTask) TableName() string {
+	return "_tool_zentao_testtasks"
+}
+
+type ZentaoTestRunRes struct {
+	ID            int64               `json:"id"`
+	Task          int64               `json:"task"`
+	Case          int64               `json:"case"`
+	Version       int                 `json:"version"`
+	Title         string              `json:"title"`
+	AssignedTo    *ApiAccount         `json:"assignedTo"`
+	LastRunner    *ApiAccount         `json:"lastRunner"`
+	LastRunDate   *common.Iso8601Time `json:"lastRunDate"`
+	LastRunResult string              `json:"lastRunResult"`
+	Status        string              `json:"status"`
+}
+
+// ZentaoTestRun is a test case run in a testtask, with the result of its last run
+type ZentaoTestRun struct {
+	common.NoPKModel
+	ConnectionId  uint64 `gorm:"primaryKey;type:BIGINT  NOT NULL"`
+	ID            int64  `json:"id" gorm:"primaryKey;type:BIGINT  NOT NULL;autoIncrement:false"`
+	Project       int64  `json:"project" gorm:"index"`
+	Task          int64  `json:"task" gorm:"index"`
+	Case          int64  `json:"case"`
+	Version       int    `json:"version"`
+	Title         string `json:"title"`
+	AssignedToId  int64
+	LastRunnerId  int64
+	LastRunDate   *common.Iso8601Time `json:"lastRunDate"`
+	LastRunResult string              `json:"lastRunResult" gorm:"type:varchar(100)"`
+	Status        string              `json:"status" gorm:"type:varchar(100)"`
+}
+
+func (ZentaoTestRun) TableName() string {
+	return "_tool_zentao_testruns"
+}
@@ -45,37 +45,59 @@ type collectBugInput struct {
 	ProjectId   int64
 	ProductId   int64
 	ExecutionId int64
+	Order       string
 }
 
 func CollectBug(taskCtx plugin.SubTaskContext) errors.Error {
 	data := taskCtx.GetData().(*ZentaoTaskData)
-	// project bug iterator
-	projectBugsIter := newIteratorFromSlice([]interface{}{
-		collectBugInput{
-			ProjectId: data.Options.ProjectId,
-			Path:      fmt.Sprintf("/projects/%d", data.Options.ProjectId),
-		},
+	apiCollector, err := api.NewStatefulApiCollector(api.RawDataSubTaskArgs{
+		Ctx:     taskCtx,
+		Options: data.Options,
+		Table:   RAW_BUG_TABLE,
 	})
-	// execution bug iterator
-	executionCursor, executionIterator, err := getExecutionIterator(taskCtx)
 	if err != nil {
 		return err
 	}
-	defer executionCursor.Close()
-	executionBugIter := newIteratorWrapper(executionIterator, func(arg interface{}) interface{} {
-		return &collectBugInput{
-			ExecutionId: arg.(*input).Id,
-			Path:        fmt.Sprintf("/executions/%d", arg.(*input).Id),
+	var iterators []api.Iterator
+	for _, order := range getCollectOrders(apiCollector) {
+		order := order
+		if data.Options.ProductId != 0 {
+			// product bug iterator
+			iterators = append(iterators, newIteratorFromSlice([]interface{}{
+				&collectBugInput{
+					ProductId: data.Options.ProductId,
+					Path:      fmt.Sprintf("/products/%d", data.Options.ProductId),
+					Order:     order,
+				},
+			}))
+			continue
 		}
-	})
+		// project bug iterator
+		projectBugsIter := newIteratorFromSlice([]interface{}{
+			&collectBugInput{
+				ProjectId: data.Options.ProjectId,
+				Path:      fmt.Sprintf("/projects/%d", data.Options.ProjectId),
+				Order:     order,
+			},
+		})
+		// execution bug iterator
+		executionCursor, executionIterator, err := getExecutionIterator(taskCtx)
+		if err != nil {
+			return err
+		}
+		defer executionCursor.Close()
+		executionBugIter := newIteratorWrapper(executionIterator, func(arg interface{}) interface{} {
+			return &collectBugInput{
+				ExecutionId: arg.(*input).Id,
+				Path:        fmt.Sprintf("/executions/%d", arg.(*input).Id),
+				Order:       order,
+			}
+		})
+		iterators = append(iterators, projectBugsIter, executionBugIter)
+	}
 
-	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
-		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
-			Ctx:     taskCtx,
-			Options: data.Options,
-			Table:   RAW_BUG_TABLE,
-		},
-		Input:       newIteratorConcator(projectBugsIter, executionBugIter),
+	err = apiCollector.InitCollector(api.ApiCollectorArgs{
+		Input:       newIteratorConcator(iterators...),
 		ApiClient:   data.ApiClient,
 		PageSize:    100,
 		UrlTemplate: "{{ .Input.Path }}/bugs",
@@ -84,9 +106,10 @@ func CollectBug(taskCtx plugin.SubTaskContext) errors.Error {
 			query.Set("page", fmt.Sprintf("%v", reqData.Pager.Page))
 			query.Set("limit", fmt.Sprintf("%v", reqData.Pager.Size))
 			query.Set("status", "all")
+			setCollectOrder(query, reqData.Input.(*collectBugInput).Order)
 			return query, nil
 		},
-		GetTotalPages: GetTotalPagesFromResponse,
+		GetTotalPages: getTotalPagesFunc(apiCollector),
 		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
 			var data struct {
 				Bugs []json.RawMessage `json:"bugs"`
@@ -98,12 +121,12 @@ func CollectBug(taskCtx plugin.SubTaskContext) errors.Error {
 			if err != nil {
 				return nil, errors.Default.Wrap(err, "error reading endpoint response by Zentao bug collector")
 			}
-			return data.Bugs, nil
+			return filterChangedItems(apiCollector, res, data.Bugs)
 		},
 	})
 	if err != nil {
 		return err
 	}
 
-	return collector.Execute()
+	return apiCollector.Execute()
 }
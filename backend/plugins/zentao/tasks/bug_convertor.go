@@ -47,18 +47,23 @@ func ConvertBug(taskCtx plugin.SubTaskContext) errors.Error {
 	bugIdGen := didgen.NewDomainIdGenerator(&models.ZentaoBug{})
 	accountIdGen := didgen.NewDomainIdGenerator(&models.ZentaoAccount{})
 	executionIdGen := didgen.NewDomainIdGenerator(&models.ZentaoExecution{})
-	boardIdGen := didgen.NewDomainIdGenerator(&models.ZentaoProduct{})
+	boardId := getBoardId(data)
 	stdTypeMappings := getStdTypeMappings(data)
-	if data.Options.ProjectId != 0 {
-		boardIdGen = didgen.NewDomainIdGenerator(&models.ZentaoProject{})
-	}
+	stdStatusMappings := getBugStatusMapping(data)
 
 	storyIdGen := didgen.NewDomainIdGenerator(&models.ZentaoStory{})
-	cursor, err := db.Cursor(
+	clauses := []dal.Clause{
 		dal.From(&models.ZentaoBug{}),
 		dal.Where(`project = ? and
 			connection_id = ?`, data.Options.ProjectId, data.Options.ConnectionId),
-	)
+	}
+	if data.Options.ProductId != 0 {
+		clauses = []dal.Clause{
+			dal.From(&models.ZentaoBug{}),
+			dal.Where(`product = ? and connection_id = ?`, data.Options.ProductId, data.Options.ConnectionId),
+		}
+	}
+	cursor, err := db.Cursor(clauses...)
 	if err != nil {
 		return err
 	}
@@ -79,7 +84,7 @@ func ConvertBug(taskCtx plugin.SubTaskContext) errors.Error {
 				},
 				IssueKey:        strconv.FormatInt(toolEntity.ID, 10),
 				Title:           toolEntity.Title,
-				Type:            toolEntity.StdType,
+				Type:            getStdType(stdTypeMappings, toolEntity.StdType, "bug", toolEntity.Type),
 				OriginalType:    "bug",
 				OriginalStatus:  toolEntity.Status,
 				ResolutionDate:  toolEntity.ClosedDate.ToNullableTime(),
@@ -88,13 +93,10 @@ func ConvertBug(taskCtx plugin.SubTaskContext) errors.Error {
 				Priority:        getPriority(toolEntity.Pri),
 				CreatorName:     toolEntity.OpenedByName,
 				AssigneeName:    toolEntity.AssignedToName,
-				Severity:        string(rune(toolEntity.Severity)),
+				Severity:        strconv.Itoa(toolEntity.Severity),
 				Url:             convertIssueURL(toolEntity.Url, "bug", toolEntity.ID),
 				OriginalProject: getOriginalProject(data),
-				Status:          toolEntity.StdStatus,
-			}
-			if mappingType, ok := stdTypeMappings[domainEntity.OriginalType]; ok && mappingType != "" {
-				domainEntity.Type = mappingType
+				Status:          getStdStatus(stdStatusMappings, toolEntity.StdStatus, toolEntity.Status),
 			}
 			if toolEntity.Story != 0 {
 				domainEntity.ParentIssueId = storyIdGen.Generate(data.Options.ConnectionId, toolEntity.Story)
@@ -125,7 +127,7 @@ func ConvertBug(taskCtx plugin.SubTaskContext) errors.Error {
 			}
 
 			domainBoardIssue := &ticket.BoardIssue{
-				BoardId: boardIdGen.Generate(data.Options.ConnectionId, data.Options.ProjectId),
+				BoardId: boardId,
 				IssueId: domainEntity.Id,
 			}
 			if toolEntity.Execution != 0 {
@@ -64,10 +64,14 @@ func ExtractBug(taskCtx plugin.SubTaskContext) errors.Error {
 				return nil, errors.Default.WrapRaw(err)
 			}
 			data.Bugs[res.ID] = struct{}{}
+			project := data.Options.ProjectId
+			if data.Options.ProductId != 0 {
+				project = res.Project
+			}
 			bug := &models.ZentaoBug{
 				ConnectionId:   data.Options.ConnectionId,
 				ID:             res.ID,
-				Project:        data.Options.ProjectId,
+				Project:        project,
 				Product:        res.Product,
 				Injection:      res.Injection,
 				Identify:       res.Identify,
@@ -145,18 +149,10 @@ func ExtractBug(taskCtx plugin.SubTaskContext) errors.Error {
 			if bug.StdType == "" {
 				bug.StdType = ticket.BUG
 			}
-			if len(statusMappings) != 0 {
-				if stdStatus, ok := statusMappings[bug.Status]; ok {
-					bug.StdStatus = stdStatus
-				} else {
-					bug.StdStatus = bug.Status
-				}
-			} else {
-				bug.StdStatus = ticket.GetStatus(&ticket.StatusRule{
-					Done:    []string{"resolved"},
-					Default: ticket.IN_PROGRESS,
-				}, bug.Status)
-			}
+			bug.StdStatus = getStdStatus(statusMappings, ticket.GetStatus(&ticket.StatusRule{
+				Done:    []string{"resolved"},
+				Default: ticket.IN_PROGRESS,
+			}, bug.Status), bug.Status)
 
 			results := make([]interface{}, 0)
 			results = append(results, bug)
@@ -0,0 +1,53 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/zentao/models"
+)
+
+var ConvertBugWorklogsMeta = plugin.SubTaskMeta{
+	Name:             "convertBugWorklogs",
+	EntryPoint:       ConvertBugWorklogs,
+	EnabledByDefault: true,
+	Description:      "convert Zentao bug worklogs",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func ConvertBugWorklogs(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*ZentaoTaskData)
+	// the efforts logged on the bugs are not always attached to the project, pick them up by the bugs of the project
+	clauses := []dal.Clause{
+		dal.Select("_tool_zentao_worklogs.*"),
+		dal.From(&models.ZentaoWorklog{}),
+		dal.Join(`LEFT JOIN _tool_zentao_bugs ON
+			_tool_zentao_bugs.id = _tool_zentao_worklogs.object_id
+				AND _tool_zentao_bugs.connection_id = _tool_zentao_worklogs.connection_id`),
+		dal.Where(
+			"_tool_zentao_worklogs.connection_id = ? AND _tool_zentao_bugs.project = ? AND _tool_zentao_worklogs.object_type = ?",
+			data.Options.ConnectionId,
+			data.Options.ProjectId,
+			"bug",
+		),
+	}
+	return convertWorklogs(taskCtx, RAW_BUG_WORKLOGS_TABLE, clauses, didgen.NewDomainIdGenerator(&models.ZentaoBug{}))
+}
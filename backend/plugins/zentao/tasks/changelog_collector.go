@@ -0,0 +1,157 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/zentao/models"
+)
+
+const RAW_ACTION_TABLE = "zentao_api_actions"
+
+var _ plugin.SubTaskEntryPoint = CollectApiChangelog
+
+var CollectApiChangelogMeta = plugin.SubTaskMeta{
+	Name:             "collectApiChangelog",
+	EntryPoint:       CollectApiChangelog,
+	EnabledByDefault: true,
+	Description:      "collect the actions of the stories, tasks and bugs from Zentao api when the Zentao database is not available",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+type changelogInput struct {
+	Id         int64
+	ObjectType string
+	Path       string
+}
+
+func CollectApiChangelog(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*ZentaoTaskData)
+	// the changelogs are read from the database by DBGetActionHistory
+	if data.RemoteDb != nil {
+		return nil
+	}
+	db := taskCtx.GetDal()
+	apiCollector, err := api.NewStatefulApiCollector(api.RawDataSubTaskArgs{
+		Ctx:     taskCtx,
+		Options: data.Options,
+		Table:   RAW_ACTION_TABLE,
+	})
+	if err != nil {
+		return err
+	}
+
+	objects := []struct {
+		objectType string
+		path       string
+		clauses    []dal.Clause
+	}{
+		{
+			objectType: "story",
+			path:       "stories",
+			clauses: []dal.Clause{
+				dal.Select("_tool_zentao_project_stories.story_id AS id"),
+				dal.From(&models.ZentaoProjectStory{}),
+				dal.Join(`LEFT JOIN _tool_zentao_stories ON
+						_tool_zentao_project_stories.story_id = _tool_zentao_stories.id
+							AND _tool_zentao_project_stories.connection_id = _tool_zentao_stories.connection_id`),
+				dal.Where(`_tool_zentao_project_stories.project_id = ? and
+			_tool_zentao_project_stories.connection_id = ?`, data.Options.ProjectId, data.Options.ConnectionId),
+			},
+		},
+		{
+			objectType: "task",
+			path:       "tasks",
+			clauses: []dal.Clause{
+				dal.Select("id"),
+				dal.From(&models.ZentaoTask{}),
+				dal.Where("project = ? AND connection_id = ?", data.Options.ProjectId, data.Options.ConnectionId),
+			},
+		},
+		{
+			objectType: "bug",
+			path:       "bugs",
+			clauses: []dal.Clause{
+				dal.Select("id"),
+				dal.From(&models.ZentaoBug{}),
+				dal.Where("project = ? AND connection_id = ?", data.Options.ProjectId, data.Options.ConnectionId),
+			},
+		},
+	}
+	var iterators []api.Iterator
+	for _, object := range objects {
+		object := object
+		clauses := object.clauses
+		if apiCollector.IsIncremental() && apiCollector.GetSince() != nil {
+			clauses = append(clauses, dal.Where("last_edited_date IS NOT NULL AND last_edited_date > ?", apiCollector.GetSince()))
+		}
+		cursor, err := db.Cursor(clauses...)
+		if err != nil {
+			return err
+		}
+		defer cursor.Close()
+		iterator, err := api.NewDalCursorIterator(db, cursor, reflect.TypeOf(input{}))
+		if err != nil {
+			return err
+		}
+		iterators = append(iterators, newIteratorWrapper(iterator, func(arg interface{}) interface{} {
+			return &changelogInput{
+				Id:         arg.(*input).Id,
+				ObjectType: object.objectType,
+				Path:       fmt.Sprintf("/%s/%d", object.path, arg.(*input).Id),
+			}
+		}))
+	}
+
+	err = apiCollector.InitCollector(api.ApiCollectorArgs{
+		Input:       newIteratorConcator(iterators...),
+		ApiClient:   data.ApiClient,
+		UrlTemplate: "{{ .Input.Path }}",
+		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+			return nil, nil
+		},
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			var data struct {
+				Actions []json.RawMessage `json:"actions"`
+			}
+			err := api.UnmarshalResponse(res, &data)
+			if errors.Is(err, api.ErrEmptyResponse) {
+				return nil, nil
+			}
+			if err != nil {
+				return nil, errors.Default.Wrap(err, "error reading endpoint response by Zentao changelog collector")
+			}
+			return data.Actions, nil
+		},
+		AfterResponse: ignoreHTTPStatus404,
+	})
+	if err != nil {
+		return err
+	}
+
+	return apiCollector.Execute()
+}
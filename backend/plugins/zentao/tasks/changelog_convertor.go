@@ -75,6 +75,11 @@ func ConvertChangelog(taskCtx plugin.SubTaskContext) errors.Error {
 	storyIdGen := didgen.NewDomainIdGenerator(&models.ZentaoStory{})
 	taskIdGen := didgen.NewDomainIdGenerator(&models.ZentaoTask{})
 	bugIdGen := didgen.NewDomainIdGenerator(&models.ZentaoBug{})
+	statusMappings := map[string]map[string]string{
+		"story": getStoryStatusMapping(data),
+		"task":  getTaskStatusMapping(data),
+		"bug":   getBugStatusMapping(data),
+	}
 	cn := models.ZentaoChangelog{}.TableName()
 	cdn := models.ZentaoChangelogDetail{}.TableName()
 	an := models.ZentaoAccount{}.TableName()
@@ -163,6 +168,14 @@ func ConvertChangelog(taskCtx plugin.SubTaskContext) errors.Error {
 					}
 				}
 			}
+			if domainCl.FieldName == "status" {
+				domainCl.FromValue = getChangelogStdStatus(statusMappings[cl.ObjectType], cl.ObjectType, cl.Old)
+				domainCl.ToValue = getChangelogStdStatus(statusMappings[cl.ObjectType], cl.ObjectType, cl.New)
+				return []interface{}{
+					domainCl,
+					ticket.NewIssueStatusChange(domainCl),
+				}, nil
+			}
 
 			return []interface{}{
 				domainCl,
@@ -176,3 +189,33 @@ func ConvertChangelog(taskCtx plugin.SubTaskContext) errors.Error {
 
 	return convertor.Execute()
 }
+
+// getChangelogStdStatus maps a status recorded in the changelog to the standard status, using the same
+// rules as the extractor of the object type
+func getChangelogStdStatus(statusMappings map[string]string, objectType string, status string) string {
+	if status == "" {
+		return ""
+	}
+	if stdStatus, ok := statusMappings[status]; ok && stdStatus != "" {
+		return stdStatus
+	}
+	switch objectType {
+	case "task":
+		return ticket.GetStatus(&ticket.StatusRule{
+			Done:    []string{"done", "closed", "cancel"},
+			Todo:    []string{"wait"},
+			Default: ticket.IN_PROGRESS,
+		}, status)
+	case "bug":
+		return ticket.GetStatus(&ticket.StatusRule{
+			Done:    []string{"resolved"},
+			Default: ticket.IN_PROGRESS,
+		}, status)
+	default:
+		return ticket.GetStatus(&ticket.StatusRule{
+			Done:    []string{"closed"},
+			Todo:    []string{"draft"},
+			Default: ticket.IN_PROGRESS,
+		}, status)
+	}
+}
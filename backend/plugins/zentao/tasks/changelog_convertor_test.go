@@ -0,0 +1,52 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+)
+
+func Test_getChangelogStdStatus(t *testing.T) {
+	tests := []struct {
+		name           string
+		statusMappings map[string]string
+		objectType     string
+		status         string
+		want           string
+	}{
+		{"no status", nil, "task", "", ""},
+		{"task todo", nil, "task", "wait", ticket.TODO},
+		{"task in progress", nil, "task", "doing", ticket.IN_PROGRESS},
+		{"task cancelled", nil, "task", "cancel", ticket.DONE},
+		{"bug resolved", nil, "bug", "resolved", ticket.DONE},
+		{"bug active", nil, "bug", "active", ticket.IN_PROGRESS},
+		{"story draft", nil, "story", "draft", ticket.TODO},
+		{"story closed", nil, "story", "closed", ticket.DONE},
+		{"mapped status", map[string]string{"doing": ticket.DONE}, "task", "doing", ticket.DONE},
+		{"unmapped status falls back to the default rules", map[string]string{"doing": ticket.DONE}, "task", "wait", ticket.TODO},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getChangelogStdStatus(tt.statusMappings, tt.objectType, tt.status); got != tt.want {
+				t.Errorf("getChangelogStdStatus() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
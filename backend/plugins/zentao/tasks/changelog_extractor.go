@@ -0,0 +1,101 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/zentao/models"
+	"github.com/spf13/cast"
+)
+
+var _ plugin.SubTaskEntryPoint = ExtractApiChangelog
+
+var ExtractApiChangelogMeta = plugin.SubTaskMeta{
+	Name:             "extractApiChangelog",
+	EntryPoint:       ExtractApiChangelog,
+	EnabledByDefault: true,
+	Description:      "extract Zentao changelogs from the actions collected by collectApiChangelog",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func ExtractApiChangelog(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*ZentaoTaskData)
+	if data.RemoteDb != nil {
+		return nil
+	}
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx:     taskCtx,
+			Options: data.Options,
+			Table:   RAW_ACTION_TABLE,
+		},
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			res := &models.ZentaoActionRes{}
+			err := json.Unmarshal(row.Data, res)
+			if err != nil {
+				return nil, errors.Default.WrapRaw(err)
+			}
+			object := &changelogInput{}
+			err = json.Unmarshal(row.Input, object)
+			if err != nil {
+				return nil, errors.Default.WrapRaw(err)
+			}
+			changelog := &models.ZentaoChangelog{
+				ConnectionId: data.Options.ConnectionId,
+				Id:           res.Id,
+				ObjectId:     object.Id,
+				ObjectType:   object.ObjectType,
+				// the stories belong to products, keep them along with the project being collected
+				Project:   data.Options.ProjectId,
+				Execution: res.Execution,
+				Actor:     res.Actor,
+				Action:    res.Action,
+				Extra:     cast.ToString(res.Extra),
+				Vision:    res.Vision,
+				Comment:   res.Comment,
+				Efforted:  cast.ToString(res.Efforted),
+				Read:      cast.ToString(res.Read),
+			}
+			if res.Date != nil {
+				changelog.Date = res.Date.ToTime()
+			}
+			results := []interface{}{changelog}
+			for _, history := range res.History {
+				results = append(results, &models.ZentaoChangelogDetail{
+					ConnectionId: data.Options.ConnectionId,
+					Id:           history.Id,
+					ChangelogId:  res.Id,
+					Field:        history.Field,
+					Old:          history.Old,
+					New:          history.New,
+					Diff:         history.Diff,
+				})
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
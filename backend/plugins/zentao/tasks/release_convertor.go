@@ -0,0 +1,147 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/zentao/models"
+)
+
+var _ plugin.SubTaskEntryPoint = ConvertRelease
+
+var ConvertReleaseMeta = plugin.SubTaskMeta{
+	Name:             "convertRelease",
+	EntryPoint:       ConvertRelease,
+	EnabledByDefault: true,
+	Description:      "convert Zentao releases into domain layer table cicd_deployments and cicd_deployment_commits",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+// ConvertRelease takes every release of the project as a production deployment, so the deployment frequency could
+// be measured without a CI/CD plugin. Zentao doesn't know the commits of the releases, the deployment commits are
+// created without the commit sha for the DORA metrics, which are computed from cicd_deployment_commits
+func ConvertRelease(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*ZentaoTaskData)
+	db := taskCtx.GetDal()
+	logger := taskCtx.GetLogger()
+	// the cicd scope shares the id with the board of the zentao project
+	cicdScopeId := didgen.NewDomainIdGenerator(&models.ZentaoProject{}).Generate(data.Options.ConnectionId, data.Options.ProjectId)
+	project := &models.ZentaoProject{}
+	err := db.First(project, dal.Where("connection_id = ? AND id = ?", data.Options.ConnectionId, data.Options.ProjectId))
+	if err != nil && !db.IsErrorNotFound(err) {
+		return err
+	}
+	err = db.CreateOrUpdate(devops.NewCicdScope(cicdScopeId, project.Name))
+	if err != nil {
+		return err
+	}
+	homePage, getZentaoHomePageErr := getZentaoHomePage(data.ApiClient.GetEndpoint())
+	if getZentaoHomePageErr != nil {
+		logger.Error(getZentaoHomePageErr, "get zentao homepage")
+		return errors.Default.WrapRaw(getZentaoHomePageErr)
+	}
+
+	cursor, err := db.Cursor(
+		dal.From(&models.ZentaoRelease{}),
+		dal.Where("connection_id = ? AND project = ? AND deleted = ?", data.Options.ConnectionId, data.Options.ProjectId, false),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	releaseIdGen := didgen.NewDomainIdGenerator(&models.ZentaoRelease{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx:     taskCtx,
+			Options: data.Options,
+			Table:   RAW_RELEASE_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.ZentaoRelease{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			release := inputRow.(*models.ZentaoRelease)
+			releasedAt := getReleasedDate(release)
+			var createdAt time.Time
+			if release.CreatedDate != nil && !release.CreatedDate.ToTime().IsZero() {
+				createdAt = release.CreatedDate.ToTime()
+			} else if releasedAt != nil {
+				createdAt = *releasedAt
+			} else {
+				// a deployment without any date would be counted on the day of the collection
+				logger.Warn(nil, "skip the zentao release %d without any date", release.ID)
+				return nil, nil
+			}
+			deploymentCommit := &devops.CicdDeploymentCommit{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: releaseIdGen.Generate(release.ConnectionId, release.ID),
+				},
+				CicdScopeId: cicdScopeId,
+				Name:        release.Name,
+				Result: devops.GetResult(&devops.ResultRule{
+					Success: []string{models.RELEASE_STATUS_NORMAL},
+					Failure: []string{models.RELEASE_STATUS_FAIL, models.RELEASE_STATUS_TERMINATE},
+					Default: devops.RESULT_DEFAULT,
+				}, release.Status),
+				Status: devops.GetStatus(&devops.StatusRule{
+					Done:       []string{models.RELEASE_STATUS_NORMAL, models.RELEASE_STATUS_FAIL, models.RELEASE_STATUS_TERMINATE},
+					InProgress: []string{models.RELEASE_STATUS_WAIT},
+					Default:    devops.STATUS_OTHER,
+				}, release.Status),
+				OriginalStatus:      release.Status,
+				Environment:         devops.PRODUCTION,
+				OriginalEnvironment: devops.PRODUCTION,
+				TaskDatesInfo: devops.TaskDatesInfo{
+					CreatedDate: createdAt,
+				},
+				DisplayTitle: release.Name,
+				Url:          fmt.Sprintf("%s/projectrelease-view-%d.html", homePage, release.ID),
+			}
+			if deploymentCommit.Status == devops.STATUS_DONE {
+				deploymentCommit.FinishedDate = releasedAt
+			}
+			deploymentCommit.CicdDeploymentId = deploymentCommit.Id
+			return []interface{}{deploymentCommit, deploymentCommit.ToDeployment()}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+// getReleasedDate returns the time the release was published, or the planned release date if it's not recorded
+func getReleasedDate(release *models.ZentaoRelease) *time.Time {
+	if release.ReleasedDate != nil && !release.ReleasedDate.ToTime().IsZero() {
+		return release.ReleasedDate.ToNullableTime()
+	}
+	if release.Date != nil && !release.Date.ToTime().IsZero() {
+		return release.Date.ToNullableTime()
+	}
+	return nil
+}
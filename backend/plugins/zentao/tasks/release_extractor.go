@@ -0,0 +1,80 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/spf13/cast"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/zentao/models"
+)
+
+var _ plugin.SubTaskEntryPoint = ExtractRelease
+
+var ExtractReleaseMeta = plugin.SubTaskMeta{
+	Name:             "extractRelease",
+	EntryPoint:       ExtractRelease,
+	EnabledByDefault: true,
+	Description:      "extract Zentao release",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func ExtractRelease(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*ZentaoTaskData)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx:     taskCtx,
+			Options: data.Options,
+			Table:   RAW_RELEASE_TABLE,
+		},
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			res := &models.ZentaoReleaseRes{}
+			err := json.Unmarshal(row.Data, res)
+			if err != nil {
+				return nil, errors.Default.WrapRaw(err)
+			}
+			release := &models.ZentaoRelease{
+				ConnectionId: data.Options.ConnectionId,
+				ID:           res.ID,
+				Project:      data.Options.ProjectId,
+				Product:      res.Product,
+				Branch:       cast.ToString(res.Branch),
+				Build:        cast.ToString(res.Build),
+				Name:         res.Name,
+				Marker:       cast.ToBool(res.Marker),
+				Date:         res.Date,
+				ReleasedDate: res.ReleasedDate,
+				Desc:         res.Desc,
+				Status:       res.Status,
+				CreatedById:  data.AccountCache.getAccountIDFromApiAccount(res.CreatedBy),
+				CreatedDate:  res.CreatedDate,
+				Deleted:      res.Deleted,
+			}
+			return []interface{}{release}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
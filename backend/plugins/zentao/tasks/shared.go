@@ -18,6 +18,7 @@ limitations under the License.
 package tasks
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -25,9 +26,12 @@ import (
 	"reflect"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"github.com/apache/incubator-devlake/plugins/zentao/models"
@@ -50,6 +54,71 @@ func GetTotalPagesFromResponse(res *http.Response, args *api.ApiCollectorArgs) (
 	return pages, nil
 }
 
+// the orders to collect the items changed since the previous collection in, the items never edited have no
+// lastEditedDate and are sorted to the end, so the newly opened ones are collected in the order of openedDate
+var incrementalCollectOrders = []string{"lastEditedDate_desc", "openedDate_desc"}
+
+// zentao returns the times in the timezone of the server without the offset, so the incremental collection looks
+// back a little further to not miss any change
+const incrementalCollectLookBack = 24 * time.Hour
+
+// getCollectOrders returns the orders to collect the items in, the full collection needs no particular order
+func getCollectOrders(collector *api.StatefulApiCollector) []string {
+	if collector.IsIncremental() {
+		return incrementalCollectOrders
+	}
+	return []string{""}
+}
+
+// getTotalPagesFunc returns GetTotalPagesFromResponse for the full collection only, the incremental collection
+// fetches the pages one after another until the first unchanged item
+func getTotalPagesFunc(collector *api.StatefulApiCollector) func(res *http.Response, args *api.ApiCollectorArgs) (int, errors.Error) {
+	if collector.IsIncremental() {
+		return nil
+	}
+	return GetTotalPagesFromResponse
+}
+
+// setCollectOrder sets the order of the items of the incremental collection into the query
+func setCollectOrder(query url.Values, order string) {
+	if order != "" {
+		query.Set("order", order)
+	}
+}
+
+// filterChangedItems keeps the items changed since the previous collection out of a page sorted in the order of the
+// request, and finishes the collection on the first unchanged item since the following ones are all older
+func filterChangedItems(collector *api.StatefulApiCollector, res *http.Response, items []json.RawMessage) ([]json.RawMessage, errors.Error) {
+	order := res.Request.URL.Query().Get("order")
+	if order == "" || !collector.IsIncremental() || collector.GetSince() == nil {
+		return items, nil
+	}
+	return filterItemsChangedSince(items, strings.TrimSuffix(order, "_desc"), collector.GetSince().Add(-incrementalCollectLookBack))
+}
+
+// filterItemsChangedSince returns the leading items whose field is after since, along with api.ErrFinishCollect
+// if any item is left out
+func filterItemsChangedSince(items []json.RawMessage, field string, since time.Time) ([]json.RawMessage, errors.Error) {
+	for i, item := range items {
+		var fields map[string]json.RawMessage
+		err := json.Unmarshal(item, &fields)
+		if err != nil {
+			return nil, errors.Default.Wrap(err, "error decoding zentao item")
+		}
+		changedAt := &common.Iso8601Time{}
+		if value, ok := fields[field]; ok {
+			err = json.Unmarshal(value, changedAt)
+			if err != nil {
+				return nil, errors.Default.Wrap(err, fmt.Sprintf("error decoding %s of zentao item", field))
+			}
+		}
+		if !changedAt.ToTime().After(since) {
+			return items[:i], api.ErrFinishCollect
+		}
+	}
+	return items, nil
+}
+
 func getAccountId(account *models.ZentaoAccount) int64 {
 	if account != nil {
 		return account.ID
@@ -80,8 +149,16 @@ func getPriority(pri int) string {
 	*/
 }
 
+// getBoardId returns the id of the domain board of the project or the product being collected
+func getBoardId(data *ZentaoTaskData) string {
+	if data.Options.ProductId != 0 {
+		return didgen.NewDomainIdGenerator(&models.ZentaoProduct{}).Generate(data.Options.ConnectionId, data.Options.ProductId)
+	}
+	return didgen.NewDomainIdGenerator(&models.ZentaoProject{}).Generate(data.Options.ConnectionId, data.Options.ProjectId)
+}
+
 func getOriginalProject(data *ZentaoTaskData) string {
-	if data.Options.ProjectId != 0 {
+	if data.Options.ProjectId != 0 || data.Options.ProductId != 0 {
 		return data.ProjectName
 	}
 	return ""
@@ -147,6 +224,29 @@ func getStdTypeMappings(data *ZentaoTaskData) map[string]string {
 	return stdTypeMappings
 }
 
+// getStdType returns the standard type of the object by the type mappings, the mapping of the zentao type (e.g.
+// codeerror) takes precedence over the one of the object (e.g. bug), and the defaultType is used if none is mapped.
+func getStdType(stdTypeMappings map[string]string, defaultType string, objectType string, zentaoType string) string {
+	for _, originalType := range []string{zentaoType, objectType} {
+		if stdType, ok := stdTypeMappings[originalType]; ok && stdType != "" {
+			return stdType
+		}
+	}
+	return defaultType
+}
+
+// getStdStatus returns the standard status mapped from the first of the original values found in the status
+// mappings, the defaultStatus is used if none of them is mapped so that the customized values not configured yet
+// still end up in TODO, IN_PROGRESS or DONE.
+func getStdStatus(stdStatusMappings map[string]string, defaultStatus string, originalValues ...string) string {
+	for _, originalValue := range originalValues {
+		if stdStatus, ok := stdStatusMappings[originalValue]; ok && stdStatus != "" {
+			return stdStatus
+		}
+	}
+	return defaultStatus
+}
+
 // parseRepoUrl parses a repository URL and returns the host, namespace, and repository name.
 func parseRepoUrl(repoUrl string) (string, string, string, error) {
 	parsedUrl, err := url.Parse(repoUrl)
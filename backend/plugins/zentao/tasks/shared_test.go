@@ -18,8 +18,15 @@ limitations under the License.
 package tasks
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/zentao/models"
 )
 
 func Test_convertIssueURL(t *testing.T) {
@@ -195,3 +202,93 @@ func Test_getZentaoWebURL(t *testing.T) {
 		})
 	}
 }
+
+func Test_getTestRunStatus(t *testing.T) {
+	tests := []struct {
+		lastRunResult  string
+		testTaskStatus string
+		want           string
+	}{
+		{"pass", "done", "SUCCESS"},
+		{"fail", "doing", "FAILED"},
+		{"blocked", "done", "FAILED"},
+		{"", "doing", "IN_PROGRESS"},
+		{"n/a", "wait", "PENDING"},
+	}
+	for _, tt := range tests {
+		if got := getTestRunStatus(tt.lastRunResult, tt.testTaskStatus); got != tt.want {
+			t.Errorf("getTestRunStatus(%s, %s) = %v, want %v", tt.lastRunResult, tt.testTaskStatus, got, tt.want)
+		}
+	}
+}
+
+func Test_getReleasedDate(t *testing.T) {
+	date := &common.Iso8601Time{Time: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)}
+	releasedDate := &common.Iso8601Time{Time: time.Date(2026, 10, 3, 12, 0, 0, 0, time.UTC)}
+	if got := getReleasedDate(&models.ZentaoRelease{Date: date, ReleasedDate: releasedDate}); !got.Equal(releasedDate.Time) {
+		t.Errorf("getReleasedDate() = %v, want %v", got, releasedDate.Time)
+	}
+	if got := getReleasedDate(&models.ZentaoRelease{Date: date, ReleasedDate: &common.Iso8601Time{}}); !got.Equal(date.Time) {
+		t.Errorf("getReleasedDate() = %v, want %v", got, date.Time)
+	}
+	if got := getReleasedDate(&models.ZentaoRelease{}); got != nil {
+		t.Errorf("getReleasedDate() = %v, want nil", got)
+	}
+}
+
+func Test_filterItemsChangedSince(t *testing.T) {
+	items := []json.RawMessage{
+		json.RawMessage(`{"id":3,"lastEditedDate":"2026-10-03T08:00:00Z"}`),
+		json.RawMessage(`{"id":2,"lastEditedDate":"2026-10-02T08:00:00Z"}`),
+		json.RawMessage(`{"id":1,"lastEditedDate":null}`),
+	}
+	got, err := filterItemsChangedSince(items, "lastEditedDate", time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC))
+	if !errors.Is(err, api.ErrFinishCollect) || len(got) != 2 {
+		t.Errorf("filterItemsChangedSince() = %d items, %v, want 2 items, %v", len(got), err, api.ErrFinishCollect)
+	}
+	got, err = filterItemsChangedSince(items[:2], "lastEditedDate", time.Date(2026, 10, 2, 12, 0, 0, 0, time.UTC))
+	if !errors.Is(err, api.ErrFinishCollect) || len(got) != 1 {
+		t.Errorf("filterItemsChangedSince() = %d items, %v, want 1 item, %v", len(got), err, api.ErrFinishCollect)
+	}
+	got, err = filterItemsChangedSince(items[:2], "lastEditedDate", time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil || len(got) != 2 {
+		t.Errorf("filterItemsChangedSince() = %d items, %v, want 2 items, nil", len(got), err)
+	}
+}
+
+func Test_getStdStatus(t *testing.T) {
+	mappings := map[string]string{"active": "DONE", "verified": "DONE", "testing": "IN_PROGRESS", "empty": ""}
+	tests := []struct {
+		originalValues []string
+		want           string
+	}{
+		{[]string{"verified", "active"}, "DONE"},
+		{[]string{"developing", "testing"}, "IN_PROGRESS"},
+		{[]string{"custom"}, "TODO"},
+		{[]string{"empty"}, "TODO"},
+		{nil, "TODO"},
+	}
+	for _, tt := range tests {
+		if got := getStdStatus(mappings, "TODO", tt.originalValues...); got != tt.want {
+			t.Errorf("getStdStatus(%v) = %v, want %v", tt.originalValues, got, tt.want)
+		}
+	}
+}
+
+func Test_getStdType(t *testing.T) {
+	mappings := map[string]string{"codeerror": "CODE_ERROR", "bug": "INCIDENT"}
+	tests := []struct {
+		objectType string
+		zentaoType string
+		want       string
+	}{
+		{"bug", "codeerror", "CODE_ERROR"},
+		{"bug", "config", "INCIDENT"},
+		{"task", "devel", "TASK"},
+	}
+	for _, tt := range tests {
+		if got := getStdType(mappings, "TASK", tt.objectType, tt.zentaoType); got != tt.want {
+			t.Errorf("getStdType(%s, %s) = %v, want %v", tt.objectType, tt.zentaoType, got, tt.want)
+		}
+	}
+}
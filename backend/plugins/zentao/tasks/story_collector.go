@@ -45,38 +45,59 @@ type storyInput struct {
 	ProjectId   int64
 	ProductId   int64
 	ExecutionId int64
+	Order       string
 }
 
 func CollectStory(taskCtx plugin.SubTaskContext) errors.Error {
 	data := taskCtx.GetData().(*ZentaoTaskData)
-	// project iterator
-	projectStoryIter := newIteratorFromSlice([]interface{}{
-		&storyInput{
-			ProjectId: data.Options.ProjectId,
-			Path:      fmt.Sprintf("/projects/%d", data.Options.ProjectId),
-		},
+	apiCollector, err := api.NewStatefulApiCollector(api.RawDataSubTaskArgs{
+		Ctx:     taskCtx,
+		Options: data.Options,
+		Table:   RAW_STORY_TABLE,
 	})
-
-	// execution iterator
-	executionCursor, executionIterator, err := getExecutionIterator(taskCtx)
 	if err != nil {
 		return err
 	}
-	defer executionCursor.Close()
-	executionStoryIter := newIteratorWrapper(executionIterator, func(arg interface{}) interface{} {
-		return &storyInput{
-			ExecutionId: arg.(*input).Id,
-			Path:        fmt.Sprintf("/executions/%d", arg.(*input).Id),
+	var iterators []api.Iterator
+	for _, order := range getCollectOrders(apiCollector) {
+		order := order
+		if data.Options.ProductId != 0 {
+			// product iterator
+			iterators = append(iterators, newIteratorFromSlice([]interface{}{
+				&storyInput{
+					ProductId: data.Options.ProductId,
+					Path:      fmt.Sprintf("/products/%d", data.Options.ProductId),
+					Order:     order,
+				},
+			}))
+			continue
 		}
-	})
+		// project iterator
+		projectStoryIter := newIteratorFromSlice([]interface{}{
+			&storyInput{
+				ProjectId: data.Options.ProjectId,
+				Path:      fmt.Sprintf("/projects/%d", data.Options.ProjectId),
+				Order:     order,
+			},
+		})
+		// execution iterator
+		executionCursor, executionIterator, err := getExecutionIterator(taskCtx)
+		if err != nil {
+			return err
+		}
+		defer executionCursor.Close()
+		executionStoryIter := newIteratorWrapper(executionIterator, func(arg interface{}) interface{} {
+			return &storyInput{
+				ExecutionId: arg.(*input).Id,
+				Path:        fmt.Sprintf("/executions/%d", arg.(*input).Id),
+				Order:       order,
+			}
+		})
+		iterators = append(iterators, projectStoryIter, executionStoryIter)
+	}
 
-	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
-		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
-			Ctx:     taskCtx,
-			Options: data.Options,
-			Table:   RAW_STORY_TABLE,
-		},
-		Input:       newIteratorConcator(projectStoryIter, executionStoryIter),
+	err = apiCollector.InitCollector(api.ApiCollectorArgs{
+		Input:       newIteratorConcator(iterators...),
 		ApiClient:   data.ApiClient,
 		PageSize:    100,
 		UrlTemplate: "{{ .Input.Path }}/stories",
@@ -85,9 +106,10 @@ func CollectStory(taskCtx plugin.SubTaskContext) errors.Error {
 			query.Set("page", fmt.Sprintf("%v", reqData.Pager.Page))
 			query.Set("limit", fmt.Sprintf("%v", reqData.Pager.Size))
 			query.Set("status", "allstory")
+			setCollectOrder(query, reqData.Input.(*storyInput).Order)
 			return query, nil
 		},
-		GetTotalPages: GetTotalPagesFromResponse,
+		GetTotalPages: getTotalPagesFunc(apiCollector),
 		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
 			var data struct {
 				Story []json.RawMessage `json:"stories"`
@@ -99,12 +121,12 @@ func CollectStory(taskCtx plugin.SubTaskContext) errors.Error {
 			if err != nil {
 				return nil, errors.Default.Wrap(err, "error reading endpoint response by Zentao story collector")
 			}
-			return data.Story, nil
+			return filterChangedItems(apiCollector, res, data.Story)
 		},
 	})
 	if err != nil {
 		return err
 	}
 
-	return collector.Execute()
+	return apiCollector.Execute()
 }
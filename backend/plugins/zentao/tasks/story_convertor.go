@@ -45,17 +45,25 @@ func ConvertStory(taskCtx plugin.SubTaskContext) errors.Error {
 	data := taskCtx.GetData().(*ZentaoTaskData)
 	db := taskCtx.GetDal()
 	storyIdGen := didgen.NewDomainIdGenerator(&models.ZentaoStory{})
-	boardIdGen := didgen.NewDomainIdGenerator(&models.ZentaoProject{})
+	boardId := getBoardId(data)
 	accountIdGen := didgen.NewDomainIdGenerator(&models.ZentaoAccount{})
 	stdTypeMappings := getStdTypeMappings(data)
-	cursor, err := db.Cursor(
+	stdStatusMappings := getStoryStatusMapping(data)
+	clauses := []dal.Clause{
 		dal.From(&models.ZentaoStory{}),
 		dal.Join(`LEFT JOIN _tool_zentao_project_stories ON
 						_tool_zentao_project_stories.story_id = _tool_zentao_stories.id
 							AND _tool_zentao_project_stories.connection_id = _tool_zentao_stories.connection_id`),
 		dal.Where(`_tool_zentao_project_stories.project_id = ? and
 			_tool_zentao_project_stories.connection_id = ?`, data.Options.ProjectId, data.Options.ConnectionId),
-	)
+	}
+	if data.Options.ProductId != 0 {
+		clauses = []dal.Clause{
+			dal.From(&models.ZentaoStory{}),
+			dal.Where(`product = ? and connection_id = ?`, data.Options.ProductId, data.Options.ConnectionId),
+		}
+	}
+	cursor, err := db.Cursor(clauses...)
 	if err != nil {
 		return err
 	}
@@ -77,7 +85,7 @@ func ConvertStory(taskCtx plugin.SubTaskContext) errors.Error {
 				},
 				IssueKey:                strconv.FormatInt(toolEntity.ID, 10),
 				Title:                   toolEntity.Title,
-				Type:                    toolEntity.StdType,
+				Type:                    getStdType(stdTypeMappings, toolEntity.StdType, "story", toolEntity.Type),
 				OriginalType:            "story",
 				OriginalStatus:          toolEntity.Status,
 				ResolutionDate:          toolEntity.ClosedDate.ToNullableTime(),
@@ -88,13 +96,10 @@ func ConvertStory(taskCtx plugin.SubTaskContext) errors.Error {
 				AssigneeName:            toolEntity.AssignedToName,
 				Url:                     convertIssueURL(toolEntity.Url, "story", toolEntity.ID),
 				OriginalProject:         getOriginalProject(data),
-				Status:                  toolEntity.StdStatus,
+				Status:                  getStdStatus(stdStatusMappings, toolEntity.StdStatus, toolEntity.Stage, toolEntity.Status),
 				OriginalEstimateMinutes: &originalEstimateMinutes,
 				StoryPoint:              &toolEntity.Estimate,
 			}
-			if mappingType, ok := stdTypeMappings[domainEntity.OriginalType]; ok && mappingType != "" {
-				domainEntity.Type = mappingType
-			}
 			if toolEntity.Parent != 0 {
 				domainEntity.ParentIssueId = storyIdGen.Generate(data.Options.ConnectionId, toolEntity.Parent)
 			}
@@ -128,7 +133,7 @@ func ConvertStory(taskCtx plugin.SubTaskContext) errors.Error {
 			}
 
 			domainBoardIssue := &ticket.BoardIssue{
-				BoardId: boardIdGen.Generate(data.Options.ConnectionId, data.Options.ProjectId),
+				BoardId: boardId,
 				IssueId: domainEntity.Id,
 			}
 			results = append(results, domainEntity, domainBoardIssue)
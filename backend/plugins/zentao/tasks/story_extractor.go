@@ -66,12 +66,14 @@ func ExtractStory(taskCtx plugin.SubTaskContext) errors.Error {
 			}
 			data.Stories[res.ID] = struct{}{}
 			var results []interface{}
-			projectStory := &models.ZentaoProjectStory{
-				ConnectionId: data.Options.ConnectionId,
-				ProjectId:    data.Options.ProjectId,
-				StoryId:      res.ID,
+			if data.Options.ProjectId != 0 {
+				projectStory := &models.ZentaoProjectStory{
+					ConnectionId: data.Options.ConnectionId,
+					ProjectId:    data.Options.ProjectId,
+					StoryId:      res.ID,
+				}
+				results = append(results, projectStory)
 			}
-			results = append(results, projectStory)
 			story := &models.ZentaoStory{
 				ConnectionId:     data.Options.ConnectionId,
 				ID:               res.ID,
@@ -146,19 +148,12 @@ func ExtractStory(taskCtx plugin.SubTaskContext) errors.Error {
 			default:
 				story.Status = "active"
 			}
-			if len(statusMappings) != 0 {
-				if stdStatus, ok := statusMappings[story.Status]; ok {
-					story.StdStatus = stdStatus
-				} else {
-					story.StdStatus = story.Status
-				}
-			} else {
-				story.StdStatus = ticket.GetStatus(&ticket.StatusRule{
-					Done:    []string{"closed"},
-					Todo:    []string{"wait"},
-					Default: ticket.IN_PROGRESS,
-				}, story.Stage)
-			}
+			// the stage is more specific than the status, so the mapping of the stage wins
+			story.StdStatus = getStdStatus(statusMappings, ticket.GetStatus(&ticket.StatusRule{
+				Done:    []string{"closed"},
+				Todo:    []string{"wait"},
+				Default: ticket.IN_PROGRESS,
+			}, story.Stage), story.Stage, story.Status)
 
 			results = append(results, story)
 			if inputParams.ExecutionId != 0 {
@@ -0,0 +1,53 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/zentao/models"
+)
+
+var ConvertStoryWorklogsMeta = plugin.SubTaskMeta{
+	Name:             "convertStoryWorklogs",
+	EntryPoint:       ConvertStoryWorklogs,
+	EnabledByDefault: true,
+	Description:      "convert Zentao story worklogs",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func ConvertStoryWorklogs(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*ZentaoTaskData)
+	// the stories belong to the products, pick up the efforts logged on them by the stories linked to the project
+	clauses := []dal.Clause{
+		dal.Select("_tool_zentao_worklogs.*"),
+		dal.From(&models.ZentaoWorklog{}),
+		dal.Join(`LEFT JOIN _tool_zentao_project_stories ON
+			_tool_zentao_project_stories.story_id = _tool_zentao_worklogs.object_id
+				AND _tool_zentao_project_stories.connection_id = _tool_zentao_worklogs.connection_id`),
+		dal.Where(
+			"_tool_zentao_worklogs.connection_id = ? AND _tool_zentao_project_stories.project_id = ? AND _tool_zentao_worklogs.object_type = ?",
+			data.Options.ConnectionId,
+			data.Options.ProjectId,
+			"story",
+		),
+	}
+	return convertWorklogs(taskCtx, RAW_STORY_WORKLOGS_TABLE, clauses, didgen.NewDomainIdGenerator(&models.ZentaoStory{}))
+}
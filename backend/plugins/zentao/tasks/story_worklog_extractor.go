@@ -0,0 +1,37 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var _ plugin.SubTaskEntryPoint = ExtractStoryWorklogs
+
+var ExtractStoryWorklogsMeta = plugin.SubTaskMeta{
+	Name:             "extractStoryWorklogs",
+	EntryPoint:       ExtractStoryWorklogs,
+	EnabledByDefault: true,
+	Description:      "Extract raw zentao story worklog data into tool layer table _tool_zentao_worklogs",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func ExtractStoryWorklogs(taskCtx plugin.SubTaskContext) errors.Error {
+	return extractWorklogs(taskCtx, RAW_STORY_WORKLOGS_TABLE)
+}
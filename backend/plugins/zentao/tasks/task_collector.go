@@ -23,9 +23,11 @@ import (
 	"net/http"
 	"net/url"
 	"reflect"
+	"time"
 
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"github.com/apache/incubator-devlake/plugins/zentao/models"
@@ -37,6 +39,14 @@ var _ plugin.SubTaskEntryPoint = CollectTask
 
 func CollectTask(taskCtx plugin.SubTaskContext) errors.Error {
 	data := taskCtx.GetData().(*ZentaoTaskData)
+	apiCollector, err := api.NewStatefulApiCollector(api.RawDataSubTaskArgs{
+		Ctx:     taskCtx,
+		Options: data.Options,
+		Table:   RAW_TASK_TABLE,
+	})
+	if err != nil {
+		return err
+	}
 
 	cursor, err := taskCtx.GetDal().Cursor(
 		dal.Select(`id`),
@@ -47,18 +57,20 @@ func CollectTask(taskCtx plugin.SubTaskContext) errors.Error {
 		return err
 	}
 	defer cursor.Close()
-
 	iterator, err := api.NewDalCursorIterator(taskCtx.GetDal(), cursor, reflect.TypeOf(input{}))
 	if err != nil {
 		return err
 	}
 
-	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
-		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
-			Ctx:     taskCtx,
-			Options: data.Options,
-			Table:   RAW_TASK_TABLE,
-		},
+	// the children are nested in their parents and the pages are sorted by the parents only, a child may be edited
+	// without its parent, so every page is read and the changed tasks are picked out after the flattening
+	var since *time.Time
+	if apiCollector.IsIncremental() && apiCollector.GetSince() != nil {
+		lookBack := apiCollector.GetSince().Add(-incrementalCollectLookBack)
+		since = &lookBack
+	}
+
+	err = apiCollector.InitCollector(api.ApiCollectorArgs{
 		Input:       iterator,
 		ApiClient:   data.ApiClient,
 		PageSize:    100,
@@ -73,7 +85,7 @@ func CollectTask(taskCtx plugin.SubTaskContext) errors.Error {
 		GetTotalPages: GetTotalPagesFromResponse,
 		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
 			var data struct {
-				Tasks []models.ZentaoTaskRes `json:"tasks"`
+				Tasks []json.RawMessage `json:"tasks"`
 			}
 			err := api.UnmarshalResponse(res, &data)
 			if errors.Is(err, api.ErrEmptyResponse) {
@@ -82,16 +94,22 @@ func CollectTask(taskCtx plugin.SubTaskContext) errors.Error {
 			if err != nil {
 				return nil, errors.Default.Wrap(err, "error reading endpoint response by Zentao bug collector")
 			}
-
 			allTaskRecords := make(map[int64]models.ZentaoTaskRes)
-			for _, task := range data.Tasks {
+			for _, rawTask := range data.Tasks {
+				var task models.ZentaoTaskRes
+				err = errors.Convert(json.Unmarshal(rawTask, &task))
+				if err != nil {
+					return nil, errors.Default.Wrap(err, "error decoding Zentao task")
+				}
 				// extract task's children
 				childTasks, err := extractChildrenWithDFS(task)
 				if err != nil {
 					return nil, errors.Default.New(fmt.Sprintf("extract task: %v chidren err: %v", task, err))
 				}
 				for _, task := range childTasks {
-					allTaskRecords[task.Id] = task
+					if since == nil || taskChangedSince(task, *since) {
+						allTaskRecords[task.Id] = task
+					}
 				}
 			}
 			var allTask []json.RawMessage
@@ -108,7 +126,17 @@ func CollectTask(taskCtx plugin.SubTaskContext) errors.Error {
 	if err != nil {
 		return err
 	}
-	return collector.Execute()
+	return apiCollector.Execute()
+}
+
+// taskChangedSince tells if the task was opened or edited after since
+func taskChangedSince(task models.ZentaoTaskRes, since time.Time) bool {
+	for _, date := range []*common.Iso8601Time{task.LastEditedDate, task.OpenedDate} {
+		if date != nil && date.ToTime().After(since) {
+			return true
+		}
+	}
+	return false
 }
 
 // extractChildrenWithDFS return task's child tasks and itself.
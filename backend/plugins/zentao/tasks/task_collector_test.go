@@ -18,9 +18,11 @@ limitations under the License.
 package tasks
 
 import (
+	"github.com/apache/incubator-devlake/core/models/common"
 	"github.com/apache/incubator-devlake/plugins/zentao/models"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func Test_extractChildrenWithDFS(t *testing.T) {
@@ -115,3 +117,47 @@ func Test_extractChildrenWithDFS(t *testing.T) {
 		})
 	}
 }
+
+func Test_taskChangedSince(t *testing.T) {
+	since := time.Date(2025, 2, 20, 10, 0, 0, 0, time.UTC)
+	before := common.Iso8601Time{Time: since.Add(-time.Hour)}
+	after := common.Iso8601Time{Time: since.Add(time.Hour)}
+	tests := []struct {
+		name string
+		task models.ZentaoTaskRes
+		want bool
+	}{
+		{name: "no dates", task: models.ZentaoTaskRes{Id: 1}, want: false},
+		{name: "edited before", task: models.ZentaoTaskRes{Id: 2, OpenedDate: &before, LastEditedDate: &before}, want: false},
+		{name: "edited after", task: models.ZentaoTaskRes{Id: 3, OpenedDate: &before, LastEditedDate: &after}, want: true},
+		{name: "opened after", task: models.ZentaoTaskRes{Id: 4, OpenedDate: &after}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := taskChangedSince(tt.task, since); got != tt.want {
+				t.Errorf("taskChangedSince() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_taskChangedSinceFindsChildUnderUnchangedParent(t *testing.T) {
+	since := time.Date(2025, 2, 20, 10, 0, 0, 0, time.UTC)
+	before := common.Iso8601Time{Time: since.Add(-time.Hour)}
+	after := common.Iso8601Time{Time: since.Add(time.Hour)}
+	child := &models.ZentaoTaskRes{Id: 2, Parent: 1, LastEditedDate: &after}
+	parent := models.ZentaoTaskRes{Id: 1, LastEditedDate: &before, Children: []*models.ZentaoTaskRes{child}}
+	tasks, err := extractChildrenWithDFS(parent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var changed []int64
+	for _, task := range tasks {
+		if taskChangedSince(task, since) {
+			changed = append(changed, task.Id)
+		}
+	}
+	if !reflect.DeepEqual(changed, []int64{2}) {
+		t.Errorf("changed tasks: %v, want: [2]", changed)
+	}
+}
@@ -51,6 +51,7 @@ func ConvertTask(taskCtx plugin.SubTaskContext) errors.Error {
 	taskIdGen := didgen.NewDomainIdGenerator(&models.ZentaoTask{})
 	accountIdGen := didgen.NewDomainIdGenerator(&models.ZentaoAccount{})
 	stdTypeMappings := getStdTypeMappings(data)
+	stdStatusMappings := getTaskStatusMapping(data)
 	cursor, err := db.Cursor(
 		dal.From(&models.ZentaoTask{}),
 		dal.Where(`project = ? and connection_id = ?`, data.Options.ProjectId, data.Options.ConnectionId),
@@ -80,7 +81,7 @@ func ConvertTask(taskCtx plugin.SubTaskContext) errors.Error {
 				IssueKey:                strconv.FormatInt(toolEntity.ID, 10),
 				Title:                   toolEntity.Name,
 				Description:             toolEntity.Description,
-				Type:                    toolEntity.StdType,
+				Type:                    getStdType(stdTypeMappings, toolEntity.StdType, "task", toolEntity.Type),
 				OriginalType:            "task",
 				OriginalStatus:          toolEntity.Status,
 				ResolutionDate:          toolEntity.ClosedDate.ToNullableTime(),
@@ -91,14 +92,11 @@ func ConvertTask(taskCtx plugin.SubTaskContext) errors.Error {
 				AssigneeName:            toolEntity.AssignedToName,
 				Url:                     convertIssueURL(toolEntity.Url, "task", toolEntity.ID),
 				OriginalProject:         getOriginalProject(data),
-				Status:                  toolEntity.StdStatus,
+				Status:                  getStdStatus(stdStatusMappings, toolEntity.StdStatus, toolEntity.Status),
 				OriginalEstimateMinutes: &originalEstimateMinutes,
 				TimeSpentMinutes:        &timeSpentMinutes,
 				TimeRemainingMinutes:    &timeRemainingMinutes,
 			}
-			if mappingType, ok := stdTypeMappings[domainEntity.OriginalType]; ok && mappingType != "" {
-				domainEntity.Type = mappingType
-			}
 			if toolEntity.Parent != 0 {
 				domainEntity.ParentIssueId = storyIdGen.Generate(data.Options.ConnectionId, toolEntity.Parent)
 			}
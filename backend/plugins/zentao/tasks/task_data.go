@@ -22,6 +22,7 @@ import (
 
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"github.com/apache/incubator-devlake/plugins/zentao/models"
 	"github.com/mitchellh/mapstructure"
@@ -35,6 +36,8 @@ type ZentaoOptions struct {
 	// You can use it in subtasks, and you need to pass it to main.go and pipelines.
 	ConnectionId uint64 `json:"connectionId"`
 	ProjectId    int64  `json:"projectId" mapstructure:"projectId"`
+	// ProductId is set instead of ProjectId to collect the stories and bugs of a product
+	ProductId int64 `json:"productId" mapstructure:"productId"`
 	// TODO not support now
 	ScopeConfigId uint64                    `json:"scopeConfigId" mapstructure:"scopeConfigId,omitempty"`
 	ScopeConfig   *models.ZentaoScopeConfig `json:"scopeConfig" mapstructure:"scopeConfig,omitempty"`
@@ -44,9 +47,26 @@ func (o *ZentaoOptions) GetParams() any {
 	return models.ZentaoApiParams{
 		ConnectionId: o.ConnectionId,
 		ProjectId:    o.ProjectId,
+		ProductId:    o.ProductId,
 	}
 }
 
+// ProductSubTaskMetas are the subtasks collecting the products, the rest of them work on the projects only
+var ProductSubTaskMetas = []*plugin.SubTaskMeta{
+	&ConvertProductMeta,
+	&CollectAccountMeta,
+	&ExtractAccountMeta,
+	&ConvertAccountMeta,
+	&CollectDepartmentMeta,
+	&ExtractDepartmentMeta,
+	&CollectStoryMeta,
+	&ExtractStoryMeta,
+	&ConvertStoryMeta,
+	&CollectBugMeta,
+	&ExtractBugMeta,
+	&ConvertBugMeta,
+}
+
 type ZentaoTaskData struct {
 	Options  *ZentaoOptions
 	RemoteDb dal.Dal
@@ -69,8 +89,8 @@ func DecodeAndValidateTaskOptions(options map[string]interface{}) (*ZentaoOption
 	if op.ConnectionId == 0 {
 		return nil, fmt.Errorf("connectionId is invalid")
 	}
-	if op.ProjectId == 0 {
-		return nil, fmt.Errorf("please set projectId")
+	if op.ProjectId == 0 && op.ProductId == 0 {
+		return nil, fmt.Errorf("please set projectId or productId")
 	}
 	return &op, nil
 }
@@ -167,18 +167,10 @@ func (c *taskExtractor) toZentaoTasks(accountCache *AccountCache, res *models.Ze
 	if task.StdType == "" {
 		task.StdType = ticket.TASK
 	}
-	if len(c.statusMappings) != 0 {
-		if stdStatus, ok := c.statusMappings[task.Status]; ok {
-			task.StdStatus = stdStatus
-		} else {
-			task.StdStatus = task.Status
-		}
-	} else {
-		task.StdStatus = ticket.GetStatus(&ticket.StatusRule{
-			Done:    []string{"done", "closed", "cancel"},
-			Todo:    []string{"wait"},
-			Default: ticket.IN_PROGRESS,
-		}, task.Status)
-	}
+	task.StdStatus = getStdStatus(c.statusMappings, ticket.GetStatus(&ticket.StatusRule{
+		Done:    []string{"done", "closed", "cancel"},
+		Todo:    []string{"wait"},
+		Default: ticket.IN_PROGRESS,
+	}, task.Status), task.Status)
 	*tasks = append(*tasks, task)
 }
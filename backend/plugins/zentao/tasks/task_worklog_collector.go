@@ -90,27 +90,8 @@ func CollectTaskWorklogs(taskCtx plugin.SubTaskContext) errors.Error {
 		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
 			return nil, nil
 		},
-		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
-			var data struct {
-				Effort json.RawMessage `json:"effort"`
-			}
-			err := api.UnmarshalResponse(res, &data)
-			if err != nil {
-				return nil, err
-			}
-
-			if string(data.Effort) == "{}" || string(data.Effort) == "null" {
-				return nil, nil
-			}
-
-			var efforts []json.RawMessage
-			jsonErr := json.Unmarshal(data.Effort, &efforts)
-			if jsonErr != nil {
-				return nil, errors.Default.Wrap(jsonErr, "failed to unmarshal efforts")
-			}
-			return efforts, nil
-		},
-		AfterResponse: ignoreHTTPStatus404,
+		ResponseParser: parseWorklogs,
+		AfterResponse:  ignoreHTTPStatus404,
 	})
 	if err != nil {
 		logger.Error(err, "collect Zentao task worklogs error")
@@ -119,3 +100,25 @@ func CollectTaskWorklogs(taskCtx plugin.SubTaskContext) errors.Error {
 
 	return apiCollector.Execute()
 }
+
+// parseWorklogs parses the efforts out of the estimate response of zentao tasks, bugs and stories
+func parseWorklogs(res *http.Response) ([]json.RawMessage, errors.Error) {
+	var data struct {
+		Effort json.RawMessage `json:"effort"`
+	}
+	err := api.UnmarshalResponse(res, &data)
+	if err != nil {
+		return nil, err
+	}
+
+	if string(data.Effort) == "{}" || string(data.Effort) == "null" {
+		return nil, nil
+	}
+
+	var efforts []json.RawMessage
+	jsonErr := json.Unmarshal(data.Effort, &efforts)
+	if jsonErr != nil {
+		return nil, errors.Default.Wrap(jsonErr, "failed to unmarshal efforts")
+	}
+	return efforts, nil
+}
@@ -40,15 +40,7 @@ var ConvertTaskWorklogsMeta = plugin.SubTaskMeta{
 }
 
 func ConvertTaskWorklogs(taskCtx plugin.SubTaskContext) errors.Error {
-	logger := taskCtx.GetLogger()
-	db := taskCtx.GetDal()
 	data := taskCtx.GetData().(*ZentaoTaskData)
-	logger.Info(
-		"convert Zentao task worklogs of %d in %d",
-		data.Options.ProjectId,
-		data.Options.ConnectionId,
-	)
-	worklogIdGen := didgen.NewDomainIdGenerator(&models.ZentaoWorklog{})
 	clauses := []dal.Clause{
 		dal.From(&models.ZentaoWorklog{}),
 		dal.Where(
@@ -58,6 +50,21 @@ func ConvertTaskWorklogs(taskCtx plugin.SubTaskContext) errors.Error {
 			"task",
 		),
 	}
+	return convertWorklogs(taskCtx, RAW_TASK_WORKLOGS_TABLE, clauses, didgen.NewDomainIdGenerator(&models.ZentaoTask{}))
+}
+
+// convertWorklogs converts the worklogs selected by the clauses into issue_worklogs of the issues generated by issueIdGen
+func convertWorklogs(taskCtx plugin.SubTaskContext, rawTable string, clauses []dal.Clause, issueIdGen *didgen.DomainIdGenerator) errors.Error {
+	logger := taskCtx.GetLogger()
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*ZentaoTaskData)
+	logger.Info(
+		"convert Zentao worklogs of %s of %d in %d",
+		rawTable,
+		data.Options.ProjectId,
+		data.Options.ConnectionId,
+	)
+	worklogIdGen := didgen.NewDomainIdGenerator(&models.ZentaoWorklog{})
 
 	cursor, err := db.Cursor(clauses...)
 	if err != nil {
@@ -65,13 +72,11 @@ func ConvertTaskWorklogs(taskCtx plugin.SubTaskContext) errors.Error {
 	}
 	defer cursor.Close()
 
-	taskIdGen := didgen.NewDomainIdGenerator(&models.ZentaoTask{})
-
 	converter, err := helper.NewDataConverter(helper.DataConverterArgs{
 		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
 			Ctx:     taskCtx,
 			Options: data.Options,
-			Table:   RAW_TASK_WORKLOGS_TABLE,
+			Table:   rawTable,
 		},
 		InputRowType: reflect.TypeOf(models.ZentaoWorklog{}),
 		Input:        cursor,
@@ -86,13 +91,13 @@ func ConvertTaskWorklogs(taskCtx plugin.SubTaskContext) errors.Error {
 			}
 			timeData, err := common.ConvertStringToTime(toolL.Date)
 			if err != nil {
-				return nil, errors.Default.Wrap(err, "failed to convert zentao task worklog date")
+				return nil, errors.Default.Wrap(err, "failed to convert zentao worklog date")
 			}
-			// zentao task only has one field as date type for worklog creation
+			// zentao effort only has one field as date type for worklog creation
 			domainL.StartedDate = &timeData
 			domainL.LoggedDate = &timeData
 
-			domainL.IssueId = taskIdGen.Generate(data.Options.ConnectionId, toolL.ObjectId)
+			domainL.IssueId = issueIdGen.Generate(data.Options.ConnectionId, toolL.ObjectId)
 
 			// get ID of account by username
 			var account models.ZentaoAccount
@@ -36,12 +36,17 @@ var ExtractTaskWorklogsMeta = plugin.SubTaskMeta{
 }
 
 func ExtractTaskWorklogs(taskCtx plugin.SubTaskContext) errors.Error {
+	return extractWorklogs(taskCtx, RAW_TASK_WORKLOGS_TABLE)
+}
+
+// extractWorklogs extracts the efforts collected into the rawTable into _tool_zentao_worklogs
+func extractWorklogs(taskCtx plugin.SubTaskContext, rawTable string) errors.Error {
 	data := taskCtx.GetData().(*ZentaoTaskData)
 	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
 		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
 			Ctx:     taskCtx,
 			Options: data.Options,
-			Table:   RAW_TASK_WORKLOGS_TABLE,
+			Table:   rawTable,
 		},
 		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
 			var input struct {
@@ -0,0 +1,116 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/qa"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/zentao/models"
+)
+
+var _ plugin.SubTaskEntryPoint = ConvertTestCase
+
+var ConvertTestCaseMeta = plugin.SubTaskMeta{
+	Name:             "convertTestCase",
+	EntryPoint:       ConvertTestCase,
+	EnabledByDefault: true,
+	Description:      "convert Zentao testcases into domain layer table qa_test_cases",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_QUALITY},
+}
+
+func ConvertTestCase(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*ZentaoTaskData)
+	db := taskCtx.GetDal()
+	// the qa project shares the id with the board of the zentao project, so the test cases can be
+	// associated with the devlake project through the project_mapping of the board
+	qaProjectId := didgen.NewDomainIdGenerator(&models.ZentaoProject{}).Generate(data.Options.ConnectionId, data.Options.ProjectId)
+	project := &models.ZentaoProject{}
+	err := db.First(project, dal.Where("connection_id = ? AND id = ?", data.Options.ConnectionId, data.Options.ProjectId))
+	if err != nil && !db.IsErrorNotFound(err) {
+		return err
+	}
+	err = db.CreateOrUpdate(&qa.QaProject{
+		DomainEntityExtended: domainlayer.DomainEntityExtended{Id: qaProjectId},
+		Name:                 project.Name,
+	})
+	if err != nil {
+		return err
+	}
+
+	cursor, err := db.Cursor(
+		dal.From(&models.ZentaoTestCase{}),
+		dal.Where("connection_id = ? AND project = ?", data.Options.ConnectionId, data.Options.ProjectId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	testCaseIdGen := didgen.NewDomainIdGenerator(&models.ZentaoTestCase{})
+	accountIdGen := didgen.NewDomainIdGenerator(&models.ZentaoAccount{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx:     taskCtx,
+			Options: data.Options,
+			Table:   RAW_TESTCASE_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.ZentaoTestCase{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			toolEntity := inputRow.(*models.ZentaoTestCase)
+			if toolEntity.Deleted {
+				return nil, nil
+			}
+			domainEntity := &qa.QaTestCase{
+				DomainEntityExtended: domainlayer.DomainEntityExtended{
+					Id: testCaseIdGen.Generate(toolEntity.ConnectionId, toolEntity.Project, toolEntity.ID),
+				},
+				Name:        toolEntity.Title,
+				Type:        getTestCaseType(toolEntity.Type),
+				QaProjectId: qaProjectId,
+			}
+			if toolEntity.OpenedDate != nil {
+				domainEntity.CreateTime = toolEntity.OpenedDate.ToTime()
+			}
+			if toolEntity.OpenedById != 0 {
+				domainEntity.CreatorId = accountIdGen.Generate(toolEntity.ConnectionId, toolEntity.OpenedById)
+			}
+			return []interface{}{domainEntity}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+// getTestCaseType maps the zentao testcase types (feature, performance, config, install, security, interface,
+// unit and other) to the types of qa_test_cases
+func getTestCaseType(caseType string) string {
+	if caseType == "interface" {
+		return "api"
+	}
+	return "functional"
+}
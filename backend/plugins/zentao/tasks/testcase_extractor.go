@@ -0,0 +1,90 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/zentao/models"
+)
+
+var _ plugin.SubTaskEntryPoint = ExtractTestCase
+
+var ExtractTestCaseMeta = plugin.SubTaskMeta{
+	Name:             "extractTestCase",
+	EntryPoint:       ExtractTestCase,
+	EnabledByDefault: true,
+	Description:      "extract Zentao testcase",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_QUALITY},
+}
+
+func ExtractTestCase(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*ZentaoTaskData)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx:     taskCtx,
+			Options: data.Options,
+			Table:   RAW_TESTCASE_TABLE,
+		},
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			res := &models.ZentaoTestCaseRes{}
+			err := json.Unmarshal(row.Data, res)
+			if err != nil {
+				return nil, errors.Default.WrapRaw(err)
+			}
+			testCase := &models.ZentaoTestCase{
+				ConnectionId:   data.Options.ConnectionId,
+				ID:             res.ID,
+				Project:        data.Options.ProjectId,
+				Product:        res.Product,
+				Branch:         res.Branch,
+				Lib:            res.Lib,
+				Module:         res.Module,
+				Story:          res.Story,
+				StoryVersion:   res.StoryVersion,
+				Title:          res.Title,
+				Precondition:   res.Precondition,
+				Keywords:       res.Keywords,
+				Pri:            res.Pri,
+				Type:           res.Type,
+				Auto:           res.Auto,
+				Stage:          res.Stage,
+				Status:         res.Status,
+				OpenedById:     data.AccountCache.getAccountIDFromApiAccount(res.OpenedBy),
+				OpenedDate:     res.OpenedDate,
+				LastEditedById: data.AccountCache.getAccountIDFromApiAccount(res.LastEditedBy),
+				LastEditedDate: res.LastEditedDate,
+				Version:        res.Version,
+				FromBug:        res.FromBug,
+				LastRunnerId:   data.AccountCache.getAccountIDFromApiAccount(res.LastRunner),
+				LastRunDate:    res.LastRunDate,
+				LastRunResult:  res.LastRunResult,
+				Deleted:        res.Deleted,
+			}
+			return []interface{}{testCase}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
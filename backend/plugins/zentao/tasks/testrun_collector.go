@@ -0,0 +1,93 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/zentao/models"
+)
+
+const RAW_TESTRUN_TABLE = "zentao_api_testruns"
+
+var _ plugin.SubTaskEntryPoint = CollectTestRun
+
+var CollectTestRunMeta = plugin.SubTaskMeta{
+	Name:             "collectTestRun",
+	EntryPoint:       CollectTestRun,
+	EnabledByDefault: true,
+	Description:      "Collect the testcase runs of the testtasks from Zentao api",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_QUALITY},
+}
+
+func CollectTestRun(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*ZentaoTaskData)
+	db := taskCtx.GetDal()
+	cursor, err := db.Cursor(
+		dal.Select("id"),
+		dal.From(&models.ZentaoTestTask{}),
+		dal.Where("project = ? AND connection_id = ? AND deleted = ?", data.Options.ProjectId, data.Options.ConnectionId, false),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	iterator, err := api.NewDalCursorIterator(db, cursor, reflect.TypeOf(input{}))
+	if err != nil {
+		return err
+	}
+	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx:     taskCtx,
+			Options: data.Options,
+			Table:   RAW_TESTRUN_TABLE,
+		},
+		Input:       iterator,
+		ApiClient:   data.ApiClient,
+		UrlTemplate: "/testtasks/{{ .Input.Id }}",
+		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+			return nil, nil
+		},
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			var data struct {
+				TestCases []json.RawMessage `json:"testcases"`
+			}
+			err := api.UnmarshalResponse(res, &data)
+			if errors.Is(err, api.ErrEmptyResponse) {
+				return nil, nil
+			}
+			if err != nil {
+				return nil, errors.Default.Wrap(err, "error reading endpoint response by Zentao testrun collector")
+			}
+			return data.TestCases, nil
+		},
+		AfterResponse: ignoreHTTPStatus404,
+	})
+	if err != nil {
+		return err
+	}
+
+	return collector.Execute()
+}
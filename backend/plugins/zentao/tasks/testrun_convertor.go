@@ -0,0 +1,125 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/qa"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/zentao/models"
+)
+
+var _ plugin.SubTaskEntryPoint = ConvertTestRun
+
+var ConvertTestRunMeta = plugin.SubTaskMeta{
+	Name:             "convertTestRun",
+	EntryPoint:       ConvertTestRun,
+	EnabledByDefault: true,
+	Description:      "convert Zentao testcase runs into domain layer table qa_test_case_executions",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_QUALITY},
+}
+
+func ConvertTestRun(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*ZentaoTaskData)
+	db := taskCtx.GetDal()
+	var testTasks []*models.ZentaoTestTask
+	err := db.All(&testTasks, dal.Where("connection_id = ? AND project = ?", data.Options.ConnectionId, data.Options.ProjectId))
+	if err != nil {
+		return err
+	}
+	testTaskMap := make(map[int64]*models.ZentaoTestTask, len(testTasks))
+	for _, testTask := range testTasks {
+		testTaskMap[testTask.ID] = testTask
+	}
+
+	cursor, err := db.Cursor(
+		dal.From(&models.ZentaoTestRun{}),
+		dal.Where("connection_id = ? AND project = ?", data.Options.ConnectionId, data.Options.ProjectId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	qaProjectId := didgen.NewDomainIdGenerator(&models.ZentaoProject{}).Generate(data.Options.ConnectionId, data.Options.ProjectId)
+	testRunIdGen := didgen.NewDomainIdGenerator(&models.ZentaoTestRun{})
+	testCaseIdGen := didgen.NewDomainIdGenerator(&models.ZentaoTestCase{})
+	accountIdGen := didgen.NewDomainIdGenerator(&models.ZentaoAccount{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx:     taskCtx,
+			Options: data.Options,
+			Table:   RAW_TESTRUN_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.ZentaoTestRun{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			toolEntity := inputRow.(*models.ZentaoTestRun)
+			testTask := testTaskMap[toolEntity.Task]
+			if testTask == nil || testTask.Deleted {
+				return nil, nil
+			}
+			domainEntity := &qa.QaTestCaseExecution{
+				DomainEntityExtended: domainlayer.DomainEntityExtended{
+					Id: testRunIdGen.Generate(toolEntity.ConnectionId, toolEntity.ID),
+				},
+				QaProjectId:  qaProjectId,
+				QaTestCaseId: testCaseIdGen.Generate(toolEntity.ConnectionId, toolEntity.Project, toolEntity.Case),
+				Status:       getTestRunStatus(toolEntity.LastRunResult, testTask.Status),
+			}
+			if testTask.CreatedDate != nil {
+				domainEntity.CreateTime = testTask.CreatedDate.ToTime()
+			}
+			if testTask.Begin != nil {
+				domainEntity.StartTime = testTask.Begin.ToTime()
+			}
+			if toolEntity.LastRunDate != nil {
+				domainEntity.FinishTime = toolEntity.LastRunDate.ToTime()
+			}
+			if toolEntity.LastRunnerId != 0 {
+				domainEntity.CreatorId = accountIdGen.Generate(toolEntity.ConnectionId, toolEntity.LastRunnerId)
+			}
+			return []interface{}{domainEntity}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+// getTestRunStatus maps the result of the last run of the testcase (pass, fail, blocked or n/a) to the status of
+// qa_test_case_executions, the testcases not run yet are IN_PROGRESS while the testtask is in progress
+func getTestRunStatus(lastRunResult string, testTaskStatus string) string {
+	switch lastRunResult {
+	case "pass":
+		return "SUCCESS"
+	case "fail", "blocked":
+		return "FAILED"
+	}
+	if testTaskStatus == "doing" {
+		return "IN_PROGRESS"
+	}
+	return "PENDING"
+}
@@ -0,0 +1,83 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/zentao/models"
+)
+
+var _ plugin.SubTaskEntryPoint = ExtractTestRun
+
+var ExtractTestRunMeta = plugin.SubTaskMeta{
+	Name:             "extractTestRun",
+	EntryPoint:       ExtractTestRun,
+	EnabledByDefault: true,
+	Description:      "extract Zentao testcase runs of the testtasks",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_QUALITY},
+}
+
+func ExtractTestRun(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*ZentaoTaskData)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx:     taskCtx,
+			Options: data.Options,
+			Table:   RAW_TESTRUN_TABLE,
+		},
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			res := &models.ZentaoTestRunRes{}
+			err := json.Unmarshal(row.Data, res)
+			if err != nil {
+				return nil, errors.Default.WrapRaw(err)
+			}
+			if res.Task == 0 {
+				testTask := &input{}
+				err = json.Unmarshal(row.Input, testTask)
+				if err != nil {
+					return nil, errors.Default.WrapRaw(err)
+				}
+				res.Task = testTask.Id
+			}
+			testRun := &models.ZentaoTestRun{
+				ConnectionId:  data.Options.ConnectionId,
+				ID:            res.ID,
+				Project:       data.Options.ProjectId,
+				Task:          res.Task,
+				Case:          res.Case,
+				Version:       res.Version,
+				Title:         res.Title,
+				AssignedToId:  data.AccountCache.getAccountIDFromApiAccount(res.AssignedTo),
+				LastRunnerId:  data.AccountCache.getAccountIDFromApiAccount(res.LastRunner),
+				LastRunDate:   res.LastRunDate,
+				LastRunResult: res.LastRunResult,
+				Status:        res.Status,
+			}
+			return []interface{}{testRun}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
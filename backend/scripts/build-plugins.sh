@@ -33,11 +33,15 @@ set -e
 echo "Usage: "
 echo "  build all plugins:              $0 [golang build flags...]"
 echo "  build and keep specified plugins only: DEVLAKE_PLUGINS=github,jira $0 [golang build flags...]"
+echo "  build plugins as standalone grpc executables: DEVLAKE_PLUGIN_MODE=grpc $0 [golang build flags...]"
 
 ROOT_DIR=$(dirname $(dirname "$0"))
 EXTRA=""
 PLUGIN_SRC_DIR=$ROOT_DIR/plugins
 PLUGIN_OUTPUT_DIR=${PLUGIN_DIR:-$ROOT_DIR/bin/plugins}
+if [ "$DEVLAKE_PLUGIN_MODE" = "grpc" ]; then
+    PLUGIN_OUTPUT_DIR=${GRPC_PLUGIN_DIR:-$ROOT_DIR/bin/grpc-plugins}
+fi
 
 
 rm -rf $PLUGIN_OUTPUT_DIR/*
@@ -66,8 +70,13 @@ fi
 PIDS=""
 for PLUG in $PLUGINS; do
     NAME=$(basename $PLUG)
-    echo "Building plugin $NAME to bin/plugins/$NAME/$NAME.so with args: $*  --gcflags="$GCFLAGS""
-    go build -buildmode=plugin --gcflags="$GCFLAGS" -o $PLUGIN_OUTPUT_DIR/$NAME/$NAME.so $PLUG/*.go &
+    if [ "$DEVLAKE_PLUGIN_MODE" = "grpc" ]; then
+        echo "Building plugin $NAME to $PLUGIN_OUTPUT_DIR/$NAME/$NAME with args: $*  --gcflags="$GCFLAGS""
+        go build --gcflags="$GCFLAGS" -o $PLUGIN_OUTPUT_DIR/$NAME/$NAME $PLUG/*.go &
+    else
+        echo "Building plugin $NAME to bin/plugins/$NAME/$NAME.so with args: $*  --gcflags="$GCFLAGS""
+        go build -buildmode=plugin --gcflags="$GCFLAGS" -o $PLUGIN_OUTPUT_DIR/$NAME/$NAME.so $PLUG/*.go &
+    fi
     PIDS="$PIDS $!"
     # avoid too many processes causing signal killed
     COUNT=$(echo "$PIDS" | wc -w)
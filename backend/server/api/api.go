@@ -27,6 +27,7 @@ import (
 
 	// "github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"github.com/swaggo/swag"
@@ -103,10 +104,16 @@ func CreateApiServer() *gin.Engine {
 	router.GET("/ready", ping.Ready)
 	router.GET("/health", ping.Health)
 	router.GET("/version", version.Get)
+	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
 
 	// Api keys
 	router.Use(RestAuthentication(router, basicRes))
 	router.Use(OAuth2ProxyAuthentication(basicRes))
+	router.Use(RbacAuthorization(basicRes))
+
+	// the deep health check tells about the connections, it requires the authentication like the other apis but is
+	// registered ahead of the migration check so it still answers while the migration waits for the confirmation
+	router.GET("/health/details", ping.HealthDetails)
 
 	return router
 }
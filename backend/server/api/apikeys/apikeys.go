@@ -18,17 +18,22 @@ limitations under the License.
 package apikeys
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
 
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models"
 	"github.com/apache/incubator-devlake/impls/logruslog"
+	"github.com/apache/incubator-devlake/server/api/rbac"
 	"github.com/apache/incubator-devlake/server/api/shared"
 	"github.com/apache/incubator-devlake/server/services"
 	"github.com/gin-gonic/gin"
 )
 
+// getApiKey loads the api key checked by getOwnApiKey
+var getApiKey = services.GetApiKey
+
 type PaginatedApiKeys struct {
 	ApiKeys []*models.ApiKey `json:"apikeys"`
 	Count   int64            `json:"count"`
@@ -50,6 +55,14 @@ func GetApiKeys(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
 		return
 	}
+	if !isApiKeyAdmin(c) {
+		user, _ := shared.GetUser(c)
+		if user == nil {
+			shared.ApiOutputError(c, errors.Forbidden.New("the user is unknown"))
+			return
+		}
+		query.Creator = user.Name
+	}
 	apiKeys, count, err := services.GetApiKeys(&query)
 	if err != nil {
 		shared.ApiOutputAbort(c, errors.Default.Wrap(err, "error getting api keys"))
@@ -77,11 +90,23 @@ func DeleteApiKey(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad apiKeyId format supplied"))
 		return
 	}
+	oldApiKey, err := getOwnApiKey(c, id)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
 	err = services.DeleteApiKey(id)
 	if err != nil {
 		shared.ApiOutputError(c, errors.Default.Wrap(err, "error deleting api key"))
 		return
 	}
+	user, _ := shared.GetUser(c)
+	services.RecordAudit(user, &models.AuditLog{
+		Action:       models.AUDIT_ACTION_DELETE,
+		ResourceType: models.AUDIT_RESOURCE_API_KEY,
+		ResourceId:   apiKeyId,
+		Path:         c.Request.URL.Path,
+	}, oldApiKey, nil)
 	shared.ApiOutputSuccess(c, nil, http.StatusOK)
 }
 
@@ -104,11 +129,66 @@ func PutApiKey(c *gin.Context) {
 	if !exist {
 		logruslog.Global.Warn(nil, "user doesn't exist")
 	}
+	oldApiKey, err := getOwnApiKey(c, id)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
 	apiOutputApiKey, err := services.PutApiKey(user, id)
 	if err != nil {
 		shared.ApiOutputError(c, errors.Default.Wrap(err, "error regenerate api key"))
 		return
 	}
+	services.RecordAudit(user, &models.AuditLog{
+		Action:       models.AUDIT_ACTION_UPDATE,
+		ResourceType: models.AUDIT_RESOURCE_API_KEY,
+		ResourceId:   apiKeyId,
+		Path:         c.Request.URL.Path,
+	}, oldApiKey, apiOutputApiKey)
+	shared.ApiOutputSuccess(c, apiOutputApiKey, http.StatusOK)
+}
+
+// @Summary Rotate an api key
+// @Description Replace an api key with a new one, the replaced key is still accepted during the grace period
+// @Tags framework/api-keys
+// @Accept application/json
+// @Param rotation body models.ApiInputRotateApiKey false "json"
+// @Success 200  {object} models.ApiOutputApiKey
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 403  {string} errcode.Error "Forbidden"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /api-keys/{apiKeyId}/rotate [post]
+func PostRotateApiKey(c *gin.Context) {
+	apiKeyId := c.Param("apiKeyId")
+	id, err := strconv.ParseUint(apiKeyId, 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad apiKeyId format supplied"))
+		return
+	}
+	input := &models.ApiInputRotateApiKey{}
+	if c.Request.ContentLength != 0 {
+		if err = c.ShouldBindJSON(input); err != nil {
+			shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+			return
+		}
+	}
+	oldApiKey, err := getOwnApiKey(c, id)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	user, _ := shared.GetUser(c)
+	apiOutputApiKey, err := services.RotateApiKey(user, id, input)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error rotating api key"))
+		return
+	}
+	services.RecordAudit(user, &models.AuditLog{
+		Action:       models.AUDIT_ACTION_UPDATE,
+		ResourceType: models.AUDIT_RESOURCE_API_KEY,
+		ResourceId:   apiKeyId,
+		Path:         c.Request.URL.Path,
+	}, oldApiKey, apiOutputApiKey)
 	shared.ApiOutputSuccess(c, apiOutputApiKey, http.StatusOK)
 }
 
@@ -137,6 +217,35 @@ func PostApiKey(c *gin.Context) {
 		shared.ApiOutputError(c, errors.Default.Wrap(err, "error creating api key"))
 		return
 	}
+	services.RecordAudit(user, &models.AuditLog{
+		Action:       models.AUDIT_ACTION_CREATE,
+		ResourceType: models.AUDIT_RESOURCE_API_KEY,
+		ResourceId:   strconv.FormatUint(apiKeyOutput.ID, 10),
+		Path:         c.Request.URL.Path,
+	}, nil, apiKeyOutput)
 
 	shared.ApiOutputSuccess(c, apiKeyOutput, http.StatusCreated)
 }
+
+// isApiKeyAdmin tells whether the user may manage the api keys of everyone, which is always the case unless the
+// requests are authorized by rbac
+func isApiKeyAdmin(c *gin.Context) bool {
+	grants := rbac.GetGrants(c)
+	return grants == nil || grants.IsAdmin()
+}
+
+// getOwnApiKey loads the api key, making sure it belongs to the user unless the user is an admin
+func getOwnApiKey(c *gin.Context, id uint64) (*models.ApiKey, errors.Error) {
+	apiKey, err := getApiKey(id)
+	if err != nil {
+		return nil, errors.NotFound.Wrap(err, fmt.Sprintf("could not find api key id[%d]", id))
+	}
+	if isApiKeyAdmin(c) {
+		return apiKey, nil
+	}
+	user, _ := shared.GetUser(c)
+	if user == nil || apiKey.Creator.Creator != user.Name {
+		return nil, errors.Forbidden.New("the api key belongs to another user")
+	}
+	return apiKey, nil
+}
@@ -0,0 +1,116 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apikeys
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/server/api/rbac"
+	"github.com/apache/incubator-devlake/server/services"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	admin = services.NewRbacGrants([]*models.RoleBinding{
+		{Role: models.ROLE_ADMIN, ResourceType: models.RBAC_RESOURCE_GLOBAL},
+	})
+	maintainer = services.NewRbacGrants([]*models.RoleBinding{
+		{Role: models.ROLE_MAINTAINER, ResourceType: models.RBAC_RESOURCE_GLOBAL},
+	})
+)
+
+func withApiKeyOf(t *testing.T, creator string) {
+	getApiKey = func(id uint64) (*models.ApiKey, errors.Error) {
+		apiKey := &models.ApiKey{Name: "key"}
+		apiKey.ID = id
+		apiKey.Creator.Creator = creator
+		return apiKey, nil
+	}
+	t.Cleanup(func() { getApiKey = services.GetApiKey })
+}
+
+func authorized(grants *services.RbacGrants, user *common.User) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if user != nil {
+			c.Set(common.USER, user)
+		}
+		if grants != nil {
+			rbac.SetGrants(c, grants)
+		}
+	}
+}
+
+func TestApiKeyOfAnotherUser(t *testing.T) {
+	withApiKeyOf(t, "bob")
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(authorized(maintainer, &common.User{Name: "alice"}))
+	router.PUT("/api-keys/:apiKeyId", PutApiKey)
+	router.DELETE("/api-keys/:apiKeyId", DeleteApiKey)
+	router.POST("/api-keys/:apiKeyId/rotate", PostRotateApiKey)
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodPut, "/api-keys/1", nil),
+		httptest.NewRequest(http.MethodDelete, "/api-keys/1", nil),
+		httptest.NewRequest(http.MethodPost, "/api-keys/1/rotate", nil),
+	} {
+		t.Run(req.Method, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, req)
+			assert.Equal(t, http.StatusForbidden, recorder.Code)
+		})
+	}
+}
+
+func TestGetOwnApiKey(t *testing.T) {
+	withApiKeyOf(t, "bob")
+	tests := []struct {
+		name    string
+		grants  *services.RbacGrants
+		user    *common.User
+		allowed bool
+	}{
+		{"creator", maintainer, &common.User{Name: "bob"}, true},
+		{"another user", maintainer, &common.User{Name: "alice"}, false},
+		{"unknown user", maintainer, nil, false},
+		{"admin", admin, &common.User{Name: "alice"}, true},
+		{"without rbac", nil, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodPut, "/api-keys/1", nil)
+			authorized(tt.grants, tt.user)(c)
+			apiKey, err := getOwnApiKey(c, 1)
+			if !tt.allowed {
+				if assert.NotNil(t, err) {
+					assert.Equal(t, errors.Forbidden, err.GetType())
+				}
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, uint64(1), apiKey.ID)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditlog
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+	"github.com/gin-gonic/gin"
+)
+
+type PaginatedAuditLogs struct {
+	AuditLogs []*models.AuditLog `json:"auditLogs"`
+	Count     int64              `json:"count"`
+}
+
+// @Summary Get list of audit logs
+// @Description GET /audit-logs?resourceType=connection&plugin=github&resourceId=1&actor=admin&since=2023-01-01T00:00:00Z&page=1&pageSize=50
+// @Tags framework/audit-logs
+// @Param resourceType query string false "connection, scope, scope-config, blueprint or api-key"
+// @Param plugin query string false "plugin name"
+// @Param resourceId query string false "resource id"
+// @Param actor query string false "user name or email"
+// @Param action query string false "create, update or delete"
+// @Param since query string false "RFC3339 time, inclusive"
+// @Param until query string false "RFC3339 time, exclusive"
+// @Param page query int false "query"
+// @Param pageSize query int false "query"
+// @Success 200  {object} PaginatedAuditLogs
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /audit-logs [get]
+func GetAuditLogs(c *gin.Context) {
+	var query services.AuditLogQuery
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	auditLogs, count, err := services.GetAuditLogs(&query)
+	if err != nil {
+		shared.ApiOutputAbort(c, errors.Default.Wrap(err, "error getting audit logs"))
+		return
+	}
+	shared.ApiOutputSuccess(c, PaginatedAuditLogs{
+		AuditLogs: auditLogs,
+		Count:     count,
+	}, http.StatusOK)
+}
@@ -0,0 +1,99 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/api/rbac"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Back up the configuration
+// @Description Download the connections, scope configs, scopes, projects and blueprints of the instance, only admins
+// @Description are allowed. The secrets of the connections are left out unless includeSecrets is set, they are then
+// @Description encrypted with the ENCRYPTION_SECRET and can only be restored into an instance sharing it
+// @Tags framework/backup
+// @Param includeSecrets query bool false "include the secrets of the connections"
+// @Success 200  {object} services.Backup
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 401  {string} errcode.Error "Unauthorized"
+// @Failure 403  {string} errcode.Error "Forbidden"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /backup [get]
+func GetBackup(c *gin.Context) {
+	if !rbac.RequireAdmin(c) {
+		return
+	}
+	includeSecrets, _ := strconv.ParseBool(c.Query("includeSecrets"))
+	backup, err := services.CreateBackup(includeSecrets)
+	user, _ := shared.GetUser(c)
+	services.RecordAudit(user, &models.AuditLog{
+		Action:       models.AUDIT_ACTION_EXPORT,
+		ResourceType: models.AUDIT_RESOURCE_BACKUP,
+		Path:         c.Request.URL.Path,
+	}, nil, map[string]interface{}{"includeSecrets": includeSecrets, "succeeded": err == nil})
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error creating the backup"))
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="devlake-backup-%s.json"`, backup.CreatedAt.Format("20060102150405")))
+	c.JSON(http.StatusOK, backup)
+}
+
+// @Summary Restore the configuration
+// @Description Create or update the records of a backup, matching them by name, only admins are allowed. The secrets
+// @Description absent from the backup are kept for the existing connections
+// @Tags framework/backup
+// @Accept application/json
+// @Param backup body services.Backup true "json"
+// @Success 200  {object} services.BackupRestoreResult
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 401  {string} errcode.Error "Unauthorized"
+// @Failure 403  {string} errcode.Error "Forbidden"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /restore [post]
+func PostRestore(c *gin.Context) {
+	if !rbac.RequireAdmin(c) {
+		return
+	}
+	backup := &services.Backup{}
+	if e := c.ShouldBindJSON(backup); e != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(e, shared.BadRequestBody))
+		return
+	}
+	result, err := services.RestoreBackup(backup)
+	user, _ := shared.GetUser(c)
+	services.RecordAudit(user, &models.AuditLog{
+		Action:       models.AUDIT_ACTION_RESTORE,
+		ResourceType: models.AUDIT_RESOURCE_BACKUP,
+		ResourceId:   backup.CreatedAt.Format("20060102150405"),
+		Path:         c.Request.URL.Path,
+	}, nil, result)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error restoring the backup"))
+		return
+	}
+	shared.ApiOutputSuccess(c, result, http.StatusOK)
+}
@@ -0,0 +1,75 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/server/api/rbac"
+	"github.com/apache/incubator-devlake/server/services"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func serveBackup(method string, path string, grants *services.RbacGrants, user *common.User) int {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if user != nil {
+			c.Set(common.USER, user)
+		}
+		if grants != nil {
+			rbac.SetGrants(c, grants)
+		}
+	})
+	router.GET("/backup", GetBackup)
+	router.POST("/restore", PostRestore)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(method, path, strings.NewReader(`{"version": 1}`)))
+	return recorder.Code
+}
+
+func TestBackupRequiresAuthentication(t *testing.T) {
+	assert.Equal(t, http.StatusUnauthorized, serveBackup(http.MethodGet, "/backup", nil, nil))
+	assert.Equal(t, http.StatusUnauthorized, serveBackup(http.MethodGet, "/backup?includeSecrets=true", nil, nil))
+	assert.Equal(t, http.StatusUnauthorized, serveBackup(http.MethodPost, "/restore", nil, nil))
+}
+
+func TestBackupRequiresAdmin(t *testing.T) {
+	user := &common.User{Name: "alice"}
+	maintainer := services.NewRbacGrants([]*models.RoleBinding{
+		{Role: models.ROLE_MAINTAINER, ResourceType: models.RBAC_RESOURCE_GLOBAL},
+	})
+	assert.Equal(t, http.StatusForbidden, serveBackup(http.MethodGet, "/backup", maintainer, user))
+	assert.Equal(t, http.StatusForbidden, serveBackup(http.MethodPost, "/restore", maintainer, user))
+
+	// an admin api key scoped to some projects must not dump the whole instance
+	admin := services.NewRbacGrants([]*models.RoleBinding{
+		{Role: models.ROLE_ADMIN, ResourceType: models.RBAC_RESOURCE_GLOBAL},
+	})
+	restricted := admin.RestrictToProjects([]string{"project1"})
+	assert.Equal(t, http.StatusForbidden, serveBackup(http.MethodGet, "/backup", restricted, user))
+
+	// a user without any binding is not an admin either, even with rbac disabled
+	assert.Equal(t, http.StatusForbidden, serveBackup(http.MethodGet, "/backup", nil, &common.User{}))
+}
@@ -18,15 +18,19 @@ limitations under the License.
 package blueprints
 
 import (
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/api/rbac"
 	"github.com/apache/incubator-devlake/server/api/shared"
 	"github.com/apache/incubator-devlake/server/services"
 
 	"github.com/gin-gonic/gin"
+	"gopkg.in/yaml.v3"
 )
 
 type PaginatedBlueprint struct {
@@ -55,6 +59,7 @@ func Post(c *gin.Context) {
 		shared.ApiOutputError(c, errors.Default.Wrap(err, "error creating blueprint"))
 		return
 	}
+	recordBlueprintAudit(c, models.AUDIT_ACTION_CREATE, blueprint.ID, nil, blueprint)
 	shared.ApiOutputSuccess(c, blueprint, http.StatusCreated)
 }
 
@@ -77,6 +82,7 @@ func Index(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
 		return
 	}
+	query.ProjectNames = rbac.ProjectNames(c)
 	blueprints, count, err := services.GetBlueprints(&query, true)
 	if err != nil {
 		shared.ApiOutputAbort(c, errors.Default.Wrap(err, "error getting blueprints"))
@@ -152,14 +158,49 @@ func Patch(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
 		return
 	}
+	oldBlueprint, _ := services.GetBlueprint(id, true)
 	blueprint, err := services.PatchBlueprint(id, body)
 	if err != nil {
 		shared.ApiOutputError(c, errors.Default.Wrap(err, "error patching the blueprint"))
 		return
 	}
+	recordBlueprintAudit(c, models.AUDIT_ACTION_UPDATE, id, oldBlueprint, blueprint)
 	shared.ApiOutputSuccess(c, blueprint, http.StatusOK)
 }
 
+// @Summary dry-run blueprint
+// @Description expand the plan the blueprint would run, with the changes in the body applied as by PATCH, and estimate
+// @Description the api requests per connection, nothing is executed nor saved
+// @Tags framework/blueprints
+// @Accept application/json
+// @Param blueprintId path string true "blueprintId"
+// @Success 200  {object} services.BlueprintDryRun
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /blueprints/{blueprintId}/dry-run [Post]
+func PostDryRun(c *gin.Context) {
+	blueprintId := c.Param("blueprintId")
+	id, err := strconv.ParseUint(blueprintId, 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad blueprintID format supplied"))
+		return
+	}
+	body := map[string]interface{}{}
+	if c.Request.Body != nil && c.Request.ContentLength != 0 {
+		err = c.ShouldBindJSON(&body)
+		if err != nil {
+			shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+			return
+		}
+	}
+	dryRun, err := services.DryRunBlueprint(id, body)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error dry-running the blueprint"))
+		return
+	}
+	shared.ApiOutputSuccess(c, dryRun, http.StatusOK)
+}
+
 // @Summary trigger blueprint
 // @Description trigger a blueprint immediately
 // @Tags framework/blueprints
@@ -243,10 +284,99 @@ func Delete(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad blueprintId format supplied"))
 		return
 	}
+	oldBlueprint, _ := services.GetBlueprint(id, true)
 	err = services.DeleteBlueprint(id)
 	if err != nil {
 		shared.ApiOutputError(c, errors.Default.Wrap(err, "error deleting blueprint"))
 		return
 	}
+	recordBlueprintAudit(c, models.AUDIT_ACTION_DELETE, id, oldBlueprint, nil)
 	shared.ApiOutputSuccess(c, nil, http.StatusOK)
 }
+
+// @Summary export blueprint
+// @Description export the blueprint with its scopes and scope configs, the connections are referenced by name
+// @Tags framework/blueprints
+// @Param blueprintId path string true "blueprintId"
+// @Param format query string false "yaml (default) or json"
+// @Success 200  {object} services.BlueprintExport
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /blueprints/{blueprintId}/export [get]
+func GetExport(c *gin.Context) {
+	blueprintId := c.Param("blueprintId")
+	id, err := strconv.ParseUint(blueprintId, 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad blueprintID format supplied"))
+		return
+	}
+	export, err := services.ExportBlueprint(id)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error exporting the blueprint"))
+		return
+	}
+	if c.Query("format") == "json" {
+		shared.ApiOutputSuccess(c, export, http.StatusOK)
+		return
+	}
+	content, e := yaml.Marshal(export)
+	if e != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(e, "error encoding the blueprint"))
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="blueprint-%d.yaml"`, id))
+	c.Data(http.StatusOK, "application/yaml", content)
+}
+
+// @Summary import blueprint
+// @Description import a blueprint exported as YAML or JSON, the blueprint of the same name is updated if any.
+// @Description The connections must exist with the same names, the scopes and scope configs are created or updated.
+// @Tags framework/blueprints
+// @Accept application/yaml
+// @Param blueprint body services.BlueprintExport true "yaml or json"
+// @Success 200  {object} models.Blueprint
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /blueprints/import [post]
+func PostImport(c *gin.Context) {
+	content, e := io.ReadAll(c.Request.Body)
+	if e != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(e, shared.BadRequestBody))
+		return
+	}
+	// json is yaml too
+	export := &services.BlueprintExport{}
+	if e = yaml.Unmarshal(content, export); e != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(e, shared.BadRequestBody))
+		return
+	}
+	oldBlueprint, _ := services.GetBlueprintByName(export.Name)
+	blueprint, err := services.ImportBlueprint(export)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error importing the blueprint"))
+		return
+	}
+	action := models.AUDIT_ACTION_CREATE
+	if oldBlueprint != nil {
+		action = models.AUDIT_ACTION_UPDATE
+	}
+	recordBlueprintAudit(c, action, blueprint.ID, oldBlueprint, blueprint)
+	shared.ApiOutputSuccess(c, blueprint, http.StatusOK)
+}
+
+func recordBlueprintAudit(c *gin.Context, action string, blueprintId uint64, oldBlueprint, newBlueprint *models.Blueprint) {
+	user, _ := shared.GetUser(c)
+	var oldValue, newValue interface{}
+	if oldBlueprint != nil {
+		oldValue = oldBlueprint
+	}
+	if newBlueprint != nil {
+		newValue = newBlueprint
+	}
+	services.RecordAudit(user, &models.AuditLog{
+		Action:       action,
+		ResourceType: models.AUDIT_RESOURCE_BLUEPRINT,
+		ResourceId:   strconv.FormatUint(blueprintId, 10),
+		Path:         c.Request.URL.Path,
+	}, oldValue, newValue)
+}
@@ -20,33 +20,110 @@ package api
 import (
 	"encoding/base64"
 	"fmt"
-	"github.com/apache/incubator-devlake/core/log"
+	"net"
 	"net/http"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/apache/incubator-devlake/core/log"
+	"golang.org/x/crypto/bcrypt"
+
 	"github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models/common"
 	"github.com/apache/incubator-devlake/helpers/apikeyhelper"
+	"github.com/apache/incubator-devlake/server/api/shared"
 	"github.com/gin-gonic/gin"
 )
 
-func getOAuthUserInfo(c *gin.Context) (*common.User, error) {
+// forwardedAuth decides which identities asserted by the request itself are believed. The X-Forwarded-User and
+// X-Forwarded-Email headers are set by the authenticating proxy, so once RBAC relies on them they are only accepted
+// from the proxies listed in RBAC_TRUSTED_PROXIES. Basic auth credentials are checked against the bcrypt hashes of
+// RBAC_BASIC_AUTH_USERS, or else only accepted when they were already verified by a trusted proxy.
+type forwardedAuth struct {
+	rbacEnabled    bool
+	trustedProxies []*net.IPNet
+	basicAuthUsers map[string]string
+}
+
+func newForwardedAuth(basicRes context.BasicRes) *forwardedAuth {
+	logger := basicRes.GetLogger()
+	auth := &forwardedAuth{
+		basicAuthUsers: make(map[string]string),
+	}
+	auth.rbacEnabled, _ = strconv.ParseBool(basicRes.GetConfig("RBAC_ENABLED"))
+	for _, proxy := range strings.Split(basicRes.GetConfig("RBAC_TRUSTED_PROXIES"), ",") {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+		if !strings.Contains(proxy, "/") {
+			if strings.Contains(proxy, ":") {
+				proxy += "/128"
+			} else {
+				proxy += "/32"
+			}
+		}
+		_, ipNet, err := net.ParseCIDR(proxy)
+		if err != nil {
+			logger.Warn(err, "ignoring the invalid trusted proxy %s", proxy)
+			continue
+		}
+		auth.trustedProxies = append(auth.trustedProxies, ipNet)
+	}
+	for _, user := range strings.Split(basicRes.GetConfig("RBAC_BASIC_AUTH_USERS"), ",") {
+		name, hash, found := strings.Cut(strings.TrimSpace(user), ":")
+		if found && name != "" && hash != "" {
+			auth.basicAuthUsers[name] = hash
+		}
+	}
+	return auth
+}
+
+// fromTrustedProxy tells whether the request was sent by a trusted proxy. Without RBAC and without any trusted
+// proxy configured the identity headers are only informational and kept trusted as before
+func (a *forwardedAuth) fromTrustedProxy(c *gin.Context) bool {
+	if len(a.trustedProxies) == 0 {
+		return !a.rbacEnabled
+	}
+	host, _, err := net.SplitHostPort(c.Request.RemoteAddr)
+	if err != nil {
+		host = c.Request.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, ipNet := range a.trustedProxies {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *forwardedAuth) getOAuthUserInfo(c *gin.Context) (*common.User, error) {
 	if c == nil {
 		return nil, errors.Default.New("request is nil")
 	}
 	user := c.GetHeader("X-Forwarded-User")
 	email := c.GetHeader("X-Forwarded-Email")
+	if user == "" && email == "" {
+		return nil, nil
+	}
+	if !a.fromTrustedProxy(c) {
+		return nil, errors.Unauthorized.New("the forwarded user is not sent by a trusted proxy")
+	}
 	return &common.User{
 		Name:  user,
 		Email: email,
 	}, nil
 }
 
-func getBasicAuthUserInfo(c *gin.Context, basicRes context.BasicRes) (*common.User, error) {
+func (a *forwardedAuth) getBasicAuthUserInfo(c *gin.Context, basicRes context.BasicRes) (*common.User, error) {
 	if c == nil {
 		return nil, errors.Default.New("request is nil")
 	}
@@ -63,10 +140,17 @@ func getBasicAuthUserInfo(c *gin.Context, basicRes context.BasicRes) (*common.Us
 	if err != nil {
 		return nil, errors.Default.Wrap(err, "base64 decode")
 	}
-	userInfo := strings.Split(string(userInfoData), ":")
+	userInfo := strings.SplitN(string(userInfoData), ":", 2)
 	if len(userInfo) != 2 {
 		return nil, errors.Default.New("invalid user info data")
 	}
+	if hash, ok := a.basicAuthUsers[userInfo[0]]; ok {
+		if bcrypt.CompareHashAndPassword([]byte(hash), []byte(userInfo[1])) != nil {
+			return nil, errors.Unauthorized.New("invalid basic auth credentials")
+		}
+	} else if !a.fromTrustedProxy(c) {
+		return nil, errors.Unauthorized.New("the basic auth credentials can not be verified")
+	}
 	return &common.User{
 		Name: userInfo[0],
 	}, nil
@@ -74,18 +158,26 @@ func getBasicAuthUserInfo(c *gin.Context, basicRes context.BasicRes) (*common.Us
 
 func OAuth2ProxyAuthentication(basicRes context.BasicRes) gin.HandlerFunc {
 	logger := basicRes.GetLogger()
+	auth := newForwardedAuth(basicRes)
 	return func(c *gin.Context) {
 		_, exist := c.Get(common.USER)
+		if apiKey, ok := shared.GetApiKey(c); !exist && ok {
+			c.Set(common.USER, &common.User{
+				Name:  apiKey.Creator.Creator,
+				Email: apiKey.Creator.CreatorEmail,
+			})
+			exist = true
+		}
 		if !exist {
-			user, err := getOAuthUserInfo(c)
+			user, err := auth.getOAuthUserInfo(c)
 			if err != nil {
-				logger.Error(err, "getOAuthUserInfo")
+				logger.Warn(err, "getOAuthUserInfo")
 			}
 			if user == nil || user.Name == "" {
 				// fetch with basic auth header
-				user, err = getBasicAuthUserInfo(c, basicRes)
+				user, err = auth.getBasicAuthUserInfo(c, basicRes)
 				if err != nil {
-					logger.Debug("getBasicAuthUserInfo")
+					logger.Debug("getBasicAuthUserInfo: %s", err)
 				}
 			}
 			if user != nil && user.Name != "" {
@@ -150,18 +242,7 @@ func CheckAuthorizationHeader(c *gin.Context, logger log.Logger, db dal.Dal, api
 		return false
 	}
 
-	hashedApiKey, err := apiKeyHelper.DigestToken(apiKeyStr)
-	if err != nil {
-		logger.Error(err, "DigestToken")
-		c.Abort()
-		c.JSON(http.StatusInternalServerError, &apiBody{
-			Success: false,
-			Message: err.Error(),
-		})
-		return false
-	}
-
-	apiKey, err := apiKeyHelper.GetApiKey(nil, dal.Where("api_key = ?", hashedApiKey))
+	apiKey, err := apiKeyHelper.GetApiKeyByToken(nil, apiKeyStr)
 	if err != nil {
 		c.Abort()
 		if db.IsErrorNotFound(err) {
@@ -204,6 +285,14 @@ func CheckAuthorizationHeader(c *gin.Context, logger log.Logger, db dal.Dal, api
 		})
 		return false
 	}
+	if apiKey.ReadOnly && c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+		c.Abort()
+		c.JSON(http.StatusForbidden, &apiBody{
+			Success: false,
+			Message: "api key is read-only",
+		})
+		return false
+	}
 
 	logger.Info("redirect path: %s to: %s", c.Request.URL.Path, path)
 	c.Request.URL.Path = path
@@ -211,5 +300,6 @@ func CheckAuthorizationHeader(c *gin.Context, logger log.Logger, db dal.Dal, api
 		Name:  apiKey.Creator.Creator,
 		Email: apiKey.Creator.CreatorEmail,
 	})
+	shared.SetApiKey(c, apiKey)
 	return true
 }
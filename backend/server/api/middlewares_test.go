@@ -0,0 +1,208 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/helpers/apikeyhelper"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockcontext "github.com/apache/incubator-devlake/mocks/core/context"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/apache/incubator-devlake/server/services"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func mockAuthBasicRes(configs map[string]string) *mockcontext.BasicRes {
+	basicRes := new(mockcontext.BasicRes)
+	basicRes.On("GetLogger").Return(unithelper.DummyLogger()).Maybe()
+	basicRes.On("GetConfig", mock.Anything).Return(func(name string) string {
+		return configs[name]
+	}).Maybe()
+	return basicRes
+}
+
+func authenticate(basicRes *mockcontext.BasicRes, remoteAddr string, header http.Header) *common.User {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(OAuth2ProxyAuthentication(basicRes))
+	var user *common.User
+	router.GET("/projects", func(c *gin.Context) {
+		if u, ok := c.Get(common.USER); ok {
+			user = u.(*common.User)
+		}
+	})
+	req := httptest.NewRequest(http.MethodGet, "/projects", nil)
+	req.RemoteAddr = remoteAddr
+	req.Header = header
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	return user
+}
+
+func basicAuthHeader(name, password string) http.Header {
+	return http.Header{"Authorization": {"Basic " + base64.StdEncoding.EncodeToString([]byte(name+":"+password))}}
+}
+
+func TestForwardedUserNeedsTrustedProxy(t *testing.T) {
+	forwarded := http.Header{"X-Forwarded-User": {"admin"}, "X-Forwarded-Email": {"admin@example.com"}}
+
+	// without rbac nor trusted proxies the headers are informational and kept as before
+	user := authenticate(mockAuthBasicRes(nil), "10.0.0.1:1234", forwarded)
+	assert.Equal(t, &common.User{Name: "admin", Email: "admin@example.com"}, user)
+
+	rbac := mockAuthBasicRes(map[string]string{"RBAC_ENABLED": "true"})
+	assert.Nil(t, authenticate(rbac, "10.0.0.1:1234", forwarded))
+
+	proxied := mockAuthBasicRes(map[string]string{"RBAC_ENABLED": "true", "RBAC_TRUSTED_PROXIES": "192.168.1.0/24, 10.0.0.2"})
+	assert.Nil(t, authenticate(proxied, "10.0.0.1:1234", forwarded))
+	assert.Equal(t, "admin", authenticate(proxied, "10.0.0.2:1234", forwarded).Name)
+	assert.Equal(t, "admin", authenticate(proxied, "192.168.1.20:1234", forwarded).Name)
+}
+
+func TestBasicAuthIsVerified(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	assert.Nil(t, err)
+	basicRes := mockAuthBasicRes(map[string]string{
+		"RBAC_ENABLED":          "true",
+		"RBAC_TRUSTED_PROXIES":  "10.0.0.2",
+		"RBAC_BASIC_AUTH_USERS": "alice:" + string(hash),
+	})
+
+	assert.Equal(t, "alice", authenticate(basicRes, "10.0.0.1:1234", basicAuthHeader("alice", "secret")).Name)
+	assert.Nil(t, authenticate(basicRes, "10.0.0.1:1234", basicAuthHeader("alice", "wrong")))
+	// a trusted proxy does not override a wrong password of a configured user
+	assert.Nil(t, authenticate(basicRes, "10.0.0.2:1234", basicAuthHeader("alice", "wrong")))
+	// the users not configured are only accepted from the trusted proxies which verified them
+	assert.Nil(t, authenticate(basicRes, "10.0.0.1:1234", basicAuthHeader("bob", "anything")))
+	assert.Equal(t, "bob", authenticate(basicRes, "10.0.0.2:1234", basicAuthHeader("bob", "anything")).Name)
+}
+
+func authorize(grants *services.RbacGrants, method, route, path, body string) bool {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	var allowed bool
+	var handlerBody bytes.Buffer
+	router.Handle(method, route, func(c *gin.Context) {
+		allowed, _ = authorizeRequest(c, grants)
+		_, _ = handlerBody.ReadFrom(c.Request.Body)
+	})
+	req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	if handlerBody.String() != body {
+		panic("the body is not left intact for the handler")
+	}
+	return allowed
+}
+
+func TestAuthorizeProjectScopedBodies(t *testing.T) {
+	grants := services.NewRbacGrants([]*models.RoleBinding{
+		{Role: models.ROLE_MAINTAINER, ResourceType: models.RBAC_RESOURCE_PROJECT, ResourceId: "team-a"},
+		{Role: models.ROLE_VIEWER, ResourceType: models.RBAC_RESOURCE_CONNECTION, Plugin: "github", ResourceId: "1"},
+	})
+
+	tests := []struct {
+		name    string
+		method  string
+		route   string
+		path    string
+		body    string
+		allowed bool
+	}{
+		{"blueprint of the project", http.MethodPost, "/blueprints", "/blueprints",
+			`{"projectName":"team-a","connections":[{"pluginName":"github","connectionId":1}]}`, true},
+		{"blueprint of another project", http.MethodPost, "/blueprints", "/blueprints",
+			`{"projectName":"team-b"}`, false},
+		{"blueprint without project", http.MethodPost, "/blueprints", "/blueprints", `{}`, false},
+		{"blueprint using a hidden connection", http.MethodPost, "/blueprints", "/blueprints",
+			`{"projectName":"team-a","connections":[{"pluginName":"github","connectionId":2}]}`, false},
+		{"project", http.MethodPatch, "/projects/:projectName", "/projects/team-a",
+			`{"name":"team-a","blueprint":{"connections":[{"pluginName":"github","connectionId":1}]}}`, true},
+		{"project renamed", http.MethodPatch, "/projects/:projectName", "/projects/team-a",
+			`{"name":"team-b"}`, false},
+		{"project using a hidden connection", http.MethodPatch, "/projects/:projectName", "/projects/team-a",
+			`{"name":"team-a","blueprint":{"connections":[{"pluginName":"gitlab","connectionId":1}]}}`, false},
+		{"another project", http.MethodPatch, "/projects/:projectName", "/projects/team-b", `{}`, false},
+		{"connection", http.MethodGet, "/plugins/github/connections/:connectionId", "/plugins/github/connections/1", "", true},
+		{"connection modified", http.MethodPatch, "/plugins/github/connections/:connectionId", "/plugins/github/connections/1", `{}`, false},
+		{"pipeline without blueprint", http.MethodPost, "/pipelines", "/pipelines", `{}`, false},
+		{"admin path", http.MethodGet, "/backup", "/backup", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.allowed, authorize(grants, tt.method, tt.route, tt.path, tt.body))
+		})
+	}
+}
+
+func checkApiKey(t *testing.T, apiKey *models.ApiKey, method string) int {
+	t.Setenv("ENCRYPTION_SECRET", "secret")
+	var mockDal *mockdal.Dal
+	basicRes := unithelper.DummyBasicRes(func(m *mockdal.Dal) {
+		m.On("First", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			*args.Get(0).(*models.ApiKey) = *apiKey
+		}).Return(nil)
+		mockDal = m
+	})
+	logger := unithelper.DummyLogger()
+	apiKeyHelper := apikeyhelper.NewApiKeyHelper(basicRes, logger)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Handle(method, "/rest/projects", func(c *gin.Context) {
+		if CheckAuthorizationHeader(c, logger, mockDal, apiKeyHelper, c.GetHeader("Authorization"), "/projects") {
+			c.Status(http.StatusOK)
+		}
+	})
+	req := httptest.NewRequest(method, "/rest/projects", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder.Code
+}
+
+func TestReadOnlyApiKey(t *testing.T) {
+	readOnly := &models.ApiKey{AllowedPath: ".*", ApiKeyScopes: models.ApiKeyScopes{ReadOnly: true}}
+	writable := &models.ApiKey{AllowedPath: ".*"}
+	tests := []struct {
+		method   string
+		readOnly int
+		writable int
+	}{
+		{http.MethodGet, http.StatusOK, http.StatusOK},
+		{http.MethodHead, http.StatusOK, http.StatusOK},
+		{http.MethodPost, http.StatusForbidden, http.StatusOK},
+		{http.MethodPut, http.StatusForbidden, http.StatusOK},
+		{http.MethodPatch, http.StatusForbidden, http.StatusOK},
+		{http.MethodDelete, http.StatusForbidden, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			assert.Equal(t, tt.readOnly, checkApiKey(t, readOnly, tt.method))
+			assert.Equal(t, tt.writable, checkApiKey(t, writable, tt.method))
+		})
+	}
+}
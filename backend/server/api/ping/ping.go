@@ -67,3 +67,20 @@ func Health(c *gin.Context) {
 	}
 	shared.ApiOutputSuccess(c, shared.ApiBody{Success: true, Message: msg}, http.StatusOK)
 }
+
+// @Summary Health details
+// @Description check the database connectivity and latency, the pending migrations, the encryption key and the loaded plugins,
+// @Description along with the age of the last successful sync of each connection. Responds 503 if the service is down.
+// @Description The result is cached for 10 seconds
+// @Tags framework/ping
+// @Success 200  {object} services.HealthDetails
+// @Failure 503  {object} services.HealthDetails
+// @Router /health/details [get]
+func HealthDetails(c *gin.Context) {
+	details := services.GetHealthDetails()
+	status := http.StatusOK
+	if details.Status == services.HEALTH_DOWN {
+		status = http.StatusServiceUnavailable
+	}
+	shared.ApiOutputSuccess(c, details, status)
+}
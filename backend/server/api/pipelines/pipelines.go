@@ -20,12 +20,15 @@ package pipelines
 import (
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/api/rbac"
 	"github.com/apache/incubator-devlake/server/api/shared"
 	"github.com/apache/incubator-devlake/server/services"
+	"io"
 	"net/http"
 	"os"
 	"path/filepath"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
@@ -78,6 +81,7 @@ func Index(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
 		return
 	}
+	query.ProjectNames = rbac.ProjectNames(c)
 	pipelines, count, err := services.GetPipelines(&query, true)
 	if err != nil {
 		shared.ApiOutputError(c, errors.Default.Wrap(err, "error getting pipelines"))
@@ -200,3 +204,103 @@ func PostRerun(c *gin.Context) {
 	}
 	shared.ApiOutputSuccess(c, rerunTasks, http.StatusOK)
 }
+
+// @Summary Pause a running pipeline
+// @Description Pause a running pipeline after the current subtask of its running tasks
+// @Tags framework/pipelines
+// @Param pipelineId path int true "pipeline ID"
+// @Success 200
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /pipelines/{pipelineId}/pause [post]
+func PostPause(c *gin.Context) {
+	pipelineId := c.Param("pipelineId")
+	id, err := strconv.ParseUint(pipelineId, 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad pipelineID format supplied"))
+		return
+	}
+	err = services.PausePipeline(id)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error pausing pipeline"))
+		return
+	}
+	shared.ApiOutputSuccess(c, nil, http.StatusOK)
+}
+
+// @Summary Resume a paused pipeline
+// @Description Resume a paused pipeline, the subtasks finished before the pause are skipped
+// @Tags framework/pipelines
+// @Param pipelineId path int true "pipeline ID"
+// @Success 200
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /pipelines/{pipelineId}/resume [post]
+func PostResume(c *gin.Context) {
+	pipelineId := c.Param("pipelineId")
+	id, err := strconv.ParseUint(pipelineId, 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad pipelineID format supplied"))
+		return
+	}
+	err = services.ResumePipeline(id)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error resuming pipeline"))
+		return
+	}
+	shared.ApiOutputSuccess(c, nil, http.StatusOK)
+}
+
+// @Summary Stream the progress of a pipeline
+// @Description Stream the progress events of a pipeline as server-sent events: subtask started/finished, records processed,
+// @Description task and pipeline finished. The stream ends once the pipeline finished.
+// @Tags framework/pipelines
+// @Produce text/event-stream
+// @Param pipelineId path int true "pipeline ID"
+// @Success 200  {object} services.PipelineEvent
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /pipelines/{pipelineId}/stream [get]
+func GetStream(c *gin.Context) {
+	pipelineId := c.Param("pipelineId")
+	id, err := strconv.ParseUint(pipelineId, 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad pipelineID format supplied"))
+		return
+	}
+	events, unsubscribe := services.SubscribePipelineEvents(id)
+	defer unsubscribe()
+	status, err := services.GetPipelineStatusEvent(id)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error getting pipeline"))
+		return
+	}
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	c.SSEvent(status.Type, status)
+	if status.Type == services.PIPELINE_EVENT_FINISHED {
+		return
+	}
+	// the pipeline may run on another instance, its status is polled to end the stream anyway
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case event := <-events:
+			c.SSEvent(event.Type, event)
+			return event.Type != services.PIPELINE_EVENT_FINISHED
+		case <-ticker.C:
+			latest, err := services.GetPipelineStatusEvent(id)
+			if err != nil {
+				return false
+			}
+			if latest.Status != status.Status || latest.FinishedTasks != status.FinishedTasks {
+				status = latest
+				c.SSEvent(status.Type, status)
+			}
+			return status.Type != services.PIPELINE_EVENT_FINISHED
+		}
+	})
+}
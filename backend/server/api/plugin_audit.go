@@ -0,0 +1,150 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/server/services"
+)
+
+// pluginAuditResource tells whether a plugin resource path is a connection, scope or scope config endpoint, and
+// the name of the param holding the resource id if any
+func pluginAuditResource(resourcePath string) (resourceType string, idParam string, ok bool) {
+	segments := strings.Split(resourcePath, "/")
+	if segments[0] != "connections" {
+		return "", "", false
+	}
+	last := segments[len(segments)-1]
+	if len(segments) > 1 && (strings.HasPrefix(last, ":") || strings.HasPrefix(last, "*")) {
+		idParam = last[1:]
+		segments = segments[:len(segments)-1]
+	}
+	switch {
+	case len(segments) == 1:
+		return models.AUDIT_RESOURCE_CONNECTION, idParam, true
+	case len(segments) == 3 && segments[1] == ":connectionId" && segments[2] == "scopes":
+		return models.AUDIT_RESOURCE_SCOPE, idParam, true
+	case len(segments) == 3 && segments[1] == ":connectionId" && segments[2] == "scope-configs":
+		return models.AUDIT_RESOURCE_SCOPE_CONFIG, idParam, true
+	}
+	return "", "", false
+}
+
+func pluginAuditAction(method string) string {
+	switch method {
+	case http.MethodPost:
+		return models.AUDIT_ACTION_CREATE
+	case http.MethodPut, http.MethodPatch:
+		return models.AUDIT_ACTION_UPDATE
+	case http.MethodDelete:
+		return models.AUDIT_ACTION_DELETE
+	}
+	return ""
+}
+
+// pluginAuditor records the changes made through the connection, scope and scope config endpoints of plugins, the
+// old value is loaded with the GET handler of the same resource before the change is applied
+type pluginAuditor struct {
+	pluginName   string
+	resourceType string
+	idParam      string
+	action       string
+	getHandler   plugin.ApiResourceHandler
+}
+
+func newPluginAuditor(pluginName, resourcePath, method string, getHandler plugin.ApiResourceHandler) *pluginAuditor {
+	action := pluginAuditAction(method)
+	if action == "" {
+		return nil
+	}
+	resourceType, idParam, ok := pluginAuditResource(resourcePath)
+	if !ok {
+		return nil
+	}
+	return &pluginAuditor{
+		pluginName:   pluginName,
+		resourceType: resourceType,
+		idParam:      idParam,
+		action:       action,
+		getHandler:   getHandler,
+	}
+}
+
+func (a *pluginAuditor) loadOldValue(input *plugin.ApiResourceInput) interface{} {
+	if a.action == models.AUDIT_ACTION_CREATE || a.idParam == "" || a.getHandler == nil {
+		return nil
+	}
+	output, err := a.getHandler(&plugin.ApiResourceInput{
+		Params: input.Params,
+		Query:  input.Query,
+		User:   input.User,
+	})
+	if err != nil || output == nil {
+		return nil
+	}
+	return output.Body
+}
+
+func (a *pluginAuditor) record(path string, input *plugin.ApiResourceInput, oldValue interface{}, output *plugin.ApiResourceOutput) {
+	var newValue interface{}
+	if output != nil {
+		if _, isBlob := output.Body.([]byte); !isBlob {
+			newValue = output.Body
+		}
+	}
+	if a.action == models.AUDIT_ACTION_DELETE {
+		// some plugins respond with the deleted record
+		if oldValue == nil {
+			oldValue = newValue
+		}
+		newValue = nil
+	}
+	resourceId := input.Params[a.idParam]
+	if resourceId == "" && a.action == models.AUDIT_ACTION_CREATE {
+		resourceId = auditValueId(newValue)
+	}
+	services.RecordAudit(input.User, &models.AuditLog{
+		Action:       a.action,
+		ResourceType: a.resourceType,
+		Plugin:       a.pluginName,
+		ResourceId:   resourceId,
+		Path:         path,
+	}, oldValue, newValue)
+}
+
+// auditValueId extracts the `id` of the created connection or scope config
+func auditValueId(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	blob, e := json.Marshal(value)
+	if e != nil {
+		return ""
+	}
+	var record map[string]interface{}
+	if json.Unmarshal(blob, &record) != nil || record["id"] == nil {
+		return ""
+	}
+	return fmt.Sprint(record["id"])
+}
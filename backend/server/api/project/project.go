@@ -22,6 +22,7 @@ import (
 
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/api/rbac"
 	"github.com/apache/incubator-devlake/server/api/shared"
 	"github.com/apache/incubator-devlake/server/services"
 
@@ -92,6 +93,7 @@ func GetProjects(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
 		return
 	}
+	query.ProjectNames = rbac.ProjectNames(c)
 	projects, count, err := services.GetProjects(&query)
 	if err != nil {
 		shared.ApiOutputAbort(c, errors.Default.Wrap(err, "error getting projects"))
@@ -163,7 +165,7 @@ func PatchProject(c *gin.Context) {
 }
 
 // @Summary Delete a project
-// @Description Delete a project
+// @Description Delete a project, it is refused while the blueprints of other projects depend on its blueprint
 // @Tags framework/projects
 // @Accept application/json
 // @Success 200
@@ -179,3 +181,20 @@ func DeleteProject(c *gin.Context) {
 	}
 	shared.ApiOutputSuccess(c, nil, http.StatusOK)
 }
+
+// @Summary Provision the grafana dashboards of a project
+// @Description Create or update the grafana folder of the project holding the standard dashboards with their project, board and repo variables bound to the project
+// @Tags framework/projects
+// @Param projectName path string true "project name"
+// @Success 200  {object} grafana.ProvisionResult
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /projects/{projectName}/grafana [post]
+func PostProjectGrafana(c *gin.Context) {
+	result, err := services.ProvisionProjectDashboards(c.Param("projectName"))
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error provisioning grafana dashboards"))
+		return
+	}
+	shared.ApiOutputSuccess(c, result, http.StatusOK)
+}
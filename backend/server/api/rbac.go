@@ -0,0 +1,253 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/api/rbac"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+	"github.com/gin-gonic/gin"
+)
+
+// endpoints managing the instance itself, reserved to admins
+var rbacAdminPaths = []string{
+	"/role-bindings",
+	"/audit-logs",
+	"/retention-policies",
+	"/raw-tables",
+	"/proceed-db-migration",
+	"/push",
+	"/store",
+	"/backup",
+	"/restore",
+	"/search",
+}
+
+// RbacAuthorization checks the role bindings of the user or api key against the requested resource, the projects,
+// blueprints, pipelines and plugin connections are checked individually while anything else needs an admin to be
+// modified. Enforced when RBAC_ENABLED is set, or for the api keys restricted to some projects
+func RbacAuthorization(basicRes context.BasicRes) gin.HandlerFunc {
+	logger := basicRes.GetLogger()
+	return func(c *gin.Context) {
+		apiKey, _ := shared.GetApiKey(c)
+		restricted := apiKey != nil && len(apiKey.Projects) > 0
+		if (!services.RbacEnabled() && !restricted) || c.FullPath() == "" {
+			c.Next()
+			return
+		}
+		grants := services.NewRbacGrants([]*models.RoleBinding{{Role: models.ROLE_ADMIN, ResourceType: models.RBAC_RESOURCE_GLOBAL}})
+		if services.RbacEnabled() {
+			user, _ := shared.GetUser(c)
+			if user == nil && apiKey == nil {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, &apiBody{
+					Success: false,
+					Message: "authentication is required",
+				})
+				return
+			}
+			var err errors.Error
+			grants, err = services.GetRbacGrants(user, apiKey)
+			if err != nil {
+				shared.ApiOutputError(c, err)
+				c.Abort()
+				return
+			}
+		}
+		if restricted {
+			grants = grants.RestrictToProjects(apiKey.Projects)
+		}
+		allowed, err := authorizeRequest(c, grants)
+		if err != nil {
+			shared.ApiOutputError(c, err)
+			c.Abort()
+			return
+		}
+		if !allowed {
+			logger.Debug("%s %s is denied by rbac", c.Request.Method, c.Request.URL.Path)
+			c.AbortWithStatusJSON(http.StatusForbidden, &apiBody{
+				Success: false,
+				Message: "permission denied",
+			})
+			return
+		}
+		rbac.SetGrants(c, grants)
+		c.Next()
+	}
+}
+
+func authorizeRequest(c *gin.Context, grants *services.RbacGrants) (bool, errors.Error) {
+	if grants.IsAdmin() {
+		return true, nil
+	}
+	role := models.ROLE_MAINTAINER
+	if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+		role = models.ROLE_VIEWER
+	}
+	path := c.FullPath()
+	for _, adminPath := range rbacAdminPaths {
+		if path == adminPath || strings.HasPrefix(path, adminPath+"/") {
+			return false, nil
+		}
+	}
+	switch {
+	case path == "/api-keys" || strings.HasPrefix(path, "/api-keys/"):
+		// everyone manages their own api keys, the handlers check the ownership
+		return grants.HasAnyRole(), nil
+	case strings.HasPrefix(path, "/projects/:projectName"):
+		if !grants.HasProjectRole(c.Param("projectName"), role) {
+			return false, nil
+		}
+		if path != "/projects/:projectName" || c.Request.Method != http.MethodPatch {
+			return true, nil
+		}
+		body, err := peekRbacBody(c)
+		if err != nil {
+			return false, err
+		}
+		if body.Name != "" && !grants.HasProjectRole(body.Name, role) {
+			return false, nil
+		}
+		return body.authorizeConnections(grants), nil
+	case path == "/blueprints" && role == models.ROLE_MAINTAINER:
+		body, err := peekRbacBody(c)
+		if err != nil || body.ProjectName == "" {
+			return false, err
+		}
+		return grants.HasProjectRole(body.ProjectName, role) && body.authorizeConnections(grants), nil
+	case strings.HasPrefix(path, "/blueprints/:blueprintId"):
+		allowed, err := authorizeProjectOf(grants, role, c.Param("blueprintId"), services.GetBlueprintProjectName)
+		if err != nil || !allowed || role == models.ROLE_VIEWER {
+			return allowed, err
+		}
+		body, err := peekRbacBody(c)
+		if err != nil {
+			return false, err
+		}
+		// moving the blueprint to another project needs the same role over there
+		if body.ProjectName != "" && !grants.HasProjectRole(body.ProjectName, role) {
+			return false, nil
+		}
+		return body.authorizeConnections(grants), nil
+	case strings.HasPrefix(path, "/pipelines/:pipelineId"):
+		return authorizeProjectOf(grants, role, c.Param("pipelineId"), services.GetPipelineProjectName)
+	case strings.HasPrefix(path, "/tasks/:taskId"):
+		return authorizeProjectOf(grants, role, c.Param("taskId"), services.GetTaskProjectName)
+	case strings.HasPrefix(path, "/plugins/"):
+		segments := strings.Split(strings.TrimPrefix(path, "/plugins/"), "/")
+		if len(segments) > 2 && segments[1] == "connections" && segments[2] == ":connectionId" {
+			return grants.HasConnectionRole(segments[0], c.Param("connectionId"), role), nil
+		}
+	}
+	// the lists are filtered by the handlers, anything else can only be modified by admins
+	return role == models.ROLE_VIEWER && grants.HasAnyRole(), nil
+}
+
+func authorizeProjectOf(grants *services.RbacGrants, role string, id string, getProjectName func(uint64) (string, errors.Error)) (bool, errors.Error) {
+	parsedId, e := strconv.ParseUint(id, 10, 64)
+	if e != nil {
+		return false, errors.BadInput.Wrap(e, fmt.Sprintf("bad id format supplied: %s", id))
+	}
+	projectName, err := getProjectName(parsedId)
+	if err != nil || projectName == "" {
+		return false, err
+	}
+	return grants.HasProjectRole(projectName, role), nil
+}
+
+// rbacBody is the part of the blueprint and project payloads naming the resources they are bound to
+type rbacBody struct {
+	Name        string                        `json:"name"`
+	ProjectName string                        `json:"projectName"`
+	Connections []*models.BlueprintConnection `json:"connections"`
+	Blueprint   *struct {
+		Connections []*models.BlueprintConnection `json:"connections"`
+	} `json:"blueprint"`
+}
+
+// authorizeConnections tells whether every connection the blueprint collects from is visible to the subject
+func (b *rbacBody) authorizeConnections(grants *services.RbacGrants) bool {
+	connections := b.Connections
+	if b.Blueprint != nil {
+		connections = append(connections, b.Blueprint.Connections...)
+	}
+	for _, connection := range connections {
+		if connection == nil {
+			continue
+		}
+		if !grants.HasConnectionRole(connection.PluginName, fmt.Sprint(connection.ConnectionId), models.ROLE_VIEWER) {
+			return false
+		}
+	}
+	return true
+}
+
+// peekRbacBody reads the json body, leaving it intact for the handler
+func peekRbacBody(c *gin.Context) (*rbacBody, errors.Error) {
+	payload := &rbacBody{}
+	if c.Request.Body == nil {
+		return payload, nil
+	}
+	body, e := io.ReadAll(c.Request.Body)
+	if e != nil {
+		return nil, errors.BadInput.Wrap(e, shared.BadRequestBody)
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	_ = json.Unmarshal(body, payload)
+	return payload, nil
+}
+
+// filterRbacConnections keeps the connections of the list visible to the subject of the request
+func filterRbacConnections(c *gin.Context, pluginName string, body interface{}) interface{} {
+	grants := rbac.GetGrants(c)
+	if grants == nil {
+		return body
+	}
+	connectionIds := grants.ConnectionIds(pluginName)
+	if connectionIds == nil {
+		return body
+	}
+	blob, e := json.Marshal(body)
+	if e != nil {
+		return body
+	}
+	var connections []map[string]interface{}
+	if json.Unmarshal(blob, &connections) != nil {
+		return body
+	}
+	visible := make([]map[string]interface{}, 0, len(connections))
+	for _, connection := range connections {
+		for _, connectionId := range connectionIds {
+			if fmt.Sprint(connection["id"]) == connectionId {
+				visible = append(visible, connection)
+				break
+			}
+		}
+	}
+	return visible
+}
@@ -0,0 +1,180 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+	"github.com/gin-gonic/gin"
+)
+
+const grantsKey = "rbacGrants"
+
+type PaginatedRoleBindings struct {
+	RoleBindings []*models.RoleBinding `json:"roleBindings"`
+	Count        int64                 `json:"count"`
+}
+
+// SetGrants attaches the grants of the subject authorized for the request
+func SetGrants(c *gin.Context, grants *services.RbacGrants) {
+	c.Set(grantsKey, grants)
+}
+
+// GetGrants returns the grants of the subject of the request, nil if rbac is disabled
+func GetGrants(c *gin.Context) *services.RbacGrants {
+	grants, exist := c.Get(grantsKey)
+	if !exist {
+		return nil
+	}
+	return grants.(*services.RbacGrants)
+}
+
+// RequireAdmin aborts the request unless its subject is a global admin, it is checked even when rbac is disabled for
+// the endpoints exposing the whole instance. It returns whether the request may proceed
+func RequireAdmin(c *gin.Context) bool {
+	grants := GetGrants(c)
+	if grants == nil {
+		user, _ := shared.GetUser(c)
+		apiKey, _ := shared.GetApiKey(c)
+		if user == nil && apiKey == nil {
+			shared.ApiOutputAbort(c, errors.Unauthorized.New("authentication is required"))
+			return false
+		}
+		var err errors.Error
+		grants, err = services.GetRbacGrants(user, apiKey)
+		if err != nil {
+			shared.ApiOutputAbort(c, err)
+			return false
+		}
+		if apiKey != nil && len(apiKey.Projects) > 0 {
+			grants = grants.RestrictToProjects(apiKey.Projects)
+		}
+	}
+	if !grants.IsAdmin() {
+		shared.ApiOutputAbort(c, errors.Forbidden.New("only admins are allowed"))
+		return false
+	}
+	return true
+}
+
+// ProjectNames returns the projects visible to the subject of the request, nil if all of them are
+func ProjectNames(c *gin.Context) []string {
+	grants := GetGrants(c)
+	if grants == nil {
+		return nil
+	}
+	return grants.ProjectNames()
+}
+
+// @Summary Get list of role bindings
+// @Description GET /role-bindings?subjectType=user&subject=alice&resourceType=project&resourceId=my-project&page=1&pageSize=50
+// @Tags framework/role-bindings
+// @Param subjectType query string false "user or api-key"
+// @Param subject query string false "user name or email, or api key id"
+// @Param resourceType query string false "global, project or connection"
+// @Param plugin query string false "plugin of the connection"
+// @Param resourceId query string false "project name or connection id"
+// @Param page query int false "query"
+// @Param pageSize query int false "query"
+// @Success 200  {object} PaginatedRoleBindings
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /role-bindings [get]
+func GetRoleBindings(c *gin.Context) {
+	var query services.RoleBindingQuery
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	roleBindings, count, err := services.GetRoleBindings(&query)
+	if err != nil {
+		shared.ApiOutputAbort(c, errors.Default.Wrap(err, "error getting role bindings"))
+		return
+	}
+	shared.ApiOutputSuccess(c, PaginatedRoleBindings{
+		RoleBindings: roleBindings,
+		Count:        count,
+	}, http.StatusOK)
+}
+
+// @Summary Bind a role to a user or an api key
+// @Description Bind admin globally, or maintainer and viewer globally, on a project or on a plugin connection
+// @Tags framework/role-bindings
+// @Accept application/json
+// @Param roleBinding body models.RoleBinding true "json"
+// @Success 201  {object} models.RoleBinding
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /role-bindings [post]
+func PostRoleBinding(c *gin.Context) {
+	roleBinding := &models.RoleBinding{}
+	err := c.ShouldBindJSON(roleBinding)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	user, _ := shared.GetUser(c)
+	roleBinding, err = services.CreateRoleBinding(user, roleBinding)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error creating role binding"))
+		return
+	}
+	services.RecordAudit(user, &models.AuditLog{
+		Action:       models.AUDIT_ACTION_CREATE,
+		ResourceType: models.AUDIT_RESOURCE_ROLE_BINDING,
+		ResourceId:   strconv.FormatUint(roleBinding.ID, 10),
+		Path:         c.Request.URL.Path,
+	}, nil, roleBinding)
+	shared.ApiOutputSuccess(c, roleBinding, http.StatusCreated)
+}
+
+// @Summary Delete a role binding
+// @Description Delete a role binding
+// @Tags framework/role-bindings
+// @Success 200
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /role-bindings/{roleBindingId} [delete]
+func DeleteRoleBinding(c *gin.Context) {
+	roleBindingId := c.Param("roleBindingId")
+	id, err := strconv.ParseUint(roleBindingId, 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad roleBindingId format supplied"))
+		return
+	}
+	roleBinding, err := services.DeleteRoleBinding(id)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error deleting role binding"))
+		return
+	}
+	user, _ := shared.GetUser(c)
+	services.RecordAudit(user, &models.AuditLog{
+		Action:       models.AUDIT_ACTION_DELETE,
+		ResourceType: models.AUDIT_RESOURCE_ROLE_BINDING,
+		ResourceId:   roleBindingId,
+		Path:         c.Request.URL.Path,
+	}, roleBinding, nil)
+	shared.ApiOutputSuccess(c, nil, http.StatusOK)
+}
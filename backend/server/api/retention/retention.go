@@ -0,0 +1,125 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package retention
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Get the retention policies
+// @Description The policies apply to a plugin, or to every plugin without a policy of its own when the plugin is empty.
+// @Description RAW_RETENTION_DAYS applies when no policy matches
+// @Tags framework/retention-policies
+// @Success 200  {object} []models.RetentionPolicy
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /retention-policies [get]
+func GetRetentionPolicies(c *gin.Context) {
+	policies, err := services.GetRetentionPolicies()
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error getting retention policies"))
+		return
+	}
+	shared.ApiOutputSuccess(c, policies, http.StatusOK)
+}
+
+// @Summary Create or update a retention policy
+// @Description Keep the rows of the raw layer tables of a plugin for the given days, 0 to keep them forever.
+// @Description The collector states of the pruned tables are reset, so their next collection is a full one
+// @Tags framework/retention-policies
+// @Accept application/json
+// @Param policy body models.RetentionPolicy true "json"
+// @Success 200  {object} models.RetentionPolicy
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /retention-policies [put]
+func PutRetentionPolicy(c *gin.Context) {
+	policy := &models.RetentionPolicy{}
+	err := c.ShouldBindJSON(policy)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	policy, err = services.PutRetentionPolicy(policy)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error saving retention policy"))
+		return
+	}
+	shared.ApiOutputSuccess(c, policy, http.StatusOK)
+}
+
+// @Summary Delete a retention policy
+// @Description Delete a retention policy
+// @Tags framework/retention-policies
+// @Success 200
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /retention-policies/{policyId} [delete]
+func DeleteRetentionPolicy(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("policyId"), 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad policyId format supplied"))
+		return
+	}
+	err = services.DeleteRetentionPolicy(id)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error deleting retention policy"))
+		return
+	}
+	shared.ApiOutputSuccess(c, nil, http.StatusOK)
+}
+
+// @Summary Prune the expired data now
+// @Description Prune the expired rows right away instead of waiting for the janitor
+// @Tags framework/retention-policies
+// @Success 200  {object} []services.RetentionPruneResult
+// @Failure 409  {string} errcode.Error "Pruning by another instance"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /retention-policies/prune [post]
+func PostPrune(c *gin.Context) {
+	results, err := services.PruneExpiredData()
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error pruning expired data"))
+		return
+	}
+	shared.ApiOutputSuccess(c, results, http.StatusOK)
+}
+
+// @Summary Partition the existing raw tables
+// @Description Convert the raw tables created before RAW_TABLE_PARTITION_BY was set into partitioned ones, it may take
+// @Description a while for large tables. PostgreSQL only, refused while pipelines are running
+// @Tags framework/retention-policies
+// @Success 200  {object} []string
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 409  {string} errcode.Error "Pipelines are running"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /raw-tables/partition [post]
+func PostPartitionRawTables(c *gin.Context) {
+	tables, err := services.PartitionRawTables()
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error partitioning raw tables"))
+		return
+	}
+	shared.ApiOutputSuccess(c, tables, http.StatusOK)
+}
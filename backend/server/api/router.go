@@ -29,17 +29,24 @@ import (
 	"github.com/apache/incubator-devlake/server/api/store"
 
 	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/server/api/auditlog"
+	"github.com/apache/incubator-devlake/server/api/backup"
 	"github.com/apache/incubator-devlake/server/api/blueprints"
 	"github.com/apache/incubator-devlake/server/api/domainlayer"
 	"github.com/apache/incubator-devlake/server/api/pipelines"
 	"github.com/apache/incubator-devlake/server/api/plugininfo"
 	"github.com/apache/incubator-devlake/server/api/project"
 	"github.com/apache/incubator-devlake/server/api/push"
+	"github.com/apache/incubator-devlake/server/api/rbac"
+	"github.com/apache/incubator-devlake/server/api/retention"
+	"github.com/apache/incubator-devlake/server/api/search"
+	"github.com/apache/incubator-devlake/server/api/servicecatalog"
 	"github.com/apache/incubator-devlake/server/api/shared"
 	"github.com/apache/incubator-devlake/server/api/task"
 	"github.com/apache/incubator-devlake/server/services"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 )
 
 func RegisterRouter(r *gin.Engine, basicRes context.BasicRes) {
@@ -48,16 +55,25 @@ func RegisterRouter(r *gin.Engine, basicRes context.BasicRes) {
 	r.GET("/pipelines/:pipelineId", pipelines.Get)
 	r.DELETE("/pipelines/:pipelineId", pipelines.Delete)
 	r.GET("/pipelines/:pipelineId/tasks", task.GetTaskByPipeline)
+	r.POST("/pipelines/:pipelineId/tasks/:taskId/subtasks/:subtaskName/rerun", task.PostRerunSubtask)
+	r.GET("/pipelines/:pipelineId/tasks/:taskId/logs", task.GetTaskLogs)
+	r.GET("/pipelines/:pipelineId/tasks/:taskId/logs/download", task.DownloadTaskLogs)
 	r.GET("/pipelines/:pipelineId/subtasks", task.GetSubtaskByPipeline)
 	r.POST("/pipelines/:pipelineId/rerun", pipelines.PostRerun)
+	r.POST("/pipelines/:pipelineId/pause", pipelines.PostPause)
+	r.POST("/pipelines/:pipelineId/resume", pipelines.PostResume)
 	r.GET("/pipelines/:pipelineId/logging.tar.gz", pipelines.DownloadLogs)
+	r.GET("/pipelines/:pipelineId/stream", pipelines.GetStream)
 
 	r.GET("/blueprints", blueprints.Index)
 	r.POST("/blueprints", blueprints.Post)
+	r.POST("/blueprints/import", blueprints.PostImport)
 	r.PATCH("/blueprints/:blueprintId", blueprints.Patch)
 	r.DELETE("/blueprints/:blueprintId", blueprints.Delete)
 	r.GET("/blueprints/:blueprintId", blueprints.Get)
 	r.POST("/blueprints/:blueprintId/trigger", blueprints.Trigger)
+	r.POST("/blueprints/:blueprintId/dry-run", blueprints.PostDryRun)
+	r.GET("/blueprints/:blueprintId/export", blueprints.GetExport)
 	r.GET("/blueprints/:blueprintId/pipelines", blueprints.GetBlueprintPipelines)
 
 	r.POST("/tasks/:taskId/rerun", task.PostRerun)
@@ -74,8 +90,18 @@ func RegisterRouter(r *gin.Engine, basicRes context.BasicRes) {
 	r.GET("/projects/:projectName/check", project.GetProjectCheck)
 	r.PATCH("/projects/:projectName", project.PatchProject)
 	r.DELETE("/projects/:projectName", project.DeleteProject)
+	r.POST("/projects/:projectName/grafana", project.PostProjectGrafana)
 	r.POST("/projects", project.PostProject)
 	r.GET("/projects", project.GetProjects)
+
+	// service catalog api
+	r.GET("/services", servicecatalog.GetServices)
+	r.POST("/services", servicecatalog.PostService)
+	r.GET("/services/:serviceName", servicecatalog.GetService)
+	r.PATCH("/services/:serviceName", servicecatalog.PatchService)
+	r.DELETE("/services/:serviceName", servicecatalog.DeleteService)
+	r.GET("/services/:serviceName/mappings", servicecatalog.GetServiceMappings)
+	r.PUT("/services/:serviceName/mappings", servicecatalog.PutServiceMappings)
 	// on board api
 	r.GET("/store/:storeKey", store.GetStore)
 	r.PUT("/store/:storeKey", store.PutStore)
@@ -85,6 +111,29 @@ func RegisterRouter(r *gin.Engine, basicRes context.BasicRes) {
 	r.POST("/api-keys", apikeys.PostApiKey)
 	r.PUT("/api-keys/:apiKeyId", apikeys.PutApiKey)
 	r.DELETE("/api-keys/:apiKeyId", apikeys.DeleteApiKey)
+	r.POST("/api-keys/:apiKeyId/rotate", apikeys.PostRotateApiKey)
+
+	// audit logs api
+	r.GET("/audit-logs", auditlog.GetAuditLogs)
+
+	// role bindings api
+	r.GET("/role-bindings", rbac.GetRoleBindings)
+	r.POST("/role-bindings", rbac.PostRoleBinding)
+	r.DELETE("/role-bindings/:roleBindingId", rbac.DeleteRoleBinding)
+
+	// retention policies api
+	r.GET("/retention-policies", retention.GetRetentionPolicies)
+	r.PUT("/retention-policies", retention.PutRetentionPolicy)
+	r.DELETE("/retention-policies/:policyId", retention.DeleteRetentionPolicy)
+	r.POST("/retention-policies/prune", retention.PostPrune)
+	r.POST("/raw-tables/partition", retention.PostPartitionRawTables)
+
+	// search api
+	r.GET("/search", search.GetSearch)
+
+	// backup and restore api
+	r.GET("/backup", backup.GetBackup)
+	r.POST("/restore", backup.PostRestore)
 
 	// mount all api resources for all plugins
 	resources, err := services.GetPluginsApiResources()
@@ -103,13 +152,13 @@ func registerPluginEndpoints(r *gin.Engine, basicRes context.BasicRes, pluginNam
 			r.Handle(
 				method,
 				fmt.Sprintf("/plugins/%s/%s", pluginName, resourcePath),
-				handlePluginCall(basicRes, pluginName, h),
+				handlePluginCall(basicRes, pluginName, resourcePath, h, newPluginAuditor(pluginName, resourcePath, method, resourceHandlers[http.MethodGet])),
 			)
 		}
 	}
 }
 
-func handlePluginCall(basicRes context.BasicRes, pluginName string, handler plugin.ApiResourceHandler) func(c *gin.Context) {
+func handlePluginCall(basicRes context.BasicRes, pluginName string, resourcePath string, handler plugin.ApiResourceHandler, auditor *pluginAuditor) func(c *gin.Context) {
 	return func(c *gin.Context) {
 		var err errors.Error
 		input := &plugin.ApiResourceInput{}
@@ -131,14 +180,29 @@ func handlePluginCall(basicRes context.BasicRes, pluginName string, handler plug
 			if strings.HasPrefix(c.Request.Header.Get("Content-Type"), "multipart/form-data;") {
 				input.Request = c.Request
 			} else {
-				shouldBindJSONErr := c.ShouldBindJSON(&input.Body)
+				// keep the raw body around for the handlers verifying signatures
+				shouldBindJSONErr := c.ShouldBindBodyWith(&input.Body, binding.JSON)
 				if shouldBindJSONErr != nil && shouldBindJSONErr.Error() != "EOF" {
 					shared.ApiOutputError(c, shouldBindJSONErr)
 					return
 				}
+				if rawBody, ok := c.Get(gin.BodyBytesKey); ok {
+					input.RawBody, _ = rawBody.([]byte)
+				}
+				input.Request = c.Request
 			}
 		}
+		var oldValue interface{}
+		if auditor != nil {
+			oldValue = auditor.loadOldValue(input)
+		}
 		output, err := handler(input)
+		if err == nil && auditor != nil {
+			auditor.record(c.Request.URL.Path, input, oldValue, output)
+		}
+		if err == nil && output != nil && resourcePath == "connections" && c.Request.Method == http.MethodGet {
+			output.Body = filterRbacConnections(c, pluginName, output.Body)
+		}
 		if err != nil {
 			if output != nil && output.Body != nil {
 				logruslog.Global.Error(err, "")
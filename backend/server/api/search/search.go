@@ -0,0 +1,51 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package search
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Search connections, scopes, scope configs, blueprints and projects
+// @Description GET /search?q=devlake&limit=20, find where a repo or a board is configured across all plugins
+// @Tags framework/search
+// @Param q query string true "keyword"
+// @Param limit query int false "max number of results of each kind, 20 by default"
+// @Success 200  {object} services.SearchResult
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /search [get]
+func GetSearch(c *gin.Context) {
+	var query services.SearchQuery
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	result, err := services.Search(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error searching"))
+		return
+	}
+	shared.ApiOutputSuccess(c, result, http.StatusOK)
+}
@@ -0,0 +1,186 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+	"github.com/gin-gonic/gin"
+)
+
+type PaginatedServices struct {
+	Services []*crossdomain.Service `json:"services"`
+	Count    int64                  `json:"count"`
+}
+
+// @Summary Get list of services
+// @Description GET /services?page=1&pageSize=10&keyword=api
+// @Tags framework/services
+// @Param page query int false "query"
+// @Param pageSize query int false "query"
+// @Param keyword query string false "query"
+// @Success 200  {object} PaginatedServices
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /services [get]
+func GetServices(c *gin.Context) {
+	var query services.ServiceQuery
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	serviceList, count, err := services.GetServices(&query)
+	if err != nil {
+		shared.ApiOutputAbort(c, errors.Default.Wrap(err, "error getting services"))
+		return
+	}
+	shared.ApiOutputSuccess(c, PaginatedServices{
+		Services: serviceList,
+		Count:    count,
+	}, http.StatusOK)
+}
+
+// @Summary Get a service
+// @Description Get a service
+// @Tags framework/services
+// @Param serviceName path string true "service name"
+// @Success 200  {object} crossdomain.Service
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /services/{serviceName} [get]
+func GetService(c *gin.Context) {
+	service, err := services.GetService(c.Param("serviceName"))
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error getting service"))
+		return
+	}
+	shared.ApiOutputSuccess(c, service, http.StatusOK)
+}
+
+// @Summary Create a service
+// @Description Create a service
+// @Tags framework/services
+// @Accept application/json
+// @Param service body crossdomain.Service true "json"
+// @Success 201  {object} crossdomain.Service
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /services [post]
+func PostService(c *gin.Context) {
+	input := &crossdomain.Service{}
+	err := c.ShouldBindJSON(input)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	service, err := services.CreateService(input)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error creating service"))
+		return
+	}
+	shared.ApiOutputSuccess(c, service, http.StatusCreated)
+}
+
+// @Summary Patch a service
+// @Description Patch the description or the owning team of a service
+// @Tags framework/services
+// @Accept application/json
+// @Param serviceName path string true "service name"
+// @Param service body services.ServicePatch true "json"
+// @Success 200  {object} crossdomain.Service
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /services/{serviceName} [patch]
+func PatchService(c *gin.Context) {
+	patch := &services.ServicePatch{}
+	err := c.ShouldBindJSON(patch)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	service, err := services.PatchService(c.Param("serviceName"), patch)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error patching service"))
+		return
+	}
+	shared.ApiOutputSuccess(c, service, http.StatusOK)
+}
+
+// @Summary Delete a service
+// @Description Delete a service and its mappings
+// @Tags framework/services
+// @Param serviceName path string true "service name"
+// @Success 200
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /services/{serviceName} [delete]
+func DeleteService(c *gin.Context) {
+	err := services.DeleteService(c.Param("serviceName"))
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error deleting service"))
+		return
+	}
+	shared.ApiOutputSuccess(c, nil, http.StatusOK)
+}
+
+// @Summary Get the mappings of a service
+// @Description Get the repos, cicd scopes, boards and environments mapped onto a service
+// @Tags framework/services
+// @Param serviceName path string true "service name"
+// @Success 200  {object} []crossdomain.ServiceMapping
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /services/{serviceName}/mappings [get]
+func GetServiceMappings(c *gin.Context) {
+	mappings, err := services.GetServiceMappings(c.Param("serviceName"))
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error getting service mappings"))
+		return
+	}
+	shared.ApiOutputSuccess(c, mappings, http.StatusOK)
+}
+
+// @Summary Replace the mappings of a service
+// @Description Replace the repos, cicd scopes, boards and environments mapped onto a service
+// @Tags framework/services
+// @Accept application/json
+// @Param serviceName path string true "service name"
+// @Param mappings body []services.ServiceMappingInput true "json"
+// @Success 200  {object} []crossdomain.ServiceMapping
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /services/{serviceName}/mappings [put]
+func PutServiceMappings(c *gin.Context) {
+	var inputs []*services.ServiceMappingInput
+	err := c.ShouldBindJSON(&inputs)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	mappings, err := services.PutServiceMappings(c.Param("serviceName"), inputs)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error saving service mappings"))
+		return
+	}
+	shared.ApiOutputSuccess(c, mappings, http.StatusOK)
+}
@@ -18,10 +18,15 @@ limitations under the License.
 package shared
 
 import (
+	"context"
+
+	"github.com/apache/incubator-devlake/core/models"
 	"github.com/apache/incubator-devlake/core/models/common"
 	"github.com/gin-gonic/gin"
 )
 
+type apiKeyContextKey struct{}
+
 func GetUser(c *gin.Context) (*common.User, bool) {
 	userObj, exist := c.Get(common.USER)
 	if !exist {
@@ -30,3 +35,15 @@ func GetUser(c *gin.Context) (*common.User, bool) {
 	user := userObj.(*common.User)
 	return user, true
 }
+
+// SetApiKey attaches the api key authenticating the request, it is kept in the request context since the keys of
+// the gin context are reset when the `/rest` requests are handled again
+func SetApiKey(c *gin.Context, apiKey *models.ApiKey) {
+	c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), apiKeyContextKey{}, apiKey))
+}
+
+// GetApiKey returns the api key authenticating the request if any
+func GetApiKey(c *gin.Context) (*models.ApiKey, bool) {
+	apiKey, ok := c.Request.Context().Value(apiKeyContextKey{}).(*models.ApiKey)
+	return apiKey, ok
+}
@@ -18,8 +18,12 @@ limitations under the License.
 package task
 
 import (
+	"fmt"
+	"io"
 	"net/http"
+	"path/filepath"
 	"strconv"
+	"time"
 
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models"
@@ -118,3 +122,131 @@ func PostRerun(c *gin.Context) {
 	}
 	shared.ApiOutputSuccess(c, task, http.StatusOK)
 }
+
+// PostRerunSubtask rerun a single subtask of the specified task.
+// @Summary rerun a subtask
+// @Description rerun a single subtask of the task, e.g. a failed converter, the raw data collected previously is kept
+// @Tags framework/tasks
+// @Accept application/json
+// @Param pipelineId path int true "pipelineId"
+// @Param taskId path int true "taskId"
+// @Param subtaskName path string true "subtask name"
+// @Success 200  {object} models.Task
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /pipelines/{pipelineId}/tasks/{taskId}/subtasks/{subtaskName}/rerun [post]
+func PostRerunSubtask(c *gin.Context) {
+	pipelineId, err := strconv.ParseUint(c.Param("pipelineId"), 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad pipelineId format supplied"))
+		return
+	}
+	taskId, err := strconv.ParseUint(c.Param("taskId"), 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad taskId format supplied"))
+		return
+	}
+	task, err := services.RerunSubtask(pipelineId, taskId, c.Param("subtaskName"))
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, task, http.StatusOK)
+}
+
+// GetTaskLogs return the log of a task
+// @Summary Get the log of a task
+// @Description get the last lines of the log of a task, or of one of its subtasks. Pass the returned offset to get the
+// @Description lines logged since, or follow=true to stream the lines as plain text until the task finishes
+// @Tags framework/tasks
+// @Param pipelineId path int true "pipelineId"
+// @Param taskId path int true "taskId"
+// @Param subtask query string false "only the lines logged by the subtask"
+// @Param tail query int false "number of the last lines, 1000 by default"
+// @Param offset query int false "offset returned by the previous call"
+// @Param follow query bool false "keep streaming the new lines until the task finishes"
+// @Success 200  {object} services.TaskLogs
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 404  {object} shared.ApiBody "Task or Log file not found"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /pipelines/{pipelineId}/tasks/{taskId}/logs [get]
+func GetTaskLogs(c *gin.Context) {
+	pipelineId, taskId, err := parseTaskPath(c)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	var query services.TaskLogsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	logs, err := services.GetTaskLogs(pipelineId, taskId, &query)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	if c.Query("follow") != "true" {
+		shared.ApiOutputSuccess(c, logs, http.StatusOK)
+		return
+	}
+	c.Header("Content-Type", "text/plain; charset=utf-8")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	c.Stream(func(w io.Writer) bool {
+		for _, line := range logs.Lines {
+			if _, e := fmt.Fprintln(w, line); e != nil {
+				return false
+			}
+		}
+		if logs.Finished {
+			return false
+		}
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case <-ticker.C:
+		}
+		query.Offset = logs.Offset
+		logs, err = services.GetTaskLogs(pipelineId, taskId, &query)
+		return err == nil
+	})
+}
+
+// DownloadTaskLogs download the log file of a task
+// @Summary download the log of a task
+// @Tags framework/tasks
+// @Param pipelineId path int true "pipelineId"
+// @Param taskId path int true "taskId"
+// @Success 200  "The log file"
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 404  {object} shared.ApiBody "Task or Log file not found"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /pipelines/{pipelineId}/tasks/{taskId}/logs/download [get]
+func DownloadTaskLogs(c *gin.Context) {
+	pipelineId, taskId, err := parseTaskPath(c)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	_, path, err := services.GetTaskLogPath(pipelineId, taskId)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	c.FileAttachment(path, filepath.Base(path))
+}
+
+func parseTaskPath(c *gin.Context) (uint64, uint64, errors.Error) {
+	pipelineId, err := strconv.ParseUint(c.Param("pipelineId"), 10, 64)
+	if err != nil {
+		return 0, 0, errors.BadInput.Wrap(err, "bad pipelineId format supplied")
+	}
+	taskId, err := strconv.ParseUint(c.Param("taskId"), 10, 64)
+	if err != nil {
+		return 0, 0, errors.BadInput.Wrap(err, "bad taskId format supplied")
+	}
+	return pipelineId, taskId, nil
+}
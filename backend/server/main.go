@@ -22,6 +22,7 @@ import (
 	"github.com/apache/incubator-devlake/core/plugin"
 	_ "github.com/apache/incubator-devlake/core/version"
 	"github.com/apache/incubator-devlake/server/api"
+	"github.com/apache/incubator-devlake/server/services/grpcplugin"
 )
 
 func main() {
@@ -30,5 +31,6 @@ func main() {
 	if encryptionSecret == "" {
 		panic("ENCRYPTION_SECRET must be set in environment variable or .env file")
 	}
+	defer grpcplugin.Shutdown()
 	api.CreateAndRunApiServer()
 }
@@ -18,6 +18,8 @@ limitations under the License.
 package services
 
 import (
+	"time"
+
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models"
@@ -28,6 +30,8 @@ import (
 // ApiKeysQuery used to query api keys as the api key input
 type ApiKeysQuery struct {
 	Pagination
+	// Creator restricts the result to the api keys of the user unless empty
+	Creator string `form:"-"`
 }
 
 // GetApiKeys returns a paginated list of api keys based on `query`
@@ -40,6 +44,9 @@ func GetApiKeys(query *ApiKeysQuery) ([]*models.ApiKey, int64, errors.Error) {
 		dal.From(&models.ApiKey{}),
 		dal.Where("type = ?", "devlake"),
 	}
+	if query.Creator != "" {
+		clauses = append(clauses, dal.Where("creator = ?", query.Creator))
+	}
 
 	logger.Info("query: %+v", query)
 	count, err := db.Count(clauses...)
@@ -64,6 +71,17 @@ func GetApiKeys(query *ApiKeysQuery) ([]*models.ApiKey, int64, errors.Error) {
 	return apiKeys, count, nil
 }
 
+// GetApiKey returns the api key with the given id, the hashed api key is removed
+func GetApiKey(id uint64) (*models.ApiKey, errors.Error) {
+	apiKeyHelper := apikeyhelper.NewApiKeyHelper(basicRes, logger)
+	apiKey, err := apiKeyHelper.GetApiKey(db, dal.Where("id = ?", id))
+	if err != nil {
+		return nil, err
+	}
+	apiKey.RemoveHashedApiKey()
+	return apiKey, nil
+}
+
 func DeleteApiKey(id uint64) errors.Error {
 	// verify input
 	if id == 0 {
@@ -93,6 +111,23 @@ func PutApiKey(user *common.User, id uint64) (*models.ApiOutputApiKey, errors.Er
 	return apiKey, nil
 }
 
+// RotateApiKey replaces the api key with a new one, the replaced key keeps working during the grace period
+func RotateApiKey(user *common.User, id uint64, input *models.ApiInputRotateApiKey) (*models.ApiOutputApiKey, errors.Error) {
+	if id == 0 {
+		return nil, errors.BadInput.New("api key's id is missing")
+	}
+	if err := VerifyStruct(input); err != nil {
+		return nil, err
+	}
+	apiKeyHelper := apikeyhelper.NewApiKeyHelper(basicRes, logger)
+	apiKey, err := apiKeyHelper.Rotate(user, id, time.Duration(input.GracePeriodSeconds)*time.Second)
+	if err != nil {
+		logger.Error(err, "api key helper rotate: %d", id)
+		return nil, err
+	}
+	return apiKey, nil
+}
+
 // CreateApiKey accepts an api key instance and insert it to database
 func CreateApiKey(user *common.User, apiKeyInput *models.ApiInputApiKey) (*models.ApiOutputApiKey, errors.Error) {
 	// verify input
@@ -103,7 +138,7 @@ func CreateApiKey(user *common.User, apiKeyInput *models.ApiInputApiKey) (*model
 
 	apiKeyHelper := apikeyhelper.NewApiKeyHelper(basicRes, logger)
 	tx := basicRes.GetDal().Begin()
-	apiKey, err := apiKeyHelper.Create(tx, user, apiKeyInput.Name, apiKeyInput.ExpiredAt, apiKeyInput.AllowedPath, apiKeyInput.Type, "")
+	apiKey, err := apiKeyHelper.CreateWithScopes(tx, user, apiKeyInput.Name, apiKeyInput.ExpiredAt, apiKeyInput.AllowedPath, apiKeyInput.Type, "", apiKeyInput.ApiKeyScopes)
 	if err != nil {
 		if err := tx.Rollback(); err != nil {
 			logger.Error(err, "transaction Rollback")
@@ -0,0 +1,354 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/dbhelper"
+	"github.com/spf13/cast"
+)
+
+// BACKUP_VERSION is the version of the backup format, backups of a newer version are refused
+const BACKUP_VERSION = 1
+
+// Backup holds the configuration of the instance, it references the records by name so that it can be restored
+// into another instance
+type Backup struct {
+	Version      int                            `json:"version" yaml:"version"`
+	CreatedAt    time.Time                      `json:"createdAt" yaml:"createdAt"`
+	Connections  []*BackupConnection            `json:"connections" yaml:"connections"`
+	ScopeConfigs []*ExportedScopeConfig         `json:"scopeConfigs" yaml:"scopeConfigs"`
+	Scopes       []*ExportedBlueprintConnection `json:"scopes" yaml:"scopes"`
+	Projects     []*BackupProject               `json:"projects" yaml:"projects"`
+	Blueprints   []*BlueprintExport             `json:"blueprints" yaml:"blueprints"`
+}
+
+// BackupConnection is a connection without its secrets, they are only included on demand and encrypted with the
+// ENCRYPTION_SECRET, so that they can only be restored into an instance sharing it
+type BackupConnection struct {
+	PluginName string                 `json:"pluginName" yaml:"pluginName"`
+	Name       string                 `json:"name" yaml:"name"`
+	Data       map[string]interface{} `json:"data" yaml:"data"`
+	Secrets    string                 `json:"secrets,omitempty" yaml:"secrets,omitempty"`
+}
+
+// BackupProject is a project with its metrics, its blueprint is in the blueprints of the backup
+type BackupProject struct {
+	Name        string               `json:"name" yaml:"name"`
+	Description string               `json:"description" yaml:"description"`
+	Metrics     []*models.BaseMetric `json:"metrics" yaml:"metrics"`
+}
+
+// BackupRestoreResult counts the restored records
+type BackupRestoreResult struct {
+	Connections  int `json:"connections"`
+	ScopeConfigs int `json:"scopeConfigs"`
+	Scopes       int `json:"scopes"`
+	Projects     int `json:"projects"`
+	Blueprints   int `json:"blueprints"`
+}
+
+// CreateBackup returns the connections, scope configs, scopes, projects and blueprints of the instance, the secrets
+// of the connections are left out unless includeSecrets is set
+func CreateBackup(includeSecrets bool) (*Backup, errors.Error) {
+	backup := &Backup{Version: BACKUP_VERSION, CreatedAt: time.Now()}
+	pluginNames := make([]string, 0)
+	for pluginName, pluginMeta := range plugin.AllPlugins() {
+		if _, ok := pluginMeta.(plugin.PluginSource); ok {
+			pluginNames = append(pluginNames, pluginName)
+		}
+	}
+	sort.Strings(pluginNames)
+	for _, pluginName := range pluginNames {
+		if err := backupPlugin(backup, pluginName, includeSecrets); err != nil {
+			return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to back up plugin %s", pluginName))
+		}
+	}
+
+	projects := make([]*models.Project, 0)
+	if err := db.All(&projects, dal.Orderby("name")); err != nil {
+		return nil, err
+	}
+	for _, project := range projects {
+		metrics := make([]*models.ProjectMetricSetting, 0)
+		if err := db.All(&metrics, dal.Where("project_name = ?", project.Name), dal.Orderby("plugin_name")); err != nil {
+			return nil, err
+		}
+		backupProject := &BackupProject{Name: project.Name, Description: project.Description}
+		for _, metric := range metrics {
+			backupProject.Metrics = append(backupProject.Metrics, &metric.BaseMetric)
+		}
+		backup.Projects = append(backup.Projects, backupProject)
+	}
+
+	var blueprintIds []uint64
+	if err := db.Pluck("id", &blueprintIds, dal.From(&models.Blueprint{}), dal.Orderby("id")); err != nil {
+		return nil, err
+	}
+	for _, blueprintId := range blueprintIds {
+		export, err := ExportBlueprint(blueprintId)
+		if err != nil {
+			return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to back up blueprint %d", blueprintId))
+		}
+		backup.Blueprints = append(backup.Blueprints, export)
+	}
+	return backup, nil
+}
+
+func backupPlugin(backup *Backup, pluginName string, includeSecrets bool) errors.Error {
+	pluginSrc, err := getPluginSource(pluginName)
+	if err != nil {
+		return err
+	}
+	connectionNames := make(map[uint64]string)
+	connections, err := allToolModels(pluginSrc.Connection())
+	if err != nil {
+		return err
+	}
+	for _, connection := range connections {
+		backupConnection, err := newBackupConnection(pluginName, toExportData(connection), includeSecrets)
+		if err != nil {
+			return err
+		}
+		connectionNames[cast.ToUint64(toRecordData(connection)["id"])] = backupConnection.Name
+		backup.Connections = append(backup.Connections, backupConnection)
+	}
+
+	scopeConfigNames := make(map[uint64]string)
+	if pluginSrc.ScopeConfig() != nil {
+		scopeConfigs, err := allToolModels(pluginSrc.ScopeConfig())
+		if err != nil {
+			return err
+		}
+		for _, scopeConfig := range scopeConfigs {
+			record := toRecordData(scopeConfig)
+			data := toExportData(scopeConfig)
+			name := cast.ToString(data["name"])
+			delete(data, "name")
+			scopeConfigNames[cast.ToUint64(record["id"])] = name
+			backup.ScopeConfigs = append(backup.ScopeConfigs, &ExportedScopeConfig{
+				PluginName:     pluginName,
+				ConnectionName: connectionNames[cast.ToUint64(record["connectionId"])],
+				Name:           name,
+				Data:           data,
+			})
+		}
+	}
+
+	scopes, err := allToolModels(pluginSrc.Scope())
+	if err != nil {
+		return err
+	}
+	scopesByConnection := make(map[uint64]*ExportedBlueprintConnection)
+	for _, record := range scopes {
+		scope, ok := record.(plugin.ToolLayerScope)
+		if !ok {
+			return errors.Default.New(fmt.Sprintf("scope of plugin %s is not a ToolLayerScope", pluginName))
+		}
+		connectionName, ok := connectionNames[scope.ScopeConnectionId()]
+		if !ok {
+			// the connection of the scope is gone
+			continue
+		}
+		exported, ok := scopesByConnection[scope.ScopeConnectionId()]
+		if !ok {
+			exported = &ExportedBlueprintConnection{PluginName: pluginName, ConnectionName: connectionName}
+			scopesByConnection[scope.ScopeConnectionId()] = exported
+			backup.Scopes = append(backup.Scopes, exported)
+		}
+		exported.Scopes = append(exported.Scopes, &ExportedScope{
+			ScopeId:     scope.ScopeId(),
+			ScopeConfig: scopeConfigNames[scope.ScopeScopeConfigId()],
+			Data:        toExportData(scope),
+		})
+	}
+	return nil
+}
+
+// RestoreBackup creates or updates the records of the backup, matching them by name. The connections are restored
+// first since everything else depends on them, then the scope configs, the scopes, the projects and the blueprints
+func RestoreBackup(backup *Backup) (*BackupRestoreResult, errors.Error) {
+	if backup.Version == 0 || backup.Version > BACKUP_VERSION {
+		return nil, errors.BadInput.New(fmt.Sprintf("unsupported backup version %d", backup.Version))
+	}
+	result := &BackupRestoreResult{}
+	for _, connection := range backup.Connections {
+		if err := restoreConnection(connection); err != nil {
+			return result, errors.Default.Wrap(err, fmt.Sprintf("failed to restore connection %s of plugin %s", connection.Name, connection.PluginName))
+		}
+		result.Connections++
+	}
+
+	_, err := importToolLayer(&BlueprintExport{ScopeConfigs: backup.ScopeConfigs, Connections: backup.Scopes})
+	if err != nil {
+		return result, errors.Default.Wrap(err, "failed to restore the scopes")
+	}
+	result.ScopeConfigs = len(backup.ScopeConfigs)
+	for _, connection := range backup.Scopes {
+		result.Scopes += len(connection.Scopes)
+	}
+
+	for _, project := range backup.Projects {
+		if err := restoreProject(project); err != nil {
+			return result, errors.Default.Wrap(err, fmt.Sprintf("failed to restore project %s", project.Name))
+		}
+		result.Projects++
+	}
+
+	// the blueprints may depend on each other, create them all before restoring the dependencies
+	for _, export := range backup.Blueprints {
+		independent := *export
+		independent.DependsOn = nil
+		if _, err := ImportBlueprint(&independent); err != nil {
+			return result, errors.Default.Wrap(err, fmt.Sprintf("failed to restore blueprint %s", export.Name))
+		}
+		result.Blueprints++
+	}
+	for _, export := range backup.Blueprints {
+		if len(export.DependsOn) == 0 {
+			continue
+		}
+		if _, err := ImportBlueprint(export); err != nil {
+			return result, errors.Default.Wrap(err, fmt.Sprintf("failed to restore the dependencies of blueprint %s", export.Name))
+		}
+	}
+	return result, nil
+}
+
+// newBackupConnection moves the secrets out of the exported fields of the connection, they are kept encrypted with
+// the ENCRYPTION_SECRET when includeSecrets is set and dropped otherwise
+func newBackupConnection(pluginName string, data map[string]interface{}, includeSecrets bool) (*BackupConnection, errors.Error) {
+	secrets := make(map[string]interface{})
+	for key, value := range data {
+		if isBackupSecret(key, value) {
+			secrets[key] = value
+			delete(data, key)
+		}
+	}
+	backupConnection := &BackupConnection{PluginName: pluginName, Name: cast.ToString(data["name"]), Data: data}
+	if includeSecrets && len(secrets) > 0 {
+		encrypted, err := plugin.Encrypt(cfg.GetString(plugin.EncodeKeyEnvStr), string(errors.Must1(json.Marshal(secrets))))
+		if err != nil {
+			return nil, err
+		}
+		backupConnection.Secrets = encrypted
+	}
+	return backupConnection, nil
+}
+
+// isBackupSecret tells whether the field of a connection is a secret, the values of the fields named like secrets
+// are, and so are the urls embedding a password like the proxies
+func isBackupSecret(key string, value interface{}) bool {
+	if isAuditEmpty(value) {
+		return false
+	}
+	return isAuditSecretKey(key) || redactAuditValue(value) != string(errors.Must1(json.Marshal(value)))
+}
+
+// restoredConnectionData returns the fields of the connection to restore, the secrets left out of the backup are
+// absent so that the ones of an existing connection are kept
+func restoredConnectionData(backupConnection *BackupConnection) (map[string]interface{}, errors.Error) {
+	data := make(map[string]interface{}, len(backupConnection.Data))
+	for key, value := range backupConnection.Data {
+		data[key] = value
+	}
+	if backupConnection.Secrets == "" {
+		return data, nil
+	}
+	decrypted, err := plugin.Decrypt(cfg.GetString(plugin.EncodeKeyEnvStr), backupConnection.Secrets)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "the secrets were encrypted with another ENCRYPTION_SECRET")
+	}
+	secrets := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(decrypted), &secrets); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid secrets")
+	}
+	for key, value := range secrets {
+		data[key] = value
+	}
+	return data, nil
+}
+
+func restoreConnection(backupConnection *BackupConnection) errors.Error {
+	pluginSrc, err := getPluginSource(backupConnection.PluginName)
+	if err != nil {
+		return err
+	}
+	data, err := restoredConnectionData(backupConnection)
+	if err != nil {
+		return err
+	}
+	connection := newToolModel(pluginSrc.Connection())
+	err = db.First(connection, dal.From(pluginSrc.Connection().TableName()), dal.Where("name = ?", backupConnection.Name))
+	if err != nil && !db.IsErrorNotFound(err) {
+		return err
+	}
+	fields := map[string]interface{}{"name": backupConnection.Name}
+	if err == nil {
+		fields["id"] = toRecordData(connection)["id"]
+	}
+	// decoded onto the existing connection so that the fields absent from the backup are left untouched
+	if err := decodeToolModel(fromExportData(data, fields), connection); err != nil {
+		return err
+	}
+	return db.CreateOrUpdate(connection)
+}
+
+func restoreProject(backupProject *BackupProject) (err errors.Error) {
+	txHelper := dbhelper.NewTxHelper(basicRes, &err)
+	defer txHelper.End()
+	tx := txHelper.Begin()
+	project := &models.Project{}
+	project.Name = backupProject.Name
+	project.Description = backupProject.Description
+	if err := tx.CreateOrUpdate(project); err != nil {
+		return err
+	}
+	projectInput := &models.ApiInputProject{Metrics: backupProject.Metrics}
+	projectInput.Name = backupProject.Name
+	return refreshProjectMetrics(tx, projectInput)
+}
+
+// allToolModels loads all the records of the model
+func allToolModels(model dal.Tabler) ([]interface{}, errors.Error) {
+	records := reflect.New(reflect.SliceOf(reflect.TypeOf(newToolModel(model))))
+	if err := db.All(records.Interface(), dal.From(model.TableName())); err != nil {
+		return nil, err
+	}
+	result := make([]interface{}, records.Elem().Len())
+	for i := range result {
+		result[i] = records.Elem().Index(i).Interface()
+	}
+	return result, nil
+}
+
+// toRecordData returns all the fields of the record
+func toRecordData(record interface{}) map[string]interface{} {
+	data := make(map[string]interface{})
+	_ = json.Unmarshal(errors.Must1(json.Marshal(record)), &data)
+	return data
+}
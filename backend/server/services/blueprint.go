@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/services"
 
@@ -46,6 +47,8 @@ type BlueprintQuery struct {
 	Label    string `form:"label"`
 	// isManual must be omitted or `null` for type to take effect
 	Type string `form:"type" enums:"ALL,MANUAL,DAILY,WEEKLY,MONTHLY,CUSTOM" validate:"oneof=ALL MANUAL DAILY WEEKLY MONTHLY CUSTOM"`
+	// ProjectNames restricts the result to the blueprints of the given projects unless nil
+	ProjectNames []string `form:"-"`
 }
 
 type BlueprintJob struct {
@@ -54,6 +57,18 @@ type BlueprintJob struct {
 
 func (bj BlueprintJob) Run() {
 	blueprint := bj.Blueprint
+	if until, ok := blueprintBlackoutUntil(blueprint, time.Now()); ok {
+		scheduleCatchUp(blueprint.ID, until)
+		return
+	}
+	if dependencyId, err := unsucceededDependency(blueprint); err != nil || dependencyId != 0 {
+		if err != nil {
+			blueprintLog.Error(err, fmt.Sprintf("failed to check the dependencies of blueprint:[%d][%s]", blueprint.ID, blueprint.Name))
+		} else {
+			blueprintLog.Info("skip blueprint:[%d][%s], the latest pipeline of blueprint %d it depends on did not succeed", blueprint.ID, blueprint.Name, dependencyId)
+		}
+		return
+	}
 	pipeline, err := createPipelineByBlueprint(blueprint, &blueprint.SyncPolicy)
 	if err == ErrEmptyPlan {
 		blueprintLog.Info("Empty plan, blueprint id:[%d] blueprint name:[%s]", blueprint.ID, blueprint.Name)
@@ -75,12 +90,13 @@ func CreateBlueprint(blueprint *models.Blueprint) errors.Error {
 // GetBlueprints returns a paginated list of Blueprints based on `query`
 func GetBlueprints(query *BlueprintQuery, shouldSanitize bool) ([]*models.Blueprint, int64, errors.Error) {
 	blueprints, count, err := bpManager.GetDbBlueprints(&services.GetBlueprintQuery{
-		Enable:      query.Enable,
-		IsManual:    query.IsManual,
-		Label:       query.Label,
-		SkipRecords: query.GetSkip(),
-		PageSize:    query.GetPageSize(),
-		Type:        query.Type,
+		Enable:       query.Enable,
+		IsManual:     query.IsManual,
+		Label:        query.Label,
+		SkipRecords:  query.GetSkip(),
+		PageSize:     query.GetPageSize(),
+		Type:         query.Type,
+		ProjectNames: query.ProjectNames,
 	})
 	if err != nil {
 		return nil, 0, err
@@ -130,6 +146,19 @@ func GetBlueprintByProjectName(projectName string) (*models.Blueprint, errors.Er
 	return blueprint, nil
 }
 
+// GetBlueprintByName returns the sanitized blueprint with the given name, nil if there is none
+func GetBlueprintByName(name string) (*models.Blueprint, errors.Error) {
+	blueprint := &models.Blueprint{}
+	err := db.First(blueprint, dal.Where("name = ?", name))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Internal.Wrap(err, fmt.Sprintf("error getting the blueprint %s from database", name))
+	}
+	return GetBlueprint(blueprint.ID, true)
+}
+
 func validateBlueprintAndMakePlan(blueprint *models.Blueprint) errors.Error {
 	// validation
 	err := vld.Struct(blueprint)
@@ -158,8 +187,22 @@ func validateBlueprintAndMakePlan(blueprint *models.Blueprint) errors.Error {
 	if strings.ToLower(blueprint.CronConfig) == "manual" {
 		blueprint.IsManual = true
 	}
+	if e := validateBlueprintSchedule(blueprint); e != nil {
+		return e
+	}
+	if e := validateBlueprintDependencies(blueprint); e != nil {
+		return e
+	}
+	for _, notification := range blueprint.Notifications {
+		if e := notification.Validate(); e != nil {
+			return e
+		}
+		if notification.IsSanitized() {
+			return errors.BadInput.New(fmt.Sprintf("%s notification: the url and the secret can not be masked", notification.Type))
+		}
+	}
 	if !blueprint.IsManual {
-		_, err = cron.ParseStandard(blueprint.CronConfig)
+		_, err = cron.ParseStandard(blueprintCronSpec(blueprint))
 		if err != nil {
 			return errors.Default.Wrap(err, "invalid cronConfig")
 		}
@@ -207,10 +250,14 @@ func PatchBlueprint(id uint64, body map[string]interface{}) (*models.Blueprint,
 	}
 
 	originMode := blueprint.Mode
+	originNotifications := blueprint.Notifications
 	err = helper.DecodeMapStruct(body, blueprint, true)
 	if err != nil {
 		return nil, err
 	}
+	for _, notification := range blueprint.Notifications {
+		notification.RestoreSanitized(originNotifications)
+	}
 
 	// make sure mode is not being updated
 	if originMode != blueprint.Mode {
@@ -248,6 +295,9 @@ func DeleteBlueprint(id uint64) errors.Error {
 	if pipelinesAreUnfinished {
 		return errors.Default.New("There are unfinished pipelines in the current project. It cannot be deleted at this time.")
 	}
+	if err = checkNoDependentBlueprints(bp.ID); err != nil {
+		return err
+	}
 	err = bpManager.DeleteBlueprint(bp.ID)
 	if err != nil {
 		return errors.Default.Wrap(err, "Failed to delete the blueprint")
@@ -255,6 +305,18 @@ func DeleteBlueprint(id uint64) errors.Error {
 	return nil
 }
 
+// checkNoDependentBlueprints refuses to delete the blueprint other blueprints depend on
+func checkNoDependentBlueprints(blueprintId uint64) errors.Error {
+	dependents, err := bpManager.GetDependentBlueprintIds(blueprintId)
+	if err != nil {
+		return err
+	}
+	if len(dependents) > 0 {
+		return errors.BadInput.New(fmt.Sprintf("Blueprints %v depend on the blueprint. It cannot be deleted until they are updated.", dependents))
+	}
+	return nil
+}
+
 var blueprintReloadLock sync.Mutex
 var bpCronIdMap map[uint64]cron.EntryID
 
@@ -298,12 +360,12 @@ func reloadBlueprint(blueprint *models.Blueprint) errors.Error {
 		logger.Info("removed blueprint %d from cronjobs, cron id: %v", blueprint.ID, cronId)
 	}
 	if blueprint.Enable && !blueprint.IsManual {
-		if cronId, err := cronManager.AddJob(blueprint.CronConfig, &BlueprintJob{blueprint}); err != nil {
+		if cronId, err := cronManager.AddJob(blueprintCronSpec(blueprint), &BlueprintJob{blueprint}); err != nil {
 			blueprintLog.Error(err, failToCreateCronJob)
 			return errors.Default.Wrap(err, "created cron job failed")
 		} else {
 			bpCronIdMap[blueprint.ID] = cronId
-			logger.Info("added blueprint %d to cronjobs, cron id: %v, cron config: %s", blueprint.ID, cronId, blueprint.CronConfig)
+			logger.Info("added blueprint %d to cronjobs, cron id: %v, cron config: %s", blueprint.ID, cronId, blueprintCronSpec(blueprint))
 		}
 	}
 	return nil
@@ -410,6 +472,18 @@ func SequentializePipelinePlans(plans ...models.PipelinePlan) models.PipelinePla
 	return merged
 }
 
+// manualPipelinePriority raises the priority to at least MANUAL_PIPELINE_PRIORITY, so manual pipelines jump ahead of the scheduled ones
+func manualPipelinePriority(priority int) int {
+	manualPriority := models.PIPELINE_PRIORITY_HIGH
+	if cfg.IsSet("MANUAL_PIPELINE_PRIORITY") {
+		manualPriority = cfg.GetInt("MANUAL_PIPELINE_PRIORITY")
+	}
+	if priority < manualPriority {
+		return manualPriority
+	}
+	return priority
+}
+
 // TriggerBlueprint triggers blueprint immediately
 func TriggerBlueprint(id uint64, triggerSyncPolicy *models.TriggerSyncPolicy, shouldSanitize bool) (*models.Pipeline, errors.Error) {
 	// load record from db
@@ -423,6 +497,7 @@ func TriggerBlueprint(id uint64, triggerSyncPolicy *models.TriggerSyncPolicy, sh
 	}
 	blueprint.SkipCollectors = triggerSyncPolicy.SkipCollectors
 	blueprint.FullSync = triggerSyncPolicy.FullSync
+	blueprint.Priority = manualPipelinePriority(blueprint.Priority)
 	pipeline, err := createPipelineByBlueprint(blueprint, &models.SyncPolicy{
 		SkipOnFail:        false,
 		TimeAfter:         nil,
@@ -0,0 +1,132 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"fmt"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+)
+
+func loadBlueprintDependencies(blueprintId uint64) ([]uint64, errors.Error) {
+	var dependsOn []uint64
+	err := db.Pluck("depends_on_id", &dependsOn, dal.From(&models.BlueprintDependency{}), dal.Where("blueprint_id = ?", blueprintId))
+	return dependsOn, err
+}
+
+// validateBlueprintDependencies makes sure the blueprints depended on exist and don't depend on the blueprint in turn
+func validateBlueprintDependencies(blueprint *models.Blueprint) errors.Error {
+	seen := make(map[uint64]bool, len(blueprint.DependsOn))
+	for _, id := range blueprint.DependsOn {
+		if id == blueprint.ID {
+			return errors.BadInput.New("a blueprint can not depend on itself")
+		}
+		if seen[id] {
+			return errors.BadInput.New(fmt.Sprintf("blueprint %d is listed in dependsOn more than once", id))
+		}
+		seen[id] = true
+		count, err := db.Count(dal.From(&models.Blueprint{}), dal.Where("id = ?", id))
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return errors.BadInput.New(fmt.Sprintf("blueprint %d in dependsOn not found", id))
+		}
+	}
+	// a new blueprint can not be depended on yet
+	if blueprint.ID == 0 {
+		return nil
+	}
+	return checkDependencyCycle(blueprint.ID, blueprint.DependsOn, loadBlueprintDependencies)
+}
+
+// checkDependencyCycle walks the dependencies of the blueprint, reaching the blueprint again means a cycle
+func checkDependencyCycle(blueprintId uint64, dependsOn []uint64, load func(uint64) ([]uint64, errors.Error)) errors.Error {
+	visited := make(map[uint64]bool)
+	queue := append([]uint64{}, dependsOn...)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if id == blueprintId {
+			return errors.BadInput.New(fmt.Sprintf("blueprint %d depends on itself through its dependencies", blueprintId))
+		}
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+		next, err := load(id)
+		if err != nil {
+			return err
+		}
+		queue = append(queue, next...)
+	}
+	return nil
+}
+
+// unsucceededDependency returns the first blueprint depended on whose latest pipeline did not succeed, 0 if there is none
+func unsucceededDependency(blueprint *models.Blueprint) (uint64, errors.Error) {
+	for _, id := range blueprint.DependsOn {
+		latest := &models.Pipeline{}
+		err := db.First(latest, dal.Where("blueprint_id = ?", id), dal.Orderby("id DESC"))
+		if db.IsErrorNotFound(err) {
+			return id, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		if latest.Status != models.TASK_COMPLETED {
+			return id, nil
+		}
+	}
+	return 0, nil
+}
+
+// runDependentBlueprints triggers the blueprints depending on the blueprint of the succeeded pipeline,
+// the ones still waiting for other dependencies are triggered when the last of them succeeds
+func runDependentBlueprints(dbPipeline *models.Pipeline) {
+	if dbPipeline.BlueprintId == 0 || dbPipeline.Status != models.TASK_COMPLETED {
+		return
+	}
+	ids, err := bpManager.GetDependentBlueprintIds(dbPipeline.BlueprintId)
+	if err != nil {
+		blueprintLog.Error(err, fmt.Sprintf("failed to get the blueprints depending on blueprint %d", dbPipeline.BlueprintId))
+		return
+	}
+	for _, id := range ids {
+		blueprint, err := GetBlueprint(id, false)
+		if err != nil {
+			blueprintLog.Error(err, fmt.Sprintf("failed to load blueprint %d depending on blueprint %d", id, dbPipeline.BlueprintId))
+			continue
+		}
+		if !blueprint.Enable {
+			continue
+		}
+		unfinished, err := thereAreUnfinishedPipelinesUnderBlueprint(id)
+		if err != nil {
+			blueprintLog.Error(err, fmt.Sprintf("failed to check the pipelines of blueprint %d", id))
+			continue
+		}
+		if unfinished {
+			blueprintLog.Info("skip blueprint %d depending on blueprint %d, a pipeline is running already", id, dbPipeline.BlueprintId)
+			continue
+		}
+		BlueprintJob{blueprint}.Run()
+	}
+}
@@ -0,0 +1,37 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckDependencyCycle(t *testing.T) {
+	// 2 -> 3 -> 4, 5 -> 1
+	dependencies := map[uint64][]uint64{2: {3}, 3: {4}, 5: {1}}
+	load := func(id uint64) ([]uint64, errors.Error) {
+		return dependencies[id], nil
+	}
+	assert.Nil(t, checkDependencyCycle(1, []uint64{2}, load))
+	assert.Nil(t, checkDependencyCycle(1, []uint64{2, 3}, load))
+	assert.NotNil(t, checkDependencyCycle(1, []uint64{5}, load))
+	assert.NotNil(t, checkDependencyCycle(4, []uint64{2}, load))
+}
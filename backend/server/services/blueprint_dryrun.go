@@ -0,0 +1,252 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/spf13/cast"
+)
+
+// requests assumed per collector without a previous run to learn from, and records per page otherwise
+const (
+	dryRunRequestsPerCollector = 1
+	dryRunRecordsPerPage       = 100
+	defaultRequestsPerHour     = 18000
+)
+
+// BlueprintDryRun is the pipeline a blueprint would run, nothing is executed nor saved
+type BlueprintDryRun struct {
+	Plan      models.PipelinePlan `json:"plan"`
+	Scopes    []*DryRunScope      `json:"scopes"`
+	Estimates []*DryRunEstimate   `json:"estimates"`
+}
+
+// DryRunScope is a scope collected by the pipeline
+type DryRunScope struct {
+	PluginName   string `json:"pluginName"`
+	ConnectionId uint64 `json:"connectionId"`
+	ScopeId      string `json:"scopeId"`
+}
+
+// DryRunEstimate is the expected api usage of a connection, learnt from the latest finished pipeline of the blueprint
+type DryRunEstimate struct {
+	PluginName   string `json:"pluginName"`
+	ConnectionId uint64 `json:"connectionId"`
+	Collectors   int    `json:"collectors"`
+	ApiRequests  int    `json:"apiRequests"`
+	// false if no previous run was found for some of the collectors, which are assumed to send a single request
+	FromHistory      bool    `json:"fromHistory"`
+	RateLimitPerHour int     `json:"rateLimitPerHour"`
+	EstimatedMinutes float64 `json:"estimatedMinutes"`
+}
+
+// DryRunBlueprint expands the plan of the blueprint with the changes in body applied, the same way PatchBlueprint would,
+// and estimates the api requests it would send
+func DryRunBlueprint(id uint64, body map[string]interface{}) (*BlueprintDryRun, errors.Error) {
+	blueprint, err := GetBlueprint(id, false)
+	if err != nil {
+		return nil, err
+	}
+	originMode := blueprint.Mode
+	err = helper.DecodeMapStruct(body, blueprint, true)
+	if err != nil {
+		return nil, err
+	}
+	if originMode != blueprint.Mode {
+		return nil, errors.BadInput.New("mode is not updatable")
+	}
+	err = helper.DecodeMapStruct(body, &blueprint.SyncPolicy, true)
+	if err != nil {
+		return nil, err
+	}
+	// the plan of normal blueprints is expanded by the validation
+	err = validateBlueprintAndMakePlan(blueprint)
+	if err != nil {
+		return nil, errors.BadInput.WrapRaw(err)
+	}
+	plan := blueprint.Plan
+	history, err := latestCollectedRecords(blueprint.ID)
+	if err != nil {
+		return nil, err
+	}
+	dryRun := &BlueprintDryRun{
+		Scopes:    make([]*DryRunScope, 0),
+		Estimates: estimateApiRequests(plan, history, blueprint.SkipCollectors),
+	}
+	for _, connection := range blueprint.Connections {
+		for _, scope := range connection.Scopes {
+			dryRun.Scopes = append(dryRun.Scopes, &DryRunScope{
+				PluginName:   connection.PluginName,
+				ConnectionId: connection.ConnectionId,
+				ScopeId:      scope.ScopeId,
+			})
+		}
+	}
+	for _, estimate := range dryRun.Estimates {
+		estimate.RateLimitPerHour = connectionRateLimit(estimate.PluginName, estimate.ConnectionId)
+		estimate.EstimatedMinutes = math.Round(float64(estimate.ApiRequests)/float64(estimate.RateLimitPerHour)*60*10) / 10
+	}
+	// the plan is sanitized only now as the options identify the tasks of the previous runs
+	dryRun.Plan = make(models.PipelinePlan, len(plan))
+	for i, stage := range plan {
+		dryRun.Plan[i] = make(models.PipelineStage, len(stage))
+		for j, task := range stage {
+			sanitized := *task
+			options, e := SanitizePluginOption(task.Plugin, task.Options)
+			if e != nil {
+				return nil, errors.Convert(e)
+			}
+			sanitized.Options = options
+			dryRun.Plan[i][j] = &sanitized
+		}
+	}
+	return dryRun, nil
+}
+
+// latestCollectedRecords returns the records collected by the subtasks of the latest finished pipeline of the blueprint,
+// keyed by taskKey and the subtask name
+func latestCollectedRecords(blueprintId uint64) (map[string]map[string]int, errors.Error) {
+	history := make(map[string]map[string]int)
+	if blueprintId == 0 {
+		return history, nil
+	}
+	pipeline := &models.Pipeline{}
+	err := db.First(pipeline,
+		dal.Where("blueprint_id = ? AND status IN ?", blueprintId, []string{models.TASK_COMPLETED, models.TASK_PARTIAL}),
+		dal.Orderby("id DESC"),
+	)
+	if db.IsErrorNotFound(err) {
+		return history, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := GetLatestTasksOfPipeline(pipeline)
+	if err != nil {
+		return nil, err
+	}
+	for _, task := range tasks {
+		var subtasks []*models.Subtask
+		err = db.All(&subtasks, dal.Where("task_id = ? AND is_collector = ? AND finished_at IS NOT NULL", task.ID, true))
+		if err != nil {
+			return nil, err
+		}
+		records := make(map[string]int)
+		for _, subtask := range subtasks {
+			records[subtask.Name] = subtask.FinishedRecords
+		}
+		history[taskKey(task.Plugin, task.Options)] = records
+	}
+	return history, nil
+}
+
+// taskKey identifies the task of a plan across the runs, by its plugin and options
+func taskKey(pluginName string, options map[string]interface{}) string {
+	optionsJson, _ := json.Marshal(options)
+	return pluginName + ":" + string(optionsJson)
+}
+
+// estimateApiRequests sums the requests of the collectors in the plan up per connection
+func estimateApiRequests(plan models.PipelinePlan, history map[string]map[string]int, skipCollectors bool) []*DryRunEstimate {
+	estimates := make([]*DryRunEstimate, 0)
+	if skipCollectors {
+		return estimates
+	}
+	byConnection := make(map[string]*DryRunEstimate)
+	for _, stage := range plan {
+		for _, task := range stage {
+			collectors := taskCollectors(task)
+			connectionId := cast.ToUint64(task.Options["connectionId"])
+			if len(collectors) == 0 || connectionId == 0 {
+				continue
+			}
+			key := taskKey(task.Plugin, map[string]interface{}{"connectionId": connectionId})
+			estimate := byConnection[key]
+			if estimate == nil {
+				estimate = &DryRunEstimate{PluginName: task.Plugin, ConnectionId: connectionId, FromHistory: true}
+				byConnection[key] = estimate
+				estimates = append(estimates, estimate)
+			}
+			records, found := history[taskKey(task.Plugin, task.Options)]
+			for _, collector := range collectors {
+				estimate.Collectors++
+				if count, ok := records[collector]; found && ok {
+					estimate.ApiRequests += count/dryRunRecordsPerPage + 1
+				} else {
+					estimate.ApiRequests += dryRunRequestsPerCollector
+					estimate.FromHistory = false
+				}
+			}
+		}
+	}
+	return estimates
+}
+
+// taskCollectors returns the collectors the task would run
+func taskCollectors(task *models.PipelineTask) []string {
+	var subtasks []string
+	if len(task.Subtasks) > 0 {
+		subtasks = task.Subtasks
+	} else if pluginMeta, err := plugin.GetPlugin(task.Plugin); err == nil {
+		if pluginTask, ok := pluginMeta.(plugin.PluginTask); ok {
+			for _, meta := range pluginTask.SubTaskMetas() {
+				if meta.EnabledByDefault {
+					subtasks = append(subtasks, meta.Name)
+				}
+			}
+		}
+	}
+	collectors := make([]string, 0)
+	for _, subtask := range subtasks {
+		if strings.Contains(strings.ToLower(subtask), "collect") {
+			collectors = append(collectors, subtask)
+		}
+	}
+	return collectors
+}
+
+// connectionRateLimit returns the requests per hour allowed on the connection, API_REQUESTS_PER_HOUR if the
+// connection doesn't set any
+func connectionRateLimit(pluginName string, connectionId uint64) int {
+	rateLimit := cfg.GetInt("API_REQUESTS_PER_HOUR")
+	if rateLimit <= 0 {
+		rateLimit = defaultRequestsPerHour
+	}
+	pluginMeta, err := plugin.GetPlugin(pluginName)
+	if err != nil {
+		return rateLimit
+	}
+	pluginSrc, ok := pluginMeta.(plugin.PluginSource)
+	if !ok {
+		return rateLimit
+	}
+	var limits []int
+	err = db.Pluck("rate_limit_per_hour", &limits, dal.From(pluginSrc.Connection().TableName()), dal.Where("id = ?", connectionId))
+	if err == nil && len(limits) > 0 && limits[0] > 0 {
+		return limits[0]
+	}
+	return rateLimit
+}
@@ -0,0 +1,60 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateApiRequests(t *testing.T) {
+	repo1 := map[string]interface{}{"connectionId": 1, "name": "apache/incubator-devlake"}
+	repo2 := map[string]interface{}{"connectionId": 1, "name": "apache/incubator-devlake-website"}
+	board := map[string]interface{}{"connectionId": 2, "boardId": 8}
+	plan := models.PipelinePlan{
+		{
+			{Plugin: "github", Subtasks: []string{"collectApiIssues", "extractApiIssues", "collectApiPullRequests"}, Options: repo1},
+			{Plugin: "github", Subtasks: []string{"collectApiIssues"}, Options: repo2},
+			{Plugin: "jira", Subtasks: []string{"collectIssues", "convertIssues"}, Options: board},
+		},
+		{
+			{Plugin: "dora", Subtasks: []string{"calculateChangeLeadTime"}, Options: map[string]interface{}{"projectName": "p"}},
+		},
+	}
+	history := map[string]map[string]int{
+		taskKey("github", repo1): {"collectApiIssues": 250, "collectApiPullRequests": 99},
+		taskKey("jira", board):   {"collectIssues": 1000},
+	}
+
+	estimates := estimateApiRequests(plan, history, false)
+	assert.Len(t, estimates, 2)
+	github, jira := estimates[0], estimates[1]
+	assert.Equal(t, "github", github.PluginName)
+	assert.Equal(t, uint64(1), github.ConnectionId)
+	assert.Equal(t, 3, github.Collectors)
+	// 250 issues in 3 pages, 99 pull requests in 1, and no history for the second repo
+	assert.Equal(t, 3+1+1, github.ApiRequests)
+	assert.False(t, github.FromHistory)
+	assert.Equal(t, uint64(2), jira.ConnectionId)
+	assert.Equal(t, 11, jira.ApiRequests)
+	assert.True(t, jira.FromHistory)
+
+	assert.Empty(t, estimateApiRequests(plan, history, true))
+}
@@ -0,0 +1,474 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/dbhelper"
+	"github.com/spf13/cast"
+)
+
+// BlueprintExport is the declarative form of a blueprint, the connections are referenced by name so it can be
+// imported into another instance where the connections were created with the same names
+type BlueprintExport struct {
+	Name            string                          `json:"name" yaml:"name"`
+	ProjectName     string                          `json:"projectName,omitempty" yaml:"projectName,omitempty"`
+	Mode            string                          `json:"mode" yaml:"mode"`
+	Enable          bool                            `json:"enable" yaml:"enable"`
+	CronConfig      string                          `json:"cronConfig" yaml:"cronConfig"`
+	IsManual        bool                            `json:"isManual" yaml:"isManual"`
+	Labels          []string                        `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Priority        int                             `json:"priority,omitempty" yaml:"priority,omitempty"`
+	Timezone        string                          `json:"timezone,omitempty" yaml:"timezone,omitempty"`
+	BlackoutWindows []*models.BlackoutWindow        `json:"blackoutWindows,omitempty" yaml:"blackoutWindows,omitempty"`
+	DependsOn       []string                        `json:"dependsOn,omitempty" yaml:"dependsOn,omitempty"`
+	Notifications   []*models.BlueprintNotification `json:"notifications,omitempty" yaml:"notifications,omitempty"`
+	SkipOnFail      bool                            `json:"skipOnFail" yaml:"skipOnFail"`
+	TimeAfter       *time.Time                      `json:"timeAfter,omitempty" yaml:"timeAfter,omitempty"`
+	SkipCollectors  bool                            `json:"skipCollectors,omitempty" yaml:"skipCollectors,omitempty"`
+	FullSync        bool                            `json:"fullSync,omitempty" yaml:"fullSync,omitempty"`
+	Timeouts        *models.Timeouts                `json:"timeouts,omitempty" yaml:"timeouts,omitempty"`
+	RateLimitBudget string                          `json:"rateLimitBudget,omitempty" yaml:"rateLimitBudget,omitempty"`
+	Plan            []interface{}                   `json:"plan,omitempty" yaml:"plan,omitempty"`
+	BeforePlan      []interface{}                   `json:"beforePlan,omitempty" yaml:"beforePlan,omitempty"`
+	AfterPlan       []interface{}                   `json:"afterPlan,omitempty" yaml:"afterPlan,omitempty"`
+	ScopeConfigs    []*ExportedScopeConfig          `json:"scopeConfigs,omitempty" yaml:"scopeConfigs,omitempty"`
+	Connections     []*ExportedBlueprintConnection  `json:"connections,omitempty" yaml:"connections,omitempty"`
+}
+
+// ExportedBlueprintConnection is a connection of the blueprint with its scopes
+type ExportedBlueprintConnection struct {
+	PluginName     string           `json:"pluginName" yaml:"pluginName"`
+	ConnectionName string           `json:"connectionName" yaml:"connectionName"`
+	Scopes         []*ExportedScope `json:"scopes" yaml:"scopes"`
+}
+
+// ExportedScope is a scope with its tool layer record, the scope config is referenced by name
+type ExportedScope struct {
+	ScopeId     string                  `json:"scopeId" yaml:"scopeId"`
+	ScopeConfig string                  `json:"scopeConfig,omitempty" yaml:"scopeConfig,omitempty"`
+	SyncPolicy  *models.ScopeSyncPolicy `json:"syncPolicy,omitempty" yaml:"syncPolicy,omitempty"`
+	Data        map[string]interface{}  `json:"data" yaml:"data"`
+}
+
+// ExportedScopeConfig is a scope config used by the scopes of the blueprint
+type ExportedScopeConfig struct {
+	PluginName     string                 `json:"pluginName" yaml:"pluginName"`
+	ConnectionName string                 `json:"connectionName" yaml:"connectionName"`
+	Name           string                 `json:"name" yaml:"name"`
+	Data           map[string]interface{} `json:"data" yaml:"data"`
+}
+
+// the fields bound to the instance the record lives in, they are resolved again on import
+var instanceBoundFields = []string{
+	"id", "connectionId", "scopeConfigId", "createdAt", "updatedAt",
+	"_raw_data_params", "_raw_data_table", "_raw_data_id", "_raw_data_remark",
+}
+
+// ExportBlueprint returns the declarative form of the blueprint, secrets in the plans are sanitized
+func ExportBlueprint(id uint64) (*BlueprintExport, errors.Error) {
+	blueprint, err := GetBlueprint(id, true)
+	if err != nil {
+		return nil, err
+	}
+	export := &BlueprintExport{
+		Name:            blueprint.Name,
+		ProjectName:     blueprint.ProjectName,
+		Mode:            blueprint.Mode,
+		Enable:          blueprint.Enable,
+		CronConfig:      blueprint.CronConfig,
+		IsManual:        blueprint.IsManual,
+		Labels:          blueprint.Labels,
+		Priority:        blueprint.Priority,
+		Timezone:        blueprint.Timezone,
+		BlackoutWindows: blueprint.BlackoutWindows,
+		Notifications:   blueprint.Notifications,
+		SkipOnFail:      blueprint.SkipOnFail,
+		TimeAfter:       blueprint.TimeAfter,
+		SkipCollectors:  blueprint.SkipCollectors,
+		FullSync:        blueprint.FullSync,
+		RateLimitBudget: blueprint.RateLimitBudget,
+		BeforePlan:      toGenericPlan(blueprint.BeforePlan),
+		AfterPlan:       toGenericPlan(blueprint.AfterPlan),
+	}
+	if blueprint.Timeouts != (models.Timeouts{}) {
+		export.Timeouts = &blueprint.Timeouts
+	}
+	if len(blueprint.DependsOn) > 0 {
+		err = db.Pluck("name", &export.DependsOn, dal.From(&models.Blueprint{}), dal.Where("id IN ?", blueprint.DependsOn))
+		if err != nil {
+			return nil, err
+		}
+	}
+	// the plan of normal blueprints is generated from the connections
+	if blueprint.Mode == models.BLUEPRINT_MODE_ADVANCED {
+		export.Plan = toGenericPlan(blueprint.Plan)
+	}
+	exportedScopeConfigs := make(map[string]bool)
+	for _, connection := range blueprint.Connections {
+		pluginSrc, err := getPluginSource(connection.PluginName)
+		if err != nil {
+			return nil, err
+		}
+		connectionName, err := getConnectionName(pluginSrc, connection.ConnectionId)
+		if err != nil {
+			return nil, err
+		}
+		exported := &ExportedBlueprintConnection{
+			PluginName:     connection.PluginName,
+			ConnectionName: connectionName,
+			Scopes:         make([]*ExportedScope, 0, len(connection.Scopes)),
+		}
+		for _, bpScope := range connection.Scopes {
+			scope, err := getToolScope(pluginSrc, connection.ConnectionId, bpScope.ScopeId)
+			if err != nil {
+				return nil, err
+			}
+			exportedScope := &ExportedScope{ScopeId: bpScope.ScopeId, SyncPolicy: bpScope.SyncPolicy, Data: toExportData(scope)}
+			if scopeConfigId := scope.ScopeScopeConfigId(); scopeConfigId != 0 {
+				scopeConfig, err := getToolScopeConfig(pluginSrc, scopeConfigId)
+				if err != nil {
+					return nil, err
+				}
+				exportedScope.ScopeConfig = scopeConfig.Name
+				key := fmt.Sprintf("%s:%d", connection.PluginName, scopeConfigId)
+				if !exportedScopeConfigs[key] {
+					exportedScopeConfigs[key] = true
+					export.ScopeConfigs = append(export.ScopeConfigs, &ExportedScopeConfig{
+						PluginName:     connection.PluginName,
+						ConnectionName: connectionName,
+						Name:           scopeConfig.Name,
+						Data:           scopeConfig.Data,
+					})
+				}
+			}
+			exported.Scopes = append(exported.Scopes, exportedScope)
+		}
+		export.Connections = append(export.Connections, exported)
+	}
+	return export, nil
+}
+
+// ImportBlueprint creates the blueprint, or updates the blueprint of the same name, along with its scope configs
+// and scopes. The connections must exist with the same names already.
+func ImportBlueprint(export *BlueprintExport) (*models.Blueprint, errors.Error) {
+	if export.Name == "" {
+		return nil, errors.BadInput.New("name is required")
+	}
+	connections, err := importToolLayer(export)
+	if err != nil {
+		return nil, err
+	}
+	blueprint := &models.Blueprint{}
+	err = db.First(blueprint, dal.Where("name = ?", export.Name))
+	if err != nil && !db.IsErrorNotFound(err) {
+		return nil, err
+	}
+	if err == nil {
+		blueprint, err = GetBlueprint(blueprint.ID, false)
+		if err != nil {
+			return nil, err
+		}
+		if blueprint.Mode != export.Mode {
+			return nil, errors.BadInput.New(fmt.Sprintf("blueprint %s exists in the %s mode already", export.Name, blueprint.Mode))
+		}
+	}
+	blueprint.Name = export.Name
+	blueprint.ProjectName = export.ProjectName
+	blueprint.Mode = export.Mode
+	blueprint.Enable = export.Enable
+	blueprint.CronConfig = export.CronConfig
+	blueprint.IsManual = export.IsManual
+	blueprint.Labels = export.Labels
+	blueprint.Priority = export.Priority
+	blueprint.Timezone = export.Timezone
+	blueprint.BlackoutWindows = export.BlackoutWindows
+	notifications := make([]*models.BlueprintNotification, 0, len(export.Notifications))
+	for _, notification := range export.Notifications {
+		notification.RestoreSanitized(blueprint.Notifications)
+		if notification.IsSanitized() {
+			// the exports mask the secrets, the channel has to be set up again unless the blueprint had it already
+			logger.Warn(nil, "the %s notification of the blueprint %s is left out since it is masked", notification.Type, export.Name)
+			continue
+		}
+		notifications = append(notifications, notification)
+	}
+	blueprint.Notifications = notifications
+	blueprint.SkipOnFail = export.SkipOnFail
+	blueprint.TimeAfter = export.TimeAfter
+	blueprint.SkipCollectors = export.SkipCollectors
+	blueprint.FullSync = export.FullSync
+	blueprint.RateLimitBudget = export.RateLimitBudget
+	blueprint.Timeouts = models.Timeouts{}
+	if export.Timeouts != nil {
+		blueprint.Timeouts = *export.Timeouts
+	}
+	blueprint.DependsOn = make([]uint64, 0, len(export.DependsOn))
+	for _, name := range export.DependsOn {
+		dependency := &models.Blueprint{}
+		err = db.First(dependency, dal.Where("name = ?", name))
+		if db.IsErrorNotFound(err) {
+			return nil, errors.BadInput.New(fmt.Sprintf("blueprint %s in dependsOn not found", name))
+		}
+		if err != nil {
+			return nil, err
+		}
+		blueprint.DependsOn = append(blueprint.DependsOn, dependency.ID)
+	}
+	blueprint.Connections = connections
+	for target, plan := range map[*models.PipelinePlan][]interface{}{
+		&blueprint.Plan:       export.Plan,
+		&blueprint.BeforePlan: export.BeforePlan,
+		&blueprint.AfterPlan:  export.AfterPlan,
+	} {
+		if *target, err = fromGenericPlan(plan); err != nil {
+			return nil, err
+		}
+	}
+	blueprint, err = saveBlueprint(blueprint)
+	if err != nil {
+		return nil, err
+	}
+	if err := SanitizeBlueprint(blueprint); err != nil {
+		return nil, errors.Convert(err)
+	}
+	return blueprint, nil
+}
+
+// importToolLayer saves the scope configs and the scopes, and returns the blueprint connections of the target instance
+func importToolLayer(export *BlueprintExport) (connections []*models.BlueprintConnection, err errors.Error) {
+	txHelper := dbhelper.NewTxHelper(basicRes, &err)
+	defer txHelper.End()
+	tx := txHelper.Begin()
+	connectionIds := make(map[string]uint64)
+	resolveConnection := func(pluginName, connectionName string) (plugin.PluginSource, uint64, errors.Error) {
+		pluginSrc, err := getPluginSource(pluginName)
+		if err != nil {
+			return nil, 0, err
+		}
+		// scope configs may not belong to any connection
+		if connectionName == "" {
+			return pluginSrc, 0, nil
+		}
+		key := pluginName + ":" + connectionName
+		if _, ok := connectionIds[key]; !ok {
+			var ids []uint64
+			err = tx.Pluck("id", &ids, dal.From(pluginSrc.Connection().TableName()), dal.Where("name = ?", connectionName))
+			if err != nil {
+				return nil, 0, err
+			}
+			if len(ids) == 0 {
+				return nil, 0, errors.NotFound.New(fmt.Sprintf("connection %s of plugin %s not found, it has to be created first", connectionName, pluginName))
+			}
+			connectionIds[key] = ids[0]
+		}
+		return pluginSrc, connectionIds[key], nil
+	}
+	scopeConfigIds := make(map[string]uint64)
+	for _, exported := range export.ScopeConfigs {
+		pluginSrc, connectionId, err := resolveConnection(exported.PluginName, exported.ConnectionName)
+		if err != nil {
+			return nil, err
+		}
+		record := newToolModel(pluginSrc.ScopeConfig())
+		data := fromExportData(exported.Data, map[string]interface{}{"connectionId": connectionId, "name": exported.Name})
+		var ids []uint64
+		err = tx.Pluck("id", &ids, dal.From(pluginSrc.ScopeConfig().TableName()), dal.Where("name = ?", exported.Name))
+		if err != nil {
+			return nil, err
+		}
+		if len(ids) > 0 {
+			data["id"] = ids[0]
+		}
+		if err := decodeToolModel(data, record); err != nil {
+			return nil, err
+		}
+		if err := tx.CreateOrUpdate(record); err != nil {
+			return nil, err
+		}
+		scopeConfig, ok := record.(plugin.ToolLayerScopeConfig)
+		if !ok {
+			return nil, errors.Default.New(fmt.Sprintf("scope config of plugin %s is not a ToolLayerScopeConfig", exported.PluginName))
+		}
+		scopeConfigIds[exported.PluginName+":"+exported.Name] = scopeConfig.ScopeConfigId()
+	}
+	for _, exported := range export.Connections {
+		pluginSrc, connectionId, err := resolveConnection(exported.PluginName, exported.ConnectionName)
+		if err != nil {
+			return nil, err
+		}
+		connection := &models.BlueprintConnection{PluginName: exported.PluginName, ConnectionId: connectionId}
+		for _, exportedScope := range exported.Scopes {
+			fields := map[string]interface{}{"connectionId": connectionId}
+			if exportedScope.ScopeConfig != "" {
+				scopeConfigId, ok := scopeConfigIds[exported.PluginName+":"+exportedScope.ScopeConfig]
+				if !ok {
+					return nil, errors.BadInput.New(fmt.Sprintf("scope config %s of scope %s is not in scopeConfigs", exportedScope.ScopeConfig, exportedScope.ScopeId))
+				}
+				fields["scopeConfigId"] = scopeConfigId
+			}
+			scope := newToolModel(pluginSrc.Scope())
+			if err := decodeToolModel(fromExportData(exportedScope.Data, fields), scope); err != nil {
+				return nil, err
+			}
+			if toolScope, ok := scope.(plugin.ToolLayerScope); !ok || toolScope.ScopeId() != exportedScope.ScopeId {
+				return nil, errors.BadInput.New(fmt.Sprintf("data of scope %s doesn't hold its id", exportedScope.ScopeId))
+			}
+			if err := tx.CreateOrUpdate(scope); err != nil {
+				return nil, err
+			}
+			connection.Scopes = append(connection.Scopes, &models.BlueprintScope{ScopeId: exportedScope.ScopeId, SyncPolicy: exportedScope.SyncPolicy})
+		}
+		connections = append(connections, connection)
+	}
+	return connections, nil
+}
+
+func getPluginSource(pluginName string) (plugin.PluginSource, errors.Error) {
+	pluginMeta, err := plugin.GetPlugin(pluginName)
+	if err != nil {
+		return nil, err
+	}
+	pluginSrc, ok := pluginMeta.(plugin.PluginSource)
+	if !ok {
+		return nil, errors.BadInput.New(fmt.Sprintf("plugin %s is not a data source", pluginName))
+	}
+	return pluginSrc, nil
+}
+
+func getConnectionName(pluginSrc plugin.PluginSource, connectionId uint64) (string, errors.Error) {
+	var names []string
+	err := db.Pluck("name", &names, dal.From(pluginSrc.Connection().TableName()), dal.Where("id = ?", connectionId))
+	if err != nil {
+		return "", err
+	}
+	if len(names) == 0 {
+		return "", errors.NotFound.New(fmt.Sprintf("connection %d not found in %s", connectionId, pluginSrc.Connection().TableName()))
+	}
+	return names[0], nil
+}
+
+// getToolScope loads the scope of the connection by the primary key column other than connection_id
+func getToolScope(pluginSrc plugin.PluginSource, connectionId uint64, scopeId string) (plugin.ToolLayerScope, errors.Error) {
+	scope, ok := newToolModel(pluginSrc.Scope()).(plugin.ToolLayerScope)
+	if !ok {
+		return nil, errors.Default.New(fmt.Sprintf("scope of %s is not a ToolLayerScope", pluginSrc.Scope().TableName()))
+	}
+	pkColumns, err := dal.GetPrimarykeyColumnNames(db, scope)
+	if err != nil {
+		return nil, err
+	}
+	clauses := []dal.Clause{dal.Where(fmt.Sprintf("%s.connection_id = ?", scope.TableName()), connectionId)}
+	for _, column := range pkColumns {
+		if !strings.HasSuffix(column, ".connection_id") {
+			clauses = append(clauses, dal.Where(fmt.Sprintf("%s = ?", column), scopeId))
+		}
+	}
+	err = db.First(scope, clauses...)
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return nil, errors.NotFound.New(fmt.Sprintf("scope %s of connection %d not found in %s", scopeId, connectionId, scope.TableName()))
+		}
+		return nil, err
+	}
+	return scope, nil
+}
+
+// getToolScopeConfig loads the scope config as an ExportedScopeConfig, the name being referenced by the scopes
+func getToolScopeConfig(pluginSrc plugin.PluginSource, scopeConfigId uint64) (*ExportedScopeConfig, errors.Error) {
+	record := newToolModel(pluginSrc.ScopeConfig())
+	err := db.First(record, dal.Where("id = ?", scopeConfigId))
+	if err != nil {
+		return nil, err
+	}
+	data := toExportData(record)
+	name := cast.ToString(data["name"])
+	delete(data, "name")
+	return &ExportedScopeConfig{Name: name, Data: data}, nil
+}
+
+// newToolModel returns a pointer to a new record of the same type as the model
+func newToolModel(model interface{}) interface{} {
+	t := reflect.TypeOf(model)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return reflect.New(t).Interface()
+}
+
+// toExportData returns the fields of the record but the instanceBoundFields
+func toExportData(record interface{}) map[string]interface{} {
+	data := toRecordData(record)
+	for _, field := range instanceBoundFields {
+		delete(data, field)
+	}
+	return data
+}
+
+// fromExportData returns the exported fields along with the fields resolved in the target instance
+func fromExportData(data map[string]interface{}, fields map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(data)+len(fields))
+	for k, v := range data {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return merged
+}
+
+func decodeToolModel(data map[string]interface{}, record interface{}) errors.Error {
+	dataJson, err := json.Marshal(data)
+	if err != nil {
+		return errors.BadInput.Wrap(err, "invalid data")
+	}
+	return errors.Convert(json.Unmarshal(dataJson, record))
+}
+
+func toGenericPlan(plan models.PipelinePlan) []interface{} {
+	if len(plan) == 0 {
+		return nil
+	}
+	var generic []interface{}
+	_ = json.Unmarshal(errors.Must1(json.Marshal(plan)), &generic)
+	return generic
+}
+
+func fromGenericPlan(generic []interface{}) (models.PipelinePlan, errors.Error) {
+	plan := make(models.PipelinePlan, 0)
+	if len(generic) == 0 {
+		return plan, nil
+	}
+	planJson, err := json.Marshal(generic)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid plan")
+	}
+	if err := json.Unmarshal(planJson, &plan); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid plan")
+	}
+	return plan, nil
+}
@@ -0,0 +1,233 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gopkg.in/yaml.v3"
+)
+
+type exportTestConnection struct{}
+
+func (exportTestConnection) TableName() string { return "_tool_exporttest_connections" }
+
+type exportTestScope struct {
+	common.Scope
+	GithubId int    `json:"githubId" gorm:"primaryKey"`
+	Name     string `json:"name"`
+}
+
+func (s exportTestScope) ScopeId() string          { return strconv.Itoa(s.GithubId) }
+func (s exportTestScope) ScopeName() string        { return s.Name }
+func (s exportTestScope) ScopeFullName() string    { return s.Name }
+func (s exportTestScope) ScopeParams() interface{} { return nil }
+func (s exportTestScope) TableName() string        { return "_tool_exporttest_repos" }
+
+type exportTestScopeConfig struct {
+	common.ScopeConfig
+	DeploymentPattern string `json:"deploymentPattern"`
+}
+
+func (exportTestScopeConfig) TableName() string { return "_tool_exporttest_scope_configs" }
+
+type exportTestPlugin struct{}
+
+func (exportTestPlugin) Description() string          { return "" }
+func (exportTestPlugin) RootPkgPath() string          { return "" }
+func (exportTestPlugin) Name() string                 { return "exporttest" }
+func (exportTestPlugin) Connection() dal.Tabler       { return &exportTestConnection{} }
+func (exportTestPlugin) Scope() plugin.ToolLayerScope { return &exportTestScope{} }
+func (exportTestPlugin) ScopeConfig() dal.Tabler      { return &exportTestScopeConfig{} }
+
+func mockPluck(mockTx *mockdal.Transaction, table string, ids ...uint64) {
+	mockTx.On("Pluck", "id", mock.Anything, fromTable(table)).Run(func(args mock.Arguments) {
+		*args.Get(1).(*[]uint64) = ids
+	}).Return(nil).Once()
+}
+
+func mockImportTx(t *testing.T, mockTx *mockdal.Transaction) {
+	_ = plugin.RegisterPlugin("exporttest", exportTestPlugin{})
+	originalBasicRes := basicRes
+	t.Cleanup(func() { basicRes = originalBasicRes })
+	basicRes = unithelper.DummyBasicRes(func(mockDal *mockdal.Dal) {
+		mockDal.On("Begin").Return(mockTx).Once()
+	})
+	mockTx.On("UnlockTables").Return(nil).Maybe()
+}
+
+func TestToExportData(t *testing.T) {
+	scope := &exportTestScope{GithubId: 42, Name: "apache/incubator-devlake"}
+	scope.ConnectionId = 1
+	scope.ScopeConfigId = 3
+	scope.RawDataTable = "_raw_github_api_repositories"
+	data := toExportData(scope)
+	// the ids of the instance are left out
+	assert.Equal(t, map[string]interface{}{"githubId": float64(42), "name": "apache/incubator-devlake"}, data)
+
+	imported := newToolModel(&exportTestScope{}).(*exportTestScope)
+	err := decodeToolModel(fromExportData(data, map[string]interface{}{"connectionId": 7}), imported)
+	assert.Nil(t, err)
+	assert.Equal(t, 42, imported.GithubId)
+	assert.Equal(t, uint64(7), imported.ConnectionId)
+	assert.Equal(t, uint64(0), imported.ScopeConfigId)
+}
+
+func TestBlueprintExportYaml(t *testing.T) {
+	plan := models.PipelinePlan{{{Plugin: "dora", Subtasks: []string{"calculateChangeLeadTime"}, Options: map[string]interface{}{"projectName": "p"}}}}
+	export := &BlueprintExport{
+		Name:       "p-Blueprint",
+		Mode:       models.BLUEPRINT_MODE_NORMAL,
+		CronConfig: "0 0 * * *",
+		AfterPlan:  toGenericPlan(plan),
+		Connections: []*ExportedBlueprintConnection{{
+			PluginName:     "github",
+			ConnectionName: "github cloud",
+			Scopes: []*ExportedScope{{
+				ScopeId:     "42",
+				ScopeConfig: "default",
+				Data:        map[string]interface{}{"githubId": 42, "name": "apache/incubator-devlake"},
+			}},
+		}},
+	}
+	content, err := yaml.Marshal(export)
+	assert.Nil(t, err)
+
+	imported := &BlueprintExport{}
+	assert.Nil(t, yaml.Unmarshal(content, imported))
+	assert.Equal(t, "github cloud", imported.Connections[0].ConnectionName)
+	assert.Equal(t, "apache/incubator-devlake", imported.Connections[0].Scopes[0].Data["name"])
+	afterPlan, e := fromGenericPlan(imported.AfterPlan)
+	assert.Nil(t, e)
+	assert.Equal(t, plan, afterPlan)
+
+	// json is accepted as well
+	fromJson := &BlueprintExport{}
+	assert.Nil(t, yaml.Unmarshal([]byte(`{"name": "p-Blueprint", "mode": "NORMAL", "connections": [{"pluginName": "jira", "connectionName": "jira", "scopes": [{"scopeId": "8", "data": {"boardId": 8}}]}]}`), fromJson))
+	assert.Equal(t, "8", fromJson.Connections[0].Scopes[0].ScopeId)
+}
+
+func TestImportToolLayer(t *testing.T) {
+	mockTx := new(mockdal.Transaction)
+	// the connection is looked up by its name, the scope config of the same name is updated in place
+	mockPluck(mockTx, "_tool_exporttest_connections", 5)
+	mockPluck(mockTx, "_tool_exporttest_scope_configs", 9)
+	var scopeConfig *exportTestScopeConfig
+	mockTx.On("CreateOrUpdate", mock.AnythingOfType("*services.exportTestScopeConfig"), mock.Anything).Run(func(args mock.Arguments) {
+		scopeConfig = args.Get(0).(*exportTestScopeConfig)
+	}).Return(nil).Once()
+	var scopes []*exportTestScope
+	mockTx.On("CreateOrUpdate", mock.AnythingOfType("*services.exportTestScope"), mock.Anything).Run(func(args mock.Arguments) {
+		scopes = append(scopes, args.Get(0).(*exportTestScope))
+	}).Return(nil).Twice()
+	mockTx.On("Commit").Return(nil).Once()
+	mockImportTx(t, mockTx)
+
+	connections, err := importToolLayer(&BlueprintExport{
+		Name: "p-Blueprint",
+		ScopeConfigs: []*ExportedScopeConfig{{
+			PluginName:     "exporttest",
+			ConnectionName: "github cloud",
+			Name:           "default",
+			Data:           map[string]interface{}{"deploymentPattern": "(?i)deploy"},
+		}},
+		Connections: []*ExportedBlueprintConnection{{
+			PluginName:     "exporttest",
+			ConnectionName: "github cloud",
+			Scopes: []*ExportedScope{
+				{ScopeId: "42", ScopeConfig: "default", Data: map[string]interface{}{"githubId": 42, "name": "apache/incubator-devlake"}},
+				{ScopeId: "43", SyncPolicy: &models.ScopeSyncPolicy{SkipCollectors: true}, Data: map[string]interface{}{"githubId": 43, "name": "apache/devlake-website"}},
+			},
+		}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(9), scopeConfig.ID)
+	assert.Equal(t, uint64(5), scopeConfig.ConnectionId)
+	assert.Equal(t, "default", scopeConfig.Name)
+	assert.Equal(t, "(?i)deploy", scopeConfig.DeploymentPattern)
+	if assert.Len(t, scopes, 2) {
+		assert.Equal(t, uint64(5), scopes[0].ConnectionId)
+		assert.Equal(t, uint64(9), scopes[0].ScopeConfigId)
+		assert.Equal(t, "apache/incubator-devlake", scopes[0].Name)
+		assert.Equal(t, uint64(5), scopes[1].ConnectionId)
+		assert.Equal(t, uint64(0), scopes[1].ScopeConfigId)
+	}
+	assert.Equal(t, []*models.BlueprintConnection{{
+		PluginName:   "exporttest",
+		ConnectionId: 5,
+		Scopes: []*models.BlueprintScope{
+			{ScopeId: "42"},
+			{ScopeId: "43", SyncPolicy: &models.ScopeSyncPolicy{SkipCollectors: true}},
+		},
+	}}, connections)
+	mockTx.AssertExpectations(t)
+}
+
+func TestImportToolLayerRejectsBadExports(t *testing.T) {
+	scope := func(scopeId, scopeConfig string, githubId int) *ExportedBlueprintConnection {
+		return &ExportedBlueprintConnection{
+			PluginName:     "exporttest",
+			ConnectionName: "github cloud",
+			Scopes:         []*ExportedScope{{ScopeId: scopeId, ScopeConfig: scopeConfig, Data: map[string]interface{}{"githubId": githubId}}},
+		}
+	}
+	cases := []struct {
+		name       string
+		connection *ExportedBlueprintConnection
+		connIds    []uint64
+		errType    *errors.Type
+	}{
+		{"unknown connection", scope("42", "", 42), nil, errors.NotFound},
+		{"scope config not exported", scope("42", "default", 42), []uint64{5}, errors.BadInput},
+		{"data without the scope id", scope("42", "", 43), []uint64{5}, errors.BadInput},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			mockTx := new(mockdal.Transaction)
+			mockPluck(mockTx, "_tool_exporttest_connections", c.connIds...)
+			mockTx.On("Rollback").Return(nil).Once()
+			mockImportTx(t, mockTx)
+
+			connections, err := importToolLayer(&BlueprintExport{Name: "p-Blueprint", Connections: []*ExportedBlueprintConnection{c.connection}})
+			assert.Nil(t, connections)
+			if assert.NotNil(t, err) {
+				assert.Equal(t, c.errType, err.GetType())
+			}
+			mockTx.AssertNotCalled(t, "CreateOrUpdate", mock.Anything, mock.Anything)
+			mockTx.AssertNotCalled(t, "Commit")
+			mockTx.AssertExpectations(t)
+		})
+	}
+}
+
+func TestImportBlueprintRequiresName(t *testing.T) {
+	_, err := ImportBlueprint(&BlueprintExport{})
+	if assert.NotNil(t, err) {
+		assert.Equal(t, errors.BadInput, err.GetType())
+	}
+}
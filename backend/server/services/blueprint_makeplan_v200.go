@@ -38,7 +38,7 @@ func GeneratePlanJsonV200(
 	// make plan for data-source coreModels fist. generate plan for each
 	// connection, then merge them into one legitimate plan and collect the
 	// scopes produced by the data-source plugins
-	sourcePlans := make([]coreModels.PipelinePlan, len(connections))
+	sourcePlans := make([]coreModels.PipelinePlan, 0, len(connections))
 	scopes := make([]plugin.Scope, 0, len(connections))
 	for i, connection := range connections {
 		if len(connection.Scopes) == 0 && connection.PluginName != `webhook` && connection.PluginName != `jenkins` {
@@ -52,17 +52,31 @@ func GeneratePlanJsonV200(
 			return nil, err
 		}
 		if pluginBp, ok := p.(plugin.DataSourcePluginBlueprintV200); ok {
-			var pluginScopes []plugin.Scope
-			sourcePlans[i], pluginScopes, err = pluginBp.MakeDataSourcePipelinePlanV200(
-				connection.ConnectionId,
-				connection.Scopes,
-			)
-			if err != nil {
-				return nil, err
+			// scopes overriding the sync policy get plans of their own, so their
+			// tasks can carry the override
+			for _, group := range groupScopesBySyncPolicy(connection.Scopes) {
+				sourcePlan, pluginScopes, err := pluginBp.MakeDataSourcePipelinePlanV200(
+					connection.ConnectionId,
+					group.scopes,
+				)
+				if err != nil {
+					return nil, err
+				}
+				if group.syncPolicy != nil {
+					for _, stage := range sourcePlan {
+						for _, task := range stage {
+							task.SyncPolicy = group.syncPolicy
+						}
+					}
+					if group.syncPolicy.SkipCollectors && !skipCollectors {
+						sourcePlan = skipCollectorTasks(sourcePlan)
+					}
+				}
+				sourcePlans = append(sourcePlans, sourcePlan)
+				// collect scopes for the project. a github repository may produce
+				// 2 scopes, 1 repo and 1 board
+				scopes = append(scopes, pluginScopes...)
 			}
-			// collect scopes for the project. a github repository may produce
-			// 2 scopes, 1 repo and 1 board
-			scopes = append(scopes, pluginScopes...)
 		} else {
 			return nil, errors.Default.New(
 				fmt.Sprintf("plugin %s does not support DataSourcePluginBlueprintV200", connection.PluginName),
@@ -73,25 +87,7 @@ func GeneratePlanJsonV200(
 	// skip collectors
 	if skipCollectors {
 		for i, plan := range sourcePlans {
-			sourcePlans[i] = removeCollectorTasks(plan)
-		}
-
-		// remove gitextractor plugin if it's not the only task
-		for i, plan := range sourcePlans {
-			for j, stage := range plan {
-				newStage := make(coreModels.PipelineStage, 0, len(stage))
-				hasGitExtractor := false
-				for _, task := range stage {
-					if task.Plugin != "gitextractor" {
-						newStage = append(newStage, task)
-					} else {
-						hasGitExtractor = true
-					}
-				}
-				if !hasGitExtractor || len(newStage) > 0 {
-					sourcePlans[i][j] = newStage
-				}
-			}
+			sourcePlans[i] = skipCollectorTasks(plan)
 		}
 	}
 
@@ -140,6 +136,60 @@ func GeneratePlanJsonV200(
 	return plan, err
 }
 
+type scopeGroup struct {
+	syncPolicy *coreModels.ScopeSyncPolicy
+	scopes     []*coreModels.BlueprintScope
+}
+
+// groupScopesBySyncPolicy groups the scopes sharing the same sync policy override, the scopes
+// without override come first and are passed through untouched if no scope overrides the policy
+func groupScopesBySyncPolicy(scopes []*coreModels.BlueprintScope) []scopeGroup {
+	groups := []scopeGroup{{}}
+	keys := map[string]int{}
+	for _, scope := range scopes {
+		if scope.SyncPolicy == nil {
+			groups[0].scopes = append(groups[0].scopes, scope)
+			continue
+		}
+		key, _ := json.Marshal(scope.SyncPolicy)
+		j, ok := keys[string(key)]
+		if !ok {
+			j = len(groups)
+			keys[string(key)] = j
+			groups = append(groups, scopeGroup{syncPolicy: scope.SyncPolicy})
+		}
+		groups[j].scopes = append(groups[j].scopes, scope)
+	}
+	if len(groups) == 1 {
+		groups[0].scopes = scopes
+		return groups
+	}
+	if len(groups[0].scopes) == 0 {
+		return groups[1:]
+	}
+	return groups
+}
+
+// skipCollectorTasks removes the collectors, and the gitextractor if it's not the only task of the stage
+func skipCollectorTasks(plan coreModels.PipelinePlan) coreModels.PipelinePlan {
+	plan = removeCollectorTasks(plan)
+	for j, stage := range plan {
+		newStage := make(coreModels.PipelineStage, 0, len(stage))
+		hasGitExtractor := false
+		for _, task := range stage {
+			if task.Plugin != "gitextractor" {
+				newStage = append(newStage, task)
+			} else {
+				hasGitExtractor = true
+			}
+		}
+		if !hasGitExtractor || len(newStage) > 0 {
+			plan[j] = newStage
+		}
+	}
+	return plan
+}
+
 func removeCollectorTasks(plan coreModels.PipelinePlan) coreModels.PipelinePlan {
 	for j, stage := range plan {
 		for k, task := range stage {
@@ -101,3 +101,29 @@ func TestMakePlanV200(t *testing.T) {
 
 	assert.Equal(t, expectedPlan, plan)
 }
+
+func TestGroupScopesBySyncPolicy(t *testing.T) {
+	fullHistory := &coreModels.ScopeSyncPolicy{FullSync: true}
+	scopes := []*coreModels.BlueprintScope{
+		{ScopeId: "1"},
+		{ScopeId: "2"},
+	}
+	groups := groupScopesBySyncPolicy(scopes)
+	assert.Equal(t, []scopeGroup{{scopes: scopes}}, groups)
+
+	scopes = []*coreModels.BlueprintScope{
+		{ScopeId: "1", SyncPolicy: fullHistory},
+		{ScopeId: "2"},
+		{ScopeId: "3", SyncPolicy: &coreModels.ScopeSyncPolicy{SkipCollectors: true}},
+		{ScopeId: "4", SyncPolicy: &coreModels.ScopeSyncPolicy{FullSync: true}},
+	}
+	groups = groupScopesBySyncPolicy(scopes)
+	assert.Equal(t, []scopeGroup{
+		{scopes: []*coreModels.BlueprintScope{scopes[1]}},
+		{syncPolicy: fullHistory, scopes: []*coreModels.BlueprintScope{scopes[0], scopes[3]}},
+		{syncPolicy: scopes[2].SyncPolicy, scopes: []*coreModels.BlueprintScope{scopes[2]}},
+	}, groups)
+
+	groups = groupScopesBySyncPolicy(scopes[:1])
+	assert.Equal(t, []scopeGroup{{syncPolicy: fullHistory, scopes: scopes[:1]}}, groups)
+}
@@ -0,0 +1,240 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/smtp"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+)
+
+var notificationClient = &http.Client{Timeout: 10 * time.Second}
+
+// BlueprintNotificationMessage is the content sent to the notification channels of a blueprint
+type BlueprintNotificationMessage struct {
+	BlueprintId   uint64     `json:"blueprintId"`
+	BlueprintName string     `json:"blueprintName"`
+	ProjectName   string     `json:"projectName"`
+	PipelineId    uint64     `json:"pipelineId"`
+	Status        string     `json:"status"`
+	Message       string     `json:"message,omitempty"`
+	BeganAt       *time.Time `json:"beganAt"`
+	FinishedAt    *time.Time `json:"finishedAt"`
+	SpentSeconds  int        `json:"spentSeconds"`
+	PipelineUrl   string     `json:"pipelineUrl,omitempty"`
+	LogsUrl       string     `json:"logsUrl,omitempty"`
+}
+
+var notificationStatusText = map[string]string{
+	models.TASK_COMPLETED: "succeeded",
+	models.TASK_PARTIAL:   "partially failed",
+	models.TASK_FAILED:    "failed",
+	models.TASK_CANCELLED: "was cancelled",
+}
+
+func newBlueprintNotificationMessage(blueprint *models.Blueprint, pipeline *models.Pipeline, baseUrl string) *BlueprintNotificationMessage {
+	message := &BlueprintNotificationMessage{
+		BlueprintId:   blueprint.ID,
+		BlueprintName: blueprint.Name,
+		ProjectName:   blueprint.ProjectName,
+		PipelineId:    pipeline.ID,
+		Status:        pipeline.Status,
+		Message:       pipeline.Message,
+		BeganAt:       pipeline.BeganAt,
+		FinishedAt:    pipeline.FinishedAt,
+		SpentSeconds:  pipeline.SpentSeconds,
+	}
+	if baseUrl != "" {
+		baseUrl = strings.TrimSuffix(baseUrl, "/")
+		message.PipelineUrl = fmt.Sprintf("%s/api/pipelines/%d", baseUrl, pipeline.ID)
+		message.LogsUrl = fmt.Sprintf("%s/api/pipelines/%d/logging.tar.gz", baseUrl, pipeline.ID)
+	}
+	return message
+}
+
+// Title summarizes the message in one line
+func (m *BlueprintNotificationMessage) Title() string {
+	status, ok := notificationStatusText[m.Status]
+	if !ok {
+		status = m.Status
+	}
+	return fmt.Sprintf("Pipeline #%d of blueprint %s %s", m.PipelineId, m.BlueprintName, status)
+}
+
+// Text details the message in plain text
+func (m *BlueprintNotificationMessage) Text() string {
+	lines := []string{}
+	if m.ProjectName != "" {
+		lines = append(lines, fmt.Sprintf("Project: %s", m.ProjectName))
+	}
+	lines = append(lines, fmt.Sprintf("Duration: %s", time.Duration(m.SpentSeconds)*time.Second))
+	if m.Message != "" {
+		lines = append(lines, fmt.Sprintf("Error: %s", m.Message))
+	}
+	if m.LogsUrl != "" {
+		lines = append(lines, fmt.Sprintf("Logs: %s", m.LogsUrl))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// notifyBlueprintChannels sends the finished pipeline to the notification channels of its blueprint,
+// failing channels are logged and recorded without failing the pipeline
+func notifyBlueprintChannels(pipeline *models.Pipeline) {
+	if pipeline.BlueprintId == 0 {
+		return
+	}
+	blueprint, err := GetBlueprint(pipeline.BlueprintId, false)
+	if err != nil {
+		globalPipelineLog.Error(err, "failed to load blueprint %d for the notifications", pipeline.BlueprintId)
+		return
+	}
+	var message *BlueprintNotificationMessage
+	for _, channel := range blueprint.Notifications {
+		if !channel.NotifiesOn(pipeline.Status) {
+			continue
+		}
+		if message == nil {
+			message = newBlueprintNotificationMessage(blueprint, pipeline, cfg.GetString("NOTIFICATION_BASE_URL"))
+		}
+		if err := sendBlueprintNotification(channel, message); err != nil {
+			globalPipelineLog.Error(err, "failed to send the %s notification of pipeline %d", channel.Type, pipeline.ID)
+		}
+	}
+}
+
+func sendBlueprintNotification(channel *models.BlueprintNotification, message *BlueprintNotificationMessage) errors.Error {
+	data, e := json.Marshal(message)
+	if e != nil {
+		return errors.Convert(e)
+	}
+	notification := &models.Notification{
+		Type:     models.NotificationPipelineStatusChanged,
+		Endpoint: notificationEndpoint(channel),
+		Data:     string(data),
+	}
+	var err errors.Error
+	switch channel.Type {
+	case models.NOTIFICATION_CHANNEL_SLACK:
+		err = postNotification(notification, channel.Url, map[string]interface{}{
+			"text": fmt.Sprintf("*%s*\n%s", message.Title(), message.Text()),
+		}, nil)
+	case models.NOTIFICATION_CHANNEL_TEAMS:
+		err = postNotification(notification, channel.Url, map[string]interface{}{
+			"@type":    "MessageCard",
+			"@context": "https://schema.org/extensions",
+			"summary":  message.Title(),
+			"title":    message.Title(),
+			// teams renders markdown, two trailing spaces break the line
+			"text": strings.ReplaceAll(message.Text(), "\n", "  \n"),
+		}, nil)
+	case models.NOTIFICATION_CHANNEL_WEBHOOK:
+		var headers map[string]string
+		if channel.Secret != "" {
+			mac := hmac.New(sha256.New, []byte(channel.Secret))
+			mac.Write(data)
+			headers = map[string]string{"X-Devlake-Signature": "sha256=" + hex.EncodeToString(mac.Sum(nil))}
+		}
+		err = postNotification(notification, channel.Url, json.RawMessage(data), headers)
+	case models.NOTIFICATION_CHANNEL_EMAIL:
+		err = sendEmail(channel.Recipients, message.Title(), message.Text())
+	default:
+		err = errors.BadInput.New(fmt.Sprintf("unsupported notification type %s", channel.Type))
+	}
+	if err != nil {
+		notification.Response = err.Error()
+	}
+	if e := db.Create(notification); e != nil {
+		globalPipelineLog.Error(e, "failed to record the notification")
+	}
+	return err
+}
+
+// notificationEndpoint identifies the channel without the tokens the webhook urls carry
+func notificationEndpoint(channel *models.BlueprintNotification) string {
+	if channel.Type == models.NOTIFICATION_CHANNEL_EMAIL {
+		return fmt.Sprintf("email:%s", strings.Join(channel.Recipients, ","))
+	}
+	u, err := url.Parse(channel.Url)
+	if err != nil {
+		return channel.Type
+	}
+	return fmt.Sprintf("%s:%s://%s", channel.Type, u.Scheme, u.Host)
+}
+
+func postNotification(notification *models.Notification, endpoint string, payload interface{}, headers map[string]string) errors.Error {
+	body, e := json.Marshal(payload)
+	if e != nil {
+		return errors.Convert(e)
+	}
+	req, e := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+	if e != nil {
+		return errors.Convert(e)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, e := notificationClient.Do(req)
+	if e != nil {
+		return errors.Convert(e)
+	}
+	defer resp.Body.Close()
+	notification.ResponseCode = resp.StatusCode
+	respBody, e := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if e != nil {
+		return errors.Convert(e)
+	}
+	notification.Response = string(respBody)
+	if resp.StatusCode >= 300 {
+		return errors.HttpStatus(resp.StatusCode).New(fmt.Sprintf("notification endpoint responded %d", resp.StatusCode))
+	}
+	return nil
+}
+
+func sendEmail(recipients []string, subject, text string) errors.Error {
+	host := cfg.GetString("SMTP_HOST")
+	if host == "" {
+		return errors.Default.New("SMTP_HOST is not configured")
+	}
+	addr := fmt.Sprintf("%s:%d", host, cfg.GetInt("SMTP_PORT"))
+	from := cfg.GetString("SMTP_FROM")
+	var auth smtp.Auth
+	if username := cfg.GetString("SMTP_USERNAME"); username != "" {
+		auth = smtp.PlainAuth("", username, cfg.GetString("SMTP_PASSWORD"), host)
+	}
+	msg := fmt.Sprintf(
+		"From: %s\r\nTo: %s\r\nSubject: %s\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s\r\n",
+		from, strings.Join(recipients, ", "), subject, strings.ReplaceAll(text, "\n", "\r\n"),
+	)
+	if err := smtp.SendMail(addr, auth, from, recipients, []byte(msg)); err != nil {
+		return errors.Convert(err)
+	}
+	return nil
+}
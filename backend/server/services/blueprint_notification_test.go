@@ -0,0 +1,102 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlueprintNotificationMessage(t *testing.T) {
+	blueprint := &models.Blueprint{Model: common.Model{ID: 3}, Name: "bp", ProjectName: "p"}
+	pipeline := &models.Pipeline{Model: common.Model{ID: 12}, Status: models.TASK_FAILED, Message: "boom", SpentSeconds: 90}
+	message := newBlueprintNotificationMessage(blueprint, pipeline, "http://localhost:4000/")
+	assert.Equal(t, "Pipeline #12 of blueprint bp failed", message.Title())
+	assert.Equal(t, "http://localhost:4000/api/pipelines/12/logging.tar.gz", message.LogsUrl)
+	assert.Equal(t, "Project: p\nDuration: 1m30s\nError: boom\nLogs: http://localhost:4000/api/pipelines/12/logging.tar.gz", message.Text())
+
+	message = newBlueprintNotificationMessage(blueprint, pipeline, "")
+	assert.Empty(t, message.LogsUrl)
+}
+
+func TestNotificationEndpoint(t *testing.T) {
+	assert.Equal(t, "slack:https://hooks.slack.com", notificationEndpoint(&models.BlueprintNotification{
+		Type: models.NOTIFICATION_CHANNEL_SLACK,
+		Url:  "https://hooks.slack.com/services/T/B/secret",
+	}))
+	assert.Equal(t, "email:a@example.com,b@example.com", notificationEndpoint(&models.BlueprintNotification{
+		Type:       models.NOTIFICATION_CHANNEL_EMAIL,
+		Recipients: []string{"a@example.com", "b@example.com"},
+	}))
+}
+
+func TestPostNotification(t *testing.T) {
+	var body []byte
+	var signature string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		signature = r.Header.Get("X-Devlake-Signature")
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	data := json.RawMessage(`{"pipelineId":1}`)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(data)
+	expected := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	notification := &models.Notification{}
+	err := postNotification(notification, server.URL, data, map[string]string{"X-Devlake-Signature": expected})
+	assert.Nil(t, err)
+	assert.JSONEq(t, string(data), string(body))
+	assert.Equal(t, expected, signature)
+	assert.Equal(t, http.StatusAccepted, notification.ResponseCode)
+	assert.Equal(t, "ok", notification.Response)
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer failing.Close()
+	assert.NotNil(t, postNotification(&models.Notification{}, failing.URL, data, nil))
+}
+
+func TestSanitizeBlueprintNotifications(t *testing.T) {
+	blueprint := &models.Blueprint{
+		Notifications: []*models.BlueprintNotification{
+			{Type: models.NOTIFICATION_CHANNEL_WEBHOOK, Url: "https://hooks.example.com/notify", Secret: "s3cret"},
+		},
+	}
+	assert.Nil(t, SanitizeBlueprint(blueprint))
+	assert.Equal(t, "https://hooks.example.com/********", blueprint.Notifications[0].Url)
+	assert.Equal(t, models.SanitizedNotificationSecret, blueprint.Notifications[0].Secret)
+	exported := string(errors.Must1(json.Marshal(blueprint)))
+	assert.NotContains(t, exported, "s3cret")
+	assert.NotContains(t, exported, "/notify")
+}
@@ -0,0 +1,114 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+)
+
+var catchUpLock sync.Mutex
+var catchUpTimers = make(map[uint64]*time.Timer)
+
+func blueprintLocation(blueprint *models.Blueprint) (*time.Location, errors.Error) {
+	if blueprint.Timezone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(blueprint.Timezone)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, fmt.Sprintf("invalid timezone %s", blueprint.Timezone))
+	}
+	return loc, nil
+}
+
+func validateBlueprintSchedule(blueprint *models.Blueprint) errors.Error {
+	if _, err := blueprintLocation(blueprint); err != nil {
+		return err
+	}
+	for _, window := range blueprint.BlackoutWindows {
+		if err := window.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// blueprintCronSpec evaluates the cronConfig in the timezone of the blueprint unless it carries its own
+func blueprintCronSpec(blueprint *models.Blueprint) string {
+	spec := blueprint.CronConfig
+	if blueprint.Timezone == "" || strings.HasPrefix(spec, "TZ=") || strings.HasPrefix(spec, "CRON_TZ=") {
+		return spec
+	}
+	return fmt.Sprintf("CRON_TZ=%s %s", blueprint.Timezone, spec)
+}
+
+// blueprintBlackoutUntil returns when the blackout of the blueprint covering t ends
+func blueprintBlackoutUntil(blueprint *models.Blueprint, t time.Time) (time.Time, bool) {
+	if len(blueprint.BlackoutWindows) == 0 {
+		return time.Time{}, false
+	}
+	loc, err := blueprintLocation(blueprint)
+	if err != nil {
+		loc = time.UTC
+	}
+	return models.BlackoutUntil(blueprint.BlackoutWindows, t, loc)
+}
+
+// scheduleCatchUp runs the blueprint once the blackout ends, the runs skipped in the window are
+// merged into one. Catch-ups are kept in memory, the next scheduled run covers them after a restart
+func scheduleCatchUp(blueprintId uint64, at time.Time) {
+	catchUpLock.Lock()
+	defer catchUpLock.Unlock()
+	if timer, ok := catchUpTimers[blueprintId]; ok {
+		timer.Stop()
+	}
+	catchUpTimers[blueprintId] = time.AfterFunc(time.Until(at), func() {
+		catchUpLock.Lock()
+		delete(catchUpTimers, blueprintId)
+		catchUpLock.Unlock()
+		runCatchUp(blueprintId)
+	})
+	blueprintLog.Info("blueprint %d is in a blackout window, catching up at %s", blueprintId, at.Format(time.RFC3339))
+}
+
+func runCatchUp(blueprintId uint64) {
+	// the blueprint may have been updated during the blackout
+	blueprint, err := GetBlueprint(blueprintId, false)
+	if err != nil {
+		blueprintLog.Error(err, fmt.Sprintf("failed to load blueprint %d for the catch-up run", blueprintId))
+		return
+	}
+	if !blueprint.Enable || blueprint.IsManual {
+		return
+	}
+	unfinished, err := thereAreUnfinishedPipelinesUnderBlueprint(blueprintId)
+	if err != nil {
+		blueprintLog.Error(err, fmt.Sprintf("failed to check the pipelines of blueprint %d for the catch-up run", blueprintId))
+		return
+	}
+	if unfinished {
+		blueprintLog.Info("skip the catch-up run of blueprint %d, a pipeline is running already", blueprintId)
+		return
+	}
+	BlueprintJob{blueprint}.Run()
+}
@@ -0,0 +1,37 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlueprintCronSpec(t *testing.T) {
+	assert.Equal(t, "0 0 * * *", blueprintCronSpec(&models.Blueprint{CronConfig: "0 0 * * *"}))
+	assert.Equal(t, "CRON_TZ=Asia/Shanghai 0 0 * * *", blueprintCronSpec(&models.Blueprint{CronConfig: "0 0 * * *", Timezone: "Asia/Shanghai"}))
+	assert.Equal(t, "TZ=UTC 0 0 * * *", blueprintCronSpec(&models.Blueprint{CronConfig: "TZ=UTC 0 0 * * *", Timezone: "Asia/Shanghai"}))
+}
+
+func TestValidateBlueprintSchedule(t *testing.T) {
+	assert.Nil(t, validateBlueprintSchedule(&models.Blueprint{Timezone: "Europe/Berlin"}))
+	assert.NotNil(t, validateBlueprintSchedule(&models.Blueprint{Timezone: "Mars/Olympus"}))
+	assert.NotNil(t, validateBlueprintSchedule(&models.Blueprint{BlackoutWindows: []*models.BlackoutWindow{{StartTime: "09:00"}}}))
+}
@@ -20,8 +20,13 @@ package services
 import (
 	"testing"
 
+	"github.com/apache/incubator-devlake/core/errors"
 	coreModels "github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/services"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestParallelizePipelineTasks(t *testing.T) {
@@ -147,3 +152,37 @@ func TestRemoveCollectorTasks(t *testing.T) {
 		},
 	}, removeCollectorTasks(plan1))
 }
+
+func TestDeleteProjectBlueprintWithDependents(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	mockDal.On("First", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(0).(*coreModels.Blueprint).ID = 3
+	}).Return(nil)
+	mockDal.On("Pluck", "name", mock.Anything, mock.Anything).Return(nil)
+	mockDal.On("Pluck", "depends_on_id", mock.Anything, mock.Anything).Return(nil)
+	mockDal.On("All", mock.Anything, mock.Anything).Return(nil)
+	mockDal.On("Pluck", "blueprint_id", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(1).(*[]uint64) = []uint64{5}
+	}).Return(nil)
+	original := bpManager
+	defer func() { bpManager = original }()
+	bpManager = services.NewBlueprintManager(mockDal)
+
+	err := deleteProjectBlueprint("devlake")
+	assert.Equal(t, errors.BadInput, err.GetType())
+	mockDal.AssertNotCalled(t, "Begin")
+}
+
+func TestManualPipelinePriority(t *testing.T) {
+	original := cfg
+	defer func() { cfg = original }()
+	v := viper.New()
+	cfg = v
+
+	assert.Equal(t, coreModels.PIPELINE_PRIORITY_HIGH, manualPipelinePriority(coreModels.PIPELINE_PRIORITY_NORMAL))
+	assert.Equal(t, 20, manualPipelinePriority(20))
+
+	v.Set("MANUAL_PIPELINE_PRIORITY", 5)
+	assert.Equal(t, 5, manualPipelinePriority(coreModels.PIPELINE_PRIORITY_LOW))
+	assert.Equal(t, 8, manualPipelinePriority(8))
+}
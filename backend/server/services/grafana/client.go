@@ -0,0 +1,187 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grafana
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+// Client talks to the HTTP API of Grafana, authenticated by either a service account token (api key) or a user
+type Client struct {
+	endpoint string
+	apiKey   string
+	username string
+	password string
+	client   *http.Client
+}
+
+// NewClient creates a Client, the apiKey takes precedence over the username and password
+func NewClient(endpoint, apiKey, username, password string) (*Client, errors.Error) {
+	if endpoint == "" {
+		return nil, errors.BadInput.New("GRAFANA_ENDPOINT is not configured")
+	}
+	if _, err := url.ParseRequestURI(endpoint); err != nil {
+		return nil, errors.BadInput.Wrap(err, "GRAFANA_ENDPOINT is invalid")
+	}
+	if apiKey == "" && username == "" {
+		return nil, errors.BadInput.New("either GRAFANA_API_KEY or GRAFANA_USER must be configured")
+	}
+	return &Client{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		apiKey:   apiKey,
+		username: username,
+		password: password,
+		client:   &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// Folder is a dashboard folder of Grafana
+type Folder struct {
+	Id    int64  `json:"id"`
+	Uid   string `json:"uid"`
+	Title string `json:"title"`
+	Url   string `json:"url"`
+}
+
+// SavedDashboard is the response of saving a dashboard
+type SavedDashboard struct {
+	Id      int64  `json:"id"`
+	Uid     string `json:"uid"`
+	Url     string `json:"url"`
+	Status  string `json:"status"`
+	Version int    `json:"version"`
+}
+
+// GetDashboard returns the model of the dashboard, nil if it doesn't exist
+func (c *Client) GetDashboard(uid string) (map[string]interface{}, errors.Error) {
+	var res struct {
+		Dashboard map[string]interface{} `json:"dashboard"`
+	}
+	status, err := c.do(http.MethodGet, "/api/dashboards/uid/"+url.PathEscape(uid), nil, &res)
+	if status == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res.Dashboard, nil
+}
+
+// EnsureFolder creates the folder unless it exists, the title of an existing folder is updated
+func (c *Client) EnsureFolder(uid, title string) (*Folder, errors.Error) {
+	folder := &Folder{}
+	status, err := c.do(http.MethodGet, "/api/folders/"+url.PathEscape(uid), nil, folder)
+	if status == http.StatusNotFound {
+		_, err = c.do(http.MethodPost, "/api/folders", map[string]interface{}{"uid": uid, "title": title}, folder)
+		return folder, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	if folder.Title != title {
+		_, err = c.do(http.MethodPut, "/api/folders/"+url.PathEscape(uid), map[string]interface{}{
+			"title":     title,
+			"overwrite": true,
+		}, folder)
+	}
+	return folder, err
+}
+
+// SaveDashboard creates or overwrites the dashboard in the folder
+func (c *Client) SaveDashboard(dashboard map[string]interface{}, folderUid, message string) (*SavedDashboard, errors.Error) {
+	saved := &SavedDashboard{}
+	_, err := c.do(http.MethodPost, "/api/dashboards/db", map[string]interface{}{
+		"dashboard": dashboard,
+		"folderUid": folderUid,
+		"overwrite": true,
+		"message":   message,
+	}, saved)
+	return saved, err
+}
+
+// Url returns the absolute url of the path returned by Grafana
+func (c *Client) Url(path string) string {
+	if path == "" || strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		return path
+	}
+	// grafana served from a sub path includes the sub path in the urls it returns
+	if u, err := url.Parse(c.endpoint); err == nil && u.Path != "" && strings.HasPrefix(path, u.Path+"/") {
+		path = strings.TrimPrefix(path, u.Path)
+	}
+	return c.endpoint + path
+}
+
+func (c *Client) do(method, path string, body interface{}, result interface{}) (int, errors.Error) {
+	var reader io.Reader
+	if body != nil {
+		blob, err := json.Marshal(body)
+		if err != nil {
+			return 0, errors.Convert(err)
+		}
+		reader = bytes.NewReader(blob)
+	}
+	req, e := http.NewRequest(method, c.endpoint+path, reader)
+	if e != nil {
+		return 0, errors.Convert(e)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	} else {
+		req.SetBasicAuth(c.username, c.password)
+	}
+	resp, e := c.client.Do(req)
+	if e != nil {
+		return 0, errors.Default.Wrap(e, fmt.Sprintf("failed to request grafana %s %s", method, path))
+	}
+	defer resp.Body.Close()
+	blob, e := io.ReadAll(resp.Body)
+	if e != nil {
+		return resp.StatusCode, errors.Convert(e)
+	}
+	if resp.StatusCode >= 300 {
+		var res struct {
+			Message string `json:"message"`
+		}
+		_ = json.Unmarshal(blob, &res)
+		if res.Message == "" {
+			res.Message = http.StatusText(resp.StatusCode)
+		}
+		return resp.StatusCode, errors.HttpStatus(resp.StatusCode).New(
+			fmt.Sprintf("grafana %s %s responded %d: %s", method, path, resp.StatusCode, res.Message),
+		)
+	}
+	if result != nil && len(blob) > 0 {
+		if e := json.Unmarshal(blob, result); e != nil {
+			return resp.StatusCode, errors.Default.Wrap(e, fmt.Sprintf("failed to decode the response of grafana %s %s", method, path))
+		}
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,185 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grafana
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+// DefaultTemplateUids are the standard dashboards filtering by project, board or repo
+var DefaultTemplateUids = []string{
+	"qNo8_0M4z",                            // DORA
+	"ZF6abXX7z",                            // Engineering Overview
+	"Jaaimc67k",                            // Engineering Throughput and Cycle Time
+	"KXWvOFQnz",                            // GitHub
+	"msSjEq97z",                            // GitLab
+	"4LzQHZa4k",                            // Bitbucket
+	"ba7e3a95-80ed-4067-a54b-2a82758eb3dd", // Azure DevOps
+	"F5vqBQl7z",                            // Jira
+	"hi-907hVk",                            // TAPD
+	"hi-908hVk",                            // Teambition
+	"yMb4MKh4k",                            // Zentao
+	"b4556439-f173-4411-93d4-65f261726d24", // Opsgenie
+	"abb26d07-3268-4bdf-b871-6e39b37b9e00", // PagerDuty
+}
+
+// the plugin filter of the board_id and repo_id variables, e.g. `where id like 'github%'`
+var idPrefixPattern = regexp.MustCompile(`(?i)\bid\s+like\s+'([^'%]*)%'`)
+
+// Option is a board or a repo the variables of the dashboards are bound to
+type Option struct {
+	Text  string `json:"text"`
+	Value string `json:"value"`
+}
+
+// Bindings are the values the variables of the dashboards of a project are bound to
+type Bindings struct {
+	Project string
+	Boards  []Option
+	Repos   []Option
+}
+
+// FolderUid returns the uid of the folder of the project, it stays the same so provisioning again updates the folder
+func FolderUid(projectName string) string {
+	return "devlake-" + shortHash(projectName, 16)
+}
+
+// DashboardUid returns the uid of the copy of the template in the folder of the project
+func DashboardUid(projectName, templateUid string) string {
+	return "dl-" + shortHash(projectName+"/"+templateUid, 24)
+}
+
+// BindDashboard copies the template and binds its project, board_id and repo_id variables to the project, false is
+// returned when the project has no boards or repos of the plugin the template is about
+func BindDashboard(template map[string]interface{}, uid string, bindings *Bindings) (map[string]interface{}, bool, errors.Error) {
+	blob, err := json.Marshal(template)
+	if err != nil {
+		return nil, false, errors.Convert(err)
+	}
+	dashboard := make(map[string]interface{})
+	if err := json.Unmarshal(blob, &dashboard); err != nil {
+		return nil, false, errors.Convert(err)
+	}
+	delete(dashboard, "id")
+	delete(dashboard, "version")
+	dashboard["uid"] = uid
+	templating, _ := dashboard["templating"].(map[string]interface{})
+	variables, _ := templating["list"].([]interface{})
+	for _, v := range variables {
+		variable, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		switch variable["name"] {
+		case "project":
+			bindVariable(variable, []Option{{Text: bindings.Project, Value: bindings.Project}})
+			// every dashboard in the folder is about the project
+			variable["hide"] = 2
+		case "board_id":
+			options := filterOptions(variable, bindings.Boards)
+			if len(options) == 0 {
+				return nil, false, nil
+			}
+			bindVariable(variable, options)
+		case "repo_id":
+			options := filterOptions(variable, bindings.Repos)
+			if len(options) == 0 {
+				return nil, false, nil
+			}
+			bindVariable(variable, options)
+		}
+	}
+	return dashboard, true, nil
+}
+
+// filterOptions keeps the options of the plugin the query of the variable selects
+func filterOptions(variable map[string]interface{}, options []Option) []Option {
+	query, _ := variable["query"].(string)
+	if q, ok := variable["query"].(map[string]interface{}); ok {
+		query, _ = q["query"].(string)
+	}
+	match := idPrefixPattern.FindStringSubmatch(query)
+	if match == nil {
+		return options
+	}
+	filtered := make([]Option, 0, len(options))
+	for _, option := range options {
+		if strings.HasPrefix(option.Value, match[1]) {
+			filtered = append(filtered, option)
+		}
+	}
+	return filtered
+}
+
+// bindVariable turns the sql query variable into a custom variable listing the options only
+func bindVariable(variable map[string]interface{}, options []Option) {
+	includeAll, _ := variable["includeAll"].(bool)
+	multi, _ := variable["multi"].(bool)
+	if len(options) == 1 {
+		includeAll = false
+	}
+	pairs := make([]string, len(options))
+	grafanaOptions := make([]interface{}, 0, len(options)+1)
+	if includeAll {
+		grafanaOptions = append(grafanaOptions, map[string]interface{}{"text": "All", "value": "$__all", "selected": true})
+	}
+	for i, option := range options {
+		pairs[i] = escapeCustomValue(option.Value)
+		if option.Text != option.Value {
+			pairs[i] = escapeCustomValue(option.Text) + " : " + pairs[i]
+		}
+		grafanaOptions = append(grafanaOptions, map[string]interface{}{
+			"text":     option.Text,
+			"value":    option.Value,
+			"selected": !includeAll && i == 0,
+		})
+	}
+	current := grafanaOptions[0].(map[string]interface{})
+	if multi {
+		current = map[string]interface{}{
+			"text":     []string{current["text"].(string)},
+			"value":    []string{current["value"].(string)},
+			"selected": true,
+		}
+	}
+	variable["type"] = "custom"
+	variable["query"] = strings.Join(pairs, ",")
+	variable["options"] = grafanaOptions
+	variable["current"] = current
+	variable["includeAll"] = includeAll
+	variable["allValue"] = ""
+	delete(variable, "datasource")
+	delete(variable, "definition")
+	delete(variable, "regex")
+	delete(variable, "refresh")
+}
+
+func escapeCustomValue(s string) string {
+	return strings.ReplaceAll(s, ",", `\,`)
+}
+
+func shortHash(s string, length int) string {
+	sum := sha1.Sum([]byte(s))
+	return hex.EncodeToString(sum[:])[:length]
+}
@@ -0,0 +1,86 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grafana
+
+import (
+	"fmt"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+// ProvisionResult lists the dashboards provisioned in the folder of a project
+type ProvisionResult struct {
+	FolderUid  string                  `json:"folderUid"`
+	FolderUrl  string                  `json:"folderUrl"`
+	Dashboards []*ProvisionedDashboard `json:"dashboards"`
+	// the templates not found in grafana or not relevant to the project, e.g. GitHub for a project without GitHub repos
+	Skipped []string `json:"skipped"`
+}
+
+// ProvisionedDashboard is the copy of a template in the folder of a project
+type ProvisionedDashboard struct {
+	TemplateUid string `json:"templateUid"`
+	Uid         string `json:"uid"`
+	Title       string `json:"title"`
+	Url         string `json:"url"`
+}
+
+// Provision copies the templates into the folder of the project with their variables bound to the project,
+// provisioning again overwrites the copies so they follow the changes of the templates and the project
+func Provision(client *Client, bindings *Bindings, templateUids []string) (*ProvisionResult, errors.Error) {
+	folder, err := client.EnsureFolder(FolderUid(bindings.Project), "DevLake - "+bindings.Project)
+	if err != nil {
+		return nil, err
+	}
+	result := &ProvisionResult{
+		FolderUid:  folder.Uid,
+		FolderUrl:  client.Url(folder.Url),
+		Dashboards: []*ProvisionedDashboard{},
+		Skipped:    []string{},
+	}
+	for _, templateUid := range templateUids {
+		template, err := client.GetDashboard(templateUid)
+		if err != nil {
+			return nil, err
+		}
+		if template == nil {
+			result.Skipped = append(result.Skipped, templateUid)
+			continue
+		}
+		dashboard, ok, err := BindDashboard(template, DashboardUid(bindings.Project, templateUid), bindings)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			result.Skipped = append(result.Skipped, templateUid)
+			continue
+		}
+		saved, err := client.SaveDashboard(dashboard, folder.Uid, fmt.Sprintf("provisioned by devlake for project %s", bindings.Project))
+		if err != nil {
+			return nil, err
+		}
+		title, _ := dashboard["title"].(string)
+		result.Dashboards = append(result.Dashboards, &ProvisionedDashboard{
+			TemplateUid: templateUid,
+			Uid:         saved.Uid,
+			Title:       title,
+			Url:         client.Url(saved.Url),
+		})
+	}
+	return result, nil
+}
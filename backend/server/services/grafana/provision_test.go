@@ -0,0 +1,174 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grafana
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func templateVariable(name, query string) map[string]interface{} {
+	return map[string]interface{}{
+		"name":       name,
+		"type":       "query",
+		"query":      query,
+		"definition": query,
+		"datasource": "mysql",
+		"includeAll": true,
+		"multi":      true,
+		"regex":      "/^(?<text>.*)--(?<value>.*)$/",
+	}
+}
+
+func templateDashboard(uid, title string, variables ...interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"id":         float64(12),
+		"uid":        uid,
+		"title":      title,
+		"version":    float64(3),
+		"templating": map[string]interface{}{"list": variables},
+	}
+}
+
+var testBindings = &Bindings{
+	Project: "devlake",
+	Boards:  []Option{{Text: "DL", Value: "jira:JiraBoard:1:8"}},
+	Repos: []Option{
+		{Text: "apache/incubator-devlake", Value: "github:GithubRepo:1:384111310"},
+		{Text: "apache/incubator-devlake-website", Value: "github:GithubRepo:1:454012304"},
+		{Text: "devlake/lake", Value: "gitlab:GitlabProject:1:8967944"},
+	},
+}
+
+func TestBindDashboard(t *testing.T) {
+	template := templateDashboard("KXWvOFQnz", "GitHub",
+		templateVariable("repo_id", "select concat(name, '--', id) as text from repos where id like 'github%'"),
+		templateVariable("project", "select distinct name from projects"),
+	)
+	dashboard, ok, err := BindDashboard(template, "dl-1", testBindings)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "dl-1", dashboard["uid"])
+	assert.NotContains(t, dashboard, "id")
+	assert.NotContains(t, dashboard, "version")
+	// the template is left intact
+	assert.Equal(t, "KXWvOFQnz", template["uid"])
+
+	variables := dashboard["templating"].(map[string]interface{})["list"].([]interface{})
+	repo := variables[0].(map[string]interface{})
+	assert.Equal(t, "custom", repo["type"])
+	assert.Equal(t, "apache/incubator-devlake : github:GithubRepo:1:384111310,apache/incubator-devlake-website : github:GithubRepo:1:454012304", repo["query"])
+	assert.Len(t, repo["options"], 3)
+	assert.Equal(t, []string{"$__all"}, repo["current"].(map[string]interface{})["value"])
+	assert.NotContains(t, repo, "regex")
+	assert.NotContains(t, repo, "datasource")
+
+	project := variables[1].(map[string]interface{})
+	assert.Equal(t, "devlake", project["query"])
+	assert.Equal(t, false, project["includeAll"])
+	assert.Equal(t, 2, project["hide"])
+	assert.Equal(t, []string{"devlake"}, project["current"].(map[string]interface{})["value"])
+}
+
+func TestBindDashboardWithoutScopesOfThePlugin(t *testing.T) {
+	template := templateDashboard("F5vqBQl7z", "Jira",
+		templateVariable("board_id", "select concat(name, '--', id) from boards where id like 'jira%'"),
+	)
+	_, ok, err := BindDashboard(template, "dl-1", &Bindings{Project: "devlake"})
+	assert.Nil(t, err)
+	assert.False(t, ok)
+}
+
+func TestProvision(t *testing.T) {
+	saved := make(map[string]map[string]interface{})
+	folders := make(map[string]string)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/grafana/api/folders/"+FolderUid("devlake"):
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"folder not found"}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/grafana/api/folders":
+			var body map[string]string
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+			folders[body["uid"]] = body["title"]
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"uid": body["uid"], "title": body["title"], "url": "/grafana/dashboards/f/" + body["uid"] + "/"})
+		case r.Method == http.MethodGet && r.URL.Path == "/grafana/api/dashboards/uid/qNo8_0M4z":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"dashboard": templateDashboard("qNo8_0M4z", "DORA",
+				templateVariable("project", "select distinct name from projects"),
+			)})
+		case r.Method == http.MethodGet && r.URL.Path == "/grafana/api/dashboards/uid/F5vqBQl7z":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"dashboard": templateDashboard("F5vqBQl7z", "Jira",
+				templateVariable("board_id", "select concat(name, '--', id) from boards where id like 'jira%'"),
+			)})
+		case r.Method == http.MethodGet && r.URL.Path == "/grafana/api/dashboards/uid/msSjEq97z":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"dashboard": templateDashboard("msSjEq97z", "GitLab",
+				templateVariable("repo_id", "select concat(name, '--', id) as text from repos where id like 'gitlab%'"),
+			)})
+		case r.Method == http.MethodPost && r.URL.Path == "/grafana/api/dashboards/db":
+			var body struct {
+				Dashboard map[string]interface{} `json:"dashboard"`
+				FolderUid string                 `json:"folderUid"`
+				Overwrite bool                   `json:"overwrite"`
+			}
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, FolderUid("devlake"), body.FolderUid)
+			assert.True(t, body.Overwrite)
+			uid := body.Dashboard["uid"].(string)
+			saved[uid] = body.Dashboard
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"uid": uid, "url": "/grafana/d/" + uid + "/x", "status": "success"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Dashboard not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL+"/grafana/", "secret", "", "")
+	assert.Nil(t, err)
+	bindings := &Bindings{Project: "devlake", Boards: []Option{}, Repos: testBindings.Repos}
+	result, err := Provision(client, bindings, []string{"qNo8_0M4z", "F5vqBQl7z", "msSjEq97z", "missing"})
+	assert.Nil(t, err)
+	assert.Equal(t, "DevLake - devlake", folders[FolderUid("devlake")])
+	assert.Equal(t, server.URL+"/grafana/dashboards/f/"+FolderUid("devlake")+"/", result.FolderUrl)
+	assert.Equal(t, []string{"F5vqBQl7z", "missing"}, result.Skipped)
+	if assert.Len(t, result.Dashboards, 2) {
+		assert.Equal(t, "DORA", result.Dashboards[0].Title)
+		assert.Equal(t, DashboardUid("devlake", "qNo8_0M4z"), result.Dashboards[0].Uid)
+		assert.Equal(t, server.URL+"/grafana/d/"+result.Dashboards[0].Uid+"/x", result.Dashboards[0].Url)
+		assert.Equal(t, "GitLab", result.Dashboards[1].Title)
+	}
+	gitlab := saved[DashboardUid("devlake", "msSjEq97z")]
+	repo := gitlab["templating"].(map[string]interface{})["list"].([]interface{})[0].(map[string]interface{})
+	// a single repo needs no "All"
+	assert.Equal(t, "devlake/lake : gitlab:GitlabProject:1:8967944", repo["query"])
+	assert.Equal(t, false, repo["includeAll"])
+}
+
+func TestNewClient(t *testing.T) {
+	_, err := NewClient("", "secret", "", "")
+	assert.NotNil(t, err)
+	_, err = NewClient("http://grafana:3000", "", "", "")
+	assert.NotNil(t, err)
+	_, err = NewClient("http://grafana:3000", "", "admin", "admin")
+	assert.Nil(t, err)
+}
@@ -0,0 +1,150 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/log"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/services/grafana"
+)
+
+// ProvisionProjectDashboards creates the grafana folder of the project holding the standard dashboards with their
+// project, board and repo variables bound to the project
+func ProvisionProjectDashboards(projectName string) (*grafana.ProvisionResult, errors.Error) {
+	if projectName == "" {
+		return nil, errors.BadInput.New("project name is missing")
+	}
+	if _, err := getProjectByName(db, projectName); err != nil {
+		return nil, err
+	}
+	bindings, err := loadProjectBindings(projectName)
+	if err != nil {
+		return nil, err
+	}
+	return provisionBindings(bindings)
+}
+
+// project name => *grafana.Bindings the dashboards of the project were last provisioned with
+var provisionedBindings sync.Map
+
+func provisionBindings(bindings *grafana.Bindings) (*grafana.ProvisionResult, errors.Error) {
+	client, err := grafana.NewClient(
+		cfg.GetString("GRAFANA_ENDPOINT"),
+		cfg.GetString("GRAFANA_API_KEY"),
+		cfg.GetString("GRAFANA_USER"),
+		cfg.GetString("GRAFANA_PASSWORD"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	result, err := grafana.Provision(client, bindings, grafanaTemplateUids())
+	if err != nil {
+		return nil, err
+	}
+	provisionedBindings.Store(bindings.Project, bindings)
+	return result, nil
+}
+
+// bindingsChanged tells whether the boards or repos of the project changed since its dashboards were provisioned
+func bindingsChanged(bindings *grafana.Bindings) bool {
+	provisioned, ok := provisionedBindings.Load(bindings.Project)
+	return !ok || !reflect.DeepEqual(provisioned, bindings)
+}
+
+func loadProjectBindings(projectName string) (*grafana.Bindings, errors.Error) {
+	var err errors.Error
+	bindings := &grafana.Bindings{Project: projectName}
+	bindings.Boards, err = loadProjectOptions(projectName, "boards")
+	if err != nil {
+		return nil, err
+	}
+	bindings.Repos, err = loadProjectOptions(projectName, "repos")
+	if err != nil {
+		return nil, err
+	}
+	return bindings, nil
+}
+
+// autoProvisionProjectDashboards provisions the dashboards of the project created or synced when
+// GRAFANA_AUTO_PROVISION is on, the boards and repos are known only after the first sync of the project. The
+// dashboards are left alone as long as the boards and repos stay the same
+func autoProvisionProjectDashboards(logger log.Logger, projectName string) {
+	if !cfg.GetBool("GRAFANA_AUTO_PROVISION") || projectName == "" {
+		return
+	}
+	bindings, err := loadProjectBindings(projectName)
+	if err == nil && bindingsChanged(bindings) {
+		_, err = provisionBindings(bindings)
+	}
+	if err != nil {
+		logger.Error(err, "failed to provision the grafana dashboards of project %s", projectName)
+	}
+}
+
+// provisionProjectDashboardsOfPipeline provisions the dashboards in the background, grafana being slow or down
+// mustn't hold the pipeline from finishing
+func provisionProjectDashboardsOfPipeline(logger log.Logger, pipeline *models.Pipeline) {
+	if !cfg.GetBool("GRAFANA_AUTO_PROVISION") || pipeline.Status != models.TASK_COMPLETED || pipeline.BlueprintId == 0 {
+		return
+	}
+	blueprintId := pipeline.BlueprintId
+	go func() {
+		blueprint, err := GetBlueprint(blueprintId, false)
+		if err != nil {
+			logger.Error(err, "failed to load blueprint %d for provisioning grafana dashboards", blueprintId)
+			return
+		}
+		autoProvisionProjectDashboards(logger, blueprint.ProjectName)
+	}()
+}
+
+// loadProjectOptions returns the boards or repos mapped to the project
+func loadProjectOptions(projectName, table string) ([]grafana.Option, errors.Error) {
+	options := make([]grafana.Option, 0)
+	err := db.All(&options,
+		dal.Select("t.id AS value, t.name AS text"),
+		dal.From(table+" t"),
+		dal.Join("INNER JOIN project_mapping pm ON pm.row_id = t.id AND pm.table = ?", table),
+		dal.Where("pm.project_name = ?", projectName),
+		dal.Orderby("t.name"),
+	)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error loading the "+table+" of project "+projectName)
+	}
+	return options, nil
+}
+
+func grafanaTemplateUids() []string {
+	configured := cfg.GetString("GRAFANA_DASHBOARD_TEMPLATES")
+	if strings.TrimSpace(configured) == "" {
+		return grafana.DefaultTemplateUids
+	}
+	uids := make([]string, 0)
+	for _, uid := range strings.Split(configured, ",") {
+		if uid = strings.TrimSpace(uid); uid != "" {
+			uids = append(uids, uid)
+		}
+	}
+	return uids
+}
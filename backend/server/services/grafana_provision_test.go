@@ -0,0 +1,45 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/server/services/grafana"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBindingsChanged(t *testing.T) {
+	defer provisionedBindings.Delete("devlake")
+	bindings := func(repos ...string) *grafana.Bindings {
+		b := &grafana.Bindings{Project: "devlake", Boards: []grafana.Option{{Value: "jira:JiraBoard:1:1", Text: "DLK"}}}
+		for _, repo := range repos {
+			b.Repos = append(b.Repos, grafana.Option{Value: repo, Text: repo})
+		}
+		return b
+	}
+
+	// never provisioned since started
+	assert.True(t, bindingsChanged(bindings("github:GithubRepo:1:1")))
+	provisionedBindings.Store("devlake", bindings("github:GithubRepo:1:1"))
+	assert.False(t, bindingsChanged(bindings("github:GithubRepo:1:1")))
+	// a repo added to the project
+	assert.True(t, bindingsChanged(bindings("github:GithubRepo:1:1", "github:GithubRepo:1:2")))
+	// other projects are tracked on their own
+	assert.True(t, bindingsChanged(&grafana.Bindings{Project: "other"}))
+}
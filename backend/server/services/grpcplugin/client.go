@@ -0,0 +1,316 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcplugin
+
+import (
+	gocontext "context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/log"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+	remotemodels "github.com/apache/incubator-devlake/server/services/remote/models"
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-hclog"
+	goplugin "github.com/hashicorp/go-plugin"
+)
+
+// grpcPlugin is the server side proxy of a plugin running in its own process
+type grpcPlugin struct {
+	basicRes context.BasicRes
+	path     string
+	info     *InfoResponse
+	mu       sync.Mutex
+	client   *goplugin.Client
+	rpc      *rpcClient
+}
+
+// grpcDatasourcePlugin is a grpcPlugin that is also able to make pipeline plans
+type grpcDatasourcePlugin struct {
+	*grpcPlugin
+}
+
+// grpcTaskData identifies the task on the plugin side
+type grpcTaskData struct {
+	taskKey string
+	options map[string]interface{}
+}
+
+type grpcMigrationScript struct {
+	plugin *grpcPlugin
+	info   MigrationScriptInfo
+}
+
+// Load launches the plugin binary at the given path and returns a proxy that can be registered like any other plugin
+func Load(basicRes context.BasicRes, path string) (plugin.PluginMeta, errors.Error) {
+	p := &grpcPlugin{
+		basicRes: basicRes,
+		path:     path,
+	}
+	info := &InfoResponse{}
+	err := p.call(gocontext.Background(), "Info", &Empty{}, info)
+	if err != nil {
+		p.kill()
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to load plugin %s", path))
+	}
+	p.info = info
+	if info.DataSource {
+		return &grpcDatasourcePlugin{p}, nil
+	}
+	return p, nil
+}
+
+// Shutdown kills all plugin processes launched by the server
+func Shutdown() {
+	goplugin.CleanupClients()
+}
+
+// connect returns a client of the plugin process, the process gets relaunched if it exited (i.e. panicked)
+func (p *grpcPlugin) connect() (*rpcClient, errors.Error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.client != nil && !p.client.Exited() {
+		return p.rpc, nil
+	}
+	if p.client != nil {
+		p.basicRes.GetLogger().Warn(nil, "plugin process %s exited, restarting", p.path)
+	}
+	client := goplugin.NewClient(&goplugin.ClientConfig{
+		HandshakeConfig:  Handshake,
+		Plugins:          goplugin.PluginSet{pluginKey: &rpcPlugin{}},
+		Cmd:              exec.Command(p.path),
+		AllowedProtocols: []goplugin.Protocol{goplugin.ProtocolGRPC},
+		Managed:          true,
+		Logger: hclog.New(&hclog.LoggerOptions{
+			Name:   p.path,
+			Level:  hclog.Debug,
+			Output: &logWriter{p.basicRes.GetLogger()},
+		}),
+	})
+	protocolClient, err := client.Client()
+	if err != nil {
+		client.Kill()
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to start plugin process %s", p.path))
+	}
+	raw, err := protocolClient.Dispense(pluginKey)
+	if err != nil {
+		client.Kill()
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to dispense plugin %s", p.path))
+	}
+	p.client = client
+	p.rpc = raw.(*rpcClient)
+	return p.rpc, nil
+}
+
+func (p *grpcPlugin) kill() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.client != nil {
+		p.client.Kill()
+	}
+}
+
+func (p *grpcPlugin) call(ctx gocontext.Context, method string, req interface{}, resp reply) errors.Error {
+	rpc, err := p.connect()
+	if err != nil {
+		return err
+	}
+	return rpc.invoke(ctx, method, req, resp)
+}
+
+func (p *grpcPlugin) Description() string {
+	return p.info.Description
+}
+
+func (p *grpcPlugin) RootPkgPath() string {
+	return p.info.RootPkgPath
+}
+
+func (p *grpcPlugin) Name() string {
+	return p.info.Name
+}
+
+func (p *grpcPlugin) SubTaskMetas() []plugin.SubTaskMeta {
+	var subtaskMetas []plugin.SubTaskMeta
+	for _, info := range p.info.SubTaskMetas {
+		subtaskMetas = append(subtaskMetas, plugin.SubTaskMeta{
+			Name:             info.Name,
+			EntryPoint:       p.subtaskEntryPoint(info.Name),
+			Required:         info.Required,
+			EnabledByDefault: info.EnabledByDefault,
+			Description:      info.Description,
+			DomainTypes:      info.DomainTypes,
+			DependencyTables: info.DependencyTables,
+			ProductTables:    info.ProductTables,
+			ForceRunOnResume: info.ForceRunOnResume,
+		})
+	}
+	return subtaskMetas
+}
+
+func (p *grpcPlugin) subtaskEntryPoint(name string) plugin.SubTaskEntryPoint {
+	return func(c plugin.SubTaskContext) errors.Error {
+		data := c.GetData().(*grpcTaskData)
+		return p.call(c.GetContext(), "ExecuteSubtask", &ExecuteSubtaskRequest{
+			TaskKey:    data.taskKey,
+			Options:    data.options,
+			Subtask:    name,
+			SyncPolicy: c.TaskContext().SyncPolicy(),
+		}, &Reply{})
+	}
+}
+
+func (p *grpcPlugin) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]interface{}) (interface{}, errors.Error) {
+	data := &grpcTaskData{
+		taskKey: uuid.New().String(),
+		options: options,
+	}
+	err := p.call(taskCtx.GetContext(), "PrepareTask", &PrepareTaskRequest{
+		TaskKey: data.taskKey,
+		Options: options,
+	}, &Reply{})
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (p *grpcPlugin) Close(taskCtx plugin.TaskContext) errors.Error {
+	data, ok := taskCtx.GetData().(*grpcTaskData)
+	if !ok {
+		return nil
+	}
+	return p.call(gocontext.Background(), "CloseTask", &CloseTaskRequest{TaskKey: data.taskKey}, &Reply{})
+}
+
+func (p *grpcPlugin) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
+	resources := make(map[string]map[string]plugin.ApiResourceHandler)
+	for path, methods := range p.info.ApiResources {
+		resources[path] = make(map[string]plugin.ApiResourceHandler)
+		for _, method := range methods {
+			resources[path][method] = p.apiResourceHandler(path, method)
+		}
+	}
+	return resources
+}
+
+func (p *grpcPlugin) apiResourceHandler(path string, method string) plugin.ApiResourceHandler {
+	return func(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+		req := &ApiResourceRequest{
+			Path:    path,
+			Method:  method,
+			Params:  input.Params,
+			Query:   input.Query,
+			Body:    input.Body,
+			RawBody: input.RawBody,
+			User:    input.User,
+		}
+		ctx := gocontext.Background()
+		if input.Request != nil {
+			req.Header = input.Request.Header
+			ctx = input.Request.Context()
+		}
+		resp := &ApiResourceResponse{}
+		err := p.call(ctx, "ApiResource", req, resp)
+		if err != nil {
+			return nil, err
+		}
+		output := &plugin.ApiResourceOutput{
+			Status:      resp.Status,
+			File:        resp.File,
+			ContentType: resp.ContentType,
+			Header:      resp.Header,
+		}
+		if resp.Body != nil {
+			output.Body = resp.Body
+		}
+		return output, nil
+	}
+}
+
+func (p *grpcPlugin) MigrationScripts() []plugin.MigrationScript {
+	var scripts []plugin.MigrationScript
+	for _, info := range p.info.MigrationScripts {
+		scripts = append(scripts, &grpcMigrationScript{plugin: p, info: info})
+	}
+	return scripts
+}
+
+func (s *grpcMigrationScript) Up(_ context.BasicRes) errors.Error {
+	return s.plugin.call(gocontext.Background(), "Migrate", &MigrateRequest{Version: s.info.Version}, &Reply{})
+}
+
+func (s *grpcMigrationScript) Version() uint64 {
+	return s.info.Version
+}
+
+func (s *grpcMigrationScript) Name() string {
+	return s.info.Name
+}
+
+func (p *grpcDatasourcePlugin) MakeDataSourcePipelinePlanV200(
+	connectionId uint64,
+	scopes []*models.BlueprintScope,
+) (models.PipelinePlan, []plugin.Scope, errors.Error) {
+	resp := &MakePipelinePlanResponse{}
+	err := p.call(gocontext.Background(), "MakePipelinePlan", &MakePipelinePlanRequest{
+		ConnectionId: connectionId,
+		Scopes:       scopes,
+	}, resp)
+	if err != nil {
+		return nil, nil, err
+	}
+	domainScopes, err := toDomainScopes(resp.Scopes)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp.Plan, domainScopes, nil
+}
+
+func toDomainScopes(dynamicScopes []remotemodels.DynamicDomainScope) ([]plugin.Scope, errors.Error) {
+	var scopes []plugin.Scope
+	for _, dynamicScope := range dynamicScopes {
+		scope, err := dynamicScope.Load()
+		if err != nil {
+			return nil, err
+		}
+		scopes = append(scopes, scope)
+	}
+	return scopes, nil
+}
+
+// logWriter forwards the output of plugin processes to the server logger
+type logWriter struct {
+	logger log.Logger
+}
+
+func (w *logWriter) Write(p []byte) (int, error) {
+	w.logger.Info("%s", strings.TrimRight(string(p), "\n"))
+	return len(p), nil
+}
+
+var _ plugin.PluginMeta = (*grpcPlugin)(nil)
+var _ plugin.CloseablePluginTask = (*grpcPlugin)(nil)
+var _ plugin.PluginApi = (*grpcPlugin)(nil)
+var _ plugin.PluginMigration = (*grpcPlugin)(nil)
+var _ plugin.DataSourcePluginBlueprintV200 = (*grpcDatasourcePlugin)(nil)
@@ -0,0 +1,255 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcplugin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+	remotemodels "github.com/apache/incubator-devlake/server/services/remote/models"
+	goplugin "github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+)
+
+const (
+	// pluginKey is the name every plugin process dispenses its implementation under
+	pluginKey   = "devlake"
+	serviceName = "devlake.plugin.Plugin"
+	codecName   = "devlake-json"
+)
+
+// Handshake must match between the server and the plugin processes, the magic cookie also tells a plugin binary
+// that it was launched by the server rather than from the command line
+var Handshake = goplugin.HandshakeConfig{
+	ProtocolVersion:  1,
+	MagicCookieKey:   "DEVLAKE_PLUGIN_COOKIE",
+	MagicCookieValue: "a7c5d0e4-devlake-grpc-plugin",
+}
+
+// IsPluginProcess returns true if the current process was launched by the server as a gRPC plugin
+func IsPluginProcess() bool {
+	return os.Getenv(Handshake.MagicCookieKey) == Handshake.MagicCookieValue
+}
+
+// jsonCodec lets us describe the protocol with plain go structs instead of generated protobuf messages
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func (jsonCodec) Name() string {
+	return codecName
+}
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
+
+// RemoteError carries an errors.Error across the process boundary without losing its http status
+type RemoteError struct {
+	Message  string
+	HttpCode int
+}
+
+func newRemoteError(err errors.Error) *RemoteError {
+	if err == nil {
+		return nil
+	}
+	return &RemoteError{
+		Message:  err.Messages().Format(),
+		HttpCode: err.GetType().GetHttpCode(),
+	}
+}
+
+func (e *RemoteError) toError() errors.Error {
+	return errors.HttpStatus(e.HttpCode).New(e.Message)
+}
+
+// Reply is embedded by every response so that plugin errors are returned as data instead of gRPC failures
+type Reply struct {
+	Error *RemoteError `json:",omitempty"`
+}
+
+func (r *Reply) remoteError() *RemoteError {
+	return r.Error
+}
+
+type reply interface {
+	remoteError() *RemoteError
+}
+
+type Empty struct{}
+
+type SubTaskMetaInfo struct {
+	Name             string
+	Required         bool
+	EnabledByDefault bool
+	Description      string
+	DomainTypes      []string
+	DependencyTables []string
+	ProductTables    []string
+	ForceRunOnResume bool
+}
+
+type MigrationScriptInfo struct {
+	Version uint64
+	Name    string
+}
+
+type InfoResponse struct {
+	Reply
+	Name             string
+	Description      string
+	RootPkgPath      string
+	SubTaskMetas     []SubTaskMetaInfo
+	ApiResources     map[string][]string
+	MigrationScripts []MigrationScriptInfo
+	DataSource       bool
+}
+
+type PrepareTaskRequest struct {
+	TaskKey string
+	Options map[string]interface{}
+}
+
+type ExecuteSubtaskRequest struct {
+	TaskKey    string
+	Options    map[string]interface{}
+	Subtask    string
+	SyncPolicy *models.SyncPolicy
+}
+
+type CloseTaskRequest struct {
+	TaskKey string
+}
+
+type ApiResourceRequest struct {
+	Path    string
+	Method  string
+	Params  map[string]string
+	Query   url.Values
+	Body    map[string]interface{}
+	RawBody []byte
+	Header  http.Header
+	User    *common.User
+}
+
+type ApiResourceResponse struct {
+	Reply
+	Status      int
+	Body        json.RawMessage
+	ContentType string
+	Header      http.Header
+	File        *plugin.OutputFile
+}
+
+type MigrateRequest struct {
+	Version uint64
+}
+
+type MakePipelinePlanRequest struct {
+	ConnectionId uint64
+	Scopes       []*models.BlueprintScope
+}
+
+type MakePipelinePlanResponse struct {
+	Reply
+	Plan   models.PipelinePlan
+	Scopes []remotemodels.DynamicDomainScope
+}
+
+// serviceDesc is the hand-written equivalent of what protoc would generate for the plugin service
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*interface{})(nil),
+	Methods: []grpc.MethodDesc{
+		unaryMethod("Info", (*server).Info),
+		unaryMethod("PrepareTask", (*server).PrepareTask),
+		unaryMethod("ExecuteSubtask", (*server).ExecuteSubtask),
+		unaryMethod("CloseTask", (*server).CloseTask),
+		unaryMethod("ApiResource", (*server).ApiResource),
+		unaryMethod("Migrate", (*server).Migrate),
+		unaryMethod("MakePipelinePlan", (*server).MakePipelinePlan),
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "devlake_plugin",
+}
+
+func unaryMethod[Req any, Resp any](name string, call func(*server, context.Context, *Req) *Resp) grpc.MethodDesc {
+	return grpc.MethodDesc{
+		MethodName: name,
+		Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+			req := new(Req)
+			if err := dec(req); err != nil {
+				return nil, err
+			}
+			s := srv.(*server)
+			if interceptor == nil {
+				return call(s, ctx, req), nil
+			}
+			info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + serviceName + "/" + name}
+			return interceptor(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return call(s, ctx, req.(*Req)), nil
+			})
+		},
+	}
+}
+
+// rpcClient is the host side stub of the plugin service
+type rpcClient struct {
+	conn *grpc.ClientConn
+}
+
+func (c *rpcClient) invoke(ctx context.Context, method string, req interface{}, resp reply) errors.Error {
+	err := c.conn.Invoke(ctx, "/"+serviceName+"/"+method, req, resp, grpc.CallContentSubtype(codecName))
+	if err != nil {
+		return errors.Unavailable.Wrap(err, "failed to call plugin process")
+	}
+	if remoteErr := resp.remoteError(); remoteErr != nil {
+		return remoteErr.toError()
+	}
+	return nil
+}
+
+// rpcPlugin bridges the plugin service into go-plugin
+type rpcPlugin struct {
+	goplugin.NetRPCUnsupportedPlugin
+	server *server
+}
+
+func (p *rpcPlugin) GRPCServer(_ *goplugin.GRPCBroker, s *grpc.Server) error {
+	s.RegisterService(&serviceDesc, p.server)
+	return nil
+}
+
+func (p *rpcPlugin) GRPCClient(_ context.Context, _ *goplugin.GRPCBroker, conn *grpc.ClientConn) (interface{}, error) {
+	return &rpcClient{conn: conn}, nil
+}
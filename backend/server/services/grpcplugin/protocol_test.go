@@ -0,0 +1,164 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcplugin
+
+import (
+	gocontext "context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	goplugin "github.com/hashicorp/go-plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type fakePlugin struct {
+	executed []string
+	closed   bool
+}
+
+func (p *fakePlugin) Description() string { return "fake plugin" }
+func (p *fakePlugin) RootPkgPath() string { return "github.com/apache/incubator-devlake/plugins/fake" }
+func (p *fakePlugin) Name() string        { return "fake" }
+
+func (p *fakePlugin) SubTaskMetas() []plugin.SubTaskMeta {
+	return []plugin.SubTaskMeta{
+		{
+			Name:             "collect",
+			EnabledByDefault: true,
+			EntryPoint: func(c plugin.SubTaskContext) errors.Error {
+				p.executed = append(p.executed, c.GetData().(string))
+				return nil
+			},
+		},
+		{
+			Name: "explode",
+			EntryPoint: func(c plugin.SubTaskContext) errors.Error {
+				panic("boom")
+			},
+		},
+	}
+}
+
+func (p *fakePlugin) PrepareTaskData(_ plugin.TaskContext, options map[string]interface{}) (interface{}, errors.Error) {
+	if options["name"] == nil {
+		return nil, errors.BadInput.New("name is required")
+	}
+	return options["name"], nil
+}
+
+func (p *fakePlugin) Close(_ plugin.TaskContext) errors.Error {
+	p.closed = true
+	return nil
+}
+
+func (p *fakePlugin) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
+	return map[string]map[string]plugin.ApiResourceHandler{
+		"connections/:connectionId": {
+			"GET": func(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+				if input.Params["connectionId"] != "1" {
+					return nil, errors.NotFound.New("connection not found")
+				}
+				return &plugin.ApiResourceOutput{Body: map[string]interface{}{"id": 1}, Status: http.StatusOK}, nil
+			},
+		},
+	}
+}
+
+func newTestClient(t *testing.T, fake *fakePlugin) *rpcClient {
+	basicRes := unithelper.DummyBasicRes(func(mockDal *mockdal.Dal) {})
+	basicRes.On("NestedLogger", mock.Anything).Return(basicRes).Maybe()
+	client, _ := goplugin.TestPluginGRPCConn(t, map[string]goplugin.Plugin{
+		pluginKey: &rpcPlugin{server: newServer(basicRes, "fake", fake)},
+	})
+	t.Cleanup(func() { _ = client.Close() })
+	raw, err := client.Dispense(pluginKey)
+	assert.Nil(t, err)
+	return raw.(*rpcClient)
+}
+
+func TestInfo(t *testing.T) {
+	rpc := newTestClient(t, &fakePlugin{})
+	info := &InfoResponse{}
+	assert.Nil(t, rpc.invoke(gocontext.Background(), "Info", &Empty{}, info))
+	assert.Equal(t, "fake", info.Name)
+	assert.Equal(t, "fake plugin", info.Description)
+	assert.Len(t, info.SubTaskMetas, 2)
+	assert.Equal(t, []string{"GET"}, info.ApiResources["connections/:connectionId"])
+	assert.False(t, info.DataSource)
+}
+
+func TestApiResource(t *testing.T) {
+	rpc := newTestClient(t, &fakePlugin{})
+	resp := &ApiResourceResponse{}
+	err := rpc.invoke(gocontext.Background(), "ApiResource", &ApiResourceRequest{
+		Path:   "connections/:connectionId",
+		Method: "GET",
+		Params: map[string]string{"connectionId": "1"},
+	}, resp)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.Status)
+	assert.JSONEq(t, `{"id":1}`, string(resp.Body))
+
+	err = rpc.invoke(gocontext.Background(), "ApiResource", &ApiResourceRequest{
+		Path:   "connections/:connectionId",
+		Method: "GET",
+		Params: map[string]string{"connectionId": "2"},
+	}, &ApiResourceResponse{})
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.GetType().GetHttpCode())
+}
+
+func TestExecuteSubtask(t *testing.T) {
+	fake := &fakePlugin{}
+	rpc := newTestClient(t, fake)
+	ctx := gocontext.Background()
+
+	err := rpc.invoke(ctx, "PrepareTask", &PrepareTaskRequest{TaskKey: "t1"}, &Reply{})
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.GetType().GetHttpCode())
+
+	options := map[string]interface{}{"name": "devlake"}
+	assert.Nil(t, rpc.invoke(ctx, "PrepareTask", &PrepareTaskRequest{TaskKey: "t1", Options: options}, &Reply{}))
+	assert.Nil(t, rpc.invoke(ctx, "ExecuteSubtask", &ExecuteSubtaskRequest{TaskKey: "t1", Options: options, Subtask: "collect"}, &Reply{}))
+	// an unknown task is prepared on the fly, i.e. after the plugin process was restarted
+	assert.Nil(t, rpc.invoke(ctx, "ExecuteSubtask", &ExecuteSubtaskRequest{TaskKey: "t2", Options: options, Subtask: "collect"}, &Reply{}))
+	assert.Equal(t, []string{"devlake", "devlake"}, fake.executed)
+
+	err = rpc.invoke(ctx, "ExecuteSubtask", &ExecuteSubtaskRequest{TaskKey: "t1", Options: options, Subtask: "explode"}, &Reply{})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "boom")
+
+	assert.Nil(t, rpc.invoke(ctx, "CloseTask", &CloseTaskRequest{TaskKey: "t1"}, &Reply{}))
+	assert.True(t, fake.closed)
+}
+
+func TestRemoteError(t *testing.T) {
+	remoteErr := newRemoteError(errors.Forbidden.New("not allowed"))
+	data, err := json.Marshal(remoteErr)
+	assert.Nil(t, err)
+	decoded := &RemoteError{}
+	assert.Nil(t, json.Unmarshal(data, decoded))
+	assert.Equal(t, http.StatusForbidden, decoded.toError().GetType().GetHttpCode())
+	assert.Nil(t, newRemoteError(nil))
+}
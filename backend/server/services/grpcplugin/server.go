@@ -0,0 +1,302 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcplugin
+
+import (
+	gocontext "context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"runtime/debug"
+	"sync"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	contextimpl "github.com/apache/incubator-devlake/impls/context"
+	remotemodels "github.com/apache/incubator-devlake/server/services/remote/models"
+	goplugin "github.com/hashicorp/go-plugin"
+)
+
+// Serve runs inside the plugin process and exposes the plugin to the server, it blocks until the server kills the process
+func Serve(basicRes context.BasicRes, name string, meta plugin.PluginMeta) {
+	goplugin.Serve(&goplugin.ServeConfig{
+		HandshakeConfig: Handshake,
+		Plugins: goplugin.PluginSet{
+			pluginKey: &rpcPlugin{server: newServer(basicRes, name, meta)},
+		},
+		GRPCServer: goplugin.DefaultGRPCServer,
+	})
+}
+
+type serverTask struct {
+	ctx    plugin.TaskContext
+	cancel gocontext.CancelFunc
+}
+
+// server executes the requests of the host against the wrapped plugin
+type server struct {
+	basicRes context.BasicRes
+	name     string
+	meta     plugin.PluginMeta
+	mu       sync.Mutex
+	tasks    map[string]*serverTask
+}
+
+func newServer(basicRes context.BasicRes, name string, meta plugin.PluginMeta) *server {
+	return &server{
+		basicRes: basicRes,
+		name:     name,
+		meta:     meta,
+		tasks:    make(map[string]*serverTask),
+	}
+}
+
+// recoverPanic turns a panicking plugin into an error so that a single broken call doesn't bring the process down
+func recoverPanic(err *errors.Error) {
+	if r := recover(); r != nil {
+		*err = errors.Default.New(fmt.Sprintf("plugin panicked: %v\n%s", r, debug.Stack()))
+	}
+}
+
+func (s *server) Info(_ gocontext.Context, _ *Empty) *InfoResponse {
+	resp := &InfoResponse{
+		Name:        s.name,
+		Description: s.meta.Description(),
+		RootPkgPath: s.meta.RootPkgPath(),
+	}
+	if pluginTask, ok := s.meta.(plugin.PluginTask); ok {
+		for _, subtaskMeta := range pluginTask.SubTaskMetas() {
+			resp.SubTaskMetas = append(resp.SubTaskMetas, SubTaskMetaInfo{
+				Name:             subtaskMeta.Name,
+				Required:         subtaskMeta.Required,
+				EnabledByDefault: subtaskMeta.EnabledByDefault,
+				Description:      subtaskMeta.Description,
+				DomainTypes:      subtaskMeta.DomainTypes,
+				DependencyTables: subtaskMeta.DependencyTables,
+				ProductTables:    subtaskMeta.ProductTables,
+				ForceRunOnResume: subtaskMeta.ForceRunOnResume,
+			})
+		}
+	}
+	if pluginApi, ok := s.meta.(plugin.PluginApi); ok {
+		resp.ApiResources = make(map[string][]string)
+		for path, methods := range pluginApi.ApiResources() {
+			for method := range methods {
+				resp.ApiResources[path] = append(resp.ApiResources[path], method)
+			}
+		}
+	}
+	if pluginMigration, ok := s.meta.(plugin.PluginMigration); ok {
+		for _, script := range pluginMigration.MigrationScripts() {
+			resp.MigrationScripts = append(resp.MigrationScripts, MigrationScriptInfo{
+				Version: script.Version(),
+				Name:    script.Name(),
+			})
+		}
+	}
+	_, resp.DataSource = s.meta.(plugin.DataSourcePluginBlueprintV200)
+	return resp
+}
+
+func (s *server) PrepareTask(_ gocontext.Context, req *PrepareTaskRequest) *Reply {
+	_, err := s.getTask(req.TaskKey, req.Options)
+	return &Reply{Error: newRemoteError(err)}
+}
+
+// getTask returns the prepared task, or prepares it again in case the process was restarted in the middle of a task
+func (s *server) getTask(taskKey string, options map[string]interface{}) (task *serverTask, err errors.Error) {
+	defer recoverPanic(&err)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if task, ok := s.tasks[taskKey]; ok {
+		return task, nil
+	}
+	pluginTask, ok := s.meta.(plugin.PluginTask)
+	if !ok {
+		return nil, errors.Default.New(fmt.Sprintf("plugin %s doesn't support tasks", s.name))
+	}
+	// the server has already filtered the subtasks, any subtask it asks for should be run
+	subtasks := make(map[string]bool)
+	for _, subtaskMeta := range pluginTask.SubTaskMetas() {
+		subtasks[subtaskMeta.Name] = true
+	}
+	ctx, cancel := gocontext.WithCancel(gocontext.Background())
+	taskCtx := contextimpl.NewDefaultTaskContext(ctx, s.basicRes, s.name, subtasks, nil)
+	taskData, err := pluginTask.PrepareTaskData(taskCtx, options)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	taskCtx.SetData(taskData)
+	task = &serverTask{ctx: taskCtx, cancel: cancel}
+	s.tasks[taskKey] = task
+	return task, nil
+}
+
+func (s *server) ExecuteSubtask(ctx gocontext.Context, req *ExecuteSubtaskRequest) *Reply {
+	task, err := s.getTask(req.TaskKey, req.Options)
+	if err != nil {
+		return &Reply{Error: newRemoteError(err)}
+	}
+	task.ctx.SetSyncPolicy(req.SyncPolicy)
+	// the task context outlives a single call, cancel it if the server gives up on the subtask
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			select {
+			case <-done:
+			default:
+				task.cancel()
+			}
+		case <-done:
+		}
+	}()
+	return &Reply{Error: newRemoteError(s.executeSubtask(task, req.Subtask))}
+}
+
+func (s *server) executeSubtask(task *serverTask, name string) (err errors.Error) {
+	defer recoverPanic(&err)
+	for _, subtaskMeta := range s.meta.(plugin.PluginTask).SubTaskMetas() {
+		if subtaskMeta.Name != name {
+			continue
+		}
+		subtaskCtx, err := task.ctx.SubTaskContext(name)
+		if err != nil {
+			return err
+		}
+		return subtaskMeta.EntryPoint(subtaskCtx)
+	}
+	return errors.NotFound.New(fmt.Sprintf("subtask %s not found", name))
+}
+
+func (s *server) CloseTask(_ gocontext.Context, req *CloseTaskRequest) *Reply {
+	s.mu.Lock()
+	task, ok := s.tasks[req.TaskKey]
+	delete(s.tasks, req.TaskKey)
+	s.mu.Unlock()
+	if !ok {
+		return &Reply{}
+	}
+	defer task.cancel()
+	var err errors.Error
+	if closeablePlugin, ok := s.meta.(plugin.CloseablePluginTask); ok {
+		err = func() (err errors.Error) {
+			defer recoverPanic(&err)
+			return closeablePlugin.Close(task.ctx)
+		}()
+	}
+	return &Reply{Error: newRemoteError(err)}
+}
+
+func (s *server) ApiResource(_ gocontext.Context, req *ApiResourceRequest) *ApiResourceResponse {
+	output, err := s.callApiResource(req)
+	if err != nil {
+		return &ApiResourceResponse{Reply: Reply{Error: newRemoteError(err)}}
+	}
+	resp := &ApiResourceResponse{
+		Status:      output.Status,
+		ContentType: output.ContentType,
+		Header:      output.Header,
+		File:        output.File,
+	}
+	if output.Body != nil {
+		body, jsonErr := json.Marshal(output.Body)
+		if jsonErr != nil {
+			return &ApiResourceResponse{Reply: Reply{Error: newRemoteError(errors.Convert(jsonErr))}}
+		}
+		resp.Body = body
+	}
+	return resp
+}
+
+func (s *server) callApiResource(req *ApiResourceRequest) (output *plugin.ApiResourceOutput, err errors.Error) {
+	defer recoverPanic(&err)
+	pluginApi, ok := s.meta.(plugin.PluginApi)
+	if !ok {
+		return nil, errors.NotFound.New(fmt.Sprintf("plugin %s doesn't offer api resources", s.name))
+	}
+	handler, ok := pluginApi.ApiResources()[req.Path][req.Method]
+	if !ok {
+		return nil, errors.NotFound.New(fmt.Sprintf("%s %s not found", req.Method, req.Path))
+	}
+	output, err = handler(&plugin.ApiResourceInput{
+		Params:  req.Params,
+		Query:   req.Query,
+		Body:    req.Body,
+		RawBody: req.RawBody,
+		Request: &http.Request{
+			Method: req.Method,
+			URL:    &url.URL{Path: req.Path, RawQuery: req.Query.Encode()},
+			Header: req.Header,
+		},
+		User: req.User,
+	})
+	if err == nil && output == nil {
+		output = &plugin.ApiResourceOutput{}
+	}
+	return output, err
+}
+
+func (s *server) Migrate(_ gocontext.Context, req *MigrateRequest) *Reply {
+	err := func() (err errors.Error) {
+		defer recoverPanic(&err)
+		if pluginMigration, ok := s.meta.(plugin.PluginMigration); ok {
+			for _, script := range pluginMigration.MigrationScripts() {
+				if script.Version() == req.Version {
+					return script.Up(s.basicRes)
+				}
+			}
+		}
+		return errors.NotFound.New(fmt.Sprintf("migration script %d not found", req.Version))
+	}()
+	return &Reply{Error: newRemoteError(err)}
+}
+
+func (s *server) MakePipelinePlan(_ gocontext.Context, req *MakePipelinePlanRequest) *MakePipelinePlanResponse {
+	resp := &MakePipelinePlanResponse{}
+	err := func() (err errors.Error) {
+		defer recoverPanic(&err)
+		dataSource, ok := s.meta.(plugin.DataSourcePluginBlueprintV200)
+		if !ok {
+			return errors.BadInput.New(fmt.Sprintf("plugin %s is not a data source", s.name))
+		}
+		plan, scopes, err := dataSource.MakeDataSourcePipelinePlanV200(req.ConnectionId, req.Scopes)
+		if err != nil {
+			return err
+		}
+		resp.Plan = plan
+		for _, scope := range scopes {
+			data, jsonErr := json.Marshal(scope)
+			if jsonErr != nil {
+				return errors.Convert(jsonErr)
+			}
+			resp.Scopes = append(resp.Scopes, remotemodels.DynamicDomainScope{
+				TypeName: reflect.Indirect(reflect.ValueOf(scope)).Type().Name(),
+				Data:     string(data),
+			})
+		}
+		return nil
+	}()
+	resp.Error = newRemoteError(err)
+	return resp
+}
@@ -0,0 +1,252 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/core/utils"
+)
+
+const (
+	HEALTH_OK       = "ok"
+	HEALTH_DEGRADED = "degraded"
+	HEALTH_DOWN     = "down"
+)
+
+// HealthCheck is the result of checking a single dependency of the service
+type HealthCheck struct {
+	Ok        bool   `json:"ok"`
+	Message   string `json:"message,omitempty"`
+	LatencyMs int64  `json:"latencyMs,omitempty"`
+}
+
+// ConnectionHealth tells how long ago the data of a connection was synced successfully
+type ConnectionHealth struct {
+	Id                   uint64     `json:"id"`
+	LastSuccessfulSyncAt *time.Time `json:"lastSuccessfulSyncAt"`
+	// LastSuccessfulSyncAge is in seconds, nil if the connection was never synced
+	LastSuccessfulSyncAge *int64 `json:"lastSuccessfulSyncAge"`
+	// Stale is true when the last successful sync is older than HEALTH_SYNC_STALE_AFTER
+	Stale bool `json:"stale"`
+}
+
+// PluginHealth lists the diagnostics of a loaded plugin
+type PluginHealth struct {
+	Name        string              `json:"name"`
+	Loaded      bool                `json:"loaded"`
+	DataSource  bool                `json:"dataSource"`
+	Message     string              `json:"message,omitempty"`
+	Connections []*ConnectionHealth `json:"connections,omitempty"`
+}
+
+// HealthDetails is the deep health check of the service, Status is `down` if the service can't serve requests,
+// and `degraded` if it serves them but some data is getting stale
+type HealthDetails struct {
+	Status            string          `json:"status"`
+	ServiceStatus     string          `json:"serviceStatus"`
+	Database          *HealthCheck    `json:"database"`
+	Migrations        *HealthCheck    `json:"migrations"`
+	Encryption        *HealthCheck    `json:"encryption"`
+	Plugins           []*PluginHealth `json:"plugins"`
+	StaleConnections  int             `json:"staleConnections"`
+	SyncStaleAfterSec int64           `json:"syncStaleAfterSec,omitempty"`
+	CheckedAt         time.Time       `json:"checkedAt"`
+}
+
+// the deep health check reads every connection table, its result is reused for a while so that frequent polling
+// doesn't load the database
+var healthDetailsCacheTtl = 10 * time.Second
+
+var healthDetailsCache struct {
+	sync.Mutex
+	details *HealthDetails
+}
+
+type connectionSyncRow struct {
+	PluginName   string
+	ConnectionId uint64
+	FinishedAt   *time.Time
+}
+
+// GetHealthDetails checks the database, the migrations, the encryption key and the plugins one by one, so that
+// the monitoring could tell what exactly is wrong. The result is cached for healthDetailsCacheTtl
+func GetHealthDetails() *HealthDetails {
+	healthDetailsCache.Lock()
+	defer healthDetailsCache.Unlock()
+	if cached := healthDetailsCache.details; cached != nil && time.Since(cached.CheckedAt) < healthDetailsCacheTtl {
+		return cached
+	}
+	healthDetailsCache.details = checkHealthDetails()
+	return healthDetailsCache.details
+}
+
+func checkHealthDetails() *HealthDetails {
+	details := &HealthDetails{
+		CheckedAt:     time.Now(),
+		ServiceStatus: CurrentStatus(),
+		Database:      checkDatabase(),
+		Migrations:    &HealthCheck{Ok: true},
+		Encryption:    &HealthCheck{Ok: false, Message: "database is unavailable"},
+		Plugins:       []*PluginHealth{},
+	}
+	if migrator.HasPendingScripts() {
+		details.Migrations = &HealthCheck{Ok: false, Message: "there are pending migration scripts"}
+	}
+	if details.Database.Ok {
+		details.Encryption = checkEncryption()
+	}
+	staleAfter, err := utils.StrToDurationOr(cfg.GetString("HEALTH_SYNC_STALE_AFTER"), 0)
+	if err != nil {
+		logger.Warn(err, "failed to parse HEALTH_SYNC_STALE_AFTER")
+	}
+	details.SyncStaleAfterSec = int64(staleAfter.Seconds())
+	details.Plugins = checkPlugins(details.Database.Ok && details.ServiceStatus == SERVICE_STATUS_READY, staleAfter)
+	for _, p := range details.Plugins {
+		for _, connection := range p.Connections {
+			if connection.Stale {
+				details.StaleConnections++
+			}
+		}
+	}
+	details.Status = HEALTH_OK
+	if details.StaleConnections > 0 {
+		details.Status = HEALTH_DEGRADED
+	}
+	if details.ServiceStatus != SERVICE_STATUS_READY || !details.Database.Ok || !details.Migrations.Ok || !details.Encryption.Ok {
+		details.Status = HEALTH_DOWN
+	}
+	return details
+}
+
+// checkDatabase measures the latency of reading a single record, the same way Health does
+func checkDatabase() *HealthCheck {
+	start := time.Now()
+	result := make(chan errors.Error, 1)
+	go func() {
+		result <- db.All(&[]models.Pipeline{}, dal.Select("id"), dal.Limit(1))
+	}()
+	select {
+	case <-time.After(5 * time.Second):
+		return &HealthCheck{Ok: false, Message: "timeout reading from pipelines"}
+	case err := <-result:
+		check := &HealthCheck{Ok: err == nil, LatencyMs: time.Since(start).Milliseconds()}
+		if err != nil {
+			check.Message = err.Error()
+		}
+		return check
+	}
+}
+
+// checkEncryption makes sure ENCRYPTION_SECRET is set and decrypts the data encrypted previously, a secret
+// changed after the data was encrypted breaks all connections and blueprints
+func checkEncryption() *HealthCheck {
+	secret := cfg.GetString(plugin.EncodeKeyEnvStr)
+	if secret == "" {
+		return &HealthCheck{Ok: false, Message: fmt.Sprintf("%s is not set", plugin.EncodeKeyEnvStr)}
+	}
+	// the plans of the blueprints are always encrypted, the stored cipher text is decrypted here rather than
+	// through the serializer so that an empty table or a null plan can't pass for a valid secret
+	var plans []string
+	err := db.Pluck("plan", &plans,
+		dal.From(models.Blueprint{}.TableName()),
+		dal.Where("plan IS NOT NULL AND plan <> ''"),
+		dal.Orderby("id DESC"),
+		dal.Limit(1),
+	)
+	if err != nil {
+		return &HealthCheck{Ok: false, Message: fmt.Sprintf("failed to read the encrypted data: %s", err.Error())}
+	}
+	if len(plans) == 0 {
+		return &HealthCheck{Ok: true, Message: "no encrypted data to verify yet"}
+	}
+	if _, err := plugin.Decrypt(secret, plans[0]); err != nil {
+		return &HealthCheck{Ok: false, Message: fmt.Sprintf("failed to decrypt the stored data, %s might have changed: %s", plugin.EncodeKeyEnvStr, err.Error())}
+	}
+	return &HealthCheck{Ok: true}
+}
+
+// checkPlugins lists the loaded plugins and the age of the last successful sync of the connections of
+// the data source plugins, the sync is the last pipeline of a blueprint using the connection that finished successfully
+func checkPlugins(queryDb bool, staleAfter time.Duration) []*PluginHealth {
+	var syncs map[string]*time.Time
+	if queryDb {
+		var err errors.Error
+		syncs, err = getLastSuccessfulSyncs()
+		if err != nil {
+			logger.Warn(err, "failed to get the last successful syncs")
+		}
+	}
+	now := time.Now()
+	plugins := make([]*PluginHealth, 0)
+	for pluginName, pluginMeta := range plugin.AllPlugins() {
+		health := &PluginHealth{Name: pluginName, Loaded: true}
+		source, ok := pluginMeta.(plugin.PluginSource)
+		health.DataSource = ok
+		if ok && queryDb && source.Connection() != nil {
+			// the names of the connections are left out, the ids are enough to tell which one is stale
+			var ids []uint64
+			err := db.Pluck("id", &ids, dal.From(source.Connection().TableName()), dal.Orderby("id"))
+			if err != nil {
+				health.Message = err.Error()
+			}
+			for _, id := range ids {
+				connection := &ConnectionHealth{Id: id}
+				if finishedAt := syncs[fmt.Sprintf("%s:%d", pluginName, id)]; finishedAt != nil {
+					age := int64(now.Sub(*finishedAt).Seconds())
+					connection.LastSuccessfulSyncAt = finishedAt
+					connection.LastSuccessfulSyncAge = &age
+					connection.Stale = staleAfter > 0 && now.Sub(*finishedAt) > staleAfter
+				}
+				health.Connections = append(health.Connections, connection)
+			}
+		}
+		plugins = append(plugins, health)
+	}
+	sort.Slice(plugins, func(i, j int) bool {
+		return plugins[i].Name < plugins[j].Name
+	})
+	return plugins
+}
+
+// getLastSuccessfulSyncs returns the finish time of the last successful pipeline by `<plugin>:<connectionId>`
+func getLastSuccessfulSyncs() (map[string]*time.Time, errors.Error) {
+	var rows []*connectionSyncRow
+	err := db.All(&rows,
+		dal.Select("bc.plugin_name, bc.connection_id, MAX(p.finished_at) AS finished_at"),
+		dal.From("_devlake_blueprint_connections bc"),
+		dal.Join("JOIN _devlake_pipelines p ON p.blueprint_id = bc.blueprint_id"),
+		dal.Where("p.status = ?", models.TASK_COMPLETED),
+		dal.Groupby("bc.plugin_name, bc.connection_id"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	syncs := make(map[string]*time.Time, len(rows))
+	for _, row := range rows {
+		syncs[fmt.Sprintf("%s:%d", row.PluginName, row.ConnectionId)] = row.FinishedAt
+	}
+	return syncs, nil
+}
@@ -0,0 +1,74 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/config"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCheckEncryption(t *testing.T) {
+	defer func(d dal.Dal, c config.ConfigReader) { db, cfg = d, c }(db, cfg)
+	v := viper.New()
+	cfg = v
+	plan := errors.Must1(plugin.Encrypt("health-secret", `[[{"plugin":"github"}]]`))
+
+	pluck := func(plans ...string) *mockdal.Dal {
+		mockDal := new(mockdal.Dal)
+		mockDal.On("Pluck", "plan", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			*args.Get(1).(*[]string) = plans
+		}).Return(nil)
+		return mockDal
+	}
+
+	assert.False(t, checkEncryption().Ok)
+
+	v.Set(plugin.EncodeKeyEnvStr, "health-secret")
+	db = pluck()
+	assert.True(t, checkEncryption().Ok)
+	db = pluck(plan)
+	assert.True(t, checkEncryption().Ok)
+
+	v.Set(plugin.EncodeKeyEnvStr, "another-secret")
+	check := checkEncryption()
+	assert.False(t, check.Ok)
+	assert.Contains(t, check.Message, plugin.EncodeKeyEnvStr)
+
+	mockDal := new(mockdal.Dal)
+	mockDal.On("Pluck", "plan", mock.Anything, mock.Anything).Return(errors.Default.New("connection refused"))
+	db = mockDal
+	assert.False(t, checkEncryption().Ok)
+}
+
+func TestGetHealthDetailsCached(t *testing.T) {
+	defer func() { healthDetailsCache.details = nil }()
+	cached := &HealthDetails{Status: HEALTH_OK, CheckedAt: time.Now()}
+	healthDetailsCache.details = cached
+
+	// the database isn't touched while the cached result is fresh
+	assert.Same(t, cached, GetHealthDetails())
+}
@@ -95,6 +95,11 @@ func registerPluginsMigrationScripts() {
 }
 
 func InitExecuteMigration() {
+	// roll the schema back to a prior version, i.e. after a bad upgrade, before anything else touches the database
+	if rollbackTo := cfg.GetUint64("MIGRATION_ROLLBACK_TO"); rollbackTo > 0 {
+		logger.Info("rolling back db migration to version %d", rollbackTo)
+		errors.Must(migrator.Rollback(rollbackTo))
+	}
 	// check if there are pending migration
 	logger.Info("has pending scripts? %v, FORCE_MIGRATION: %v", migrator.HasPendingScripts(), cfg.GetBool("FORCE_MIGRATION"))
 	if migrator.HasPendingScripts() {
@@ -0,0 +1,71 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	unfinishedPipelinesDesc = prometheus.NewDesc(
+		"devlake_unfinished_pipelines",
+		"Unfinished pipelines by status, the pipelines created or to be rerun are waiting in the queue.",
+		[]string{"status"}, nil,
+	)
+	unfinishedTasksDesc = prometheus.NewDesc(
+		"devlake_unfinished_tasks",
+		"Unfinished tasks by status, the queued tasks are waiting for a worker in the distributed mode.",
+		[]string{"status"}, nil,
+	)
+)
+
+// queueCollector reports the depth of the pipeline and task queues from the database on every scrape
+type queueCollector struct{}
+
+func (queueCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- unfinishedPipelinesDesc
+	ch <- unfinishedTasksDesc
+}
+
+func (queueCollector) Collect(ch chan<- prometheus.Metric) {
+	collectUnfinished(ch, unfinishedPipelinesDesc, &models.Pipeline{})
+	collectUnfinished(ch, unfinishedTasksDesc, &models.Task{})
+}
+
+func collectUnfinished(ch chan<- prometheus.Metric, desc *prometheus.Desc, table dal.Tabler) {
+	var counts []struct {
+		Status string
+		Count  int64
+	}
+	err := db.All(
+		&counts,
+		dal.Select("status, COUNT(*) AS count"),
+		dal.From(table),
+		dal.Where("finished_at IS NULL AND status IN ?", append([]string{models.TASK_QUEUED}, models.PendingTaskStatus...)),
+		dal.Groupby("status"),
+	)
+	if err != nil {
+		logger.Error(err, "failed to count the unfinished %s", table.TableName())
+		return
+	}
+	for _, count := range counts {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, float64(count.Count), count.Status)
+	}
+}
@@ -0,0 +1,133 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/log"
+	"github.com/apache/incubator-devlake/core/metrics"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type statusCount = struct {
+	Status string
+	Count  int64
+}
+
+func mockUnfinished(mockDal *mockdal.Dal, table string, counts []statusCount, err errors.Error) {
+	mockDal.On("All", mock.Anything, mock.MatchedBy(func(clauses []dal.Clause) bool {
+		return len(clauses) > 1 && clauses[1].Type == dal.FromClause && clauses[1].Data.(dal.Tabler).TableName() == table
+	})).Run(func(args mock.Arguments) {
+		*args.Get(0).(*[]statusCount) = counts
+	}).Return(err)
+}
+
+func TestQueueCollector(t *testing.T) {
+	defer func(d dal.Dal) { db = d }(db)
+	mockDal := new(mockdal.Dal)
+	mockUnfinished(mockDal, "_devlake_pipelines", []statusCount{{models.TASK_CREATED, 3}, {models.TASK_RERUN, 1}}, nil)
+	mockUnfinished(mockDal, "_devlake_tasks", []statusCount{{models.TASK_QUEUED, 5}}, nil)
+	db = mockDal
+
+	err := testutil.CollectAndCompare(queueCollector{}, strings.NewReader(`
+# HELP devlake_unfinished_pipelines Unfinished pipelines by status, the pipelines created or to be rerun are waiting in the queue.
+# TYPE devlake_unfinished_pipelines gauge
+devlake_unfinished_pipelines{status="TASK_CREATED"} 3
+devlake_unfinished_pipelines{status="TASK_RERUN"} 1
+# HELP devlake_unfinished_tasks Unfinished tasks by status, the queued tasks are waiting for a worker in the distributed mode.
+# TYPE devlake_unfinished_tasks gauge
+devlake_unfinished_tasks{status="TASK_QUEUED"} 5
+`))
+	assert.Nil(t, err)
+}
+
+func TestQueueCollectorWithoutDatabase(t *testing.T) {
+	defer func(d dal.Dal) { db = d }(db)
+	mockDal := new(mockdal.Dal)
+	mockUnfinished(mockDal, "_devlake_pipelines", nil, nil)
+	mockUnfinished(mockDal, "_devlake_tasks", nil, errors.Default.New("connection refused"))
+	db = mockDal
+	defer func(l log.Logger) { logger = l }(logger)
+	logger = unithelper.DummyLogger()
+
+	// nothing is reported rather than a misleading zero when the database can't be read
+	assert.Equal(t, 0, testutil.CollectAndCount(queueCollector{}))
+}
+
+func observed(t *testing.T, histogram *prometheus.HistogramVec, labels ...string) (uint64, float64) {
+	m := &dto.Metric{}
+	assert.Nil(t, histogram.WithLabelValues(labels...).(prometheus.Metric).Write(m))
+	return m.GetHistogram().GetSampleCount(), m.GetHistogram().GetSampleSum()
+}
+
+func TestFinishPipelineObservesDuration(t *testing.T) {
+	tests := []struct {
+		name       string
+		skipOnFail bool
+		tasks      []string
+		status     string
+	}{
+		{"completed", false, []string{models.TASK_COMPLETED, models.TASK_COMPLETED}, models.TASK_COMPLETED},
+		{"partial", true, []string{models.TASK_COMPLETED, models.TASK_FAILED}, models.TASK_PARTIAL},
+		{"failed", false, []string{models.TASK_COMPLETED, models.TASK_FAILED}, models.TASK_FAILED},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func(d dal.Dal) { db = d }(db)
+			mockRows := new(mockdal.Rows)
+			for range tt.tasks {
+				mockRows.On("Next").Return(true).Once()
+			}
+			mockRows.On("Next").Return(false).Once()
+			mockRows.On("Close").Return(nil)
+			mockDal := new(mockdal.Dal)
+			mockDal.On("Cursor", mock.Anything).Return(mockRows, nil)
+			for i, status := range tt.tasks {
+				task := &models.Task{Status: status, PipelineRow: 1, PipelineCol: i + 1}
+				mockDal.On("Fetch", mockRows, mock.Anything).Run(func(args mock.Arguments) {
+					*args.Get(1).(*models.Task) = *task
+				}).Return(nil).Once()
+			}
+			mockDal.On("Update", mock.Anything, mock.Anything).Return(nil)
+			db = mockDal
+			count, sum := observed(t, metrics.PipelineDuration, tt.status)
+
+			beganAt := time.Now().Add(-90 * time.Second)
+			pipeline := &models.Pipeline{BeganAt: &beganAt}
+			pipeline.SkipOnFail = tt.skipOnFail
+			assert.Nil(t, finishPipeline(pipeline, nil, false))
+			assert.Equal(t, tt.status, pipeline.Status)
+			newCount, newSum := observed(t, metrics.PipelineDuration, tt.status)
+			assert.Equal(t, count+1, newCount)
+			assert.Equal(t, float64(pipeline.SpentSeconds), newSum-sum)
+			assert.GreaterOrEqual(t, pipeline.SpentSeconds, 90)
+		})
+	}
+}
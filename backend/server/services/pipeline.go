@@ -39,6 +39,7 @@ import (
 	"github.com/apache/incubator-devlake/helpers/dbhelper"
 	"github.com/apache/incubator-devlake/impls/logruslog"
 	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/sync/semaphore"
 )
 
@@ -66,6 +67,12 @@ var pluginOptionSanitizers = map[string]func(map[string]interface{}){
 				}
 			}
 		}
+		// secrets passed to gitextractor as options directly
+		for _, key := range []string{"password", "privateKey", "passphrase"} {
+			if v := cast.ToString(options[key]); v != "" {
+				options[key] = strings.Repeat("*", len(v))
+			}
+		}
 	},
 }
 
@@ -76,6 +83,8 @@ type PipelineQuery struct {
 	Pending     int    `form:"pending"`
 	BlueprintId uint64 `uri:"blueprintId" form:"blueprint_id"`
 	Label       string `form:"label"`
+	// ProjectNames restricts the result to the pipelines of the blueprints of the given projects unless nil
+	ProjectNames []string `form:"-"`
 }
 
 func pipelineServiceInit() {
@@ -89,12 +98,20 @@ func pipelineServiceInit() {
 		defaultNotificationService = NewDefaultPipelineNotificationService(notificationEndpoint, notificationSecret)
 	}
 
-	// standalone mode: reset pipeline status
-	if cfg.GetBool("RESUME_PIPELINES") {
+	workerInit()
+	retentionInit()
+	prometheus.MustRegister(queueCollector{})
+	// standalone mode: reset pipeline status, the expired leases are reaped in the distributed mode instead
+	if distributedMode {
+		globalPipelineLog.Info("distributed mode, interrupted pipelines are resumed once their leases expire")
+	} else if cfg.GetBool("RESUME_PIPELINES") {
 		markInterruptedPipelineAs(models.TASK_RESUME)
 	} else {
 		markInterruptedPipelineAs(models.TASK_FAILED)
 	}
+	if !distributedMode {
+		markInterruptedBackgroundJobs()
+	}
 
 	// load cronjobs for blueprints
 	errors.Must(ReloadBlueprints())
@@ -126,7 +143,21 @@ func markInterruptedPipelineAs(status string) {
 		[]dal.DalSet{
 			{ColumnName: "status", Value: status},
 		},
-		dal.Where("status = ?", models.TASK_RUNNING),
+		dal.Where("status IN ?", []string{models.TASK_RUNNING, models.TASK_PAUSING}),
+	))
+}
+
+// markInterruptedBackgroundJobs fails the background jobs left running by the previous process, their goroutines
+// are gone and nothing would ever finish them
+func markInterruptedBackgroundJobs() {
+	errors.Must(db.UpdateColumns(
+		&models.BackgroundJob{},
+		[]dal.DalSet{
+			{ColumnName: "status", Value: models.BACKGROUND_JOB_FAILED},
+			{ColumnName: "message", Value: "interrupted by the restart of devlake"},
+			{ColumnName: "finished_at", Value: time.Now()},
+		},
+		dal.Where("status = ?", models.BACKGROUND_JOB_RUNNING),
 	))
 }
 
@@ -154,6 +185,9 @@ func SanitizeBlueprint(blueprint *models.Blueprint) error {
 			blueprint.Plan[planStageIdx][planTaskIdx] = pipelineTask
 		}
 	}
+	for idx, notification := range blueprint.Notifications {
+		blueprint.Notifications[idx] = notification.Sanitize()
+	}
 	return nil
 }
 
@@ -250,7 +284,9 @@ func GetPipelineLogsArchivePath(pipeline *models.Pipeline) (string, errors.Error
 	return archive, err
 }
 
-func dequeuePipeline(runningParallelLabels []string) (pipeline *models.Pipeline, err errors.Error) {
+// dequeuePipeline picks the earliest pipeline of the highest priority not blocked by the running parallel labels,
+// nor by any of its connections running the max number of pipelines already
+func dequeuePipeline(runningParallelLabels []string, busyConnections map[string]bool) (pipeline *models.Pipeline, err errors.Error) {
 	txHelper := dbhelper.NewTxHelper(basicRes, &err)
 	defer txHelper.End()
 	tx := txHelper.Begin()
@@ -273,7 +309,8 @@ func dequeuePipeline(runningParallelLabels []string) (pipeline *models.Pipeline,
 		top_priority = top_priorities[0]
 	}
 	// 2. pick the earlier runnable pipeline with the highest priority
-	err = tx.First(pipeline,
+	var candidates []*models.Pipeline
+	err = tx.All(&candidates,
 		where_status,
 		dal.Where("priority = ?", top_priority),
 		dal.Join(
@@ -287,9 +324,12 @@ func dequeuePipeline(runningParallelLabels []string) (pipeline *models.Pipeline,
 		dal.Having("count(_devlake_pipeline_labels.name)=0"),
 		dal.Select("id"),
 		dal.Orderby("id ASC"),
-		dal.Limit(1),
+		dal.Limit(50),
 	)
 	if err == nil {
+		pipeline.ID, err = pickUnblockedPipeline(tx, candidates, busyConnections)
+	}
+	if err == nil && pipeline.ID != 0 {
 		// mark the pipeline running, now we want a write lock
 		if pipeline.BeganAt == nil {
 			now := time.Now()
@@ -301,6 +341,8 @@ func dequeuePipeline(runningParallelLabels []string) (pipeline *models.Pipeline,
 			{ColumnName: "status", Value: models.TASK_RUNNING},
 			{ColumnName: "message", Value: ""},
 			{ColumnName: "began_at", Value: pipeline.BeganAt},
+			{ColumnName: "worker_id", Value: workerId},
+			{ColumnName: "lease_expires_at", Value: time.Now().Add(leaseDuration)},
 		}, dal.Where("id = ?", pipeline.ID))
 		if err != nil {
 			panic(err)
@@ -308,7 +350,7 @@ func dequeuePipeline(runningParallelLabels []string) (pipeline *models.Pipeline,
 
 		return
 	}
-	if tx.IsErrorNotFound(err) {
+	if err == nil || tx.IsErrorNotFound(err) {
 		pipeline = nil
 		err = nil
 	} else {
@@ -319,11 +361,67 @@ func dequeuePipeline(runningParallelLabels []string) (pipeline *models.Pipeline,
 	return
 }
 
+// pickUnblockedPipeline returns the id of the first candidate none of whose connections is busy, 0 if none,
+// candidates hold the ids only as the connections are in the encrypted plan
+func pickUnblockedPipeline(tx dal.Transaction, candidates []*models.Pipeline, busyConnections map[string]bool) (uint64, errors.Error) {
+	for _, candidate := range candidates {
+		if len(busyConnections) == 0 {
+			return candidate.ID, nil
+		}
+		full := &models.Pipeline{}
+		if err := tx.First(full, dal.Where("id = ?", candidate.ID)); err != nil {
+			return 0, err
+		}
+		blocked := false
+		for _, connection := range pipelineConnections(full.Plan) {
+			blocked = blocked || busyConnections[connection]
+		}
+		if !blocked {
+			return candidate.ID, nil
+		}
+	}
+	return 0, nil
+}
+
+// pipelineConnections returns the connections the plan collects from, as `plugin:connectionId`
+func pipelineConnections(plan models.PipelinePlan) []string {
+	var connections []string
+	seen := make(map[string]bool)
+	for _, stage := range plan {
+		for _, task := range stage {
+			connectionId := cast.ToUint64(task.Options["connectionId"])
+			if connectionId == 0 {
+				continue
+			}
+			connection := fmt.Sprintf("%s:%d", task.Plugin, connectionId)
+			if !seen[connection] {
+				seen[connection] = true
+				connections = append(connections, connection)
+			}
+		}
+	}
+	return connections
+}
+
 // RunPipelineInQueue query pipeline from db and run it in a queue
 func RunPipelineInQueue(pipelineMaxParallel int64) {
 	sema := semaphore.NewWeighted(pipelineMaxParallel)
 	runningParallelLabels := []string{}
 	var runningParallelLabelLock sync.Mutex
+	// pipelines running per connection, capped by PIPELINE_MAX_PARALLEL_PER_CONNECTION
+	maxParallelPerConnection := cfg.GetInt("PIPELINE_MAX_PARALLEL_PER_CONNECTION")
+	runningConnections := make(map[string]int)
+	busyConnections := func() map[string]bool {
+		runningParallelLabelLock.Lock()
+		defer runningParallelLabelLock.Unlock()
+		busy := make(map[string]bool)
+		for connection, count := range runningConnections {
+			if maxParallelPerConnection > 0 && count >= maxParallelPerConnection {
+				busy[connection] = true
+			}
+		}
+		return busy
+	}
 	var err error
 	for {
 		// start goroutine when sema lock ready and pipeline exist.
@@ -332,7 +430,7 @@ func RunPipelineInQueue(pipelineMaxParallel int64) {
 		globalPipelineLog.Info("get lock and wait next pipeline")
 		var dbPipeline *models.Pipeline
 		for {
-			dbPipeline, err = dequeuePipeline(runningParallelLabels)
+			dbPipeline, err = dequeuePipeline(runningParallelLabels, busyConnections())
 			if err == nil && dbPipeline != nil {
 				break
 			}
@@ -350,15 +448,29 @@ func RunPipelineInQueue(pipelineMaxParallel int64) {
 				pipelineParallelLabels = append(pipelineParallelLabels, dbLabel)
 			}
 		}
+		connections := pipelineConnections(dbPipeline.Plan)
 		runningParallelLabelLock.Lock()
 		runningParallelLabels = append(runningParallelLabels, pipelineParallelLabels...)
+		for _, connection := range connections {
+			runningConnections[connection]++
+		}
 		runningParallelLabelLock.Unlock()
 
 		go func(pipelineId uint64, parallelLabels []string) {
 			defer sema.Release(1)
+			if distributedMode {
+				leaseDone := make(chan struct{})
+				defer close(leaseDone)
+				go renewPipelineLease(pipelineId, leaseDone)
+			}
 			defer func() {
 				runningParallelLabelLock.Lock()
 				runningParallelLabels = utils.SliceRemove(runningParallelLabels, parallelLabels...)
+				for _, connection := range connections {
+					if runningConnections[connection]--; runningConnections[connection] <= 0 {
+						delete(runningConnections, connection)
+					}
+				}
 				runningParallelLabelLock.Unlock()
 				globalPipelineLog.Info("finish pipeline #%d, now runningParallelLabels is %s", pipelineId, runningParallelLabels)
 			}()
@@ -435,7 +547,7 @@ func CancelPipeline(pipelineId uint64) errors.Error {
 	if err != nil {
 		return errors.BadInput.New("pipeline not found")
 	}
-	if pipeline.Status == models.TASK_CREATED || pipeline.Status == models.TASK_RERUN {
+	if pipeline.Status == models.TASK_CREATED || pipeline.Status == models.TASK_RERUN || pipeline.Status == models.TASK_PAUSED {
 		pipeline.Status = models.TASK_CANCELLED
 		err = db.Update(pipeline)
 		if err != nil {
@@ -467,6 +579,69 @@ func CancelPipeline(pipelineId uint64) errors.Error {
 	return errors.Convert(err)
 }
 
+// PausePipeline requests the running tasks of the pipeline to pause after their current subtask, the tasks
+// not started yet are paused as well. The pipeline turns TASK_PAUSED once all running tasks stopped.
+func PausePipeline(pipelineId uint64) errors.Error {
+	pipeline := &models.Pipeline{}
+	err := db.First(pipeline, dal.Where("id = ?", pipelineId))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return errors.NotFound.New("pipeline not found")
+		}
+		return errors.Default.Wrap(err, "error getting pipeline")
+	}
+	if pipeline.Status != models.TASK_RUNNING {
+		return errors.BadInput.New("only running pipelines can be paused")
+	}
+	err = db.UpdateColumn(
+		&models.Task{},
+		"status", models.TASK_PAUSING,
+		dal.Where(
+			"pipeline_id = ? AND status IN ?",
+			pipelineId, []string{models.TASK_RUNNING, models.TASK_CREATED, models.TASK_RERUN, models.TASK_RESUME, models.TASK_QUEUED},
+		),
+	)
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to pause pipeline tasks")
+	}
+	return nil
+}
+
+// ResumePipeline queues a paused pipeline again, the subtasks finished before the pause are skipped
+// and collectors continue from their latest state
+func ResumePipeline(pipelineId uint64) (err errors.Error) {
+	txHelper := dbhelper.NewTxHelper(basicRes, &err)
+	tx := txHelper.Begin()
+	defer txHelper.End()
+	err = txHelper.LockTablesTimeout(2*time.Second, dal.LockTables{
+		{Table: "_devlake_pipelines", Exclusive: true},
+		{Table: "_devlake_tasks", Exclusive: true},
+	})
+	if err != nil {
+		return errors.BadInput.Wrap(err, "failed to lock pipeline table, is there any pending pipeline or deletion?")
+	}
+	pipeline := &models.Pipeline{}
+	err = tx.First(pipeline, dal.Where("id = ?", pipelineId))
+	if err != nil {
+		if tx.IsErrorNotFound(err) {
+			return errors.NotFound.New("pipeline not found")
+		}
+		return err
+	}
+	if pipeline.Status != models.TASK_PAUSED {
+		return errors.BadInput.New("only paused pipelines can be resumed")
+	}
+	err = tx.UpdateColumn(
+		&models.Task{},
+		"status", models.TASK_RESUME,
+		dal.Where("pipeline_id = ? AND status IN ?", pipelineId, []string{models.TASK_PAUSED, models.TASK_PAUSING}),
+	)
+	if err != nil {
+		return err
+	}
+	return tx.UpdateColumn(&models.Pipeline{}, "status", models.TASK_RESUME, dal.Where("id = ?", pipelineId))
+}
+
 // getPipelineLogsPath gets the logs directory of this pipeline
 func getPipelineLogsPath(pipeline *models.Pipeline) (string, errors.Error) {
 	pipelineLog := GetPipelineLogger(pipeline)
@@ -483,6 +658,11 @@ func getPipelineLogsPath(pipeline *models.Pipeline) (string, errors.Error) {
 
 // RerunPipeline would rerun all failed tasks or specified task
 func RerunPipeline(pipelineId uint64, task *models.Task) (tasks []*models.Task, err errors.Error) {
+	return rerunPipeline(pipelineId, task, nil)
+}
+
+// rerunPipeline reruns the failed tasks or the specified task, and only the given subtasks of it if any
+func rerunPipeline(pipelineId uint64, task *models.Task, subtasks []string) (tasks []*models.Task, err errors.Error) {
 	// prevent pipeline executor from doing anything that might jeopardize the integrity
 	pipeline := &models.Pipeline{}
 	txHelper := dbhelper.NewTxHelper(basicRes, &err)
@@ -544,12 +724,19 @@ func RerunPipeline(pipelineId uint64, task *models.Task) (tasks []*models.Task,
 		if err != nil {
 			return nil, err
 		}
+		rerunSubtasks := t.Subtasks
+		if len(subtasks) > 0 {
+			rerunSubtasks = subtasks
+		}
 		// create new task
 		rerunTask, err := createTask(&models.NewTask{
 			PipelineTask: &models.PipelineTask{
-				Plugin:   t.Plugin,
-				Subtasks: t.Subtasks,
-				Options:  t.Options,
+				Plugin:      t.Plugin,
+				Subtasks:    rerunSubtasks,
+				Options:     t.Options,
+				RetryPolicy: t.RetryPolicy,
+				Timeout:     t.Timeout,
+				SyncPolicy:  t.SyncPolicy,
 			},
 			PipelineId:  t.PipelineId,
 			PipelineRow: t.PipelineRow,
@@ -0,0 +1,212 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+// Types of the pipeline progress events
+const (
+	PIPELINE_EVENT_STATUS           = "pipeline_status"
+	PIPELINE_EVENT_SUBTASK_STARTED  = "subtask_started"
+	PIPELINE_EVENT_SUBTASK_FINISHED = "subtask_finished"
+	PIPELINE_EVENT_PROGRESS         = "progress"
+	PIPELINE_EVENT_TASK_FINISHED    = "task_finished"
+	PIPELINE_EVENT_FINISHED         = "pipeline_finished"
+)
+
+// PipelineEvent reports the progress of a pipeline run by this instance
+type PipelineEvent struct {
+	Type            string    `json:"type"`
+	PipelineId      uint64    `json:"pipelineId"`
+	TaskId          uint64    `json:"taskId,omitempty"`
+	Plugin          string    `json:"plugin,omitempty"`
+	SubtaskName     string    `json:"subtaskName,omitempty"`
+	SubtaskNumber   int       `json:"subtaskNumber,omitempty"`
+	FinishedRecords int       `json:"finishedRecords,omitempty"`
+	TotalRecords    int       `json:"totalRecords,omitempty"`
+	FinishedTasks   int       `json:"finishedTasks,omitempty"`
+	TotalTasks      int       `json:"totalTasks,omitempty"`
+	Status          string    `json:"status,omitempty"`
+	Message         string    `json:"message,omitempty"`
+	Time            time.Time `json:"time"`
+}
+
+// pipelineEventBus fans the events out to the subscribers of the pipeline, slow subscribers miss events
+// rather than slowing the pipeline down
+type pipelineEventBus struct {
+	mu          sync.Mutex
+	subscribers map[uint64]map[chan *PipelineEvent]bool
+}
+
+var pipelineEvents = &pipelineEventBus{subscribers: make(map[uint64]map[chan *PipelineEvent]bool)}
+
+// SubscribePipelineEvents returns the events of the pipeline and a function to stop receiving them
+func SubscribePipelineEvents(pipelineId uint64) (<-chan *PipelineEvent, func()) {
+	return pipelineEvents.subscribe(pipelineId)
+}
+
+func (b *pipelineEventBus) subscribe(pipelineId uint64) (<-chan *PipelineEvent, func()) {
+	events := make(chan *PipelineEvent, 100)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.subscribers[pipelineId] == nil {
+		b.subscribers[pipelineId] = make(map[chan *PipelineEvent]bool)
+	}
+	b.subscribers[pipelineId][events] = true
+	return events, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.subscribers[pipelineId], events)
+		if len(b.subscribers[pipelineId]) == 0 {
+			delete(b.subscribers, pipelineId)
+		}
+	}
+}
+
+func (b *pipelineEventBus) publish(event *PipelineEvent) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for events := range b.subscribers[event.PipelineId] {
+		select {
+		case events <- event:
+		default:
+		}
+	}
+}
+
+// hasSubscribers tells whether anyone is listening to the pipeline, to skip building events nobody reads
+func (b *pipelineEventBus) hasSubscribers(pipelineId uint64) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subscribers[pipelineId]) > 0
+}
+
+// pipelineStatusEvent describes the current state of the pipeline
+func pipelineStatusEvent(pipeline *models.Pipeline) *PipelineEvent {
+	event := &PipelineEvent{
+		Type:          PIPELINE_EVENT_STATUS,
+		PipelineId:    pipeline.ID,
+		Status:        pipeline.Status,
+		Message:       pipeline.Message,
+		FinishedTasks: pipeline.FinishedTasks,
+		TotalTasks:    pipeline.TotalTasks,
+	}
+	for _, status := range models.FinishedTaskStatus {
+		if pipeline.Status == status {
+			event.Type = PIPELINE_EVENT_FINISHED
+		}
+	}
+	return event
+}
+
+// GetPipelineStatusEvent loads the pipeline and describes its current state
+func GetPipelineStatusEvent(pipelineId uint64) (*PipelineEvent, errors.Error) {
+	pipeline, err := GetDbPipeline(pipelineId)
+	if err != nil {
+		return nil, err
+	}
+	return pipelineStatusEvent(pipeline), nil
+}
+
+// taskEventPublisher turns the progress of a running task into events
+type taskEventPublisher struct {
+	task         *models.Task
+	subtaskName  string
+	lastProgress time.Time
+}
+
+func newTaskEventPublisher(taskId uint64) *taskEventPublisher {
+	task := &models.Task{}
+	if err := db.First(task, dal.Where("id = ?", taskId)); err != nil {
+		taskLog.Error(err, "failed to load task %d for its events", taskId)
+		return nil
+	}
+	return &taskEventPublisher{task: task}
+}
+
+func (p *taskEventPublisher) event(eventType string) *PipelineEvent {
+	return &PipelineEvent{
+		Type:        eventType,
+		PipelineId:  p.task.PipelineId,
+		TaskId:      p.task.ID,
+		Plugin:      p.task.Plugin,
+		SubtaskName: p.subtaskName,
+	}
+}
+
+// progress publishes the subtask transitions, and the records processed at most once a second
+func (p *taskEventPublisher) progress(progress *plugin.RunningProgress, detail *models.TaskProgressDetail) {
+	if p == nil || !pipelineEvents.hasSubscribers(p.task.PipelineId) {
+		return
+	}
+	switch progress.Type {
+	case plugin.SetCurrentSubTask:
+		p.subtaskFinished(detail)
+		p.subtaskName = progress.SubTaskName
+		event := p.event(PIPELINE_EVENT_SUBTASK_STARTED)
+		event.SubtaskNumber = progress.SubTaskNumber
+		pipelineEvents.publish(event)
+	case plugin.SubTaskSetProgress, plugin.SubTaskIncProgress:
+		if time.Since(p.lastProgress) < time.Second {
+			return
+		}
+		p.lastProgress = time.Now()
+		event := p.event(PIPELINE_EVENT_PROGRESS)
+		event.FinishedRecords = detail.FinishedRecords
+		event.TotalRecords = detail.TotalRecords
+		pipelineEvents.publish(event)
+	}
+}
+
+func (p *taskEventPublisher) subtaskFinished(detail *models.TaskProgressDetail) {
+	if p.subtaskName == "" {
+		return
+	}
+	event := p.event(PIPELINE_EVENT_SUBTASK_FINISHED)
+	event.FinishedRecords = detail.FinishedRecords
+	pipelineEvents.publish(event)
+}
+
+// finished publishes the end of the task, err is the error the task ended with
+func (p *taskEventPublisher) finished(detail *models.TaskProgressDetail, err errors.Error) {
+	if p == nil || !pipelineEvents.hasSubscribers(p.task.PipelineId) {
+		return
+	}
+	p.subtaskFinished(detail)
+	event := p.event(PIPELINE_EVENT_TASK_FINISHED)
+	event.Status = models.TASK_COMPLETED
+	task := &models.Task{}
+	if e := db.First(task, dal.Where("id = ?", p.task.ID)); e == nil {
+		event.Status = task.Status
+	}
+	if err != nil {
+		event.Message = err.Error()
+	}
+	pipelineEvents.publish(event)
+}
@@ -0,0 +1,76 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPipelineEventBus(t *testing.T) {
+	bus := &pipelineEventBus{subscribers: make(map[uint64]map[chan *PipelineEvent]bool)}
+	events, unsubscribe := bus.subscribe(1)
+	assert.True(t, bus.hasSubscribers(1))
+	assert.False(t, bus.hasSubscribers(2))
+
+	bus.publish(&PipelineEvent{Type: PIPELINE_EVENT_PROGRESS, PipelineId: 2})
+	bus.publish(&PipelineEvent{Type: PIPELINE_EVENT_SUBTASK_STARTED, PipelineId: 1, SubtaskName: "collectIssues"})
+	event := <-events
+	assert.Equal(t, "collectIssues", event.SubtaskName)
+	assert.False(t, event.Time.IsZero())
+	assert.Empty(t, events)
+
+	unsubscribe()
+	assert.False(t, bus.hasSubscribers(1))
+}
+
+func TestTaskEventPublisher(t *testing.T) {
+	events, unsubscribe := pipelineEvents.subscribe(7)
+	defer unsubscribe()
+	publisher := &taskEventPublisher{task: &models.Task{Model: common.Model{ID: 3}, PipelineId: 7, Plugin: "jira"}}
+	detail := &models.TaskProgressDetail{}
+
+	publisher.progress(&plugin.RunningProgress{Type: plugin.SetCurrentSubTask, SubTaskName: "collectIssues", SubTaskNumber: 1}, detail)
+	detail.FinishedRecords = 42
+	publisher.progress(&plugin.RunningProgress{Type: plugin.SubTaskIncProgress}, detail)
+	publisher.progress(&plugin.RunningProgress{Type: plugin.SetCurrentSubTask, SubTaskName: "extractIssues", SubTaskNumber: 2}, detail)
+
+	started := <-events
+	assert.Equal(t, PIPELINE_EVENT_SUBTASK_STARTED, started.Type)
+	assert.Equal(t, "collectIssues", started.SubtaskName)
+	progress := <-events
+	assert.Equal(t, PIPELINE_EVENT_PROGRESS, progress.Type)
+	assert.Equal(t, 42, progress.FinishedRecords)
+	finished := <-events
+	assert.Equal(t, PIPELINE_EVENT_SUBTASK_FINISHED, finished.Type)
+	assert.Equal(t, "collectIssues", finished.SubtaskName)
+	next := <-events
+	assert.Equal(t, "extractIssues", next.SubtaskName)
+	assert.Equal(t, uint64(3), next.TaskId)
+}
+
+func TestPipelineStatusEvent(t *testing.T) {
+	running := pipelineStatusEvent(&models.Pipeline{Status: models.TASK_RUNNING, TotalTasks: 3, FinishedTasks: 1})
+	assert.Equal(t, PIPELINE_EVENT_STATUS, running.Type)
+	assert.Equal(t, 1, running.FinishedTasks)
+	assert.Equal(t, PIPELINE_EVENT_FINISHED, pipelineStatusEvent(&models.Pipeline{Status: models.TASK_FAILED}).Type)
+}
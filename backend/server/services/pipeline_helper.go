@@ -32,6 +32,11 @@ var createDbPipelineLock sync.Mutex
 
 // CreateDbPipeline returns a NewPipeline
 func CreateDbPipeline(newPipeline *models.NewPipeline) (pipeline *models.Pipeline, err errors.Error) {
+	// planned ahead of locking the tables, the estimate reads the previous runs
+	delays, err := planRateLimitBudget(newPipeline)
+	if err != nil {
+		return nil, err
+	}
 	createDbPipelineLock.Lock()
 	defer createDbPipelineLock.Unlock()
 	pipeline = &models.Pipeline{}
@@ -97,6 +102,8 @@ func CreateDbPipeline(newPipeline *models.NewPipeline) (pipeline *models.Pipelin
 				PipelineId:   dbPipeline.ID,
 				PipelineRow:  i + 1,
 				PipelineCol:  j + 1,
+
+				StartDelaySeconds: delays[i+1][j+1],
 			}
 			_ = errors.Must1(createTask(newTask, tx))
 			// sync task state back to pipeline
@@ -141,6 +148,12 @@ func GetDbPipelines(query *PipelineQuery) ([]*models.Pipeline, int64, errors.Err
 			dal.Where("pl.name = ?", query.Label),
 		)
 	}
+	if query.ProjectNames != nil {
+		clauses = append(clauses, dal.Where(
+			"_devlake_pipelines.blueprint_id IN (SELECT id FROM _devlake_blueprints WHERE project_name IN ?)",
+			query.ProjectNames,
+		))
+	}
 
 	// count total records
 	count, err := db.Count(clauses...)
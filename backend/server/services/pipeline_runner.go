@@ -23,6 +23,7 @@ import (
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/log"
+	"github.com/apache/incubator-devlake/core/metrics"
 	"github.com/apache/incubator-devlake/core/models"
 	"github.com/apache/incubator-devlake/core/runner"
 	"github.com/apache/incubator-devlake/impls/logruslog"
@@ -39,6 +40,13 @@ func (p *pipelineRunner) runPipelineStandalone() errors.Error {
 		basicRes.ReplaceLogger(p.logger),
 		p.pipeline.ID,
 		func(taskIds []uint64) errors.Error {
+			err := scheduleTaskStarts(taskIds)
+			if err != nil {
+				return err
+			}
+			if distributedMode {
+				return RunTasksDistributed(p.logger, taskIds)
+			}
 			return RunTasksStandalone(p.logger, taskIds)
 		},
 	)
@@ -74,6 +82,9 @@ func runPipeline(pipelineId uint64) errors.Error {
 	}
 	// run
 	err = pipelineRun.runPipelineStandalone()
+	if errors.Is(err, runner.ErrTaskPaused) {
+		return pausePipeline(pipelineId)
+	}
 	isCancelled := errors.Is(err, context.Canceled)
 	if err != nil {
 		err = errors.Default.Wrap(err, fmt.Sprintf("Error running pipeline %d.", pipelineId))
@@ -82,7 +93,33 @@ func runPipeline(pipelineId uint64) errors.Error {
 	if e != nil {
 		return errors.Default.Wrap(err, fmt.Sprintf("Unable to get pipeline %d.", pipelineId))
 	}
-	// finished, update database
+	if err = finishPipeline(dbPipeline, err, isCancelled); err != nil {
+		return err
+	}
+	refreshProjectRollups(pipelineRun.logger, dbPipeline)
+	provisionProjectDashboardsOfPipeline(pipelineRun.logger, dbPipeline)
+	runDependentBlueprints(dbPipeline)
+	notifyBlueprintChannels(dbPipeline)
+	// notify external webhook
+	return NotifyExternal(pipelineId)
+}
+
+// pausePipeline marks the pipeline paused once its running tasks stopped, it is not finished yet
+func pausePipeline(pipelineId uint64) errors.Error {
+	err := db.UpdateColumns(&models.Pipeline{}, []dal.DalSet{
+		{ColumnName: "status", Value: models.TASK_PAUSED},
+		{ColumnName: "message", Value: ""},
+	}, dal.Where("id = ?", pipelineId))
+	if err != nil {
+		globalPipelineLog.Error(err, "update pipeline state failed")
+		return err
+	}
+	pipelineEvents.publish(&PipelineEvent{Type: PIPELINE_EVENT_STATUS, PipelineId: pipelineId, Status: models.TASK_PAUSED})
+	return NotifyExternal(pipelineId)
+}
+
+// finishPipeline saves the final status of the pipeline computed from its tasks and observes its duration
+func finishPipeline(dbPipeline *models.Pipeline, err errors.Error, isCancelled bool) errors.Error {
 	finishedAt := time.Now()
 	dbPipeline.FinishedAt = &finishedAt
 	if dbPipeline.BeganAt != nil {
@@ -102,8 +139,9 @@ func runPipeline(pipelineId uint64) errors.Error {
 		globalPipelineLog.Error(err, "update pipeline state failed")
 		return err
 	}
-	// notify external webhook
-	return NotifyExternal(pipelineId)
+	pipelineEvents.publish(pipelineStatusEvent(dbPipeline))
+	metrics.PipelineDuration.WithLabelValues(dbPipeline.Status).Observe(float64(dbPipeline.SpentSeconds))
+	return nil
 }
 
 // ComputePipelineStatus determines pipleline status by its latest(rerun included) tasks statuses
@@ -121,7 +159,7 @@ func ComputePipelineStatus(pipeline *models.Pipeline, isCancelled bool) (string,
 	for _, task := range tasks {
 		if task.Status == models.TASK_COMPLETED {
 			succeeded += 1
-		} else if task.Status == models.TASK_FAILED || task.Status == models.TASK_CANCELLED {
+		} else if task.Status == models.TASK_FAILED || task.Status == models.TASK_CANCELLED || task.Status == models.TASK_PAUSED {
 			failed += 1
 		} else if task.Status == models.TASK_RUNNING {
 			running += 1
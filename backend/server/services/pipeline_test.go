@@ -0,0 +1,148 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPipelineConnections(t *testing.T) {
+	plan := models.PipelinePlan{
+		{
+			{Plugin: "jira", Options: map[string]interface{}{"connectionId": 1, "boardId": 10}},
+			{Plugin: "jira", Options: map[string]interface{}{"connectionId": float64(1), "boardId": 11}},
+			{Plugin: "github", Options: map[string]interface{}{"connectionId": "2"}},
+		},
+		{
+			{Plugin: "dora", Options: map[string]interface{}{"projectName": "p"}},
+			{Plugin: "jira", Options: map[string]interface{}{"connectionId": 3}},
+		},
+	}
+	assert.Equal(t, []string{"jira:1", "github:2", "jira:3"}, pipelineConnections(plan))
+	assert.Empty(t, pipelineConnections(models.PipelinePlan{}))
+}
+
+func TestMarkInterruptedBackgroundJobs(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	mockDal.On("UpdateColumns", &models.BackgroundJob{}, mock.MatchedBy(func(sets []dal.DalSet) bool {
+		return len(sets) == 3 && sets[0].ColumnName == "status" && sets[0].Value == models.BACKGROUND_JOB_FAILED
+	}), mock.Anything).Return(nil).Once()
+	defer func(d dal.Dal) { db = d }(db)
+	db = mockDal
+
+	markInterruptedBackgroundJobs()
+	mockDal.AssertExpectations(t)
+}
+
+func TestPausePipeline(t *testing.T) {
+	defer func(d dal.Dal) { db = d }(db)
+	for status, wantType := range map[string]*errors.Type{
+		models.TASK_RUNNING:   nil,
+		models.TASK_PAUSED:    errors.BadInput,
+		models.TASK_COMPLETED: errors.BadInput,
+	} {
+		mockDal := new(mockdal.Dal)
+		mockDal.On("First", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			args.Get(0).(*models.Pipeline).Status = status
+		}).Return(nil).Once()
+		mockDal.On("UpdateColumn", &models.Task{}, "status", models.TASK_PAUSING, mock.Anything).Return(nil).Once()
+		db = mockDal
+
+		err := PausePipeline(1)
+		if wantType == nil {
+			assert.Nil(t, err, status)
+			mockDal.AssertExpectations(t)
+		} else {
+			assert.Equal(t, wantType, err.GetType(), status)
+			mockDal.AssertNotCalled(t, "UpdateColumn", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		}
+	}
+}
+
+func TestResumePipeline(t *testing.T) {
+	defer func(r context.BasicRes) { basicRes = r }(basicRes)
+	for status, wantType := range map[string]*errors.Type{
+		models.TASK_PAUSED:  nil,
+		models.TASK_RUNNING: errors.BadInput,
+	} {
+		mockTx := new(mockdal.Transaction)
+		mockTx.On("LockTables", mock.Anything).Return(nil).Once()
+		mockTx.On("First", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			args.Get(0).(*models.Pipeline).Status = status
+		}).Return(nil).Once()
+		mockTx.On("UpdateColumn", &models.Task{}, "status", models.TASK_RESUME, mock.Anything).Return(nil).Once()
+		mockTx.On("UpdateColumn", &models.Pipeline{}, "status", models.TASK_RESUME, mock.Anything).Return(nil).Once()
+		mockTx.On("UnlockTables").Return(nil).Once()
+		mockTx.On("Commit").Return(nil).Maybe()
+		mockTx.On("Rollback").Return(nil).Maybe()
+		basicRes = unithelper.DummyBasicRes(func(mockDal *mockdal.Dal) {
+			mockDal.On("Begin").Return(mockTx).Once()
+		})
+
+		err := ResumePipeline(1)
+		if wantType == nil {
+			assert.Nil(t, err, status)
+			mockTx.AssertExpectations(t)
+		} else {
+			assert.Equal(t, wantType, err.GetType(), status)
+			mockTx.AssertNotCalled(t, "UpdateColumn", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			mockTx.AssertNotCalled(t, "Commit")
+		}
+	}
+}
+
+func TestPickUnblockedPipeline(t *testing.T) {
+	plans := map[uint64]models.PipelinePlan{
+		1: {{{Plugin: "jira", Options: map[string]interface{}{"connectionId": 1}}}},
+		2: {{{Plugin: "jira", Options: map[string]interface{}{"connectionId": 1}}, {Plugin: "github", Options: map[string]interface{}{"connectionId": 2}}}},
+		3: {{{Plugin: "jira", Options: map[string]interface{}{"connectionId": 2}}}},
+	}
+	candidates := []*models.Pipeline{{Model: common.Model{ID: 1}}, {Model: common.Model{ID: 2}}, {Model: common.Model{ID: 3}}}
+	tests := []struct {
+		name            string
+		busyConnections map[string]bool
+		want            uint64
+	}{
+		{"no busy connection", nil, 1},
+		{"the earliest is blocked", map[string]bool{"jira:1": true}, 3},
+		{"blocked by any of its connections", map[string]bool{"github:2": true}, 1},
+		{"all blocked", map[string]bool{"jira:1": true, "jira:2": true}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTx := new(mockdal.Transaction)
+			mockTx.On("First", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				id := args.Get(1).([]dal.Clause)[0].Data.(dal.DalClause).Params[0].(uint64)
+				*args.Get(0).(*models.Pipeline) = models.Pipeline{Model: common.Model{ID: id}, Plan: plans[id]}
+			}).Return(nil)
+			got, err := pickUnblockedPipeline(mockTx, candidates, tt.busyConnections)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -41,6 +41,8 @@ var projectService ProjectService
 type ProjectQuery struct {
 	Pagination
 	Keyword *string `json:"keyword" form:"keyword"`
+	// ProjectNames restricts the result to the given projects unless nil
+	ProjectNames []string `json:"-" form:"-"`
 }
 
 func (query *ProjectQuery) GetKeyword() string {
@@ -62,6 +64,9 @@ func GetProjects(query *ProjectQuery) ([]*models.ApiOutputProject, int64, errors
 	if query.Keyword != nil {
 		clauses = append(clauses, dal.Where("LOWER(name) LIKE ?", "%"+query.GetKeyword()+"%"))
 	}
+	if query.ProjectNames != nil {
+		clauses = append(clauses, dal.Where("name IN ?", query.ProjectNames))
+	}
 
 	count, err := db.Count(clauses...)
 	if err != nil {
@@ -173,6 +178,8 @@ func CreateProject(projectInput *models.ApiInputProject) (*models.ApiOutputProje
 	if err != nil {
 		return nil, err
 	}
+	// grafana is not required for creating projects, don't keep the caller waiting for it
+	go autoProvisionProjectDashboards(logger, project.Name)
 
 	return makeProjectOutput(project, false)
 }
@@ -409,6 +416,10 @@ func deleteProjectBlueprint(projectName string) errors.Error {
 			return errors.Default.Wrap(err, fmt.Sprintf("error finding blueprint associated with project %s", projectName))
 		}
 	} else {
+		if err = checkNoDependentBlueprints(bp.ID); err != nil {
+			return err
+		}
+		// the dependencies of the blueprint go along with it
 		err = bpManager.DeleteBlueprint(bp.ID)
 		if err != nil {
 			return errors.Default.Wrap(err, fmt.Sprintf("error deleting blueprint associated with project %s", projectName))
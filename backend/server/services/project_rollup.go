@@ -0,0 +1,190 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"sort"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/log"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+type rollupDates struct {
+	StartDate *time.Time
+	EndDate   *time.Time
+}
+
+type rollupCycleTime struct {
+	PrMergedDate *time.Time
+	PrCycleTime  *int64
+}
+
+// refreshProjectRollups refreshes the rollup tables of the project of the blueprint the pipeline belongs to,
+// failures are logged and never affect the pipeline
+func refreshProjectRollups(logger log.Logger, pipeline *models.Pipeline) {
+	if pipeline.Status != models.TASK_COMPLETED || pipeline.BlueprintId == 0 {
+		return
+	}
+	blueprint, err := GetBlueprint(pipeline.BlueprintId, false)
+	if err != nil {
+		logger.Error(err, "failed to load blueprint %d for refreshing project rollups", pipeline.BlueprintId)
+		return
+	}
+	if blueprint.ProjectName == "" {
+		return
+	}
+	if err = refreshProjectMonthlyRollups(blueprint.ProjectName); err != nil {
+		logger.Error(err, "failed to refresh monthly rollups of project %s", blueprint.ProjectName)
+	}
+	for name, pluginMeta := range plugin.AllPlugins() {
+		rollup, ok := pluginMeta.(plugin.PluginProjectRollup)
+		if !ok {
+			continue
+		}
+		if err = rollup.RefreshProjectRollups(basicRes, blueprint.ProjectName); err != nil {
+			logger.Error(err, "plugin %s failed to refresh rollups of project %s", name, blueprint.ProjectName)
+		}
+	}
+}
+
+func refreshProjectMonthlyRollups(projectName string) errors.Error {
+	var prs []*rollupDates
+	err := db.All(&prs,
+		dal.Select("pr.created_date AS start_date, pr.merged_date AS end_date"),
+		dal.From("pull_requests pr"),
+		dal.Join("INNER JOIN project_mapping pm ON pm.row_id = pr.base_repo_id AND pm.table = 'repos'"),
+		dal.Where("pm.project_name = ?", projectName),
+	)
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to load pull requests")
+	}
+	var cycleTimes []*rollupCycleTime
+	err = db.All(&cycleTimes,
+		dal.Select("pr_merged_date, pr_cycle_time"),
+		dal.From(&crossdomain.ProjectPrMetric{}),
+		dal.Where("project_name = ? AND pr_merged_date IS NOT NULL", projectName),
+	)
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to load pull request metrics")
+	}
+	var deployments []*rollupDates
+	err = db.All(&deployments,
+		dal.Select("d.finished_date AS end_date"),
+		dal.From("cicd_deployments d"),
+		dal.Join("INNER JOIN project_mapping pm ON pm.row_id = d.cicd_scope_id AND pm.table = 'cicd_scopes'"),
+		dal.Where("pm.project_name = ? AND d.environment = ? AND d.result = ?", projectName, devops.PRODUCTION, devops.RESULT_SUCCESS),
+	)
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to load deployments")
+	}
+	var bugs []*rollupDates
+	err = db.All(&bugs,
+		dal.Select("DISTINCT i.id, i.created_date AS start_date, i.resolution_date AS end_date"),
+		dal.From("issues i"),
+		dal.Join("INNER JOIN board_issues bi ON bi.issue_id = i.id"),
+		dal.Join("INNER JOIN project_mapping pm ON pm.row_id = bi.board_id AND pm.table = 'boards'"),
+		dal.Where("pm.project_name = ? AND i.type = ?", projectName, ticket.BUG),
+	)
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to load bugs")
+	}
+
+	rollups := buildProjectMonthlyRollups(projectName, prs, cycleTimes, deployments, bugs)
+	err = db.Delete(&crossdomain.ProjectMonthlyRollup{}, dal.Where("project_name = ?", projectName))
+	if err != nil {
+		return err
+	}
+	if len(rollups) == 0 {
+		return nil
+	}
+	return db.CreateOrUpdate(rollups)
+}
+
+func buildProjectMonthlyRollups(
+	projectName string,
+	prs []*rollupDates,
+	cycleTimes []*rollupCycleTime,
+	deployments []*rollupDates,
+	bugs []*rollupDates,
+) []*crossdomain.ProjectMonthlyRollup {
+	months := make(map[time.Time]*crossdomain.ProjectMonthlyRollup)
+	monthOf := func(t *time.Time) *crossdomain.ProjectMonthlyRollup {
+		utc := t.UTC()
+		month := time.Date(utc.Year(), utc.Month(), 1, 0, 0, 0, 0, time.UTC)
+		rollup, ok := months[month]
+		if !ok {
+			rollup = &crossdomain.ProjectMonthlyRollup{ProjectName: projectName, Month: month}
+			months[month] = rollup
+		}
+		return rollup
+	}
+	for _, pr := range prs {
+		if pr.StartDate != nil {
+			monthOf(pr.StartDate).PrOpened++
+		}
+		if pr.EndDate != nil {
+			monthOf(pr.EndDate).PrMerged++
+		}
+	}
+	cycleTimeCounts := make(map[*crossdomain.ProjectMonthlyRollup]int)
+	for _, c := range cycleTimes {
+		if c.PrMergedDate == nil || c.PrCycleTime == nil {
+			continue
+		}
+		rollup := monthOf(c.PrMergedDate)
+		rollup.AvgPrCycleTimeMinutes += float64(*c.PrCycleTime)
+		cycleTimeCounts[rollup]++
+	}
+	for rollup, count := range cycleTimeCounts {
+		rollup.AvgPrCycleTimeMinutes /= float64(count)
+	}
+	deploymentDays := make(map[string]bool)
+	for _, d := range deployments {
+		if d.EndDate == nil {
+			continue
+		}
+		rollup := monthOf(d.EndDate)
+		rollup.Deployments++
+		day := d.EndDate.UTC().Format(time.DateOnly)
+		if !deploymentDays[day] {
+			deploymentDays[day] = true
+			rollup.DeploymentDays++
+		}
+	}
+	for _, bug := range bugs {
+		if bug.StartDate != nil {
+			monthOf(bug.StartDate).BugsCreated++
+		}
+		if bug.EndDate != nil {
+			monthOf(bug.EndDate).BugsResolved++
+		}
+	}
+	result := make([]*crossdomain.ProjectMonthlyRollup, 0, len(months))
+	for _, rollup := range months {
+		result = append(result, rollup)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Month.Before(result[j].Month) })
+	return result
+}
@@ -0,0 +1,112 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBuildProjectMonthlyRollups(t *testing.T) {
+	date := func(m time.Month, d int) *time.Time {
+		v := time.Date(2023, m, d, 12, 0, 0, 0, time.UTC)
+		return &v
+	}
+	minutes := func(m int64) *int64 {
+		return &m
+	}
+	prs := []*rollupDates{
+		{StartDate: date(1, 5), EndDate: date(2, 1)},
+		{StartDate: date(1, 20)},
+	}
+	cycleTimes := []*rollupCycleTime{
+		{PrMergedDate: date(2, 1), PrCycleTime: minutes(100)},
+		{PrMergedDate: date(2, 3), PrCycleTime: minutes(300)},
+		{PrMergedDate: date(2, 4)},
+	}
+	deployments := []*rollupDates{
+		{EndDate: date(2, 1)},
+		{EndDate: date(2, 1)},
+		{EndDate: date(2, 9)},
+		{},
+	}
+	bugs := []*rollupDates{
+		{StartDate: date(1, 2), EndDate: date(3, 1)},
+	}
+	rollups := buildProjectMonthlyRollups("proj", prs, cycleTimes, deployments, bugs)
+
+	assert.Len(t, rollups, 3)
+	jan, feb, mar := rollups[0], rollups[1], rollups[2]
+	assert.Equal(t, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), jan.Month)
+	assert.Equal(t, "proj", jan.ProjectName)
+	assert.Equal(t, 2, jan.PrOpened)
+	assert.Equal(t, 1, jan.BugsCreated)
+	assert.Equal(t, 1, feb.PrMerged)
+	assert.Equal(t, float64(200), feb.AvgPrCycleTimeMinutes)
+	assert.Equal(t, 3, feb.Deployments)
+	assert.Equal(t, 2, feb.DeploymentDays)
+	assert.Equal(t, 1, mar.BugsResolved)
+}
+
+func TestRefreshProjectMonthlyRollups(t *testing.T) {
+	merged := time.Date(2023, 2, 1, 12, 0, 0, 0, time.UTC)
+	cycleTime := int64(60)
+	mockDal := new(mockdal.Dal)
+	mockDal.On("All", mock.AnythingOfType("*[]*services.rollupDates"), mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(0).(*[]*rollupDates) = []*rollupDates{{StartDate: &merged, EndDate: &merged}}
+	}).Return(nil).Once()
+	mockDal.On("All", mock.AnythingOfType("*[]*services.rollupCycleTime"), mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(0).(*[]*rollupCycleTime) = []*rollupCycleTime{{PrMergedDate: &merged, PrCycleTime: &cycleTime}}
+	}).Return(nil).Once()
+	// no deployments nor bugs
+	mockDal.On("All", mock.AnythingOfType("*[]*services.rollupDates"), mock.Anything).Return(nil).Twice()
+	mockDal.On("Delete", &crossdomain.ProjectMonthlyRollup{}, []dal.Clause{dal.Where("project_name = ?", "proj")}).Return(nil).Once()
+	var saved []*crossdomain.ProjectMonthlyRollup
+	mockDal.On("CreateOrUpdate", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(0).([]*crossdomain.ProjectMonthlyRollup)
+	}).Return(nil).Once()
+	defer func(d dal.Dal) { db = d }(db)
+	db = mockDal
+
+	assert.Nil(t, refreshProjectMonthlyRollups("proj"))
+	mockDal.AssertExpectations(t)
+	assert.Equal(t, []*crossdomain.ProjectMonthlyRollup{{
+		ProjectName:           "proj",
+		Month:                 time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC),
+		PrOpened:              1,
+		PrMerged:              1,
+		AvgPrCycleTimeMinutes: 60,
+	}}, saved)
+}
+
+func TestRefreshProjectRollupsSkipsUnsuccessfulRuns(t *testing.T) {
+	// any access to the database would fail the test as the mock has no expectations
+	defer func(d dal.Dal) { db = d }(db)
+	db = new(mockdal.Dal)
+
+	refreshProjectRollups(nil, &models.Pipeline{Status: models.TASK_FAILED, BlueprintId: 1})
+	refreshProjectRollups(nil, &models.Pipeline{Status: models.TASK_PARTIAL, BlueprintId: 1})
+	refreshProjectRollups(nil, &models.Pipeline{Status: models.TASK_COMPLETED})
+}
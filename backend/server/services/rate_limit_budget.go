@@ -0,0 +1,181 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/log"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/robfig/cron/v3"
+)
+
+// budgetTask is a task of a stage sending requests to a connection
+type budgetTask struct {
+	col      int
+	requests int
+}
+
+// planRateLimitBudget estimates the api requests the collectors of each stage send per connection, the same way the
+// dry-run does, and compares them against the rate limit of the connection. Depending on the rate limit budget of the
+// pipeline, the stages exceeding it are only logged or their tasks get start delays, keyed by [row][col] of the plan.
+func planRateLimitBudget(newPipeline *models.NewPipeline) (map[int]map[int]int, errors.Error) {
+	delays := make(map[int]map[int]int)
+	budget := newPipeline.SyncPolicy.RateLimitBudget
+	if budget == "" || newPipeline.SyncPolicy.SkipCollectors {
+		return delays, nil
+	}
+	history, err := latestCollectedRecords(newPipeline.BlueprintId)
+	if err != nil {
+		return nil, err
+	}
+	var window time.Duration
+	if budget == models.RATE_LIMIT_BUDGET_SPLIT {
+		window = blueprintCronWindow(newPipeline.BlueprintId)
+	}
+	for i, stage := range newPipeline.Plan {
+		var connections []string
+		estimatesByConnection := make(map[string]*DryRunEstimate)
+		tasksByConnection := make(map[string][]budgetTask)
+		for j, task := range stage {
+			estimates := estimateApiRequests(models.PipelinePlan{{task}}, history, false)
+			if len(estimates) == 0 {
+				continue
+			}
+			key := taskKey(task.Plugin, map[string]interface{}{"connectionId": estimates[0].ConnectionId})
+			if _, ok := estimatesByConnection[key]; !ok {
+				connections = append(connections, key)
+				estimatesByConnection[key] = estimates[0]
+			}
+			tasksByConnection[key] = append(tasksByConnection[key], budgetTask{col: j + 1, requests: estimates[0].ApiRequests})
+		}
+		for _, key := range connections {
+			connection := estimatesByConnection[key]
+			tasks := tasksByConnection[key]
+			rateLimit := connectionRateLimit(connection.PluginName, connection.ConnectionId)
+			requests := 0
+			for _, task := range tasks {
+				requests += task.requests
+			}
+			if requests <= rateLimit {
+				continue
+			}
+			globalPipelineLog.Warn(nil, "stage %d of pipeline %s is expected to send %d requests to %s connection %d, more than its rate limit of %d per hour",
+				i+1, newPipeline.Name, requests, connection.PluginName, connection.ConnectionId, rateLimit)
+			if budget == models.RATE_LIMIT_BUDGET_WARN {
+				continue
+			}
+			if delays[i+1] == nil {
+				delays[i+1] = make(map[int]int)
+			}
+			for k, delay := range budgetDelays(tasks, rateLimit, window) {
+				delays[i+1][tasks[k].col] = delay
+			}
+		}
+	}
+	return delays, nil
+}
+
+// budgetDelays returns the start delay in seconds of each task, a task starts once the requests of the previous tasks
+// are replenished by the rate limit, and no earlier than its share of the window if the window is set
+func budgetDelays(tasks []budgetTask, rateLimit int, window time.Duration) []int {
+	total := 0
+	for _, task := range tasks {
+		total += task.requests
+	}
+	delays := make([]int, len(tasks))
+	previous := 0
+	for k, task := range tasks {
+		delay := previous * 3600 / rateLimit
+		if window > 0 && total > 0 {
+			if spread := int(window.Seconds()) * previous / total; spread > delay {
+				delay = spread
+			}
+		}
+		delays[k] = delay
+		previous += task.requests
+	}
+	return delays
+}
+
+// blueprintCronWindow returns the time until the next scheduled run of the blueprint, 0 if it isn't scheduled
+func blueprintCronWindow(blueprintId uint64) time.Duration {
+	if blueprintId == 0 {
+		return 0
+	}
+	blueprint := &models.Blueprint{}
+	if err := db.First(blueprint, dal.Where("id = ?", blueprintId)); err != nil {
+		return 0
+	}
+	if blueprint.IsManual || blueprint.CronConfig == "" {
+		return 0
+	}
+	schedule, err := cron.ParseStandard(blueprintCronSpec(blueprint))
+	if err != nil {
+		return 0
+	}
+	now := time.Now()
+	return schedule.Next(now).Sub(now)
+}
+
+// scheduleTaskStarts turns the start delays of the tasks of a stage into start times, right before the stage runs
+func scheduleTaskStarts(taskIds []uint64) errors.Error {
+	var tasks []*models.Task
+	err := db.All(&tasks, dal.Where("id IN ? AND start_delay_seconds > 0", taskIds))
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, task := range tasks {
+		startAfter := now.Add(time.Duration(task.StartDelaySeconds) * time.Second)
+		err = db.UpdateColumn(&models.Task{}, "start_after", startAfter, dal.Where("id = ?", task.ID))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// waitTaskStart blocks until the start time of the task, the task is cancelled if ctx is done in the meantime
+func waitTaskStart(ctx context.Context, logger log.Logger, taskId uint64) errors.Error {
+	task := &models.Task{}
+	err := db.First(task, dal.Where("id = ?", taskId))
+	if err != nil {
+		return err
+	}
+	if task.StartAfter == nil || !task.StartAfter.After(time.Now()) {
+		return nil
+	}
+	logger.Info("task #%d starts at %s to stay within the rate limit budget", taskId, task.StartAfter.Format(time.RFC3339))
+	timer := time.NewTimer(time.Until(*task.StartAfter))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		err = db.UpdateColumn(&models.Task{}, "status", models.TASK_CANCELLED, dal.Where("id = ?", taskId))
+		if err != nil {
+			return err
+		}
+		return errors.Convert(fmt.Errorf("task #%d cancelled before it started: %w", taskId, ctx.Err()))
+	}
+}
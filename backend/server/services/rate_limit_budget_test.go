@@ -0,0 +1,35 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBudgetDelays(t *testing.T) {
+	tasks := []budgetTask{{col: 1, requests: 3000}, {col: 2, requests: 1500}, {col: 3, requests: 1500}}
+	// staggered by the time the rate limit takes to replenish the requests of the previous tasks
+	assert.Equal(t, []int{0, 3600, 5400}, budgetDelays(tasks, 3000, 0))
+	// spread across the window if it leaves more room than the rate limit
+	assert.Equal(t, []int{0, 43200, 64800}, budgetDelays(tasks, 3000, 24*time.Hour))
+	// but never tighter than the rate limit allows
+	assert.Equal(t, []int{0, 3600, 5400}, budgetDelays(tasks, 3000, time.Hour))
+}
@@ -0,0 +1,345 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+var rbacRoleRanks = map[string]int{
+	models.ROLE_VIEWER:     1,
+	models.ROLE_MAINTAINER: 2,
+	models.ROLE_ADMIN:      3,
+}
+
+// RoleBindingQuery is used to query the role bindings
+type RoleBindingQuery struct {
+	Pagination
+	SubjectType  string `form:"subjectType"`
+	Subject      string `form:"subject"`
+	ResourceType string `form:"resourceType"`
+	Plugin       string `form:"plugin"`
+	ResourceId   string `form:"resourceId"`
+}
+
+// RbacEnabled tells whether the role bindings are enforced on the REST API
+func RbacEnabled() bool {
+	return cfg.GetBool("RBAC_ENABLED")
+}
+
+// GetRoleBindings returns a paginated list of role bindings matching `query`
+func GetRoleBindings(query *RoleBindingQuery) ([]*models.RoleBinding, int64, errors.Error) {
+	if err := VerifyStruct(query); err != nil {
+		return nil, 0, err
+	}
+	clauses := []dal.Clause{
+		dal.From(&models.RoleBinding{}),
+	}
+	if query.SubjectType != "" {
+		clauses = append(clauses, dal.Where("subject_type = ?", query.SubjectType))
+	}
+	if query.Subject != "" {
+		clauses = append(clauses, dal.Where("subject = ?", query.Subject))
+	}
+	if query.ResourceType != "" {
+		clauses = append(clauses, dal.Where("resource_type = ?", query.ResourceType))
+	}
+	if query.Plugin != "" {
+		clauses = append(clauses, dal.Where("plugin = ?", query.Plugin))
+	}
+	if query.ResourceId != "" {
+		clauses = append(clauses, dal.Where("resource_id = ?", query.ResourceId))
+	}
+	count, err := db.Count(clauses...)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error getting DB count of role bindings")
+	}
+	clauses = append(clauses,
+		dal.Orderby("id"),
+		dal.Offset(query.GetSkip()),
+		dal.Limit(query.GetPageSize()),
+	)
+	roleBindings := make([]*models.RoleBinding, 0)
+	err = db.All(&roleBindings, clauses...)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error finding DB role bindings")
+	}
+	return roleBindings, count, nil
+}
+
+// GetRoleBinding returns the role binding with the given id
+func GetRoleBinding(id uint64) (*models.RoleBinding, errors.Error) {
+	roleBinding := &models.RoleBinding{}
+	err := db.First(roleBinding, dal.Where("id = ?", id))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return nil, errors.NotFound.New(fmt.Sprintf("role binding %d not found", id))
+		}
+		return nil, errors.Default.Wrap(err, "error getting the role binding from database")
+	}
+	return roleBinding, nil
+}
+
+// CreateRoleBinding validates and saves a new role binding
+func CreateRoleBinding(user *common.User, roleBinding *models.RoleBinding) (*models.RoleBinding, errors.Error) {
+	roleBinding.ID = 0
+	if roleBinding.ResourceType == "" {
+		roleBinding.ResourceType = models.RBAC_RESOURCE_GLOBAL
+	}
+	if err := VerifyStruct(roleBinding); err != nil {
+		return nil, err
+	}
+	switch roleBinding.ResourceType {
+	case models.RBAC_RESOURCE_GLOBAL:
+		roleBinding.Plugin = ""
+		roleBinding.ResourceId = ""
+	case models.RBAC_RESOURCE_PROJECT:
+		if roleBinding.ResourceId == "" {
+			return nil, errors.BadInput.New("resourceId must be the name of the project")
+		}
+		roleBinding.Plugin = ""
+	case models.RBAC_RESOURCE_CONNECTION:
+		if roleBinding.Plugin == "" || roleBinding.ResourceId == "" {
+			return nil, errors.BadInput.New("plugin and resourceId must identify the connection")
+		}
+	}
+	if roleBinding.Role == models.ROLE_ADMIN && roleBinding.ResourceType != models.RBAC_RESOURCE_GLOBAL {
+		return nil, errors.BadInput.New("the admin role can only be bound globally")
+	}
+	if user != nil {
+		roleBinding.Creator = common.Creator{Creator: user.Name, CreatorEmail: user.Email}
+	}
+	if err := db.Create(roleBinding); err != nil {
+		return nil, errors.Default.Wrap(err, "error saving the role binding")
+	}
+	return roleBinding, nil
+}
+
+// DeleteRoleBinding deletes the role binding with the given id and returns it
+func DeleteRoleBinding(id uint64) (*models.RoleBinding, errors.Error) {
+	roleBinding, err := GetRoleBinding(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Delete(roleBinding); err != nil {
+		return nil, errors.Default.Wrap(err, "error deleting the role binding")
+	}
+	return roleBinding, nil
+}
+
+// RbacGrants holds the highest role of a subject globally, per project and per plugin connection
+type RbacGrants struct {
+	global      string
+	projects    map[string]string
+	connections map[string]string
+}
+
+// NewRbacGrants merges the role bindings of a subject
+func NewRbacGrants(roleBindings []*models.RoleBinding) *RbacGrants {
+	grants := &RbacGrants{
+		projects:    make(map[string]string),
+		connections: make(map[string]string),
+	}
+	for _, roleBinding := range roleBindings {
+		switch roleBinding.ResourceType {
+		case models.RBAC_RESOURCE_GLOBAL:
+			grants.global = higherRole(grants.global, roleBinding.Role)
+		case models.RBAC_RESOURCE_PROJECT:
+			grants.projects[roleBinding.ResourceId] = higherRole(grants.projects[roleBinding.ResourceId], roleBinding.Role)
+		case models.RBAC_RESOURCE_CONNECTION:
+			key := rbacConnectionKey(roleBinding.Plugin, roleBinding.ResourceId)
+			grants.connections[key] = higherRole(grants.connections[key], roleBinding.Role)
+		}
+	}
+	return grants
+}
+
+// GetRbacGrants loads the grants of the user, or of the api key used for the request. An api key without any
+// binding of its own inherits the grants of its creator
+func GetRbacGrants(user *common.User, apiKey *models.ApiKey) (*RbacGrants, errors.Error) {
+	if apiKey != nil {
+		roleBindings := make([]*models.RoleBinding, 0)
+		err := db.All(&roleBindings, dal.Where("subject_type = ? AND subject = ?", models.RBAC_SUBJECT_API_KEY, fmt.Sprint(apiKey.ID)))
+		if err != nil {
+			return nil, errors.Default.Wrap(err, "error finding the role bindings of the api key")
+		}
+		if len(roleBindings) > 0 {
+			return NewRbacGrants(roleBindings), nil
+		}
+		if user == nil {
+			user = &common.User{Name: apiKey.Creator.Creator, Email: apiKey.Creator.CreatorEmail}
+		}
+	}
+	if user == nil {
+		return NewRbacGrants(nil), nil
+	}
+	subjects := make([]string, 0, 2)
+	for _, subject := range []string{user.Name, user.Email} {
+		if subject == "" {
+			continue
+		}
+		if isRbacAdmin(subject) {
+			return NewRbacGrants([]*models.RoleBinding{{Role: models.ROLE_ADMIN, ResourceType: models.RBAC_RESOURCE_GLOBAL}}), nil
+		}
+		subjects = append(subjects, subject)
+	}
+	if len(subjects) == 0 {
+		return NewRbacGrants(nil), nil
+	}
+	roleBindings := make([]*models.RoleBinding, 0)
+	err := db.All(&roleBindings, dal.Where("subject_type = ? AND subject IN ?", models.RBAC_SUBJECT_USER, subjects))
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error finding the role bindings of the user")
+	}
+	return NewRbacGrants(roleBindings), nil
+}
+
+// IsAdmin tells whether the subject is a global admin
+func (g *RbacGrants) IsAdmin() bool {
+	return g.global == models.ROLE_ADMIN
+}
+
+// HasAnyRole tells whether the subject was granted anything at all
+func (g *RbacGrants) HasAnyRole() bool {
+	return g.global != "" || len(g.projects) > 0 || len(g.connections) > 0
+}
+
+// HasProjectRole tells whether the subject has at least `role` on the project
+func (g *RbacGrants) HasProjectRole(projectName string, role string) bool {
+	return isRoleSufficient(g.global, role) || isRoleSufficient(g.projects[projectName], role)
+}
+
+// HasConnectionRole tells whether the subject has at least `role` on the connection of the plugin
+func (g *RbacGrants) HasConnectionRole(pluginName string, connectionId string, role string) bool {
+	return isRoleSufficient(g.global, role) || isRoleSufficient(g.connections[rbacConnectionKey(pluginName, connectionId)], role)
+}
+
+// ProjectNames returns the projects visible to the subject, nil if all of them are
+func (g *RbacGrants) ProjectNames() []string {
+	if isRoleSufficient(g.global, models.ROLE_VIEWER) {
+		return nil
+	}
+	projectNames := make([]string, 0, len(g.projects))
+	for projectName := range g.projects {
+		projectNames = append(projectNames, projectName)
+	}
+	return projectNames
+}
+
+// ConnectionIds returns the connections of the plugin visible to the subject, nil if all of them are
+func (g *RbacGrants) ConnectionIds(pluginName string) []string {
+	if isRoleSufficient(g.global, models.ROLE_VIEWER) {
+		return nil
+	}
+	connectionIds := make([]string, 0)
+	prefix := rbacConnectionKey(pluginName, "")
+	for key := range g.connections {
+		if strings.HasPrefix(key, prefix) {
+			connectionIds = append(connectionIds, strings.TrimPrefix(key, prefix))
+		}
+	}
+	return connectionIds
+}
+
+// RestrictToProjects returns the grants limited to the given projects, used for the api keys scoped to some projects.
+// Admins are reduced to maintainers of these projects
+func (g *RbacGrants) RestrictToProjects(projectNames []string) *RbacGrants {
+	restricted := NewRbacGrants(nil)
+	for _, projectName := range projectNames {
+		role := higherRole(g.global, g.projects[projectName])
+		if role == models.ROLE_ADMIN {
+			role = models.ROLE_MAINTAINER
+		}
+		if role != "" {
+			restricted.projects[projectName] = role
+		}
+	}
+	return restricted
+}
+
+// GetBlueprintProjectName returns the project of the blueprint, empty if the blueprint belongs to no project
+func GetBlueprintProjectName(blueprintId uint64) (string, errors.Error) {
+	blueprint := &models.Blueprint{}
+	err := db.First(blueprint, dal.Select("project_name"), dal.Where("id = ?", blueprintId))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return "", nil
+		}
+		return "", errors.Default.Wrap(err, "error getting the blueprint from database")
+	}
+	return blueprint.ProjectName, nil
+}
+
+// GetPipelineProjectName returns the project of the blueprint which created the pipeline
+func GetPipelineProjectName(pipelineId uint64) (string, errors.Error) {
+	pipeline := &models.Pipeline{}
+	err := db.First(pipeline, dal.Select("blueprint_id"), dal.Where("id = ?", pipelineId))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return "", nil
+		}
+		return "", errors.Default.Wrap(err, "error getting the pipeline from database")
+	}
+	if pipeline.BlueprintId == 0 {
+		return "", nil
+	}
+	return GetBlueprintProjectName(pipeline.BlueprintId)
+}
+
+// GetTaskProjectName returns the project of the blueprint which created the pipeline of the task
+func GetTaskProjectName(taskId uint64) (string, errors.Error) {
+	task := &models.Task{}
+	err := db.First(task, dal.Select("pipeline_id"), dal.Where("id = ?", taskId))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return "", nil
+		}
+		return "", errors.Default.Wrap(err, "error getting the task from database")
+	}
+	return GetPipelineProjectName(task.PipelineId)
+}
+
+func isRbacAdmin(subject string) bool {
+	for _, admin := range strings.Split(cfg.GetString("RBAC_ADMINS"), ",") {
+		if strings.TrimSpace(admin) == subject {
+			return true
+		}
+	}
+	return false
+}
+
+func isRoleSufficient(granted string, required string) bool {
+	return granted != "" && rbacRoleRanks[granted] >= rbacRoleRanks[required]
+}
+
+func higherRole(a, b string) string {
+	if rbacRoleRanks[b] > rbacRoleRanks[a] {
+		return b
+	}
+	return a
+}
+
+func rbacConnectionKey(pluginName string, connectionId string) string {
+	return pluginName + ":" + connectionId
+}
@@ -0,0 +1,77 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRbacGrants(t *testing.T) {
+	grants := NewRbacGrants([]*models.RoleBinding{
+		{Role: models.ROLE_VIEWER, ResourceType: models.RBAC_RESOURCE_PROJECT, ResourceId: "team-a"},
+		{Role: models.ROLE_MAINTAINER, ResourceType: models.RBAC_RESOURCE_PROJECT, ResourceId: "team-a"},
+		{Role: models.ROLE_VIEWER, ResourceType: models.RBAC_RESOURCE_PROJECT, ResourceId: "team-b"},
+		{Role: models.ROLE_MAINTAINER, ResourceType: models.RBAC_RESOURCE_CONNECTION, Plugin: "github", ResourceId: "1"},
+	})
+	assert.False(t, grants.IsAdmin())
+	assert.True(t, grants.HasAnyRole())
+	assert.True(t, grants.HasProjectRole("team-a", models.ROLE_MAINTAINER))
+	assert.True(t, grants.HasProjectRole("team-b", models.ROLE_VIEWER))
+	assert.False(t, grants.HasProjectRole("team-b", models.ROLE_MAINTAINER))
+	assert.False(t, grants.HasProjectRole("team-c", models.ROLE_VIEWER))
+	assert.True(t, grants.HasConnectionRole("github", "1", models.ROLE_MAINTAINER))
+	assert.False(t, grants.HasConnectionRole("gitlab", "1", models.ROLE_VIEWER))
+	assert.ElementsMatch(t, []string{"team-a", "team-b"}, grants.ProjectNames())
+	assert.Equal(t, []string{"1"}, grants.ConnectionIds("github"))
+	assert.Empty(t, grants.ConnectionIds("gitlab"))
+	assert.NotNil(t, grants.ConnectionIds("gitlab"))
+
+	grants = NewRbacGrants([]*models.RoleBinding{
+		{Role: models.ROLE_VIEWER, ResourceType: models.RBAC_RESOURCE_GLOBAL},
+	})
+	assert.True(t, grants.HasProjectRole("team-c", models.ROLE_VIEWER))
+	assert.False(t, grants.HasProjectRole("team-c", models.ROLE_MAINTAINER))
+	assert.Nil(t, grants.ProjectNames())
+	assert.Nil(t, grants.ConnectionIds("github"))
+
+	grants = NewRbacGrants(nil)
+	assert.False(t, grants.HasAnyRole())
+	assert.Empty(t, grants.ProjectNames())
+}
+
+func TestRbacGrantsRestrictToProjects(t *testing.T) {
+	admin := NewRbacGrants([]*models.RoleBinding{
+		{Role: models.ROLE_ADMIN, ResourceType: models.RBAC_RESOURCE_GLOBAL},
+	})
+	grants := admin.RestrictToProjects([]string{"team-a"})
+	assert.False(t, grants.IsAdmin())
+	assert.True(t, grants.HasProjectRole("team-a", models.ROLE_MAINTAINER))
+	assert.False(t, grants.HasProjectRole("team-b", models.ROLE_VIEWER))
+	assert.Empty(t, grants.ConnectionIds("github"))
+
+	viewer := NewRbacGrants([]*models.RoleBinding{
+		{Role: models.ROLE_VIEWER, ResourceType: models.RBAC_RESOURCE_PROJECT, ResourceId: "team-a"},
+	})
+	grants = viewer.RestrictToProjects([]string{"team-a", "team-b"})
+	assert.True(t, grants.HasProjectRole("team-a", models.ROLE_VIEWER))
+	assert.False(t, grants.HasProjectRole("team-a", models.ROLE_MAINTAINER))
+	assert.Equal(t, []string{"team-a"}, grants.ProjectNames())
+}
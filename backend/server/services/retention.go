@@ -0,0 +1,316 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/dbhelper"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/impls/logruslog"
+)
+
+var retentionLog = logruslog.Global.Nested("retention")
+
+// RetentionPruneResult tells how many rows were pruned from a table
+type RetentionPruneResult struct {
+	Table      string `json:"table"`
+	Plugin     string `json:"plugin"`
+	TableClass string `json:"tableClass"`
+	Days       int    `json:"days"`
+	Rows       int64  `json:"rows"`
+}
+
+func retentionInit() {
+	interval, e := time.ParseDuration(cfg.GetString("RETENTION_JANITOR_INTERVAL"))
+	if e != nil || interval <= 0 {
+		interval = time.Hour
+	}
+	go runRetentionJanitor(interval)
+}
+
+func runRetentionJanitor(interval time.Duration) {
+	for {
+		time.Sleep(interval)
+		_, err := PruneExpiredData()
+		if err != nil && err.GetType() == errors.Conflict {
+			retentionLog.Info("skip pruning, %s", err.Messages().Format())
+		} else if err != nil {
+			retentionLog.Error(err, "failed to prune the expired data")
+		}
+	}
+}
+
+// GetRetentionPolicies returns all the retention policies
+func GetRetentionPolicies() ([]*models.RetentionPolicy, errors.Error) {
+	policies := make([]*models.RetentionPolicy, 0)
+	err := db.All(&policies, dal.Orderby("plugin, table_class"))
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error finding DB retention policies")
+	}
+	return policies, nil
+}
+
+// PutRetentionPolicy creates or updates the retention policy of the plugin and table class
+func PutRetentionPolicy(policy *models.RetentionPolicy) (*models.RetentionPolicy, errors.Error) {
+	if err := VerifyStruct(policy); err != nil {
+		return nil, err
+	}
+	existing := &models.RetentionPolicy{}
+	err := db.First(existing, dal.Where("plugin = ? AND table_class = ?", policy.Plugin, policy.TableClass))
+	if err != nil && !db.IsErrorNotFound(err) {
+		return nil, errors.Default.Wrap(err, "error getting the retention policy from database")
+	}
+	if err == nil {
+		existing.Days = policy.Days
+		err = db.Update(existing)
+	} else {
+		existing = &models.RetentionPolicy{Plugin: policy.Plugin, TableClass: policy.TableClass, Days: policy.Days}
+		err = db.Create(existing)
+	}
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error saving the retention policy")
+	}
+	return existing, nil
+}
+
+// DeleteRetentionPolicy deletes the retention policy with the given id
+func DeleteRetentionPolicy(id uint64) errors.Error {
+	err := db.Delete(&models.RetentionPolicy{}, dal.Where("id = ?", id))
+	if err != nil {
+		return errors.Default.Wrap(err, "error deleting the retention policy")
+	}
+	return nil
+}
+
+// retentionLockTimeout is how long to wait for the instance pruning the expired data to finish
+var retentionLockTimeout = 2 * time.Second
+
+// PruneExpiredData deletes the rows of the raw layer tables which were created before their retention, the collector
+// states of the pruned tables are reset so the next collection is a full one instead of resuming from the pruned
+// rows. The tool layer tables are derived from the raw ones and are never pruned. The policies stay locked while
+// pruning, so the instances sharing the database don't prune at the same time
+func PruneExpiredData() (results []*RetentionPruneResult, err errors.Error) {
+	txHelper := dbhelper.NewTxHelper(basicRes, &err)
+	defer txHelper.End()
+	tx := txHelper.Begin()
+	if lockErr := txHelper.LockTablesTimeout(retentionLockTimeout, dal.LockTables{{Table: "_devlake_retention_policies", Exclusive: true}}); lockErr != nil {
+		err = errors.Conflict.Wrap(lockErr, "the expired data is being pruned by another instance")
+		return nil, err
+	}
+	pluginNames, protectedTables := retentionPluginTables()
+	results, err = pruneExpiredTables(db, tx, pluginNames, protectedTables)
+	if err != nil {
+		return nil, err
+	}
+	// the retries of the pruned deliveries are no longer recognized and the failed ones can no longer be replayed
+	if result := pruneStaleRows(db, &models.WebhookDelivery{}, retentionDays("WEBHOOK_DELIVERY_RETENTION_DAYS")); result != nil {
+		results = append(results, result)
+	}
+	// the urls not requested for a while are mostly gone from the source, they would pile up forever
+	if result := pruneStaleRows(db, &models.ApiHttpCache{}, retentionDays("API_HTTP_CACHE_RETENTION_DAYS")); result != nil {
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// retentionDays reads the retention of the bookkeeping table from the config, 30 if not set
+func retentionDays(key string) int {
+	if cfg.IsSet(key) {
+		return cfg.GetInt(key)
+	}
+	return 30
+}
+
+// pruneStaleRows deletes the rows of the bookkeeping table last updated before the retention, it returns nil if they
+// are kept forever
+func pruneStaleRows(db dal.Dal, model dal.Tabler, days int) *RetentionPruneResult {
+	if days <= 0 {
+		return nil
+	}
+	table := model.TableName()
+	condition := dal.Where("updated_at < ?", time.Now().AddDate(0, 0, -days))
+	rows, err := db.Count(dal.From(table), condition)
+	if err == nil && rows > 0 {
+		err = db.Delete(model, condition)
+	}
+	if err != nil {
+		retentionLog.Error(err, "failed to prune %s", table)
+		return nil
+	}
+	if rows > 0 {
+		retentionLog.Info("pruned %d rows older than %d days from %s", rows, days, table)
+	}
+	return &RetentionPruneResult{Table: table, Days: days, Rows: rows}
+}
+
+// pruneExpiredTables prunes the raw tables through db, the policies are read and updated through the locking tx
+func pruneExpiredTables(db dal.Dal, tx dal.Dal, pluginNames []string, protectedTables map[string]bool) ([]*RetentionPruneResult, errors.Error) {
+	policies := make([]*models.RetentionPolicy, 0)
+	err := tx.All(&policies, dal.Where("table_class = ?", models.RETENTION_TABLE_CLASS_RAW))
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error finding DB retention policies")
+	}
+	tables, err := db.AllTables()
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*RetentionPruneResult, 0)
+	prunedRows := make(map[*models.RetentionPolicy]int64)
+	for _, table := range tables {
+		if protectedTables[table] {
+			continue
+		}
+		tableClass, pluginName := classifyRetentionTable(table, pluginNames)
+		if tableClass == "" {
+			continue
+		}
+		days, policy := resolveRetentionDays(policies, pluginName, tableClass)
+		if days <= 0 || !db.HasColumn(table, "created_at") {
+			continue
+		}
+		rows, err := pruneRawTable(db, table, time.Now().AddDate(0, 0, -days))
+		if err != nil {
+			retentionLog.Error(err, "failed to prune %s", table)
+			continue
+		}
+		if policy != nil {
+			prunedRows[policy] += rows
+		}
+		if rows > 0 {
+			retentionLog.Info("pruned %d rows older than %d days from %s", rows, days, table)
+		}
+		results = append(results, &RetentionPruneResult{
+			Table:      table,
+			Plugin:     pluginName,
+			TableClass: tableClass,
+			Days:       days,
+			Rows:       rows,
+		})
+	}
+	now := time.Now()
+	for policy, rows := range prunedRows {
+		policy.LastPrunedAt = &now
+		policy.LastPrunedRows = rows
+		if err := tx.Update(policy); err != nil {
+			retentionLog.Error(err, "failed to update the retention policy %d", policy.ID)
+		}
+	}
+	return results, nil
+}
+
+func pruneRawTable(db dal.Dal, table string, cutoff time.Time) (int64, errors.Error) {
+	rows, err := db.Count(dal.From(table), dal.Where("created_at < ?", cutoff))
+	if err != nil || rows == 0 {
+		return 0, err
+	}
+	// the states are reset before pruning, a failure in between only costs a full collection
+	err = db.Delete(&models.CollectorLatestState{}, dal.Where("raw_data_table = ?", table))
+	if err != nil {
+		return 0, errors.Default.Wrap(err, fmt.Sprintf("failed to reset the collector states of %s", table))
+	}
+	// whole monthly partitions are dropped, the rest is deleted row by row
+	_, err = helper.DropExpiredRawPartitions(db, table, cutoff)
+	if err != nil {
+		return 0, err
+	}
+	err = db.Exec(fmt.Sprintf("DELETE FROM %s WHERE created_at < ?", table), cutoff)
+	if err != nil {
+		return 0, err
+	}
+	return rows, nil
+}
+
+// retentionPluginTables returns the names of the plugins, the longest first, and the tables holding their
+// configuration
+func retentionPluginTables() ([]string, map[string]bool) {
+	pluginNames := make([]string, 0)
+	protectedTables := make(map[string]bool)
+	for pluginName, pluginMeta := range plugin.AllPlugins() {
+		pluginNames = append(pluginNames, pluginName)
+		if source, ok := pluginMeta.(plugin.PluginSource); ok {
+			if connection := source.Connection(); connection != nil {
+				protectedTables[connection.TableName()] = true
+			}
+			if scope := source.Scope(); scope != nil {
+				protectedTables[scope.TableName()] = true
+			}
+			if scopeConfig := source.ScopeConfig(); scopeConfig != nil {
+				protectedTables[scopeConfig.TableName()] = true
+			}
+		}
+	}
+	sort.Slice(pluginNames, func(i, j int) bool {
+		return len(pluginNames[i]) > len(pluginNames[j])
+	})
+	return pluginNames, protectedTables
+}
+
+// classifyRetentionTable tells whether the table belongs to the raw layer, and which plugin owns it
+func classifyRetentionTable(table string, pluginNames []string) (tableClass string, pluginName string) {
+	if !strings.HasPrefix(table, "_raw_") {
+		return "", ""
+	}
+	rest := strings.TrimPrefix(table, "_raw_")
+	for _, name := range pluginNames {
+		if strings.HasPrefix(rest, name+"_") {
+			return models.RETENTION_TABLE_CLASS_RAW, name
+		}
+	}
+	return models.RETENTION_TABLE_CLASS_RAW, ""
+}
+
+// resolveRetentionDays picks the policy of the plugin, then the policy for all plugins, then RAW_RETENTION_DAYS
+func resolveRetentionDays(policies []*models.RetentionPolicy, pluginName string, tableClass string) (int, *models.RetentionPolicy) {
+	var fallback *models.RetentionPolicy
+	for _, policy := range policies {
+		if policy.TableClass != tableClass {
+			continue
+		}
+		if policy.Plugin == pluginName && pluginName != "" {
+			return policy.Days, policy
+		}
+		if policy.Plugin == "" {
+			fallback = policy
+		}
+	}
+	if fallback != nil {
+		return fallback.Days, fallback
+	}
+	return cfg.GetInt("RAW_RETENTION_DAYS"), nil
+}
+
+// PartitionRawTables converts the existing raw tables into the partitioned ones of RAW_TABLE_PARTITION_BY, it is
+// refused while pipelines are running since they may be writing into the tables
+func PartitionRawTables() ([]string, errors.Error) {
+	running, err := db.Count(dal.From(&models.Pipeline{}), dal.Where("status = ?", models.TASK_RUNNING))
+	if err != nil {
+		return nil, err
+	}
+	if running > 0 {
+		return nil, errors.Conflict.New("the raw tables can't be converted while pipelines are running")
+	}
+	return helper.PartitionRawTables(db, retentionLog)
+}
@@ -0,0 +1,165 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockcontext "github.com/apache/incubator-devlake/mocks/core/context"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestClassifyRetentionTable(t *testing.T) {
+	pluginNames := []string{"github_graphql", "github", "jira"}
+	tableClass, pluginName := classifyRetentionTable("_raw_github_api_issues", pluginNames)
+	assert.Equal(t, models.RETENTION_TABLE_CLASS_RAW, tableClass)
+	assert.Equal(t, "github", pluginName)
+	tableClass, pluginName = classifyRetentionTable("_raw_github_graphql_issues", pluginNames)
+	assert.Equal(t, models.RETENTION_TABLE_CLASS_RAW, tableClass)
+	assert.Equal(t, "github_graphql", pluginName)
+	tableClass, pluginName = classifyRetentionTable("_raw_unknown_things", pluginNames)
+	assert.Equal(t, models.RETENTION_TABLE_CLASS_RAW, tableClass)
+	assert.Empty(t, pluginName)
+	// the tool layer is never pruned
+	tableClass, _ = classifyRetentionTable("_tool_jira_issues", pluginNames)
+	assert.Empty(t, tableClass)
+	tableClass, _ = classifyRetentionTable("issues", pluginNames)
+	assert.Empty(t, tableClass)
+}
+
+func TestResolveRetentionDays(t *testing.T) {
+	all := &models.RetentionPolicy{TableClass: models.RETENTION_TABLE_CLASS_RAW, Days: 90}
+	github := &models.RetentionPolicy{Plugin: "github", TableClass: models.RETENTION_TABLE_CLASS_RAW, Days: 30}
+	policies := []*models.RetentionPolicy{all, github}
+
+	days, policy := resolveRetentionDays(policies, "github", models.RETENTION_TABLE_CLASS_RAW)
+	assert.Equal(t, 30, days)
+	assert.Equal(t, github, policy)
+	days, policy = resolveRetentionDays(policies, "jira", models.RETENTION_TABLE_CLASS_RAW)
+	assert.Equal(t, 90, days)
+	assert.Equal(t, all, policy)
+}
+
+func fromTable(table string) interface{} {
+	return mock.MatchedBy(func(clauses []dal.Clause) bool {
+		return len(clauses) > 0 && clauses[0].Type == dal.FromClause && clauses[0].Data == table
+	})
+}
+
+func TestPruneExpiredTables(t *testing.T) {
+	policy := &models.RetentionPolicy{TableClass: models.RETENTION_TABLE_CLASS_RAW, Days: 30}
+	mockTx := new(mockdal.Dal)
+	mockTx.On("All", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(0).(*[]*models.RetentionPolicy) = []*models.RetentionPolicy{policy}
+	}).Return(nil).Once()
+	mockTx.On("Update", policy, mock.Anything).Return(nil).Once()
+
+	mockDal := new(mockdal.Dal)
+	mockDal.On("AllTables").Return([]string{
+		"_raw_github_api_issues",
+		"_raw_github_api_pull_requests",
+		"_raw_github_scopes",
+		"_tool_github_issues",
+		"issues",
+	}, nil).Once()
+	mockDal.On("HasColumn", mock.Anything, "created_at").Return(true)
+	mockDal.On("Dialect").Return("mysql")
+	// the expired issues are pruned along with the collector states of their table
+	mockDal.On("Count", fromTable("_raw_github_api_issues")).Return(int64(3), nil).Once()
+	mockDal.On("Delete", &models.CollectorLatestState{}, []dal.Clause{dal.Where("raw_data_table = ?", "_raw_github_api_issues")}).Return(nil).Once()
+	mockDal.On("Exec", "DELETE FROM _raw_github_api_issues WHERE created_at < ?", mock.Anything).Return(nil).Once()
+	// nothing expired, the collector states are kept
+	mockDal.On("Count", fromTable("_raw_github_api_pull_requests")).Return(int64(0), nil).Once()
+
+	results, err := pruneExpiredTables(mockDal, mockTx, []string{"github"}, map[string]bool{"_raw_github_scopes": true})
+	assert.Nil(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, "_raw_github_api_issues", results[0].Table)
+	assert.Equal(t, int64(3), results[0].Rows)
+	assert.Equal(t, "github", results[0].Plugin)
+	assert.Equal(t, "_raw_github_api_pull_requests", results[1].Table)
+	assert.Equal(t, int64(0), results[1].Rows)
+	assert.Equal(t, int64(3), policy.LastPrunedRows)
+	assert.NotNil(t, policy.LastPrunedAt)
+	mockDal.AssertExpectations(t)
+	mockTx.AssertExpectations(t)
+	mockDal.AssertNotCalled(t, "Count", fromTable("_tool_github_issues"))
+	mockDal.AssertNotCalled(t, "Count", fromTable("_raw_github_scopes"))
+}
+
+func TestPruneRawTableKeepsRowsWhenStatesNotReset(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	mockDal.On("Count", fromTable("_raw_jira_api_issues")).Return(int64(5), nil).Once()
+	mockDal.On("Delete", &models.CollectorLatestState{}, mock.Anything).Return(errors.Default.New("gone")).Once()
+
+	rows, err := pruneRawTable(mockDal, "_raw_jira_api_issues", time.Now())
+	assert.NotNil(t, err)
+	assert.Equal(t, int64(0), rows)
+	mockDal.AssertNotCalled(t, "Exec", mock.Anything, mock.Anything)
+}
+
+func TestPruneExpiredDataLocked(t *testing.T) {
+	originalBasicRes, originalTimeout := basicRes, retentionLockTimeout
+	defer func() {
+		basicRes, retentionLockTimeout = originalBasicRes, originalTimeout
+	}()
+	retentionLockTimeout = 10 * time.Millisecond
+
+	// another instance holds the lock of the policies
+	mockTx := new(mockdal.Transaction)
+	mockTx.On("LockTables", dal.LockTables{{Table: "_devlake_retention_policies", Exclusive: true}}).After(time.Second).Return(nil).Once()
+	mockTx.On("UnlockTables").Return(nil).Once()
+	mockTx.On("Rollback").Return(nil).Once()
+	mockDal := new(mockdal.Dal)
+	mockDal.On("Begin").Return(mockTx).Once()
+	mockRes := new(mockcontext.BasicRes)
+	mockRes.On("GetDal").Return(mockDal)
+	mockRes.On("GetLogger").Return(unithelper.DummyLogger())
+	basicRes = mockRes
+
+	results, err := PruneExpiredData()
+	assert.Nil(t, results)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, errors.Conflict, err.GetType())
+	}
+	mockTx.AssertNotCalled(t, "Commit")
+}
+
+func TestPruneStaleRows(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	mockDal.On("Count", fromTable("_devlake_webhook_deliveries")).Return(int64(7), nil).Once()
+	mockDal.On("Delete", &models.WebhookDelivery{}, mock.Anything).Return(nil).Once()
+	result := pruneStaleRows(mockDal, &models.WebhookDelivery{}, 30)
+	assert.Equal(t, &RetentionPruneResult{Table: "_devlake_webhook_deliveries", Days: 30, Rows: 7}, result)
+
+	// nothing stale
+	mockDal.On("Count", fromTable("_devlake_api_http_cache")).Return(int64(0), nil).Once()
+	result = pruneStaleRows(mockDal, &models.ApiHttpCache{}, 7)
+	assert.Equal(t, &RetentionPruneResult{Table: "_devlake_api_http_cache", Days: 7, Rows: 0}, result)
+	mockDal.AssertExpectations(t)
+
+	// kept forever
+	assert.Nil(t, pruneStaleRows(mockDal, &models.WebhookDelivery{}, 0))
+}
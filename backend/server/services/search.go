@@ -0,0 +1,288 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
+// SearchQuery is the keyword to look for, the results of each kind are limited to `limit`
+type SearchQuery struct {
+	Q     string `json:"q" form:"q"`
+	Limit int    `json:"limit" form:"limit"`
+}
+
+// SearchHit is a connection, scope, scope config, blueprint or project matching the keyword
+type SearchHit struct {
+	Plugin       string `json:"plugin,omitempty"`
+	ConnectionId uint64 `json:"connectionId,omitempty"`
+	Id           string `json:"id"`
+	Name         string `json:"name"`
+	FullName     string `json:"fullName,omitempty"`
+	// projects the scope is added to
+	Projects []string `json:"projects,omitempty"`
+}
+
+// SearchResult groups the hits by kind
+type SearchResult struct {
+	Connections  []*SearchHit `json:"connections"`
+	Scopes       []*SearchHit `json:"scopes"`
+	ScopeConfigs []*SearchHit `json:"scopeConfigs"`
+	Blueprints   []*SearchHit `json:"blueprints"`
+	Projects     []*SearchHit `json:"projects"`
+}
+
+type searchRow struct {
+	Id           uint64
+	Name         string
+	ConnectionId uint64
+}
+
+// Search looks for the keyword in the names of the connections, scopes and scope configs of all data source
+// plugins, and in the names of the blueprints and projects, so admins can find where a repo or a board is configured
+func Search(query *SearchQuery) (*SearchResult, errors.Error) {
+	keyword := strings.TrimSpace(query.Q)
+	if keyword == "" {
+		return nil, errors.BadInput.New("q is required")
+	}
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+	pattern := "%" + escapeLikePattern(strings.ToLower(keyword)) + "%"
+	result := &SearchResult{
+		Connections:  []*SearchHit{},
+		Scopes:       []*SearchHit{},
+		ScopeConfigs: []*SearchHit{},
+		Blueprints:   []*SearchHit{},
+		Projects:     []*SearchHit{},
+	}
+	pluginNames := make([]string, 0)
+	for pluginName, pluginMeta := range plugin.AllPlugins() {
+		if _, ok := pluginMeta.(plugin.PluginSource); ok {
+			pluginNames = append(pluginNames, pluginName)
+		}
+	}
+	sort.Strings(pluginNames)
+	for _, pluginName := range pluginNames {
+		source := plugin.AllPlugins()[pluginName].(plugin.PluginSource)
+		// a plugin with broken tables shouldn't fail the whole search
+		if err := searchPluginSource(result, pluginName, source, pattern, limit); err != nil {
+			logger.Warn(err, "failed to search plugin %s", pluginName)
+		}
+	}
+	result.Connections = truncateSearchHits(result.Connections, limit)
+	result.Scopes = truncateSearchHits(result.Scopes, limit)
+	result.ScopeConfigs = truncateSearchHits(result.ScopeConfigs, limit)
+
+	var blueprints []*models.Blueprint
+	err := db.All(&blueprints,
+		dal.Select("id, name, project_name"),
+		dal.Where("LOWER(name) LIKE ? OR LOWER(project_name) LIKE ?", pattern, pattern),
+		dal.Orderby("name"),
+		dal.Limit(limit),
+	)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error searching blueprints")
+	}
+	for _, blueprint := range blueprints {
+		hit := &SearchHit{Id: fmt.Sprintf("%d", blueprint.ID), Name: blueprint.Name}
+		if blueprint.ProjectName != "" {
+			hit.Projects = []string{blueprint.ProjectName}
+		}
+		result.Blueprints = append(result.Blueprints, hit)
+	}
+
+	var projects []*models.Project
+	err = db.All(&projects,
+		dal.Where("LOWER(name) LIKE ? OR LOWER(description) LIKE ?", pattern, pattern),
+		dal.Orderby("name"),
+		dal.Limit(limit),
+	)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error searching projects")
+	}
+	for _, project := range projects {
+		result.Projects = append(result.Projects, &SearchHit{Id: project.Name, Name: project.Name})
+	}
+	return result, nil
+}
+
+func searchPluginSource(result *SearchResult, pluginName string, source plugin.PluginSource, pattern string, limit int) errors.Error {
+	if connection := source.Connection(); connection != nil {
+		var rows []*searchRow
+		err := db.All(&rows,
+			dal.Select("id, name"),
+			dal.From(connection.TableName()),
+			dal.Where("LOWER(name) LIKE ?", pattern),
+			dal.Orderby("name"),
+			dal.Limit(limit),
+		)
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			result.Connections = append(result.Connections, &SearchHit{
+				Plugin:       pluginName,
+				ConnectionId: row.Id,
+				Id:           fmt.Sprintf("%d", row.Id),
+				Name:         row.Name,
+			})
+		}
+	}
+	if scopeConfig := source.ScopeConfig(); scopeConfig != nil {
+		var rows []*searchRow
+		err := db.All(&rows,
+			dal.Select("id, name, connection_id"),
+			dal.From(scopeConfig.TableName()),
+			dal.Where("LOWER(name) LIKE ?", pattern),
+			dal.Orderby("name"),
+			dal.Limit(limit),
+		)
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			result.ScopeConfigs = append(result.ScopeConfigs, &SearchHit{
+				Plugin:       pluginName,
+				ConnectionId: row.ConnectionId,
+				Id:           fmt.Sprintf("%d", row.Id),
+				Name:         row.Name,
+			})
+		}
+	}
+	if scope := source.Scope(); scope != nil {
+		return searchScopes(result, pluginName, scope, pattern, limit)
+	}
+	return nil
+}
+
+// searchScopes loads the matching scopes into their own model, since the name columns vary from plugin to plugin
+func searchScopes(result *SearchResult, pluginName string, scope plugin.ToolLayerScope, pattern string, limit int) errors.Error {
+	columns, err := dal.GetColumnNames(db, scope, func(columnMeta dal.ColumnMeta) bool {
+		name := columnMeta.Name()
+		return name == "name" || name == "full_name"
+	})
+	if err != nil {
+		return err
+	}
+	if len(columns) == 0 {
+		return nil
+	}
+	conditions := make([]string, len(columns))
+	params := make([]interface{}, len(columns))
+	for i, column := range columns {
+		conditions[i] = fmt.Sprintf("LOWER(%s) LIKE ?", column)
+		params[i] = pattern
+	}
+	scopeType := reflect.TypeOf(scope)
+	if scopeType.Kind() != reflect.Ptr {
+		scopeType = reflect.PtrTo(scopeType)
+	}
+	scopes := reflect.New(reflect.SliceOf(scopeType))
+	err = db.All(scopes.Interface(),
+		dal.From(scope.TableName()),
+		dal.Where(strings.Join(conditions, " OR "), params...),
+		dal.Orderby(columns[0]),
+		dal.Limit(limit),
+	)
+	if err != nil {
+		return err
+	}
+	hits := make([]*SearchHit, 0, scopes.Elem().Len())
+	for i := 0; i < scopes.Elem().Len(); i++ {
+		s := scopes.Elem().Index(i).Interface().(plugin.ToolLayerScope)
+		hits = append(hits, &SearchHit{
+			Plugin:       pluginName,
+			ConnectionId: s.ScopeConnectionId(),
+			Id:           s.ScopeId(),
+			Name:         s.ScopeName(),
+			FullName:     s.ScopeFullName(),
+		})
+	}
+	if err := attachScopeProjects(pluginName, hits); err != nil {
+		return err
+	}
+	result.Scopes = append(result.Scopes, hits...)
+	return nil
+}
+
+// attachScopeProjects fills the projects the scopes are added to
+func attachScopeProjects(pluginName string, hits []*SearchHit) errors.Error {
+	if len(hits) == 0 {
+		return nil
+	}
+	scopeIds := make([]string, len(hits))
+	for i, hit := range hits {
+		scopeIds[i] = hit.Id
+	}
+	var refs []*struct {
+		ConnectionId uint64
+		ScopeId      string
+		ProjectName  string
+	}
+	err := db.All(&refs,
+		dal.Select("DISTINCT bps.connection_id, bps.scope_id, bp.project_name"),
+		dal.From("_devlake_blueprint_scopes bps"),
+		dal.Join("JOIN _devlake_blueprints bp ON bp.id = bps.blueprint_id"),
+		dal.Where("bps.plugin_name = ? AND bps.scope_id IN ? AND bp.project_name != ''", pluginName, scopeIds),
+		dal.Orderby("bp.project_name"),
+	)
+	if err != nil {
+		return err
+	}
+	for _, ref := range refs {
+		for _, hit := range hits {
+			if hit.ConnectionId == ref.ConnectionId && hit.Id == ref.ScopeId {
+				hit.Projects = append(hit.Projects, ref.ProjectName)
+			}
+		}
+	}
+	return nil
+}
+
+func truncateSearchHits(hits []*SearchHit, limit int) []*SearchHit {
+	sort.SliceStable(hits, func(i, j int) bool {
+		return strings.ToLower(hits[i].Name) < strings.ToLower(hits[j].Name)
+	})
+	if len(hits) > limit {
+		return hits[:limit]
+	}
+	return hits
+}
+
+// escapeLikePattern escapes the wildcards of LIKE, so searching `my_repo` doesn't match `my-repo`
+func escapeLikePattern(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
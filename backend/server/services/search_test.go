@@ -0,0 +1,44 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEscapeLikePattern(t *testing.T) {
+	assert.Equal(t, `my\_repo`, escapeLikePattern("my_repo"))
+	assert.Equal(t, `100\%`, escapeLikePattern("100%"))
+	assert.Equal(t, `a\\b`, escapeLikePattern(`a\b`))
+	assert.Equal(t, "devlake", escapeLikePattern("devlake"))
+}
+
+func TestTruncateSearchHits(t *testing.T) {
+	hits := []*SearchHit{
+		{Plugin: "jira", Name: "devlake"},
+		{Plugin: "github", Name: "apache/incubator-devlake"},
+		{Plugin: "gitlab", Name: "Devlake-ui"},
+	}
+	assert.Equal(t, []*SearchHit{
+		{Plugin: "github", Name: "apache/incubator-devlake"},
+		{Plugin: "jira", Name: "devlake"},
+	}, truncateSearchHits(hits, 2))
+	assert.Empty(t, truncateSearchHits([]*SearchHit{}, 2))
+}
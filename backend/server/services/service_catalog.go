@@ -0,0 +1,194 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"golang.org/x/exp/slices"
+)
+
+// ServiceQuery used to query services of the catalog
+type ServiceQuery struct {
+	Pagination
+	Keyword *string `json:"keyword" form:"keyword"`
+}
+
+// ServicePatch holds the updatable fields of a service
+type ServicePatch struct {
+	Description *string `json:"description"`
+	TeamId      *string `json:"teamId"`
+}
+
+// ServiceMappingInput maps a row of a domain table onto a service
+type ServiceMappingInput struct {
+	Table string `json:"table" validate:"required"`
+	RowId string `json:"rowId" validate:"required"`
+}
+
+// GetServices returns a paginated list of services based on `query`
+func GetServices(query *ServiceQuery) ([]*crossdomain.Service, int64, errors.Error) {
+	clauses := []dal.Clause{
+		dal.From(&crossdomain.Service{}),
+	}
+	if query.Keyword != nil {
+		clauses = append(clauses, dal.Where("LOWER(name) LIKE ?", "%"+strings.ToLower(*query.Keyword)+"%"))
+	}
+	count, err := db.Count(clauses...)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error getting DB count of services")
+	}
+	clauses = append(clauses,
+		dal.Orderby("name ASC"),
+		dal.Offset(query.GetSkip()),
+		dal.Limit(query.GetPageSize()),
+	)
+	services := make([]*crossdomain.Service, 0)
+	err = db.All(&services, clauses...)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error finding DB services")
+	}
+	return services, count, nil
+}
+
+// GetService returns the service with the given name
+func GetService(name string) (*crossdomain.Service, errors.Error) {
+	service := &crossdomain.Service{}
+	err := db.First(service, dal.Where("name = ?", name))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return nil, errors.NotFound.New(fmt.Sprintf("could not find service [%s]", name))
+		}
+		return nil, errors.Default.Wrap(err, "error finding DB service")
+	}
+	return service, nil
+}
+
+// CreateService adds a service to the catalog
+func CreateService(service *crossdomain.Service) (*crossdomain.Service, errors.Error) {
+	if err := VerifyStruct(service); err != nil {
+		return nil, err
+	}
+	err := db.Create(service)
+	if err != nil {
+		if db.IsDuplicationError(err) {
+			return nil, errors.BadInput.New(fmt.Sprintf("A service with name [%s] already exists", service.Name))
+		}
+		return nil, errors.Default.Wrap(err, "error creating DB service")
+	}
+	return service, nil
+}
+
+// PatchService updates the description and the owning team of a service
+func PatchService(name string, patch *ServicePatch) (*crossdomain.Service, errors.Error) {
+	service, err := GetService(name)
+	if err != nil {
+		return nil, err
+	}
+	if patch.Description != nil {
+		service.Description = *patch.Description
+	}
+	if patch.TeamId != nil {
+		service.TeamId = *patch.TeamId
+	}
+	err = db.Update(service)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error updating DB service")
+	}
+	return service, nil
+}
+
+// DeleteService removes a service and its mappings
+func DeleteService(name string) (err errors.Error) {
+	if _, err = GetService(name); err != nil {
+		return err
+	}
+	tx := db.Begin()
+	defer func() {
+		if r := recover(); r != nil || err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				logger.Error(rollbackErr, "DeleteService: failed to rollback")
+			}
+		}
+	}()
+	err = tx.Delete(&crossdomain.ServiceMapping{}, dal.Where("service_name = ?", name))
+	if err != nil {
+		return errors.Default.Wrap(err, "error deleting service mappings")
+	}
+	err = tx.Delete(&crossdomain.Service{}, dal.Where("name = ?", name))
+	if err != nil {
+		return errors.Default.Wrap(err, "error deleting service")
+	}
+	return tx.Commit()
+}
+
+// GetServiceMappings returns the rows mapped onto a service
+func GetServiceMappings(name string) ([]*crossdomain.ServiceMapping, errors.Error) {
+	if _, err := GetService(name); err != nil {
+		return nil, err
+	}
+	mappings := make([]*crossdomain.ServiceMapping, 0)
+	err := db.All(&mappings, dal.Where("service_name = ?", name), dal.Orderby("service_mapping.table, service_mapping.row_id"))
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error finding DB service mappings")
+	}
+	return mappings, nil
+}
+
+// PutServiceMappings replaces the rows mapped onto a service
+func PutServiceMappings(name string, inputs []*ServiceMappingInput) (mappings []*crossdomain.ServiceMapping, err errors.Error) {
+	if _, err = GetService(name); err != nil {
+		return nil, err
+	}
+	for _, input := range inputs {
+		if err = VerifyStruct(input); err != nil {
+			return nil, err
+		}
+		if !slices.Contains(crossdomain.ServiceMappingTables, input.Table) {
+			return nil, errors.BadInput.New(fmt.Sprintf("services can not be mapped onto table [%s], supported tables are %v", input.Table, crossdomain.ServiceMappingTables))
+		}
+		mappings = append(mappings, &crossdomain.ServiceMapping{
+			ServiceName: name,
+			Table:       input.Table,
+			RowId:       input.RowId,
+		})
+	}
+	tx := db.Begin()
+	defer func() {
+		if r := recover(); r != nil || err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				logger.Error(rollbackErr, "PutServiceMappings: failed to rollback")
+			}
+		}
+	}()
+	err = tx.Delete(&crossdomain.ServiceMapping{}, dal.Where("service_name = ?", name))
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error deleting service mappings")
+	}
+	if len(mappings) > 0 {
+		err = tx.CreateOrUpdate(mappings)
+		if err != nil {
+			return nil, errors.Default.Wrap(err, "error saving service mappings")
+		}
+	}
+	return mappings, tx.Commit()
+}
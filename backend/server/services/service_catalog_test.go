@@ -0,0 +1,103 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func mockServiceCatalogDal(t *testing.T) *mockdal.Dal {
+	mockDal := new(mockdal.Dal)
+	mockDal.On("First", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(0).(*crossdomain.Service).Name = "checkout"
+	}).Return(nil)
+	originalDb, originalVld := db, vld
+	t.Cleanup(func() { db, vld = originalDb, originalVld })
+	db, vld = mockDal, validator.New()
+	return mockDal
+}
+
+func TestPutServiceMappingsReplacesTheMappings(t *testing.T) {
+	mockDal := mockServiceCatalogDal(t)
+	mockTx := new(mockdal.Transaction)
+	mockDal.On("Begin").Return(mockTx).Once()
+	mockTx.On("Delete", &crossdomain.ServiceMapping{}, []dal.Clause{dal.Where("service_name = ?", "checkout")}).Return(nil).Once()
+	mockTx.On("CreateOrUpdate", mock.Anything, mock.Anything).Return(nil).Once()
+	mockTx.On("Commit").Return(nil).Once()
+
+	mappings, err := PutServiceMappings("checkout", []*ServiceMappingInput{
+		{Table: crossdomain.SERVICE_MAPPING_REPOS, RowId: "github:GithubRepo:1:1"},
+		{Table: crossdomain.SERVICE_MAPPING_ENVIRONMENTS, RowId: "PRODUCTION"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []*crossdomain.ServiceMapping{
+		{ServiceName: "checkout", Table: crossdomain.SERVICE_MAPPING_REPOS, RowId: "github:GithubRepo:1:1"},
+		{ServiceName: "checkout", Table: crossdomain.SERVICE_MAPPING_ENVIRONMENTS, RowId: "PRODUCTION"},
+	}, mappings)
+	mockTx.AssertCalled(t, "CreateOrUpdate", mappings, []dal.Clause(nil))
+	mockTx.AssertExpectations(t)
+}
+
+func TestPutServiceMappingsClearsTheMappings(t *testing.T) {
+	mockDal := mockServiceCatalogDal(t)
+	mockTx := new(mockdal.Transaction)
+	mockDal.On("Begin").Return(mockTx).Once()
+	mockTx.On("Delete", &crossdomain.ServiceMapping{}, mock.Anything).Return(nil).Once()
+	mockTx.On("Commit").Return(nil).Once()
+
+	mappings, err := PutServiceMappings("checkout", nil)
+	assert.Nil(t, err)
+	assert.Empty(t, mappings)
+	mockTx.AssertNotCalled(t, "CreateOrUpdate", mock.Anything, mock.Anything)
+}
+
+func TestPutServiceMappingsRejectsBadInput(t *testing.T) {
+	for name, input := range map[string]*ServiceMappingInput{
+		"unsupported table": {Table: "issues", RowId: "jira:JiraIssue:1:1"},
+		"missing row id":    {Table: crossdomain.SERVICE_MAPPING_BOARDS},
+	} {
+		mockDal := mockServiceCatalogDal(t)
+		_, err := PutServiceMappings("checkout", []*ServiceMappingInput{input})
+		if assert.NotNil(t, err, name) {
+			assert.Equal(t, errors.BadInput, err.GetType(), name)
+		}
+		mockDal.AssertNotCalled(t, "Begin")
+	}
+}
+
+func TestGetServiceNotFound(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	notFound := errors.NotFound.New("record not found")
+	mockDal.On("First", mock.Anything, mock.Anything).Return(notFound).Once()
+	mockDal.On("IsErrorNotFound", notFound).Return(true).Once()
+	defer func(d dal.Dal) { db = d }(db)
+	db = mockDal
+
+	_, err := GetService("unknown")
+	if assert.NotNil(t, err) {
+		assert.Equal(t, errors.NotFound, err.GetType())
+	}
+}
@@ -27,6 +27,8 @@ import (
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/log"
 	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/core/runner"
 	"github.com/apache/incubator-devlake/impls/logruslog"
 )
 
@@ -46,11 +48,16 @@ func createTask(newTask *models.NewTask, tx dal.Transaction) (*models.Task, erro
 		Plugin:      newTask.Plugin,
 		Subtasks:    newTask.Subtasks,
 		Options:     newTask.Options,
+		RetryPolicy: newTask.RetryPolicy,
+		Timeout:     newTask.Timeout,
+		SyncPolicy:  newTask.SyncPolicy,
 		Status:      models.TASK_CREATED,
 		Message:     "",
 		PipelineId:  newTask.PipelineId,
 		PipelineRow: newTask.PipelineRow,
 		PipelineCol: newTask.PipelineCol,
+
+		StartDelaySeconds: newTask.StartDelaySeconds,
 	}
 	if newTask.IsRerun {
 		task.Status = models.TASK_RERUN
@@ -167,6 +174,12 @@ func GetTask(taskId uint64) (*models.Task, errors.Error) {
 // CancelTask FIXME ...
 func CancelTask(taskId uint64) errors.Error {
 	cancel, err := runningTasks.Remove(taskId)
+	if err != nil && distributedMode {
+		// the task may be queued or run by another worker, which cancels it on its next heartbeat
+		return db.UpdateColumns(&models.Task{}, []dal.DalSet{
+			{ColumnName: "status", Value: models.TASK_CANCELLED},
+		}, dal.Where("id = ? AND status IN ?", taskId, []string{models.TASK_QUEUED, models.TASK_RUNNING}))
+	}
 	if err != nil {
 		return err
 	}
@@ -205,6 +218,14 @@ func RunTasksStandalone(parentLogger log.Logger, taskIds []uint64) errors.Error
 		}
 	}
 	if len(errs) > 0 {
+		// the stage is paused only if all failed tasks were paused
+		paused := true
+		for _, e := range errs {
+			paused = paused && errors.Is(e, runner.ErrTaskPaused)
+		}
+		if paused {
+			return errors.Convert(errs[0])
+		}
 		var sb strings.Builder
 		for _, e := range errs {
 			_, _ = sb.WriteString(e.Error())
@@ -238,6 +259,44 @@ func RerunTask(taskId uint64) (*models.Task, errors.Error) {
 	return rerunTask, nil
 }
 
+// RerunSubtask reruns a single subtask of the task, the raw data collected by the other subtasks is kept
+// so e.g. a failed converter can be rerun without collecting again
+func RerunSubtask(pipelineId uint64, taskId uint64, subtaskName string) (*models.Task, errors.Error) {
+	task, err := GetTask(taskId)
+	if err != nil {
+		return nil, err
+	}
+	if task.PipelineId != pipelineId {
+		return nil, errors.BadInput.New("the task ID and pipeline ID doesn't match")
+	}
+	pluginMeta, err := plugin.GetPlugin(task.Plugin)
+	if err != nil {
+		return nil, err
+	}
+	pluginTask, ok := pluginMeta.(plugin.PluginTask)
+	if !ok {
+		return nil, errors.BadInput.New(fmt.Sprintf("plugin %s doesn't support PluginTask interface", task.Plugin))
+	}
+	found := false
+	for _, subtaskMeta := range pluginTask.SubTaskMetas() {
+		found = found || subtaskMeta.Name == subtaskName
+	}
+	if !found {
+		return nil, errors.NotFound.New(fmt.Sprintf("subtask %s does not exist in plugin %s", subtaskName, task.Plugin))
+	}
+	rerunTasks, err := rerunPipeline(pipelineId, task, []string{subtaskName})
+	if err != nil {
+		return nil, err
+	}
+	rerunTask := rerunTasks[0]
+	taskOption, sanitizePluginOptionErr := SanitizePluginOption(rerunTask.Plugin, rerunTask.Options)
+	if sanitizePluginOptionErr != nil {
+		return nil, errors.Convert(sanitizePluginOptionErr)
+	}
+	rerunTask.Options = taskOption
+	return rerunTask, nil
+}
+
 // GetSubTasksInfo returns subtask list of the pipeline, only the most recently subtasks would be returned
 func GetSubTasksInfo(pipelineId uint64, shouldSanitize bool, tx dal.Dal) (*models.SubTasksOuput, errors.Error) {
 	if tx == nil {
@@ -0,0 +1,160 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/log"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/utils"
+	"github.com/apache/incubator-devlake/impls/logruslog"
+)
+
+const (
+	defaultTaskLogsTail = 1000
+	maxTaskLogsTail     = 10000
+)
+
+// TaskLogsQuery selects the lines of a task log
+type TaskLogsQuery struct {
+	// Subtask keeps only the lines logged by the subtask
+	Subtask string `form:"subtask"`
+	// Tail returns the last lines only, 1000 by default and 10000 at most, it is ignored when reading from an Offset
+	Tail int `form:"tail"`
+	// Offset continues reading the log from the Offset returned by the previous call
+	Offset int64 `form:"offset"`
+}
+
+// TaskLogs is a chunk of the log of a task
+type TaskLogs struct {
+	TaskId uint64   `json:"taskId"`
+	Lines  []string `json:"lines"`
+	// Offset to pass to the next call to get the lines logged since this call
+	Offset int64 `json:"offset"`
+	// Finished is true once the task finished, there won't be more lines then
+	Finished bool `json:"finished"`
+}
+
+// GetTaskLogPath returns the task and the path of its log file
+func GetTaskLogPath(pipelineId uint64, taskId uint64) (*models.Task, string, errors.Error) {
+	task, err := GetTask(taskId)
+	if err != nil {
+		return nil, "", err
+	}
+	if task.PipelineId != pipelineId {
+		return nil, "", errors.BadInput.New("the task ID and pipeline ID doesn't match")
+	}
+	pipeline, err := GetPipeline(pipelineId, false)
+	if err != nil {
+		return nil, "", err
+	}
+	logsPath, err := getPipelineLogsPath(pipeline)
+	if err != nil {
+		return nil, "", err
+	}
+	path := logruslog.GetTaskLoggerPath(&log.LoggerConfig{Path: logsPath}, task)
+	if _, statErr := os.Stat(path); statErr != nil {
+		if os.IsNotExist(statErr) {
+			return nil, "", errors.NotFound.New(fmt.Sprintf("logs for task #%d not found, it might not have started yet", taskId))
+		}
+		return nil, "", errors.Convert(statErr)
+	}
+	return task, path, nil
+}
+
+// GetTaskLogs returns the lines of the log of the task selected by the query
+func GetTaskLogs(pipelineId uint64, taskId uint64, query *TaskLogsQuery) (*TaskLogs, errors.Error) {
+	task, path, err := GetTaskLogPath(pipelineId, taskId)
+	if err != nil {
+		return nil, err
+	}
+	// check the status first so that no line logged before the task finished would be missed
+	finished := utils.StringsContains(models.FinishedTaskStatus, task.Status)
+	lines, offset, err := readTaskLogLines(path, query)
+	if err != nil {
+		return nil, err
+	}
+	return &TaskLogs{
+		TaskId:   taskId,
+		Lines:    lines,
+		Offset:   offset,
+		Finished: finished,
+	}, nil
+}
+
+// readTaskLogLines reads the complete lines from the offset of the query, a line still being written is left for
+// the next call, and returns the offset after the last line read
+func readTaskLogLines(path string, query *TaskLogsQuery) ([]string, int64, errors.Error) {
+	file, e := os.Open(filepath.Clean(path))
+	if e != nil {
+		return nil, 0, errors.Convert(e)
+	}
+	defer file.Close()
+	offset := query.Offset
+	if offset > 0 {
+		if _, e = file.Seek(offset, io.SeekStart); e != nil {
+			return nil, 0, errors.Convert(e)
+		}
+	}
+	tail := 0
+	if query.Offset <= 0 {
+		tail = query.Tail
+		if tail <= 0 {
+			tail = defaultTaskLogsTail
+		}
+		if tail > maxTaskLogsTail {
+			tail = maxTaskLogsTail
+		}
+	}
+	subtaskPrefix := ""
+	if query.Subtask != "" {
+		subtaskPrefix = fmt.Sprintf("[%s]", query.Subtask)
+	}
+	lines := make([]string, 0)
+	reader := bufio.NewReader(file)
+	for {
+		line, e := reader.ReadString('\n')
+		if e == io.EOF {
+			break
+		}
+		if e != nil {
+			return nil, 0, errors.Convert(e)
+		}
+		offset += int64(len(line))
+		line = strings.TrimRight(line, "\r\n")
+		if subtaskPrefix != "" && !strings.Contains(line, subtaskPrefix) {
+			continue
+		}
+		lines = append(lines, line)
+		// keep the memory bounded on huge logs
+		if tail > 0 && len(lines) > 2*tail {
+			lines = append(lines[:0], lines[len(lines)-tail:]...)
+		}
+	}
+	if tail > 0 && len(lines) > tail {
+		lines = lines[len(lines)-tail:]
+	}
+	return lines, offset, nil
+}
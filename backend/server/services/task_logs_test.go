@@ -0,0 +1,66 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadTaskLogLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "task-1-1-1-github.log")
+	content := "[pipeline #1] [task #1] start\n" +
+		"[pipeline #1] [task #1] [collectIssues] page 1\n" +
+		"[pipeline #1] [task #1] [extractIssues] 10 issues\n" +
+		"[pipeline #1] [task #1] [collectIssues] page 2\n" +
+		"[pipeline #1] [task #1] [collectIssues] page"
+	assert.Nil(t, os.WriteFile(path, []byte(content), 0600))
+
+	lines, offset, err := readTaskLogLines(path, &TaskLogsQuery{})
+	assert.Nil(t, err)
+	assert.Len(t, lines, 4)
+	// the incomplete last line is left for the next read
+	assert.Equal(t, int64(len(content)-len("[pipeline #1] [task #1] [collectIssues] page")), offset)
+
+	lines, _, err = readTaskLogLines(path, &TaskLogsQuery{Tail: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"[pipeline #1] [task #1] [extractIssues] 10 issues",
+		"[pipeline #1] [task #1] [collectIssues] page 2",
+	}, lines)
+
+	lines, _, err = readTaskLogLines(path, &TaskLogsQuery{Subtask: "collectIssues"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"[pipeline #1] [task #1] [collectIssues] page 1",
+		"[pipeline #1] [task #1] [collectIssues] page 2",
+	}, lines)
+
+	f, e := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	assert.Nil(t, e)
+	_, e = f.WriteString(" 3\n")
+	assert.Nil(t, e)
+	assert.Nil(t, f.Close())
+	lines, next, err := readTaskLogLines(path, &TaskLogsQuery{Offset: offset})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"[pipeline #1] [task #1] [collectIssues] page 3"}, lines)
+	assert.Equal(t, int64(len(content)+3), next)
+}
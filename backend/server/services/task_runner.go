@@ -107,10 +107,15 @@ func runTaskStandalone(parentLog log.Logger, taskId uint64) errors.Error {
 	if err != nil {
 		return err
 	}
+	err = waitTaskStart(ctx, parentLog, taskId)
+	if err != nil {
+		return err
+	}
 	// now , create a progress update channel and kick off
 	progress := make(chan plugin.RunningProgress, 100)
 	doneSignal := make(chan struct{})
-	go updateTaskProgress(doneSignal, taskId, progress)
+	publisher := newTaskEventPublisher(taskId)
+	go updateTaskProgress(doneSignal, taskId, progress, publisher)
 	err = runner.RunTask(
 		ctx,
 		basicRes.ReplaceLogger(parentLog),
@@ -120,6 +125,9 @@ func runTaskStandalone(parentLog log.Logger, taskId uint64) errors.Error {
 	close(progress)
 	// wait all progresses are handled
 	<-doneSignal
+	if data := getRunningTaskById(taskId); data != nil {
+		publisher.finished(data.ProgressDetail, err)
+	}
 	return err
 }
 
@@ -130,7 +138,7 @@ func getRunningTaskById(taskId uint64) *RunningTaskData {
 	return runningTasks.tasks[taskId]
 }
 
-func updateTaskProgress(done chan struct{}, taskId uint64, progress chan plugin.RunningProgress) {
+func updateTaskProgress(done chan struct{}, taskId uint64, progress chan plugin.RunningProgress, publisher *taskEventPublisher) {
 	data := getRunningTaskById(taskId)
 	if data == nil {
 		return
@@ -141,6 +149,7 @@ func updateTaskProgress(done chan struct{}, taskId uint64, progress chan plugin.
 		if hasMore {
 			runningTasks.mu.Lock()
 			runner.UpdateProgressDetail(basicRes, taskId, progressDetail, &p)
+			publisher.progress(&p, progressDetail)
 			runningTasks.mu.Unlock()
 		} else {
 			done <- struct{}{}
@@ -0,0 +1,106 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type rerunSubtaskPlugin struct{}
+
+func (rerunSubtaskPlugin) Description() string { return "" }
+func (rerunSubtaskPlugin) RootPkgPath() string { return "" }
+func (rerunSubtaskPlugin) Name() string        { return "rerunsubtask" }
+func (rerunSubtaskPlugin) SubTaskMetas() []plugin.SubTaskMeta {
+	return []plugin.SubTaskMeta{{Name: "collectBugs"}, {Name: "convertBugs"}}
+}
+func (rerunSubtaskPlugin) PrepareTaskData(plugin.TaskContext, map[string]interface{}) (interface{}, errors.Error) {
+	return nil, nil
+}
+
+func mockRerunSubtaskTask(t *testing.T) *models.Task {
+	task := &models.Task{
+		Plugin:     "rerunsubtask",
+		Subtasks:   []string{"collectBugs", "convertBugs"},
+		PipelineId: 1,
+		Status:     models.TASK_FAILED,
+	}
+	mockDal := new(mockdal.Dal)
+	mockDal.On("First", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(0).(*models.Task) = *task
+	}).Return(nil).Once()
+	originalDb := db
+	t.Cleanup(func() { db = originalDb })
+	db = mockDal
+	return task
+}
+
+func TestRerunSubtask(t *testing.T) {
+	assert.Nil(t, plugin.RegisterPlugin("rerunsubtask", rerunSubtaskPlugin{}))
+	mockRerunSubtaskTask(t)
+	mockTx := new(mockdal.Transaction)
+	mockTx.On("LockTables", mock.Anything).Return(nil).Once()
+	mockTx.On("First", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(0).(*models.Pipeline).Status = models.TASK_FAILED
+	}).Return(nil).Once()
+	mockTx.On("UpdateColumn", mock.Anything, "status", mock.Anything, mock.Anything).Return(nil).Twice()
+	var created *models.Task
+	mockTx.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		created = args.Get(0).(*models.Task)
+	}).Return(nil).Once()
+	mockTx.On("UnlockTables").Return(nil).Once()
+	mockTx.On("Commit").Return(nil).Once()
+	defer func(r context.BasicRes) { basicRes = r }(basicRes)
+	basicRes = unithelper.DummyBasicRes(func(mockDal *mockdal.Dal) {
+		mockDal.On("Begin").Return(mockTx).Once()
+	})
+
+	rerunTask, err := RerunSubtask(1, 10, "convertBugs")
+	assert.Nil(t, err)
+	assert.Equal(t, created, rerunTask)
+	// only the named subtask is rerun, the raw data collected by collectBugs is kept
+	assert.Equal(t, []string{"convertBugs"}, created.Subtasks)
+	assert.Equal(t, models.TASK_RERUN, created.Status)
+	mockTx.AssertCalled(t, "UpdateColumn", &models.Pipeline{}, "status", models.TASK_RERUN, []dal.Clause{dal.Where("id = ?", uint64(1))})
+	mockTx.AssertExpectations(t)
+}
+
+func TestRerunSubtaskRejectsBadInput(t *testing.T) {
+	_ = plugin.RegisterPlugin("rerunsubtask", rerunSubtaskPlugin{})
+	mockRerunSubtaskTask(t)
+	_, err := RerunSubtask(2, 10, "convertBugs")
+	if assert.NotNil(t, err) {
+		assert.Equal(t, errors.BadInput, err.GetType())
+	}
+
+	mockRerunSubtaskTask(t)
+	_, err = RerunSubtask(1, 10, "convertIssues")
+	if assert.NotNil(t, err) {
+		assert.Equal(t, errors.NotFound, err.GetType())
+	}
+}
@@ -0,0 +1,291 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/log"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/runner"
+	"github.com/apache/incubator-devlake/helpers/dbhelper"
+	"github.com/apache/incubator-devlake/impls/logruslog"
+	"github.com/google/uuid"
+	"golang.org/x/sync/semaphore"
+)
+
+var workerLog = logruslog.Global.Nested("worker")
+
+// distributedMode is enabled by WORKER_MODE=distributed, the instances sharing the database share the
+// _devlake_tasks queue: the tasks of a stage are queued, claimed by any worker under a lease renewed by
+// heartbeats, and handed to another worker once the lease expired
+var distributedMode bool
+var workerId string
+var leaseDuration time.Duration
+
+func workerInit() {
+	distributedMode = cfg.GetString("WORKER_MODE") == "distributed"
+	workerId = cfg.GetString("WORKER_ID")
+	if workerId == "" {
+		hostname, _ := os.Hostname()
+		workerId = fmt.Sprintf("%s-%s", hostname, uuid.New().String()[:8])
+	}
+	leaseDuration = time.Duration(cfg.GetInt("WORKER_LEASE_SECONDS")) * time.Second
+	if leaseDuration <= 0 {
+		leaseDuration = time.Minute
+	}
+	if !distributedMode {
+		return
+	}
+	workerMaxParallel := cfg.GetInt64("WORKER_MAX_PARALLEL")
+	if workerMaxParallel <= 0 {
+		workerMaxParallel = 4
+	}
+	workerLog.Info("running as worker %s in the distributed mode", workerId)
+	go reapExpiredLeases()
+	if cfg.GetBool("CONSUME_TASKS") {
+		go RunTasksInQueue(workerMaxParallel)
+	}
+}
+
+// RunTasksDistributed queues the tasks for the workers and waits for all of them to finish
+func RunTasksDistributed(parentLogger log.Logger, taskIds []uint64) errors.Error {
+	if len(taskIds) == 0 {
+		return nil
+	}
+	err := db.UpdateColumns(&models.Task{}, []dal.DalSet{
+		{ColumnName: "status", Value: models.TASK_QUEUED},
+		{ColumnName: "worker_id", Value: ""},
+	}, dal.Where("id IN ? AND status IN ?", taskIds, []string{models.TASK_CREATED, models.TASK_RERUN, models.TASK_RESUME}))
+	if err != nil {
+		return err
+	}
+	for {
+		// the tasks requested to pause before any worker claimed them are paused right away
+		err = db.UpdateColumns(&models.Task{}, []dal.DalSet{
+			{ColumnName: "status", Value: models.TASK_PAUSED},
+		}, dal.Where("id IN ? AND status = ? AND worker_id = ''", taskIds, models.TASK_PAUSING))
+		if err != nil {
+			return err
+		}
+		var tasks []*models.Task
+		err = db.All(&tasks, dal.Where("id IN ?", taskIds))
+		if err != nil {
+			return err
+		}
+		if done, stageErr := stageResult(tasks); done {
+			if stageErr != nil {
+				parentLogger.Error(stageErr, "stage failed")
+			}
+			return stageErr
+		}
+		time.Sleep(time.Second)
+	}
+}
+
+// stageResult tells whether all tasks of the stage finished, and how the stage ended the same way RunTasksStandalone does
+func stageResult(tasks []*models.Task) (bool, errors.Error) {
+	failed, cancelled, paused := 0, 0, 0
+	var messages []string
+	for _, task := range tasks {
+		switch task.Status {
+		case models.TASK_COMPLETED:
+		case models.TASK_FAILED, models.TASK_PARTIAL:
+			failed++
+			messages = append(messages, fmt.Sprintf("Error running task %d. %s", task.ID, task.Message))
+		case models.TASK_CANCELLED:
+			cancelled++
+		case models.TASK_PAUSED:
+			paused++
+		default:
+			return false, nil
+		}
+	}
+	if cancelled > 0 {
+		return true, errors.Convert(context.Canceled)
+	}
+	if failed > 0 {
+		return true, errors.Default.New(strings.Join(messages, "\n"))
+	}
+	if paused > 0 {
+		return true, runner.ErrTaskPaused
+	}
+	return true, nil
+}
+
+// RunTasksInQueue claims the queued tasks and runs them, at most workerMaxParallel at a time
+func RunTasksInQueue(workerMaxParallel int64) {
+	sema := semaphore.NewWeighted(workerMaxParallel)
+	for {
+		errors.Must(sema.Acquire(context.TODO(), 1))
+		var task *models.Task
+		for {
+			var err errors.Error
+			task, err = claimTask()
+			if err != nil {
+				workerLog.Error(err, "claim task failed")
+			}
+			if task != nil {
+				break
+			}
+			time.Sleep(time.Second)
+		}
+		go func(task *models.Task) {
+			defer sema.Release(1)
+			workerLog.Info("worker %s claimed task #%d of pipeline #%d", workerId, task.ID, task.PipelineId)
+			err := runClaimedTask(task)
+			if err != nil {
+				workerLog.Error(err, "failed to run task %d", task.ID)
+			}
+		}(task)
+	}
+}
+
+// claimTask leases the earliest queued task to this worker, nil if there is none
+func claimTask() (task *models.Task, err errors.Error) {
+	txHelper := dbhelper.NewTxHelper(basicRes, &err)
+	defer txHelper.End()
+	tx := txHelper.Begin()
+	errors.Must(tx.LockTables(dal.LockTables{{Table: "_devlake_tasks", Exclusive: true}}))
+	task = &models.Task{}
+	err = tx.First(task,
+		dal.Where("status = ? AND (start_after IS NULL OR start_after <= ?)", models.TASK_QUEUED, time.Now()),
+		dal.Orderby("id ASC"),
+	)
+	if tx.IsErrorNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	leaseExpiresAt := time.Now().Add(leaseDuration)
+	err = tx.UpdateColumns(&models.Task{}, []dal.DalSet{
+		{ColumnName: "status", Value: models.TASK_RUNNING},
+		{ColumnName: "worker_id", Value: workerId},
+		{ColumnName: "lease_expires_at", Value: leaseExpiresAt},
+	}, dal.Where("id = ?", task.ID))
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// runClaimedTask runs the task while renewing its lease, the task is cancelled once cancelled
+// through another instance or once the lease was lost to another worker
+func runClaimedTask(task *models.Task) errors.Error {
+	dbPipeline, err := GetDbPipeline(task.PipelineId)
+	if err != nil {
+		return err
+	}
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(leaseDuration / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if !renewTaskLease(task.ID) {
+					workerLog.Warn(nil, "worker %s lost task #%d, cancelling it", workerId, task.ID)
+					if cancel, e := runningTasks.Remove(task.ID); e == nil {
+						cancel()
+					}
+					return
+				}
+			}
+		}
+	}()
+	return runTaskStandalone(GetPipelineLogger(dbPipeline), task.ID)
+}
+
+// renewTaskLease extends the lease of the running task, false if the task is not held by this worker anymore
+func renewTaskLease(taskId uint64) bool {
+	task := &models.Task{}
+	if err := db.First(task, dal.Where("id = ?", taskId)); err != nil {
+		workerLog.Error(err, "failed to load task %d", taskId)
+		// keep running, the database may come back before the lease expires
+		return true
+	}
+	if task.WorkerId != workerId || task.Status == models.TASK_CANCELLED {
+		return false
+	}
+	err := db.UpdateColumns(&models.Task{}, []dal.DalSet{
+		{ColumnName: "lease_expires_at", Value: time.Now().Add(leaseDuration)},
+	}, dal.Where("id = ? AND worker_id = ?", taskId, workerId))
+	if err != nil {
+		workerLog.Error(err, "failed to renew the lease of task %d", taskId)
+	}
+	return true
+}
+
+// renewPipelineLease keeps the pipeline held by this worker until done is closed
+func renewPipelineLease(pipelineId uint64, done chan struct{}) {
+	ticker := time.NewTicker(leaseDuration / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			err := db.UpdateColumns(&models.Pipeline{}, []dal.DalSet{
+				{ColumnName: "lease_expires_at", Value: time.Now().Add(leaseDuration)},
+			}, dal.Where("id = ? AND worker_id = ?", pipelineId, workerId))
+			if err != nil {
+				workerLog.Error(err, "failed to renew the lease of pipeline %d", pipelineId)
+			}
+		}
+	}
+}
+
+// reapExpiredLeases hands the tasks and pipelines of dead workers over to the others: the tasks are
+// queued again and the pipelines resumed, so the subtasks finished before are skipped
+func reapExpiredLeases() {
+	for {
+		time.Sleep(leaseDuration / 2)
+		now := time.Now()
+		err := db.UpdateColumns(&models.Task{}, []dal.DalSet{
+			{ColumnName: "status", Value: models.TASK_QUEUED},
+			{ColumnName: "worker_id", Value: ""},
+		}, dal.Where("status = ? AND lease_expires_at < ?", models.TASK_RUNNING, now))
+		if err != nil {
+			workerLog.Error(err, "failed to requeue the tasks of dead workers")
+		}
+		err = db.UpdateColumns(&models.Task{}, []dal.DalSet{
+			{ColumnName: "status", Value: models.TASK_PAUSED},
+			{ColumnName: "worker_id", Value: ""},
+		}, dal.Where("status = ? AND worker_id != '' AND lease_expires_at < ?", models.TASK_PAUSING, now))
+		if err != nil {
+			workerLog.Error(err, "failed to pause the tasks of dead workers")
+		}
+		err = db.UpdateColumns(&models.Pipeline{}, []dal.DalSet{
+			{ColumnName: "status", Value: models.TASK_RESUME},
+			{ColumnName: "worker_id", Value: ""},
+		}, dal.Where("status = ? AND lease_expires_at < ?", models.TASK_RUNNING, now))
+		if err != nil {
+			workerLog.Error(err, "failed to resume the pipelines of dead workers")
+		}
+	}
+}
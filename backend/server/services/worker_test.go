@@ -0,0 +1,191 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	coreContext "github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/runner"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestStageResult(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []string
+		wantDone bool
+		wantErr  error
+	}{
+		{"queued task", []string{models.TASK_COMPLETED, models.TASK_QUEUED}, false, nil},
+		{"running task", []string{models.TASK_COMPLETED, models.TASK_RUNNING}, false, nil},
+		{"completed", []string{models.TASK_COMPLETED, models.TASK_COMPLETED}, true, nil},
+		{"cancelled over failed", []string{models.TASK_CANCELLED, models.TASK_FAILED}, true, context.Canceled},
+		{"paused", []string{models.TASK_COMPLETED, models.TASK_PAUSED}, true, runner.ErrTaskPaused},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks := make([]*models.Task, len(tt.statuses))
+			for i, status := range tt.statuses {
+				tasks[i] = &models.Task{Status: status}
+			}
+			done, err := stageResult(tasks)
+			assert.Equal(t, tt.wantDone, done)
+			if tt.wantErr == nil {
+				assert.Nil(t, err)
+			} else {
+				assert.True(t, errors.Is(err, tt.wantErr))
+			}
+		})
+	}
+
+	// the messages of the failed tasks are reported
+	done, err := stageResult([]*models.Task{
+		{Model: common.Model{ID: 1}, Status: models.TASK_COMPLETED},
+		{Model: common.Model{ID: 2}, Status: models.TASK_FAILED, Message: "boom"},
+	})
+	assert.True(t, done)
+	assert.Equal(t, "Error running task 2. boom", err.Error())
+}
+
+func mockWorker(t *testing.T, id string) {
+	originalWorkerId, originalLeaseDuration := workerId, leaseDuration
+	t.Cleanup(func() { workerId, leaseDuration = originalWorkerId, originalLeaseDuration })
+	workerId, leaseDuration = id, time.Minute
+}
+
+func TestClaimTask(t *testing.T) {
+	mockWorker(t, "worker1")
+	defer func(r coreContext.BasicRes) { basicRes = r }(basicRes)
+
+	mockTx := new(mockdal.Transaction)
+	mockTx.On("LockTables", dal.LockTables{{Table: "_devlake_tasks", Exclusive: true}}).Return(nil).Once()
+	mockTx.On("First", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(0).(*models.Task) = models.Task{Model: common.Model{ID: 7}, Status: models.TASK_QUEUED}
+	}).Return(nil).Once()
+	mockTx.On("IsErrorNotFound", nil).Return(false)
+	var sets []dal.DalSet
+	mockTx.On("UpdateColumns", &models.Task{}, mock.Anything, []dal.Clause{dal.Where("id = ?", uint64(7))}).Run(func(args mock.Arguments) {
+		sets = args.Get(1).([]dal.DalSet)
+	}).Return(nil).Once()
+	mockTx.On("UnlockTables").Return(nil).Once()
+	mockTx.On("Commit").Return(nil).Once()
+	basicRes = unithelper.DummyBasicRes(func(mockDal *mockdal.Dal) {
+		mockDal.On("Begin").Return(mockTx).Once()
+	})
+
+	task, err := claimTask()
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(7), task.ID)
+	// the task is leased to this worker
+	if assert.Len(t, sets, 3) {
+		assert.Equal(t, dal.DalSet{ColumnName: "status", Value: models.TASK_RUNNING}, sets[0])
+		assert.Equal(t, dal.DalSet{ColumnName: "worker_id", Value: "worker1"}, sets[1])
+		assert.WithinDuration(t, time.Now().Add(time.Minute), sets[2].Value.(time.Time), 5*time.Second)
+	}
+	mockTx.AssertExpectations(t)
+}
+
+func TestClaimTaskWithEmptyQueue(t *testing.T) {
+	mockWorker(t, "worker1")
+	defer func(r coreContext.BasicRes) { basicRes = r }(basicRes)
+
+	mockTx := new(mockdal.Transaction)
+	mockTx.On("LockTables", mock.Anything).Return(nil).Once()
+	mockTx.On("First", mock.Anything, mock.Anything).Return(errors.NotFound.New("empty")).Once()
+	mockTx.On("IsErrorNotFound", mock.Anything).Return(true)
+	mockTx.On("UnlockTables").Return(nil).Once()
+	mockTx.On("Commit").Return(nil).Once()
+	basicRes = unithelper.DummyBasicRes(func(mockDal *mockdal.Dal) {
+		mockDal.On("Begin").Return(mockTx).Once()
+	})
+
+	task, err := claimTask()
+	assert.Nil(t, err)
+	assert.Nil(t, task)
+	mockTx.AssertNotCalled(t, "UpdateColumns", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRenewTaskLease(t *testing.T) {
+	mockWorker(t, "worker1")
+	defer func(d dal.Dal) { db = d }(db)
+	tests := []struct {
+		name      string
+		task      models.Task
+		wantHeld  bool
+		wantRenew bool
+	}{
+		{"held", models.Task{WorkerId: "worker1", Status: models.TASK_RUNNING}, true, true},
+		{"handed to another worker", models.Task{WorkerId: "worker2", Status: models.TASK_RUNNING}, false, false},
+		{"cancelled", models.Task{WorkerId: "worker1", Status: models.TASK_CANCELLED}, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDal := new(mockdal.Dal)
+			task := tt.task
+			mockDal.On("First", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				*args.Get(0).(*models.Task) = task
+			}).Return(nil).Once()
+			mockDal.On("UpdateColumns", &models.Task{}, mock.Anything, []dal.Clause{dal.Where("id = ? AND worker_id = ?", uint64(7), "worker1")}).Return(nil).Once()
+			db = mockDal
+
+			assert.Equal(t, tt.wantHeld, renewTaskLease(7))
+			if tt.wantRenew {
+				mockDal.AssertNumberOfCalls(t, "UpdateColumns", 1)
+			} else {
+				mockDal.AssertNotCalled(t, "UpdateColumns", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestRunTasksDistributed(t *testing.T) {
+	defer func(d dal.Dal) { db = d }(db)
+	mockDal := new(mockdal.Dal)
+	taskIds := []uint64{1, 2}
+	// the tasks are queued for the workers, then the ones requested to pause are paused
+	mockDal.On("UpdateColumns", &models.Task{}, []dal.DalSet{
+		{ColumnName: "status", Value: models.TASK_QUEUED},
+		{ColumnName: "worker_id", Value: ""},
+	}, mock.Anything).Return(nil).Once()
+	mockDal.On("UpdateColumns", &models.Task{}, []dal.DalSet{
+		{ColumnName: "status", Value: models.TASK_PAUSED},
+	}, mock.Anything).Return(nil).Once()
+	mockDal.On("All", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(0).(*[]*models.Task) = []*models.Task{
+			{Model: common.Model{ID: 1}, Status: models.TASK_COMPLETED},
+			{Model: common.Model{ID: 2}, Status: models.TASK_FAILED, Message: "boom"},
+		}
+	}).Return(nil).Once()
+	db = mockDal
+
+	err := RunTasksDistributed(unithelper.DummyLogger(), taskIds)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "boom")
+	}
+	mockDal.AssertExpectations(t)
+}
@@ -24,6 +24,8 @@ import (
 	ae "github.com/apache/incubator-devlake/plugins/ae/impl"
 	bamboo "github.com/apache/incubator-devlake/plugins/bamboo/impl"
 	bitbucket "github.com/apache/incubator-devlake/plugins/bitbucket/impl"
+	clickhouse "github.com/apache/incubator-devlake/plugins/clickhouse/impl"
+	confluence "github.com/apache/incubator-devlake/plugins/confluence/impl"
 	customize "github.com/apache/incubator-devlake/plugins/customize/impl"
 	dbt "github.com/apache/incubator-devlake/plugins/dbt/impl"
 	dora "github.com/apache/incubator-devlake/plugins/dora/impl"
@@ -63,6 +65,8 @@ func loadGoPlugins() []plugin.PluginMeta {
 		ae.AE{},
 		bamboo.Bamboo{},
 		bitbucket.Bitbucket{},
+		clickhouse.ClickHouse{},
+		confluence.Confluence{},
 		customize.Customize{},
 		dbt.Dbt{},
 		dora.Dora{},
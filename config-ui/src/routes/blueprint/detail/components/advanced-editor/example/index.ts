@@ -16,6 +16,7 @@
  *
  */
 
+import clickhouse from './clickhouse';
 import customize from './customize';
 import dbt from './dbt';
 import feishu from './feishu';
@@ -91,6 +92,11 @@ export const EXAMPLE_CONFIG = [
     name: 'Load StarRocks Configuration',
     config: starrocks,
   },
+  {
+    id: 'clickhouse',
+    name: 'Load ClickHouse Configuration',
+    config: clickhouse,
+  },
   {
     id: 'customize',
     name: 'Load Customize Configuration',
@@ -23,7 +23,8 @@ const starrocks = [
       options: {
         source_type: '', // mysql or postgres
         source_dsn: '', // gorm dsn
-        update_column: '', // update column
+        update_column: '', // update column, updated_at by default when incremental
+        incremental: false, // only load the rows updated since the last sync, new and removed columns are applied
         host: '127.0.0.1',
         port: 9030,
         user: 'root',
@@ -32,6 +33,8 @@ const starrocks = [
         be_host: '',
         be_port: 8040,
         tables: ['_tool_.*'], // support regexp
+        excluded_tables: [], // support regexp
+        table_configs: {}, // i.e. { issues: { included_columns: [], excluded_columns: [], where: '' } }
         batch_size: 10000,
         order_by: {},
         extra: {}, // will append to create table sql